@@ -94,13 +94,16 @@ var xxx_messageInfo_UserAddOptions proto.InternalMessageInfo
 
 // User is a single entry in the bucket authUsers
 type User struct {
-	Name                 []byte          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Password             []byte          `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	Roles                []string        `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
-	Options              *UserAddOptions `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Name     []byte          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Password []byte          `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Roles    []string        `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	Options  *UserAddOptions `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+	// password_changed_time is the unix time in seconds of the last password
+	// change. It is 0 if the user was created by a version not recording it.
+	PasswordChangedTime  int64    `protobuf:"varint,5,opt,name=password_changed_time,json=passwordChangedTime,proto3" json:"password_changed_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *User) Reset()         { *m = User{} }
@@ -181,11 +184,14 @@ var xxx_messageInfo_Permission proto.InternalMessageInfo
 
 // Role is a single entry in the bucket authRoles
 type Role struct {
-	Name                 []byte        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	KeyPermission        []*Permission `protobuf:"bytes,2,rep,name=keyPermission,proto3" json:"keyPermission,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Name          []byte        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	KeyPermission []*Permission `protobuf:"bytes,2,rep,name=keyPermission,proto3" json:"keyPermission,omitempty"`
+	// rpc_permissions lists the administrative RPC methods, such as
+	// "Maintenance.Defragment", that members of the role may call.
+	RpcPermissions       []string `protobuf:"bytes,3,rep,name=rpc_permissions,json=rpcPermissions,proto3" json:"rpc_permissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Role) Reset()         { *m = Role{} }
@@ -232,30 +238,33 @@ func init() {
 func init() { proto.RegisterFile("auth.proto", fileDescriptor_8bbd6f3875b0e874) }
 
 var fileDescriptor_8bbd6f3875b0e874 = []byte{
-	// 359 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcf, 0x4e, 0xc2, 0x40,
-	0x10, 0xc6, 0xbb, 0xb4, 0x60, 0x3b, 0x08, 0x21, 0x1b, 0xa2, 0x0d, 0xc6, 0xda, 0xf4, 0xd4, 0x78,
-	0x68, 0x15, 0x0e, 0x7a, 0xc5, 0xc8, 0xc1, 0x93, 0x64, 0x83, 0x31, 0xf1, 0x42, 0x8a, 0xdd, 0xd4,
-	0x06, 0xd8, 0x6d, 0xda, 0xaa, 0xe1, 0xe2, 0x73, 0x78, 0xf0, 0x81, 0x38, 0xf2, 0x08, 0x82, 0x2f,
-	0x62, 0xba, 0xcb, 0x9f, 0x10, 0x3d, 0xed, 0x37, 0xdf, 0x7c, 0x33, 0xfb, 0xcb, 0x2e, 0x40, 0xf0,
-	0x9a, 0xbf, 0x78, 0x49, 0xca, 0x73, 0x8e, 0x2b, 0x85, 0x4e, 0x46, 0xad, 0x66, 0xc4, 0x23, 0x2e,
-	0x2c, 0xbf, 0x50, 0xb2, 0xeb, 0x5c, 0x42, 0xfd, 0x21, 0xa3, 0x69, 0x37, 0x0c, 0xef, 0x93, 0x3c,
-	0xe6, 0x2c, 0xc3, 0x67, 0x50, 0x65, 0x7c, 0x98, 0x04, 0x59, 0xf6, 0xce, 0xd3, 0xd0, 0x44, 0x36,
-	0x72, 0x75, 0x02, 0x8c, 0xf7, 0xd7, 0x8e, 0xf3, 0x01, 0x5a, 0x31, 0x82, 0x31, 0x68, 0x2c, 0x98,
-	0x52, 0x91, 0x38, 0x24, 0x42, 0xe3, 0x16, 0xe8, 0xdb, 0xc9, 0x92, 0xf0, 0xb7, 0x35, 0x6e, 0x42,
-	0x39, 0xe5, 0x13, 0x9a, 0x99, 0xaa, 0xad, 0xba, 0x06, 0x91, 0x05, 0xbe, 0x80, 0x03, 0x2e, 0x6f,
-	0x36, 0x35, 0x1b, 0xb9, 0xd5, 0xf6, 0x91, 0x27, 0x81, 0xbd, 0x7d, 0x2e, 0xb2, 0x89, 0x39, 0x5f,
-	0x08, 0xa0, 0x4f, 0xd3, 0x69, 0x9c, 0x65, 0x31, 0x67, 0xb8, 0x03, 0x7a, 0x42, 0xd3, 0xe9, 0x60,
-	0x96, 0x48, 0x94, 0x7a, 0xfb, 0x78, 0xb3, 0x61, 0x97, 0xf2, 0x8a, 0x36, 0xd9, 0x06, 0x71, 0x03,
-	0xd4, 0x31, 0x9d, 0xad, 0x11, 0x0b, 0x89, 0x4f, 0xc0, 0x48, 0x03, 0x16, 0xd1, 0x21, 0x65, 0xa1,
-	0xa9, 0x4a, 0x74, 0x61, 0xf4, 0x58, 0xe8, 0x9c, 0x83, 0x26, 0xc6, 0x74, 0xd0, 0x48, 0xaf, 0x7b,
-	0xdb, 0x50, 0xb0, 0x01, 0xe5, 0x47, 0x72, 0x37, 0xe8, 0x35, 0x10, 0xae, 0x81, 0x51, 0x98, 0xb2,
-	0x2c, 0x39, 0x03, 0xd0, 0x08, 0x9f, 0xd0, 0x7f, 0x9f, 0xe7, 0x1a, 0x6a, 0x63, 0x3a, 0xdb, 0x61,
-	0x99, 0x25, 0x5b, 0x75, 0xab, 0x6d, 0xfc, 0x17, 0x98, 0xec, 0x07, 0x6f, 0xae, 0xe6, 0x4b, 0x4b,
-	0x59, 0x2c, 0x2d, 0x65, 0xbe, 0xb2, 0xd0, 0x62, 0x65, 0xa1, 0xef, 0x95, 0x85, 0x3e, 0x7f, 0x2c,
-	0xe5, 0xe9, 0x34, 0xe2, 0x1e, 0xcd, 0x9f, 0x43, 0x2f, 0xe6, 0x7e, 0x71, 0xfa, 0x41, 0x12, 0xfb,
-	0x6f, 0x1d, 0x5f, 0xae, 0x1c, 0x55, 0xc4, 0x3f, 0x77, 0x7e, 0x03, 0x00, 0x00, 0xff, 0xff, 0x61,
-	0x5a, 0xfe, 0x48, 0x13, 0x02, 0x00, 0x00,
+	// 410 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0xc1, 0x6e, 0xd4, 0x30,
+	0x14, 0x8c, 0x37, 0xd9, 0x92, 0xbc, 0xa5, 0xcb, 0xca, 0x14, 0x88, 0x8a, 0x08, 0x51, 0x2e, 0x44,
+	0x1c, 0x12, 0xc8, 0x1e, 0xe0, 0x5a, 0x60, 0x0f, 0x9c, 0xa8, 0xac, 0x45, 0x48, 0x5c, 0xa2, 0x34,
+	0xb1, 0xd2, 0xa8, 0x8d, 0x6d, 0xd9, 0x01, 0xb4, 0x07, 0xfe, 0x83, 0x03, 0x5f, 0xc1, 0x57, 0xf4,
+	0xd8, 0x4f, 0xa0, 0xcb, 0x8f, 0x20, 0xc7, 0x4d, 0x56, 0x2b, 0x38, 0x65, 0xde, 0xcc, 0xbc, 0xcc,
+	0xd8, 0x32, 0x40, 0xf1, 0xa5, 0x3b, 0x4f, 0x84, 0xe4, 0x1d, 0xc7, 0x07, 0x1a, 0x8b, 0xb3, 0xe3,
+	0xa3, 0x9a, 0xd7, 0xbc, 0xa7, 0x52, 0x8d, 0x8c, 0x1a, 0xbd, 0x84, 0xf9, 0x47, 0x45, 0xe5, 0x49,
+	0x55, 0x7d, 0x10, 0x5d, 0xc3, 0x99, 0xc2, 0x4f, 0x61, 0xc6, 0x78, 0x2e, 0x0a, 0xa5, 0xbe, 0x71,
+	0x59, 0xf9, 0x28, 0x44, 0xb1, 0x4b, 0x80, 0xf1, 0xd3, 0x5b, 0x26, 0xfa, 0x85, 0xc0, 0xd1, 0x3b,
+	0x18, 0x83, 0xc3, 0x8a, 0x96, 0xf6, 0x96, 0xbb, 0xa4, 0xc7, 0xf8, 0x18, 0xdc, 0x71, 0x75, 0xd2,
+	0xf3, 0xe3, 0x8c, 0x8f, 0x60, 0x2a, 0xf9, 0x25, 0x55, 0xbe, 0x1d, 0xda, 0xb1, 0x47, 0xcc, 0x80,
+	0x5f, 0xc0, 0x1d, 0x6e, 0xa2, 0x7d, 0x27, 0x44, 0xf1, 0x2c, 0x7b, 0x98, 0x98, 0xc6, 0xc9, 0x7e,
+	0x31, 0x32, 0xd8, 0x70, 0x06, 0x0f, 0x86, 0x7f, 0xe6, 0xe5, 0x79, 0xc1, 0x6a, 0x5a, 0xe5, 0x5d,
+	0xd3, 0x52, 0x7f, 0x1a, 0xa2, 0xd8, 0x26, 0xf7, 0x07, 0xf1, 0xad, 0xd1, 0xd6, 0x4d, 0x4b, 0xa3,
+	0x9f, 0x08, 0xe0, 0x94, 0xca, 0xb6, 0x51, 0xaa, 0xe1, 0x0c, 0x2f, 0xc1, 0x15, 0x54, 0xb6, 0xeb,
+	0x8d, 0x30, 0xf5, 0xe7, 0xd9, 0xa3, 0x21, 0x75, 0xe7, 0x4a, 0xb4, 0x4c, 0x46, 0x23, 0x5e, 0x80,
+	0x7d, 0x41, 0x37, 0xb7, 0xc7, 0xd2, 0x10, 0x3f, 0x06, 0x4f, 0xea, 0x8c, 0x9c, 0xb2, 0xca, 0xb7,
+	0xcd, 0x71, 0x7b, 0x62, 0xc5, 0xaa, 0xe8, 0x39, 0x38, 0xfd, 0x9a, 0x0b, 0x0e, 0x59, 0x9d, 0xbc,
+	0x5b, 0x58, 0xd8, 0x83, 0xe9, 0x27, 0xf2, 0x7e, 0xbd, 0x5a, 0x20, 0x7c, 0x08, 0x9e, 0x26, 0xcd,
+	0x38, 0x89, 0xbe, 0x83, 0x43, 0xf8, 0x25, 0xfd, 0xef, 0x95, 0xbe, 0x86, 0xc3, 0x0b, 0xba, 0xd9,
+	0xd5, 0xf2, 0x27, 0xa1, 0x1d, 0xcf, 0x32, 0xfc, 0x6f, 0x61, 0xb2, 0x6f, 0xc4, 0xcf, 0xe0, 0x9e,
+	0x14, 0x65, 0x2e, 0x46, 0x66, 0xb8, 0xfa, 0xb9, 0x14, 0xe5, 0xce, 0xa7, 0xde, 0xbc, 0xba, 0xba,
+	0x09, 0xac, 0xeb, 0x9b, 0xc0, 0xba, 0xda, 0x06, 0xe8, 0x7a, 0x1b, 0xa0, 0xdf, 0xdb, 0x00, 0xfd,
+	0xf8, 0x13, 0x58, 0x9f, 0x9f, 0xd4, 0x3c, 0xa1, 0x5d, 0x59, 0x25, 0x0d, 0x4f, 0xf5, 0x37, 0x2d,
+	0x44, 0x93, 0x7e, 0x5d, 0xa6, 0x26, 0xfb, 0xec, 0xa0, 0x7f, 0x45, 0xcb, 0xbf, 0x01, 0x00, 0x00,
+	0xff, 0xff, 0x4c, 0xfc, 0x07, 0xe4, 0x71, 0x02, 0x00, 0x00,
 }
 
 func (m *UserAddOptions) Marshal() (dAtA []byte, err error) {
@@ -319,6 +328,11 @@ func (m *User) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.PasswordChangedTime != 0 {
+		i = encodeVarintAuth(dAtA, i, uint64(m.PasswordChangedTime))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.Options != nil {
 		{
 			size, err := m.Options.MarshalToSizedBuffer(dAtA[:i])
@@ -427,6 +441,15 @@ func (m *Role) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.RpcPermissions) > 0 {
+		for iNdEx := len(m.RpcPermissions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RpcPermissions[iNdEx])
+			copy(dAtA[i:], m.RpcPermissions[iNdEx])
+			i = encodeVarintAuth(dAtA, i, uint64(len(m.RpcPermissions[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.KeyPermission) > 0 {
 		for iNdEx := len(m.KeyPermission) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -501,6 +524,9 @@ func (m *User) Size() (n int) {
 		l = m.Options.Size()
 		n += 1 + l + sovAuth(uint64(l))
 	}
+	if m.PasswordChangedTime != 0 {
+		n += 1 + sovAuth(uint64(m.PasswordChangedTime))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -546,6 +572,12 @@ func (m *Role) Size() (n int) {
 			n += 1 + l + sovAuth(uint64(l))
 		}
 	}
+	if len(m.RpcPermissions) > 0 {
+		for _, s := range m.RpcPermissions {
+			l = len(s)
+			n += 1 + l + sovAuth(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -794,6 +826,25 @@ func (m *User) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PasswordChangedTime", wireType)
+			}
+			m.PasswordChangedTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PasswordChangedTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -1051,6 +1102,38 @@ func (m *Role) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RpcPermissions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RpcPermissions = append(m.RpcPermissions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
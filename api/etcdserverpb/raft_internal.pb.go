@@ -31,7 +31,13 @@ type RequestHeader struct {
 	// username is a username that is associated with an auth token of gRPC connection
 	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
 	// auth_revision is a revision number of auth.authStore. It is not related to mvcc
-	AuthRevision         uint64   `protobuf:"varint,3,opt,name=auth_revision,json=authRevision,proto3" json:"auth_revision,omitempty"`
+	AuthRevision uint64 `protobuf:"varint,3,opt,name=auth_revision,json=authRevision,proto3" json:"auth_revision,omitempty"`
+	// election_lease is the lease a request presents to prove it comes from a
+	// candidate of an election guarding a protected prefix.
+	ElectionLease int64 `protobuf:"varint,4,opt,name=election_lease,json=electionLease,proto3" json:"election_lease,omitempty"`
+	// fence_token is the token a request presents to write through the fences
+	// of prefixes.
+	FenceToken           string   `protobuf:"bytes,5,opt,name=fence_token,json=fenceToken,proto3" json:"fence_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -73,42 +79,53 @@ var xxx_messageInfo_RequestHeader proto.InternalMessageInfo
 // An InternalRaftRequest is the union of all requests which can be
 // sent via raft.
 type InternalRaftRequest struct {
-	Header                   *RequestHeader                            `protobuf:"bytes,100,opt,name=header,proto3" json:"header,omitempty"`
-	ID                       uint64                                    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	V2                       *Request                                  `protobuf:"bytes,2,opt,name=v2,proto3" json:"v2,omitempty"`
-	Range                    *RangeRequest                             `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
-	Put                      *PutRequest                               `protobuf:"bytes,4,opt,name=put,proto3" json:"put,omitempty"`
-	DeleteRange              *DeleteRangeRequest                       `protobuf:"bytes,5,opt,name=delete_range,json=deleteRange,proto3" json:"delete_range,omitempty"`
-	Txn                      *TxnRequest                               `protobuf:"bytes,6,opt,name=txn,proto3" json:"txn,omitempty"`
-	Compaction               *CompactionRequest                        `protobuf:"bytes,7,opt,name=compaction,proto3" json:"compaction,omitempty"`
-	LeaseGrant               *LeaseGrantRequest                        `protobuf:"bytes,8,opt,name=lease_grant,json=leaseGrant,proto3" json:"lease_grant,omitempty"`
-	LeaseRevoke              *LeaseRevokeRequest                       `protobuf:"bytes,9,opt,name=lease_revoke,json=leaseRevoke,proto3" json:"lease_revoke,omitempty"`
-	Alarm                    *AlarmRequest                             `protobuf:"bytes,10,opt,name=alarm,proto3" json:"alarm,omitempty"`
-	LeaseCheckpoint          *LeaseCheckpointRequest                   `protobuf:"bytes,11,opt,name=lease_checkpoint,json=leaseCheckpoint,proto3" json:"lease_checkpoint,omitempty"`
-	AuthEnable               *AuthEnableRequest                        `protobuf:"bytes,1000,opt,name=auth_enable,json=authEnable,proto3" json:"auth_enable,omitempty"`
-	AuthDisable              *AuthDisableRequest                       `protobuf:"bytes,1011,opt,name=auth_disable,json=authDisable,proto3" json:"auth_disable,omitempty"`
-	AuthStatus               *AuthStatusRequest                        `protobuf:"bytes,1013,opt,name=auth_status,json=authStatus,proto3" json:"auth_status,omitempty"`
-	Authenticate             *InternalAuthenticateRequest              `protobuf:"bytes,1012,opt,name=authenticate,proto3" json:"authenticate,omitempty"`
-	AuthUserAdd              *AuthUserAddRequest                       `protobuf:"bytes,1100,opt,name=auth_user_add,json=authUserAdd,proto3" json:"auth_user_add,omitempty"`
-	AuthUserDelete           *AuthUserDeleteRequest                    `protobuf:"bytes,1101,opt,name=auth_user_delete,json=authUserDelete,proto3" json:"auth_user_delete,omitempty"`
-	AuthUserGet              *AuthUserGetRequest                       `protobuf:"bytes,1102,opt,name=auth_user_get,json=authUserGet,proto3" json:"auth_user_get,omitempty"`
-	AuthUserChangePassword   *AuthUserChangePasswordRequest            `protobuf:"bytes,1103,opt,name=auth_user_change_password,json=authUserChangePassword,proto3" json:"auth_user_change_password,omitempty"`
-	AuthUserGrantRole        *AuthUserGrantRoleRequest                 `protobuf:"bytes,1104,opt,name=auth_user_grant_role,json=authUserGrantRole,proto3" json:"auth_user_grant_role,omitempty"`
-	AuthUserRevokeRole       *AuthUserRevokeRoleRequest                `protobuf:"bytes,1105,opt,name=auth_user_revoke_role,json=authUserRevokeRole,proto3" json:"auth_user_revoke_role,omitempty"`
-	AuthUserList             *AuthUserListRequest                      `protobuf:"bytes,1106,opt,name=auth_user_list,json=authUserList,proto3" json:"auth_user_list,omitempty"`
-	AuthRoleList             *AuthRoleListRequest                      `protobuf:"bytes,1107,opt,name=auth_role_list,json=authRoleList,proto3" json:"auth_role_list,omitempty"`
-	AuthRoleAdd              *AuthRoleAddRequest                       `protobuf:"bytes,1200,opt,name=auth_role_add,json=authRoleAdd,proto3" json:"auth_role_add,omitempty"`
-	AuthRoleDelete           *AuthRoleDeleteRequest                    `protobuf:"bytes,1201,opt,name=auth_role_delete,json=authRoleDelete,proto3" json:"auth_role_delete,omitempty"`
-	AuthRoleGet              *AuthRoleGetRequest                       `protobuf:"bytes,1202,opt,name=auth_role_get,json=authRoleGet,proto3" json:"auth_role_get,omitempty"`
-	AuthRoleGrantPermission  *AuthRoleGrantPermissionRequest           `protobuf:"bytes,1203,opt,name=auth_role_grant_permission,json=authRoleGrantPermission,proto3" json:"auth_role_grant_permission,omitempty"`
-	AuthRoleRevokePermission *AuthRoleRevokePermissionRequest          `protobuf:"bytes,1204,opt,name=auth_role_revoke_permission,json=authRoleRevokePermission,proto3" json:"auth_role_revoke_permission,omitempty"`
-	ClusterVersionSet        *membershippb.ClusterVersionSetRequest    `protobuf:"bytes,1300,opt,name=cluster_version_set,json=clusterVersionSet,proto3" json:"cluster_version_set,omitempty"`
-	ClusterMemberAttrSet     *membershippb.ClusterMemberAttrSetRequest `protobuf:"bytes,1301,opt,name=cluster_member_attr_set,json=clusterMemberAttrSet,proto3" json:"cluster_member_attr_set,omitempty"`
-	DowngradeInfoSet         *membershippb.DowngradeInfoSetRequest     `protobuf:"bytes,1302,opt,name=downgrade_info_set,json=downgradeInfoSet,proto3" json:"downgrade_info_set,omitempty"`
-	DowngradeVersionTest     *DowngradeVersionTestRequest              `protobuf:"bytes,9900,opt,name=downgrade_version_test,json=downgradeVersionTest,proto3" json:"downgrade_version_test,omitempty"`
-	XXX_NoUnkeyedLiteral     struct{}                                  `json:"-"`
-	XXX_unrecognized         []byte                                    `json:"-"`
-	XXX_sizecache            int32                                     `json:"-"`
+	Header               *RequestHeader                      `protobuf:"bytes,100,opt,name=header,proto3" json:"header,omitempty"`
+	ID                   uint64                              `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	V2                   *Request                            `protobuf:"bytes,2,opt,name=v2,proto3" json:"v2,omitempty"`
+	Range                *RangeRequest                       `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
+	Put                  *PutRequest                         `protobuf:"bytes,4,opt,name=put,proto3" json:"put,omitempty"`
+	DeleteRange          *DeleteRangeRequest                 `protobuf:"bytes,5,opt,name=delete_range,json=deleteRange,proto3" json:"delete_range,omitempty"`
+	Txn                  *TxnRequest                         `protobuf:"bytes,6,opt,name=txn,proto3" json:"txn,omitempty"`
+	Compaction           *CompactionRequest                  `protobuf:"bytes,7,opt,name=compaction,proto3" json:"compaction,omitempty"`
+	LeaseGrant           *LeaseGrantRequest                  `protobuf:"bytes,8,opt,name=lease_grant,json=leaseGrant,proto3" json:"lease_grant,omitempty"`
+	LeaseRevoke          *LeaseRevokeRequest                 `protobuf:"bytes,9,opt,name=lease_revoke,json=leaseRevoke,proto3" json:"lease_revoke,omitempty"`
+	Alarm                *AlarmRequest                       `protobuf:"bytes,10,opt,name=alarm,proto3" json:"alarm,omitempty"`
+	LeaseCheckpoint      *LeaseCheckpointRequest             `protobuf:"bytes,11,opt,name=lease_checkpoint,json=leaseCheckpoint,proto3" json:"lease_checkpoint,omitempty"`
+	CompactionHoldGrant  *InternalCompactionHoldGrantRequest `protobuf:"bytes,12,opt,name=compaction_hold_grant,json=compactionHoldGrant,proto3" json:"compaction_hold_grant,omitempty"`
+	CompactionHoldRevoke *CompactionHoldRevokeRequest        `protobuf:"bytes,13,opt,name=compaction_hold_revoke,json=compactionHoldRevoke,proto3" json:"compaction_hold_revoke,omitempty"`
+	ClusterConfigSet     *ClusterConfigSetRequest            `protobuf:"bytes,14,opt,name=cluster_config_set,json=clusterConfigSet,proto3" json:"cluster_config_set,omitempty"`
+	// lease_expire revokes a lease that expired, recording the expiry under the
+	// lease expiry event prefix of the cluster configuration. It is only proposed
+	// while the prefix is set, so members older than 3.7 never receive it.
+	LeaseExpire                 *LeaseRevokeRequest                       `protobuf:"bytes,15,opt,name=lease_expire,json=leaseExpire,proto3" json:"lease_expire,omitempty"`
+	CounterAdd                  *CounterAddRequest                        `protobuf:"bytes,16,opt,name=counter_add,json=counterAdd,proto3" json:"counter_add,omitempty"`
+	FencePrefix                 *InternalFencePrefixRequest               `protobuf:"bytes,17,opt,name=fence_prefix,json=fencePrefix,proto3" json:"fence_prefix,omitempty"`
+	AuthEnable                  *AuthEnableRequest                        `protobuf:"bytes,1000,opt,name=auth_enable,json=authEnable,proto3" json:"auth_enable,omitempty"`
+	AuthDisable                 *AuthDisableRequest                       `protobuf:"bytes,1011,opt,name=auth_disable,json=authDisable,proto3" json:"auth_disable,omitempty"`
+	AuthStatus                  *AuthStatusRequest                        `protobuf:"bytes,1013,opt,name=auth_status,json=authStatus,proto3" json:"auth_status,omitempty"`
+	Authenticate                *InternalAuthenticateRequest              `protobuf:"bytes,1012,opt,name=authenticate,proto3" json:"authenticate,omitempty"`
+	AuthUserAdd                 *AuthUserAddRequest                       `protobuf:"bytes,1100,opt,name=auth_user_add,json=authUserAdd,proto3" json:"auth_user_add,omitempty"`
+	AuthUserDelete              *AuthUserDeleteRequest                    `protobuf:"bytes,1101,opt,name=auth_user_delete,json=authUserDelete,proto3" json:"auth_user_delete,omitempty"`
+	AuthUserGet                 *AuthUserGetRequest                       `protobuf:"bytes,1102,opt,name=auth_user_get,json=authUserGet,proto3" json:"auth_user_get,omitempty"`
+	AuthUserChangePassword      *AuthUserChangePasswordRequest            `protobuf:"bytes,1103,opt,name=auth_user_change_password,json=authUserChangePassword,proto3" json:"auth_user_change_password,omitempty"`
+	AuthUserGrantRole           *AuthUserGrantRoleRequest                 `protobuf:"bytes,1104,opt,name=auth_user_grant_role,json=authUserGrantRole,proto3" json:"auth_user_grant_role,omitempty"`
+	AuthUserRevokeRole          *AuthUserRevokeRoleRequest                `protobuf:"bytes,1105,opt,name=auth_user_revoke_role,json=authUserRevokeRole,proto3" json:"auth_user_revoke_role,omitempty"`
+	AuthUserList                *AuthUserListRequest                      `protobuf:"bytes,1106,opt,name=auth_user_list,json=authUserList,proto3" json:"auth_user_list,omitempty"`
+	AuthRoleList                *AuthRoleListRequest                      `protobuf:"bytes,1107,opt,name=auth_role_list,json=authRoleList,proto3" json:"auth_role_list,omitempty"`
+	AuthRoleAdd                 *AuthRoleAddRequest                       `protobuf:"bytes,1200,opt,name=auth_role_add,json=authRoleAdd,proto3" json:"auth_role_add,omitempty"`
+	AuthRoleDelete              *AuthRoleDeleteRequest                    `protobuf:"bytes,1201,opt,name=auth_role_delete,json=authRoleDelete,proto3" json:"auth_role_delete,omitempty"`
+	AuthRoleGet                 *AuthRoleGetRequest                       `protobuf:"bytes,1202,opt,name=auth_role_get,json=authRoleGet,proto3" json:"auth_role_get,omitempty"`
+	AuthRoleGrantPermission     *AuthRoleGrantPermissionRequest           `protobuf:"bytes,1203,opt,name=auth_role_grant_permission,json=authRoleGrantPermission,proto3" json:"auth_role_grant_permission,omitempty"`
+	AuthRoleRevokePermission    *AuthRoleRevokePermissionRequest          `protobuf:"bytes,1204,opt,name=auth_role_revoke_permission,json=authRoleRevokePermission,proto3" json:"auth_role_revoke_permission,omitempty"`
+	AuthRoleGrantRpcPermission  *AuthRoleGrantRPCPermissionRequest        `protobuf:"bytes,1205,opt,name=auth_role_grant_rpc_permission,json=authRoleGrantRpcPermission,proto3" json:"auth_role_grant_rpc_permission,omitempty"`
+	AuthRoleRevokeRpcPermission *AuthRoleRevokeRPCPermissionRequest       `protobuf:"bytes,1206,opt,name=auth_role_revoke_rpc_permission,json=authRoleRevokeRpcPermission,proto3" json:"auth_role_revoke_rpc_permission,omitempty"`
+	ClusterVersionSet           *membershippb.ClusterVersionSetRequest    `protobuf:"bytes,1300,opt,name=cluster_version_set,json=clusterVersionSet,proto3" json:"cluster_version_set,omitempty"`
+	ClusterMemberAttrSet        *membershippb.ClusterMemberAttrSetRequest `protobuf:"bytes,1301,opt,name=cluster_member_attr_set,json=clusterMemberAttrSet,proto3" json:"cluster_member_attr_set,omitempty"`
+	DowngradeInfoSet            *membershippb.DowngradeInfoSetRequest     `protobuf:"bytes,1302,opt,name=downgrade_info_set,json=downgradeInfoSet,proto3" json:"downgrade_info_set,omitempty"`
+	DowngradeVersionTest        *DowngradeVersionTestRequest              `protobuf:"bytes,9900,opt,name=downgrade_version_test,json=downgradeVersionTest,proto3" json:"downgrade_version_test,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{}                                  `json:"-"`
+	XXX_unrecognized            []byte                                    `json:"-"`
+	XXX_sizecache               int32                                     `json:"-"`
 }
 
 func (m *InternalRaftRequest) Reset()         { *m = InternalRaftRequest{} }
@@ -229,86 +246,212 @@ func (m *InternalAuthenticateRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_InternalAuthenticateRequest proto.InternalMessageInfo
 
+// InternalCompactionHoldGrantRequest is the version of CompactionHoldGrantRequest
+// replicated through raft. Its TTL is already bounded and its grant time is set
+// by the proposing member (etcdserver/v3_server.go), so that all members agree
+// on when the hold expires. It is also the record of the hold in the backend.
+type InternalCompactionHoldGrantRequest struct {
+	ID       int64  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Revision int64  `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	TTL      int64  `protobuf:"varint,4,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	// grant_time is the unix time in nanoseconds at which the hold was granted.
+	GrantTime            int64    `protobuf:"varint,5,opt,name=grant_time,json=grantTime,proto3" json:"grant_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InternalCompactionHoldGrantRequest) Reset()         { *m = InternalCompactionHoldGrantRequest{} }
+func (m *InternalCompactionHoldGrantRequest) String() string { return proto.CompactTextString(m) }
+func (*InternalCompactionHoldGrantRequest) ProtoMessage()    {}
+func (*InternalCompactionHoldGrantRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4c9a9be0cfca103, []int{4}
+}
+func (m *InternalCompactionHoldGrantRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InternalCompactionHoldGrantRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InternalCompactionHoldGrantRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InternalCompactionHoldGrantRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InternalCompactionHoldGrantRequest.Merge(m, src)
+}
+func (m *InternalCompactionHoldGrantRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *InternalCompactionHoldGrantRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InternalCompactionHoldGrantRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InternalCompactionHoldGrantRequest proto.InternalMessageInfo
+
+// InternalFencePrefixRequest is the version of FencePrefixRequest replicated
+// through raft. Its grant time is set by the proposing member
+// (etcdserver/v3_server.go), and the leader lifts the fence once it expires,
+// so that all members reject the same writes. It is also the record of the
+// fence in the backend.
+type InternalFencePrefixRequest struct {
+	Prefix []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// TTL is the time-to-live of the fence in seconds, 0 to lift the fence.
+	TTL   int64  `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	Token string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	// grant_time is the unix time in nanoseconds at which the fence was
+	// granted. When lifting a fence, a non-zero grant time only lifts the fence
+	// granted at that time, so that the expiry of a fence does not lift the one
+	// granted again since.
+	GrantTime            int64    `protobuf:"varint,4,opt,name=grant_time,json=grantTime,proto3" json:"grant_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InternalFencePrefixRequest) Reset()         { *m = InternalFencePrefixRequest{} }
+func (m *InternalFencePrefixRequest) String() string { return proto.CompactTextString(m) }
+func (*InternalFencePrefixRequest) ProtoMessage()    {}
+func (*InternalFencePrefixRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4c9a9be0cfca103, []int{5}
+}
+func (m *InternalFencePrefixRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InternalFencePrefixRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InternalFencePrefixRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InternalFencePrefixRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InternalFencePrefixRequest.Merge(m, src)
+}
+func (m *InternalFencePrefixRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *InternalFencePrefixRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InternalFencePrefixRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InternalFencePrefixRequest proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*RequestHeader)(nil), "etcdserverpb.RequestHeader")
 	proto.RegisterType((*InternalRaftRequest)(nil), "etcdserverpb.InternalRaftRequest")
 	proto.RegisterType((*EmptyResponse)(nil), "etcdserverpb.EmptyResponse")
 	proto.RegisterType((*InternalAuthenticateRequest)(nil), "etcdserverpb.InternalAuthenticateRequest")
+	proto.RegisterType((*InternalCompactionHoldGrantRequest)(nil), "etcdserverpb.InternalCompactionHoldGrantRequest")
+	proto.RegisterType((*InternalFencePrefixRequest)(nil), "etcdserverpb.InternalFencePrefixRequest")
 }
 
 func init() { proto.RegisterFile("raft_internal.proto", fileDescriptor_b4c9a9be0cfca103) }
 
 var fileDescriptor_b4c9a9be0cfca103 = []byte{
-	// 1101 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0xcb, 0x72, 0x1b, 0x45,
-	0x14, 0x8d, 0x6c, 0xc7, 0xb6, 0x5a, 0xb6, 0xe3, 0xb4, 0x9d, 0xa4, 0xb1, 0xab, 0x8c, 0xe3, 0x90,
-	0x60, 0x20, 0xc8, 0xc1, 0x06, 0xaa, 0x60, 0x03, 0x8a, 0xe5, 0x72, 0x4c, 0x25, 0x29, 0xd7, 0xc4,
-	0x50, 0x29, 0x28, 0x6a, 0x68, 0xcd, 0x5c, 0x4b, 0x13, 0x8f, 0x66, 0x86, 0xee, 0x96, 0xe2, 0x6c,
-	0x59, 0xb2, 0x06, 0x8a, 0x8f, 0x60, 0xc1, 0x2b, 0xff, 0x90, 0x05, 0x8f, 0x00, 0x3f, 0x00, 0x66,
-	0xc3, 0x1e, 0xd8, 0xa7, 0xfa, 0x31, 0x2f, 0xa9, 0xe5, 0xdd, 0xe8, 0xde, 0x73, 0xcf, 0x39, 0xdd,
-	0x7d, 0xbb, 0x75, 0xd1, 0x02, 0xa3, 0x87, 0xc2, 0x0d, 0x22, 0x01, 0x2c, 0xa2, 0x61, 0x3d, 0x61,
-	0xb1, 0x88, 0xf1, 0x0c, 0x08, 0xcf, 0xe7, 0xc0, 0xfa, 0xc0, 0x92, 0xd6, 0xd2, 0x62, 0x3b, 0x6e,
-	0xc7, 0x2a, 0xb1, 0x21, 0xbf, 0x34, 0x66, 0x69, 0x3e, 0xc7, 0x98, 0x48, 0x95, 0x25, 0x9e, 0xf9,
-	0x5c, 0x95, 0xc9, 0x0d, 0x9a, 0x04, 0x1b, 0x7d, 0x60, 0x3c, 0x88, 0xa3, 0xa4, 0x95, 0x7e, 0x19,
-	0xc4, 0xb5, 0x0c, 0xd1, 0x85, 0x6e, 0x0b, 0x18, 0xef, 0x04, 0x49, 0xd2, 0x2a, 0xfc, 0xd0, 0xb8,
-	0x35, 0x86, 0x66, 0x1d, 0xf8, 0xb4, 0x07, 0x5c, 0xdc, 0x02, 0xea, 0x03, 0xc3, 0x73, 0x68, 0x6c,
-	0xaf, 0x49, 0x2a, 0xab, 0x95, 0xf5, 0x09, 0x67, 0x6c, 0xaf, 0x89, 0x97, 0xd0, 0x74, 0x8f, 0x4b,
-	0xf3, 0x5d, 0x20, 0x63, 0xab, 0x95, 0xf5, 0xaa, 0x93, 0xfd, 0xc6, 0xd7, 0xd1, 0x2c, 0xed, 0x89,
-	0x8e, 0xcb, 0xa0, 0x1f, 0x48, 0x6d, 0x32, 0x2e, 0xcb, 0x6e, 0x4e, 0x7d, 0xfe, 0x98, 0x8c, 0x6f,
-	0xd5, 0x5f, 0x73, 0x66, 0x64, 0xd6, 0x31, 0xc9, 0xb7, 0xa7, 0x3e, 0x53, 0xe1, 0x1b, 0x6b, 0x8f,
-	0x17, 0xd0, 0xc2, 0x9e, 0xd9, 0x11, 0x87, 0x1e, 0x0a, 0x63, 0x00, 0x6f, 0xa1, 0xc9, 0x8e, 0x32,
-	0x41, 0xfc, 0xd5, 0xca, 0x7a, 0x6d, 0x73, 0xb9, 0x5e, 0xdc, 0xa7, 0x7a, 0xc9, 0xa7, 0x63, 0xa0,
-	0x43, 0x7e, 0xaf, 0xa2, 0xb1, 0xfe, 0xa6, 0x72, 0x5a, 0xdb, 0xbc, 0x60, 0x25, 0x70, 0xc6, 0xfa,
-	0x9b, 0xf8, 0x06, 0x3a, 0xcb, 0x68, 0xd4, 0x06, 0x65, 0xb9, 0xb6, 0xb9, 0x34, 0x80, 0x94, 0xa9,
-	0x14, 0xae, 0x81, 0xf8, 0x65, 0x34, 0x9e, 0xf4, 0x04, 0x99, 0x50, 0x78, 0x52, 0xc6, 0xef, 0xf7,
-	0xd2, 0x45, 0x38, 0x12, 0x84, 0xb7, 0xd1, 0x8c, 0x0f, 0x21, 0x08, 0x70, 0xb5, 0xc8, 0x59, 0x55,
-	0xb4, 0x5a, 0x2e, 0x6a, 0x2a, 0x44, 0x49, 0xaa, 0xe6, 0xe7, 0x31, 0x29, 0x28, 0x8e, 0x23, 0x32,
-	0x69, 0x13, 0x3c, 0x38, 0x8e, 0x32, 0x41, 0x71, 0x1c, 0xe1, 0x77, 0x10, 0xf2, 0xe2, 0x6e, 0x42,
-	0x3d, 0x21, 0x8f, 0x61, 0x4a, 0x95, 0x3c, 0x5f, 0x2e, 0xd9, 0xce, 0xf2, 0x69, 0x65, 0xa1, 0x04,
-	0xbf, 0x8b, 0x6a, 0x21, 0x50, 0x0e, 0x6e, 0x9b, 0xd1, 0x48, 0x90, 0x69, 0x1b, 0xc3, 0x6d, 0x09,
-	0xd8, 0x95, 0xf9, 0x8c, 0x21, 0xcc, 0x42, 0x72, 0xcd, 0x9a, 0x81, 0x41, 0x3f, 0x3e, 0x02, 0x52,
-	0xb5, 0xad, 0x59, 0x51, 0x38, 0x0a, 0x90, 0xad, 0x39, 0xcc, 0x63, 0xf2, 0x58, 0x68, 0x48, 0x59,
-	0x97, 0x20, 0xdb, 0xb1, 0x34, 0x64, 0x2a, 0x3b, 0x16, 0x05, 0xc4, 0xf7, 0xd1, 0xbc, 0x96, 0xf5,
-	0x3a, 0xe0, 0x1d, 0x25, 0x71, 0x10, 0x09, 0x52, 0x53, 0xc5, 0x2f, 0x58, 0xa4, 0xb7, 0x33, 0x90,
-	0xa1, 0x49, 0x9b, 0xf5, 0x75, 0xe7, 0x5c, 0x58, 0x06, 0xe0, 0x06, 0xaa, 0xa9, 0xee, 0x86, 0x88,
-	0xb6, 0x42, 0x20, 0xff, 0x58, 0x77, 0xb5, 0xd1, 0x13, 0x9d, 0x1d, 0x05, 0xc8, 0xf6, 0x84, 0x66,
-	0x21, 0xdc, 0x44, 0xea, 0x0a, 0xb8, 0x7e, 0xc0, 0x15, 0xc7, 0xbf, 0x53, 0xb6, 0x4d, 0x91, 0x1c,
-	0x4d, 0x8d, 0xc8, 0x36, 0x85, 0xe6, 0x31, 0xfc, 0x9e, 0x31, 0xc2, 0x05, 0x15, 0x3d, 0x4e, 0xfe,
-	0x1f, 0x69, 0xe4, 0x9e, 0x02, 0x0c, 0xac, 0xec, 0x0d, 0xed, 0x48, 0xe7, 0xf0, 0x5d, 0xed, 0x08,
-	0x22, 0x11, 0x78, 0x54, 0x00, 0xf9, 0x4f, 0x93, 0xbd, 0x54, 0x26, 0x4b, 0x6f, 0x67, 0xa3, 0x00,
-	0x4d, 0xad, 0x95, 0xea, 0xf1, 0x8e, 0x79, 0x02, 0xe4, 0x9b, 0xe0, 0x52, 0xdf, 0x27, 0x3f, 0x4d,
-	0x8f, 0x5a, 0xe2, 0xfb, 0x1c, 0x58, 0xc3, 0xf7, 0x4b, 0x4b, 0x34, 0x31, 0x7c, 0x17, 0xcd, 0xe7,
-	0x34, 0xfa, 0x12, 0x90, 0x9f, 0x35, 0xd3, 0x15, 0x3b, 0x93, 0xb9, 0x3d, 0x86, 0x6c, 0x8e, 0x96,
-	0xc2, 0x65, 0x5b, 0x6d, 0x10, 0xe4, 0x97, 0x53, 0x6d, 0xed, 0x82, 0x18, 0xb2, 0xb5, 0x0b, 0x02,
-	0xb7, 0xd1, 0x73, 0x39, 0x8d, 0xd7, 0x91, 0xd7, 0xd2, 0x4d, 0x28, 0xe7, 0x0f, 0x63, 0xe6, 0x93,
-	0x5f, 0x35, 0xe5, 0x2b, 0x76, 0xca, 0x6d, 0x85, 0xde, 0x37, 0xe0, 0x94, 0xfd, 0x22, 0xb5, 0xa6,
-	0xf1, 0x7d, 0xb4, 0x58, 0xf0, 0x2b, 0xef, 0x93, 0xcb, 0xe2, 0x10, 0xc8, 0x53, 0xad, 0x71, 0x6d,
-	0x84, 0x6d, 0x75, 0x17, 0xe3, 0xbc, 0x6d, 0xce, 0xd3, 0xc1, 0x0c, 0xfe, 0x08, 0x5d, 0xc8, 0x99,
-	0xf5, 0xd5, 0xd4, 0xd4, 0xbf, 0x69, 0xea, 0x17, 0xed, 0xd4, 0xe6, 0x8e, 0x16, 0xb8, 0x31, 0x1d,
-	0x4a, 0xe1, 0x5b, 0x68, 0x2e, 0x27, 0x0f, 0x03, 0x2e, 0xc8, 0xef, 0x9a, 0xf5, 0xb2, 0x9d, 0xf5,
-	0x76, 0xc0, 0x45, 0xa9, 0x8f, 0xd2, 0x60, 0xc6, 0x24, 0xad, 0x69, 0xa6, 0x3f, 0x46, 0x32, 0x49,
-	0xe9, 0x21, 0xa6, 0x34, 0x98, 0x1d, 0xbd, 0x62, 0x92, 0x1d, 0xf9, 0x6d, 0x75, 0xd4, 0xd1, 0xcb,
-	0x9a, 0xc1, 0x8e, 0x34, 0xb1, 0xac, 0x23, 0x15, 0x8d, 0xe9, 0xc8, 0xef, 0xaa, 0xa3, 0x3a, 0x52,
-	0x56, 0x59, 0x3a, 0x32, 0x0f, 0x97, 0x6d, 0xc9, 0x8e, 0xfc, 0xfe, 0x54, 0x5b, 0x83, 0x1d, 0x69,
-	0x62, 0xf8, 0x01, 0x5a, 0x2a, 0xd0, 0xa8, 0x46, 0x49, 0x80, 0x75, 0x03, 0xae, 0xfe, 0x7f, 0x7f,
-	0xd0, 0x9c, 0xd7, 0x47, 0x70, 0x4a, 0xf8, 0x7e, 0x86, 0x4e, 0xf9, 0x2f, 0x51, 0x7b, 0x1e, 0x77,
-	0xd1, 0x72, 0xae, 0x65, 0x5a, 0xa7, 0x20, 0xf6, 0xa3, 0x16, 0x7b, 0xd5, 0x2e, 0xa6, 0xbb, 0x64,
-	0x58, 0x8d, 0xd0, 0x11, 0x00, 0xfc, 0x09, 0x5a, 0xf0, 0xc2, 0x1e, 0x17, 0xc0, 0x5c, 0x33, 0xcb,
-	0xb8, 0x1c, 0x04, 0xf9, 0x02, 0x99, 0x2b, 0x50, 0x1c, 0x64, 0xea, 0xdb, 0x1a, 0xf9, 0x81, 0x06,
-	0xde, 0x03, 0x31, 0xf4, 0xea, 0x9d, 0xf7, 0x06, 0x21, 0xf8, 0x01, 0xba, 0x94, 0x2a, 0x68, 0x32,
-	0x97, 0x0a, 0xc1, 0x94, 0xca, 0x97, 0xc8, 0xbc, 0x83, 0x36, 0x95, 0x3b, 0x2a, 0xd6, 0x10, 0x82,
-	0xd9, 0x84, 0x16, 0x3d, 0x0b, 0x0a, 0x7f, 0x8c, 0xb0, 0x1f, 0x3f, 0x8c, 0xda, 0x8c, 0xfa, 0xe0,
-	0x06, 0xd1, 0x61, 0xac, 0x64, 0xbe, 0xd2, 0x32, 0x57, 0xcb, 0x32, 0xcd, 0x14, 0xb8, 0x17, 0x1d,
-	0xc6, 0x36, 0x89, 0x79, 0x7f, 0x00, 0x81, 0x03, 0x74, 0x31, 0xa7, 0x4f, 0xb7, 0x4b, 0x00, 0x17,
-	0xe4, 0x9b, 0x3b, 0xb6, 0x17, 0x3d, 0x93, 0x30, 0xdb, 0x71, 0x00, 0x7c, 0x50, 0xe6, 0x4d, 0x67,
-	0xd1, 0xb7, 0xa0, 0xf2, 0xb9, 0xed, 0x1c, 0x9a, 0xdd, 0xe9, 0x26, 0xe2, 0x91, 0x03, 0x3c, 0x89,
-	0x23, 0x0e, 0x6b, 0x8f, 0xd0, 0xf2, 0x29, 0xff, 0x14, 0x18, 0xa3, 0x09, 0x35, 0x36, 0x56, 0xd4,
-	0xd8, 0xa8, 0xbe, 0xe5, 0x38, 0x99, 0x3d, 0xa0, 0x66, 0x9c, 0x4c, 0x7f, 0xe3, 0xcb, 0x68, 0x86,
-	0x07, 0xdd, 0x24, 0x04, 0x57, 0xc4, 0x47, 0xa0, 0xa7, 0xc9, 0xaa, 0x53, 0xd3, 0xb1, 0x03, 0x19,
-	0xca, 0xbc, 0xdc, 0x7c, 0xeb, 0xc9, 0x5f, 0x2b, 0x67, 0x9e, 0x9c, 0xac, 0x54, 0x9e, 0x9e, 0xac,
-	0x54, 0xfe, 0x3c, 0x59, 0xa9, 0x7c, 0xfd, 0xf7, 0xca, 0x99, 0x0f, 0xaf, 0xb4, 0x63, 0xb5, 0xec,
-	0x7a, 0x10, 0x6f, 0xe4, 0x23, 0xf2, 0xd6, 0x46, 0x71, 0x2b, 0x5a, 0x93, 0x6a, 0xf2, 0xdd, 0x7a,
-	0x16, 0x00, 0x00, 0xff, 0xff, 0xb2, 0xa0, 0x15, 0x1f, 0x9b, 0x0b, 0x00, 0x00,
+	// 1450 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x57, 0x4b, 0x73, 0x1b, 0x45,
+	0x10, 0x8e, 0x24, 0x3f, 0xa2, 0x96, 0xec, 0x28, 0x63, 0xc7, 0x99, 0xc8, 0x85, 0xe3, 0x38, 0x24,
+	0x18, 0x08, 0x76, 0xb0, 0x09, 0x29, 0xb8, 0x80, 0x63, 0x9b, 0xc4, 0x94, 0x93, 0x72, 0x6d, 0x04,
+	0x95, 0xe2, 0x51, 0xcb, 0x78, 0x77, 0x24, 0x6d, 0xb2, 0xda, 0x5d, 0x76, 0x47, 0x8e, 0x73, 0xa0,
+	0x8a, 0xa2, 0x38, 0x71, 0x06, 0x8a, 0x13, 0xbf, 0x80, 0x03, 0xaf, 0xf0, 0x1b, 0x72, 0x08, 0x10,
+	0xe0, 0x4c, 0x15, 0x98, 0x0b, 0x77, 0xe0, 0x4e, 0xcd, 0x63, 0x9f, 0x1a, 0x19, 0x6e, 0xbb, 0xdd,
+	0xdf, 0x7c, 0x5f, 0x77, 0x4f, 0xef, 0xcc, 0x36, 0x4c, 0x85, 0xa4, 0xcd, 0x4c, 0xc7, 0x63, 0x34,
+	0xf4, 0x88, 0xbb, 0x14, 0x84, 0x3e, 0xf3, 0x51, 0x9d, 0x32, 0xcb, 0x8e, 0x68, 0xb8, 0x47, 0xc3,
+	0x60, 0xb7, 0x39, 0xdd, 0xf1, 0x3b, 0xbe, 0x70, 0x2c, 0xf3, 0x27, 0x89, 0x69, 0x36, 0x52, 0x8c,
+	0xb2, 0x54, 0xc3, 0xc0, 0x52, 0x8f, 0xf3, 0xdc, 0xb9, 0x4c, 0x02, 0x67, 0x79, 0x8f, 0x86, 0x91,
+	0xe3, 0x7b, 0xc1, 0x6e, 0xfc, 0xa4, 0x10, 0xe7, 0x13, 0x44, 0x8f, 0xf6, 0x76, 0x69, 0x18, 0x75,
+	0x9d, 0x20, 0xd8, 0xcd, 0xbc, 0x48, 0xdc, 0xc2, 0xc3, 0x12, 0x4c, 0x18, 0xf4, 0xdd, 0x3e, 0x8d,
+	0xd8, 0x35, 0x4a, 0x6c, 0x1a, 0xa2, 0x49, 0x28, 0x6f, 0x6d, 0xe0, 0xd2, 0x7c, 0x69, 0x71, 0xc4,
+	0x28, 0x6f, 0x6d, 0xa0, 0x26, 0x1c, 0xed, 0x47, 0x3c, 0xfa, 0x1e, 0xc5, 0xe5, 0xf9, 0xd2, 0x62,
+	0xd5, 0x48, 0xde, 0xd1, 0x05, 0x98, 0x20, 0x7d, 0xd6, 0x35, 0x43, 0xba, 0xe7, 0x70, 0x71, 0x5c,
+	0xe1, 0xcb, 0xae, 0x8c, 0x7f, 0x74, 0x1f, 0x57, 0x56, 0x97, 0x9e, 0x35, 0xea, 0xdc, 0x6b, 0x28,
+	0x27, 0x5a, 0x82, 0x49, 0xea, 0x52, 0x8b, 0x39, 0xbe, 0x67, 0xba, 0x94, 0x44, 0x14, 0x8f, 0xcc,
+	0x97, 0x16, 0x2b, 0x31, 0xfc, 0xb2, 0x31, 0x11, 0xbb, 0xb7, 0xb9, 0x17, 0x2d, 0x42, 0xad, 0x4d,
+	0x3d, 0x8b, 0x9a, 0xcc, 0xbf, 0x43, 0x3d, 0x3c, 0xca, 0xc5, 0x53, 0x30, 0x08, 0x5f, 0x8b, 0xbb,
+	0x5e, 0x1c, 0xff, 0x40, 0x18, 0x2f, 0x2e, 0xfc, 0x7a, 0x0a, 0xa6, 0xb6, 0x54, 0xb1, 0x0d, 0xd2,
+	0x66, 0x2a, 0x35, 0xb4, 0x0a, 0x63, 0x5d, 0x91, 0x1e, 0xb6, 0xe7, 0x4b, 0x8b, 0xb5, 0x95, 0xd9,
+	0xa5, 0xec, 0x16, 0x2c, 0xe5, 0x2a, 0x60, 0x28, 0xe8, 0x40, 0x25, 0xce, 0x41, 0x79, 0x6f, 0x45,
+	0xd4, 0xa0, 0xb6, 0x72, 0x42, 0x4b, 0x60, 0x94, 0xf7, 0x56, 0xd0, 0x45, 0x18, 0x0d, 0x89, 0xd7,
+	0xa1, 0xa2, 0x18, 0xb5, 0x95, 0x66, 0x01, 0xc9, 0x5d, 0x31, 0x5c, 0x02, 0xd1, 0x53, 0x50, 0x09,
+	0xfa, 0x4c, 0x54, 0xa3, 0xb6, 0x82, 0xf3, 0xf8, 0x9d, 0x7e, 0x9c, 0x84, 0xc1, 0x41, 0x68, 0x1d,
+	0xea, 0x36, 0x75, 0x29, 0xa3, 0xa6, 0x14, 0x19, 0x15, 0x8b, 0xe6, 0xf3, 0x8b, 0x36, 0x04, 0x22,
+	0x27, 0x55, 0xb3, 0x53, 0x1b, 0x17, 0x64, 0xfb, 0x1e, 0x1e, 0xd3, 0x09, 0xb6, 0xf6, 0xbd, 0x44,
+	0x90, 0xed, 0x7b, 0xe8, 0x25, 0x00, 0xcb, 0xef, 0x05, 0x44, 0x6c, 0x0c, 0x1e, 0x17, 0x4b, 0x4e,
+	0xe7, 0x97, 0xac, 0x27, 0xfe, 0x78, 0x65, 0x66, 0x09, 0x7a, 0x19, 0x6a, 0x62, 0xb7, 0xcd, 0x4e,
+	0x48, 0x3c, 0x86, 0x8f, 0xea, 0x18, 0xc4, 0x86, 0x5f, 0xe5, 0xfe, 0x84, 0xc1, 0x4d, 0x4c, 0x3c,
+	0x67, 0xc9, 0x10, 0xd2, 0x3d, 0xff, 0x0e, 0xc5, 0x55, 0x5d, 0xce, 0x82, 0xc2, 0x10, 0x80, 0x24,
+	0x67, 0x37, 0xb5, 0xf1, 0x6d, 0x21, 0x2e, 0x09, 0x7b, 0x18, 0x74, 0xdb, 0xb2, 0xc6, 0x5d, 0xc9,
+	0xb6, 0x08, 0x20, 0xba, 0x05, 0x0d, 0x29, 0x6b, 0x75, 0xa9, 0x75, 0x27, 0xf0, 0x1d, 0x8f, 0xe1,
+	0x9a, 0x58, 0xfc, 0xb8, 0x46, 0x7a, 0x3d, 0x01, 0x29, 0x9a, 0xb8, 0x55, 0x9f, 0x33, 0x8e, 0xb9,
+	0x79, 0x00, 0xea, 0xc1, 0x89, 0xb4, 0x40, 0x66, 0xd7, 0x77, 0x6d, 0x55, 0x9c, 0xba, 0xa0, 0xbf,
+	0x98, 0xa7, 0x8f, 0x1b, 0x3a, 0x2d, 0xf3, 0x35, 0xdf, 0xb5, 0xb3, 0xd5, 0x4a, 0xbf, 0x8a, 0x29,
+	0x6b, 0x10, 0x84, 0xba, 0x30, 0x53, 0x94, 0x53, 0x95, 0x9c, 0x10, 0x7a, 0x4f, 0x0e, 0xdb, 0x4e,
+	0x4e, 0x91, 0x2b, 0x69, 0x2a, 0x34, 0x6d, 0x69, 0x50, 0xe8, 0x2d, 0x40, 0x96, 0xdb, 0x8f, 0x18,
+	0x0d, 0x4d, 0xcb, 0xf7, 0xda, 0x4e, 0xc7, 0x8c, 0x28, 0xc3, 0x93, 0x42, 0xe5, 0x5c, 0x41, 0x45,
+	0xe2, 0xd6, 0x05, 0xec, 0x26, 0x1d, 0x4c, 0xa5, 0x61, 0x15, 0x10, 0x68, 0x3b, 0xee, 0x03, 0xba,
+	0x1f, 0x38, 0x21, 0xc5, 0xc7, 0xfe, 0x5f, 0x1f, 0xa4, 0x94, 0xb2, 0x21, 0x36, 0xc5, 0x6a, 0xb4,
+	0x05, 0x35, 0xcb, 0xef, 0xf3, 0xda, 0x9a, 0xc4, 0xb6, 0x71, 0x43, 0xdf, 0xd9, 0x02, 0xb0, 0x66,
+	0xdb, 0x03, 0x5c, 0x60, 0x25, 0x3e, 0xd4, 0x82, 0xba, 0x3c, 0xa9, 0x82, 0x90, 0xb6, 0x9d, 0x7d,
+	0x7c, 0x5c, 0x70, 0x2d, 0xea, 0xb7, 0xf1, 0x15, 0x8e, 0xdc, 0x11, 0xc0, 0xc1, 0x00, 0xdb, 0xa9,
+	0x13, 0xad, 0x41, 0x4d, 0x9c, 0xae, 0xd4, 0x23, 0xbb, 0x2e, 0xc5, 0x7f, 0x6a, 0xbf, 0xbd, 0xb5,
+	0x3e, 0xeb, 0x6e, 0x0a, 0x40, 0xf2, 0xe5, 0x90, 0xc4, 0x84, 0x36, 0x40, 0x1c, 0xc1, 0xa6, 0xed,
+	0x44, 0x82, 0xe3, 0xaf, 0x71, 0x5d, 0xc9, 0x38, 0xc7, 0x86, 0x44, 0x24, 0x9f, 0x0e, 0x49, 0x6d,
+	0xe8, 0x55, 0x15, 0x48, 0xc4, 0x08, 0xeb, 0x47, 0xf8, 0x9f, 0xa1, 0x81, 0xdc, 0x14, 0x80, 0x42,
+	0x56, 0x97, 0x64, 0x44, 0xd2, 0x87, 0x6e, 0xc8, 0x88, 0xa8, 0xc7, 0x1c, 0x8b, 0x30, 0x8a, 0xff,
+	0x1e, 0xd7, 0xb5, 0x60, 0x5c, 0xab, 0xb5, 0x0c, 0x34, 0x0e, 0x2d, 0xb7, 0x1e, 0x6d, 0xaa, 0x2b,
+	0x88, 0xdf, 0x49, 0x62, 0x1f, 0x1f, 0x1e, 0x1d, 0x96, 0xe2, 0x6b, 0x51, 0x76, 0x27, 0x65, 0x8a,
+	0xca, 0x86, 0x6e, 0x40, 0x23, 0xa5, 0x91, 0x47, 0x25, 0xfe, 0x5e, 0x32, 0x9d, 0xd5, 0x33, 0xa9,
+	0x33, 0x56, 0x91, 0x4d, 0x92, 0x9c, 0x39, 0x1f, 0x56, 0x87, 0x32, 0xfc, 0xc3, 0xa1, 0x61, 0x5d,
+	0x4d, 0xfa, 0x3f, 0x0d, 0xeb, 0x2a, 0x65, 0xa8, 0x03, 0xa7, 0x52, 0x1a, 0xab, 0xcb, 0x0f, 0x6f,
+	0x33, 0x20, 0x51, 0x74, 0xd7, 0x0f, 0x6d, 0xfc, 0xa3, 0xa4, 0x7c, 0x5a, 0x4f, 0xb9, 0x2e, 0xd0,
+	0x3b, 0x0a, 0x1c, 0xb3, 0xcf, 0x10, 0xad, 0x1b, 0xdd, 0x82, 0xe9, 0x4c, 0xbc, 0xfc, 0xd4, 0x30,
+	0x43, 0xdf, 0xa5, 0xf8, 0x91, 0xd4, 0x38, 0x3f, 0x24, 0x6c, 0x71, 0x06, 0xf9, 0x69, 0xdb, 0x1c,
+	0x27, 0x45, 0x0f, 0x7a, 0x13, 0x4e, 0xa4, 0xcc, 0xf2, 0xd8, 0x91, 0xd4, 0x3f, 0x49, 0xea, 0x27,
+	0xf4, 0xd4, 0xea, 0x0b, 0xce, 0x70, 0x23, 0x32, 0xe0, 0x42, 0xd7, 0x60, 0x32, 0x25, 0x77, 0x9d,
+	0x88, 0xe1, 0x9f, 0x25, 0xeb, 0x19, 0x3d, 0xeb, 0xb6, 0x13, 0xb1, 0x5c, 0x1f, 0xc5, 0xc6, 0x84,
+	0x89, 0x87, 0x26, 0x99, 0x7e, 0x19, 0xca, 0xc4, 0xa5, 0x07, 0x98, 0x62, 0x63, 0xb2, 0xf5, 0x82,
+	0x89, 0x77, 0xe4, 0x97, 0xd5, 0x61, 0x5b, 0xcf, 0xd7, 0x14, 0x3b, 0x52, 0xd9, 0x92, 0x8e, 0x14,
+	0x34, 0xaa, 0x23, 0xbf, 0xaa, 0x0e, 0xeb, 0x48, 0xbe, 0x4a, 0xd3, 0x91, 0xa9, 0x39, 0x1f, 0x16,
+	0xef, 0xc8, 0xaf, 0x0f, 0x0d, 0xab, 0xd8, 0x91, 0xca, 0x86, 0x6e, 0x43, 0x33, 0x43, 0x23, 0x1a,
+	0x25, 0xa0, 0x61, 0xcf, 0x89, 0xc4, 0xff, 0xdf, 0x37, 0x92, 0xf3, 0xc2, 0x10, 0x4e, 0x0e, 0xdf,
+	0x49, 0xd0, 0x31, 0xff, 0x49, 0xa2, 0xf7, 0xa3, 0x1e, 0xcc, 0xa6, 0x5a, 0xaa, 0x75, 0x32, 0x62,
+	0xdf, 0x4a, 0xb1, 0x67, 0xf4, 0x62, 0xb2, 0x4b, 0x06, 0xd5, 0x30, 0x19, 0x02, 0x40, 0xef, 0xc1,
+	0x5c, 0x31, 0xb5, 0x30, 0xb0, 0xb2, 0x8a, 0xf7, 0xa5, 0xe2, 0xf2, 0x21, 0xe9, 0x19, 0x3b, 0xeb,
+	0x03, 0x9a, 0xe9, 0xf9, 0xde, 0xcc, 0xa5, 0x6a, 0x04, 0x56, 0x46, 0xfe, 0xfd, 0x12, 0x9c, 0x1e,
+	0x48, 0xb7, 0x10, 0xc0, 0x77, 0x55, 0xdd, 0x0f, 0x42, 0x3e, 0xe5, 0xc3, 0x23, 0x98, 0xcd, 0xa7,
+	0x9f, 0x0f, 0xe1, 0x1d, 0x98, 0x8a, 0xaf, 0x6f, 0x35, 0x4e, 0x88, 0xfb, 0xfb, 0x63, 0x50, 0x87,
+	0x40, 0x76, 0x96, 0x88, 0x2f, 0xf0, 0xd7, 0x25, 0x70, 0xf0, 0x06, 0xbf, 0x64, 0x1c, 0xb7, 0x8a,
+	0x10, 0x74, 0x1b, 0x4e, 0xc6, 0x0a, 0x92, 0xcc, 0x24, 0x8c, 0x85, 0x42, 0xe5, 0x13, 0x50, 0x37,
+	0x81, 0x4e, 0xe5, 0xba, 0xb0, 0xad, 0x31, 0x16, 0xea, 0x84, 0xa6, 0x2d, 0x0d, 0x0a, 0xbd, 0x0d,
+	0xc8, 0xf6, 0xef, 0x7a, 0x9d, 0x90, 0xd8, 0xd4, 0x74, 0xbc, 0xb6, 0x2f, 0x64, 0x3e, 0x05, 0xf5,
+	0x37, 0x92, 0x93, 0xd9, 0x88, 0x81, 0x5b, 0x5e, 0xdb, 0xd7, 0x49, 0x34, 0xec, 0x02, 0x02, 0x39,
+	0x30, 0x93, 0xd2, 0xc7, 0xe5, 0x62, 0x34, 0x62, 0xf8, 0x8b, 0xeb, 0xba, 0x3b, 0x2d, 0x91, 0x50,
+	0xe5, 0x68, 0xd1, 0xa8, 0x28, 0xf3, 0xbc, 0x31, 0x6d, 0x6b, 0x50, 0xe9, 0x7c, 0x73, 0x0c, 0x26,
+	0x36, 0x7b, 0x01, 0xbb, 0x67, 0xd0, 0x28, 0xf0, 0xbd, 0x88, 0x2e, 0xdc, 0x83, 0xd9, 0x43, 0xee,
+	0x4a, 0x84, 0x60, 0x44, 0x0c, 0x6e, 0x25, 0x31, 0xb8, 0x89, 0x67, 0x3e, 0xd0, 0x25, 0x57, 0x88,
+	0x1a, 0xe8, 0xe2, 0x77, 0x74, 0x06, 0xea, 0x91, 0xd3, 0x0b, 0xdc, 0x78, 0xe6, 0xaa, 0x08, 0x7f,
+	0x4d, 0xda, 0x0a, 0xb3, 0xd6, 0xe7, 0x25, 0x58, 0xf8, 0xef, 0x5f, 0xd3, 0xcc, 0x14, 0x55, 0x11,
+	0x53, 0x54, 0x1c, 0x52, 0x39, 0x1f, 0x52, 0x6e, 0x84, 0xac, 0x18, 0xc9, 0x3b, 0x6a, 0x40, 0xa5,
+	0xd5, 0xda, 0x96, 0xa3, 0xa2, 0xc1, 0x1f, 0xd1, 0x63, 0x00, 0xf2, 0xeb, 0x64, 0x4e, 0x4f, 0x0e,
+	0x40, 0x15, 0xa3, 0x2a, 0x2c, 0x2d, 0xa7, 0x47, 0xe3, 0x00, 0x2f, 0x2f, 0x7c, 0x58, 0x82, 0xe6,
+	0xf0, 0x9f, 0x2e, 0x34, 0x03, 0x63, 0xea, 0x77, 0x8d, 0x07, 0x57, 0x37, 0xd4, 0x5b, 0x2c, 0x58,
+	0x4e, 0x05, 0xa7, 0x61, 0x34, 0x5b, 0x0e, 0xf9, 0x52, 0x08, 0x63, 0x64, 0x58, 0x18, 0x57, 0x5e,
+	0x78, 0xf0, 0xfb, 0xdc, 0x91, 0x07, 0x07, 0x73, 0xa5, 0x47, 0x07, 0x73, 0xa5, 0xdf, 0x0e, 0xe6,
+	0x4a, 0x9f, 0xfd, 0x31, 0x77, 0xe4, 0x8d, 0xb3, 0x1d, 0x5f, 0xb4, 0xc7, 0x92, 0xe3, 0x2f, 0xa7,
+	0xd3, 0xfc, 0xea, 0x72, 0xb6, 0x65, 0x76, 0xc7, 0xc4, 0x90, 0xbe, 0xfa, 0x6f, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0x29, 0x26, 0xfc, 0x65, 0x46, 0x10, 0x00, 0x00,
 }
 
 func (m *RequestHeader) Marshal() (dAtA []byte, err error) {
@@ -335,6 +478,18 @@ func (m *RequestHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.FenceToken) > 0 {
+		i -= len(m.FenceToken)
+		copy(dAtA[i:], m.FenceToken)
+		i = encodeVarintRaftInternal(dAtA, i, uint64(len(m.FenceToken)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.ElectionLease != 0 {
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.ElectionLease))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.AuthRevision != 0 {
 		i = encodeVarintRaftInternal(dAtA, i, uint64(m.AuthRevision))
 		i--
@@ -437,6 +592,34 @@ func (m *InternalRaftRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xa2
 	}
+	if m.AuthRoleRevokeRpcPermission != nil {
+		{
+			size, err := m.AuthRoleRevokeRpcPermission.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4b
+		i--
+		dAtA[i] = 0xb2
+	}
+	if m.AuthRoleGrantRpcPermission != nil {
+		{
+			size, err := m.AuthRoleGrantRpcPermission.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4b
+		i--
+		dAtA[i] = 0xaa
+	}
 	if m.AuthRoleRevokePermission != nil {
 		{
 			size, err := m.AuthRoleRevokePermission.MarshalToSizedBuffer(dAtA[:i])
@@ -689,6 +872,82 @@ func (m *InternalRaftRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xa2
 	}
+	if m.FencePrefix != nil {
+		{
+			size, err := m.FencePrefix.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.CounterAdd != nil {
+		{
+			size, err := m.CounterAdd.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.LeaseExpire != nil {
+		{
+			size, err := m.LeaseExpire.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.ClusterConfigSet != nil {
+		{
+			size, err := m.ClusterConfigSet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.CompactionHoldRevoke != nil {
+		{
+			size, err := m.CompactionHoldRevoke.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.CompactionHoldGrant != nil {
+		{
+			size, err := m.CompactionHoldGrant.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
 	if m.LeaseCheckpoint != nil {
 		{
 			size, err := m.LeaseCheckpoint.MarshalToSizedBuffer(dAtA[:i])
@@ -892,6 +1151,111 @@ func (m *InternalAuthenticateRequest) MarshalToSizedBuffer(dAtA []byte) (int, er
 	return len(dAtA) - i, nil
 }
 
+func (m *InternalCompactionHoldGrantRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InternalCompactionHoldGrantRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InternalCompactionHoldGrantRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.GrantTime != 0 {
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.GrantTime))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.TTL != 0 {
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.TTL))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Revision != 0 {
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintRaftInternal(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InternalFencePrefixRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InternalFencePrefixRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InternalFencePrefixRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.GrantTime != 0 {
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.GrantTime))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintRaftInternal(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.TTL != 0 {
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.TTL))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintRaftInternal(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintRaftInternal(dAtA []byte, offset int, v uint64) int {
 	offset -= sovRaftInternal(v)
 	base := offset
@@ -919,6 +1283,13 @@ func (m *RequestHeader) Size() (n int) {
 	if m.AuthRevision != 0 {
 		n += 1 + sovRaftInternal(uint64(m.AuthRevision))
 	}
+	if m.ElectionLease != 0 {
+		n += 1 + sovRaftInternal(uint64(m.ElectionLease))
+	}
+	l = len(m.FenceToken)
+	if l > 0 {
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -974,6 +1345,30 @@ func (m *InternalRaftRequest) Size() (n int) {
 		l = m.LeaseCheckpoint.Size()
 		n += 1 + l + sovRaftInternal(uint64(l))
 	}
+	if m.CompactionHoldGrant != nil {
+		l = m.CompactionHoldGrant.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.CompactionHoldRevoke != nil {
+		l = m.CompactionHoldRevoke.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.ClusterConfigSet != nil {
+		l = m.ClusterConfigSet.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.LeaseExpire != nil {
+		l = m.LeaseExpire.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.CounterAdd != nil {
+		l = m.CounterAdd.Size()
+		n += 2 + l + sovRaftInternal(uint64(l))
+	}
+	if m.FencePrefix != nil {
+		l = m.FencePrefix.Size()
+		n += 2 + l + sovRaftInternal(uint64(l))
+	}
 	if m.Header != nil {
 		l = m.Header.Size()
 		n += 2 + l + sovRaftInternal(uint64(l))
@@ -1046,6 +1441,14 @@ func (m *InternalRaftRequest) Size() (n int) {
 		l = m.AuthRoleRevokePermission.Size()
 		n += 2 + l + sovRaftInternal(uint64(l))
 	}
+	if m.AuthRoleGrantRpcPermission != nil {
+		l = m.AuthRoleGrantRpcPermission.Size()
+		n += 2 + l + sovRaftInternal(uint64(l))
+	}
+	if m.AuthRoleRevokeRpcPermission != nil {
+		l = m.AuthRoleRevokeRpcPermission.Size()
+		n += 2 + l + sovRaftInternal(uint64(l))
+	}
 	if m.ClusterVersionSet != nil {
 		l = m.ClusterVersionSet.Size()
 		n += 2 + l + sovRaftInternal(uint64(l))
@@ -1104,11 +1507,65 @@ func (m *InternalAuthenticateRequest) Size() (n int) {
 	return n
 }
 
-func sovRaftInternal(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozRaftInternal(x uint64) (n int) {
-	return sovRaftInternal(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *InternalCompactionHoldGrantRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRaftInternal(uint64(m.ID))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.Revision != 0 {
+		n += 1 + sovRaftInternal(uint64(m.Revision))
+	}
+	if m.TTL != 0 {
+		n += 1 + sovRaftInternal(uint64(m.TTL))
+	}
+	if m.GrantTime != 0 {
+		n += 1 + sovRaftInternal(uint64(m.GrantTime))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalFencePrefixRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.TTL != 0 {
+		n += 1 + sovRaftInternal(uint64(m.TTL))
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.GrantTime != 0 {
+		n += 1 + sovRaftInternal(uint64(m.GrantTime))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovRaftInternal(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozRaftInternal(x uint64) (n int) {
+	return sovRaftInternal(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
 func (m *RequestHeader) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
@@ -1209,6 +1666,57 @@ func (m *RequestHeader) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElectionLease", wireType)
+			}
+			m.ElectionLease = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ElectionLease |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FenceToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FenceToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftInternal(dAtA[iNdEx:])
@@ -1639,9 +2147,9 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 100:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactionHoldGrant", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1668,16 +2176,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &RequestHeader{}
+			if m.CompactionHoldGrant == nil {
+				m.CompactionHoldGrant = &InternalCompactionHoldGrantRequest{}
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CompactionHoldGrant.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1000:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthEnable", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactionHoldRevoke", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1704,16 +2212,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthEnable == nil {
-				m.AuthEnable = &AuthEnableRequest{}
+			if m.CompactionHoldRevoke == nil {
+				m.CompactionHoldRevoke = &CompactionHoldRevokeRequest{}
 			}
-			if err := m.AuthEnable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CompactionHoldRevoke.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1011:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthDisable", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterConfigSet", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1740,16 +2248,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthDisable == nil {
-				m.AuthDisable = &AuthDisableRequest{}
+			if m.ClusterConfigSet == nil {
+				m.ClusterConfigSet = &ClusterConfigSetRequest{}
 			}
-			if err := m.AuthDisable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ClusterConfigSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1012:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Authenticate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseExpire", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1776,16 +2284,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Authenticate == nil {
-				m.Authenticate = &InternalAuthenticateRequest{}
+			if m.LeaseExpire == nil {
+				m.LeaseExpire = &LeaseRevokeRequest{}
 			}
-			if err := m.Authenticate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LeaseExpire.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1013:
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CounterAdd", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1812,16 +2320,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthStatus == nil {
-				m.AuthStatus = &AuthStatusRequest{}
+			if m.CounterAdd == nil {
+				m.CounterAdd = &CounterAddRequest{}
 			}
-			if err := m.AuthStatus.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CounterAdd.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1100:
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserAdd", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FencePrefix", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1848,16 +2356,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthUserAdd == nil {
-				m.AuthUserAdd = &AuthUserAddRequest{}
+			if m.FencePrefix == nil {
+				m.FencePrefix = &InternalFencePrefixRequest{}
 			}
-			if err := m.AuthUserAdd.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.FencePrefix.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1101:
+		case 100:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserDelete", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1884,16 +2392,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthUserDelete == nil {
-				m.AuthUserDelete = &AuthUserDeleteRequest{}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
 			}
-			if err := m.AuthUserDelete.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1102:
+		case 1000:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserGet", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthEnable", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1920,16 +2428,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthUserGet == nil {
-				m.AuthUserGet = &AuthUserGetRequest{}
+			if m.AuthEnable == nil {
+				m.AuthEnable = &AuthEnableRequest{}
 			}
-			if err := m.AuthUserGet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthEnable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1103:
+		case 1011:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserChangePassword", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthDisable", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1956,16 +2464,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthUserChangePassword == nil {
-				m.AuthUserChangePassword = &AuthUserChangePasswordRequest{}
+			if m.AuthDisable == nil {
+				m.AuthDisable = &AuthDisableRequest{}
 			}
-			if err := m.AuthUserChangePassword.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthDisable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1104:
+		case 1012:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserGrantRole", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Authenticate", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1992,16 +2500,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthUserGrantRole == nil {
-				m.AuthUserGrantRole = &AuthUserGrantRoleRequest{}
+			if m.Authenticate == nil {
+				m.Authenticate = &InternalAuthenticateRequest{}
 			}
-			if err := m.AuthUserGrantRole.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Authenticate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1105:
+		case 1013:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserRevokeRole", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthStatus", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2028,16 +2536,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthUserRevokeRole == nil {
-				m.AuthUserRevokeRole = &AuthUserRevokeRoleRequest{}
+			if m.AuthStatus == nil {
+				m.AuthStatus = &AuthStatusRequest{}
 			}
-			if err := m.AuthUserRevokeRole.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthStatus.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1106:
+		case 1100:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserList", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserAdd", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2064,16 +2572,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthUserList == nil {
-				m.AuthUserList = &AuthUserListRequest{}
+			if m.AuthUserAdd == nil {
+				m.AuthUserAdd = &AuthUserAddRequest{}
 			}
-			if err := m.AuthUserList.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthUserAdd.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1107:
+		case 1101:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleList", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserDelete", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2100,16 +2608,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthRoleList == nil {
-				m.AuthRoleList = &AuthRoleListRequest{}
+			if m.AuthUserDelete == nil {
+				m.AuthUserDelete = &AuthUserDeleteRequest{}
 			}
-			if err := m.AuthRoleList.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthUserDelete.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1200:
+		case 1102:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleAdd", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserGet", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2136,16 +2644,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthRoleAdd == nil {
-				m.AuthRoleAdd = &AuthRoleAddRequest{}
+			if m.AuthUserGet == nil {
+				m.AuthUserGet = &AuthUserGetRequest{}
 			}
-			if err := m.AuthRoleAdd.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthUserGet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1201:
+		case 1103:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleDelete", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserChangePassword", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2172,16 +2680,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthRoleDelete == nil {
-				m.AuthRoleDelete = &AuthRoleDeleteRequest{}
+			if m.AuthUserChangePassword == nil {
+				m.AuthUserChangePassword = &AuthUserChangePasswordRequest{}
 			}
-			if err := m.AuthRoleDelete.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthUserChangePassword.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1202:
+		case 1104:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleGet", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserGrantRole", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2208,16 +2716,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthRoleGet == nil {
-				m.AuthRoleGet = &AuthRoleGetRequest{}
+			if m.AuthUserGrantRole == nil {
+				m.AuthUserGrantRole = &AuthUserGrantRoleRequest{}
 			}
-			if err := m.AuthRoleGet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthUserGrantRole.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1203:
+		case 1105:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleGrantPermission", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserRevokeRole", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2244,16 +2752,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthRoleGrantPermission == nil {
-				m.AuthRoleGrantPermission = &AuthRoleGrantPermissionRequest{}
+			if m.AuthUserRevokeRole == nil {
+				m.AuthUserRevokeRole = &AuthUserRevokeRoleRequest{}
 			}
-			if err := m.AuthRoleGrantPermission.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthUserRevokeRole.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1204:
+		case 1106:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleRevokePermission", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthUserList", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2280,16 +2788,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AuthRoleRevokePermission == nil {
-				m.AuthRoleRevokePermission = &AuthRoleRevokePermissionRequest{}
+			if m.AuthUserList == nil {
+				m.AuthUserList = &AuthUserListRequest{}
 			}
-			if err := m.AuthRoleRevokePermission.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthUserList.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1300:
+		case 1107:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClusterVersionSet", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleList", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2316,16 +2824,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ClusterVersionSet == nil {
-				m.ClusterVersionSet = &membershippb.ClusterVersionSetRequest{}
+			if m.AuthRoleList == nil {
+				m.AuthRoleList = &AuthRoleListRequest{}
 			}
-			if err := m.ClusterVersionSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthRoleList.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1301:
+		case 1200:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClusterMemberAttrSet", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleAdd", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2352,16 +2860,16 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ClusterMemberAttrSet == nil {
-				m.ClusterMemberAttrSet = &membershippb.ClusterMemberAttrSetRequest{}
+			if m.AuthRoleAdd == nil {
+				m.AuthRoleAdd = &AuthRoleAddRequest{}
 			}
-			if err := m.ClusterMemberAttrSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthRoleAdd.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 1302:
+		case 1201:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DowngradeInfoSet", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleDelete", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2388,16 +2896,304 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DowngradeInfoSet == nil {
-				m.DowngradeInfoSet = &membershippb.DowngradeInfoSetRequest{}
+			if m.AuthRoleDelete == nil {
+				m.AuthRoleDelete = &AuthRoleDeleteRequest{}
 			}
-			if err := m.DowngradeInfoSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AuthRoleDelete.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9900:
+		case 1202:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DowngradeVersionTest", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleGet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AuthRoleGet == nil {
+				m.AuthRoleGet = &AuthRoleGetRequest{}
+			}
+			if err := m.AuthRoleGet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1203:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleGrantPermission", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AuthRoleGrantPermission == nil {
+				m.AuthRoleGrantPermission = &AuthRoleGrantPermissionRequest{}
+			}
+			if err := m.AuthRoleGrantPermission.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1204:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleRevokePermission", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AuthRoleRevokePermission == nil {
+				m.AuthRoleRevokePermission = &AuthRoleRevokePermissionRequest{}
+			}
+			if err := m.AuthRoleRevokePermission.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1205:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleGrantRpcPermission", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AuthRoleGrantRpcPermission == nil {
+				m.AuthRoleGrantRpcPermission = &AuthRoleGrantRPCPermissionRequest{}
+			}
+			if err := m.AuthRoleGrantRpcPermission.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1206:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthRoleRevokeRpcPermission", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AuthRoleRevokeRpcPermission == nil {
+				m.AuthRoleRevokeRpcPermission = &AuthRoleRevokeRPCPermissionRequest{}
+			}
+			if err := m.AuthRoleRevokeRpcPermission.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1300:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterVersionSet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ClusterVersionSet == nil {
+				m.ClusterVersionSet = &membershippb.ClusterVersionSetRequest{}
+			}
+			if err := m.ClusterVersionSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1301:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterMemberAttrSet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ClusterMemberAttrSet == nil {
+				m.ClusterMemberAttrSet = &membershippb.ClusterMemberAttrSetRequest{}
+			}
+			if err := m.ClusterMemberAttrSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 1302:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DowngradeInfoSet", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DowngradeInfoSet == nil {
+				m.DowngradeInfoSet = &membershippb.DowngradeInfoSetRequest{}
+			}
+			if err := m.DowngradeInfoSet.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9900:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DowngradeVersionTest", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2427,9 +3223,207 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 			if m.DowngradeVersionTest == nil {
 				m.DowngradeVersionTest = &DowngradeVersionTestRequest{}
 			}
-			if err := m.DowngradeVersionTest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if err := m.DowngradeVersionTest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EmptyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EmptyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EmptyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InternalAuthenticateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InternalAuthenticateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InternalAuthenticateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Password = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SimpleToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SimpleToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2453,7 +3447,7 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EmptyResponse) Unmarshal(dAtA []byte) error {
+func (m *InternalCompactionHoldGrantRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2476,12 +3470,120 @@ func (m *EmptyResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EmptyResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: InternalCompactionHoldGrantRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EmptyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InternalCompactionHoldGrantRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+			}
+			m.TTL = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TTL |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GrantTime", wireType)
+			}
+			m.GrantTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GrantTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftInternal(dAtA[iNdEx:])
@@ -2504,7 +3606,7 @@ func (m *EmptyResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InternalAuthenticateRequest) Unmarshal(dAtA []byte) error {
+func (m *InternalFencePrefixRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2527,17 +3629,17 @@ func (m *InternalAuthenticateRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InternalAuthenticateRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: InternalFencePrefixRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InternalAuthenticateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InternalFencePrefixRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftInternal
@@ -2547,29 +3649,31 @@ func (m *InternalAuthenticateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRaftInternal
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRaftInternal
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prefix == nil {
+				m.Prefix = []byte{}
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
 			}
-			var stringLen uint64
+			m.TTL = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftInternal
@@ -2579,27 +3683,14 @@ func (m *InternalAuthenticateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.TTL |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthRaftInternal
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRaftInternal
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Password = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SimpleToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2627,8 +3718,27 @@ func (m *InternalAuthenticateRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SimpleToken = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GrantTime", wireType)
+			}
+			m.GrantTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GrantTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftInternal(dAtA[iNdEx:])
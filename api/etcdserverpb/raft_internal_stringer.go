@@ -83,8 +83,16 @@ func NewLoggableTxnRequest(request *TxnRequest) fmt.Stringer {
 }
 
 func (as *txnRequestStringer) String() string {
+	return fmt.Sprintf("compare:<%s> success:<%s> failure:<%s>",
+		loggableCompares(as.Request.Compare),
+		loggableRequestOps(as.Request.Success),
+		loggableRequestOps(as.Request.Failure),
+	)
+}
+
+func loggableCompares(cs []*Compare) string {
 	var compare []string
-	for _, c := range as.Request.Compare {
+	for _, c := range cs {
 		switch cv := c.TargetUnion.(type) {
 		case *Compare_Value:
 			compare = append(compare, newLoggableValueCompare(c, cv).String())
@@ -93,19 +101,15 @@ func (as *txnRequestStringer) String() string {
 			compare = append(compare, c.String())
 		}
 	}
-	var success []string
-	for _, s := range as.Request.Success {
-		success = append(success, newLoggableRequestOp(s).String())
-	}
-	var failure []string
-	for _, f := range as.Request.Failure {
-		failure = append(failure, newLoggableRequestOp(f).String())
+	return strings.Join(compare, " ")
+}
+
+func loggableRequestOps(ops []*RequestOp) string {
+	var ret []string
+	for _, op := range ops {
+		ret = append(ret, newLoggableRequestOp(op).String())
 	}
-	return fmt.Sprintf("compare:<%s> success:<%s> failure:<%s>",
-		strings.Join(compare, " "),
-		strings.Join(success, " "),
-		strings.Join(failure, " "),
-	)
+	return strings.Join(ret, " ")
 }
 
 // requestOpStringer implements a custom proto String to replace value bytes fields with value
@@ -124,6 +128,15 @@ func (as *requestOpStringer) String() string {
 		return fmt.Sprintf("request_put:<%s>", NewLoggablePutRequest(op.RequestPut).String())
 	case *RequestOp_RequestTxn:
 		return fmt.Sprintf("request_txn:<%s>", NewLoggableTxnRequest(op.RequestTxn).String())
+	case *RequestOp_RequestAppend:
+		return fmt.Sprintf("request_append:<%s>", newLoggableAppendRequest(op.RequestAppend).String())
+	case *RequestOp_RequestForEach:
+		r := op.RequestForEach
+		return fmt.Sprintf("request_for_each:<%s compare:<%s> ops:<%s>>",
+			(&ForEachRequest{Key: r.Key, RangeEnd: r.RangeEnd, MaxKeys: r.MaxKeys}).String(),
+			loggableCompares(r.Compare),
+			loggableRequestOps(r.Ops),
+		)
 	default:
 		// nothing to redact
 	}
@@ -181,3 +194,25 @@ func NewLoggablePutRequest(request *PutRequest) proto.Message {
 func (m *loggablePutRequest) Reset()         { *m = loggablePutRequest{} }
 func (m *loggablePutRequest) String() string { return proto.CompactTextString(m) }
 func (*loggablePutRequest) ProtoMessage()    {}
+
+// loggableAppendRequest implements proto.Message, a custom proto String to replace value bytes
+// field with a value size field.
+type loggableAppendRequest struct {
+	Key       []byte `protobuf:"bytes,1,opt,name=key,proto3"`
+	ValueSize int64  `protobuf:"varint,2,opt,name=value_size,proto3"`
+	MaxSize   int64  `protobuf:"varint,3,opt,name=max_size,proto3"`
+	PrevKv    bool   `protobuf:"varint,4,opt,name=prev_kv,proto3"`
+}
+
+func newLoggableAppendRequest(request *AppendRequest) proto.Message {
+	return &loggableAppendRequest{
+		request.Key,
+		int64(len(request.Value)),
+		request.MaxSize,
+		request.PrevKv,
+	}
+}
+
+func (m *loggableAppendRequest) Reset()         { *m = loggableAppendRequest{} }
+func (m *loggableAppendRequest) String() string { return proto.CompactTextString(m) }
+func (*loggableAppendRequest) ProtoMessage()    {}
@@ -28,3 +28,21 @@ import (
 func TestInvalidGoTypeIntPanic(t *testing.T) {
 	assert.Empty(t, pb.NewLoggablePutRequest(&pb.PutRequest{}).String())
 }
+
+func TestLoggableTxnRequestRedactsValues(t *testing.T) {
+	put := &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("k"), Value: []byte("secret")}}}
+	txn := &pb.TxnRequest{
+		Compare: []*pb.Compare{{Key: []byte("k"), Target: pb.Compare_VALUE, TargetUnion: &pb.Compare_Value{Value: []byte("secret")}}},
+		Success: []*pb.RequestOp{
+			put,
+			{Request: &pb.RequestOp_RequestAppend{RequestAppend: &pb.AppendRequest{Key: []byte("k"), Value: []byte("secret")}}},
+			{Request: &pb.RequestOp_RequestForEach{RequestForEach: &pb.ForEachRequest{Key: []byte("a"), RangeEnd: []byte("b"), MaxKeys: 1, Ops: []*pb.RequestOp{put}}}},
+		},
+		Failure: []*pb.RequestOp{{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{Success: []*pb.RequestOp{put}}}}},
+	}
+	s := pb.NewLoggableTxnRequest(txn).String()
+	assert.NotContains(t, s, "secret")
+	assert.Contains(t, s, "request_append:<")
+	assert.Contains(t, s, "request_for_each:<")
+	assert.Contains(t, s, "value_size:6")
+}
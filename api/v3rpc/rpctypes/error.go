@@ -25,19 +25,48 @@ var (
 	ErrGRPCKeyNotFound             = status.Error(codes.InvalidArgument, "etcdserver: key not found")
 	ErrGRPCValueProvided           = status.Error(codes.InvalidArgument, "etcdserver: value is provided")
 	ErrGRPCLeaseProvided           = status.Error(codes.InvalidArgument, "etcdserver: lease is provided")
+	ErrGRPCSequenceWithIgnore      = status.Error(codes.InvalidArgument, "etcdserver: sequence_suffix cannot be combined with ignore_value or ignore_lease")
 	ErrGRPCTooManyOps              = status.Error(codes.InvalidArgument, "etcdserver: too many operations in txn request")
 	ErrGRPCDuplicateKey            = status.Error(codes.InvalidArgument, "etcdserver: duplicate key given in txn request")
 	ErrGRPCInvalidClientAPIVersion = status.Error(codes.InvalidArgument, "etcdserver: invalid client api version")
 	ErrGRPCInvalidSortOption       = status.Error(codes.InvalidArgument, "etcdserver: invalid sort option")
+	ErrGRPCInvalidDeleteLimit      = status.Error(codes.InvalidArgument, "etcdserver: invalid delete limit")
+	ErrGRPCTooManyDeletions        = status.Error(codes.FailedPrecondition, "etcdserver: too many deletions")
+	ErrGRPCValueTooLarge           = status.Error(codes.FailedPrecondition, "etcdserver: appended value exceeds max size")
+	ErrGRPCForEachTooManyKeys      = status.Error(codes.FailedPrecondition, "etcdserver: foreach range holds more keys than max_keys")
+	ErrGRPCInvalidForEach          = status.Error(codes.InvalidArgument, "etcdserver: invalid foreach operation in txn request")
+	ErrGRPCInvalidSemver           = status.Error(codes.InvalidArgument, "etcdserver: invalid semantic version in txn compare")
 	ErrGRPCCompacted               = status.Error(codes.OutOfRange, "etcdserver: mvcc: required revision has been compacted")
 	ErrGRPCFutureRev               = status.Error(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision")
 	ErrGRPCNoSpace                 = status.Error(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded")
+	ErrGRPCWALNoSpace              = status.Error(codes.ResourceExhausted, "etcdserver: wal: not enough free disk space")
+	ErrGRPCThresholdExceeded       = status.Error(codes.ResourceExhausted, "etcdserver: operation blocked by an alarm threshold")
 
 	ErrGRPCLeaseNotFound    = status.Error(codes.NotFound, "etcdserver: requested lease not found")
 	ErrGRPCLeaseExist       = status.Error(codes.FailedPrecondition, "etcdserver: lease already exists")
 	ErrGRPCLeaseTTLTooLarge = status.Error(codes.OutOfRange, "etcdserver: too large lease TTL")
 
-	ErrGRPCWatchCanceled = status.Error(codes.Canceled, "etcdserver: watch canceled")
+	ErrGRPCCompactionHoldNotFound  = status.Error(codes.NotFound, "etcdserver: compaction hold not found")
+	ErrGRPCCompactionHoldsDisabled = status.Error(codes.FailedPrecondition, "etcdserver: compaction holds are disabled")
+
+	ErrGRPCCounterOverflow = status.Error(codes.OutOfRange, "etcdserver: counter overflow")
+
+	ErrGRPCJobNotFound   = status.Error(codes.NotFound, "etcdserver: job not found")
+	ErrGRPCJobNotRunning = status.Error(codes.FailedPrecondition, "etcdserver: job is not running")
+
+	ErrGRPCInvalidClusterConfig = status.Error(codes.InvalidArgument, "etcdserver: invalid cluster config")
+	ErrGRPCProtectedPrefix      = status.Error(codes.PermissionDenied, "etcdserver: write to protected prefix requires the lease of an allowed election candidate")
+	ErrGRPCPrefixFenced         = status.Error(codes.Unavailable, "etcdserver: writes to prefix are fenced")
+
+	ErrGRPCInvalidSnapshotOffset = status.Error(codes.InvalidArgument, "etcdserver: invalid snapshot offset")
+	ErrGRPCSnapshotNotFound      = status.Error(codes.NotFound, "etcdserver: snapshot to resume not found")
+	ErrGRPCSnapshotMismatch      = status.Error(codes.FailedPrecondition, "etcdserver: snapshot digest mismatch at offset")
+	ErrGRPCSnapshotNoRevision    = status.Error(codes.InvalidArgument, "etcdserver: snapshot revision is not provided")
+
+	ErrGRPCInvalidRequestLogging = status.Error(codes.InvalidArgument, "etcdserver: invalid request logging settings")
+
+	ErrGRPCWatchCanceled  = status.Error(codes.Canceled, "etcdserver: watch canceled")
+	ErrGRPCWatcherDropped = status.Error(codes.ResourceExhausted, "etcdserver: watcher dropped under memory pressure")
 
 	ErrGRPCMemberExist            = status.Error(codes.FailedPrecondition, "etcdserver: member ID already exist")
 	ErrGRPCPeerURLExist           = status.Error(codes.FailedPrecondition, "etcdserver: Peer URLs already exists")
@@ -47,6 +76,8 @@ var (
 	ErrGRPCMemberNotLearner       = status.Error(codes.FailedPrecondition, "etcdserver: can only promote a learner member")
 	ErrGRPCLearnerNotReady        = status.Error(codes.FailedPrecondition, "etcdserver: can only promote a learner member which is in sync with leader")
 	ErrGRPCTooManyLearners        = status.Error(codes.FailedPrecondition, "etcdserver: too many learner members in cluster")
+	ErrGRPCMemberNotVoter         = status.Error(codes.FailedPrecondition, "etcdserver: can only replace a voting member")
+	ErrGRPCMemberBadLabels        = status.Error(codes.InvalidArgument, "etcdserver: invalid member labels")
 	ErrGRPCClusterIDMismatch      = status.Error(codes.FailedPrecondition, "etcdserver: cluster ID mismatch")
 	//revive:disable:var-naming
 	// Deprecated: Please use ErrGRPCClusterIDMismatch.
@@ -55,6 +86,7 @@ var (
 
 	ErrGRPCRequestTooLarge        = status.Error(codes.InvalidArgument, "etcdserver: request is too large")
 	ErrGRPCRequestTooManyRequests = status.Error(codes.ResourceExhausted, "etcdserver: too many requests")
+	ErrGRPCTooBusy                = status.Error(codes.Unavailable, "etcdserver: too busy")
 
 	ErrGRPCRootUserNotExist     = status.Error(codes.FailedPrecondition, "etcdserver: root user does not exist")
 	ErrGRPCRootRoleNotExist     = status.Error(codes.FailedPrecondition, "etcdserver: root user does not have root role")
@@ -73,8 +105,15 @@ var (
 	ErrGRPCInvalidAuthToken     = status.Error(codes.Unauthenticated, "etcdserver: invalid auth token")
 	ErrGRPCInvalidAuthMgmt      = status.Error(codes.InvalidArgument, "etcdserver: invalid auth management")
 	ErrGRPCAuthOldRevision      = status.Error(codes.InvalidArgument, "etcdserver: revision of auth store is old")
+	ErrGRPCPasswordTooWeak      = status.Error(codes.InvalidArgument, "etcdserver: password does not satisfy the password policy")
+	ErrGRPCPasswordExpired      = status.Error(codes.FailedPrecondition, "etcdserver: password has expired")
+	ErrGRPCInvalidPasswordHash  = status.Error(codes.InvalidArgument, "etcdserver: invalid password hash")
+	ErrGRPCInvalidRPCMethod     = status.Error(codes.InvalidArgument, "etcdserver: invalid RPC method")
 
 	ErrGRPCNoLeader                   = status.Error(codes.Unavailable, "etcdserver: no leader")
+	ErrGRPCCatchingUp                 = status.Error(codes.Unavailable, "etcdserver: catching up with the leader")
+	ErrGRPCMemberDraining             = status.Error(codes.Unavailable, "etcdserver: member is draining")
+	ErrGRPCAppliedIndexBehind         = status.Error(codes.Unavailable, "etcdserver: applied index is behind the requested minimum")
 	ErrGRPCNotLeader                  = status.Error(codes.FailedPrecondition, "etcdserver: not leader")
 	ErrGRPCLeaderChanged              = status.Error(codes.Unavailable, "etcdserver: leader changed")
 	ErrGRPCNotCapable                 = status.Error(codes.FailedPrecondition, "etcdserver: not capable")
@@ -86,7 +125,13 @@ var (
 	ErrGRPCUnhealthy                  = status.Error(codes.Unavailable, "etcdserver: unhealthy cluster")
 	ErrGRPCCorrupt                    = status.Error(codes.DataLoss, "etcdserver: corrupt cluster")
 	ErrGRPCNotSupportedForLearner     = status.Error(codes.FailedPrecondition, "etcdserver: rpc not supported for learner")
+	ErrGRPCNotSupportedForStandby     = status.Error(codes.FailedPrecondition, "etcdserver: rpc not supported for standby")
 	ErrGRPCBadLeaderTransferee        = status.Error(codes.FailedPrecondition, "etcdserver: bad leader transferee")
+	ErrGRPCHotKeysDisabled            = status.Error(codes.FailedPrecondition, "etcdserver: hot keys tracking is disabled")
+	ErrGRPCNewerRequestFields         = status.Error(codes.FailedPrecondition, "etcdserver: request uses fields newer than the cluster version")
+	ErrGRPCDowngradeBlocked           = status.Error(codes.FailedPrecondition, "etcdserver: request uses features newer than the downgrade target version")
+	ErrGRPCNewerFieldsDisabled        = status.Error(codes.FailedPrecondition, "etcdserver: newer request fields detection is disabled")
+	ErrGRPCReseedLeader               = status.Error(codes.FailedPrecondition, "etcdserver: the leader cannot be reseeded")
 
 	ErrGRPCWrongDowngradeVersionFormat   = status.Error(codes.InvalidArgument, "etcdserver: wrong downgrade target version format")
 	ErrGRPCInvalidDowngradeTargetVersion = status.Error(codes.InvalidArgument, "etcdserver: invalid downgrade target version")
@@ -98,22 +143,52 @@ var (
 	ErrGRPCDeadlineExceeded = status.Error(codes.DeadlineExceeded, "etcdserver: context deadline exceeded")
 
 	errStringToError = map[string]error{
-		ErrorDesc(ErrGRPCEmptyKey):      ErrGRPCEmptyKey,
-		ErrorDesc(ErrGRPCKeyNotFound):   ErrGRPCKeyNotFound,
-		ErrorDesc(ErrGRPCValueProvided): ErrGRPCValueProvided,
-		ErrorDesc(ErrGRPCLeaseProvided): ErrGRPCLeaseProvided,
-
-		ErrorDesc(ErrGRPCTooManyOps):        ErrGRPCTooManyOps,
-		ErrorDesc(ErrGRPCDuplicateKey):      ErrGRPCDuplicateKey,
-		ErrorDesc(ErrGRPCInvalidSortOption): ErrGRPCInvalidSortOption,
-		ErrorDesc(ErrGRPCCompacted):         ErrGRPCCompacted,
-		ErrorDesc(ErrGRPCFutureRev):         ErrGRPCFutureRev,
-		ErrorDesc(ErrGRPCNoSpace):           ErrGRPCNoSpace,
+		ErrorDesc(ErrGRPCEmptyKey):           ErrGRPCEmptyKey,
+		ErrorDesc(ErrGRPCKeyNotFound):        ErrGRPCKeyNotFound,
+		ErrorDesc(ErrGRPCValueProvided):      ErrGRPCValueProvided,
+		ErrorDesc(ErrGRPCLeaseProvided):      ErrGRPCLeaseProvided,
+		ErrorDesc(ErrGRPCSequenceWithIgnore): ErrGRPCSequenceWithIgnore,
+
+		ErrorDesc(ErrGRPCTooManyOps):         ErrGRPCTooManyOps,
+		ErrorDesc(ErrGRPCDuplicateKey):       ErrGRPCDuplicateKey,
+		ErrorDesc(ErrGRPCInvalidSortOption):  ErrGRPCInvalidSortOption,
+		ErrorDesc(ErrGRPCInvalidDeleteLimit): ErrGRPCInvalidDeleteLimit,
+		ErrorDesc(ErrGRPCTooManyDeletions):   ErrGRPCTooManyDeletions,
+		ErrorDesc(ErrGRPCValueTooLarge):      ErrGRPCValueTooLarge,
+		ErrorDesc(ErrGRPCForEachTooManyKeys): ErrGRPCForEachTooManyKeys,
+		ErrorDesc(ErrGRPCInvalidForEach):     ErrGRPCInvalidForEach,
+		ErrorDesc(ErrGRPCInvalidSemver):      ErrGRPCInvalidSemver,
+		ErrorDesc(ErrGRPCCompacted):          ErrGRPCCompacted,
+		ErrorDesc(ErrGRPCFutureRev):          ErrGRPCFutureRev,
+		ErrorDesc(ErrGRPCNoSpace):            ErrGRPCNoSpace,
+		ErrorDesc(ErrGRPCWALNoSpace):         ErrGRPCWALNoSpace,
+		ErrorDesc(ErrGRPCThresholdExceeded):  ErrGRPCThresholdExceeded,
 
 		ErrorDesc(ErrGRPCLeaseNotFound):    ErrGRPCLeaseNotFound,
 		ErrorDesc(ErrGRPCLeaseExist):       ErrGRPCLeaseExist,
 		ErrorDesc(ErrGRPCLeaseTTLTooLarge): ErrGRPCLeaseTTLTooLarge,
 
+		ErrorDesc(ErrGRPCCompactionHoldNotFound):  ErrGRPCCompactionHoldNotFound,
+		ErrorDesc(ErrGRPCCompactionHoldsDisabled): ErrGRPCCompactionHoldsDisabled,
+
+		ErrorDesc(ErrGRPCCounterOverflow): ErrGRPCCounterOverflow,
+
+		ErrorDesc(ErrGRPCJobNotFound):   ErrGRPCJobNotFound,
+		ErrorDesc(ErrGRPCJobNotRunning): ErrGRPCJobNotRunning,
+
+		ErrorDesc(ErrGRPCInvalidClusterConfig): ErrGRPCInvalidClusterConfig,
+		ErrorDesc(ErrGRPCProtectedPrefix):      ErrGRPCProtectedPrefix,
+		ErrorDesc(ErrGRPCPrefixFenced):         ErrGRPCPrefixFenced,
+
+		ErrorDesc(ErrGRPCInvalidSnapshotOffset): ErrGRPCInvalidSnapshotOffset,
+		ErrorDesc(ErrGRPCSnapshotNotFound):      ErrGRPCSnapshotNotFound,
+		ErrorDesc(ErrGRPCSnapshotMismatch):      ErrGRPCSnapshotMismatch,
+		ErrorDesc(ErrGRPCSnapshotNoRevision):    ErrGRPCSnapshotNoRevision,
+
+		ErrorDesc(ErrGRPCInvalidRequestLogging): ErrGRPCInvalidRequestLogging,
+
+		ErrorDesc(ErrGRPCWatcherDropped): ErrGRPCWatcherDropped,
+
 		ErrorDesc(ErrGRPCMemberExist):            ErrGRPCMemberExist,
 		ErrorDesc(ErrGRPCPeerURLExist):           ErrGRPCPeerURLExist,
 		ErrorDesc(ErrGRPCMemberNotEnoughStarted): ErrGRPCMemberNotEnoughStarted,
@@ -122,10 +197,13 @@ var (
 		ErrorDesc(ErrGRPCMemberNotLearner):       ErrGRPCMemberNotLearner,
 		ErrorDesc(ErrGRPCLearnerNotReady):        ErrGRPCLearnerNotReady,
 		ErrorDesc(ErrGRPCTooManyLearners):        ErrGRPCTooManyLearners,
+		ErrorDesc(ErrGRPCMemberNotVoter):         ErrGRPCMemberNotVoter,
+		ErrorDesc(ErrGRPCMemberBadLabels):        ErrGRPCMemberBadLabels,
 		ErrorDesc(ErrGRPCClusterIDMismatch):      ErrGRPCClusterIDMismatch,
 
 		ErrorDesc(ErrGRPCRequestTooLarge):        ErrGRPCRequestTooLarge,
 		ErrorDesc(ErrGRPCRequestTooManyRequests): ErrGRPCRequestTooManyRequests,
+		ErrorDesc(ErrGRPCTooBusy):                ErrGRPCTooBusy,
 
 		ErrorDesc(ErrGRPCRootUserNotExist):     ErrGRPCRootUserNotExist,
 		ErrorDesc(ErrGRPCRootRoleNotExist):     ErrGRPCRootRoleNotExist,
@@ -143,8 +221,15 @@ var (
 		ErrorDesc(ErrGRPCInvalidAuthToken):     ErrGRPCInvalidAuthToken,
 		ErrorDesc(ErrGRPCInvalidAuthMgmt):      ErrGRPCInvalidAuthMgmt,
 		ErrorDesc(ErrGRPCAuthOldRevision):      ErrGRPCAuthOldRevision,
+		ErrorDesc(ErrGRPCPasswordTooWeak):      ErrGRPCPasswordTooWeak,
+		ErrorDesc(ErrGRPCPasswordExpired):      ErrGRPCPasswordExpired,
+		ErrorDesc(ErrGRPCInvalidPasswordHash):  ErrGRPCInvalidPasswordHash,
+		ErrorDesc(ErrGRPCInvalidRPCMethod):     ErrGRPCInvalidRPCMethod,
 
 		ErrorDesc(ErrGRPCNoLeader):                   ErrGRPCNoLeader,
+		ErrorDesc(ErrGRPCCatchingUp):                 ErrGRPCCatchingUp,
+		ErrorDesc(ErrGRPCMemberDraining):             ErrGRPCMemberDraining,
+		ErrorDesc(ErrGRPCAppliedIndexBehind):         ErrGRPCAppliedIndexBehind,
 		ErrorDesc(ErrGRPCNotLeader):                  ErrGRPCNotLeader,
 		ErrorDesc(ErrGRPCLeaderChanged):              ErrGRPCLeaderChanged,
 		ErrorDesc(ErrGRPCNotCapable):                 ErrGRPCNotCapable,
@@ -155,7 +240,13 @@ var (
 		ErrorDesc(ErrGRPCUnhealthy):                  ErrGRPCUnhealthy,
 		ErrorDesc(ErrGRPCCorrupt):                    ErrGRPCCorrupt,
 		ErrorDesc(ErrGRPCNotSupportedForLearner):     ErrGRPCNotSupportedForLearner,
+		ErrorDesc(ErrGRPCNotSupportedForStandby):     ErrGRPCNotSupportedForStandby,
 		ErrorDesc(ErrGRPCBadLeaderTransferee):        ErrGRPCBadLeaderTransferee,
+		ErrorDesc(ErrGRPCHotKeysDisabled):            ErrGRPCHotKeysDisabled,
+		ErrorDesc(ErrGRPCNewerRequestFields):         ErrGRPCNewerRequestFields,
+		ErrorDesc(ErrGRPCDowngradeBlocked):           ErrGRPCDowngradeBlocked,
+		ErrorDesc(ErrGRPCNewerFieldsDisabled):        ErrGRPCNewerFieldsDisabled,
+		ErrorDesc(ErrGRPCReseedLeader):               ErrGRPCReseedLeader,
 
 		ErrorDesc(ErrGRPCClusterVersionUnavailable):     ErrGRPCClusterVersionUnavailable,
 		ErrorDesc(ErrGRPCWrongDowngradeVersionFormat):   ErrGRPCWrongDowngradeVersionFormat,
@@ -167,21 +258,51 @@ var (
 
 // client-side error
 var (
-	ErrEmptyKey          = Error(ErrGRPCEmptyKey)
-	ErrKeyNotFound       = Error(ErrGRPCKeyNotFound)
-	ErrValueProvided     = Error(ErrGRPCValueProvided)
-	ErrLeaseProvided     = Error(ErrGRPCLeaseProvided)
-	ErrTooManyOps        = Error(ErrGRPCTooManyOps)
-	ErrDuplicateKey      = Error(ErrGRPCDuplicateKey)
-	ErrInvalidSortOption = Error(ErrGRPCInvalidSortOption)
-	ErrCompacted         = Error(ErrGRPCCompacted)
-	ErrFutureRev         = Error(ErrGRPCFutureRev)
-	ErrNoSpace           = Error(ErrGRPCNoSpace)
+	ErrEmptyKey           = Error(ErrGRPCEmptyKey)
+	ErrKeyNotFound        = Error(ErrGRPCKeyNotFound)
+	ErrValueProvided      = Error(ErrGRPCValueProvided)
+	ErrLeaseProvided      = Error(ErrGRPCLeaseProvided)
+	ErrSequenceWithIgnore = Error(ErrGRPCSequenceWithIgnore)
+	ErrTooManyOps         = Error(ErrGRPCTooManyOps)
+	ErrDuplicateKey       = Error(ErrGRPCDuplicateKey)
+	ErrInvalidSortOption  = Error(ErrGRPCInvalidSortOption)
+	ErrInvalidDeleteLimit = Error(ErrGRPCInvalidDeleteLimit)
+	ErrTooManyDeletions   = Error(ErrGRPCTooManyDeletions)
+	ErrValueTooLarge      = Error(ErrGRPCValueTooLarge)
+	ErrForEachTooManyKeys = Error(ErrGRPCForEachTooManyKeys)
+	ErrInvalidForEach     = Error(ErrGRPCInvalidForEach)
+	ErrInvalidSemver      = Error(ErrGRPCInvalidSemver)
+	ErrCompacted          = Error(ErrGRPCCompacted)
+	ErrFutureRev          = Error(ErrGRPCFutureRev)
+	ErrNoSpace            = Error(ErrGRPCNoSpace)
+	ErrWALNoSpace         = Error(ErrGRPCWALNoSpace)
+	ErrThresholdExceeded  = Error(ErrGRPCThresholdExceeded)
 
 	ErrLeaseNotFound    = Error(ErrGRPCLeaseNotFound)
 	ErrLeaseExist       = Error(ErrGRPCLeaseExist)
 	ErrLeaseTTLTooLarge = Error(ErrGRPCLeaseTTLTooLarge)
 
+	ErrCompactionHoldNotFound  = Error(ErrGRPCCompactionHoldNotFound)
+	ErrCompactionHoldsDisabled = Error(ErrGRPCCompactionHoldsDisabled)
+
+	ErrCounterOverflow = Error(ErrGRPCCounterOverflow)
+
+	ErrJobNotFound   = Error(ErrGRPCJobNotFound)
+	ErrJobNotRunning = Error(ErrGRPCJobNotRunning)
+
+	ErrInvalidClusterConfig = Error(ErrGRPCInvalidClusterConfig)
+	ErrProtectedPrefix      = Error(ErrGRPCProtectedPrefix)
+	ErrPrefixFenced         = Error(ErrGRPCPrefixFenced)
+
+	ErrInvalidSnapshotOffset = Error(ErrGRPCInvalidSnapshotOffset)
+	ErrSnapshotNotFound      = Error(ErrGRPCSnapshotNotFound)
+	ErrSnapshotMismatch      = Error(ErrGRPCSnapshotMismatch)
+	ErrSnapshotNoRevision    = Error(ErrGRPCSnapshotNoRevision)
+
+	ErrInvalidRequestLogging = Error(ErrGRPCInvalidRequestLogging)
+
+	ErrWatcherDropped = Error(ErrGRPCWatcherDropped)
+
 	ErrMemberExist            = Error(ErrGRPCMemberExist)
 	ErrPeerURLExist           = Error(ErrGRPCPeerURLExist)
 	ErrMemberNotEnoughStarted = Error(ErrGRPCMemberNotEnoughStarted)
@@ -190,9 +311,12 @@ var (
 	ErrMemberNotLearner       = Error(ErrGRPCMemberNotLearner)
 	ErrMemberLearnerNotReady  = Error(ErrGRPCLearnerNotReady)
 	ErrTooManyLearners        = Error(ErrGRPCTooManyLearners)
+	ErrMemberNotVoter         = Error(ErrGRPCMemberNotVoter)
+	ErrMemberBadLabels        = Error(ErrGRPCMemberBadLabels)
 
 	ErrRequestTooLarge = Error(ErrGRPCRequestTooLarge)
 	ErrTooManyRequests = Error(ErrGRPCRequestTooManyRequests)
+	ErrTooBusy         = Error(ErrGRPCTooBusy)
 
 	ErrRootUserNotExist     = Error(ErrGRPCRootUserNotExist)
 	ErrRootRoleNotExist     = Error(ErrGRPCRootRoleNotExist)
@@ -210,6 +334,10 @@ var (
 	ErrInvalidAuthToken     = Error(ErrGRPCInvalidAuthToken)
 	ErrAuthOldRevision      = Error(ErrGRPCAuthOldRevision)
 	ErrInvalidAuthMgmt      = Error(ErrGRPCInvalidAuthMgmt)
+	ErrPasswordTooWeak      = Error(ErrGRPCPasswordTooWeak)
+	ErrPasswordExpired      = Error(ErrGRPCPasswordExpired)
+	ErrInvalidPasswordHash  = Error(ErrGRPCInvalidPasswordHash)
+	ErrInvalidRPCMethod     = Error(ErrGRPCInvalidRPCMethod)
 	ErrClusterIDMismatch    = Error(ErrGRPCClusterIDMismatch)
 	//revive:disable:var-naming
 	// Deprecated: Please use ErrClusterIDMismatch.
@@ -217,6 +345,9 @@ var (
 	//revive:enable:var-naming
 
 	ErrNoLeader                   = Error(ErrGRPCNoLeader)
+	ErrCatchingUp                 = Error(ErrGRPCCatchingUp)
+	ErrMemberDraining             = Error(ErrGRPCMemberDraining)
+	ErrAppliedIndexBehind         = Error(ErrGRPCAppliedIndexBehind)
 	ErrNotLeader                  = Error(ErrGRPCNotLeader)
 	ErrLeaderChanged              = Error(ErrGRPCLeaderChanged)
 	ErrNotCapable                 = Error(ErrGRPCNotCapable)
@@ -228,6 +359,11 @@ var (
 	ErrUnhealthy                  = Error(ErrGRPCUnhealthy)
 	ErrCorrupt                    = Error(ErrGRPCCorrupt)
 	ErrBadLeaderTransferee        = Error(ErrGRPCBadLeaderTransferee)
+	ErrHotKeysDisabled            = Error(ErrGRPCHotKeysDisabled)
+	ErrNewerRequestFields         = Error(ErrGRPCNewerRequestFields)
+	ErrDowngradeBlocked           = Error(ErrGRPCDowngradeBlocked)
+	ErrNewerFieldsDisabled        = Error(ErrGRPCNewerFieldsDisabled)
+	ErrReseedLeader               = Error(ErrGRPCReseedLeader)
 
 	ErrClusterVersionUnavailable     = Error(ErrGRPCClusterVersionUnavailable)
 	ErrWrongDowngradeVersionFormat   = Error(ErrGRPCWrongDowngradeVersionFormat)
@@ -19,4 +19,24 @@ var (
 	MetadataHasLeader        = "true"
 
 	MetadataClientAPIVersionKey = "client-api-version"
+
+	// MetadataPriorityKey marks the priority of a request. Requests with
+	// MetadataPriorityLow are rejected with ErrGRPCTooBusy instead of being
+	// queued while the server's apply backlog is over its threshold.
+	MetadataPriorityKey = "priority"
+	MetadataPriorityLow = "low"
+
+	// MetadataRequestTimingsKey requests the breakdown of the time the server
+	// spent serving a request in the timings of its response header.
+	MetadataRequestTimingsKey = "request-timings"
+	MetadataRequestTimings    = "true"
+
+	// MetadataElectionLeaseKey carries the lease, in decimal, that proves a
+	// request comes from a candidate of the election guarding a protected
+	// prefix.
+	MetadataElectionLeaseKey = "election-lease"
+
+	// MetadataFenceTokenKey carries the token that lets a write through the
+	// fences of prefixes.
+	MetadataFenceTokenKey = "fence-token"
 )
@@ -0,0 +1,50 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpctypes
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// NewGRPCTooBusyError returns ErrGRPCTooBusy with the backoff suggested by the
+// server attached as a RetryInfo detail.
+func NewGRPCTooBusyError(backoff time.Duration) error {
+	st, err := status.Convert(ErrGRPCTooBusy).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(backoff),
+	})
+	if err != nil {
+		return ErrGRPCTooBusy
+	}
+	return st.Err()
+}
+
+// RetryDelay returns the backoff suggested by the server in the RetryInfo
+// detail of a gRPC error. Errors converted by Error no longer carry details.
+func RetryDelay(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	for _, d := range st.Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok && ri.GetRetryDelay() != nil {
+			return ri.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
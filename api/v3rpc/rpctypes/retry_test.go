@@ -0,0 +1,34 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpctypes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTooBusyRetryDelay(t *testing.T) {
+	err := NewGRPCTooBusyError(250 * time.Millisecond)
+	d, ok := RetryDelay(err)
+	if !ok || d != 250*time.Millisecond {
+		t.Fatalf("expected retry delay 250ms, got %v (found %v)", d, ok)
+	}
+	if Error(err) != ErrTooBusy {
+		t.Fatalf("expected %v, got %v", ErrTooBusy, Error(err))
+	}
+	if _, ok = RetryDelay(ErrGRPCTooBusy); ok {
+		t.Fatal("expected no retry delay without details")
+	}
+}
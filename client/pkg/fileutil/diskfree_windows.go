@@ -0,0 +1,33 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package fileutil
+
+import "golang.org/x/sys/windows"
+
+// DiskFree returns the number of bytes available to the caller on the
+// volume holding path.
+func DiskFree(path string) (uint64, error) {
+	pathp, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	var free uint64
+	if err = windows.GetDiskFreeSpaceEx(pathp, &free, nil, nil); err != nil {
+		return 0, err
+	}
+	return free, nil
+}
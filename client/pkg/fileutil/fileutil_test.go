@@ -184,3 +184,12 @@ func TestTouchDirAll(t *testing.T) {
 
 	assert.NoError(t, TouchDirAll(zaptest.NewLogger(t), tmpdir))
 }
+
+func TestDiskFree(t *testing.T) {
+	free, err := DiskFree(t.TempDir())
+	require.NoError(t, err)
+	require.Positive(t, free)
+
+	_, err = DiskFree(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
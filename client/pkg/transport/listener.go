@@ -160,6 +160,19 @@ type TLSInfo struct {
 	// connection will be closed immediately afterwards.
 	HandshakeFailure func(*tls.Conn, error)
 
+	// OCSPVerify enables checking the revocation status of peer certificates
+	// with OCSP, using the response stapled by the peer if any, or else
+	// querying the responder named in the certificate. It is one of
+	// OCSPVerifySoftFail or OCSPVerifyHardFail, or empty to disable it.
+	OCSPVerify string
+	// OCSPStapling staples an OCSP response to the served certificate. The
+	// response is fetched from the responder named in the certificate, which
+	// must be followed by its issuer in CertFile.
+	OCSPStapling bool
+	// CertificateRevoked is optionally called when a peer certificate is
+	// rejected because it is revoked.
+	CertificateRevoked func(cert *x509.Certificate, source RevocationSource)
+
 	// CipherSuites is a list of supported cipher suites.
 	// If empty, Go auto-populates it by default.
 	// Note that cipher suites are prioritized in the given order.
@@ -397,6 +410,10 @@ func (info TLSInfo) baseConfig() (*tls.Config, error) {
 		minVersion = tls.VersionTLS12
 	}
 
+	if err = validateOCSPVerify(info.OCSPVerify); err != nil {
+		return nil, err
+	}
+
 	cfg := &tls.Config{
 		MinVersion: minVersion,
 		MaxVersion: info.MaxVersion,
@@ -488,6 +505,9 @@ func (info TLSInfo) baseConfig() (*tls.Config, error) {
 				zap.Error(err),
 			)
 		}
+		if err == nil && info.OCSPStapling {
+			defaultOCSPStapler.staple(info.Logger, cert)
+		}
 		return cert, err
 	}
 	cfg.GetClientCertificate = func(unused *tls.CertificateRequestInfo) (cert *tls.Certificate, err error) {
@@ -552,6 +572,10 @@ func (info TLSInfo) ServerConfig() (*tls.Config, error) {
 		cfg.ClientCAs = cp
 	}
 
+	// reject revoked client certificates during the handshake, so that it
+	// applies to every server using this config
+	cfg.VerifyConnection = info.verifyRevocation(true)
+
 	// "h2" NextProtos is necessary for enabling HTTP2 for go's HTTP server
 	cfg.NextProtos = []string{"h2"}
 
@@ -585,6 +609,11 @@ func (info TLSInfo) ClientConfig() (*tls.Config, error) {
 		cfg.InsecureSkipVerify = true
 	}
 
+	if err = validateOCSPVerify(info.OCSPVerify); err != nil {
+		return nil, err
+	}
+	cfg.VerifyConnection = info.verifyRevocation(false)
+
 	if info.EmptyCN {
 		hasNonEmptyCN := false
 		cn := ""
@@ -20,14 +20,13 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
-	"os"
 	"strings"
 	"sync"
 )
 
 // tlsListener overrides a TLS listener so it will reject client
-// certificates with insufficient SAN credentials or CRL revoked
-// certificates.
+// certificates with insufficient SAN credentials. Revoked certificates
+// are rejected during the handshake by TLSInfo.ServerConfig.
 type tlsListener struct {
 	net.Listener
 	connc            chan net.Conn
@@ -39,7 +38,7 @@ type tlsListener struct {
 
 type tlsCheckFunc func(context.Context, *tls.Conn) error
 
-// NewTLSListener handshakes TLS connections and performs optional CRL checking.
+// NewTLSListener handshakes TLS connections and performs optional revocation checking.
 func NewTLSListener(l net.Listener, tlsinfo *TLSInfo) (net.Listener, error) {
 	check := func(context.Context, *tls.Conn) error { return nil }
 	return newTLSListener(l, tlsinfo, check)
@@ -60,20 +59,6 @@ func newTLSListener(l net.Listener, tlsinfo *TLSInfo, check tlsCheckFunc) (net.L
 		hf = func(*tls.Conn, error) {}
 	}
 
-	if len(tlsinfo.CRLFile) > 0 {
-		prevCheck := check
-		check = func(ctx context.Context, tlsConn *tls.Conn) error {
-			if err := prevCheck(ctx, tlsConn); err != nil {
-				return err
-			}
-			st := tlsConn.ConnectionState()
-			if certs := st.PeerCertificates; len(certs) > 0 {
-				return checkCRL(tlsinfo.CRLFile, certs)
-			}
-			return nil
-		}
-	}
-
 	tlsl := &tlsListener{
 		Listener:         tls.NewListener(l, tlscfg),
 		connc:            make(chan net.Conn),
@@ -166,29 +151,6 @@ func (l *tlsListener) acceptLoop() {
 	}
 }
 
-func checkCRL(crlPath string, cert []*x509.Certificate) error {
-	// TODO: cache
-	crlBytes, err := os.ReadFile(crlPath)
-	if err != nil {
-		return err
-	}
-	certList, err := x509.ParseRevocationList(crlBytes)
-	if err != nil {
-		return err
-	}
-	revokedSerials := make(map[string]struct{})
-	for _, rc := range certList.RevokedCertificateEntries {
-		revokedSerials[string(rc.SerialNumber.Bytes())] = struct{}{}
-	}
-	for _, c := range cert {
-		serial := string(c.SerialNumber.Bytes())
-		if _, ok := revokedSerials[serial]; ok {
-			return fmt.Errorf("transport: certificate serial %x revoked", serial)
-		}
-	}
-	return nil
-}
-
 func checkCertSAN(ctx context.Context, cert *x509.Certificate, remoteAddr string) error {
 	if len(cert.IPAddresses) == 0 && len(cert.DNSNames) == 0 {
 		return nil
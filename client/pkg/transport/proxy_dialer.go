@@ -0,0 +1,301 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProxyFunc returns the URL of the proxy to reach the given host:port
+// address through, or nil to reach it directly.
+type ProxyFunc func(addr string) (*url.URL, error)
+
+// ProxyFromEnvironment returns the proxy set by the HTTPS_PROXY environment
+// variable, or its lowercase version, unless the address is excluded by
+// NO_PROXY. Like http.ProxyFromEnvironment, it never proxies loopback
+// addresses.
+func ProxyFromEnvironment(addr string) (*url.URL, error) {
+	return http.ProxyFromEnvironment(&http.Request{URL: &url.URL{Scheme: "https", Host: addr}})
+}
+
+// ProxyURL returns a ProxyFunc reaching every address through the given
+// proxy.
+func ProxyURL(u *url.URL) ProxyFunc {
+	return func(string) (*url.URL, error) { return u, nil }
+}
+
+// ParseProxyURL parses the URL of a proxy. The supported schemes are http and
+// https for HTTP CONNECT proxies, and socks5 and socks5h for SOCKS5 proxies.
+// Credentials may be given as the user info of the URL.
+func ParseProxyURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", s)
+	}
+	return u, nil
+}
+
+// ProxyDialer dials addresses through HTTP CONNECT or SOCKS5 proxies. The
+// proxy only relays bytes, so TLS connections to the address are
+// established over the returned connection as if it was dialed directly.
+type ProxyDialer struct {
+	// Dialer dials the proxies, and the addresses reached directly.
+	Dialer net.Dialer
+	// Proxy selects the proxy of an address. Addresses are dialed directly
+	// if Proxy is nil.
+	Proxy ProxyFunc
+	// TLSConfig is the configuration of the connections to https proxies.
+	// If nil, the default configuration is used.
+	TLSConfig *tls.Config
+}
+
+// DialContext connects to the address on the named network through the
+// proxy selected for it. Only tcp networks are proxied.
+func (d *ProxyDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if d.Proxy == nil || !strings.HasPrefix(network, "tcp") {
+		return d.Dialer.DialContext(ctx, network, addr)
+	}
+	proxy, err := d.Proxy(addr)
+	if err != nil {
+		return nil, err
+	}
+	if proxy == nil {
+		return d.Dialer.DialContext(ctx, network, addr)
+	}
+
+	conn, err := d.dialProxy(ctx, proxy)
+	if err != nil {
+		return nil, err
+	}
+	// the handshake is bounded by the context, like the dial
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	tunnel := conn
+	switch proxy.Scheme {
+	case "socks5", "socks5h":
+		err = socks5Connect(conn, proxy.User, addr)
+	default:
+		tunnel, err = httpConnect(conn, proxy, addr)
+	}
+	if !stop() {
+		err = errors.Join(err, ctx.Err())
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s: %w", proxy.Redacted(), err)
+	}
+	conn.SetDeadline(time.Time{})
+	return tunnel, nil
+}
+
+// dialProxy connects to the proxy, over TLS for https proxies.
+func (d *ProxyDialer) dialProxy(ctx context.Context, proxy *url.URL) (net.Conn, error) {
+	host := proxy.Host
+	if proxy.Port() == "" {
+		port := "1080"
+		switch proxy.Scheme {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		}
+		host = net.JoinHostPort(proxy.Hostname(), port)
+	}
+	if proxy.Scheme != "https" {
+		return d.Dialer.DialContext(ctx, "tcp", host)
+	}
+	cfg := &tls.Config{}
+	if d.TLSConfig != nil {
+		cfg = d.TLSConfig.Clone()
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = proxy.Hostname()
+	}
+	td := &tls.Dialer{NetDialer: &d.Dialer, Config: cfg}
+	return td.DialContext(ctx, "tcp", host)
+}
+
+// httpConnect asks an HTTP proxy to tunnel conn to addr.
+func httpConnect(conn net.Conn, proxy *url.URL, addr string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if u := proxy.User; u != nil {
+		req.SetBasicAuth(u.Username(), passwordOf(u))
+		req.Header.Set("Proxy-Authorization", req.Header.Get("Authorization"))
+		req.Header.Del("Authorization")
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("CONNECT %s: %s", addr, resp.Status)
+	}
+	if br.Buffered() > 0 {
+		// the server already sent bytes through the tunnel
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) { return c.r.Read(b) }
+
+const (
+	socks5Version        = 5
+	socks5NoAuth         = 0
+	socks5UserPass       = 2
+	socks5CmdConnect     = 1
+	socks5AddrIPv4       = 1
+	socks5AddrDomain     = 3
+	socks5AddrIPv6       = 4
+	socks5UserPassVer    = 1
+	socks5ReplySucceeded = 0
+)
+
+// socks5Connect asks a SOCKS5 proxy to tunnel conn to addr, as specified by
+// RFC 1928, authenticating with the user name and password of RFC 1929 if
+// given. Host names are resolved by the proxy.
+func socks5Connect(conn net.Conn, user *url.Userinfo, addr string) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port %q", portStr)
+	}
+
+	method := byte(socks5NoAuth)
+	if user != nil {
+		method = socks5UserPass
+	}
+	if _, err = conn.Write([]byte{socks5Version, 1, method}); err != nil {
+		return err
+	}
+	buf := make([]byte, 2)
+	if _, err = io.ReadFull(conn, buf); err != nil {
+		return err
+	}
+	if buf[0] != socks5Version {
+		return fmt.Errorf("unexpected SOCKS version %d", buf[0])
+	}
+	if buf[1] != method {
+		return errors.New("no acceptable SOCKS authentication method")
+	}
+	if method == socks5UserPass {
+		name, password := user.Username(), passwordOf(user)
+		if len(name) > 255 || len(password) > 255 {
+			return errors.New("SOCKS user name or password too long")
+		}
+		req := []byte{socks5UserPassVer, byte(len(name))}
+		req = append(req, name...)
+		req = append(req, byte(len(password)))
+		req = append(req, password...)
+		if _, err = conn.Write(req); err != nil {
+			return err
+		}
+		if _, err = io.ReadFull(conn, buf); err != nil {
+			return err
+		}
+		if buf[1] != 0 {
+			return errors.New("SOCKS authentication failed")
+		}
+	}
+
+	req := []byte{socks5Version, socks5CmdConnect, 0}
+	if ip := net.ParseIP(host); ip == nil {
+		if len(host) > 255 {
+			return fmt.Errorf("host name %q too long", host)
+		}
+		req = append(req, socks5AddrDomain, byte(len(host)))
+		req = append(req, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		req = append(req, socks5AddrIPv4)
+		req = append(req, ip4...)
+	} else {
+		req = append(req, socks5AddrIPv6)
+		req = append(req, ip...)
+	}
+	req = binary.BigEndian.AppendUint16(req, uint16(port))
+	if _, err = conn.Write(req); err != nil {
+		return err
+	}
+
+	// the reply holds the address bound by the proxy, which is discarded
+	reply := make([]byte, 4)
+	if _, err = io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[1] != socks5ReplySucceeded {
+		return fmt.Errorf("SOCKS CONNECT %s failed with reply %d", addr, reply[1])
+	}
+	var n int
+	switch reply[3] {
+	case socks5AddrIPv4:
+		n = net.IPv4len
+	case socks5AddrIPv6:
+		n = net.IPv6len
+	case socks5AddrDomain:
+		if _, err = io.ReadFull(conn, reply[:1]); err != nil {
+			return err
+		}
+		n = int(reply[0])
+	default:
+		return fmt.Errorf("unexpected SOCKS address type %d", reply[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, n+2))
+	return err
+}
+
+func passwordOf(u *url.Userinfo) string {
+	p, _ := u.Password()
+	return p
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyDialer(t *testing.T) {
+	target := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer target.Close()
+
+	httpProxy, httpTunnels := newConnectProxy(t)
+	socksProxy, socksTunnels := newSOCKS5Proxy(t)
+
+	tests := []struct {
+		proxy   string
+		tunnels *atomic.Int32
+		wantErr bool
+	}{
+		{proxy: "http://" + httpProxy, tunnels: httpTunnels},
+		{proxy: "http://user:secret@" + httpProxy, tunnels: httpTunnels},
+		{proxy: "http://user:wrong@" + httpProxy, wantErr: true},
+		{proxy: "socks5://" + socksProxy, tunnels: socksTunnels},
+		{proxy: "socks5h://user:secret@" + socksProxy, tunnels: socksTunnels},
+		{proxy: "socks5://user:wrong@" + socksProxy, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.proxy, func(t *testing.T) {
+			u, err := ParseProxyURL(tt.proxy)
+			require.NoError(t, err)
+			d := &ProxyDialer{Proxy: ProxyURL(u)}
+			tr := &http.Transport{
+				DialContext:     d.DialContext,
+				TLSClientConfig: target.Client().Transport.(*http.Transport).TLSClientConfig,
+			}
+			defer tr.CloseIdleConnections()
+
+			var before int32
+			if tt.tunnels != nil {
+				before = tt.tunnels.Load()
+			}
+			resp, err := (&http.Client{Transport: tr}).Get(target.URL)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "ok", string(body))
+			assert.Equal(t, before+1, tt.tunnels.Load(), "the TLS connection goes through the proxy")
+		})
+	}
+}
+
+func TestParseProxyURL(t *testing.T) {
+	for _, s := range []string{"http://proxy:3128", "https://proxy", "socks5://u:p@proxy:1080", "socks5h://proxy"} {
+		_, err := ParseProxyURL(s)
+		require.NoErrorf(t, err, "parsing %q", s)
+	}
+	for _, s := range []string{"ftp://proxy", "proxy:3128", "socks5://"} {
+		_, err := ParseProxyURL(s)
+		require.Errorf(t, err, "parsing %q", s)
+	}
+}
+
+// newConnectProxy starts an HTTP CONNECT proxy requiring the password
+// "secret" from clients sending credentials.
+func newConnectProxy(t *testing.T) (string, *atomic.Int32) {
+	var tunnels atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "CONNECT only", http.StatusMethodNotAllowed)
+			return
+		}
+		if r.Header.Get("Proxy-Authorization") != "" {
+			r.Header.Set("Authorization", r.Header.Get("Proxy-Authorization"))
+			if _, p, _ := r.BasicAuth(); p != "secret" {
+				http.Error(w, "bad credentials", http.StatusProxyAuthRequired)
+				return
+			}
+		}
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		tunnels.Add(1)
+		pipe(conn, upstream)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.Listener.Addr().String(), &tunnels
+}
+
+// newSOCKS5Proxy starts a SOCKS5 proxy requiring the password "secret" from
+// clients authenticating with a user name and password.
+func newSOCKS5Proxy(t *testing.T) (string, *atomic.Int32) {
+	var tunnels atomic.Int32
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				upstream, err := socks5Handshake(conn)
+				if err != nil {
+					conn.Close()
+					return
+				}
+				tunnels.Add(1)
+				pipe(conn, upstream)
+			}()
+		}
+	}()
+	return ln.Addr().String(), &tunnels
+}
+
+func socks5Handshake(conn net.Conn) (net.Conn, error) {
+	buf := make([]byte, 256)
+	if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+		return nil, err
+	}
+	methods := buf[2 : 2+buf[1]]
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return nil, err
+	}
+	method := methods[0]
+	conn.Write([]byte{socks5Version, method})
+	if method == socks5UserPass {
+		io.ReadFull(conn, buf[:2])
+		io.ReadFull(conn, buf[:buf[1]])
+		io.ReadFull(conn, buf[:1])
+		password := make([]byte, buf[0])
+		io.ReadFull(conn, password)
+		if string(password) != "secret" {
+			conn.Write([]byte{socks5UserPassVer, 1})
+			return nil, io.EOF
+		}
+		conn.Write([]byte{socks5UserPassVer, 0})
+	}
+
+	if _, err := io.ReadFull(conn, buf[:4]); err != nil {
+		return nil, err
+	}
+	var host string
+	switch buf[3] {
+	case socks5AddrIPv4:
+		io.ReadFull(conn, buf[:net.IPv4len])
+		host = net.IP(buf[:net.IPv4len]).String()
+	case socks5AddrDomain:
+		io.ReadFull(conn, buf[:1])
+		n := buf[0]
+		io.ReadFull(conn, buf[:n])
+		host = string(buf[:n])
+	}
+	io.ReadFull(conn, buf[:2])
+	port := binary.BigEndian.Uint16(buf[:2])
+	upstream, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(port))))
+	if err != nil {
+		conn.Write([]byte{socks5Version, 5, 0, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+		return nil, err
+	}
+	conn.Write([]byte{socks5Version, socks5ReplySucceeded, 0, socks5AddrIPv4, 127, 0, 0, 1, 0, 0})
+	return upstream, nil
+}
+
+func pipe(a, b net.Conn) {
+	go func() {
+		io.Copy(a, b)
+		a.Close()
+	}()
+	io.Copy(b, a)
+	b.Close()
+}
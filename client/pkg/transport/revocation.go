@@ -0,0 +1,394 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ocsp"
+)
+
+// RevocationSource is the mechanism that reported a certificate as revoked.
+type RevocationSource string
+
+const (
+	RevocationSourceCRL  RevocationSource = "crl"
+	RevocationSourceOCSP RevocationSource = "ocsp"
+)
+
+const (
+	// OCSPVerifySoftFail rejects certificates that OCSP reports as revoked,
+	// and accepts certificates whose status cannot be determined.
+	OCSPVerifySoftFail = "soft-fail"
+	// OCSPVerifyHardFail rejects certificates unless OCSP reports them as good.
+	OCSPVerifyHardFail = "hard-fail"
+)
+
+const (
+	ocspRequestTimeout = 5 * time.Second
+	// ocspDefaultValidity is how long a response without NextUpdate is cached.
+	ocspDefaultValidity = time.Hour
+	// ocspStapleRetryInterval bounds how often a failed staple fetch is retried.
+	ocspStapleRetryInterval = time.Minute
+)
+
+// ErrCertificateRevoked matches errors returned for revoked certificates.
+var ErrCertificateRevoked = errors.New("transport: certificate revoked")
+
+// RevokedError is returned when a certificate is revoked. It matches
+// ErrCertificateRevoked.
+type RevokedError struct {
+	SerialNumber *big.Int
+	Source       RevocationSource
+}
+
+func (e *RevokedError) Error() string {
+	return fmt.Sprintf("transport: certificate serial %x revoked (%s)", e.SerialNumber.Bytes(), e.Source)
+}
+
+func (e *RevokedError) Is(target error) bool { return target == ErrCertificateRevoked }
+
+func validateOCSPVerify(mode string) error {
+	switch mode {
+	case "", OCSPVerifySoftFail, OCSPVerifyHardFail:
+		return nil
+	default:
+		return fmt.Errorf("unknown OCSP verification mode %q (supported: %q, %q)", mode, OCSPVerifySoftFail, OCSPVerifyHardFail)
+	}
+}
+
+// verifyRevocation returns a tls.Config.VerifyConnection function rejecting
+// peer certificates that are revoked according to the CRL file or OCSP.
+// checkCRL is false on the dialing side, where the CRL file does not apply.
+func (info TLSInfo) verifyRevocation(checkCRL bool) func(tls.ConnectionState) error {
+	crlFile := info.CRLFile
+	if !checkCRL {
+		crlFile = ""
+	}
+	if crlFile == "" && info.OCSPVerify == "" {
+		return nil
+	}
+	lg := info.Logger
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return nil
+		}
+		err := info.checkRevocation(lg, crlFile, cs)
+		var revoked *RevokedError
+		if errors.As(err, &revoked) && info.CertificateRevoked != nil {
+			info.CertificateRevoked(cs.PeerCertificates[0], revoked.Source)
+		}
+		return err
+	}
+}
+
+func (info TLSInfo) checkRevocation(lg *zap.Logger, crlFile string, cs tls.ConnectionState) error {
+	if crlFile != "" {
+		if err := checkCRL(crlFile, cs.PeerCertificates); err != nil {
+			return err
+		}
+	}
+	if info.OCSPVerify == "" {
+		return nil
+	}
+	if len(cs.VerifiedChains) == 0 || len(cs.VerifiedChains[0]) < 2 {
+		// unverified or self-signed certificates have no issuer to ask
+		return nil
+	}
+	cert, issuer := cs.VerifiedChains[0][0], cs.VerifiedChains[0][1]
+	err := defaultOCSPCache.check(cert, issuer, cs.OCSPResponse)
+	if err == nil || errors.Is(err, ErrCertificateRevoked) {
+		return err
+	}
+	if info.OCSPVerify == OCSPVerifyHardFail {
+		return fmt.Errorf("transport: cannot determine OCSP status of certificate serial %x: %w", cert.SerialNumber.Bytes(), err)
+	}
+	lg.Warn(
+		"cannot determine OCSP status of certificate, accepting it",
+		zap.String("serial", fmt.Sprintf("%x", cert.SerialNumber.Bytes())),
+		zap.Error(err),
+	)
+	return nil
+}
+
+// crlCache caches the revoked serials of a CRL file, and reloads them when
+// the file changes.
+type crlCache struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	revoked map[string]struct{}
+}
+
+// crlCaches maps CRL file paths to their *crlCache.
+var crlCaches sync.Map
+
+func checkCRL(crlPath string, certs []*x509.Certificate) error {
+	v, _ := crlCaches.LoadOrStore(crlPath, &crlCache{path: crlPath})
+	revoked, err := v.(*crlCache).revokedSerials()
+	if err != nil {
+		return err
+	}
+	for _, c := range certs {
+		if _, ok := revoked[string(c.SerialNumber.Bytes())]; ok {
+			return &RevokedError{SerialNumber: c.SerialNumber, Source: RevocationSourceCRL}
+		}
+	}
+	return nil
+}
+
+func (c *crlCache) revokedSerials() (map[string]struct{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fi, err := os.Stat(c.path)
+	if err != nil {
+		return nil, err
+	}
+	if c.revoked != nil && fi.ModTime().Equal(c.modTime) && fi.Size() == c.size {
+		return c.revoked, nil
+	}
+
+	crlBytes, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+	certList, err := x509.ParseRevocationList(crlBytes)
+	if err != nil {
+		// do not keep serving a stale list once the file changed
+		c.revoked = nil
+		return nil, err
+	}
+	revoked := make(map[string]struct{}, len(certList.RevokedCertificateEntries))
+	for _, rc := range certList.RevokedCertificateEntries {
+		revoked[string(rc.SerialNumber.Bytes())] = struct{}{}
+	}
+	c.revoked, c.modTime, c.size = revoked, fi.ModTime(), fi.Size()
+	return revoked, nil
+}
+
+// ocspCache caches OCSP responses until their next update.
+type ocspCache struct {
+	client *http.Client
+
+	mu        sync.Mutex
+	responses map[string]*ocsp.Response
+}
+
+var defaultOCSPCache = &ocspCache{
+	client:    &http.Client{Timeout: ocspRequestTimeout},
+	responses: make(map[string]*ocsp.Response),
+}
+
+func ocspCacheKey(cert, issuer *x509.Certificate) string {
+	h := sha256.Sum256(issuer.Raw)
+	return string(h[:]) + string(cert.SerialNumber.Bytes())
+}
+
+// check returns nil if cert is good, a *RevokedError if it is revoked, or
+// another error if its status cannot be determined. A stapled response is
+// used in preference to querying the responder.
+func (c *ocspCache) check(cert, issuer *x509.Certificate, stapled []byte) error {
+	resp, err := c.response(cert, issuer, stapled)
+	if err != nil {
+		return err
+	}
+	switch resp.Status {
+	case ocsp.Good:
+		return nil
+	case ocsp.Revoked:
+		return &RevokedError{SerialNumber: cert.SerialNumber, Source: RevocationSourceOCSP}
+	default:
+		return errors.New("OCSP status unknown")
+	}
+}
+
+func (c *ocspCache) response(cert, issuer *x509.Certificate, stapled []byte) (*ocsp.Response, error) {
+	now := time.Now()
+	if len(stapled) > 0 {
+		if resp, err := ocsp.ParseResponseForCert(stapled, cert, issuer); err == nil && ocspResponseValid(resp, now) {
+			return resp, nil
+		}
+	}
+
+	key := ocspCacheKey(cert, issuer)
+	c.mu.Lock()
+	resp, ok := c.responses[key]
+	c.mu.Unlock()
+	if ok && ocspResponseValid(resp, now) {
+		return resp, nil
+	}
+
+	resp, err := c.fetch(cert, issuer)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.responses[key] = resp
+	c.mu.Unlock()
+	return resp, nil
+}
+
+// fetch queries the responders listed in cert for its status.
+func (c *ocspCache) fetch(cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	if len(cert.OCSPServer) == 0 {
+		return nil, errors.New("certificate does not name an OCSP responder")
+	}
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+	var lastErr error
+	for _, server := range cert.OCSPServer {
+		var resp *ocsp.Response
+		if resp, lastErr = c.query(server, req, cert, issuer); lastErr == nil {
+			return resp, nil
+		}
+	}
+	return nil, lastErr
+}
+
+func (c *ocspCache) query(server string, req []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ocspRequestTimeout)
+	defer cancel()
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	hreq.Header.Set("Content-Type", "application/ocsp-request")
+	hresp, err := c.client.Do(hreq)
+	if err != nil {
+		return nil, err
+	}
+	defer hresp.Body.Close()
+	if hresp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder %s returned %s", server, hresp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(hresp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ocsp.ParseResponseForCert(body, cert, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if !ocspResponseValid(resp, time.Now()) {
+		return nil, fmt.Errorf("OCSP responder %s returned an expired response", server)
+	}
+	return resp, nil
+}
+
+func ocspResponseValid(resp *ocsp.Response, now time.Time) bool {
+	if now.Before(resp.ThisUpdate) {
+		return false
+	}
+	if resp.NextUpdate.IsZero() {
+		return now.Before(resp.ThisUpdate.Add(ocspDefaultValidity))
+	}
+	return now.Before(resp.NextUpdate)
+}
+
+// ocspStapler staples OCSP responses to served certificates, and refreshes
+// them halfway through their validity.
+type ocspStapler struct {
+	lg    *zap.Logger
+	cache *ocspCache
+
+	mu      sync.Mutex
+	staples map[string]*ocspStaple
+}
+
+type ocspStaple struct {
+	raw       []byte
+	refreshAt time.Time
+}
+
+var defaultOCSPStapler = &ocspStapler{
+	cache:   defaultOCSPCache,
+	staples: make(map[string]*ocspStaple),
+}
+
+// staple sets the OCSP response of cert. The issuer must follow the leaf in
+// the certificate chain.
+func (s *ocspStapler) staple(lg *zap.Logger, cert *tls.Certificate) {
+	if cert == nil || len(cert.Certificate) < 2 {
+		return
+	}
+	key := string(cert.Certificate[0])
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.staples[key]
+	if !ok || now.After(st.refreshAt) {
+		st = s.fetch(lg, cert, now, st)
+		s.staples[key] = st
+	}
+	cert.OCSPStaple = st.raw
+}
+
+func (s *ocspStapler) fetch(lg *zap.Logger, cert *tls.Certificate, now time.Time, prev *ocspStaple) *ocspStaple {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return &ocspStaple{refreshAt: now.Add(ocspStapleRetryInterval)}
+	}
+	issuer, err := x509.ParseCertificate(cert.Certificate[1])
+	if err != nil {
+		return &ocspStaple{refreshAt: now.Add(ocspStapleRetryInterval)}
+	}
+	req, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err == nil {
+		for _, server := range leaf.OCSPServer {
+			var resp *ocsp.Response
+			if resp, err = s.cache.query(server, req, leaf, issuer); err == nil {
+				next := resp.NextUpdate
+				if next.IsZero() {
+					next = resp.ThisUpdate.Add(ocspDefaultValidity)
+				}
+				return &ocspStaple{raw: resp.Raw, refreshAt: resp.ThisUpdate.Add(next.Sub(resp.ThisUpdate) / 2)}
+			}
+		}
+	}
+	if err == nil {
+		err = errors.New("certificate does not name an OCSP responder")
+	}
+	lg.Warn("failed to fetch OCSP response to staple", zap.Error(err))
+	st := &ocspStaple{refreshAt: now.Add(ocspStapleRetryInterval)}
+	if prev != nil {
+		// keep stapling the previous response until it expires
+		if resp, perr := ocsp.ParseResponse(prev.raw, issuer); perr == nil && ocspResponseValid(resp, now) {
+			st.raw = prev.raw
+		}
+	}
+	return st
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) issue(t *testing.T, serial int64, ocspServer string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "test-leaf"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	if ocspServer != "" {
+		tmpl.OCSPServer = []string{ocspServer}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func (ca *testCA) writeCRL(t *testing.T, path string, number int64, serials ...int64) {
+	tmpl := &x509.RevocationList{
+		ThisUpdate: time.Now(),
+		NextUpdate: time.Now().Add(time.Hour),
+		Number:     big.NewInt(number),
+	}
+	for _, s := range serials {
+		tmpl.RevokedCertificateEntries = append(tmpl.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   big.NewInt(s),
+			RevocationTime: time.Now(),
+		})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, tmpl, ca.cert, ca.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, der, 0o600))
+}
+
+func TestCheckCRLReload(t *testing.T) {
+	ca := newTestCA(t)
+	leaf := ca.issue(t, 100, "")
+	crlPath := filepath.Join(t.TempDir(), "crl")
+
+	ca.writeCRL(t, crlPath, 1)
+	require.NoError(t, checkCRL(crlPath, []*x509.Certificate{leaf}))
+
+	// make sure the modification time changes
+	ca.writeCRL(t, crlPath, 2, 99, 100)
+	require.NoError(t, os.Chtimes(crlPath, time.Now(), time.Now().Add(time.Second)))
+	err := checkCRL(crlPath, []*x509.Certificate{leaf})
+	require.ErrorIs(t, err, ErrCertificateRevoked)
+	var revoked *RevokedError
+	require.ErrorAs(t, err, &revoked)
+	assert.Equal(t, RevocationSourceCRL, revoked.Source)
+
+	require.NoError(t, os.WriteFile(crlPath, []byte("invalid"), 0o600))
+	require.NoError(t, os.Chtimes(crlPath, time.Now(), time.Now().Add(2*time.Second)))
+	err = checkCRL(crlPath, []*x509.Certificate{leaf})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrCertificateRevoked)
+}
+
+func newTestOCSPResponder(t *testing.T, ca *testCA, status *atomic.Int32, queries *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries.Add(1)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		req, err := ocsp.ParseRequest(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tmpl := ocsp.Response{
+			Status:       int(status.Load()),
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}
+		if tmpl.Status == ocsp.Revoked {
+			tmpl.RevokedAt = time.Now().Add(-time.Minute)
+		}
+		resp, err := ocsp.CreateResponse(ca.cert, ca.cert, tmpl, ca.key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(resp)
+	}))
+}
+
+func TestOCSPVerify(t *testing.T) {
+	ca := newTestCA(t)
+	var status, queries atomic.Int32
+	responder := newTestOCSPResponder(t, ca, &status, &queries)
+	defer responder.Close()
+
+	var revokedSources []RevocationSource
+	info := TLSInfo{
+		OCSPVerify: OCSPVerifyHardFail,
+		CertificateRevoked: func(_ *x509.Certificate, source RevocationSource) {
+			revokedSources = append(revokedSources, source)
+		},
+	}
+	verify := info.verifyRevocation(true)
+	state := func(leaf *x509.Certificate) tls.ConnectionState {
+		return tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{leaf},
+			VerifiedChains:   [][]*x509.Certificate{{leaf, ca.cert}},
+		}
+	}
+
+	status.Store(ocsp.Good)
+	good := ca.issue(t, 200, responder.URL)
+	require.NoError(t, verify(state(good)))
+	// the response is cached until its next update
+	require.NoError(t, verify(state(good)))
+	assert.Equal(t, int32(1), queries.Load())
+
+	status.Store(ocsp.Revoked)
+	revoked := ca.issue(t, 201, responder.URL)
+	require.ErrorIs(t, verify(state(revoked)), ErrCertificateRevoked)
+	assert.Equal(t, []RevocationSource{RevocationSourceOCSP}, revokedSources)
+
+	// without a responder, only the hard-fail mode rejects the certificate
+	unknown := ca.issue(t, 202, "")
+	err := verify(state(unknown))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrCertificateRevoked)
+	info.OCSPVerify = OCSPVerifySoftFail
+	require.NoError(t, info.verifyRevocation(true)(state(unknown)))
+}
+
+func TestOCSPVerifyStapled(t *testing.T) {
+	ca := newTestCA(t)
+	leaf := ca.issue(t, 300, "")
+	staple, err := ocsp.CreateResponse(ca.cert, ca.cert, ocsp.Response{
+		Status:       ocsp.Revoked,
+		SerialNumber: leaf.SerialNumber,
+		ThisUpdate:   time.Now().Add(-time.Minute),
+		NextUpdate:   time.Now().Add(time.Hour),
+		RevokedAt:    time.Now().Add(-time.Minute),
+	}, ca.key)
+	require.NoError(t, err)
+
+	info := TLSInfo{OCSPVerify: OCSPVerifyHardFail}
+	err = info.verifyRevocation(false)(tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{leaf},
+		VerifiedChains:   [][]*x509.Certificate{{leaf, ca.cert}},
+		OCSPResponse:     staple,
+	})
+	require.ErrorIs(t, err, ErrCertificateRevoked)
+}
+
+func TestOCSPStapling(t *testing.T) {
+	ca := newTestCA(t)
+	var status, queries atomic.Int32
+	status.Store(ocsp.Good)
+	responder := newTestOCSPResponder(t, ca, &status, &queries)
+	defer responder.Close()
+
+	leaf := ca.issue(t, 400, responder.URL)
+	stapler := &ocspStapler{cache: defaultOCSPCache, staples: make(map[string]*ocspStaple)}
+	for i := 0; i < 2; i++ {
+		cert := &tls.Certificate{Certificate: [][]byte{leaf.Raw, ca.cert.Raw}}
+		stapler.staple(nil, cert)
+		require.NotEmpty(t, cert.OCSPStaple)
+		resp, err := ocsp.ParseResponseForCert(cert.OCSPStaple, leaf, ca.cert)
+		require.NoError(t, err)
+		assert.Equal(t, ocsp.Good, resp.Status)
+	}
+	assert.Equal(t, int32(1), queries.Load())
+}
+
+func TestValidateOCSPVerify(t *testing.T) {
+	require.NoError(t, validateOCSPVerify(""))
+	require.NoError(t, validateOCSPVerify(OCSPVerifySoftFail))
+	require.NoError(t, validateOCSPVerify(OCSPVerifyHardFail))
+	require.Error(t, validateOCSPVerify("strict"))
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const (
+	// codeUnauthenticated is the gRPC status code of authentication failures.
+	codeUnauthenticated = 16
+
+	errMsgInvalidAuthToken = "etcdserver: invalid auth token"
+)
+
+// ErrNoAvailableEndpoints is returned by New without endpoints.
+var ErrNoAvailableEndpoints = errors.New("etcdclient: no available endpoints")
+
+// Config is the configuration of a Client.
+type Config struct {
+	// Endpoints are the client URLs of the etcd members, e.g.
+	// "http://localhost:2379".
+	Endpoints []string
+
+	// Username is the user name for authentication.
+	Username string
+	// Password is the password for authentication.
+	Password string
+
+	// TLS holds the client TLS configuration of https endpoints.
+	TLS *tls.Config
+
+	// HTTPClient sends the requests if set, ignoring TLS. It should not
+	// have a timeout, which would interrupt watches.
+	HTTPClient *http.Client
+}
+
+// Client sends requests to the etcd gateway.
+type Client struct {
+	endpoints []*url.URL
+	username  string
+	password  string
+	hc        *http.Client
+
+	// authMu serializes authentication.
+	authMu sync.Mutex
+
+	mu sync.Mutex
+	// ep is the index of the endpoint in use.
+	ep    int
+	token string
+}
+
+// Error is an error returned by the server.
+type Error struct {
+	// Code is the gRPC status code of the error.
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// New creates a client for the given configuration.
+func New(cfg Config) (*Client, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, ErrNoAvailableEndpoints
+	}
+	c := &Client{username: cfg.Username, password: cfg.Password, hc: cfg.HTTPClient}
+	for _, ep := range cfg.Endpoints {
+		u, err := url.Parse(ep)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("etcdclient: endpoint %q must be an http or https URL", ep)
+		}
+		c.endpoints = append(c.endpoints, u)
+	}
+	if c.hc == nil {
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = cfg.TLS
+		c.hc = &http.Client{Transport: tr}
+	}
+	return c, nil
+}
+
+// Close closes the idle connections of the client.
+func (c *Client) Close() error {
+	c.hc.CloseIdleConnections()
+	return nil
+}
+
+// call sends in to the gateway path and decodes the response into out.
+func (c *Client) call(ctx context.Context, path string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	resp, err := c.send(ctx, path, body, "application/json")
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, out)
+}
+
+// send posts body to the gateway path and returns the successful response.
+// Authentication failures due to an invalid token are retried once with a
+// new token.
+func (c *Client) send(ctx context.Context, path string, body []byte, accept string) (*http.Response, error) {
+	for retried := false; ; retried = true {
+		token, err := c.authToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.post(ctx, path, body, accept, token)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+		err = responseError(resp)
+		var rerr *Error
+		if retried || c.username == "" || !errors.As(err, &rerr) || rerr.Code != codeUnauthenticated || rerr.Message != errMsgInvalidAuthToken {
+			return nil, err
+		}
+		c.mu.Lock()
+		if c.token == token {
+			c.token = ""
+		}
+		c.mu.Unlock()
+	}
+}
+
+// post posts body to the gateway path, failing over to the next endpoint
+// when a member cannot be reached.
+func (c *Client) post(ctx context.Context, path string, body []byte, accept, token string) (*http.Response, error) {
+	c.mu.Lock()
+	ep := c.ep
+	c.mu.Unlock()
+
+	var lastErr error
+	for i := range c.endpoints {
+		idx := (ep + i) % len(c.endpoints)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoints[idx].JoinPath(path).String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", accept)
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		resp, err := c.hc.Do(req)
+		if err == nil {
+			c.mu.Lock()
+			c.ep = idx
+			c.mu.Unlock()
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// authToken returns the token of the client, authenticating first if needed.
+func (c *Client) authToken(ctx context.Context) (string, error) {
+	if c.username == "" {
+		return "", nil
+	}
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.mu.Lock()
+	token := c.token
+	c.mu.Unlock()
+	if token != "" {
+		return token, nil
+	}
+
+	body, err := json.Marshal(authenticateRequest{Name: c.username, Password: c.password})
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.post(ctx, "/v3/auth/authenticate", body, "application/json", "")
+	if err != nil {
+		return "", err
+	}
+	var ar authenticateResponse
+	if err = decodeResponse(resp, &ar); err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.token = ar.Token
+	c.mu.Unlock()
+	return ar.Token, nil
+}
+
+type authenticateRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type authenticateResponse struct {
+	Token string `json:"token"`
+}
+
+func decodeResponse(resp *http.Response, out any) error {
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// responseError returns the error of a failed response and closes its body.
+func responseError(resp *http.Response) error {
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	rerr := &Error{}
+	if err = json.Unmarshal(b, rerr); err != nil || rerr.Message == "" {
+		return fmt.Errorf("etcdclient: unexpected response %q: %s", resp.Status, bytes.TrimSpace(b))
+	}
+	return rerr
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New(Config{}); !errors.Is(err, ErrNoAvailableEndpoints) {
+		t.Errorf("expected %v, got %v", ErrNoAvailableEndpoints, err)
+	}
+	if _, err := New(Config{Endpoints: []string{"localhost:2379"}}); err == nil {
+		t.Error("expected error for endpoint without scheme")
+	}
+	if _, err := New(Config{Endpoints: []string{"http://localhost:2379", "https://localhost:22379"}}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// fakeGateway answers like the etcd gateway, with a token revoked after
+// each use if revokeTokens is set.
+type fakeGateway struct {
+	mu           sync.Mutex
+	tokens       int
+	token        string
+	revokeTokens bool
+	bodies       map[string]string
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	b, _ := io.ReadAll(r.Body)
+	if g.bodies == nil {
+		g.bodies = make(map[string]string)
+	}
+	g.bodies[r.URL.Path] = string(b)
+	switch r.URL.Path {
+	case "/v3/auth/authenticate":
+		var req authenticateRequest
+		json.Unmarshal(b, &req)
+		if req.Password != "pass" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code":3,"message":"etcdserver: authentication failed, invalid user ID or password"}`)
+			return
+		}
+		g.tokens++
+		g.token = fmt.Sprintf("token%d", g.tokens)
+		fmt.Fprintf(w, `{"header":{"revision":"1"},"token":%q}`, g.token)
+		return
+	}
+	if g.token != "" {
+		if r.Header.Get("Authorization") != g.token {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"code":16,"message":"etcdserver: invalid auth token"}`)
+			return
+		}
+		if g.revokeTokens {
+			g.token = "revoked"
+		}
+	}
+	switch r.URL.Path {
+	case "/v3/kv/range":
+		fmt.Fprint(w, `{"header":{"cluster_id":"14841639068965178418","member_id":"10276657743932975437","revision":"3","raft_term":"2"},"kvs":[{"key":"Zm9v","create_revision":"2","mod_revision":"3","version":"2","value":"YmFy"}],"count":"1"}`)
+	case "/v3/watch":
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"result\":{\"header\":{\"revision\":\"3\"},\"created\":true}}\n\n")
+		fmt.Fprint(w, "data: {\"result\":{\"header\":{\"revision\":\"4\"},\"events\":[{\"kv\":{\"key\":\"Zm9v\",\"value\":\"YmFy\",\"mod_revision\":\"4\"}},{\"type\":\"DELETE\",\"kv\":{\"key\":\"Zm9v\",\"mod_revision\":\"4\"}}]}}\n\n")
+		fmt.Fprint(w, "data: {\"error\":{\"code\":14,\"message\":\"etcdserver: no leader\"}}\n\n")
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "Not Found")
+	}
+}
+
+func TestRange(t *testing.T) {
+	ts := httptest.NewServer(&fakeGateway{})
+	defer ts.Close()
+	c, err := New(Config{Endpoints: []string{ts.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	resp, err := c.Range(context.Background(), &RangeRequest{Key: []byte("foo")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &RangeResponse{
+		Header: &ResponseHeader{ClusterID: 14841639068965178418, MemberID: 10276657743932975437, Revision: 3, RaftTerm: 2},
+		Kvs:    []*KeyValue{{Key: []byte("foo"), CreateRevision: 2, ModRevision: 3, Version: 2, Value: []byte("bar")}},
+		Count:  1,
+	}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("expected %+v, got %+v", want, resp)
+	}
+
+	_, err = c.DeleteRange(context.Background(), &DeleteRangeRequest{Key: []byte("foo")})
+	if err == nil || err.Error() != `etcdclient: unexpected response "404 Not Found": Not Found` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestEndpointFailover(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	ts := httptest.NewServer(&fakeGateway{})
+	defer ts.Close()
+	c, err := New(Config{Endpoints: []string{down.URL, ts.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err = c.Range(context.Background(), &RangeRequest{Key: []byte("foo")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if c.ep != 1 {
+		t.Errorf("expected endpoint 1 in use, got %d", c.ep)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	g := &fakeGateway{}
+	ts := httptest.NewServer(g)
+	defer ts.Close()
+
+	c, err := New(Config{Endpoints: []string{ts.URL}, Username: "root", Password: "wrong"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Range(context.Background(), &RangeRequest{Key: []byte("foo")})
+	var rerr *Error
+	if !errors.As(err, &rerr) || rerr.Code != 3 {
+		t.Fatalf("expected authentication error, got %v", err)
+	}
+
+	c, err = New(Config{Endpoints: []string{ts.URL}, Username: "root", Password: "pass"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.Range(context.Background(), &RangeRequest{Key: []byte("foo")}); err != nil {
+		t.Fatal(err)
+	}
+	if g.bodies["/v3/auth/authenticate"] != `{"name":"root","password":"pass"}` {
+		t.Errorf("unexpected authenticate request %s", g.bodies["/v3/auth/authenticate"])
+	}
+
+	// an invalid token is replaced once per request
+	g.revokeTokens = true
+	for i := 0; i < 3; i++ {
+		if _, err = c.Range(context.Background(), &RangeRequest{Key: []byte("foo")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.tokens != 3 {
+		t.Errorf("expected 3 tokens, got %d", g.tokens)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	g := &fakeGateway{}
+	ts := httptest.NewServer(g)
+	defer ts.Close()
+	c, err := New(Config{Endpoints: []string{ts.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var resps []WatchResponse
+	for wr := range c.Watch(context.Background(), &WatchRequest{Key: []byte("foo"), StartRevision: 4, Filters: []WatchFilter{FilterNoDelete}}) {
+		resps = append(resps, wr)
+	}
+	if g.bodies["/v3/watch"] != `{"create_request":{"key":"Zm9v","start_revision":"4","filters":["NODELETE"]}}` {
+		t.Errorf("unexpected watch request %s", g.bodies["/v3/watch"])
+	}
+	if len(resps) != 2 {
+		t.Fatalf("expected 2 responses, got %+v", resps)
+	}
+	want := []*Event{
+		{Type: EventTypePut, Kv: &KeyValue{Key: []byte("foo"), Value: []byte("bar"), ModRevision: 4}},
+		{Type: EventTypeDelete, Kv: &KeyValue{Key: []byte("foo"), ModRevision: 4}},
+	}
+	if !reflect.DeepEqual(resps[0].Events, want) || resps[0].Err() != nil {
+		t.Errorf("expected events %+v, got %+v", want, resps[0])
+	}
+	var rerr *Error
+	if !errors.As(resps[1].Err(), &rerr) || rerr.Code != 14 {
+		t.Errorf("expected stream error, got %v", resps[1].Err())
+	}
+}
+
+func TestCompareMarshalJSON(t *testing.T) {
+	tests := []struct {
+		cmp  Compare
+		want string
+	}{
+		{
+			Compare{Key: []byte("foo"), Result: CompareEqual, Target: CompareVersion},
+			`{"key":"Zm9v","result":"EQUAL","target":"VERSION","version":"0"}`,
+		},
+		{
+			Compare{Key: []byte("foo"), Result: CompareNotEqual, Target: CompareValue},
+			`{"key":"Zm9v","result":"NOT_EQUAL","target":"VALUE","value":""}`,
+		},
+		{
+			Compare{Key: []byte("a"), RangeEnd: []byte("b"), Result: CompareLess, Target: CompareModRevision, ModRevision: 5, Version: 1},
+			`{"key":"YQ==","mod_revision":"5","range_end":"Yg==","result":"LESS","target":"MOD"}`,
+		},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.cmp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("expected %s, got %s", tt.want, b)
+		}
+	}
+}
+
+func TestPrefixRangeEnd(t *testing.T) {
+	tests := []struct {
+		prefix, want []byte
+	}{
+		{[]byte("a"), []byte("b")},
+		{[]byte{'a', 0xff}, []byte("b")},
+		{[]byte{0xff}, []byte{0}},
+	}
+	for _, tt := range tests {
+		if got := PrefixRangeEnd(tt.prefix); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("prefix %q: expected %q, got %q", tt.prefix, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rest implements a minimal etcd v3 KV client over the HTTP/JSON
+// gateway, without depending on gRPC.
+//
+// Create a client using `rest.New`:
+//
+//	cli, err := rest.New(rest.Config{
+//		Endpoints: []string{"http://localhost:2379", "http://localhost:22379"},
+//	})
+//	if err != nil {
+//		// handle error!
+//	}
+//	defer cli.Close()
+//
+// The requests mirror the etcd v3 API messages:
+//
+//	resp, err := cli.Range(ctx, &rest.RangeRequest{Key: []byte("foo")})
+//	if err != nil {
+//		var rerr *rest.Error
+//		if errors.As(err, &rerr) {
+//			// the server rejected the request with a gRPC status code
+//		}
+//		// handle error!
+//	}
+//
+// Requests fail over to the next endpoint when a member cannot be reached.
+// If Username is set, the client authenticates before its first request and
+// again whenever its token is rejected as invalid.
+package rest
@@ -0,0 +1,5 @@
+module go.etcd.io/etcd/client/rest/v3
+
+go 1.24
+
+toolchain go1.24.6
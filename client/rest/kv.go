@@ -0,0 +1,256 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// The types below mirror the JSON mapping of the etcd v3 API messages, in
+// which 64-bit integers are strings and bytes are base64 encoded.
+
+// ResponseHeader is the header of every response.
+type ResponseHeader struct {
+	ClusterID uint64 `json:"cluster_id,omitempty,string"`
+	MemberID  uint64 `json:"member_id,omitempty,string"`
+	Revision  int64  `json:"revision,omitempty,string"`
+	RaftTerm  uint64 `json:"raft_term,omitempty,string"`
+}
+
+// KeyValue is a key and its value at a revision.
+type KeyValue struct {
+	Key            []byte `json:"key,omitempty"`
+	CreateRevision int64  `json:"create_revision,omitempty,string"`
+	ModRevision    int64  `json:"mod_revision,omitempty,string"`
+	Version        int64  `json:"version,omitempty,string"`
+	Value          []byte `json:"value,omitempty"`
+	Lease          int64  `json:"lease,omitempty,string"`
+}
+
+type SortOrder string
+
+const (
+	SortNone    SortOrder = "NONE"
+	SortAscend  SortOrder = "ASCEND"
+	SortDescend SortOrder = "DESCEND"
+)
+
+type SortTarget string
+
+const (
+	SortByKey            SortTarget = "KEY"
+	SortByVersion        SortTarget = "VERSION"
+	SortByCreateRevision SortTarget = "CREATE"
+	SortByModRevision    SortTarget = "MOD"
+	SortByValue          SortTarget = "VALUE"
+)
+
+// RangeRequest gets the keys in the range [Key, RangeEnd), or Key alone if
+// RangeEnd is empty.
+type RangeRequest struct {
+	Key          []byte     `json:"key,omitempty"`
+	RangeEnd     []byte     `json:"range_end,omitempty"`
+	Limit        int64      `json:"limit,omitempty,string"`
+	Revision     int64      `json:"revision,omitempty,string"`
+	SortOrder    SortOrder  `json:"sort_order,omitempty"`
+	SortTarget   SortTarget `json:"sort_target,omitempty"`
+	Serializable bool       `json:"serializable,omitempty"`
+	KeysOnly     bool       `json:"keys_only,omitempty"`
+	CountOnly    bool       `json:"count_only,omitempty"`
+}
+
+type RangeResponse struct {
+	Header *ResponseHeader `json:"header,omitempty"`
+	Kvs    []*KeyValue     `json:"kvs,omitempty"`
+	More   bool            `json:"more,omitempty"`
+	Count  int64           `json:"count,omitempty,string"`
+}
+
+// PutRequest puts the given key into the store.
+type PutRequest struct {
+	Key         []byte `json:"key,omitempty"`
+	Value       []byte `json:"value,omitempty"`
+	Lease       int64  `json:"lease,omitempty,string"`
+	PrevKv      bool   `json:"prev_kv,omitempty"`
+	IgnoreValue bool   `json:"ignore_value,omitempty"`
+	IgnoreLease bool   `json:"ignore_lease,omitempty"`
+	// SequenceSuffix appends the revision of the put to Key.
+	SequenceSuffix bool `json:"sequence_suffix,omitempty"`
+}
+
+type PutResponse struct {
+	Header *ResponseHeader `json:"header,omitempty"`
+	PrevKv *KeyValue       `json:"prev_kv,omitempty"`
+	// Key is the created key of a put with SequenceSuffix.
+	Key []byte `json:"key,omitempty"`
+}
+
+// DeleteRangeRequest deletes the keys in the range [Key, RangeEnd), or Key
+// alone if RangeEnd is empty.
+type DeleteRangeRequest struct {
+	Key      []byte `json:"key,omitempty"`
+	RangeEnd []byte `json:"range_end,omitempty"`
+	PrevKv   bool   `json:"prev_kv,omitempty"`
+}
+
+type DeleteRangeResponse struct {
+	Header  *ResponseHeader `json:"header,omitempty"`
+	Deleted int64           `json:"deleted,omitempty,string"`
+	PrevKvs []*KeyValue     `json:"prev_kvs,omitempty"`
+}
+
+type CompareResult string
+
+const (
+	CompareEqual    CompareResult = "EQUAL"
+	CompareGreater  CompareResult = "GREATER"
+	CompareLess     CompareResult = "LESS"
+	CompareNotEqual CompareResult = "NOT_EQUAL"
+)
+
+type CompareTarget string
+
+const (
+	CompareVersion        CompareTarget = "VERSION"
+	CompareCreateRevision CompareTarget = "CREATE"
+	CompareModRevision    CompareTarget = "MOD"
+	CompareValue          CompareTarget = "VALUE"
+	CompareLease          CompareTarget = "LEASE"
+)
+
+// Compare compares the Target of the keys in [Key, RangeEnd) with the field
+// of the same target, e.g. Version for CompareVersion.
+type Compare struct {
+	Key      []byte
+	RangeEnd []byte
+	Result   CompareResult
+	Target   CompareTarget
+
+	Version        int64
+	CreateRevision int64
+	ModRevision    int64
+	Value          []byte
+	Lease          int64
+}
+
+// MarshalJSON sets the compared field of the target, even if it is zero.
+func (cmp Compare) MarshalJSON() ([]byte, error) {
+	m := map[string]any{"result": cmp.Result, "target": cmp.Target}
+	if len(cmp.Key) > 0 {
+		m["key"] = cmp.Key
+	}
+	if len(cmp.RangeEnd) > 0 {
+		m["range_end"] = cmp.RangeEnd
+	}
+	switch cmp.Target {
+	case CompareVersion:
+		m["version"] = strconv.FormatInt(cmp.Version, 10)
+	case CompareCreateRevision:
+		m["create_revision"] = strconv.FormatInt(cmp.CreateRevision, 10)
+	case CompareModRevision:
+		m["mod_revision"] = strconv.FormatInt(cmp.ModRevision, 10)
+	case CompareValue:
+		m["value"] = cmp.Value
+		if cmp.Value == nil {
+			m["value"] = []byte{}
+		}
+	case CompareLease:
+		m["lease"] = strconv.FormatInt(cmp.Lease, 10)
+	}
+	return json.Marshal(m)
+}
+
+// RequestOp is one request of a transaction, with exactly one field set.
+type RequestOp struct {
+	RequestRange       *RangeRequest       `json:"request_range,omitempty"`
+	RequestPut         *PutRequest         `json:"request_put,omitempty"`
+	RequestDeleteRange *DeleteRangeRequest `json:"request_delete_range,omitempty"`
+	RequestTxn         *TxnRequest         `json:"request_txn,omitempty"`
+}
+
+// ResponseOp is the response to a RequestOp.
+type ResponseOp struct {
+	ResponseRange       *RangeResponse       `json:"response_range,omitempty"`
+	ResponsePut         *PutResponse         `json:"response_put,omitempty"`
+	ResponseDeleteRange *DeleteRangeResponse `json:"response_delete_range,omitempty"`
+	ResponseTxn         *TxnResponse         `json:"response_txn,omitempty"`
+}
+
+// TxnRequest runs Success if all the comparisons succeed, and Failure
+// otherwise.
+type TxnRequest struct {
+	Compare []Compare   `json:"compare,omitempty"`
+	Success []RequestOp `json:"success,omitempty"`
+	Failure []RequestOp `json:"failure,omitempty"`
+}
+
+type TxnResponse struct {
+	Header    *ResponseHeader `json:"header,omitempty"`
+	Succeeded bool            `json:"succeeded,omitempty"`
+	Responses []ResponseOp    `json:"responses,omitempty"`
+}
+
+// Range gets the keys in the range from the store.
+func (c *Client) Range(ctx context.Context, r *RangeRequest) (*RangeResponse, error) {
+	resp := &RangeResponse{}
+	if err := c.call(ctx, "/v3/kv/range", r, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Put puts the given key into the store.
+func (c *Client) Put(ctx context.Context, r *PutRequest) (*PutResponse, error) {
+	resp := &PutResponse{}
+	if err := c.call(ctx, "/v3/kv/put", r, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteRange deletes the keys in the range from the store.
+func (c *Client) DeleteRange(ctx context.Context, r *DeleteRangeRequest) (*DeleteRangeResponse, error) {
+	resp := &DeleteRangeResponse{}
+	if err := c.call(ctx, "/v3/kv/deleterange", r, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Txn processes multiple requests in a single transaction.
+func (c *Client) Txn(ctx context.Context, r *TxnRequest) (*TxnResponse, error) {
+	resp := &TxnResponse{}
+	if err := c.call(ctx, "/v3/kv/txn", r, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PrefixRangeEnd returns the RangeEnd covering all the keys with the given
+// prefix.
+func PrefixRangeEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// next prefix does not exist (e.g., 0xffff);
+	// default to WithFromKey policy
+	return []byte{0}
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// maxEventSize is the maximum size of a server-sent watch event.
+const maxEventSize = 64 * 1024 * 1024
+
+type EventType string
+
+const (
+	EventTypePut    EventType = "PUT"
+	EventTypeDelete EventType = "DELETE"
+)
+
+// Event is a change of a key.
+type Event struct {
+	Type   EventType `json:"type,omitempty"`
+	Kv     *KeyValue `json:"kv,omitempty"`
+	PrevKv *KeyValue `json:"prev_kv,omitempty"`
+}
+
+type WatchFilter string
+
+const (
+	// FilterNoPut filters out put events.
+	FilterNoPut WatchFilter = "NOPUT"
+	// FilterNoDelete filters out delete events.
+	FilterNoDelete WatchFilter = "NODELETE"
+)
+
+// WatchRequest watches the keys in the range [Key, RangeEnd), or Key alone
+// if RangeEnd is empty.
+type WatchRequest struct {
+	Key            []byte        `json:"key,omitempty"`
+	RangeEnd       []byte        `json:"range_end,omitempty"`
+	StartRevision  int64         `json:"start_revision,omitempty,string"`
+	ProgressNotify bool          `json:"progress_notify,omitempty"`
+	Filters        []WatchFilter `json:"filters,omitempty"`
+	PrevKv         bool          `json:"prev_kv,omitempty"`
+}
+
+type WatchResponse struct {
+	Header          *ResponseHeader `json:"header,omitempty"`
+	WatchID         int64           `json:"watch_id,omitempty,string"`
+	Created         bool            `json:"created,omitempty"`
+	Canceled        bool            `json:"canceled,omitempty"`
+	CompactRevision int64           `json:"compact_revision,omitempty,string"`
+	CancelReason    string          `json:"cancel_reason,omitempty"`
+	Events          []*Event        `json:"events,omitempty"`
+
+	err error
+}
+
+// Err returns the error that ended the watch, if any.
+func (wr *WatchResponse) Err() error { return wr.err }
+
+// IsProgressNotify returns true if the response is a progress notification.
+func (wr *WatchResponse) IsProgressNotify() bool {
+	return len(wr.Events) == 0 && !wr.Created && !wr.Canceled && wr.CompactRevision == 0 && wr.Header != nil && wr.Header.Revision != 0
+}
+
+type WatchChan <-chan WatchResponse
+
+// watchEvent is the data of a server-sent watch event.
+type watchEvent struct {
+	Result *WatchResponse `json:"result,omitempty"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// Watch watches the keys of the request, streaming the responses as
+// server-sent events. The returned channel is closed when ctx is done, when
+// the server cancels the watch, or after a response carrying the error that
+// ended the stream. The watch is not resumed on failures.
+func (c *Client) Watch(ctx context.Context, r *WatchRequest) WatchChan {
+	ch := make(chan WatchResponse)
+	go func() {
+		defer close(ch)
+		err := c.watch(ctx, r, ch)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		select {
+		case ch <- WatchResponse{err: err}:
+		case <-ctx.Done():
+		}
+	}()
+	return ch
+}
+
+func (c *Client) watch(ctx context.Context, r *WatchRequest, ch chan<- WatchResponse) error {
+	body, err := json.Marshal(map[string]*WatchRequest{"create_request": r})
+	if err != nil {
+		return err
+	}
+	resp, err := c.send(ctx, "/v3/watch", body, "text/event-stream")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(nil, maxEventSize)
+	for sc.Scan() {
+		data, ok := bytes.CutPrefix(sc.Bytes(), []byte("data:"))
+		if !ok {
+			// blank lines end events, other fields are not used
+			continue
+		}
+		var ev watchEvent
+		if err = json.Unmarshal(bytes.TrimPrefix(data, []byte(" ")), &ev); err != nil {
+			return err
+		}
+		if ev.Error != nil {
+			return ev.Error
+		}
+		wr := ev.Result
+		if wr == nil || (wr.Created && !wr.Canceled && len(wr.Events) == 0) {
+			continue
+		}
+		for _, e := range wr.Events {
+			// the zero value is omitted
+			if e.Type == "" {
+				e.Type = EventTypePut
+			}
+		}
+		select {
+		case ch <- *wr:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if wr.Canceled {
+			return nil
+		}
+	}
+	if err = sc.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
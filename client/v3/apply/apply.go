@@ -0,0 +1,259 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apply converges the keys under a prefix to a desired state.
+package apply
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// defaultMaxTxnOps matches the default --max-txn-ops of the server.
+	defaultMaxTxnOps  = 128
+	defaultMaxRetries = 5
+	readBatchLimit    = 1000
+)
+
+var (
+	// ErrKeyOutsidePrefix is returned when a desired key is not under the
+	// prefix of the Applier.
+	ErrKeyOutsidePrefix = errors.New("apply: key outside of the prefix")
+	// ErrTooManyConflicts is returned when the keys keep being changed
+	// concurrently after all the retries.
+	ErrTooManyConflicts = errors.New("apply: too many conflicting changes")
+)
+
+// Applier makes the keys under a prefix match a desired state with the
+// fewest writes. It reads the keys at a single revision, and only creates,
+// updates or deletes the ones that differ. Each write is guarded by the
+// revision of the key it was computed from, so a concurrent change makes
+// the Applier read the keys again instead of overwriting it.
+type Applier struct {
+	kv         clientv3.KV
+	prefix     string
+	maxTxnOps  int
+	maxRetries int
+}
+
+// Option configures an Applier.
+type Option func(*Applier)
+
+// WithMaxTxnOps sets the maximum number of writes in a transaction. It must
+// not exceed the --max-txn-ops of the cluster. Applying more writes takes
+// several transactions, so the changes are not atomic as a whole.
+func WithMaxTxnOps(n int) Option {
+	return func(a *Applier) {
+		if n > 0 {
+			a.maxTxnOps = n
+		}
+	}
+}
+
+// WithMaxRetries sets how many times the keys are read again after a
+// concurrent change before Apply gives up.
+func WithMaxRetries(n int) Option {
+	return func(a *Applier) {
+		if n >= 0 {
+			a.maxRetries = n
+		}
+	}
+}
+
+// New returns an Applier of the keys under prefix. An empty prefix covers
+// the whole keyspace.
+func New(kv clientv3.KV, prefix string, opts ...Option) *Applier {
+	a := &Applier{kv: kv, prefix: prefix, maxTxnOps: defaultMaxTxnOps, maxRetries: defaultMaxRetries}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Report describes the changes made by Apply.
+type Report struct {
+	// Created are the keys that were written without existing before.
+	Created []string
+	// Updated are the existing keys whose value was changed.
+	Updated []string
+	// Deleted are the keys that were pruned.
+	Deleted []string
+	// Revision is the revision of the last change, or the revision the keys
+	// were read at if they already matched the desired state.
+	Revision int64
+	// Conflicts is the number of times the keys were read again because
+	// they were changed concurrently.
+	Conflicts int
+}
+
+// Changed returns whether Apply wrote any key.
+func (r *Report) Changed() bool {
+	return len(r.Created)+len(r.Updated)+len(r.Deleted) > 0
+}
+
+// Apply makes the keys under the prefix hold the desired values. Keys are
+// written without a lease. If prune is set, the keys under the prefix that
+// are not desired are deleted. Apply is idempotent: applying the same state
+// again changes nothing. On error, the report holds the changes made so far.
+func (a *Applier) Apply(ctx context.Context, desired map[string][]byte, prune bool) (*Report, error) {
+	for key := range desired {
+		if !strings.HasPrefix(key, a.prefix) {
+			return nil, fmt.Errorf("%w: %q", ErrKeyOutsidePrefix, key)
+		}
+	}
+	report := &Report{}
+	for {
+		current, rev, err := a.read(ctx)
+		if err != nil {
+			return report, err
+		}
+		changes := diff(current, desired, prune)
+		if len(changes) == 0 {
+			if !report.Changed() {
+				report.Revision = rev
+			}
+			return report, nil
+		}
+		conflict, err := a.write(ctx, changes, report)
+		if err != nil || !conflict {
+			return report, err
+		}
+		report.Conflicts++
+		if report.Conflicts > a.maxRetries {
+			return report, ErrTooManyConflicts
+		}
+	}
+}
+
+// read returns the keys under the prefix at a single revision.
+func (a *Applier) read(ctx context.Context) (map[string]*mvccpb.KeyValue, int64, error) {
+	key, end := a.prefix, clientv3.GetPrefixRangeEnd(a.prefix)
+	if a.prefix == "" {
+		key, end = "\x00", "\x00"
+	}
+	current := make(map[string]*mvccpb.KeyValue)
+	var rev int64
+	for {
+		resp, err := a.kv.Get(ctx, key, clientv3.WithRange(end), clientv3.WithRev(rev), clientv3.WithLimit(readBatchLimit))
+		if err != nil {
+			return nil, 0, err
+		}
+		if rev == 0 {
+			rev = resp.Header.Revision
+		}
+		for _, kv := range resp.Kvs {
+			current[string(kv.Key)] = kv
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return current, rev, nil
+		}
+		key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
+
+// write commits the changes in transactions of up to maxTxnOps writes, and
+// records the committed ones in the report. It returns whether a transaction
+// failed because a key was changed concurrently.
+func (a *Applier) write(ctx context.Context, changes []change, report *Report) (conflict bool, err error) {
+	for len(changes) > 0 {
+		batch := changes[:min(a.maxTxnOps, len(changes))]
+		changes = changes[len(batch):]
+
+		cmps := make([]clientv3.Cmp, len(batch))
+		ops := make([]clientv3.Op, len(batch))
+		for i, c := range batch {
+			cmps[i], ops[i] = c.cmp(), c.op()
+		}
+		resp, err := a.kv.Txn(ctx).If(cmps...).Then(ops...).Commit()
+		if err != nil {
+			return false, err
+		}
+		if !resp.Succeeded {
+			return true, nil
+		}
+		report.Revision = resp.Header.Revision
+		for _, c := range batch {
+			switch c.kind {
+			case create:
+				report.Created = append(report.Created, c.key)
+			case update:
+				report.Updated = append(report.Updated, c.key)
+			case remove:
+				report.Deleted = append(report.Deleted, c.key)
+			}
+		}
+	}
+	return false, nil
+}
+
+type changeKind int
+
+const (
+	create changeKind = iota
+	update
+	remove
+)
+
+// change is a write of a key, guarded by the revision the key was read at.
+type change struct {
+	kind        changeKind
+	key         string
+	value       []byte
+	modRevision int64
+}
+
+func (c change) cmp() clientv3.Cmp {
+	if c.kind == create {
+		return clientv3.Compare(clientv3.CreateRevision(c.key), "=", 0)
+	}
+	return clientv3.Compare(clientv3.ModRevision(c.key), "=", c.modRevision)
+}
+
+func (c change) op() clientv3.Op {
+	if c.kind == remove {
+		return clientv3.OpDelete(c.key)
+	}
+	return clientv3.OpPut(c.key, string(c.value))
+}
+
+// diff returns the changes turning current into desired, sorted by key.
+func diff(current map[string]*mvccpb.KeyValue, desired map[string][]byte, prune bool) []change {
+	var changes []change
+	for key, value := range desired {
+		kv, ok := current[key]
+		switch {
+		case !ok:
+			changes = append(changes, change{kind: create, key: key, value: value})
+		case !bytes.Equal(kv.Value, value):
+			changes = append(changes, change{kind: update, key: key, value: value, modRevision: kv.ModRevision})
+		}
+	}
+	if prune {
+		for key, kv := range current {
+			if _, ok := desired[key]; !ok {
+				changes = append(changes, change{kind: remove, key: key, modRevision: kv.ModRevision})
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].key < changes[j].key })
+	return changes
+}
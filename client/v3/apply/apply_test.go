@@ -0,0 +1,47 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestDiff(t *testing.T) {
+	current := map[string]*mvccpb.KeyValue{
+		"a": {Key: []byte("a"), Value: []byte("1"), ModRevision: 2},
+		"b": {Key: []byte("b"), Value: []byte("2"), ModRevision: 3},
+		"c": {Key: []byte("c"), Value: []byte("3"), ModRevision: 4},
+	}
+	desired := map[string][]byte{
+		"a": []byte("1"),
+		"b": []byte("20"),
+		"d": []byte("4"),
+	}
+
+	assert.Equal(t, []change{
+		{kind: update, key: "b", value: []byte("20"), modRevision: 3},
+		{kind: create, key: "d", value: []byte("4")},
+	}, diff(current, desired, false))
+	assert.Equal(t, []change{
+		{kind: update, key: "b", value: []byte("20"), modRevision: 3},
+		{kind: remove, key: "c", modRevision: 4},
+		{kind: create, key: "d", value: []byte("4")},
+	}, diff(current, desired, true))
+	assert.Empty(t, diff(current, map[string][]byte{"a": []byte("1"), "b": []byte("2"), "c": []byte("3")}, true))
+}
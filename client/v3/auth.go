@@ -33,6 +33,7 @@ type (
 	AuthUserAddResponse              pb.AuthUserAddResponse
 	AuthUserDeleteResponse           pb.AuthUserDeleteResponse
 	AuthUserChangePasswordResponse   pb.AuthUserChangePasswordResponse
+	AuthUserRotatePasswordResponse   pb.AuthUserRotatePasswordResponse
 	AuthUserGrantRoleResponse        pb.AuthUserGrantRoleResponse
 	AuthUserGetResponse              pb.AuthUserGetResponse
 	AuthUserRevokeRoleResponse       pb.AuthUserRevokeRoleResponse
@@ -44,6 +45,9 @@ type (
 	AuthUserListResponse             pb.AuthUserListResponse
 	AuthRoleListResponse             pb.AuthRoleListResponse
 
+	AuthRoleGrantRPCPermissionResponse  pb.AuthRoleGrantRPCPermissionResponse
+	AuthRoleRevokeRPCPermissionResponse pb.AuthRoleRevokeRPCPermissionResponse
+
 	PermissionType authpb.Permission_Type
 	Permission     authpb.Permission
 )
@@ -75,18 +79,34 @@ type Auth interface {
 	// UserAddWithOptions adds a new user to an etcd cluster with some options.
 	UserAddWithOptions(ctx context.Context, name string, password string, opt *UserAddOptions) (*AuthUserAddResponse, error)
 
+	// UserAddWithPasswordHash adds a new user to an etcd cluster with the
+	// password hash of a user, as returned by UserGetWithPasswordHash.
+	UserAddWithPasswordHash(ctx context.Context, name string, hashedPassword string) (*AuthUserAddResponse, error)
+
 	// UserDelete deletes a user from an etcd cluster.
 	UserDelete(ctx context.Context, name string) (*AuthUserDeleteResponse, error)
 
 	// UserChangePassword changes a password of a user.
 	UserChangePassword(ctx context.Context, name string, password string) (*AuthUserChangePasswordResponse, error)
 
+	// UserChangePasswordHash replaces the password of a user with the password
+	// hash of a user, as returned by UserGetWithPasswordHash.
+	UserChangePasswordHash(ctx context.Context, name string, hashedPassword string) (*AuthUserChangePasswordResponse, error)
+
+	// UserRotatePassword replaces the password of a user with a generated one,
+	// which is only returned by this call.
+	UserRotatePassword(ctx context.Context, name string) (*AuthUserRotatePasswordResponse, error)
+
 	// UserGrantRole grants a role to a user.
 	UserGrantRole(ctx context.Context, user string, role string) (*AuthUserGrantRoleResponse, error)
 
 	// UserGet gets a detailed information of a user.
 	UserGet(ctx context.Context, name string) (*AuthUserGetResponse, error)
 
+	// UserGetWithPasswordHash gets a detailed information of a user including
+	// its password hash. It requires the root role.
+	UserGetWithPasswordHash(ctx context.Context, name string) (*AuthUserGetResponse, error)
+
 	// UserList gets a list of all users.
 	UserList(ctx context.Context) (*AuthUserListResponse, error)
 
@@ -110,6 +130,13 @@ type Auth interface {
 
 	// RoleDelete deletes a role.
 	RoleDelete(ctx context.Context, role string) (*AuthRoleDeleteResponse, error)
+
+	// RoleGrantRPCPermission allows a role to call an administrative RPC
+	// method, such as "Maintenance.Defragment" or "Cluster.MemberAdd".
+	RoleGrantRPCPermission(ctx context.Context, role string, method string) (*AuthRoleGrantRPCPermissionResponse, error)
+
+	// RoleRevokeRPCPermission revokes an administrative RPC method from a role.
+	RoleRevokeRPCPermission(ctx context.Context, role string, method string) (*AuthRoleRevokeRPCPermissionResponse, error)
 }
 
 type authClient struct {
@@ -163,6 +190,11 @@ func (auth *authClient) UserAddWithOptions(ctx context.Context, name string, pas
 	return (*AuthUserAddResponse)(resp), ContextError(ctx, err)
 }
 
+func (auth *authClient) UserAddWithPasswordHash(ctx context.Context, name string, hashedPassword string) (*AuthUserAddResponse, error) {
+	resp, err := auth.remote.UserAdd(ctx, &pb.AuthUserAddRequest{Name: name, HashedPassword: hashedPassword, Options: &authpb.UserAddOptions{NoPassword: false}}, auth.callOpts...)
+	return (*AuthUserAddResponse)(resp), ContextError(ctx, err)
+}
+
 func (auth *authClient) UserDelete(ctx context.Context, name string) (*AuthUserDeleteResponse, error) {
 	resp, err := auth.remote.UserDelete(ctx, &pb.AuthUserDeleteRequest{Name: name}, auth.callOpts...)
 	return (*AuthUserDeleteResponse)(resp), ContextError(ctx, err)
@@ -173,6 +205,16 @@ func (auth *authClient) UserChangePassword(ctx context.Context, name string, pas
 	return (*AuthUserChangePasswordResponse)(resp), ContextError(ctx, err)
 }
 
+func (auth *authClient) UserChangePasswordHash(ctx context.Context, name string, hashedPassword string) (*AuthUserChangePasswordResponse, error) {
+	resp, err := auth.remote.UserChangePassword(ctx, &pb.AuthUserChangePasswordRequest{Name: name, HashedPassword: hashedPassword}, auth.callOpts...)
+	return (*AuthUserChangePasswordResponse)(resp), ContextError(ctx, err)
+}
+
+func (auth *authClient) UserRotatePassword(ctx context.Context, name string) (*AuthUserRotatePasswordResponse, error) {
+	resp, err := auth.remote.UserRotatePassword(ctx, &pb.AuthUserRotatePasswordRequest{Name: name}, auth.callOpts...)
+	return (*AuthUserRotatePasswordResponse)(resp), ContextError(ctx, err)
+}
+
 func (auth *authClient) UserGrantRole(ctx context.Context, user string, role string) (*AuthUserGrantRoleResponse, error) {
 	resp, err := auth.remote.UserGrantRole(ctx, &pb.AuthUserGrantRoleRequest{User: user, Role: role}, auth.callOpts...)
 	return (*AuthUserGrantRoleResponse)(resp), ContextError(ctx, err)
@@ -183,6 +225,11 @@ func (auth *authClient) UserGet(ctx context.Context, name string) (*AuthUserGetR
 	return (*AuthUserGetResponse)(resp), ContextError(ctx, err)
 }
 
+func (auth *authClient) UserGetWithPasswordHash(ctx context.Context, name string) (*AuthUserGetResponse, error) {
+	resp, err := auth.remote.UserGet(ctx, &pb.AuthUserGetRequest{Name: name, WithPasswordHash: true}, auth.callOpts...)
+	return (*AuthUserGetResponse)(resp), ContextError(ctx, err)
+}
+
 func (auth *authClient) UserList(ctx context.Context) (*AuthUserListResponse, error) {
 	resp, err := auth.remote.UserList(ctx, &pb.AuthUserListRequest{}, auth.callOpts...)
 	return (*AuthUserListResponse)(resp), ContextError(ctx, err)
@@ -228,6 +275,16 @@ func (auth *authClient) RoleDelete(ctx context.Context, role string) (*AuthRoleD
 	return (*AuthRoleDeleteResponse)(resp), ContextError(ctx, err)
 }
 
+func (auth *authClient) RoleGrantRPCPermission(ctx context.Context, role string, method string) (*AuthRoleGrantRPCPermissionResponse, error) {
+	resp, err := auth.remote.RoleGrantRPCPermission(ctx, &pb.AuthRoleGrantRPCPermissionRequest{Name: role, Method: method}, auth.callOpts...)
+	return (*AuthRoleGrantRPCPermissionResponse)(resp), ContextError(ctx, err)
+}
+
+func (auth *authClient) RoleRevokeRPCPermission(ctx context.Context, role string, method string) (*AuthRoleRevokeRPCPermissionResponse, error) {
+	resp, err := auth.remote.RoleRevokeRPCPermission(ctx, &pb.AuthRoleRevokeRPCPermissionRequest{Role: role, Method: method}, auth.callOpts...)
+	return (*AuthRoleRevokeRPCPermissionResponse)(resp), ContextError(ctx, err)
+}
+
 func StrToPermissionType(s string) (PermissionType, error) {
 	val, ok := authpb.Permission_Type_value[strings.ToUpper(s)]
 	if ok {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 	"time"
@@ -28,12 +29,16 @@ import (
 	"google.golang.org/grpc/codes"
 	grpccredentials "google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	// advertise gzip so that servers compressing responses can use it
+	_ "google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
 	"go.etcd.io/etcd/client/pkg/v3/verify"
 	"go.etcd.io/etcd/client/v3/credentials"
 	"go.etcd.io/etcd/client/v3/internal/endpoint"
@@ -54,12 +59,27 @@ type Client struct {
 	Watcher
 	Auth
 	Maintenance
+	Counter
 
 	conn *grpc.ClientConn
+	// watchConns and leaseConns are the connections dedicated to watch
+	// streams and lease RPCs, if any. See Config.ConnectionsPerEndpoint.
+	watchConns *connPool
+	leaseConns *connPool
+	// epConns holds a connection to each endpoint, for the requests that
+	// must reach a given member.
+	epConns *endpointConns
+	// hedger sends hedged reads, see WithHedging.
+	hedger *hedger
+	// leader routes linearizable requests to the leader, see
+	// Config.LeaderRouting.
+	leader *leaderRouter
 
 	cfg      Config
 	creds    grpccredentials.TransportCredentials
 	resolver *resolver.EtcdManualResolver
+	// dialer dials the endpoints, through a proxy if one applies to them.
+	dialer *transport.ProxyDialer
 
 	epMu      *sync.RWMutex
 	endpoints []string
@@ -78,17 +98,30 @@ type Client struct {
 
 	callOpts []grpc.CallOption
 
+	offlineQueueDir     string
+	offlineQueueOnError func(Op, error)
+	offlineQueue        *OfflineQueue
+
+	persistentCacheDir      string
+	persistentCachePrefixes []string
+	persistentCache         *PersistentCache
+
+	// metrics records the RPCs sent by the client, nil if not configured.
+	metrics Metrics
+
 	lgMu *sync.RWMutex
 	lg   *zap.Logger
+	// componentLgs are the loggers of the client components, derived from lg.
+	componentLgs map[LogComponent]*zap.Logger
 }
 
 // New creates a new etcdv3 client from a given configuration.
-func New(cfg Config) (*Client, error) {
+func New(cfg Config, opts ...Option) (*Client, error) {
 	if len(cfg.Endpoints) == 0 {
 		return nil, ErrNoAvailableEndpoints
 	}
 
-	return newClient(&cfg)
+	return newClient(&cfg, opts...)
 }
 
 // NewCtxClient creates a client with a context but no underlying grpc
@@ -103,10 +136,11 @@ func NewCtxClient(ctx context.Context, opts ...Option) *Client {
 	if c.lg == nil {
 		c.lg = zap.NewNop()
 	}
+	c.componentLgs = newComponentLoggers(c.lg, nil)
 	return c
 }
 
-// Option is a function type that can be passed as argument to NewCtxClient to configure client
+// Option is a function type that can be passed as argument to New or NewCtxClient to configure client
 type Option func(*Client)
 
 // NewFromURL creates a new etcdv3 client from a URL.
@@ -135,6 +169,7 @@ func WithZapLogger(lg *zap.Logger) Option {
 func (c *Client) WithLogger(lg *zap.Logger) *Client {
 	c.lgMu.Lock()
 	c.lg = lg
+	c.componentLgs = newComponentLoggers(lg, c.cfg.LogLevels)
 	c.lgMu.Unlock()
 	return c
 }
@@ -148,15 +183,40 @@ func (c *Client) GetLogger() *zap.Logger {
 	return l
 }
 
+// componentLogger returns the logger of a client component.
+func (c *Client) componentLogger(comp LogComponent) *zap.Logger {
+	c.lgMu.RLock()
+	l, ok := c.componentLgs[comp]
+	if !ok {
+		l = c.lg
+	}
+	c.lgMu.RUnlock()
+	return l
+}
+
 // Close shuts down the client's etcd connections.
 func (c *Client) Close() error {
 	c.cancel()
+	if c.offlineQueue != nil {
+		c.offlineQueue.close()
+	}
+	if c.persistentCache != nil {
+		c.persistentCache.close()
+	}
 	if c.Watcher != nil {
 		c.Watcher.Close()
 	}
 	if c.Lease != nil {
 		c.Lease.Close()
 	}
+	for _, p := range []*connPool{c.watchConns, c.leaseConns} {
+		if p != nil {
+			p.close()
+		}
+	}
+	if c.epConns != nil {
+		c.epConns.close()
+	}
 	if c.conn != nil {
 		return ContextError(c.ctx, c.conn.Close())
 	}
@@ -185,30 +245,66 @@ func (c *Client) SetEndpoints(eps ...string) {
 	c.endpoints = eps
 
 	c.resolver.SetEndpoints(eps)
+	for _, p := range []*connPool{c.watchConns, c.leaseConns} {
+		if p != nil {
+			p.setEndpoints(eps)
+		}
+	}
+	if c.epConns != nil {
+		c.epConns.setEndpoints(eps)
+	}
+	if c.leader != nil {
+		c.leader.setEndpoints(eps)
+	}
 }
 
 // Sync synchronizes client's endpoints with the known endpoints from the etcd membership.
+// Draining members are skipped unless every member is draining.
 func (c *Client) Sync(ctx context.Context) error {
 	mresp, err := c.MemberList(ctx)
 	if err != nil {
 		return err
 	}
-	var eps []string
+	var eps, preferred, draining []string
 	for _, m := range mresp.Members {
 		if len(m.Name) != 0 && !m.IsLearner {
+			if m.IsDraining {
+				draining = append(draining, m.ClientURLs...)
+				continue
+			}
 			eps = append(eps, m.ClientURLs...)
+			if len(c.cfg.PreferredMemberLabels) != 0 && hasLabels(m, c.cfg.PreferredMemberLabels) {
+				preferred = append(preferred, m.ClientURLs...)
+			}
 		}
 	}
+	if len(preferred) != 0 {
+		eps = preferred
+	}
+	// Keep the draining members only if no other member can serve.
+	if len(eps) == 0 {
+		eps = draining
+	}
 	// The linearizable `MemberList` returned successfully, so the
 	// endpoints shouldn't be empty.
 	verify.Verify("empty endpoints returned from etcd cluster", func() (bool, map[string]any) {
 		return len(eps) > 0, nil
 	})
 	c.SetEndpoints(eps...)
-	c.lg.Debug("set etcd endpoints by autoSync", zap.Strings("endpoints", eps))
+	c.componentLogger(LogComponentBalancer).Debug("set etcd endpoints by autoSync", zap.Strings("endpoints", eps))
 	return nil
 }
 
+// hasLabels returns true if the member has all the given labels.
+func hasLabels(m *pb.Member, labels map[string]string) bool {
+	for k, v := range labels {
+		if mv, ok := m.Labels[k]; !ok || mv != v {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *Client) autoSync() {
 	if c.cfg.AutoSyncInterval == time.Duration(0) {
 		return
@@ -223,7 +319,7 @@ func (c *Client) autoSync() {
 			err := c.Sync(ctx)
 			cancel()
 			if err != nil && !errors.Is(err, c.ctx.Err()) {
-				c.lg.Info("Auto sync endpoints failed.", zap.Error(err))
+				c.componentLogger(LogComponentBalancer).Info("Auto sync endpoints failed.", zap.Error(err))
 			}
 		}
 	}
@@ -241,6 +337,7 @@ func (c *Client) dialSetupOpts(creds grpccredentials.TransportCredentials, dopts
 		}
 		opts = append(opts, grpc.WithKeepaliveParams(params))
 	}
+	opts = append(opts, grpc.WithContextDialer(c.dialContext))
 	opts = append(opts, dopts...)
 
 	if creds != nil {
@@ -268,16 +365,41 @@ func (c *Client) dialSetupOpts(creds grpccredentials.TransportCredentials, dopts
 	// TODO: Replace all of clientv3/retry.go with RetryPolicy:
 	// https://github.com/grpc/grpc-proto/blob/cdd9ed5c3d3f87aef62f373b93361cf7bddc620d/grpc/service_config/service_config.proto#L130
 	rrBackoff := withBackoff(c.roundRobinQuorumBackoff(backoffWaitBetween, backoffJitterFraction))
-	opts = append(opts,
-		// Disable stream retry by default since go-grpc-middleware/retry does not support client streams.
-		// Streams that are safe to retry are enabled individually.
-		grpc.WithStreamInterceptor(c.streamClientInterceptor(withMax(0), rrBackoff)),
-		grpc.WithUnaryInterceptor(c.unaryClientInterceptor(withMax(unaryMaxRetries), rrBackoff)),
-	)
+	// Disable stream retry by default since go-grpc-middleware/retry does not support client streams.
+	// Streams that are safe to retry are enabled individually.
+	streamInt := c.streamClientInterceptor(withMax(0), rrBackoff)
+	unaryInt := c.unaryClientInterceptor(withMax(unaryMaxRetries), rrBackoff)
+	if c.metrics != nil {
+		streamInt = c.streamMetricsInterceptor(streamInt)
+		unaryInt = c.unaryMetricsInterceptor(unaryInt)
+	}
+	opts = append(opts, grpc.WithStreamInterceptor(streamInt), grpc.WithUnaryInterceptor(unaryInt))
 
 	return opts
 }
 
+// dialContext dials the address of an endpoint given by the resolver.
+func (c *Client) dialContext(ctx context.Context, addr string) (net.Conn, error) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return c.dialer.DialContext(ctx, "unix", strings.TrimPrefix(path, "//"))
+	}
+	return c.dialer.DialContext(ctx, "tcp", addr)
+}
+
+// newDialer returns the dialer reaching the endpoints through the given
+// proxy, or through the proxy set by the environment if empty.
+func newDialer(proxy string) (*transport.ProxyDialer, error) {
+	d := &transport.ProxyDialer{Proxy: transport.ProxyFromEnvironment}
+	if proxy != "" {
+		u, err := transport.ParseProxyURL(proxy)
+		if err != nil {
+			return nil, err
+		}
+		d.Proxy = transport.ProxyURL(u)
+	}
+	return d, nil
+}
+
 // Dial connects to a single endpoint using the client's config.
 func (c *Client) Dial(ep string) (*grpc.ClientConn, error) {
 	creds := c.credentialsForEndpoint(ep)
@@ -374,7 +496,7 @@ func (c *Client) credentialsForEndpoint(ep string) grpccredentials.TransportCred
 	}
 }
 
-func newClient(cfg *Config) (*Client, error) {
+func newClient(cfg *Config, opts ...Option) (*Client, error) {
 	if cfg == nil {
 		cfg = &Config{}
 	}
@@ -404,10 +526,17 @@ func newClient(cfg *Config) (*Client, error) {
 		callOpts: defaultCallOpts,
 		lgMu:     new(sync.RWMutex),
 	}
+	for _, opt := range opts {
+		opt(client)
+	}
 
 	var err error
-	if cfg.Logger != nil {
+	if client.lg != nil {
+		// set by WithZapLogger
+	} else if cfg.Logger != nil {
 		client.lg = cfg.Logger
+	} else if cfg.LogHandler != nil {
+		client.lg = NewSlogLogger(cfg.LogHandler).Named("etcd-client")
 	} else if cfg.LogConfig != nil {
 		client.lg, err = cfg.LogConfig.Build()
 	} else {
@@ -419,6 +548,19 @@ func newClient(cfg *Config) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	client.componentLgs = newComponentLoggers(client.lg, cfg.LogLevels)
+
+	if client.dialer, err = newDialer(cfg.Proxy); err != nil {
+		return nil, err
+	}
+
+	if cfg.Metrics != nil {
+		client.metrics = cfg.Metrics
+	} else if cfg.MeterProvider != nil {
+		if client.metrics, err = newOTelMetrics(cfg.MeterProvider); err != nil {
+			return nil, err
+		}
+	}
 
 	if cfg.Username != "" && cfg.Password != "" {
 		client.Username = cfg.Username
@@ -467,13 +609,40 @@ func newClient(cfg *Config) (*Client, error) {
 		return nil, err
 	}
 	client.conn = conn
+	if err = client.dialConnPools(); err != nil {
+		client.Close()
+		return nil, err
+	}
 
+	client.epConns = newEndpointConns(client)
+	client.hedger = newHedger(client)
+	if cfg.LeaderRouting {
+		client.leader = newLeaderRouter(client)
+	}
 	client.Cluster = NewCluster(client)
 	client.KV = NewKV(client)
 	client.Lease = NewLease(client)
 	client.Watcher = NewWatcher(client)
 	client.Auth = NewAuth(client)
 	client.Maintenance = NewMaintenance(client)
+	client.Counter = NewCounter(client)
+
+	if client.offlineQueueDir != "" {
+		client.offlineQueue, err = newOfflineQueue(client, client.KV)
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+		client.KV = client.offlineQueue
+	}
+	if client.persistentCacheDir != "" {
+		client.persistentCache, err = newPersistentCache(client, client.KV)
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+		client.KV = client.persistentCache
+	}
 
 	// get token with established connection
 	ctx, cancel = client.ctx, func() {}
@@ -508,10 +677,10 @@ func (c *Client) roundRobinQuorumBackoff(waitBetween time.Duration, jitterFracti
 		n := uint(len(c.Endpoints()))
 		quorum := (n/2 + 1)
 		if attempt%quorum == 0 {
-			c.lg.Debug("backoff", zap.Uint("attempt", attempt), zap.Uint("quorum", quorum), zap.Duration("waitBetween", waitBetween), zap.Float64("jitterFraction", jitterFraction))
+			c.componentLogger(LogComponentRetry).Debug("backoff", zap.Uint("attempt", attempt), zap.Uint("quorum", quorum), zap.Duration("waitBetween", waitBetween), zap.Float64("jitterFraction", jitterFraction))
 			return jitterUp(waitBetween, jitterFraction)
 		}
-		c.lg.Debug("backoff skipped", zap.Uint("attempt", attempt), zap.Uint("quorum", quorum))
+		c.componentLogger(LogComponentRetry).Debug("backoff skipped", zap.Uint("attempt", attempt), zap.Uint("quorum", quorum))
 		return 0
 	}
 }
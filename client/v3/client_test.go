@@ -15,10 +15,12 @@
 package clientv3
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"io"
 	"net"
+	"net/http"
 	"sync"
 	"testing"
 	"time"
@@ -152,6 +154,43 @@ func TestDialNoTimeout(t *testing.T) {
 	c.Close()
 }
 
+func TestDialProxy(t *testing.T) {
+	_, err := NewClient(t, Config{Endpoints: []string{"127.0.0.1:12345"}, Proxy: "ftp://127.0.0.1:21"})
+	require.Error(t, err, "unsupported proxy schemes are rejected")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	connects := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			if req, err := http.ReadRequest(bufio.NewReader(conn)); err == nil {
+				connects <- req.Method + " " + req.Host
+				io.WriteString(conn, "HTTP/1.1 503 Service Unavailable\r\n\r\n")
+			}
+			conn.Close()
+		}
+	}()
+
+	c, err := NewClient(t, Config{Endpoints: []string{"http://127.0.0.1:12345"}, Proxy: "http://" + ln.Addr().String()})
+	require.NoError(t, err)
+	defer c.Close()
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	_, err = c.Get(ctx, "foo")
+	require.Error(t, err)
+	select {
+	case got := <-connects:
+		require.Equal(t, "CONNECT 127.0.0.1:12345", got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the endpoint was not dialed through the proxy")
+	}
+}
+
 func TestMaxUnaryRetries(t *testing.T) {
 	maxUnaryRetries := uint(10)
 	cfg := Config{
@@ -404,6 +443,27 @@ func TestSyncFiltersMembers(t *testing.T) {
 	}
 }
 
+func TestSyncPrefersLabeledMembers(t *testing.T) {
+	c, _ := NewClient(t, Config{
+		Endpoints:             []string{"http://254.0.0.1:12345"},
+		PreferredMemberLabels: map[string]string{"zone": "a"},
+	})
+	defer c.Close()
+	members := []*etcdserverpb.Member{
+		{ID: 1, Name: "zone-a", ClientURLs: []string{"http://254.0.0.2:12345"}, Labels: map[string]string{"zone": "a"}},
+		{ID: 2, Name: "zone-b", ClientURLs: []string{"http://254.0.0.3:12345"}, Labels: map[string]string{"zone": "b"}},
+		{ID: 3, Name: "unlabeled", ClientURLs: []string{"http://254.0.0.4:12345"}},
+	}
+	c.Cluster = &mockCluster{members}
+	require.NoError(t, c.Sync(t.Context()))
+	require.Equal(t, []string{"http://254.0.0.2:12345"}, c.Endpoints())
+
+	// without a matching member, all members are used
+	c.Cluster = &mockCluster{members[1:]}
+	require.NoError(t, c.Sync(t.Context()))
+	require.Equal(t, []string{"http://254.0.0.3:12345", "http://254.0.0.4:12345"}, c.Endpoints())
+}
+
 func TestMinSupportedVersion(t *testing.T) {
 	testutil.BeforeTest(t)
 	tests := []struct {
@@ -533,6 +593,10 @@ func (mm mockMaintenance) SnapshotWithVersion(ctx context.Context) (*SnapshotRes
 	return nil, nil
 }
 
+func (mm mockMaintenance) SnapshotWithOptions(ctx context.Context, opts SnapshotOptions) (*SnapshotResponse, error) {
+	return nil, nil
+}
+
 func (mm mockMaintenance) Snapshot(ctx context.Context) (io.ReadCloser, error) {
 	return nil, nil
 }
@@ -545,6 +609,82 @@ func (mm mockMaintenance) Downgrade(ctx context.Context, action DowngradeAction,
 	return nil, nil
 }
 
+func (mm mockMaintenance) CompactionHoldGrant(ctx context.Context, name string, rev, ttl int64) (*CompactionHoldGrantResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) CompactionHoldRenew(ctx context.Context, id, rev, ttl int64) (*CompactionHoldGrantResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) CompactionHoldRevoke(ctx context.Context, id int64) (*CompactionHoldRevokeResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) CompactionHoldList(ctx context.Context) (*CompactionHoldListResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) GarbageCollect(ctx context.Context, emptyLeaseMinAge time.Duration, repair bool) (*GarbageCollectResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) MemoryStats(ctx context.Context, endpoint string) (*MemoryStatsResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) VerifySnapshot(ctx context.Context, endpoint string, rev int64, hash uint32, compactRev int64) (*VerifySnapshotResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) HotKeys(ctx context.Context, endpoint string, sortBy HotKeysSortBy, limit int64, reset bool) (*HotKeysResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) JobList(ctx context.Context, endpoint string) (*JobListResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) JobStatus(ctx context.Context, endpoint string, id int64) (*JobStatusResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) JobCancel(ctx context.Context, endpoint string, id int64) (*JobCancelResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) NewerFields(ctx context.Context, endpoint string, reset bool) (*NewerFieldsResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) Checkpoint(ctx context.Context, endpoint string) (*CheckpointResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) Reseed(ctx context.Context, endpoint string) (*ReseedResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) FencePrefix(ctx context.Context, prefix string, ttl int64, token string) (*FencePrefixResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) SetRequestLogging(ctx context.Context, endpoint string, rl RequestLogging) (*SetRequestLoggingResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) DrainMember(ctx context.Context, endpoint string, undrain bool) (*DrainMemberResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) StorageStats(ctx context.Context, endpoint string) (*StorageStatsResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) Export(ctx context.Context, key string, opts ...OpOption) (*ExportResponse, error) {
+	return nil, nil
+}
+
 type mockFailingAuthServer struct {
 	*etcdserverpb.UnimplementedAuthServer
 }
@@ -577,6 +717,14 @@ func (mc *mockCluster) MemberAddAsLearner(ctx context.Context, peerAddrs []strin
 	return nil, nil
 }
 
+func (mc *mockCluster) MemberAddAsStandby(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
+	return nil, nil
+}
+
+func (mc *mockCluster) MemberAddDryRun(ctx context.Context, peerAddrs []string, isLearner bool) (*MemberAddResponse, error) {
+	return nil, nil
+}
+
 func (mc *mockCluster) MemberRemove(ctx context.Context, id uint64) (*MemberRemoveResponse, error) {
 	return nil, nil
 }
@@ -585,6 +733,26 @@ func (mc *mockCluster) MemberUpdate(ctx context.Context, id uint64, peerAddrs []
 	return nil, nil
 }
 
+func (mc *mockCluster) MemberUpdateLabels(ctx context.Context, id uint64, labels map[string]string) (*MemberUpdateResponse, error) {
+	return nil, nil
+}
+
+func (mc *mockCluster) MemberUpdateLeadership(ctx context.Context, id uint64, disallowed bool) (*MemberUpdateResponse, error) {
+	return nil, nil
+}
+
 func (mc *mockCluster) MemberPromote(ctx context.Context, id uint64) (*MemberPromoteResponse, error) {
 	return nil, nil
 }
+
+func (mc *mockCluster) MemberPromoteReplacing(ctx context.Context, id, replaceID uint64) (*MemberPromoteResponse, error) {
+	return nil, nil
+}
+
+func (mc *mockCluster) ClusterConfigGet(ctx context.Context) (*ClusterConfigGetResponse, error) {
+	return nil, nil
+}
+
+func (mc *mockCluster) ClusterConfigSet(ctx context.Context, cfg *ClusterConfig) (*ClusterConfigSetResponse, error) {
+	return nil, nil
+}
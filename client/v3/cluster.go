@@ -30,6 +30,10 @@ type (
 	MemberRemoveResponse  pb.MemberRemoveResponse
 	MemberUpdateResponse  pb.MemberUpdateResponse
 	MemberPromoteResponse pb.MemberPromoteResponse
+
+	ClusterConfig            pb.ClusterConfig
+	ClusterConfigGetResponse pb.ClusterConfigGetResponse
+	ClusterConfigSetResponse pb.ClusterConfigSetResponse
 )
 
 type Cluster interface {
@@ -42,14 +46,44 @@ type Cluster interface {
 	// MemberAddAsLearner adds a new learner member into the cluster.
 	MemberAddAsLearner(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error)
 
+	// MemberAddAsStandby adds a new warm standby member into the cluster. A standby
+	// member replicates data as a raft learner but never serves client requests
+	// until it is promoted.
+	MemberAddAsStandby(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error)
+
+	// MemberAddDryRun validates adding a new member, or a new learner member if
+	// isLearner is set, without adding it. The response reports the impact of
+	// the addition on the quorum. It requires etcd 3.7 or later on every member.
+	MemberAddDryRun(ctx context.Context, peerAddrs []string, isLearner bool) (*MemberAddResponse, error)
+
 	// MemberRemove removes an existing member from the cluster.
 	MemberRemove(ctx context.Context, id uint64) (*MemberRemoveResponse, error)
 
 	// MemberUpdate updates the peer addresses of the member.
 	MemberUpdate(ctx context.Context, id uint64, peerAddrs []string) (*MemberUpdateResponse, error)
 
+	// MemberUpdateLabels replaces the labels of the member, such as its zone or rack.
+	MemberUpdateLabels(ctx context.Context, id uint64, labels map[string]string) (*MemberUpdateResponse, error)
+
+	// MemberUpdateLeadership sets whether the member is disallowed to be the raft leader.
+	// A disallowed member that wins an election transfers its leadership to an eligible member.
+	MemberUpdateLeadership(ctx context.Context, id uint64, disallowed bool) (*MemberUpdateResponse, error)
+
 	// MemberPromote promotes a member from raft learner (non-voting) to raft voting member.
 	MemberPromote(ctx context.Context, id uint64) (*MemberPromoteResponse, error)
+
+	// MemberPromoteReplacing promotes a member from raft learner (non-voting) to raft voting member
+	// in place of the voting member replaceID, in a single configuration change, so that the
+	// promoted member takes over its slot.
+	MemberPromoteReplacing(ctx context.Context, id, replaceID uint64) (*MemberPromoteResponse, error)
+
+	// ClusterConfigGet gets the cluster-wide configuration.
+	ClusterConfigGet(ctx context.Context) (*ClusterConfigGetResponse, error)
+
+	// ClusterConfigSet replaces the cluster-wide configuration, such as the
+	// protected prefixes that only allowed candidates of an election can write
+	// to. Such writes are made with a context from WithElectionLease.
+	ClusterConfigSet(ctx context.Context, cfg *ClusterConfig) (*ClusterConfigSetResponse, error)
 }
 
 type cluster struct {
@@ -74,14 +108,22 @@ func NewClusterFromClusterClient(remote pb.ClusterClient, c *Client) Cluster {
 }
 
 func (c *cluster) MemberAdd(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
-	return c.memberAdd(ctx, peerAddrs, false)
+	return c.memberAdd(ctx, peerAddrs, false, false, false)
 }
 
 func (c *cluster) MemberAddAsLearner(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
-	return c.memberAdd(ctx, peerAddrs, true)
+	return c.memberAdd(ctx, peerAddrs, true, false, false)
+}
+
+func (c *cluster) MemberAddAsStandby(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
+	return c.memberAdd(ctx, peerAddrs, true, true, false)
 }
 
-func (c *cluster) memberAdd(ctx context.Context, peerAddrs []string, isLearner bool) (*MemberAddResponse, error) {
+func (c *cluster) MemberAddDryRun(ctx context.Context, peerAddrs []string, isLearner bool) (*MemberAddResponse, error) {
+	return c.memberAdd(ctx, peerAddrs, isLearner, false, true)
+}
+
+func (c *cluster) memberAdd(ctx context.Context, peerAddrs []string, isLearner, isStandby, dryRun bool) (*MemberAddResponse, error) {
 	// fail-fast before panic in rafthttp
 	if _, err := types.NewURLs(peerAddrs); err != nil {
 		return nil, err
@@ -90,6 +132,8 @@ func (c *cluster) memberAdd(ctx context.Context, peerAddrs []string, isLearner b
 	r := &pb.MemberAddRequest{
 		PeerURLs:  peerAddrs,
 		IsLearner: isLearner,
+		IsStandby: isStandby,
+		DryRun:    dryRun,
 	}
 	resp, err := c.remote.MemberAdd(ctx, r, c.callOpts...)
 	if err != nil {
@@ -122,6 +166,26 @@ func (c *cluster) MemberUpdate(ctx context.Context, id uint64, peerAddrs []strin
 	return nil, ContextError(ctx, err)
 }
 
+func (c *cluster) MemberUpdateLabels(ctx context.Context, id uint64, labels map[string]string) (*MemberUpdateResponse, error) {
+	// it is safe to retry on update.
+	r := &pb.MemberUpdateRequest{ID: id, Labels: labels, UpdateLabels: true}
+	resp, err := c.remote.MemberUpdate(ctx, r, c.callOpts...)
+	if err == nil {
+		return (*MemberUpdateResponse)(resp), nil
+	}
+	return nil, ContextError(ctx, err)
+}
+
+func (c *cluster) MemberUpdateLeadership(ctx context.Context, id uint64, disallowed bool) (*MemberUpdateResponse, error) {
+	// it is safe to retry on update.
+	r := &pb.MemberUpdateRequest{ID: id, LeadershipDisallowed: disallowed, UpdateLeadership: true}
+	resp, err := c.remote.MemberUpdate(ctx, r, c.callOpts...)
+	if err == nil {
+		return (*MemberUpdateResponse)(resp), nil
+	}
+	return nil, ContextError(ctx, err)
+}
+
 func (c *cluster) MemberList(ctx context.Context, opts ...OpOption) (*MemberListResponse, error) {
 	opt := OpGet("", opts...)
 	resp, err := c.remote.MemberList(ctx, &pb.MemberListRequest{Linearizable: !opt.serializable}, c.callOpts...)
@@ -132,10 +196,32 @@ func (c *cluster) MemberList(ctx context.Context, opts ...OpOption) (*MemberList
 }
 
 func (c *cluster) MemberPromote(ctx context.Context, id uint64) (*MemberPromoteResponse, error) {
-	r := &pb.MemberPromoteRequest{ID: id}
+	return c.MemberPromoteReplacing(ctx, id, 0)
+}
+
+func (c *cluster) MemberPromoteReplacing(ctx context.Context, id, replaceID uint64) (*MemberPromoteResponse, error) {
+	r := &pb.MemberPromoteRequest{ID: id, ReplaceID: replaceID}
 	resp, err := c.remote.MemberPromote(ctx, r, c.callOpts...)
 	if err != nil {
 		return nil, ContextError(ctx, err)
 	}
 	return (*MemberPromoteResponse)(resp), nil
 }
+
+func (c *cluster) ClusterConfigGet(ctx context.Context) (*ClusterConfigGetResponse, error) {
+	resp, err := c.remote.ClusterConfigGet(ctx, &pb.ClusterConfigGetRequest{}, c.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ClusterConfigGetResponse)(resp), nil
+}
+
+func (c *cluster) ClusterConfigSet(ctx context.Context, cfg *ClusterConfig) (*ClusterConfigSetResponse, error) {
+	// it is safe to retry on set.
+	r := &pb.ClusterConfigSetRequest{Config: (*pb.ClusterConfig)(cfg)}
+	resp, err := c.remote.ClusterConfigSet(ctx, r, c.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ClusterConfigSetResponse)(resp), nil
+}
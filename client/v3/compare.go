@@ -64,6 +64,16 @@ func Compare(cmp Cmp, result string, v any) Cmp {
 		cmp.TargetUnion = &pb.Compare_ModRevision{ModRevision: mustInt64(v)}
 	case pb.Compare_LEASE:
 		cmp.TargetUnion = &pb.Compare_Lease{Lease: mustInt64orLeaseID(v)}
+	case pb.Compare_VALUE_INT:
+		cmp.TargetUnion = &pb.Compare_ValueInt{ValueInt: mustInt64(v)}
+	case pb.Compare_VALUE_SEMVER:
+		val, ok := v.(string)
+		if !ok {
+			panic("bad compare value")
+		}
+		cmp.TargetUnion = &pb.Compare_ValueSemver{ValueSemver: val}
+	case pb.Compare_FENCING_TOKEN:
+		cmp.TargetUnion = &pb.Compare_FencingToken{FencingToken: mustInt64(v)}
 	default:
 		panic("Unknown compare type")
 	}
@@ -92,6 +102,27 @@ func LeaseValue(key string) Cmp {
 	return Cmp{Key: []byte(key), Target: pb.Compare_LEASE}
 }
 
+// ValueInt compares a key's value, parsed as a base 10 integer, to an integer
+// of your choosing. The comparison fails if the value is not an integer.
+func ValueInt(key string) Cmp {
+	return Cmp{Key: []byte(key), Target: pb.Compare_VALUE_INT}
+}
+
+// ValueSemver compares a key's value, parsed as a semantic version with an
+// optional "v" prefix, to a version of your choosing. The comparison fails if
+// the value is not a semantic version.
+func ValueSemver(key string) Cmp {
+	return Cmp{Key: []byte(key), Target: pb.Compare_VALUE_SEMVER}
+}
+
+// FencingToken compares the fencing token of the lease attached to a key to
+// a token of your choosing. Keys without a lease have a token of 0. Holders
+// of a lock guard their writes with it, so that the writes sent after losing
+// the lease of the lock are rejected.
+func FencingToken(key string) Cmp {
+	return Cmp{Key: []byte(key), Target: pb.Compare_FENCING_TOKEN}
+}
+
 // KeyBytes returns the byte slice holding with the comparison key.
 func (cmp *Cmp) KeyBytes() []byte { return cmp.Key }
 
@@ -0,0 +1,259 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+var (
+	ErrBarrierHeld       = errors.New("barrier: held by another session")
+	ErrBarrierNotHeld    = errors.New("barrier: not held by this session")
+	ErrTooManyClients    = errors.New("barrier: too many clients")
+	ErrBarrierNotEntered = errors.New("barrier: not entered by this session")
+)
+
+// Barrier blocks processes on Wait until the holder releases it. The barrier
+// key is attached to the session lease of the holder, so the barrier is
+// released when the holder's session expires.
+type Barrier struct {
+	s   *Session
+	key string
+}
+
+// NewBarrier creates a Barrier on the given key.
+func NewBarrier(s *Session, key string) *Barrier {
+	return &Barrier{s: s, key: key}
+}
+
+// Hold creates the barrier key causing processes to block on Wait. Holding a
+// barrier that is already held by this session is a no-op.
+func (b *Barrier) Hold(ctx context.Context) error {
+	client := b.s.Client()
+	resp, err := client.Txn(ctx).
+		If(v3.Compare(v3.CreateRevision(b.key), "=", 0)).
+		Then(v3.OpPut(b.key, "", v3.WithLease(b.s.Lease()))).
+		Else(v3.OpGet(b.key)).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if resp.Succeeded {
+		return nil
+	}
+	kvs := resp.Responses[0].GetResponseRange().Kvs
+	if len(kvs) == 1 && v3.LeaseID(kvs[0].Lease) == b.s.Lease() {
+		return nil
+	}
+	return ErrBarrierHeld
+}
+
+// Release deletes the barrier key to unblock all waiting processes.
+func (b *Barrier) Release(ctx context.Context) error {
+	client := b.s.Client()
+	resp, err := client.Txn(ctx).
+		If(v3.Compare(v3.LeaseValue(b.key), "=", b.s.Lease())).
+		Then(v3.OpDelete(b.key)).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return ErrBarrierNotHeld
+	}
+	return nil
+}
+
+// Wait blocks on the barrier key until it is deleted, either by Release or by
+// the expiry of the holder's session. If there is no key, Wait assumes the
+// barrier has already been released and returns immediately.
+func (b *Barrier) Wait(ctx context.Context) error {
+	client := b.s.Client()
+	resp, err := client.Get(ctx, b.key)
+	if err != nil {
+		return err
+	}
+	if len(resp.Kvs) == 0 {
+		// key already removed
+		return nil
+	}
+	return waitDelete(ctx, client, b.key, resp.Header.Revision+1)
+}
+
+// DoubleBarrier blocks processes on Enter until an expected count enters, then
+// blocks again on Leave until all processes have left.
+//
+// Each process is represented by a key attached to its session lease, so a
+// process whose session expires releases its slot: it no longer counts
+// towards entering, and it no longer blocks others from leaving.
+type DoubleBarrier struct {
+	s *Session
+
+	key   string // key for the collective barrier
+	count int
+	myKey string // key of this session on the barrier
+	myRev int64  // create revision of myKey
+}
+
+// NewDoubleBarrier creates a DoubleBarrier on the given key for count processes.
+func NewDoubleBarrier(s *Session, key string, count int) *DoubleBarrier {
+	return &DoubleBarrier{
+		s:     s,
+		key:   key,
+		count: count,
+		myKey: fmt.Sprintf("%s/waiters/%x", key, s.Lease()),
+		myRev: -1,
+	}
+}
+
+func (b *DoubleBarrier) waitersPrefix() string { return b.key + "/waiters/" }
+func (b *DoubleBarrier) readyKey() string      { return b.key + "/ready" }
+
+// Enter waits for "count" processes to enter the barrier then returns. If
+// entering fails, for example because the context is canceled while waiting,
+// the key of this session is removed so that it does not hold a slot.
+func (b *DoubleBarrier) Enter(ctx context.Context) error {
+	client := b.s.Client()
+	resp, err := client.Txn(ctx).
+		If(v3.Compare(v3.CreateRevision(b.myKey), "=", 0)).
+		Then(v3.OpPut(b.myKey, "", v3.WithLease(b.s.Lease()))).
+		Else(v3.OpGet(b.myKey)).
+		Commit()
+	if err != nil {
+		return err
+	}
+	b.myRev = resp.Header.Revision
+	if !resp.Succeeded {
+		b.myRev = resp.Responses[0].GetResponseRange().Kvs[0].CreateRevision
+	}
+
+	if err = b.waitEntered(ctx); err != nil {
+		if !errors.Is(err, ErrSessionExpired) {
+			// release the slot so that other processes are not blocked on it
+			client.Delete(client.Ctx(), b.myKey)
+		}
+		b.myRev = -1
+	}
+	return err
+}
+
+func (b *DoubleBarrier) waitEntered(ctx context.Context) error {
+	client := b.s.Client()
+	for {
+		resp, err := client.Txn(ctx).Then(
+			v3.OpGet(b.readyKey()),
+			v3.OpGet(b.waitersPrefix(), v3.WithPrefix(), v3.WithSort(v3.SortByCreateRevision, v3.SortAscend)),
+		).Commit()
+		if err != nil {
+			return err
+		}
+		waiters := resp.Responses[1].GetResponseRange().Kvs
+		pos := -1
+		for i, kv := range waiters {
+			if string(kv.Key) == b.myKey {
+				pos = i
+				break
+			}
+		}
+		if pos < 0 {
+			// the session expired, so its slot was released
+			return ErrSessionExpired
+		}
+		if pos >= b.count {
+			return ErrTooManyClients
+		}
+		if ready := resp.Responses[0].GetResponseRange().Kvs; len(ready) != 0 {
+			if ready[0].ModRevision > waiters[0].CreateRevision {
+				return nil
+			}
+			// the ready key was left behind by a previous round whose
+			// processes are all gone; ignore it and clean it up.
+			client.Txn(ctx).
+				If(v3.Compare(v3.ModRevision(b.readyKey()), "=", ready[0].ModRevision)).
+				Then(v3.OpDelete(b.readyKey())).
+				Commit()
+		}
+		if len(waiters) >= b.count {
+			// unblock all other waiters
+			_, err = client.Put(ctx, b.readyKey(), "")
+			return err
+		}
+
+		// wait for the barrier to become ready or for waiters to change
+		if err = waitChange(ctx, client, b.key+"/", resp.Header.Revision+1); err != nil {
+			return err
+		}
+	}
+}
+
+// Leave waits for all processes that entered the barrier to leave then
+// returns. Processes whose session expires are treated as having left.
+func (b *DoubleBarrier) Leave(ctx context.Context) error {
+	if b.myRev <= 0 {
+		return ErrBarrierNotEntered
+	}
+	client := b.s.Client()
+	if _, err := client.Delete(ctx, b.myKey); err != nil {
+		return err
+	}
+	b.myRev = -1
+
+	for {
+		// the last process to leave cleans up the ready key
+		resp, err := client.Txn(ctx).
+			If(v3.Compare(v3.CreateRevision(b.waitersPrefix()), "=", 0).WithPrefix()).
+			Then(v3.OpDelete(b.readyKey())).
+			Else(v3.OpGet(b.waitersPrefix(), v3.WithLastCreate()...)).
+			Commit()
+		if err != nil {
+			return err
+		}
+		if resp.Succeeded {
+			return nil
+		}
+		kvs := resp.Responses[0].GetResponseRange().Kvs
+		if len(kvs) == 0 {
+			continue
+		}
+		if err = waitDelete(ctx, client, string(kvs[0].Key), resp.Header.Revision+1); err != nil {
+			return err
+		}
+	}
+}
+
+// waitChange waits for any event on keys with the given prefix from rev.
+func waitChange(ctx context.Context, client *v3.Client, pfx string, rev int64) error {
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wr v3.WatchResponse
+	wch := client.Watch(cctx, pfx, v3.WithPrefix(), v3.WithRev(rev))
+	for wr = range wch {
+		if len(wr.Events) != 0 {
+			return nil
+		}
+	}
+	if err := wr.Err(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New("lost watcher waiting for change")
+}
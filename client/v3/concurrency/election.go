@@ -106,6 +106,41 @@ func (e *Election) Campaign(ctx context.Context, val string) error {
 	return nil
 }
 
+// tryCampaign becomes the leader with the given value only if no other
+// candidate is already on the prefix. Otherwise the candidate key is removed
+// again and ErrElectionNotLeader is returned.
+func (e *Election) tryCampaign(ctx context.Context, val string) error {
+	s := e.session
+	client := e.session.Client()
+
+	k := fmt.Sprintf("%s%x", e.keyPrefix, s.Lease())
+	getOwner := v3.OpGet(e.keyPrefix, v3.WithFirstCreate()...)
+	txn := client.Txn(ctx).If(v3.Compare(v3.CreateRevision(k), "=", 0))
+	txn = txn.Then(v3.OpPut(k, val, v3.WithLease(s.Lease())), getOwner)
+	txn = txn.Else(v3.OpGet(k), getOwner)
+	resp, err := txn.Commit()
+	if err != nil {
+		return err
+	}
+	rev := resp.Header.Revision
+	if !resp.Succeeded {
+		rev = resp.Responses[0].GetResponseRange().Kvs[0].CreateRevision
+	}
+	owner := resp.Responses[1].GetResponseRange().Kvs
+	if len(owner) != 0 && owner[0].CreateRevision != rev {
+		if _, err = client.Delete(ctx, k); err != nil {
+			return err
+		}
+		return ErrElectionNotLeader
+	}
+	e.leaderKey, e.leaderRev, e.leaderSession = k, rev, s
+	e.hdr = resp.Header
+	if !resp.Succeeded {
+		return e.Proclaim(ctx, val)
+	}
+	return nil
+}
+
 // Proclaim lets the leader announce a new value without another election.
 func (e *Election) Proclaim(ctx context.Context, val string) error {
 	if e.leaderSession == nil {
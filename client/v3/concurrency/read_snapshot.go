@@ -0,0 +1,103 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+// readSnapshotHoldName names the compaction holds of read snapshots.
+const readSnapshotHoldName = "read-snapshot"
+
+// ReadSnapshot pins the current revision of the cluster for a series of reads,
+// so that they all observe the same data. A compaction hold keeps
+// auto-compaction from compacting past the revision until the snapshot is
+// released; it is renewed in the background, and expires after its TTL if the
+// client goes away. Compaction holds must be enabled on the server with
+// --compaction-max-hold.
+type ReadSnapshot struct {
+	client *v3.Client
+	id     int64
+	rev    int64
+
+	cancel context.CancelFunc
+	donec  <-chan struct{}
+}
+
+// NewReadSnapshot pins the current revision of the cluster with a compaction
+// hold of ttl seconds, or the maximum hold duration of the server if ttl is 0.
+func NewReadSnapshot(ctx context.Context, client *v3.Client, ttl int64) (*ReadSnapshot, error) {
+	resp, err := client.CompactionHoldGrant(ctx, readSnapshotHoldName, 0, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	kctx, cancel := context.WithCancel(client.Ctx())
+	donec := make(chan struct{})
+	s := &ReadSnapshot{client: client, id: resp.ID, rev: resp.Revision, cancel: cancel, donec: donec}
+
+	// renew the hold until it is lost or the snapshot is released
+	go func() {
+		defer close(donec)
+		ticker := time.NewTicker(max(time.Duration(resp.TTL)*time.Second/3, time.Second))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-kctx.Done():
+				return
+			case <-ticker.C:
+			}
+			_, rerr := client.CompactionHoldRenew(kctx, s.id, s.rev, resp.TTL)
+			if errors.Is(rerr, rpctypes.ErrCompactionHoldNotFound) {
+				return
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// Rev is the pinned revision.
+func (s *ReadSnapshot) Rev() int64 { return s.rev }
+
+// HoldID is the ID of the compaction hold of the snapshot.
+func (s *ReadSnapshot) HoldID() int64 { return s.id }
+
+// Get reads keys at the pinned revision.
+func (s *ReadSnapshot) Get(ctx context.Context, key string, opts ...v3.OpOption) (*v3.GetResponse, error) {
+	return s.client.Get(ctx, key, append(opts, v3.WithRev(s.rev))...)
+}
+
+// Done returns a channel that closes when the snapshot is released or its
+// compaction hold is lost, after which reads may fail with ErrCompacted.
+func (s *ReadSnapshot) Done() <-chan struct{} { return s.donec }
+
+// Release stops renewing the compaction hold and releases it, letting
+// auto-compaction compact the pinned revision.
+func (s *ReadSnapshot) Release(ctx context.Context) error {
+	s.cancel()
+	<-s.donec
+	_, err := s.client.CompactionHoldRevoke(ctx, s.id)
+	if errors.Is(err, rpctypes.ErrCompactionHoldNotFound) {
+		// already expired
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,407 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+// resilientSessionRetryInterval is the wait between attempts to grant a new
+// lease after the previous one was lost.
+var resilientSessionRetryInterval = time.Second
+
+// Epoch describes a lease generation of a ResilientSession.
+type Epoch struct {
+	// Number is 1 for the initial session and grows by one every time
+	// the session is rebuilt on a new lease.
+	Number int
+	// Session is the session of the epoch.
+	Session *Session
+	// Reacquired lists the prefixes of mutexes and elections that were held
+	// when the previous lease was lost and are held again under the new one.
+	Reacquired []string
+	// Lost lists the prefixes of mutexes and elections that were held when
+	// the previous lease was lost, but could not be taken again because
+	// another session got them in the meantime.
+	Lost []string
+}
+
+// ResilientSession is a Session that grants itself a new lease when its
+// lease expires or is revoked, instead of ending for good. Mutexes and
+// elections created through it are taken again under the new lease if no
+// other session acquired them in the meantime. Every rebuild starts a new
+// Epoch, which is announced on Epochs since the application may have lost
+// exclusive access between the two leases.
+type ResilientSession struct {
+	client *v3.Client
+	opts   []SessionOption
+	ttl    int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	donec  chan struct{}
+	epochc chan Epoch
+
+	mu        sync.Mutex
+	epoch     Epoch
+	mutexes   []*ResilientMutex
+	elections []*ResilientElection
+}
+
+// NewResilientSession creates the initial session of a ResilientSession.
+// WithLease only applies to the initial session; later epochs always grant
+// a new lease. The session keeps rebuilding itself until Close is called or
+// the context given by WithContext is canceled.
+func NewResilientSession(client *v3.Client, opts ...SessionOption) (*ResilientSession, error) {
+	lg := client.GetLogger()
+	ops := &sessionOptions{ttl: defaultSessionTTL, ctx: client.Ctx()}
+	for _, opt := range opts {
+		opt(ops, lg)
+	}
+
+	ctx, cancel := context.WithCancel(ops.ctx)
+	s, err := NewSession(client, append(opts, WithContext(ctx))...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	rs := &ResilientSession{
+		client: client,
+		opts:   append(opts, WithContext(ctx), WithLease(v3.NoLease)),
+		ttl:    ops.ttl,
+		ctx:    ctx,
+		cancel: cancel,
+		donec:  make(chan struct{}),
+		epochc: make(chan Epoch, 1),
+		epoch:  Epoch{Number: 1, Session: s},
+	}
+	go rs.run()
+	return rs, nil
+}
+
+// Session returns the session of the current epoch.
+func (rs *ResilientSession) Session() *Session {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.epoch.Session
+}
+
+// Epoch returns the current epoch.
+func (rs *ResilientSession) Epoch() Epoch {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.epoch
+}
+
+// Epochs returns a channel announcing every new epoch. If the application
+// does not keep up, only the latest epoch is kept. The channel is closed
+// once the session is closed.
+func (rs *ResilientSession) Epochs() <-chan Epoch { return rs.epochc }
+
+// Done returns a channel that closes when the session is closed and no
+// longer rebuilds itself.
+func (rs *ResilientSession) Done() <-chan struct{} { return rs.donec }
+
+// Close stops rebuilding the session and revokes the current lease.
+func (rs *ResilientSession) Close() error {
+	rs.cancel()
+	<-rs.donec
+	// if revoke takes longer than the ttl, lease is expired anyway
+	ctx, cancel := context.WithTimeout(rs.client.Ctx(), time.Duration(rs.ttl)*time.Second)
+	_, err := rs.client.Revoke(ctx, rs.Session().Lease())
+	cancel()
+	return err
+}
+
+func (rs *ResilientSession) run() {
+	defer func() {
+		close(rs.epochc)
+		close(rs.donec)
+	}()
+	lg := rs.client.GetLogger()
+	for {
+		s := rs.Session()
+		select {
+		case <-s.Done():
+		case <-rs.ctx.Done():
+			return
+		}
+		if rs.ctx.Err() != nil {
+			return
+		}
+		lg.Warn("session lease lost, granting a new one", zap.Int64("lease", int64(s.Lease())))
+
+		// the keep alive may have given up while the lease still exists;
+		// revoke it so that its keys cannot block the new epoch.
+		ctx, cancel := context.WithTimeout(rs.ctx, time.Duration(rs.ttl)*time.Second)
+		rs.client.Revoke(ctx, s.Lease())
+		cancel()
+
+		ns, err := rs.newSession()
+		if err != nil {
+			return
+		}
+		rs.advance(ns)
+	}
+}
+
+func (rs *ResilientSession) newSession() (*Session, error) {
+	lg := rs.client.GetLogger()
+	for {
+		s, err := NewSession(rs.client, rs.opts...)
+		if err == nil {
+			return s, nil
+		}
+		lg.Warn("failed to grant a new session lease", zap.Error(err))
+		select {
+		case <-time.After(resilientSessionRetryInterval):
+		case <-rs.ctx.Done():
+			return nil, rs.ctx.Err()
+		}
+	}
+}
+
+// advance switches to the new session, takes back what was held in the
+// previous epoch where possible and announces the new epoch.
+func (rs *ResilientSession) advance(s *Session) {
+	rs.mu.Lock()
+	ep := Epoch{Number: rs.epoch.Number + 1, Session: s}
+	rs.epoch = ep
+	mutexes, elections := rs.mutexes, rs.elections
+	rs.mu.Unlock()
+
+	for _, m := range mutexes {
+		if held, ok := m.reacquire(rs.ctx, s); held {
+			if ok {
+				ep.Reacquired = append(ep.Reacquired, m.pfx)
+			} else {
+				ep.Lost = append(ep.Lost, m.pfx)
+			}
+		}
+	}
+	for _, e := range elections {
+		if held, ok := e.reacquire(rs.ctx, s); held {
+			if ok {
+				ep.Reacquired = append(ep.Reacquired, e.pfx)
+			} else {
+				ep.Lost = append(ep.Lost, e.pfx)
+			}
+		}
+	}
+
+	rs.mu.Lock()
+	rs.epoch = ep
+	rs.mu.Unlock()
+
+	select {
+	case <-rs.epochc:
+	default:
+	}
+	rs.epochc <- ep
+}
+
+// ResilientMutex is a Mutex bound to a ResilientSession. A lock held when
+// the session loses its lease is taken again under the new lease if no other
+// session acquired it in the meantime.
+type ResilientMutex struct {
+	rs  *ResilientSession
+	pfx string
+
+	mu   sync.Mutex
+	m    *Mutex
+	held bool
+}
+
+// NewMutex returns a mutex on the given prefix that follows the session
+// across epochs.
+func (rs *ResilientSession) NewMutex(pfx string) *ResilientMutex {
+	m := &ResilientMutex{rs: rs, pfx: pfx}
+	rs.mu.Lock()
+	rs.mutexes = append(rs.mutexes, m)
+	rs.mu.Unlock()
+	return m
+}
+
+// Lock locks the mutex under the lease of the current epoch. If the epoch
+// ends before the lock is acquired, ErrSessionExpired is returned.
+func (m *ResilientMutex) Lock(ctx context.Context) error {
+	s := m.rs.Session()
+	mu := NewMutex(s, m.pfx)
+	if err := mu.Lock(ctx); err != nil {
+		return err
+	}
+	return m.set(s, mu)
+}
+
+// TryLock locks the mutex if not already locked by another session.
+func (m *ResilientMutex) TryLock(ctx context.Context) error {
+	s := m.rs.Session()
+	mu := NewMutex(s, m.pfx)
+	if err := mu.TryLock(ctx); err != nil {
+		return err
+	}
+	return m.set(s, mu)
+}
+
+func (m *ResilientMutex) set(s *Session, mu *Mutex) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s != m.rs.Session() {
+		return ErrSessionExpired
+	}
+	m.m, m.held = mu, true
+	return nil
+}
+
+// Unlock releases the mutex.
+func (m *ResilientMutex) Unlock(ctx context.Context) error {
+	m.mu.Lock()
+	mu := m.m
+	m.m, m.held = nil, false
+	m.mu.Unlock()
+	if mu == nil {
+		return ErrLockReleased
+	}
+	return mu.Unlock(ctx)
+}
+
+// Mutex returns the underlying mutex of the current epoch, or nil if the
+// mutex is not held.
+func (m *ResilientMutex) Mutex() *Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m
+}
+
+// reacquire tries to take a lock held in the previous epoch under the new
+// session. It returns whether the lock was held and whether it is held again.
+func (m *ResilientMutex) reacquire(ctx context.Context, s *Session) (held, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.held {
+		return false, false
+	}
+	mu := NewMutex(s, m.pfx)
+	if err := mu.TryLock(ctx); err != nil {
+		m.m, m.held = nil, false
+		return true, false
+	}
+	m.m = mu
+	return true, true
+}
+
+// ResilientElection is an Election bound to a ResilientSession. Leadership
+// held when the session loses its lease is taken again under the new lease,
+// with the last proclaimed value, if no other candidate joined the election
+// in the meantime.
+type ResilientElection struct {
+	rs  *ResilientSession
+	pfx string
+
+	mu     sync.Mutex
+	e      *Election
+	val    string
+	leader bool
+}
+
+// NewElection returns an election on the given prefix that follows the
+// session across epochs.
+func (rs *ResilientSession) NewElection(pfx string) *ResilientElection {
+	e := &ResilientElection{rs: rs, pfx: pfx}
+	rs.mu.Lock()
+	rs.elections = append(rs.elections, e)
+	rs.mu.Unlock()
+	return e
+}
+
+// Campaign blocks until elected under the lease of the current epoch. If the
+// epoch ends before that, ErrSessionExpired is returned.
+func (e *ResilientElection) Campaign(ctx context.Context, val string) error {
+	s := e.rs.Session()
+	el := NewElection(s, e.pfx)
+	if err := el.Campaign(ctx, val); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if s != e.rs.Session() {
+		return ErrSessionExpired
+	}
+	e.e, e.val, e.leader = el, val, true
+	return nil
+}
+
+// Proclaim lets the leader announce a new value without another election.
+func (e *ResilientElection) Proclaim(ctx context.Context, val string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.leader {
+		return ErrElectionNotLeader
+	}
+	if err := e.e.Proclaim(ctx, val); err != nil {
+		if errors.Is(err, ErrElectionNotLeader) {
+			e.leader = false
+		}
+		return err
+	}
+	e.val = val
+	return nil
+}
+
+// Resign lets a leader start a new election.
+func (e *ResilientElection) Resign(ctx context.Context) error {
+	e.mu.Lock()
+	el := e.e
+	e.leader = false
+	e.mu.Unlock()
+	if el == nil {
+		return nil
+	}
+	return el.Resign(ctx)
+}
+
+// Election returns the underlying election of the latest campaign, or nil
+// if there was none.
+func (e *ResilientElection) Election() *Election {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.e
+}
+
+// reacquire tries to take back leadership held in the previous epoch under
+// the new session. It returns whether leadership was held and whether it is
+// held again.
+func (e *ResilientElection) reacquire(ctx context.Context, s *Session) (held, ok bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.leader {
+		return false, false
+	}
+	el := NewElection(s, e.pfx)
+	if err := el.tryCampaign(ctx, e.val); err != nil {
+		e.leader = false
+		return true, false
+	}
+	e.e = el
+	return true, true
+}
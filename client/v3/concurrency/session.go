@@ -31,6 +31,8 @@ type Session struct {
 	client *v3.Client
 	opts   *sessionOptions
 	id     v3.LeaseID
+	// fencingToken is the fencing token of the lease granted for the session.
+	fencingToken int64
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -45,13 +47,13 @@ func NewSession(client *v3.Client, opts ...SessionOption) (*Session, error) {
 		opt(ops, lg)
 	}
 
-	id := ops.leaseID
+	id, fencingToken := ops.leaseID, int64(0)
 	if id == v3.NoLease {
 		resp, err := client.Grant(ops.ctx, int64(ops.ttl))
 		if err != nil {
 			return nil, err
 		}
-		id = resp.ID
+		id, fencingToken = resp.ID, resp.FencingToken
 	}
 
 	ctx, cancel := context.WithCancel(ops.ctx)
@@ -62,7 +64,7 @@ func NewSession(client *v3.Client, opts ...SessionOption) (*Session, error) {
 	}
 
 	donec := make(chan struct{})
-	s := &Session{client: client, opts: ops, id: id, ctx: ctx, cancel: cancel, donec: donec}
+	s := &Session{client: client, opts: ops, id: id, fencingToken: fencingToken, ctx: ctx, cancel: cancel, donec: donec}
 
 	// keep the lease alive until client error or cancelled context
 	go func() {
@@ -86,6 +88,11 @@ func (s *Session) Client() *v3.Client {
 // Lease is the lease ID for keys bound to the session.
 func (s *Session) Lease() v3.LeaseID { return s.id }
 
+// FencingToken is the fencing token of the lease granted for the session, or 0
+// if the session was created with WithLease. Compare it with v3.FencingToken to
+// guard writes against a session whose lease was revoked and re-granted.
+func (s *Session) FencingToken() int64 { return s.fencingToken }
+
 // Ctx is the context attached to the session, it is canceled when the lease is orphaned, expires, or
 // is otherwise no longer being refreshed.
 func (s *Session) Ctx() context.Context {
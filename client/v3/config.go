@@ -17,8 +17,10 @@ package clientv3
 import (
 	"context"
 	"crypto/tls"
+	"log/slog"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
@@ -36,6 +38,13 @@ type Config struct {
 	// DialTimeout is the timeout for failing to establish a connection.
 	DialTimeout time.Duration `json:"dial-timeout"`
 
+	// Proxy is the URL of the proxy to reach the endpoints through, with an
+	// http or https scheme for HTTP CONNECT proxies, or a socks5 scheme for
+	// SOCKS5 proxies. If empty, the proxy set by the HTTPS_PROXY environment
+	// variable is used, unless NO_PROXY excludes the endpoint. TLS
+	// connections to the endpoints are tunneled through the proxy.
+	Proxy string `json:"proxy"`
+
 	// DialKeepAliveTime is the time after which client pings the server to see if
 	// transport is alive.
 	DialKeepAliveTime time.Duration `json:"dial-keep-alive-time"`
@@ -57,6 +66,18 @@ type Config struct {
 	// ("--max-recv-bytes" flag to etcd).
 	MaxCallRecvMsgSize int
 
+	// WatchMaxResponseBytes is the largest watch response the server may send
+	// to this client, e.g. to get through proxies limiting the message size.
+	// Larger responses are fragmented by the server and reassembled by the
+	// client. If 0, it defaults to "MaxCallRecvMsgSize" when set; otherwise
+	// only watchers created with "WithFragment" get fragmented responses.
+	WatchMaxResponseBytes int
+
+	// WatchMaxReassemblyBytes caps the memory used to reassemble a fragmented
+	// watch response. Watchers receiving a larger response are canceled with
+	// "ErrWatchResponseTooLarge". If 0, there is no limit.
+	WatchMaxReassemblyBytes int
+
 	// TLS holds the client secure credentials, if any.
 	TLS *tls.Config
 
@@ -90,6 +111,24 @@ type Config struct {
 	// TODO: configure gRPC logger
 	LogConfig *zap.Config
 
+	// LogHandler, if set and Logger is not, receives the client logs. It lets
+	// applications using log/slog, or any framework providing a slog.Handler,
+	// collect the client logs without building a zap logger.
+	LogHandler slog.Handler
+
+	// LogLevels sets the minimum level of the logs of client components.
+	// It only filters out logs, the levels enabled by the logger still apply.
+	LogLevels map[LogComponent]slog.Level
+
+	// Metrics, if set, receives the measurements of the RPCs sent by the
+	// client. See the metrics package for a Prometheus implementation.
+	Metrics Metrics
+
+	// MeterProvider, if set and Metrics is not, provides the OpenTelemetry
+	// meters recording the client metrics, so applications exporting their
+	// metrics with OpenTelemetry don't need a Prometheus registry.
+	MeterProvider metric.MeterProvider
+
 	// PermitWithoutStream when set will allow client to send keepalive pings to server without any active streams(RPCs).
 	PermitWithoutStream bool `json:"permit-without-stream"`
 
@@ -102,6 +141,31 @@ type Config struct {
 	// BackoffJitterFraction is the jitter fraction to randomize backoff wait time.
 	BackoffJitterFraction float64 `json:"backoff-jitter-fraction"`
 
+	// PreferredMemberLabels restricts the endpoints set by Sync, and thus by
+	// AutoSyncInterval, to members that have all these labels, e.g. to prefer
+	// members in the same zone. If no member has them, all members are used.
+	PreferredMemberLabels map[string]string `json:"preferred-member-labels"`
+
+	// ConnectionsPerEndpoint is the number of connections the client opens to
+	// each endpoint. With 0 or 1, all RPCs share a single connection. With 2,
+	// watch streams use a connection of their own, so that a busy watch does
+	// not delay unary RPCs and lease keep alives behind it. With 3 or more,
+	// lease RPCs, including keep alives, also use a connection of their own,
+	// and watch streams are spread over the remaining connections.
+	ConnectionsPerEndpoint int `json:"connections-per-endpoint"`
+
+	// HedgingBudget caps the extra load of hedged reads, see WithHedging. Every
+	// hedged read earns this fraction of a hedged request, and at most 10 unused
+	// hedged requests are saved up. With 0, 0.1 is used, i.e., in the long run at
+	// most one read in 10 is sent twice. A negative value disables hedging.
+	HedgingBudget float64 `json:"hedging-budget"`
+
+	// LeaderRouting sends linearizable reads and writes straight to the leader,
+	// as hinted by the headers of the responses, instead of letting a follower
+	// forward them. A request is sent to the balanced endpoints again if the
+	// leader is unknown, no longer the leader, or unavailable.
+	LeaderRouting bool `json:"leader-routing"`
+
 	// TODO: support custom balancer picker
 }
 
@@ -112,6 +176,7 @@ type ConfigSpec struct {
 	Endpoints          []string      `json:"endpoints"`
 	RequestTimeout     time.Duration `json:"request-timeout"`
 	DialTimeout        time.Duration `json:"dial-timeout"`
+	Proxy              string        `json:"proxy"`
 	KeepAliveTime      time.Duration `json:"keepalive-time"`
 	KeepAliveTimeout   time.Duration `json:"keepalive-timeout"`
 	MaxCallSendMsgSize int           `json:"max-request-bytes"`
@@ -174,6 +239,7 @@ func NewClientConfig(confSpec *ConfigSpec, lg *zap.Logger) (*Config, error) {
 	cfg := &Config{
 		Endpoints:            confSpec.Endpoints,
 		DialTimeout:          confSpec.DialTimeout,
+		Proxy:                confSpec.Proxy,
 		DialKeepAliveTime:    confSpec.KeepAliveTime,
 		DialKeepAliveTimeout: confSpec.KeepAliveTimeout,
 		MaxCallSendMsgSize:   confSpec.MaxCallSendMsgSize,
@@ -0,0 +1,199 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/v3/internal/resolver"
+)
+
+// StreamType is a type of traffic sent by the client.
+type StreamType int
+
+const (
+	// StreamTypeUnary is the traffic of unary RPCs.
+	StreamTypeUnary StreamType = iota
+	// StreamTypeWatch is the traffic of watch streams.
+	StreamTypeWatch
+	// StreamTypeLease is the traffic of lease RPCs, including keep alive
+	// streams.
+	StreamTypeLease
+)
+
+// connPool is a set of connections dedicated to a type of traffic. Each
+// connection has its own resolver, since a resolver only serves one
+// connection.
+type connPool struct {
+	conns     []*grpc.ClientConn
+	resolvers []*resolver.EtcdManualResolver
+}
+
+func (p *connPool) setEndpoints(eps []string) {
+	for _, r := range p.resolvers {
+		r.SetEndpoints(eps)
+	}
+}
+
+func (p *connPool) close() error {
+	var errs []error
+	for _, conn := range p.conns {
+		errs = append(errs, conn.Close())
+	}
+	for _, r := range p.resolvers {
+		r.Close()
+	}
+	return errors.Join(errs...)
+}
+
+// endpointConns holds a connection to each endpoint of the client, for the
+// requests that must reach a given member, e.g. hedged reads and reads and
+// writes routed to the leader.
+type endpointConns struct {
+	c *Client
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newEndpointConns(c *Client) *endpointConns {
+	return &endpointConns{c: c, conns: make(map[string]*grpc.ClientConn)}
+}
+
+// get returns the connection to the endpoint, dialing it if needed.
+func (ec *endpointConns) get(ep string) (*grpc.ClientConn, error) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	if err := ec.c.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if conn, ok := ec.conns[ep]; ok {
+		return conn, nil
+	}
+	conn, err := ec.c.Dial(ep)
+	if err != nil {
+		return nil, err
+	}
+	ec.conns[ep] = conn
+	return conn, nil
+}
+
+// setEndpoints closes the connections to endpoints no longer in use.
+func (ec *endpointConns) setEndpoints(eps []string) {
+	keep := make(map[string]struct{}, len(eps))
+	for _, ep := range eps {
+		keep[ep] = struct{}{}
+	}
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	for ep, conn := range ec.conns {
+		if _, ok := keep[ep]; !ok {
+			conn.Close()
+			delete(ec.conns, ep)
+		}
+	}
+}
+
+func (ec *endpointConns) close() {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	for ep, conn := range ec.conns {
+		conn.Close()
+		delete(ec.conns, ep)
+	}
+}
+
+// dialPool dials n connections to the endpoints of the client.
+func (c *Client) dialPool(n int) (*connPool, error) {
+	p := &connPool{}
+	for i := 0; i < n; i++ {
+		r := resolver.New(c.Endpoints()...)
+		conn, err := c.dial(c.credentialsForEndpoint(c.Endpoints()[0]), grpc.WithResolvers(r))
+		if err != nil {
+			r.Close()
+			p.close()
+			return nil, err
+		}
+		p.conns = append(p.conns, conn)
+		p.resolvers = append(p.resolvers, r)
+	}
+	return p, nil
+}
+
+// dialConnPools dials the connections dedicated to watch streams and lease
+// RPCs, as configured by Config.ConnectionsPerEndpoint.
+func (c *Client) dialConnPools() (err error) {
+	n := c.cfg.ConnectionsPerEndpoint
+	if n <= 1 {
+		return nil
+	}
+	if n >= 3 {
+		if c.leaseConns, err = c.dialPool(1); err != nil {
+			return err
+		}
+		n--
+	}
+	c.watchConns, err = c.dialPool(n - 1)
+	return err
+}
+
+// watchClient returns the client of the watch streams.
+func (c *Client) watchClient() pb.WatchClient {
+	if c.watchConns == nil {
+		return pb.NewWatchClient(c.conn)
+	}
+	rr := &roundRobinWatchClient{}
+	for _, conn := range c.watchConns.conns {
+		rr.clients = append(rr.clients, pb.NewWatchClient(conn))
+	}
+	return rr
+}
+
+// roundRobinWatchClient spreads the watch streams over several connections.
+type roundRobinWatchClient struct {
+	clients []pb.WatchClient
+	next    atomic.Uint64
+}
+
+func (rr *roundRobinWatchClient) Watch(ctx context.Context, opts ...grpc.CallOption) (pb.Watch_WatchClient, error) {
+	return rr.clients[(rr.next.Add(1)-1)%uint64(len(rr.clients))].Watch(ctx, opts...)
+}
+
+// leaseConn returns the connection of the lease RPCs.
+func (c *Client) leaseConn() *grpc.ClientConn {
+	if c.leaseConns == nil {
+		return c.conn
+	}
+	return c.leaseConns.conns[0]
+}
+
+// Connections returns the connections used for the given type of traffic.
+// They are shared with other types of traffic unless
+// Config.ConnectionsPerEndpoint dedicates connections to it.
+func (c *Client) Connections(t StreamType) []*grpc.ClientConn {
+	switch {
+	case t == StreamTypeWatch && c.watchConns != nil:
+		return c.watchConns.conns
+	case t == StreamTypeLease && c.leaseConns != nil:
+		return c.leaseConns.conns
+	}
+	return []*grpc.ClientConn{c.conn}
+}
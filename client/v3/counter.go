@@ -0,0 +1,90 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+type (
+	CounterValue       pb.CounterValue
+	CounterAddResponse pb.CounterAddResponse
+	CounterGetResponse pb.CounterGetResponse
+)
+
+// CounterDelta is the amount to add to the counter stored under Key.
+type CounterDelta struct {
+	Key   string
+	Delta int64
+}
+
+type Counter interface {
+	// CounterAdd atomically adds every delta to its counter and returns the
+	// resulting values. Either all deltas are applied or, if any counter
+	// would overflow, none of them is.
+	CounterAdd(ctx context.Context, deltas ...CounterDelta) (*CounterAddResponse, error)
+
+	// CounterGet retrieves the counter for the given key, or the counters in a
+	// range when WithRange, WithPrefix or WithFromKey is passed. WithSerializable
+	// serves the read from the local member.
+	CounterGet(ctx context.Context, key string, opts ...OpOption) (*CounterGetResponse, error)
+}
+
+type counter struct {
+	remote   pb.CounterClient
+	callOpts []grpc.CallOption
+}
+
+func NewCounter(c *Client) Counter {
+	api := &counter{remote: RetryCounterClient(c)}
+	if c != nil {
+		api.callOpts = c.callOpts
+	}
+	return api
+}
+
+func NewCounterFromCounterClient(remote pb.CounterClient, c *Client) Counter {
+	api := &counter{remote: remote}
+	if c != nil {
+		api.callOpts = c.callOpts
+	}
+	return api
+}
+
+func (c *counter) CounterAdd(ctx context.Context, deltas ...CounterDelta) (*CounterAddResponse, error) {
+	r := &pb.CounterAddRequest{Deltas: make([]*pb.CounterDelta, len(deltas))}
+	for i, d := range deltas {
+		r.Deltas[i] = &pb.CounterDelta{Key: []byte(d.Key), Delta: d.Delta}
+	}
+	resp, err := c.remote.CounterAdd(ctx, r, c.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*CounterAddResponse)(resp), nil
+}
+
+func (c *counter) CounterGet(ctx context.Context, key string, opts ...OpOption) (*CounterGetResponse, error) {
+	op := OpGet(key, opts...)
+	r := &pb.CounterGetRequest{Key: op.KeyBytes(), RangeEnd: op.RangeBytes(), Serializable: op.IsSerializable()}
+	resp, err := c.remote.CounterGet(ctx, r, c.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*CounterGetResponse)(resp), nil
+}
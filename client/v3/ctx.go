@@ -16,6 +16,7 @@ package clientv3
 
 import (
 	"context"
+	"strconv"
 
 	"google.golang.org/grpc/metadata"
 
@@ -37,6 +38,66 @@ func WithRequireLeader(ctx context.Context) context.Context {
 	return metadata.NewOutgoingContext(ctx, copied)
 }
 
+// WithLowPriority marks client requests as low priority. While the apply
+// backlog of the server is over its threshold, low priority writes fail fast
+// with rpctypes.ErrTooBusy instead of being queued. The client retries them
+// after the backoff suggested by the server, within its retry budget.
+func WithLowPriority(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.Pairs(rpctypes.MetadataPriorityKey, rpctypes.MetadataPriorityLow)
+		return metadata.NewOutgoingContext(ctx, md)
+	}
+	copied := md.Copy() // avoid racey updates
+	copied.Set(rpctypes.MetadataPriorityKey, rpctypes.MetadataPriorityLow)
+	return metadata.NewOutgoingContext(ctx, copied)
+}
+
+// WithRequestTimings asks the server to report the breakdown of the time it
+// spent serving the requests made with ctx. The breakdown is returned in the
+// Timings of the response header of unary requests.
+func WithRequestTimings(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.Pairs(rpctypes.MetadataRequestTimingsKey, rpctypes.MetadataRequestTimings)
+		return metadata.NewOutgoingContext(ctx, md)
+	}
+	copied := md.Copy() // avoid racey updates
+	copied.Set(rpctypes.MetadataRequestTimingsKey, rpctypes.MetadataRequestTimings)
+	return metadata.NewOutgoingContext(ctx, copied)
+}
+
+// WithElectionLease attaches to client requests the lease of a candidate of an
+// election, such as the lease of a concurrency.Session, proving they come from
+// a candidate allowed to write to the protected prefixes guarded by that
+// election. Writes to protected prefixes without it fail with
+// rpctypes.ErrProtectedPrefix.
+func WithElectionLease(ctx context.Context, id LeaseID) context.Context {
+	v := strconv.FormatInt(int64(id), 10)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.Pairs(rpctypes.MetadataElectionLeaseKey, v)
+		return metadata.NewOutgoingContext(ctx, md)
+	}
+	copied := md.Copy() // avoid racey updates
+	copied.Set(rpctypes.MetadataElectionLeaseKey, v)
+	return metadata.NewOutgoingContext(ctx, copied)
+}
+
+// WithFenceToken attaches to client requests the token of the fences of
+// prefixes, letting their writes through the fences set with the same token
+// by Maintenance.FencePrefix.
+func WithFenceToken(ctx context.Context, token string) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.Pairs(rpctypes.MetadataFenceTokenKey, token)
+		return metadata.NewOutgoingContext(ctx, md)
+	}
+	copied := md.Copy() // avoid racey updates
+	copied.Set(rpctypes.MetadataFenceTokenKey, token)
+	return metadata.NewOutgoingContext(ctx, copied)
+}
+
 // embeds client version
 func withVersion(ctx context.Context) context.Context {
 	md, ok := metadata.FromOutgoingContext(ctx)
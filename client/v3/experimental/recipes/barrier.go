@@ -23,6 +23,9 @@ import (
 
 // Barrier creates a key in etcd to block processes, then deletes the key to
 // release all blocked processes.
+//
+// Deprecated: use concurrency.Barrier, which releases the barrier when the
+// holder's session expires.
 type Barrier struct {
 	client *v3.Client
 	ctx    context.Context
@@ -24,6 +24,9 @@ import (
 
 // DoubleBarrier blocks processes on Enter until an expected count enters, then
 // blocks again on Leave until all processes have left.
+//
+// Deprecated: use concurrency.DoubleBarrier, which recovers from processes
+// whose session expires and supports context cancellation.
 type DoubleBarrier struct {
 	s   *concurrency.Session
 	ctx context.Context
@@ -0,0 +1,30 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export reads and writes exports, the logical backups of the
+// key-value pairs of etcd at a single revision streamed by
+// Maintenance.Export. Unlike snapshots, exports do not depend on the
+// storage engine of etcd, and can be imported partially into any cluster.
+//
+// An export is made of, in order:
+//
+//	magic     the 7 bytes "etcdexp" and the format version byte, 1
+//	revision  the uvarint revision the pairs are exported at
+//	records   per key-value pair, in key order, the uvarint size of the pair
+//	          marshaled as an mvccpb.KeyValue, followed by the marshaled pair
+//	end       a zero uvarint, then the uvarint number of records
+//	checksum  the 4 bytes big endian CRC-32C of all the previous bytes
+//
+// Keys being never empty, a marshaled pair is never empty either.
+package export
@@ -0,0 +1,186 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+const (
+	magic   = "etcdexp"
+	version = 1
+)
+
+var (
+	ErrInvalid          = errors.New("export: invalid format")
+	ErrChecksumMismatch = errors.New("export: checksum mismatch")
+
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// Writer writes an export.
+type Writer struct {
+	w     io.Writer
+	crc   hash.Hash32
+	buf   []byte
+	count uint64
+}
+
+// NewWriter writes the start of an export of the pairs at rev to w.
+func NewWriter(w io.Writer, rev int64) (*Writer, error) {
+	crc := crc32.New(crcTable)
+	ew := &Writer{w: io.MultiWriter(w, crc), crc: crc}
+	ew.buf = append(ew.buf, magic...)
+	ew.buf = append(ew.buf, version)
+	ew.buf = binary.AppendUvarint(ew.buf, uint64(rev))
+	if _, err := ew.w.Write(ew.buf); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// Write writes the next pair of the export. The pairs must be written in
+// key order.
+func (ew *Writer) Write(kv *mvccpb.KeyValue) error {
+	size := kv.Size()
+	ew.buf = binary.AppendUvarint(ew.buf[:0], uint64(size))
+	n := len(ew.buf)
+	ew.buf = append(ew.buf, make([]byte, size)...)
+	if _, err := kv.MarshalToSizedBuffer(ew.buf[n:]); err != nil {
+		return err
+	}
+	if _, err := ew.w.Write(ew.buf); err != nil {
+		return err
+	}
+	ew.count++
+	return nil
+}
+
+// Close writes the end of the export. It does not close the underlying
+// writer.
+func (ew *Writer) Close() error {
+	ew.buf = binary.AppendUvarint(ew.buf[:0], 0)
+	ew.buf = binary.AppendUvarint(ew.buf, ew.count)
+	if _, err := ew.w.Write(ew.buf); err != nil {
+		return err
+	}
+	ew.buf = binary.BigEndian.AppendUint32(ew.buf[:0], ew.crc.Sum32())
+	_, err := ew.w.Write(ew.buf)
+	return err
+}
+
+// Reader reads an export.
+type Reader struct {
+	r     *bufio.Reader
+	crc   hash.Hash32
+	rev   int64
+	count uint64
+	done  bool
+}
+
+// NewReader reads the start of the export of r.
+func NewReader(r io.Reader) (*Reader, error) {
+	er := &Reader{r: bufio.NewReader(r), crc: crc32.New(crcTable)}
+	head := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(er.r, head); err != nil {
+		return nil, invalid(err)
+	}
+	if string(head[:len(magic)]) != magic {
+		return nil, ErrInvalid
+	}
+	if head[len(magic)] != version {
+		return nil, fmt.Errorf("export: unsupported format version %d", head[len(magic)])
+	}
+	er.crc.Write(head)
+	rev, err := er.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	er.rev = int64(rev)
+	return er, nil
+}
+
+// Revision returns the revision the pairs of the export are exported at.
+func (er *Reader) Revision() int64 { return er.rev }
+
+// Next returns the next pair of the export. It returns io.EOF at the end
+// of the export, once its checksum is verified.
+func (er *Reader) Next() (*mvccpb.KeyValue, error) {
+	if er.done {
+		return nil, io.EOF
+	}
+	size, err := er.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, er.readEnd()
+	}
+	b := make([]byte, size)
+	if _, err = io.ReadFull(er.r, b); err != nil {
+		return nil, invalid(err)
+	}
+	er.crc.Write(b)
+	kv := &mvccpb.KeyValue{}
+	if err = kv.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	er.count++
+	return kv, nil
+}
+
+func (er *Reader) readEnd() error {
+	count, err := er.readUvarint()
+	if err != nil {
+		return err
+	}
+	if count != er.count {
+		return fmt.Errorf("%w: %d records, expected %d", ErrInvalid, er.count, count)
+	}
+	sum := make([]byte, 4)
+	if _, err = io.ReadFull(er.r, sum); err != nil {
+		return invalid(err)
+	}
+	if binary.BigEndian.Uint32(sum) != er.crc.Sum32() {
+		return ErrChecksumMismatch
+	}
+	er.done = true
+	return io.EOF
+}
+
+func (er *Reader) readUvarint() (uint64, error) {
+	v, err := binary.ReadUvarint(er.r)
+	if err != nil {
+		return 0, invalid(err)
+	}
+	er.crc.Write(binary.AppendUvarint(nil, v))
+	return v, nil
+}
+
+// invalid reports a truncated export as invalid.
+func invalid(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: unexpected end of export", ErrInvalid)
+	}
+	return err
+}
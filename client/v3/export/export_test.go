@@ -0,0 +1,93 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func writeExport(t *testing.T, rev int64, kvs []*mvccpb.KeyValue) []byte {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, rev)
+	require.NoError(t, err)
+	for _, kv := range kvs {
+		require.NoError(t, w.Write(kv))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func readExport(b []byte) (int64, []*mvccpb.KeyValue, error) {
+	r, err := NewReader(bytes.NewReader(b))
+	if err != nil {
+		return 0, nil, err
+	}
+	var kvs []*mvccpb.KeyValue
+	for {
+		kv, err := r.Next()
+		if err == io.EOF {
+			return r.Revision(), kvs, nil
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		kvs = append(kvs, kv)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	kvs := []*mvccpb.KeyValue{
+		{Key: []byte("a"), Value: []byte("1"), CreateRevision: 2, ModRevision: 5, Version: 2, Lease: 7},
+		{Key: []byte("b"), Value: bytes.Repeat([]byte("x"), 1000), CreateRevision: 3, ModRevision: 3, Version: 1},
+	}
+	for _, tc := range []struct {
+		name string
+		kvs  []*mvccpb.KeyValue
+	}{
+		{"empty", nil},
+		{"pairs", kvs},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rev, got, err := readExport(writeExport(t, 42, tc.kvs))
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), rev)
+			assert.Equal(t, tc.kvs, got)
+		})
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	b := writeExport(t, 42, []*mvccpb.KeyValue{{Key: []byte("a"), Value: []byte("1")}})
+
+	corrupted := bytes.Clone(b)
+	corrupted[bytes.LastIndexByte(corrupted, '1')] = '2'
+	_, _, err := readExport(corrupted)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	for _, n := range []int{0, 4, len(b) / 2, len(b) - 1} {
+		_, _, err = readExport(b[:n])
+		require.ErrorIsf(t, err, ErrInvalid, "truncated to %d bytes", n)
+	}
+
+	_, _, err = readExport([]byte("snapshot"))
+	require.ErrorIs(t, err, ErrInvalid)
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Fetch exports the pairs selected by key and opts, as for
+// Maintenance.Export, to w. It returns the exported revision and the
+// number of exported pairs.
+func Fetch(ctx context.Context, m clientv3.Maintenance, w io.Writer, key string, opts ...clientv3.OpOption) (rev, count int64, err error) {
+	resp, err := m.Export(ctx, key, opts...)
+	if err != nil {
+		return 0, 0, err
+	}
+	ew, err := NewWriter(w, resp.Revision)
+	if err != nil {
+		return 0, 0, err
+	}
+	for {
+		kvs, err := resp.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, kv := range kvs {
+			if err = ew.Write(kv); err != nil {
+				return 0, 0, err
+			}
+		}
+		count += int64(len(kvs))
+	}
+	return resp.Revision, count, ew.Close()
+}
+
+// Save is Fetch to the file at path, which is only created once the
+// export is complete.
+func Save(ctx context.Context, m clientv3.Maintenance, path string, key string, opts ...clientv3.OpOption) (rev, count int64, err error) {
+	partpath := path + ".part"
+	defer os.RemoveAll(partpath)
+
+	f, err := os.OpenFile(partpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileutil.PrivateFileMode)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not open %s (%w)", partpath, err)
+	}
+	defer f.Close()
+
+	bw := bufio.NewWriter(f)
+	if rev, count, err = Fetch(ctx, m, bw, key, opts...); err != nil {
+		return 0, 0, err
+	}
+	if err = bw.Flush(); err != nil {
+		return 0, 0, err
+	}
+	if err = fileutil.Fsync(f); err != nil {
+		return 0, 0, fmt.Errorf("could not fsync export: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return 0, 0, fmt.Errorf("could not close file descriptor: %w", err)
+	}
+	if err = os.Rename(partpath, path); err != nil {
+		return 0, 0, fmt.Errorf("could not rename %s to %s (%w)", partpath, path, err)
+	}
+	return rev, count, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+const (
+	// defaultHedgingBudget is the fraction of hedgeable reads which may be
+	// hedged when Config.HedgingBudget is not set.
+	defaultHedgingBudget = 0.1
+	// maxHedgingTokens is the number of hedged requests which can be saved
+	// up by reads that were not hedged, bounding bursts of hedged requests.
+	maxHedgingTokens = 10
+)
+
+// hedger sends hedged serializable reads, see WithHedging. Each read goes to
+// the connection of an endpoint, so that the hedged request is sent to
+// another member than the first one.
+type hedger struct {
+	c      *Client
+	budget float64
+	next   atomic.Uint64
+
+	budgetMu sync.Mutex
+	// tokens is the number of hedged requests that can be sent.
+	tokens float64
+}
+
+func newHedger(c *Client) *hedger {
+	budget := c.cfg.HedgingBudget
+	if budget == 0 {
+		budget = defaultHedgingBudget
+	}
+	return &hedger{
+		c:      c,
+		budget: math.Max(budget, 0),
+		tokens: maxHedgingTokens,
+	}
+}
+
+// enabled returns whether reads can be hedged, which needs a budget and at
+// least two endpoints.
+func (h *hedger) enabled() bool {
+	return h.budget > 0 && len(h.c.Endpoints()) > 1
+}
+
+// earn adds the share of a hedged request earned by a read.
+func (h *hedger) earn() {
+	h.budgetMu.Lock()
+	h.tokens = math.Min(h.tokens+h.budget, maxHedgingTokens)
+	h.budgetMu.Unlock()
+}
+
+// spend takes a hedged request from the budget, if any is left.
+func (h *hedger) spend() bool {
+	h.budgetMu.Lock()
+	defer h.budgetMu.Unlock()
+	if h.tokens < 1 {
+		return false
+	}
+	h.tokens--
+	return true
+}
+
+type hedgedResult struct {
+	resp *pb.RangeResponse
+	err  error
+}
+
+// Range sends the request to an endpoint and, if it has not answered
+// successfully within delay, to the next one, returning the first successful
+// response. It fails only if every request sent fails, returning the last
+// error.
+func (h *hedger) Range(ctx context.Context, r *pb.RangeRequest, delay time.Duration, opts ...grpc.CallOption) (*pb.RangeResponse, error) {
+	eps := h.c.Endpoints()
+	if len(eps) < 2 {
+		return nil, ErrNoAvailableEndpoints
+	}
+	h.earn()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan hedgedResult, 2)
+	i := h.next.Add(1)
+	send := func(ep string) {
+		conn, err := h.c.epConns.get(ep)
+		if err != nil {
+			results <- hedgedResult{err: err}
+			return
+		}
+		resp, err := pb.NewKVClient(conn).Range(ctx, r, opts...)
+		results <- hedgedResult{resp: resp, err: err}
+	}
+	go send(eps[i%uint64(len(eps))])
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	pending, hedged := 1, false
+	hedge := func() {
+		if !hedged && h.spend() {
+			hedged = true
+			pending++
+			ep := eps[(i+1)%uint64(len(eps))]
+			h.c.GetLogger().Debug("sending hedged read", zap.String("endpoint", ep))
+			go send(ep)
+		}
+	}
+	var err error
+	for pending > 0 {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				return res.resp, nil
+			}
+			err = res.err
+			hedge()
+		case <-timer.C:
+			hedge()
+		}
+	}
+	return nil, err
+}
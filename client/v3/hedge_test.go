@@ -0,0 +1,68 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedgerBudget(t *testing.T) {
+	tests := []struct {
+		budget     float64
+		reads      int
+		wantHedges int
+	}{
+		{budget: 0.25, reads: 0, wantHedges: maxHedgingTokens},
+		{budget: 0.25, reads: 100, wantHedges: maxHedgingTokens + 25},
+		{budget: 0.5, reads: 100, wantHedges: maxHedgingTokens + 50},
+		{budget: 1, reads: 100, wantHedges: maxHedgingTokens + 100},
+	}
+	for _, tt := range tests {
+		h := newHedger(&Client{cfg: Config{HedgingBudget: tt.budget}})
+		hedges := 0
+		for h.spend() {
+			hedges++
+		}
+		for i := 0; i < tt.reads; i++ {
+			h.earn()
+			for h.spend() {
+				hedges++
+			}
+		}
+		for h.spend() {
+			hedges++
+		}
+		assert.Equal(t, tt.wantHedges, hedges, "budget %v", tt.budget)
+	}
+
+	// reads which are not hedged save up at most maxHedgingTokens
+	h := newHedger(&Client{cfg: Config{HedgingBudget: 1}})
+	for i := 0; i < 100; i++ {
+		h.earn()
+	}
+	hedges := 0
+	for h.spend() {
+		hedges++
+	}
+	assert.Equal(t, maxHedgingTokens, hedges)
+
+	h = newHedger(&Client{cfg: Config{}})
+	assert.InDelta(t, defaultHedgingBudget, h.budget, 0)
+	// a negative budget disables hedging
+	h = newHedger(&Client{cfg: Config{HedgingBudget: -1}})
+	assert.False(t, h.enabled())
+}
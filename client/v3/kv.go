@@ -29,6 +29,8 @@ type (
 	GetResponse     pb.RangeResponse
 	DeleteResponse  pb.DeleteRangeResponse
 	TxnResponse     pb.TxnResponse
+	AppendResponse  pb.AppendResponse
+	ForEachResponse pb.ForEachResponse
 )
 
 type KV interface {
@@ -70,12 +72,16 @@ type OpResponse struct {
 	get *GetResponse
 	del *DeleteResponse
 	txn *TxnResponse
+	app *AppendResponse
+	fe  *ForEachResponse
 }
 
-func (op OpResponse) Put() *PutResponse    { return op.put }
-func (op OpResponse) Get() *GetResponse    { return op.get }
-func (op OpResponse) Del() *DeleteResponse { return op.del }
-func (op OpResponse) Txn() *TxnResponse    { return op.txn }
+func (op OpResponse) Put() *PutResponse         { return op.put }
+func (op OpResponse) Get() *GetResponse         { return op.get }
+func (op OpResponse) Del() *DeleteResponse      { return op.del }
+func (op OpResponse) Txn() *TxnResponse         { return op.txn }
+func (op OpResponse) Append() *AppendResponse   { return op.app }
+func (op OpResponse) ForEach() *ForEachResponse { return op.fe }
 
 func (resp *PutResponse) OpResponse() OpResponse {
 	return OpResponse{put: resp}
@@ -93,15 +99,29 @@ func (resp *TxnResponse) OpResponse() OpResponse {
 	return OpResponse{txn: resp}
 }
 
+func (resp *AppendResponse) OpResponse() OpResponse {
+	return OpResponse{app: resp}
+}
+
+func (resp *ForEachResponse) OpResponse() OpResponse {
+	return OpResponse{fe: resp}
+}
+
 type kv struct {
 	remote   pb.KVClient
 	callOpts []grpc.CallOption
+	// hedger sends hedged reads, nil if the KV has no client.
+	hedger *hedger
 }
 
 func NewKV(c *Client) KV {
 	api := &kv{remote: RetryKVClient(c)}
 	if c != nil {
 		api.callOpts = c.callOpts
+		api.hedger = c.hedger
+		if c.leader != nil {
+			api.remote = &leaderKVClient{KVClient: api.remote, r: c.leader}
+		}
 	}
 	return api
 }
@@ -110,6 +130,7 @@ func NewKVFromKVClient(remote pb.KVClient, c *Client) KV {
 	api := &kv{remote: remote}
 	if c != nil {
 		api.callOpts = c.callOpts
+		api.hedger = c.hedger
 	}
 	return api
 }
@@ -151,7 +172,11 @@ func (kv *kv) Do(ctx context.Context, op Op) (OpResponse, error) {
 	case tRange:
 		if op.IsSortOptionValid() {
 			var resp *pb.RangeResponse
-			resp, err = kv.remote.Range(ctx, op.toRangeRequest(), kv.callOpts...)
+			if op.hedgeDelay > 0 && kv.hedger != nil && kv.hedger.enabled() {
+				resp, err = kv.hedger.Range(ctx, op.toRangeRequest(), op.hedgeDelay, kv.callOpts...)
+			} else {
+				resp, err = kv.remote.Range(ctx, op.toRangeRequest(), kv.callOpts...)
+			}
 			if err == nil {
 				return OpResponse{get: (*GetResponse)(resp)}, nil
 			}
@@ -160,14 +185,14 @@ func (kv *kv) Do(ctx context.Context, op Op) (OpResponse, error) {
 		}
 	case tPut:
 		var resp *pb.PutResponse
-		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease}
+		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease, SequenceSuffix: op.sequenceSuffix, ReturnKv: op.returnKV}
 		resp, err = kv.remote.Put(ctx, r, kv.callOpts...)
 		if err == nil {
 			return OpResponse{put: (*PutResponse)(resp)}, nil
 		}
 	case tDeleteRange:
 		var resp *pb.DeleteRangeResponse
-		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV}
+		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV, MaxDeletions: op.maxDeletions, IfCountLessThan: op.ifCountLessThan}
 		resp, err = kv.remote.DeleteRange(ctx, r, kv.callOpts...)
 		if err == nil {
 			return OpResponse{del: (*DeleteResponse)(resp)}, nil
@@ -178,6 +203,26 @@ func (kv *kv) Do(ctx context.Context, op Op) (OpResponse, error) {
 		if err == nil {
 			return OpResponse{txn: (*TxnResponse)(resp)}, nil
 		}
+	case tAppend:
+		// appends are only applied within a transaction
+		var resp *pb.TxnResponse
+		r := &pb.TxnRequest{Success: []*pb.RequestOp{op.toRequestOp()}}
+		resp, err = kv.remote.Txn(ctx, r, kv.callOpts...)
+		if err == nil {
+			ar := resp.Responses[0].GetResponseAppend()
+			ar.Header = resp.Header
+			return OpResponse{app: (*AppendResponse)(ar)}, nil
+		}
+	case tForEach:
+		// foreach is only applied within a transaction
+		var resp *pb.TxnResponse
+		r := &pb.TxnRequest{Success: []*pb.RequestOp{op.toRequestOp()}}
+		resp, err = kv.remote.Txn(ctx, r, kv.callOpts...)
+		if err == nil {
+			fr := resp.Responses[0].GetResponseForEach()
+			fr.Header = resp.Header
+			return OpResponse{fe: (*ForEachResponse)(fr)}, nil
+		}
 	default:
 		panic("Unknown op")
 	}
@@ -0,0 +1,262 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+const (
+	// followerHintValidity is how long the leader reported by a follower is
+	// trusted. The leader reports its own validity, which is usually longer.
+	followerHintValidity = time.Second
+	// memberListTimeout bounds the member lists resolving the members of the
+	// endpoints.
+	memberListTimeout = 5 * time.Second
+)
+
+// leaderRouter tracks the leader reported by the response headers and the
+// endpoint of the client it is reachable at, see Config.LeaderRouting.
+type leaderRouter struct {
+	c *Client
+
+	mu sync.Mutex
+	// leader is the member ID of the leader, 0 if unknown.
+	leader uint64
+	// validUntil is when the leader must be confirmed again.
+	validUntil time.Time
+	// endpoint is the endpoint of the leader, "" if the leader is not one of
+	// the endpoints of the client or its endpoint is not known yet.
+	endpoint string
+	// eps are the endpoints of the client.
+	eps []string
+	// members are the IDs of the members answering at the endpoints.
+	members map[string]uint64
+	// resolving is whether the members are being resolved.
+	resolving bool
+}
+
+func newLeaderRouter(c *Client) *leaderRouter {
+	return &leaderRouter{c: c, eps: c.Endpoints(), members: make(map[string]uint64)}
+}
+
+// observe records the leader reported by a response header.
+func (r *leaderRouter) observe(h *pb.ResponseHeader) {
+	if h == nil || h.Leader == 0 {
+		return
+	}
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	validity := followerHintValidity
+	if h.LeaderValidMs > 0 {
+		validity = time.Duration(h.LeaderValidMs) * time.Millisecond
+	}
+	switch {
+	case h.Leader != r.leader:
+		r.leader = h.Leader
+		r.endpoint = r.endpointOf(h.Leader)
+		r.validUntil = now.Add(validity)
+	case h.LeaderValidMs > 0 || now.Add(validity).After(r.validUntil):
+		// a follower does not shorten the validity reported by the leader.
+		r.validUntil = now.Add(validity)
+	}
+	if r.endpoint == "" {
+		r.resolve()
+	}
+}
+
+// endpointOf returns the endpoint of the client the member answers at, or
+// "" if there is none.
+func (r *leaderRouter) endpointOf(id uint64) string {
+	for _, ep := range r.eps {
+		if mid, ok := r.members[ep]; ok && mid == id {
+			return ep
+		}
+	}
+	return ""
+}
+
+// resolve finds the members answering at the endpoints of the client in the
+// background, from the headers of a member list sent to each endpoint not
+// resolved yet.
+func (r *leaderRouter) resolve() {
+	if r.resolving {
+		return
+	}
+	var eps []string
+	for _, ep := range r.eps {
+		if _, ok := r.members[ep]; !ok {
+			eps = append(eps, ep)
+		}
+	}
+	if len(eps) == 0 {
+		return
+	}
+	r.resolving = true
+	go func() {
+		ctx, cancel := context.WithTimeout(r.c.ctx, memberListTimeout)
+		defer cancel()
+		ids := make([]uint64, len(eps))
+		var wg sync.WaitGroup
+		for i, ep := range eps {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				conn, err := r.c.epConns.get(ep)
+				if err != nil {
+					return
+				}
+				resp, err := pb.NewClusterClient(conn).MemberList(ctx, &pb.MemberListRequest{})
+				if err != nil {
+					r.c.GetLogger().Debug("failed to resolve the member of an endpoint", zap.String("endpoint", ep), zap.Error(err))
+					return
+				}
+				ids[i] = resp.Header.MemberId
+			}()
+		}
+		wg.Wait()
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.resolving = false
+		for i, ep := range eps {
+			if ids[i] != 0 {
+				r.members[ep] = ids[i]
+			}
+		}
+		if r.leader != 0 {
+			r.endpoint = r.endpointOf(r.leader)
+		}
+	}()
+}
+
+func (r *leaderRouter) setEndpoints(eps []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.eps = eps
+	r.endpoint = r.endpointOf(r.leader)
+	if r.leader != 0 && r.endpoint == "" {
+		r.resolve()
+	}
+}
+
+// kv returns the KV client of the leader and its endpoint, or nil if the
+// leader is not known.
+func (r *leaderRouter) kv() (pb.KVClient, string) {
+	r.mu.Lock()
+	ep := r.endpoint
+	if ep == "" || time.Now().After(r.validUntil) {
+		r.mu.Unlock()
+		return nil, ""
+	}
+	r.mu.Unlock()
+	conn, err := r.c.epConns.get(ep)
+	if err != nil {
+		return nil, ""
+	}
+	return pb.NewKVClient(conn), ep
+}
+
+// fallback returns whether a request sent to the leader at the endpoint
+// failed without being served, in which case it is sent to the balanced
+// endpoints again and the leader is forgotten.
+func (r *leaderRouter) fallback(ep string, err error, mutable bool) bool {
+	if err == nil {
+		return false
+	}
+	eErr := rpctypes.Error(err)
+	notLeader := errors.Is(eErr, rpctypes.ErrNotLeader) || errors.Is(eErr, rpctypes.ErrNoLeader)
+	if !notLeader && (mutable && !isSafeRetryMutableRPC(err) || !mutable && !isSafeRetryImmutableRPC(err)) {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.endpoint == ep {
+		r.leader, r.endpoint, r.validUntil = 0, "", time.Time{}
+	}
+	r.c.GetLogger().Debug("falling back from the leader endpoint", zap.String("endpoint", ep), zap.Error(err))
+	return true
+}
+
+// leaderKVClient sends linearizable reads and writes to the leader while it
+// is known, and the other requests to the wrapped client.
+type leaderKVClient struct {
+	pb.KVClient
+	r *leaderRouter
+}
+
+func (lk *leaderKVClient) Range(ctx context.Context, in *pb.RangeRequest, opts ...grpc.CallOption) (*pb.RangeResponse, error) {
+	if !in.Serializable {
+		if kvc, ep := lk.r.kv(); kvc != nil {
+			resp, err := kvc.Range(ctx, in, opts...)
+			if !lk.r.fallback(ep, err, false) {
+				lk.r.observe(resp.GetHeader())
+				return resp, err
+			}
+		}
+	}
+	resp, err := lk.KVClient.Range(ctx, in, opts...)
+	lk.r.observe(resp.GetHeader())
+	return resp, err
+}
+
+func (lk *leaderKVClient) Put(ctx context.Context, in *pb.PutRequest, opts ...grpc.CallOption) (*pb.PutResponse, error) {
+	if kvc, ep := lk.r.kv(); kvc != nil {
+		resp, err := kvc.Put(ctx, in, opts...)
+		if !lk.r.fallback(ep, err, true) {
+			lk.r.observe(resp.GetHeader())
+			return resp, err
+		}
+	}
+	resp, err := lk.KVClient.Put(ctx, in, opts...)
+	lk.r.observe(resp.GetHeader())
+	return resp, err
+}
+
+func (lk *leaderKVClient) DeleteRange(ctx context.Context, in *pb.DeleteRangeRequest, opts ...grpc.CallOption) (*pb.DeleteRangeResponse, error) {
+	if kvc, ep := lk.r.kv(); kvc != nil {
+		resp, err := kvc.DeleteRange(ctx, in, opts...)
+		if !lk.r.fallback(ep, err, true) {
+			lk.r.observe(resp.GetHeader())
+			return resp, err
+		}
+	}
+	resp, err := lk.KVClient.DeleteRange(ctx, in, opts...)
+	lk.r.observe(resp.GetHeader())
+	return resp, err
+}
+
+func (lk *leaderKVClient) Txn(ctx context.Context, in *pb.TxnRequest, opts ...grpc.CallOption) (*pb.TxnResponse, error) {
+	if kvc, ep := lk.r.kv(); kvc != nil {
+		resp, err := kvc.Txn(ctx, in, opts...)
+		if !lk.r.fallback(ep, err, true) {
+			lk.r.observe(resp.GetHeader())
+			return resp, err
+		}
+	}
+	resp, err := lk.KVClient.Txn(ctx, in, opts...)
+	lk.r.observe(resp.GetHeader())
+	return resp, err
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+func newTestLeaderRouter(t *testing.T, eps ...string) *leaderRouter {
+	c := &Client{lg: zaptest.NewLogger(t), lgMu: new(sync.RWMutex), epMu: new(sync.RWMutex), endpoints: eps}
+	return newLeaderRouter(c)
+}
+
+func TestLeaderRouterObserve(t *testing.T) {
+	r := newTestLeaderRouter(t, "http://10.0.0.1:2379", "10.0.0.2:2379")
+	r.members = map[string]uint64{"http://10.0.0.1:2379": 1, "10.0.0.2:2379": 2, "10.0.0.3:2379": 3}
+	r.resolving = true // no member list
+
+	r.observe(&pb.ResponseHeader{MemberId: 2})
+	assert.Equal(t, "", r.endpoint, "header without leader")
+
+	// a follower reports the leader for a short time
+	r.observe(&pb.ResponseHeader{MemberId: 2, Leader: 1})
+	assert.Equal(t, "http://10.0.0.1:2379", r.endpoint)
+	assert.WithinDuration(t, time.Now().Add(followerHintValidity), r.validUntil, followerHintValidity/2)
+
+	// the leader reports its own validity, which a follower does not shorten
+	r.observe(&pb.ResponseHeader{MemberId: 1, Leader: 1, LeaderValidMs: 10000})
+	r.observe(&pb.ResponseHeader{MemberId: 2, Leader: 1})
+	assert.WithinDuration(t, time.Now().Add(10*time.Second), r.validUntil, time.Second)
+
+	// a new leader replaces the old one, even if reported by a follower
+	r.observe(&pb.ResponseHeader{MemberId: 1, Leader: 2})
+	assert.Equal(t, uint64(2), r.leader)
+	assert.Equal(t, "10.0.0.2:2379", r.endpoint)
+
+	// a leader which is not an endpoint of the client is not routed to
+	r.observe(&pb.ResponseHeader{MemberId: 3, Leader: 3, LeaderValidMs: 10000})
+	assert.Equal(t, "", r.endpoint)
+	r.setEndpoints([]string{"10.0.0.3:2379"})
+	assert.Equal(t, "10.0.0.3:2379", r.endpoint)
+}
+
+func TestLeaderRouterFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		mutable bool
+		want    bool
+	}{
+		{name: "success", err: nil, want: false},
+		{name: "not leader", err: rpctypes.ErrGRPCNotLeader, mutable: true, want: true},
+		{name: "no leader", err: rpctypes.ErrGRPCNoLeader, mutable: true, want: true},
+		{name: "read unavailable", err: rpctypes.ErrGRPCLeaderChanged, want: true},
+		{name: "write unavailable", err: rpctypes.ErrGRPCLeaderChanged, mutable: true, want: false},
+		{name: "read failure", err: rpctypes.ErrGRPCCompacted, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestLeaderRouter(t, "10.0.0.1:2379")
+			r.members = map[string]uint64{"10.0.0.1:2379": 1}
+			r.observe(&pb.ResponseHeader{Leader: 1, LeaderValidMs: 10000})
+
+			assert.Equal(t, tt.want, r.fallback("10.0.0.1:2379", tt.err, tt.mutable))
+			if tt.want {
+				assert.Equal(t, "", r.endpoint, "leader is forgotten")
+			} else {
+				assert.Equal(t, "10.0.0.1:2379", r.endpoint)
+			}
+		})
+	}
+}
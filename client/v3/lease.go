@@ -39,6 +39,9 @@ type LeaseGrantResponse struct {
 	ID    LeaseID
 	TTL   int64
 	Error string
+	// FencingToken is greater than the fencing tokens of the leases granted
+	// before. Supported since etcd 3.7.
+	FencingToken int64
 }
 
 // LeaseKeepAliveResponse wraps the protobuf message LeaseKeepAliveResponse.
@@ -46,6 +49,8 @@ type LeaseKeepAliveResponse struct {
 	*pb.ResponseHeader
 	ID  LeaseID
 	TTL int64
+	// FencingToken is the fencing token of the lease. Supported since etcd 3.7.
+	FencingToken int64
 }
 
 // LeaseTimeToLiveResponse wraps the protobuf message LeaseTimeToLiveResponse.
@@ -204,7 +209,7 @@ func NewLeaseFromLeaseClient(remote pb.LeaseClient, c *Client, keepAliveTimeout
 		l.firstKeepAliveTimeout = defaultTTL
 	}
 	if c != nil {
-		l.lg = c.lg
+		l.lg = c.componentLogger(LogComponentLease)
 		l.callOpts = c.callOpts
 	}
 	reqLeaderCtx := WithRequireLeader(context.Background())
@@ -221,6 +226,7 @@ func (l *lessor) Grant(ctx context.Context, ttl int64) (*LeaseGrantResponse, err
 			ID:             LeaseID(resp.ID),
 			TTL:            resp.TTL,
 			Error:          resp.Error,
+			FencingToken:   resp.FencingToken,
 		}
 		return gresp, nil
 	}
@@ -442,6 +448,7 @@ func (l *lessor) keepAliveOnce(ctx context.Context, id LeaseID) (karesp *LeaseKe
 		ResponseHeader: resp.GetHeader(),
 		ID:             LeaseID(resp.ID),
 		TTL:            resp.TTL,
+		FencingToken:   resp.FencingToken,
 	}
 	return karesp, nil
 }
@@ -521,6 +528,7 @@ func (l *lessor) recvKeepAlive(resp *pb.LeaseKeepAliveResponse) {
 		ResponseHeader: resp.GetHeader(),
 		ID:             LeaseID(resp.ID),
 		TTL:            resp.TTL,
+		FencingToken:   resp.FencingToken,
 	}
 
 	l.mu.Lock()
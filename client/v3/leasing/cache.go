@@ -158,6 +158,19 @@ func (lc *leaseCache) Update(key, val []byte, respHeader *v3pb.ResponseHeader) {
 	}
 }
 
+// appendValue updates a cached key after val was appended to it on the server.
+func (lc *leaseCache) appendValue(key string, val []byte, respHeader *v3pb.ResponseHeader) {
+	li := lc.entries[key]
+	if li == nil {
+		return
+	}
+	var newVal []byte
+	if len(li.response.Kvs) != 0 {
+		newVal = append(newVal, li.response.Kvs[0].Value...)
+	}
+	lc.Update([]byte(key), append(newVal, val...), respHeader)
+}
+
 func (lc *leaseCache) Delete(key string, hdr *v3pb.ResponseHeader) {
 	lc.mu.Lock()
 	defer lc.mu.Unlock()
@@ -193,7 +206,9 @@ func (lc *leaseCache) EvictRange(key, end string) {
 	}
 }
 
-func isBadOp(op v3.Op) bool { return op.Rev() > 0 || len(op.RangeBytes()) > 0 }
+func isBadOp(op v3.Op) bool {
+	return op.Rev() > 0 || len(op.RangeBytes()) > 0 || op.CountModificationsSince() > 0
+}
 
 func (lc *leaseCache) Get(ctx context.Context, op v3.Op) (*v3.GetResponse, bool) {
 	if isBadOp(op) {
@@ -110,6 +110,22 @@ func (lkv *leasingKV) Do(ctx context.Context, op v3.Op) (v3.OpResponse, error) {
 		cmps, thenOps, elseOps := op.Txn()
 		resp, err := lkv.Txn(ctx).If(cmps...).Then(thenOps...).Else(elseOps...).Commit()
 		return resp.OpResponse(), err
+	case op.IsAppend():
+		resp, err := lkv.Txn(ctx).Then(op).Commit()
+		if err != nil {
+			return v3.OpResponse{}, err
+		}
+		ar := resp.Responses[0].GetResponseAppend()
+		ar.Header = resp.Header
+		return (*v3.AppendResponse)(ar).OpResponse(), nil
+	case op.IsForEach():
+		resp, err := lkv.Txn(ctx).Then(op).Commit()
+		if err != nil {
+			return v3.OpResponse{}, err
+		}
+		fr := resp.Responses[0].GetResponseForEach()
+		fr.Header = resp.Header
+		return (*v3.ForEachResponse)(fr).OpResponse(), nil
 	}
 	return v3.OpResponse{}, nil
 }
@@ -230,6 +246,11 @@ func (lkv *leasingKV) tryModifyOp(ctx context.Context, op v3.Op) (*v3.TxnRespons
 }
 
 func (lkv *leasingKV) put(ctx context.Context, op v3.Op) (pr *v3.PutResponse, err error) {
+	if op.IsSequenceSuffix() {
+		// the put creates a new key, which no cache can hold yet
+		resp, err := lkv.kv.Do(ctx, op)
+		return resp.Put(), err
+	}
 	if err := lkv.waitSession(ctx); err != nil {
 		return nil, err
 	}
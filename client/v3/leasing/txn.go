@@ -171,9 +171,21 @@ func (txn *txnLeasing) commitToCache(txnResp *v3pb.TxnResponse, userTxn v3.Op) {
 		} else if op.IsDelete() {
 			txn.lkv.leases.delete(key, txnResp.Header)
 		}
-		if op.IsPut() {
+		if op.IsPut() && !op.IsSequenceSuffix() {
 			txn.lkv.leases.Update(op.KeyBytes(), op.ValueBytes(), txnResp.Header)
 		}
+		if op.IsAppend() {
+			txn.lkv.leases.appendValue(key, op.ValueBytes(), txnResp.Header)
+		}
+		if op.IsForEach() {
+			// the keys a foreach modified are not known here; drop its range
+			end := string(op.RangeBytes())
+			for k := range txn.lkv.leases.entries {
+				if k == key || (end != "" && inRange(k, key, end)) {
+					txn.lkv.leases.delete(k, txnResp.Header)
+				}
+			}
+		}
 	}
 	txn.lkv.leases.mu.Unlock()
 }
@@ -15,9 +15,12 @@
 package clientv3
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"os"
 
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zapgrpc"
 	"google.golang.org/grpc/grpclog"
@@ -58,3 +61,150 @@ func etcdClientDebugLevel() zapcore.Level {
 	}
 	return l
 }
+
+// LogComponent identifies a client component in Config.LogLevels.
+type LogComponent string
+
+const (
+	// LogComponentBalancer covers the selection and sync of endpoints.
+	LogComponentBalancer LogComponent = "balancer"
+	// LogComponentWatch covers the watch streams.
+	LogComponentWatch LogComponent = "watch"
+	// LogComponentLease covers the lease keep alives.
+	LogComponentLease LogComponent = "lease"
+	// LogComponentRetry covers the retries of requests.
+	LogComponentRetry LogComponent = "retry"
+)
+
+var logComponents = []LogComponent{LogComponentBalancer, LogComponentWatch, LogComponentLease, LogComponentRetry}
+
+// newComponentLoggers returns the named loggers of the client components,
+// restricted to the given levels.
+func newComponentLoggers(lg *zap.Logger, levels map[LogComponent]slog.Level) map[LogComponent]*zap.Logger {
+	if lg == nil {
+		return nil
+	}
+	lgs := make(map[LogComponent]*zap.Logger, len(logComponents))
+	for _, comp := range logComponents {
+		clg := lg.Named(string(comp))
+		if level, ok := levels[comp]; ok {
+			zl := slogToZapLevel(level)
+			clg = clg.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+				return &minLevelCore{Core: core, level: zl}
+			}))
+		}
+		lgs[comp] = clg
+	}
+	return lgs
+}
+
+// minLevelCore drops the entries of a core below a minimum level.
+type minLevelCore struct {
+	zapcore.Core
+	level zapcore.Level
+}
+
+func (c *minLevelCore) Enabled(l zapcore.Level) bool {
+	return l >= c.level && c.Core.Enabled(l)
+}
+
+func (c *minLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &minLevelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *minLevelCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if e.Level < c.level {
+		return ce
+	}
+	return c.Core.Check(e, ce)
+}
+
+// NewSlogLogger returns a zap logger writing to the given slog handler, for
+// the client APIs that take a zap logger. Config.LogHandler uses it to write
+// the client logs to a slog handler.
+func NewSlogLogger(h slog.Handler) *zap.Logger {
+	return zap.New(&slogCore{h: h})
+}
+
+// slogCore is a zap core writing to a slog handler.
+type slogCore struct {
+	h slog.Handler
+}
+
+func (c *slogCore) Enabled(l zapcore.Level) bool {
+	return c.h.Enabled(context.Background(), zapToSlogLevel(l))
+}
+
+func (c *slogCore) With(fields []zapcore.Field) zapcore.Core {
+	return &slogCore{h: c.h.WithAttrs(zapFieldsToAttrs(fields))}
+}
+
+func (c *slogCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *slogCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	r := slog.NewRecord(e.Time, zapToSlogLevel(e.Level), e.Message, e.Caller.PC)
+	if e.LoggerName != "" {
+		r.AddAttrs(slog.String("logger", e.LoggerName))
+	}
+	r.AddAttrs(zapFieldsToAttrs(fields)...)
+	return c.h.Handle(context.Background(), r)
+}
+
+func (c *slogCore) Sync() error {
+	return nil
+}
+
+func zapFieldsToAttrs(fields []zapcore.Field) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			attrs = append(attrs, slog.String(f.Key, f.String))
+		case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+			attrs = append(attrs, slog.Int64(f.Key, f.Integer))
+		case zapcore.BoolType:
+			attrs = append(attrs, slog.Bool(f.Key, f.Integer == 1))
+		case zapcore.SkipType:
+		default:
+			// let zap encode the other types, e.g. errors, durations and arrays
+			enc := zapcore.NewMapObjectEncoder()
+			f.AddTo(enc)
+			for k, v := range enc.Fields {
+				attrs = append(attrs, slog.Any(k, v))
+			}
+		}
+	}
+	return attrs
+}
+
+func zapToSlogLevel(l zapcore.Level) slog.Level {
+	switch {
+	case l <= zapcore.DebugLevel:
+		return slog.LevelDebug
+	case l == zapcore.InfoLevel:
+		return slog.LevelInfo
+	case l == zapcore.WarnLevel:
+		return slog.LevelWarn
+	default:
+		// zap levels above error are written as errors
+		return slog.LevelError
+	}
+}
+
+func slogToZapLevel(l slog.Level) zapcore.Level {
+	switch {
+	case l <= slog.LevelDebug:
+		return zapcore.DebugLevel
+	case l <= slog.LevelInfo:
+		return zapcore.InfoLevel
+	case l <= slog.LevelWarn:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
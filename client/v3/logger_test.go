@@ -0,0 +1,107 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func decodeSlogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		m := make(map[string]any)
+		require.NoError(t, json.Unmarshal([]byte(line), &m))
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	lg := NewSlogLogger(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	lg.Debug("dropped")
+	lg.Named("etcd-client").With(zap.String("endpoint", "a")).Warn("failed",
+		zap.Int("attempt", 2),
+		zap.Bool("retry", true),
+		zap.Error(errors.New("boom")),
+		zap.Duration("backoff", time.Second),
+		zap.Strings("endpoints", []string{"a", "b"}),
+	)
+
+	lines := decodeSlogLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "WARN", lines[0]["level"])
+	assert.Equal(t, "failed", lines[0]["msg"])
+	assert.Equal(t, "etcd-client", lines[0]["logger"])
+	assert.Equal(t, "a", lines[0]["endpoint"])
+	assert.InDelta(t, 2, lines[0]["attempt"], 0)
+	assert.Equal(t, true, lines[0]["retry"])
+	assert.Equal(t, "boom", lines[0]["error"])
+	assert.Equal(t, []any{"a", "b"}, lines[0]["endpoints"])
+	assert.Contains(t, lines[0], "backoff")
+}
+
+func TestComponentLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	lg := NewSlogLogger(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	lgs := newComponentLoggers(lg, map[LogComponent]slog.Level{
+		LogComponentRetry: slog.LevelWarn,
+	})
+	require.Len(t, lgs, len(logComponents))
+
+	lgs[LogComponentRetry].Info("dropped")
+	lgs[LogComponentRetry].With(zap.String("k", "v")).Debug("dropped")
+	lgs[LogComponentRetry].Warn("retry")
+	lgs[LogComponentWatch].Debug("watch")
+
+	lines := decodeSlogLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "retry", lines[0]["logger"])
+	assert.Equal(t, "retry", lines[0]["msg"])
+	assert.Equal(t, "watch", lines[1]["logger"])
+	assert.Equal(t, "DEBUG", lines[1]["level"])
+}
+
+func TestConfigLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	// not NewClient, which sets a test logger
+	c, err := New(Config{
+		Endpoints:  []string{"localhost:0"},
+		LogHandler: slog.NewJSONHandler(&buf, nil),
+		LogLevels:  map[LogComponent]slog.Level{LogComponentBalancer: slog.LevelError},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	c.componentLogger(LogComponentBalancer).Warn("dropped")
+	c.componentLogger(LogComponentWatch).Warn("watch")
+	lines := decodeSlogLines(t, &buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "etcd-client.watch", lines[0]["logger"])
+}
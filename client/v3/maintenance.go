@@ -19,11 +19,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 )
 
 type (
@@ -35,15 +37,65 @@ type (
 	MoveLeaderResponse pb.MoveLeaderResponse
 	DowngradeResponse  pb.DowngradeResponse
 
+	CompactionHoldGrantResponse  pb.CompactionHoldGrantResponse
+	CompactionHoldRevokeResponse pb.CompactionHoldRevokeResponse
+	CompactionHoldListResponse   pb.CompactionHoldListResponse
+	GarbageCollectResponse       pb.GarbageCollectResponse
+	MemoryStatsResponse          pb.MemoryStatsResponse
+	VerifySnapshotResponse       pb.VerifySnapshotResponse
+	HotKeysResponse              pb.HotKeysResponse
+	JobListResponse              pb.JobListResponse
+	JobStatusResponse            pb.JobStatusResponse
+	JobCancelResponse            pb.JobCancelResponse
+	NewerFieldsResponse          pb.NewerFieldsResponse
+	CheckpointResponse           pb.CheckpointResponse
+	DrainMemberResponse          pb.DrainMemberResponse
+	StorageStatsResponse         pb.StorageStatsResponse
+	ReseedResponse               pb.ReseedResponse
+	FencePrefixResponse          pb.FencePrefixResponse
+	SetRequestLoggingResponse    pb.SetRequestLoggingResponse
+
 	DowngradeAction pb.DowngradeRequest_DowngradeAction
+	HotKeysSortBy   pb.HotKeysRequest_SortBy
 )
 
 const (
 	DowngradeValidate = DowngradeAction(pb.DowngradeRequest_VALIDATE)
 	DowngradeEnable   = DowngradeAction(pb.DowngradeRequest_ENABLE)
 	DowngradeCancel   = DowngradeAction(pb.DowngradeRequest_CANCEL)
+	// DowngradeStatus gets the downgrade in progress and the features newer
+	// than its target version that requests to the endpoint tried to use.
+	// Supported since etcd 3.7.
+	DowngradeStatus = DowngradeAction(pb.DowngradeRequest_STATUS)
+)
+
+const (
+	HotKeysByReads      = HotKeysSortBy(pb.HotKeysRequest_READS)
+	HotKeysByWrites     = HotKeysSortBy(pb.HotKeysRequest_WRITES)
+	HotKeysByReadBytes  = HotKeysSortBy(pb.HotKeysRequest_READ_BYTES)
+	HotKeysByWriteBytes = HotKeysSortBy(pb.HotKeysRequest_WRITE_BYTES)
 )
 
+// RequestLogging is the setting of the request logging of a member.
+type RequestLogging struct {
+	// Duration is the time the requests are logged for, rounded up to
+	// seconds. Zero stops the logging.
+	Duration time.Duration
+	// SampleRate is the fraction of the matching requests logged. Zero logs
+	// all of them.
+	SampleRate float64
+	// Methods are the names of the logged methods, either short, such as
+	// "Range", or full, such as "/etcdserverpb.KV/Put". Empty logs all of
+	// them.
+	Methods []string
+	// Prefixes are the prefixes of the keys of the logged requests. Empty
+	// logs the requests of any key.
+	Prefixes []string
+	// LogValues logs the values of the requests, which are redacted
+	// otherwise.
+	LogValues bool
+}
+
 type Maintenance interface {
 	// AlarmList gets all active alarms.
 	AlarmList(ctx context.Context) (*AlarmResponse, error)
@@ -73,6 +125,11 @@ type Maintenance interface {
 	// "io.ReadCloser" would error out (e.g. context.Canceled, context.DeadlineExceeded).
 	SnapshotWithVersion(ctx context.Context) (*SnapshotResponse, error)
 
+	// SnapshotWithOptions is SnapshotWithVersion with a server-side rate limit
+	// and the ability to resume an interrupted transfer of the same snapshot.
+	// Supported since etcd 3.7.
+	SnapshotWithOptions(ctx context.Context, opts SnapshotOptions) (*SnapshotResponse, error)
+
 	// Snapshot provides a reader for a point-in-time snapshot of etcd.
 	// If the context "ctx" is canceled or timed out, reading from returned
 	// "io.ReadCloser" would error out (e.g. context.Canceled, context.DeadlineExceeded).
@@ -84,9 +141,145 @@ type Maintenance interface {
 	MoveLeader(ctx context.Context, transfereeID uint64) (*MoveLeaderResponse, error)
 
 	// Downgrade requests downgrades, verifies feasibility or cancels downgrade
-	// on the cluster version.
+	// on the cluster version, or gets the status of the downgrade.
 	// Supported since etcd 3.5.
 	Downgrade(ctx context.Context, action DowngradeAction, version string) (*DowngradeResponse, error)
+
+	// CompactionHoldGrant registers a hold asking auto-compaction not to compact
+	// past rev, so that a slow consumer can still read or watch from it. If rev
+	// is 0, the current revision of the cluster is held and returned in the
+	// response, as done by concurrency.ReadSnapshot. The hold expires after ttl
+	// seconds, bounded by the maximum hold duration of the server; ttl 0
+	// requests the maximum. Holds are advisory and do not prevent explicit
+	// compactions.
+	// Supported since etcd 3.7.
+	CompactionHoldGrant(ctx context.Context, name string, rev, ttl int64) (*CompactionHoldGrantResponse, error)
+
+	// CompactionHoldRenew extends the hold of the given ID for another ttl
+	// seconds and moves it to rev, typically as the consumer progresses.
+	// Supported since etcd 3.7.
+	CompactionHoldRenew(ctx context.Context, id, rev, ttl int64) (*CompactionHoldGrantResponse, error)
+
+	// CompactionHoldRevoke releases the hold of the given ID.
+	// Supported since etcd 3.7.
+	CompactionHoldRevoke(ctx context.Context, id int64) (*CompactionHoldRevokeResponse, error)
+
+	// CompactionHoldList lists the holds that have not expired.
+	// Supported since etcd 3.7.
+	CompactionHoldList(ctx context.Context) (*CompactionHoldListResponse, error)
+
+	// GarbageCollect reports the keys attached to leases that do not exist,
+	// the leases without keys at least emptyLeaseMinAge old and the number of
+	// auth tokens of deleted users held by the endpoint. Leases without keys
+	// are not reported if emptyLeaseMinAge is 0. If repair is set, the keys
+	// are deleted unless modified meanwhile, the leases revoked and the tokens
+	// invalidated. Requires admin privilege.
+	// Supported since etcd 3.7.
+	GarbageCollect(ctx context.Context, emptyLeaseMinAge time.Duration, repair bool) (*GarbageCollectResponse, error)
+
+	// MemoryStats gets an estimate of the memory held by the key index,
+	// watchers, leases and gRPC buffers of the given endpoint. Requires
+	// admin privilege.
+	// Supported since etcd 3.7.
+	MemoryStats(ctx context.Context, endpoint string) (*MemoryStatsResponse, error)
+
+	// VerifySnapshot checks whether the given endpoint has the keys of a
+	// snapshot, by comparing the hash of the keys of the snapshot at rev, as
+	// computed by HashKV, with the hash of the endpoint at the same revision.
+	// compactRev is the compacted revision of the snapshot, which must match
+	// the one of the endpoint. Requires admin privilege.
+	// Supported since etcd 3.7.
+	VerifySnapshot(ctx context.Context, endpoint string, rev int64, hash uint32, compactRev int64) (*VerifySnapshotResponse, error)
+
+	// HotKeys gets up to limit of the hottest keys of the requests served by
+	// the given endpoint, sorted by sortBy. A limit of 0 gets all the tracked
+	// keys. If reset is set, the endpoint clears the tracked usage once it is
+	// reported. The endpoint must have hot keys tracking enabled. Requires
+	// admin privilege.
+	// Supported since etcd 3.7.
+	HotKeys(ctx context.Context, endpoint string, sortBy HotKeysSortBy, limit int64, reset bool) (*HotKeysResponse, error)
+
+	// JobList lists the running and the latest ended maintenance jobs, such
+	// as defragmentations and garbage collections, of the given endpoint.
+	// Requires admin privilege.
+	// Supported since etcd 3.7.
+	JobList(ctx context.Context, endpoint string) (*JobListResponse, error)
+
+	// JobStatus gets the maintenance job of the given ID of the given
+	// endpoint. Requires admin privilege.
+	// Supported since etcd 3.7.
+	JobStatus(ctx context.Context, endpoint string, id int64) (*JobStatusResponse, error)
+
+	// JobCancel asks the running maintenance job of the given ID of the given
+	// endpoint to stop. It does not wait for the job to end. Requires admin
+	// privilege.
+	// Supported since etcd 3.7.
+	JobCancel(ctx context.Context, endpoint string, id int64) (*JobCancelResponse, error)
+
+	// NewerFields gets the fields, messages and enum values newer than the
+	// cluster version used by the requests served by the given endpoint. If
+	// reset is set, the endpoint clears them once they are reported. The
+	// endpoint must run with --newer-request-fields set to log or reject.
+	// Requires admin privilege.
+	// Supported since etcd 3.7.
+	NewerFields(ctx context.Context, endpoint string, reset bool) (*NewerFieldsResponse, error)
+
+	// Checkpoint makes the given endpoint write a checkpoint of its backend to
+	// its checkpoint directory, which is cheap on filesystems supporting
+	// copy-on-write clones. The oldest checkpoints beyond the retention of
+	// the endpoint are removed. Requires admin privilege.
+	// Supported since etcd 3.7.
+	Checkpoint(ctx context.Context, endpoint string) (*CheckpointResponse, error)
+
+	// DrainMember marks the member of the given endpoint as draining, or
+	// clears the mark if undrain is set. A draining member transfers its
+	// leadership away, rejects new lease grants and watches, closes its open
+	// watches and is skipped by the endpoint sync of the clients. The
+	// response reports the member as ready to be stopped once it is neither
+	// leader nor serving watches; call DrainMember again to poll it. A
+	// restarted member clears its mark once it is ready. Requires admin
+	// privilege.
+	// Supported since etcd 3.7.
+	DrainMember(ctx context.Context, endpoint string, undrain bool) (*DrainMemberResponse, error)
+
+	// StorageStats gets the number of keys and the size of each bucket of
+	// the backend of the given endpoint, as last tracked by the endpoint
+	// every --backend-bucket-stats-interval. Requires admin privilege.
+	// Supported since etcd 3.7.
+	StorageStats(ctx context.Context, endpoint string) (*StorageStatsResponse, error)
+
+	// Export streams the key-value pairs selected by key and opts, as for
+	// Get, at the revision set with WithRev, or else the current revision
+	// of the member serving the request. The revision is pinned once the
+	// export starts, so that compactions do not affect it. Canceling ctx
+	// stops the export. Requires admin privilege.
+	// Supported since etcd 3.7.
+	Export(ctx context.Context, key string, opts ...OpOption) (*ExportResponse, error)
+
+	// Reseed makes the member of the given endpoint replace its backend with
+	// a copy of the backend of the leader and clear its CORRUPT alarm, if
+	// raised, so that a corrupted member is repaired without being removed
+	// from the cluster. The member must not be the leader. The copy is sent
+	// over the peer network before the call returns, so ctx must allow for
+	// it. Requires admin privilege.
+	// Supported since etcd 3.7.
+	Reseed(ctx context.Context, endpoint string) (*ReseedResponse, error)
+
+	// FencePrefix makes the cluster reject the writes to the keys under the
+	// prefix with rpctypes.ErrPrefixFenced for ttl seconds, for example to
+	// quiesce a subtree while its data is migrated. Writes sent with
+	// WithFenceToken and the given token are let through; an empty token lets
+	// no write through. Fencing a prefix again replaces its fence, and a ttl
+	// of 0 lifts it. Requires admin privilege.
+	// Supported since etcd 3.7.
+	FencePrefix(ctx context.Context, prefix string, ttl int64, token string) (*FencePrefixResponse, error)
+
+	// SetRequestLogging makes the given endpoint log the unary requests it
+	// serves matching rl for rl.Duration, to capture them during an incident
+	// without restarting the member. It replaces the previous setting of the
+	// endpoint. Requires admin privilege.
+	// Supported since etcd 3.7.
+	SetRequestLogging(ctx context.Context, endpoint string, rl RequestLogging) (*SetRequestLoggingResponse, error)
 }
 
 // SnapshotResponse is aggregated response from the snapshot stream.
@@ -102,6 +295,64 @@ type SnapshotResponse struct {
 	// Informs which etcd server version should be used when restoring the snapshot.
 	// Supported on etcd >= v3.6.
 	Version string
+	// Offset is the position in the snapshot at which Snapshot starts.
+	// It is zero unless the transfer was resumed.
+	Offset int64
+	// ID identifies the snapshot to resume the transfer of if it is
+	// interrupted. It is zero if the server does not support resuming.
+	ID uint64
+}
+
+// SnapshotOptions configures a snapshot transfer.
+type SnapshotOptions struct {
+	// ResumeID is the SnapshotResponse.ID of an interrupted transfer to
+	// resume. The server keeps the snapshot of an interrupted transfer for
+	// a minute; afterwards, resuming fails with rpctypes.ErrSnapshotNotFound.
+	ResumeID uint64
+	// Offset is the number of leading snapshot bytes already received from
+	// the interrupted transfer.
+	Offset int64
+	// OffsetSHA256 is the sha256 digest of the first Offset bytes of the
+	// snapshot. Resuming fails with rpctypes.ErrSnapshotMismatch if it does
+	// not match the snapshot kept by the server.
+	OffsetSHA256 []byte
+	// RateLimit is the maximum number of bytes per second sent by the server.
+	// Zero means no limit.
+	RateLimit uint64
+}
+
+// ExportResponse is the stream of an export. Its key-value pairs are
+// received with Next.
+type ExportResponse struct {
+	// Header is the header of the first response of the export stream.
+	Header *pb.ResponseHeader
+	// Revision is the revision the key-value pairs are exported at.
+	Revision int64
+
+	ctx    context.Context
+	stream pb.Maintenance_ExportClient
+	kvs    []*mvccpb.KeyValue
+}
+
+// Next returns the next key-value pairs of the export, in key order. It
+// returns io.EOF once all of them are returned.
+func (er *ExportResponse) Next() ([]*mvccpb.KeyValue, error) {
+	if kvs := er.kvs; kvs != nil {
+		er.kvs = nil
+		return kvs, nil
+	}
+	for {
+		resp, err := er.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, ContextError(er.ctx, err)
+		}
+		if len(resp.Kvs) > 0 {
+			return resp.Kvs, nil
+		}
+	}
 }
 
 type maintenance struct {
@@ -228,7 +479,17 @@ func (m *maintenance) HashKV(ctx context.Context, endpoint string, rev int64) (*
 }
 
 func (m *maintenance) SnapshotWithVersion(ctx context.Context) (*SnapshotResponse, error) {
-	ss, err := m.remote.Snapshot(ctx, &pb.SnapshotRequest{}, append(m.callOpts, withMax(defaultStreamMaxRetries))...)
+	return m.SnapshotWithOptions(ctx, SnapshotOptions{})
+}
+
+func (m *maintenance) SnapshotWithOptions(ctx context.Context, opts SnapshotOptions) (*SnapshotResponse, error) {
+	req := &pb.SnapshotRequest{
+		ResumeId:     opts.ResumeID,
+		Offset:       uint64(opts.Offset),
+		OffsetSha256: opts.OffsetSHA256,
+		RateLimit:    opts.RateLimit,
+	}
+	ss, err := m.remote.Snapshot(ctx, req, append(m.callOpts, withMax(defaultStreamMaxRetries))...)
 	if err != nil {
 		return nil, ContextError(ctx, err)
 	}
@@ -267,6 +528,8 @@ func (m *maintenance) SnapshotWithVersion(ctx context.Context) (*SnapshotRespons
 		Header:   resp.GetHeader(),
 		Snapshot: &snapshotReadCloser{ctx: ctx, ReadCloser: pr},
 		Version:  resp.GetVersion(),
+		Offset:   int64(resp.GetOffset()),
+		ID:       resp.GetSnapshotId(),
 	}, nil
 }
 
@@ -342,9 +605,238 @@ func (m *maintenance) Downgrade(ctx context.Context, action DowngradeAction, ver
 		actionType = pb.DowngradeRequest_ENABLE
 	case DowngradeCancel:
 		actionType = pb.DowngradeRequest_CANCEL
+	case DowngradeStatus:
+		actionType = pb.DowngradeRequest_STATUS
 	default:
 		return nil, errors.New("etcdclient: unknown downgrade action")
 	}
 	resp, err := m.remote.Downgrade(ctx, &pb.DowngradeRequest{Action: actionType, Version: version}, m.callOpts...)
 	return (*DowngradeResponse)(resp), ContextError(ctx, err)
 }
+
+func (m *maintenance) CompactionHoldGrant(ctx context.Context, name string, rev, ttl int64) (*CompactionHoldGrantResponse, error) {
+	resp, err := m.remote.CompactionHoldGrant(ctx, &pb.CompactionHoldGrantRequest{Name: name, Revision: rev, TTL: ttl}, m.callOpts...)
+	return (*CompactionHoldGrantResponse)(resp), ContextError(ctx, err)
+}
+
+func (m *maintenance) CompactionHoldRenew(ctx context.Context, id, rev, ttl int64) (*CompactionHoldGrantResponse, error) {
+	if id == 0 {
+		return nil, errors.New("etcdclient: compaction hold ID must be set")
+	}
+	resp, err := m.remote.CompactionHoldGrant(ctx, &pb.CompactionHoldGrantRequest{ID: id, Revision: rev, TTL: ttl}, m.callOpts...)
+	return (*CompactionHoldGrantResponse)(resp), ContextError(ctx, err)
+}
+
+func (m *maintenance) CompactionHoldRevoke(ctx context.Context, id int64) (*CompactionHoldRevokeResponse, error) {
+	resp, err := m.remote.CompactionHoldRevoke(ctx, &pb.CompactionHoldRevokeRequest{ID: id}, m.callOpts...)
+	return (*CompactionHoldRevokeResponse)(resp), ContextError(ctx, err)
+}
+
+func (m *maintenance) CompactionHoldList(ctx context.Context) (*CompactionHoldListResponse, error) {
+	resp, err := m.remote.CompactionHoldList(ctx, &pb.CompactionHoldListRequest{}, m.callOpts...)
+	return (*CompactionHoldListResponse)(resp), ContextError(ctx, err)
+}
+
+func (m *maintenance) GarbageCollect(ctx context.Context, emptyLeaseMinAge time.Duration, repair bool) (*GarbageCollectResponse, error) {
+	req := &pb.GarbageCollectRequest{Repair: repair}
+	if emptyLeaseMinAge > 0 {
+		req.EmptyLeaseMinAge = max(int64(emptyLeaseMinAge/time.Second), 1)
+	}
+	resp, err := m.remote.GarbageCollect(ctx, req, m.callOpts...)
+	return (*GarbageCollectResponse)(resp), ContextError(ctx, err)
+}
+
+func (m *maintenance) MemoryStats(ctx context.Context, endpoint string) (*MemoryStatsResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.MemoryStats(ctx, &pb.MemoryStatsRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*MemoryStatsResponse)(resp), nil
+}
+
+func (m *maintenance) VerifySnapshot(ctx context.Context, endpoint string, rev int64, hash uint32, compactRev int64) (*VerifySnapshotResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.VerifySnapshot(ctx, &pb.VerifySnapshotRequest{Revision: rev, Hash: hash, CompactRevision: compactRev}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*VerifySnapshotResponse)(resp), nil
+}
+
+func (m *maintenance) HotKeys(ctx context.Context, endpoint string, sortBy HotKeysSortBy, limit int64, reset bool) (*HotKeysResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	req := &pb.HotKeysRequest{SortBy: pb.HotKeysRequest_SortBy(sortBy), Limit: limit, Reset_: reset}
+	resp, err := remote.HotKeys(ctx, req, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*HotKeysResponse)(resp), nil
+}
+
+func (m *maintenance) JobList(ctx context.Context, endpoint string) (*JobListResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.JobList(ctx, &pb.JobListRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*JobListResponse)(resp), nil
+}
+
+func (m *maintenance) JobStatus(ctx context.Context, endpoint string, id int64) (*JobStatusResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.JobStatus(ctx, &pb.JobStatusRequest{ID: id}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*JobStatusResponse)(resp), nil
+}
+
+func (m *maintenance) JobCancel(ctx context.Context, endpoint string, id int64) (*JobCancelResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.JobCancel(ctx, &pb.JobCancelRequest{ID: id}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*JobCancelResponse)(resp), nil
+}
+
+func (m *maintenance) NewerFields(ctx context.Context, endpoint string, reset bool) (*NewerFieldsResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.NewerFields(ctx, &pb.NewerFieldsRequest{Reset_: reset}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*NewerFieldsResponse)(resp), nil
+}
+
+func (m *maintenance) Checkpoint(ctx context.Context, endpoint string) (*CheckpointResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.Checkpoint(ctx, &pb.CheckpointRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*CheckpointResponse)(resp), nil
+}
+
+func (m *maintenance) Reseed(ctx context.Context, endpoint string) (*ReseedResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.Reseed(ctx, &pb.ReseedRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*ReseedResponse)(resp), nil
+}
+
+func (m *maintenance) FencePrefix(ctx context.Context, prefix string, ttl int64, token string) (*FencePrefixResponse, error) {
+	resp, err := m.remote.FencePrefix(ctx, &pb.FencePrefixRequest{Prefix: []byte(prefix), TTL: ttl, Token: token}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*FencePrefixResponse)(resp), nil
+}
+
+func (m *maintenance) SetRequestLogging(ctx context.Context, endpoint string, rl RequestLogging) (*SetRequestLoggingResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	req := &pb.SetRequestLoggingRequest{
+		Duration:   int64((rl.Duration + time.Second - 1) / time.Second),
+		SampleRate: rl.SampleRate,
+		Methods:    rl.Methods,
+		LogValues:  rl.LogValues,
+	}
+	for _, p := range rl.Prefixes {
+		req.Prefixes = append(req.Prefixes, []byte(p))
+	}
+	resp, err := remote.SetRequestLogging(ctx, req, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*SetRequestLoggingResponse)(resp), nil
+}
+
+func (m *maintenance) DrainMember(ctx context.Context, endpoint string, undrain bool) (*DrainMemberResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.DrainMember(ctx, &pb.DrainMemberRequest{Undrain: undrain}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*DrainMemberResponse)(resp), nil
+}
+
+func (m *maintenance) StorageStats(ctx context.Context, endpoint string) (*StorageStatsResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.StorageStats(ctx, &pb.StorageStatsRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*StorageStatsResponse)(resp), nil
+}
+
+func (m *maintenance) Export(ctx context.Context, key string, opts ...OpOption) (*ExportResponse, error) {
+	op := OpGet(key, opts...)
+	req := &pb.ExportRequest{Key: op.key, RangeEnd: op.end, Revision: op.rev}
+	stream, err := m.remote.Export(ctx, req, append(m.callOpts, withMax(defaultStreamMaxRetries))...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	// the first response tells the exported revision
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return &ExportResponse{
+		Header:   resp.Header,
+		Revision: resp.Revision,
+		ctx:      ctx,
+		stream:   stream,
+		kvs:      resp.Kvs,
+	}, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics receives the measurements of the RPCs sent by a Client. Its
+// methods may be called concurrently. Method names are full gRPC method
+// names such as "/etcdserverpb.KV/Range".
+type Metrics interface {
+	// RequestDone records a unary RPC to method that completed with code
+	// after d, including the time spent on retries.
+	RequestDone(method string, code codes.Code, d time.Duration)
+	// RequestRetried records a retry of a unary RPC to method.
+	RequestRetried(method string)
+	// StreamStarted records the opening of a stream to method, code being
+	// the outcome of opening it.
+	StreamStarted(method string, code codes.Code)
+}
+
+// meterName is the name of the OpenTelemetry meter of the client.
+const meterName = "go.etcd.io/etcd/client/v3"
+
+type otelMetrics struct {
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+	retries  metric.Int64Counter
+	streams  metric.Int64Counter
+}
+
+// newOTelMetrics records the client metrics with the meters of mp.
+func newOTelMetrics(mp metric.MeterProvider) (Metrics, error) {
+	meter := mp.Meter(meterName)
+	requests, err1 := meter.Int64Counter("etcd.client.requests",
+		metric.WithDescription("Number of unary RPCs completed by the client."))
+	duration, err2 := meter.Float64Histogram("etcd.client.request.duration",
+		metric.WithDescription("Duration of the unary RPCs completed by the client, including retries."),
+		metric.WithUnit("s"))
+	retries, err3 := meter.Int64Counter("etcd.client.retries",
+		metric.WithDescription("Number of unary RPCs retried by the client."))
+	streams, err4 := meter.Int64Counter("etcd.client.streams",
+		metric.WithDescription("Number of streams opened by the client."))
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		return nil, err
+	}
+	return &otelMetrics{requests: requests, duration: duration, retries: retries, streams: streams}, nil
+}
+
+func (m *otelMetrics) RequestDone(method string, code codes.Code, d time.Duration) {
+	ctx := context.Background()
+	m.requests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.grpc.status_code", code.String())))
+	m.duration.Record(ctx, d.Seconds(), metric.WithAttributes(attribute.String("rpc.method", method)))
+}
+
+func (m *otelMetrics) RequestRetried(method string) {
+	m.retries.Add(context.Background(), 1, metric.WithAttributes(attribute.String("rpc.method", method)))
+}
+
+func (m *otelMetrics) StreamStarted(method string, code codes.Code) {
+	m.streams.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.grpc.status_code", code.String())))
+}
+
+// unaryMetricsInterceptor records the outcome of the RPCs going through
+// next, which retries them.
+func (c *Client) unaryMetricsInterceptor(next grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := next(ctx, method, req, reply, cc, invoker, opts...)
+		c.metrics.RequestDone(method, status.Code(err), time.Since(start))
+		return err
+	}
+}
+
+// streamMetricsInterceptor records the streams opened through next.
+func (c *Client) streamMetricsInterceptor(next grpc.StreamClientInterceptor) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		s, err := next(ctx, desc, cc, method, streamer, opts...)
+		c.metrics.StreamStarted(method, status.Code(err))
+		return s, err
+	}
+}
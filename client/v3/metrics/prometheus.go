@@ -0,0 +1,88 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides implementations of clientv3.Metrics.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Prometheus records the client metrics as Prometheus collectors.
+type Prometheus struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	streams  *prometheus.CounterVec
+}
+
+var _ clientv3.Metrics = (*Prometheus)(nil)
+
+// NewPrometheus returns client metrics registered with reg. The same
+// metrics may be shared by several clients.
+func NewPrometheus(reg prometheus.Registerer) (*Prometheus, error) {
+	m := &Prometheus{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "client",
+			Name:      "requests_total",
+			Help:      "Total number of unary RPCs completed by the client.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "etcd",
+			Subsystem: "client",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the unary RPCs completed by the client, including retries.",
+
+			// 0.5ms to 4s
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"method"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "client",
+			Name:      "retries_total",
+			Help:      "Total number of unary RPCs retried by the client.",
+		}, []string{"method"}),
+		streams: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "client",
+			Name:      "streams_started_total",
+			Help:      "Total number of streams opened by the client.",
+		}, []string{"method", "code"}),
+	}
+	for _, c := range []prometheus.Collector{m.requests, m.duration, m.retries, m.streams} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Prometheus) RequestDone(method string, code codes.Code, d time.Duration) {
+	m.requests.WithLabelValues(method, code.String()).Inc()
+	m.duration.WithLabelValues(method).Observe(d.Seconds())
+}
+
+func (m *Prometheus) RequestRetried(method string) {
+	m.retries.WithLabelValues(method).Inc()
+}
+
+func (m *Prometheus) StreamStarted(method string, code codes.Code) {
+	m.streams.WithLabelValues(method, code.String()).Inc()
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type counterPrefix struct {
+	clientv3.Counter
+	pfx string
+}
+
+// NewCounter wraps a Counter instance so that all counter keys
+// are prefixed with a given string and all responses have the
+// prefix removed.
+func NewCounter(c clientv3.Counter, prefix string) clientv3.Counter {
+	if inner, ok := c.(*counterPrefix); ok {
+		return &counterPrefix{inner.Counter, inner.pfx + prefix}
+	}
+	return &counterPrefix{c, prefix}
+}
+
+func (c *counterPrefix) CounterAdd(ctx context.Context, deltas ...clientv3.CounterDelta) (*clientv3.CounterAddResponse, error) {
+	pfxDeltas := make([]clientv3.CounterDelta, len(deltas))
+	for i, d := range deltas {
+		if len(d.Key) == 0 {
+			return nil, rpctypes.ErrEmptyKey
+		}
+		pfxDeltas[i] = clientv3.CounterDelta{Key: c.pfx + d.Key, Delta: d.Delta}
+	}
+	resp, err := c.Counter.CounterAdd(ctx, pfxDeltas...)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range resp.Counters {
+		v.Key = v.Key[len(c.pfx):]
+	}
+	return resp, nil
+}
+
+func (c *counterPrefix) CounterGet(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.CounterGetResponse, error) {
+	if len(key) == 0 && !(clientv3.IsOptsWithFromKey(opts) || clientv3.IsOptsWithPrefix(opts)) {
+		return nil, rpctypes.ErrEmptyKey
+	}
+	// since OpOption is opaque, determine range for prefixing through an OpGet
+	op := clientv3.OpGet(key, opts...)
+	pfxBegin, pfxEnd := prefixInterval(c.pfx, []byte(key), op.RangeBytes())
+	if pfxEnd != nil {
+		opts = append(opts, clientv3.WithRange(string(pfxEnd)))
+	}
+	resp, err := c.Counter.CounterGet(ctx, string(pfxBegin), opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range resp.Counters {
+		v.Key = v.Key[len(c.pfx):]
+	}
+	return resp, nil
+}
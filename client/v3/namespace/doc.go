@@ -28,6 +28,7 @@
 //	cli.KV = namespace.NewKV(cli.KV, "my-prefix/")
 //	cli.Watcher = namespace.NewWatcher(cli.Watcher, "my-prefix/")
 //	cli.Lease = namespace.NewLease(cli.Lease, "my-prefix/")
+//	cli.Counter = namespace.NewCounter(cli.Counter, "my-prefix/")
 //
 // Now calls using 'cli' will namespace / prefix all keys with "my-prefix/":
 //
@@ -39,4 +40,13 @@
 //	resp, _ = cli.Get(context.TODO(), "abc")
 //	fmt.Printf("%s\n", resp.Kvs[0].Value)
 //	// Output: 456
+//
+// Namespaces may be stacked by wrapping an already namespaced interface;
+// the keys are then prefixed with the outer prefix inside the inner one:
+//
+//	cli.KV = namespace.NewKV(namespace.NewKV(unprefixedKV, "my-prefix/"), "app/")
+//	cli.Put(context.TODO(), "abc", "789")
+//	resp, _ = unprefixedKV.Get(context.TODO(), "my-prefix/app/abc")
+//	fmt.Printf("%s\n", resp.Kvs[0].Value)
+//	// Output: 789
 package namespace
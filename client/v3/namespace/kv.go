@@ -28,8 +28,12 @@ type kvPrefix struct {
 }
 
 // NewKV wraps a KV instance so that all requests
-// are prefixed with a given string.
+// are prefixed with a given string. Wrapping a namespaced KV
+// nests the namespace under the one of the wrapped KV.
 func NewKV(kv clientv3.KV, prefix string) clientv3.KV {
+	if inner, ok := kv.(*kvPrefix); ok {
+		return &kvPrefix{inner.KV, inner.pfx + prefix}
+	}
 	return &kvPrefix{kv, prefix}
 }
 
@@ -94,6 +98,10 @@ func (kv *kvPrefix) Do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse
 		kv.unprefixDeleteResponse(r.Del())
 	case r.Txn() != nil:
 		kv.unprefixTxnResponse(r.Txn())
+	case r.Append() != nil:
+		kv.unprefixAppendResponse(r.Append())
+	case r.ForEach() != nil:
+		kv.unprefixForEachResponse(r.ForEach())
 	}
 	return r, nil
 }
@@ -152,6 +160,12 @@ func (kv *kvPrefix) unprefixPutResponse(resp *clientv3.PutResponse) {
 	if resp.PrevKv != nil {
 		resp.PrevKv.Key = resp.PrevKv.Key[len(kv.pfx):]
 	}
+	if len(resp.Key) != 0 {
+		resp.Key = resp.Key[len(kv.pfx):]
+	}
+	if resp.Kv != nil {
+		resp.Kv.Key = resp.Kv.Key[len(kv.pfx):]
+	}
 }
 
 func (kv *kvPrefix) unprefixDeleteResponse(resp *clientv3.DeleteResponse) {
@@ -160,6 +174,19 @@ func (kv *kvPrefix) unprefixDeleteResponse(resp *clientv3.DeleteResponse) {
 	}
 }
 
+func (kv *kvPrefix) unprefixAppendResponse(resp *clientv3.AppendResponse) {
+	if resp.PrevKv != nil {
+		resp.PrevKv.Key = resp.PrevKv.Key[len(kv.pfx):]
+	}
+}
+
+func (kv *kvPrefix) unprefixForEachResponse(resp *clientv3.ForEachResponse) {
+	for i := range resp.Keys {
+		resp.Keys[i] = resp.Keys[i][len(kv.pfx):]
+	}
+	kv.unprefixTxnResponse(&clientv3.TxnResponse{Responses: resp.Responses})
+}
+
 func (kv *kvPrefix) unprefixTxnResponse(resp *clientv3.TxnResponse) {
 	for _, r := range resp.Responses {
 		switch tv := r.Response.(type) {
@@ -179,6 +206,14 @@ func (kv *kvPrefix) unprefixTxnResponse(resp *clientv3.TxnResponse) {
 			if tv.ResponseTxn != nil {
 				kv.unprefixTxnResponse((*clientv3.TxnResponse)(tv.ResponseTxn))
 			}
+		case *pb.ResponseOp_ResponseAppend:
+			if tv.ResponseAppend != nil {
+				kv.unprefixAppendResponse((*clientv3.AppendResponse)(tv.ResponseAppend))
+			}
+		case *pb.ResponseOp_ResponseForEach:
+			if tv.ResponseForEach != nil {
+				kv.unprefixForEachResponse((*clientv3.ForEachResponse)(tv.ResponseForEach))
+			}
 		default:
 		}
 	}
@@ -0,0 +1,620 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+)
+
+var (
+	// ErrWriteQueued is returned by writes that could not reach the cluster
+	// and were queued by the offline queue, to be replayed later.
+	ErrWriteQueued = errors.New("etcdclient: write queued for replay")
+	// ErrOfflineWriteConflict is passed to the offline queue error handler
+	// for queued writes dropped because their keys were modified by
+	// others while the client was offline.
+	ErrOfflineWriteConflict = errors.New("etcdclient: queued write conflicts with a newer revision")
+)
+
+const (
+	offlineQueueFile      = "queue"
+	offlineQueueStateFile = "state"
+
+	offlineReplayTimeout    = 5 * time.Second
+	offlineReplayMinBackoff = 100 * time.Millisecond
+	offlineReplayMaxBackoff = 30 * time.Second
+)
+
+var offlineQueueCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// WithOfflineQueue is a New option that enables the offline write queue,
+// persisted in dir. Puts without lease and deletes that fail before being
+// sent, because no connection to the cluster can be established, are
+// appended to the queue, and return ErrWriteQueued instead of failing.
+// Writes failing after being sent, such as on a deadline, may have been
+// applied and return their error instead, so that they are never applied
+// twice. The queued writes are replayed in
+// order once the cluster is reachable again, also after a restart of the
+// client. While writes are queued, new writes are queued behind them to
+// keep the order; reads are not affected and do not observe queued writes.
+//
+// A queued write is replayed only if its keys were not modified after the
+// latest revision the client observed when queueing it; otherwise it is
+// dropped and reported to the handler set by WithOfflineQueueErrorHandler
+// with ErrOfflineWriteConflict.
+func WithOfflineQueue(dir string) Option {
+	return func(c *Client) {
+		c.offlineQueueDir = dir
+	}
+}
+
+// WithOfflineQueueErrorHandler is a New option that sets the function called
+// with the queued writes dropped on replay, either because of a conflict or
+// because the cluster rejected them.
+func WithOfflineQueueErrorHandler(h func(op Op, err error)) Option {
+	return func(c *Client) {
+		c.offlineQueueOnError = h
+	}
+}
+
+// OfflineQueue returns the offline write queue of the client, or nil if it
+// is not enabled.
+func (c *Client) OfflineQueue() *OfflineQueue { return c.offlineQueue }
+
+// OfflineQueue is a KV that queues the writes that cannot reach the cluster.
+type OfflineQueue struct {
+	KV
+
+	c  *Client
+	lg *zap.Logger
+
+	// rev is the latest revision observed by the client.
+	rev atomic.Int64
+
+	mu      sync.Mutex
+	f       *fileutil.LockedFile
+	dir     string
+	entries []offlineEntry
+	state   offlineQueueState
+	// drainc is closed when the queue becomes empty.
+	drainc chan struct{}
+
+	kickc chan struct{}
+	donec chan struct{}
+}
+
+type offlineEntry struct {
+	op Op
+	// rev is the latest revision observed by the client when the write was
+	// queued, or 0 if it did not observe any.
+	rev int64
+	// end is the offset of the end of the entry in the queue file.
+	end int64
+}
+
+// offlineQueueState records the progress of the replay.
+type offlineQueueState struct {
+	// Offset is the offset in the queue file of the next write to replay.
+	Offset int64 `json:"offset"`
+	// Written holds the revisions of the puts replayed from the queue, so
+	// that later writes to the same keys do not conflict with them.
+	Written []offlineWrite `json:"written,omitempty"`
+}
+
+type offlineWrite struct {
+	Key      []byte `json:"key"`
+	Revision int64  `json:"revision"`
+}
+
+func newOfflineQueue(c *Client, kv KV) (*OfflineQueue, error) {
+	lg := c.GetLogger()
+	if err := fileutil.TouchDirAll(lg, c.offlineQueueDir); err != nil {
+		return nil, err
+	}
+	f, err := fileutil.TryLockFile(filepath.Join(c.offlineQueueDir, offlineQueueFile), os.O_RDWR|os.O_CREATE, fileutil.PrivateFileMode)
+	if err != nil {
+		return nil, fmt.Errorf("cannot lock offline queue: %w", err)
+	}
+	q := &OfflineQueue{
+		KV:     kv,
+		c:      c,
+		lg:     lg,
+		f:      f,
+		dir:    c.offlineQueueDir,
+		drainc: make(chan struct{}),
+		kickc:  make(chan struct{}, 1),
+		donec:  make(chan struct{}),
+	}
+	if err = q.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if len(q.entries) == 0 {
+		close(q.drainc)
+	} else {
+		lg.Info("replaying offline queue", zap.String("dir", q.dir), zap.Int("writes", len(q.entries)))
+	}
+	go q.run()
+	return q, nil
+}
+
+// load reads the queued writes not replayed yet. A torn write at the end of
+// the queue file is truncated.
+func (q *OfflineQueue) load() error {
+	data, err := io.ReadAll(q.f)
+	if err != nil {
+		return err
+	}
+	var entries []offlineEntry
+	off := int64(0)
+	for int64(len(data)) > off {
+		e, n, derr := decodeOfflineEntry(data[off:])
+		if derr != nil {
+			q.lg.Warn("truncating offline queue", zap.String("dir", q.dir), zap.Int64("offset", off), zap.Error(derr))
+			if err = q.f.Truncate(off); err != nil {
+				return err
+			}
+			break
+		}
+		off += n
+		e.end = off
+		entries = append(entries, e)
+	}
+	if _, err = q.f.Seek(off, io.SeekStart); err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(filepath.Join(q.dir, offlineQueueStateFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		if err = json.Unmarshal(b, &q.state); err != nil {
+			return fmt.Errorf("cannot decode offline queue state: %w", err)
+		}
+	}
+	if q.state.Offset > off {
+		// the queue was reset after the state was saved
+		q.state = offlineQueueState{}
+	}
+	for len(entries) > 0 && entries[0].end <= q.state.Offset {
+		entries = entries[1:]
+	}
+	q.entries = entries
+	return nil
+}
+
+func decodeOfflineEntry(data []byte) (offlineEntry, int64, error) {
+	if len(data) < 8 {
+		return offlineEntry{}, 0, io.ErrUnexpectedEOF
+	}
+	size := int64(binary.BigEndian.Uint32(data))
+	if int64(len(data)-8) < size || size < 8 {
+		return offlineEntry{}, 0, io.ErrUnexpectedEOF
+	}
+	payload := data[8 : 8+size]
+	if crc32.Checksum(payload, offlineQueueCRCTable) != binary.BigEndian.Uint32(data[4:]) {
+		return offlineEntry{}, 0, errors.New("checksum mismatch")
+	}
+	var r pb.RequestOp
+	if err := r.Unmarshal(payload[8:]); err != nil {
+		return offlineEntry{}, 0, err
+	}
+	op, ok := opFromOfflineRequest(&r)
+	if !ok {
+		return offlineEntry{}, 0, errors.New("unexpected request")
+	}
+	return offlineEntry{op: op, rev: int64(binary.BigEndian.Uint64(payload))}, 8 + size, nil
+}
+
+func encodeOfflineEntry(e offlineEntry) ([]byte, error) {
+	r, err := e.op.toRequestOp().Marshal()
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 16+len(r))
+	binary.BigEndian.PutUint32(data, uint32(8+len(r)))
+	binary.BigEndian.PutUint64(data[8:], uint64(e.rev))
+	copy(data[16:], r)
+	binary.BigEndian.PutUint32(data[4:], crc32.Checksum(data[8:], offlineQueueCRCTable))
+	return data, nil
+}
+
+func opFromOfflineRequest(r *pb.RequestOp) (Op, bool) {
+	switch {
+	case r.GetRequestPut() != nil:
+		put := r.GetRequestPut()
+		return Op{t: tPut, key: put.Key, val: put.Value}, true
+	case r.GetRequestDeleteRange() != nil:
+		del := r.GetRequestDeleteRange()
+		return Op{t: tDeleteRange, key: del.Key, end: del.RangeEnd}, true
+	default:
+		return Op{}, false
+	}
+}
+
+// queueable returns true if op is a write that has the same effect when
+// replayed later.
+func queueable(op Op) bool {
+	switch op.t {
+	case tPut:
+		return op.leaseID == NoLease && !op.ignoreValue && !op.ignoreLease && !op.sequenceSuffix && !op.returnKV
+	case tDeleteRange:
+		return op.maxDeletions == 0 && op.ifCountLessThan == 0
+	default:
+		return false
+	}
+}
+
+// isUnsentErr returns true if err proves that the request never left the
+// client, as no connection to the cluster could be established. The outcome
+// of requests failing otherwise, such as on a deadline or on a connection
+// lost after sending, is unknown: they may have been applied.
+func isUnsentErr(err error) bool {
+	if ev, ok := status.FromError(err); !ok || ev.Code() != codes.Unavailable {
+		return false
+	}
+	desc := rpctypes.ErrorDesc(err)
+	return desc == "there is no address available" ||
+		desc == "there is no connection available" ||
+		strings.Contains(desc, "transport: Error while dialing")
+}
+
+// isOfflineErr returns true if err means the request could not reach the
+// cluster, or did not complete before the deadline.
+func isOfflineErr(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	ev, ok := status.FromError(err)
+	return ok && (ev.Code() == codes.Unavailable || ev.Code() == codes.DeadlineExceeded)
+}
+
+func (q *OfflineQueue) Put(ctx context.Context, key, val string, opts ...OpOption) (*PutResponse, error) {
+	r, err := q.Do(ctx, OpPut(key, val, opts...))
+	return r.put, err
+}
+
+func (q *OfflineQueue) Get(ctx context.Context, key string, opts ...OpOption) (*GetResponse, error) {
+	resp, err := q.KV.Get(ctx, key, opts...)
+	if err == nil {
+		q.observe(resp.Header)
+	}
+	return resp, err
+}
+
+func (q *OfflineQueue) Delete(ctx context.Context, key string, opts ...OpOption) (*DeleteResponse, error) {
+	r, err := q.Do(ctx, OpDelete(key, opts...))
+	return r.del, err
+}
+
+func (q *OfflineQueue) Do(ctx context.Context, op Op) (OpResponse, error) {
+	if !queueable(op) {
+		resp, err := q.KV.Do(ctx, op)
+		if err == nil {
+			q.observe(opResponseHeader(resp))
+		}
+		return resp, err
+	}
+	if q.Len() == 0 && !q.disconnected() {
+		resp, err := q.KV.Do(ctx, op)
+		if err == nil {
+			q.observe(opResponseHeader(resp))
+			return resp, nil
+		}
+		if !isUnsentErr(err) {
+			return resp, err
+		}
+	}
+	op.prevKV = false
+	if err := q.enqueue(op); err != nil {
+		return OpResponse{}, err
+	}
+	return OpResponse{}, ErrWriteQueued
+}
+
+// Len returns the number of queued writes.
+func (q *OfflineQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+// Flush waits until all the queued writes are replayed or dropped.
+func (q *OfflineQueue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	drainc := q.drainc
+	q.mu.Unlock()
+	q.kick()
+	select {
+	case <-drainc:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *OfflineQueue) disconnected() bool {
+	return q.c.conn != nil && q.c.conn.GetState() == connectivity.TransientFailure
+}
+
+func (q *OfflineQueue) observe(h *pb.ResponseHeader) {
+	rev := h.GetRevision()
+	for {
+		cur := q.rev.Load()
+		if rev <= cur || q.rev.CompareAndSwap(cur, rev) {
+			return
+		}
+	}
+}
+
+func opResponseHeader(resp OpResponse) *pb.ResponseHeader {
+	switch {
+	case resp.put != nil:
+		return resp.put.Header
+	case resp.get != nil:
+		return resp.get.Header
+	case resp.del != nil:
+		return resp.del.Header
+	case resp.txn != nil:
+		return resp.txn.Header
+	}
+	return nil
+}
+
+func (q *OfflineQueue) enqueue(op Op) error {
+	e := offlineEntry{op: op, rev: q.rev.Load()}
+	data, err := encodeOfflineEntry(e)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, err = q.f.Write(data); err != nil {
+		return err
+	}
+	if err = fileutil.Fdatasync(q.f.File); err != nil {
+		return err
+	}
+	if e.end, err = q.f.Seek(0, io.SeekCurrent); err != nil {
+		return err
+	}
+	if len(q.entries) == 0 {
+		q.drainc = make(chan struct{})
+	}
+	q.entries = append(q.entries, e)
+	q.kick()
+	return nil
+}
+
+func (q *OfflineQueue) kick() {
+	select {
+	case q.kickc <- struct{}{}:
+	default:
+	}
+}
+
+func (q *OfflineQueue) run() {
+	defer close(q.donec)
+	backoff := offlineReplayMinBackoff
+	for {
+		var retryc <-chan time.Time
+		if err := q.replay(); err != nil {
+			q.lg.Debug("offline queue replay interrupted", zap.Duration("backoff", backoff), zap.Error(err))
+			retryc = time.After(backoff)
+			backoff = min(2*backoff, offlineReplayMaxBackoff)
+		} else {
+			backoff = offlineReplayMinBackoff
+		}
+		select {
+		case <-q.c.ctx.Done():
+			return
+		case <-q.kickc:
+		case <-retryc:
+		}
+	}
+}
+
+// replay replays the queued writes in order, until the queue is empty or the
+// cluster cannot be reached.
+func (q *OfflineQueue) replay() error {
+	for {
+		q.mu.Lock()
+		if len(q.entries) == 0 {
+			q.mu.Unlock()
+			return nil
+		}
+		e := q.entries[0]
+		bound := q.conflictBound(e)
+		q.mu.Unlock()
+
+		written, err := q.replayEntry(e, bound)
+		if err != nil {
+			if isOfflineErr(err) || q.c.ctx.Err() != nil {
+				return err
+			}
+			q.drop(e.op, err)
+		}
+		if err = q.advance(e, written); err != nil {
+			return err
+		}
+	}
+}
+
+// conflictBound returns the highest revision the keys of e may be modified
+// at for it to be replayed, or 0 to replay it unconditionally.
+func (q *OfflineQueue) conflictBound(e offlineEntry) int64 {
+	if e.rev == 0 {
+		return 0
+	}
+	bound := e.rev
+	for _, w := range q.state.Written {
+		if offlineOpContains(e.op, w.Key) {
+			bound = max(bound, w.Revision)
+		}
+	}
+	return bound
+}
+
+func offlineOpContains(op Op, key []byte) bool {
+	switch {
+	case len(op.end) == 0:
+		return bytes.Equal(op.key, key)
+	case bytes.Equal(op.end, []byte{0}):
+		return bytes.Compare(op.key, key) <= 0
+	default:
+		return bytes.Compare(op.key, key) <= 0 && bytes.Compare(key, op.end) < 0
+	}
+}
+
+// replayEntry applies e if its keys were not modified after bound. It
+// returns the revision the put of e was applied at, if any.
+func (q *OfflineQueue) replayEntry(e offlineEntry, bound int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(q.c.ctx, offlineReplayTimeout)
+	defer cancel()
+	if bound == 0 {
+		resp, err := q.KV.Do(ctx, e.op)
+		if err != nil {
+			return 0, err
+		}
+		q.observe(opResponseHeader(resp))
+		if e.op.t == tPut {
+			return resp.put.Header.Revision, nil
+		}
+		return 0, nil
+	}
+
+	cmp := Compare(ModRevision(string(e.op.key)), "<", bound+1)
+	get := OpGet(string(e.op.key))
+	if len(e.op.end) > 0 {
+		cmp = cmp.WithRange(string(e.op.end))
+		get = OpGet(string(e.op.key), WithRange(string(e.op.end)), WithCountOnly())
+	}
+	resp, err := q.KV.Txn(ctx).If(cmp).Then(e.op).Else(get).Commit()
+	if err != nil {
+		return 0, err
+	}
+	q.observe(resp.Header)
+	if resp.Succeeded {
+		if e.op.t == tPut {
+			return resp.Header.Revision, nil
+		}
+		return 0, nil
+	}
+
+	// the write may have been applied by an earlier replay, as the outcome
+	// of replays failing on a deadline is unknown
+	rr := resp.Responses[0].GetResponseRange()
+	switch {
+	case e.op.t == tPut && len(rr.Kvs) == 1 && bytes.Equal(rr.Kvs[0].Value, e.op.val):
+		return rr.Kvs[0].ModRevision, nil
+	case e.op.t == tDeleteRange && rr.Count == 0:
+		return 0, nil
+	}
+	q.drop(e.op, ErrOfflineWriteConflict)
+	return 0, nil
+}
+
+func (q *OfflineQueue) drop(op Op, err error) {
+	q.lg.Warn(
+		"dropped queued write",
+		zap.String("key", string(op.key)),
+		zap.String("range-end", string(op.end)),
+		zap.Error(err),
+	)
+	if q.c.offlineQueueOnError != nil {
+		q.c.offlineQueueOnError(op, err)
+	}
+}
+
+// advance records that e was replayed, and resets the queue once it is
+// empty.
+func (q *OfflineQueue) advance(e offlineEntry, written int64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.entries = q.entries[1:]
+	if len(q.entries) == 0 {
+		if err := q.f.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := q.f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		q.state = offlineQueueState{}
+		close(q.drainc)
+		return q.saveState()
+	}
+
+	q.state.Offset = e.end
+	if written != 0 {
+		q.state.Written = append(q.state.Written, offlineWrite{Key: e.op.key, Revision: written})
+	}
+	return q.saveState()
+}
+
+func (q *OfflineQueue) saveState() error {
+	b, err := json.Marshal(q.state)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(q.dir, offlineQueueStateFile)
+	f, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileutil.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if err == nil {
+		err = fileutil.Fsync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (q *OfflineQueue) close() {
+	<-q.donec
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.f.Close()
+}
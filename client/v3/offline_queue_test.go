@@ -0,0 +1,124 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOfflineEntryEncoding(t *testing.T) {
+	for _, e := range []offlineEntry{
+		{op: OpPut("foo", "bar"), rev: 10},
+		{op: OpDelete("foo", WithPrefix())},
+	} {
+		data, err := encodeOfflineEntry(e)
+		require.NoError(t, err)
+		got, n, err := decodeOfflineEntry(data)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), n)
+		assert.Equal(t, e.rev, got.rev)
+		assert.Equal(t, e.op.t, got.op.t)
+		assert.Equal(t, e.op.key, got.op.key)
+		assert.Equal(t, e.op.end, got.op.end)
+		assert.Equal(t, e.op.val, got.op.val)
+
+		_, _, err = decodeOfflineEntry(data[:len(data)-1])
+		require.Error(t, err)
+		data[len(data)-1]++
+		_, _, err = decodeOfflineEntry(data)
+		require.Error(t, err)
+	}
+}
+
+func TestOfflineQueueQueueable(t *testing.T) {
+	assert.True(t, queueable(OpPut("foo", "bar")))
+	assert.True(t, queueable(OpDelete("foo", WithPrefix())))
+	assert.False(t, queueable(OpPut("foo", "bar", WithLease(1))))
+	assert.False(t, queueable(OpPut("foo", "", WithIgnoreValue())))
+	assert.False(t, queueable(OpPut("foo", "bar", WithReturnKV())))
+	assert.False(t, queueable(OpGet("foo")))
+	assert.False(t, queueable(OpTxn(nil, nil, nil)))
+
+	assert.True(t, isUnsentErr(status.Error(codes.Unavailable, "there is no connection available")))
+	assert.True(t, isUnsentErr(status.Error(codes.Unavailable, `connection error: desc = "transport: Error while dialing: dial tcp 127.0.0.1:2379: connect: connection refused"`)))
+	assert.False(t, isUnsentErr(status.Error(codes.Unavailable, "error reading from server: EOF")))
+	assert.False(t, isUnsentErr(status.Error(codes.DeadlineExceeded, "context deadline exceeded")))
+	assert.False(t, isUnsentErr(context.DeadlineExceeded))
+
+	assert.True(t, isOfflineErr(context.DeadlineExceeded))
+	assert.True(t, isOfflineErr(status.Error(codes.Unavailable, "unavailable")))
+	assert.False(t, isOfflineErr(context.Canceled))
+	assert.False(t, isOfflineErr(status.Error(codes.PermissionDenied, "denied")))
+}
+
+func TestOfflineQueueLoad(t *testing.T) {
+	dir := t.TempDir()
+	var data []byte
+	for _, op := range []Op{OpPut("a", "1"), OpPut("b", "2"), OpDelete("c")} {
+		b, err := encodeOfflineEntry(offlineEntry{op: op, rev: 5})
+		require.NoError(t, err)
+		data = append(data, b...)
+	}
+	first, err := encodeOfflineEntry(offlineEntry{op: OpPut("a", "1"), rev: 5})
+	require.NoError(t, err)
+	// a torn write at the end
+	require.NoError(t, os.WriteFile(filepath.Join(dir, offlineQueueFile), append(data, 0, 0, 1), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, offlineQueueStateFile), []byte(`{"offset":`+strconv.Itoa(len(first))+`}`), 0o600))
+
+	c := NewCtxClient(context.Background(), WithZapLogger(zaptest.NewLogger(t)), WithOfflineQueue(dir))
+	q, err := newOfflineQueue(c, unavailableKV{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, q.Len())
+	assert.Equal(t, []byte("b"), q.entries[0].op.key)
+	assert.Equal(t, int64(len(data)), q.entries[1].end)
+
+	// the directory can be used by a single client
+	_, err = newOfflineQueue(c, unavailableKV{})
+	require.Error(t, err)
+
+	c.offlineQueue = q
+	c.Close()
+	fi, err := os.Stat(filepath.Join(dir, offlineQueueFile))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), fi.Size())
+}
+
+// unavailableKV fails all requests as if the cluster cannot be reached.
+type unavailableKV struct{ KV }
+
+func (unavailableKV) Do(context.Context, Op) (OpResponse, error) {
+	return OpResponse{}, status.Error(codes.Unavailable, "unavailable")
+}
+
+func (unavailableKV) Txn(context.Context) Txn { return unavailableTxn{} }
+
+type unavailableTxn struct{}
+
+func (t unavailableTxn) If(...Cmp) Txn  { return t }
+func (t unavailableTxn) Then(...Op) Txn { return t }
+func (t unavailableTxn) Else(...Op) Txn { return t }
+func (unavailableTxn) Commit() (*TxnResponse, error) {
+	return nil, status.Error(codes.Unavailable, "unavailable")
+}
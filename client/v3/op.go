@@ -14,7 +14,11 @@
 
 package clientv3
 
-import pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+import (
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
 
 type opType int
 
@@ -24,6 +28,8 @@ const (
 	tPut
 	tDeleteRange
 	tTxn
+	tAppend
+	tForEach
 )
 
 var noPrefixEnd = []byte{0}
@@ -35,15 +41,19 @@ type Op struct {
 	end []byte
 
 	// for range
-	limit        int64
-	sort         *SortOption
-	serializable bool
-	keysOnly     bool
-	countOnly    bool
-	minModRev    int64
-	maxModRev    int64
-	minCreateRev int64
-	maxCreateRev int64
+	limit           int64
+	sort            *SortOption
+	serializable    bool
+	readConsistency ReadConsistency
+	keysOnly        bool
+	countOnly       bool
+	modsSince       int64
+	hedgeDelay      time.Duration
+	minAppliedIdx   uint64
+	minModRev       int64
+	maxModRev       int64
+	minCreateRev    int64
+	maxCreateRev    int64
 
 	// for range, watch
 	rev int64
@@ -56,10 +66,26 @@ type Op struct {
 	// if true, split watch events when total exceeds
 	// "--max-request-bytes" flag value + 512-byte
 	fragment bool
+	// maxResponseBytes is the largest watch response the server may send
+	maxResponseBytes int64
+	// initialState streams the current key-value pairs before the events
+	initialState bool
 
 	// for put
-	ignoreValue bool
-	ignoreLease bool
+	ignoreValue    bool
+	ignoreLease    bool
+	sequenceSuffix bool
+	returnKV       bool
+
+	// for delete
+	maxDeletions    int64
+	ifCountLessThan int64
+
+	// for append
+	maxSize int64
+
+	// for foreach
+	maxKeys int64
 
 	// progressNotify is for progress updates.
 	progressNotify bool
@@ -73,7 +99,7 @@ type Op struct {
 	val     []byte
 	leaseID LeaseID
 
-	// txn
+	// txn, foreach
 	cmps    []Cmp
 	thenOps []Op
 	elseOps []Op
@@ -118,6 +144,22 @@ func (op Op) IsGet() bool { return op.t == tRange }
 // IsDelete returns true iff the operation is a Delete.
 func (op Op) IsDelete() bool { return op.t == tDeleteRange }
 
+// IsAppend returns true iff the operation is an Append.
+func (op Op) IsAppend() bool { return op.t == tAppend }
+
+// MaxSize returns the maximum size of the appended value, if any.
+func (op Op) MaxSize() int64 { return op.maxSize }
+
+// IsForEach returns true iff the operation is a ForEach.
+func (op Op) IsForEach() bool { return op.t == tForEach }
+
+// ForEach returns the comparisons and the operations applied to each key of
+// a ForEach.
+func (op Op) ForEach() ([]Cmp, []Op) { return op.cmps, op.thenOps }
+
+// MaxKeys returns the maximum number of keys a ForEach may visit.
+func (op Op) MaxKeys() int64 { return op.maxKeys }
+
 // IsSerializable returns true if the serializable field is true.
 func (op Op) IsSerializable() bool { return op.serializable }
 
@@ -127,6 +169,17 @@ func (op Op) IsKeysOnly() bool { return op.keysOnly }
 // IsCountOnly returns whether countOnly is set.
 func (op Op) IsCountOnly() bool { return op.countOnly }
 
+// CountModificationsSince returns the revision after which modifications are counted.
+func (op Op) CountModificationsSince() int64 { return op.modsSince }
+
+// HedgingDelay returns the delay after which a hedged read is sent, 0 if the
+// read is not hedged.
+func (op Op) HedgingDelay() time.Duration { return op.hedgeDelay }
+
+// MinAppliedIndex returns the raft index the member serving a serializable
+// 'Get' request must have applied, 0 if any member can serve it.
+func (op Op) MinAppliedIndex() uint64 { return op.minAppliedIdx }
+
 // IsSortSet returns true if WithSort is set.
 func (op Op) IsSortSet() bool { return op.sort != nil }
 
@@ -137,6 +190,12 @@ func (op Op) IsOptsWithPrefix() bool { return op.isOptsWithPrefix }
 // IsPrevKV returns whether WithPrevKV() is set.
 func (op Op) IsPrevKV() bool { return op.prevKV }
 
+// IsSequenceSuffix returns whether WithSequenceSuffix() is set.
+func (op Op) IsSequenceSuffix() bool { return op.sequenceSuffix }
+
+// IsReturnKV returns whether WithReturnKV() is set.
+func (op Op) IsReturnKV() bool { return op.returnKV }
+
 // IsFragment returns whether WithFragment() is set.
 func (op Op) IsFragment() bool { return op.fragment }
 
@@ -189,6 +248,10 @@ func (op Op) toRangeRequest() *pb.RangeRequest {
 		MaxModRevision:    op.maxModRev,
 		MinCreateRevision: op.minCreateRev,
 		MaxCreateRevision: op.maxCreateRev,
+		ReadConsistency:   pb.RangeRequest_ReadConsistency(op.readConsistency),
+
+		CountModificationsSinceRev: op.modsSince,
+		MinAppliedIndex:            op.minAppliedIdx,
 	}
 	if op.sort != nil {
 		r.SortOrder = pb.RangeRequest_SortOrder(op.sort.Order)
@@ -206,11 +269,23 @@ func (op Op) toTxnRequest() *pb.TxnRequest {
 	for i, eOp := range op.elseOps {
 		elseOps[i] = eOp.toRequestOp()
 	}
+	return &pb.TxnRequest{Compare: op.toCompares(), Success: thenOps, Failure: elseOps}
+}
+
+func (op Op) toForEachRequest() *pb.ForEachRequest {
+	ops := make([]*pb.RequestOp, len(op.thenOps))
+	for i, fOp := range op.thenOps {
+		ops[i] = fOp.toRequestOp()
+	}
+	return &pb.ForEachRequest{Key: op.key, RangeEnd: op.end, MaxKeys: op.maxKeys, Compare: op.toCompares(), Ops: ops}
+}
+
+func (op Op) toCompares() []*pb.Compare {
 	cmps := make([]*pb.Compare, len(op.cmps))
 	for i := range op.cmps {
 		cmps[i] = (*pb.Compare)(&op.cmps[i])
 	}
-	return &pb.TxnRequest{Compare: cmps, Success: thenOps, Failure: elseOps}
+	return cmps
 }
 
 func (op Op) toRequestOp() *pb.RequestOp {
@@ -218,20 +293,25 @@ func (op Op) toRequestOp() *pb.RequestOp {
 	case tRange:
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: op.toRangeRequest()}}
 	case tPut:
-		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease}
+		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease, SequenceSuffix: op.sequenceSuffix, ReturnKv: op.returnKV}
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: r}}
 	case tDeleteRange:
-		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV}
+		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV, MaxDeletions: op.maxDeletions, IfCountLessThan: op.ifCountLessThan}
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: r}}
 	case tTxn:
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestTxn{RequestTxn: op.toTxnRequest()}}
+	case tAppend:
+		r := &pb.AppendRequest{Key: op.key, Value: op.val, MaxSize: op.maxSize, PrevKv: op.prevKV}
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestAppend{RequestAppend: r}}
+	case tForEach:
+		return &pb.RequestOp{Request: &pb.RequestOp_RequestForEach{RequestForEach: op.toForEachRequest()}}
 	default:
 		panic("Unknown Op")
 	}
 }
 
 func (op Op) isWrite() bool {
-	if op.t == tTxn {
+	if op.t == tTxn || op.t == tForEach {
 		for _, tOp := range op.thenOps {
 			if tOp.isWrite() {
 				return true
@@ -259,6 +339,12 @@ func OpGet(key string, opts ...OpOption) Op {
 	}
 	ret := Op{t: tRange, key: []byte(key)}
 	ret.applyOpts(opts)
+	if ret.hedgeDelay != 0 && !ret.serializable {
+		panic("`WithHedging` requires `WithSerializable`")
+	}
+	if ret.minAppliedIdx != 0 && !ret.serializable {
+		panic("`WithMinAppliedIndex` requires `WithSerializable`")
+	}
 	return ret
 }
 
@@ -281,8 +367,14 @@ func OpDelete(key string, opts ...OpOption) Op {
 		panic("unexpected sort in delete")
 	case ret.serializable:
 		panic("unexpected serializable in delete")
+	case ret.readConsistency != ReadConsistencyDefault:
+		panic("unexpected read consistency in delete")
 	case ret.countOnly:
 		panic("unexpected countOnly in delete")
+	case ret.modsSince != 0:
+		panic("unexpected count modifications in delete")
+	case ret.hedgeDelay != 0:
+		panic("unexpected hedging in delete")
 	case ret.minModRev != 0, ret.maxModRev != 0:
 		panic("unexpected mod revision filter in delete")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
@@ -310,8 +402,14 @@ func OpPut(key, val string, opts ...OpOption) Op {
 		panic("unexpected sort in put")
 	case ret.serializable:
 		panic("unexpected serializable in put")
+	case ret.readConsistency != ReadConsistencyDefault:
+		panic("unexpected read consistency in put")
 	case ret.countOnly:
 		panic("unexpected countOnly in put")
+	case ret.modsSince != 0:
+		panic("unexpected count modifications in put")
+	case ret.hedgeDelay != 0:
+		panic("unexpected hedging in put")
 	case ret.minModRev != 0, ret.maxModRev != 0:
 		panic("unexpected mod revision filter in put")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
@@ -324,6 +422,83 @@ func OpPut(key, val string, opts ...OpOption) Op {
 	return ret
 }
 
+// OpAppend returns "append" operation which appends val to the current value
+// of key, creating the key if it does not exist. Only WithPrevKV and
+// WithMaxSize are accepted as options. Appending requires etcd 3.7 or later
+// on every member.
+func OpAppend(key, val string, opts ...OpOption) Op {
+	ret := Op{t: tAppend, key: []byte(key), val: []byte(val)}
+	ret.applyOpts(opts)
+	switch {
+	case ret.end != nil:
+		panic("unexpected range in append")
+	case ret.leaseID != 0:
+		panic("unexpected lease in append")
+	case ret.limit != 0:
+		panic("unexpected limit in append")
+	case ret.rev != 0:
+		panic("unexpected revision in append")
+	case ret.sort != nil:
+		panic("unexpected sort in append")
+	case ret.serializable:
+		panic("unexpected serializable in append")
+	case ret.readConsistency != ReadConsistencyDefault:
+		panic("unexpected read consistency in append")
+	case ret.countOnly:
+		panic("unexpected countOnly in append")
+	case ret.modsSince != 0:
+		panic("unexpected count modifications in append")
+	case ret.hedgeDelay != 0:
+		panic("unexpected hedging in append")
+	case ret.minModRev != 0, ret.maxModRev != 0:
+		panic("unexpected mod revision filter in append")
+	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
+		panic("unexpected create revision filter in append")
+	case ret.filterDelete, ret.filterPut:
+		panic("unexpected filter in append")
+	case ret.createdNotify:
+		panic("unexpected createdNotify in append")
+	case ret.ignoreValue, ret.ignoreLease:
+		panic("unexpected ignore flag in append")
+	case ret.sequenceSuffix:
+		panic("unexpected sequence suffix in append")
+	case ret.returnKV:
+		panic("unexpected return kv in append")
+	}
+	return ret
+}
+
+// OpForEach returns "foreach" operation which applies ops to every key in
+// the range given by key and WithRange, WithPrefix or WithFromKey whose
+// key-value pair satisfies all of cmps. The keys of cmps and ops must be
+// empty; they are replaced by each matching key in turn. The range may not
+// hold more than WithMaxKeys keys, which must be set, or the transaction
+// fails with ErrForEachTooManyKeys. ForEach requires etcd 3.7 or later on
+// every member.
+func OpForEach(key string, cmps []Cmp, ops []Op, opts ...OpOption) Op {
+	// WithPrefix and WithFromKey are not supported together
+	if IsOptsWithPrefix(opts) && IsOptsWithFromKey(opts) {
+		panic("`WithPrefix` and `WithFromKey` cannot be set at the same time, choose one")
+	}
+	ret := Op{t: tForEach, key: []byte(key), cmps: cmps, thenOps: ops}
+	ret.applyOpts(opts)
+	switch {
+	case ret.leaseID != 0:
+		panic("unexpected lease in foreach")
+	case ret.limit != 0:
+		panic("unexpected limit in foreach")
+	case ret.rev != 0:
+		panic("unexpected revision in foreach")
+	case ret.sort != nil:
+		panic("unexpected sort in foreach")
+	case ret.serializable:
+		panic("unexpected serializable in foreach")
+	case ret.prevKV:
+		panic("unexpected prevKV in foreach")
+	}
+	return ret
+}
+
 // OpTxn returns "txn" operation based on given transaction conditions.
 func OpTxn(cmps []Cmp, thenOps []Op, elseOps []Op) Op {
 	return Op{t: tTxn, cmps: cmps, thenOps: thenOps, elseOps: elseOps}
@@ -341,8 +516,14 @@ func OpWatch(key string, opts ...OpOption) Op {
 		panic("unexpected sort in watch")
 	case ret.serializable:
 		panic("unexpected serializable in watch")
+	case ret.readConsistency != ReadConsistencyDefault:
+		panic("unexpected read consistency in watch")
 	case ret.countOnly:
 		panic("unexpected countOnly in watch")
+	case ret.modsSince != 0:
+		panic("unexpected count modifications in watch")
+	case ret.hedgeDelay != 0:
+		panic("unexpected hedging in watch")
 	case ret.minModRev != 0, ret.maxModRev != 0:
 		panic("unexpected mod revision filter in watch")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
@@ -459,6 +640,27 @@ func WithSerializable() OpOption {
 	return func(op *Op) { op.serializable = true }
 }
 
+// ReadConsistency selects how a linearizable 'Get' request confirms that it
+// reads the latest data.
+type ReadConsistency int
+
+const (
+	// ReadConsistencyDefault uses the read consistency configured on the server.
+	ReadConsistencyDefault ReadConsistency = iota
+	// ReadConsistencyReadIndex confirms the leadership of the leader with a
+	// quorum of members before reading.
+	ReadConsistencyReadIndex
+	// ReadConsistencyLease reads from the leader lease, without a round trip
+	// to a quorum of members. It relies on bounded clock drift between members.
+	ReadConsistencyLease
+)
+
+// WithReadConsistency sets the read consistency of a linearizable 'Get'
+// request. It has no effect on serializable requests.
+func WithReadConsistency(rc ReadConsistency) OpOption {
+	return func(op *Op) { op.readConsistency = rc }
+}
+
 // WithKeysOnly makes the 'Get' request return only the keys and the corresponding
 // values will be omitted.
 func WithKeysOnly() OpOption {
@@ -470,6 +672,35 @@ func WithCountOnly() OpOption {
 	return func(op *Op) { op.countOnly = true }
 }
 
+// WithCountModificationsSince makes the 'Get' request return only the number of
+// modifications, puts and deletes, in the range after the given revision. The
+// count is returned in GetResponse.Count. It fails with ErrCompacted if rev is
+// compacted. It requires etcd 3.7 or later on every member.
+func WithCountModificationsSince(rev int64) OpOption {
+	return func(op *Op) { op.modsSince = rev }
+}
+
+// WithHedging makes a serializable 'Get' request hedged: if the endpoint it is
+// sent to has not answered within delay, or fails, the request is also sent
+// to another endpoint, and the first successful response is returned. This
+// cuts the tail latency of reads while a member is slow. The extra load is
+// capped by Config.HedgingBudget. Hedging has no effect on requests in a
+// transaction, or if the client has a single endpoint.
+func WithHedging(delay time.Duration) OpOption {
+	return func(op *Op) { op.hedgeDelay = delay }
+}
+
+// WithMinAppliedIndex makes a serializable 'Get' request fail with
+// rpctypes.ErrAppliedIndexBehind on members that have not applied the raft
+// log up to index yet. The error is retried, on another endpoint if the
+// client has several, until a member that caught up serves the request.
+// Passing the AppliedIndex of a previous GetResponse ensures serializable
+// reads spread over several members never go back in time. It has no effect
+// on requests in a transaction. It requires etcd 3.7 or later on every member.
+func WithMinAppliedIndex(index uint64) OpOption {
+	return func(op *Op) { op.minAppliedIdx = index }
+}
+
 // WithMinModRev filters out keys for Get with modification revisions less than the given revision.
 func WithMinModRev(rev int64) OpOption { return func(op *Op) { op.minModRev = rev } }
 
@@ -539,6 +770,31 @@ func WithPrevKV() OpOption {
 	}
 }
 
+// WithMaxDeletions makes a delete request fail with ErrTooManyDeletions instead
+// of deleting anything if the range holds more than n keys.
+func WithMaxDeletions(n int64) OpOption {
+	return func(op *Op) { op.maxDeletions = n }
+}
+
+// WithMaxSize makes an append request fail with ErrValueTooLarge instead of
+// modifying the key if the resulting value would be larger than n bytes.
+func WithMaxSize(n int64) OpOption {
+	return func(op *Op) { op.maxSize = n }
+}
+
+// WithMaxKeys bounds the number of keys a foreach operation may visit. The
+// transaction fails if the range holds more than n keys.
+func WithMaxKeys(n int64) OpOption {
+	return func(op *Op) { op.maxKeys = n }
+}
+
+// WithIfCountLessThan makes a delete request only delete the range if it holds
+// fewer than n keys. Otherwise nothing is deleted and the response reports zero
+// deleted keys.
+func WithIfCountLessThan(n int64) OpOption {
+	return func(op *Op) { op.ifCountLessThan = n }
+}
+
 // WithFragment to receive raw watch response with fragmentation.
 // Fragmentation is disabled by default. If fragmentation is enabled,
 // etcd watch server will split watch response before sending to clients
@@ -550,6 +806,23 @@ func WithFragment() OpOption {
 	return func(op *Op) { op.fragment = true }
 }
 
+// WithMaxResponseBytes limits the size of the watch responses sent by the
+// server to n bytes. Larger responses are fragmented and reassembled by the
+// client before delivery. It overrides "Config.WatchMaxResponseBytes" and is
+// capped by the server-side request limit.
+func WithMaxResponseBytes(n int) OpOption {
+	return func(op *Op) { op.maxResponseBytes = int64(n) }
+}
+
+// WithInitialState makes a watcher first receive the current key-value pairs
+// of the watched range as PUT events, in responses with InitialState set,
+// and then the events that follow. With WithRev, the key-value pairs are the
+// ones at the revision before the given one. If the watch is interrupted
+// before InitialStateMore is unset, the initial state is sent again.
+func WithInitialState() OpOption {
+	return func(op *Op) { op.initialState = true }
+}
+
 // WithIgnoreValue updates the key using its current value.
 // This option can not be combined with non-empty values.
 // Returns an error if the key does not exist.
@@ -568,6 +841,28 @@ func WithIgnoreLease() OpOption {
 	}
 }
 
+// WithSequenceSuffix makes 'Put' treat the key as a prefix and create the
+// key made of the prefix followed by the zero padded revision of the put, so
+// that keys created under the same prefix are unique and sort in creation
+// order. The created key is returned in PutResponse.Key.
+// This option can not be combined with WithIgnoreValue or WithIgnoreLease,
+// and requires etcd 3.7 or later on every member.
+func WithSequenceSuffix() OpOption {
+	return func(op *Op) {
+		op.sequenceSuffix = true
+	}
+}
+
+// WithReturnKV makes 'Put' return the key-value pair it stores, with its new
+// mod revision and version, in PutResponse.Kv. It saves the Get otherwise
+// needed to learn the ModRevision for a later compare-and-swap.
+// It requires etcd 3.7 or later on every member.
+func WithReturnKV() OpOption {
+	return func(op *Op) {
+		op.returnKV = true
+	}
+}
+
 // LeaseOp represents an Operation that lease can execute.
 type LeaseOp struct {
 	id LeaseID
@@ -17,6 +17,7 @@ package clientv3
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 )
@@ -103,3 +104,26 @@ func TestIsOptsWithFromKey(t *testing.T) {
 		t.Errorf("IsOptsWithFromKey = true, expected false")
 	}
 }
+
+func TestOpWithHedging(t *testing.T) {
+	op := OpGet("key", WithSerializable(), WithHedging(time.Second))
+	if op.HedgingDelay() != time.Second {
+		t.Errorf("HedgingDelay = %v, expected %v", op.HedgingDelay(), time.Second)
+	}
+
+	for _, f := range []func(){
+		func() { OpGet("key", WithHedging(time.Second)) },
+		func() { OpPut("key", "val", WithHedging(time.Second)) },
+		func() { OpDelete("key", WithHedging(time.Second)) },
+		func() { OpWatch("key", WithHedging(time.Second)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
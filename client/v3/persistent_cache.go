@@ -0,0 +1,393 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+)
+
+var errPersistentCacheNoPrefixes = errors.New("etcdclient: persistent cache has no prefixes")
+
+const (
+	persistentCacheFile     = "cache"
+	persistentCacheLockFile = "cache.lock"
+
+	persistentCacheFlushInterval      = time.Second
+	persistentCacheRevalidateTimeout  = 5 * time.Second
+	persistentCacheRevalidateMinDelay = 100 * time.Millisecond
+	persistentCacheRevalidateMaxDelay = 30 * time.Second
+)
+
+// WithPersistentCache is a New option that enables the persistent read
+// cache, stored in dir, of the prefixes set by WithPersistentCachePrefixes.
+// The cache keeps the last known key-value pairs of the prefixes read from
+// the cluster. After a restart of the client, gets within a cached prefix
+// are served from the cache until the prefix is read again from the
+// cluster in background, so that the client can start while the cluster
+// is unreachable. Afterwards, gets are sent to the cluster and update the
+// cache, and fall back to the cache if the cluster cannot be reached.
+//
+// Only gets without revision, limit, sort, filters, count-only and
+// keys-only options are cached. Responses served from the cache have the
+// revision the prefix was last read at and a zero member ID in their header.
+// Writes do not update the cache.
+func WithPersistentCache(dir string) Option {
+	return func(c *Client) {
+		c.persistentCacheDir = dir
+	}
+}
+
+// WithPersistentCachePrefixes is a New option that sets the key prefixes
+// kept by the persistent cache.
+func WithPersistentCachePrefixes(prefixes ...string) Option {
+	return func(c *Client) {
+		c.persistentCachePrefixes = prefixes
+	}
+}
+
+// PersistentCache returns the persistent read cache of the client, or nil
+// if it is not enabled.
+func (c *Client) PersistentCache() *PersistentCache { return c.persistentCache }
+
+// PersistentCache is a KV that keeps the last known key-value pairs of some
+// prefixes on disk.
+type PersistentCache struct {
+	KV
+
+	c  *Client
+	lg *zap.Logger
+
+	lock *fileutil.LockedFile
+	dir  string
+
+	mu        sync.RWMutex
+	prefixes  []*cachedPrefix
+	clusterID uint64
+	// dirty is set when the cache was updated since it was last saved.
+	dirty bool
+
+	// revalidatedc is closed once all the prefixes were read from the
+	// cluster.
+	revalidatedc chan struct{}
+	donec        chan struct{}
+}
+
+type cachedPrefix struct {
+	key, end []byte
+	// rev is the latest revision the prefix was read at, or 0 if the prefix
+	// was never read.
+	rev int64
+	// revalidated is set once the prefix was read from the cluster since
+	// the client started.
+	revalidated bool
+	kvs         map[string]*mvccpb.KeyValue
+}
+
+// persistentCacheState is the content of the cache file.
+type persistentCacheState struct {
+	ClusterID uint64                  `json:"cluster_id"`
+	Prefixes  []persistentCachePrefix `json:"prefixes"`
+}
+
+type persistentCachePrefix struct {
+	Prefix   []byte             `json:"prefix"`
+	Revision int64              `json:"revision"`
+	KVs      []*mvccpb.KeyValue `json:"kvs,omitempty"`
+}
+
+func newPersistentCache(c *Client, kv KV) (*PersistentCache, error) {
+	if len(c.persistentCachePrefixes) == 0 {
+		return nil, errPersistentCacheNoPrefixes
+	}
+	lg := c.GetLogger()
+	if err := fileutil.TouchDirAll(lg, c.persistentCacheDir); err != nil {
+		return nil, err
+	}
+	lock, err := fileutil.TryLockFile(filepath.Join(c.persistentCacheDir, persistentCacheLockFile), os.O_RDWR|os.O_CREATE, fileutil.PrivateFileMode)
+	if err != nil {
+		return nil, fmt.Errorf("cannot lock persistent cache: %w", err)
+	}
+	pc := &PersistentCache{
+		KV:           kv,
+		c:            c,
+		lg:           lg,
+		lock:         lock,
+		dir:          c.persistentCacheDir,
+		revalidatedc: make(chan struct{}),
+		donec:        make(chan struct{}),
+	}
+	for _, p := range c.persistentCachePrefixes {
+		pc.prefixes = append(pc.prefixes, &cachedPrefix{
+			key: []byte(p),
+			end: getPrefix([]byte(p)),
+			kvs: make(map[string]*mvccpb.KeyValue),
+		})
+	}
+	if err = pc.load(); err != nil {
+		lg.Warn("ignoring persistent cache", zap.String("dir", pc.dir), zap.Error(err))
+	}
+	go pc.run()
+	return pc, nil
+}
+
+// load reads the prefixes saved by a previous client that are still
+// configured.
+func (pc *PersistentCache) load() error {
+	b, err := os.ReadFile(filepath.Join(pc.dir, persistentCacheFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var st persistentCacheState
+	if err = json.Unmarshal(b, &st); err != nil {
+		return fmt.Errorf("cannot decode persistent cache: %w", err)
+	}
+	pc.clusterID = st.ClusterID
+	for _, sp := range st.Prefixes {
+		for _, p := range pc.prefixes {
+			if !bytes.Equal(p.key, sp.Prefix) {
+				continue
+			}
+			p.rev = sp.Revision
+			for _, kv := range sp.KVs {
+				p.kvs[string(kv.Key)] = kv
+			}
+		}
+	}
+	return nil
+}
+
+func (pc *PersistentCache) Get(ctx context.Context, key string, opts ...OpOption) (*GetResponse, error) {
+	r, err := pc.Do(ctx, OpGet(key, opts...))
+	return r.get, err
+}
+
+func (pc *PersistentCache) Do(ctx context.Context, op Op) (OpResponse, error) {
+	p := pc.prefixOf(op)
+	if p == nil {
+		return pc.KV.Do(ctx, op)
+	}
+	if resp, ok := pc.serve(p, op, false); ok {
+		return resp, nil
+	}
+	resp, err := pc.KV.Do(ctx, op)
+	if err == nil {
+		pc.update(p, op, resp.get)
+		return resp, nil
+	}
+	if !isOfflineErr(err) {
+		return resp, err
+	}
+	if cresp, ok := pc.serve(p, op, true); ok {
+		pc.lg.Debug("serving get from persistent cache", zap.String("key", string(op.key)), zap.Error(err))
+		return cresp, nil
+	}
+	return resp, err
+}
+
+// Revalidated returns a channel that is closed once all the cached prefixes
+// were read from the cluster since the client started.
+func (pc *PersistentCache) Revalidated() <-chan struct{} { return pc.revalidatedc }
+
+// prefixOf returns the cached prefix containing the range of op, or nil if
+// op is not a cacheable get.
+func (pc *PersistentCache) prefixOf(op Op) *cachedPrefix {
+	if op.t != tRange || op.rev != 0 || op.limit != 0 || op.sort != nil || op.countOnly || op.keysOnly ||
+		op.modsSince != 0 || op.minModRev != 0 || op.maxModRev != 0 || op.minCreateRev != 0 || op.maxCreateRev != 0 {
+		return nil
+	}
+	for _, p := range pc.prefixes {
+		if p.contains(op.key, op.end) {
+			return p
+		}
+	}
+	return nil
+}
+
+// contains returns true if the range [key, end) is within the prefix.
+func (p *cachedPrefix) contains(key, end []byte) bool {
+	if bytes.Compare(key, p.key) < 0 {
+		return false
+	}
+	all := bytes.Equal(p.end, []byte{0})
+	switch {
+	case len(end) == 0:
+		return all || bytes.Compare(key, p.end) < 0
+	case bytes.Equal(end, []byte{0}):
+		return all
+	default:
+		return all || bytes.Compare(end, p.end) <= 0
+	}
+}
+
+// serve returns the cached response of op. Unless fallback is set, the
+// response is only served until the prefix is revalidated.
+func (pc *PersistentCache) serve(p *cachedPrefix, op Op, fallback bool) (OpResponse, bool) {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	if p.rev == 0 || (p.revalidated && !fallback) {
+		return OpResponse{}, false
+	}
+	resp := &GetResponse{
+		Header: &pb.ResponseHeader{ClusterId: pc.clusterID, Revision: p.rev},
+	}
+	for k, kv := range p.kvs {
+		if offlineOpContains(op, []byte(k)) {
+			resp.Kvs = append(resp.Kvs, kv)
+		}
+	}
+	sort.Slice(resp.Kvs, func(i, j int) bool { return bytes.Compare(resp.Kvs[i].Key, resp.Kvs[j].Key) < 0 })
+	resp.Count = int64(len(resp.Kvs))
+	return OpResponse{get: resp}, true
+}
+
+// update replaces the cached key-value pairs in the range of op by the ones
+// of resp.
+func (pc *PersistentCache) update(p *cachedPrefix, op Op, resp *GetResponse) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	for k := range p.kvs {
+		if offlineOpContains(op, []byte(k)) {
+			delete(p.kvs, k)
+		}
+	}
+	for _, kv := range resp.Kvs {
+		p.kvs[string(kv.Key)] = kv
+	}
+	p.rev = max(p.rev, resp.Header.Revision)
+	pc.clusterID = resp.Header.ClusterId
+	pc.dirty = true
+}
+
+func (pc *PersistentCache) run() {
+	defer close(pc.donec)
+	ticker := time.NewTicker(persistentCacheFlushInterval)
+	defer ticker.Stop()
+	delay := persistentCacheRevalidateMinDelay
+	retryc := time.After(0)
+	for {
+		select {
+		case <-pc.c.ctx.Done():
+			pc.flush()
+			return
+		case <-retryc:
+			retryc = nil
+			if err := pc.revalidate(); err != nil {
+				pc.lg.Debug("persistent cache revalidation interrupted", zap.Duration("delay", delay), zap.Error(err))
+				retryc = time.After(delay)
+				delay = min(2*delay, persistentCacheRevalidateMaxDelay)
+			}
+		case <-ticker.C:
+			pc.flush()
+		}
+	}
+}
+
+// revalidate reads the prefixes not read from the cluster yet.
+func (pc *PersistentCache) revalidate() error {
+	for _, p := range pc.prefixes {
+		pc.mu.RLock()
+		revalidated := p.revalidated
+		pc.mu.RUnlock()
+		if revalidated {
+			continue
+		}
+		op := OpGet(string(p.key), WithRange(string(p.end)))
+		ctx, cancel := context.WithTimeout(pc.c.ctx, persistentCacheRevalidateTimeout)
+		resp, err := pc.KV.Do(ctx, op)
+		cancel()
+		if err != nil {
+			return err
+		}
+		pc.update(p, op, resp.get)
+		pc.mu.Lock()
+		p.revalidated = true
+		pc.mu.Unlock()
+	}
+	pc.lg.Info("revalidated persistent cache", zap.String("dir", pc.dir))
+	close(pc.revalidatedc)
+	pc.flush()
+	return nil
+}
+
+// flush saves the cache if it was updated.
+func (pc *PersistentCache) flush() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if !pc.dirty {
+		return
+	}
+	if err := pc.save(); err != nil {
+		pc.lg.Warn("failed to save persistent cache", zap.String("dir", pc.dir), zap.Error(err))
+		return
+	}
+	pc.dirty = false
+}
+
+func (pc *PersistentCache) save() error {
+	st := persistentCacheState{ClusterID: pc.clusterID}
+	for _, p := range pc.prefixes {
+		if p.rev == 0 {
+			continue
+		}
+		sp := persistentCachePrefix{Prefix: p.key, Revision: p.rev}
+		for _, kv := range p.kvs {
+			sp.KVs = append(sp.KVs, kv)
+		}
+		st.Prefixes = append(st.Prefixes, sp)
+	}
+	b, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(pc.dir, persistentCacheFile)
+	f, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileutil.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if err == nil {
+		err = fileutil.Fsync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (pc *PersistentCache) close() {
+	<-pc.donec
+	pc.lock.Close()
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func newTestCachedPrefix(prefix string) *cachedPrefix {
+	return &cachedPrefix{
+		key: []byte(prefix),
+		end: getPrefix([]byte(prefix)),
+		kvs: make(map[string]*mvccpb.KeyValue),
+	}
+}
+
+func TestCachedPrefixContains(t *testing.T) {
+	p := newTestCachedPrefix("foo/")
+	for _, op := range []Op{
+		OpGet("foo/a"),
+		OpGet("foo/", WithPrefix()),
+		OpGet("foo/a", WithPrefix()),
+		OpGet("foo/a", WithRange("foo/b")),
+	} {
+		assert.True(t, p.contains(op.key, op.end), string(op.key))
+	}
+	for _, op := range []Op{
+		OpGet("foo"),
+		OpGet("fop"),
+		OpGet("foo", WithPrefix()),
+		OpGet("foo/a", WithFromKey()),
+		OpGet("foo/a", WithRange("fop/")),
+	} {
+		assert.False(t, p.contains(op.key, op.end), string(op.key))
+	}
+
+	all := newTestCachedPrefix("")
+	op := OpGet("a", WithFromKey())
+	assert.True(t, all.contains(op.key, op.end))
+}
+
+func TestPersistentCacheLoad(t *testing.T) {
+	dir := t.TempDir()
+	pc := &PersistentCache{dir: dir, clusterID: 7}
+	for _, prefix := range []string{"a/", "b/", "c/"} {
+		pc.prefixes = append(pc.prefixes, newTestCachedPrefix(prefix))
+	}
+	pc.prefixes[0].rev = 10
+	pc.prefixes[0].kvs["a/1"] = &mvccpb.KeyValue{Key: []byte("a/1"), Value: []byte("v1"), ModRevision: 9}
+	pc.prefixes[1].rev = 10
+	pc.prefixes[1].kvs["b/1"] = &mvccpb.KeyValue{Key: []byte("b/1"), Value: []byte("v2"), ModRevision: 10}
+	require.NoError(t, pc.save())
+
+	// the prefixes no longer configured are dropped, and the prefixes never
+	// read are not served
+	loaded := &PersistentCache{dir: dir}
+	for _, prefix := range []string{"a/", "c/"} {
+		loaded.prefixes = append(loaded.prefixes, newTestCachedPrefix(prefix))
+	}
+	require.NoError(t, loaded.load())
+	assert.Equal(t, uint64(7), loaded.clusterID)
+
+	resp, ok := loaded.serve(loaded.prefixes[0], OpGet("a/", WithPrefix()), false)
+	require.True(t, ok)
+	assert.Equal(t, int64(10), resp.get.Header.Revision)
+	require.Len(t, resp.get.Kvs, 1)
+	assert.Equal(t, "v1", string(resp.get.Kvs[0].Value))
+
+	_, ok = loaded.serve(loaded.prefixes[1], OpGet("c/", WithPrefix()), true)
+	assert.False(t, ok)
+
+	// a revalidated prefix is only served as a fallback
+	loaded.prefixes[0].revalidated = true
+	_, ok = loaded.serve(loaded.prefixes[0], OpGet("a/1"), false)
+	assert.False(t, ok)
+	_, ok = loaded.serve(loaded.prefixes[0], OpGet("a/1"), true)
+	assert.True(t, ok)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, persistentCacheFile), []byte("{"), 0o600))
+	require.Error(t, loaded.load())
+}
@@ -82,7 +82,13 @@ func isSafeRetryImmutableRPC(err error) bool {
 //
 // Returning "false" means retry should stop, otherwise it violates
 // write-at-most-once semantics.
+//
+// rpctypes.ErrTooBusy is safe for retry, since the server rejects the
+// request before proposing it.
 func isSafeRetryMutableRPC(err error) bool {
+	if errors.Is(rpctypes.Error(err), rpctypes.ErrTooBusy) {
+		return true
+	}
 	if ev, ok := status.FromError(err); ok && ev.Code() != codes.Unavailable {
 		// not safe for mutable RPCs
 		// e.g. interrupted by non-transient error that client cannot handle itself,
@@ -131,7 +137,7 @@ type retryLeaseClient struct {
 // RetryLeaseClient implements a LeaseClient.
 func RetryLeaseClient(c *Client) pb.LeaseClient {
 	return &retryLeaseClient{
-		lc: pb.NewLeaseClient(c.conn),
+		lc: pb.NewLeaseClient(c.leaseConn()),
 	}
 }
 
@@ -186,6 +192,33 @@ func (rcc *retryClusterClient) MemberPromote(ctx context.Context, in *pb.MemberP
 	return rcc.cc.MemberPromote(ctx, in, opts...)
 }
 
+func (rcc *retryClusterClient) ClusterConfigGet(ctx context.Context, in *pb.ClusterConfigGetRequest, opts ...grpc.CallOption) (resp *pb.ClusterConfigGetResponse, err error) {
+	return rcc.cc.ClusterConfigGet(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rcc *retryClusterClient) ClusterConfigSet(ctx context.Context, in *pb.ClusterConfigSetRequest, opts ...grpc.CallOption) (resp *pb.ClusterConfigSetResponse, err error) {
+	return rcc.cc.ClusterConfigSet(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+type retryCounterClient struct {
+	cc pb.CounterClient
+}
+
+// RetryCounterClient implements a CounterClient.
+func RetryCounterClient(c *Client) pb.CounterClient {
+	return &retryCounterClient{
+		cc: pb.NewCounterClient(c.conn),
+	}
+}
+
+func (rcc *retryCounterClient) CounterAdd(ctx context.Context, in *pb.CounterAddRequest, opts ...grpc.CallOption) (resp *pb.CounterAddResponse, err error) {
+	return rcc.cc.CounterAdd(ctx, in, opts...)
+}
+
+func (rcc *retryCounterClient) CounterGet(ctx context.Context, in *pb.CounterGetRequest, opts ...grpc.CallOption) (resp *pb.CounterGetResponse, err error) {
+	return rcc.cc.CounterGet(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryMaintenanceClient struct {
 	mc pb.MaintenanceClient
 }
@@ -217,6 +250,10 @@ func (rmc *retryMaintenanceClient) Snapshot(ctx context.Context, in *pb.Snapshot
 	return rmc.mc.Snapshot(ctx, in, append(opts, withRepeatablePolicy())...)
 }
 
+func (rmc *retryMaintenanceClient) Export(ctx context.Context, in *pb.ExportRequest, opts ...grpc.CallOption) (stream pb.Maintenance_ExportClient, err error) {
+	return rmc.mc.Export(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 func (rmc *retryMaintenanceClient) MoveLeader(ctx context.Context, in *pb.MoveLeaderRequest, opts ...grpc.CallOption) (resp *pb.MoveLeaderResponse, err error) {
 	return rmc.mc.MoveLeader(ctx, in, append(opts, withRepeatablePolicy())...)
 }
@@ -229,6 +266,74 @@ func (rmc *retryMaintenanceClient) Downgrade(ctx context.Context, in *pb.Downgra
 	return rmc.mc.Downgrade(ctx, in, opts...)
 }
 
+func (rmc *retryMaintenanceClient) CompactionHoldGrant(ctx context.Context, in *pb.CompactionHoldGrantRequest, opts ...grpc.CallOption) (resp *pb.CompactionHoldGrantResponse, err error) {
+	return rmc.mc.CompactionHoldGrant(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) CompactionHoldRevoke(ctx context.Context, in *pb.CompactionHoldRevokeRequest, opts ...grpc.CallOption) (resp *pb.CompactionHoldRevokeResponse, err error) {
+	return rmc.mc.CompactionHoldRevoke(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) CompactionHoldList(ctx context.Context, in *pb.CompactionHoldListRequest, opts ...grpc.CallOption) (resp *pb.CompactionHoldListResponse, err error) {
+	return rmc.mc.CompactionHoldList(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) GarbageCollect(ctx context.Context, in *pb.GarbageCollectRequest, opts ...grpc.CallOption) (resp *pb.GarbageCollectResponse, err error) {
+	return rmc.mc.GarbageCollect(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) MemoryStats(ctx context.Context, in *pb.MemoryStatsRequest, opts ...grpc.CallOption) (resp *pb.MemoryStatsResponse, err error) {
+	return rmc.mc.MemoryStats(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) VerifySnapshot(ctx context.Context, in *pb.VerifySnapshotRequest, opts ...grpc.CallOption) (resp *pb.VerifySnapshotResponse, err error) {
+	return rmc.mc.VerifySnapshot(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) HotKeys(ctx context.Context, in *pb.HotKeysRequest, opts ...grpc.CallOption) (resp *pb.HotKeysResponse, err error) {
+	return rmc.mc.HotKeys(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) JobList(ctx context.Context, in *pb.JobListRequest, opts ...grpc.CallOption) (resp *pb.JobListResponse, err error) {
+	return rmc.mc.JobList(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) JobStatus(ctx context.Context, in *pb.JobStatusRequest, opts ...grpc.CallOption) (resp *pb.JobStatusResponse, err error) {
+	return rmc.mc.JobStatus(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) JobCancel(ctx context.Context, in *pb.JobCancelRequest, opts ...grpc.CallOption) (resp *pb.JobCancelResponse, err error) {
+	return rmc.mc.JobCancel(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) NewerFields(ctx context.Context, in *pb.NewerFieldsRequest, opts ...grpc.CallOption) (resp *pb.NewerFieldsResponse, err error) {
+	return rmc.mc.NewerFields(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) Checkpoint(ctx context.Context, in *pb.CheckpointRequest, opts ...grpc.CallOption) (resp *pb.CheckpointResponse, err error) {
+	return rmc.mc.Checkpoint(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) Reseed(ctx context.Context, in *pb.ReseedRequest, opts ...grpc.CallOption) (resp *pb.ReseedResponse, err error) {
+	return rmc.mc.Reseed(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) FencePrefix(ctx context.Context, in *pb.FencePrefixRequest, opts ...grpc.CallOption) (resp *pb.FencePrefixResponse, err error) {
+	return rmc.mc.FencePrefix(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) SetRequestLogging(ctx context.Context, in *pb.SetRequestLoggingRequest, opts ...grpc.CallOption) (resp *pb.SetRequestLoggingResponse, err error) {
+	return rmc.mc.SetRequestLogging(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) DrainMember(ctx context.Context, in *pb.DrainMemberRequest, opts ...grpc.CallOption) (resp *pb.DrainMemberResponse, err error) {
+	return rmc.mc.DrainMember(ctx, in, opts...)
+}
+
+func (rmc *retryMaintenanceClient) StorageStats(ctx context.Context, in *pb.StorageStatsRequest, opts ...grpc.CallOption) (resp *pb.StorageStatsResponse, err error) {
+	return rmc.mc.StorageStats(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryAuthClient struct {
 	ac pb.AuthClient
 }
@@ -280,6 +385,10 @@ func (rac *retryAuthClient) UserChangePassword(ctx context.Context, in *pb.AuthU
 	return rac.ac.UserChangePassword(ctx, in, opts...)
 }
 
+func (rac *retryAuthClient) UserRotatePassword(ctx context.Context, in *pb.AuthUserRotatePasswordRequest, opts ...grpc.CallOption) (resp *pb.AuthUserRotatePasswordResponse, err error) {
+	return rac.ac.UserRotatePassword(ctx, in, opts...)
+}
+
 func (rac *retryAuthClient) UserGrantRole(ctx context.Context, in *pb.AuthUserGrantRoleRequest, opts ...grpc.CallOption) (resp *pb.AuthUserGrantRoleResponse, err error) {
 	return rac.ac.UserGrantRole(ctx, in, opts...)
 }
@@ -304,6 +413,14 @@ func (rac *retryAuthClient) RoleRevokePermission(ctx context.Context, in *pb.Aut
 	return rac.ac.RoleRevokePermission(ctx, in, opts...)
 }
 
+func (rac *retryAuthClient) RoleGrantRPCPermission(ctx context.Context, in *pb.AuthRoleGrantRPCPermissionRequest, opts ...grpc.CallOption) (resp *pb.AuthRoleGrantRPCPermissionResponse, err error) {
+	return rac.ac.RoleGrantRPCPermission(ctx, in, opts...)
+}
+
+func (rac *retryAuthClient) RoleRevokeRPCPermission(ctx context.Context, in *pb.AuthRoleRevokeRPCPermissionRequest, opts ...grpc.CallOption) (resp *pb.AuthRoleRevokeRPCPermissionResponse, err error) {
+	return rac.ac.RoleRevokeRPCPermission(ctx, in, opts...)
+}
+
 func (rac *retryAuthClient) Authenticate(ctx context.Context, in *pb.AuthenticateRequest, opts ...grpc.CallOption) (resp *pb.AuthenticateResponse, err error) {
 	return rac.ac.Authenticate(ctx, in, opts...)
 }
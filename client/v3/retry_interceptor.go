@@ -48,11 +48,15 @@ func (c *Client) unaryClientInterceptor(optFuncs ...retryOption) grpc.UnaryClien
 			return invoker(ctx, method, req, reply, cc, grpcOpts...)
 		}
 		var lastErr error
+		var serverBackoff time.Duration
 		for attempt := uint(0); attempt < callOpts.max; attempt++ {
-			if err := waitRetryBackoff(ctx, attempt, callOpts); err != nil {
+			if err := waitRetryBackoff(ctx, attempt, callOpts, serverBackoff); err != nil {
 				return err
 			}
-			c.GetLogger().Debug(
+			if attempt > 0 && c.metrics != nil {
+				c.metrics.RequestRetried(method)
+			}
+			c.componentLogger(LogComponentRetry).Debug(
 				"retrying of unary invoker",
 				zap.String("target", cc.Target()),
 				zap.String("method", method),
@@ -62,7 +66,7 @@ func (c *Client) unaryClientInterceptor(optFuncs ...retryOption) grpc.UnaryClien
 			if lastErr == nil {
 				return nil
 			}
-			c.GetLogger().Warn(
+			c.componentLogger(LogComponentRetry).Warn(
 				"retrying of unary invoker failed",
 				zap.String("target", cc.Target()),
 				zap.String("method", method),
@@ -80,7 +84,7 @@ func (c *Client) unaryClientInterceptor(optFuncs ...retryOption) grpc.UnaryClien
 			if c.shouldRefreshToken(lastErr, callOpts) {
 				gtErr := c.refreshToken(ctx)
 				if gtErr != nil {
-					c.GetLogger().Warn(
+					c.componentLogger(LogComponentRetry).Warn(
 						"retrying of unary invoker failed to fetch new auth token",
 						zap.String("target", cc.Target()),
 						zap.Error(gtErr),
@@ -92,6 +96,8 @@ func (c *Client) unaryClientInterceptor(optFuncs ...retryOption) grpc.UnaryClien
 			if !isSafeRetry(c, lastErr, callOpts) {
 				return lastErr
 			}
+			// honor the backoff suggested by an overloaded server
+			serverBackoff, _ = rpctypes.RetryDelay(lastErr)
 		}
 		return lastErr
 	}
@@ -113,7 +119,7 @@ func (c *Client) streamClientInterceptor(optFuncs ...retryOption) grpc.StreamCli
 		// (see https://github.com/etcd-io/etcd/issues/11954 for more).
 		err := c.getToken(ctx)
 		if err != nil {
-			c.GetLogger().Error("clientv3/retry_interceptor: getToken failed", zap.Error(err))
+			c.componentLogger(LogComponentRetry).Error("clientv3/retry_interceptor: getToken failed", zap.Error(err))
 			return nil, err
 		}
 		grpcOpts, retryOpts := filterCallOptions(opts)
@@ -127,7 +133,7 @@ func (c *Client) streamClientInterceptor(optFuncs ...retryOption) grpc.StreamCli
 		}
 		newStreamer, err := streamer(ctx, desc, cc, method, grpcOpts...)
 		if err != nil {
-			c.GetLogger().Error("streamer failed to create ClientStream", zap.Error(err))
+			c.componentLogger(LogComponentRetry).Error("streamer failed to create ClientStream", zap.Error(err))
 			return nil, err // TODO(mwitkow): Maybe dial and transport errors should be retriable?
 		}
 		retryingStreamer := &serverStreamingRetryingStream{
@@ -230,7 +236,7 @@ func (s *serverStreamingRetryingStream) RecvMsg(m any) error {
 
 	// We start off from attempt 1, because zeroth was already made on normal SendMsg().
 	for attempt := uint(1); attempt < s.callOpts.max; attempt++ {
-		if err := waitRetryBackoff(s.ctx, attempt, s.callOpts); err != nil {
+		if err := waitRetryBackoff(s.ctx, attempt, s.callOpts, 0); err != nil {
 			return err
 		}
 		newStream, err := s.reestablishStreamAndResendBuffer(s.ctx)
@@ -300,10 +306,12 @@ func (s *serverStreamingRetryingStream) reestablishStreamAndResendBuffer(callCtx
 	return newStream, nil
 }
 
-func waitRetryBackoff(ctx context.Context, attempt uint, callOpts *options) error {
+// waitRetryBackoff waits before the given attempt, for at least the backoff
+// suggested by the server on the previous attempt.
+func waitRetryBackoff(ctx context.Context, attempt uint, callOpts *options, serverBackoff time.Duration) error {
 	waitTime := time.Duration(0)
 	if attempt > 0 {
-		waitTime = callOpts.backoffFunc(attempt)
+		waitTime = max(callOpts.backoffFunc(attempt), serverBackoff)
 	}
 	if waitTime > 0 {
 		timer := time.NewTimer(waitTime)
@@ -339,7 +347,7 @@ func isSafeRetry(c *Client, err error, callOpts *options) bool {
 	case nonRepeatable:
 		return isSafeRetryMutableRPC(err)
 	default:
-		c.lg.Warn("unrecognized retry policy", zap.String("retryPolicy", callOpts.retryPolicy.String()))
+		c.componentLogger(LogComponentRetry).Warn("unrecognized retry policy", zap.String("retryPolicy", callOpts.retryPolicy.String()))
 		return false
 	}
 }
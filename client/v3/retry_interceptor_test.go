@@ -15,8 +15,11 @@
 package clientv3
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"go.uber.org/zap"
 	grpccredentials "google.golang.org/grpc/credentials"
 
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
@@ -130,3 +133,27 @@ func TestClientShouldRefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSafeRetryTooBusy(t *testing.T) {
+	c := &Client{lg: zap.NewNop()}
+	err := rpctypes.NewGRPCTooBusyError(time.Second)
+	for _, policy := range []retryPolicy{repeatable, nonRepeatable} {
+		if !isSafeRetry(c, err, &options{retryPolicy: policy}) {
+			t.Errorf("expected too busy error to be safe for retry with %v policy", policy)
+		}
+	}
+	if isSafeRetry(c, rpctypes.ErrGRPCRequestTooManyRequests, &options{retryPolicy: nonRepeatable}) {
+		t.Error("expected too many requests error not to be safe for retry with nonRepeatable policy")
+	}
+}
+
+func TestWaitRetryBackoffServerBackoff(t *testing.T) {
+	opts := &options{backoffFunc: func(uint) time.Duration { return time.Millisecond }}
+	start := time.Now()
+	if err := waitRetryBackoff(context.Background(), 1, opts, 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if took := time.Since(start); took < 50*time.Millisecond {
+		t.Errorf("expected to wait for the server backoff, waited %v", took)
+	}
+}
@@ -15,10 +15,12 @@
 package snapshot
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"time"
@@ -26,6 +28,7 @@ import (
 	"github.com/dustin/go-humanize"
 	"go.uber.org/zap"
 
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/client/pkg/v3/fileutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
@@ -38,6 +41,18 @@ func hasChecksum(n int64) bool {
 	return (n % 512) == sha256.Size
 }
 
+// SaveOptions configures how a snapshot is fetched.
+type SaveOptions struct {
+	// RateLimit is the maximum number of bytes per second sent by the server.
+	// Zero means no limit.
+	RateLimit uint64
+	// MaxResumes is the number of times an interrupted transfer is resumed
+	// from the bytes already fetched. A transfer that cannot be resumed, as
+	// the server released the snapshot meanwhile, is restarted from the
+	// beginning if possible.
+	MaxResumes int
+}
+
 // SaveWithVersion fetches snapshot from remote etcd server, saves data
 // to target path and returns server version. If the context "ctx" is canceled or timed out,
 // snapshot save stream will error out (e.g. context.Canceled,
@@ -47,11 +62,13 @@ func hasChecksum(n int64) bool {
 // the selected node.
 // Etcd <v3.6 will return "" as version.
 func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, dbPath string) (string, error) {
-	cfg.Logger = lg.Named("client")
-	if len(cfg.Endpoints) != 1 {
-		return "", fmt.Errorf("snapshot must be requested to one selected node, not multiple %v", cfg.Endpoints)
-	}
-	cli, err := clientv3.New(cfg)
+	return SaveWithOptions(ctx, lg, cfg, dbPath, SaveOptions{})
+}
+
+// SaveWithOptions is SaveWithVersion with rate limiting and resuming
+// configured by opts.
+func SaveWithOptions(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, dbPath string, opts SaveOptions) (string, error) {
+	cli, err := newClient(lg, cfg)
 	if err != nil {
 		return "", err
 	}
@@ -82,42 +99,180 @@ func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, d
 	}()
 	lg.Info("created temporary db file", zap.String("path", partpath))
 
+	reset := func() error {
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		_, err := f.Seek(0, io.SeekStart)
+		return err
+	}
 	start := time.Now()
-	resp, err := cli.SnapshotWithVersion(ctx)
+	lg.Info("fetching snapshot", zap.String("endpoint", cfg.Endpoints[0]))
+	version, size, err := fetch(ctx, lg, cli, f, reset, opts)
+	if err != nil {
+		return version, err
+	}
+	if err = fileutil.Fsync(f); err != nil {
+		return version, fmt.Errorf("could not fsync snapshot: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return version, fmt.Errorf("could not close file descriptor: %w", err)
+	}
+	lg.Info("fetched snapshot",
+		zap.String("endpoint", cfg.Endpoints[0]),
+		zap.String("size", humanize.Bytes(uint64(size))),
+		zap.Duration("took", time.Since(start)),
+		zap.String("etcd-version", version),
+	)
+
+	if err = os.Rename(partpath, dbPath); err != nil {
+		return version, fmt.Errorf("could not rename %s to %s (%w)", partpath, dbPath, err)
+	}
+	lg.Info("saved", zap.String("path", dbPath))
+	return version, nil
+}
+
+// SaveToWriter fetches snapshot from remote etcd server, writes it to w
+// together with the appended sha256 digest and returns server version.
+// The digest is only verified once the whole snapshot has been written,
+// so the written data must be discarded if an error is returned.
+// Interrupted transfers are resumed, but never restarted.
+func SaveToWriter(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, w io.Writer, opts SaveOptions) (string, error) {
+	cli, err := newClient(lg, cfg)
 	if err != nil {
 		return "", err
 	}
 	defer func() {
-		err = resp.Snapshot.Close()
+		err = cli.Close()
 		if err != nil {
-			lg.Error("Could not close snapshot stream", zap.Error(err))
+			lg.Error("Failed to close client", zap.Error(err))
 		}
 	}()
+
+	start := time.Now()
 	lg.Info("fetching snapshot", zap.String("endpoint", cfg.Endpoints[0]))
-	var size int64
-	size, err = io.Copy(f, resp.Snapshot)
+	version, size, err := fetch(ctx, lg, cli, w, nil, opts)
 	if err != nil {
-		return resp.Version, fmt.Errorf("could not write snapshot: %w", err)
-	}
-	if !hasChecksum(size) {
-		return resp.Version, fmt.Errorf("sha256 checksum not found [bytes: %d]", size)
-	}
-	if err = fileutil.Fsync(f); err != nil {
-		return resp.Version, fmt.Errorf("could not fsync snapshot: %w", err)
-	}
-	if err = f.Close(); err != nil {
-		return resp.Version, fmt.Errorf("could not close file descriptor: %w", err)
+		return version, err
 	}
 	lg.Info("fetched snapshot",
 		zap.String("endpoint", cfg.Endpoints[0]),
 		zap.String("size", humanize.Bytes(uint64(size))),
 		zap.Duration("took", time.Since(start)),
-		zap.String("etcd-version", resp.Version),
+		zap.String("etcd-version", version),
 	)
+	return version, nil
+}
 
-	if err = os.Rename(partpath, dbPath); err != nil {
-		return resp.Version, fmt.Errorf("could not rename %s to %s (%w)", partpath, dbPath, err)
+func newClient(lg *zap.Logger, cfg clientv3.Config) (*clientv3.Client, error) {
+	cfg.Logger = lg.Named("client")
+	if len(cfg.Endpoints) != 1 {
+		return nil, fmt.Errorf("snapshot must be requested to one selected node, not multiple %v", cfg.Endpoints)
 	}
-	lg.Info("saved", zap.String("path", dbPath))
-	return resp.Version, nil
+	return clientv3.New(cfg)
+}
+
+// fetch writes the snapshot stream to w and verifies its sha256 digest,
+// resuming an interrupted transfer up to opts.MaxResumes times. reset
+// discards the bytes written to w, to restart a transfer that cannot be
+// resumed; it is nil if w cannot be reset.
+func fetch(ctx context.Context, lg *zap.Logger, cli *clientv3.Client, w io.Writer, reset func() error, opts SaveOptions) (version string, size int64, err error) {
+	dw := newDigestWriter(w)
+	snapOpts := clientv3.SnapshotOptions{RateLimit: opts.RateLimit}
+	for resumes := 0; ; resumes++ {
+		var resp *clientv3.SnapshotResponse
+		resp, err = cli.SnapshotWithOptions(ctx, snapOpts)
+		if err == nil {
+			version = resp.Version
+			if resp.Offset != snapOpts.Offset {
+				err = fmt.Errorf("snapshot resumed at offset %d, expected %d", resp.Offset, snapOpts.Offset)
+			} else {
+				_, err = io.Copy(dw, resp.Snapshot)
+			}
+			if cerr := resp.Snapshot.Close(); cerr != nil {
+				lg.Error("Could not close snapshot stream", zap.Error(cerr))
+			}
+			if err == nil {
+				break
+			}
+			snapOpts.ResumeID = resp.ID
+		}
+		if resumes >= opts.MaxResumes || ctx.Err() != nil {
+			return version, dw.n, fmt.Errorf("could not write snapshot: %w", err)
+		}
+		if snapOpts.ResumeID == 0 || isResumeRejected(err) {
+			// the transfer cannot be resumed, restart it
+			if dw.n > 0 {
+				if reset == nil {
+					return version, dw.n, fmt.Errorf("could not resume snapshot: %w", err)
+				}
+				if rerr := reset(); rerr != nil {
+					return version, dw.n, fmt.Errorf("could not restart snapshot: %w", rerr)
+				}
+				dw = newDigestWriter(w)
+			}
+			snapOpts.ResumeID = 0
+		}
+		snapOpts.Offset, snapOpts.OffsetSHA256 = dw.n, dw.sum()
+		lg.Warn("snapshot transfer interrupted; resuming",
+			zap.Error(err),
+			zap.Uint64("snapshot-id", snapOpts.ResumeID),
+			zap.Int64("offset", snapOpts.Offset),
+		)
+	}
+	if !hasChecksum(dw.n) {
+		return version, dw.n, fmt.Errorf("sha256 checksum not found [bytes: %d]", dw.n)
+	}
+	return version, dw.n, dw.verify()
+}
+
+// isResumeRejected returns true if the server refused to resume the
+// snapshot transfer.
+func isResumeRejected(err error) bool {
+	err = rpctypes.Error(err)
+	return errors.Is(err, rpctypes.ErrSnapshotNotFound) ||
+		errors.Is(err, rpctypes.ErrSnapshotMismatch) ||
+		errors.Is(err, rpctypes.ErrInvalidSnapshotOffset)
+}
+
+// digestWriter writes the snapshot stream to w, keeping the digest of the
+// written bytes to resume the transfer, and the digest of all but the
+// trailing sha256 digest appended by the server to verify it.
+type digestWriter struct {
+	w   io.Writer
+	n   int64
+	all hash.Hash
+	// data hashes the written bytes but tail, which may be part of the
+	// appended digest.
+	data hash.Hash
+	tail []byte
+}
+
+func newDigestWriter(w io.Writer) *digestWriter {
+	return &digestWriter{w: w, all: sha256.New(), data: sha256.New()}
+}
+
+func (d *digestWriter) Write(p []byte) (int, error) {
+	n, err := d.w.Write(p)
+	d.n += int64(n)
+	d.all.Write(p[:n])
+	d.tail = append(d.tail, p[:n]...)
+	if extra := len(d.tail) - sha256.Size; extra > 0 {
+		d.data.Write(d.tail[:extra])
+		d.tail = append(d.tail[:0], d.tail[extra:]...)
+	}
+	return n, err
+}
+
+// sum returns the digest of the written bytes.
+func (d *digestWriter) sum() []byte {
+	return d.all.Sum(nil)
+}
+
+// verify checks the trailing digest against the preceding bytes.
+func (d *digestWriter) verify() error {
+	if !bytes.Equal(d.data.Sum(nil), d.tail) {
+		return errors.New("snapshot sha256 checksum mismatch")
+	}
+	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -44,8 +45,18 @@ const (
 
 	// InvalidWatchID represents an invalid watch ID and prevents duplication with an existing watch.
 	InvalidWatchID = -1
+
+	// maxBulkWatchCreates and maxBulkWatchBytes bound the number and the size
+	// of the create requests sent in a single bulk request, well below the
+	// smallest request size a server accepts.
+	maxBulkWatchCreates = 1000
+	maxBulkWatchBytes   = 256 * 1024
 )
 
+// ErrWatchRangeDeleted is the error of watchers canceled because an
+// administrative operation removed their range.
+var ErrWatchRangeDeleted = errors.New("etcdclient: watched range deleted by an administrator")
+
 type Event mvccpb.Event
 
 type WatchChan <-chan WatchResponse
@@ -107,6 +118,46 @@ type WatchResponse struct {
 
 	// CancelReason is a reason of canceling watch
 	CancelReason string
+
+	// CancelCode is the machine-readable reason of canceling watch. It is
+	// WatchResponse_UNSPECIFIED if the server does not report it.
+	CancelCode pb.WatchResponse_CancelCode
+
+	// CancelDetails holds the details of the CancelCode, if any.
+	CancelDetails *pb.WatchCancelDetails
+
+	// InitialState is set on the responses holding the initial state of a
+	// watcher created with WithInitialState. Their header revision is the
+	// revision the state is read at.
+	InitialState bool
+
+	// InitialStateMore is set on the initial state responses followed by
+	// more initial state responses.
+	InitialStateMore bool
+
+	// FirstSequence is the sequence token of the first event of the
+	// response. The events delivered on a watch channel are numbered
+	// consecutively, so that Events[i] has the token FirstSequence+i. It is
+	// 0 on responses without events, or if the server does not number the
+	// events.
+	FirstSequence int64
+
+	// PermissionChange is set on the response notifying that the user is
+	// permitted to read only part of the watched range, or all of it again.
+	// The watcher only receives the events of the keys in its permitted
+	// ranges. It is canceled with ErrPermissionDenied instead if the user
+	// may read none of the range. Supported since etcd 3.7.
+	PermissionChange *pb.WatchPermissionChange
+
+	// ServerTime is the wall-clock time of the member when it sent the
+	// progress notification, and ReceivedTime the time the notification was
+	// received. ServerMonotonicTime is the time elapsed on the monotonic
+	// clock of the member since it started. They are zero on other
+	// responses. See watchutil.Staleness to estimate how stale a watch is
+	// from them. Supported since etcd 3.7.
+	ServerTime          time.Time
+	ServerMonotonicTime time.Duration
+	ReceivedTime        time.Time
 }
 
 // IsCreate returns true if the event tells that the key is newly created.
@@ -127,17 +178,49 @@ func (wr *WatchResponse) Err() error {
 	case wr.CompactRevision != 0:
 		return v3rpc.ErrCompacted
 	case wr.Canceled:
-		if len(wr.CancelReason) != 0 {
-			return v3rpc.Error(status.Error(codes.FailedPrecondition, wr.CancelReason))
+		if err := cancelError(wr.CancelCode, wr.CancelReason); err != nil {
+			return err
 		}
 		return v3rpc.ErrFutureRev
 	}
 	return nil
 }
 
+// cancelError returns the error of a watcher canceled by the server, or nil
+// if neither the code nor the reason tell why. The code takes precedence over
+// the reason, whose wording may change across releases.
+func cancelError(code pb.WatchResponse_CancelCode, reason string) error {
+	switch code {
+	case pb.WatchResponse_COMPACTED:
+		return v3rpc.ErrCompacted
+	case pb.WatchResponse_WATCHER_OVERLOADED:
+		return v3rpc.ErrWatcherDropped
+	case pb.WatchResponse_SERVER_SHUTDOWN:
+		return v3rpc.ErrStopped
+	case pb.WatchResponse_AUTH_REVOKED:
+		// the reason is the message of the gRPC error, which tells a
+		// missing permission from an invalid token
+		if i := strings.LastIndex(reason, "desc = "); i >= 0 {
+			reason = reason[i+len("desc = "):]
+		}
+		if err, ok := v3rpc.Error(errors.New(reason)).(v3rpc.EtcdError); ok {
+			return err
+		}
+		return v3rpc.ErrPermissionDenied
+	case pb.WatchResponse_RANGE_DELETED_BY_ADMIN:
+		return ErrWatchRangeDeleted
+	case pb.WatchResponse_THRESHOLD_EXCEEDED:
+		return v3rpc.ErrThresholdExceeded
+	}
+	if len(reason) != 0 {
+		return v3rpc.Error(status.Error(codes.FailedPrecondition, reason))
+	}
+	return nil
+}
+
 // IsProgressNotify returns true if the WatchResponse is progress notification.
 func (wr *WatchResponse) IsProgressNotify() bool {
-	return len(wr.Events) == 0 && !wr.Canceled && !wr.Created && wr.CompactRevision == 0 && wr.Header.Revision != 0
+	return len(wr.Events) == 0 && !wr.Canceled && !wr.Created && !wr.InitialState && wr.PermissionChange == nil && wr.CompactRevision == 0 && wr.Header.Revision != 0
 }
 
 // watcher implements the Watcher interface
@@ -151,6 +234,11 @@ type watcher struct {
 	// streams holds all the active grpc streams keyed by ctx value.
 	streams map[string]*watchGRPCStream
 	lg      *zap.Logger
+
+	// maxResponseBytes is the default size limit of watch responses
+	maxResponseBytes int
+	// maxReassemblyBytes caps the size of reassembled fragmented responses
+	maxReassemblyBytes int
 }
 
 // watchGRPCStream tracks all watch resources attached to a single grpc stream.
@@ -169,6 +257,11 @@ type watchGRPCStream struct {
 	substreams map[int64]*watcherStream
 	// resuming holds all resuming watchers on this grpc stream
 	resuming []*watcherStream
+	// inflight is the number of watchers at the head of resuming whose
+	// create requests are sent and not answered yet
+	inflight int
+	// bulk is set once the server announced that it accepts bulk requests
+	bulk bool
 
 	// reqc sends a watch request from Watch() to the main goroutine
 	reqc chan watchStreamRequest
@@ -185,6 +278,8 @@ type watchGRPCStream struct {
 
 	// resumec closes to signal that all substreams should begin resuming
 	resumec chan struct{}
+	// fragments reassembles the fragmented watch responses
+	fragments *WatchResponseAssembler
 	// closeErr is the error that closed the watch stream
 	closeErr error
 
@@ -211,11 +306,15 @@ type watchRequest struct {
 	// if true, split watch events when total exceeds
 	// "--max-request-bytes" flag value + 512-byte
 	fragment bool
+	// maxResponseBytes is the largest watch response the server may send
+	maxResponseBytes int64
 
 	// filters is the list of events to filter out
 	filters []pb.WatchCreateRequest_FilterType
 	// get the previous key-value pair before the event happens
 	prevKV bool
+	// initialState is set until the initial state of the watcher is complete
+	initialState bool
 	// retc receives a chan WatchResponse once the watcher is established
 	retc chan chan WatchResponse
 }
@@ -241,10 +340,16 @@ type watcherStream struct {
 
 	// buf holds all events received from etcd but not yet consumed by the client
 	buf []*WatchResponse
+
+	// seq is the sequence token of the last event received. The tokens of
+	// the server restart with each watch it creates for the stream, so they
+	// are offset by seqBase, the last token before the stream resumed.
+	seq     int64
+	seqBase int64
 }
 
 func NewWatcher(c *Client) Watcher {
-	return NewWatchFromWatchClient(pb.NewWatchClient(c.conn), c)
+	return NewWatchFromWatchClient(c.watchClient(), c)
 }
 
 func NewWatchFromWatchClient(wc pb.WatchClient, c *Client) Watcher {
@@ -254,7 +359,12 @@ func NewWatchFromWatchClient(wc pb.WatchClient, c *Client) Watcher {
 	}
 	if c != nil {
 		w.callOpts = c.callOpts
-		w.lg = c.lg
+		w.lg = c.componentLogger(LogComponentWatch)
+		w.maxResponseBytes = c.cfg.WatchMaxResponseBytes
+		if w.maxResponseBytes == 0 {
+			w.maxResponseBytes = c.cfg.MaxCallRecvMsgSize
+		}
+		w.maxReassemblyBytes = c.cfg.WatchMaxReassemblyBytes
 	}
 	return w
 }
@@ -288,6 +398,7 @@ func (w *watcher) newWatcherGRPCStream(inctx context.Context) *watchGRPCStream {
 		errc:       make(chan error, 1),
 		closingc:   make(chan *watcherStream),
 		resumec:    make(chan struct{}),
+		fragments:  NewWatchResponseAssembler(w.maxReassemblyBytes),
 		lg:         w.lg,
 	}
 	go wgs.run()
@@ -306,17 +417,24 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 		filters = append(filters, pb.WatchCreateRequest_NODELETE)
 	}
 
+	maxResponseBytes := ow.maxResponseBytes
+	if maxResponseBytes == 0 {
+		maxResponseBytes = int64(w.maxResponseBytes)
+	}
+
 	wr := &watchRequest{
-		ctx:            ctx,
-		createdNotify:  ow.createdNotify,
-		key:            string(ow.key),
-		end:            string(ow.end),
-		rev:            ow.rev,
-		progressNotify: ow.progressNotify,
-		fragment:       ow.fragment,
-		filters:        filters,
-		prevKV:         ow.prevKV,
-		retc:           make(chan chan WatchResponse, 1),
+		ctx:              ctx,
+		createdNotify:    ow.createdNotify,
+		key:              string(ow.key),
+		end:              string(ow.end),
+		rev:              ow.rev,
+		progressNotify:   ow.progressNotify,
+		fragment:         ow.fragment,
+		maxResponseBytes: maxResponseBytes,
+		filters:          filters,
+		prevKV:           ow.prevKV,
+		initialState:     ow.initialState,
+		retc:             make(chan chan WatchResponse, 1),
 	}
 
 	ok := false
@@ -458,8 +576,11 @@ func (w *watcher) closeStream(wgs *watchGRPCStream) {
 
 func (w *watchGRPCStream) addSubstream(resp *pb.WatchResponse, ws *watcherStream) {
 	// check watch ID for backward compatibility (<= v3.3)
-	if resp.WatchId == InvalidWatchID || (resp.Canceled && resp.CancelReason != "") {
-		w.closeErr = v3rpc.Error(errors.New(resp.CancelReason))
+	if resp.WatchId == InvalidWatchID || (resp.Canceled && (resp.CancelReason != "" || resp.CancelCode != pb.WatchResponse_UNSPECIFIED)) {
+		w.closeErr = cancelError(resp.CancelCode, resp.CancelReason)
+		if w.closeErr == nil {
+			w.closeErr = v3rpc.Error(errors.New(resp.CancelReason))
+		}
 		// failed; no channel
 		close(ws.recvc)
 		return
@@ -540,7 +661,6 @@ func (w *watchGRPCStream) run() {
 
 	cancelSet := make(map[int64]struct{})
 
-	var cur *pb.WatchResponse
 	backoff := time.Millisecond
 	for {
 		select {
@@ -564,12 +684,7 @@ func (w *watchGRPCStream) run() {
 
 				// queue up for watcher creation/resume
 				w.resuming = append(w.resuming, ws)
-				if len(w.resuming) == 1 {
-					// head of resume queue, can register a new watcher
-					if err := wc.Send(ws.initReq.toPB()); err != nil {
-						w.lg.Debug("error when sending request", zap.Error(err))
-					}
-				}
+				w.sendResumes(wc)
 			case *progressRequest:
 				if err := wc.Send(wreq.toPB()); err != nil {
 					w.lg.Debug("error when sending request", zap.Error(err))
@@ -578,36 +693,34 @@ func (w *watchGRPCStream) run() {
 
 		// new events from the watch client
 		case pbresp := <-w.respc:
-			if cur == nil || pbresp.Created || pbresp.Canceled {
-				cur = pbresp
-			} else if cur.WatchId == pbresp.WatchId {
-				// merge new events
-				cur.Events = append(cur.Events, pbresp.Events...)
-				// update "Fragment" field; last response with "Fragment" == false
-				cur.Fragment = pbresp.Fragment
+			cur, err := w.fragments.Add(pbresp)
+			if err != nil {
+				// the reassembled response would exceed the memory cap;
+				// cancel the watcher, its substream sends the cancel request
+				w.lg.Warn("canceling watcher on too large watch response", zap.Int64("watch-id", pbresp.WatchId), zap.Error(err))
+				w.unicastResponse(&WatchResponse{Header: *pbresp.Header, Canceled: true, closeErr: err}, pbresp.WatchId)
+				continue
 			}
 
 			switch {
 			case pbresp.Created:
+				w.bulk = pbresp.BulkSupported
 				// response to head of queue creation
 				if len(w.resuming) != 0 {
 					if ws := w.resuming[0]; ws != nil {
 						w.addSubstream(pbresp, ws)
 						w.dispatchEvent(pbresp)
-						w.resuming[0] = nil
 					}
+					w.resuming = w.resuming[1:]
 				}
-
-				if ws := w.nextResume(); ws != nil {
-					if err := wc.Send(ws.initReq.toPB()); err != nil {
-						w.lg.Debug("error when sending request", zap.Error(err))
-					}
+				if w.inflight > 0 {
+					w.inflight--
 				}
+				w.sendResumes(wc)
 
-				// reset for next iteration
-				cur = nil
-
-			case pbresp.Canceled && pbresp.CompactRevision == 0:
+			case pbresp.Canceled && pbresp.CompactRevision == 0 && pbresp.CancelReason == "" && pbresp.CancelCode == pb.WatchResponse_UNSPECIFIED:
+				// canceled by the client; watchers canceled by the server
+				// with a reason are dispatched so the subscriber gets the error
 				delete(cancelSet, pbresp.WatchId)
 				if ws, ok := w.substreams[pbresp.WatchId]; ok {
 					// signal to stream goroutine to update closingc
@@ -615,22 +728,14 @@ func (w *watchGRPCStream) run() {
 					closing[ws] = struct{}{}
 				}
 
-				// reset for next iteration
-				cur = nil
-
-			case cur.Fragment:
+			case cur == nil:
 				// watch response events are still fragmented
 				// continue to fetch next fragmented event arrival
 				continue
 
 			default:
 				// dispatch to appropriate watch stream
-				ok := w.dispatchEvent(cur)
-
-				// reset for next iteration
-				cur = nil
-
-				if ok {
+				if w.dispatchEvent(cur) {
 					break
 				}
 
@@ -662,12 +767,10 @@ func (w *watchGRPCStream) run() {
 			if wc, closeErr = w.newWatchClient(); closeErr != nil {
 				return
 			}
-			if ws := w.nextResume(); ws != nil {
-				if err := wc.Send(ws.initReq.toPB()); err != nil {
-					w.lg.Debug("error when sending request", zap.Error(err))
-				}
-			}
+			w.sendResumes(wc)
 			cancelSet = make(map[int64]struct{})
+			// fragments of the broken stream are never completed
+			w.fragments = NewWatchResponseAssembler(w.owner.maxReassemblyBytes)
 
 		case <-w.ctx.Done():
 			return
@@ -698,16 +801,48 @@ func (w *watchGRPCStream) run() {
 	}
 }
 
-// nextResume chooses the next resuming to register with the grpc stream. Abandoned
-// streams are marked as nil in the queue since the head must wait for its inflight registration.
-func (w *watchGRPCStream) nextResume() *watcherStream {
-	for len(w.resuming) != 0 {
-		if w.resuming[0] != nil {
-			return w.resuming[0]
+// sendResumes registers the resuming watchers with the grpc stream once the
+// registrations in flight are answered. Servers accepting bulk requests are
+// sent the create requests of many watchers at once, other servers one at a
+// time. Abandoned streams are marked as nil in the queue since their
+// registrations in flight must be answered first.
+func (w *watchGRPCStream) sendResumes(wc pb.Watch_WatchClient) {
+	if w.inflight > 0 {
+		return
+	}
+	// none of the registrations are in flight, drop the abandoned streams
+	resuming := w.resuming[:0]
+	for _, ws := range w.resuming {
+		if ws != nil {
+			resuming = append(resuming, ws)
 		}
-		w.resuming = w.resuming[1:len(w.resuming)]
 	}
-	return nil
+	w.resuming = resuming
+	if len(w.resuming) == 0 {
+		return
+	}
+
+	req := w.resuming[0].initReq.toPB()
+	w.inflight = 1
+	if w.bulk && len(w.resuming) > 1 {
+		var creates []*pb.WatchCreateRequest
+		size := 0
+		for _, ws := range w.resuming {
+			creq := ws.initReq.toPB().GetCreateRequest()
+			size += creq.Size()
+			if len(creates) > 0 && (len(creates) == maxBulkWatchCreates || size > maxBulkWatchBytes) {
+				break
+			}
+			creates = append(creates, creq)
+		}
+		req = &pb.WatchRequest{RequestUnion: &pb.WatchRequest_BulkRequest{
+			BulkRequest: &pb.WatchBulkRequest{CreateRequests: creates},
+		}}
+		w.inflight = len(creates)
+	}
+	if err := wc.Send(req); err != nil {
+		w.lg.Debug("error when sending request", zap.Error(err))
+	}
 }
 
 // dispatchEvent sends a WatchResponse to the appropriate watcher stream
@@ -718,12 +853,23 @@ func (w *watchGRPCStream) dispatchEvent(pbresp *pb.WatchResponse) bool {
 	}
 	// TODO: return watch ID?
 	wr := &WatchResponse{
-		Header:          *pbresp.Header,
-		Events:          events,
-		CompactRevision: pbresp.CompactRevision,
-		Created:         pbresp.Created,
-		Canceled:        pbresp.Canceled,
-		CancelReason:    pbresp.CancelReason,
+		Header:           *pbresp.Header,
+		Events:           events,
+		CompactRevision:  pbresp.CompactRevision,
+		Created:          pbresp.Created,
+		Canceled:         pbresp.Canceled,
+		CancelReason:     pbresp.CancelReason,
+		CancelCode:       pbresp.CancelCode,
+		CancelDetails:    pbresp.CancelDetails,
+		InitialState:     pbresp.InitialState,
+		InitialStateMore: pbresp.InitialStateMore,
+		FirstSequence:    pbresp.FirstSequence,
+		PermissionChange: pbresp.PermissionChange,
+	}
+	if pbresp.ServerTime != 0 {
+		wr.ServerTime = time.Unix(0, pbresp.ServerTime)
+		wr.ServerMonotonicTime = time.Duration(pbresp.ServerMonotonicTime)
+		wr.ReceivedTime = time.Now()
 	}
 
 	// watch IDs are zero indexed, so request notify watch responses are assigned a watch ID of InvalidWatchID to
@@ -788,6 +934,7 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 	// nextRev is the minimum expected next revision
 	nextRev := ws.initReq.rev
 	resuming := false
+	ws.seqBase = ws.seq
 	defer func() {
 		if !resuming {
 			ws.closing = true
@@ -846,17 +993,31 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 						nextRev = wr.Header.Revision
 					}
 				}
-			} else {
+			} else if wr.PermissionChange == nil {
 				// current progress of watch; <= store revision
 				nextRev = wr.Header.Revision + 1
 			}
 
-			if len(wr.Events) > 0 {
+			switch {
+			case wr.InitialState:
+				// the initial state events are ordered by key, not revision;
+				// a resumed watcher restarts from the same initial state
+				// until it is complete
+				nextRev = wr.Header.Revision + 1
+				if !wr.InitialStateMore && !wr.Created {
+					ws.initReq.initialState = false
+				}
+			case len(wr.Events) > 0:
 				nextRev = wr.Events[len(wr.Events)-1].Kv.ModRevision + 1
 			}
 
 			ws.initReq.rev = nextRev
 
+			if wr.FirstSequence > 0 {
+				wr.FirstSequence += ws.seqBase
+				ws.seq = wr.FirstSequence + int64(len(wr.Events)) - 1
+			}
+
 			// created event is already sent above,
 			// watcher should not post duplicate events
 			if wr.Created {
@@ -894,6 +1055,9 @@ func (w *watchGRPCStream) newWatchClient() (pb.Watch_WatchClient, error) {
 		}
 	}
 	w.resuming = resuming
+	w.inflight = 0
+	// the new stream may be served by another server
+	w.bulk = false
 	w.substreams = make(map[int64]*watcherStream)
 
 	// connect to grpc stream while accepting watcher cancelation
@@ -1022,7 +1186,10 @@ func (wr *watchRequest) toPB() *pb.WatchRequest {
 		ProgressNotify: wr.progressNotify,
 		Filters:        wr.filters,
 		PrevKv:         wr.prevKV,
-		Fragment:       wr.fragment,
+		// servers predating the negotiation still fragment at their own limit
+		Fragment:         wr.fragment || wr.maxResponseBytes > 0,
+		MaxResponseBytes: wr.maxResponseBytes,
+		SendInitialState: wr.initialState,
 	}
 	cr := &pb.WatchRequest_CreateRequest{CreateRequest: req}
 	return &pb.WatchRequest{RequestUnion: cr}
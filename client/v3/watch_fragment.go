@@ -0,0 +1,98 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"errors"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// ErrWatchResponseTooLarge is returned by WatchResponse.Err when a watcher is
+// canceled because the fragments of a response exceed the reassembly limit.
+var ErrWatchResponseTooLarge = errors.New("etcdclient: watch response exceeds the reassembly limit")
+
+// WatchResponseAssembler reassembles the fragments of watch responses
+// received on a watch stream. The Watcher uses it internally; it is exported
+// for users of the raw gRPC watch stream.
+type WatchResponseAssembler struct {
+	maxBytes int
+	pending  map[int64]*pendingWatchResponse
+}
+
+type pendingWatchResponse struct {
+	// resp is nil while skipping the rest of a too large response
+	resp *pb.WatchResponse
+	// size is the total size of the fragments received so far
+	size int
+}
+
+// NewWatchResponseAssembler creates an assembler that keeps at most maxBytes
+// of pending fragments per watcher. If maxBytes is 0, there is no limit.
+func NewWatchResponseAssembler(maxBytes int) *WatchResponseAssembler {
+	return &WatchResponseAssembler{
+		maxBytes: maxBytes,
+		pending:  make(map[int64]*pendingWatchResponse),
+	}
+}
+
+// Add adds a response received on the watch stream. It returns the complete
+// response on the last fragment, carrying the events of all fragments, and
+// nil while fragments are pending. Responses that are not fragmented are
+// returned as is. If the fragments of a response exceed the limit,
+// ErrWatchResponseTooLarge is returned once and the remaining fragments of
+// that response are dropped.
+func (a *WatchResponseAssembler) Add(resp *pb.WatchResponse) (*pb.WatchResponse, error) {
+	if resp.Created || resp.Canceled {
+		a.Forget(resp.WatchId)
+		return resp, nil
+	}
+	cur, ok := a.pending[resp.WatchId]
+	if !ok {
+		if !resp.Fragment {
+			return resp, nil
+		}
+		cur = &pendingWatchResponse{resp: resp}
+	} else if cur.resp == nil {
+		if !resp.Fragment {
+			delete(a.pending, resp.WatchId)
+		}
+		return nil, nil
+	} else {
+		cur.resp.Events = append(cur.resp.Events, resp.Events...)
+		// the last fragment has "Fragment" == false
+		cur.resp.Fragment = resp.Fragment
+	}
+	cur.size += resp.Size()
+	if a.maxBytes > 0 && cur.size > a.maxBytes {
+		if resp.Fragment {
+			a.pending[resp.WatchId] = &pendingWatchResponse{}
+		} else {
+			delete(a.pending, resp.WatchId)
+		}
+		return nil, ErrWatchResponseTooLarge
+	}
+	if cur.resp.Fragment {
+		a.pending[resp.WatchId] = cur
+		return nil, nil
+	}
+	delete(a.pending, resp.WatchId)
+	return cur.resp, nil
+}
+
+// Forget drops the pending fragments of a watcher, e.g. once it is canceled.
+func (a *WatchResponseAssembler) Forget(watchID int64) {
+	delete(a.pending, watchID)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func fragmentResp(watchID int64, fragment bool, keys ...string) *pb.WatchResponse {
+	resp := &pb.WatchResponse{Header: &pb.ResponseHeader{Revision: 10}, WatchId: watchID, Fragment: fragment}
+	for _, k := range keys {
+		resp.Events = append(resp.Events, &mvccpb.Event{Kv: &mvccpb.KeyValue{Key: []byte(k), Value: make([]byte, 100)}})
+	}
+	return resp
+}
+
+func eventKeys(resp *pb.WatchResponse) []string {
+	var keys []string
+	for _, ev := range resp.Events {
+		keys = append(keys, string(ev.Kv.Key))
+	}
+	return keys
+}
+
+func TestWatchResponseAssembler(t *testing.T) {
+	a := NewWatchResponseAssembler(0)
+
+	resp, err := a.Add(fragmentResp(1, false, "a"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, eventKeys(resp))
+
+	// interleaved fragments of two watchers
+	for _, r := range []*pb.WatchResponse{
+		fragmentResp(1, true, "b", "c"),
+		fragmentResp(2, true, "x"),
+		fragmentResp(1, true, "d"),
+	} {
+		resp, err = a.Add(r)
+		require.NoError(t, err)
+		require.Nil(t, resp)
+	}
+	resp, err = a.Add(fragmentResp(1, false, "e"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c", "d", "e"}, eventKeys(resp))
+	require.False(t, resp.Fragment)
+
+	// a cancellation drops the pending fragments
+	resp, err = a.Add(&pb.WatchResponse{Header: &pb.ResponseHeader{}, WatchId: 2, Canceled: true})
+	require.NoError(t, err)
+	require.True(t, resp.Canceled)
+	resp, err = a.Add(fragmentResp(2, false, "y"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"y"}, eventKeys(resp))
+}
+
+func TestWatchResponseAssemblerTooLarge(t *testing.T) {
+	a := NewWatchResponseAssembler(500)
+
+	// a response within the limit is reassembled
+	_, err := a.Add(fragmentResp(1, true, "a"))
+	require.NoError(t, err)
+	resp, err := a.Add(fragmentResp(1, false, "b"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, eventKeys(resp))
+
+	// the limit is reported once and the rest of the response is dropped
+	_, err = a.Add(fragmentResp(1, true, "a", "b", "c"))
+	require.NoError(t, err)
+	_, err = a.Add(fragmentResp(1, true, "d", "e", "f"))
+	require.ErrorIs(t, err, ErrWatchResponseTooLarge)
+	for _, r := range []*pb.WatchResponse{fragmentResp(1, true, "g"), fragmentResp(1, false, "h")} {
+		resp, err = a.Add(r)
+		require.NoError(t, err)
+		require.Nil(t, resp)
+	}
+
+	// the next response is delivered again
+	resp, err = a.Add(fragmentResp(1, false, "i"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"i"}, eventKeys(resp))
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	v3rpc "go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// relistBatchLimit is the number of keys fetched per request while relisting
+// the range of a watch after it was compacted.
+const relistBatchLimit = 1000
+
+// WatchWithRecovery watches key like Watch, but recovers from compaction
+// instead of canceling the watch with ErrCompacted: it lists the watched
+// range at the current revision, passes the keys to onRelist, and resumes
+// the watch right after the listed revision. Consumers rebuild their state
+// from the listed keys and keep applying the events that follow.
+//
+// A watch started with WithRev at an already compacted revision relists
+// right away, so with WithRev(1) the consumer builds the full state of the
+// range either from its remaining history or from a list.
+//
+// Responses canceled for any other reason are delivered and close the
+// returned channel, as are errors of the relist.
+func (c *Client) WatchWithRecovery(ctx context.Context, key string, onRelist func(kvs []*mvccpb.KeyValue), opts ...OpOption) WatchChan {
+	op := OpWatch(key, opts...)
+	outc := make(chan WatchResponse)
+	go func() {
+		defer close(outc)
+		send := func(resp WatchResponse) bool {
+			select {
+			case outc <- resp:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		rev := op.rev
+		for {
+			wopts := opts
+			if rev != 0 {
+				wopts = append(append([]OpOption{}, opts...), WithRev(rev))
+			}
+			wctx, cancel := context.WithCancel(ctx)
+			compacted := false
+			for resp := range c.Watch(wctx, key, wopts...) {
+				if resp.CompactRevision != 0 {
+					compacted = true
+					break
+				}
+				if n := len(resp.Events); n > 0 {
+					rev = resp.Events[n-1].Kv.ModRevision + 1
+				} else if resp.IsProgressNotify() {
+					rev = resp.Header.Revision + 1
+				}
+				if !send(resp) || resp.Canceled {
+					cancel()
+					return
+				}
+			}
+			cancel()
+			if !compacted {
+				return
+			}
+
+			kvs, listRev, err := c.relist(ctx, op)
+			if err != nil {
+				if ctx.Err() == nil {
+					send(WatchResponse{Canceled: true, closeErr: err})
+				}
+				return
+			}
+			onRelist(kvs)
+			rev = listRev + 1
+		}
+	}()
+	return outc
+}
+
+// relist returns the keys in the range of op at the current revision,
+// fetched in batches of relistBatchLimit keys.
+func (c *Client) relist(ctx context.Context, op Op) ([]*mvccpb.KeyValue, int64, error) {
+	if len(op.end) == 0 {
+		resp, err := c.Get(ctx, string(op.key))
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Kvs, resp.Header.Revision, nil
+	}
+
+	for {
+		var kvs []*mvccpb.KeyValue
+		var rev int64
+		key := string(op.key)
+		for {
+			resp, err := c.Get(ctx, key, WithRange(string(op.end)), WithRev(rev), WithLimit(relistBatchLimit))
+			if rev != 0 && errors.Is(err, v3rpc.ErrCompacted) {
+				// the listed revision was compacted while paging, list
+				// again at the current revision.
+				break
+			}
+			if err != nil {
+				return nil, 0, err
+			}
+			rev = resp.Header.Revision
+			kvs = append(kvs, resp.Kvs...)
+			if !resp.More || len(resp.Kvs) == 0 {
+				return kvs, rev, nil
+			}
+			key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	v3rpc "go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// relistKV serves ranges over sorted keys at revision 10, failing the
+// requests at the revisions in compacted once.
+type relistKV struct {
+	KV
+	keys      []string
+	compacted map[int64]bool
+	gets      []Op
+}
+
+func (kv *relistKV) Get(_ context.Context, key string, opts ...OpOption) (*GetResponse, error) {
+	op := OpGet(key, opts...)
+	kv.gets = append(kv.gets, op)
+	if kv.compacted[op.Rev()] {
+		delete(kv.compacted, op.Rev())
+		return nil, v3rpc.ErrCompacted
+	}
+	resp := &GetResponse{Header: &pb.ResponseHeader{Revision: 10}}
+	for _, k := range kv.keys {
+		if k < string(op.KeyBytes()) || (len(op.RangeBytes()) > 0 && k >= string(op.RangeBytes())) {
+			continue
+		}
+		if len(op.RangeBytes()) == 0 && k != key {
+			continue
+		}
+		if op.Limit() > 0 && int64(len(resp.Kvs)) == op.Limit() {
+			resp.More = true
+			break
+		}
+		resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: []byte(k)})
+	}
+	return resp, nil
+}
+
+// relistWatcher hands out the queued responses, one channel per Watch call.
+type relistWatcher struct {
+	Watcher
+	resps [][]WatchResponse
+	revs  []int64
+}
+
+func (w *relistWatcher) Watch(_ context.Context, key string, opts ...OpOption) WatchChan {
+	w.revs = append(w.revs, OpWatch(key, opts...).Rev())
+	ch := make(chan WatchResponse, 10)
+	if len(w.resps) > 0 {
+		for _, resp := range w.resps[0] {
+			ch <- resp
+		}
+		w.resps = w.resps[1:]
+	}
+	close(ch)
+	return ch
+}
+
+func TestWatchWithRecovery(t *testing.T) {
+	var keys []string
+	for i := 0; i < 2*relistBatchLimit+5; i++ {
+		keys = append(keys, fmt.Sprintf("foo/%05d", i))
+	}
+	kv := &relistKV{keys: append(keys, "zoo"), compacted: map[int64]bool{10: true}}
+	w := &relistWatcher{resps: [][]WatchResponse{
+		{{Header: pb.ResponseHeader{Revision: 4}, Events: []*Event{{Kv: &mvccpb.KeyValue{ModRevision: 3}}}}, {CompactRevision: 5, Canceled: true}},
+		{{Header: pb.ResponseHeader{Revision: 12}, Events: []*Event{{Kv: &mvccpb.KeyValue{ModRevision: 12}}}}},
+	}}
+	c := &Client{KV: kv, Watcher: w}
+
+	var relisted [][]*mvccpb.KeyValue
+	wch := c.WatchWithRecovery(t.Context(), "foo/", func(kvs []*mvccpb.KeyValue) {
+		relisted = append(relisted, kvs)
+	}, WithPrefix(), WithRev(2))
+
+	var revs []int64
+	for resp := range wch {
+		require.NoError(t, resp.Err())
+		for _, ev := range resp.Events {
+			revs = append(revs, ev.Kv.ModRevision)
+		}
+	}
+	assert.Equal(t, []int64{3, 12}, revs)
+	// the watch resumed after the event before the compaction, and after the
+	// revision of the relist.
+	assert.Equal(t, []int64{2, 11}, w.revs)
+
+	require.Len(t, relisted, 1)
+	require.Len(t, relisted[0], len(keys))
+	for i, kv := range relisted[0] {
+		require.Equal(t, keys[i], string(kv.Key))
+	}
+	// the first list got compacted on its second page and started over.
+	assert.Len(t, kv.gets, 5)
+}
+
+func TestWatchWithRecoveryRelistError(t *testing.T) {
+	kv := &relistKV{compacted: map[int64]bool{0: true}}
+	w := &relistWatcher{resps: [][]WatchResponse{{{CompactRevision: 5, Canceled: true}}}}
+	c := &Client{KV: kv, Watcher: w}
+
+	wch := c.WatchWithRecovery(t.Context(), "foo", func([]*mvccpb.KeyValue) {
+		t.Fatal("unexpected relist")
+	}, WithRev(2))
+	resp, ok := <-wch
+	require.True(t, ok)
+	require.True(t, resp.Canceled)
+	require.ErrorIs(t, resp.Err(), v3rpc.ErrCompacted)
+	_, ok = <-wch
+	require.False(t, ok)
+}
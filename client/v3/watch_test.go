@@ -16,11 +16,14 @@ package clientv3
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"google.golang.org/grpc/metadata"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
 
 func TestEvent(t *testing.T) {
@@ -57,6 +60,31 @@ func TestEvent(t *testing.T) {
 	}
 }
 
+// TestWatchResponseErrCancelCode ensures the error of a canceled watch
+// response follows its cancel code rather than its human-readable reason.
+func TestWatchResponseErrCancelCode(t *testing.T) {
+	tests := []struct {
+		code   pb.WatchResponse_CancelCode
+		reason string
+		werr   error
+	}{
+		{pb.WatchResponse_COMPACTED, "", rpctypes.ErrCompacted},
+		{pb.WatchResponse_WATCHER_OVERLOADED, "some new wording", rpctypes.ErrWatcherDropped},
+		{pb.WatchResponse_SERVER_SHUTDOWN, "", rpctypes.ErrStopped},
+		{pb.WatchResponse_AUTH_REVOKED, "", rpctypes.ErrPermissionDenied},
+		{pb.WatchResponse_AUTH_REVOKED, rpctypes.ErrGRPCInvalidAuthToken.Error(), rpctypes.ErrInvalidAuthToken},
+		{pb.WatchResponse_RANGE_DELETED_BY_ADMIN, "", ErrWatchRangeDeleted},
+		{pb.WatchResponse_THRESHOLD_EXCEEDED, "", rpctypes.ErrThresholdExceeded},
+		{pb.WatchResponse_UNSPECIFIED, "", rpctypes.ErrFutureRev},
+	}
+	for i, tt := range tests {
+		wr := &WatchResponse{Canceled: true, CancelCode: tt.code, CancelReason: tt.reason}
+		if err := wr.Err(); !errors.Is(err, tt.werr) {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+	}
+}
+
 // TestStreamKeyFromCtx tests the streamKeyFromCtx function to ensure it correctly
 // formats metadata as a map[string][]string when extracting metadata from the context.
 //
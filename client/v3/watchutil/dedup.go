@@ -0,0 +1,133 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watchutil contains helpers for consumers of watches.
+package watchutil
+
+import (
+	"context"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Checkpoint is the position of the last event delivered to a consumer.
+// Consumers persist it along with the effects of the events to restart
+// their watch exactly after the last delivered event.
+type Checkpoint struct {
+	// Revision is the revision of the last delivered event.
+	Revision int64
+	// Events is the number of events of Revision delivered. A revision
+	// holds several events if it was written by a transaction.
+	Events int
+}
+
+// Dedup drops the events delivered more than once to a consumer, either
+// because the consumer restarted its watch at the revision of its last
+// checkpoint, or because a watch channel delivered them again.
+//
+// Dedup relies on the events being ordered by revision, so it does not
+// support watches created with WithInitialState.
+type Dedup struct {
+	cp Checkpoint
+	// seq is the sequence token of the last event seen on the current watch
+	// channel.
+	seq int64
+	// seen is the number of events of cp.Revision seen on the current watch
+	// channel.
+	seen int
+}
+
+// NewDedup returns a Dedup delivering the events after the given
+// checkpoint. The zero checkpoint delivers all events.
+func NewDedup(cp Checkpoint) *Dedup {
+	return &Dedup{cp: cp}
+}
+
+// Checkpoint returns the position of the last event returned by Filter.
+func (d *Dedup) Checkpoint() Checkpoint { return d.cp }
+
+// StartRevision returns the revision to start the watch at so that no event
+// after the checkpoint is missed, or 0 to watch from the current revision if
+// no event was delivered yet.
+func (d *Dedup) StartRevision() int64 { return d.cp.Revision }
+
+// Reset must be called when the watch is restarted on a new channel, since
+// sequence tokens are scoped to a watch channel.
+func (d *Dedup) Reset() {
+	d.seq = 0
+	d.seen = 0
+}
+
+// Filter returns the events of resp that were not delivered yet, and moves
+// the checkpoint to the last of them.
+func (d *Dedup) Filter(resp clientv3.WatchResponse) []*clientv3.Event {
+	events := resp.Events
+	if resp.FirstSequence > 0 {
+		// drop the events already seen on this channel
+		if skip := d.seq - resp.FirstSequence + 1; skip > 0 {
+			events = events[min(skip, int64(len(events))):]
+		}
+		d.seq = max(d.seq, resp.FirstSequence+int64(len(resp.Events))-1)
+	}
+
+	var ret []*clientv3.Event
+	for _, ev := range events {
+		rev := ev.Kv.ModRevision
+		switch {
+		case rev < d.cp.Revision:
+			continue
+		case rev == d.cp.Revision:
+			d.seen++
+			if d.seen <= d.cp.Events {
+				continue
+			}
+			d.cp.Events = d.seen
+		default:
+			d.cp = Checkpoint{Revision: rev, Events: 1}
+			d.seen = 1
+		}
+		ret = append(ret, ev)
+	}
+	return ret
+}
+
+// Watch starts a watch on key at StartRevision, and returns its responses
+// with the events delivered before removed. Responses left without events
+// are dropped, unless they carry other information, and responses have no
+// sequence tokens. WithRev is overridden by the checkpoint.
+func (d *Dedup) Watch(ctx context.Context, w clientv3.Watcher, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	d.Reset()
+	if rev := d.StartRevision(); rev > 0 {
+		opts = append(opts, clientv3.WithRev(rev))
+	}
+	wch := w.Watch(ctx, key, opts...)
+	ch := make(chan clientv3.WatchResponse)
+	go func() {
+		defer close(ch)
+		for resp := range wch {
+			n := len(resp.Events)
+			resp.Events = d.Filter(resp)
+			if n > 0 && len(resp.Events) == 0 && !resp.Created && !resp.Canceled && resp.CompactRevision == 0 {
+				continue
+			}
+			resp.FirstSequence = 0
+			select {
+			case ch <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func events(revs ...int64) []*clientv3.Event {
+	evs := make([]*clientv3.Event, len(revs))
+	for i, rev := range revs {
+		evs[i] = &clientv3.Event{Kv: &mvccpb.KeyValue{ModRevision: rev}}
+	}
+	return evs
+}
+
+func revisions(evs []*clientv3.Event) []int64 {
+	var revs []int64
+	for _, ev := range evs {
+		revs = append(revs, ev.Kv.ModRevision)
+	}
+	return revs
+}
+
+func TestDedupCheckpoint(t *testing.T) {
+	// the consumer stopped after the first event of revision 5
+	d := NewDedup(Checkpoint{Revision: 5, Events: 1})
+	assert.Equal(t, int64(5), d.StartRevision())
+
+	got := d.Filter(clientv3.WatchResponse{Events: events(5, 5, 5), FirstSequence: 1})
+	assert.Equal(t, []int64{5, 5}, revisions(got))
+	assert.Equal(t, Checkpoint{Revision: 5, Events: 3}, d.Checkpoint())
+
+	got = d.Filter(clientv3.WatchResponse{Events: events(6, 7), FirstSequence: 4})
+	assert.Equal(t, []int64{6, 7}, revisions(got))
+	assert.Equal(t, Checkpoint{Revision: 7, Events: 1}, d.Checkpoint())
+
+	// restart at the checkpoint
+	d.Reset()
+	got = d.Filter(clientv3.WatchResponse{Events: events(7, 8, 8), FirstSequence: 1})
+	assert.Equal(t, []int64{8, 8}, revisions(got))
+	assert.Equal(t, Checkpoint{Revision: 8, Events: 2}, d.Checkpoint())
+}
+
+func TestDedupSequence(t *testing.T) {
+	d := NewDedup(Checkpoint{})
+	assert.Zero(t, d.StartRevision())
+
+	got := d.Filter(clientv3.WatchResponse{Events: events(2, 3), FirstSequence: 1})
+	assert.Equal(t, []int64{2, 3}, revisions(got))
+	// the channel delivers the events 2 and 3 again
+	got = d.Filter(clientv3.WatchResponse{Events: events(3, 4), FirstSequence: 2})
+	assert.Equal(t, []int64{4}, revisions(got))
+	got = d.Filter(clientv3.WatchResponse{Events: events(3, 4), FirstSequence: 2})
+	assert.Empty(t, got)
+	// without tokens, the revisions are compared
+	got = d.Filter(clientv3.WatchResponse{Events: events(3, 5)})
+	assert.Equal(t, []int64{5}, revisions(got))
+}
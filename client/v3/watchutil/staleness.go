@@ -0,0 +1,114 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchutil
+
+import (
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// clockJumpThreshold is the difference between the wall-clock and the
+// monotonic time elapsed between two progress notifications beyond which
+// either clock is considered set, so that the clock offset is estimated
+// again.
+const clockJumpThreshold = 100 * time.Millisecond
+
+// Staleness estimates how stale a watch is: for how long it may have missed
+// the latest changes of the key-value store. It is safe for concurrent use.
+//
+// The estimate relies on the progress notifications of the watch, so the
+// watch must be created with WithProgressNotify, or progress requested
+// periodically with Watcher.RequestProgress. A progress notification tells
+// that all the events up to its revision were delivered at the time it was
+// sent, as read on the clock of the member. That time is converted to the
+// clock of the client with the smallest difference seen between the time a
+// notification is received and the time it was sent, which is the clock
+// skew between the member and the client plus the shortest network delay.
+type Staleness struct {
+	mu sync.Mutex
+	// last is the last progress notification observed.
+	last *clientv3.WatchResponse
+	// offset is the estimated offset of the client clock from the member
+	// clock, network delay included.
+	offset time.Duration
+	// current is the time, on the client clock, at which the watch was
+	// last known to be current.
+	current time.Time
+}
+
+// NewStaleness returns a Staleness without observed progress notification.
+func NewStaleness() *Staleness {
+	return &Staleness{}
+}
+
+// Observe updates the estimate with a response of the watch. Responses
+// other than progress notifications of members reporting their time are
+// ignored.
+func (s *Staleness) Observe(resp clientv3.WatchResponse) {
+	if resp.ServerTime.IsZero() || resp.ReceivedTime.IsZero() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sample := resp.ReceivedTime.Round(0).Sub(resp.ServerTime)
+	if s.last == nil || s.clockChanged(&resp) || sample < s.offset {
+		s.offset = sample
+	}
+	s.last = &resp
+	s.current = resp.ServerTime.Add(s.offset)
+}
+
+// clockChanged tells whether the member serving the watch changed, or the
+// clock of the member or of the client was set, since the last progress
+// notification.
+func (s *Staleness) clockChanged(resp *clientv3.WatchResponse) bool {
+	last := s.last
+	if resp.Header.MemberId != last.Header.MemberId || resp.ServerMonotonicTime < last.ServerMonotonicTime {
+		return true
+	}
+	server := resp.ServerTime.Sub(last.ServerTime) - (resp.ServerMonotonicTime - last.ServerMonotonicTime)
+	// time.Time.Sub uses the monotonic clock readings of the receive times,
+	// which are lost by Round(0)
+	client := resp.ReceivedTime.Round(0).Sub(last.ReceivedTime.Round(0)) - resp.ReceivedTime.Sub(last.ReceivedTime)
+	return server.Abs() > clockJumpThreshold || client.Abs() > clockJumpThreshold
+}
+
+// ClockOffset returns the estimated offset of the client clock from the
+// clock of the member serving the watch, network delay included, and false
+// if no progress notification was observed.
+func (s *Staleness) ClockOffset() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offset, s.last != nil
+}
+
+// Estimate returns for how long the watch may have missed changes, that is
+// the time elapsed since it was last known to be current, and false if no
+// progress notification was observed. The estimate grows between progress
+// notifications even if no change is missed.
+func (s *Staleness) Estimate() (time.Duration, bool) {
+	return s.estimate(time.Now())
+}
+
+func (s *Staleness) estimate(now time.Time) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last == nil {
+		return 0, false
+	}
+	return max(now.Round(0).Sub(s.current), 0), true
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func progress(member uint64, server time.Time, mono, delay time.Duration) clientv3.WatchResponse {
+	return clientv3.WatchResponse{
+		Header:              pb.ResponseHeader{MemberId: member, Revision: 1},
+		ServerTime:          server,
+		ServerMonotonicTime: mono,
+		ReceivedTime:        server.Add(delay),
+	}
+}
+
+func TestStaleness(t *testing.T) {
+	s := NewStaleness()
+	_, ok := s.Estimate()
+	require.False(t, ok)
+	s.Observe(clientv3.WatchResponse{Header: pb.ResponseHeader{Revision: 1}})
+	_, ok = s.ClockOffset()
+	require.False(t, ok, "only progress notifications reporting the time are observed")
+
+	t0 := time.Unix(1000, 0)
+	s.Observe(progress(1, t0, time.Minute, 50*time.Millisecond))
+	offset, ok := s.ClockOffset()
+	require.True(t, ok)
+	assert.Equal(t, 50*time.Millisecond, offset)
+	d, _ := s.estimate(t0.Add(50*time.Millisecond + time.Second))
+	assert.Equal(t, time.Second, d)
+
+	// a slower notification does not change the offset, but tells that the
+	// watch was delayed more than usual
+	t1 := t0.Add(10 * time.Second)
+	s.Observe(progress(1, t1, time.Minute+10*time.Second, 80*time.Millisecond))
+	offset, _ = s.ClockOffset()
+	assert.Equal(t, 50*time.Millisecond, offset)
+	d, _ = s.estimate(t1.Add(80 * time.Millisecond))
+	assert.Equal(t, 30*time.Millisecond, d)
+
+	// a faster one lowers it
+	t2 := t1.Add(10 * time.Second)
+	s.Observe(progress(1, t2, time.Minute+20*time.Second, 20*time.Millisecond))
+	offset, _ = s.ClockOffset()
+	assert.Equal(t, 20*time.Millisecond, offset)
+
+	// the wall clock of the member is set back an hour
+	t3 := t2.Add(10*time.Second - time.Hour)
+	s.Observe(progress(1, t3, time.Minute+30*time.Second, time.Hour+40*time.Millisecond))
+	offset, _ = s.ClockOffset()
+	assert.Equal(t, time.Hour+40*time.Millisecond, offset)
+	d, _ = s.estimate(t3.Add(time.Hour + 40*time.Millisecond))
+	assert.Equal(t, time.Duration(0), d)
+
+	// another member serves the watch
+	t4 := t3.Add(10 * time.Second)
+	s.Observe(progress(2, t4, time.Second, 2*time.Second))
+	offset, _ = s.ClockOffset()
+	assert.Equal(t, 2*time.Second, offset)
+}
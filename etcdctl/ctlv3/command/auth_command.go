@@ -17,13 +17,24 @@ package command
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
+var (
+	authExportWithPasswordHashes bool
+	authApplyFile                string
+	authApplyPrune               bool
+	authApplyDryRun              bool
+)
+
 // NewAuthCommand returns the cobra command for "auth".
 func NewAuthCommand() *cobra.Command {
 	ac := &cobra.Command{
@@ -36,6 +47,8 @@ func NewAuthCommand() *cobra.Command {
 	ac.AddCommand(newAuthEnableCommand())
 	ac.AddCommand(newAuthDisableCommand())
 	ac.AddCommand(newAuthStatusCommand())
+	ac.AddCommand(newAuthExportCommand())
+	ac.AddCommand(newAuthApplyCommand())
 
 	return ac
 }
@@ -103,11 +116,13 @@ func authEnableCommandFunc(cmd *cobra.Command, args []string) {
 }
 
 func newAuthDisableCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "disable",
 		Short: "Disables authentication",
 		Run:   authDisableCommandFunc,
 	}
+	addAuditFlags(cmd, false)
+	return cmd
 }
 
 // authDisableCommandFunc executes the "auth disable" command.
@@ -116,8 +131,25 @@ func authDisableCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("auth disable command does not accept any arguments"))
 	}
 
+	c := mustClientFromCmd(cmd)
+	if auditInteractive {
+		ctx, cancel := commandCtx(cmd)
+		users, err := c.Auth.UserList(ctx)
+		if err != nil {
+			cancel()
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		roles, err := c.Auth.RoleList(ctx)
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		mustConfirm(fmt.Sprintf("Disable authentication, giving everyone full access, and stop enforcing the permissions of %d user(s) and %d role(s)?",
+			len(users.Users), len(roles.Roles)))
+	}
+
 	ctx, cancel := commandCtx(cmd)
-	_, err := mustClientFromCmd(cmd).Auth.AuthDisable(ctx)
+	_, err := c.Auth.AuthDisable(ctx)
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
@@ -125,3 +157,157 @@ func authDisableCommandFunc(cmd *cobra.Command, args []string) {
 
 	fmt.Println("Authentication Disabled")
 }
+
+func newAuthExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Writes the users, roles and permissions as YAML to stdout",
+		Long: `Writes the users, roles and permissions as YAML to stdout, in the format read by
+"auth apply". Password hashes are left out unless --with-password-hashes is set.`,
+		Run: authExportCommandFunc,
+	}
+	cmd.Flags().BoolVar(&authExportWithPasswordHashes, "with-password-hashes", false, "Include the password hashes of the users, e.g. to migrate them to another cluster. Requires the root role")
+	return cmd
+}
+
+// authExportCommandFunc executes the "auth export" command.
+func authExportCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("auth export command does not accept any arguments"))
+	}
+
+	spec, err := getAuthSpec(cmd, mustClientFromCmd(cmd).Auth, authExportWithPasswordHashes)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	out, err := yaml.Marshal(spec)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	os.Stdout.Write(out)
+}
+
+func newAuthApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply -f <file>",
+		Short: "Reconciles the users, roles and permissions with a file",
+		Long: `Reconciles the users, roles and permissions with a file in the format written by
+"auth export". Missing roles and users are added, and the permissions and roles
+granted are made to match the file. Password hashes are only changed for users
+specifying one. Users and roles not in the file are only deleted with --prune.`,
+		Run: authApplyCommandFunc,
+	}
+	cmd.Flags().StringVarP(&authApplyFile, "file", "f", "", "File to apply, '-' for stdin")
+	cmd.Flags().BoolVar(&authApplyPrune, "prune", false, "Delete the users and roles not in the file")
+	cmd.Flags().BoolVar(&authApplyDryRun, "dry-run", false, "Only print the changes")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// authApplyCommandFunc executes the "auth apply" command.
+func authApplyCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("auth apply command does not accept any arguments"))
+	}
+
+	var data []byte
+	var err error
+	if authApplyFile == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(authApplyFile)
+	}
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	var desired authSpec
+	if err = yaml.UnmarshalStrict(data, &desired); err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid auth file %q: %w", authApplyFile, err))
+	}
+
+	withHashes := false
+	for _, u := range desired.Users {
+		withHashes = withHashes || u.PasswordHash != ""
+	}
+	a := mustClientFromCmd(cmd).Auth
+	current, err := getAuthSpec(cmd, a, withHashes)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	actions, err := planAuthApply(current, desired, authApplyPrune)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+
+	for _, action := range actions {
+		fmt.Println(action.desc)
+		if authApplyDryRun {
+			continue
+		}
+		ctx, cancel := commandCtx(cmd)
+		err = action.do(ctx, a)
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to %s: %w", action.desc, err))
+		}
+	}
+	switch {
+	case len(actions) == 0:
+		fmt.Println("No changes")
+	case authApplyDryRun:
+		fmt.Printf("%d change(s) planned (dry run)\n", len(actions))
+	default:
+		fmt.Printf("%d change(s) applied\n", len(actions))
+	}
+}
+
+// getAuthSpec reads the users, roles and permissions of the cluster.
+func getAuthSpec(cmd *cobra.Command, a clientv3.Auth, withPasswordHashes bool) (authSpec, error) {
+	var spec authSpec
+	ctx, cancel := commandCtx(cmd)
+	roles, err := a.RoleList(ctx)
+	cancel()
+	if err != nil {
+		return spec, err
+	}
+	for _, name := range roles.Roles {
+		ctx, cancel = commandCtx(cmd)
+		role, err := a.RoleGet(ctx, name)
+		cancel()
+		if err != nil {
+			return spec, fmt.Errorf("failed to get role %q: %w", name, err)
+		}
+		r := roleSpec{Name: name, RPCs: role.RpcPermissions}
+		for _, p := range role.Perm {
+			r.Permissions = append(r.Permissions, newPermSpec(p))
+		}
+		spec.Roles = append(spec.Roles, r)
+	}
+
+	ctx, cancel = commandCtx(cmd)
+	users, err := a.UserList(ctx)
+	cancel()
+	if err != nil {
+		return spec, err
+	}
+	for _, name := range users.Users {
+		var user *clientv3.AuthUserGetResponse
+		ctx, cancel = commandCtx(cmd)
+		if withPasswordHashes {
+			user, err = a.UserGetWithPasswordHash(ctx, name)
+		} else {
+			user, err = a.UserGet(ctx, name)
+		}
+		cancel()
+		if err != nil {
+			return spec, fmt.Errorf("failed to get user %q: %w", name, err)
+		}
+		spec.Users = append(spec.Users, userSpec{
+			Name:         name,
+			Roles:        user.Roles,
+			NoPassword:   user.NoPassword,
+			PasswordHash: user.HashedPassword,
+		})
+	}
+	return spec, nil
+}
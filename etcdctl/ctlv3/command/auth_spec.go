@@ -0,0 +1,360 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.etcd.io/etcd/api/v3/authpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// authSpec is the declarative description of the users and roles of a
+// cluster, as written by "auth export" and read by "auth apply".
+type authSpec struct {
+	Roles []roleSpec `json:"roles"`
+	Users []userSpec `json:"users"`
+}
+
+type roleSpec struct {
+	Name        string     `json:"name"`
+	Permissions []permSpec `json:"permissions,omitempty"`
+	// RPCs are the administrative RPC methods granted to the role, such as
+	// "Maintenance.Defragment".
+	RPCs []string `json:"rpcs,omitempty"`
+}
+
+// permSpec is a key permission. The range is either the single key, the
+// keys in [key, rangeEnd), the keys prefixed by key or the keys from key on.
+// An empty key with prefix or fromKey covers the whole key space.
+type permSpec struct {
+	Type     string `json:"type"`
+	Key      string `json:"key"`
+	RangeEnd string `json:"rangeEnd,omitempty"`
+	Prefix   bool   `json:"prefix,omitempty"`
+	FromKey  bool   `json:"fromKey,omitempty"`
+}
+
+type userSpec struct {
+	Name         string   `json:"name"`
+	Roles        []string `json:"roles,omitempty"`
+	NoPassword   bool     `json:"noPassword,omitempty"`
+	PasswordHash string   `json:"passwordHash,omitempty"`
+}
+
+// newPermSpec describes a permission in the most readable form.
+func newPermSpec(p *authpb.Permission) permSpec {
+	spec := permSpec{Type: strings.ToLower(authpb.Permission_Type_name[int32(p.PermType)]), Key: string(p.Key)}
+	switch end := string(p.RangeEnd); {
+	case end == "":
+	case spec.Key == "\x00" && end == "\x00":
+		spec.Key = ""
+		spec.Prefix = true
+	case end == "\x00":
+		spec.FromKey = true
+	case end == clientv3.GetPrefixRangeEnd(spec.Key):
+		spec.Prefix = true
+	default:
+		spec.RangeEnd = end
+	}
+	return spec
+}
+
+// keyRange returns the range of the permission as stored by etcd.
+func (p permSpec) keyRange() (key, rangeEnd string, err error) {
+	switch {
+	case p.Prefix && p.FromKey:
+		return "", "", errors.New("prefix and fromKey are mutually exclusive")
+	case (p.Prefix || p.FromKey) && p.RangeEnd != "":
+		return "", "", errors.New("rangeEnd cannot be combined with prefix or fromKey")
+	case p.Key == "" && (p.Prefix || p.FromKey):
+		return "\x00", "\x00", nil
+	case p.Key == "":
+		return "", "", errors.New("empty key")
+	case p.Prefix:
+		return p.Key, clientv3.GetPrefixRangeEnd(p.Key), nil
+	case p.FromKey:
+		return p.Key, "\x00", nil
+	}
+	return p.Key, p.RangeEnd, nil
+}
+
+// authAction is a single change made by "auth apply".
+type authAction struct {
+	desc string
+	do   func(context.Context, clientv3.Auth) error
+}
+
+// planAuthApply returns the actions turning the users and roles of current
+// into the ones of desired. Users and roles missing from desired are only
+// deleted if prune is set. Password hashes are only changed if desired
+// specifies them.
+func planAuthApply(current, desired authSpec, prune bool) ([]authAction, error) {
+	var actions []authAction
+
+	currentRoles := make(map[string]roleSpec)
+	for _, r := range current.Roles {
+		currentRoles[r.Name] = r
+	}
+	desiredRoles := make(map[string]bool)
+	for _, r := range desired.Roles {
+		if r.Name == "" {
+			return nil, errors.New("role without name")
+		}
+		if desiredRoles[r.Name] {
+			return nil, fmt.Errorf("role %q is specified more than once", r.Name)
+		}
+		desiredRoles[r.Name] = true
+
+		cur, ok := currentRoles[r.Name]
+		if !ok {
+			name := r.Name
+			actions = append(actions, authAction{
+				desc: fmt.Sprintf("add role %s", name),
+				do: func(ctx context.Context, a clientv3.Auth) error {
+					_, err := a.RoleAdd(ctx, name)
+					return err
+				},
+			})
+		}
+		permActions, err := planRolePermissions(r.Name, cur.Permissions, r.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, permActions...)
+		actions = append(actions, planRoleRPCs(r.Name, cur.RPCs, r.RPCs)...)
+	}
+
+	currentUsers := make(map[string]userSpec)
+	for _, u := range current.Users {
+		currentUsers[u.Name] = u
+	}
+	desiredUsers := make(map[string]bool)
+	for _, u := range desired.Users {
+		if u.Name == "" {
+			return nil, errors.New("user without name")
+		}
+		if desiredUsers[u.Name] {
+			return nil, fmt.Errorf("user %q is specified more than once", u.Name)
+		}
+		desiredUsers[u.Name] = true
+		if u.NoPassword && u.PasswordHash != "" {
+			return nil, fmt.Errorf("user %q cannot have both noPassword and passwordHash", u.Name)
+		}
+		for _, role := range u.Roles {
+			// the root role can be granted without being added.
+			if _, ok := currentRoles[role]; !ok && !desiredRoles[role] && role != "root" {
+				return nil, fmt.Errorf("user %q is granted unknown role %q", u.Name, role)
+			}
+		}
+
+		name := u.Name
+		cur, ok := currentUsers[u.Name]
+		switch {
+		case !ok && u.NoPassword:
+			actions = append(actions, authAction{
+				desc: fmt.Sprintf("add user %s without password", name),
+				do: func(ctx context.Context, a clientv3.Auth) error {
+					_, err := a.UserAddWithOptions(ctx, name, "", &clientv3.UserAddOptions{NoPassword: true})
+					return err
+				},
+			})
+		case !ok && u.PasswordHash != "":
+			hash := u.PasswordHash
+			actions = append(actions, authAction{
+				desc: fmt.Sprintf("add user %s", name),
+				do: func(ctx context.Context, a clientv3.Auth) error {
+					_, err := a.UserAddWithPasswordHash(ctx, name, hash)
+					return err
+				},
+			})
+		case !ok:
+			return nil, fmt.Errorf("new user %q needs either passwordHash or noPassword", u.Name)
+		case cur.NoPassword != u.NoPassword:
+			return nil, fmt.Errorf("user %q cannot change noPassword, delete the user first", u.Name)
+		case u.PasswordHash != "" && u.PasswordHash != cur.PasswordHash:
+			hash := u.PasswordHash
+			actions = append(actions, authAction{
+				desc: fmt.Sprintf("change password of user %s", name),
+				do: func(ctx context.Context, a clientv3.Auth) error {
+					_, err := a.UserChangePasswordHash(ctx, name, hash)
+					return err
+				},
+			})
+		}
+
+		for _, role := range u.Roles {
+			if slices.Contains(cur.Roles, role) {
+				continue
+			}
+			actions = append(actions, authAction{
+				desc: fmt.Sprintf("grant role %s to user %s", role, name),
+				do: func(ctx context.Context, a clientv3.Auth) error {
+					_, err := a.UserGrantRole(ctx, name, role)
+					return err
+				},
+			})
+		}
+		for _, role := range cur.Roles {
+			if slices.Contains(u.Roles, role) {
+				continue
+			}
+			actions = append(actions, authAction{
+				desc: fmt.Sprintf("revoke role %s from user %s", role, name),
+				do: func(ctx context.Context, a clientv3.Auth) error {
+					_, err := a.UserRevokeRole(ctx, name, role)
+					return err
+				},
+			})
+		}
+	}
+
+	if !prune {
+		return actions, nil
+	}
+	for _, u := range current.Users {
+		if desiredUsers[u.Name] {
+			continue
+		}
+		name := u.Name
+		actions = append(actions, authAction{
+			desc: fmt.Sprintf("delete user %s", name),
+			do: func(ctx context.Context, a clientv3.Auth) error {
+				_, err := a.UserDelete(ctx, name)
+				return err
+			},
+		})
+	}
+	for _, r := range current.Roles {
+		if desiredRoles[r.Name] {
+			continue
+		}
+		name := r.Name
+		actions = append(actions, authAction{
+			desc: fmt.Sprintf("delete role %s", name),
+			do: func(ctx context.Context, a clientv3.Auth) error {
+				_, err := a.RoleDelete(ctx, name)
+				return err
+			},
+		})
+	}
+	return actions, nil
+}
+
+// planRolePermissions returns the actions turning the current permissions of
+// a role into the desired ones.
+func planRolePermissions(role string, current, desired []permSpec) ([]authAction, error) {
+	type permRange struct{ key, rangeEnd string }
+	currentTypes := make(map[permRange]string)
+	for _, p := range current {
+		key, rangeEnd, err := p.keyRange()
+		if err != nil {
+			return nil, err
+		}
+		currentTypes[permRange{key, rangeEnd}] = p.Type
+	}
+
+	var actions []authAction
+	desiredRanges := make(map[permRange]bool)
+	for _, p := range desired {
+		key, rangeEnd, err := p.keyRange()
+		if err != nil {
+			return nil, fmt.Errorf("role %q: permission on %q: %w", role, p.Key, err)
+		}
+		permType, err := clientv3.StrToPermissionType(p.Type)
+		if err != nil {
+			return nil, fmt.Errorf("role %q: permission on %q: %w", role, p.Key, err)
+		}
+		r := permRange{key, rangeEnd}
+		if desiredRanges[r] {
+			return nil, fmt.Errorf("role %q: permission on %q is specified more than once", role, p.Key)
+		}
+		desiredRanges[r] = true
+		if t, ok := currentTypes[r]; ok && strings.EqualFold(t, p.Type) {
+			continue
+		}
+		desc := describePermRange(key, rangeEnd)
+		actions = append(actions, authAction{
+			desc: fmt.Sprintf("grant %s permission on %s to role %s", strings.ToLower(p.Type), desc, role),
+			do: func(ctx context.Context, a clientv3.Auth) error {
+				_, err := a.RoleGrantPermission(ctx, role, key, rangeEnd, permType)
+				return err
+			},
+		})
+	}
+	for _, p := range current {
+		key, rangeEnd, _ := p.keyRange()
+		if desiredRanges[permRange{key, rangeEnd}] {
+			continue
+		}
+		actions = append(actions, authAction{
+			desc: fmt.Sprintf("revoke permission on %s from role %s", describePermRange(key, rangeEnd), role),
+			do: func(ctx context.Context, a clientv3.Auth) error {
+				_, err := a.RoleRevokePermission(ctx, role, key, rangeEnd)
+				return err
+			},
+		})
+	}
+	return actions, nil
+}
+
+// planRoleRPCs returns the actions turning the current RPC permissions of a
+// role into the desired ones.
+func planRoleRPCs(role string, current, desired []string) []authAction {
+	var actions []authAction
+	for _, method := range desired {
+		if slices.Contains(current, method) {
+			continue
+		}
+		actions = append(actions, authAction{
+			desc: fmt.Sprintf("grant rpc %s to role %s", method, role),
+			do: func(ctx context.Context, a clientv3.Auth) error {
+				_, err := a.RoleGrantRPCPermission(ctx, role, method)
+				return err
+			},
+		})
+	}
+	for _, method := range current {
+		if slices.Contains(desired, method) {
+			continue
+		}
+		actions = append(actions, authAction{
+			desc: fmt.Sprintf("revoke rpc %s from role %s", method, role),
+			do: func(ctx context.Context, a clientv3.Auth) error {
+				_, err := a.RoleRevokeRPCPermission(ctx, role, method)
+				return err
+			},
+		})
+	}
+	return actions
+}
+
+func describePermRange(key, rangeEnd string) string {
+	switch {
+	case rangeEnd == "":
+		return fmt.Sprintf("%q", key)
+	case key == "\x00" && rangeEnd == "\x00":
+		return "all keys"
+	case rangeEnd == "\x00":
+		return fmt.Sprintf("keys from %q", key)
+	case rangeEnd == clientv3.GetPrefixRangeEnd(key):
+		return fmt.Sprintf("prefix %q", key)
+	}
+	return fmt.Sprintf("[%q, %q)", key, rangeEnd)
+}
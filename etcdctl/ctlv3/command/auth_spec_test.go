@@ -0,0 +1,155 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/authpb"
+)
+
+func TestPermSpecRoundTrip(t *testing.T) {
+	tests := []struct {
+		key, rangeEnd string
+		want          permSpec
+	}{
+		{key: "foo", want: permSpec{Type: "read", Key: "foo"}},
+		{key: "foo", rangeEnd: "fop", want: permSpec{Type: "read", Key: "foo", Prefix: true}},
+		{key: "foo", rangeEnd: "foz", want: permSpec{Type: "read", Key: "foo", RangeEnd: "foz"}},
+		{key: "foo", rangeEnd: "\x00", want: permSpec{Type: "read", Key: "foo", FromKey: true}},
+		{key: "\x00", rangeEnd: "\x00", want: permSpec{Type: "read", Key: "", Prefix: true}},
+	}
+	for _, tt := range tests {
+		spec := newPermSpec(&authpb.Permission{PermType: authpb.READ, Key: []byte(tt.key), RangeEnd: []byte(tt.rangeEnd)})
+		assert.Equal(t, tt.want, spec)
+		key, rangeEnd, err := spec.keyRange()
+		require.NoError(t, err)
+		assert.Equal(t, tt.key, key)
+		assert.Equal(t, tt.rangeEnd, rangeEnd)
+	}
+}
+
+func TestPlanAuthApply(t *testing.T) {
+	current := authSpec{
+		Roles: []roleSpec{
+			{Name: "root"},
+			{Name: "reader", Permissions: []permSpec{{Type: "read", Key: "a", Prefix: true}, {Type: "read", Key: "b"}}, RPCs: []string{"Maintenance.Status"}},
+			{Name: "old"},
+		},
+		Users: []userSpec{
+			{Name: "root", Roles: []string{"root"}, PasswordHash: "h1"},
+			{Name: "alice", Roles: []string{"reader", "old"}, PasswordHash: "h2"},
+			{Name: "bob"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		desired authSpec
+		prune   bool
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "no changes",
+			desired: current,
+			prune:   true,
+		},
+		{
+			name: "no changes without password hashes",
+			desired: authSpec{
+				Roles: current.Roles,
+				Users: []userSpec{{Name: "root", Roles: []string{"root"}}, {Name: "alice", Roles: []string{"reader", "old"}}, {Name: "bob"}},
+			},
+		},
+		{
+			name: "reconcile",
+			desired: authSpec{
+				Roles: []roleSpec{
+					{Name: "root"},
+					{Name: "reader", Permissions: []permSpec{{Type: "readwrite", Key: "a", Prefix: true}, {Type: "read", Key: "c", FromKey: true}}, RPCs: []string{"Maintenance.Defragment"}},
+					{Name: "writer", Permissions: []permSpec{{Type: "write", Key: "", Prefix: true}}},
+				},
+				Users: []userSpec{
+					{Name: "root", Roles: []string{"root"}},
+					{Name: "alice", Roles: []string{"reader", "writer"}, PasswordHash: "h3"},
+					{Name: "carol", Roles: []string{"writer"}, NoPassword: true},
+					{Name: "dave", PasswordHash: "h4"},
+				},
+			},
+			prune: true,
+			want: []string{
+				`grant readwrite permission on prefix "a" to role reader`,
+				`grant read permission on keys from "c" to role reader`,
+				`revoke permission on "b" from role reader`,
+				`grant rpc Maintenance.Defragment to role reader`,
+				`revoke rpc Maintenance.Status from role reader`,
+				`add role writer`,
+				`grant write permission on all keys to role writer`,
+				`change password of user alice`,
+				`grant role writer to user alice`,
+				`revoke role old from user alice`,
+				`add user carol without password`,
+				`grant role writer to user carol`,
+				`add user dave`,
+				`delete user bob`,
+				`delete role old`,
+			},
+		},
+		{
+			name:    "new user without password",
+			desired: authSpec{Users: []userSpec{{Name: "carol"}}},
+			wantErr: `new user "carol" needs either passwordHash or noPassword`,
+		},
+		{
+			name:    "unknown role",
+			desired: authSpec{Users: []userSpec{{Name: "carol", NoPassword: true, Roles: []string{"nope"}}}},
+			wantErr: `user "carol" is granted unknown role "nope"`,
+		},
+		{
+			name:    "change noPassword",
+			desired: authSpec{Users: []userSpec{{Name: "alice", NoPassword: true}}},
+			wantErr: `user "alice" cannot change noPassword, delete the user first`,
+		},
+		{
+			name:    "invalid permission type",
+			desired: authSpec{Roles: []roleSpec{{Name: "reader", Permissions: []permSpec{{Type: "all", Key: "a"}}}}},
+			wantErr: `role "reader": permission on "a": invalid permission type: all`,
+		},
+		{
+			name:    "duplicate role",
+			desired: authSpec{Roles: []roleSpec{{Name: "x"}, {Name: "x"}}},
+			wantErr: `role "x" is specified more than once`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions, err := planAuthApply(current, tt.desired, tt.prune)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var got []string
+			for _, a := range actions {
+				got = append(got, a.desc)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	protectWriter  string
+	thresholdBlock []string
+)
+
+// NewClusterConfigCommand returns the cobra command for "cluster-config".
+func NewClusterConfigCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:     "cluster-config <subcommand>",
+		Short:   "Cluster-wide configuration related commands",
+		GroupID: groupClusterMaintenanceID,
+	}
+
+	cc.AddCommand(newClusterConfigGetCommand())
+	cc.AddCommand(newClusterConfigProtectCommand())
+	cc.AddCommand(newClusterConfigUnprotectCommand())
+	cc.AddCommand(newClusterConfigLeaseExpiryEventsCommand())
+	cc.AddCommand(newClusterConfigAlarmThresholdCommand())
+
+	return cc
+}
+
+func newClusterConfigGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get",
+		Short: "Gets the cluster-wide configuration",
+		Run:   clusterConfigGetCommandFunc,
+	}
+}
+
+func newClusterConfigProtectCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "protect <prefix> <election>",
+		Short: "Restricts writes to a prefix to candidates of an election",
+		Long: `Restricts writes to the keys starting with <prefix> to requests carrying the lease of
+a candidate of the election <election>, as used by "etcdctl elect". By default only the
+leader of the election may write.`,
+		Run: clusterConfigProtectCommandFunc,
+	}
+	cc.Flags().StringVar(&protectWriter, "writer", "leader", "candidates allowed to write, 'leader' or 'follower'")
+	return cc
+}
+
+func newClusterConfigUnprotectCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unprotect <prefix>",
+		Short: "Lifts the write restriction of a prefix",
+		Run:   clusterConfigUnprotectCommandFunc,
+	}
+}
+
+func newClusterConfigLeaseExpiryEventsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lease-expiry-events <prefix>",
+		Short: "Records the expiry of each lease under a prefix",
+		Long: `Records the expiry of each lease under <prefix>, followed by the lease ID in hexadecimal,
+with the keys the expiry deleted. An empty prefix stops recording expiries.`,
+		Run: clusterConfigLeaseExpiryEventsCommandFunc,
+	}
+}
+
+func newClusterConfigAlarmThresholdCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "alarm-threshold <metric> <value>",
+		Short: "Raises the THRESHOLD alarm when a metric of a member reaches a value",
+		Long: `Raises the THRESHOLD alarm when <metric> of a member reaches <value>, until it is back
+under. The metric is one of 'db_size_percent', 'apply_backlog', 'watchers' or 'leases'.
+While the alarm is raised, the cluster rejects the operations given by --block.
+A value of 0 removes the threshold of the metric.`,
+		Run: clusterConfigAlarmThresholdCommandFunc,
+	}
+	cc.Flags().StringSliceVar(&thresholdBlock, "block", nil, "operations rejected while the alarm is raised, among 'put', 'delete', 'lease_grant' and 'watch'")
+	return cc
+}
+
+// clusterConfigGetCommandFunc executes the "cluster-config get" command.
+func clusterConfigGetCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("cluster-config get command accepts no arguments"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).ClusterConfigGet(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.ClusterConfig(*resp)
+}
+
+// clusterConfigProtectCommandFunc executes the "cluster-config protect" command.
+func clusterConfigProtectCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("cluster-config protect command needs a prefix and an election"))
+	}
+	writer, ok := pb.ProtectedPrefix_Writer_value[strings.ToUpper(protectWriter)]
+	if !ok {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid writer %q, expected 'leader' or 'follower'", protectWriter))
+	}
+
+	p := &pb.ProtectedPrefix{Prefix: []byte(args[0]), Election: []byte(args[1]), Writer: pb.ProtectedPrefix_Writer(writer)}
+	updateClusterConfig(cmd, func(cfg *clientv3.ClusterConfig) {
+		cfg.ProtectedPrefixes = append(removeProtectedPrefix(cfg.ProtectedPrefixes, args[0]), p)
+	})
+	fmt.Printf("Protected prefix %q with election %q\n", args[0], args[1])
+}
+
+// clusterConfigUnprotectCommandFunc executes the "cluster-config unprotect" command.
+func clusterConfigUnprotectCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("cluster-config unprotect command needs a prefix"))
+	}
+
+	updateClusterConfig(cmd, func(cfg *clientv3.ClusterConfig) {
+		cfg.ProtectedPrefixes = removeProtectedPrefix(cfg.ProtectedPrefixes, args[0])
+	})
+	fmt.Printf("Unprotected prefix %q\n", args[0])
+}
+
+// clusterConfigLeaseExpiryEventsCommandFunc executes the "cluster-config lease-expiry-events" command.
+func clusterConfigLeaseExpiryEventsCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("cluster-config lease-expiry-events command needs a prefix"))
+	}
+
+	updateClusterConfig(cmd, func(cfg *clientv3.ClusterConfig) {
+		cfg.LeaseExpiryEventPrefix = []byte(args[0])
+	})
+	if args[0] == "" {
+		fmt.Println("Stopped recording lease expiries")
+		return
+	}
+	fmt.Printf("Recording lease expiries under %q\n", args[0])
+}
+
+// clusterConfigAlarmThresholdCommandFunc executes the "cluster-config alarm-threshold" command.
+func clusterConfigAlarmThresholdCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("cluster-config alarm-threshold command needs a metric and a value"))
+	}
+	metric, ok := pb.AlarmThreshold_Metric_value[strings.ToUpper(args[0])]
+	if !ok {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid metric %q", args[0]))
+	}
+	value, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || value < 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid value %q", args[1]))
+	}
+	t := &pb.AlarmThreshold{Metric: pb.AlarmThreshold_Metric(metric), Value: value}
+	for _, b := range thresholdBlock {
+		op, ok := pb.AlarmThreshold_Operation_value[strings.ToUpper(b)]
+		if !ok {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid operation %q", b))
+		}
+		t.Block = append(t.Block, pb.AlarmThreshold_Operation(op))
+	}
+
+	updateClusterConfig(cmd, func(cfg *clientv3.ClusterConfig) {
+		var ts []*pb.AlarmThreshold
+		for _, ct := range cfg.AlarmThresholds {
+			if ct.Metric != t.Metric {
+				ts = append(ts, ct)
+			}
+		}
+		if value > 0 {
+			ts = append(ts, t)
+		}
+		cfg.AlarmThresholds = ts
+	})
+	if value == 0 {
+		fmt.Printf("Removed alarm threshold of %s\n", args[0])
+		return
+	}
+	fmt.Printf("Set alarm threshold of %s to %d\n", args[0], value)
+}
+
+// updateClusterConfig replaces the cluster configuration with the current one
+// modified by update.
+func updateClusterConfig(cmd *cobra.Command, update func(*clientv3.ClusterConfig)) {
+	cli := mustClientFromCmd(cmd)
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := cli.ClusterConfigGet(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	cfg := &clientv3.ClusterConfig{}
+	if resp.Config != nil {
+		cfg = (*clientv3.ClusterConfig)(resp.Config)
+	}
+	update(cfg)
+
+	ctx, cancel = commandCtx(cmd)
+	_, err = cli.ClusterConfigSet(ctx, cfg)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+func removeProtectedPrefix(ps []*pb.ProtectedPrefix, prefix string) []*pb.ProtectedPrefix {
+	var ret []*pb.ProtectedPrefix
+	for _, p := range ps {
+		if string(p.Prefix) != prefix {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
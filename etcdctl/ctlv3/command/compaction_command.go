@@ -35,9 +35,18 @@ func NewCompactionCommand() *cobra.Command {
 		GroupID: groupKVID,
 	}
 	cmd.Flags().BoolVar(&compactPhysical, "physical", false, "'true' to wait for compaction to physically remove all old revisions")
+	cmd.AddCommand(newCompactionHoldsCommand())
 	return cmd
 }
 
+func newCompactionHoldsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "holds",
+		Short: "Lists the compaction holds delaying auto compaction",
+		Run:   compactionHoldsCommandFunc,
+	}
+}
+
 // compactionCommandFunc executes the "compaction" command.
 func compactionCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
@@ -63,3 +72,18 @@ func compactionCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	fmt.Println("compacted revision", rev)
 }
+
+// compactionHoldsCommandFunc executes the "compaction holds" command.
+func compactionHoldsCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("compaction holds command accepts no arguments"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).CompactionHoldList(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.CompactionHolds(*resp)
+}
@@ -15,9 +15,15 @@
 package command
 
 import (
+	"context"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 func NewCompletionCommand() *cobra.Command {
@@ -62,6 +68,11 @@ PowerShell:
   # To load completions for every new session, run:
   PS> etcdctl completion powershell > etcdctl.ps1
   # and source this file from your PowerShell profile.
+
+Keys of commands like "get", "put", "del" and "watch" are completed by querying
+the cluster with a bounded, serializable, keys-only range request. Use
+--completion-keys=false (or ETCDCTL_COMPLETION_KEYS=false) to disable it, and
+--completion-timeout and --completion-limit to bound the query.
 `,
 		DisableFlagsInUseLine: true,
 		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
@@ -83,3 +94,98 @@ PowerShell:
 
 	return cmd
 }
+
+// keyCompletionFunc completes the first positional argument of key based commands
+// by querying the cluster for keys with the given prefix. Any failure results in
+// no completion rather than an error, since completion must never block the shell.
+func keyCompletionFunc(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 || authPromptRequired(cmd) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cc := clientConfigFromCmd(cmd)
+	enabled, err := cmd.Flags().GetBool("completion-keys")
+	if err != nil || !enabled {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	timeout, err := cmd.Flags().GetDuration("completion-timeout")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	limit, err := cmd.Flags().GetInt64("completion-limit")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// the dial timeout must not exceed the completion budget
+	if cc.DialTimeout == 0 || cc.DialTimeout > timeout {
+		cc.DialTimeout = timeout
+	}
+	cfg, err := clientv3.NewClientConfig(cc, zap.NewNop())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cli, err := clientv3.New(*cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer cli.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	resp, err := cli.Get(ctx, toComplete,
+		clientv3.WithPrefix(),
+		clientv3.WithKeysOnly(),
+		clientv3.WithSerializable(),
+		clientv3.WithLimit(limit),
+	)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	keys := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		keys = append(keys, string(kv.Key))
+	}
+	candidates := completeKeySegments(keys, toComplete)
+	directive := cobra.ShellCompDirectiveNoFileComp
+	for _, c := range candidates {
+		if strings.HasSuffix(c, "/") {
+			// let the user keep completing the next path segment
+			directive |= cobra.ShellCompDirectiveNoSpace
+			break
+		}
+	}
+	return candidates, directive
+}
+
+// completeKeySegments collapses the given keys to the next "/" separated segment
+// after toComplete, so that completing "/reg" offers "/registry/" instead of every
+// key below it.
+func completeKeySegments(keys []string, toComplete string) []string {
+	seen := make(map[string]struct{})
+	for _, k := range keys {
+		if !strings.HasPrefix(k, toComplete) {
+			continue
+		}
+		c := k
+		if i := strings.Index(k[len(toComplete):], "/"); i >= 0 {
+			c = k[:len(toComplete)+i+1]
+		}
+		seen[c] = struct{}{}
+	}
+	candidates := make([]string, 0, len(seen))
+	for c := range seen {
+		candidates = append(candidates, c)
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+// authPromptRequired returns true if building a client would prompt for a password,
+// which is not possible while completing.
+func authPromptRequired(cmd *cobra.Command) bool {
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	return user != "" && password == "" && !strings.Contains(user, ":")
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompleteKeySegments(t *testing.T) {
+	keys := []string{
+		"/registry/pods/default/a",
+		"/registry/pods/default/b",
+		"/registry/services/default/c",
+		"/regions",
+		"/other",
+	}
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{
+			name:       "complete first segment",
+			toComplete: "/reg",
+			want:       []string{"/regions", "/registry/"},
+		},
+		{
+			name:       "complete nested segment",
+			toComplete: "/registry/",
+			want:       []string{"/registry/pods/", "/registry/services/"},
+		},
+		{
+			name:       "complete leaf keys",
+			toComplete: "/registry/pods/default/",
+			want:       []string{"/registry/pods/default/a", "/registry/pods/default/b"},
+		},
+		{
+			name:       "no match",
+			toComplete: "/missing",
+			want:       []string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, completeKeySegments(keys, tc.toComplete))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	counterGetConsistency string
+	counterGetPrefix      bool
+	counterGetFromKey     bool
+)
+
+// NewCounterCommand returns the cobra command for "counter".
+func NewCounterCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:     "counter <subcommand>",
+		Short:   "Counter related commands",
+		GroupID: groupKVID,
+	}
+
+	cc.AddCommand(newCounterAddCommand())
+	cc.AddCommand(newCounterGetCommand())
+
+	return cc
+}
+
+func newCounterAddCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <key> <delta> [<key> <delta>...]",
+		Short: "Atomically adds deltas to one or more counters",
+		Long: `Atomically adds each delta to its counter and prints the resulting values. Either all
+the deltas are applied or, if any counter would overflow, none of them is. A counter
+that reaches zero is removed.`,
+		Run: counterAddCommandFunc,
+	}
+}
+
+func newCounterGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get [options] <key> [range_end]",
+		Short: "Gets the counter or a range of counters",
+		Run:   counterGetCommandFunc,
+	}
+	cmd.Flags().StringVar(&counterGetConsistency, "consistency", "l", "Linearizable(l) or Serializable(s)")
+	cmd.Flags().BoolVar(&counterGetPrefix, "prefix", false, "Get counters with matching prefix")
+	cmd.Flags().BoolVar(&counterGetFromKey, "from-key", false, "Get counters that are greater than or equal to the given key using byte compare")
+	return cmd
+}
+
+// counterAddCommandFunc executes the "counter add" command.
+func counterAddCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) == 0 || len(args)%2 != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("counter add command needs pairs of key and delta"))
+	}
+	deltas := make([]clientv3.CounterDelta, 0, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		delta, err := strconv.ParseInt(args[i+1], 10, 64)
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad delta %q for counter %q: %w", args[i+1], args[i], err))
+		}
+		deltas = append(deltas, clientv3.CounterDelta{Key: args[i], Delta: delta})
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).CounterAdd(ctx, deltas...)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.CounterAdd(*resp)
+}
+
+// counterGetCommandFunc executes the "counter get" command.
+func counterGetCommandFunc(cmd *cobra.Command, args []string) {
+	key, opts := getCounterGetOp(args)
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).CounterGet(ctx, key, opts...)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.CounterGet(*resp)
+}
+
+func getCounterGetOp(args []string) (string, []clientv3.OpOption) {
+	if len(args) == 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("counter get command needs one argument as key and an optional argument as range_end"))
+	}
+	if counterGetPrefix && counterGetFromKey {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("`--prefix` and `--from-key` cannot be set at the same time, choose one"))
+	}
+
+	var opts []clientv3.OpOption
+	switch counterGetConsistency {
+	case "s":
+		opts = append(opts, clientv3.WithSerializable())
+	case "l":
+	default:
+		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown consistency flag %q", counterGetConsistency))
+	}
+
+	key := args[0]
+	if len(args) > 1 {
+		if counterGetPrefix || counterGetFromKey {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("too many arguments, only accept one argument when `--prefix` or `--from-key` is set"))
+		}
+		opts = append(opts, clientv3.WithRange(args[1]))
+	}
+	if counterGetPrefix {
+		opts = append(opts, clientv3.WithPrefix())
+	}
+	if counterGetFromKey {
+		opts = append(opts, clientv3.WithFromKey())
+	}
+	return key, opts
+}
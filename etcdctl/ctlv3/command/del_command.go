@@ -30,36 +30,80 @@ var (
 	delPrevKV  bool
 	delFromKey bool
 	delRange   bool
+	delMax     int64
 )
 
 // NewDelCommand returns the cobra command for "del".
 func NewDelCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "del [options] <key> [range_end]",
-		Short:   "Removes the specified key or range of keys [key, range_end)",
-		Run:     delCommandFunc,
-		GroupID: groupKVID,
+		Use:               "del [options] <key> [range_end]",
+		Short:             "Removes the specified key or range of keys [key, range_end)",
+		Run:               delCommandFunc,
+		ValidArgsFunction: keyCompletionFunc,
+		GroupID:           groupKVID,
 	}
 
 	cmd.Flags().BoolVar(&delPrefix, "prefix", false, "delete keys with matching prefix")
 	cmd.Flags().BoolVar(&delPrevKV, "prev-kv", false, "return deleted key-value pairs")
 	cmd.Flags().BoolVar(&delFromKey, "from-key", false, "delete keys that are greater than or equal to the given key using byte compare")
 	cmd.Flags().BoolVar(&delRange, "range", false, "delete range of keys")
+	cmd.Flags().Int64Var(&delMax, "max", 0, "fail without deleting anything if more than the given number of keys would be deleted (0 means no limit)")
+	addAuditFlags(cmd, true)
 	return cmd
 }
 
 // delCommandFunc executes the "del" command.
 func delCommandFunc(cmd *cobra.Command, args []string) {
 	key, opts := getDelOp(args)
+	c := mustClientFromCmd(cmd)
+	if auditInteractive {
+		ctx, cancel := commandCtx(cmd)
+		resp, err := c.Get(ctx, key, append(opts, clientv3.WithCountOnly())...)
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		mustConfirm(fmt.Sprintf("Delete %d key(s) %s?", resp.Count, describeRange(clientv3.OpGet(key, opts...))))
+	}
+
+	undoFile := mustCreateUndoFile()
+	if undoFile != nil {
+		opts = append(opts, clientv3.WithPrevKV())
+	}
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).Delete(ctx, key, opts...)
+	resp, err := c.Delete(ctx, key, opts...)
 	cancel()
 	if err != nil {
+		discardUndoFile(undoFile)
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
+	if undoFile != nil {
+		b := undoBundle{Command: "del"}
+		for _, kv := range resp.PrevKvs {
+			b.KVs = append(b.KVs, undoKV{Key: kv.Key, Value: kv.Value, Lease: kv.Lease})
+		}
+		mustWriteUndoBundle(undoFile, b)
+		if !delPrevKV {
+			resp.PrevKvs = nil
+		}
+	}
 	display.Del(*resp)
 }
 
+// describeRange describes the keys of a range operation.
+func describeRange(op clientv3.Op) string {
+	end := op.RangeBytes()
+	switch {
+	case len(end) == 0:
+		return fmt.Sprintf("at %q", op.KeyBytes())
+	case string(end) == "\x00":
+		return fmt.Sprintf("from %q", op.KeyBytes())
+	case string(end) == clientv3.GetPrefixRangeEnd(string(op.KeyBytes())):
+		return fmt.Sprintf("with prefix %q", op.KeyBytes())
+	}
+	return fmt.Sprintf("in [%q, %q)", op.KeyBytes(), end)
+}
+
 func getDelOp(args []string) (string, []clientv3.OpOption) {
 	if len(args) == 0 || len(args) > 2 {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("del command needs one argument as key and an optional argument as range_end"))
@@ -94,6 +138,12 @@ func getDelOp(args []string) (string, []clientv3.OpOption) {
 	if delPrevKV {
 		opts = append(opts, clientv3.WithPrevKV())
 	}
+	if delMax < 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--max` must not be negative"))
+	}
+	if delMax > 0 {
+		opts = append(opts, clientv3.WithMaxDeletions(delMax))
+	}
 
 	if delFromKey {
 		if len(key) == 0 {
@@ -16,6 +16,8 @@ package command
 
 import (
 	"errors"
+	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -35,6 +37,7 @@ func NewDowngradeCommand() *cobra.Command {
 	dc.AddCommand(NewDowngradeValidateCommand())
 	dc.AddCommand(NewDowngradeEnableCommand())
 	dc.AddCommand(NewDowngradeCancelCommand())
+	dc.AddCommand(NewDowngradeStatusCommand())
 
 	return dc
 }
@@ -72,6 +75,22 @@ func NewDowngradeCancelCommand() *cobra.Command {
 	return cc
 }
 
+// NewDowngradeStatusCommand returns the cobra command for "downgrade status".
+func NewDowngradeStatusCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "status",
+		Short: "Prints the downgrade in progress and the features it blocked on each endpoint in --endpoints",
+		Long: `Prints the downgrade in progress and, for each endpoint in --endpoints, the
+fields, messages and enum values newer than the downgrade target version that
+requests to the endpoint tried to use. Such requests are rejected while the
+downgrade is in progress, as members of the target version could not apply them.
+`,
+
+		Run: downgradeStatusCommandFunc,
+	}
+	return cc
+}
+
 // downgradeValidateCommandFunc executes the "downgrade validate" command.
 func downgradeValidateCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 1 {
@@ -137,3 +156,36 @@ func downgradeCancelCommandFunc(cmd *cobra.Command, args []string) {
 
 	display.DowngradeCancel(*resp)
 }
+
+type downgradeStatus struct {
+	Ep   string                      `json:"Endpoint"`
+	Resp *clientv3.DowngradeResponse `json:"Status"`
+}
+
+// downgradeStatusCommandFunc executes the "downgrade status" command.
+func downgradeStatusCommandFunc(cmd *cobra.Command, args []string) {
+	cfg := clientConfigFromCmd(cmd)
+
+	var statusList []downgradeStatus
+	var err error
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, serr := c.Downgrade(ctx, clientv3.DowngradeStatus, "")
+		cancel()
+		c.Close()
+		if serr != nil {
+			err = serr
+			fmt.Fprintf(os.Stderr, "Failed to get the downgrade status of endpoint %s (%v)\n", ep, serr)
+			continue
+		}
+		statusList = append(statusList, downgradeStatus{Ep: ep, Resp: resp})
+	}
+
+	display.DowngradeStatus(statusList)
+
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
@@ -15,9 +15,12 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
@@ -34,6 +37,26 @@ import (
 var (
 	epClusterEndpoints bool
 	epHashKVRev        int64
+
+	epPerfDuration time.Duration
+	epPerfInterval time.Duration
+	epPerfPrefix   string
+
+	epHotKeysSortBy string
+	epHotKeysLimit  int64
+	epHotKeysReset  bool
+
+	epNewerFieldsReset bool
+
+	epDrainUndrain bool
+	epDrainWait    bool
+
+	epRequestLogDuration   time.Duration
+	epRequestLogSampleRate float64
+	epRequestLogMethods    []string
+	epRequestLogPrefixes   []string
+	epRequestLogValues     bool
+	epRequestLogStop       bool
 )
 
 // NewEndpointCommand returns the cobra command for "endpoint".
@@ -49,6 +72,13 @@ func NewEndpointCommand() *cobra.Command {
 	ec.AddCommand(newEpHealthCommand())
 	ec.AddCommand(newEpStatusCommand())
 	ec.AddCommand(newEpHashKVCommand())
+	ec.AddCommand(newEpPerfCommand())
+	ec.AddCommand(newEpMemoryCommand())
+	ec.AddCommand(newEpStorageStatsCommand())
+	ec.AddCommand(newEpHotKeysCommand())
+	ec.AddCommand(newEpNewerFieldsCommand())
+	ec.AddCommand(newEpDrainCommand())
+	ec.AddCommand(newEpRequestLogCommand())
 
 	return ec
 }
@@ -84,6 +114,118 @@ func newEpHashKVCommand() *cobra.Command {
 	return hc
 }
 
+func newEpMemoryCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "memory",
+		Short: "Prints the memory held by the key index, watchers, leases and gRPC buffers of each endpoint in --endpoints",
+		Run:   epMemoryCommandFunc,
+	}
+}
+
+func newEpStorageStatsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "storage-stats",
+		Short: "Prints the number of keys and the size of each backend bucket of each endpoint in --endpoints",
+		Long: `Prints the number of keys and the size of each backend bucket of each endpoint
+in --endpoints, as last tracked by the endpoint every --backend-bucket-stats-interval.
+`,
+		Run: epStorageStatsCommandFunc,
+	}
+}
+
+func newEpHotKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hotkeys",
+		Short: "Prints the hottest keys of the requests served by each endpoint in --endpoints",
+		Long: `Prints the hottest keys of the requests served by each endpoint in --endpoints.
+
+The endpoints must run with --hot-keys-top-k set. The reads, writes and bytes of
+each key are estimated from the requests sampled at --hot-keys-sample-rate.
+`,
+		Run: epHotKeysCommandFunc,
+	}
+	cmd.Flags().StringVar(&epHotKeysSortBy, "sort-by", "reads", "usage to sort the keys by: reads, writes, read-bytes or write-bytes")
+	cmd.Flags().Int64Var(&epHotKeysLimit, "limit", 10, "maximum number of keys printed per endpoint (0 prints all the tracked keys)")
+	cmd.Flags().BoolVar(&epHotKeysReset, "reset", false, "clear the tracked usage of each endpoint once it is printed")
+	return cmd
+}
+
+func newEpNewerFieldsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "newer-fields",
+		Short: "Prints the request fields newer than the cluster version used against each endpoint in --endpoints",
+		Long: `Prints the fields, messages and enum values newer than the cluster version used
+by the requests served by each endpoint in --endpoints.
+
+The endpoints must run with --newer-request-fields set to log or reject.
+`,
+		Run: epNewerFieldsCommandFunc,
+	}
+	cmd.Flags().BoolVar(&epNewerFieldsReset, "reset", false, "clear the fields observed by each endpoint once they are printed")
+	return cmd
+}
+
+func newEpDrainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drain",
+		Short: "Marks each endpoint in --endpoints as draining before it is restarted",
+		Long: `Marks the member of each endpoint in --endpoints as draining.
+
+A draining member transfers its leadership away, rejects new lease grants and
+watches, closes its open watches and is skipped by the endpoint sync of the
+clients. The member is ready to be stopped once it is neither leader nor serving
+watches. A restarted member clears its draining mark once it is ready.
+`,
+		Run: epDrainCommandFunc,
+	}
+	cmd.Flags().BoolVar(&epDrainUndrain, "undrain", false, "clear the draining mark of each endpoint")
+	cmd.Flags().BoolVar(&epDrainWait, "wait", false, "wait until each endpoint is ready to be stopped")
+	return cmd
+}
+
+func newEpRequestLogCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "request-log",
+		Short: "Logs a sample of the requests served by each endpoint in --endpoints for a while",
+		Long: `Makes the member of each endpoint in --endpoints log the unary requests it
+serves, at info level, until the duration elapses or the logging is stopped.
+
+The logged requests may be limited to a sample, to some methods and to the
+requests accessing keys under some prefixes. The values of the requests are
+redacted unless --log-values is set. Running the command again replaces the
+previous setting of the endpoints.
+`,
+		Run: epRequestLogCommandFunc,
+	}
+	cmd.Flags().DurationVar(&epRequestLogDuration, "duration", 5*time.Minute, "how long the requests are logged, rounded up to the second")
+	cmd.Flags().Float64Var(&epRequestLogSampleRate, "sample-rate", 0, "fraction of the matching requests logged (0 logs all of them)")
+	cmd.Flags().StringSliceVar(&epRequestLogMethods, "methods", nil, "methods of the logged requests, such as Range or /etcdserverpb.KV/Put (all methods if not set)")
+	cmd.Flags().StringSliceVar(&epRequestLogPrefixes, "prefix", nil, "prefixes of the keys of the logged requests (all keys if not set)")
+	cmd.Flags().BoolVar(&epRequestLogValues, "log-values", false, "log the values of the requests instead of redacting them")
+	cmd.Flags().BoolVar(&epRequestLogStop, "stop", false, "stop the request logging of each endpoint")
+	return cmd
+}
+
+func newEpPerfCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "perf",
+		Short: "Measures the request latencies of each endpoint in --endpoints",
+		Long: `Measures, from the location of the client, the latencies of each endpoint for:
+
+  rtt         a serializable range request served by the endpoint alone
+  read-index  a linearizable range request, which confirms the leadership with a quorum of members
+  put         a small put request, which is committed by a quorum of members
+
+The put requests write keys under --prefix, which are deleted at the end.
+`,
+		Run: epPerfCommandFunc,
+	}
+	cmd.Flags().DurationVar(&epPerfDuration, "duration", 10*time.Second, "duration of the measurement")
+	cmd.Flags().DurationVar(&epPerfInterval, "interval", 100*time.Millisecond, "interval between the probes of an endpoint")
+	cmd.Flags().StringVar(&epPerfPrefix, "prefix", "/etcdctl-endpoint-perf/", "prefix of the keys written by the put probes")
+	return cmd
+}
+
 type epHealth struct {
 	Ep     string `json:"endpoint"`
 	Health bool   `json:"health"`
@@ -148,6 +290,10 @@ func epHealthCommandFunc(cmd *cobra.Command, args []string) {
 							eh.Error = eh.Error + "NOSPACE "
 						case etcdserverpb.AlarmType_CORRUPT:
 							eh.Error = eh.Error + "CORRUPT "
+						case etcdserverpb.AlarmType_WALNOSPACE:
+							eh.Error = eh.Error + "WALNOSPACE "
+						case etcdserverpb.AlarmType_THRESHOLD:
+							eh.Error = eh.Error + "THRESHOLD "
 						default:
 							eh.Error = eh.Error + "UNKNOWN "
 						}
@@ -243,6 +389,280 @@ func epHashKVCommandFunc(cmd *cobra.Command, args []string) {
 	}
 }
 
+type epMemory struct {
+	Ep   string                        `json:"Endpoint"`
+	Resp *clientv3.MemoryStatsResponse `json:"MemoryStats"`
+}
+
+func epMemoryCommandFunc(cmd *cobra.Command, args []string) {
+	cfg := clientConfigFromCmd(cmd)
+
+	var memList []epMemory
+	var err error
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, serr := c.MemoryStats(ctx, ep)
+		cancel()
+		c.Close()
+		if serr != nil {
+			err = serr
+			fmt.Fprintf(os.Stderr, "Failed to get the memory stats of endpoint %s (%v)\n", ep, serr)
+			continue
+		}
+		memList = append(memList, epMemory{Ep: ep, Resp: resp})
+	}
+
+	display.EndpointMemory(memList)
+
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+type epStorageStats struct {
+	Ep   string                         `json:"Endpoint"`
+	Resp *clientv3.StorageStatsResponse `json:"StorageStats"`
+}
+
+func epStorageStatsCommandFunc(cmd *cobra.Command, args []string) {
+	cfg := clientConfigFromCmd(cmd)
+
+	var statsList []epStorageStats
+	var err error
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, serr := c.StorageStats(ctx, ep)
+		cancel()
+		c.Close()
+		if serr != nil {
+			err = serr
+			fmt.Fprintf(os.Stderr, "Failed to get the storage stats of endpoint %s (%v)\n", ep, serr)
+			continue
+		}
+		statsList = append(statsList, epStorageStats{Ep: ep, Resp: resp})
+	}
+
+	display.EndpointStorageStats(statsList)
+
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+type epHotKeys struct {
+	Ep   string                    `json:"Endpoint"`
+	Resp *clientv3.HotKeysResponse `json:"HotKeys"`
+}
+
+func epHotKeysCommandFunc(cmd *cobra.Command, args []string) {
+	var sortBy clientv3.HotKeysSortBy
+	switch epHotKeysSortBy {
+	case "reads":
+		sortBy = clientv3.HotKeysByReads
+	case "writes":
+		sortBy = clientv3.HotKeysByWrites
+	case "read-bytes":
+		sortBy = clientv3.HotKeysByReadBytes
+	case "write-bytes":
+		sortBy = clientv3.HotKeysByWriteBytes
+	default:
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid --sort-by %q", epHotKeysSortBy))
+	}
+	if epHotKeysLimit < 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--limit must be >=0 (set to %d)", epHotKeysLimit))
+	}
+
+	cfg := clientConfigFromCmd(cmd)
+
+	var hotList []epHotKeys
+	var err error
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, herr := c.HotKeys(ctx, ep, sortBy, epHotKeysLimit, epHotKeysReset)
+		cancel()
+		c.Close()
+		if herr != nil {
+			err = herr
+			fmt.Fprintf(os.Stderr, "Failed to get the hot keys of endpoint %s (%v)\n", ep, herr)
+			continue
+		}
+		hotList = append(hotList, epHotKeys{Ep: ep, Resp: resp})
+	}
+
+	display.EndpointHotKeys(hotList)
+
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+type epNewerFields struct {
+	Ep   string                        `json:"Endpoint"`
+	Resp *clientv3.NewerFieldsResponse `json:"NewerFields"`
+}
+
+func epNewerFieldsCommandFunc(cmd *cobra.Command, args []string) {
+	cfg := clientConfigFromCmd(cmd)
+
+	var fieldsList []epNewerFields
+	var err error
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, ferr := c.NewerFields(ctx, ep, epNewerFieldsReset)
+		cancel()
+		c.Close()
+		if ferr != nil {
+			err = ferr
+			fmt.Fprintf(os.Stderr, "Failed to get the newer fields of endpoint %s (%v)\n", ep, ferr)
+			continue
+		}
+		fieldsList = append(fieldsList, epNewerFields{Ep: ep, Resp: resp})
+	}
+
+	display.EndpointNewerFields(fieldsList)
+
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+type epPerf struct {
+	Ep     string        `json:"endpoint"`
+	Probes []epPerfProbe `json:"probes,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// epPerfProbe summarizes the latencies of the successful requests of a probe.
+type epPerfProbe struct {
+	Name   string        `json:"probe"`
+	Count  int           `json:"count"`
+	Errors int           `json:"errors"`
+	P50    time.Duration `json:"p50"`
+	P90    time.Duration `json:"p90"`
+	P99    time.Duration `json:"p99"`
+	Max    time.Duration `json:"max"`
+}
+
+func newEpPerfProbe(name string, took []time.Duration, errs int) epPerfProbe {
+	pr := epPerfProbe{Name: name, Count: len(took), Errors: errs}
+	if len(took) == 0 {
+		return pr
+	}
+	slices.Sort(took)
+	percentile := func(p float64) time.Duration {
+		return took[int(math.Ceil(p*float64(len(took))))-1]
+	}
+	pr.P50, pr.P90, pr.P99, pr.Max = percentile(0.5), percentile(0.9), percentile(0.99), took[len(took)-1]
+	return pr
+}
+
+func epPerfCommandFunc(cmd *cobra.Command, args []string) {
+	if epPerfDuration <= 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--duration must be >0 (set to %v)", epPerfDuration))
+	}
+	lg, err := logutil.CreateDefaultZapLogger(zap.InfoLevel)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	cfg := clientConfigFromCmd(cmd)
+
+	endpoints := endpointsFromCluster(cmd)
+	perfList := make([]epPerf, len(endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range endpoints {
+		epCfg := cfg.Clone()
+		epCfg.Endpoints = []string{ep}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			perfList[i] = measureEndpointPerf(cmd, lg, epCfg, fmt.Sprintf("%s%d", epPerfPrefix, i))
+		}()
+	}
+	wg.Wait()
+
+	display.EndpointPerf(perfList)
+
+	for _, p := range perfList {
+		if p.Error != "" {
+			cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to measure endpoint %s (%s)", p.Ep, p.Error))
+		}
+		for _, pr := range p.Probes {
+			if pr.Errors > 0 {
+				cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("%d %s requests to endpoint %s failed", pr.Errors, pr.Name, p.Ep))
+			}
+		}
+	}
+}
+
+func measureEndpointPerf(cmd *cobra.Command, lg *zap.Logger, cfg *clientv3.ConfigSpec, key string) epPerf {
+	ep := cfg.Endpoints[0]
+	ccfg, err := clientv3.NewClientConfig(cfg, lg)
+	if err != nil {
+		return epPerf{Ep: ep, Error: err.Error()}
+	}
+	ccfg.Logger = lg.Named("client")
+	c, err := clientv3.New(*ccfg)
+	if err != nil {
+		return epPerf{Ep: ep, Error: err.Error()}
+	}
+	defer c.Close()
+
+	probes := []struct {
+		name string
+		do   func(ctx context.Context) error
+		took []time.Duration
+		errs int
+	}{
+		{name: "rtt", do: func(ctx context.Context) error {
+			_, err := c.Get(ctx, key, clientv3.WithSerializable(), clientv3.WithCountOnly())
+			return err
+		}},
+		{name: "read-index", do: func(ctx context.Context) error {
+			_, err := c.Get(ctx, key, clientv3.WithReadConsistency(clientv3.ReadConsistencyReadIndex), clientv3.WithCountOnly())
+			return err
+		}},
+		{name: "put", do: func(ctx context.Context) error {
+			_, err := c.Put(ctx, key, "")
+			return err
+		}},
+	}
+
+	ticker := time.NewTicker(epPerfInterval)
+	defer ticker.Stop()
+	for end := time.Now().Add(epPerfDuration); time.Now().Before(end); <-ticker.C {
+		for i := range probes {
+			ctx, cancel := commandCtx(cmd)
+			st := time.Now()
+			err := probes[i].do(ctx)
+			took := time.Since(st)
+			cancel()
+			if err != nil {
+				probes[i].errs++
+				continue
+			}
+			probes[i].took = append(probes[i].took, took)
+		}
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	c.Delete(ctx, key)
+	cancel()
+
+	ret := epPerf{Ep: ep}
+	for _, pr := range probes {
+		ret.Probes = append(ret.Probes, newEpPerfProbe(pr.name, pr.took, pr.errs))
+	}
+	return ret
+}
+
 func endpointsFromCluster(cmd *cobra.Command) []string {
 	if !epClusterEndpoints {
 		endpoints, err := cmd.Flags().GetStringSlice("endpoints")
@@ -295,3 +715,87 @@ func endpointsFromCluster(cmd *cobra.Command) []string {
 	}
 	return ret
 }
+
+type epDrain struct {
+	Ep   string                        `json:"Endpoint"`
+	Resp *clientv3.DrainMemberResponse `json:"Drain"`
+}
+
+func epDrainCommandFunc(cmd *cobra.Command, args []string) {
+	cfg := clientConfigFromCmd(cmd)
+
+	var drainList []epDrain
+	var err error
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		resp, derr := drainEndpoint(cmd, c, ep)
+		c.Close()
+		if derr != nil {
+			err = derr
+			fmt.Fprintf(os.Stderr, "Failed to update the draining mark of endpoint %s (%v)\n", ep, derr)
+			continue
+		}
+		drainList = append(drainList, epDrain{Ep: ep, Resp: resp})
+	}
+
+	display.EndpointDrain(drainList)
+
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+func epRequestLogCommandFunc(cmd *cobra.Command, args []string) {
+	rl := clientv3.RequestLogging{
+		SampleRate: epRequestLogSampleRate,
+		Methods:    epRequestLogMethods,
+		Prefixes:   epRequestLogPrefixes,
+		LogValues:  epRequestLogValues,
+	}
+	if !epRequestLogStop {
+		if epRequestLogDuration <= 0 {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("request-log command needs a positive --duration, got %v", epRequestLogDuration))
+		}
+		rl.Duration = epRequestLogDuration
+	}
+	cfg := clientConfigFromCmd(cmd)
+
+	var err error
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		_, rerr := c.SetRequestLogging(ctx, ep, rl)
+		cancel()
+		c.Close()
+		if rerr != nil {
+			err = rerr
+			fmt.Fprintf(os.Stderr, "Failed to set the request logging of endpoint %s (%v)\n", ep, rerr)
+			continue
+		}
+		if epRequestLogStop {
+			fmt.Printf("Stopped the request logging of endpoint %s\n", ep)
+		} else {
+			fmt.Printf("Logging the requests of endpoint %s for %s\n", ep, rl.Duration)
+		}
+	}
+
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+// drainEndpoint updates the draining mark of the endpoint, then polls it
+// until it is ready to be stopped if --wait is set.
+func drainEndpoint(cmd *cobra.Command, c *clientv3.Client, ep string) (*clientv3.DrainMemberResponse, error) {
+	for {
+		ctx, cancel := commandCtx(cmd)
+		resp, err := c.DrainMember(ctx, ep, epDrainUndrain)
+		cancel()
+		if err != nil || !epDrainWait || epDrainUndrain || resp.Ready {
+			return resp, err
+		}
+		time.Sleep(time.Second)
+	}
+}
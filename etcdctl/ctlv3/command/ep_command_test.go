@@ -0,0 +1,42 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEpPerfProbe(t *testing.T) {
+	var took []time.Duration
+	for i := 100; i > 0; i-- {
+		took = append(took, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, epPerfProbe{
+		Name:   "put",
+		Count:  100,
+		Errors: 2,
+		P50:    50 * time.Millisecond,
+		P90:    90 * time.Millisecond,
+		P99:    99 * time.Millisecond,
+		Max:    100 * time.Millisecond,
+	}, newEpPerfProbe("put", took, 2))
+
+	assert.Equal(t, epPerfProbe{Name: "rtt", Count: 1, P50: time.Second, P90: time.Second, P99: time.Second, Max: time.Second},
+		newEpPerfProbe("rtt", []time.Duration{time.Second}, 0))
+	assert.Equal(t, epPerfProbe{Name: "rtt", Errors: 3}, newEpPerfProbe("rtt", nil, 3))
+}
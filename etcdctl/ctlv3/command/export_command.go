@@ -0,0 +1,211 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/export"
+	"go.etcd.io/etcd/etcdctl/v3/util"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var exportExample = util.Normalize(`
+	# Export all the keys at the current revision
+	etcdctl export /backup/etcd.export
+
+	# Export the keys under /config at revision 1000
+	etcdctl export --prefix=/config --rev=1000 /backup/config.export
+
+	# Restore the keys under /config/app of an export
+	etcdctl import --prefix=/config/app /backup/config.export`)
+
+var (
+	exportPrefix string
+	exportRev    int64
+
+	importPrefix    string
+	importMaxTxnOps uint
+)
+
+// NewExportCommand returns the cobra command for "export".
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [options] <filename>",
+		Short: "Exports the keys at a revision to a given file, or to stdout if the filename is '-'",
+		Long: `Exports the keys at a single revision to a file, as a logical backup that
+does not depend on the storage engine of etcd. The revision is pinned on the
+endpoint once the export starts, so that compactions do not affect it. The
+export can be restored, completely or partially, with "etcdctl import".
+`,
+		Run:     exportCommandFunc,
+		Example: exportExample,
+		GroupID: groupClusterMaintenanceID,
+	}
+	cmd.Flags().StringVar(&exportPrefix, "prefix", "", "Export only the keys with the prefix (all keys if empty)")
+	cmd.Flags().Int64Var(&exportRev, "rev", 0, "Revision to export the keys at (current revision if 0)")
+	return cmd
+}
+
+// NewImportCommand returns the cobra command for "import".
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [options] <filename>",
+		Short: "Puts the keys of an export file, or of stdin if the filename is '-'",
+		Long: `Puts the keys of a file written by "etcdctl export". The keys get new
+revisions, and are not attached to leases. Existing keys are overwritten,
+other keys are left untouched. The checksum of the file is verified before
+any key is put, unless it is read from stdin.
+`,
+		Run:     importCommandFunc,
+		Example: exportExample,
+		GroupID: groupClusterMaintenanceID,
+	}
+	cmd.Flags().StringVar(&importPrefix, "prefix", "", "Import only the keys with the prefix (all keys if empty)")
+	cmd.Flags().UintVar(&importMaxTxnOps, "max-txn-ops", defaultMaxTxnOps, "Maximum number of keys put per transaction")
+	return cmd
+}
+
+func exportCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("export expects one argument <filename>"))
+	}
+
+	// like snapshot save, export has no timeout unless "--command-timeout" is set
+	ctx, cancel := context.WithCancel(context.Background())
+	if isCommandTimeoutFlagSet(cmd) {
+		ctx, cancel = commandCtx(cmd)
+	}
+	defer cancel()
+
+	c := mustClientFromCmd(cmd)
+	defer c.Close()
+
+	opts := []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithRev(exportRev)}
+	path := args[0]
+	if path == "-" {
+		w := bufio.NewWriter(os.Stdout)
+		if _, _, err := export.Fetch(ctx, c, w, exportPrefix, opts...); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitInterrupted, err)
+		}
+		if err := w.Flush(); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitIO, err)
+		}
+		return
+	}
+	rev, count, err := export.Save(ctx, c, path, exportPrefix, opts...)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitInterrupted, err)
+	}
+	fmt.Printf("Exported %d keys at revision %d to %s\n", count, rev, path)
+}
+
+func importCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("import expects one argument <filename>"))
+	}
+	if importMaxTxnOps == 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--max-txn-ops must be positive"))
+	}
+
+	path := args[0]
+	var f io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitIO, err)
+		}
+		defer file.Close()
+		// verify the whole export first, so that a corrupted file does not
+		// get partially imported
+		if err = verifyExport(file); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitInvalidInput, err)
+		}
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitIO, err)
+		}
+		f = file
+	}
+	r, err := export.NewReader(f)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitInvalidInput, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if isCommandTimeoutFlagSet(cmd) {
+		ctx, cancel = commandCtx(cmd)
+	}
+	defer cancel()
+
+	c := mustClientFromCmd(cmd)
+	defer c.Close()
+
+	var (
+		ops   []clientv3.Op
+		count int
+	)
+	commit := func() {
+		if len(ops) == 0 {
+			return
+		}
+		if _, err := c.Txn(ctx).Then(ops...).Commit(); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("imported %d keys before failing: %w", count, err))
+		}
+		count += len(ops)
+		ops = ops[:0]
+	}
+	for {
+		kv, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitInvalidInput, fmt.Errorf("imported %d keys before failing: %w", count, err))
+		}
+		if !strings.HasPrefix(string(kv.Key), importPrefix) {
+			continue
+		}
+		ops = append(ops, clientv3.OpPut(string(kv.Key), string(kv.Value)))
+		if uint(len(ops)) >= importMaxTxnOps {
+			commit()
+		}
+	}
+	commit()
+	fmt.Printf("Imported %d keys exported at revision %d\n", count, r.Revision())
+}
+
+func verifyExport(f io.Reader) error {
+	r, err := export.NewReader(f)
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err = r.Next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	fenceTTL   time.Duration
+	fenceToken string
+	fenceLift  bool
+)
+
+// NewFenceCommand returns the cobra command for "fence".
+func NewFenceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fence [options] <prefix>",
+		Short: "Rejects the writes to a prefix for a while",
+		Long: `Makes the cluster reject the writes to the keys under the given prefix until
+the TTL elapses or the fence is lifted. Writes carrying the token of the fence
+are let through, for example the writes of a data migration.
+`,
+		Run:     fenceCommandFunc,
+		GroupID: groupClusterMaintenanceID,
+	}
+	cmd.Flags().DurationVar(&fenceTTL, "ttl", time.Minute, "How long the writes are rejected, rounded up to the second")
+	cmd.Flags().StringVar(&fenceToken, "token", "", "Token letting the writes through the fence")
+	cmd.Flags().BoolVar(&fenceLift, "lift", false, "Lifts the fence of the prefix")
+	return cmd
+}
+
+// fenceCommandFunc executes the "fence" command.
+func fenceCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("fence command needs 1 argument"))
+	}
+	prefix := args[0]
+	var ttl int64
+	if !fenceLift {
+		if fenceTTL <= 0 {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("fence command needs a positive --ttl, got %v", fenceTTL))
+		}
+		ttl = int64((fenceTTL + time.Second - 1) / time.Second)
+	}
+
+	c := mustClientFromCmd(cmd)
+	ctx, cancel := commandCtx(cmd)
+	_, err := c.FencePrefix(ctx, prefix, ttl, fenceToken)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	if fenceLift {
+		fmt.Printf("Lifted the fence of prefix %q\n", prefix)
+		return
+	}
+	fmt.Printf("Fenced prefix %q for %s\n", prefix, time.Duration(ttl)*time.Second)
+}
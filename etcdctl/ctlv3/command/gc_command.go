@@ -0,0 +1,60 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	gcEmptyLeaseMinAge time.Duration
+	gcRepair           bool
+)
+
+// NewGarbageCollectCommand returns the cobra command for "garbage-collect".
+func NewGarbageCollectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "garbage-collect [options]",
+		Short:   "Finds, and optionally repairs, orphaned keys, empty leases and stale auth tokens",
+		Run:     garbageCollectCommandFunc,
+		GroupID: groupClusterMaintenanceID,
+	}
+	cmd.Flags().DurationVar(&gcEmptyLeaseMinAge, "empty-lease-min-age", 0, "report leases without keys at least this old; 0 does not report them")
+	cmd.Flags().BoolVar(&gcRepair, "repair", false, "delete the orphaned keys, revoke the empty leases and invalidate the stale tokens found")
+	return cmd
+}
+
+// garbageCollectCommandFunc executes the "garbage-collect" command.
+func garbageCollectCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("garbage-collect command accepts no arguments"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).GarbageCollect(ctx, gcEmptyLeaseMinAge, gcRepair)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.GarbageCollect(*resp)
+	if gcRepair && !resp.Repaired {
+		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to repair all the anomalies found, see the server logs"))
+	}
+}
@@ -26,6 +26,8 @@ import (
 
 var (
 	getConsistency  string
+	getReadConsist  string
+	getMinApplied   uint64
 	getLimit        int64
 	getSortOrder    string
 	getSortTarget   string
@@ -34,6 +36,7 @@ var (
 	getRev          int64
 	getKeysOnly     bool
 	getCountOnly    bool
+	getModsSince    int64
 	printValueOnly  bool
 	getMinCreateRev int64
 	getMaxCreateRev int64
@@ -44,13 +47,16 @@ var (
 // NewGetCommand returns the cobra command for "get".
 func NewGetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "get [options] <key> [range_end]",
-		Short:   "Gets the key or a range of keys",
-		Run:     getCommandFunc,
-		GroupID: groupKVID,
+		Use:               "get [options] <key> [range_end]",
+		Short:             "Gets the key or a range of keys",
+		Run:               getCommandFunc,
+		ValidArgsFunction: keyCompletionFunc,
+		GroupID:           groupKVID,
 	}
 
 	cmd.Flags().StringVar(&getConsistency, "consistency", "l", "Linearizable(l) or Serializable(s)")
+	cmd.Flags().StringVar(&getReadConsist, "read-consistency", "", "How a linearizable read confirms it reads the latest data; read-index or lease (server default if empty)")
+	cmd.Flags().Uint64Var(&getMinApplied, "min-applied-index", 0, "Minimum raft index the member must have applied to serve a serializable read (the AppliedIndex of a previous read)")
 	cmd.Flags().StringVar(&getSortOrder, "order", "", "Order of results; ASCEND or DESCEND (ASCEND by default)")
 	cmd.Flags().StringVar(&getSortTarget, "sort-by", "", "Sort target; CREATE, KEY, MODIFY, VALUE, or VERSION")
 	cmd.Flags().Int64Var(&getLimit, "limit", 0, "Maximum number of results")
@@ -59,6 +65,7 @@ func NewGetCommand() *cobra.Command {
 	cmd.Flags().Int64Var(&getRev, "rev", 0, "Specify the kv revision")
 	cmd.Flags().BoolVar(&getKeysOnly, "keys-only", false, "Get only the keys")
 	cmd.Flags().BoolVar(&getCountOnly, "count-only", false, "Get only the count")
+	cmd.Flags().Int64Var(&getModsSince, "count-modifications-since", 0, "Get only the number of modifications in the range after the given revision")
 	cmd.Flags().BoolVar(&printValueOnly, "print-value-only", false, `Only write values when using the "simple" output format`)
 	cmd.Flags().Int64Var(&getMinCreateRev, "min-create-rev", 0, "Minimum create revision")
 	cmd.Flags().Int64Var(&getMaxCreateRev, "max-create-rev", 0, "Maximum create revision")
@@ -68,6 +75,9 @@ func NewGetCommand() *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("consistency", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"l", "s"}, cobra.ShellCompDirectiveDefault
 	})
+	cmd.RegisterFlagCompletionFunc("read-consistency", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"read-index", "lease"}, cobra.ShellCompDirectiveDefault
+	})
 	cmd.RegisterFlagCompletionFunc("order", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"ASCEND", "DESCEND"}, cobra.ShellCompDirectiveDefault
 	})
@@ -94,6 +104,12 @@ func getCommandFunc(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	if getModsSince > 0 {
+		if _, fields := display.(*fieldsPrinter); !fields {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--count-modifications-since is only for `--write-out=fields`"))
+		}
+	}
+
 	if printValueOnly {
 		dp, simple := (display).(*simplePrinter)
 		if !simple {
@@ -120,6 +136,18 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 	var opts []clientv3.OpOption
 	if IsSerializable(getConsistency) {
 		opts = append(opts, clientv3.WithSerializable())
+		opts = append(opts, clientv3.WithMinAppliedIndex(getMinApplied))
+	} else if getMinApplied > 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--min-applied-index` requires `--consistency=s`"))
+	}
+	switch getReadConsist {
+	case "":
+	case "read-index":
+		opts = append(opts, clientv3.WithReadConsistency(clientv3.ReadConsistencyReadIndex))
+	case "lease":
+		opts = append(opts, clientv3.WithReadConsistency(clientv3.ReadConsistencyLease))
+	default:
+		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown read consistency flag %q", getReadConsist))
 	}
 
 	key := args[0]
@@ -193,6 +221,10 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 		opts = append(opts, clientv3.WithCountOnly())
 	}
 
+	if getModsSince > 0 {
+		opts = append(opts, clientv3.WithCountModificationsSince(getModsSince))
+	}
+
 	if getMinCreateRev > 0 {
 		opts = append(opts, clientv3.WithMinCreateRev(getMinCreateRev))
 	}
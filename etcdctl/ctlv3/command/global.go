@@ -44,6 +44,7 @@ type GlobalFlags struct {
 	InsecureDiscovery     bool
 	Endpoints             []string
 	DialTimeout           time.Duration
+	Proxy                 string
 	CommandTimeOut        time.Duration
 	KeepAliveTime         time.Duration
 	KeepAliveTimeout      time.Duration
@@ -55,6 +56,7 @@ type GlobalFlags struct {
 
 	OutputFormat string
 	IsHex        bool
+	PrintTimings bool
 
 	User     string
 	Password string
@@ -83,6 +85,17 @@ func initDisplayFromCmd(cmd *cobra.Command) {
 	if display = NewPrinter(outputType, isHex); display == nil {
 		cobrautl.ExitWithError(cobrautl.ExitBadFeature, errors.New("unsupported output format"))
 	}
+	if printTimingsFromCmd(cmd) {
+		display = &timingsPrinter{display}
+	}
+}
+
+func printTimingsFromCmd(cmd *cobra.Command) bool {
+	printTimings, err := cmd.Flags().GetBool("print-timings")
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	return printTimings
 }
 
 type discardValue struct{}
@@ -129,6 +142,7 @@ func clientConfigFromCmd(cmd *cobra.Command) *clientv3.ConfigSpec {
 	}
 
 	cfg.DialTimeout = dialTimeoutFromCmd(cmd)
+	cfg.Proxy = proxyFromCmd(cmd)
 	cfg.KeepAliveTime = keepAliveTimeFromCmd(cmd)
 	cfg.KeepAliveTimeout = keepAliveTimeoutFromCmd(cmd)
 	cfg.MaxCallSendMsgSize = maxCallSendMsgSizeFromCmd(cmd)
@@ -190,6 +204,14 @@ func dialTimeoutFromCmd(cmd *cobra.Command) time.Duration {
 	return dialTimeout
 }
 
+func proxyFromCmd(cmd *cobra.Command) string {
+	proxy, err := cmd.Flags().GetString("proxy")
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	return proxy
+}
+
 func keepAliveTimeFromCmd(cmd *cobra.Command) time.Duration {
 	keepAliveTime, err := cmd.Flags().GetDuration("keepalive-time")
 	if err != nil {
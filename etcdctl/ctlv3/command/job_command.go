@@ -0,0 +1,148 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+// NewJobCommand returns the cobra command for "job".
+func NewJobCommand() *cobra.Command {
+	jc := &cobra.Command{
+		Use:     "job <subcommand>",
+		Short:   "Job related commands",
+		GroupID: groupClusterMaintenanceID,
+	}
+
+	jc.AddCommand(newJobListCommand())
+	jc.AddCommand(newJobStatusCommand())
+	jc.AddCommand(newJobCancelCommand())
+
+	return jc
+}
+
+func newJobListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the running and the latest ended maintenance jobs of each endpoint",
+		Run:   jobListCommandFunc,
+	}
+}
+
+func newJobStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <job ID>",
+		Short: "Prints the maintenance job of the given ID of the endpoint",
+		Run:   jobStatusCommandFunc,
+	}
+}
+
+func newJobCancelCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <job ID>",
+		Short: "Cancels the running maintenance job of the given ID of the endpoint",
+		Run:   jobCancelCommandFunc,
+	}
+}
+
+type epJobs struct {
+	Ep   string                    `json:"Endpoint"`
+	Resp *clientv3.JobListResponse `json:"Jobs"`
+}
+
+// jobListCommandFunc executes the "job list" command.
+func jobListCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("job list command accepts no arguments"))
+	}
+
+	cfg := clientConfigFromCmd(cmd)
+
+	var jobList []epJobs
+	var err error
+	for _, ep := range endpointsFromCluster(cmd) {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, jerr := c.JobList(ctx, ep)
+		cancel()
+		c.Close()
+		if jerr != nil {
+			err = jerr
+			fmt.Fprintf(os.Stderr, "Failed to list the jobs of endpoint %s (%v)\n", ep, jerr)
+			continue
+		}
+		jobList = append(jobList, epJobs{Ep: ep, Resp: resp})
+	}
+
+	display.JobList(jobList)
+
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+// jobStatusCommandFunc executes the "job status" command.
+func jobStatusCommandFunc(cmd *cobra.Command, args []string) {
+	ep, id := jobEndpointAndID(cmd, args, "status")
+
+	c := mustClientFromCmd(cmd)
+	ctx, cancel := commandCtx(cmd)
+	resp, err := c.JobStatus(ctx, ep, id)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.JobStatus(*resp)
+}
+
+// jobCancelCommandFunc executes the "job cancel" command.
+func jobCancelCommandFunc(cmd *cobra.Command, args []string) {
+	ep, id := jobEndpointAndID(cmd, args, "cancel")
+
+	c := mustClientFromCmd(cmd)
+	ctx, cancel := commandCtx(cmd)
+	resp, err := c.JobCancel(ctx, ep, id)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.JobCancel(*resp)
+}
+
+// jobEndpointAndID returns the single endpoint and the job ID the job
+// command applies to. Job IDs are local to a member, so exactly one
+// endpoint must be given.
+func jobEndpointAndID(cmd *cobra.Command, args []string, name string) (string, int64) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("job %s command needs 1 argument", name))
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad job ID %q (%v)", args[0], err))
+	}
+	eps := clientConfigFromCmd(cmd).Endpoints
+	if len(eps) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("job %s command needs exactly 1 endpoint, as job IDs are local to a member (got %d)", name, len(eps)))
+	}
+	return eps[0], id
+}
@@ -83,7 +83,7 @@ func NewLeaseRevokeCommand() *cobra.Command {
 
 		Run: leaseRevokeCommandFunc,
 	}
-
+	addAuditFlags(lc, true)
 	return lc
 }
 
@@ -94,15 +94,55 @@ func leaseRevokeCommandFunc(cmd *cobra.Command, args []string) {
 	}
 
 	id := leaseFromArgs(args[0])
+	c := mustClientFromCmd(cmd)
+	var b undoBundle
+	if auditInteractive || auditUndoFile != "" {
+		ctx, cancel := commandCtx(cmd)
+		var err error
+		b, err = leaseUndoBundle(ctx, c, id)
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		mustConfirm(fmt.Sprintf("Revoke lease %016x and delete its %d attached key(s)?", id, len(b.KVs)))
+	}
+
+	undoFile := mustCreateUndoFile()
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).Revoke(ctx, id)
+	resp, err := c.Revoke(ctx, id)
 	cancel()
 	if err != nil {
+		discardUndoFile(undoFile)
 		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to revoke lease (%w)", err))
 	}
+	mustWriteUndoBundle(undoFile, b)
 	display.Revoke(id, *resp)
 }
 
+// leaseUndoBundle returns the undo bundle of revoking the lease: the lease
+// and its attached keys.
+func leaseUndoBundle(ctx context.Context, c *v3.Client, id v3.LeaseID) (undoBundle, error) {
+	b := undoBundle{Command: "lease revoke"}
+	ttl, err := c.TimeToLive(ctx, id, v3.WithAttachedKeys())
+	if err != nil {
+		return b, err
+	}
+	if ttl.TTL == -1 {
+		return b, fmt.Errorf("lease %016x not found", id)
+	}
+	b.Leases = []undoLease{{ID: int64(id), TTL: ttl.GrantedTTL}}
+	for _, key := range ttl.Keys {
+		resp, err := c.Get(ctx, string(key))
+		if err != nil {
+			return b, err
+		}
+		for _, kv := range resp.Kvs {
+			b.KVs = append(b.KVs, undoKV{Key: kv.Key, Value: kv.Value, Lease: kv.Lease})
+		}
+	}
+	return b, nil
+}
+
 var timeToLiveKeys bool
 
 // NewLeaseTimeToLiveCommand returns the cobra command for "lease timetolive".
@@ -109,6 +109,7 @@ func makeMirrorCommandFunc(cmd *cobra.Command, args []string) {
 	}
 
 	dialTimeout := dialTimeoutFromCmd(cmd)
+	proxy := proxyFromCmd(cmd)
 	keepAliveTime := keepAliveTimeFromCmd(cmd)
 	keepAliveTimeout := keepAliveTimeoutFromCmd(cmd)
 	maxCallSendMsgSize := maxCallSendMsgSizeFromCmd(cmd)
@@ -125,6 +126,7 @@ func makeMirrorCommandFunc(cmd *cobra.Command, args []string) {
 	cc := &clientv3.ConfigSpec{
 		Endpoints:          []string{args[0]},
 		DialTimeout:        dialTimeout,
+		Proxy:              proxy,
 		KeepAliveTime:      keepAliveTime,
 		KeepAliveTimeout:   keepAliveTimeout,
 		MaxCallSendMsgSize: maxCallSendMsgSize,
@@ -29,7 +29,12 @@ import (
 var (
 	memberPeerURLs    string
 	isLearner         bool
+	isStandby         bool
+	memberAddDryRun   bool
 	memberConsistency string
+	memberReplaceID   string
+	memberLabels      string
+	memberLeadership  string
 )
 
 // NewMemberCommand returns the cobra command for "member".
@@ -61,6 +66,8 @@ func NewMemberAddCommand() *cobra.Command {
 
 	cc.Flags().StringVar(&memberPeerURLs, "peer-urls", "", "comma separated peer URLs for the new member.")
 	cc.Flags().BoolVar(&isLearner, "learner", false, "indicates if the new member is raft learner")
+	cc.Flags().BoolVar(&isStandby, "standby", false, "indicates if the new member is a warm standby that replicates as raft learner but never serves client requests")
+	cc.Flags().BoolVar(&memberAddDryRun, "dry-run", false, "validate the member add and report its impact on the quorum without adding the member")
 
 	return cc
 }
@@ -73,7 +80,7 @@ func NewMemberRemoveCommand() *cobra.Command {
 
 		Run: memberRemoveCommandFunc,
 	}
-
+	addAuditFlags(cc, true)
 	return cc
 }
 
@@ -87,6 +94,8 @@ func NewMemberUpdateCommand() *cobra.Command {
 	}
 
 	cc.Flags().StringVar(&memberPeerURLs, "peer-urls", "", "comma separated peer URLs for the updated member.")
+	cc.Flags().StringVar(&memberLabels, "labels", "", "comma separated key=value labels replacing all labels of the member, e.g. zone=us-east-1a,rack=r1. Empty clears the labels.")
+	cc.Flags().StringVar(&memberLeadership, "raft-leadership", "", "leadership eligibility of the member, one of: allowed|disallowed. A disallowed member transfers its leadership whenever it wins an election.")
 
 	return cc
 }
@@ -98,6 +107,7 @@ func NewMemberListCommand() *cobra.Command {
 		Short: "Lists all members in the cluster",
 		Long: `When --write-out is set to simple, this command prints out comma-separated member lists for each endpoint.
 The items in the lists are ID, Status, Name, Peer Addrs, Client Addrs, Is Learner.
+Member labels and leadership eligibility are printed with --write-out=fields or --write-out=json.
 `,
 
 		Run: memberListCommandFunc,
@@ -114,11 +124,17 @@ func NewMemberPromoteCommand() *cobra.Command {
 		Use:   "promote <memberID>",
 		Short: "Promotes a non-voting member in the cluster",
 		Long: `Promotes a non-voting learner member to a voting one in the cluster.
+
+When --replace is set, the voting member with the given ID is removed in the
+same configuration change as the promotion, so that a warm standby member can
+take over the slot of a failed member in a single request.
 `,
 
 		Run: memberPromoteCommandFunc,
 	}
 
+	cc.Flags().StringVar(&memberReplaceID, "replace", "", "ID (in Hex) of the voting member to remove along with the promotion")
+
 	return cc
 }
 
@@ -149,9 +165,14 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 		resp *clientv3.MemberAddResponse
 		err  error
 	)
-	if isLearner {
+	switch {
+	case memberAddDryRun:
+		resp, err = cli.MemberAddDryRun(ctx, urls, isLearner || isStandby)
+	case isStandby:
+		resp, err = cli.MemberAddAsStandby(ctx, urls)
+	case isLearner:
 		resp, err = cli.MemberAddAsLearner(ctx, urls)
-	} else {
+	default:
 		resp, err = cli.MemberAdd(ctx, urls)
 	}
 	cancel()
@@ -162,7 +183,7 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 
 	display.MemberAdd(*resp)
 
-	if _, ok := (display).(*simplePrinter); ok {
+	if _, ok := (display).(*simplePrinter); ok && !memberAddDryRun {
 		var conf []string
 		for _, memb := range resp.Members {
 			for _, u := range memb.PeerURLs {
@@ -193,12 +214,36 @@ func memberRemoveCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
 	}
 
+	c := mustClientFromCmd(cmd)
+	var b undoBundle
+	if auditInteractive || auditUndoFile != "" {
+		ctx, cancel := commandCtx(cmd)
+		list, err := c.MemberList(ctx)
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		for _, m := range list.Members {
+			if m.ID == id {
+				b = undoBundle{Command: "member remove", Member: &undoMember{ID: m.ID, Name: m.Name, PeerURLs: m.PeerURLs, IsLearner: m.IsLearner}}
+			}
+		}
+		if b.Member == nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("member %x not found", id))
+		}
+		mustConfirm(fmt.Sprintf("Remove member %s (%x, peer URLs %s), leaving %d member(s)?",
+			b.Member.Name, id, strings.Join(b.Member.PeerURLs, ","), len(list.Members)-1))
+	}
+
+	undoFile := mustCreateUndoFile()
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).MemberRemove(ctx, id)
+	resp, err := c.MemberRemove(ctx, id)
 	cancel()
 	if err != nil {
+		discardUndoFile(undoFile)
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
+	mustWriteUndoBundle(undoFile, b)
 	display.MemberRemove(id, *resp)
 }
 
@@ -213,22 +258,71 @@ func memberUpdateCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
 	}
 
-	if len(memberPeerURLs) == 0 {
+	updateLabels := cmd.Flags().Changed("labels")
+	updateLeadership := cmd.Flags().Changed("raft-leadership")
+	if len(memberPeerURLs) == 0 && !updateLabels && !updateLeadership {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member peer urls not provided"))
 	}
+	if updateLeadership && memberLeadership != "allowed" && memberLeadership != "disallowed" {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("invalid --raft-leadership %q, expecting allowed or disallowed", memberLeadership))
+	}
+	var labels map[string]string
+	if updateLabels {
+		if labels, err = parseMemberLabels(memberLabels); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		}
+	}
 
-	urls := strings.Split(memberPeerURLs, ",")
-
-	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).MemberUpdate(ctx, id, urls)
-	cancel()
-	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	cli := mustClientFromCmd(cmd)
+	var resp *clientv3.MemberUpdateResponse
+	if len(memberPeerURLs) != 0 {
+		urls := strings.Split(memberPeerURLs, ",")
+		ctx, cancel := commandCtx(cmd)
+		resp, err = cli.MemberUpdate(ctx, id, urls)
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+	}
+	if updateLabels {
+		ctx, cancel := commandCtx(cmd)
+		resp, err = cli.MemberUpdateLabels(ctx, id, labels)
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+	}
+	if updateLeadership {
+		ctx, cancel := commandCtx(cmd)
+		resp, err = cli.MemberUpdateLeadership(ctx, id, memberLeadership == "disallowed")
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
 	}
 
 	display.MemberUpdate(id, *resp)
 }
 
+// parseMemberLabels parses comma separated key=value labels.
+func parseMemberLabels(s string) (map[string]string, error) {
+	labels := make(map[string]string)
+	if s == "" {
+		return labels, nil
+	}
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid member label %q, expecting key=value", kv)
+		}
+		if _, dup := labels[k]; dup {
+			return nil, fmt.Errorf("duplicate member label %q", k)
+		}
+		labels[k] = v
+	}
+	return labels, nil
+}
+
 // memberListCommandFunc executes the "member list" command.
 func memberListCommandFunc(cmd *cobra.Command, args []string) {
 	var opts []clientv3.OpOption
@@ -256,8 +350,16 @@ func memberPromoteCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
 	}
 
+	var replaceID uint64
+	if memberReplaceID != "" {
+		replaceID, err = strconv.ParseUint(memberReplaceID, 16, 64)
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad replaced member ID arg (%w), expecting ID in Hex", err))
+		}
+	}
+
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).MemberPromote(ctx, id)
+	resp, err := mustClientFromCmd(cmd).MemberPromoteReplacing(ctx, id, replaceID)
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
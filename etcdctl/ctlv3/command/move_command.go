@@ -0,0 +1,297 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+// maxRenameRetries is the number of times in a row a batch is retried when
+// its keys change while it is being moved.
+const maxRenameRetries = 10
+
+var (
+	renameBatch       int
+	renameProgressKey string
+
+	errRenameConflict = errors.New("keys changed while being moved")
+)
+
+// NewMoveCommand returns the cobra command for "move".
+func NewMoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "move <key> <new-key>",
+		Short:             "Atomically moves a key to a new key, keeping its value and lease",
+		Run:               moveCommandFunc,
+		ValidArgsFunction: keyCompletionFunc,
+		GroupID:           groupKVID,
+	}
+}
+
+// NewRenamePrefixCommand returns the cobra command for "rename-prefix".
+func NewRenamePrefixCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename-prefix <prefix> <new-prefix>",
+		Short: "Moves the keys with a prefix to a new prefix, keeping their values and leases",
+		Long: `Moves the keys with a prefix to a new prefix in batches. Each batch is moved
+atomically by a transaction, which fails if the keys of the batch changed or
+if any of the new keys exists.
+
+The progress of the rename is stored at --progress-key, so that an interrupted
+rename is resumed by running the same command again. The progress key is
+deleted once all the keys are moved.
+`,
+		Run:               renamePrefixCommandFunc,
+		ValidArgsFunction: keyCompletionFunc,
+		GroupID:           groupKVID,
+	}
+	cmd.Flags().IntVar(&renameBatch, "batch", 128, "maximum number of keys moved by each transaction; halved if the server rejects the transaction as too large")
+	cmd.Flags().StringVar(&renameProgressKey, "progress-key", "/etcdctl/rename-prefix/progress", "key storing the progress of the rename")
+	return cmd
+}
+
+func moveCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("move command needs a key and a new key as arguments"))
+	}
+	if args[0] == args[1] {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("the new key must differ from the key"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	err := moveKey(ctx, mustClientFromCmd(cmd), args[0], args[1])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	fmt.Printf("moved %q to %q\n", args[0], args[1])
+}
+
+func moveKey(ctx context.Context, c *clientv3.Client, key, newKey string) error {
+	resp, err := c.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if len(resp.Kvs) == 0 {
+		return fmt.Errorf("key %q not found", key)
+	}
+	kv := resp.Kvs[0]
+	txn, err := c.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(key), "=", kv.ModRevision),
+		clientv3.Compare(clientv3.CreateRevision(newKey), "=", 0),
+	).Then(
+		clientv3.OpPut(newKey, string(kv.Value), clientv3.WithLease(clientv3.LeaseID(kv.Lease))),
+		clientv3.OpDelete(key),
+	).Else(
+		clientv3.OpGet(newKey, clientv3.WithCountOnly()),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !txn.Succeeded {
+		if txn.Responses[0].GetResponseRange().Count > 0 {
+			return fmt.Errorf("key %q already exists", newKey)
+		}
+		return fmt.Errorf("key %q changed while being moved", key)
+	}
+	return nil
+}
+
+// renameProgress is stored at the progress key while a rename is in
+// progress.
+type renameProgress struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Moved int64  `json:"moved"`
+}
+
+func renamePrefixCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("rename-prefix command needs a prefix and a new prefix as arguments"))
+	}
+	from, to := args[0], args[1]
+	if err := validateRename(from, to, renameProgressKey); err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	if renameBatch <= 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--batch must be positive"))
+	}
+
+	c := mustClientFromCmd(cmd)
+	ctx, cancel := commandCtx(cmd)
+	progress, progressRev, err := getRenameProgress(ctx, c, renameProgressKey)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	switch {
+	case progress == nil:
+		progress = &renameProgress{From: from, To: to}
+	case progress.From != from || progress.To != to:
+		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("%q holds the progress of the rename of %q to %q; finish it or use another --progress-key", renameProgressKey, progress.From, progress.To))
+	default:
+		fmt.Printf("resuming the rename of %q to %q after %d keys\n", from, to, progress.Moved)
+	}
+
+	batch, retries := renameBatch, 0
+	for {
+		ctx, cancel = commandCtx(cmd)
+		n, rev, err := renameNextBatch(ctx, c, progress, renameProgressKey, progressRev, batch)
+		cancel()
+		switch {
+		case errors.Is(err, rpctypes.ErrTooManyOps) && batch > 1:
+			batch /= 2
+			continue
+		case (errors.Is(err, errRenameConflict) || errors.Is(err, rpctypes.ErrLeaseNotFound)) && retries < maxRenameRetries:
+			retries++
+			continue
+		case err != nil:
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		if n == 0 {
+			break
+		}
+		progress.Moved += int64(n)
+		progressRev = rev
+		retries = 0
+	}
+
+	if progressRev != 0 {
+		ctx, cancel = commandCtx(cmd)
+		_, err = c.Txn(ctx).If(
+			clientv3.Compare(clientv3.ModRevision(renameProgressKey), "=", progressRev),
+		).Then(
+			clientv3.OpDelete(renameProgressKey),
+		).Commit()
+		cancel()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+	}
+	fmt.Printf("moved %d keys from %q to %q\n", progress.Moved, from, to)
+}
+
+// validateRename checks that the keys moved to the new prefix cannot be
+// moved again and that the progress key is not moved.
+func validateRename(from, to, progressKey string) error {
+	switch {
+	case from == "":
+		return errors.New("the prefix must not be empty")
+	case strings.HasPrefix(to, from) || strings.HasPrefix(from, to):
+		return errors.New("neither prefix may be a prefix of the other")
+	case progressKey == "":
+		return errors.New("--progress-key must not be empty")
+	case strings.HasPrefix(progressKey, from) || strings.HasPrefix(progressKey, to):
+		return errors.New("--progress-key must not have either prefix")
+	}
+	return nil
+}
+
+// getRenameProgress returns the progress stored at the progress key and its
+// mod revision, or nil and 0 if no rename is in progress.
+func getRenameProgress(ctx context.Context, c *clientv3.Client, key string) (*renameProgress, int64, error) {
+	resp, err := c.Get(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, 0, nil
+	}
+	var progress renameProgress
+	if err = json.Unmarshal(resp.Kvs[0].Value, &progress); err != nil {
+		return nil, 0, fmt.Errorf("invalid rename progress at %q: %w", key, err)
+	}
+	return &progress, resp.Kvs[0].ModRevision, nil
+}
+
+// renameNextBatch moves up to batch keys with the prefix and updates the
+// progress key in the same transaction. It returns the number of keys moved
+// and the revision of the transaction.
+func renameNextBatch(ctx context.Context, c *clientv3.Client, progress *renameProgress, progressKey string, progressRev int64, batch int) (int, int64, error) {
+	resp, err := c.Get(ctx, progress.From, clientv3.WithPrefix(), clientv3.WithLimit(int64(batch)))
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, 0, nil
+	}
+	rev := resp.Header.Revision
+	first, end := string(resp.Kvs[0].Key), string(resp.Kvs[len(resp.Kvs)-1].Key)+"\x00"
+	newFirst, newEnd := renamedKey(first, progress.From, progress.To), renamedKey(end, progress.From, progress.To)
+
+	dst, err := c.Get(ctx, newFirst, clientv3.WithRange(newEnd), clientv3.WithRev(rev), clientv3.WithKeysOnly())
+	if err != nil {
+		return 0, 0, err
+	}
+	existing := make(map[string]bool, len(dst.Kvs))
+	for _, kv := range dst.Kvs {
+		existing[string(kv.Key)] = true
+	}
+
+	// nothing may change in the source and destination ranges of the batch
+	// since they were read, and the keys of the batch must still exist
+	cmps := []clientv3.Cmp{
+		clientv3.Compare(clientv3.ModRevision(progressKey), "=", progressRev),
+		clientv3.Compare(clientv3.ModRevision(first).WithRange(end), "<", rev+1),
+		clientv3.Compare(clientv3.ModRevision(newFirst).WithRange(newEnd), "<", rev+1),
+	}
+	ops := make([]clientv3.Op, 0, len(resp.Kvs)+2)
+	for _, kv := range resp.Kvs {
+		newKey := renamedKey(string(kv.Key), progress.From, progress.To)
+		if existing[newKey] {
+			return 0, 0, fmt.Errorf("key %q already exists", newKey)
+		}
+		cmps = append(cmps, clientv3.Compare(clientv3.ModRevision(string(kv.Key)), "=", kv.ModRevision))
+		ops = append(ops, clientv3.OpPut(newKey, string(kv.Value), clientv3.WithLease(clientv3.LeaseID(kv.Lease))))
+	}
+	ops = append(ops, clientv3.OpDelete(first, clientv3.WithRange(end)))
+
+	next := *progress
+	next.Moved += int64(len(resp.Kvs))
+	value, err := json.Marshal(next)
+	if err != nil {
+		return 0, 0, err
+	}
+	ops = append(ops, clientv3.OpPut(progressKey, string(value)))
+
+	txn, err := c.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	if err != nil {
+		return 0, 0, err
+	}
+	if !txn.Succeeded {
+		if progressRev != 0 {
+			// another rename may have updated the progress
+			if _, rev, err := getRenameProgress(ctx, c, progressKey); err == nil && rev != progressRev {
+				return 0, 0, fmt.Errorf("%q was updated by another rename", progressKey)
+			}
+		}
+		return 0, 0, errRenameConflict
+	}
+	return len(resp.Kvs), txn.Header.Revision, nil
+}
+
+func renamedKey(key, from, to string) string {
+	return to + strings.TrimPrefix(key, from)
+}
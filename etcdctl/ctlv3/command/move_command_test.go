@@ -0,0 +1,46 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRename(t *testing.T) {
+	tests := []struct {
+		from, to, progressKey string
+		wantErr               bool
+	}{
+		{from: "/old/", to: "/new/", progressKey: "/progress"},
+		{from: "", to: "/new/", progressKey: "/progress", wantErr: true},
+		{from: "/a/", to: "/a/b/", progressKey: "/progress", wantErr: true},
+		{from: "/a/b/", to: "/a/", progressKey: "/progress", wantErr: true},
+		{from: "/old/", to: "/new/", progressKey: "", wantErr: true},
+		{from: "/old/", to: "/new/", progressKey: "/old/progress", wantErr: true},
+		{from: "/old/", to: "/new/", progressKey: "/new/progress", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := validateRename(tt.from, tt.to, tt.progressKey)
+		assert.Equalf(t, tt.wantErr, err != nil, "validateRename(%q, %q, %q) = %v", tt.from, tt.to, tt.progressKey, err)
+	}
+}
+
+func TestRenamedKey(t *testing.T) {
+	assert.Equal(t, "/new/a", renamedKey("/old/a", "/old/", "/new/"))
+	assert.Equal(t, "/new/", renamedKey("/old/", "/old/", "/new/"))
+	assert.Equal(t, "/new/a\x00", renamedKey("/old/a\x00", "/old/", "/new/"))
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 
@@ -34,6 +35,9 @@ type printer interface {
 	Txn(v3.TxnResponse)
 	Watch(v3.WatchResponse)
 
+	CounterAdd(v3.CounterAddResponse)
+	CounterGet(v3.CounterGetResponse)
+
 	Grant(r v3.LeaseGrantResponse)
 	Revoke(id v3.LeaseID, r v3.LeaseRevokeResponse)
 	KeepAlive(r v3.LeaseKeepAliveResponse)
@@ -45,17 +49,30 @@ type printer interface {
 	MemberUpdate(id uint64, r v3.MemberUpdateResponse)
 	MemberPromote(id uint64, r v3.MemberPromoteResponse)
 	MemberList(v3.MemberListResponse)
+	ClusterConfig(v3.ClusterConfigGetResponse)
 
 	EndpointHealth([]epHealth)
 	EndpointStatus([]epStatus)
 	EndpointHashKV([]epHashKV)
+	EndpointPerf([]epPerf)
+	EndpointMemory([]epMemory)
+	EndpointStorageStats([]epStorageStats)
+	EndpointHotKeys([]epHotKeys)
+	EndpointNewerFields([]epNewerFields)
+	EndpointDrain([]epDrain)
 	MoveLeader(leader, target uint64, r v3.MoveLeaderResponse)
 
 	DowngradeValidate(r v3.DowngradeResponse)
 	DowngradeEnable(r v3.DowngradeResponse)
 	DowngradeCancel(r v3.DowngradeResponse)
+	DowngradeStatus([]downgradeStatus)
 
 	Alarm(v3.AlarmResponse)
+	CompactionHolds(v3.CompactionHoldListResponse)
+	GarbageCollect(v3.GarbageCollectResponse)
+	JobList([]epJobs)
+	JobStatus(v3.JobStatusResponse)
+	JobCancel(v3.JobCancelResponse)
 
 	RoleAdd(role string, r v3.AuthRoleAddResponse)
 	RoleGet(role string, r v3.AuthRoleGetResponse)
@@ -63,11 +80,14 @@ type printer interface {
 	RoleList(v3.AuthRoleListResponse)
 	RoleGrantPermission(role string, r v3.AuthRoleGrantPermissionResponse)
 	RoleRevokePermission(role string, key string, end string, r v3.AuthRoleRevokePermissionResponse)
+	RoleGrantRPCPermission(role string, method string, r v3.AuthRoleGrantRPCPermissionResponse)
+	RoleRevokeRPCPermission(role string, method string, r v3.AuthRoleRevokeRPCPermissionResponse)
 
 	UserAdd(user string, r v3.AuthUserAddResponse)
 	UserGet(user string, r v3.AuthUserGetResponse)
 	UserList(r v3.AuthUserListResponse)
 	UserChangePassword(v3.AuthUserChangePasswordResponse)
+	UserRotatePassword(string, v3.AuthUserRotatePasswordResponse)
 	UserGrantRole(user string, role string, r v3.AuthUserGrantRoleResponse)
 	UserRevokeRole(user string, role string, r v3.AuthUserRevokeRoleResponse)
 	UserDelete(user string, r v3.AuthUserDeleteResponse)
@@ -102,6 +122,9 @@ func (p *printerRPC) Put(r v3.PutResponse)     { p.p((*pb.PutResponse)(&r)) }
 func (p *printerRPC) Txn(r v3.TxnResponse)     { p.p((*pb.TxnResponse)(&r)) }
 func (p *printerRPC) Watch(r v3.WatchResponse) { p.p(&r) }
 
+func (p *printerRPC) CounterAdd(r v3.CounterAddResponse) { p.p((*pb.CounterAddResponse)(&r)) }
+func (p *printerRPC) CounterGet(r v3.CounterGetResponse) { p.p((*pb.CounterGetResponse)(&r)) }
+
 func (p *printerRPC) Grant(r v3.LeaseGrantResponse)                      { p.p(r) }
 func (p *printerRPC) Revoke(id v3.LeaseID, r v3.LeaseRevokeResponse)     { p.p(r) }
 func (p *printerRPC) KeepAlive(r v3.LeaseKeepAliveResponse)              { p.p(r) }
@@ -122,6 +145,17 @@ func (p *printerRPC) MemberPromote(id uint64, r v3.MemberPromoteResponse) {
 }
 func (p *printerRPC) MemberList(r v3.MemberListResponse) { p.p((*pb.MemberListResponse)(&r)) }
 func (p *printerRPC) Alarm(r v3.AlarmResponse)           { p.p((*pb.AlarmResponse)(&r)) }
+func (p *printerRPC) ClusterConfig(r v3.ClusterConfigGetResponse) {
+	p.p((*pb.ClusterConfigGetResponse)(&r))
+}
+func (p *printerRPC) CompactionHolds(r v3.CompactionHoldListResponse) {
+	p.p((*pb.CompactionHoldListResponse)(&r))
+}
+func (p *printerRPC) GarbageCollect(r v3.GarbageCollectResponse) {
+	p.p((*pb.GarbageCollectResponse)(&r))
+}
+func (p *printerRPC) JobStatus(r v3.JobStatusResponse) { p.p((*pb.JobStatusResponse)(&r)) }
+func (p *printerRPC) JobCancel(r v3.JobCancelResponse) { p.p((*pb.JobCancelResponse)(&r)) }
 func (p *printerRPC) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) {
 	p.p((*pb.MoveLeaderResponse)(&r))
 }
@@ -141,6 +175,14 @@ func (p *printerRPC) RoleGrantPermission(_ string, r v3.AuthRoleGrantPermissionR
 func (p *printerRPC) RoleRevokePermission(_ string, _ string, _ string, r v3.AuthRoleRevokePermissionResponse) {
 	p.p((*pb.AuthRoleRevokePermissionResponse)(&r))
 }
+
+func (p *printerRPC) RoleGrantRPCPermission(_ string, _ string, r v3.AuthRoleGrantRPCPermissionResponse) {
+	p.p((*pb.AuthRoleGrantRPCPermissionResponse)(&r))
+}
+
+func (p *printerRPC) RoleRevokeRPCPermission(_ string, _ string, r v3.AuthRoleRevokeRPCPermissionResponse) {
+	p.p((*pb.AuthRoleRevokeRPCPermissionResponse)(&r))
+}
 func (p *printerRPC) UserAdd(_ string, r v3.AuthUserAddResponse) { p.p((*pb.AuthUserAddResponse)(&r)) }
 func (p *printerRPC) UserGet(_ string, r v3.AuthUserGetResponse) { p.p((*pb.AuthUserGetResponse)(&r)) }
 func (p *printerRPC) UserList(r v3.AuthUserListResponse)         { p.p((*pb.AuthUserListResponse)(&r)) }
@@ -148,6 +190,10 @@ func (p *printerRPC) UserChangePassword(r v3.AuthUserChangePasswordResponse) {
 	p.p((*pb.AuthUserChangePasswordResponse)(&r))
 }
 
+func (p *printerRPC) UserRotatePassword(_ string, r v3.AuthUserRotatePasswordResponse) {
+	p.p((*pb.AuthUserRotatePasswordResponse)(&r))
+}
+
 func (p *printerRPC) UserGrantRole(_ string, _ string, r v3.AuthUserGrantRoleResponse) {
 	p.p((*pb.AuthUserGrantRoleResponse)(&r))
 }
@@ -173,14 +219,22 @@ func newPrinterUnsupported(n string) printer {
 	return &printerUnsupported{printerRPC{nil, f}}
 }
 
-func (p *printerUnsupported) EndpointHealth([]epHealth) { p.p(nil) }
-func (p *printerUnsupported) EndpointStatus([]epStatus) { p.p(nil) }
-func (p *printerUnsupported) EndpointHashKV([]epHashKV) { p.p(nil) }
+func (p *printerUnsupported) EndpointHealth([]epHealth)             { p.p(nil) }
+func (p *printerUnsupported) EndpointStatus([]epStatus)             { p.p(nil) }
+func (p *printerUnsupported) EndpointHashKV([]epHashKV)             { p.p(nil) }
+func (p *printerUnsupported) EndpointPerf([]epPerf)                 { p.p(nil) }
+func (p *printerUnsupported) EndpointMemory([]epMemory)             { p.p(nil) }
+func (p *printerUnsupported) EndpointStorageStats([]epStorageStats) { p.p(nil) }
+func (p *printerUnsupported) EndpointHotKeys([]epHotKeys)           { p.p(nil) }
+func (p *printerUnsupported) EndpointNewerFields([]epNewerFields)   { p.p(nil) }
+func (p *printerUnsupported) EndpointDrain([]epDrain)               { p.p(nil) }
+func (p *printerUnsupported) JobList([]epJobs)                      { p.p(nil) }
 
 func (p *printerUnsupported) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) { p.p(nil) }
 func (p *printerUnsupported) DowngradeValidate(r v3.DowngradeResponse)                  { p.p(nil) }
 func (p *printerUnsupported) DowngradeEnable(r v3.DowngradeResponse)                    { p.p(nil) }
 func (p *printerUnsupported) DowngradeCancel(r v3.DowngradeResponse)                    { p.p(nil) }
+func (p *printerUnsupported) DowngradeStatus([]downgradeStatus)                         { p.p(nil) }
 
 func makeMemberListTable(r v3.MemberListResponse) (hdr []string, rows [][]string) {
 	hdr = []string{"ID", "Status", "Name", "Peer Addrs", "Client Addrs", "Is Learner"}
@@ -246,6 +300,164 @@ func makeEndpointStatusTable(statusList []epStatus) (hdr []string, rows [][]stri
 	return hdr, rows
 }
 
+func makeEndpointPerfTable(perfList []epPerf) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "probe", "count", "errors", "p50", "p90", "p99", "max"}
+	for _, p := range perfList {
+		if p.Error != "" {
+			rows = append(rows, []string{p.Ep, "", "", "", "", "", "", p.Error})
+			continue
+		}
+		for _, pr := range p.Probes {
+			rows = append(rows, []string{
+				p.Ep,
+				pr.Name,
+				fmt.Sprint(pr.Count),
+				fmt.Sprint(pr.Errors),
+				pr.P50.String(),
+				pr.P90.String(),
+				pr.P99.String(),
+				pr.Max.String(),
+			})
+		}
+	}
+	return hdr, rows
+}
+
+func makeEndpointMemoryTable(memList []epMemory) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "component", "bytes"}
+	for _, m := range memList {
+		for _, u := range m.Resp.Usage {
+			rows = append(rows, []string{m.Ep, u.Component, humanize.Bytes(uint64(u.Bytes))})
+		}
+		rows = append(rows, []string{m.Ep, "total", humanize.Bytes(uint64(m.Resp.Total))})
+		if m.Resp.SoftLimit > 0 {
+			rows = append(rows, []string{m.Ep, "soft_limit", humanize.Bytes(uint64(m.Resp.SoftLimit))})
+		}
+		rows = append(rows, []string{m.Ep, "heap_alloc", humanize.Bytes(m.Resp.HeapAlloc)})
+	}
+	return hdr, rows
+}
+
+func makeEndpointStorageStatsTable(statsList []epStorageStats) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "bucket", "keys", "size"}
+	for _, s := range statsList {
+		for _, b := range s.Resp.Buckets {
+			rows = append(rows, []string{s.Ep, b.Name, fmt.Sprint(b.Keys), humanize.Bytes(uint64(b.SizeBytes))})
+		}
+		rows = append(rows, []string{s.Ep, "db_size", "", humanize.Bytes(uint64(s.Resp.DbSize))})
+		rows = append(rows, []string{s.Ep, "db_size_in_use", "", humanize.Bytes(uint64(s.Resp.DbSizeInUse))})
+	}
+	return hdr, rows
+}
+
+func makeEndpointHotKeysTable(hotList []epHotKeys) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "key", "reads", "writes", "read bytes", "write bytes"}
+	for _, h := range hotList {
+		for _, k := range h.Resp.Keys {
+			rows = append(rows, []string{
+				h.Ep,
+				string(k.Key),
+				fmt.Sprint(k.Reads),
+				fmt.Sprint(k.Writes),
+				humanize.Bytes(uint64(k.ReadBytes)),
+				humanize.Bytes(uint64(k.WriteBytes)),
+			})
+		}
+	}
+	return hdr, rows
+}
+
+func makeJobListTable(jobList []epJobs) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "ID", "type", "state", "progress", "message", "start time", "end time"}
+	for _, j := range jobList {
+		for _, job := range j.Resp.Jobs {
+			rows = append(rows, append([]string{j.Ep}, makeJobRow(job)...))
+		}
+	}
+	return hdr, rows
+}
+
+func makeJobRow(job *pb.Job) []string {
+	progress := ""
+	if job.Total > 0 {
+		progress = fmt.Sprintf("%d/%d", job.Done, job.Total)
+	}
+	state := job.State.String()
+	if job.State == pb.Job_RUNNING && job.CancelRequested {
+		state += " (canceling)"
+	}
+	end := ""
+	if job.EndTime != 0 {
+		end = time.Unix(0, job.EndTime).Format(time.RFC3339)
+	}
+	return []string{
+		fmt.Sprint(job.ID),
+		job.Type,
+		state,
+		progress,
+		job.Message,
+		time.Unix(0, job.StartTime).Format(time.RFC3339),
+		end,
+	}
+}
+
+func makeEndpointNewerFieldsTable(fieldsList []epNewerFields) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "cluster version", "field", "version", "count", "last method", "last seen"}
+	for _, f := range fieldsList {
+		for _, field := range f.Resp.Fields {
+			rows = append(rows, []string{
+				f.Ep,
+				f.Resp.ClusterVersion,
+				field.Name,
+				field.Version,
+				fmt.Sprint(field.Count),
+				field.LastMethod,
+				time.Unix(0, field.LastSeenTime).UTC().Format(time.RFC3339),
+			})
+		}
+	}
+	return hdr, rows
+}
+
+func makeDowngradeStatusTable(statusList []downgradeStatus) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "cluster version", "downgrade target", "enabled", "blocked field", "version", "count", "last request", "last seen"}
+	for _, s := range statusList {
+		row := []string{
+			s.Ep,
+			s.Resp.Version,
+			s.Resp.DowngradeInfo.GetTargetVersion(),
+			fmt.Sprint(s.Resp.DowngradeInfo.GetEnabled()),
+		}
+		if len(s.Resp.BlockedFeatures) == 0 {
+			rows = append(rows, append(row, "", "", "", "", ""))
+		}
+		for _, field := range s.Resp.BlockedFeatures {
+			rows = append(rows, append(row[:4:4],
+				field.Name,
+				field.Version,
+				fmt.Sprint(field.Count),
+				field.LastMethod,
+				time.Unix(0, field.LastSeenTime).UTC().Format(time.RFC3339),
+			))
+		}
+	}
+	return hdr, rows
+}
+
+func makeEndpointDrainTable(drainList []epDrain) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "draining", "is leader", "watch streams", "ready"}
+	for _, d := range drainList {
+		rows = append(rows, []string{
+			d.Ep,
+			fmt.Sprint(d.Resp.Draining),
+			fmt.Sprint(d.Resp.Leader),
+			fmt.Sprint(d.Resp.WatchStreams),
+			fmt.Sprint(d.Resp.Ready),
+		})
+	}
+	return hdr, rows
+}
+
 func makeEndpointHashKVTable(hashList []epHashKV) (hdr []string, rows [][]string) {
 	hdr = []string{"endpoint", "hash", "hash_revision"}
 	for _, h := range hashList {
@@ -16,6 +16,7 @@ package command
 
 import (
 	"fmt"
+	"sort"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	spb "go.etcd.io/etcd/api/v3/mvccpb"
@@ -73,13 +74,20 @@ func (p *fieldsPrinter) Get(r v3.GetResponse) {
 	}
 	fmt.Println(`"More" :`, r.More)
 	fmt.Println(`"Count" :`, r.Count)
+	fmt.Println(`"AppliedIndex" :`, r.AppliedIndex)
 }
 
 func (p *fieldsPrinter) Put(r v3.PutResponse) {
 	p.hdr(r.Header)
+	if len(r.Key) != 0 {
+		fmt.Printf("\"Key\" : %q\n", string(r.Key))
+	}
 	if r.PrevKv != nil {
 		p.kv("Prev", r.PrevKv)
 	}
+	if r.Kv != nil {
+		p.kv("Stored", r.Kv)
+	}
 }
 
 func (p *fieldsPrinter) Txn(r v3.TxnResponse) {
@@ -93,6 +101,11 @@ func (p *fieldsPrinter) Txn(r v3.TxnResponse) {
 			p.Put((v3.PutResponse)(*v.ResponsePut))
 		case *pb.ResponseOp_ResponseRange:
 			p.Get((v3.GetResponse)(*v.ResponseRange))
+		case *pb.ResponseOp_ResponseAppend:
+			fmt.Println(`"ValueSize" :`, v.ResponseAppend.ValueSize)
+			if v.ResponseAppend.PrevKv != nil {
+				p.kv("Prev", v.ResponseAppend.PrevKv)
+			}
 		default:
 			fmt.Printf("\"Unknown\" : %q\n", fmt.Sprintf("%+v", v))
 		}
@@ -110,6 +123,23 @@ func (p *fieldsPrinter) Watch(resp v3.WatchResponse) {
 	}
 }
 
+func (p *fieldsPrinter) counters(cs []*pb.CounterValue) {
+	for _, c := range cs {
+		fmt.Printf("\"Key\" : %q\n", string(c.Key))
+		fmt.Println(`"Value" :`, c.Value)
+	}
+}
+
+func (p *fieldsPrinter) CounterAdd(r v3.CounterAddResponse) {
+	p.hdr(r.Header)
+	p.counters(r.Counters)
+}
+
+func (p *fieldsPrinter) CounterGet(r v3.CounterGetResponse) {
+	p.hdr(r.Header)
+	p.counters(r.Counters)
+}
+
 func (p *fieldsPrinter) Grant(r v3.LeaseGrantResponse) {
 	p.hdr(r.ResponseHeader)
 	if p.isHex {
@@ -118,6 +148,7 @@ func (p *fieldsPrinter) Grant(r v3.LeaseGrantResponse) {
 		fmt.Println(`"ID" :`, r.ID)
 	}
 	fmt.Println(`"TTL" :`, r.TTL)
+	fmt.Println(`"FencingToken" :`, r.FencingToken)
 }
 
 func (p *fieldsPrinter) Revoke(id v3.LeaseID, r v3.LeaseRevokeResponse) {
@@ -132,6 +163,7 @@ func (p *fieldsPrinter) KeepAlive(r v3.LeaseKeepAliveResponse) {
 		fmt.Println(`"ID" :`, r.ID)
 	}
 	fmt.Println(`"TTL" :`, r.TTL)
+	fmt.Println(`"FencingToken" :`, r.FencingToken)
 }
 
 func (p *fieldsPrinter) TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool) {
@@ -159,6 +191,28 @@ func (p *fieldsPrinter) Leases(r v3.LeaseLeasesResponse) {
 	}
 }
 
+func (p *fieldsPrinter) ClusterConfig(r v3.ClusterConfigGetResponse) {
+	p.hdr(r.Header)
+	if r.Config == nil {
+		return
+	}
+	for _, pp := range r.Config.ProtectedPrefixes {
+		fmt.Printf("\"Prefix\" : %q\n", pp.Prefix)
+		fmt.Printf("\"Election\" : %q\n", pp.Election)
+		fmt.Printf("\"Writer\" : %q\n", pp.Writer)
+		fmt.Println()
+	}
+	fmt.Printf("\"LeaseExpiryEventPrefix\" : %q\n", r.Config.LeaseExpiryEventPrefix)
+	for _, t := range r.Config.AlarmThresholds {
+		fmt.Println()
+		fmt.Printf("\"Metric\" : %q\n", t.Metric)
+		fmt.Println(`"Value" :`, t.Value)
+		for _, op := range t.Block {
+			fmt.Printf("\"Block\" : %q\n", op)
+		}
+	}
+}
+
 func (p *fieldsPrinter) MemberList(r v3.MemberListResponse) {
 	p.hdr(r.Header)
 	for _, m := range r.Members {
@@ -175,6 +229,16 @@ func (p *fieldsPrinter) MemberList(r v3.MemberListResponse) {
 			fmt.Printf("\"ClientURL\" : %q\n", u)
 		}
 		fmt.Println(`"IsLearner" :`, m.IsLearner)
+		fmt.Println(`"IsStandby" :`, m.IsStandby)
+		fmt.Println(`"LeadershipDisallowed" :`, m.LeadershipDisallowed)
+		keys := make([]string, 0, len(m.Labels))
+		for k := range m.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("\"Label\" : %q\n", k+"="+m.Labels[k])
+		}
 		fmt.Println()
 	}
 }
@@ -219,6 +283,120 @@ func (p *fieldsPrinter) EndpointHashKV(hs []epHashKV) {
 	}
 }
 
+func (p *fieldsPrinter) EndpointMemory(ms []epMemory) {
+	for _, m := range ms {
+		p.hdr(m.Resp.Header)
+		fmt.Printf("\"Endpoint\" : %q\n", m.Ep)
+		for _, u := range m.Resp.Usage {
+			fmt.Printf("\"Component\" : %q\n", u.Component)
+			fmt.Println(`"Bytes" :`, u.Bytes)
+		}
+		fmt.Println(`"Total" :`, m.Resp.Total)
+		fmt.Println(`"SoftLimit" :`, m.Resp.SoftLimit)
+		fmt.Println(`"HeapAlloc" :`, m.Resp.HeapAlloc)
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) EndpointStorageStats(ss []epStorageStats) {
+	for _, s := range ss {
+		p.hdr(s.Resp.Header)
+		fmt.Printf("\"Endpoint\" : %q\n", s.Ep)
+		for _, b := range s.Resp.Buckets {
+			fmt.Printf("\"Bucket\" : %q\n", b.Name)
+			fmt.Println(`"Keys" :`, b.Keys)
+			fmt.Println(`"SizeBytes" :`, b.SizeBytes)
+		}
+		fmt.Println(`"DbSize" :`, s.Resp.DbSize)
+		fmt.Println(`"DbSizeInUse" :`, s.Resp.DbSizeInUse)
+		fmt.Println(`"Time" :`, s.Resp.Time)
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) EndpointHotKeys(hs []epHotKeys) {
+	for _, h := range hs {
+		p.hdr(h.Resp.Header)
+		fmt.Printf("\"Endpoint\" : %q\n", h.Ep)
+		fmt.Println(`"SampleRate" :`, h.Resp.SampleRate)
+		for _, k := range h.Resp.Keys {
+			fmt.Printf("\"Key\" : %q\n", k.Key)
+			fmt.Println(`"Reads" :`, k.Reads)
+			fmt.Println(`"Writes" :`, k.Writes)
+			fmt.Println(`"ReadBytes" :`, k.ReadBytes)
+			fmt.Println(`"WriteBytes" :`, k.WriteBytes)
+		}
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) EndpointNewerFields(fs []epNewerFields) {
+	for _, f := range fs {
+		p.hdr(f.Resp.Header)
+		fmt.Printf("\"Endpoint\" : %q\n", f.Ep)
+		fmt.Printf("\"ClusterVersion\" : %q\n", f.Resp.ClusterVersion)
+		for _, field := range f.Resp.Fields {
+			fmt.Printf("\"Name\" : %q\n", field.Name)
+			fmt.Printf("\"Version\" : %q\n", field.Version)
+			fmt.Println(`"Count" :`, field.Count)
+			fmt.Printf("\"LastMethod\" : %q\n", field.LastMethod)
+			fmt.Println(`"LastSeenTime" :`, field.LastSeenTime)
+		}
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) DowngradeStatus(ss []downgradeStatus) {
+	for _, s := range ss {
+		p.hdr(s.Resp.Header)
+		fmt.Printf("\"Endpoint\" : %q\n", s.Ep)
+		fmt.Printf("\"ClusterVersion\" : %q\n", s.Resp.Version)
+		fmt.Printf("\"DowngradeTargetVersion\" : %q\n", s.Resp.DowngradeInfo.GetTargetVersion())
+		fmt.Println(`"DowngradeEnabled" :`, s.Resp.DowngradeInfo.GetEnabled())
+		for _, field := range s.Resp.BlockedFeatures {
+			fmt.Printf("\"Name\" : %q\n", field.Name)
+			fmt.Printf("\"Version\" : %q\n", field.Version)
+			fmt.Println(`"Count" :`, field.Count)
+			fmt.Printf("\"LastMethod\" : %q\n", field.LastMethod)
+			fmt.Println(`"LastSeenTime" :`, field.LastSeenTime)
+		}
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) EndpointDrain(ds []epDrain) {
+	for _, d := range ds {
+		p.hdr(d.Resp.Header)
+		fmt.Printf("\"Endpoint\" : %q\n", d.Ep)
+		fmt.Println(`"Draining" :`, d.Resp.Draining)
+		fmt.Println(`"Leader" :`, d.Resp.Leader)
+		fmt.Println(`"WatchStreams" :`, d.Resp.WatchStreams)
+		fmt.Println(`"Ready" :`, d.Resp.Ready)
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) EndpointPerf(perfList []epPerf) {
+	for _, ep := range perfList {
+		for _, pr := range ep.Probes {
+			fmt.Printf("\"Endpoint\" : %q\n", ep.Ep)
+			fmt.Printf("\"Probe\" : %q\n", pr.Name)
+			fmt.Println(`"Count" :`, pr.Count)
+			fmt.Println(`"Errors" :`, pr.Errors)
+			fmt.Println(`"P50" :`, int64(pr.P50))
+			fmt.Println(`"P90" :`, int64(pr.P90))
+			fmt.Println(`"P99" :`, int64(pr.P99))
+			fmt.Println(`"Max" :`, int64(pr.Max))
+			fmt.Println()
+		}
+		if ep.Error != "" {
+			fmt.Printf("\"Endpoint\" : %q\n", ep.Ep)
+			fmt.Println(`"Error" :`, ep.Error)
+			fmt.Println()
+		}
+	}
+}
+
 func (p *fieldsPrinter) Alarm(r v3.AlarmResponse) {
 	p.hdr(r.Header)
 	for _, a := range r.Alarms {
@@ -228,10 +406,84 @@ func (p *fieldsPrinter) Alarm(r v3.AlarmResponse) {
 			fmt.Println(`"MemberID" :`, a.MemberID)
 		}
 		fmt.Println(`"AlarmType" :`, a.Alarm)
+		for _, m := range a.Metrics {
+			fmt.Printf("\"Metric\" : %q\n", m)
+		}
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) CompactionHolds(r v3.CompactionHoldListResponse) {
+	p.hdr(r.Header)
+	for _, h := range r.Holds {
+		if p.isHex {
+			fmt.Printf("\"ID\" : %016x\n", h.ID)
+		} else {
+			fmt.Println(`"ID" :`, h.ID)
+		}
+		fmt.Printf("\"Name\" : %q\n", h.Name)
+		fmt.Println(`"Revision" :`, h.Revision)
+		fmt.Println(`"TTL" :`, h.TTL)
+		fmt.Println()
+	}
+}
+
+func (p *fieldsPrinter) GarbageCollect(r v3.GarbageCollectResponse) {
+	p.hdr(r.Header)
+	for _, k := range r.OrphanedKeys {
+		fmt.Printf("\"Key\" : %q\n", string(k.Key))
+		if p.isHex {
+			fmt.Printf("\"Lease\" : %016x\n", k.Lease)
+		} else {
+			fmt.Println(`"Lease" :`, k.Lease)
+		}
+		fmt.Println(`"ModRevision" :`, k.ModRevision)
+		fmt.Println()
+	}
+	for _, id := range r.EmptyLeases {
+		if p.isHex {
+			fmt.Printf("\"EmptyLease\" : %016x\n", id)
+		} else {
+			fmt.Println(`"EmptyLease" :`, id)
+		}
+	}
+	fmt.Println(`"StaleTokens" :`, r.StaleTokens)
+	fmt.Println(`"Repaired" :`, r.Repaired)
+}
+
+func (p *fieldsPrinter) JobList(jobList []epJobs) {
+	for _, j := range jobList {
+		p.hdr(j.Resp.Header)
+		fmt.Printf("\"Endpoint\" : %q\n", j.Ep)
+		for _, job := range j.Resp.Jobs {
+			p.job(job)
+		}
 		fmt.Println()
 	}
 }
 
+func (p *fieldsPrinter) JobStatus(r v3.JobStatusResponse) {
+	p.hdr(r.Header)
+	p.job(r.Job)
+}
+
+func (p *fieldsPrinter) JobCancel(r v3.JobCancelResponse) {
+	p.hdr(r.Header)
+	p.job(r.Job)
+}
+
+func (p *fieldsPrinter) job(job *pb.Job) {
+	fmt.Println(`"ID" :`, job.ID)
+	fmt.Printf("\"Type\" : %q\n", job.Type)
+	fmt.Printf("\"State\" : %q\n", job.State)
+	fmt.Println(`"Done" :`, job.Done)
+	fmt.Println(`"Total" :`, job.Total)
+	fmt.Printf("\"Message\" : %q\n", job.Message)
+	fmt.Println(`"StartTime" :`, job.StartTime)
+	fmt.Println(`"EndTime" :`, job.EndTime)
+	fmt.Println(`"CancelRequested" :`, job.CancelRequested)
+}
+
 func (p *fieldsPrinter) RoleAdd(role string, r v3.AuthRoleAddResponse) { p.hdr(r.Header) }
 func (p *fieldsPrinter) RoleGet(role string, r v3.AuthRoleGetResponse) {
 	p.hdr(r.Header)
@@ -240,6 +492,13 @@ func (p *fieldsPrinter) RoleGet(role string, r v3.AuthRoleGetResponse) {
 		fmt.Printf("\"Key\" : %q\n", string(p.Key))
 		fmt.Printf("\"RangeEnd\" : %q\n", string(p.RangeEnd))
 	}
+	if len(r.RpcPermissions) > 0 {
+		fmt.Print(`"RPCPermissions" :`)
+		for _, m := range r.RpcPermissions {
+			fmt.Printf(" %q", m)
+		}
+		fmt.Println()
+	}
 }
 func (p *fieldsPrinter) RoleDelete(role string, r v3.AuthRoleDeleteResponse) { p.hdr(r.Header) }
 func (p *fieldsPrinter) RoleList(r v3.AuthRoleListResponse) {
@@ -258,8 +517,20 @@ func (p *fieldsPrinter) RoleGrantPermission(role string, r v3.AuthRoleGrantPermi
 func (p *fieldsPrinter) RoleRevokePermission(role string, key string, end string, r v3.AuthRoleRevokePermissionResponse) {
 	p.hdr(r.Header)
 }
+
+func (p *fieldsPrinter) RoleGrantRPCPermission(role string, method string, r v3.AuthRoleGrantRPCPermissionResponse) {
+	p.hdr(r.Header)
+}
+
+func (p *fieldsPrinter) RoleRevokeRPCPermission(role string, method string, r v3.AuthRoleRevokeRPCPermissionResponse) {
+	p.hdr(r.Header)
+}
 func (p *fieldsPrinter) UserAdd(user string, r v3.AuthUserAddResponse)          { p.hdr(r.Header) }
 func (p *fieldsPrinter) UserChangePassword(r v3.AuthUserChangePasswordResponse) { p.hdr(r.Header) }
+func (p *fieldsPrinter) UserRotatePassword(user string, r v3.AuthUserRotatePasswordResponse) {
+	p.hdr(r.Header)
+	fmt.Printf("\"Password\" : %q\n", r.Password)
+}
 func (p *fieldsPrinter) UserGrantRole(user string, role string, r v3.AuthUserGrantRoleResponse) {
 	p.hdr(r.Header)
 }
@@ -69,9 +69,17 @@ func newJSONPrinter(isHex bool) printer {
 	}
 }
 
-func (p *jsonPrinter) EndpointHealth(r []epHealth) { printJSON(r) }
-func (p *jsonPrinter) EndpointStatus(r []epStatus) { printJSON(r) }
-func (p *jsonPrinter) EndpointHashKV(r []epHashKV) { printJSON(r) }
+func (p *jsonPrinter) EndpointHealth(r []epHealth)             { printJSON(r) }
+func (p *jsonPrinter) EndpointStatus(r []epStatus)             { printJSON(r) }
+func (p *jsonPrinter) EndpointHashKV(r []epHashKV)             { printJSON(r) }
+func (p *jsonPrinter) EndpointPerf(r []epPerf)                 { printJSON(r) }
+func (p *jsonPrinter) EndpointMemory(r []epMemory)             { printJSON(r) }
+func (p *jsonPrinter) EndpointStorageStats(r []epStorageStats) { printJSON(r) }
+func (p *jsonPrinter) EndpointHotKeys(r []epHotKeys)           { printJSON(r) }
+func (p *jsonPrinter) EndpointNewerFields(r []epNewerFields)   { printJSON(r) }
+func (p *jsonPrinter) DowngradeStatus(r []downgradeStatus)     { printJSON(r) }
+func (p *jsonPrinter) EndpointDrain(r []epDrain)               { printJSON(r) }
+func (p *jsonPrinter) JobList(r []epJobs)                      { printJSON(r) }
 
 func (p *jsonPrinter) MemberAdd(r clientv3.MemberAddResponse)                   { p.printJSON(r) }
 func (p *jsonPrinter) MemberRemove(_ uint64, r clientv3.MemberRemoveResponse)   { p.printJSON(r) }
@@ -15,6 +15,7 @@
 package command
 
 import (
+	"encoding/hex"
 	"fmt"
 	"os"
 	"strings"
@@ -46,9 +47,19 @@ func (s *simplePrinter) Get(resp v3.GetResponse) {
 
 func (s *simplePrinter) Put(r v3.PutResponse) {
 	fmt.Println("OK")
+	if len(r.Key) != 0 {
+		k := string(r.Key)
+		if s.isHex {
+			k = addHexPrefix(hex.EncodeToString(r.Key))
+		}
+		fmt.Println(k)
+	}
 	if r.PrevKv != nil {
 		printKV(s.isHex, s.valueOnly, r.PrevKv)
 	}
+	if r.Kv != nil {
+		printKV(s.isHex, s.valueOnly, r.Kv)
+	}
 }
 
 func (s *simplePrinter) Txn(resp v3.TxnResponse) {
@@ -67,6 +78,11 @@ func (s *simplePrinter) Txn(resp v3.TxnResponse) {
 			s.Put((v3.PutResponse)(*v.ResponsePut))
 		case *pb.ResponseOp_ResponseRange:
 			s.Get(((v3.GetResponse)(*v.ResponseRange)))
+		case *pb.ResponseOp_ResponseAppend:
+			fmt.Println(v.ResponseAppend.ValueSize)
+			if v.ResponseAppend.PrevKv != nil {
+				printKV(s.isHex, s.valueOnly, v.ResponseAppend.PrevKv)
+			}
 		default:
 			fmt.Printf("unexpected response %+v\n", r)
 		}
@@ -83,7 +99,25 @@ func (s *simplePrinter) Watch(resp v3.WatchResponse) {
 	}
 }
 
+func (s *simplePrinter) counters(cs []*pb.CounterValue) {
+	for _, c := range cs {
+		k := string(c.Key)
+		if s.isHex {
+			k = addHexPrefix(hex.EncodeToString(c.Key))
+		}
+		fmt.Println(k)
+		fmt.Println(c.Value)
+	}
+}
+
+func (s *simplePrinter) CounterAdd(resp v3.CounterAddResponse) { s.counters(resp.Counters) }
+func (s *simplePrinter) CounterGet(resp v3.CounterGetResponse) { s.counters(resp.Counters) }
+
 func (s *simplePrinter) Grant(resp v3.LeaseGrantResponse) {
+	if resp.FencingToken != 0 {
+		fmt.Printf("lease %016x granted with TTL(%ds), fencing token(%d)\n", resp.ID, resp.TTL, resp.FencingToken)
+		return
+	}
 	fmt.Printf("lease %016x granted with TTL(%ds)\n", resp.ID, resp.TTL)
 }
 
@@ -92,6 +126,10 @@ func (s *simplePrinter) Revoke(id v3.LeaseID, r v3.LeaseRevokeResponse) {
 }
 
 func (s *simplePrinter) KeepAlive(resp v3.LeaseKeepAliveResponse) {
+	if resp.FencingToken != 0 {
+		fmt.Printf("lease %016x keepalived with TTL(%d), fencing token(%d)\n", resp.ID, resp.TTL, resp.FencingToken)
+		return
+	}
 	fmt.Printf("lease %016x keepalived with TTL(%d)\n", resp.ID, resp.TTL)
 }
 
@@ -125,11 +163,60 @@ func (s *simplePrinter) Alarm(resp v3.AlarmResponse) {
 	}
 }
 
+func (s *simplePrinter) CompactionHolds(resp v3.CompactionHoldListResponse) {
+	fmt.Printf("found %d compaction holds\n", len(resp.Holds))
+	for _, h := range resp.Holds {
+		fmt.Printf("%016x, %s, revision %d, ttl %ds\n", h.ID, h.Name, h.Revision, h.TTL)
+	}
+}
+
+func (s *simplePrinter) GarbageCollect(resp v3.GarbageCollectResponse) {
+	fmt.Printf("found %d orphaned keys\n", len(resp.OrphanedKeys))
+	for _, k := range resp.OrphanedKeys {
+		fmt.Printf("%q, lease %016x, mod revision %d\n", k.Key, k.Lease, k.ModRevision)
+	}
+	fmt.Printf("found %d empty leases\n", len(resp.EmptyLeases))
+	for _, id := range resp.EmptyLeases {
+		fmt.Printf("%016x\n", id)
+	}
+	fmt.Printf("found %d stale auth tokens\n", resp.StaleTokens)
+	if resp.Repaired {
+		fmt.Println("repaired")
+	}
+}
+
+func (s *simplePrinter) JobList(jobList []epJobs) {
+	_, rows := makeJobListTable(jobList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
+func (s *simplePrinter) JobStatus(resp v3.JobStatusResponse) {
+	fmt.Println(strings.Join(makeJobRow(resp.Job), ", "))
+}
+
+func (s *simplePrinter) JobCancel(resp v3.JobCancelResponse) {
+	fmt.Printf("Requested the cancellation of job %d\n", resp.Job.ID)
+}
+
 func (s *simplePrinter) MemberAdd(r v3.MemberAddResponse) {
 	asLearner := " "
-	if r.Member.IsLearner {
+	if r.Member.IsStandby {
+		asLearner = " as standby "
+	} else if r.Member.IsLearner {
 		asLearner = " as learner "
 	}
+	if qi := r.QuorumImpact; qi != nil {
+		fmt.Printf("Member can be added%sto cluster %16x (dry run)\n", asLearner, r.Header.ClusterId)
+		fmt.Printf("Voting members: %d -> %d\n", qi.VotingMembersBefore, qi.VotingMembersAfter)
+		fmt.Printf("Quorum: %d -> %d\n", qi.QuorumBefore, qi.QuorumAfter)
+		fmt.Printf("Failure tolerance: %d -> %d\n", qi.FailureToleranceBefore, qi.FailureToleranceAfter)
+		for _, w := range qi.Warnings {
+			fmt.Printf("Warning: %s\n", w)
+		}
+		return
+	}
 	fmt.Printf("Member %16x added%sto cluster %16x\n", r.Member.ID, asLearner, r.Header.ClusterId)
 }
 
@@ -145,6 +232,25 @@ func (s *simplePrinter) MemberPromote(id uint64, r v3.MemberPromoteResponse) {
 	fmt.Printf("Member %16x promoted in cluster %16x\n", id, r.Header.ClusterId)
 }
 
+func (s *simplePrinter) ClusterConfig(resp v3.ClusterConfigGetResponse) {
+	if resp.Config == nil {
+		return
+	}
+	for _, p := range resp.Config.ProtectedPrefixes {
+		fmt.Printf("protected prefix %q, election %q, writer %s\n", p.Prefix, p.Election, strings.ToLower(p.Writer.String()))
+	}
+	if len(resp.Config.LeaseExpiryEventPrefix) > 0 {
+		fmt.Printf("lease expiry event prefix %q\n", resp.Config.LeaseExpiryEventPrefix)
+	}
+	for _, t := range resp.Config.AlarmThresholds {
+		blocks := make([]string, len(t.Block))
+		for i, op := range t.Block {
+			blocks[i] = strings.ToLower(op.String())
+		}
+		fmt.Printf("alarm threshold %s %d, blocks [%s]\n", strings.ToLower(t.Metric.String()), t.Value, strings.Join(blocks, ", "))
+	}
+}
+
 func (s *simplePrinter) MemberList(resp v3.MemberListResponse) {
 	_, rows := makeMemberListTable(resp)
 	for _, row := range rows {
@@ -176,6 +282,48 @@ func (s *simplePrinter) EndpointHashKV(hashList []epHashKV) {
 	}
 }
 
+func (s *simplePrinter) EndpointStorageStats(statsList []epStorageStats) {
+	_, rows := makeEndpointStorageStatsTable(statsList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
+func (s *simplePrinter) EndpointMemory(memList []epMemory) {
+	_, rows := makeEndpointMemoryTable(memList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
+func (s *simplePrinter) EndpointHotKeys(hotList []epHotKeys) {
+	_, rows := makeEndpointHotKeysTable(hotList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
+func (s *simplePrinter) EndpointNewerFields(fieldsList []epNewerFields) {
+	_, rows := makeEndpointNewerFieldsTable(fieldsList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
+func (s *simplePrinter) EndpointDrain(drainList []epDrain) {
+	_, rows := makeEndpointDrainTable(drainList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
+func (s *simplePrinter) EndpointPerf(perfList []epPerf) {
+	_, rows := makeEndpointPerfTable(perfList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
 func (s *simplePrinter) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) {
 	fmt.Printf("Leadership transferred from %s to %s\n", types.ID(leader), types.ID(target))
 }
@@ -192,6 +340,13 @@ func (s *simplePrinter) DowngradeCancel(r v3.DowngradeResponse) {
 	fmt.Printf("Downgrade cancel success, cluster version %s\n", r.Version)
 }
 
+func (s *simplePrinter) DowngradeStatus(statusList []downgradeStatus) {
+	_, rows := makeDowngradeStatusTable(statusList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
 func (s *simplePrinter) RoleAdd(role string, r v3.AuthRoleAddResponse) {
 	fmt.Printf("Role %s created\n", role)
 }
@@ -241,6 +396,12 @@ func (s *simplePrinter) RoleGet(role string, r v3.AuthRoleGetResponse) {
 			}
 		}
 	}
+	if len(r.RpcPermissions) > 0 {
+		fmt.Println("RPC:")
+		for _, method := range r.RpcPermissions {
+			fmt.Printf("\t%s\n", method)
+		}
+	}
 }
 
 func (s *simplePrinter) RoleList(r v3.AuthRoleListResponse) {
@@ -269,6 +430,14 @@ func (s *simplePrinter) RoleRevokePermission(role string, key string, end string
 	}
 }
 
+func (s *simplePrinter) RoleGrantRPCPermission(role string, method string, r v3.AuthRoleGrantRPCPermissionResponse) {
+	fmt.Printf("Role %s updated\n", role)
+}
+
+func (s *simplePrinter) RoleRevokeRPCPermission(role string, method string, r v3.AuthRoleRevokeRPCPermissionResponse) {
+	fmt.Printf("Permission of RPC %s is revoked from role %s\n", method, role)
+}
+
 func (s *simplePrinter) UserAdd(name string, r v3.AuthUserAddResponse) {
 	fmt.Printf("User %s created\n", name)
 }
@@ -286,6 +455,11 @@ func (s *simplePrinter) UserChangePassword(v3.AuthUserChangePasswordResponse) {
 	fmt.Println("Password updated")
 }
 
+func (s *simplePrinter) UserRotatePassword(user string, r v3.AuthUserRotatePasswordResponse) {
+	fmt.Printf("Password of user %s rotated, it will not be shown again:\n", user)
+	fmt.Println(r.Password)
+}
+
 func (s *simplePrinter) UserGrantRole(user string, role string, r v3.AuthUserGrantRoleResponse) {
 	fmt.Printf("Role %s is granted to user %s\n", role, user)
 }
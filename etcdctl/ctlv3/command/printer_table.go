@@ -68,3 +68,91 @@ func (tp *tablePrinter) EndpointHashKV(r []epHashKV) {
 	}
 	table.Render()
 }
+
+func (tp *tablePrinter) EndpointPerf(r []epPerf) {
+	hdr, rows := makeEndpointPerfTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) EndpointMemory(r []epMemory) {
+	hdr, rows := makeEndpointMemoryTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) EndpointStorageStats(r []epStorageStats) {
+	hdr, rows := makeEndpointStorageStatsTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) EndpointHotKeys(r []epHotKeys) {
+	hdr, rows := makeEndpointHotKeysTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) JobList(r []epJobs) {
+	hdr, rows := makeJobListTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) DowngradeStatus(r []downgradeStatus) {
+	hdr, rows := makeDowngradeStatusTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) EndpointNewerFields(r []epNewerFields) {
+	hdr, rows := makeEndpointNewerFieldsTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) EndpointDrain(r []epDrain) {
+	hdr, rows := makeEndpointDrainTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
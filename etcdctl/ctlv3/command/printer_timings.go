@@ -0,0 +1,60 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+// timingsPrinter prints the breakdown of the time the server spent serving
+// key-value requests to stderr, so that it does not mix with the output.
+type timingsPrinter struct {
+	printer
+}
+
+func (tp *timingsPrinter) Del(r v3.DeleteResponse) {
+	tp.printer.Del(r)
+	printTimings(r.Header)
+}
+
+func (tp *timingsPrinter) Get(r v3.GetResponse) {
+	tp.printer.Get(r)
+	printTimings(r.Header)
+}
+
+func (tp *timingsPrinter) Put(r v3.PutResponse) {
+	tp.printer.Put(r)
+	printTimings(r.Header)
+}
+
+func (tp *timingsPrinter) Txn(r v3.TxnResponse) {
+	tp.printer.Txn(r)
+	printTimings(r.Header)
+}
+
+func printTimings(h *pb.ResponseHeader) {
+	t := h.GetTimings()
+	if t == nil {
+		// the server does not report timings
+		return
+	}
+	fmt.Fprintf(os.Stderr, "timings: queue wait %v, raft commit %v, apply %v, backend read %v\n",
+		time.Duration(t.QueueWaitNs), time.Duration(t.RaftCommitNs), time.Duration(t.ApplyNs), time.Duration(t.BackendReadNs))
+}
@@ -30,6 +30,8 @@ var (
 	putPrevKV      bool
 	putIgnoreVal   bool
 	putIgnoreLease bool
+	putSequence    bool
+	putReturnKV    bool
 )
 
 // NewPutCommand returns the cobra command for "put".
@@ -52,17 +54,23 @@ this command tries to read the value from standard input.
 If <lease> isn't given as a command line argument and '--ignore-lease' is not specified,
 this command tries to read the value from standard input.
 
+If '--sequence-suffix' is specified, <key> is used as a prefix and the server
+appends the revision of the put, zero padded to 20 digits. The created key is printed.
+
 For example,
 $ cat file | put <key>
 will store the content of the file to <key>.
 `,
-		Run:     putCommandFunc,
-		GroupID: groupKVID,
+		Run:               putCommandFunc,
+		ValidArgsFunction: keyCompletionFunc,
+		GroupID:           groupKVID,
 	}
 	cmd.Flags().StringVar(&leaseStr, "lease", "0", "lease ID (in hexadecimal) to attach to the key")
 	cmd.Flags().BoolVar(&putPrevKV, "prev-kv", false, "return the previous key-value pair before modification")
 	cmd.Flags().BoolVar(&putIgnoreVal, "ignore-value", false, "updates the key using its current value")
 	cmd.Flags().BoolVar(&putIgnoreLease, "ignore-lease", false, "updates the key using its current lease")
+	cmd.Flags().BoolVar(&putSequence, "sequence-suffix", false, "append the revision of the put to <key> and print the created key")
+	cmd.Flags().BoolVar(&putReturnKV, "return-kv", false, "return the key-value pair as stored by the put, with its new mod revision and version")
 	return cmd
 }
 
@@ -116,6 +124,12 @@ func getPutOp(args []string) (string, string, []clientv3.OpOption) {
 	if putIgnoreLease {
 		opts = append(opts, clientv3.WithIgnoreLease())
 	}
+	if putSequence {
+		opts = append(opts, clientv3.WithSequenceSuffix())
+	}
+	if putReturnKV {
+		opts = append(opts, clientv3.WithReturnKV())
+	}
 
 	return key, value, opts
 }
@@ -0,0 +1,61 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+// NewReseedCommand returns the cobra command for "reseed".
+func NewReseedCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reseed",
+		Short: "Replaces the backend of a member with a copy of the backend of the leader",
+		Long: `Makes the member of the given endpoint replace its backend with a copy of
+the backend of the leader, fetched over the peer network, and clear its
+CORRUPT alarm. The member keeps its ID, data directory and raft log, so it
+does not have to be removed and added back to the cluster. The leader cannot
+be reseeded: move the leadership away first.
+`,
+		Run:     reseedCommandFunc,
+		GroupID: groupClusterMaintenanceID,
+	}
+}
+
+func reseedCommandFunc(cmd *cobra.Command, args []string) {
+	cfg := clientConfigFromCmd(cmd)
+	if len(cfg.Endpoints) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("reseed command needs exactly 1 endpoint, got %v", cfg.Endpoints))
+	}
+	ep := cfg.Endpoints[0]
+	c := mustClient(cfg)
+	defer c.Close()
+
+	ctx, cancel := commandCtx(cmd)
+	start := time.Now()
+	resp, err := c.Reseed(ctx, ep)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	fmt.Printf("Reseeded etcd member[%s] from leader %x at index %d (%s). took %s\n",
+		ep, resp.Leader, resp.ConsistentIndex, humanize.Bytes(uint64(resp.DbSize)), time.Since(start))
+}
@@ -44,6 +44,8 @@ func NewRoleCommand() *cobra.Command {
 	ac.AddCommand(newRoleListCommand())
 	ac.AddCommand(newRoleGrantPermissionCommand())
 	ac.AddCommand(newRoleRevokePermissionCommand())
+	ac.AddCommand(newRoleGrantRPCPermissionCommand())
+	ac.AddCommand(newRoleRevokeRPCPermissionCommand())
 
 	return ac
 }
@@ -106,6 +108,27 @@ func newRoleRevokePermissionCommand() *cobra.Command {
 	return cmd
 }
 
+func newRoleGrantRPCPermissionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant-rpc <role name> <method>",
+		Short: "Grants an administrative RPC method, such as Maintenance.Defragment, to a role",
+		Long: `Grants an administrative RPC method to a role.
+
+Methods are named "<service>.<method>", for example Maintenance.Defragment,
+Maintenance.Snapshot, Maintenance.AlarmDisarm or Cluster.MemberAdd.
+Users with the role may call the method without having the root role.`,
+		Run: roleGrantRPCPermissionCommandFunc,
+	}
+}
+
+func newRoleRevokeRPCPermissionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-rpc <role name> <method>",
+		Short: "Revokes an administrative RPC method from a role",
+		Run:   roleRevokeRPCPermissionCommandFunc,
+	}
+}
+
 // roleAddCommandFunc executes the "role add" command.
 func roleAddCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
@@ -197,6 +220,32 @@ func roleRevokePermissionCommandFunc(cmd *cobra.Command, args []string) {
 	display.RoleRevokePermission(args[0], args[1], rangeEnd, *resp)
 }
 
+// roleGrantRPCPermissionCommandFunc executes the "role grant-rpc" command.
+func roleGrantRPCPermissionCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role grant-rpc command requires role name and method as its argument"))
+	}
+
+	resp, err := mustClientFromCmd(cmd).Auth.RoleGrantRPCPermission(context.TODO(), args[0], args[1])
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.RoleGrantRPCPermission(args[0], args[1], *resp)
+}
+
+// roleRevokeRPCPermissionCommandFunc executes the "role revoke-rpc" command.
+func roleRevokeRPCPermissionCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role revoke-rpc command requires role name and method as its argument"))
+	}
+
+	resp, err := mustClientFromCmd(cmd).Auth.RoleRevokeRPCPermission(context.TODO(), args[0], args[1])
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.RoleRevokeRPCPermission(args[0], args[1], *resp)
+}
+
 func permRange(args []string) (string, string) {
 	key := args[0]
 	var rangeEnd string
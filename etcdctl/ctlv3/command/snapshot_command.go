@@ -17,7 +17,10 @@ package command
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
@@ -44,7 +47,29 @@ var snapshotExample = util.Normalize(`
 	etcdctl --endpoints=https://127.0.0.1:2379 --dial-timeout=20s snapshot save /backup/etcd-snapshot.db
 
 	# Save snapshot with desirable time format
-	etcdctl snapshot save /mnt/backup/etcd/backup_$(date +%Y%m%d_%H%M%S).db`)
+	etcdctl snapshot save /mnt/backup/etcd/backup_$(date +%Y%m%d_%H%M%S).db
+
+	# Limit the transfer rate and resume the transfer up to 3 times if it is interrupted
+	etcdctl snapshot save --rate=50MB/s --max-resumes=3 /backup/etcd-snapshot.db
+
+	# Stream the snapshot to another tool
+	etcdctl snapshot save - | gzip > /backup/etcd-snapshot.db.gz
+
+	# Check a snapshot against the cluster, using the hash computed by "etcdutl hashkv"
+	etcdutl hashkv /backup/etcd-snapshot.db
+	etcdctl snapshot verify --rev=1000 --hash=1084519789 --compact-rev=-1
+
+	# Write a checkpoint of the backend of each endpoint to its checkpoint directory
+	etcdctl snapshot checkpoint --endpoints=127.0.0.1:2379,127.0.0.1:22379`)
+
+var (
+	snapshotRate       string
+	snapshotMaxResumes int
+
+	snapshotVerifyRev        int64
+	snapshotVerifyHash       uint32
+	snapshotVerifyCompactRev int64
+)
 
 // NewSnapshotCommand returns the cobra command for "snapshot".
 func NewSnapshotCommand() *cobra.Command {
@@ -55,16 +80,21 @@ func NewSnapshotCommand() *cobra.Command {
 		GroupID: groupClusterMaintenanceID,
 	}
 	cmd.AddCommand(NewSnapshotSaveCommand())
+	cmd.AddCommand(NewSnapshotVerifyCommand())
+	cmd.AddCommand(NewSnapshotCheckpointCommand())
 	return cmd
 }
 
 func NewSnapshotSaveCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "save <filename>",
-		Short:   "Stores an etcd node backend snapshot to a given file",
+		Short:   "Stores an etcd node backend snapshot to a given file, or to stdout if the filename is '-'",
 		Run:     snapshotSaveCommandFunc,
 		Example: snapshotExample,
 	}
+	cmd.Flags().StringVar(&snapshotRate, "rate", "", "Maximum transfer rate of the snapshot, e.g. 50MB/s (unlimited if empty)")
+	cmd.Flags().IntVar(&snapshotMaxResumes, "max-resumes", 0, "Number of times an interrupted transfer is resumed")
+	return cmd
 }
 
 func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
@@ -86,8 +116,20 @@ func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	defer cancel()
 
+	rate, err := parseSnapshotRate(snapshotRate)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	opts := snapshot.SaveOptions{RateLimit: rate, MaxResumes: snapshotMaxResumes}
+
 	path := args[0]
-	version, err := snapshot.SaveWithVersion(ctx, lg, *cfg, path)
+	if path == "-" {
+		if _, err = snapshot.SaveToWriter(ctx, lg, *cfg, os.Stdout, opts); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitInterrupted, err)
+		}
+		return
+	}
+	version, err := snapshot.SaveWithOptions(ctx, lg, *cfg, path, opts)
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitInterrupted, err)
 	}
@@ -96,3 +138,114 @@ func snapshotSaveCommandFunc(cmd *cobra.Command, args []string) {
 		fmt.Printf("Server version %s\n", version)
 	}
 }
+
+// NewSnapshotVerifyCommand returns the cobra command for "snapshot verify".
+func NewSnapshotVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Checks a snapshot against the endpoints by the hash of its keys",
+		Long: `Checks a snapshot against the endpoints without restoring it. The hash
+of the keys of the snapshot at a revision, as printed by "etcdutl hashkv",
+is compared with the hash of the keys of each endpoint at the same revision.
+The revision must not be compacted on the endpoints.
+`,
+		Run:     snapshotVerifyCommandFunc,
+		Example: snapshotExample,
+	}
+	cmd.Flags().Int64Var(&snapshotVerifyRev, "rev", 0, "Revision the hash of the snapshot is computed at")
+	cmd.Flags().Uint32Var(&snapshotVerifyHash, "hash", 0, "Hash of the keys of the snapshot")
+	cmd.Flags().Int64Var(&snapshotVerifyCompactRev, "compact-rev", 0, "Compacted revision of the snapshot")
+	cmd.MarkFlagRequired("rev")
+	cmd.MarkFlagRequired("hash")
+	cmd.MarkFlagRequired("compact-rev")
+	return cmd
+}
+
+func snapshotVerifyCommandFunc(cmd *cobra.Command, args []string) {
+	cfg := clientConfigFromCmd(cmd)
+
+	var err error
+	for _, ep := range cfg.Endpoints {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, verr := c.VerifySnapshot(ctx, ep, snapshotVerifyRev, snapshotVerifyHash, snapshotVerifyCompactRev)
+		cancel()
+		c.Close()
+		switch {
+		case verr != nil:
+			err = verr
+			fmt.Fprintf(os.Stderr, "Failed to verify the snapshot against endpoint %s (%v)\n", ep, verr)
+		case resp.Match:
+			fmt.Printf("%s: snapshot matches at revision %d\n", ep, snapshotVerifyRev)
+		case resp.CompactRevision != snapshotVerifyCompactRev:
+			err = fmt.Errorf("cannot verify the snapshot against endpoint %s", ep)
+			fmt.Printf("%s: cannot compare the hashes, compacted revision %d differs from %d of the snapshot\n", ep, resp.CompactRevision, snapshotVerifyCompactRev)
+		default:
+			err = fmt.Errorf("snapshot does not match endpoint %s", ep)
+			fmt.Printf("%s: snapshot does not match at revision %d, hash %d differs from %d of the snapshot\n", ep, snapshotVerifyRev, resp.Hash, snapshotVerifyHash)
+		}
+	}
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+// NewSnapshotCheckpointCommand returns the cobra command for "snapshot checkpoint".
+func NewSnapshotCheckpointCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "checkpoint",
+		Short: "Writes a checkpoint of the backend of the endpoints on their hosts",
+		Long: `Makes each endpoint write a checkpoint of its backend to its checkpoint
+directory, set by --backend-checkpoint-dir, without sending it over the
+network. The checkpoint is a copy-on-write clone of the database file on
+filesystems supporting it, like XFS and btrfs, and a full copy otherwise.
+The oldest checkpoints beyond --backend-checkpoint-retention are removed.
+`,
+		Run:     snapshotCheckpointCommandFunc,
+		Example: snapshotExample,
+	}
+}
+
+func snapshotCheckpointCommandFunc(cmd *cobra.Command, args []string) {
+	cfg := clientConfigFromCmd(cmd)
+
+	var err error
+	for _, ep := range cfg.Endpoints {
+		cfg.Endpoints = []string{ep}
+		c := mustClient(cfg)
+		ctx, cancel := commandCtx(cmd)
+		resp, cerr := c.Checkpoint(ctx, ep)
+		cancel()
+		c.Close()
+		if cerr != nil {
+			err = cerr
+			fmt.Fprintf(os.Stderr, "Failed to write a checkpoint on endpoint %s (%v)\n", ep, cerr)
+			continue
+		}
+		method := "copied"
+		if resp.Cloned {
+			method = "cloned"
+		}
+		fmt.Printf("%s: checkpoint %s at %s (%s)\n", ep, method, resp.Path, humanize.Bytes(uint64(resp.DbSize)))
+	}
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+// parseSnapshotRate parses a transfer rate such as "50MB/s" into bytes per
+// second. An empty rate means no limit.
+func parseSnapshotRate(rate string) (uint64, error) {
+	if rate == "" {
+		return 0, nil
+	}
+	n, err := humanize.ParseBytes(strings.TrimSuffix(rate, "/s"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid --rate %q: %w", rate, err)
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("invalid --rate %q: must be positive", rate)
+	}
+	return n, nil
+}
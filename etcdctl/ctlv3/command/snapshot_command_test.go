@@ -0,0 +1,40 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSnapshotRate(t *testing.T) {
+	for rate, expected := range map[string]uint64{
+		"":          0,
+		"50MB/s":    50 * 1000 * 1000,
+		"1MiB/s":    1024 * 1024,
+		"512KB":     512 * 1000,
+		"1048576/s": 1024 * 1024,
+	} {
+		n, err := parseSnapshotRate(rate)
+		require.NoError(t, err, rate)
+		assert.Equal(t, expected, n, rate)
+	}
+	for _, rate := range []string{"fast", "0MB/s", "-1MB/s"} {
+		_, err := parseSnapshotRate(rate)
+		assert.Error(t, err, rate)
+	}
+}
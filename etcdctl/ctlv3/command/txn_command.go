@@ -50,10 +50,11 @@ etcdctl txn -i
 # compares:
 mod("key1") > "0"
 
-# success requests (get, put, delete):
+# success requests (get, put, delete, append):
 put key1 "overwrote-key1"
+append log1 "entry;"
 
-# failure requests (get, put, delete):
+# failure requests (get, put, delete, append):
 put key1 "created-key1"
 put key2 "some extra key"
 ---
@@ -77,9 +78,9 @@ func txnCommandFunc(cmd *cobra.Command, args []string) {
 	txn := mustClientFromCmd(cmd).Txn(context.Background())
 	promptInteractive("compares:")
 	txn.If(readCompares(reader)...)
-	promptInteractive("success requests (get, put, del):")
+	promptInteractive("success requests (get, put, del, append):")
 	txn.Then(readOps(reader)...)
-	promptInteractive("failure requests (get, put, del):")
+	promptInteractive("failure requests (get, put, del, append):")
 	txn.Else(readOps(reader)...)
 
 	resp, err := txn.Commit()
@@ -168,8 +169,23 @@ func parseRequestUnion(line string) (*clientv3.Op, error) {
 		key, opts := getDelOp(args)
 		opc <- clientv3.OpDelete(key, opts...)
 	}
+	var appendMaxSize int64
+	var appendPrevKV bool
+	app := &cobra.Command{
+		Use:  "append <key> <value>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			opts := []clientv3.OpOption{clientv3.WithMaxSize(appendMaxSize)}
+			if appendPrevKV {
+				opts = append(opts, clientv3.WithPrevKV())
+			}
+			opc <- clientv3.OpAppend(args[0], args[1], opts...)
+		},
+	}
+	app.Flags().Int64Var(&appendMaxSize, "max-size", 0, "Fail the transaction if the appended value would exceed this many bytes")
+	app.Flags().BoolVar(&appendPrevKV, "prev-kv", false, "return the previous key-value pair before modification")
 	cmds := &cobra.Command{SilenceErrors: true}
-	cmds.AddCommand(put, get, del)
+	cmds.AddCommand(put, get, del, app)
 
 	cmds.SetArgs(args)
 	if err := cmds.Execute(); err != nil {
@@ -223,6 +239,16 @@ func ParseCompare(line string) (*clientv3.Cmp, error) {
 		cmp = clientv3.Compare(clientv3.Value(key), op, val)
 	case "lease":
 		cmp = clientv3.Compare(clientv3.Cmp{Target: pb.Compare_LEASE}, op, val)
+	case "int":
+		if v, err = strconv.ParseInt(val, 10, 64); err == nil {
+			cmp = clientv3.Compare(clientv3.ValueInt(key), op, v)
+		}
+	case "semver":
+		cmp = clientv3.Compare(clientv3.ValueSemver(key), op, val)
+	case "fencing":
+		if v, err = strconv.ParseInt(val, 10, 64); err == nil {
+			cmp = clientv3.Compare(clientv3.FencingToken(key), op, v)
+		}
 	default:
 		return nil, fmt.Errorf("malformed comparison: %s (unknown target %s)", line, target)
 	}
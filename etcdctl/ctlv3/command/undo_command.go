@@ -0,0 +1,252 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+// Destructive commands share these flags, see addAuditFlags.
+var (
+	auditInteractive bool
+	auditUndoFile    string
+)
+
+// undoBundle is written by destructive commands to --undo-file, recording
+// what they removed. Bundles of KV operations are restored by "undo".
+type undoBundle struct {
+	Command string    `json:"command"`
+	Time    time.Time `json:"time"`
+	// KVs are the deleted keys.
+	KVs []undoKV `json:"kvs,omitempty"`
+	// Leases are the revoked leases.
+	Leases []undoLease `json:"leases,omitempty"`
+	// Member is the removed member.
+	Member *undoMember `json:"member,omitempty"`
+}
+
+type undoKV struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+	Lease int64  `json:"lease,omitempty"`
+}
+
+type undoLease struct {
+	ID  int64 `json:"id"`
+	TTL int64 `json:"ttl"`
+}
+
+type undoMember struct {
+	ID        uint64   `json:"id"`
+	Name      string   `json:"name"`
+	PeerURLs  []string `json:"peer-urls"`
+	IsLearner bool     `json:"is-learner,omitempty"`
+}
+
+// addAuditFlags adds --interactive and, if the command can write an undo
+// bundle, --undo-file to a destructive command.
+func addAuditFlags(cmd *cobra.Command, undo bool) {
+	cmd.Flags().BoolVarP(&auditInteractive, "interactive", "i", false, "show what the command removes and ask for confirmation before running it")
+	if undo {
+		cmd.Flags().StringVar(&auditUndoFile, "undo-file", "", "write what the command removes to this file, which must not exist")
+	}
+}
+
+// mustConfirm asks for confirmation on stdin if --interactive is set, and
+// exits unless it is given.
+func mustConfirm(prompt string) {
+	if !auditInteractive {
+		return
+	}
+	if !confirm(os.Stdin, os.Stderr, prompt) {
+		cobrautl.ExitWithError(cobrautl.ExitInterrupted, errors.New("canceled"))
+	}
+}
+
+// confirm writes the prompt to w and returns whether the answer read from r
+// is yes.
+func confirm(r io.Reader, w io.Writer, prompt string) bool {
+	fmt.Fprintf(w, "%s [y/N] ", prompt)
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || answer == "") {
+		fmt.Fprintln(w)
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// mustCreateUndoFile creates --undo-file, if set, before the command removes
+// anything, so that nothing is removed if the bundle cannot be written.
+func mustCreateUndoFile() *os.File {
+	if auditUndoFile == "" {
+		return nil
+	}
+	f, err := os.OpenFile(auditUndoFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitIO, fmt.Errorf("failed to create undo file (%w)", err))
+	}
+	return f
+}
+
+// discardUndoFile removes the undo file of a command that failed.
+func discardUndoFile(f *os.File) {
+	if f == nil {
+		return
+	}
+	f.Close()
+	os.Remove(f.Name())
+}
+
+// mustWriteUndoBundle writes the bundle to the undo file, if any.
+func mustWriteUndoBundle(f *os.File, b undoBundle) {
+	if f == nil {
+		return
+	}
+	b.Time = time.Now().UTC()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(b)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitIO, fmt.Errorf("failed to write undo file %s (%w)", f.Name(), err))
+	}
+	fmt.Fprintf(os.Stderr, "undo bundle written to %s\n", f.Name())
+}
+
+func readUndoBundle(path string) (undoBundle, error) {
+	var b undoBundle
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return b, err
+	}
+	if err = json.Unmarshal(data, &b); err != nil {
+		return b, fmt.Errorf("invalid undo bundle %s (%w)", path, err)
+	}
+	return b, nil
+}
+
+// NewUndoCommand returns the cobra command for "undo".
+func NewUndoCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "undo <bundle>",
+		Short: "Restores the keys and leases removed by a destructive command from its undo bundle",
+		Long: `Restores the keys and leases recorded in an undo bundle, written by "del" and
+"lease revoke" with --undo-file. Revoked leases are granted again with their ID
+and TTL. Keys are put back with their value and lease, unless they have been
+created again since; keys whose lease no longer exists are put back without a
+lease.
+
+Bundles of "member remove" cannot be undone, the member has to be added again
+with "member add".
+`,
+		Run:     undoCommandFunc,
+		GroupID: groupKVID,
+	}
+}
+
+func undoCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("undo command needs an undo bundle as argument"))
+	}
+	b, err := readUndoBundle(args[0])
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	if m := b.Member; m != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("member removals cannot be undone, add the member again with: etcdctl member add %s --peer-urls=%s",
+			m.Name, strings.Join(m.PeerURLs, ",")))
+	}
+
+	c := mustClientFromCmd(cmd)
+	ctx, cancel := commandCtx(cmd)
+	defer cancel()
+	for _, l := range b.Leases {
+		_, err = clientv3.RetryLeaseClient(c).LeaseGrant(ctx, &pb.LeaseGrantRequest{ID: l.ID, TTL: l.TTL})
+		if err != nil && !errors.Is(rpctypes.Error(err), rpctypes.ErrLeaseExist) {
+			cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to grant lease %016x (%w)", l.ID, err))
+		}
+	}
+	restored, skipped, err := restoreKVs(ctx, c, b.KVs)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	fmt.Printf("restored %d leases and %d keys, skipped %d existing keys\n", len(b.Leases), restored, skipped)
+}
+
+// restoreKVs puts back the keys which do not exist.
+func restoreKVs(ctx context.Context, c *clientv3.Client, kvs []undoKV) (restored, skipped int, err error) {
+	// leases caches whether the leases of the keys exist
+	leases := make(map[int64]bool)
+	for _, kv := range kvs {
+		var opts []clientv3.OpOption
+		if kv.Lease != 0 {
+			exists, ok := leases[kv.Lease]
+			if !ok {
+				resp, err := c.TimeToLive(ctx, clientv3.LeaseID(kv.Lease))
+				if err != nil {
+					return restored, skipped, err
+				}
+				exists = resp.TTL != -1
+				leases[kv.Lease] = exists
+				if !exists {
+					fmt.Fprintf(os.Stderr, "lease %016x not found, keys are restored without it\n", kv.Lease)
+				}
+			}
+			if exists {
+				opts = append(opts, clientv3.WithLease(clientv3.LeaseID(kv.Lease)))
+			}
+		}
+		key := string(kv.Key)
+		resp, err := c.Txn(ctx).If(
+			clientv3.Compare(clientv3.CreateRevision(key), "=", 0),
+		).Then(
+			clientv3.OpPut(key, string(kv.Value), opts...),
+		).Commit()
+		if err != nil {
+			return restored, skipped, fmt.Errorf("failed to restore %q (%w)", key, err)
+		}
+		if !resp.Succeeded {
+			fmt.Fprintf(os.Stderr, "skipped %q, it exists\n", key)
+			skipped++
+			continue
+		}
+		restored++
+	}
+	return restored, skipped, nil
+}
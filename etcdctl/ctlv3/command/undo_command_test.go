@@ -0,0 +1,43 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "y\n", want: true},
+		{input: "Yes\r\n", want: true},
+		{input: "y", want: true},
+		{input: "n\n"},
+		{input: "\n"},
+		{input: ""},
+		{input: "yep\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		assert.Equalf(t, tt.want, confirm(strings.NewReader(tt.input), &out, "Continue?"), "input %q", tt.input)
+		assert.True(t, strings.HasPrefix(out.String(), "Continue? [y/N] "))
+	}
+}
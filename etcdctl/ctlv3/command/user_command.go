@@ -42,6 +42,7 @@ func NewUserCommand() *cobra.Command {
 	ac.AddCommand(newUserGetCommand())
 	ac.AddCommand(newUserListCommand())
 	ac.AddCommand(newUserChangePasswordCommand())
+	ac.AddCommand(newUserRotatePasswordCommand())
 	ac.AddCommand(newUserGrantRoleCommand())
 	ac.AddCommand(newUserRevokeRoleCommand())
 
@@ -108,6 +109,14 @@ func newUserChangePasswordCommand() *cobra.Command {
 	return &cmd
 }
 
+func newUserRotatePasswordCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate-password <user name>",
+		Short: "Replaces password of user with a generated one",
+		Run:   userRotatePasswordCommandFunc,
+	}
+}
+
 func newUserGrantRoleCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "grant-role <user name> <role name>",
@@ -248,6 +257,20 @@ func userChangePasswordCommandFunc(cmd *cobra.Command, args []string) {
 	display.UserChangePassword(*resp)
 }
 
+// userRotatePasswordCommandFunc executes the "user rotate-password" command.
+func userRotatePasswordCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user rotate-password command requires user name as its argument"))
+	}
+
+	resp, err := mustClientFromCmd(cmd).Auth.UserRotatePassword(context.TODO(), args[0])
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	display.UserRotatePassword(args[0], *resp)
+}
+
 // userGrantRoleCommandFunc executes the "user grant-role" command.
 func userGrantRoleCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 2 {
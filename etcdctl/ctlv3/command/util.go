@@ -82,7 +82,11 @@ func commandCtx(cmd *cobra.Command) (context.Context, context.CancelFunc) {
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
-	return context.WithTimeout(context.Background(), timeOut)
+	ctx := context.Background()
+	if printTimingsFromCmd(cmd) {
+		ctx = clientv3.WithRequestTimings(ctx)
+	}
+	return context.WithTimeout(ctx, timeOut)
 }
 
 func isCommandTimeoutFlagSet(cmd *cobra.Command) bool {
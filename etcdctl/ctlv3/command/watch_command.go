@@ -42,15 +42,17 @@ var (
 	watchInteractive bool
 	watchPrevKey     bool
 	progressNotify   bool
+	watchInitial     bool
 )
 
 // NewWatchCommand returns the cobra command for "watch".
 func NewWatchCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "watch [options] [key or prefix] [range_end] [--] [exec-command arg1 arg2 ...]",
-		Short:   "Watches events stream on keys or prefixes",
-		Run:     watchCommandFunc,
-		GroupID: groupKVID,
+		Use:               "watch [options] [key or prefix] [range_end] [--] [exec-command arg1 arg2 ...]",
+		Short:             "Watches events stream on keys or prefixes",
+		Run:               watchCommandFunc,
+		ValidArgsFunction: keyCompletionFunc,
+		GroupID:           groupKVID,
 	}
 
 	cmd.Flags().BoolVarP(&watchInteractive, "interactive", "i", false, "Interactive mode")
@@ -58,6 +60,7 @@ func NewWatchCommand() *cobra.Command {
 	cmd.Flags().Int64Var(&watchRev, "rev", 0, "Revision to start watching")
 	cmd.Flags().BoolVar(&watchPrevKey, "prev-kv", false, "get the previous key-value pair before the event happens")
 	cmd.Flags().BoolVar(&progressNotify, "progress-notify", false, "get periodic watch progress notification from server")
+	cmd.Flags().BoolVar(&watchInitial, "initial-state", false, "get the current key-value pairs as PUT events before the following events")
 
 	return cmd
 }
@@ -160,6 +163,9 @@ func getWatchChan(c *clientv3.Client, args []string) (clientv3.WatchChan, error)
 	if progressNotify {
 		opts = append(opts, clientv3.WithProgressNotify())
 	}
+	if watchInitial {
+		opts = append(opts, clientv3.WithInitialState())
+	}
 	return c.Watch(clientv3.WithRequireLeader(context.Background()), key, opts...), nil
 }
 
@@ -171,6 +177,13 @@ func printWatchCh(c *clientv3.Client, ch clientv3.WatchChan, execArgs []string)
 		if resp.IsProgressNotify() {
 			fmt.Fprintf(os.Stdout, "progress notify: %d\n", resp.Header.Revision)
 		}
+		if pc := resp.PermissionChange; pc != nil {
+			var ranges []string
+			for _, r := range pc.PermittedRanges {
+				ranges = append(ranges, fmt.Sprintf("[%q, %q)", r.Key, r.RangeEnd))
+			}
+			fmt.Fprintf(os.Stderr, "watch permissions of user %q changed, permitted ranges: %s\n", pc.User, strings.Join(ranges, ", "))
+		}
 		display.Watch(resp)
 
 		if len(execArgs) > 0 {
@@ -35,6 +35,9 @@ const (
 	defaultCommandTimeOut   = 5 * time.Second
 	defaultKeepAliveTime    = 2 * time.Second
 	defaultKeepAliveTimeOut = 6 * time.Second
+
+	defaultCompletionTimeout = time.Second
+	defaultCompletionLimit   = 100
 )
 
 var (
@@ -52,11 +55,13 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVarP(&globalFlags.OutputFormat, "write-out", "w", "simple", "set the output format (fields, json, protobuf, simple, table)")
 	rootCmd.PersistentFlags().BoolVar(&globalFlags.IsHex, "hex", false, "print byte strings as hex encoded strings")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.PrintTimings, "print-timings", false, "print to stderr where the server spent the time of key-value requests")
 	rootCmd.RegisterFlagCompletionFunc("write-out", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"fields", "json", "protobuf", "simple", "table"}, cobra.ShellCompDirectiveDefault
 	})
 
 	rootCmd.PersistentFlags().DurationVar(&globalFlags.DialTimeout, "dial-timeout", defaultDialTimeout, "dial timeout for client connections")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.Proxy, "proxy", "", "URL of the HTTP CONNECT (http://, https://) or SOCKS5 (socks5://) proxy to reach the endpoints through (defaults to HTTPS_PROXY)")
 	rootCmd.PersistentFlags().DurationVar(&globalFlags.CommandTimeOut, "command-timeout", defaultCommandTimeOut, "timeout for short running command (excluding dial timeout)")
 	rootCmd.PersistentFlags().DurationVar(&globalFlags.KeepAliveTime, "keepalive-time", defaultKeepAliveTime, "keepalive time for client connections")
 	rootCmd.PersistentFlags().DurationVar(&globalFlags.KeepAliveTimeout, "keepalive-timeout", defaultKeepAliveTimeOut, "keepalive timeout for client connections")
@@ -76,6 +81,10 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&globalFlags.TLS.ServerName, "discovery-srv", "d", "", "domain name to query for SRV records describing cluster endpoints")
 	rootCmd.PersistentFlags().StringVarP(&globalFlags.DNSClusterServiceName, "discovery-srv-name", "", "", "service name to query when using DNS discovery")
 
+	rootCmd.PersistentFlags().Bool("completion-keys", true, "complete keys of key based commands by querying the cluster")
+	rootCmd.PersistentFlags().Duration("completion-timeout", defaultCompletionTimeout, "timeout for querying the cluster when completing keys")
+	rootCmd.PersistentFlags().Int64("completion-limit", defaultCompletionLimit, "maximum number of keys fetched from the cluster when completing keys")
+
 	rootCmd.AddGroup(
 		command.NewKVGroup(),
 		command.NewClusterMaintenanceGroup(),
@@ -88,17 +97,28 @@ func init() {
 		command.NewGetCommand(),
 		command.NewPutCommand(),
 		command.NewDelCommand(),
+		command.NewUndoCommand(),
+		command.NewMoveCommand(),
+		command.NewRenamePrefixCommand(),
 		command.NewTxnCommand(),
+		command.NewCounterCommand(),
 		command.NewCompactionCommand(),
 		command.NewAlarmCommand(),
 		command.NewDefragCommand(),
+		command.NewGarbageCollectCommand(),
+		command.NewJobCommand(),
 		command.NewEndpointCommand(),
 		command.NewMoveLeaderCommand(),
+		command.NewReseedCommand(),
+		command.NewFenceCommand(),
 		command.NewWatchCommand(),
 		command.NewVersionCommand(),
 		command.NewLeaseCommand(),
 		command.NewMemberCommand(),
+		command.NewClusterConfigCommand(),
 		command.NewSnapshotCommand(),
+		command.NewExportCommand(),
+		command.NewImportCommand(),
 		command.NewMakeMirrorCommand(),
 		command.NewLockCommand(),
 		command.NewElectCommand(),
@@ -40,6 +40,7 @@ func init() {
 
 	rootCmd.AddCommand(
 		etcdutl.NewDefragCommand(),
+		etcdutl.NewConvertBackendCommand(),
 		etcdutl.NewSnapshotCommand(),
 		etcdutl.NewHashKVCommand(),
 		etcdutl.NewVersionCommand(),
@@ -48,6 +49,9 @@ func init() {
 		etcdutl.NewListBucketCommand(),
 		etcdutl.NewIterateBucketCommand(),
 		etcdutl.NewHashCommand(),
+		etcdutl.NewWALCommand(),
+		etcdutl.NewRollbackCommand(),
+		etcdutl.NewImportCommand(),
 	)
 }
 
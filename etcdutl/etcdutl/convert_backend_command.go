@@ -0,0 +1,102 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+)
+
+var (
+	convertBackendDataDir string
+	convertBackendEngine  string
+)
+
+// NewConvertBackendCommand returns the cobra command for "convert-backend".
+func NewConvertBackendCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert-backend",
+		Short: "Converts the backend of an etcd data dir to another storage engine",
+		Run:   convertBackendCommandFunc,
+	}
+	cmd.Flags().StringVar(&convertBackendDataDir, "data-dir", "", "Required. Path to an etcd data dir not in use by etcd.")
+	cmd.MarkFlagRequired("data-dir")
+	cmd.MarkFlagDirname("data-dir")
+	cmd.Flags().StringVar(&convertBackendEngine, "backend-engine", "", fmt.Sprintf("Required. Storage engine to convert the backend to, one of: %s|%s.", backend.EngineBolt, backend.EnginePebble))
+	cmd.MarkFlagRequired("backend-engine")
+	cmd.RegisterFlagCompletionFunc("backend-engine", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{backend.EngineBolt, backend.EnginePebble}, cobra.ShellCompDirectiveDefault
+	})
+	return cmd
+}
+
+func convertBackendCommandFunc(cmd *cobra.Command, args []string) {
+	switch convertBackendEngine {
+	case backend.EngineBolt, backend.EnginePebble:
+	default:
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("unknown --backend-engine %q", convertBackendEngine))
+	}
+	if err := ConvertBackend(GetLogger(), convertBackendDataDir, convertBackendEngine); err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError,
+			fmt.Errorf("failed to convert the backend of etcd data[%s] (%w)", convertBackendDataDir, err))
+	}
+}
+
+// ConvertBackend converts the backend of the data dir to the given engine. The
+// converted backend is written next to the current one, which is replaced
+// only once the conversion is complete.
+func ConvertBackend(lg *zap.Logger, dataDir, engine string) error {
+	dbPath := datadir.ToBackendFileName(dataDir)
+	current, err := backend.DetectEngine(dbPath)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		return fmt.Errorf("backend %q does not exist", dbPath)
+	}
+	if current == engine {
+		lg.Info("backend already uses the engine", zap.String("path", dbPath), zap.String("engine", engine))
+		return nil
+	}
+
+	convertPath, oldPath := dbPath+".convert", dbPath+".old"
+	for _, p := range []string{convertPath, oldPath} {
+		if err = os.RemoveAll(p); err != nil {
+			return err
+		}
+	}
+	lg.Info("converting backend", zap.String("path", dbPath), zap.String("from", current), zap.String("to", engine))
+	if err = backend.Convert(lg, dbPath, convertPath, engine); err != nil {
+		return err
+	}
+	if err = os.Rename(dbPath, oldPath); err != nil {
+		return err
+	}
+	if err = os.Rename(convertPath, dbPath); err != nil {
+		return err
+	}
+	if err = os.RemoveAll(oldPath); err != nil {
+		return err
+	}
+	lg.Info("converted backend", zap.String("path", dbPath), zap.String("engine", engine))
+	return nil
+}
@@ -0,0 +1,360 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	importFrom         string
+	importEndpoints    []string
+	importUser         string
+	importCACertFile   string
+	importCertFile     string
+	importKeyFile      string
+	importDialTimeout  time.Duration
+	importPrefix       string
+	importMapACL       bool
+	importEphemeralTTL time.Duration
+	importIncludeEmpty bool
+	importOverwrite    bool
+	importSkipVerify   bool
+)
+
+// NewImportCommand returns the cobra command for "import".
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Imports the nodes of a ZooKeeper or Consul tree into etcd keys",
+		Long: `Imports the nodes under a path of a ZooKeeper ensemble, or the keys under a prefix of
+the Consul KV store, into etcd keys. The path of each node relative to the root of the
+source, appended to --prefix, is the etcd key it is imported to.
+
+Ephemeral ZooKeeper nodes and Consul keys held by a session are attached to a lease with
+a TTL of --ephemeral-ttl, one lease per session. The leases expire unless kept alive,
+the way the nodes vanish with the sessions owning them.
+
+With --map-acl, the ACLs of the ZooKeeper nodes are mapped to etcd roles named after
+the identities they grant permissions to, such as digest:alice. The roles are granted
+read or write permissions on the keys of the nodes, and are left to be granted to users.
+
+Once imported, the source is read again to verify that etcd holds the same values. The
+source should not be written to during the import.`,
+		Run: importCommandFunc,
+	}
+	cmd.Flags().StringVar(&importFrom, "from", "", "Required. Source to import from: zookeeper://[user:password@]host[:port][,host[:port]...][/path], consul://host[:port][/prefix] or consul+https://host[:port][/prefix]")
+	cmd.Flags().StringSliceVar(&importEndpoints, "endpoints", []string{"127.0.0.1:2379"}, "etcd endpoints to import into")
+	cmd.Flags().StringVar(&importUser, "user", "", "etcd username[:password] for authentication")
+	cmd.Flags().StringVar(&importCACertFile, "cacert", "", "verify certificates of TLS-enabled etcd servers using this CA bundle")
+	cmd.Flags().StringVar(&importCertFile, "cert", "", "identify secure client using this TLS certificate file")
+	cmd.Flags().StringVar(&importKeyFile, "key", "", "identify secure client using this TLS key file")
+	cmd.Flags().DurationVar(&importDialTimeout, "dial-timeout", 5*time.Second, "dial timeout for client connections")
+	cmd.Flags().StringVar(&importPrefix, "prefix", "", "Prefix of the etcd keys the nodes are imported to, in place of the root of the source")
+	cmd.Flags().BoolVar(&importMapACL, "map-acl", false, "Map the ACLs of the ZooKeeper nodes to etcd roles")
+	cmd.Flags().DurationVar(&importEphemeralTTL, "ephemeral-ttl", time.Minute, "TTL of the leases ephemeral nodes are attached to")
+	cmd.Flags().BoolVar(&importIncludeEmpty, "include-empty", false, "Import the nodes without a value, which are skipped unless ephemeral")
+	cmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "Overwrite existing etcd keys, which are left untouched otherwise")
+	cmd.Flags().BoolVar(&importSkipVerify, "skip-verify", false, "Skip verifying the imported keys against the source")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagFilename("cacert")
+	cmd.MarkFlagFilename("cert")
+	cmd.MarkFlagFilename("key")
+	return cmd
+}
+
+func importCommandFunc(cmd *cobra.Command, args []string) {
+	ccfg := clientv3.Config{
+		Endpoints:   importEndpoints,
+		DialTimeout: importDialTimeout,
+		Logger:      zap.NewNop(),
+	}
+	if importUser != "" {
+		ccfg.Username, ccfg.Password, _ = strings.Cut(importUser, ":")
+	}
+	tlsinfo := transport.TLSInfo{CertFile: importCertFile, KeyFile: importKeyFile, TrustedCAFile: importCACertFile}
+	if !tlsinfo.Empty() || importCACertFile != "" {
+		tlsConfig, err := tlsinfo.ClientConfig()
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		}
+		ccfg.TLS = tlsConfig
+	}
+	cli, err := clientv3.New(ccfg)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	defer cli.Close()
+
+	res, err := Import(context.Background(), GetLogger(), cli, ImportConfig{
+		From:         importFrom,
+		ConsulToken:  os.Getenv("CONSUL_HTTP_TOKEN"),
+		Prefix:       importPrefix,
+		MapACL:       importMapACL,
+		EphemeralTTL: importEphemeralTTL,
+		IncludeEmpty: importIncludeEmpty,
+		Overwrite:    importOverwrite,
+		SkipVerify:   importSkipVerify,
+	})
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	fmt.Printf("Imported %d keys, %d of them attached to %d leases\n", res.Imported, res.Ephemeral, res.Leases)
+	for _, k := range res.Existing {
+		fmt.Printf("Left existing key %q untouched\n", k)
+	}
+	if len(res.Roles) > 0 {
+		fmt.Printf("Granted permissions to roles %s\n", strings.Join(res.Roles, ", "))
+	}
+	if importSkipVerify {
+		return
+	}
+	for _, m := range res.Mismatches {
+		fmt.Println(m)
+	}
+	if len(res.Mismatches) > 0 {
+		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("%d of %d keys differ from the source", len(res.Mismatches), res.Verified))
+	}
+	fmt.Printf("Verified %d keys\n", res.Verified)
+}
+
+// ImportConfig configures Import.
+type ImportConfig struct {
+	// From is the URL of the source, with the zookeeper, consul or
+	// consul+https scheme.
+	From string
+	// ConsulToken is the ACL token reading the Consul KV store.
+	ConsulToken string
+	// Prefix replaces the root of the source in the imported keys.
+	Prefix string
+	// MapACL maps the ACLs of the ZooKeeper nodes to etcd roles.
+	MapACL bool
+	// EphemeralTTL is the TTL of the leases ephemeral nodes are attached to.
+	EphemeralTTL time.Duration
+	// IncludeEmpty imports the nodes without a value that are not ephemeral.
+	IncludeEmpty bool
+	// Overwrite overwrites the existing keys.
+	Overwrite bool
+	// SkipVerify skips verifying the imported keys against the source.
+	SkipVerify bool
+}
+
+// ImportResult is the outcome of Import.
+type ImportResult struct {
+	// Imported is the number of imported keys.
+	Imported int
+	// Ephemeral is the number of imported keys attached to a lease.
+	Ephemeral int
+	// Leases is the number of leases granted for the ephemeral nodes.
+	Leases int
+	// Existing are the keys left untouched since they already existed.
+	Existing []string
+	// Roles are the roles granted permissions on the imported keys.
+	Roles []string
+	// Verified is the number of keys verified against the source.
+	Verified int
+	// Mismatches describe the keys that differ from the source.
+	Mismatches []string
+}
+
+// ImportNode is a node read from the source of an import.
+type ImportNode struct {
+	// Path is the path of the node relative to the root of the source.
+	Path  string
+	Value []byte
+	// Owner identifies the session owning the node if it is ephemeral.
+	Owner string
+	// ACL lists the permissions on the node, if asked for.
+	ACL []ImportACL
+}
+
+// ImportACL is a permission on a node granted to an identity.
+type ImportACL struct {
+	// Identity is the scheme and ID of the identity, such as digest:alice.
+	Identity string
+	Read     bool
+	Write    bool
+}
+
+// importSource reads the nodes of a tree of another system.
+type importSource interface {
+	// Walk calls fn with each node of the tree, parents before their
+	// children, reading their ACLs if withACL is set.
+	Walk(ctx context.Context, withACL bool, fn func(ImportNode) error) error
+	Close() error
+}
+
+func openImportSource(ctx context.Context, cfg ImportConfig) (importSource, error) {
+	u, err := url.Parse(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source %q: %w", cfg.From, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid source %q: missing host", cfg.From)
+	}
+	switch u.Scheme {
+	case "zookeeper":
+		return newZooKeeperSource(ctx, u)
+	case "consul", "consul+https":
+		if cfg.MapACL {
+			return nil, errors.New("ACLs can only be mapped from zookeeper")
+		}
+		return newConsulSource(u, cfg.ConsulToken), nil
+	}
+	return nil, fmt.Errorf("invalid source %q: unsupported scheme %q", cfg.From, u.Scheme)
+}
+
+// importKey returns the key the node is imported to, or false if the node is
+// not imported.
+func importKey(cfg ImportConfig, n ImportNode) (string, bool) {
+	key := cfg.Prefix + n.Path
+	if key == "" {
+		return "", false
+	}
+	return key, len(n.Value) > 0 || n.Owner != "" || cfg.IncludeEmpty
+}
+
+// Import copies the nodes of a ZooKeeper or Consul tree into etcd keys, then
+// reads the source again to verify them unless asked otherwise.
+func Import(ctx context.Context, lg *zap.Logger, cli *clientv3.Client, cfg ImportConfig) (ImportResult, error) {
+	var res ImportResult
+	src, err := openImportSource(ctx, cfg)
+	if err != nil {
+		return res, err
+	}
+	defer src.Close()
+
+	ttl := int64(cfg.EphemeralTTL / time.Second)
+	if ttl <= 0 {
+		return res, fmt.Errorf("invalid ephemeral TTL %v", cfg.EphemeralTTL)
+	}
+	leases := make(map[string]clientv3.LeaseID)
+	roles := make(map[string]bool)
+	err = src.Walk(ctx, cfg.MapACL, func(n ImportNode) error {
+		key, ok := importKey(cfg, n)
+		if !ok {
+			return nil
+		}
+		var opts []clientv3.OpOption
+		if n.Owner != "" {
+			id, ok := leases[n.Owner]
+			if !ok {
+				resp, gerr := cli.Grant(ctx, ttl)
+				if gerr != nil {
+					return fmt.Errorf("cannot grant a lease for session %s: %w", n.Owner, gerr)
+				}
+				id = resp.ID
+				leases[n.Owner] = id
+			}
+			opts = append(opts, clientv3.WithLease(id))
+		}
+
+		put := clientv3.OpPut(key, string(n.Value), opts...)
+		if cfg.Overwrite {
+			if _, perr := cli.Do(ctx, put); perr != nil {
+				return fmt.Errorf("cannot import %q: %w", key, perr)
+			}
+		} else {
+			resp, terr := cli.Txn(ctx).If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).Then(put).Commit()
+			if terr != nil {
+				return fmt.Errorf("cannot import %q: %w", key, terr)
+			}
+			if !resp.Succeeded {
+				res.Existing = append(res.Existing, key)
+				return nil
+			}
+		}
+		res.Imported++
+		if n.Owner != "" {
+			res.Ephemeral++
+		}
+
+		for _, acl := range n.ACL {
+			if gerr := grantImportACL(ctx, cli, key, acl, roles); gerr != nil {
+				return gerr
+			}
+			if !roles[acl.Identity] {
+				roles[acl.Identity] = true
+				res.Roles = append(res.Roles, acl.Identity)
+			}
+		}
+		return nil
+	})
+	res.Leases = len(leases)
+	if err != nil {
+		return res, err
+	}
+	lg.Info("imported nodes", zap.String("from", cfg.From), zap.Int("keys", res.Imported), zap.Int("existing", len(res.Existing)))
+	if cfg.SkipVerify {
+		return res, nil
+	}
+
+	err = src.Walk(ctx, false, func(n ImportNode) error {
+		key, ok := importKey(cfg, n)
+		if !ok {
+			return nil
+		}
+		resp, gerr := cli.Get(ctx, key)
+		if gerr != nil {
+			return fmt.Errorf("cannot verify %q: %w", key, gerr)
+		}
+		res.Verified++
+		switch {
+		case len(resp.Kvs) == 0:
+			res.Mismatches = append(res.Mismatches, fmt.Sprintf("key %q is missing", key))
+		case !bytes.Equal(resp.Kvs[0].Value, n.Value):
+			res.Mismatches = append(res.Mismatches, fmt.Sprintf("key %q differs from %s", key, n.Path))
+		case n.Owner != "" && resp.Kvs[0].Lease == 0:
+			res.Mismatches = append(res.Mismatches, fmt.Sprintf("key %q of an ephemeral node is not attached to a lease", key))
+		}
+		return nil
+	})
+	return res, err
+}
+
+// grantImportACL grants the role of the identity of acl its permission on
+// key, creating the role unless known to exist.
+func grantImportACL(ctx context.Context, cli *clientv3.Client, key string, acl ImportACL, roles map[string]bool) error {
+	perm := clientv3.PermissionType(clientv3.PermReadWrite)
+	if !acl.Write {
+		perm = clientv3.PermissionType(clientv3.PermRead)
+	} else if !acl.Read {
+		perm = clientv3.PermissionType(clientv3.PermWrite)
+	}
+	if !roles[acl.Identity] {
+		if _, err := cli.RoleAdd(ctx, acl.Identity); err != nil && !errors.Is(err, rpctypes.ErrRoleAlreadyExist) {
+			return fmt.Errorf("cannot add role %s: %w", acl.Identity, err)
+		}
+	}
+	if _, err := cli.RoleGrantPermission(ctx, acl.Identity, key, "", perm); err != nil {
+		return fmt.Errorf("cannot grant role %s a permission on %q: %w", acl.Identity, key, err)
+	}
+	return nil
+}
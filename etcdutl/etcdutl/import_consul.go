@@ -0,0 +1,105 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// consulSource reads the keys under a prefix of the Consul KV store through
+// its HTTP API.
+type consulSource struct {
+	client *http.Client
+	// url lists the keys under the prefix.
+	url   string
+	root  string
+	token string
+}
+
+// consulKV is an entry of the Consul KV store. Its value is base64 encoded
+// by the API.
+type consulKV struct {
+	Key     string
+	Value   []byte
+	Session string
+}
+
+// newConsulSource reads consul://host[:port][/prefix], or
+// consul+https://host[:port][/prefix] over TLS, with the ACL token if not
+// empty.
+func newConsulSource(u *url.URL, token string) *consulSource {
+	scheme := "http"
+	if u.Scheme == "consul+https" {
+		scheme = "https"
+	}
+	root := strings.TrimPrefix(u.Path, "/")
+	list := url.URL{Scheme: scheme, Host: u.Host, Path: "/v1/kv/" + root, RawQuery: "recurse=true"}
+	return &consulSource{client: http.DefaultClient, url: list.String(), root: root, token: token}
+}
+
+func (s *consulSource) Walk(ctx context.Context, withACL bool, fn func(ImportNode) error) error {
+	kvs, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	for _, kv := range kvs {
+		if err = fn(ImportNode{Path: strings.TrimPrefix(kv.Key, s.root), Value: kv.Value, Owner: kv.Session}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *consulSource) list(ctx context.Context) ([]consulKV, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.token != "" {
+		req.Header.Set("X-Consul-Token", s.token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// no key under the prefix
+		return nil, nil
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("cannot list consul keys: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var kvs []consulKV
+	if err = json.NewDecoder(resp.Body).Decode(&kvs); err != nil {
+		return nil, fmt.Errorf("cannot decode consul keys: %w", err)
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	return kvs, nil
+}
+
+func (s *consulSource) Close() error {
+	return nil
+}
@@ -0,0 +1,437 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The subset of the ZooKeeper client protocol needed to read a tree of
+// znodes. Requests and replies are jute records framed by their length.
+const (
+	zkOpGetData     = 4
+	zkOpGetACL      = 6
+	zkOpGetChildren = 8
+	zkOpClose       = -11
+	zkOpSetAuth     = 100
+
+	zkXidWatchEvent = -1
+	zkXidPing       = -2
+	zkXidSetAuth    = -4
+
+	zkPermRead   = 1
+	zkPermWrite  = 2
+	zkPermCreate = 4
+	zkPermDelete = 8
+
+	zkDefaultPort    = "2181"
+	zkSessionTimeout = 30 * time.Second
+	// zkMaxPacketSize bounds the replies read, above the default 1 MiB
+	// jute.maxbuffer of the servers.
+	zkMaxPacketSize = 16 << 20
+)
+
+// zkError is an error code returned by a ZooKeeper server.
+type zkError int32
+
+const (
+	zkErrNoNode     zkError = -101
+	zkErrNoAuth     zkError = -102
+	zkErrAuthFailed zkError = -115
+)
+
+func (e zkError) Error() string {
+	switch e {
+	case zkErrNoNode:
+		return "zookeeper: node does not exist"
+	case zkErrNoAuth:
+		return "zookeeper: not authorized"
+	case zkErrAuthFailed:
+		return "zookeeper: authentication failed"
+	}
+	return fmt.Sprintf("zookeeper: error code %d", int32(e))
+}
+
+// zkSource reads the znodes under a path of a ZooKeeper ensemble.
+type zkSource struct {
+	conn *zkConn
+	// root is the path of the imported tree, empty for the whole tree.
+	root string
+}
+
+// newZooKeeperSource connects to the first reachable server of
+// zookeeper://[user:password@]host[:port][,host[:port]...][/path]. The user
+// and password authenticate with the digest scheme.
+func newZooKeeperSource(ctx context.Context, u *url.URL) (*zkSource, error) {
+	var lastErr error
+	for _, host := range strings.Split(u.Host, ",") {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, zkDefaultPort)
+		}
+		conn, err := dialZooKeeper(ctx, host)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if u.User != nil {
+			password, _ := u.User.Password()
+			if err = conn.setAuth("digest", u.User.Username()+":"+password); err != nil {
+				conn.close()
+				return nil, err
+			}
+		}
+		return &zkSource{conn: conn, root: strings.TrimSuffix(u.Path, "/")}, nil
+	}
+	return nil, fmt.Errorf("cannot connect to zookeeper %s: %w", u.Host, lastErr)
+}
+
+func (s *zkSource) Walk(ctx context.Context, withACL bool, fn func(ImportNode) error) error {
+	root := s.root
+	if root == "" {
+		root = "/"
+	}
+	return s.walk(ctx, root, withACL, fn)
+}
+
+func (s *zkSource) walk(ctx context.Context, p string, withACL bool, fn func(ImportNode) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, owner, err := s.conn.getData(p)
+	if errors.Is(err, zkErrNoNode) {
+		// deleted during the walk
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", p, err)
+	}
+	n := ImportNode{Path: strings.TrimPrefix(p, s.root), Value: data}
+	if n.Path == "/" && s.root == "" {
+		n.Path = ""
+	}
+	if owner != 0 {
+		n.Owner = fmt.Sprintf("%x", owner)
+	}
+	if withACL {
+		if n.ACL, err = s.conn.getACL(p); err != nil && !errors.Is(err, zkErrNoNode) {
+			return fmt.Errorf("cannot read the ACL of %s: %w", p, err)
+		}
+	}
+	if err = fn(n); err != nil {
+		return err
+	}
+
+	children, err := s.conn.getChildren(p)
+	if errors.Is(err, zkErrNoNode) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot list the children of %s: %w", p, err)
+	}
+	sort.Strings(children)
+	for _, c := range children {
+		if p == "/" && c == "zookeeper" {
+			// the quotas and configuration of the ensemble itself
+			continue
+		}
+		if err = s.walk(ctx, strings.TrimSuffix(p, "/")+"/"+c, withACL, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *zkSource) Close() error {
+	return s.conn.close()
+}
+
+// zkConn is a ZooKeeper session sending one request at a time.
+type zkConn struct {
+	conn net.Conn
+	xid  int32
+}
+
+func dialZooKeeper(ctx context.Context, host string) (*zkConn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	c := &zkConn{conn: conn}
+
+	var req zkEncoder
+	req.int32(0) // protocol version
+	req.int64(0) // last zxid seen
+	req.int32(int32(zkSessionTimeout / time.Millisecond))
+	req.int64(0) // session ID
+	req.buffer(make([]byte, 16))
+	req.bool(false) // read only
+	resp, err := c.roundTrip(req.Bytes())
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	d := zkDecoder{b: resp}
+	d.int32() // protocol version
+	timeout := d.int32()
+	if d.err != nil || timeout <= 0 {
+		conn.Close()
+		return nil, fmt.Errorf("zookeeper %s refused the session", host)
+	}
+	return c, nil
+}
+
+func (c *zkConn) setAuth(scheme, auth string) error {
+	var req zkEncoder
+	req.int32(0) // auth type
+	req.string(scheme)
+	req.buffer([]byte(auth))
+	_, err := c.request(zkXidSetAuth, zkOpSetAuth, req.Bytes())
+	return err
+}
+
+func (c *zkConn) getData(p string) (data []byte, ephemeralOwner int64, err error) {
+	var req zkEncoder
+	req.string(p)
+	req.bool(false) // watch
+	resp, err := c.request(c.nextXid(), zkOpGetData, req.Bytes())
+	if err != nil {
+		return nil, 0, err
+	}
+	d := zkDecoder{b: resp}
+	data = d.buffer()
+	ephemeralOwner = d.stat()
+	return data, ephemeralOwner, d.err
+}
+
+func (c *zkConn) getChildren(p string) ([]string, error) {
+	var req zkEncoder
+	req.string(p)
+	req.bool(false) // watch
+	resp, err := c.request(c.nextXid(), zkOpGetChildren, req.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	d := zkDecoder{b: resp}
+	n := d.int32()
+	children := make([]string, 0, max(n, 0))
+	for i := int32(0); i < n && d.err == nil; i++ {
+		children = append(children, d.string())
+	}
+	return children, d.err
+}
+
+// getACL returns the ACL of the znode at p, mapped to the identities the
+// permissions are granted to. The permissions of everyone and of IP
+// addresses, and the admin permission, have no etcd counterpart and are left
+// out.
+func (c *zkConn) getACL(p string) ([]ImportACL, error) {
+	var req zkEncoder
+	req.string(p)
+	resp, err := c.request(c.nextXid(), zkOpGetACL, req.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	d := zkDecoder{b: resp}
+	n := d.int32()
+	var acl []ImportACL
+	for i := int32(0); i < n && d.err == nil; i++ {
+		perms, scheme, id := d.int32(), d.string(), d.string()
+		switch scheme {
+		case "world", "ip":
+			continue
+		case "digest":
+			// digest IDs are user:base64(sha1(user:password))
+			id, _, _ = strings.Cut(id, ":")
+		}
+		a := ImportACL{
+			Identity: scheme + ":" + id,
+			Read:     perms&zkPermRead != 0,
+			Write:    perms&(zkPermWrite|zkPermCreate|zkPermDelete) != 0,
+		}
+		if a.Read || a.Write {
+			acl = append(acl, a)
+		}
+	}
+	return acl, d.err
+}
+
+func (c *zkConn) close() error {
+	c.request(c.nextXid(), zkOpClose, nil)
+	return c.conn.Close()
+}
+
+func (c *zkConn) nextXid() int32 {
+	c.xid++
+	return c.xid
+}
+
+// request sends a request and returns the body of its reply, skipping the
+// pings and watch events sent meanwhile.
+func (c *zkConn) request(xid, op int32, body []byte) ([]byte, error) {
+	var req zkEncoder
+	req.int32(xid)
+	req.int32(op)
+	req.Write(body)
+	if err := c.write(req.Bytes()); err != nil {
+		return nil, err
+	}
+	for {
+		resp, err := c.read()
+		if err != nil {
+			return nil, err
+		}
+		d := zkDecoder{b: resp}
+		rxid := d.int32()
+		d.int64() // zxid
+		code := d.int32()
+		if d.err != nil {
+			return nil, d.err
+		}
+		if rxid == zkXidPing || rxid == zkXidWatchEvent {
+			continue
+		}
+		if rxid != xid {
+			return nil, fmt.Errorf("zookeeper: reply to request %d while waiting for %d", rxid, xid)
+		}
+		if code != 0 {
+			return nil, zkError(code)
+		}
+		return d.b, nil
+	}
+}
+
+func (c *zkConn) roundTrip(packet []byte) ([]byte, error) {
+	if err := c.write(packet); err != nil {
+		return nil, err
+	}
+	return c.read()
+}
+
+func (c *zkConn) write(packet []byte) error {
+	c.conn.SetDeadline(time.Now().Add(zkSessionTimeout))
+	buf := binary.BigEndian.AppendUint32(make([]byte, 0, 4+len(packet)), uint32(len(packet)))
+	_, err := c.conn.Write(append(buf, packet...))
+	return err
+}
+
+func (c *zkConn) read() ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(c.conn, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > zkMaxPacketSize {
+		return nil, fmt.Errorf("zookeeper: reply of %d bytes is too large", n)
+	}
+	packet := make([]byte, n)
+	_, err := io.ReadFull(c.conn, packet)
+	return packet, err
+}
+
+// zkEncoder encodes jute records.
+type zkEncoder struct {
+	bytes.Buffer
+}
+
+func (e *zkEncoder) int32(v int32) {
+	e.Write(binary.BigEndian.AppendUint32(nil, uint32(v)))
+}
+
+func (e *zkEncoder) int64(v int64) {
+	e.Write(binary.BigEndian.AppendUint64(nil, uint64(v)))
+}
+
+func (e *zkEncoder) bool(v bool) {
+	if v {
+		e.WriteByte(1)
+	} else {
+		e.WriteByte(0)
+	}
+}
+
+func (e *zkEncoder) buffer(v []byte) {
+	e.int32(int32(len(v)))
+	e.Write(v)
+}
+
+func (e *zkEncoder) string(v string) {
+	e.buffer([]byte(v))
+}
+
+// zkDecoder decodes jute records, keeping the first error met.
+type zkDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *zkDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.b) {
+		d.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	v := d.b[:n]
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *zkDecoder) int32() int32 {
+	if v := d.next(4); v != nil {
+		return int32(binary.BigEndian.Uint32(v))
+	}
+	return 0
+}
+
+func (d *zkDecoder) int64() int64 {
+	if v := d.next(8); v != nil {
+		return int64(binary.BigEndian.Uint64(v))
+	}
+	return 0
+}
+
+// buffer decodes a byte buffer, nil if null.
+func (d *zkDecoder) buffer() []byte {
+	n := d.int32()
+	if n == -1 {
+		return nil
+	}
+	return bytes.Clone(d.next(int(n)))
+}
+
+func (d *zkDecoder) string() string {
+	return string(d.buffer())
+}
+
+// stat decodes the stat of a znode and returns its ephemeral owner.
+func (d *zkDecoder) stat() int64 {
+	d.next(4 * 8) // czxid, mzxid, ctime, mtime
+	d.next(3 * 4) // version, cversion, aversion
+	owner := d.int64()
+	d.next(2*4 + 8) // data length, number of children, pzxid
+	return owner
+}
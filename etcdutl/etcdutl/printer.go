@@ -17,6 +17,7 @@ package etcdutl
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
@@ -29,6 +30,7 @@ var OutputFormat string
 
 type printer interface {
 	DBStatus(snapshot.Status)
+	DBDeepStatus(snapshot.DeepStatus)
 	DBHashKV(HashKV)
 }
 
@@ -62,8 +64,9 @@ func newPrinterUnsupported(n string) printer {
 	return &printerUnsupported{printerRPC{nil, f}}
 }
 
-func (p *printerUnsupported) DBStatus(snapshot.Status) { p.p(nil) }
-func (p *printerUnsupported) DBHashKV(HashKV)          { p.p(nil) }
+func (p *printerUnsupported) DBStatus(snapshot.Status)         { p.p(nil) }
+func (p *printerUnsupported) DBDeepStatus(snapshot.DeepStatus) { p.p(nil) }
+func (p *printerUnsupported) DBHashKV(HashKV)                  { p.p(nil) }
 
 func makeDBStatusTable(ds snapshot.Status) (hdr []string, rows [][]string) {
 	hdr = []string{"hash", "revision", "total keys", "total size", "version"}
@@ -77,6 +80,14 @@ func makeDBStatusTable(ds snapshot.Status) (hdr []string, rows [][]string) {
 	return hdr, rows
 }
 
+func makeDBChecksTable(ds snapshot.DeepStatus) (hdr []string, rows [][]string) {
+	hdr = []string{"check", "result", "detail", "errors"}
+	for _, c := range ds.Checks {
+		rows = append(rows, []string{c.Name, c.Result, c.Detail, strings.Join(c.Errors, "; ")})
+	}
+	return hdr, rows
+}
+
 func makeDBHashKVTable(ds HashKV) (hdr []string, rows [][]string) {
 	hdr = []string{"hash", "hash revision", "compact revision"}
 	rows = append(rows, []string{
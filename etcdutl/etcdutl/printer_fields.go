@@ -30,6 +30,19 @@ func (p *fieldsPrinter) DBStatus(r snapshot.Status) {
 	fmt.Println(`"Version" :`, r.Version)
 }
 
+func (p *fieldsPrinter) DBDeepStatus(r snapshot.DeepStatus) {
+	p.DBStatus(r.Status)
+	fmt.Println(`"Passed" :`, r.Passed)
+	for _, c := range r.Checks {
+		fmt.Printf("\"Check\" : %q\n", c.Name)
+		fmt.Printf("\"Result\" : %q\n", c.Result)
+		fmt.Printf("\"Detail\" : %q\n", c.Detail)
+		for _, e := range c.Errors {
+			fmt.Printf("\"Error\" : %q\n", e)
+		}
+	}
+}
+
 func (p *fieldsPrinter) DBHashKV(r HashKV) {
 	fmt.Println(`"Hash" :`, r.Hash)
 	fmt.Println(`"Hash revision" :`, r.HashRevision)
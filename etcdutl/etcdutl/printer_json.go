@@ -32,8 +32,9 @@ func newJSONPrinter() printer {
 	}
 }
 
-func (p *jsonPrinter) DBStatus(r snapshot.Status) { printJSON(r) }
-func (p *jsonPrinter) DBHashKV(r HashKV)          { printJSON(r) }
+func (p *jsonPrinter) DBStatus(r snapshot.Status)         { printJSON(r) }
+func (p *jsonPrinter) DBDeepStatus(r snapshot.DeepStatus) { printJSON(r) }
+func (p *jsonPrinter) DBHashKV(r HashKV)                  { printJSON(r) }
 
 // !!! Share ??
 func printJSON(v any) {
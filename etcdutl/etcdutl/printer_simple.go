@@ -30,6 +30,16 @@ func (s *simplePrinter) DBStatus(ds snapshot.Status) {
 	}
 }
 
+func (s *simplePrinter) DBDeepStatus(ds snapshot.DeepStatus) {
+	s.DBStatus(ds.Status)
+	for _, c := range ds.Checks {
+		fmt.Println(strings.Join([]string{c.Name, c.Result, c.Detail}, ", "))
+		for _, e := range c.Errors {
+			fmt.Println("\t" + e)
+		}
+	}
+}
+
 func (s *simplePrinter) DBHashKV(ds HashKV) {
 	_, rows := makeDBHashKVTable(ds)
 	for _, row := range rows {
@@ -36,6 +36,17 @@ func (tp *tablePrinter) DBStatus(r snapshot.Status) {
 	table.Render()
 }
 
+func (tp *tablePrinter) DBDeepStatus(r snapshot.DeepStatus) {
+	tp.DBStatus(r.Status)
+	hdr, rows := makeDBChecksTable(r)
+	table := tablewriter.NewTable(os.Stdout)
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
 func (tp *tablePrinter) DBHashKV(r HashKV) {
 	hdr, rows := makeDBHashKVTable(r)
 	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
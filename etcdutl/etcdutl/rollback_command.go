@@ -0,0 +1,559 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+	"go.etcd.io/etcd/server/v3/auth"
+	"go.etcd.io/etcd/server/v3/embed"
+	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v2store"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
+	"go.etcd.io/etcd/server/v3/etcdserver/apply"
+	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
+	"go.etcd.io/etcd/server/v3/lease"
+	serverstorage "go.etcd.io/etcd/server/v3/storage"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+	"go.etcd.io/etcd/server/v3/storage/wal"
+	"go.etcd.io/etcd/server/v3/storage/wal/walpb"
+	"go.etcd.io/raft/v3/confchange"
+	"go.etcd.io/raft/v3/raftpb"
+	"go.etcd.io/raft/v3/tracker"
+)
+
+var (
+	rollbackDataDir      string
+	rollbackWALDir       string
+	rollbackToIndex      uint64
+	rollbackFromSnapshot string
+	rollbackOutput       string
+)
+
+// NewRollbackCommand returns the cobra command for "rollback".
+func NewRollbackCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Rebuilds the backend of a data directory not in use by etcd as of a previous index",
+		Long: `Rebuilds the backend as it was right after the entry at --to-index was applied, by
+replaying the committed WAL entries up to that index into a fresh backend. The data
+directory is left untouched.
+
+Entries are replayed on top of --from-snapshot if given, otherwise on top of the newest
+backend snapshot in the data directory at or below --to-index, otherwise on top of an
+empty backend. The WAL must still hold every entry after the starting point.
+
+The rebuilt backend is written to --output as a snapshot file, which can be restored
+with "etcdutl snapshot restore" to start a new cluster from the rolled back state.`,
+		Run: rollbackCommandFunc,
+	}
+	cmd.Flags().StringVar(&rollbackDataDir, "data-dir", "", "Required. Path to a data directory not in use by etcd.")
+	cmd.Flags().StringVar(&rollbackWALDir, "wal-dir", "", "Path to the WAL directory (use --data-dir if none given)")
+	cmd.Flags().Uint64Var(&rollbackToIndex, "to-index", 0, "Required. Last raft index to replay.")
+	cmd.Flags().StringVar(&rollbackFromSnapshot, "from-snapshot", "", "Path to a backend snapshot taken at or below --to-index to replay from")
+	cmd.Flags().StringVar(&rollbackOutput, "output", "", "Required. Path to write the rolled back backend to, as a snapshot file.")
+	cmd.MarkFlagRequired("data-dir")
+	cmd.MarkFlagRequired("to-index")
+	cmd.MarkFlagRequired("output")
+	cmd.MarkFlagDirname("data-dir")
+	cmd.MarkFlagDirname("wal-dir")
+	cmd.MarkFlagFilename("from-snapshot")
+	return cmd
+}
+
+func rollbackCommandFunc(cmd *cobra.Command, args []string) {
+	res, err := Rollback(GetLogger(), RollbackConfig{
+		DataDir:      rollbackDataDir,
+		WALDir:       rollbackWALDir,
+		ToIndex:      rollbackToIndex,
+		FromSnapshot: rollbackFromSnapshot,
+		OutputPath:   rollbackOutput,
+	})
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	fmt.Printf("Rolled back to index %d by replaying %d entries after index %d, at revision %d\n", rollbackToIndex, res.Replayed, res.FromIndex, res.Revision)
+	fmt.Printf("Saved rolled back backend to %s\n", rollbackOutput)
+}
+
+// RollbackConfig configures Rollback.
+type RollbackConfig struct {
+	// DataDir is the data directory of the member.
+	DataDir string
+	// WALDir is the WAL directory, if it is not in DataDir.
+	WALDir string
+	// ToIndex is the index of the last entry to replay.
+	ToIndex uint64
+	// FromSnapshot is the backend snapshot to replay the entries on. If empty,
+	// the newest backend snapshot of the data directory at or below ToIndex is
+	// used, or an empty backend if there is none.
+	FromSnapshot string
+	// OutputPath is where the rolled back backend is written, as a snapshot
+	// file with an integrity hash.
+	OutputPath string
+}
+
+// RollbackResult is the outcome of Rollback.
+type RollbackResult struct {
+	// FromIndex is the consistent index of the backend the entries were
+	// replayed on.
+	FromIndex uint64
+	// Replayed is the number of replayed entries.
+	Replayed int
+	// Revision is the current revision of the rolled back backend.
+	Revision int64
+}
+
+// Rollback rebuilds the backend of a data directory not in use by etcd as it
+// was right after the entry at cfg.ToIndex was applied. The committed WAL
+// entries up to that index are replayed into a fresh backend, which is saved
+// to cfg.OutputPath.
+func Rollback(lg *zap.Logger, cfg RollbackConfig) (RollbackResult, error) {
+	if cfg.ToIndex == 0 {
+		return RollbackResult{}, errors.New("--to-index must be >0")
+	}
+	if cfg.OutputPath == "" {
+		return RollbackResult{}, errors.New("--output is required")
+	}
+	if fileutil.Exist(cfg.OutputPath) {
+		return RollbackResult{}, fmt.Errorf("output %q already exists", cfg.OutputPath)
+	}
+	walDir := cfg.WALDir
+	if walDir == "" {
+		walDir = datadir.ToWALDir(cfg.DataDir)
+	}
+
+	unlock, err := lockWAL(walDir)
+	if err != nil {
+		return RollbackResult{}, err
+	}
+	defer unlock()
+
+	partPath := cfg.OutputPath + ".part"
+	if err = os.RemoveAll(partPath); err != nil {
+		return RollbackResult{}, err
+	}
+	defer os.RemoveAll(partPath)
+	if err = seedRollbackBackend(lg, cfg, partPath); err != nil {
+		return RollbackResult{}, err
+	}
+
+	r, err := newRollbackReplayer(lg, partPath)
+	if err != nil {
+		return RollbackResult{}, err
+	}
+	res := RollbackResult{FromIndex: r.ci.ConsistentIndex()}
+	if res.FromIndex > cfg.ToIndex {
+		r.close()
+		return res, fmt.Errorf("backend to replay from is at index %d, past --to-index %d", res.FromIndex, cfg.ToIndex)
+	}
+	md, ents, err := readRollbackEntries(lg, walDir, res.FromIndex, cfg.ToIndex)
+	if err != nil {
+		r.close()
+		return res, err
+	}
+	r.cl.SetID(types.ID(md.NodeID), types.ID(md.ClusterID))
+	r.status.memberID = types.ID(md.NodeID)
+
+	lg.Info("replaying WAL entries", zap.Uint64("from-index", res.FromIndex), zap.Uint64("to-index", cfg.ToIndex), zap.Int("entries", len(ents)))
+	for i := range ents {
+		if err = r.apply(ents[i]); err != nil {
+			r.close()
+			return res, fmt.Errorf("failed to replay entry at index %d: %w", ents[i].Index, err)
+		}
+	}
+	res.Replayed = len(ents)
+	res.Revision = r.kv.Rev()
+	if err = r.close(); err != nil {
+		return res, err
+	}
+
+	if err = appendSnapshotHash(partPath); err != nil {
+		return res, err
+	}
+	if err = os.Rename(partPath, cfg.OutputPath); err != nil {
+		return res, err
+	}
+	lg.Info("saved rolled back backend", zap.String("path", cfg.OutputPath), zap.Int64("revision", res.Revision))
+	return res, nil
+}
+
+// seedRollbackBackend copies the backend snapshot to replay from to path. If
+// there is none, path is left absent so that an empty backend is created.
+func seedRollbackBackend(lg *zap.Logger, cfg RollbackConfig, path string) error {
+	src := cfg.FromSnapshot
+	if src == "" {
+		var err error
+		if src, err = newestSnapshotDB(datadir.ToSnapDir(cfg.DataDir), cfg.ToIndex); err != nil {
+			return err
+		}
+	}
+	if src == "" {
+		lg.Info("no backend snapshot to replay from, replaying into an empty backend")
+		return nil
+	}
+	lg.Info("replaying from backend snapshot", zap.String("path", src))
+	return copySnapshotDB(src, path)
+}
+
+// newestSnapshotDB returns the path of the newest backend snapshot received
+// by the member, named "<index>.snap.db", that is at or below index.
+func newestSnapshotDB(snapDir string, index uint64) (string, error) {
+	names, err := fileutil.ReadDir(snapDir, fileutil.WithExt(".db"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	var newest string
+	var newestIndex uint64
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".snap.db") {
+			continue
+		}
+		idx, perr := strconv.ParseUint(strings.TrimSuffix(name, ".snap.db"), 16, 64)
+		if perr != nil || idx > index || idx < newestIndex {
+			continue
+		}
+		newest, newestIndex = filepath.Join(snapDir, name), idx
+	}
+	return newest, nil
+}
+
+// copySnapshotDB copies a backend snapshot to dst, verifying and dropping the
+// integrity hash that snapshots saved from a member end with.
+func copySnapshotDB(src, dst string) error {
+	srcf, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcf.Close()
+	dstf, err := os.OpenFile(dst, os.O_RDWR|os.O_CREATE|os.O_EXCL, fileutil.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+	defer dstf.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(dstf, h), srcf)
+	if err != nil {
+		return err
+	}
+	if n%512 != sha256.Size {
+		return dstf.Sync()
+	}
+	if _, err = dstf.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	h.Reset()
+	if _, err = io.CopyN(h, dstf, n-sha256.Size); err != nil {
+		return err
+	}
+	sum := make([]byte, sha256.Size)
+	if _, err = io.ReadFull(dstf, sum); err != nil {
+		return err
+	}
+	if string(sum) != string(h.Sum(nil)) {
+		return fmt.Errorf("snapshot %q does not match its sha256 hash", src)
+	}
+	if err = dstf.Truncate(n - sha256.Size); err != nil {
+		return err
+	}
+	return dstf.Sync()
+}
+
+// appendSnapshotHash appends the sha256 hash of the backend at path, as
+// snapshots saved from a member have, so that it restores without
+// --skip-hash-check.
+func appendSnapshotHash(path string) error {
+	f, err := os.OpenFile(path, os.O_RDWR, fileutil.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return err
+	}
+	if _, err = f.Write(h.Sum(nil)); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// readRollbackEntries returns the WAL metadata and the committed entries
+// after fromIndex up to toIndex.
+func readRollbackEntries(lg *zap.Logger, walDir string, fromIndex, toIndex uint64) (pb.Metadata, []raftpb.Entry, error) {
+	var md pb.Metadata
+	walSnaps, err := wal.ValidSnapshotEntries(lg, walDir)
+	if err != nil {
+		return md, nil, err
+	}
+	var walSnap walpb.Snapshot
+	for _, s := range walSnaps {
+		if s.Index <= fromIndex && s.Index >= walSnap.Index {
+			walSnap = s
+		}
+	}
+	w, err := wal.OpenForRead(lg, walDir, walSnap)
+	if err != nil {
+		return md, nil, fmt.Errorf("failed to open WAL from index %d: %w", walSnap.Index, err)
+	}
+	defer w.Close()
+	metadata, st, ents, err := w.ReadAll()
+	if err != nil {
+		return md, nil, fmt.Errorf("failed to read WAL from index %d: %w", walSnap.Index, err)
+	}
+	if err = md.Unmarshal(metadata); err != nil {
+		return md, nil, err
+	}
+	if toIndex > st.Commit {
+		return md, nil, fmt.Errorf("--to-index %d is past the commit index %d", toIndex, st.Commit)
+	}
+	if toIndex == fromIndex {
+		return md, nil, nil
+	}
+	if len(ents) == 0 || ents[0].Index > fromIndex+1 || ents[len(ents)-1].Index < toIndex {
+		return md, nil, fmt.Errorf("WAL does not hold all entries from index %d to %d", fromIndex+1, toIndex)
+	}
+	first := fromIndex + 1 - ents[0].Index
+	return md, ents[first : first+toIndex-fromIndex], nil
+}
+
+// rollbackReplayer applies WAL entries to a backend the way a member does,
+// without a running server.
+type rollbackReplayer struct {
+	lg *zap.Logger
+
+	be        backend.Backend
+	hooks     *serverstorage.BackendHooks
+	ci        cindex.ConsistentIndexer
+	cl        *membership.RaftCluster
+	lessor    lease.Lessor
+	kv        mvcc.WatchableKV
+	authStore auth.AuthStore
+	status    *rollbackRaftStatus
+	applier   apply.UberApplier
+	confState raftpb.ConfState
+}
+
+func newRollbackReplayer(lg *zap.Logger, path string) (*rollbackReplayer, error) {
+	r := &rollbackReplayer{lg: lg, status: &rollbackRaftStatus{}}
+	r.ci = cindex.NewConsistentIndex(nil)
+	r.hooks = serverstorage.NewBackendHooks(lg, r.ci)
+	bcfg := backend.DefaultBackendConfig(lg)
+	bcfg.Path = path
+	bcfg.Hooks = r.hooks
+	r.be = backend.New(bcfg)
+	r.ci.SetBackend(r.be)
+	schema.CreateMetaBucket(r.be.BatchTx())
+
+	tx := r.be.ReadTx()
+	tx.RLock()
+	if cs := schema.UnsafeConfStateFromBackend(lg, tx); cs != nil {
+		r.confState = *cs
+	}
+	tx.RUnlock()
+
+	r.cl = membership.NewCluster(lg)
+	r.cl.SetBackend(schema.NewMembershipBackend(lg, r.be))
+	r.cl.UnsafeLoad()
+	st := v2store.New(etcdserver.StoreClusterPrefix, etcdserver.StoreKeysPrefix)
+	r.cl.Store(st)
+	r.cl.SetStore(st)
+
+	// The lessor is never promoted, so leases only expire through the
+	// revocations recorded in the WAL.
+	r.lessor = lease.NewLessor(lg, r.be, r.cl, lease.LessorConfig{})
+	r.kv = mvcc.New(lg, r.be, r.lessor, mvcc.StoreConfig{})
+	tp, err := auth.NewTokenProvider(lg, "", nil, 0)
+	if err != nil {
+		r.close()
+		return nil, err
+	}
+	r.authStore = auth.NewAuthStore(lg, schema.NewAuthBackend(lg, r.be), tp, bcrypt.DefaultCost)
+	alarmStore, err := v3alarm.NewAlarmStore(lg, schema.NewAlarmBackend(lg, r.be))
+	if err != nil {
+		r.close()
+		return nil, err
+	}
+	r.applier = apply.NewUberApplier(apply.ApplierOptions{
+		Logger:               lg,
+		KV:                   r.kv,
+		AlarmStore:           alarmStore,
+		CompactionHolds:      v3compactor.NewHoldStore(r.be),
+		ClusterConfig:        clusterconfig.NewStore(r.be),
+		AuthStore:            r.authStore,
+		Lessor:               r.lessor,
+		Cluster:              r.cl,
+		RaftStatus:           r.status,
+		SnapshotServer:       r.status,
+		ConsistentIndex:      r.ci,
+		Backend:              r.be,
+		WarningApplyDuration: embed.DefaultWarningApplyDuration,
+		// The quota the entries were applied with is unknown, and requests
+		// rejected for space are followed by a NOSPACE alarm in the WAL,
+		// which caps the applier on its own.
+		QuotaBackendBytesCfg: -1,
+	})
+	return r, nil
+}
+
+func (r *rollbackReplayer) apply(e raftpb.Entry) error {
+	r.ci.SetConsistentApplyingIndex(e.Index, e.Term)
+	r.status.index, r.status.term = e.Index, e.Term
+	var err error
+	switch e.Type {
+	case raftpb.EntryNormal:
+		err = r.applyNormal(e)
+	case raftpb.EntryConfChange:
+		err = r.applyConfChange(e)
+	default:
+		err = fmt.Errorf("unknown entry type %s", e.Type)
+	}
+	if err != nil {
+		return err
+	}
+	if r.ci.ConsistentIndex() < e.Index {
+		r.ci.SetConsistentIndex(e.Index, e.Term)
+	}
+	return nil
+}
+
+func (r *rollbackReplayer) applyNormal(e raftpb.Entry) error {
+	if len(e.Data) == 0 {
+		return nil
+	}
+	var req pb.InternalRaftRequest
+	if err := req.Unmarshal(e.Data); err != nil || req.V2 != nil {
+		return errors.New("v2 requests cannot be replayed")
+	}
+	// Requests without side effects are only applied to answer the client.
+	if req.Range != nil || req.AuthUserGet != nil || req.AuthRoleGet != nil || req.AuthStatus != nil {
+		return nil
+	}
+	res := r.applier.Apply(&req, membership.ApplyBoth)
+	if res != nil && res.Physc != nil {
+		<-res.Physc
+	}
+	return nil
+}
+
+func (r *rollbackReplayer) applyConfChange(e raftpb.Entry) error {
+	var cc raftpb.ConfChange
+	if err := cc.Unmarshal(e.Data); err != nil {
+		return err
+	}
+	if err := r.cl.ValidateConfigurationChange(cc, membership.ApplyBoth); err != nil {
+		r.lg.Info("skipped rejected configuration change", zap.Uint64("index", e.Index), zap.Error(err))
+		return nil
+	}
+	cs, err := nextConfState(r.confState, cc)
+	if err != nil {
+		return err
+	}
+	r.confState = cs
+	r.hooks.SetConfState(&r.confState)
+
+	switch cc.Type {
+	case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode:
+		ctx := new(membership.ConfigChangeContext)
+		if err = json.Unmarshal(cc.Context, ctx); err != nil {
+			return err
+		}
+		if ctx.IsPromote {
+			r.cl.PromoteMember(ctx.Member.ID, membership.ApplyBoth)
+		} else {
+			r.cl.AddMember(&ctx.Member, membership.ApplyBoth)
+		}
+	case raftpb.ConfChangeRemoveNode:
+		r.cl.RemoveMember(types.ID(cc.NodeID), membership.ApplyBoth)
+	case raftpb.ConfChangeUpdateNode:
+		m := new(membership.Member)
+		if err = json.Unmarshal(cc.Context, m); err != nil {
+			return err
+		}
+		r.cl.UpdateRaftAttributes(m.ID, m.RaftAttributes, membership.ApplyBoth)
+	}
+	return nil
+}
+
+// nextConfState returns the raft configuration after applying cc to cs.
+func nextConfState(cs raftpb.ConfState, cc raftpb.ConfChange) (raftpb.ConfState, error) {
+	trk := tracker.MakeProgressTracker(1, 0)
+	cfg, prs, err := confchange.Restore(confchange.Changer{Tracker: trk}, cs)
+	if err != nil {
+		return cs, err
+	}
+	trk.Config, trk.Progress = cfg, prs
+	if cfg, prs, err = (confchange.Changer{Tracker: trk}).Simple(cc.AsV2().Changes...); err != nil {
+		return cs, err
+	}
+	trk.Config, trk.Progress = cfg, prs
+	return trk.ConfState(), nil
+}
+
+func (r *rollbackReplayer) close() error {
+	if r.authStore != nil {
+		r.authStore.Close()
+	}
+	if r.kv != nil {
+		r.kv.Close()
+	}
+	if r.lessor != nil {
+		r.lessor.Stop()
+	}
+	r.be.ForceCommit()
+	return r.be.Close()
+}
+
+// rollbackRaftStatus stands in for the raft state of the member while
+// replaying.
+type rollbackRaftStatus struct {
+	memberID    types.ID
+	index, term uint64
+}
+
+func (s *rollbackRaftStatus) MemberID() types.ID     { return s.memberID }
+func (s *rollbackRaftStatus) Leader() types.ID       { return s.memberID }
+func (s *rollbackRaftStatus) CommittedIndex() uint64 { return s.index }
+func (s *rollbackRaftStatus) AppliedIndex() uint64   { return s.index }
+func (s *rollbackRaftStatus) Term() uint64           { return s.term }
+
+// ForceSnapshot is a no-op, as no snapshot is taken while replaying.
+func (s *rollbackRaftStatus) ForceSnapshot() {}
@@ -0,0 +1,123 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/pkg/v3/pbutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/wal"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// newRollbackTestDataDir creates a data directory whose WAL adds member 1 at
+// index 1 and then puts "key<i>" at every index i from 2 up to lastIndex.
+func newRollbackTestDataDir(t *testing.T, lastIndex uint64) string {
+	lg := zaptest.NewLogger(t)
+	dataDir := t.TempDir()
+	require.NoError(t, fileutil.TouchDirAll(lg, datadir.ToWALDir(dataDir)))
+
+	w, err := wal.Create(lg, datadir.ToWALDir(dataDir), pbutil.MustMarshal(&etcdserverpb.Metadata{NodeID: 1, ClusterID: 2}))
+	require.NoError(t, err)
+	m := membership.Member{ID: 1, RaftAttributes: membership.RaftAttributes{PeerURLs: []string{"http://localhost:2380"}}}
+	cctx, err := json.Marshal(&membership.ConfigChangeContext{Member: m})
+	require.NoError(t, err)
+	cc := raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 1, Context: cctx}
+	ents := []raftpb.Entry{{Index: 1, Term: 1, Type: raftpb.EntryConfChange, Data: pbutil.MustMarshal(&cc)}}
+	for i := uint64(2); i <= lastIndex; i++ {
+		req := etcdserverpb.InternalRaftRequest{
+			Header: &etcdserverpb.RequestHeader{ID: i},
+			Put:    &etcdserverpb.PutRequest{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte("v")},
+		}
+		ents = append(ents, raftpb.Entry{Index: i, Term: 1, Data: pbutil.MustMarshal(&req)})
+	}
+	require.NoError(t, w.Save(raftpb.HardState{Term: 1, Commit: lastIndex, Vote: 1}, ents))
+	require.NoError(t, w.Close())
+	return dataDir
+}
+
+// readRollbackOutput returns the keys and the revision of a rolled back
+// backend.
+func readRollbackOutput(t *testing.T, path string) ([]string, int64) {
+	lg := zaptest.NewLogger(t)
+	dbPath := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, copySnapshotDB(path, dbPath))
+	be := backend.NewDefaultBackend(lg, dbPath)
+	defer be.Close()
+	kv := mvcc.NewStore(lg, be, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	defer kv.Close()
+	res, err := kv.Range(t.Context(), []byte{0}, []byte{0xff}, mvcc.RangeOptions{})
+	require.NoError(t, err)
+	var keys []string
+	for _, kv := range res.KVs {
+		keys = append(keys, string(kv.Key))
+	}
+	return keys, res.Rev
+}
+
+func TestRollback(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	dataDir := newRollbackTestDataDir(t, 5)
+	out := filepath.Join(t.TempDir(), "rollback.db")
+
+	res, err := Rollback(lg, RollbackConfig{DataDir: dataDir, ToIndex: 3, OutputPath: out})
+	require.NoError(t, err)
+	assert.Equal(t, RollbackResult{FromIndex: 0, Replayed: 3, Revision: 3}, res)
+	keys, rev := readRollbackOutput(t, out)
+	assert.Equal(t, []string{"key2", "key3"}, keys)
+	assert.Equal(t, int64(3), rev)
+
+	// the rolled back backend is itself a starting point to replay from
+	out2 := filepath.Join(t.TempDir(), "rollback.db")
+	res, err = Rollback(lg, RollbackConfig{DataDir: dataDir, ToIndex: 5, FromSnapshot: out, OutputPath: out2})
+	require.NoError(t, err)
+	assert.Equal(t, RollbackResult{FromIndex: 3, Replayed: 2, Revision: 5}, res)
+	keys, rev = readRollbackOutput(t, out2)
+	assert.Equal(t, []string{"key2", "key3", "key4", "key5"}, keys)
+	assert.Equal(t, int64(5), rev)
+}
+
+func TestRollbackErrors(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	dataDir := newRollbackTestDataDir(t, 5)
+	dir := t.TempDir()
+	out := filepath.Join(dir, "rollback.db")
+
+	_, err := Rollback(lg, RollbackConfig{DataDir: dataDir, ToIndex: 6, OutputPath: out})
+	require.ErrorContains(t, err, "past the commit index 5")
+	assert.False(t, fileutil.Exist(out))
+	assert.False(t, fileutil.Exist(out+".part"))
+
+	_, err = Rollback(lg, RollbackConfig{DataDir: dataDir, ToIndex: 4, OutputPath: out})
+	require.NoError(t, err)
+	_, err = Rollback(lg, RollbackConfig{DataDir: dataDir, ToIndex: 4, OutputPath: out})
+	require.ErrorContains(t, err, "already exists")
+	_, err = Rollback(lg, RollbackConfig{DataDir: dataDir, ToIndex: 3, FromSnapshot: out, OutputPath: filepath.Join(dir, "other.db")})
+	require.ErrorContains(t, err, "past --to-index 3")
+}
@@ -15,9 +15,16 @@
 package etcdutl
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
 	"go.etcd.io/etcd/etcdutl/v3/snapshot"
@@ -42,6 +49,10 @@ var (
 	initialMmapSize     = backend.InitialMmapSize
 	markCompacted       bool
 	revisionBump        uint64
+	stripAuth           bool
+	resetLeases         string
+	restoreProgress     string
+	statusDeep          bool
 )
 
 // NewSnapshotCommand returns the cobra command for "snapshot".
@@ -56,21 +67,29 @@ func NewSnapshotCommand() *cobra.Command {
 }
 
 func newSnapshotStatusCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "status <filename>",
 		Short: "Gets backend snapshot status of a given file",
 		Long: `When --write-out is set to simple, this command prints out comma-separated status lists for each endpoint.
 The items in the lists are hash, revision, total keys, total size.
+
+With --deep, the whole snapshot is validated and the result of each check is
+printed after the status. The command fails if a check fails.
 `,
 		Run: SnapshotStatusCommandFunc,
 	}
+	cmd.Flags().BoolVar(&statusDeep, "deep", false, "Validate the whole snapshot: integrity hash, buckets, revisions, leases and keyspace hash")
+	return cmd
 }
 
 func NewSnapshotRestoreCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restore <filename> --data-dir {output dir} [options]",
 		Short: "Restores an etcd member snapshot to an etcd directory",
-		Run:   snapshotRestoreCommandFunc,
+		Long: `Restores an etcd member snapshot to an etcd directory.
+If <filename> is "-", the snapshot is read from the standard input.
+`,
+		Run: snapshotRestoreCommandFunc,
 	}
 	cmd.Flags().StringVar(&restoreDataDir, "data-dir", "", "Path to the output data directory")
 	cmd.Flags().StringVar(&restoreWALDir, "wal-dir", "", "Path to the WAL directory (use --data-dir if none given)")
@@ -82,6 +101,9 @@ func NewSnapshotRestoreCommand() *cobra.Command {
 	cmd.Flags().Uint64Var(&initialMmapSize, "initial-memory-map-size", initialMmapSize, "Initial memory map size of the database in bytes. It uses the default value if not defined or defined to 0")
 	cmd.Flags().Uint64Var(&revisionBump, "bump-revision", 0, "How much to increase the latest revision after restore")
 	cmd.Flags().BoolVar(&markCompacted, "mark-compacted", false, "Mark the latest revision after restore as the point of scheduled compaction (required if --bump-revision > 0, disallowed otherwise)")
+	cmd.Flags().BoolVar(&stripAuth, "strip-auth", false, "Remove all users and roles and disable authentication after restore")
+	cmd.Flags().StringVar(&resetLeases, "reset-leases", "", "Reset all leases to the given TTL in seconds, or drop all leases and detach the keys from them if set to 'drop'")
+	cmd.Flags().StringVar(&restoreProgress, "progress", "", "Report the progress of the restore to stderr ('simple' or 'json')")
 
 	cmd.MarkFlagDirname("data-dir")
 	cmd.MarkFlagDirname("wal-dir")
@@ -98,6 +120,17 @@ func SnapshotStatusCommandFunc(cmd *cobra.Command, args []string) {
 
 	lg := GetLogger()
 	sp := snapshot.NewV3(lg)
+	if statusDeep {
+		ds, err := sp.DeepStatus(args[0])
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		printer.DBDeepStatus(ds)
+		if !ds.Passed {
+			cobrautl.ExitWithError(cobrautl.ExitError, errors.New("snapshot failed deep validation"))
+		}
+		return
+	}
 	ds, err := sp.Status(args[0])
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
@@ -107,7 +140,7 @@ func SnapshotStatusCommandFunc(cmd *cobra.Command, args []string) {
 
 func snapshotRestoreCommandFunc(_ *cobra.Command, args []string) {
 	SnapshotRestoreCommandFunc(restoreCluster, restoreClusterToken, restoreDataDir, restoreWALDir,
-		restorePeerURLs, restoreName, skipHashCheck, initialMmapSize, revisionBump, markCompacted, args)
+		restorePeerURLs, restoreName, skipHashCheck, initialMmapSize, revisionBump, markCompacted, stripAuth, resetLeases, restoreProgress, args)
 }
 
 func SnapshotRestoreCommandFunc(restoreCluster string,
@@ -120,6 +153,9 @@ func SnapshotRestoreCommandFunc(restoreCluster string,
 	initialMmapSize uint64,
 	revisionBump uint64,
 	markCompacted bool,
+	stripAuth bool,
+	resetLeases string,
+	progress string,
 	args []string,
 ) {
 	if len(args) != 1 {
@@ -132,6 +168,29 @@ func SnapshotRestoreCommandFunc(restoreCluster string,
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
 	}
 
+	var report func(snapshot.RestoreProgress)
+	switch progress {
+	case "":
+	case "simple":
+		report = func(p snapshot.RestoreProgress) { printSimpleRestoreProgress(os.Stderr, p) }
+	case "json":
+		report = func(p snapshot.RestoreProgress) { printJSONRestoreProgress(os.Stderr, p) }
+	default:
+		err := fmt.Errorf("--progress must be 'simple' or 'json' (set to %q)", progress)
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+
+	var resetLeasesTTL int64
+	dropLeases := resetLeases == "drop"
+	if resetLeases != "" && !dropLeases {
+		ttl, err := strconv.ParseInt(resetLeases, 10, 64)
+		if err != nil || ttl <= 0 {
+			err = fmt.Errorf("--reset-leases must be 'drop' or a TTL in seconds > 0 (set to %q)", resetLeases)
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		}
+		resetLeasesTTL = ttl
+	}
+
 	dataDir := restoreDataDir
 	if dataDir == "" {
 		dataDir = restoreName + ".etcd"
@@ -145,8 +204,15 @@ func SnapshotRestoreCommandFunc(restoreCluster string,
 	lg := GetLogger()
 	sp := snapshot.NewV3(lg)
 
+	var snapshotReader io.Reader
+	if args[0] == "-" {
+		snapshotReader = os.Stdin
+	}
+
 	if err := sp.Restore(snapshot.RestoreConfig{
 		SnapshotPath:        args[0],
+		SnapshotReader:      snapshotReader,
+		Progress:            report,
 		Name:                restoreName,
 		OutputDataDir:       dataDir,
 		OutputWALDir:        walDir,
@@ -157,11 +223,40 @@ func SnapshotRestoreCommandFunc(restoreCluster string,
 		InitialMmapSize:     initialMmapSize,
 		RevisionBump:        revisionBump,
 		MarkCompacted:       markCompacted,
+		StripAuth:           stripAuth,
+		ResetLeasesTTL:      resetLeasesTTL,
+		DropLeases:          dropLeases,
 	}); err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
 }
 
+// printSimpleRestoreProgress prints a line such as
+// "copy: 64 MB of 128 MB read in 2.1s".
+func printSimpleRestoreProgress(w io.Writer, p snapshot.RestoreProgress) {
+	read := humanize.Bytes(uint64(p.Bytes))
+	if p.TotalBytes > 0 {
+		read += " of " + humanize.Bytes(uint64(p.TotalBytes))
+	}
+	fmt.Fprintf(w, "%s: %s read in %v\n", p.Stage, read, p.Elapsed.Round(100*time.Millisecond))
+}
+
+// printJSONRestoreProgress prints a JSON object per line, such as
+// {"stage":"copy","bytes":67108864,"total_bytes":134217728,"elapsed_seconds":2.1}.
+// total_bytes is omitted if the size of the snapshot is not known.
+func printJSONRestoreProgress(w io.Writer, p snapshot.RestoreProgress) {
+	b, err := json.Marshal(struct {
+		Stage          string  `json:"stage"`
+		Bytes          int64   `json:"bytes"`
+		TotalBytes     int64   `json:"total_bytes,omitempty"`
+		ElapsedSeconds float64 `json:"elapsed_seconds"`
+	}{p.Stage, p.Bytes, p.TotalBytes, p.Elapsed.Seconds()})
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "%s\n", b)
+}
+
 func initialClusterFromName(name string) string {
 	n := name
 	if name == "" {
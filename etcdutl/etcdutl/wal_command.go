@@ -0,0 +1,243 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+	"go.etcd.io/etcd/server/v3/storage/wal"
+	"go.etcd.io/etcd/server/v3/storage/wal/walpb"
+)
+
+var (
+	walPruneDataDir          string
+	walPruneWALDir           string
+	walPruneKeepFromSnapshot int
+	walPruneDryRun           bool
+)
+
+// NewWALCommand returns the cobra command for "wal".
+func NewWALCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wal <subcommand>",
+		Short: "Manages the WAL of a data directory not in use by etcd",
+	}
+	cmd.AddCommand(NewWALPruneCommand())
+	return cmd
+}
+
+// NewWALPruneCommand returns the cobra command for "wal prune".
+func NewWALPruneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Removes the WAL files fully covered by a valid snapshot",
+		Long: `Removes the WAL files whose entries all precede a snapshot, so that they are not
+needed to restart the member from it.
+
+The snapshot is the --keep-from-snapshot newest snapshot that is recorded in the WAL
+and whose snapshot file loads successfully. Before removing anything, the WAL is
+verified to be readable from that snapshot, and the backend is verified to have
+applied at least up to it.`,
+		Run: walPruneCommandFunc,
+	}
+	cmd.Flags().StringVar(&walPruneDataDir, "data-dir", "", "Required. Path to a data directory not in use by etcd.")
+	cmd.Flags().StringVar(&walPruneWALDir, "wal-dir", "", "Path to the WAL directory (use --data-dir if none given)")
+	cmd.Flags().IntVar(&walPruneKeepFromSnapshot, "keep-from-snapshot", 1, "Keep the WAL needed to restart from the n-th newest valid snapshot, so that older snapshots remain usable if newer ones are lost")
+	cmd.Flags().BoolVar(&walPruneDryRun, "dry-run", false, "Only print the WAL files that would be removed")
+	cmd.MarkFlagRequired("data-dir")
+	cmd.MarkFlagDirname("data-dir")
+	cmd.MarkFlagDirname("wal-dir")
+	return cmd
+}
+
+func walPruneCommandFunc(cmd *cobra.Command, args []string) {
+	res, err := PruneWAL(GetLogger(), WALPruneConfig{
+		DataDir:          walPruneDataDir,
+		WALDir:           walPruneWALDir,
+		KeepFromSnapshot: walPruneKeepFromSnapshot,
+		DryRun:           walPruneDryRun,
+	})
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	verb := "Removed"
+	if walPruneDryRun {
+		verb = "Would remove"
+	}
+	for _, f := range res.Files {
+		fmt.Printf("%s %s\n", verb, f)
+	}
+	fmt.Printf("%s %d WAL files (%d bytes) before snapshot at index %d\n", verb, len(res.Files), res.Bytes, res.Snapshot.Index)
+}
+
+// WALPruneConfig configures PruneWAL.
+type WALPruneConfig struct {
+	// DataDir is the data directory of the member.
+	DataDir string
+	// WALDir is the WAL directory, if it is not in DataDir.
+	WALDir string
+	// KeepFromSnapshot selects the snapshot to keep the WAL from, 1 being the
+	// newest valid snapshot.
+	KeepFromSnapshot int
+	// DryRun only reports the WAL files that would be removed.
+	DryRun bool
+}
+
+// WALPruneResult is the outcome of PruneWAL.
+type WALPruneResult struct {
+	// Snapshot is the snapshot the WAL is kept from.
+	Snapshot walpb.Snapshot
+	// Files are the paths of the removed WAL files.
+	Files []string
+	// Bytes is the total size of the removed WAL files.
+	Bytes int64
+}
+
+// PruneWAL removes the WAL files of a data directory not in use by etcd
+// that are fully covered by a valid snapshot.
+func PruneWAL(lg *zap.Logger, cfg WALPruneConfig) (WALPruneResult, error) {
+	if cfg.KeepFromSnapshot < 1 {
+		return WALPruneResult{}, fmt.Errorf("--keep-from-snapshot must be >=1 (set to %d)", cfg.KeepFromSnapshot)
+	}
+	walDir := cfg.WALDir
+	if walDir == "" {
+		walDir = datadir.ToWALDir(cfg.DataDir)
+	}
+
+	unlock, err := lockWAL(walDir)
+	if err != nil {
+		return WALPruneResult{}, err
+	}
+	defer unlock()
+
+	walSnap, err := selectPruneSnapshot(lg, cfg.DataDir, walDir, cfg.KeepFromSnapshot)
+	if err != nil {
+		return WALPruneResult{}, err
+	}
+	if _, err = wal.Verify(lg, walDir, walSnap); err != nil {
+		return WALPruneResult{}, fmt.Errorf("failed to verify WAL from snapshot at index %d: %w", walSnap.Index, err)
+	}
+	if err = verifyBackendCoversSnapshot(lg, cfg.DataDir, walSnap); err != nil {
+		return WALPruneResult{}, err
+	}
+
+	names, err := wal.FilesBefore(lg, walDir, walSnap)
+	if err != nil {
+		return WALPruneResult{}, err
+	}
+	res := WALPruneResult{Snapshot: walSnap}
+	for _, name := range names {
+		p := filepath.Join(walDir, name)
+		fi, serr := os.Stat(p)
+		if serr != nil {
+			return res, serr
+		}
+		if !cfg.DryRun {
+			if err = os.Remove(p); err != nil {
+				return res, err
+			}
+			lg.Info("removed WAL file", zap.String("path", p))
+		}
+		res.Files = append(res.Files, p)
+		res.Bytes += fi.Size()
+	}
+	if cfg.DryRun || len(res.Files) == 0 {
+		return res, nil
+	}
+
+	dir, err := fileutil.OpenDir(walDir)
+	if err != nil {
+		return res, err
+	}
+	defer dir.Close()
+	return res, fileutil.Fsync(dir)
+}
+
+// lockWAL locks the last WAL file, which a running etcd always holds locked.
+func lockWAL(walDir string) (func(), error) {
+	names, err := fileutil.ReadDir(walDir, fileutil.WithExt(".wal"))
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no WAL files found in %q", walDir)
+	}
+	sort.Strings(names)
+	l, err := fileutil.TryLockFile(filepath.Join(walDir, names[len(names)-1]), os.O_WRONLY, fileutil.PrivateFileMode)
+	if err != nil {
+		if errors.Is(err, fileutil.ErrLocked) {
+			return nil, fmt.Errorf("WAL in %q is in use, stop etcd first", walDir)
+		}
+		return nil, err
+	}
+	return func() { l.Close() }, nil
+}
+
+// selectPruneSnapshot returns the n-th newest snapshot that is recorded in
+// the WAL and whose snapshot file loads successfully.
+func selectPruneSnapshot(lg *zap.Logger, dataDir, walDir string, n int) (walpb.Snapshot, error) {
+	walSnaps, err := wal.ValidSnapshotEntries(lg, walDir)
+	if err != nil {
+		return walpb.Snapshot{}, err
+	}
+	ss := snap.New(lg, datadir.ToSnapDir(dataDir))
+	found := 0
+	for i := len(walSnaps) - 1; i >= 0; i-- {
+		if walSnaps[i].Index == 0 {
+			continue
+		}
+		if _, err = ss.LoadNewestAvailable(walSnaps[i : i+1]); err != nil {
+			if errors.Is(err, snap.ErrNoSnapshot) {
+				lg.Warn("skipped snapshot without a valid snapshot file", zap.Uint64("index", walSnaps[i].Index))
+				continue
+			}
+			return walpb.Snapshot{}, err
+		}
+		if found++; found == n {
+			return walSnaps[i], nil
+		}
+	}
+	return walpb.Snapshot{}, fmt.Errorf("found %d valid snapshots, need at least %d to prune the WAL", found, n)
+}
+
+// verifyBackendCoversSnapshot checks that the backend has applied the entries
+// up to the snapshot, as they are no longer replayable once the WAL is pruned.
+func verifyBackendCoversSnapshot(lg *zap.Logger, dataDir string, walSnap walpb.Snapshot) error {
+	dbPath := datadir.ToBackendFileName(dataDir)
+	if !fileutil.Exist(dbPath) {
+		return fmt.Errorf("backend %q not found", dbPath)
+	}
+	be := backend.NewDefaultBackend(lg, dbPath)
+	defer be.Close()
+	index, _ := schema.ReadConsistentIndex(be.ReadTx())
+	if index < walSnap.Index {
+		return fmt.Errorf("backend consistent index %d is behind snapshot at index %d", index, walSnap.Index)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/pkg/v3/pbutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+	"go.etcd.io/etcd/server/v3/storage/wal"
+	"go.etcd.io/etcd/server/v3/storage/wal/walpb"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// newPruneTestDataDir creates a data directory whose WAL spans several
+// segments, with snapshots at indexes 10 and 20 and a backend applied up to
+// appliedIndex.
+func newPruneTestDataDir(t *testing.T, appliedIndex uint64) string {
+	oldSegmentSizeBytes := wal.SegmentSizeBytes
+	wal.SegmentSizeBytes = 4096
+	defer func() {
+		wal.SegmentSizeBytes = oldSegmentSizeBytes
+	}()
+
+	lg := zaptest.NewLogger(t)
+	dataDir := t.TempDir()
+	require.NoError(t, fileutil.TouchDirAll(lg, datadir.ToWALDir(dataDir)))
+	require.NoError(t, fileutil.TouchDirAll(lg, datadir.ToSnapDir(dataDir)))
+
+	w, err := wal.Create(lg, datadir.ToWALDir(dataDir), pbutil.MustMarshal(&etcdserverpb.Metadata{NodeID: 1, ClusterID: 2}))
+	require.NoError(t, err)
+	ss := snap.New(lg, datadir.ToSnapDir(dataDir))
+	confState := raftpb.ConfState{Voters: []uint64{1}}
+	for i := uint64(1); i <= 30; i++ {
+		ents := []raftpb.Entry{{Index: i, Term: 1, Data: make([]byte, 1024)}}
+		require.NoError(t, w.Save(raftpb.HardState{Term: 1, Commit: i, Vote: 1}, ents))
+		if i%10 == 0 && i < 30 {
+			require.NoError(t, ss.SaveSnap(raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: i, Term: 1, ConfState: confState}}))
+			require.NoError(t, w.SaveSnapshot(walpb.Snapshot{Index: i, Term: 1, ConfState: &confState}))
+		}
+	}
+	require.NoError(t, w.Close())
+
+	be := backend.NewDefaultBackend(lg, datadir.ToBackendFileName(dataDir))
+	tx := be.BatchTx()
+	tx.LockOutsideApply()
+	schema.UnsafeCreateMetaBucket(tx)
+	schema.UnsafeUpdateConsistentIndex(tx, appliedIndex, 1)
+	tx.Unlock()
+	be.ForceCommit()
+	require.NoError(t, be.Close())
+	return dataDir
+}
+
+func TestPruneWAL(t *testing.T) {
+	for _, keep := range []int{1, 2} {
+		dataDir := newPruneTestDataDir(t, 30)
+		walDir := datadir.ToWALDir(dataDir)
+		before, err := fileutil.ReadDir(walDir, fileutil.WithExt(".wal"))
+		require.NoError(t, err)
+		require.Greater(t, len(before), 3)
+
+		res, err := PruneWAL(zaptest.NewLogger(t), WALPruneConfig{DataDir: dataDir, KeepFromSnapshot: keep, DryRun: true})
+		require.NoError(t, err)
+		require.NotEmpty(t, res.Files)
+		assert.Equal(t, uint64(30-10*keep), res.Snapshot.Index)
+		assert.Positive(t, res.Bytes)
+		after, err := fileutil.ReadDir(walDir, fileutil.WithExt(".wal"))
+		require.NoError(t, err)
+		require.Equal(t, before, after)
+
+		pruned, err := PruneWAL(zaptest.NewLogger(t), WALPruneConfig{DataDir: dataDir, KeepFromSnapshot: keep})
+		require.NoError(t, err)
+		require.Equal(t, res, pruned)
+		after, err = fileutil.ReadDir(walDir, fileutil.WithExt(".wal"))
+		require.NoError(t, err)
+		require.Len(t, after, len(before)-len(res.Files))
+
+		// the WAL is still readable from the snapshot and nothing else is prunable
+		_, err = wal.Verify(zaptest.NewLogger(t), walDir, res.Snapshot)
+		require.NoError(t, err)
+		again, err := PruneWAL(zaptest.NewLogger(t), WALPruneConfig{DataDir: dataDir, KeepFromSnapshot: keep})
+		require.NoError(t, err)
+		assert.Empty(t, again.Files)
+	}
+}
+
+func TestPruneWALErrors(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+
+	_, err := PruneWAL(lg, WALPruneConfig{DataDir: newPruneTestDataDir(t, 30), KeepFromSnapshot: 3})
+	require.ErrorContains(t, err, "found 2 valid snapshots")
+
+	_, err = PruneWAL(lg, WALPruneConfig{DataDir: newPruneTestDataDir(t, 15), KeepFromSnapshot: 1})
+	require.ErrorContains(t, err, "is behind snapshot")
+
+	_, err = PruneWAL(lg, WALPruneConfig{DataDir: newPruneTestDataDir(t, 30), KeepFromSnapshot: 0})
+	require.Error(t, err)
+
+	// a running etcd holds the lock of the last WAL file
+	dataDir := newPruneTestDataDir(t, 30)
+	w, err := wal.Open(lg, datadir.ToWALDir(dataDir), walpb.Snapshot{Index: 20, Term: 1})
+	require.NoError(t, err)
+	_, _, _, err = w.ReadAll()
+	require.NoError(t, err)
+	defer w.Close()
+	_, err = PruneWAL(lg, WALPruneConfig{DataDir: dataDir, KeepFromSnapshot: 1})
+	require.ErrorContains(t, err, "in use")
+}
@@ -0,0 +1,405 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/zap"
+
+	bolt "go.etcd.io/bbolt"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/lease/leasepb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+// Results of the checks of a deep validation.
+const (
+	CheckPassed  = "passed"
+	CheckFailed  = "failed"
+	CheckSkipped = "skipped"
+)
+
+// maxCheckErrors bounds the errors reported by a check, the others are only
+// counted.
+const maxCheckErrors = 10
+
+// CheckResult is the result of a check of the deep validation of a snapshot
+// file.
+type CheckResult struct {
+	Name string `json:"name"`
+	// Result is CheckPassed, CheckFailed or CheckSkipped.
+	Result string `json:"result"`
+	// Detail summarizes what was checked, or why the check was skipped.
+	Detail string `json:"detail,omitempty"`
+	// Errors are the problems found by a failed check.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// DeepStatus is the snapshot file status with the results of its deep
+// validation.
+type DeepStatus struct {
+	Status
+	// Passed is whether no check failed.
+	Passed bool          `json:"passed"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// checker records the result of a check.
+type checker struct {
+	CheckResult
+	errs int
+}
+
+func newChecker(name string) *checker {
+	return &checker{CheckResult: CheckResult{Name: name, Result: CheckPassed}}
+}
+
+func (c *checker) failf(format string, args ...any) {
+	c.Result = CheckFailed
+	c.errs++
+	if len(c.Errors) < maxCheckErrors {
+		c.Errors = append(c.Errors, fmt.Sprintf(format, args...))
+	}
+}
+
+func (c *checker) skipf(format string, args ...any) {
+	c.Result = CheckSkipped
+	c.Detail = fmt.Sprintf(format, args...)
+}
+
+func (c *checker) result() CheckResult {
+	if c.errs > len(c.Errors) {
+		c.Errors = append(c.Errors, fmt.Sprintf("and %d more errors", c.errs-len(c.Errors)))
+	}
+	return c.CheckResult
+}
+
+// DeepStatus returns the snapshot file information and validates the whole
+// snapshot.
+func (s *v3Manager) DeepStatus(dbPath string) (ds DeepStatus, err error) {
+	sha, err := checkSHA256(dbPath)
+	if err != nil {
+		return ds, err
+	}
+
+	db, err := bolt.Open(dbPath, 0o400, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		return ds, err
+	}
+	defer db.Close()
+
+	integrity := newChecker("integrity")
+	buckets := newChecker("buckets")
+	revisions := newChecker("revisions")
+	leases := newChecker("leases")
+	if err = db.View(func(tx *bolt.Tx) error {
+		for _, e := range integrityErrors(tx) {
+			integrity.failf("%s", e)
+		}
+		if integrity.Result == CheckFailed {
+			// a corrupted file cannot be traversed safely.
+			for _, c := range []*checker{buckets, revisions, leases} {
+				c.skipf("the integrity check failed")
+			}
+			return nil
+		}
+
+		if ds.Status, err = readStatus(tx); err != nil {
+			buckets.failf("%v", err)
+		} else {
+			buckets.Detail = fmt.Sprintf("read %d keys at revision %d", ds.TotalKey, ds.Revision)
+		}
+		for _, name := range [][]byte{schema.Key.Name(), schema.Meta.Name()} {
+			if tx.Bucket(name) == nil {
+				buckets.failf("missing bucket %q", name)
+			}
+		}
+
+		keys := checkRevisions(tx, revisions)
+		checkLeases(tx, keys, leases)
+		return nil
+	}); err != nil {
+		return ds, err
+	}
+
+	hashKV := newChecker("hashkv")
+	if revisions.Result != CheckPassed {
+		hashKV.skipf("the revisions check did not pass")
+	} else if err = s.checkHashKV(dbPath, hashKV); err != nil {
+		return ds, err
+	}
+
+	ds.Passed = true
+	for _, c := range []*checker{sha, integrity, buckets, revisions, leases, hashKV} {
+		ds.Checks = append(ds.Checks, c.result())
+		ds.Passed = ds.Passed && c.Result != CheckFailed
+	}
+	return ds, nil
+}
+
+// checkSHA256 checks the integrity hash appended to the snapshot, if any.
+func checkSHA256(dbPath string) (*checker, error) {
+	c := newChecker("sha256")
+	f, err := os.Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !hasChecksum(fi.Size()) {
+		c.skipf("no integrity hash appended to the file, as when copied from a data directory")
+		return c, nil
+	}
+
+	th := &tailHasher{h: sha256.New()}
+	if _, err = io.Copy(th, f); err != nil {
+		return nil, err
+	}
+	if sha, dbsha := th.tail, th.h.Sum(nil); !bytes.Equal(sha, dbsha) {
+		c.failf("expected sha256 %x, got %x", sha, dbsha)
+	} else {
+		c.Detail = fmt.Sprintf("sha256 %x", sha)
+	}
+	return c, nil
+}
+
+// keyState is the last revision of a key seen while traversing the key
+// bucket.
+type keyState struct {
+	tombstone bool
+	create    int64
+	version   int64
+	lease     int64
+}
+
+// checkRevisions checks that the revisions of the key bucket increase and
+// that the revisions of each key follow each other, and returns the last
+// revision of each key.
+func checkRevisions(tx *bolt.Tx, c *checker) map[string]*keyState {
+	keys := make(map[string]*keyState)
+	b := tx.Bucket(schema.Key.Name())
+	if b == nil {
+		c.failf("missing bucket %q", schema.Key.Name())
+		return keys
+	}
+
+	compacted := metaRevision(tx, schema.FinishedCompactKeyName, c)
+	scheduled := metaRevision(tx, schema.ScheduledCompactKeyName, c)
+	if scheduled < compacted {
+		c.failf("finished compaction at revision %d is after the scheduled compaction at revision %d", compacted, scheduled)
+	}
+	// the revisions up to the compacted revision may have been removed.
+	compacted = max(compacted, scheduled)
+
+	var last mvcc.Revision
+	entries := 0
+	b.ForEach(func(k, v []byte) error {
+		rev, err := bytesToRev(k)
+		if err != nil {
+			c.failf("cannot parse revision %x: %v", k, err)
+			return nil
+		}
+		if entries > 0 && !rev.GreaterThan(last) {
+			c.failf("revision %d_%d does not follow revision %d_%d", rev.Main, rev.Sub, last.Main, last.Sub)
+		}
+		last = rev
+		entries++
+
+		var kv mvccpb.KeyValue
+		if err = kv.Unmarshal(v); err != nil {
+			c.failf("cannot unmarshal revision %d_%d: %v", rev.Main, rev.Sub, err)
+			return nil
+		}
+		ks, seen := keys[string(kv.Key)]
+		if !seen {
+			ks = &keyState{}
+			keys[string(kv.Key)] = ks
+		}
+		if mvcc.IsTombstone(k) {
+			if seen && ks.tombstone {
+				c.failf("key %q: tombstone at revision %d follows a tombstone", kv.Key, rev.Main)
+			}
+			*ks = keyState{tombstone: true}
+			return nil
+		}
+
+		// the first revision of a key must create it, unless the previous
+		// revisions may have been compacted.
+		created := seen && ks.tombstone || !seen && kv.CreateRevision > compacted
+		switch {
+		case kv.ModRevision != rev.Main:
+			c.failf("key %q: mod revision %d stored at revision %d", kv.Key, kv.ModRevision, rev.Main)
+		case kv.CreateRevision <= 0 || kv.CreateRevision > kv.ModRevision:
+			c.failf("key %q: create revision %d at revision %d", kv.Key, kv.CreateRevision, rev.Main)
+		case kv.Version <= 0:
+			c.failf("key %q: version %d at revision %d", kv.Key, kv.Version, rev.Main)
+		case seen && !ks.tombstone && (kv.CreateRevision != ks.create || kv.Version != ks.version+1):
+			c.failf("key %q: create revision %d and version %d at revision %d do not follow create revision %d and version %d",
+				kv.Key, kv.CreateRevision, kv.Version, rev.Main, ks.create, ks.version)
+		case created && (kv.CreateRevision != rev.Main || kv.Version != 1):
+			c.failf("key %q: create revision %d and version %d at revision %d do not create the key", kv.Key, kv.CreateRevision, kv.Version, rev.Main)
+		}
+		*ks = keyState{create: kv.CreateRevision, version: kv.Version, lease: kv.Lease}
+		return nil
+	})
+	c.Detail = fmt.Sprintf("checked %d revisions of %d keys, compacted at revision %d", entries, len(keys), compacted)
+	return keys
+}
+
+// metaRevision returns the revision stored under the key of the meta bucket,
+// or 0 if there is none.
+func metaRevision(tx *bolt.Tx, key []byte, c *checker) int64 {
+	b := tx.Bucket(schema.Meta.Name())
+	if b == nil {
+		return 0
+	}
+	v := b.Get(key)
+	if v == nil {
+		return 0
+	}
+	rev, err := bytesToRev(v)
+	if err != nil {
+		c.failf("cannot parse %s revision %x: %v", key, v, err)
+		return 0
+	}
+	return rev.Main
+}
+
+// checkLeases checks that the leases can be read, and that the keys are
+// attached to existing leases.
+func checkLeases(tx *bolt.Tx, keys map[string]*keyState, c *checker) {
+	leases := make(map[int64]struct{})
+	if b := tx.Bucket(schema.Lease.Name()); b != nil {
+		b.ForEach(func(k, v []byte) error {
+			if len(k) != 8 {
+				c.failf("lease key %x is not a lease ID", k)
+				return nil
+			}
+			id := int64(binary.BigEndian.Uint64(k))
+			var l leasepb.Lease
+			if err := l.Unmarshal(v); err != nil {
+				c.failf("cannot unmarshal lease %x: %v", id, err)
+				return nil
+			}
+			if l.ID != id {
+				c.failf("lease %x stored as lease %x", l.ID, id)
+			}
+			leases[id] = struct{}{}
+			return nil
+		})
+	}
+
+	var attached []string
+	for key, ks := range keys {
+		if !ks.tombstone && ks.lease != 0 {
+			attached = append(attached, key)
+		}
+	}
+	sort.Strings(attached)
+	for _, key := range attached {
+		if _, ok := leases[keys[key].lease]; !ok {
+			c.failf("key %q is attached to missing lease %x", key, keys[key].lease)
+		}
+	}
+	c.Detail = fmt.Sprintf("checked %d leases and %d keys attached to leases", len(leases), len(attached))
+}
+
+// checkHashKV recomputes the hash of the keyspace as the members do, on a
+// copy of the snapshot so that the snapshot is not written to, and compares
+// it with the hash stored on clean shutdown, if any.
+func (s *v3Manager) checkHashKV(dbPath string, c *checker) error {
+	dir, err := os.MkdirTemp("", "etcdutl-hashkv")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	copyPath := filepath.Join(dir, "db")
+	if err = copySnapshotDB(dbPath, copyPath); err != nil {
+		return err
+	}
+
+	cfg := backend.DefaultBackendConfig(zap.NewNop())
+	cfg.Path = copyPath
+	be := backend.New(cfg)
+	defer be.Close()
+
+	tx := be.ReadTx()
+	tx.RLock()
+	stored := schema.UnsafeReadBootHash(s.lg, tx)
+	tx.RUnlock()
+
+	st := mvcc.NewStore(zap.NewNop(), be, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	defer st.Close()
+	h, _, err := mvcc.NewHashStorage(zap.NewNop(), st).HashByRev(0)
+	if err != nil {
+		c.failf("cannot hash the keyspace: %v", err)
+		return nil
+	}
+
+	c.Detail = fmt.Sprintf("hash %d at revision %d, compact revision %d", h.Hash, h.Revision, h.CompactRevision)
+	switch {
+	case stored == nil:
+		c.Detail += "; no hash stored on clean shutdown to compare with"
+	case stored.Revision != h.Revision || stored.CompactRevision != h.CompactRevision:
+		c.Detail += fmt.Sprintf("; the hash stored on clean shutdown is at revision %d, compact revision %d", stored.Revision, stored.CompactRevision)
+	case stored.Hash != h.Hash:
+		c.failf("hash %d differs from the hash %d stored on clean shutdown", h.Hash, stored.Hash)
+	default:
+		c.Detail += "; matches the hash stored on clean shutdown"
+	}
+	return nil
+}
+
+// copySnapshotDB copies the database of the snapshot, without its integrity
+// hash if any.
+func copySnapshotDB(src, dst string) error {
+	sf, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sf.Close()
+	fi, err := sf.Stat()
+	if err != nil {
+		return err
+	}
+	size := fi.Size()
+	if hasChecksum(size) {
+		size -= sha256.Size
+	}
+
+	df, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.CopyN(df, sf, size); err != nil {
+		df.Close()
+		return err
+	}
+	return df.Close()
+}
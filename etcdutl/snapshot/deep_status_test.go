@@ -0,0 +1,167 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.etcd.io/bbolt"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func TestSnapshotDeepStatus(t *testing.T) {
+	var compactRev int64
+	dbpath := createDB(t, func(srv *etcdserver.EtcdServer) {
+		lresp, err := srv.LeaseGrant(t.Context(), &etcdserverpb.LeaseGrantRequest{TTL: 1000})
+		require.NoError(t, err)
+		_, err = srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte("leased"), Value: []byte("v"), Lease: lresp.ID})
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			_, err = srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte("foo"), Value: []byte("v")})
+			require.NoError(t, err)
+		}
+		resp, err := srv.DeleteRange(t.Context(), &etcdserverpb.DeleteRangeRequest{Key: []byte("foo")})
+		require.NoError(t, err)
+		compactRev = resp.Header.Revision
+		_, err = srv.Compact(t.Context(), &etcdserverpb.CompactionRequest{Revision: compactRev, Physical: true})
+		require.NoError(t, err)
+		_, err = srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte("foo"), Value: []byte("v")})
+		require.NoError(t, err)
+	})
+	status, err := NewV3(zap.NewNop()).Status(dbpath)
+	require.NoError(t, err)
+
+	results := func(ds DeepStatus) map[string]string {
+		ret := make(map[string]string)
+		for _, c := range ds.Checks {
+			ret[c.Name] = c.Result
+		}
+		return ret
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		ds, err := NewV3(zap.NewNop()).DeepStatus(dbpath)
+		require.NoError(t, err)
+		assert.True(t, ds.Passed)
+		assert.Equal(t, status, ds.Status)
+		assert.Equal(t, map[string]string{
+			"sha256":    CheckSkipped,
+			"integrity": CheckPassed,
+			"buckets":   CheckPassed,
+			"revisions": CheckPassed,
+			"leases":    CheckPassed,
+			"hashkv":    CheckPassed,
+		}, results(ds))
+	})
+
+	t.Run("integrity hash", func(t *testing.T) {
+		db, err := os.ReadFile(dbpath)
+		require.NoError(t, err)
+		sha := sha256.Sum256(db)
+		path := filepath.Join(t.TempDir(), "snapshot.db")
+
+		require.NoError(t, os.WriteFile(path, append(db, sha[:]...), 0o600))
+		ds, err := NewV3(zap.NewNop()).DeepStatus(path)
+		require.NoError(t, err)
+		assert.True(t, ds.Passed)
+		assert.Equal(t, CheckPassed, results(ds)["sha256"])
+
+		sha[0]++
+		require.NoError(t, os.WriteFile(path, append(db, sha[:]...), 0o600))
+		ds, err = NewV3(zap.NewNop()).DeepStatus(path)
+		require.NoError(t, err)
+		assert.False(t, ds.Passed)
+		assert.Equal(t, CheckFailed, results(ds)["sha256"])
+	})
+
+	t.Run("broken revisions and leases", func(t *testing.T) {
+		path := copyDB(t, dbpath)
+		updateDB(t, path, func(tx *bbolt.Tx) error {
+			put := func(rev int64, kv *mvccpb.KeyValue) error {
+				v, err := kv.Marshal()
+				if err != nil {
+					return err
+				}
+				return tx.Bucket(schema.Key.Name()).Put(mvcc.RevToBytes(mvcc.Revision{Main: rev}, mvcc.NewRevBytes()), v)
+			}
+			rev := status.Revision
+			if err := put(rev+1, &mvccpb.KeyValue{Key: []byte("a"), CreateRevision: rev + 1, ModRevision: rev + 5, Version: 1}); err != nil {
+				return err
+			}
+			return put(rev+2, &mvccpb.KeyValue{Key: []byte("b"), CreateRevision: rev + 2, ModRevision: rev + 2, Version: 1, Lease: 0x1234})
+		})
+
+		ds, err := NewV3(zap.NewNop()).DeepStatus(path)
+		require.NoError(t, err)
+		assert.False(t, ds.Passed)
+		assert.Equal(t, CheckFailed, results(ds)["revisions"])
+		assert.Equal(t, CheckFailed, results(ds)["leases"])
+		assert.Equal(t, CheckSkipped, results(ds)["hashkv"])
+		for _, c := range ds.Checks {
+			switch c.Name {
+			case "revisions":
+				assert.Equal(t, []string{fmt.Sprintf(`key "a": mod revision %d stored at revision %d`, status.Revision+5, status.Revision+1)}, c.Errors)
+			case "leases":
+				assert.Equal(t, []string{`key "b" is attached to missing lease 1234`}, c.Errors)
+			}
+		}
+	})
+
+	t.Run("stored hash mismatch", func(t *testing.T) {
+		path := copyDB(t, dbpath)
+		updateDB(t, path, func(tx *bbolt.Tx) error {
+			h, err := json.Marshal(schema.BootHash{Revision: status.Revision, CompactRevision: compactRev, Hash: 1})
+			if err != nil {
+				return err
+			}
+			return tx.Bucket(schema.Meta.Name()).Put(schema.MetaBootHashName, h)
+		})
+
+		ds, err := NewV3(zap.NewNop()).DeepStatus(path)
+		require.NoError(t, err)
+		assert.False(t, ds.Passed)
+		assert.Equal(t, CheckFailed, results(ds)["hashkv"])
+	})
+}
+
+func copyDB(t *testing.T, src string) string {
+	t.Helper()
+	db, err := os.ReadFile(src)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, os.WriteFile(path, db, 0o600))
+	return path
+}
+
+func updateDB(t *testing.T, path string, update func(tx *bbolt.Tx) error) {
+	t.Helper()
+	db, err := bbolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Update(update))
+}
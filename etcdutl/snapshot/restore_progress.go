@@ -0,0 +1,115 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"io"
+	"time"
+)
+
+// Stages of a restore, as reported in RestoreProgress.
+const (
+	// RestoreStageCopy is reading the snapshot into the new data directory
+	// and verifying its integrity hash.
+	RestoreStageCopy = "copy"
+	// RestoreStageRewrite is updating the restored database for the new
+	// cluster: trimming the membership and applying the requested changes to
+	// revisions, auth and leases.
+	RestoreStageRewrite = "rewrite"
+	// RestoreStageWAL is creating the WAL and the raft snapshot of the new
+	// member.
+	RestoreStageWAL = "wal"
+	// RestoreStageDone is reported once the restore has succeeded.
+	RestoreStageDone = "done"
+)
+
+// restoreProgressInterval is how often the progress of reading the snapshot
+// is reported.
+const restoreProgressInterval = time.Second
+
+// RestoreProgress is the progress of a restore.
+type RestoreProgress struct {
+	// Stage is the stage the restore is in.
+	Stage string
+	// Bytes is the number of snapshot bytes read so far.
+	Bytes int64
+	// TotalBytes is the size of the snapshot, or 0 if it is not known, as
+	// when the snapshot is read from a stream.
+	TotalBytes int64
+	// Elapsed is the time since the restore started.
+	Elapsed time.Duration
+}
+
+type restoreProgressReporter struct {
+	report func(RestoreProgress)
+	start  time.Time
+	last   time.Time
+
+	bytes      int64
+	totalBytes int64
+}
+
+func newRestoreProgressReporter(report func(RestoreProgress)) *restoreProgressReporter {
+	return &restoreProgressReporter{report: report, start: time.Now()}
+}
+
+func (r *restoreProgressReporter) stage(stage string) {
+	if r == nil || r.report == nil {
+		return
+	}
+	now := time.Now()
+	r.last = now
+	r.report(RestoreProgress{
+		Stage:      stage,
+		Bytes:      r.bytes,
+		TotalBytes: r.totalBytes,
+		Elapsed:    now.Sub(r.start),
+	})
+}
+
+func (r *restoreProgressReporter) copyStarted(totalBytes int64) {
+	if r == nil {
+		return
+	}
+	r.totalBytes = totalBytes
+	r.stage(RestoreStageCopy)
+}
+
+// copied records that n snapshot bytes have been read, reporting it if the
+// last report is older than restoreProgressInterval or if force is set.
+func (r *restoreProgressReporter) copied(n int64, force bool) {
+	if r == nil {
+		return
+	}
+	r.bytes = n
+	if force || time.Since(r.last) >= restoreProgressInterval {
+		r.stage(RestoreStageCopy)
+	}
+}
+
+// restoreCopyWriter reports the progress of the snapshot copy written
+// through it.
+type restoreCopyWriter struct {
+	w        io.Writer
+	n        int64
+	progress *restoreProgressReporter
+}
+
+func (c *restoreCopyWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.progress.copied(c.n, false)
+	return n, err
+}
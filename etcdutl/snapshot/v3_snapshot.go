@@ -20,6 +20,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"hash/crc32"
 	"io"
 	"os"
@@ -41,6 +42,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
+	"go.etcd.io/etcd/server/v3/lease"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/server/v3/storage/schema"
@@ -65,6 +67,13 @@ type Manager interface {
 	// Status returns the snapshot file information.
 	Status(dbPath string) (Status, error)
 
+	// DeepStatus returns the snapshot file information like Status, and
+	// validates the whole snapshot: its integrity hash, the traversal of all
+	// buckets, the revisions of the keys, the leases the keys are attached to
+	// and the hash of the keyspace. It only returns an error if the file
+	// cannot be read; the problems found are reported by the failed checks.
+	DeepStatus(dbPath string) (DeepStatus, error)
+
 	// Restore restores a new etcd data directory from given snapshot
 	// file. It returns an error if specified data directory already
 	// exists, to prevent unintended data directory overwrites.
@@ -81,12 +90,14 @@ type v3Manager struct {
 
 	name      string
 	srcDbPath string
+	srcDb     io.Reader
 	walDir    string
 	snapDir   string
 	cl        *membership.RaftCluster
 
 	skipHashCheck   bool
 	initialMmapSize uint64
+	progress        *restoreProgressReporter
 }
 
 // hasChecksum returns "true" if the file size "n"
@@ -125,73 +136,85 @@ func (s *v3Manager) Status(dbPath string) (ds Status, err error) {
 	}
 	defer db.Close()
 
-	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
-	seenKeys := make(map[string]struct{})
-
 	if err = db.View(func(tx *bolt.Tx) error {
 		// check snapshot file integrity first
-		var dbErrStrings []string
-		for dbErr := range tx.Check() {
-			dbErrStrings = append(dbErrStrings, dbErr.Error())
-		}
-		if len(dbErrStrings) > 0 {
+		if dbErrStrings := integrityErrors(tx); len(dbErrStrings) > 0 {
 			return fmt.Errorf("snapshot file integrity check failed. %d errors found.\n"+strings.Join(dbErrStrings, "\n"), len(dbErrStrings))
 		}
-		ds.TotalSize = tx.Size()
-		v := schema.ReadStorageVersionFromSnapshot(tx)
-		if v != nil {
-			ds.Version = v.String()
+		ds, err = readStatus(tx)
+		return err
+	}); err != nil {
+		return ds, err
+	}
+	return ds, nil
+}
+
+// integrityErrors returns the errors found by the bolt consistency check of
+// the snapshot.
+func integrityErrors(tx *bolt.Tx) []string {
+	var dbErrStrings []string
+	for dbErr := range tx.Check() {
+		dbErrStrings = append(dbErrStrings, dbErr.Error())
+	}
+	return dbErrStrings
+}
+
+// readStatus reads the status of the snapshot, hashing every bucket.
+func readStatus(tx *bolt.Tx) (ds Status, err error) {
+	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	seenKeys := make(map[string]struct{})
+
+	ds.TotalSize = tx.Size()
+	v := schema.ReadStorageVersionFromSnapshot(tx)
+	if v != nil {
+		ds.Version = v.String()
+	}
+	c := tx.Cursor()
+	for next, _ := c.First(); next != nil; next, _ = c.Next() {
+		b := tx.Bucket(next)
+		if b == nil {
+			return ds, fmt.Errorf("nil bucket: %q", string(next))
+		}
+		_, err = h.Write(next)
+		if err != nil {
+			return ds, fmt.Errorf("cannot hash bucket name: %q err: %w", string(next), err)
 		}
-		c := tx.Cursor()
-		for next, _ := c.First(); next != nil; next, _ = c.Next() {
-			b := tx.Bucket(next)
-			if b == nil {
-				return fmt.Errorf("nil bucket: %q", string(next))
+
+		iskeyb := (bytes.Equal(next, schema.Key.Name()))
+		if err = b.ForEach(func(k, v []byte) error {
+			_, err = h.Write(k)
+			if err != nil {
+				return fmt.Errorf("cannot hash bucket key: %q err: %w", k, err)
 			}
-			_, err = h.Write(next)
+			_, err = h.Write(v)
 			if err != nil {
-				return fmt.Errorf("cannot hash bucket name: %q err: %w", string(next), err)
+				return fmt.Errorf("cannot hash bucket key: %q value: %q err: %w", k, v, err)
 			}
-
-			iskeyb := (bytes.Equal(next, schema.Key.Name()))
-			if err = b.ForEach(func(k, v []byte) error {
-				_, err = h.Write(k)
+			if iskeyb {
+				var rev mvcc.Revision
+				rev, err = bytesToRev(k)
 				if err != nil {
-					return fmt.Errorf("cannot hash bucket key: %q err: %w", k, err)
+					return fmt.Errorf("cannot parse revision key: %q err: %w", k, err)
 				}
-				_, err = h.Write(v)
+				ds.Revision = rev.Main
+
+				var kv mvccpb.KeyValue
+				err = kv.Unmarshal(v)
 				if err != nil {
-					return fmt.Errorf("cannot hash bucket key: %q value: %q err: %w", k, v, err)
+					return fmt.Errorf("cannot unmarshal value, key: %q value: %q err: %w", k, v, err)
 				}
-				if iskeyb {
-					var rev mvcc.Revision
-					rev, err = bytesToRev(k)
-					if err != nil {
-						return fmt.Errorf("cannot parse revision key: %q err: %w", k, err)
-					}
-					ds.Revision = rev.Main
-
-					var kv mvccpb.KeyValue
-					err = kv.Unmarshal(v)
-					if err != nil {
-						return fmt.Errorf("cannot unmarshal value, key: %q value: %q err: %w", k, v, err)
-					}
-					key := string(kv.Key)
-					// refer to https://etcd.io/docs/v3.5/learning/data_model/
-					if !mvcc.IsTombstone(k) {
-						seenKeys[key] = struct{}{}
-					} else {
-						delete(seenKeys, key)
-					}
+				key := string(kv.Key)
+				// refer to https://etcd.io/docs/v3.5/learning/data_model/
+				if !mvcc.IsTombstone(k) {
+					seenKeys[key] = struct{}{}
+				} else {
+					delete(seenKeys, key)
 				}
-				return nil
-			}); err != nil {
-				return fmt.Errorf("error during bucket key iteration, name: %q err: %w", string(next), err)
 			}
+			return nil
+		}); err != nil {
+			return ds, fmt.Errorf("error during bucket key iteration, name: %q err: %w", string(next), err)
 		}
-		return nil
-	}); err != nil {
-		return ds, err
 	}
 
 	ds.TotalKey = len(seenKeys)
@@ -212,6 +235,15 @@ func bytesToRev(b []byte) (rev mvcc.Revision, err error) {
 type RestoreConfig struct {
 	// SnapshotPath is the path of snapshot file to restore from.
 	SnapshotPath string
+	// SnapshotReader, if not nil, is read for the snapshot instead of
+	// SnapshotPath, which is then only used for logging. The snapshot is
+	// streamed straight into the restored data directory and its integrity
+	// hash is verified on the way, so no additional copy is made.
+	SnapshotReader io.Reader
+
+	// Progress, if not nil, is called as the restore goes through its stages
+	// and periodically while the snapshot is being read.
+	Progress func(RestoreProgress)
 
 	// Name is the human-readable name of this member.
 	Name string
@@ -250,6 +282,20 @@ type RestoreConfig struct {
 	// MarkCompacted is "true" to mark the latest revision as compacted.
 	// (required if RevisionBump > 0)
 	MarkCompacted bool
+
+	// StripAuth is "true" to remove all users and roles and to disable auth,
+	// so that the restored data does not carry the credentials of the
+	// snapshotted cluster.
+	StripAuth bool
+
+	// ResetLeasesTTL is the TTL in seconds all leases are reset to, with no
+	// remaining TTL carried over from the snapshot. If 0, leases are kept as is.
+	ResetLeasesTTL int64
+
+	// DropLeases is "true" to remove all leases and detach the keys from them,
+	// so that no key expires after restore.
+	// (disallowed if ResetLeasesTTL > 0)
+	DropLeases bool
 }
 
 // Restore restores a new etcd data directory from given snapshot file.
@@ -263,6 +309,12 @@ func (s *v3Manager) Restore(cfg RestoreConfig) error {
 	if err != nil {
 		return err
 	}
+	if cfg.ResetLeasesTTL < 0 || cfg.ResetLeasesTTL > lease.MaxLeaseTTL {
+		return fmt.Errorf("leases TTL %d is out of range [0, %d]", cfg.ResetLeasesTTL, lease.MaxLeaseTTL)
+	}
+	if cfg.ResetLeasesTTL > 0 && cfg.DropLeases {
+		return fmt.Errorf("leases cannot be both reset and dropped")
+	}
 
 	srv := config.ServerConfig{
 		Logger:              s.lg,
@@ -297,6 +349,8 @@ func (s *v3Manager) Restore(cfg RestoreConfig) error {
 
 	s.name = cfg.Name
 	s.srcDbPath = cfg.SnapshotPath
+	s.srcDb = cfg.SnapshotReader
+	s.progress = newRestoreProgressReporter(cfg.Progress)
 	s.walDir = walDir
 	s.snapDir = filepath.Join(dataDir, "member", "snap")
 	s.skipHashCheck = cfg.SkipHashCheck
@@ -315,12 +369,26 @@ func (s *v3Manager) Restore(cfg RestoreConfig) error {
 		return err
 	}
 
+	s.progress.stage(RestoreStageRewrite)
 	if cfg.MarkCompacted && cfg.RevisionBump > 0 {
 		if err = s.modifyLatestRevision(cfg.RevisionBump); err != nil {
 			return err
 		}
 	}
 
+	if cfg.StripAuth {
+		s.stripAuth()
+	}
+
+	if cfg.ResetLeasesTTL > 0 {
+		s.resetLeases(cfg.ResetLeasesTTL)
+	} else if cfg.DropLeases {
+		if err = s.dropLeases(); err != nil {
+			return err
+		}
+	}
+
+	s.progress.stage(RestoreStageWAL)
 	hardstate, err := s.saveWALAndSnap()
 	if err != nil {
 		return err
@@ -339,11 +407,15 @@ func (s *v3Manager) Restore(cfg RestoreConfig) error {
 		zap.Uint64("initial-memory-map-size", s.initialMmapSize),
 	)
 
-	return verify.VerifyIfEnabled(verify.Config{
+	if err = verify.VerifyIfEnabled(verify.Config{
 		ExactIndex: true,
 		Logger:     s.lg,
 		DataDir:    dataDir,
-	})
+	}); err != nil {
+		return err
+	}
+	s.progress.stage(RestoreStageDone)
+	return nil
 }
 
 func (s *v3Manager) outDbPath() string {
@@ -392,6 +464,124 @@ func (s *v3Manager) modifyLatestRevision(bumpAmount uint64) error {
 	return nil
 }
 
+// stripAuth removes all users and roles and disables auth.
+func (s *v3Manager) stripAuth() {
+	be := backend.NewDefaultBackend(s.lg, s.outDbPath())
+	defer func() {
+		be.ForceCommit()
+		be.Close()
+	}()
+
+	abe := schema.NewAuthBackend(s.lg, be)
+	abe.CreateAuthBuckets()
+	tx := abe.BatchTx()
+	tx.Lock()
+	defer tx.Unlock()
+
+	users := tx.UnsafeGetAllUsers()
+	for _, u := range users {
+		tx.UnsafeDeleteUser(string(u.Name))
+	}
+	roles := tx.UnsafeGetAllRoles()
+	for _, r := range roles {
+		tx.UnsafeDeleteRole(string(r.Name))
+	}
+	tx.UnsafeSaveAuthEnabled(false)
+
+	s.lg.Info(
+		"stripped auth",
+		zap.Int("removed-users", len(users)),
+		zap.Int("removed-roles", len(roles)),
+	)
+}
+
+// resetLeases sets the TTL of all leases to the given TTL. Their remaining
+// TTL is cleared, so that they expire a full TTL after a leader is elected.
+func (s *v3Manager) resetLeases(ttl int64) {
+	be := backend.NewDefaultBackend(s.lg, s.outDbPath())
+	defer func() {
+		be.ForceCommit()
+		be.Close()
+	}()
+
+	tx := be.BatchTx()
+	tx.LockOutsideApply()
+	defer tx.Unlock()
+
+	leases := schema.MustUnsafeGetAllLeases(tx)
+	for _, l := range leases {
+		l.TTL = ttl
+		l.RemainingTTL = 0
+		schema.MustUnsafePutLease(tx, l)
+	}
+	unsafeDropIndexCheckpoint(tx)
+
+	s.lg.Info(
+		"reset leases",
+		zap.Int("leases", len(leases)),
+		zap.Int64("ttl", ttl),
+	)
+}
+
+// dropLeases removes all leases and detaches all revisions of keys from them.
+func (s *v3Manager) dropLeases() error {
+	be := backend.NewDefaultBackend(s.lg, s.outDbPath())
+	defer func() {
+		be.ForceCommit()
+		be.Close()
+	}()
+
+	tx := be.BatchTx()
+	tx.LockOutsideApply()
+	defer tx.Unlock()
+
+	leases := schema.MustUnsafeGetAllLeases(tx)
+	for _, l := range leases {
+		schema.UnsafeDeleteLease(tx, l)
+	}
+
+	// keys cannot be put while iterating the bucket
+	var keys, values [][]byte
+	err := tx.UnsafeForEach(schema.Key, func(k, v []byte) error {
+		var kv mvccpb.KeyValue
+		if err := kv.Unmarshal(v); err != nil {
+			return err
+		}
+		if kv.Lease == 0 {
+			return nil
+		}
+		kv.Lease = 0
+		nv, err := kv.Marshal()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, bytes.Clone(k))
+		values = append(values, nv)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := range keys {
+		tx.UnsafePut(schema.Key, keys[i], values[i])
+	}
+	unsafeDropIndexCheckpoint(tx)
+
+	s.lg.Info(
+		"dropped leases",
+		zap.Int("leases", len(leases)),
+		zap.Int("detached-revisions", len(keys)),
+	)
+	return nil
+}
+
+// unsafeDropIndexCheckpoint removes the key index checkpoint, which records
+// the leases of keys as they were before the leases were rewritten. The
+// restored member rebuilds the index from the key bucket instead.
+func unsafeDropIndexCheckpoint(tx backend.UnsafeWriter) {
+	tx.UnsafeDeleteBucket(schema.KeyIndexCheckpoint)
+}
+
 func (s *v3Manager) unsafeBumpBucketsRevision(tx backend.UnsafeWriter, latest mvcc.Revision, amount int64) mvcc.Revision {
 	s.lg.Info(
 		"bumping latest revision",
@@ -433,22 +623,19 @@ func (s *v3Manager) unsafeGetLatestRevision(tx backend.UnsafeReader) (mvcc.Revis
 }
 
 func (s *v3Manager) copyAndVerifyDB() error {
-	srcf, ferr := os.Open(s.srcDbPath)
-	if ferr != nil {
-		return ferr
-	}
-	defer srcf.Close()
-
-	// get snapshot integrity hash
-	if _, err := srcf.Seek(-sha256.Size, io.SeekEnd); err != nil {
-		return err
-	}
-	sha := make([]byte, sha256.Size)
-	if _, err := srcf.Read(sha); err != nil {
-		return err
-	}
-	if _, err := srcf.Seek(0, io.SeekStart); err != nil {
-		return err
+	src := s.srcDb
+	var total int64
+	if src == nil {
+		srcf, ferr := os.Open(s.srcDbPath)
+		if ferr != nil {
+			return ferr
+		}
+		defer srcf.Close()
+		fi, ferr := srcf.Stat()
+		if ferr != nil {
+			return ferr
+		}
+		src, total = srcf, fi.Size()
 	}
 
 	if err := fileutil.CreateDirAll(s.lg, s.snapDir); err != nil {
@@ -463,15 +650,20 @@ func (s *v3Manager) copyAndVerifyDB() error {
 	}
 	defer db.Close()
 
-	if _, err := io.Copy(db, srcf); err != nil {
+	// The integrity hash, if any, is appended to the snapshot, so whether the
+	// last sha256.Size bytes are a hash is only known once the whole snapshot
+	// has been read. Hash everything but those bytes while copying, so that
+	// the snapshot is read only once and can come from a stream.
+	th := &tailHasher{h: sha256.New()}
+	s.progress.copyStarted(total)
+	cw := &restoreCopyWriter{w: io.MultiWriter(db, th), progress: s.progress}
+	off, err := io.Copy(cw, src)
+	s.progress.copied(off, true)
+	if err != nil {
 		return err
 	}
 
 	// truncate away integrity hash, if any.
-	off, serr := db.Seek(0, io.SeekEnd)
-	if serr != nil {
-		return serr
-	}
 	hasHash := hasChecksum(off)
 	if hasHash {
 		if err := db.Truncate(off - sha256.Size); err != nil {
@@ -485,14 +677,7 @@ func (s *v3Manager) copyAndVerifyDB() error {
 
 	if hasHash && !s.skipHashCheck {
 		// check for match
-		if _, err := db.Seek(0, io.SeekStart); err != nil {
-			return err
-		}
-		h := sha256.New()
-		if _, err := io.Copy(h, db); err != nil {
-			return err
-		}
-		dbsha := h.Sum(nil)
+		sha, dbsha := th.tail, th.h.Sum(nil)
 		if !reflect.DeepEqual(sha, dbsha) {
 			return fmt.Errorf("expected sha256 %v, got %v", sha, dbsha)
 		}
@@ -503,6 +688,22 @@ func (s *v3Manager) copyAndVerifyDB() error {
 	return nil
 }
 
+// tailHasher hashes everything written to it but the last sha256.Size bytes,
+// which it holds back in tail.
+type tailHasher struct {
+	h    hash.Hash
+	tail []byte
+}
+
+func (t *tailHasher) Write(p []byte) (int, error) {
+	t.tail = append(t.tail, p...)
+	if n := len(t.tail) - sha256.Size; n > 0 {
+		t.h.Write(t.tail[:n])
+		t.tail = append(t.tail[:0], t.tail[n:]...)
+	}
+	return len(p), nil
+}
+
 // saveWALAndSnap creates a WAL for the initial cluster
 //
 // TODO: This code ignores learners !!!
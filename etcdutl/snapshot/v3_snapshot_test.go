@@ -15,7 +15,11 @@
 package snapshot
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
+	"io"
+	"os"
 	"path/filepath"
 	"strconv"
 	"testing"
@@ -27,8 +31,10 @@ import (
 
 	"go.etcd.io/bbolt"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/server/v3/embed"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/server/v3/storage/schema"
 )
@@ -258,3 +264,173 @@ func createDB(t *testing.T, generateContent func(*etcdserver.EtcdServer)) string
 
 	return filepath.Join(cfg.Dir, "member", "snap", "db")
 }
+
+func TestRestoreStripAuthAndLeases(t *testing.T) {
+	var leaseID int64
+	dbpath := createDB(t, func(srv *etcdserver.EtcdServer) {
+		lresp, err := srv.LeaseGrant(t.Context(), &etcdserverpb.LeaseGrantRequest{TTL: 1000})
+		require.NoError(t, err)
+		leaseID = lresp.ID
+		_, err = srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte("leased"), Value: []byte("v"), Lease: leaseID})
+		require.NoError(t, err)
+		_, err = srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte("unleased"), Value: []byte("v")})
+		require.NoError(t, err)
+
+		_, err = srv.UserAdd(t.Context(), &etcdserverpb.AuthUserAddRequest{Name: "root", Password: "root"})
+		require.NoError(t, err)
+		_, err = srv.RoleAdd(t.Context(), &etcdserverpb.AuthRoleAddRequest{Name: "root"})
+		require.NoError(t, err)
+		_, err = srv.UserGrantRole(t.Context(), &etcdserverpb.AuthUserGrantRoleRequest{User: "root", Role: "root"})
+		require.NoError(t, err)
+		_, err = srv.AuthEnable(t.Context(), &etcdserverpb.AuthEnableRequest{})
+		require.NoError(t, err)
+
+		tx := srv.Backend().BatchTx()
+		tx.LockOutsideApply()
+		tx.UnsafeCreateBucket(schema.KeyIndexCheckpoint)
+		tx.UnsafePut(schema.KeyIndexCheckpoint, []byte("meta"), []byte{1})
+		tx.Unlock()
+		srv.Backend().ForceCommit()
+	})
+
+	restore := func(t *testing.T, cfg RestoreConfig) backend.Backend {
+		dataDir := t.TempDir()
+		cfg.SnapshotPath = dbpath
+		cfg.Name = "default"
+		cfg.OutputDataDir = dataDir
+		cfg.PeerURLs = []string{"http://localhost:2380"}
+		cfg.InitialCluster = "default=http://localhost:2380"
+		cfg.InitialClusterToken = "etcd-cluster"
+		cfg.SkipHashCheck = true
+		require.NoError(t, NewV3(zap.NewNop()).Restore(cfg))
+		be := backend.NewDefaultBackend(zap.NewNop(), filepath.Join(dataDir, "member", "snap", "db"))
+		t.Cleanup(func() { be.Close() })
+		return be
+	}
+	leasedRevisions := func(be backend.Backend) (n int) {
+		tx := be.ReadTx()
+		tx.RLock()
+		defer tx.RUnlock()
+		require.NoError(t, tx.UnsafeForEach(schema.Key, func(_, v []byte) error {
+			var kv mvccpb.KeyValue
+			require.NoError(t, kv.Unmarshal(v))
+			if kv.Lease != 0 {
+				n++
+			}
+			return nil
+		}))
+		return n
+	}
+	indexCheckpointKeys := func(be backend.Backend) (n int) {
+		tx := be.ReadTx()
+		tx.RLock()
+		defer tx.RUnlock()
+		require.NoError(t, tx.UnsafeForEach(schema.KeyIndexCheckpoint, func(_, _ []byte) error {
+			n++
+			return nil
+		}))
+		return n
+	}
+
+	t.Run("strip auth and drop leases", func(t *testing.T) {
+		be := restore(t, RestoreConfig{StripAuth: true, DropLeases: true})
+		abe := schema.NewAuthBackend(zap.NewNop(), be)
+		assert.Empty(t, abe.GetAllUsers())
+		assert.Empty(t, abe.GetAllRoles())
+		tx := abe.ReadTx()
+		tx.RLock()
+		assert.False(t, tx.UnsafeReadAuthEnabled())
+		tx.RUnlock()
+
+		rtx := be.ReadTx()
+		rtx.RLock()
+		assert.Empty(t, schema.MustUnsafeGetAllLeases(rtx))
+		rtx.RUnlock()
+		assert.Equal(t, 0, leasedRevisions(be))
+		assert.Equal(t, 0, indexCheckpointKeys(be))
+	})
+
+	t.Run("reset leases", func(t *testing.T) {
+		be := restore(t, RestoreConfig{ResetLeasesTTL: 60})
+		assert.Len(t, schema.NewAuthBackend(zap.NewNop(), be).GetAllUsers(), 1)
+
+		tx := be.ReadTx()
+		tx.RLock()
+		leases := schema.MustUnsafeGetAllLeases(tx)
+		tx.RUnlock()
+		require.Len(t, leases, 1)
+		assert.Equal(t, leaseID, leases[0].ID)
+		assert.Equal(t, int64(60), leases[0].TTL)
+		assert.Equal(t, int64(0), leases[0].RemainingTTL)
+		assert.Equal(t, 1, leasedRevisions(be))
+		assert.Equal(t, 0, indexCheckpointKeys(be))
+	})
+
+	t.Run("reset and drop leases", func(t *testing.T) {
+		err := NewV3(zap.NewNop()).Restore(RestoreConfig{
+			SnapshotPath:   dbpath,
+			PeerURLs:       []string{"http://localhost:2380"},
+			InitialCluster: "default=http://localhost:2380",
+			ResetLeasesTTL: 60,
+			DropLeases:     true,
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestRestoreFromReader(t *testing.T) {
+	dbpath := createDB(t, insertKeys(t, 10, 100))
+	db, err := os.ReadFile(dbpath)
+	require.NoError(t, err)
+	sha := sha256.Sum256(db)
+	snap := append(db, sha[:]...)
+
+	restore := func(t *testing.T, snap []byte, progress func(RestoreProgress)) (string, error) {
+		dataDir := t.TempDir()
+		err := NewV3(zap.NewNop()).Restore(RestoreConfig{
+			SnapshotPath: "-",
+			// hide bytes.Reader's Seek, as on a pipe
+			SnapshotReader:      io.MultiReader(bytes.NewReader(snap)),
+			Progress:            progress,
+			Name:                "default",
+			OutputDataDir:       dataDir,
+			PeerURLs:            []string{"http://localhost:2380"},
+			InitialCluster:      "default=http://localhost:2380",
+			InitialClusterToken: "etcd-cluster",
+		})
+		return dataDir, err
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		var stages []string
+		var copied int64
+		dataDir, err := restore(t, snap, func(p RestoreProgress) {
+			if len(stages) == 0 || stages[len(stages)-1] != p.Stage {
+				stages = append(stages, p.Stage)
+			}
+			if p.Stage == RestoreStageCopy {
+				copied = p.Bytes
+			}
+			assert.Zero(t, p.TotalBytes)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{RestoreStageCopy, RestoreStageRewrite, RestoreStageWAL, RestoreStageDone}, stages)
+		assert.Equal(t, int64(len(snap)), copied)
+
+		fi, err := os.Stat(filepath.Join(dataDir, "member", "snap", "db"))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, fi.Size(), int64(len(db)))
+	})
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		corrupted := bytes.Clone(snap)
+		corrupted[len(corrupted)-1] ^= 0xff
+		_, err := restore(t, corrupted, nil)
+		require.ErrorContains(t, err, "expected sha256")
+	})
+
+	t.Run("missing hash", func(t *testing.T) {
+		_, err := restore(t, db, nil)
+		require.ErrorContains(t, err, "snapshot missing hash")
+	})
+}
@@ -89,6 +89,7 @@ tools_build() {
   tools_path="tools/benchmark
     tools/etcd-dump-db
     tools/etcd-dump-logs
+    tools/etcd-soak
     tools/local-tester/bridge"
   for tool in ${tools_path}
   do
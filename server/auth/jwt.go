@@ -37,6 +37,7 @@ type tokenJWT struct {
 func (t *tokenJWT) enable()                         {}
 func (t *tokenJWT) disable()                        {}
 func (t *tokenJWT) invalidateUser(string)           {}
+func (t *tokenJWT) tokenUsers() map[string]int      { return nil }
 func (t *tokenJWT) genTokenPrefix() (string, error) { return "", nil }
 
 func (t *tokenJWT) info(ctx context.Context, token string, rev uint64) (*AuthInfo, bool) {
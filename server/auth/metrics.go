@@ -37,8 +37,42 @@ var (
 	// overridden by auth store initialization
 	reportCurrentAuthRevMu sync.RWMutex
 	reportCurrentAuthRev   = func() float64 { return 0 }
+
+	expiredPasswordUsers = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "auth",
+			Name:      "expired_password_users",
+			Help:      "The number of users whose password is older than the maximum password age.",
+		},
+		func() float64 {
+			reportStalePasswordUsersMu.RLock()
+			defer reportStalePasswordUsersMu.RUnlock()
+			expired, _ := reportStalePasswordUsers()
+			return float64(expired)
+		},
+	)
+	unknownAgePasswordUsers = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "auth",
+			Name:      "unknown_age_password_users",
+			Help:      "The number of users whose password was set without recording its change time, so that it never expires.",
+		},
+		func() float64 {
+			reportStalePasswordUsersMu.RLock()
+			defer reportStalePasswordUsersMu.RUnlock()
+			_, unknownAge := reportStalePasswordUsers()
+			return float64(unknownAge)
+		},
+	)
+	// overridden by auth store initialization
+	reportStalePasswordUsersMu sync.RWMutex
+	reportStalePasswordUsers   = func() (expired, unknownAge int) { return 0, 0 }
 )
 
 func init() {
 	prometheus.MustRegister(currentAuthRevision)
+	prometheus.MustRegister(expiredPasswordUsers)
+	prometheus.MustRegister(unknownAgePasswordUsers)
 }
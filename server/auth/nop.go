@@ -23,6 +23,7 @@ type tokenNop struct{}
 func (t *tokenNop) enable()                         {}
 func (t *tokenNop) disable()                        {}
 func (t *tokenNop) invalidateUser(string)           {}
+func (t *tokenNop) tokenUsers() map[string]int      { return nil }
 func (t *tokenNop) genTokenPrefix() (string, error) { return "", nil }
 func (t *tokenNop) info(ctx context.Context, token string, rev uint64) (*AuthInfo, bool) {
 	return nil, false
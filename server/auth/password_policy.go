@@ -0,0 +1,146 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"math/big"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// generatedPasswordMinLength is the length of generated passwords, unless
+	// the policy requires longer ones.
+	generatedPasswordMinLength = 24
+
+	passwordLowerChars = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigitChars = "0123456789"
+	passwordOtherChars = "!#%+-.:=@^_~"
+)
+
+// PasswordPolicy constrains the passwords of users. The zero value accepts
+// any password and never expires them.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters of a password.
+	MinLength int
+	// MinCharClasses is the minimum number of character classes, out of
+	// lower case letters, upper case letters, digits and other characters,
+	// used by a password.
+	MinCharClasses int
+	// MaxAge is the duration after which a password expires and is no longer
+	// accepted for authentication. Zero disables the expiry.
+	MaxAge time.Duration
+}
+
+// Check returns ErrPasswordTooWeak if password does not satisfy the policy.
+func (p PasswordPolicy) Check(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return ErrPasswordTooWeak
+	}
+	if passwordCharClasses(password) < p.MinCharClasses {
+		return ErrPasswordTooWeak
+	}
+	return nil
+}
+
+// Expired returns true if a password changed at the given unix time is
+// older than MaxAge. Passwords without a recorded change time never expire,
+// as their age is unknown.
+func (p PasswordPolicy) Expired(changedTime int64, now time.Time) bool {
+	if p.MaxAge == 0 || changedTime == 0 {
+		return false
+	}
+	return now.Sub(time.Unix(changedTime, 0)) > p.MaxAge
+}
+
+// CheckPasswordHash returns ErrInvalidPasswordHash if hashedPassword is not a
+// base64 encoded bcrypt hash, as stored for users with a password.
+func CheckPasswordHash(hashedPassword string) error {
+	hash, err := base64.StdEncoding.DecodeString(hashedPassword)
+	if err != nil {
+		return ErrInvalidPasswordHash
+	}
+	if _, err := bcrypt.Cost(hash); err != nil {
+		return ErrInvalidPasswordHash
+	}
+	return nil
+}
+
+// GeneratePassword returns a random password satisfying the policy.
+func (p PasswordPolicy) GeneratePassword() (string, error) {
+	length := max(p.MinLength, generatedPasswordMinLength)
+	classes := []string{passwordLowerChars, passwordUpperChars, passwordDigitChars, passwordOtherChars}
+	all := passwordLowerChars + passwordUpperChars + passwordDigitChars + passwordOtherChars
+
+	password := make([]byte, length)
+	// use every character class at least once, so that any required number
+	// of classes is satisfied
+	for i := range password {
+		chars := all
+		if i < len(classes) {
+			chars = classes[i]
+		}
+		c, err := randomIndex(len(chars))
+		if err != nil {
+			return "", err
+		}
+		password[i] = chars[c]
+	}
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(i.Int64()), nil
+}
+
+func passwordCharClasses(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	n := 0
+	for _, used := range []bool{lower, upper, digit, other} {
+		if used {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/authpb"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		wantErr  bool
+	}{
+		{name: "zero policy accepts empty password", password: ""},
+		{name: "long enough", policy: PasswordPolicy{MinLength: 3}, password: "abc"},
+		{name: "too short", policy: PasswordPolicy{MinLength: 4}, password: "abc", wantErr: true},
+		{name: "length counts characters", policy: PasswordPolicy{MinLength: 3}, password: "äöü"},
+		{name: "enough classes", policy: PasswordPolicy{MinCharClasses: 3}, password: "aB3"},
+		{name: "too few classes", policy: PasswordPolicy{MinCharClasses: 3}, password: "aBc", wantErr: true},
+		{name: "all classes", policy: PasswordPolicy{MinCharClasses: 4}, password: "aB3!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrPasswordTooWeak)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyExpired(t *testing.T) {
+	now := time.Unix(10000, 0)
+	p := PasswordPolicy{MaxAge: time.Hour}
+	assert.False(t, p.Expired(now.Add(-time.Minute).Unix(), now))
+	assert.True(t, p.Expired(now.Add(-2*time.Hour).Unix(), now))
+	// the age of passwords without a change time is unknown
+	assert.False(t, p.Expired(0, now))
+	assert.False(t, PasswordPolicy{}.Expired(1, now))
+}
+
+func TestPasswordPolicyGeneratePassword(t *testing.T) {
+	for _, p := range []PasswordPolicy{{}, {MinLength: 40, MinCharClasses: 4}} {
+		password, err := p.GeneratePassword()
+		require.NoError(t, err)
+		require.NoError(t, p.Check(password))
+		assert.GreaterOrEqual(t, len(password), max(p.MinLength, generatedPasswordMinLength))
+		assert.Equal(t, 4, passwordCharClasses(password))
+
+		other, err := p.GeneratePassword()
+		require.NoError(t, err)
+		assert.NotEqual(t, password, other)
+	}
+}
+
+func TestCheckPasswordHash(t *testing.T) {
+	require.NoError(t, CheckPasswordHash(encodePassword("foo")))
+	require.ErrorIs(t, CheckPasswordHash("not base64!"), ErrInvalidPasswordHash)
+	require.ErrorIs(t, CheckPasswordHash("Zm9v"), ErrInvalidPasswordHash)
+	require.ErrorIs(t, CheckPasswordHash(""), ErrInvalidPasswordHash)
+}
+
+func TestCheckPasswordExpired(t *testing.T) {
+	as, tearDown := setupAuthStore(t)
+	defer tearDown(t)
+	as.SetPasswordPolicy(PasswordPolicy{MaxAge: time.Hour})
+
+	_, err := as.UserAdd(&pb.AuthUserAddRequest{
+		Name:                "expired",
+		HashedPassword:      encodePassword("bar"),
+		Options:             &authpb.UserAddOptions{},
+		PasswordChangedTime: time.Now().Add(-2 * time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	_, err = as.CheckPassword("expired", "bar")
+	require.ErrorIs(t, err, ErrPasswordExpired)
+	// a wrong password does not reveal the expiry
+	_, err = as.CheckPassword("expired", "baz")
+	require.ErrorIs(t, err, ErrAuthFailed)
+
+	expired, unknownAge := as.stalePasswordUsers()
+	assert.Equal(t, 1, expired)
+	// the users of setupAuthStore are added without change time
+	assert.Positive(t, unknownAge)
+
+	_, err = as.UserChangePassword(&pb.AuthUserChangePasswordRequest{
+		Name:                "expired",
+		HashedPassword:      encodePassword("baz"),
+		PasswordChangedTime: time.Now().Unix(),
+	})
+	require.NoError(t, err)
+	_, err = as.CheckPassword("expired", "baz")
+	require.NoError(t, err)
+	expired, _ = as.stalePasswordUsers()
+	assert.Equal(t, 0, expired)
+
+	// the change time is kept when roles change
+	_, err = as.UserGrantRole(&pb.AuthUserGrantRoleRequest{User: "expired", Role: "role-test"})
+	require.NoError(t, err)
+	assert.NotZero(t, as.be.GetUser("expired").PasswordChangedTime)
+	assert.True(t, as.HasPassword("expired"))
+	assert.False(t, as.HasPassword("no-such-user"))
+}
@@ -15,6 +15,8 @@
 package auth
 
 import (
+	"bytes"
+
 	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/api/v3/authpb"
@@ -67,6 +69,7 @@ func getMergedPerms(tx UnsafeAuthReader, userName string) *unifiedRangePermissio
 	return &unifiedRangePermissions{
 		readPerms:  readPerms,
 		writePerms: writePerms,
+		root:       hasRootRole(user),
 	}
 }
 
@@ -152,11 +155,116 @@ func (as *authStore) refreshRangePermCache(tx UnsafeAuthReader) {
 		}
 		as.rangePermCache[userName] = perms
 	}
+
+	as.refreshPasswordChangedTimes(users)
+	as.permissionsChanged.Notify()
 }
 
 type unifiedRangePermissions struct {
 	readPerms  adt.IntervalTree
 	writePerms adt.IntervalTree
+	// root is set if the user has the root role
+	root bool
+}
+
+// KeyRange is the range of keys [Key, RangeEnd). RangeEnd is empty for the
+// single key Key, and []byte{0} for all the keys from Key on.
+type KeyRange struct {
+	Key      []byte
+	RangeEnd []byte
+}
+
+// Contains returns true if the key is in the range.
+func (r KeyRange) Contains(key []byte) bool {
+	switch {
+	case len(r.RangeEnd) == 0:
+		return bytes.Equal(key, r.Key)
+	case isOpenEnded(r.RangeEnd):
+		return bytes.Compare(key, r.Key) >= 0
+	}
+	return bytes.Compare(key, r.Key) >= 0 && bytes.Compare(key, r.RangeEnd) < 0
+}
+
+func (r KeyRange) interval() adt.Interval {
+	switch {
+	case len(r.RangeEnd) == 0:
+		return adt.NewBytesAffinePoint(r.Key)
+	case isOpenEnded(r.RangeEnd):
+		return adt.NewBytesAffineInterval(r.Key, nil)
+	}
+	return adt.NewBytesAffineInterval(r.Key, r.RangeEnd)
+}
+
+// keyRangeOf returns the key range of an interval of the interval tree.
+func keyRangeOf(ivl adt.Interval) KeyRange {
+	key := []byte(ivl.Begin.(adt.BytesAffineComparable))
+	end := []byte(ivl.End.(adt.BytesAffineComparable))
+	switch {
+	case len(end) == 0:
+		end = []byte{0}
+	case len(end) == len(key)+1 && end[len(key)] == 0 && bytes.HasPrefix(end, key):
+		// the interval of a single key
+		end = nil
+	}
+	return KeyRange{Key: key, RangeEnd: end}
+}
+
+// ReadableRanges returns the parts of the range r the user is permitted to
+// read, in key order. It returns r alone if the user may read all of it,
+// and nil if the user may read none of it or does not exist.
+func (as *authStore) ReadableRanges(userName string, r KeyRange) []KeyRange {
+	if !as.IsAuthEnabled() {
+		return []KeyRange{r}
+	}
+
+	as.rangePermCacheMu.RLock()
+	defer as.rangePermCacheMu.RUnlock()
+
+	perms, ok := as.rangePermCache[userName]
+	if !ok {
+		return nil
+	}
+	if perms.root {
+		return []KeyRange{r}
+	}
+
+	ivl := r.interval()
+	if len(r.RangeEnd) == 0 {
+		if perms.readPerms.Intersects(ivl) {
+			return []KeyRange{r}
+		}
+		return nil
+	}
+	if perms.readPerms.Contains(ivl) {
+		return []KeyRange{r}
+	}
+
+	// clip the permissions overlapping the range to it, merging those that
+	// overlap each other; they are visited in the order of their beginning
+	var ivls []adt.Interval
+	perms.readPerms.Visit(ivl, func(n *adt.IntervalValue) bool {
+		p := n.Ivl
+		if p.Begin.Compare(ivl.Begin) < 0 {
+			p.Begin = ivl.Begin
+		}
+		if p.End.Compare(ivl.End) > 0 {
+			p.End = ivl.End
+		}
+		if last := len(ivls) - 1; last >= 0 && p.Begin.Compare(ivls[last].End) <= 0 {
+			if p.End.Compare(ivls[last].End) > 0 {
+				ivls[last].End = p.End
+			}
+			return true
+		}
+		ivls = append(ivls, p)
+		return true
+	})
+
+	var ranges []KeyRange
+	for _, p := range ivls {
+		ranges = append(ranges, keyRangeOf(p))
+	}
+	return ranges
 }
 
 // Constraints related to key range
@@ -0,0 +1,86 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/authpb"
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func TestRoleGrantRPCPermission(t *testing.T) {
+	as, tearDown := setupAuthStore(t)
+	defer tearDown(t)
+
+	_, err := as.RoleGrantRPCPermission(&pb.AuthRoleGrantRPCPermissionRequest{Name: "role-test", Method: "Maintenance.Unknown"})
+	require.ErrorIs(t, err, ErrInvalidRPCMethod)
+	_, err = as.RoleGrantRPCPermission(&pb.AuthRoleGrantRPCPermissionRequest{Name: "no-role", Method: RPCMaintenanceDefragment})
+	require.ErrorIs(t, err, ErrRoleNotFound)
+
+	for _, m := range []string{RPCMaintenanceSnapshot, RPCMaintenanceDefragment, RPCMaintenanceSnapshot} {
+		_, err = as.RoleGrantRPCPermission(&pb.AuthRoleGrantRPCPermissionRequest{Name: "role-test", Method: m})
+		require.NoError(t, err)
+	}
+	resp, err := as.RoleGet(&pb.AuthRoleGetRequest{Role: "role-test"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{RPCMaintenanceDefragment, RPCMaintenanceSnapshot}, resp.RpcPermissions)
+
+	// revoking a key permission keeps the RPC permissions of the role
+	_, err = as.RoleGrantPermission(&pb.AuthRoleGrantPermissionRequest{Name: "role-test", Perm: &authpb.Permission{PermType: authpb.READ, Key: []byte("foo")}})
+	require.NoError(t, err)
+	_, err = as.RoleRevokePermission(&pb.AuthRoleRevokePermissionRequest{Role: "role-test", Key: []byte("foo")})
+	require.NoError(t, err)
+	resp, err = as.RoleGet(&pb.AuthRoleGetRequest{Role: "role-test"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{RPCMaintenanceDefragment, RPCMaintenanceSnapshot}, resp.RpcPermissions)
+
+	_, err = as.RoleRevokeRPCPermission(&pb.AuthRoleRevokeRPCPermissionRequest{Role: "role-test", Method: RPCMaintenanceSnapshot})
+	require.NoError(t, err)
+	_, err = as.RoleRevokeRPCPermission(&pb.AuthRoleRevokeRPCPermissionRequest{Role: "role-test", Method: RPCMaintenanceSnapshot})
+	require.ErrorIs(t, err, ErrPermissionNotGranted)
+	resp, err = as.RoleGet(&pb.AuthRoleGetRequest{Role: "role-test"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{RPCMaintenanceDefragment}, resp.RpcPermissions)
+}
+
+func TestIsRPCPermitted(t *testing.T) {
+	as, tearDown := setupAuthStore(t)
+	defer tearDown(t)
+
+	_, err := as.RoleGrantRPCPermission(&pb.AuthRoleGrantRPCPermissionRequest{Name: "role-test", Method: RPCClusterMemberAdd})
+	require.NoError(t, err)
+
+	foo := &AuthInfo{Username: "foo", Revision: 1}
+	require.ErrorIs(t, as.IsRPCPermitted(foo, RPCClusterMemberAdd), ErrPermissionDenied)
+
+	_, err = as.UserGrantRole(&pb.AuthUserGrantRoleRequest{User: "foo", Role: "role-test"})
+	require.NoError(t, err)
+	require.NoError(t, as.IsRPCPermitted(foo, RPCClusterMemberAdd))
+	require.ErrorIs(t, as.IsRPCPermitted(foo, RPCClusterMemberRemove), ErrPermissionDenied)
+
+	require.NoError(t, as.IsRPCPermitted(&AuthInfo{Username: "root", Revision: 1}, RPCClusterMemberRemove))
+	require.ErrorIs(t, as.IsRPCPermitted(&AuthInfo{Username: "nope", Revision: 1}, RPCClusterMemberAdd), ErrUserNotFound)
+	require.ErrorIs(t, as.IsRPCPermitted(&AuthInfo{}, RPCClusterMemberAdd), ErrUserEmpty)
+
+	// the RPC permission is no admin permission
+	require.ErrorIs(t, as.IsAdminPermitted(foo), ErrPermissionDenied)
+
+	as.AuthDisable()
+	require.NoError(t, as.IsRPCPermitted(foo, RPCClusterMemberRemove))
+}
@@ -156,6 +156,16 @@ func (t *tokenSimple) invalidateUser(username string) {
 	t.simpleTokensMu.Unlock()
 }
 
+func (t *tokenSimple) tokenUsers() map[string]int {
+	t.simpleTokensMu.Lock()
+	defer t.simpleTokensMu.Unlock()
+	users := make(map[string]int)
+	for _, name := range t.simpleTokens {
+		users[name]++
+	}
+	return users
+}
+
 func (t *tokenSimple) enable() {
 	t.simpleTokensMu.Lock()
 	defer t.simpleTokensMu.Unlock()
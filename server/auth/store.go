@@ -34,6 +34,7 @@ import (
 	"go.etcd.io/etcd/api/v3/authpb"
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/pkg/v3/notify"
 )
 
 var _ AuthStore = (*authStore)(nil)
@@ -64,6 +65,10 @@ var (
 	ErrMissingKey           = errors.New("auth: missing key data")
 	ErrKeyMismatch          = errors.New("auth: public and private keys don't match")
 	ErrVerifyOnly           = errors.New("auth: token signing attempted with verify-only key")
+	ErrPasswordTooWeak      = errors.New("auth: password does not satisfy the password policy")
+	ErrPasswordExpired      = errors.New("auth: password has expired")
+	ErrInvalidPasswordHash  = errors.New("auth: invalid password hash")
+	ErrInvalidRPCMethod     = errors.New("auth: invalid RPC method")
 )
 
 const (
@@ -132,6 +137,12 @@ type AuthStore interface {
 	// RoleRevokePermission gets the detailed information of a role
 	RoleRevokePermission(r *pb.AuthRoleRevokePermissionRequest) (*pb.AuthRoleRevokePermissionResponse, error)
 
+	// RoleGrantRPCPermission grants an administrative RPC method to a role
+	RoleGrantRPCPermission(r *pb.AuthRoleGrantRPCPermissionRequest) (*pb.AuthRoleGrantRPCPermissionResponse, error)
+
+	// RoleRevokeRPCPermission revokes an administrative RPC method from a role
+	RoleRevokeRPCPermission(r *pb.AuthRoleRevokeRPCPermissionRequest) (*pb.AuthRoleRevokeRPCPermissionResponse, error)
+
 	// RoleDelete gets the detailed information of a role
 	RoleDelete(r *pb.AuthRoleDeleteRequest) (*pb.AuthRoleDeleteResponse, error)
 
@@ -153,6 +164,10 @@ type AuthStore interface {
 	// IsAdminPermitted checks admin permission of the user
 	IsAdminPermitted(authInfo *AuthInfo) error
 
+	// IsRPCPermitted checks whether the user may call the given
+	// administrative RPC method, either as root or through a role
+	IsRPCPermitted(authInfo *AuthInfo, method string) error
+
 	// GenTokenPrefix produces a random string in a case of simple token
 	// in a case of JWT, it produces an empty string
 	GenTokenPrefix() (string, error)
@@ -178,8 +193,29 @@ type AuthStore interface {
 	// HasRole checks that user has role
 	HasRole(user, role string) bool
 
+	// HasPassword checks that user exists and authenticates with a password
+	HasPassword(user string) bool
+
 	// BcryptCost gets strength of hashing bcrypted auth password
 	BcryptCost() int
+
+	// SetPasswordPolicy sets the policy the passwords of users must satisfy
+	SetPasswordPolicy(p PasswordPolicy)
+
+	// PasswordPolicy gets the policy the passwords of users must satisfy
+	PasswordPolicy() PasswordPolicy
+
+	// StaleTokens returns the number of tokens held for users that no
+	// longer exist, invalidating them if invalidate is set
+	StaleTokens(invalidate bool) int
+
+	// ReadableRanges returns the parts of a key range the user is permitted
+	// to read
+	ReadableRanges(userName string, r KeyRange) []KeyRange
+
+	// PermissionsNotify returns a channel that is closed when the
+	// permissions of the users may have changed
+	PermissionsNotify() <-chan struct{}
 }
 
 type TokenProvider interface {
@@ -190,6 +226,9 @@ type TokenProvider interface {
 
 	invalidateUser(string)
 	genTokenPrefix() (string, error)
+	// tokenUsers returns the number of live tokens held for each user. Token
+	// providers that do not keep tokens return nil.
+	tokenUsers() map[string]int
 }
 
 type AuthBackend interface {
@@ -259,6 +298,17 @@ type authStore struct {
 
 	tokenProvider TokenProvider
 	bcryptCost    int // the algorithm cost / strength for hashing auth passwords
+
+	// passwordChangedTimes holds the password change time of the users with
+	// a password, refreshed along with rangePermCache for the metrics of
+	// stale passwords
+	passwordChangedTimes []int64
+	passwordPolicy       PasswordPolicy
+	passwordMu           sync.RWMutex
+
+	// permissionsChanged is notified when rangePermCache is refreshed or
+	// auth is disabled
+	permissionsChanged *notify.Notifier
 }
 
 func (as *authStore) AuthEnable() error {
@@ -314,6 +364,7 @@ func (as *authStore) AuthDisable() {
 
 	as.enabled = false
 	as.tokenProvider.disable()
+	as.permissionsChanged.Notify()
 
 	as.lg.Info("disabled authentication")
 }
@@ -389,6 +440,10 @@ func (as *authStore) CheckPassword(username, password string) (uint64, error) {
 		as.lg.Info("invalid password", zap.String("user-name", username))
 		return 0, ErrAuthFailed
 	}
+	if as.PasswordPolicy().Expired(user.PasswordChangedTime, time.Now()) {
+		as.lg.Info("expired password", zap.String("user-name", username))
+		return 0, ErrPasswordExpired
+	}
 	return revision, nil
 }
 
@@ -455,6 +510,9 @@ func (as *authStore) UserAdd(r *pb.AuthUserAddRequest) (*pb.AuthUserAddResponse,
 		Password: password,
 		Options:  options,
 	}
+	if password != nil {
+		newUser.PasswordChangedTime = r.PasswordChangedTime
+	}
 	tx.UnsafePutUser(newUser)
 
 	as.commitRevision(tx)
@@ -520,6 +578,9 @@ func (as *authStore) UserChangePassword(r *pb.AuthUserChangePasswordRequest) (*p
 		Password: password,
 		Options:  user.Options,
 	}
+	if password != nil {
+		updatedUser.PasswordChangedTime = r.PasswordChangedTime
+	}
 	tx.UnsafePutUser(updatedUser)
 
 	as.commitRevision(tx)
@@ -589,6 +650,10 @@ func (as *authStore) UserGet(r *pb.AuthUserGetRequest) (*pb.AuthUserGetResponse,
 
 	var resp pb.AuthUserGetResponse
 	resp.Roles = append(resp.Roles, user.Roles...)
+	resp.NoPassword = user.Options != nil && user.Options.NoPassword
+	if r.WithPasswordHash && len(user.Password) > 0 {
+		resp.HashedPassword = base64.StdEncoding.EncodeToString(user.Password)
+	}
 	return &resp, nil
 }
 
@@ -622,9 +687,10 @@ func (as *authStore) UserRevokeRole(r *pb.AuthUserRevokeRoleRequest) (*pb.AuthUs
 	}
 
 	updatedUser := &authpb.User{
-		Name:     user.Name,
-		Password: user.Password,
-		Options:  user.Options,
+		Name:                user.Name,
+		Password:            user.Password,
+		Options:             user.Options,
+		PasswordChangedTime: user.PasswordChangedTime,
 	}
 
 	for _, role := range user.Roles {
@@ -663,6 +729,7 @@ func (as *authStore) RoleGet(r *pb.AuthRoleGetRequest) (*pb.AuthRoleGetResponse,
 		resp.Perm = append(resp.Perm, &rootPerm)
 	} else {
 		resp.Perm = append(resp.Perm, role.KeyPermission...)
+		resp.RpcPermissions = append(resp.RpcPermissions, role.RpcPermissions...)
 	}
 	return &resp, nil
 }
@@ -688,7 +755,8 @@ func (as *authStore) RoleRevokePermission(r *pb.AuthRoleRevokePermissionRequest)
 	}
 
 	updatedRole := &authpb.Role{
-		Name: role.Name,
+		Name:           role.Name,
+		RpcPermissions: role.RpcPermissions,
 	}
 
 	for _, perm := range role.KeyPermission {
@@ -735,9 +803,10 @@ func (as *authStore) RoleDelete(r *pb.AuthRoleDeleteRequest) (*pb.AuthRoleDelete
 	users := tx.UnsafeGetAllUsers()
 	for _, user := range users {
 		updatedUser := &authpb.User{
-			Name:     user.Name,
-			Password: user.Password,
-			Options:  user.Options,
+			Name:                user.Name,
+			Password:            user.Password,
+			Options:             user.Options,
+			PasswordChangedTime: user.PasswordChangedTime,
 		}
 
 		for _, role := range user.Roles {
@@ -970,6 +1039,8 @@ func NewAuthStore(lg *zap.Logger, be AuthBackend, tp TokenProvider, bcryptCost i
 		rangePermCache: make(map[string]*unifiedRangePermissions),
 		tokenProvider:  tp,
 		bcryptCost:     bcryptCost,
+
+		permissionsChanged: notify.NewNotifier(),
 	}
 
 	if enabled {
@@ -1009,6 +1080,10 @@ func (as *authStore) Revision() uint64 {
 	return atomic.LoadUint64(&as.revision)
 }
 
+func (as *authStore) PermissionsNotify() <-chan struct{} {
+	return as.permissionsChanged.Receive()
+}
+
 func (as *authStore) AuthInfoFromTLS(ctx context.Context) (ai *AuthInfo) {
 	peer, ok := peer.FromContext(ctx)
 	if !ok || peer == nil || peer.AuthInfo == nil {
@@ -1217,14 +1292,76 @@ func (as *authStore) HasRole(user, role string) bool {
 	return false
 }
 
+func (as *authStore) HasPassword(user string) bool {
+	u := as.be.GetUser(user)
+	return u != nil && (u.Options == nil || !u.Options.NoPassword)
+}
+
 func (as *authStore) BcryptCost() int {
 	return as.bcryptCost
 }
 
+func (as *authStore) SetPasswordPolicy(p PasswordPolicy) {
+	as.passwordMu.Lock()
+	defer as.passwordMu.Unlock()
+	as.passwordPolicy = p
+}
+
+func (as *authStore) PasswordPolicy() PasswordPolicy {
+	as.passwordMu.RLock()
+	defer as.passwordMu.RUnlock()
+	return as.passwordPolicy
+}
+
+func (as *authStore) refreshPasswordChangedTimes(users []*authpb.User) {
+	as.passwordMu.Lock()
+	defer as.passwordMu.Unlock()
+	as.passwordChangedTimes = as.passwordChangedTimes[:0]
+	for _, u := range users {
+		if len(u.Password) != 0 {
+			as.passwordChangedTimes = append(as.passwordChangedTimes, u.PasswordChangedTime)
+		}
+	}
+}
+
+// stalePasswordUsers returns the number of users with an expired password
+// and with a password of unknown age.
+func (as *authStore) stalePasswordUsers() (expired, unknownAge int) {
+	as.passwordMu.RLock()
+	defer as.passwordMu.RUnlock()
+	now := time.Now()
+	for _, t := range as.passwordChangedTimes {
+		if t == 0 {
+			unknownAge++
+		} else if as.passwordPolicy.Expired(t, now) {
+			expired++
+		}
+	}
+	return expired, unknownAge
+}
+
+func (as *authStore) StaleTokens(invalidate bool) int {
+	stale := 0
+	for user, n := range as.tokenProvider.tokenUsers() {
+		if as.be.GetUser(user) != nil {
+			continue
+		}
+		stale += n
+		if invalidate {
+			as.tokenProvider.invalidateUser(user)
+		}
+	}
+	return stale
+}
+
 func (as *authStore) setupMetricsReporter() {
 	reportCurrentAuthRevMu.Lock()
 	reportCurrentAuthRev = func() float64 {
 		return float64(as.Revision())
 	}
 	reportCurrentAuthRevMu.Unlock()
+
+	reportStalePasswordUsersMu.Lock()
+	reportStalePasswordUsers = as.stalePasswordUsers
+	reportStalePasswordUsersMu.Unlock()
 }
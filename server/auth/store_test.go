@@ -174,6 +174,36 @@ func TestUserAdd(t *testing.T) {
 	require.Truef(t, ok, "user %s should be added but it doesn't exist in rangePermCache", userName)
 }
 
+func TestUserGetWithPasswordHash(t *testing.T) {
+	as, tearDown := setupAuthStore(t)
+	defer tearDown(t)
+
+	resp, err := as.UserGet(&pb.AuthUserGetRequest{Name: "foo"})
+	require.NoError(t, err)
+	assert.False(t, resp.NoPassword)
+	assert.Empty(t, resp.HashedPassword)
+
+	resp, err = as.UserGet(&pb.AuthUserGetRequest{Name: "foo", WithPasswordHash: true})
+	require.NoError(t, err)
+	hash, err := base64.StdEncoding.DecodeString(resp.HashedPassword)
+	require.NoError(t, err)
+	require.NoError(t, bcrypt.CompareHashAndPassword(hash, []byte("bar")))
+
+	// the hash can be used to create another user with the same password
+	_, err = as.UserAdd(&pb.AuthUserAddRequest{Name: "foo-copy", HashedPassword: resp.HashedPassword, Options: &authpb.UserAddOptions{}})
+	require.NoError(t, err)
+	ctx := context.WithValue(context.WithValue(t.Context(), AuthenticateParamIndex{}, uint64(1)), AuthenticateParamSimpleTokenPrefix{}, "dummy")
+	_, err = as.Authenticate(ctx, "foo-copy", "bar")
+	require.NoError(t, err)
+
+	_, err = as.UserAdd(&pb.AuthUserAddRequest{Name: "no-password", Options: &authpb.UserAddOptions{NoPassword: true}})
+	require.NoError(t, err)
+	resp, err = as.UserGet(&pb.AuthUserGetRequest{Name: "no-password", WithPasswordHash: true})
+	require.NoError(t, err)
+	assert.True(t, resp.NoPassword)
+	assert.Empty(t, resp.HashedPassword)
+}
+
 func TestRecover(t *testing.T) {
 	as, tearDown := setupAuthStore(t)
 	defer as.Close()
@@ -725,6 +755,67 @@ func TestRoleRevokePermission(t *testing.T) {
 	}
 }
 
+func TestReadableRanges(t *testing.T) {
+	as, tearDown := setupAuthStore(t)
+	defer tearDown(t)
+
+	_, err := as.UserGrantRole(&pb.AuthUserGrantRoleRequest{User: "foo", Role: "role-test"})
+	require.NoError(t, err)
+	for _, perm := range []*authpb.Permission{
+		{PermType: authpb.READ, Key: []byte("a"), RangeEnd: []byte("c")},
+		{PermType: authpb.READWRITE, Key: []byte("b"), RangeEnd: []byte("d")},
+		{PermType: authpb.READ, Key: []byte("f")},
+		{PermType: authpb.WRITE, Key: []byte("g"), RangeEnd: []byte("h")},
+		{PermType: authpb.READ, Key: []byte("x"), RangeEnd: []byte{0}},
+	} {
+		_, err = as.RoleGrantPermission(&pb.AuthRoleGrantPermissionRequest{Name: "role-test", Perm: perm})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		user string
+		r    KeyRange
+		want []KeyRange
+	}{
+		{"foo", KeyRange{Key: []byte("a"), RangeEnd: []byte("d")}, []KeyRange{{Key: []byte("a"), RangeEnd: []byte("d")}}},
+		{"foo", KeyRange{Key: []byte("b")}, []KeyRange{{Key: []byte("b")}}},
+		{"foo", KeyRange{Key: []byte("e")}, nil},
+		{"foo", KeyRange{Key: []byte("g"), RangeEnd: []byte("h")}, nil},
+		{"foo", KeyRange{Key: []byte("c"), RangeEnd: []byte("z")}, []KeyRange{
+			{Key: []byte("c"), RangeEnd: []byte("d")},
+			{Key: []byte("f")},
+			{Key: []byte("x"), RangeEnd: []byte("z")},
+		}},
+		{"foo", KeyRange{Key: []byte("w"), RangeEnd: []byte{0}}, []KeyRange{{Key: []byte("x"), RangeEnd: []byte{0}}}},
+		{"root", KeyRange{Key: []byte("e")}, []KeyRange{{Key: []byte("e")}}},
+		{"unknown", KeyRange{Key: []byte("a")}, nil},
+	}
+	for i, tt := range tests {
+		assert.Equalf(t, tt.want, as.ReadableRanges(tt.user, tt.r), "#%d", i)
+	}
+	assert.True(t, KeyRange{Key: []byte("x"), RangeEnd: []byte{0}}.Contains([]byte("y")))
+	assert.False(t, KeyRange{Key: []byte("c"), RangeEnd: []byte("d")}.Contains([]byte("d")))
+
+	notifyc := as.PermissionsNotify()
+	_, err = as.RoleRevokePermission(&pb.AuthRoleRevokePermissionRequest{Role: "role-test", Key: []byte("f")})
+	require.NoError(t, err)
+	select {
+	case <-notifyc:
+	default:
+		t.Fatal("expected a notification of the permission change")
+	}
+	assert.Nil(t, as.ReadableRanges("foo", KeyRange{Key: []byte("f")}))
+
+	notifyc = as.PermissionsNotify()
+	as.AuthDisable()
+	select {
+	case <-notifyc:
+	default:
+		t.Fatal("expected a notification of auth being disabled")
+	}
+	assert.Equal(t, []KeyRange{{Key: []byte("e")}}, as.ReadableRanges("foo", KeyRange{Key: []byte("e")}))
+}
+
 func TestUserRevokePermission(t *testing.T) {
 	as, tearDown := setupAuthStore(t)
 	defer tearDown(t)
@@ -1172,3 +1263,28 @@ func TestUserChangePasswordWithOldLog(t *testing.T) {
 	require.Errorf(t, err, "expected %v, got %v", ErrUserNotFound, err)
 	require.ErrorIsf(t, err, ErrUserNotFound, "expected %v, got %v", ErrUserNotFound, err)
 }
+
+func TestStaleTokens(t *testing.T) {
+	as, tearDown := setupAuthStore(t)
+	defer tearDown(t)
+
+	assign := func(user, prefix string) string {
+		ctx := context.WithValue(context.WithValue(t.Context(), AuthenticateParamIndex{}, uint64(1)), AuthenticateParamSimpleTokenPrefix{}, prefix)
+		token, err := as.tokenProvider.assign(ctx, user, as.Revision())
+		require.NoError(t, err)
+		return token
+	}
+	assign("foo", "a")
+	// the user of the token does not exist
+	ghost := assign("ghost", "b")
+	ctx := t.Context()
+
+	assert.Equal(t, 1, as.StaleTokens(false))
+	_, ok := as.tokenProvider.info(ctx, ghost, as.Revision())
+	assert.True(t, ok)
+
+	assert.Equal(t, 1, as.StaleTokens(true))
+	_, ok = as.tokenProvider.info(ctx, ghost, as.Revision())
+	assert.False(t, ok)
+	assert.Zero(t, as.StaleTokens(false))
+}
@@ -38,6 +38,18 @@ const (
 	grpcOverheadBytes = 512 * 1024
 )
 
+const (
+	// NewerRequestFieldsIgnore serves requests regardless of the etcd
+	// version of their fields.
+	NewerRequestFieldsIgnore = "ignore"
+	// NewerRequestFieldsLog serves requests with fields newer than the
+	// cluster version, but logs and reports them.
+	NewerRequestFieldsLog = "log"
+	// NewerRequestFieldsReject rejects requests with fields newer than the
+	// cluster version, and logs and reports them.
+	NewerRequestFieldsReject = "reject"
+)
+
 // ServerConfig holds the configuration of etcd as taken from the command line or discovery.
 type ServerConfig struct {
 	Name string
@@ -70,6 +82,21 @@ type ServerConfig struct {
 
 	// BackendFreelistType is the type of the backend boltdb freelist.
 	BackendFreelistType bolt.FreelistType
+	// BackendEngine is the storage engine of the backend, see backend.EngineBolt.
+	BackendEngine string
+
+	// BackendCheckpointInterval is the interval at which a checkpoint of the
+	// backend is written. 0 disables periodic checkpoints.
+	BackendCheckpointInterval time.Duration
+	// BackendCheckpointDir is the directory holding the backend checkpoints,
+	// see CheckpointDir.
+	BackendCheckpointDir string
+	// BackendCheckpointRetention is the number of checkpoints kept. 0 keeps
+	// all of them.
+	BackendCheckpointRetention int
+	// BackendBucketStatsInterval is the interval at which the number of keys
+	// and the size of each backend bucket are tracked. 0 disables tracking.
+	BackendBucketStatsInterval time.Duration
 
 	InitialPeerURLsMap  types.URLsMap
 	InitialClusterToken string
@@ -119,8 +146,19 @@ type ServerConfig struct {
 
 	AutoCompactionRetention time.Duration
 	AutoCompactionMode      string
+	// CompactionMaxHold is the longest time a compaction hold delays
+	// auto-compaction. 0 disables compaction holds.
+	CompactionMaxHold       time.Duration
 	CompactionBatchLimit    int
 	CompactionSleepInterval time.Duration
+	ValueChunkSize          int
+	// AutoCompactionCoordinated delays auto-compaction until the members
+	// caught up with the leader, for at most AutoCompactionCoordinationTimeout.
+	AutoCompactionCoordinated         bool
+	AutoCompactionCoordinationTimeout time.Duration
+	// IndexCheckpointInterval is the interval between persisted key index
+	// checkpoints. Zero disables them.
+	IndexCheckpointInterval time.Duration
 	QuotaBackendBytes       int64
 	MaxTxnOps               uint
 
@@ -131,11 +169,53 @@ type ServerConfig struct {
 	// streams that each client can open at a time.
 	MaxConcurrentStreams uint32
 
+	// GRPCResponseCompressors are the compressors unary responses may be
+	// compressed with, in order of preference. Empty disables compression.
+	GRPCResponseCompressors []string
+	// GRPCResponseCompressionMinBytes is the size under which responses are
+	// not compressed.
+	GRPCResponseCompressionMinBytes int
+
+	// TooBusyApplyBacklog is the apply backlog above which low priority
+	// writes are rejected with ErrTooBusy. 0 disables it.
+	TooBusyApplyBacklog uint64
+	// TooBusyBackoff is the base backoff suggested with ErrTooBusy.
+	TooBusyBackoff time.Duration
+
+	// GateStaleReads rejects serializable reads until the applied index is
+	// within StaleReadsCatchUpThreshold of the leader's commit index.
+	GateStaleReads             bool
+	StaleReadsCatchUpThreshold uint64
+
+	// MemorySoftLimit is the accounted memory in bytes above which idle
+	// watchers are dropped. 0 disables it.
+	MemorySoftLimit int64
+
+	// WALDiskFreeThreshold is the free disk space in bytes of the WAL
+	// directory under which writes are rejected. 0 disables it.
+	WALDiskFreeThreshold uint64
+
+	// HealthChecks are the probes run by the /health and /readyz
+	// endpoints. Empty enables the read probes only.
+	HealthChecks []string
+	// HealthCheckTimeouts bounds the duration of individual probes by name.
+	HealthCheckTimeouts map[string]time.Duration
+
 	WarningApplyDuration        time.Duration
 	WarningUnaryRequestDuration time.Duration
 
 	StrictReconfigCheck bool
 
+	// LeaderTransferPreferLabels are the member label keys whose values a
+	// transferee should share with the leader on shutdown.
+	LeaderTransferPreferLabels []string
+
+	// SetLeadership applies LeadershipDisallowed to the local member once
+	// the server is ready. Otherwise the member keeps its leadership
+	// eligibility from the cluster membership.
+	SetLeadership        bool
+	LeadershipDisallowed bool
+
 	// ClientCertAuthEnabled is true when cert has been signed by the client CA.
 	ClientCertAuthEnabled bool
 
@@ -143,14 +223,29 @@ type ServerConfig struct {
 	BcryptCost uint
 	TokenTTL   uint
 
+	// PasswordMinLength, PasswordMinCharClasses and PasswordMaxAge define
+	// the policy the passwords of users must satisfy.
+	PasswordMinLength      int
+	PasswordMinCharClasses int
+	PasswordMaxAge         time.Duration
+
 	// InitialCorruptCheck is true to check data corruption on boot
 	// before serving any peer/client traffic.
 	InitialCorruptCheck  bool
 	CorruptCheckTime     time.Duration
 	CompactHashCheckTime time.Duration
+	// CorruptCheckHashCache is true to store the keyspace hash on clean
+	// shutdown, so that the next initial corruption check can reuse it
+	// instead of hashing the whole keyspace.
+	CorruptCheckHashCache bool
 
 	// PreVote is true to enable Raft Pre-Vote.
 	PreVote bool
+	// CheckQuorum is true to enable Raft CheckQuorum.
+	CheckQuorum bool
+	// LeaseReads is true to serve linearizable reads from the leader lease
+	// by default, instead of with ReadIndex. It requires CheckQuorum.
+	LeaseReads bool
 
 	// SocketOpts are socket options passed to listener config.
 	SocketOpts transport.SocketOpts
@@ -162,6 +257,9 @@ type ServerConfig struct {
 
 	// LeaseCheckpointInterval time.Duration is the wait duration between lease checkpoints.
 	LeaseCheckpointInterval time.Duration
+	// LeaseRevokeRate is the maximum number of expired leases revoked per
+	// second. 0 uses the lessor default.
+	LeaseRevokeRate int
 
 	EnableGRPCGateway bool
 
@@ -171,6 +269,27 @@ type ServerConfig struct {
 	TracerOptions []otelgrpc.Option
 
 	WatchProgressNotifyInterval time.Duration
+	// WatchSendBatchInterval is the maximum time watch events are held back
+	// to be sent in batches on a watch stream. Zero disables batching.
+	WatchSendBatchInterval time.Duration
+
+	// SerializableReadCacheBytes is the maximum size of the cache of
+	// serializable single key reads. Zero disables the cache.
+	SerializableReadCacheBytes int
+	// SerializableReadCacheTTL is the maximum time a read is cached.
+	SerializableReadCacheTTL time.Duration
+
+	// HotKeysTopK is the number of hottest keys tracked per usage. Zero
+	// disables hot keys tracking.
+	HotKeysTopK int
+	// HotKeysSampleRate is the fraction of the requests sampled to track the
+	// hottest keys.
+	HotKeysSampleRate float64
+
+	// NewerRequestFields is how requests with fields newer than the cluster
+	// version are handled, one of NewerRequestFieldsIgnore,
+	// NewerRequestFieldsLog or NewerRequestFieldsReject.
+	NewerRequestFields string
 
 	// UnsafeNoFsync disables all uses of fsync.
 	// Setting this is unsafe and will cause data loss.
@@ -312,6 +431,14 @@ func (c *ServerConfig) WALDir() string {
 
 func (c *ServerConfig) SnapDir() string { return filepath.Join(c.MemberDir(), "snap") }
 
+// CheckpointDir returns the directory holding the backend checkpoints.
+func (c *ServerConfig) CheckpointDir() string {
+	if c.BackendCheckpointDir != "" {
+		return c.BackendCheckpointDir
+	}
+	return filepath.Join(c.DataDir, "checkpoints")
+}
+
 func (c *ServerConfig) ShouldDiscover() bool {
 	return len(c.DiscoveryCfg.Endpoints) > 0
 }
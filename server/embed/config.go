@@ -26,6 +26,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -47,11 +48,15 @@ import (
 	"go.etcd.io/etcd/pkg/v3/netutil"
 	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/etcdhttp"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3defrag"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3discovery"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3rpc"
 	"go.etcd.io/etcd/server/v3/features"
+	"go.etcd.io/etcd/server/v3/storage/backend"
 )
 
 const (
@@ -66,16 +71,28 @@ const (
 	DefaultWarningUnaryRequestDuration = 300 * time.Millisecond
 	DefaultMaxRequestBytes             = 1.5 * 1024 * 1024
 	DefaultMaxConcurrentStreams        = math.MaxUint32
+	DefaultTooBusyBackoff              = 100 * time.Millisecond
+	DefaultStaleReadsCatchUpThreshold  = 1000
+	DefaultWALDiskFreeThreshold        = 128 * 1024 * 1024
+	DefaultSerializableReadCacheTTL    = 10 * time.Second
+	DefaultHotKeysSampleRate           = 0.01
 	DefaultGRPCKeepAliveMinTime        = 5 * time.Second
 	DefaultGRPCKeepAliveInterval       = 2 * time.Hour
 	DefaultGRPCKeepAliveTimeout        = 20 * time.Second
 	DefaultDowngradeCheckTime          = 5 * time.Second
 	DefaultAutoCompactionMode          = "periodic"
 	DefaultAutoCompactionRetention     = "0"
+	DefaultAutoDefragThreshold         = "30%"
+	DefaultBackendCheckpointRetention  = 3
+	DefaultBackendBucketStatsInterval  = time.Minute
 	DefaultAuthToken                   = "simple"
 	DefaultCompactHashCheckTime        = time.Minute
 	DefaultLoggingFormat               = "json"
 
+	// DefaultGRPCResponseCompressionMinBytes is the size under which
+	// responses are not compressed, as compression does not pay off.
+	DefaultGRPCResponseCompressionMinBytes = 1024
+
 	DefaultDiscoveryDialTimeout       = 2 * time.Second
 	DefaultDiscoveryRequestTimeOut    = 5 * time.Second
 	DefaultDiscoveryKeepAliveTime     = 2 * time.Second
@@ -113,6 +130,8 @@ const (
 	// maxElectionMs specifies the maximum value of election timeout.
 	// More details are listed on etcd.io/docs > version > tuning/#time-parameters
 	maxElectionMs = 50000
+	// maxWatchSendBatchInterval bounds the latency added by watch send batching.
+	maxWatchSendBatchInterval = time.Second
 	// backend freelist map type
 	freelistArrayType = "array"
 
@@ -152,6 +171,26 @@ var (
 	CompactorModeRevision = v3compactor.ModeRevision
 )
 
+const (
+	// ReadConsistencyReadIndex confirms the leadership with a quorum of
+	// members before serving linearizable reads.
+	ReadConsistencyReadIndex = "read-index"
+
+	// ReadConsistencyLease serves linearizable reads on the leader from its
+	// lease, without a round trip to a quorum of members. It relies on
+	// bounded clock drift between members and requires "--check-quorum".
+	ReadConsistencyLease = "lease"
+)
+
+const (
+	// RaftLeadershipAllowed makes the member eligible to be the raft leader.
+	RaftLeadershipAllowed = "allowed"
+
+	// RaftLeadershipDisallowed makes the member transfer its leadership to
+	// an eligible member whenever it wins an election.
+	RaftLeadershipDisallowed = "disallowed"
+)
+
 func init() {
 	defaultHostname, defaultHostStatus = netutil.GetDefaultHost()
 }
@@ -221,16 +260,71 @@ type Config struct {
 	BackendBatchInterval time.Duration `json:"backend-batch-interval"`
 	// BackendBatchLimit is the maximum operations before commit the backend transaction.
 	BackendBatchLimit int `json:"backend-batch-limit"`
+	// BackendEngine is the storage engine of the backend, either
+	// backend.EngineBolt or the experimental backend.EnginePebble.
+	BackendEngine string `json:"backend-engine"`
 	// BackendFreelistType specifies the type of freelist that boltdb backend uses (array and map are supported types).
 	BackendFreelistType string `json:"backend-bbolt-freelist-type"`
 	QuotaBackendBytes   int64  `json:"quota-backend-bytes"`
 	MaxTxnOps           uint   `json:"max-txn-ops"`
 	MaxRequestBytes     uint   `json:"max-request-bytes"`
 
+	// BackendCheckpointInterval is the interval at which the member writes a
+	// checkpoint of its backend to BackendCheckpointDir. The checkpoints are
+	// copy-on-write clones of the database file on filesystems supporting
+	// them, like XFS and btrfs, and full copies otherwise. 0 disables
+	// periodic checkpoints; they can still be taken with the Checkpoint RPC.
+	BackendCheckpointInterval time.Duration `json:"backend-checkpoint-interval"`
+	// BackendCheckpointDir is the directory holding the backend checkpoints.
+	// It defaults to the "checkpoints" directory of the data directory.
+	BackendCheckpointDir string `json:"backend-checkpoint-dir"`
+	// BackendCheckpointRetention is the number of checkpoints kept. 0 keeps
+	// all of them.
+	BackendCheckpointRetention int `json:"backend-checkpoint-retention"`
+	// BackendBucketStatsInterval is the interval at which the member tracks
+	// the number of keys and the size of each bucket of its backend, exposed
+	// by metrics and the StorageStats RPC. Tracking walks the whole backend.
+	// 0 disables it.
+	BackendBucketStatsInterval time.Duration `json:"backend-bucket-stats-interval"`
+
 	// MaxConcurrentStreams specifies the maximum number of concurrent
 	// streams that each client can open at a time.
 	MaxConcurrentStreams uint32 `json:"max-concurrent-streams"`
 
+	// GRPCResponseCompression lists the compressors, among gzip and zstd,
+	// unary gRPC responses may be compressed with, in order of preference.
+	// The first one advertised by the client is used for each call. Empty
+	// disables response compression.
+	GRPCResponseCompression []string `json:"grpc-response-compression"`
+	// GRPCResponseCompressionMinBytes is the size in bytes under which
+	// responses are sent uncompressed.
+	GRPCResponseCompressionMinBytes int `json:"grpc-response-compression-min-bytes"`
+
+	// TooBusyApplyBacklog is the number of committed but not yet applied
+	// entries above which low priority writes are rejected with a retriable
+	// "too busy" error instead of being queued. 0 disables it.
+	TooBusyApplyBacklog uint64 `json:"too-busy-apply-backlog"`
+	// TooBusyBackoff is the backoff suggested to low priority writes rejected
+	// at TooBusyApplyBacklog. The suggestion grows with the backlog.
+	TooBusyBackoff time.Duration `json:"too-busy-backoff"`
+
+	// ServeStaleReads serves serializable reads while the member replays the
+	// entries it missed. If false, serializable reads are rejected with a
+	// retriable error until the applied index is within
+	// StaleReadsCatchUpThreshold entries of the leader's commit index.
+	ServeStaleReads            bool   `json:"serve-stale-reads"`
+	StaleReadsCatchUpThreshold uint64 `json:"stale-reads-catch-up-threshold"`
+
+	// MemorySoftLimit is the memory in bytes held by the key index, watchers,
+	// leases and gRPC buffers above which the member drops idle watchers
+	// to reclaim memory. 0 disables it.
+	MemorySoftLimit int64 `json:"memory-soft-limit"`
+
+	// WALDiskFreeThreshold is the free disk space in bytes of the WAL
+	// directory under which the member raises the WALNOSPACE alarm and the
+	// cluster rejects writes, before the WAL runs out of space. 0 disables it.
+	WALDiskFreeThreshold uint64 `json:"wal-disk-free-threshold"`
+
 	//revive:disable:var-naming
 	ListenPeerUrls, ListenClientUrls, ListenClientHttpUrls []url.URL
 	AdvertisePeerUrls, AdvertiseClientUrls                 []url.URL
@@ -269,6 +363,17 @@ type Config struct {
 	InitialClusterToken string `json:"initial-cluster-token"`
 	StrictReconfigCheck bool   `json:"strict-reconfig-check"`
 
+	// LeaderTransferPreferLabels are member label keys. When the leader
+	// transfers its leadership on shutdown, it prefers a member with the same
+	// values as itself for all these labels, e.g. a member in the same zone.
+	LeaderTransferPreferLabels []string `json:"leader-transfer-prefer-labels"`
+
+	// RaftLeadership is the leadership eligibility applied to the member once
+	// it has joined the cluster, either RaftLeadershipAllowed or
+	// RaftLeadershipDisallowed. If empty, the member keeps its eligibility
+	// from the cluster membership, which can be changed with MemberUpdate.
+	RaftLeadership string `json:"raft-leadership"`
+
 	// AutoCompactionMode is either 'periodic' or 'revision'.
 	AutoCompactionMode string `json:"auto-compaction-mode"`
 	// AutoCompactionRetention is either duration string with time unit
@@ -276,6 +381,29 @@ type Config struct {
 	// If no time unit is provided and compaction mode is 'periodic',
 	// the unit defaults to hour. For example, '5' translates into 5-hour.
 	AutoCompactionRetention string `json:"auto-compaction-retention"`
+	// CompactionMaxHold is the longest time a compaction hold registered by a
+	// client delays auto-compaction. 0 disables compaction holds.
+	CompactionMaxHold time.Duration `json:"compaction-max-hold"`
+	// AutoCompactionCoordinated makes the leader wait for the members to
+	// catch up before proposing an auto-compaction, so that the members
+	// compact at roughly the same time and answer ErrCompacted consistently.
+	AutoCompactionCoordinated bool `json:"auto-compaction-coordinated"`
+	// AutoCompactionCoordinationTimeout is the longest time a coordinated
+	// auto-compaction waits for the members to catch up.
+	AutoCompactionCoordinationTimeout time.Duration `json:"auto-compaction-coordination-timeout"`
+
+	// AutoDefragSchedule is the schedule at which the member defragments its
+	// backend, in the form "cron:<expression>" (e.g. "cron:0 3 * * *").
+	// Members coordinate through a lease based lock so that only one member
+	// defragments at a time, and the leader never does. Empty disables
+	// scheduled defragmentation.
+	// The lock is taken through the in-process client, which carries no
+	// credentials, so scheduled defragmentation does not run while
+	// authentication is enabled.
+	AutoDefragSchedule string `json:"auto-defrag-schedule"`
+	// AutoDefragThreshold is the minimum fragmentation of the backend, as a
+	// percentage of its size (e.g. "30%"), for scheduled defragmentation to run.
+	AutoDefragThreshold string `json:"auto-defrag-threshold"`
 
 	// GRPCKeepAliveMinTime is the minimum interval that a client should
 	// wait before pinging server. When client pings "too fast", server
@@ -308,6 +436,15 @@ type Config struct {
 	// an election, thus minimizing disruptions.
 	PreVote bool `json:"pre-vote"`
 
+	// CheckQuorum is true to make the leader step down when it has not heard
+	// from a quorum of members within an election timeout.
+	CheckQuorum bool `json:"check-quorum"`
+
+	// ReadConsistency is the default consistency of linearizable reads,
+	// either ReadConsistencyReadIndex or ReadConsistencyLease. Range requests
+	// may override it.
+	ReadConsistency string `json:"read-consistency"`
+
 	CORS map[string]struct{}
 
 	// HostWhitelist lists acceptable hostnames from HTTP client requests.
@@ -349,6 +486,11 @@ type Config struct {
 	//	}
 	//	embed.StartEtcd(cfg)
 	ServiceRegister func(*grpc.Server) `json:"-"`
+	// EventHandlers are called with the lifecycle events of the server, such
+	// as leadership changes, membership changes, compactions, defragmentations
+	// and alarms. They are subscribed before the server starts, so that no
+	// event is missed. See also Etcd.OnEvent.
+	EventHandlers []etcdserver.EventHandler `json:"-"`
 
 	AuthToken  string `json:"auth-token"`
 	BcryptCost uint   `json:"bcrypt-cost"`
@@ -356,8 +498,20 @@ type Config struct {
 	// AuthTokenTTL in seconds of the simple token
 	AuthTokenTTL uint `json:"auth-token-ttl"`
 
+	// PasswordMinLength is the minimum length of the passwords of users.
+	PasswordMinLength int `json:"password-min-length"`
+	// PasswordMinCharClasses is the minimum number of character classes
+	// (lower case, upper case, digits, others) used by the passwords of users.
+	PasswordMinCharClasses int `json:"password-min-char-classes"`
+	// PasswordMaxAge is the duration after which a password expires and is
+	// rejected for authentication. 0 disables the expiry.
+	PasswordMaxAge time.Duration `json:"password-max-age"`
+
 	// CorruptCheckTime is the duration of time between cluster corruption check passes.
 	CorruptCheckTime time.Duration `json:"corrupt-check-time"`
+	// CorruptCheckHashCache stores the keyspace hash on clean shutdown so the
+	// initial corruption check on the next boot can skip hashing the keyspace.
+	CorruptCheckHashCache bool `json:"corrupt-check-hash-cache"`
 
 	// CompactHashCheckTime is the duration of time between leader checks followers compaction hashes.
 	CompactHashCheckTime time.Duration `json:"compact-hash-check-time"`
@@ -365,8 +519,37 @@ type Config struct {
 	CompactionBatchLimit int `json:"compaction-batch-limit"`
 	// CompactionSleepInterval is the sleep interval between every etcd compaction loop.
 	CompactionSleepInterval time.Duration `json:"compaction-sleep-interval"`
+	// ValueChunkSize is the size in bytes above which values are split into chunks
+	// stored outside the key bucket. Zero disables value chunking. Requires the
+	// ValueChunking feature gate.
+	ValueChunkSize int `json:"value-chunk-size"`
+	// LeaseRevokeRate is the maximum number of expired leases revoked per
+	// second. After a leader change, lease expiries are spread so that they do
+	// not exceed it. Zero uses 1000.
+	LeaseRevokeRate int `json:"lease-revoke-rate"`
+	// IndexCheckpointInterval is the interval between checkpoints of the key
+	// index persisted in the backend, which are used to skip most of the index
+	// rebuild on restart. Zero disables index checkpoints.
+	IndexCheckpointInterval time.Duration `json:"index-checkpoint-interval"`
 	// WatchProgressNotifyInterval is the time duration of periodic watch progress notifications.
 	WatchProgressNotifyInterval time.Duration `json:"watch-progress-notify-interval"`
+	// WatchSendBatchInterval is the maximum time watch events are held back to
+	// be sent in batches on a watch stream. Zero sends every response immediately.
+	WatchSendBatchInterval time.Duration `json:"watch-send-batch-interval"`
+	// SerializableReadCacheBytes is the maximum size in bytes of the cache of
+	// serializable reads of single keys. Zero disables the cache.
+	SerializableReadCacheBytes int `json:"serializable-read-cache-bytes"`
+	// SerializableReadCacheTTL is the maximum time a serializable read is cached.
+	SerializableReadCacheTTL time.Duration `json:"serializable-read-cache-ttl"`
+	// HotKeysTopK is the number of hottest keys tracked by reads, writes,
+	// bytes read and bytes written. Zero disables hot keys tracking.
+	HotKeysTopK int `json:"hot-keys-top-k"`
+	// HotKeysSampleRate is the fraction of the requests sampled to track the
+	// hottest keys.
+	HotKeysSampleRate float64 `json:"hot-keys-sample-rate"`
+	// NewerRequestFields is how requests with fields, messages or enum values
+	// newer than the cluster version are handled: "ignore", "log" or "reject".
+	NewerRequestFields string `json:"newer-request-fields"`
 	// WarningApplyDuration is the time duration after which a warning is generated if applying request
 	WarningApplyDuration time.Duration `json:"warning-apply-duration"`
 	// BootstrapDefragThresholdMegabytes is the minimum number of megabytes needed to be freed for etcd server to
@@ -385,6 +568,19 @@ type Config struct {
 	ListenMetricsUrls     []url.URL
 	ListenMetricsUrlsJSON string `json:"listen-metrics-urls"`
 
+	// HealthChecks are the probes run by the /health and /readyz endpoints,
+	// among serializable_read, linearizable_read, lease_grant and
+	// disk_fsync. Empty enables serializable_read and linearizable_read.
+	HealthChecks []string `json:"health-checks"`
+	// HealthCheckTimeouts bounds the duration of individual probes by name.
+	// Probes without a timeout are bounded by the request timeout on
+	// /health, and by the HTTP request on /readyz.
+	HealthCheckTimeouts map[string]time.Duration `json:"health-check-timeouts"`
+
+	// ListenerConfigs overrides the settings of individual client and
+	// metrics listeners.
+	ListenerConfigs []ListenerConfig `json:"listener-configs"`
+
 	// EnableDistributedTracing indicates if tracing using OpenTelemetry is enabled.
 	EnableDistributedTracing bool `json:"enable-distributed-tracing"`
 	// DistributedTracingAddress is the address of the OpenTelemetry Collector.
@@ -493,6 +689,8 @@ type securityConfig struct {
 	AllowedCNs          []string `json:"allowed-cn"`
 	AllowedHostnames    []string `json:"allowed-hostname"`
 	SkipClientSANVerify bool     `json:"skip-client-san-verification,omitempty"`
+	OCSPVerify          string   `json:"ocsp-verify,omitempty"`
+	OCSPStapling        bool     `json:"ocsp-stapling,omitempty"`
 }
 
 // NewConfig creates a new Config populated with default values.
@@ -510,11 +708,24 @@ func NewConfig() *Config {
 		SnapshotCount:          etcdserver.DefaultSnapshotCount,
 		SnapshotCatchUpEntries: etcdserver.DefaultSnapshotCatchUpEntries,
 
+		BackendEngine:        backend.EngineBolt,
 		MaxTxnOps:            DefaultMaxTxnOps,
 		MaxRequestBytes:      DefaultMaxRequestBytes,
 		MaxConcurrentStreams: DefaultMaxConcurrentStreams,
+		TooBusyBackoff:       DefaultTooBusyBackoff,
 		WarningApplyDuration: DefaultWarningApplyDuration,
 
+		GRPCResponseCompressionMinBytes: DefaultGRPCResponseCompressionMinBytes,
+
+		ServeStaleReads:            true,
+		StaleReadsCatchUpThreshold: DefaultStaleReadsCatchUpThreshold,
+
+		WALDiskFreeThreshold: DefaultWALDiskFreeThreshold,
+
+		SerializableReadCacheTTL: DefaultSerializableReadCacheTTL,
+		HotKeysSampleRate:        DefaultHotKeysSampleRate,
+		NewerRequestFields:       config.NewerRequestFieldsIgnore,
+
 		GRPCKeepAliveMinTime:  DefaultGRPCKeepAliveMinTime,
 		GRPCKeepAliveInterval: DefaultGRPCKeepAliveInterval,
 		GRPCKeepAliveTimeout:  DefaultGRPCKeepAliveTimeout,
@@ -538,6 +749,7 @@ func NewConfig() *Config {
 
 		StrictReconfigCheck: DefaultStrictReconfigCheck,
 		Metrics:             "basic",
+		HealthChecks:        slices.Clone(etcdhttp.DefaultHealthChecks),
 
 		CORS:          map[string]struct{}{"*": {}},
 		HostWhitelist: map[string]struct{}{"*": {}},
@@ -548,7 +760,9 @@ func NewConfig() *Config {
 		SelfSignedCertValidity: DefaultSelfSignedCertValidity,
 		TlsMinVersion:          DefaultTLSMinVersion,
 
-		PreVote: true,
+		PreVote:         true,
+		CheckQuorum:     true,
+		ReadConsistency: ReadConsistencyReadIndex,
 
 		loggerMu:              new(sync.RWMutex),
 		logger:                nil,
@@ -585,10 +799,14 @@ func NewConfig() *Config {
 			},
 		},
 
-		AutoCompactionMode:      DefaultAutoCompactionMode,
-		AutoCompactionRetention: DefaultAutoCompactionRetention,
-		ServerFeatureGate:       features.NewDefaultServerFeatureGate(DefaultName, nil),
-		FlagsExplicitlySet:      map[string]bool{},
+		AutoCompactionMode:                DefaultAutoCompactionMode,
+		AutoCompactionRetention:           DefaultAutoCompactionRetention,
+		AutoCompactionCoordinationTimeout: v3compactor.DefaultCoordinationTimeout,
+		AutoDefragThreshold:               DefaultAutoDefragThreshold,
+		BackendCheckpointRetention:        DefaultBackendCheckpointRetention,
+		BackendBucketStatsInterval:        DefaultBackendBucketStatsInterval,
+		ServerFeatureGate:                 features.NewDefaultServerFeatureGate(DefaultName, nil),
+		FlagsExplicitlySet:                map[string]bool{},
 	}
 	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
 	return cfg
@@ -616,6 +834,8 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 		"listen-metrics-urls",
 		"List of URLs to listen on for the metrics and health endpoints.",
 	)
+	fs.Var(flags.NewStringsValue(strings.Join(etcdhttp.DefaultHealthChecks, ",")), "health-checks", "Comma-separated list of probes run by /health and /readyz, among: "+strings.Join(etcdhttp.HealthChecks, ", ")+".")
+	fs.Var(flags.NewStringsValue(""), "health-check-timeouts", "Comma-separated list of <probe>=<duration> timeouts of individual health probes, e.g. 'lease_grant=2s'.")
 	fs.UintVar(&cfg.MaxSnapFiles, "max-snapshots", cfg.MaxSnapFiles, "Maximum number of snapshot files to retain (0 is unlimited). Deprecated in v3.6 and will be decommissioned in v3.7.")
 	fs.UintVar(&cfg.MaxWalFiles, "max-wals", cfg.MaxWalFiles, "Maximum number of wal files to retain (0 is unlimited).")
 	fs.StringVar(&cfg.Name, "name", cfg.Name, "Human-readable name for this member.")
@@ -625,7 +845,12 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&cfg.InitialElectionTickAdvance, "initial-election-tick-advance", cfg.InitialElectionTickAdvance, "Whether to fast-forward initial election ticks on boot for faster election.")
 	fs.Int64Var(&cfg.QuotaBackendBytes, "quota-backend-bytes", cfg.QuotaBackendBytes, "Sets the maximum size (in bytes) that the etcd backend database may consume. Exceeding this triggers an alarm and puts etcd in read-only mode. Set to 0 to use the default 2GiB limit.")
 	fs.StringVar(&cfg.BackendFreelistType, "backend-bbolt-freelist-type", cfg.BackendFreelistType, "BackendFreelistType specifies the type of freelist that boltdb backend uses(array and map are supported types)")
+	fs.StringVar(&cfg.BackendEngine, "backend-engine", cfg.BackendEngine, "Storage engine of the backend, one of: bolt|pebble. The pebble engine is experimental. An existing backend must be converted with 'etcdutl convert-backend' to change it.")
 	fs.DurationVar(&cfg.BackendBatchInterval, "backend-batch-interval", cfg.BackendBatchInterval, "BackendBatchInterval is the maximum time before commit the backend transaction.")
+	fs.DurationVar(&cfg.BackendCheckpointInterval, "backend-checkpoint-interval", cfg.BackendCheckpointInterval, "Interval at which to write a checkpoint of the backend, cloned on filesystems supporting copy-on-write like XFS and btrfs. 0 disables periodic checkpoints.")
+	fs.StringVar(&cfg.BackendCheckpointDir, "backend-checkpoint-dir", cfg.BackendCheckpointDir, "Directory holding the backend checkpoints. Defaults to the 'checkpoints' directory of the data directory.")
+	fs.IntVar(&cfg.BackendCheckpointRetention, "backend-checkpoint-retention", cfg.BackendCheckpointRetention, "Number of backend checkpoints to keep. 0 keeps all of them.")
+	fs.DurationVar(&cfg.BackendBucketStatsInterval, "backend-bucket-stats-interval", cfg.BackendBucketStatsInterval, "Interval at which to track the number of keys and the size of each backend bucket. 0 disables tracking.")
 	fs.IntVar(&cfg.BackendBatchLimit, "backend-batch-limit", cfg.BackendBatchLimit, "BackendBatchLimit is the maximum operations before commit the backend transaction.")
 	fs.UintVar(&cfg.MaxTxnOps, "max-txn-ops", cfg.MaxTxnOps, "Maximum number of operations permitted in a transaction.")
 	fs.UintVar(&cfg.MaxRequestBytes, "max-request-bytes", cfg.MaxRequestBytes, "Maximum client request size in bytes the server will accept.")
@@ -636,6 +861,14 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&cfg.SocketOpts.ReuseAddress, "socket-reuse-address", cfg.SocketOpts.ReuseAddress, "Enable to set socket option SO_REUSEADDR on listeners allowing binding to an address in `TIME_WAIT` state.")
 
 	fs.Var(flags.NewUint32Value(cfg.MaxConcurrentStreams), "max-concurrent-streams", "Maximum concurrent streams that each client can open at a time.")
+	fs.Var(flags.NewStringsValue(""), "grpc-response-compression", "Comma-separated list of compressors, among: "+strings.Join(v3rpc.ResponseCompressors, ", ")+", unary gRPC responses may be compressed with, in order of preference. Empty disables response compression.")
+	fs.IntVar(&cfg.GRPCResponseCompressionMinBytes, "grpc-response-compression-min-bytes", cfg.GRPCResponseCompressionMinBytes, "Size in bytes under which gRPC responses are sent uncompressed.")
+	fs.Uint64Var(&cfg.TooBusyApplyBacklog, "too-busy-apply-backlog", cfg.TooBusyApplyBacklog, "Number of committed but unapplied entries above which low priority writes are rejected as too busy. 0 disables it.")
+	fs.DurationVar(&cfg.TooBusyBackoff, "too-busy-backoff", cfg.TooBusyBackoff, "Backoff suggested to low priority writes rejected as too busy. It grows with the apply backlog.")
+	fs.BoolVar(&cfg.ServeStaleReads, "serve-stale-reads", cfg.ServeStaleReads, "Serve serializable reads while the member catches up with the leader after it starts. If false, they are rejected until the member is within stale-reads-catch-up-threshold entries of the leader.")
+	fs.Uint64Var(&cfg.StaleReadsCatchUpThreshold, "stale-reads-catch-up-threshold", cfg.StaleReadsCatchUpThreshold, "Number of entries behind the leader's commit index under which a member started with --serve-stale-reads=false serves serializable reads.")
+	fs.Int64Var(&cfg.MemorySoftLimit, "memory-soft-limit", cfg.MemorySoftLimit, "Memory in bytes held by the key index, watchers, leases and gRPC buffers above which idle watchers are dropped. 0 disables it.")
+	fs.Uint64Var(&cfg.WALDiskFreeThreshold, "wal-disk-free-threshold", cfg.WALDiskFreeThreshold, "Free disk space in bytes of the WAL directory under which a WALNOSPACE alarm is raised and writes are rejected. 0 disables it.")
 
 	// raft connection timeouts
 	fs.DurationVar(&rafthttp.ConnReadTimeout, "raft-read-timeout", rafthttp.DefaultConnReadTimeout, "Read timeout set on each rafthttp connection")
@@ -677,8 +910,12 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&cfg.InitialCluster, "initial-cluster", cfg.InitialCluster, "Initial cluster configuration for bootstrapping.")
 	fs.StringVar(&cfg.InitialClusterToken, "initial-cluster-token", cfg.InitialClusterToken, "Initial cluster token for the etcd cluster during bootstrap.")
 	fs.BoolVar(&cfg.StrictReconfigCheck, "strict-reconfig-check", cfg.StrictReconfigCheck, "Reject reconfiguration requests that would cause quorum loss.")
+	fs.Var(flags.NewStringsValue(""), "leader-transfer-prefer-labels", "Comma-separated list of member label keys. On shutdown, the leader prefers transferring leadership to a member with the same values for these labels.")
+	fs.StringVar(&cfg.RaftLeadership, "raft-leadership", "", "Leadership eligibility of the member, one of: allowed|disallowed. A disallowed member transfers its leadership whenever it wins an election. If empty, the eligibility recorded in the cluster membership is kept.")
 
 	fs.BoolVar(&cfg.PreVote, "pre-vote", cfg.PreVote, "Enable the raft Pre-Vote algorithm to prevent disruption when a node that has been partitioned away rejoins the cluster.")
+	fs.BoolVar(&cfg.CheckQuorum, "check-quorum", cfg.CheckQuorum, "Make the raft leader step down when it has not heard from a quorum of members within an election timeout.")
+	fs.StringVar(&cfg.ReadConsistency, "read-consistency", cfg.ReadConsistency, "Default consistency of linearizable reads, one of: read-index|lease. 'lease' serves reads from the leader lease and relies on bounded clock drift between members.")
 
 	// security
 	fs.StringVar(&cfg.ClientTLSInfo.CertFile, "cert-file", "", "Path to the client server TLS cert file.")
@@ -687,6 +924,8 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&cfg.ClientTLSInfo.ClientKeyFile, "client-key-file", "", "Path to an explicit peer client TLS key file otherwise key file will be used when client auth is required.")
 	fs.BoolVar(&cfg.ClientTLSInfo.ClientCertAuth, "client-cert-auth", false, "Enable client cert authentication.")
 	fs.StringVar(&cfg.ClientTLSInfo.CRLFile, "client-crl-file", "", "Path to the client certificate revocation list file.")
+	fs.StringVar(&cfg.ClientTLSInfo.OCSPVerify, "client-ocsp-verify", "", "Check the revocation status of client certificates with OCSP. Possible values: soft-fail, hard-fail (empty disables it).")
+	fs.BoolVar(&cfg.ClientTLSInfo.OCSPStapling, "client-ocsp-stapling", false, "Staple an OCSP response to the client server TLS cert.")
 	fs.Var(flags.NewStringsValue(""), "client-cert-allowed-hostname", "Comma-separated list of allowed SAN hostnames for client cert authentication.")
 	fs.StringVar(&cfg.ClientTLSInfo.TrustedCAFile, "trusted-ca-file", "", "Path to the client server TLS trusted CA cert file.")
 	fs.BoolVar(&cfg.ClientAutoTLS, "auto-tls", false, "Client TLS using generated certificates")
@@ -699,6 +938,8 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&cfg.PeerAutoTLS, "peer-auto-tls", false, "Peer TLS using generated certificates")
 	fs.UintVar(&cfg.SelfSignedCertValidity, "self-signed-cert-validity", 1, "The validity period of the client and peer certificates, unit is year")
 	fs.StringVar(&cfg.PeerTLSInfo.CRLFile, "peer-crl-file", "", "Path to the peer certificate revocation list file.")
+	fs.StringVar(&cfg.PeerTLSInfo.OCSPVerify, "peer-ocsp-verify", "", "Check the revocation status of peer certificates with OCSP. Possible values: soft-fail, hard-fail (empty disables it).")
+	fs.BoolVar(&cfg.PeerTLSInfo.OCSPStapling, "peer-ocsp-stapling", false, "Staple an OCSP response to the peer server TLS cert.")
 	fs.Var(flags.NewStringsValue(""), "peer-cert-allowed-cn", "Comma-separated list of allowed CNs for inter-peer TLS authentication.")
 	fs.Var(flags.NewStringsValue(""), "peer-cert-allowed-hostname", "Comma-separated list of allowed SAN hostnames for inter-peer TLS authentication.")
 	fs.Var(flags.NewStringsValue(""), "cipher-suites", "Comma-separated list of supported TLS cipher suites between client/server and peers (empty will be auto-populated by Go).")
@@ -722,7 +963,12 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&cfg.LogRotationConfigJSON, "log-rotation-config-json", DefaultLogRotationConfig, "Configures log rotation if enabled with a JSON logger config. Default: MaxSize=100(MB), MaxAge=0(days,no limit), MaxBackups=0(no limit), LocalTime=false(UTC), Compress=false(gzip)")
 
 	fs.StringVar(&cfg.AutoCompactionRetention, "auto-compaction-retention", "0", "Auto compaction retention for mvcc key value store. 0 means disable auto compaction.")
+	fs.DurationVar(&cfg.CompactionMaxHold, "compaction-max-hold", 0, "Maximum duration a compaction hold registered by a client delays auto compaction. 0 disables compaction holds.")
+	fs.BoolVar(&cfg.AutoCompactionCoordinated, "auto-compaction-coordinated", false, "Delay auto compaction until all active voting members caught up with the leader, so that they compact at roughly the same time.")
+	fs.DurationVar(&cfg.AutoCompactionCoordinationTimeout, "auto-compaction-coordination-timeout", v3compactor.DefaultCoordinationTimeout, "Maximum duration a coordinated auto compaction waits for the members to catch up.")
 	fs.StringVar(&cfg.AutoCompactionMode, "auto-compaction-mode", "periodic", "interpret 'auto-compaction-retention' one of: periodic|revision. 'periodic' for duration based retention, defaulting to hours if no time unit is provided (e.g. '5m'). 'revision' for revision number based retention.")
+	fs.StringVar(&cfg.AutoDefragSchedule, "auto-defrag-schedule", "", "Schedule at which to defragment the backend, e.g. 'cron:0 3 * * *'. Members defragment one at a time and the leader never does. Empty disables scheduled defragmentation.")
+	fs.StringVar(&cfg.AutoDefragThreshold, "auto-defrag-threshold", DefaultAutoDefragThreshold, "Minimum fragmentation of the backend, as a percentage of its size, for scheduled defragmentation to run.")
 
 	// pprof profiler via HTTP
 	fs.BoolVar(&cfg.EnablePprof, "enable-pprof", false, "Enable runtime profiling data via HTTP server. Address is at client URL + \"/debug/pprof/\"")
@@ -740,15 +986,28 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&cfg.AuthToken, "auth-token", cfg.AuthToken, "Specify auth token specific options.")
 	fs.UintVar(&cfg.BcryptCost, "bcrypt-cost", cfg.BcryptCost, "Specify bcrypt algorithm cost factor for auth password hashing.")
 	fs.UintVar(&cfg.AuthTokenTTL, "auth-token-ttl", cfg.AuthTokenTTL, "The lifetime in seconds of the auth token.")
+	fs.IntVar(&cfg.PasswordMinLength, "password-min-length", cfg.PasswordMinLength, "Minimum length of the passwords of users.")
+	fs.IntVar(&cfg.PasswordMinCharClasses, "password-min-char-classes", cfg.PasswordMinCharClasses, "Minimum number of character classes (lower case, upper case, digits, others) used by the passwords of users.")
+	fs.DurationVar(&cfg.PasswordMaxAge, "password-max-age", cfg.PasswordMaxAge, "Duration after which the password of a user expires. 0 means passwords never expire.")
 
 	// gateway
 	fs.BoolVar(&cfg.EnableGRPCGateway, "enable-grpc-gateway", cfg.EnableGRPCGateway, "Enable GRPC gateway.")
 	fs.DurationVar(&cfg.CorruptCheckTime, "corrupt-check-time", cfg.CorruptCheckTime, "Duration of time between cluster corruption check passes.")
 	fs.DurationVar(&cfg.CompactHashCheckTime, "compact-hash-check-time", cfg.CompactHashCheckTime, "Duration of time between leader checks followers compaction hashes.")
+	fs.BoolVar(&cfg.CorruptCheckHashCache, "corrupt-check-hash-cache", cfg.CorruptCheckHashCache, "Store the keyspace hash on clean shutdown so the initial corruption check on the next boot does not need to hash the keyspace.")
 
 	fs.IntVar(&cfg.CompactionBatchLimit, "compaction-batch-limit", cfg.CompactionBatchLimit, "Sets the maximum revisions deleted in each compaction batch.")
 	fs.DurationVar(&cfg.CompactionSleepInterval, "compaction-sleep-interval", cfg.CompactionSleepInterval, "Sets the sleep interval between each compaction batch.")
+	fs.IntVar(&cfg.ValueChunkSize, "value-chunk-size", cfg.ValueChunkSize, "Size in bytes above which values are stored in chunks outside the key bucket. 0 disables value chunking. Requires feature gate ValueChunking.")
+	fs.IntVar(&cfg.LeaseRevokeRate, "lease-revoke-rate", cfg.LeaseRevokeRate, "Maximum number of expired leases revoked per second, pacing revocations after a leader change. 0 uses 1000.")
+	fs.DurationVar(&cfg.IndexCheckpointInterval, "index-checkpoint-interval", cfg.IndexCheckpointInterval, "Interval between checkpoints of the key index persisted in the backend to speed up restarts. 0 disables index checkpoints.")
 	fs.DurationVar(&cfg.WatchProgressNotifyInterval, "watch-progress-notify-interval", cfg.WatchProgressNotifyInterval, "Duration of periodic watch progress notifications.")
+	fs.DurationVar(&cfg.WatchSendBatchInterval, "watch-send-batch-interval", cfg.WatchSendBatchInterval, "Maximum time watch events are held back to be sent in batches on a watch stream. 0 disables batching.")
+	fs.IntVar(&cfg.SerializableReadCacheBytes, "serializable-read-cache-bytes", cfg.SerializableReadCacheBytes, "Maximum size in bytes of the cache of serializable reads of single keys. 0 disables the cache.")
+	fs.DurationVar(&cfg.SerializableReadCacheTTL, "serializable-read-cache-ttl", cfg.SerializableReadCacheTTL, "Maximum time a serializable read is cached.")
+	fs.IntVar(&cfg.HotKeysTopK, "hot-keys-top-k", cfg.HotKeysTopK, "Number of hottest keys tracked by reads, writes, bytes read and bytes written. 0 disables hot keys tracking.")
+	fs.Float64Var(&cfg.HotKeysSampleRate, "hot-keys-sample-rate", cfg.HotKeysSampleRate, "Fraction of the requests sampled to track the hottest keys.")
+	fs.StringVar(&cfg.NewerRequestFields, "newer-request-fields", cfg.NewerRequestFields, "How requests with fields newer than the cluster version are handled: 'ignore', 'log' or 'reject'. 'log' and 'reject' log each field once and report them through the NewerFields maintenance API.")
 	fs.DurationVar(&cfg.DowngradeCheckTime, "downgrade-check-time", cfg.DowngradeCheckTime, "Duration of time between two downgrade status checks.")
 	fs.DurationVar(&cfg.WarningApplyDuration, "warning-apply-duration", cfg.WarningApplyDuration, "Time duration after which a warning is generated if watch progress takes more time.")
 	fs.DurationVar(&cfg.WarningUnaryRequestDuration, "warning-unary-request-duration", cfg.WarningUnaryRequestDuration, "Time duration after which a warning is generated if a unary request takes more time.")
@@ -774,14 +1033,18 @@ func ConfigFromFile(path string) (*Config, error) {
 }
 
 func (cfg *configYAML) configFromFile(path string) error {
-	b, err := os.ReadFile(path)
+	b, strict, err := loadConfigFile(path)
 	if err != nil {
 		return err
 	}
 
 	defaultInitialCluster := cfg.InitialCluster
 
-	err = yaml.Unmarshal(b, cfg)
+	if strict {
+		err = yaml.UnmarshalStrict(b, cfg)
+	} else {
+		err = yaml.Unmarshal(b, cfg)
+	}
 	if err != nil {
 		return err
 	}
@@ -896,9 +1159,14 @@ func (cfg *configYAML) configFromFile(path string) error {
 		tls.AllowedCNs = ysc.AllowedCNs
 		tls.AllowedHostnames = ysc.AllowedHostnames
 		tls.SkipClientSANVerify = ysc.SkipClientSANVerify
+		tls.OCSPVerify = ysc.OCSPVerify
+		tls.OCSPStapling = ysc.OCSPStapling
 	}
 	copySecurityDetails(&cfg.ClientTLSInfo, &cfg.ClientSecurityJSON)
 	copySecurityDetails(&cfg.PeerTLSInfo, &cfg.PeerSecurityJSON)
+	for i := range cfg.ListenerConfigs {
+		copySecurityDetails(&cfg.ListenerConfigs[i].TLSInfo, &cfg.ListenerConfigs[i].SecurityJSON)
+	}
 	cfg.ClientAutoTLS = cfg.ClientSecurityJSON.AutoTLS
 	cfg.PeerAutoTLS = cfg.PeerSecurityJSON.AutoTLS
 	if cfg.SelfSignedCertValidity == 0 {
@@ -952,6 +1220,9 @@ func (cfg *Config) Validate() error {
 	if err := checkBindURLs(cfg.ListenMetricsUrls); err != nil {
 		return err
 	}
+	if err := cfg.validateListenerConfigs(); err != nil {
+		return err
+	}
 	if err := checkHostURLs(cfg.AdvertisePeerUrls); err != nil {
 		addrs := cfg.getAdvertisePeerURLs()
 		return fmt.Errorf(`--initial-advertise-peer-urls %q must be "host:port" (%w)`, strings.Join(addrs, ","), err)
@@ -1013,6 +1284,43 @@ func (cfg *Config) Validate() error {
 	default:
 		return fmt.Errorf("unknown auto-compaction-mode %q", cfg.AutoCompactionMode)
 	}
+	switch cfg.ReadConsistency {
+	case ReadConsistencyReadIndex:
+	case ReadConsistencyLease:
+		if !cfg.CheckQuorum {
+			return fmt.Errorf("--read-consistency=%s requires --check-quorum", ReadConsistencyLease)
+		}
+	default:
+		return fmt.Errorf("unknown --read-consistency %q (supported: %q, %q)", cfg.ReadConsistency, ReadConsistencyReadIndex, ReadConsistencyLease)
+	}
+
+	switch cfg.BackendEngine {
+	case backend.EngineBolt, backend.EnginePebble:
+	default:
+		return fmt.Errorf("unknown --backend-engine %q (supported: %q, %q)", cfg.BackendEngine, backend.EngineBolt, backend.EnginePebble)
+	}
+
+	switch cfg.RaftLeadership {
+	case "", RaftLeadershipAllowed, RaftLeadershipDisallowed:
+	default:
+		return fmt.Errorf("unknown --raft-leadership %q (supported: %q, %q)", cfg.RaftLeadership, RaftLeadershipAllowed, RaftLeadershipDisallowed)
+	}
+
+	if cfg.CompactionMaxHold < 0 {
+		return fmt.Errorf("--compaction-max-hold must be >=0 (set to %v)", cfg.CompactionMaxHold)
+	}
+	if cfg.AutoCompactionCoordinationTimeout < 0 {
+		return fmt.Errorf("--auto-compaction-coordination-timeout must be >=0 (set to %v)", cfg.AutoCompactionCoordinationTimeout)
+	}
+
+	if cfg.AutoDefragSchedule != "" {
+		if _, err := v3defrag.ParseSchedule(cfg.AutoDefragSchedule); err != nil {
+			return fmt.Errorf("invalid --auto-defrag-schedule: %w", err)
+		}
+		if _, err := v3defrag.ParseThreshold(cfg.AutoDefragThreshold); err != nil {
+			return fmt.Errorf("invalid --auto-defrag-threshold: %w", err)
+		}
+	}
 
 	// Validate distributed tracing configuration but only if enabled.
 	if cfg.EnableDistributedTracing {
@@ -1033,6 +1341,101 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("--compact-hash-check-time must be >0 (set to %v)", cfg.CompactHashCheckTime)
 	}
 
+	for flag, mode := range map[string]string{"--client-ocsp-verify": cfg.ClientTLSInfo.OCSPVerify, "--peer-ocsp-verify": cfg.PeerTLSInfo.OCSPVerify} {
+		switch mode {
+		case "", transport.OCSPVerifySoftFail, transport.OCSPVerifyHardFail:
+		default:
+			return fmt.Errorf("unknown %s %q (supported: %q, %q)", flag, mode, transport.OCSPVerifySoftFail, transport.OCSPVerifyHardFail)
+		}
+	}
+
+	for _, name := range cfg.GRPCResponseCompression {
+		if !slices.Contains(v3rpc.ResponseCompressors, name) {
+			return fmt.Errorf("unknown compressor %q in --grpc-response-compression (supported: %s)", name, strings.Join(v3rpc.ResponseCompressors, ", "))
+		}
+	}
+	if cfg.GRPCResponseCompressionMinBytes < 0 {
+		return fmt.Errorf("--grpc-response-compression-min-bytes must be >=0 (set to %d)", cfg.GRPCResponseCompressionMinBytes)
+	}
+	for _, name := range cfg.HealthChecks {
+		if !slices.Contains(etcdhttp.HealthChecks, name) {
+			return fmt.Errorf("unknown health check %q in --health-checks (supported: %s)", name, strings.Join(etcdhttp.HealthChecks, ", "))
+		}
+	}
+	for name, timeout := range cfg.HealthCheckTimeouts {
+		if !slices.Contains(etcdhttp.HealthChecks, name) {
+			return fmt.Errorf("unknown health check %q in --health-check-timeouts (supported: %s)", name, strings.Join(etcdhttp.HealthChecks, ", "))
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("--health-check-timeouts of %q must be >0 (set to %v)", name, timeout)
+		}
+	}
+
+	if cfg.TooBusyApplyBacklog > 0 && cfg.TooBusyBackoff <= 0 {
+		return fmt.Errorf("--too-busy-backoff must be >0 (set to %v)", cfg.TooBusyBackoff)
+	}
+
+	if cfg.WatchSendBatchInterval < 0 || cfg.WatchSendBatchInterval > maxWatchSendBatchInterval {
+		return fmt.Errorf("--watch-send-batch-interval must be between 0 and %v (set to %v)", maxWatchSendBatchInterval, cfg.WatchSendBatchInterval)
+	}
+
+	if cfg.SerializableReadCacheBytes < 0 {
+		return fmt.Errorf("--serializable-read-cache-bytes must be >=0 (set to %d)", cfg.SerializableReadCacheBytes)
+	}
+	if cfg.SerializableReadCacheBytes > 0 && cfg.SerializableReadCacheTTL <= 0 {
+		return fmt.Errorf("--serializable-read-cache-ttl must be >0 (set to %v)", cfg.SerializableReadCacheTTL)
+	}
+
+	if cfg.HotKeysTopK < 0 {
+		return fmt.Errorf("--hot-keys-top-k must be >=0 (set to %d)", cfg.HotKeysTopK)
+	}
+	if cfg.HotKeysTopK > 0 && (cfg.HotKeysSampleRate <= 0 || cfg.HotKeysSampleRate > 1) {
+		return fmt.Errorf("--hot-keys-sample-rate must be >0 and <=1 (set to %v)", cfg.HotKeysSampleRate)
+	}
+
+	switch cfg.NewerRequestFields {
+	case config.NewerRequestFieldsIgnore, config.NewerRequestFieldsLog, config.NewerRequestFieldsReject:
+	default:
+		return fmt.Errorf("--newer-request-fields must be one of %q, %q or %q (set to %q)",
+			config.NewerRequestFieldsIgnore, config.NewerRequestFieldsLog, config.NewerRequestFieldsReject, cfg.NewerRequestFields)
+	}
+
+	if cfg.PasswordMinLength < 0 {
+		return fmt.Errorf("--password-min-length must be >=0 (set to %d)", cfg.PasswordMinLength)
+	}
+	if cfg.PasswordMinCharClasses < 0 || cfg.PasswordMinCharClasses > 4 {
+		return fmt.Errorf("--password-min-char-classes must be between 0 and 4 (set to %d)", cfg.PasswordMinCharClasses)
+	}
+	if cfg.PasswordMaxAge < 0 {
+		return fmt.Errorf("--password-max-age must be >=0 (set to %v)", cfg.PasswordMaxAge)
+	}
+
+	if cfg.MemorySoftLimit < 0 {
+		return fmt.Errorf("--memory-soft-limit must be >=0 (set to %d)", cfg.MemorySoftLimit)
+	}
+	if cfg.BackendCheckpointInterval < 0 {
+		return fmt.Errorf("--backend-checkpoint-interval must be >=0 (set to %v)", cfg.BackendCheckpointInterval)
+	}
+	if cfg.BackendCheckpointRetention < 0 {
+		return fmt.Errorf("--backend-checkpoint-retention must be >=0 (set to %d)", cfg.BackendCheckpointRetention)
+	}
+	if cfg.BackendBucketStatsInterval < 0 {
+		return fmt.Errorf("--backend-bucket-stats-interval must be >=0 (set to %v)", cfg.BackendBucketStatsInterval)
+	}
+
+	if cfg.ValueChunkSize < 0 {
+		return fmt.Errorf("--value-chunk-size must be >=0 (set to %d)", cfg.ValueChunkSize)
+	}
+	if cfg.ValueChunkSize > 0 && !cfg.ServerFeatureGate.Enabled(features.ValueChunking) {
+		return fmt.Errorf("setting --value-chunk-size requires enabling feature gate ValueChunking")
+	}
+	if cfg.LeaseRevokeRate < 0 {
+		return fmt.Errorf("--lease-revoke-rate must be >=0 (set to %d)", cfg.LeaseRevokeRate)
+	}
+	if cfg.IndexCheckpointInterval < 0 {
+		return fmt.Errorf("--index-checkpoint-interval must be >=0 (set to %v)", cfg.IndexCheckpointInterval)
+	}
+
 	// If `--name` isn't configured, then multiple members may have the same "default" name.
 	// When adding a new member with the "default" name as well, etcd may regards its peerURL
 	// as one additional peerURL of the existing member which has the same "default" name,
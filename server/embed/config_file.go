@@ -0,0 +1,198 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigAPIVersionV1Alpha1 is the version of the structured configuration
+// file schema.
+//
+// A configuration file that sets "apiVersion" to this value:
+//   - may list other configuration files under "include". Included files are
+//     merged in order, and the values of the including file take precedence.
+//     Relative paths are resolved against the directory of the including file.
+//   - has "${VAR}" and "${VAR:-default}" references expanded from the
+//     environment. Referencing an unset variable without a default is an
+//     error. "$${" produces a literal "${".
+//   - is strictly validated, so unknown fields are rejected.
+//
+// Configuration files without "apiVersion" keep the legacy behavior.
+const ConfigAPIVersionV1Alpha1 = "etcd/v1alpha1"
+
+const (
+	configAPIVersionKey = "apiVersion"
+	configIncludeKey    = "include"
+
+	maxConfigIncludeDepth = 16
+)
+
+var configEnvRefRegexp = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// loadConfigFile reads the configuration file at path and returns its YAML
+// content with includes and environment references resolved. strict reports
+// whether the file uses the versioned schema and must be decoded strictly.
+func loadConfigFile(path string) (b []byte, strict bool, err error) {
+	b, err = os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	version, err := configFileAPIVersion(b)
+	if err != nil {
+		return nil, false, err
+	}
+	if version == "" {
+		return b, false, nil
+	}
+
+	m, err := loadVersionedConfigFile(path, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	b, err = yaml.Marshal(m)
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+func configFileAPIVersion(b []byte) (string, error) {
+	var header struct {
+		APIVersion string `json:"apiVersion"`
+	}
+	if err := yaml.Unmarshal(b, &header); err != nil {
+		return "", err
+	}
+	switch header.APIVersion {
+	case "", ConfigAPIVersionV1Alpha1:
+		return header.APIVersion, nil
+	default:
+		return "", fmt.Errorf("unsupported config apiVersion %q (supported: %q)", header.APIVersion, ConfigAPIVersionV1Alpha1)
+	}
+}
+
+// loadVersionedConfigFile loads the file at path and the files it includes
+// into a single map. stack holds the files currently being loaded and is used
+// to detect include cycles.
+func loadVersionedConfigFile(path string, stack []string) (map[string]any, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range stack {
+		if p == abs {
+			return nil, fmt.Errorf("config include cycle: %s -> %s", strings.Join(stack, " -> "), abs)
+		}
+	}
+	if len(stack) >= maxConfigIncludeDepth {
+		return nil, fmt.Errorf("config includes are nested deeper than %d levels at %s", maxConfigIncludeDepth, abs)
+	}
+	stack = append(stack, abs)
+
+	b, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+	if b, err = expandConfigEnv(b); err != nil {
+		return nil, fmt.Errorf("%s: %w", abs, err)
+	}
+	if _, err = configFileAPIVersion(b); err != nil {
+		return nil, fmt.Errorf("%s: %w", abs, err)
+	}
+	var m map[string]any
+	if err = yaml.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", abs, err)
+	}
+	if m == nil {
+		m = make(map[string]any)
+	}
+
+	var includes []string
+	if v, ok := m[configIncludeKey]; ok {
+		vs, isList := v.([]any)
+		if !isList {
+			return nil, fmt.Errorf("%s: %s must be a list of paths", abs, configIncludeKey)
+		}
+		for _, inc := range vs {
+			s, isString := inc.(string)
+			if !isString || s == "" {
+				return nil, fmt.Errorf("%s: %s must be a list of paths", abs, configIncludeKey)
+			}
+			includes = append(includes, s)
+		}
+	}
+	delete(m, configIncludeKey)
+	delete(m, configAPIVersionKey)
+
+	merged := make(map[string]any)
+	for _, inc := range includes {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(abs), inc)
+		}
+		im, err := loadVersionedConfigFile(inc, stack)
+		if err != nil {
+			return nil, err
+		}
+		mergeConfigMaps(merged, im)
+	}
+	mergeConfigMaps(merged, m)
+	return merged, nil
+}
+
+// mergeConfigMaps merges src into dst. Nested maps are merged recursively,
+// any other value in src replaces the one in dst.
+func mergeConfigMaps(dst, src map[string]any) {
+	for k, v := range src {
+		sm, sok := v.(map[string]any)
+		dm, dok := dst[k].(map[string]any)
+		if sok && dok {
+			mergeConfigMaps(dm, sm)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// expandConfigEnv replaces "${VAR}" and "${VAR:-default}" references with
+// values from the environment.
+func expandConfigEnv(b []byte) ([]byte, error) {
+	var err error
+	out := configEnvRefRegexp.ReplaceAllFunc(b, func(ref []byte) []byte {
+		if ref[0] == '$' && ref[1] == '$' {
+			// escaped reference
+			return ref[1:]
+		}
+		sm := configEnvRefRegexp.FindSubmatch(ref)
+		name := string(sm[1])
+		if v, ok := os.LookupEnv(name); ok {
+			return []byte(v)
+		}
+		if sm[2] != nil {
+			return sm[3]
+		}
+		if err == nil {
+			err = fmt.Errorf("environment variable %q referenced in config is not set", name)
+		}
+		return ref
+	})
+	return out, err
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCfgFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
+}
+
+func TestConfigFileVersionedIncludes(t *testing.T) {
+	dir := t.TempDir()
+	writeCfgFile(t, dir, "base.yaml", `
+apiVersion: etcd/v1alpha1
+name: base
+snapshot-count: 100
+client-transport-security:
+  cert-file: base.crt
+  key-file: base.key
+`)
+	writeCfgFile(t, dir, "tuning.yaml", `
+snapshot-count: 200
+max-txn-ops: 256
+`)
+	t.Setenv("ETCD_TEST_MEMBER_NAME", "member-1")
+	p := writeCfgFile(t, dir, "etcd.yaml", `
+apiVersion: etcd/v1alpha1
+include:
+  - base.yaml
+  - tuning.yaml
+name: ${ETCD_TEST_MEMBER_NAME}
+data-dir: ${ETCD_TEST_UNSET_DATA_DIR:-/var/lib/etcd}
+client-transport-security:
+  cert-file: member.crt
+`)
+
+	cfg, err := ConfigFromFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, "member-1", cfg.Name)
+	assert.Equal(t, "/var/lib/etcd", cfg.Dir)
+	assert.Equal(t, uint64(200), cfg.SnapshotCount)
+	assert.Equal(t, uint(256), cfg.MaxTxnOps)
+	assert.Equal(t, "member.crt", cfg.ClientTLSInfo.CertFile)
+	assert.Equal(t, "base.key", cfg.ClientTLSInfo.KeyFile)
+	assert.True(t, cfg.FlagsExplicitlySet["max-txn-ops"])
+}
+
+func TestConfigFileVersionedErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "unknown field",
+			files: map[string]string{"etcd.yaml": `
+apiVersion: etcd/v1alpha1
+snapshot-cuont: 100
+`},
+			wantErr: `unknown field "snapshot-cuont"`,
+		},
+		{
+			name: "unsupported version",
+			files: map[string]string{"etcd.yaml": `
+apiVersion: etcd/v2
+`},
+			wantErr: "unsupported config apiVersion",
+		},
+		{
+			name: "unset environment variable",
+			files: map[string]string{"etcd.yaml": `
+apiVersion: etcd/v1alpha1
+name: ${ETCD_TEST_UNSET_NAME}
+`},
+			wantErr: `environment variable "ETCD_TEST_UNSET_NAME"`,
+		},
+		{
+			name: "include cycle",
+			files: map[string]string{
+				"etcd.yaml": `
+apiVersion: etcd/v1alpha1
+include: [a.yaml]
+`,
+				"a.yaml": `
+include: [etcd.yaml]
+`,
+			},
+			wantErr: "config include cycle",
+		},
+		{
+			name: "missing include",
+			files: map[string]string{"etcd.yaml": `
+apiVersion: etcd/v1alpha1
+include: [missing.yaml]
+`},
+			wantErr: "missing.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeCfgFile(t, dir, name, content)
+			}
+			_, err := ConfigFromFile(filepath.Join(dir, "etcd.yaml"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfigFileLegacyIgnoresUnknownFields(t *testing.T) {
+	p := writeCfgFile(t, t.TempDir(), "etcd.yaml", `
+name: legacy
+snapshot-cuont: 100
+data-dir: ${NOT_EXPANDED}
+`)
+	cfg, err := ConfigFromFile(p)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", cfg.Name)
+	assert.Equal(t, "${NOT_EXPANDED}", cfg.Dir)
+}
+
+func TestExpandConfigEnv(t *testing.T) {
+	t.Setenv("ETCD_TEST_VALUE", "v")
+	out, err := expandConfigEnv([]byte("a: ${ETCD_TEST_VALUE}\nb: $${ETCD_TEST_VALUE}\nc: ${ETCD_TEST_UNSET_VALUE:-d}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "a: v\nb: ${ETCD_TEST_VALUE}\nc: d\n", string(out))
+}
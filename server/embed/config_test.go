@@ -452,6 +452,84 @@ func TestAutoCompactionModeInvalid(t *testing.T) {
 	}
 }
 
+func TestAutoDefragValidate(t *testing.T) {
+	tests := []struct {
+		schedule  string
+		threshold string
+		werr      bool
+	}{
+		{"", "bad", false},
+		{"cron:0 3 * * *", "30%", false},
+		{"cron:0 3 * * *", "5", false},
+		{"0 3 * * *", "30%", true},
+		{"cron:0 25 * * *", "30%", true},
+		{"cron:0 3 * * *", "130%", true},
+		{"cron:0 3 * * *", "", true},
+	}
+	for _, tt := range tests {
+		cfg := NewConfig()
+		cfg.Logger = "zap"
+		cfg.LogOutputs = []string{"/dev/null"}
+		cfg.AutoDefragSchedule = tt.schedule
+		cfg.AutoDefragThreshold = tt.threshold
+		err := cfg.Validate()
+		if (err != nil) != tt.werr {
+			t.Errorf("schedule %q threshold %q: expected error %v, got %v", tt.schedule, tt.threshold, tt.werr, err)
+		}
+	}
+}
+
+func TestReadConsistencyValidate(t *testing.T) {
+	tests := []struct {
+		readConsistency string
+		checkQuorum     bool
+		werr            bool
+	}{
+		{ReadConsistencyReadIndex, true, false},
+		{ReadConsistencyReadIndex, false, false},
+		{ReadConsistencyLease, true, false},
+		{ReadConsistencyLease, false, true},
+		{"", true, true},
+		{"linearizable", true, true},
+	}
+	for _, tt := range tests {
+		cfg := NewConfig()
+		cfg.Logger = "zap"
+		cfg.LogOutputs = []string{"/dev/null"}
+		cfg.ReadConsistency = tt.readConsistency
+		cfg.CheckQuorum = tt.checkQuorum
+		err := cfg.Validate()
+		if (err != nil) != tt.werr {
+			t.Errorf("read consistency %q check quorum %v: expected error %v, got %v", tt.readConsistency, tt.checkQuorum, tt.werr, err)
+		}
+	}
+}
+
+func TestHealthChecksValidate(t *testing.T) {
+	tests := []struct {
+		checks   []string
+		timeouts map[string]time.Duration
+		werr     bool
+	}{
+		{nil, nil, false},
+		{[]string{"lease_grant", "disk_fsync"}, map[string]time.Duration{"disk_fsync": time.Second}, false},
+		{[]string{"range"}, nil, true},
+		{nil, map[string]time.Duration{"range": time.Second}, true},
+		{nil, map[string]time.Duration{"lease_grant": 0}, true},
+	}
+	for _, tt := range tests {
+		cfg := NewConfig()
+		cfg.Logger = "zap"
+		cfg.LogOutputs = []string{"/dev/null"}
+		cfg.HealthChecks = tt.checks
+		cfg.HealthCheckTimeouts = tt.timeouts
+		err := cfg.Validate()
+		if (err != nil) != tt.werr {
+			t.Errorf("health checks %v timeouts %v: expected error %v, got %v", tt.checks, tt.timeouts, tt.werr, err)
+		}
+	}
+}
+
 func TestAutoCompactionModeParse(t *testing.T) {
 	tests := []struct {
 		mode      string
@@ -608,6 +686,40 @@ func TestLeaseCheckpointValidate(t *testing.T) {
 	}
 }
 
+func TestValueChunkSizeValidate(t *testing.T) {
+	tcs := []struct {
+		name               string
+		valueChunkSize     int
+		serverFeatureGates string
+		expectError        bool
+	}{
+		{
+			name: "Default config should pass",
+		},
+		{
+			name:               "Setting value chunk size with value chunking should pass",
+			valueChunkSize:     1024,
+			serverFeatureGates: "ValueChunking=true",
+		},
+		{
+			name:           "Setting value chunk size without value chunking should fail",
+			valueChunkSize: 1024,
+			expectError:    true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := *NewConfig()
+			cfg.ValueChunkSize = tc.valueChunkSize
+			cfg.ServerFeatureGate.(featuregate.MutableFeatureGate).Set(tc.serverFeatureGates)
+			err := cfg.Validate()
+			if (err != nil) != tc.expectError {
+				t.Errorf("config.Validate() = %q, expected error: %v", err, tc.expectError)
+			}
+		})
+	}
+}
+
 func TestLogRotation(t *testing.T) {
 	tests := []struct {
 		name              string
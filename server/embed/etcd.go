@@ -35,11 +35,11 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/keepalive"
 
 	"go.etcd.io/etcd/api/v3/version"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.etcd.io/etcd/client/v3/credentials"
 	"go.etcd.io/etcd/pkg/v3/debugutil"
 	runtimeutil "go.etcd.io/etcd/pkg/v3/runtime"
@@ -47,12 +47,21 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/etcdhttp"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3client"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3defrag"
 	"go.etcd.io/etcd/server/v3/features"
 	"go.etcd.io/etcd/server/v3/storage"
 	"go.etcd.io/etcd/server/v3/verify"
 )
 
 const (
+	// autoDefragLockPrefix is the key prefix of the mutex serializing
+	// scheduled defragmentation across members.
+	autoDefragLockPrefix = "/etcd-internal/auto-defrag-lock"
+	// autoDefragLockTTL is the TTL in seconds of the session holding the
+	// scheduled defragmentation lock.
+	autoDefragLockTTL = 60
+
 	// internal fd usage includes disk usage and transport usage.
 	// To read/write snapshot, snap pkg needs 1. In normal case, wal pkg needs
 	// at most 2 to read/lock/write WALs. One case that it needs to 2 is to
@@ -135,6 +144,9 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 			zap.Bool("reuse-port", cfg.SocketOpts.ReusePort),
 		)
 	}
+	cfg.ClientTLSInfo.CertificateRevoked = cfg.certificateRevokedFunc("client")
+	cfg.PeerTLSInfo.CertificateRevoked = cfg.certificateRevokedFunc("peer")
+
 	e.cfg.logger.Info(
 		"configuring peer listeners",
 		zap.Strings("listen-peer-urls", e.cfg.getListenPeerURLs()),
@@ -199,24 +211,51 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		InitialElectionTickAdvance:        cfg.InitialElectionTickAdvance,
 		AutoCompactionRetention:           autoCompactionRetention,
 		AutoCompactionMode:                cfg.AutoCompactionMode,
+		CompactionMaxHold:                 cfg.CompactionMaxHold,
+		AutoCompactionCoordinated:         cfg.AutoCompactionCoordinated,
+		AutoCompactionCoordinationTimeout: cfg.AutoCompactionCoordinationTimeout,
 		QuotaBackendBytes:                 cfg.QuotaBackendBytes,
 		BackendBatchLimit:                 cfg.BackendBatchLimit,
 		BackendFreelistType:               backendFreelistType,
+		BackendEngine:                     cfg.BackendEngine,
 		BackendBatchInterval:              cfg.BackendBatchInterval,
+		BackendCheckpointInterval:         cfg.BackendCheckpointInterval,
+		BackendCheckpointDir:              cfg.BackendCheckpointDir,
+		BackendCheckpointRetention:        cfg.BackendCheckpointRetention,
+		BackendBucketStatsInterval:        cfg.BackendBucketStatsInterval,
 		MaxTxnOps:                         cfg.MaxTxnOps,
 		MaxRequestBytes:                   cfg.MaxRequestBytes,
+		TooBusyApplyBacklog:               cfg.TooBusyApplyBacklog,
+		TooBusyBackoff:                    cfg.TooBusyBackoff,
+		GateStaleReads:                    !cfg.ServeStaleReads,
+		StaleReadsCatchUpThreshold:        cfg.StaleReadsCatchUpThreshold,
+		MemorySoftLimit:                   cfg.MemorySoftLimit,
+		WALDiskFreeThreshold:              cfg.WALDiskFreeThreshold,
+		HealthChecks:                      cfg.HealthChecks,
+		HealthCheckTimeouts:               cfg.HealthCheckTimeouts,
 		MaxConcurrentStreams:              cfg.MaxConcurrentStreams,
+		GRPCResponseCompressors:           cfg.GRPCResponseCompression,
+		GRPCResponseCompressionMinBytes:   cfg.GRPCResponseCompressionMinBytes,
 		SocketOpts:                        cfg.SocketOpts,
 		StrictReconfigCheck:               cfg.StrictReconfigCheck,
+		LeaderTransferPreferLabels:        cfg.LeaderTransferPreferLabels,
+		SetLeadership:                     cfg.RaftLeadership != "",
+		LeadershipDisallowed:              cfg.RaftLeadership == RaftLeadershipDisallowed,
 		ClientCertAuthEnabled:             cfg.ClientTLSInfo.ClientCertAuth,
 		AuthToken:                         cfg.AuthToken,
 		BcryptCost:                        cfg.BcryptCost,
 		TokenTTL:                          cfg.AuthTokenTTL,
+		PasswordMinLength:                 cfg.PasswordMinLength,
+		PasswordMinCharClasses:            cfg.PasswordMinCharClasses,
+		PasswordMaxAge:                    cfg.PasswordMaxAge,
 		CORS:                              cfg.CORS,
 		HostWhitelist:                     cfg.HostWhitelist,
 		CorruptCheckTime:                  cfg.CorruptCheckTime,
 		CompactHashCheckTime:              cfg.CompactHashCheckTime,
+		CorruptCheckHashCache:             cfg.CorruptCheckHashCache,
 		PreVote:                           cfg.PreVote,
+		CheckQuorum:                       cfg.CheckQuorum,
+		LeaseReads:                        cfg.ReadConsistency == ReadConsistencyLease,
 		Logger:                            cfg.logger,
 		ForceNewCluster:                   cfg.ForceNewCluster,
 		EnableGRPCGateway:                 cfg.EnableGRPCGateway,
@@ -224,7 +263,16 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		UnsafeNoFsync:                     cfg.UnsafeNoFsync,
 		CompactionBatchLimit:              cfg.CompactionBatchLimit,
 		CompactionSleepInterval:           cfg.CompactionSleepInterval,
+		ValueChunkSize:                    cfg.ValueChunkSize,
+		LeaseRevokeRate:                   cfg.LeaseRevokeRate,
+		IndexCheckpointInterval:           cfg.IndexCheckpointInterval,
 		WatchProgressNotifyInterval:       cfg.WatchProgressNotifyInterval,
+		WatchSendBatchInterval:            cfg.WatchSendBatchInterval,
+		SerializableReadCacheBytes:        cfg.SerializableReadCacheBytes,
+		SerializableReadCacheTTL:          cfg.SerializableReadCacheTTL,
+		HotKeysTopK:                       cfg.HotKeysTopK,
+		HotKeysSampleRate:                 cfg.HotKeysSampleRate,
+		NewerRequestFields:                cfg.NewerRequestFields,
 		DowngradeCheckTime:                cfg.DowngradeCheckTime,
 		WarningApplyDuration:              cfg.WarningApplyDuration,
 		WarningUnaryRequestDuration:       cfg.WarningUnaryRequestDuration,
@@ -260,6 +308,9 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 	if e.Server, err = etcdserver.NewServer(srvcfg); err != nil {
 		return e, err
 	}
+	for _, h := range cfg.EventHandlers {
+		e.Server.Events().Subscribe(h)
+	}
 
 	// buffer channel so goroutines on closed connections won't wait forever
 	e.errc = make(chan error, len(e.Peers)+len(e.Clients)+2*len(e.sctxs))
@@ -287,6 +338,10 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		return e, err
 	}
 
+	if err = e.startDefragScheduler(); err != nil {
+		return e, err
+	}
+
 	e.cfg.logger.Info(
 		"now serving peer/client/metrics",
 		zap.String("local-member-id", e.Server.MemberID().String()),
@@ -353,17 +408,31 @@ func print(lg *zap.Logger, ec Config, sc config.ServerConfig, memberInitialized
 		zap.String("initial-cluster-state", ec.ClusterState),
 		zap.String("initial-cluster-token", sc.InitialClusterToken),
 		zap.Int64("quota-backend-bytes", quota),
+		zap.String("backend-engine", sc.BackendEngine),
+		zap.Duration("backend-checkpoint-interval", sc.BackendCheckpointInterval),
+		zap.String("backend-checkpoint-dir", sc.CheckpointDir()),
+		zap.Int("backend-checkpoint-retention", sc.BackendCheckpointRetention),
+		zap.Duration("backend-bucket-stats-interval", sc.BackendBucketStatsInterval),
 		zap.Uint("max-request-bytes", sc.MaxRequestBytes),
 		zap.Uint32("max-concurrent-streams", sc.MaxConcurrentStreams),
+		zap.Strings("grpc-response-compression", sc.GRPCResponseCompressors),
+		zap.Int("grpc-response-compression-min-bytes", sc.GRPCResponseCompressionMinBytes),
 
 		zap.Bool("pre-vote", sc.PreVote),
+		zap.Bool("check-quorum", sc.CheckQuorum),
+		zap.Bool("leadership-disallowed", sc.LeadershipDisallowed),
+		zap.Bool("lease-reads", sc.LeaseReads),
 		zap.String(ServerFeatureGateFlagName, sc.ServerFeatureGate.String()),
 		zap.Bool("initial-corrupt-check", sc.InitialCorruptCheck),
 		zap.String("corrupt-check-time-interval", sc.CorruptCheckTime.String()),
 		zap.Duration("compact-check-time-interval", sc.CompactHashCheckTime),
+		zap.Bool("corrupt-check-hash-cache", sc.CorruptCheckHashCache),
 		zap.String("auto-compaction-mode", sc.AutoCompactionMode),
 		zap.Duration("auto-compaction-retention", sc.AutoCompactionRetention),
 		zap.String("auto-compaction-interval", sc.AutoCompactionRetention.String()),
+		zap.Duration("compaction-max-hold", sc.CompactionMaxHold),
+		zap.Bool("auto-compaction-coordinated", sc.AutoCompactionCoordinated),
+		zap.Duration("auto-compaction-coordination-timeout", sc.AutoCompactionCoordinationTimeout),
 
 		zap.String("discovery-token", sc.DiscoveryCfg.Token),
 		zap.String("discovery-endpoints", strings.Join(sc.DiscoveryCfg.Endpoints, ",")),
@@ -390,6 +459,14 @@ func (e *Etcd) Config() Config {
 	return e.cfg
 }
 
+// OnEvent calls h with the lifecycle events of the given types, or with all
+// events if no type is given, until the returned function is called or the
+// server stops. Events published before OnEvent is called are not delivered;
+// use Config.EventHandlers to receive the events from the start.
+func (e *Etcd) OnEvent(h etcdserver.EventHandler, types ...etcdserver.EventType) (cancel func()) {
+	return e.Server.Events().Subscribe(h, types...)
+}
+
 // Close gracefully shuts down all servers/listeners.
 // Client requests will be terminated with request timeout.
 // After timeout, enforce remaning requests be closed immediately.
@@ -650,21 +727,26 @@ func configureClientListeners(cfg *Config) (sctxs map[string]*serveCtx, err erro
 		cfg.logger.Fatal("failed to get client self-signed certs", zap.Error(err))
 	}
 	updateMinMaxVersions(&cfg.ClientTLSInfo, cfg.TlsMinVersion, cfg.TlsMaxVersion)
+	if err = cfg.setupListenerTLSInfo(); err != nil {
+		return nil, err
+	}
 	if cfg.EnablePprof {
 		cfg.logger.Info("pprof is enabled", zap.String("path", debugutil.HTTPPrefixPProf))
 	}
 
 	sctxs = make(map[string]*serveCtx)
 	for _, u := range append(cfg.ListenClientUrls, cfg.ListenClientHttpUrls...) {
+		addr, _, _ := resolveURL(u)
+		tlsInfo := cfg.listenerTLSInfo(cfg.listenerConfig(addr))
 		if u.Scheme == "http" || u.Scheme == "unix" {
-			if !cfg.ClientTLSInfo.Empty() {
+			if !tlsInfo.Empty() {
 				cfg.logger.Warn("scheme is http or unix while key and cert files are present; ignoring key and cert files", zap.String("client-url", u.String()))
 			}
-			if cfg.ClientTLSInfo.ClientCertAuth {
+			if tlsInfo.ClientCertAuth {
 				cfg.logger.Warn("scheme is http or unix while --client-cert-auth is enabled; ignoring client cert auth for this URL", zap.String("client-url", u.String()))
 			}
 		}
-		if (u.Scheme == "https" || u.Scheme == "unixs") && cfg.ClientTLSInfo.Empty() {
+		if (u.Scheme == "https" || u.Scheme == "unixs") && tlsInfo.Empty() {
 			return nil, fmt.Errorf("TLS key/cert (--cert-file, --key-file) must be provided for client url %s with HTTPS scheme", u.String())
 		}
 	}
@@ -710,6 +792,14 @@ func configureClientListeners(cfg *Config) (sctxs map[string]*serveCtx, err erro
 		// net.Listener will rewrite ipv4 0.0.0.0 to ipv6 [::], breaking
 		// hosts that disable ipv6. So, use the address given by the user.
 
+		lc := cfg.listenerConfig(sctx.addr)
+		name := listenerName(lc, sctx.addr)
+		if lc != nil {
+			sctx.lg = sctx.lg.With(zap.String("listener", name))
+		}
+		sctx.tlsInfo = cfg.listenerTLSInfo(lc)
+		sctx.l = instrumentListener(sctx.l, name)
+		maxConns := 0
 		if fdLimit, fderr := runtimeutil.FDLimit(); fderr == nil {
 			if fdLimit <= reservedInternalFDNum {
 				cfg.logger.Fatal(
@@ -718,7 +808,14 @@ func configureClientListeners(cfg *Config) (sctxs map[string]*serveCtx, err erro
 					zap.Int("recommended-limit", reservedInternalFDNum),
 				)
 			}
-			sctx.l = transport.LimitListener(sctx.l, int(fdLimit-reservedInternalFDNum))
+			maxConns = int(fdLimit - reservedInternalFDNum)
+		}
+		if lc != nil && lc.MaxConnections > 0 && (maxConns == 0 || lc.MaxConnections < maxConns) {
+			maxConns = lc.MaxConnections
+		}
+		if maxConns > 0 {
+			sctx.l = transport.LimitListener(sctx.l, maxConns)
+			listenerMaxConnections.WithLabelValues(name).Set(float64(maxConns))
 		}
 
 		defer func(sctx *serveCtx) {
@@ -773,22 +870,6 @@ func (e *Etcd) serveClients() {
 	etcdhttp.HandleMetrics(mux)
 	etcdhttp.HandleHealth(e.cfg.logger, mux, e.Server)
 
-	var gopts []grpc.ServerOption
-	if e.cfg.GRPCKeepAliveMinTime > time.Duration(0) {
-		gopts = append(gopts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
-			MinTime:             e.cfg.GRPCKeepAliveMinTime,
-			PermitWithoutStream: false,
-		}))
-	}
-	if e.cfg.GRPCKeepAliveInterval > time.Duration(0) &&
-		e.cfg.GRPCKeepAliveTimeout > time.Duration(0) {
-		gopts = append(gopts, grpc.KeepaliveParams(keepalive.ServerParameters{
-			Time:    e.cfg.GRPCKeepAliveInterval,
-			Timeout: e.cfg.GRPCKeepAliveTimeout,
-		}))
-	}
-	gopts = append(gopts, e.cfg.GRPCAdditionalServerOptions...)
-
 	splitHTTP := false
 	for _, sctx := range e.sctxs {
 		if sctx.httpOnly {
@@ -799,8 +880,17 @@ func (e *Etcd) serveClients() {
 	// start client servers in each goroutine
 	for _, sctx := range e.sctxs {
 		s := sctx
+		var gopts []grpc.ServerOption
+		ep, sp := e.cfg.grpcKeepAlive(e.cfg.listenerConfig(s.addr))
+		if ep != nil {
+			gopts = append(gopts, grpc.KeepaliveEnforcementPolicy(*ep))
+		}
+		if sp != nil {
+			gopts = append(gopts, grpc.KeepaliveParams(*sp))
+		}
+		gopts = append(gopts, e.cfg.GRPCAdditionalServerOptions...)
 		e.startHandler(func() error {
-			return s.serve(e.Server, &e.cfg.ClientTLSInfo, mux, e.errHandler, e.grpcGatewayDial(splitHTTP), splitHTTP, gopts...)
+			return s.serve(e.Server, s.tlsInfo, mux, e.errHandler, e.grpcGatewayDial(splitHTTP), splitHTTP, gopts...)
 		})
 	}
 }
@@ -817,7 +907,7 @@ func (e *Etcd) grpcGatewayDial(splitHTTP bool) (grpcDial func(ctx context.Contex
 	}
 	opts := []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32))}
 	if sctx.secure {
-		tlscfg, tlsErr := e.cfg.ClientTLSInfo.ServerConfig()
+		tlscfg, tlsErr := sctx.tlsInfo.ServerConfig()
 		if tlsErr != nil {
 			return func(ctx context.Context) (*grpc.ClientConn, error) {
 				return nil, tlsErr
@@ -853,19 +943,100 @@ func (e *Etcd) pickGRPCGatewayServeContext(splitHTTP bool) *serveCtx {
 var ErrMissingClientTLSInfoForMetricsURL = errors.New("client TLS key/cert (--cert-file, --key-file) must be provided for metrics secure url")
 
 func (e *Etcd) createMetricsListener(murl url.URL) (net.Listener, error) {
-	tlsInfo := &e.cfg.ClientTLSInfo
+	addr, _, _ := resolveURL(murl)
+	lc := e.cfg.listenerConfig(addr)
+	tlsInfo := e.cfg.listenerTLSInfo(lc)
 	switch murl.Scheme {
 	case "http":
 		tlsInfo = nil
 	case "https", "unixs":
-		if e.cfg.ClientTLSInfo.Empty() {
+		if tlsInfo.Empty() {
 			return nil, ErrMissingClientTLSInfoForMetricsURL
 		}
 	}
-	return transport.NewListenerWithOpts(murl.Host, murl.Scheme,
+	l, err := transport.NewListenerWithOpts(murl.Host, murl.Scheme,
 		transport.WithTLSInfo(tlsInfo),
 		transport.WithSocketOpts(&e.cfg.SocketOpts),
 	)
+	if err != nil {
+		return nil, err
+	}
+	name := listenerName(lc, addr)
+	l = instrumentListener(l, name)
+	if lc != nil && lc.MaxConnections > 0 {
+		l = transport.LimitListener(l, lc.MaxConnections)
+		listenerMaxConnections.WithLabelValues(name).Set(float64(lc.MaxConnections))
+	}
+	return l, nil
+}
+
+// serverDefragmentable defragments through the server, which publishes the
+// defragmentation as events.
+type serverDefragmentable struct {
+	v3defrag.Defragmentable
+	s *etcdserver.EtcdServer
+}
+
+func (d serverDefragmentable) Defrag() error { return d.s.Defragment() }
+
+// startDefragScheduler starts scheduled defragmentation if configured. The
+// members coordinate through a mutex held by a session of the in-process
+// client, so a member that crashes while defragmenting releases the lock
+// once its session lease expires.
+func (e *Etcd) startDefragScheduler() error {
+	if e.cfg.AutoDefragSchedule == "" {
+		return nil
+	}
+	schedule, err := v3defrag.ParseSchedule(e.cfg.AutoDefragSchedule)
+	if err != nil {
+		return err
+	}
+	threshold, err := v3defrag.ParseThreshold(e.cfg.AutoDefragThreshold)
+	if err != nil {
+		return err
+	}
+
+	s := e.Server
+	lg := e.cfg.logger.With(zap.String("component", "auto-defrag"))
+	sched := v3defrag.NewScheduler(v3defrag.Config{
+		Logger:    lg,
+		Schedule:  schedule,
+		Threshold: threshold,
+		Backend:   func() v3defrag.Defragmentable { return serverDefragmentable{s.Backend(), s} },
+		IsLeader:  func() bool { return s.Leader() == s.MemberID() },
+		Lock: func(ctx context.Context) (func(), error) {
+			cli := v3client.New(s)
+			// the session outlives ctx, which only bounds the wait for the lock
+			session, err := concurrency.NewSession(cli, concurrency.WithTTL(autoDefragLockTTL))
+			if err != nil {
+				cli.Close()
+				return nil, err
+			}
+			mu := concurrency.NewMutex(session, autoDefragLockPrefix)
+			if err = mu.Lock(ctx); err != nil {
+				session.Close()
+				cli.Close()
+				return nil, err
+			}
+			return func() {
+				// closing the session revokes its lease, which releases the lock
+				session.Close()
+				cli.Close()
+			}, nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.GoAttach(func() {
+		<-s.StoppingNotify()
+		cancel()
+	})
+	s.GoAttach(func() { sched.Run(ctx) })
+	lg.Info("enabled scheduled defragmentation",
+		zap.String("schedule", e.cfg.AutoDefragSchedule),
+		zap.Float64("threshold-percent", threshold),
+	)
+	return nil
 }
 
 func (e *Etcd) serveMetrics() (err error) {
@@ -0,0 +1,144 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	protov1 "github.com/golang/protobuf/proto"
+	gw "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+const batchGatewayPath = "/v3/kv/batch"
+
+// batchRequest is the body of a gateway batch request. Each op is a
+// RequestOp in the gateway JSON encoding; only puts and deletes are
+// accepted.
+type batchRequest struct {
+	Ops []json.RawMessage `json:"ops"`
+	// ChunkSize is the number of ops applied per transaction. It defaults
+	// to, and may not exceed, the server's max-txn-ops.
+	ChunkSize int `json:"chunk_size,omitempty"`
+	// ContinueOnError keeps applying the remaining chunks after a chunk fails.
+	ContinueOnError bool `json:"continue_on_error,omitempty"`
+}
+
+// batchResponse reports the outcome of every attempted chunk. Chunks are
+// applied atomically, so a failed chunk leaves none of its ops applied.
+type batchResponse struct {
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Skipped   int          `json:"skipped"`
+	Chunks    []batchChunk `json:"chunks"`
+}
+
+type batchChunk struct {
+	// Start is the index of the first op of the chunk in the request.
+	Start    int         `json:"start"`
+	Count    int         `json:"count"`
+	Revision int64       `json:"revision,omitempty"`
+	Error    *batchError `json:"error,omitempty"`
+}
+
+type batchError struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+// registerBatchHandler serves batches of puts and deletes applied as a
+// series of transactions, sparing REST clients one round trip per key.
+func registerBatchHandler(gwmux *gw.ServeMux, conn *grpc.ClientConn, maxTxnOps uint) error {
+	kvc := pb.NewKVClient(conn)
+	return gwmux.HandlePath(http.MethodPost, batchGatewayPath, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		inbound, outbound := gw.MarshalerForRequest(gwmux, r)
+		ctx, err := gw.AnnotateContext(r.Context(), gwmux, r, "/etcdserverpb.KV/Txn", gw.WithHTTPPathPattern(batchGatewayPath))
+		if err != nil {
+			gw.HTTPError(r.Context(), gwmux, outbound, w, r, err)
+			return
+		}
+		ops, chunkSize, continueOnError, err := decodeBatchRequest(inbound, r.Body, maxTxnOps)
+		if err != nil {
+			gw.HTTPError(ctx, gwmux, outbound, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		resp := applyBatch(ctx, kvc, ops, chunkSize, continueOnError)
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			gw.HTTPError(ctx, gwmux, outbound, w, r, err)
+		}
+	})
+}
+
+func decodeBatchRequest(m gw.Marshaler, body io.Reader, maxTxnOps uint) ([]*pb.RequestOp, int, bool, error) {
+	var req batchRequest
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		return nil, 0, false, err
+	}
+	chunkSize := int(maxTxnOps)
+	switch {
+	case req.ChunkSize < 0:
+		return nil, 0, false, fmt.Errorf("invalid chunk_size %d", req.ChunkSize)
+	case req.ChunkSize > int(maxTxnOps):
+		return nil, 0, false, fmt.Errorf("chunk_size %d exceeds max-txn-ops %d", req.ChunkSize, maxTxnOps)
+	case req.ChunkSize > 0:
+		chunkSize = req.ChunkSize
+	}
+	ops := make([]*pb.RequestOp, len(req.Ops))
+	for i, raw := range req.Ops {
+		op := &pb.RequestOp{}
+		if err := m.Unmarshal(raw, protov1.MessageV2(op)); err != nil {
+			return nil, 0, false, fmt.Errorf("op %d: %w", i, err)
+		}
+		switch op.Request.(type) {
+		case *pb.RequestOp_RequestPut, *pb.RequestOp_RequestDeleteRange:
+		default:
+			return nil, 0, false, fmt.Errorf("op %d: only request_put and request_delete_range are allowed", i)
+		}
+		ops[i] = op
+	}
+	return ops, chunkSize, req.ContinueOnError, nil
+}
+
+func applyBatch(ctx context.Context, kvc pb.KVClient, ops []*pb.RequestOp, chunkSize int, continueOnError bool) *batchResponse {
+	resp := &batchResponse{Chunks: []batchChunk{}}
+	for start := 0; start < len(ops); start += chunkSize {
+		end := min(start+chunkSize, len(ops))
+		chunk := batchChunk{Start: start, Count: end - start}
+		txn, err := kvc.Txn(ctx, &pb.TxnRequest{Success: ops[start:end]})
+		if err != nil {
+			st, _ := status.FromError(err)
+			chunk.Error = &batchError{Code: st.Code(), Message: st.Message()}
+			resp.Failed += chunk.Count
+		} else {
+			chunk.Revision = txn.Header.Revision
+			resp.Succeeded += chunk.Count
+		}
+		resp.Chunks = append(resp.Chunks, chunk)
+		if err != nil && !continueOnError {
+			resp.Skipped = len(ops) - end
+			break
+		}
+	}
+	return resp
+}
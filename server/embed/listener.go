@@ -0,0 +1,240 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/keepalive"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+)
+
+// ListenerConfig overrides the settings of a single client or metrics
+// listener, so that a busy or misbehaving interface cannot consume the
+// resources of the others.
+type ListenerConfig struct {
+	// URL is the listen URL the config applies to, one of ListenClientUrls,
+	// ListenClientHttpUrls or ListenMetricsUrls. URLs sharing the address
+	// of URL share its listener.
+	URL string `json:"url"`
+	// Name labels the metrics and logs of the listener. Defaults to the
+	// listen address.
+	Name string `json:"name"`
+	// MaxConnections is the maximum number of simultaneous connections
+	// accepted by the listener. 0 keeps the default limit, derived from
+	// the file descriptor limit of the process for client listeners.
+	MaxConnections int `json:"max-connections"`
+	// TLSInfo replaces ClientTLSInfo for the listener, if not empty.
+	TLSInfo transport.TLSInfo `json:"-"`
+	// SecurityJSON is the config file form of TLSInfo.
+	SecurityJSON securityConfig `json:"transport-security"`
+
+	// GRPCKeepAliveMinTime replaces Config.GRPCKeepAliveMinTime for the
+	// listener, if not zero.
+	GRPCKeepAliveMinTime time.Duration `json:"grpc-keepalive-min-time"`
+	// GRPCKeepAliveInterval replaces Config.GRPCKeepAliveInterval for the
+	// listener, if not zero.
+	GRPCKeepAliveInterval time.Duration `json:"grpc-keepalive-interval"`
+	// GRPCKeepAliveTimeout replaces Config.GRPCKeepAliveTimeout for the
+	// listener, if not zero.
+	GRPCKeepAliveTimeout time.Duration `json:"grpc-keepalive-timeout"`
+	// GRPCKeepAlivePermitWithoutStream lets clients ping connections without
+	// active streams. Otherwise such pings count as a violation of the
+	// keepalive enforcement policy, and the connection is closed.
+	GRPCKeepAlivePermitWithoutStream bool `json:"grpc-keepalive-permit-without-stream"`
+	// GRPCMaxConnectionIdle closes the gRPC connections of the listener that
+	// had no active streams for longer, with a GOAWAY. 0 keeps them open.
+	GRPCMaxConnectionIdle time.Duration `json:"grpc-max-connection-idle"`
+	// GRPCMaxConnectionAge closes the gRPC connections of the listener open
+	// for longer, with a GOAWAY. gRPC spreads the age by +/-10%, so that the
+	// long-lived watch connections of a member are drained gradually, e.g.
+	// during a rolling update, rather than all at once. 0 keeps them open.
+	GRPCMaxConnectionAge time.Duration `json:"grpc-max-connection-age"`
+	// GRPCMaxConnectionAgeGrace is how long the streams of a connection
+	// closed for its age may run before the connection is forcibly closed.
+	// 0 lets them run until they end.
+	GRPCMaxConnectionAgeGrace time.Duration `json:"grpc-max-connection-age-grace"`
+}
+
+func (cfg *Config) validateListenerConfigs() error {
+	addrs := make(map[string]bool)
+	for _, urls := range [][]url.URL{cfg.ListenClientUrls, cfg.ListenClientHttpUrls, cfg.ListenMetricsUrls} {
+		for _, u := range urls {
+			addr, _, _ := resolveURL(u)
+			addrs[addr] = true
+		}
+	}
+	seen := make(map[string]bool)
+	for _, lc := range cfg.ListenerConfigs {
+		u, err := url.Parse(lc.URL)
+		if err != nil {
+			return fmt.Errorf("invalid listener config url %q (%w)", lc.URL, err)
+		}
+		addr, _, _ := resolveURL(*u)
+		if !addrs[addr] {
+			return fmt.Errorf("listener config url %q does not match any client or metrics listen url", lc.URL)
+		}
+		if seen[addr] {
+			return fmt.Errorf("duplicate listener config for address %q", addr)
+		}
+		seen[addr] = true
+		if lc.MaxConnections < 0 {
+			return fmt.Errorf("listener config max-connections %d of %q must not be negative", lc.MaxConnections, lc.URL)
+		}
+		for name, d := range map[string]time.Duration{
+			"grpc-keepalive-min-time":       lc.GRPCKeepAliveMinTime,
+			"grpc-keepalive-interval":       lc.GRPCKeepAliveInterval,
+			"grpc-keepalive-timeout":        lc.GRPCKeepAliveTimeout,
+			"grpc-max-connection-idle":      lc.GRPCMaxConnectionIdle,
+			"grpc-max-connection-age":       lc.GRPCMaxConnectionAge,
+			"grpc-max-connection-age-grace": lc.GRPCMaxConnectionAgeGrace,
+		} {
+			if d < 0 {
+				return fmt.Errorf("listener config %s %v of %q must not be negative", name, d, lc.URL)
+			}
+		}
+	}
+	return nil
+}
+
+// listenerConfig returns the config of the listener of the given address,
+// or nil if there is none. Validate() has been called, so the URLs of the
+// configs are valid.
+func (cfg *Config) listenerConfig(addr string) *ListenerConfig {
+	for i := range cfg.ListenerConfigs {
+		u, err := url.Parse(cfg.ListenerConfigs[i].URL)
+		if err != nil {
+			continue
+		}
+		if a, _, _ := resolveURL(*u); a == addr {
+			return &cfg.ListenerConfigs[i]
+		}
+	}
+	return nil
+}
+
+// listenerTLSInfo returns the TLS config of the listener of the given config,
+// which may be nil.
+func (cfg *Config) listenerTLSInfo(lc *ListenerConfig) *transport.TLSInfo {
+	if lc == nil || lc.TLSInfo.Empty() {
+		return &cfg.ClientTLSInfo
+	}
+	return &lc.TLSInfo
+}
+
+// setupListenerTLSInfo applies the server wide TLS settings to the TLS
+// configs of the listeners.
+func (cfg *Config) setupListenerTLSInfo() error {
+	for i := range cfg.ListenerConfigs {
+		tls := &cfg.ListenerConfigs[i].TLSInfo
+		if tls.Empty() {
+			continue
+		}
+		if err := updateCipherSuites(tls, cfg.CipherSuites); err != nil {
+			return err
+		}
+		updateMinMaxVersions(tls, cfg.TlsMinVersion, cfg.TlsMaxVersion)
+		tls.CertificateRevoked = cfg.certificateRevokedFunc("client")
+	}
+	return nil
+}
+
+// grpcKeepAlive returns the keepalive enforcement policy and parameters of the
+// gRPC server of the listener of the given config, which may be nil. A nil
+// result keeps the defaults of gRPC.
+func (cfg *Config) grpcKeepAlive(lc *ListenerConfig) (*keepalive.EnforcementPolicy, *keepalive.ServerParameters) {
+	minTime, interval, timeout := cfg.GRPCKeepAliveMinTime, cfg.GRPCKeepAliveInterval, cfg.GRPCKeepAliveTimeout
+	var ep *keepalive.EnforcementPolicy
+	var sp *keepalive.ServerParameters
+	if lc != nil {
+		if lc.GRPCKeepAliveMinTime > 0 {
+			minTime = lc.GRPCKeepAliveMinTime
+		}
+		if lc.GRPCKeepAliveInterval > 0 {
+			interval = lc.GRPCKeepAliveInterval
+		}
+		if lc.GRPCKeepAliveTimeout > 0 {
+			timeout = lc.GRPCKeepAliveTimeout
+		}
+		if lc.GRPCKeepAlivePermitWithoutStream {
+			ep = &keepalive.EnforcementPolicy{PermitWithoutStream: true}
+		}
+		if lc.GRPCMaxConnectionIdle > 0 || lc.GRPCMaxConnectionAge > 0 {
+			sp = &keepalive.ServerParameters{
+				MaxConnectionIdle:     lc.GRPCMaxConnectionIdle,
+				MaxConnectionAge:      lc.GRPCMaxConnectionAge,
+				MaxConnectionAgeGrace: lc.GRPCMaxConnectionAgeGrace,
+			}
+		}
+	}
+	if minTime > 0 {
+		if ep == nil {
+			ep = &keepalive.EnforcementPolicy{}
+		}
+		ep.MinTime = minTime
+	}
+	if interval > 0 && timeout > 0 {
+		if sp == nil {
+			sp = &keepalive.ServerParameters{}
+		}
+		sp.Time = interval
+		sp.Timeout = timeout
+	}
+	return ep, sp
+}
+
+func listenerName(lc *ListenerConfig, addr string) string {
+	if lc != nil && lc.Name != "" {
+		return lc.Name
+	}
+	return addr
+}
+
+// instrumentListener reports the connections accepted by l under the given
+// listener name.
+func instrumentListener(l net.Listener, name string) net.Listener {
+	return &instrumentedListener{Listener: l, name: name}
+}
+
+type instrumentedListener struct {
+	net.Listener
+	name string
+}
+
+func (l *instrumentedListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	listenerAcceptedConnections.WithLabelValues(l.name).Inc()
+	listenerConnections.WithLabelValues(l.name).Inc()
+	return &instrumentedConn{Conn: c, name: l.name}, nil
+}
+
+type instrumentedConn struct {
+	net.Conn
+	name      string
+	closeOnce sync.Once
+}
+
+func (c *instrumentedConn) Close() error {
+	c.closeOnce.Do(func() { listenerConnections.WithLabelValues(c.name).Dec() })
+	return c.Conn.Close()
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/keepalive"
+	"sigs.k8s.io/yaml"
+)
+
+func TestValidateListenerConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []ListenerConfig
+		wantErr bool
+	}{
+		{name: "client url", configs: []ListenerConfig{{URL: "http://127.0.0.1:2379", MaxConnections: 10}}},
+		{name: "metrics url", configs: []ListenerConfig{{URL: "http://127.0.0.1:2381"}}},
+		{name: "other scheme of the same address", configs: []ListenerConfig{{URL: "https://127.0.0.1:2379"}}},
+		{name: "unknown url", configs: []ListenerConfig{{URL: "http://127.0.0.1:2380"}}, wantErr: true},
+		{name: "duplicate", configs: []ListenerConfig{{URL: "http://127.0.0.1:2379"}, {URL: "https://127.0.0.1:2379"}}, wantErr: true},
+		{name: "negative max connections", configs: []ListenerConfig{{URL: "http://127.0.0.1:2379", MaxConnections: -1}}, wantErr: true},
+		{name: "negative max connection age", configs: []ListenerConfig{{URL: "http://127.0.0.1:2379", GRPCMaxConnectionAge: -time.Second}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.ListenClientUrls = []url.URL{{Scheme: "http", Host: "127.0.0.1:2379"}}
+			cfg.ListenMetricsUrls = []url.URL{{Scheme: "http", Host: "127.0.0.1:2381"}}
+			cfg.ListenerConfigs = tt.configs
+			err := cfg.validateListenerConfigs()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigFileListenerConfigs(t *testing.T) {
+	b, err := yaml.Marshal(map[string]any{
+		"listen-metrics-urls": "http://127.0.0.1:2381",
+		"listener-configs": []map[string]any{{
+			"url":                     "http://127.0.0.1:2381",
+			"name":                    "metrics",
+			"max-connections":         5,
+			"grpc-max-connection-age": 30 * time.Minute,
+			"transport-security":      map[string]any{"cert-file": "mcert", "key-file": "mkey"},
+		}},
+	})
+	require.NoError(t, err)
+	tmpfile := mustCreateCfgFile(t, b)
+	defer os.Remove(tmpfile.Name())
+
+	cfg, err := ConfigFromFile(tmpfile.Name())
+	require.NoError(t, err)
+	require.Len(t, cfg.ListenerConfigs, 1)
+	lc := cfg.listenerConfig("127.0.0.1:2381")
+	require.NotNil(t, lc)
+	assert.Equal(t, "metrics", lc.Name)
+	assert.Equal(t, 5, lc.MaxConnections)
+	assert.Equal(t, 30*time.Minute, lc.GRPCMaxConnectionAge)
+	assert.Equal(t, "mcert", lc.TLSInfo.CertFile)
+	assert.Equal(t, "mkey", lc.TLSInfo.KeyFile)
+	assert.Same(t, &cfg.ClientTLSInfo, cfg.listenerTLSInfo(nil))
+	assert.Same(t, &lc.TLSInfo, cfg.listenerTLSInfo(lc))
+}
+
+func TestGRPCKeepAlive(t *testing.T) {
+	cfg := NewConfig()
+
+	ep, sp := cfg.grpcKeepAlive(nil)
+	assert.Equal(t, &keepalive.EnforcementPolicy{MinTime: DefaultGRPCKeepAliveMinTime}, ep)
+	assert.Equal(t, &keepalive.ServerParameters{Time: DefaultGRPCKeepAliveInterval, Timeout: DefaultGRPCKeepAliveTimeout}, sp)
+
+	ep, sp = cfg.grpcKeepAlive(&ListenerConfig{
+		GRPCKeepAliveInterval:            time.Minute,
+		GRPCKeepAlivePermitWithoutStream: true,
+		GRPCMaxConnectionAge:             time.Hour,
+		GRPCMaxConnectionAgeGrace:        10 * time.Minute,
+	})
+	assert.Equal(t, &keepalive.EnforcementPolicy{MinTime: DefaultGRPCKeepAliveMinTime, PermitWithoutStream: true}, ep)
+	assert.Equal(t, &keepalive.ServerParameters{
+		Time:                  time.Minute,
+		Timeout:               DefaultGRPCKeepAliveTimeout,
+		MaxConnectionAge:      time.Hour,
+		MaxConnectionAgeGrace: 10 * time.Minute,
+	}, sp)
+
+	cfg.GRPCKeepAliveMinTime = 0
+	cfg.GRPCKeepAliveInterval = 0
+	ep, sp = cfg.grpcKeepAlive(nil)
+	assert.Nil(t, ep)
+	assert.Nil(t, sp)
+	ep, sp = cfg.grpcKeepAlive(&ListenerConfig{GRPCMaxConnectionIdle: time.Minute})
+	assert.Nil(t, ep)
+	assert.Equal(t, &keepalive.ServerParameters{MaxConnectionIdle: time.Minute}, sp)
+}
+
+func TestCreateMetricsListenerMaxConnections(t *testing.T) {
+	e := &Etcd{cfg: Config{ListenerConfigs: []ListenerConfig{
+		{URL: "http://127.0.0.1:0", Name: "test-metrics", MaxConnections: 1},
+	}}}
+	l, err := e.createMetricsListener(url.URL{Scheme: "http", Host: "127.0.0.1:0"})
+	require.NoError(t, err)
+	defer l.Close()
+	assert.InDelta(t, 1, testutil.ToFloat64(listenerMaxConnections.WithLabelValues("test-metrics")), 0)
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			c, aerr := l.Accept()
+			if aerr != nil {
+				return
+			}
+			accepted <- c
+		}
+	}()
+	for i := 0; i < 2; i++ {
+		c, derr := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, derr)
+		defer c.Close()
+	}
+
+	c := <-accepted
+	assert.InDelta(t, 1, testutil.ToFloat64(listenerConnections.WithLabelValues("test-metrics")), 0)
+	select {
+	case <-accepted:
+		t.Fatal("accepted more connections than the limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, c.Close())
+	c = <-accepted
+	defer c.Close()
+	assert.InDelta(t, 2, testutil.ToFloat64(listenerAcceptedConnections.WithLabelValues("test-metrics")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(listenerConnections.WithLabelValues("test-metrics")), 0)
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embed
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+)
+
+var revokedConnectionAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "etcd",
+	Subsystem: "server",
+	Name:      "revoked_connection_attempts_total",
+	Help:      "The total number of connection attempts rejected because the certificate was revoked.",
+},
+	// listener is "client" or "peer", source is "crl" or "ocsp"
+	[]string{"listener", "source"},
+)
+
+var (
+	listenerConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "listener_connections",
+		Help:      "The number of open connections of the client and metrics listeners.",
+	},
+		[]string{"listener"},
+	)
+	listenerAcceptedConnections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "listener_accepted_connections_total",
+		Help:      "The total number of connections accepted by the client and metrics listeners.",
+	},
+		[]string{"listener"},
+	)
+	listenerMaxConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "listener_max_connections",
+		Help:      "The maximum number of simultaneous connections of the client and metrics listeners, if limited.",
+	},
+		[]string{"listener"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(revokedConnectionAttempts)
+	prometheus.MustRegister(listenerConnections)
+	prometheus.MustRegister(listenerAcceptedConnections)
+	prometheus.MustRegister(listenerMaxConnections)
+}
+
+// certificateRevokedFunc returns the TLSInfo.CertificateRevoked callback of
+// the given listener.
+func (cfg *Config) certificateRevokedFunc(listener string) func(*x509.Certificate, transport.RevocationSource) {
+	return func(cert *x509.Certificate, source transport.RevocationSource) {
+		revokedConnectionAttempts.WithLabelValues(listener, string(source)).Inc()
+		cfg.GetLogger().Warn(
+			"rejected connection with revoked certificate",
+			zap.String("listener", listener),
+			zap.String("source", string(source)),
+			zap.String("subject", cert.Subject.String()),
+			zap.String("serial", fmt.Sprintf("%x", cert.SerialNumber.Bytes())),
+		)
+	}
+}
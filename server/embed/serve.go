@@ -15,6 +15,7 @@
 package embed
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -60,6 +61,8 @@ type serveCtx struct {
 	secure   bool
 	insecure bool
 	httpOnly bool
+	// tlsInfo is the TLS config of the secure listener.
+	tlsInfo *transport.TLSInfo
 
 	// ctx is used to control the grpc gateway. Terminate the grpc gateway
 	// by calling `cancel` when shutting down the etcd.
@@ -151,7 +154,7 @@ func (sctx *serveCtx) serve(
 	var gwmux *gw.ServeMux
 	if s.Cfg.EnableGRPCGateway {
 		// GRPC gateway connects to grpc server via connection provided by grpc dial.
-		gwmux, err = sctx.registerGateway(grpcDialForRestGatewayBackends)
+		gwmux, err = sctx.registerGateway(grpcDialForRestGatewayBackends, s.Cfg.MaxTxnOps)
 		if err != nil {
 			sctx.lg.Error("registerGateway failed", zap.Error(err))
 			return err
@@ -319,7 +322,7 @@ func grpcHandlerFunc(grpcServer *grpc.Server, otherHandler http.Handler) http.Ha
 
 type registerHandlerFunc func(context.Context, *gw.ServeMux, *grpc.ClientConn) error
 
-func (sctx *serveCtx) registerGateway(dial func(ctx context.Context) (*grpc.ClientConn, error)) (*gw.ServeMux, error) {
+func (sctx *serveCtx) registerGateway(dial func(ctx context.Context) (*grpc.ClientConn, error), maxTxnOps uint) (*gw.ServeMux, error) {
 	ctx := sctx.ctx
 
 	conn, err := dial(ctx)
@@ -348,6 +351,7 @@ func (sctx *serveCtx) registerGateway(dial func(ctx context.Context) (*grpc.Clie
 		etcdservergw.RegisterKVHandler,
 		etcdservergw.RegisterWatchHandler,
 		etcdservergw.RegisterLeaseHandler,
+		etcdservergw.RegisterCounterHandler,
 		etcdservergw.RegisterClusterHandler,
 		etcdservergw.RegisterMaintenanceHandler,
 		etcdservergw.RegisterAuthHandler,
@@ -359,6 +363,9 @@ func (sctx *serveCtx) registerGateway(dial func(ctx context.Context) (*grpc.Clie
 			return nil, err
 		}
 	}
+	if err := registerBatchHandler(gwmux, conn, maxTxnOps); err != nil {
+		return nil, err
+	}
 	sctx.startHandler(nil, func() error {
 		<-ctx.Done()
 		if cerr := conn.Close(); cerr != nil {
@@ -396,7 +403,7 @@ func (sctx *serveCtx) createMux(gwmux *gw.ServeMux, handler http.Handler) *http.
 		httpmux.Handle(
 			"/v3/",
 			wsproxy.WebsocketProxy(
-				gwmux,
+				sseStream(gwmux),
 				wsproxy.WithRequestMutator(
 					// Default to the POST method for streams
 					func(_ *http.Request, outgoing *http.Request) *http.Request {
@@ -415,6 +422,84 @@ func (sctx *serveCtx) createMux(gwmux *gw.ServeMux, handler http.Handler) *http.
 	return httpmux
 }
 
+// sseStream serves the gateway responses as server-sent events to requests
+// accepting text/event-stream, with one event per streamed message.
+// Failed requests are answered as usual.
+func sseStream(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			h.ServeHTTP(w, r)
+			return
+		}
+		sw := &sseResponseWriter{ResponseWriter: w}
+		h.ServeHTTP(sw, r)
+		// unary responses are not terminated by a delimiter
+		if sw.events && len(sw.buf) > 0 {
+			sw.writeEvent(sw.buf)
+			sw.Flush()
+		}
+	})
+}
+
+type sseResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	// events is set once the response is framed as events.
+	events bool
+	buf    []byte
+}
+
+func (w *sseResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if code == http.StatusOK {
+		w.events = true
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *sseResponseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	if !w.events {
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if i > 0 {
+			if err := w.writeEvent(w.buf[:i]); err != nil {
+				return 0, err
+			}
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+func (w *sseResponseWriter) writeEvent(data []byte) error {
+	if _, err := io.WriteString(w.ResponseWriter, "data: "); err != nil {
+		return err
+	}
+	if _, err := w.ResponseWriter.Write(data); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.ResponseWriter, "\n\n")
+	return err
+}
+
+func (w *sseResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // createAccessController wraps HTTP multiplexer:
 // - mutate gRPC gateway request paths
 // - check hostname whitelist
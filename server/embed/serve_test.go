@@ -16,6 +16,8 @@ package embed
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
@@ -49,6 +51,48 @@ func TestStartEtcdWrongToken(t *testing.T) {
 	require.ErrorIsf(t, err, auth.ErrInvalidAuthOpts, "expected %v, got %v", auth.ErrInvalidAuthOpts, err)
 }
 
+func TestSSEStream(t *testing.T) {
+	stream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/stream":
+			for _, msg := range []string{`{"result":1}`, `{"result":2}`} {
+				w.Write([]byte(msg))
+				w.Write([]byte("\n"))
+				w.(http.Flusher).Flush()
+			}
+		case "/unary":
+			w.Write([]byte(`{"count":1}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":3}`))
+		}
+	})
+	tests := []struct {
+		path        string
+		accept      string
+		code        int
+		contentType string
+		body        string
+	}{
+		{"/stream", "text/event-stream", http.StatusOK, "text/event-stream", "data: {\"result\":1}\n\ndata: {\"result\":2}\n\n"},
+		{"/stream", "application/json", http.StatusOK, "application/json", "{\"result\":1}\n{\"result\":2}\n"},
+		{"/unary", "text/event-stream", http.StatusOK, "text/event-stream", "data: {\"count\":1}\n\n"},
+		{"/error", "text/event-stream", http.StatusBadRequest, "application/json", `{"code":3}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path+" "+tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			sseStream(stream).ServeHTTP(rec, req)
+			require.Equal(t, tt.code, rec.Code)
+			require.Equal(t, tt.contentType, rec.Header().Get("Content-Type"))
+			require.Equal(t, tt.body, rec.Body.String())
+		})
+	}
+}
+
 func newEmbedURLs(n int) (urls []url.URL) {
 	scheme := "unix"
 	for i := 0; i < n; i++ {
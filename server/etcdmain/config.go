@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -240,6 +241,15 @@ func (cfg *config) configFromCmdLine() error {
 
 	cfg.ec.CipherSuites = flags.StringsFromFlag(cfg.cf.flagSet, "cipher-suites")
 
+	cfg.ec.LeaderTransferPreferLabels = flags.StringsFromFlag(cfg.cf.flagSet, "leader-transfer-prefer-labels")
+
+	cfg.ec.HealthChecks = flags.StringsFromFlag(cfg.cf.flagSet, "health-checks")
+	cfg.ec.GRPCResponseCompression = flags.StringsFromFlag(cfg.cf.flagSet, "grpc-response-compression")
+	cfg.ec.HealthCheckTimeouts, err = parseHealthCheckTimeouts(flags.StringsFromFlag(cfg.cf.flagSet, "health-check-timeouts"))
+	if err != nil {
+		return err
+	}
+
 	cfg.ec.MaxConcurrentStreams = flags.Uint32FromFlag(cfg.cf.flagSet, "max-concurrent-streams")
 
 	cfg.ec.LogOutputs = flags.UniqueStringsFromFlag(cfg.cf.flagSet, "log-outputs")
@@ -266,6 +276,26 @@ func (cfg *config) configFromCmdLine() error {
 	return cfg.validate()
 }
 
+// parseHealthCheckTimeouts parses <probe>=<duration> pairs.
+func parseHealthCheckTimeouts(pairs []string) (map[string]time.Duration, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	timeouts := make(map[string]time.Duration, len(pairs))
+	for _, pair := range pairs {
+		name, d, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --health-check-timeouts %q, expecting <probe>=<duration>", pair)
+		}
+		timeout, err := time.ParseDuration(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --health-check-timeouts %q: %w", pair, err)
+		}
+		timeouts[name] = timeout
+	}
+	return timeouts, nil
+}
+
 func (cfg *config) configFromFile(path string) error {
 	eCfg, err := embed.ConfigFromFile(path)
 	if err != nil {
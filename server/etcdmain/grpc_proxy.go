@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -27,6 +28,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
@@ -77,6 +79,8 @@ var (
 	grpcProxyCert                  string
 	grpcProxyKey                   string
 	grpcProxyInsecureSkipTLSVerify bool
+	grpcProxyUpstreamServerName    string
+	grpcProxyUpstreamAllowedSANs   []string
 
 	// tls for clients connecting to proxy
 
@@ -163,6 +167,8 @@ func newGRPCProxyStartCommand() *cobra.Command {
 	cmd.Flags().StringVar(&grpcProxyKey, "key", "", "identify secure connections with etcd servers using this TLS key file")
 	cmd.Flags().StringVar(&grpcProxyCA, "cacert", "", "verify certificates of TLS-enabled secure etcd servers using this CA bundle")
 	cmd.Flags().BoolVar(&grpcProxyInsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "skip authentication of etcd server TLS certificates (CAUTION: this option should be enabled only for testing purposes)")
+	cmd.Flags().StringVar(&grpcProxyUpstreamServerName, "upstream-server-name", "", "verify certificates of etcd servers against this server name instead of the endpoint host, and send it as SNI")
+	cmd.Flags().StringSliceVar(&grpcProxyUpstreamAllowedSANs, "upstream-allowed-san", nil, "Comma-separated list of DNS names, IP addresses or URIs of which the certificates of etcd servers must contain at least one as subject alternative name (empty allows any)")
 
 	// client TLS for connecting to proxy
 	cmd.Flags().StringVar(&grpcProxyListenCert, "cert-file", "", "identify secure connections to the proxy using this TLS certificate file")
@@ -337,6 +343,12 @@ func checkArgs() {
 		fmt.Fprintln(os.Stderr, fmt.Errorf("cipher suites cannot be configured when only TLS1.3 is enabled"))
 		os.Exit(1)
 	}
+
+	upstreamTLS := newTLS(grpcProxyCA, grpcProxyCert, grpcProxyKey, true) != nil || grpcProxyInsecureSkipTLSVerify
+	if !upstreamTLS && (grpcProxyUpstreamServerName != "" || len(grpcProxyUpstreamAllowedSANs) > 0) {
+		fmt.Fprintln(os.Stderr, fmt.Errorf("upstream-server-name and upstream-allowed-san require TLS to etcd servers (set cacert, cert and key)"))
+		os.Exit(1)
+	}
 }
 
 func mustNewClient(lg *zap.Logger) *clientv3.Client {
@@ -430,12 +442,65 @@ func newClientCfg(lg *zap.Logger, eps []string) (*clientv3.Config, error) {
 		if clientTLS.InsecureSkipVerify {
 			lg.Warn("--insecure-skip-tls-verify was given, this grpc proxy process skips authentication of etcd server TLS certificates. This option should be enabled only for testing purposes.")
 		}
+		setUpstreamTLSVerification(clientTLS, grpcProxyUpstreamServerName, grpcProxyUpstreamAllowedSANs)
 		cfg.TLS = clientTLS
-		lg.Info("gRPC proxy client TLS", zap.String("tls-info", fmt.Sprintf("%+v", tls)))
+		lg.Info("gRPC proxy client TLS",
+			zap.String("tls-info", fmt.Sprintf("%+v", tls)),
+			zap.String("upstream-server-name", grpcProxyUpstreamServerName),
+			zap.Strings("upstream-allowed-sans", grpcProxyUpstreamAllowedSANs),
+		)
+		if grpcProxyCert != "" && grpcProxyCert == grpcProxyListenCert {
+			lg.Warn("the proxy uses the same certificate towards etcd servers and its clients; use distinct certificates to separate the upstream identity from the one presented to clients", zap.String("cert", grpcProxyCert))
+		}
 	}
 	return &cfg, nil
 }
 
+// setUpstreamTLSVerification makes cfg verify the certificates of etcd
+// servers against serverName, if set, instead of the endpoint host, and
+// requires them to contain one of allowedSANs, if any.
+func setUpstreamTLSVerification(cfg *tls.Config, serverName string, allowedSANs []string) {
+	if serverName != "" {
+		cfg.ServerName = serverName
+	}
+	if len(allowedSANs) > 0 {
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyAllowedSANs(cs, allowedSANs)
+		}
+	}
+}
+
+// verifyAllowedSANs returns an error unless the peer certificate contains at
+// least one of the allowed DNS names, IP addresses or URIs. It is also checked
+// with InsecureSkipVerify, so that pinning holds without a trusted CA.
+func verifyAllowedSANs(cs tls.ConnectionState, allowedSANs []string) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no peer certificate to verify subject alternative names")
+	}
+	cert := cs.PeerCertificates[0]
+	for _, san := range allowedSANs {
+		if ip := net.ParseIP(san); ip != nil {
+			for _, certIP := range cert.IPAddresses {
+				if ip.Equal(certIP) {
+					return nil
+				}
+			}
+			continue
+		}
+		for _, name := range cert.DNSNames {
+			if strings.EqualFold(san, name) {
+				return nil
+			}
+		}
+		for _, uri := range cert.URIs {
+			if san == uri.String() {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("peer certificate %q does not contain any of the allowed subject alternative names %v", cert.Subject, allowedSANs)
+}
+
 func newTLS(ca, cert, key string, requireEmptyCN bool) *transport.TLSInfo {
 	if ca == "" && cert == "" && key == "" {
 		return nil
@@ -484,6 +549,7 @@ func newGRPCProxyServer(lg *zap.Logger, client *clientv3.Client) *grpc.Server {
 		client.KV = namespace.NewKV(client.KV, grpcProxyNamespace)
 		client.Watcher = namespace.NewWatcher(client.Watcher, grpcProxyNamespace)
 		client.Lease = namespace.NewLease(client.Lease, grpcProxyNamespace)
+		client.Counter = namespace.NewCounter(client.Counter, grpcProxyNamespace)
 	}
 
 	if len(grpcProxyLeasing) > 0 {
@@ -500,6 +566,7 @@ func newGRPCProxyServer(lg *zap.Logger, client *clientv3.Client) *grpc.Server {
 
 	mainp := grpcproxy.NewMaintenanceProxy(client)
 	authp := grpcproxy.NewAuthProxy(client)
+	counterp := grpcproxy.NewCounterProxy(client)
 	electionp := grpcproxy.NewElectionProxy(client)
 	lockp := grpcproxy.NewLockProxy(client)
 
@@ -548,6 +615,7 @@ func newGRPCProxyServer(lg *zap.Logger, client *clientv3.Client) *grpc.Server {
 	pb.RegisterLeaseServer(server, leasep)
 	pb.RegisterMaintenanceServer(server, mainp)
 	pb.RegisterAuthServer(server, authp)
+	pb.RegisterCounterServer(server, counterp)
 	v3electionpb.RegisterElectionServer(server, electionp)
 	v3lockpb.RegisterLockServer(server, lockp)
 
@@ -618,6 +686,9 @@ func newHTTPTransport(ca, cert, key string) (*http.Transport, error) {
 		tlsConfig := &tls.Config{InsecureSkipVerify: grpcProxyInsecureSkipTLSVerify}
 		tr.TLSClientConfig = tlsConfig
 	}
+	if tr.TLSClientConfig != nil {
+		setUpstreamTLSVerification(tr.TLSClientConfig, grpcProxyUpstreamServerName, grpcProxyUpstreamAllowedSANs)
+	}
 	return tr, nil
 }
 
@@ -0,0 +1,75 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdmain
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAllowedSANs(t *testing.T) {
+	spiffe, err := url.Parse("spiffe://example.com/etcd")
+	require.NoError(t, err)
+	cs := tls.ConnectionState{PeerCertificates: []*x509.Certificate{{
+		DNSNames:    []string{"etcd-0.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:        []*url.URL{spiffe},
+	}}}
+
+	tests := []struct {
+		name    string
+		allowed []string
+		wantErr bool
+	}{
+		{name: "dns name", allowed: []string{"other.example.com", "etcd-0.example.com"}},
+		{name: "dns name is case insensitive", allowed: []string{"ETCD-0.example.com"}},
+		{name: "ip address", allowed: []string{"10.0.0.1"}},
+		{name: "uri", allowed: []string{"spiffe://example.com/etcd"}},
+		{name: "no match", allowed: []string{"etcd-1.example.com", "10.0.0.2"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyAllowedSANs(cs, tt.allowed)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// IP addresses are only matched against IP SANs
+	ipAsDNS := tls.ConnectionState{PeerCertificates: []*x509.Certificate{{DNSNames: []string{"10.0.0.1"}}}}
+	require.Error(t, verifyAllowedSANs(ipAsDNS, []string{"10.0.0.1"}))
+	require.Error(t, verifyAllowedSANs(tls.ConnectionState{}, []string{"etcd-0.example.com"}))
+}
+
+func TestSetUpstreamTLSVerification(t *testing.T) {
+	cfg := &tls.Config{}
+	setUpstreamTLSVerification(cfg, "", nil)
+	assert.Empty(t, cfg.ServerName)
+	assert.Nil(t, cfg.VerifyConnection)
+
+	setUpstreamTLSVerification(cfg, "etcd.internal", []string{"etcd-0.example.com"})
+	assert.Equal(t, "etcd.internal", cfg.ServerName)
+	require.NotNil(t, cfg.VerifyConnection)
+	require.Error(t, cfg.VerifyConnection(tls.ConnectionState{}))
+}
@@ -48,6 +48,9 @@ var (
 
   etcd grpc-proxy
     Run the stateless etcd v3 gRPC L7 reverse proxy.
+
+  etcd validate-config --config-file
+    Validate the server configuration file and exit.
 `
 	flagsline = `
 Member:
@@ -81,16 +84,42 @@ Member:
     Sets the maximum size (in bytes) that the etcd backend database may consume. Exceeding this triggers an alarm and puts etcd in read-only mode. Set to 0 to use the default 2GiB limit.
   --backend-bbolt-freelist-type 'map'
     BackendFreelistType specifies the type of freelist that boltdb backend uses(array and map are supported types).
+  --backend-engine 'bolt'
+    Storage engine of the backend, one of: bolt|pebble. The pebble engine is experimental. An existing backend must be converted with 'etcdutl convert-backend' to change it.
   --backend-batch-interval ''
     BackendBatchInterval is the maximum time before commit the backend transaction.
   --backend-batch-limit '0'
     BackendBatchLimit is the maximum operations before commit the backend transaction.
+  --backend-checkpoint-interval '0s'
+    Interval at which to write a checkpoint of the backend, cloned on filesystems supporting copy-on-write like XFS and btrfs. 0 disables periodic checkpoints.
+  --backend-checkpoint-dir ''
+    Directory holding the backend checkpoints. Defaults to the 'checkpoints' directory of the data directory.
+  --backend-checkpoint-retention '3'
+    Number of backend checkpoints to keep. 0 keeps all of them.
+  --backend-bucket-stats-interval '1m0s'
+    Interval at which to track the number of keys and the size of each backend bucket. 0 disables tracking.
   --max-txn-ops '128'
     Maximum number of operations permitted in a transaction.
   --max-request-bytes '1572864'
     Maximum client request size in bytes the server will accept.
   --max-concurrent-streams 'math.MaxUint32'
     Maximum concurrent streams that each client can open at a time.
+  --grpc-response-compression ''
+    Comma-separated list of compressors, among: gzip, zstd, unary gRPC responses may be compressed with, in order of preference. Empty disables response compression.
+  --grpc-response-compression-min-bytes '1024'
+    Size in bytes under which gRPC responses are sent uncompressed.
+  --too-busy-apply-backlog '0'
+    Number of committed but unapplied entries above which low priority writes are rejected as too busy. 0 disables it.
+  --too-busy-backoff '100ms'
+    Backoff suggested to low priority writes rejected as too busy. It grows with the apply backlog.
+  --serve-stale-reads 'true'
+    Serve serializable reads while the member catches up with the leader after it starts. If false, they are rejected until the member is within stale-reads-catch-up-threshold entries of the leader.
+  --stale-reads-catch-up-threshold '1000'
+    Number of entries behind the leader's commit index under which a member started with --serve-stale-reads=false serves serializable reads.
+  --memory-soft-limit '0'
+    Memory in bytes held by the key index, watchers, leases and gRPC buffers above which idle watchers are dropped. 0 disables it.
+  --wal-disk-free-threshold '134217728'
+    Free disk space in bytes of the WAL directory under which a WALNOSPACE alarm is raised and writes are rejected. 0 disables it.
   --grpc-keepalive-min-time '5s'
     Minimum duration interval that a client should wait before pinging server.
   --grpc-keepalive-interval '2h'
@@ -162,12 +191,30 @@ Clustering:
     Suffix to the dns srv name queried when bootstrapping.
   --strict-reconfig-check '` + strconv.FormatBool(embed.DefaultStrictReconfigCheck) + `'
     Reject reconfiguration requests that would cause quorum loss.
+  --leader-transfer-prefer-labels ''
+    Comma-separated list of member label keys. On shutdown, the leader prefers transferring leadership to a member with the same values for these labels.
+  --raft-leadership ''
+    Leadership eligibility of the member, one of: allowed|disallowed. A disallowed member transfers its leadership whenever it wins an election. If empty, the eligibility recorded in the cluster membership is kept.
   --pre-vote 'true'
     Enable the raft Pre-Vote algorithm to prevent disruption when a node that has been partitioned away rejoins the cluster.
+  --check-quorum 'true'
+    Make the raft leader step down when it has not heard from a quorum of members within an election timeout.
+  --read-consistency 'read-index'
+    Default consistency of linearizable reads, one of: read-index|lease. 'lease' serves reads from the leader lease and relies on bounded clock drift between members.
   --auto-compaction-retention '0'
     Auto compaction retention length. 0 means disable auto compaction.
   --auto-compaction-mode 'periodic'
     Interpret 'auto-compaction-retention' one of: periodic|revision. 'periodic' for duration based retention, defaulting to hours if no time unit is provided (e.g. '5m'). 'revision' for revision number based retention.
+  --compaction-max-hold '0s'
+    Maximum duration a compaction hold registered by a client delays auto compaction. 0 disables compaction holds.
+  --auto-compaction-coordinated 'false'
+    Delay auto compaction until all active voting members caught up with the leader, so that they compact at roughly the same time.
+  --auto-compaction-coordination-timeout '1m0s'
+    Maximum duration a coordinated auto compaction waits for the members to catch up.
+  --auto-defrag-schedule ''
+    Schedule at which to defragment the backend, e.g. 'cron:0 3 * * *'. Members defragment one at a time and the leader never does. Empty disables scheduled defragmentation.
+  --auto-defrag-threshold '30%'
+    Minimum fragmentation of the backend, as a percentage of its size, for scheduled defragmentation to run.
   --v2-deprecation '` + string(cconfig.V2DeprDefault) + `'
     Phase of v2store deprecation. Deprecated and scheduled for removal in v3.8. The default value is enforced, ignoring user input.
     Supported values:
@@ -188,7 +235,11 @@ Security:
   --client-key-file ''
     Path to an explicit peer client TLS key file otherwise key file will be used when client auth is required.
   --client-crl-file ''
-    Path to the client certificate revocation list file.
+    Path to the client certificate revocation list file. The file is reloaded when it changes.
+  --client-ocsp-verify ''
+    Check the revocation status of client certificates with OCSP. Possible values: soft-fail, hard-fail (empty disables it).
+  --client-ocsp-stapling 'false'
+    Staple an OCSP response to the client server TLS cert.
   --client-cert-allowed-hostname ''
     Comma-separated list of SAN hostnames for client cert authentication.
   --trusted-ca-file ''
@@ -216,7 +267,11 @@ Security:
   --self-signed-cert-validity '1'
     The validity period of the client and peer certificates that are automatically generated by etcd when you specify ClientAutoTLS and PeerAutoTLS, the unit is year, and the default is 1.
   --peer-crl-file ''
-    Path to the peer certificate revocation list file.
+    Path to the peer certificate revocation list file. The file is reloaded when it changes.
+  --peer-ocsp-verify ''
+    Check the revocation status of peer certificates with OCSP. Possible values: soft-fail, hard-fail (empty disables it).
+  --peer-ocsp-stapling 'false'
+    Staple an OCSP response to the peer server TLS cert.
   --cipher-suites ''
     Comma-separated list of supported TLS cipher suites between client/server and peers (empty will be auto-populated by Go).
   --cors '*'
@@ -235,6 +290,12 @@ Auth:
     Specify the cost / strength of the bcrypt algorithm for hashing auth passwords. Valid values are between ` + fmt.Sprintf("%d", bcrypt.MinCost) + ` and ` + fmt.Sprintf("%d", bcrypt.MaxCost) + `.
   --auth-token-ttl 300
     Time (in seconds) of the auth-token-ttl.
+  --password-min-length 0
+    Minimum length of the passwords of users.
+  --password-min-char-classes 0
+    Minimum number of character classes (lower case, upper case, digits, others) used by the passwords of users.
+  --password-max-age 0
+    Duration after which the password of a user expires and is rejected for authentication. 0 means passwords never expire.
 
 Profiling and Monitoring:
   --enable-pprof 'false'
@@ -243,6 +304,10 @@ Profiling and Monitoring:
     Set level of detail for exported metrics, specify 'extensive' to include server side grpc histogram metrics.
   --listen-metrics-urls ''
     List of URLs to listen on for the /metrics and /health endpoints. For https, the client URL TLS info is used.
+  --health-checks 'serializable_read,linearizable_read'
+    Comma-separated list of probes run by /health and /readyz, among: serializable_read, linearizable_read, lease_grant, disk_fsync.
+  --health-check-timeouts ''
+    Comma-separated list of <probe>=<duration> timeouts of individual health probes, e.g. 'lease_grant=2s'.
 
 Logging:
   --logger 'zap'
@@ -277,12 +342,32 @@ Features:
     Duration of time between cluster corruption check passes.
   --compact-hash-check-time '1m'
     Duration of time between leader checks followers compaction hashes.
+  --corrupt-check-hash-cache 'false'
+    Store the keyspace hash on clean shutdown so the initial corruption check on the next boot does not need to hash the keyspace.
   --compaction-batch-limit 1000
     CompactionBatchLimit sets the maximum revisions deleted in each compaction batch.
+  --value-chunk-size 0
+    Size in bytes above which values are stored in chunks outside the key bucket. 0 disables value chunking. Requires feature gate ValueChunking.
+  --lease-revoke-rate 0
+    Maximum number of expired leases revoked per second, pacing revocations after a leader change. 0 uses 1000.
+  --index-checkpoint-interval '0s'
+    Interval between checkpoints of the key index persisted in the backend to speed up restarts. 0 disables index checkpoints.
   --peer-skip-client-san-verification 'false'
     Skip verification of SAN field in client certificate for peer connections.
   --watch-progress-notify-interval '10m'
     Duration of periodical watch progress notification.
+  --watch-send-batch-interval '0s'
+    Maximum time watch events are held back to be sent in batches on a watch stream. 0 disables batching.
+  --serializable-read-cache-bytes 0
+    Maximum size in bytes of the cache of serializable reads of single keys. 0 disables the cache.
+  --serializable-read-cache-ttl '10s'
+    Maximum time a serializable read is cached.
+  --hot-keys-top-k 0
+    Number of hottest keys tracked by reads, writes, bytes read and bytes written. 0 disables hot keys tracking.
+  --hot-keys-sample-rate 0.01
+    Fraction of the requests sampled to track the hottest keys.
+  --newer-request-fields 'ignore'
+    How requests with fields newer than the cluster version are handled: 'ignore', 'log' or 'reject'. 'log' and 'reject' log each field once and report them through the NewerFields maintenance API.
   --warning-apply-duration '100ms'
     Warning is generated if requests take more than this duration.
   --bootstrap-defrag-threshold-megabytes
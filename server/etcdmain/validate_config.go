@@ -0,0 +1,55 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdmain
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"go.etcd.io/etcd/server/v3/embed"
+)
+
+var validateConfigFile string
+
+func init() {
+	rootCmd.AddCommand(newValidateConfigCommand())
+}
+
+// newValidateConfigCommand returns the cobra command for "validate-config".
+func newValidateConfigCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "validate-config",
+		Short: "Validate a server configuration file without starting the server",
+		Run:   validateConfig,
+	}
+
+	cmd.Flags().StringVar(&validateConfigFile, "config-file", "", "Path to the server configuration file to validate.")
+
+	return &cmd
+}
+
+func validateConfig(cmd *cobra.Command, args []string) {
+	if validateConfigFile == "" {
+		fmt.Fprintln(os.Stderr, "--config-file is required")
+		os.Exit(1)
+	}
+	if _, err := embed.ConfigFromFile(validateConfigFile); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration file %q: %v\n", validateConfigFile, err)
+		os.Exit(1)
+	}
+	fmt.Printf("configuration file %q is valid\n", validateConfigFile)
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage"
+)
+
+// alarmThresholdCheckInterval is the interval at which the metrics of the
+// member are checked against the alarm thresholds of the cluster
+// configuration.
+const alarmThresholdCheckInterval = 5 * time.Second
+
+// ThresholdBlocked reports whether the operation is blocked by the crossed
+// alarm thresholds of a member of the cluster.
+func (s *EtcdServer) ThresholdBlocked(op pb.AlarmThreshold_Operation) bool {
+	if s.clusterConfig == nil {
+		return false
+	}
+	return s.clusterConfig.Blocked(s.alarmStore.Get(pb.AlarmType_THRESHOLD))[op]
+}
+
+// monitorAlarmThresholds checks the metrics of the member against the alarm
+// thresholds of the cluster configuration. The member raises the THRESHOLD
+// alarm with the metrics over their threshold, so that the cluster rejects the
+// operations they block, and clears it once all of them are back under.
+func (s *EtcdServer) monitorAlarmThresholds() {
+	if s.clusterConfig == nil {
+		return
+	}
+
+	ticker := time.NewTicker(alarmThresholdCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+		}
+
+		var crossed []pb.AlarmThreshold_Metric
+		for _, t := range s.clusterConfig.Config().AlarmThresholds {
+			if s.thresholdMetric(t.Metric) >= t.Value {
+				crossed = append(crossed, t.Metric)
+			}
+		}
+		slices.Sort(crossed)
+		if !slices.Equal(crossed, s.thresholdAlarmMetrics()) {
+			s.alarmThreshold(crossed)
+		}
+	}
+}
+
+// thresholdMetric returns the current value of the metric of the member.
+func (s *EtcdServer) thresholdMetric(m pb.AlarmThreshold_Metric) int64 {
+	switch m {
+	case pb.AlarmThreshold_DB_SIZE_PERCENT:
+		quota := s.Cfg.QuotaBackendBytes
+		if quota < 0 {
+			return 0
+		}
+		if quota == 0 {
+			quota = storage.DefaultQuotaBytes
+		}
+		return s.Backend().Size() * 100 / quota
+	case pb.AlarmThreshold_APPLY_BACKLOG:
+		committed, applied := s.getCommittedIndex(), s.getAppliedIndex()
+		if committed < applied {
+			return 0
+		}
+		return int64(committed - applied)
+	case pb.AlarmThreshold_WATCHERS:
+		return int64(s.KV().MemoryStats().Watchers)
+	case pb.AlarmThreshold_LEASES:
+		return int64(len(s.lessor.Leases()))
+	}
+	return 0
+}
+
+// thresholdAlarmMetrics returns the metrics of the THRESHOLD alarm of the
+// member, or nil if it is not raised.
+func (s *EtcdServer) thresholdAlarmMetrics() []pb.AlarmThreshold_Metric {
+	for _, m := range s.alarmStore.Get(pb.AlarmType_THRESHOLD) {
+		if m.MemberID == uint64(s.MemberID()) {
+			return m.Metrics
+		}
+	}
+	return nil
+}
+
+// alarmThreshold raises the THRESHOLD alarm of the member with the given
+// metrics, or clears it if there are none. Failures are retried at the next
+// check.
+func (s *EtcdServer) alarmThreshold(metrics []pb.AlarmThreshold_Metric) {
+	a := &pb.AlarmRequest{
+		MemberID: uint64(s.MemberID()),
+		Action:   pb.AlarmRequest_DEACTIVATE,
+		Alarm:    pb.AlarmType_THRESHOLD,
+	}
+	if len(metrics) > 0 {
+		a.Action = pb.AlarmRequest_ACTIVATE
+		a.Metrics = metrics
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	if _, err := s.raftRequest(ctx, pb.InternalRaftRequest{Alarm: a}); err != nil {
+		s.Logger().Warn("failed to update the THRESHOLD alarm", zap.Stringer("action", a.Action), zap.Error(err))
+		return
+	}
+	if len(metrics) > 0 {
+		s.Logger().Warn("raised THRESHOLD alarm", zap.Stringers("metrics", metrics))
+	} else {
+		s.Logger().Info("cleared THRESHOLD alarm")
+	}
+}
@@ -27,8 +27,20 @@ import (
 type Capability string
 
 const (
-	AuthCapability  Capability = "auth"
-	V3rpcCapability Capability = "v3rpc"
+	AuthCapability           Capability = "auth"
+	V3rpcCapability          Capability = "v3rpc"
+	AppendCapability         Capability = "append"
+	ClusterConfigCapability  Capability = "clusterConfig"
+	SequenceCapability       Capability = "sequence"
+	CounterCapability        Capability = "counter"
+	ForEachCapability        Capability = "foreach"
+	ReplaceMemberCapability  Capability = "replaceMember"
+	CompactionHoldCapability Capability = "compactionHold"
+	FenceCapability          Capability = "fence"
+	RPCPermissionCapability  Capability = "rpcPermission"
+	ValueCompareCapability   Capability = "valueCompare"
+	FencingTokenCapability   Capability = "fencingToken"
+	DeleteLimitCapability    Capability = "deleteLimit"
 )
 
 var (
@@ -41,7 +53,7 @@ var (
 		"3.4.0": {AuthCapability: true, V3rpcCapability: true},
 		"3.5.0": {AuthCapability: true, V3rpcCapability: true},
 		"3.6.0": {AuthCapability: true, V3rpcCapability: true},
-		"3.7.0": {AuthCapability: true, V3rpcCapability: true},
+		"3.7.0": {AuthCapability: true, V3rpcCapability: true, AppendCapability: true, ClusterConfigCapability: true, SequenceCapability: true, CounterCapability: true, ForEachCapability: true, ReplaceMemberCapability: true, CompactionHoldCapability: true, FenceCapability: true, RPCPermissionCapability: true, ValueCompareCapability: true, FencingTokenCapability: true, DeleteLimitCapability: true},
 	}
 
 	enableMapMu sync.RWMutex
@@ -53,8 +65,20 @@ var (
 
 func init() {
 	enabledMap = map[Capability]bool{
-		AuthCapability:  true,
-		V3rpcCapability: true,
+		AuthCapability:           true,
+		V3rpcCapability:          true,
+		AppendCapability:         true,
+		ClusterConfigCapability:  true,
+		SequenceCapability:       true,
+		CounterCapability:        true,
+		ForEachCapability:        true,
+		ReplaceMemberCapability:  true,
+		CompactionHoldCapability: true,
+		FenceCapability:          true,
+		RPCPermissionCapability:  true,
+		ValueCompareCapability:   true,
+		FencingTokenCapability:   true,
+		DeleteLimitCapability:    true,
 	}
 }
 
@@ -0,0 +1,203 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clusterconfig keeps the cluster-wide configuration set through the
+// Cluster API.
+package clusterconfig
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var (
+	ErrInvalidConfig   = errors.New("etcdserver: invalid cluster config")
+	ErrProtectedPrefix = errors.New("etcdserver: write to protected prefix requires the lease of an allowed election candidate")
+)
+
+// Store keeps the cluster configuration. The configuration is replicated
+// through raft and persisted in the backend.
+type Store struct {
+	mu  sync.RWMutex
+	be  backend.Backend
+	cfg *pb.ClusterConfig
+}
+
+func NewStore(be backend.Backend) *Store {
+	s := &Store{}
+	s.Recover(be)
+	return s
+}
+
+// Recover reloads the configuration from the given backend, which replaces
+// the previous one.
+func (s *Store) Recover(be backend.Backend) {
+	tx := be.ReadTx()
+	tx.RLock()
+	cfg := schema.MustUnsafeGetClusterConfig(tx)
+	tx.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.be = be
+	s.cfg = cfg
+	if s.cfg == nil {
+		s.cfg = &pb.ClusterConfig{}
+	}
+}
+
+// Set replaces the configuration.
+func (s *Store) Set(cfg *pb.ClusterConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.be.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	schema.MustUnsafePutClusterConfig(tx, cfg)
+	s.cfg = cfg
+}
+
+// Config returns the current configuration. It must not be modified.
+func (s *Store) Config() *pb.ClusterConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg
+}
+
+// Protections returns the protected prefixes that overlap the range
+// [key, end), using the same conventions as a range request for end.
+func (s *Store) Protections(key, end []byte) []*pb.ProtectedPrefix {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ret []*pb.ProtectedPrefix
+	for _, p := range s.cfg.ProtectedPrefixes {
+		if OverlapsPrefix(key, end, p.Prefix) {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// Blocked returns the operations blocked by the thresholds that the metrics
+// of the given THRESHOLD alarms crossed.
+func (s *Store) Blocked(alarms []*pb.AlarmMember) map[pb.AlarmThreshold_Operation]bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blocked := make(map[pb.AlarmThreshold_Operation]bool)
+	for _, a := range alarms {
+		for _, m := range a.Metrics {
+			for _, t := range s.cfg.AlarmThresholds {
+				if t.Metric != m {
+					continue
+				}
+				for _, op := range t.Block {
+					blocked[op] = true
+				}
+			}
+		}
+	}
+	return blocked
+}
+
+// Validate checks that cfg can be applied. The election of a protected prefix
+// must not overlap any protected prefix, so that candidates can always join
+// and leave the election. Each metric has at most one positive threshold.
+func Validate(cfg *pb.ClusterConfig) error {
+	if cfg == nil {
+		return ErrInvalidConfig
+	}
+	for _, p := range cfg.ProtectedPrefixes {
+		if len(p.Prefix) == 0 || len(p.Election) == 0 {
+			return ErrInvalidConfig
+		}
+		if _, ok := pb.ProtectedPrefix_Writer_name[int32(p.Writer)]; !ok {
+			return ErrInvalidConfig
+		}
+	}
+	metrics := make(map[pb.AlarmThreshold_Metric]bool)
+	for _, t := range cfg.AlarmThresholds {
+		if _, ok := pb.AlarmThreshold_Metric_name[int32(t.Metric)]; !ok || metrics[t.Metric] || t.Value <= 0 {
+			return ErrInvalidConfig
+		}
+		metrics[t.Metric] = true
+		for _, op := range t.Block {
+			if _, ok := pb.AlarmThreshold_Operation_name[int32(op)]; !ok {
+				return ErrInvalidConfig
+			}
+		}
+	}
+	for _, p := range cfg.ProtectedPrefixes {
+		key, end := CandidateRange(p.Election)
+		for _, q := range cfg.ProtectedPrefixes {
+			if overlaps(key, end, q.Prefix, prefixEnd(q.Prefix)) {
+				return ErrInvalidConfig
+			}
+		}
+	}
+	return nil
+}
+
+// CandidateRange returns the range of the keys held by the candidates of the
+// given election.
+func CandidateRange(election []byte) (key, end []byte) {
+	key = append(bytes.Clone(election), '/')
+	return key, prefixEnd(key)
+}
+
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// no limit
+	return []byte{0}
+}
+
+// OverlapsPrefix reports whether the range [key, end) has a key under the
+// prefix, using the same conventions as a range request for end.
+func OverlapsPrefix(key, end, prefix []byte) bool {
+	return overlaps(key, end, prefix, prefixEnd(prefix))
+}
+
+// overlaps reports whether the ranges [k1, e1) and [k2, e2) share a key, where
+// an empty end denotes a single key and "\x00" a range without limit.
+func overlaps(k1, e1, k2, e2 []byte) bool {
+	return before(k1, rangeEnd(k2, e2)) && before(k2, rangeEnd(k1, e1))
+}
+
+func rangeEnd(key, end []byte) []byte {
+	if len(end) == 0 {
+		return append(bytes.Clone(key), 0)
+	}
+	return end
+}
+
+// before reports whether key is before the end of a range.
+func before(key, end []byte) bool {
+	if len(end) == 1 && end[0] == 0 {
+		return true
+	}
+	return bytes.Compare(key, end) < 0
+}
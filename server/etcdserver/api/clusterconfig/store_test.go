@@ -0,0 +1,171 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestStore(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	s := NewStore(be)
+	assert.Empty(t, s.Config().ProtectedPrefixes)
+	assert.Empty(t, s.Protections([]byte("a"), nil))
+
+	cfg := &pb.ClusterConfig{ProtectedPrefixes: []*pb.ProtectedPrefix{
+		{Prefix: []byte("/state/"), Election: []byte("/elect")},
+		{Prefix: []byte("\xff"), Election: []byte("/elect"), Writer: pb.ProtectedPrefix_FOLLOWER},
+	}}
+	s.Set(cfg)
+	be.ForceCommit()
+
+	// the configuration survives a reload from the backend
+	s = NewStore(be)
+	assert.Equal(t, cfg, s.Config())
+
+	tcs := []struct {
+		name     string
+		key, end string
+		want     int
+	}{
+		{name: "single key inside", key: "/state/a", want: 1},
+		{name: "single key outside", key: "/statea", want: 0},
+		{name: "prefix itself", key: "/state/", want: 1},
+		{name: "range ending at prefix", key: "/a", end: "/state/", want: 0},
+		{name: "range ending inside prefix", key: "/a", end: "/state/b", want: 1},
+		{name: "range starting after prefix", key: "/state0", end: "/z", want: 0},
+		{name: "range without limit", key: "/", end: "\x00", want: 2},
+		{name: "key in unbounded prefix", key: "\xff\xff", want: 1},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var end []byte
+			if tc.end != "" {
+				end = []byte(tc.end)
+			}
+			assert.Len(t, s.Protections([]byte(tc.key), end), tc.want)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tcs := []struct {
+		name  string
+		cfg   *pb.ClusterConfig
+		valid bool
+	}{
+		{name: "empty", cfg: &pb.ClusterConfig{}, valid: true},
+		{name: "nil", cfg: nil},
+		{
+			name: "valid",
+			cfg: &pb.ClusterConfig{ProtectedPrefixes: []*pb.ProtectedPrefix{
+				{Prefix: []byte("/state/"), Election: []byte("/elect")},
+				{Prefix: []byte("/elected/"), Election: []byte("/other")},
+			}},
+			valid: true,
+		},
+		{
+			name: "empty prefix",
+			cfg:  &pb.ClusterConfig{ProtectedPrefixes: []*pb.ProtectedPrefix{{Election: []byte("/elect")}}},
+		},
+		{
+			name: "empty election",
+			cfg:  &pb.ClusterConfig{ProtectedPrefixes: []*pb.ProtectedPrefix{{Prefix: []byte("/state/")}}},
+		},
+		{
+			name: "unknown writer",
+			cfg:  &pb.ClusterConfig{ProtectedPrefixes: []*pb.ProtectedPrefix{{Prefix: []byte("/state/"), Election: []byte("/elect"), Writer: 5}}},
+		},
+		{
+			name: "election inside protected prefix",
+			cfg:  &pb.ClusterConfig{ProtectedPrefixes: []*pb.ProtectedPrefix{{Prefix: []byte("/app/"), Election: []byte("/app/elect")}}},
+		},
+		{
+			name: "election inside other protected prefix",
+			cfg: &pb.ClusterConfig{ProtectedPrefixes: []*pb.ProtectedPrefix{
+				{Prefix: []byte("/state/"), Election: []byte("/elect")},
+				{Prefix: []byte("/elect/"), Election: []byte("/other")},
+			}},
+		},
+		{
+			name: "valid alarm thresholds",
+			cfg: &pb.ClusterConfig{AlarmThresholds: []*pb.AlarmThreshold{
+				{Metric: pb.AlarmThreshold_DB_SIZE_PERCENT, Value: 80, Block: []pb.AlarmThreshold_Operation{pb.AlarmThreshold_PUT}},
+				{Metric: pb.AlarmThreshold_WATCHERS, Value: 10000},
+			}},
+			valid: true,
+		},
+		{
+			name: "non-positive alarm threshold",
+			cfg:  &pb.ClusterConfig{AlarmThresholds: []*pb.AlarmThreshold{{Metric: pb.AlarmThreshold_LEASES}}},
+		},
+		{
+			name: "duplicated alarm threshold",
+			cfg: &pb.ClusterConfig{AlarmThresholds: []*pb.AlarmThreshold{
+				{Metric: pb.AlarmThreshold_LEASES, Value: 10},
+				{Metric: pb.AlarmThreshold_LEASES, Value: 20},
+			}},
+		},
+		{
+			name: "unknown alarm threshold metric",
+			cfg:  &pb.ClusterConfig{AlarmThresholds: []*pb.AlarmThreshold{{Metric: 9, Value: 10}}},
+		},
+		{
+			name: "unknown blocked operation",
+			cfg:  &pb.ClusterConfig{AlarmThresholds: []*pb.AlarmThreshold{{Metric: pb.AlarmThreshold_LEASES, Value: 10, Block: []pb.AlarmThreshold_Operation{9}}}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.cfg)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidConfig)
+			}
+		})
+	}
+}
+
+func TestBlocked(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	s := NewStore(be)
+	s.Set(&pb.ClusterConfig{AlarmThresholds: []*pb.AlarmThreshold{
+		{Metric: pb.AlarmThreshold_DB_SIZE_PERCENT, Value: 80, Block: []pb.AlarmThreshold_Operation{pb.AlarmThreshold_PUT, pb.AlarmThreshold_LEASE_GRANT}},
+		{Metric: pb.AlarmThreshold_WATCHERS, Value: 10000, Block: []pb.AlarmThreshold_Operation{pb.AlarmThreshold_WATCH}},
+		{Metric: pb.AlarmThreshold_LEASES, Value: 1000},
+	}})
+
+	assert.Empty(t, s.Blocked(nil))
+	assert.Empty(t, s.Blocked([]*pb.AlarmMember{{MemberID: 1, Alarm: pb.AlarmType_THRESHOLD, Metrics: []pb.AlarmThreshold_Metric{pb.AlarmThreshold_LEASES}}}))
+	assert.Equal(t, map[pb.AlarmThreshold_Operation]bool{
+		pb.AlarmThreshold_PUT:         true,
+		pb.AlarmThreshold_LEASE_GRANT: true,
+		pb.AlarmThreshold_WATCH:       true,
+	}, s.Blocked([]*pb.AlarmMember{
+		{MemberID: 1, Alarm: pb.AlarmType_THRESHOLD, Metrics: []pb.AlarmThreshold_Metric{pb.AlarmThreshold_DB_SIZE_PERCENT}},
+		{MemberID: 2, Alarm: pb.AlarmType_THRESHOLD, Metrics: []pb.AlarmThreshold_Metric{pb.AlarmThreshold_WATCHERS, pb.AlarmThreshold_LEASES}},
+	}))
+}
@@ -21,18 +21,23 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	errorspkg "errors"
 	"fmt"
 	"net/http"
+	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/config"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/raft/v3"
 )
 
@@ -46,17 +51,40 @@ const (
 	checkTypeHealth            = "health"
 )
 
+// Probes run by /health and /readyz, enabled by ServerConfig.HealthChecks.
+const (
+	// HealthCheckSerializableRead checks if local read is ok.
+	HealthCheckSerializableRead = "serializable_read"
+	// HealthCheckLinearizableRead checks if there is consensus in the cluster.
+	HealthCheckLinearizableRead = "linearizable_read"
+	// HealthCheckLeaseGrant checks if the cluster commits writes, by
+	// granting and revoking a lease.
+	HealthCheckLeaseGrant = "lease_grant"
+	// HealthCheckDiskFsync checks if the local disk syncs a write to a
+	// file in the member directory.
+	HealthCheckDiskFsync = "disk_fsync"
+)
+
+var (
+	// HealthChecks are the probes that can be enabled.
+	HealthChecks = []string{HealthCheckSerializableRead, HealthCheckLinearizableRead, HealthCheckLeaseGrant, HealthCheckDiskFsync}
+	// DefaultHealthChecks are the probes enabled if none is configured.
+	DefaultHealthChecks = []string{HealthCheckSerializableRead, HealthCheckLinearizableRead}
+)
+
 type ServerHealth interface {
 	Alarms() []*pb.AlarmMember
 	Leader() types.ID
 	Range(context.Context, *pb.RangeRequest) (*pb.RangeResponse, error)
+	LeaseGrant(context.Context, *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error)
+	LeaseRevoke(context.Context, *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error)
 	Config() config.ServerConfig
 	AuthStore() auth.AuthStore
 	IsLearner() bool
 }
 
-// HandleHealth registers metrics and health handlers. it checks health by using v3 range request
-// and its corresponding timeout.
+// HandleHealth registers metrics and health handlers. it checks health by using the enabled
+// probes and their corresponding timeouts.
 func HandleHealth(lg *zap.Logger, mux *http.ServeMux, srv ServerHealth) {
 	mux.Handle(PathHealth, NewHealthHandler(lg, func(ctx context.Context, excludedAlarms StringSet, serializable bool) Health {
 		if h := checkAlarms(lg, srv, excludedAlarms); h.Health != "true" {
@@ -65,7 +93,7 @@ func HandleHealth(lg *zap.Logger, mux *http.ServeMux, srv ServerHealth) {
 		if h := checkLeader(lg, srv, serializable); h.Health != "true" {
 			return h
 		}
-		return checkAPI(ctx, lg, srv, serializable)
+		return checkProbes(ctx, lg, srv, serializable)
 	}))
 
 	installLivezEndpoints(lg, mux, srv)
@@ -88,6 +116,10 @@ func NewHealthHandler(lg *zap.Logger, hfunc func(ctx context.Context, excludedAl
 		// the etcd process vs readiness of the cluster to serve requests.
 		serializableFlag := getSerializableFlag(r)
 		h := hfunc(r.Context(), excludedAlarms, serializableFlag)
+		// Only lists the individual probes for verbose requests.
+		if _, found := r.URL.Query()["verbose"]; !found {
+			h.Checks = nil
+		}
 		defer func() {
 			if h.Health == "true" {
 				healthSuccess.Inc()
@@ -152,6 +184,8 @@ func init() {
 type Health struct {
 	Health string `json:"health"`
 	Reason string `json:"reason"`
+	// Checks lists the outcome of each probe for verbose requests.
+	Checks []string `json:"checks,omitempty"`
 }
 
 // HealthStatus is used in new /readyz or /livez health checks instead of the Health struct.
@@ -196,6 +230,10 @@ func checkAlarms(lg *zap.Logger, srv ServerHealth, excludedAlarms StringSet) Hea
 			h.Reason = "ALARM NOSPACE"
 		case pb.AlarmType_CORRUPT:
 			h.Reason = "ALARM CORRUPT"
+		case pb.AlarmType_WALNOSPACE:
+			h.Reason = "ALARM WALNOSPACE"
+		case pb.AlarmType_THRESHOLD:
+			h.Reason = "ALARM THRESHOLD"
 		default:
 			h.Reason = "ALARM UNKNOWN"
 		}
@@ -216,20 +254,56 @@ func checkLeader(lg *zap.Logger, srv ServerHealth, serializable bool) Health {
 	return h
 }
 
-func checkAPI(ctx context.Context, lg *zap.Logger, srv ServerHealth, serializable bool) Health {
+// checkProbes runs the enabled probes for /health. Serializable requests
+// only run the probes served locally.
+func checkProbes(ctx context.Context, lg *zap.Logger, srv ServerHealth, serializable bool) Health {
 	h := Health{Health: "true"}
 	cfg := srv.Config()
 	ctx = srv.AuthStore().WithRoot(ctx)
-	cctx, cancel := context.WithTimeout(ctx, cfg.ReqTimeout())
-	_, err := srv.Range(cctx, &pb.RangeRequest{KeysOnly: true, Limit: 1, Serializable: serializable})
-	cancel()
-	if err != nil {
-		h.Health = "false"
-		h.Reason = fmt.Sprintf("RANGE ERROR:%s", err)
-		lg.Warn("serving /health false; Range fails", zap.Error(err))
-		return h
+	for _, name := range enabledHealthChecks(cfg) {
+		var reason string
+		switch name {
+		case HealthCheckSerializableRead:
+			if !serializable {
+				continue
+			}
+			reason = "RANGE ERROR"
+		case HealthCheckLinearizableRead:
+			if serializable {
+				continue
+			}
+			reason = "RANGE ERROR"
+		case HealthCheckLeaseGrant:
+			if serializable {
+				continue
+			}
+			reason = "LEASE GRANT ERROR"
+		case HealthCheckDiskFsync:
+			reason = "DISK FSYNC ERROR"
+		default:
+			continue
+		}
+		timeout := cfg.HealthCheckTimeouts[name]
+		if timeout <= 0 {
+			timeout = cfg.ReqTimeout()
+		}
+		cctx, cancel := context.WithTimeout(ctx, timeout)
+		err := newProbe(srv, name)(cctx)
+		cancel()
+		if err != nil {
+			h.Checks = append(h.Checks, fmt.Sprintf("[-]%s failed: %v", name, err))
+			if h.Health == "true" {
+				h.Health = "false"
+				h.Reason = fmt.Sprintf("%s:%s", reason, err)
+			}
+			lg.Warn("serving /health false; probe fails", zap.String("check", name), zap.Error(err))
+			continue
+		}
+		h.Checks = append(h.Checks, fmt.Sprintf("[+]%s ok", name))
+	}
+	if h.Health == "true" {
+		lg.Debug("serving /health true")
 	}
-	lg.Debug("serving /health true")
 	return h
 }
 
@@ -242,19 +316,26 @@ type CheckRegistry struct {
 
 func installLivezEndpoints(lg *zap.Logger, mux *http.ServeMux, server ServerHealth) {
 	reg := CheckRegistry{checkType: checkTypeLivez, checks: make(map[string]HealthCheck)}
-	reg.Register("serializable_read", readCheck(server, true /* serializable */))
+	reg.Register("serializable_read", liveReadCheck(server))
 	reg.InstallHTTPEndpoints(lg, mux)
 }
 
 func installReadyzEndpoints(lg *zap.Logger, mux *http.ServeMux, server ServerHealth) {
 	reg := CheckRegistry{checkType: checkTypeReadyz, checks: make(map[string]HealthCheck)}
 	reg.Register("data_corruption", activeAlarmCheck(server, pb.AlarmType_CORRUPT))
-	// serializable_read checks if local read is ok.
-	// linearizable_read checks if there is consensus in the cluster.
 	// Having both serializable_read and linearizable_read helps isolate the cause of problems if there is a read failure.
-	reg.Register("serializable_read", readCheck(server, true))
 	// linearizable_read check would be replaced by read_index check in 3.6
-	reg.Register("linearizable_read", readCheck(server, false))
+	cfg := server.Config()
+	for _, name := range enabledHealthChecks(cfg) {
+		check := newProbe(server, name)
+		if check == nil {
+			continue
+		}
+		if timeout := cfg.HealthCheckTimeouts[name]; timeout > 0 {
+			check = withTimeout(check, timeout)
+		}
+		reg.Register(name, check)
+	}
 	// check if local is learner
 	reg.Register("non_learner", learnerCheck(server))
 	reg.InstallHTTPEndpoints(lg, mux)
@@ -437,6 +518,94 @@ func readCheck(srv ServerHealth, serializable bool) func(ctx context.Context) er
 	}
 }
 
+// liveReadCheck is the serializable read check of /livez. A member catching
+// up with the leader before serving serializable reads is alive.
+func liveReadCheck(srv ServerHealth) func(ctx context.Context) error {
+	check := readCheck(srv, true)
+	return func(ctx context.Context) error {
+		if err := check(ctx); err != nil && !errorspkg.Is(err, errors.ErrCatchingUp) {
+			return err
+		}
+		return nil
+	}
+}
+
+// enabledHealthChecks returns the probes enabled by cfg.
+func enabledHealthChecks(cfg config.ServerConfig) []string {
+	if len(cfg.HealthChecks) == 0 {
+		return DefaultHealthChecks
+	}
+	return cfg.HealthChecks
+}
+
+// newProbe returns the probe with the given name, or nil if there is none.
+func newProbe(srv ServerHealth, name string) HealthCheck {
+	switch name {
+	case HealthCheckSerializableRead:
+		return readCheck(srv, true)
+	case HealthCheckLinearizableRead:
+		return readCheck(srv, false)
+	case HealthCheckLeaseGrant:
+		return leaseGrantCheck(srv)
+	case HealthCheckDiskFsync:
+		return diskFsyncCheck(srv)
+	default:
+		return nil
+	}
+}
+
+func withTimeout(check HealthCheck, timeout time.Duration) HealthCheck {
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return check(ctx)
+	}
+}
+
+func leaseGrantCheck(srv ServerHealth) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		resp, err := srv.LeaseGrant(ctx, &pb.LeaseGrantRequest{TTL: 1})
+		if err != nil {
+			return err
+		}
+		// the lease expires on its own if the revoke fails
+		_, err = srv.LeaseRevoke(ctx, &pb.LeaseRevokeRequest{ID: resp.ID})
+		return err
+	}
+}
+
+func diskFsyncCheck(srv ServerHealth) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		cfg := srv.Config()
+		errc := make(chan error, 1)
+		go func() {
+			errc <- syncProbeFile(cfg.MemberDir())
+		}()
+		select {
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// syncProbeFile writes and syncs a temporary file in dir.
+func syncProbeFile(dir string) error {
+	f, err := os.CreateTemp(dir, ".health-fsync-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write([]byte{0}); err == nil {
+		err = fileutil.Fdatasync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
 func learnerCheck(srv ServerHealth) func(ctx context.Context) error {
 	return func(ctx context.Context) error {
 		if srv.IsLearner() {
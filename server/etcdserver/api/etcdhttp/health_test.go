@@ -20,10 +20,15 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
@@ -43,6 +48,9 @@ type fakeHealthServer struct {
 	missingLeader         bool
 	authStore             auth.AuthStore
 	isLearner             bool
+	leaseGrantError       error
+	leaseGrantBlocks      bool
+	cfg                   config.ServerConfig
 }
 
 func (s *fakeHealthServer) Range(_ context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
@@ -52,12 +60,27 @@ func (s *fakeHealthServer) Range(_ context.Context, req *pb.RangeRequest) (*pb.R
 	return nil, s.linearizableReadError
 }
 
+func (s *fakeHealthServer) LeaseGrant(ctx context.Context, req *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	if s.leaseGrantBlocks {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if s.leaseGrantError != nil {
+		return nil, s.leaseGrantError
+	}
+	return &pb.LeaseGrantResponse{ID: 1, TTL: req.TTL}, nil
+}
+
+func (s *fakeHealthServer) LeaseRevoke(context.Context, *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	return &pb.LeaseRevokeResponse{}, nil
+}
+
 func (s *fakeHealthServer) IsLearner() bool {
 	return s.isLearner
 }
 
 func (s *fakeHealthServer) Config() config.ServerConfig {
-	return config.ServerConfig{}
+	return s.cfg
 }
 
 func (s *fakeHealthServer) Leader() types.ID {
@@ -130,6 +153,18 @@ func TestHealthHandler(t *testing.T) {
 			healthCheckURL:   "/health?exclude=NOSPACE&exclude=CORRUPT",
 			expectStatusCode: http.StatusOK,
 		},
+		{
+			name:             "Unhealthy if WALNOSPACE alarm is on",
+			alarms:           []*pb.AlarmMember{{MemberID: uint64(0), Alarm: pb.AlarmType_WALNOSPACE}},
+			healthCheckURL:   "/health?exclude=NOSPACE",
+			expectStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:             "Healthy if only excluded THRESHOLD alarm is on",
+			alarms:           []*pb.AlarmMember{{MemberID: uint64(0), Alarm: pb.AlarmType_THRESHOLD}},
+			healthCheckURL:   "/health?exclude=THRESHOLD",
+			expectStatusCode: http.StatusOK,
+		},
 		{
 			name:             "Unhealthy if api is not available",
 			healthCheckURL:   "/health",
@@ -390,6 +425,127 @@ func TestLearnerReadyCheck(t *testing.T) {
 	}
 }
 
+func TestConfiguredHealthChecks(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+	dataDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dataDir, "member"), 0o700))
+	tests := []struct {
+		healthTestCase
+		cfg              config.ServerConfig
+		leaseGrantError  error
+		leaseGrantBlocks bool
+	}{
+		{
+			healthTestCase: healthTestCase{
+				name:             "disabled check is not run",
+				healthCheckURL:   "/readyz?verbose",
+				apiError:         fmt.Errorf("Unexpected error"),
+				expectStatusCode: http.StatusOK,
+				notInResult:      []string{"linearizable_read"},
+			},
+			cfg: config.ServerConfig{HealthChecks: []string{HealthCheckSerializableRead}},
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "disabled check has no sub path",
+				healthCheckURL:   "/readyz/linearizable_read",
+				expectStatusCode: http.StatusNotFound,
+			},
+			cfg: config.ServerConfig{HealthChecks: []string{HealthCheckSerializableRead}},
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "Not ready if lease grant fails",
+				healthCheckURL:   "/readyz",
+				expectStatusCode: http.StatusServiceUnavailable,
+				inResult:         []string{"[+]linearizable_read ok", "[-]lease_grant failed: Unexpected error"},
+			},
+			cfg:             config.ServerConfig{HealthChecks: []string{HealthCheckLinearizableRead, HealthCheckLeaseGrant}},
+			leaseGrantError: fmt.Errorf("Unexpected error"),
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "Unhealthy if lease grant fails",
+				healthCheckURL:   "/health?verbose",
+				expectStatusCode: http.StatusServiceUnavailable,
+				inResult:         []string{`"reason":"LEASE GRANT ERROR:Unexpected error"`, "[+]linearizable_read ok", "[-]lease_grant failed: Unexpected error"},
+			},
+			cfg:             config.ServerConfig{HealthChecks: []string{HealthCheckLinearizableRead, HealthCheckLeaseGrant}},
+			leaseGrantError: fmt.Errorf("Unexpected error"),
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "Healthy if lease grant fails and serializable=true",
+				healthCheckURL:   "/health?serializable=true&verbose",
+				expectStatusCode: http.StatusOK,
+				notInResult:      []string{"lease_grant"},
+			},
+			cfg:             config.ServerConfig{HealthChecks: []string{HealthCheckSerializableRead, HealthCheckLeaseGrant}},
+			leaseGrantError: fmt.Errorf("Unexpected error"),
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "Checks are only listed for verbose requests",
+				healthCheckURL:   "/health",
+				expectStatusCode: http.StatusOK,
+				notInResult:      []string{"checks"},
+			},
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "Not ready if lease grant times out",
+				healthCheckURL:   "/readyz",
+				expectStatusCode: http.StatusServiceUnavailable,
+				inResult:         []string{"[-]lease_grant failed: context deadline exceeded"},
+			},
+			cfg: config.ServerConfig{
+				HealthChecks:        []string{HealthCheckLeaseGrant},
+				HealthCheckTimeouts: map[string]time.Duration{HealthCheckLeaseGrant: 10 * time.Millisecond},
+			},
+			leaseGrantBlocks: true,
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "Ready if disk syncs",
+				healthCheckURL:   "/readyz?verbose",
+				expectStatusCode: http.StatusOK,
+				inResult:         []string{"[+]disk_fsync ok"},
+			},
+			cfg: config.ServerConfig{DataDir: dataDir, HealthChecks: []string{HealthCheckDiskFsync}},
+		},
+		{
+			healthTestCase: healthTestCase{
+				name:             "Not ready if disk fails",
+				healthCheckURL:   "/readyz",
+				expectStatusCode: http.StatusServiceUnavailable,
+				inResult:         []string{"[-]disk_fsync failed"},
+			},
+			cfg: config.ServerConfig{DataDir: filepath.Join(dataDir, "missing"), HealthChecks: []string{HealthCheckDiskFsync}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			logger := zaptest.NewLogger(t)
+			s := &fakeHealthServer{
+				linearizableReadError: tt.apiError,
+				authStore:             auth.NewAuthStore(logger, schema.NewAuthBackend(logger, be), nil, 0),
+				leaseGrantError:       tt.leaseGrantError,
+				leaseGrantBlocks:      tt.leaseGrantBlocks,
+				cfg:                   tt.cfg,
+			}
+			HandleHealth(logger, mux, s)
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+			checkHTTPResponse(t, ts, tt.healthCheckURL, tt.expectStatusCode, tt.inResult, tt.notInResult)
+		})
+	}
+	entries, err := os.ReadDir(filepath.Join(dataDir, "member"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
 func checkHTTPResponse(t *testing.T, ts *httptest.Server, url string, expectStatusCode int, inResult []string, notInResult []string) {
 	res, err := ts.Client().Do(&http.Request{Method: http.MethodGet, URL: testutil.MustNewURL(t, ts.URL+url)})
 	if err != nil {
@@ -40,7 +40,7 @@ const (
 
 // NewPeerHandler generates an http.Handler to handle etcd peer requests.
 func NewPeerHandler(lg *zap.Logger, s etcdserver.ServerPeerV2) http.Handler {
-	return newPeerHandler(lg, s, s.RaftHandler(), s.LeaseHandler(), s.HashKVHandler(), s.DowngradeEnabledHandler())
+	return newPeerHandler(lg, s, s.RaftHandler(), s.LeaseHandler(), s.HashKVHandler(), s.DowngradeEnabledHandler(), s.BackendHandler())
 }
 
 func newPeerHandler(
@@ -50,6 +50,7 @@ func newPeerHandler(
 	leaseHandler http.Handler,
 	hashKVHandler http.Handler,
 	downgradeEnabledHandler http.Handler,
+	backendHandler http.Handler,
 ) http.Handler {
 	if lg == nil {
 		lg = zap.NewNop()
@@ -73,6 +74,9 @@ func newPeerHandler(
 	if hashKVHandler != nil {
 		mux.Handle(etcdserver.PeerHashKVPath, hashKVHandler)
 	}
+	if backendHandler != nil {
+		mux.Handle(etcdserver.PeerBackendPath, backendHandler)
+	}
 	mux.HandleFunc(versionPath, versionHandler(s, serveVersion))
 	return mux
 }
@@ -137,12 +141,22 @@ func (h *peerMemberPromoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	resp, err := h.server.PromoteMember(r.Context(), id)
+	var resp []*membership.Member
+	if replaceStr := r.URL.Query().Get("replace"); replaceStr != "" {
+		replaceID, perr := strconv.ParseUint(replaceStr, 10, 64)
+		if perr != nil {
+			http.Error(w, fmt.Sprintf("member %s not found in cluster", replaceStr), http.StatusNotFound)
+			return
+		}
+		resp, err = h.server.PromoteMemberReplacing(r.Context(), id, replaceID)
+	} else {
+		resp, err = h.server.PromoteMember(r.Context(), id)
+	}
 	if err != nil {
 		switch {
 		case errorspkg.Is(err, membership.ErrIDNotFound):
 			http.Error(w, err.Error(), http.StatusNotFound)
-		case errorspkg.Is(err, membership.ErrMemberNotLearner):
+		case errorspkg.Is(err, membership.ErrMemberNotLearner), errorspkg.Is(err, membership.ErrMemberNotVoter):
 			http.Error(w, err.Error(), http.StatusPreconditionFailed)
 		case errorspkg.Is(err, errors.ErrLearnerNotReady):
 			http.Error(w, err.Error(), http.StatusPreconditionFailed)
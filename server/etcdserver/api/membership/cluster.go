@@ -503,7 +503,7 @@ func (c *RaftCluster) UpdateAttributes(id types.ID, attr Attributes, shouldApply
 	)
 }
 
-// PromoteMember marks the member's IsLearner RaftAttributes to false.
+// PromoteMember marks the member's IsLearner and IsStandby RaftAttributes to false.
 func (c *RaftCluster) PromoteMember(id types.ID, shouldApplyV3 ShouldApplyV3) {
 	c.Lock()
 	defer c.Unlock()
@@ -512,6 +512,7 @@ func (c *RaftCluster) PromoteMember(id types.ID, shouldApplyV3 ShouldApplyV3) {
 	if _, ok := membersMap[id]; ok {
 		m := *(membersMap[id])
 		m.RaftAttributes.IsLearner = false
+		m.RaftAttributes.IsStandby = false
 		mustUpdateMemberInStore(c.lg, c.v2store, &m)
 	} else {
 		c.lg.Info("Skipped promoting non-existent member in v2store",
@@ -527,6 +528,7 @@ func (c *RaftCluster) PromoteMember(id types.ID, shouldApplyV3 ShouldApplyV3) {
 
 	if shouldApplyV3 {
 		c.members[id].RaftAttributes.IsLearner = false
+		c.members[id].RaftAttributes.IsStandby = false
 		c.updateMembershipMetric(id, true)
 		c.be.MustSaveMemberToBackend(c.members[id])
 
@@ -808,6 +810,21 @@ func (c *RaftCluster) IsLocalMemberLearner() bool {
 	return localMember.IsLearner
 }
 
+// IsLocalMemberStandby returns if the local member is a warm standby
+func (c *RaftCluster) IsLocalMemberStandby() bool {
+	c.Lock()
+	defer c.Unlock()
+	localMember, ok := c.members[c.localID]
+	if !ok {
+		c.lg.Panic(
+			"failed to find local ID in cluster members",
+			zap.String("cluster-id", c.cid.String()),
+			zap.String("local-member-id", c.localID.String()),
+		)
+	}
+	return localMember.IsStandby
+}
+
 // DowngradeInfo returns the downgrade status of the cluster
 func (c *RaftCluster) DowngradeInfo() *serverversion.DowngradeInfo {
 	c.Lock()
@@ -1030,6 +1030,18 @@ func TestPromoteMember(t *testing.T) {
 				2: newTestMember(2, nil, "2", clientURLs),
 			},
 		},
+		{
+			name: "promote a standby",
+			members: []*Member{
+				newTestMember(1, nil, "1", clientURLs),
+				newTestMemberAsStandby(2, nil, "2", clientURLs),
+			},
+			promoteID: 2,
+			wantMembers: map[types.ID]*Member{
+				1: newTestMember(1, nil, "1", clientURLs),
+				2: newTestMember(2, nil, "2", clientURLs),
+			},
+		},
 		{
 			name: "promote a non-exist member",
 			members: []*Member{
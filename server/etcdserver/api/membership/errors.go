@@ -27,6 +27,8 @@ var (
 	ErrPeerURLexists    = errors.New("membership: peerURL exists")
 	ErrMemberNotLearner = errors.New("membership: can only promote a learner member")
 	ErrTooManyLearners  = errors.New("membership: too many learner members in cluster")
+	ErrMemberNotVoter   = errors.New("membership: can only replace a voting member")
+	ErrBadLabels        = errors.New("membership: invalid member labels")
 )
 
 func isKeyNotFound(err error) bool {
@@ -32,6 +32,21 @@ type RaftAttributes struct {
 	PeerURLs []string `json:"peerURLs"`
 	// IsLearner indicates if the member is raft learner.
 	IsLearner bool `json:"isLearner,omitempty"`
+	// IsStandby indicates if the member is a warm standby. A standby member is
+	// always a raft learner and never serves client traffic until promoted.
+	IsStandby bool `json:"isStandby,omitempty"`
+	// Labels are operator-defined labels of the member, such as its zone or
+	// rack. They are replicated through the same configuration changes as
+	// the peer URLs.
+	Labels map[string]string `json:"labels,omitempty"`
+	// LeadershipDisallowed indicates the member must not be the raft leader.
+	// A disallowed member that wins an election transfers its leadership to
+	// an eligible member.
+	LeadershipDisallowed bool `json:"leadershipDisallowed,omitempty"`
+	// Draining indicates the member is being drained ahead of a restart. A
+	// draining member gives up its leadership like a disallowed one and stops
+	// serving new leases and watches.
+	Draining bool `json:"draining,omitempty"`
 }
 
 // Attributes represents all the non-raft related attributes of an etcd member.
@@ -60,6 +75,15 @@ func NewMemberAsLearner(name string, peerURLs types.URLs, clusterName string, no
 	return newMember(name, peerURLs, memberID, true)
 }
 
+// NewMemberAsStandby creates a standby Member without an ID and generates one based on the
+// cluster name, peer URLs, and time. A standby member replicates as a learner but rejects
+// all client requests until it is promoted.
+func NewMemberAsStandby(name string, peerURLs types.URLs, clusterName string, now *time.Time) *Member {
+	m := NewMemberAsLearner(name, peerURLs, clusterName, now)
+	m.IsStandby = true
+	return m
+}
+
 func computeMemberID(peerURLs types.URLs, clusterName string, now *time.Time) types.ID {
 	peerURLstrs := peerURLs.StringSlice()
 	sort.Strings(peerURLstrs)
@@ -94,7 +118,10 @@ func (m *Member) Clone() *Member {
 	mm := &Member{
 		ID: m.ID,
 		RaftAttributes: RaftAttributes{
-			IsLearner: m.IsLearner,
+			IsLearner:            m.IsLearner,
+			IsStandby:            m.IsStandby,
+			LeadershipDisallowed: m.LeadershipDisallowed,
+			Draining:             m.Draining,
 		},
 		Attributes: Attributes{
 			Name: m.Name,
@@ -108,9 +135,46 @@ func (m *Member) Clone() *Member {
 		mm.ClientURLs = make([]string, len(m.ClientURLs))
 		copy(mm.ClientURLs, m.ClientURLs)
 	}
+	if m.Labels != nil {
+		mm.Labels = make(map[string]string, len(m.Labels))
+		for k, v := range m.Labels {
+			mm.Labels[k] = v
+		}
+	}
 	return mm
 }
 
+const (
+	maxMemberLabels          = 32
+	maxMemberLabelKeyBytes   = 63
+	maxMemberLabelValueBytes = 256
+)
+
+// ValidateLabels returns ErrBadLabels if the given member labels have too
+// many entries, or an empty or oversized key or value, or a key with
+// characters other than letters, digits, '-', '_', '.' and '/'.
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > maxMemberLabels {
+		return ErrBadLabels
+	}
+	for k, v := range labels {
+		if len(k) == 0 || len(k) > maxMemberLabelKeyBytes || len(v) > maxMemberLabelValueBytes {
+			return ErrBadLabels
+		}
+		for _, c := range k {
+			if !isLabelKeyChar(c) {
+				return ErrBadLabels
+			}
+		}
+	}
+	return nil
+}
+
+func isLabelKeyChar(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == '/'
+}
+
 func (m *Member) IsStarted() bool {
 	return len(m.Name) != 0
 }
@@ -15,8 +15,11 @@
 package membership
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -61,6 +64,10 @@ func TestMemberClone(t *testing.T) {
 		newTestMember(1, []string{"http://a"}, "abc", nil),
 		newTestMember(1, nil, "abc", []string{"http://b"}),
 		newTestMember(1, []string{"http://a"}, "abc", []string{"http://b"}),
+		newTestMemberAsStandby(1, []string{"http://a"}, "abc", []string{"http://b"}),
+		{ID: 1, RaftAttributes: RaftAttributes{PeerURLs: []string{"http://a"}, Labels: map[string]string{"zone": "a"}}},
+		{ID: 1, RaftAttributes: RaftAttributes{PeerURLs: []string{"http://a"}, LeadershipDisallowed: true}},
+		{ID: 1, RaftAttributes: RaftAttributes{PeerURLs: []string{"http://a"}, Draining: true}},
 	}
 	for i, tt := range tests {
 		nm := tt.Clone()
@@ -73,6 +80,34 @@ func TestMemberClone(t *testing.T) {
 	}
 }
 
+func TestValidateLabels(t *testing.T) {
+	tooMany := make(map[string]string)
+	for i := 0; i <= maxMemberLabels; i++ {
+		tooMany[fmt.Sprintf("label%d", i)] = "v"
+	}
+	tests := []struct {
+		labels map[string]string
+		valid  bool
+	}{
+		{nil, true},
+		{map[string]string{"zone": "us-east-1a", "topology.example.com/rack": "r1", "empty": ""}, true},
+		{map[string]string{"": "v"}, false},
+		{map[string]string{"zone name": "v"}, false},
+		{map[string]string{strings.Repeat("k", maxMemberLabelKeyBytes+1): "v"}, false},
+		{map[string]string{"zone": strings.Repeat("v", maxMemberLabelValueBytes+1)}, false},
+		{tooMany, false},
+	}
+	for i, tt := range tests {
+		err := ValidateLabels(tt.labels)
+		if tt.valid && err != nil {
+			t.Errorf("#%d: unexpected error %v", i, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrBadLabels) {
+			t.Errorf("#%d: error = %v, want %v", i, err, ErrBadLabels)
+		}
+	}
+}
+
 func newTestMember(id uint64, peerURLs []string, name string, clientURLs []string) *Member {
 	return &Member{
 		ID:             types.ID(id),
@@ -88,3 +123,11 @@ func newTestMemberAsLearner(id uint64, peerURLs []string, name string, clientURL
 		Attributes:     Attributes{Name: name, ClientURLs: clientURLs},
 	}
 }
+
+func newTestMemberAsStandby(id uint64, peerURLs []string, name string, clientURLs []string) *Member {
+	return &Member{
+		ID:             types.ID(id),
+		RaftAttributes: RaftAttributes{PeerURLs: peerURLs, IsLearner: true, IsStandby: true},
+		Attributes:     Attributes{Name: name, ClientURLs: clientURLs},
+	}
+}
@@ -16,6 +16,7 @@
 package v3alarm
 
 import (
+	"slices"
 	"sync"
 
 	"go.uber.org/zap"
@@ -63,6 +64,25 @@ func (a *AlarmStore) Activate(id types.ID, at pb.AlarmType) *pb.AlarmMember {
 	return newAlarm
 }
 
+// ActivateThreshold raises the THRESHOLD alarm of the member for the given
+// metrics, which replace the metrics of the alarm if it is already raised.
+func (a *AlarmStore) ActivateThreshold(id types.ID, metrics []pb.AlarmThreshold_Metric) *pb.AlarmMember {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	newAlarm := &pb.AlarmMember{MemberID: uint64(id), Alarm: pb.AlarmType_THRESHOLD, Metrics: metrics}
+	if m := a.addToMap(newAlarm); m != newAlarm {
+		if slices.Equal(m.Metrics, metrics) {
+			return m
+		}
+		a.be.MustDeleteAlarm(m)
+		a.types[pb.AlarmType_THRESHOLD][id] = newAlarm
+	}
+
+	a.be.MustPutAlarm(newAlarm)
+	return newAlarm
+}
+
 func (a *AlarmStore) Deactivate(id types.ID, at pb.AlarmType) *pb.AlarmMember {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -48,6 +48,9 @@ func New(s *etcdserver.EtcdServer) *clientv3.Client {
 	a := adapter.AuthServerToAuthClient(v3rpc.NewAuthServer(s))
 	c.Auth = clientv3.NewAuthFromAuthClient(a, c)
 
+	ctc := adapter.CounterServerToCounterClient(v3rpc.NewQuotaCounterServer(s))
+	c.Counter = clientv3.NewCounterFromCounterClient(ctc, c)
+
 	return c
 }
 
@@ -0,0 +1,89 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3compactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+const (
+	// DefaultCoordinationTimeout is how long coordinated compaction waits for
+	// the members to catch up before compacting anyway.
+	DefaultCoordinationTimeout = time.Minute
+	// coordinationMaxLag is the number of raft entries a member may be behind
+	// the leader while still considered caught up.
+	coordinationMaxLag = 16
+	// coordinationPollInterval is how often coordinated compaction checks
+	// whether the members caught up.
+	coordinationPollInterval = 100 * time.Millisecond
+)
+
+// ReplicationLagGetter reports how far the members are behind the leader.
+type ReplicationLagGetter interface {
+	// MaxReplicationLag returns the largest number of raft entries an active
+	// voting member is behind the leader.
+	MaxReplicationLag() uint64
+}
+
+// NewCoordinatedCompactable returns a Compactable that delays compaction
+// requests until every active voting member has caught up with the leader.
+// Since a member compacts when it applies the compaction request, the members
+// then start answering ErrCompacted for the compacted revisions at roughly the
+// same time, rather than a lagging member keeping serving the compacted
+// revisions to serializable reads. The request is proposed anyway once
+// timeout elapses, so that a slow member cannot block compaction.
+func NewCoordinatedCompactable(lg *zap.Logger, c Compactable, rl ReplicationLagGetter, timeout time.Duration) Compactable {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	if timeout <= 0 {
+		timeout = DefaultCoordinationTimeout
+	}
+	return &coordinatedCompactable{lg: lg, clock: clockwork.NewRealClock(), c: c, rl: rl, timeout: timeout}
+}
+
+type coordinatedCompactable struct {
+	lg      *zap.Logger
+	clock   clockwork.Clock
+	c       Compactable
+	rl      ReplicationLagGetter
+	timeout time.Duration
+}
+
+func (cc *coordinatedCompactable) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error) {
+	deadline := cc.clock.After(cc.timeout)
+	for lag := cc.rl.MaxReplicationLag(); lag > coordinationMaxLag; lag = cc.rl.MaxReplicationLag() {
+		select {
+		case <-cc.clock.After(coordinationPollInterval):
+		case <-deadline:
+			cc.lg.Warn(
+				"members did not catch up before coordinated compaction; compacting anyway",
+				zap.Int64("revision", r.Revision),
+				zap.Uint64("replication-lag", lag),
+				zap.Duration("timeout", cc.timeout),
+			)
+			return cc.c.Compact(ctx, r)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return cc.c.Compact(ctx, r)
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3compactor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/testutil"
+)
+
+type fakeReplicationLagGetter struct {
+	lag atomic.Uint64
+}
+
+func (g *fakeReplicationLagGetter) MaxReplicationLag() uint64 { return g.lag.Load() }
+
+func TestCoordinatedCompactable(t *testing.T) {
+	fc := &fakeCompactable{&testutil.RecorderBuffered{}}
+	rl := &fakeReplicationLagGetter{}
+	clock := clockwork.NewFakeClock()
+	cc := &coordinatedCompactable{lg: zaptest.NewLogger(t), clock: clock, c: fc, rl: rl, timeout: time.Minute}
+
+	// caught up members do not delay compaction
+	rl.lag.Store(coordinationMaxLag)
+	_, err := cc.Compact(t.Context(), &pb.CompactionRequest{Revision: 10})
+	require.NoError(t, err)
+	require.Len(t, fc.Action(), 1)
+
+	// lagging members delay compaction until they catch up
+	rl.lag.Store(coordinationMaxLag + 1)
+	done := make(chan error, 1)
+	go func() {
+		_, err := cc.Compact(t.Context(), &pb.CompactionRequest{Revision: 20})
+		done <- err
+	}()
+	require.NoError(t, clock.BlockUntilContext(t.Context(), 2))
+	clock.Advance(coordinationPollInterval)
+	require.NoError(t, clock.BlockUntilContext(t.Context(), 2))
+	assert.Len(t, fc.Action(), 1)
+	rl.lag.Store(0)
+	clock.Advance(coordinationPollInterval)
+	require.NoError(t, <-done)
+	acts := fc.Action()
+	require.Len(t, acts, 2)
+	assert.Equal(t, int64(20), acts[1].Params[0].(*pb.CompactionRequest).Revision)
+}
+
+func TestCoordinatedCompactableTimeout(t *testing.T) {
+	fc := &fakeCompactable{&testutil.RecorderBuffered{}}
+	rl := &fakeReplicationLagGetter{}
+	rl.lag.Store(1000)
+	clock := clockwork.NewFakeClock()
+	cc := &coordinatedCompactable{lg: zaptest.NewLogger(t), clock: clock, c: fc, rl: rl, timeout: time.Minute}
+
+	// a member that does not catch up does not block compaction
+	done := make(chan error, 1)
+	go func() {
+		_, err := cc.Compact(t.Context(), &pb.CompactionRequest{Revision: 10})
+		done <- err
+	}()
+	require.NoError(t, clock.BlockUntilContext(t.Context(), 2))
+	clock.Advance(time.Minute)
+	require.NoError(t, <-done)
+	require.Len(t, fc.Action(), 1)
+
+	// canceling the context aborts the wait
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		_, err := cc.Compact(ctx, &pb.CompactionRequest{Revision: 20})
+		done <- err
+	}()
+	require.NoError(t, clock.BlockUntilContext(t.Context(), 2))
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	assert.Len(t, fc.Action(), 1)
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3compactor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var (
+	ErrHoldNotFound  = errors.New("etcdserver: compaction hold not found")
+	ErrHoldsDisabled = errors.New("etcdserver: compaction holds are disabled")
+)
+
+// HoldStore keeps the compaction holds registered by clients. A hold asks
+// auto-compaction not to compact past a revision until the hold expires.
+// Holds are replicated through raft and persisted in the backend; they
+// expire relative to the grant time set by the proposing member.
+type HoldStore struct {
+	mu    sync.RWMutex
+	be    backend.Backend
+	holds map[int64]*pb.InternalCompactionHoldGrantRequest
+}
+
+func NewHoldStore(be backend.Backend) *HoldStore {
+	hs := &HoldStore{}
+	hs.Recover(be)
+	return hs
+}
+
+// Recover reloads the holds from the given backend, which replaces the
+// previous one.
+func (hs *HoldStore) Recover(be backend.Backend) {
+	tx := be.ReadTx()
+	tx.RLock()
+	holds := schema.MustUnsafeGetAllCompactionHolds(tx)
+	tx.RUnlock()
+
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	hs.be = be
+	hs.holds = make(map[int64]*pb.InternalCompactionHoldGrantRequest, len(holds))
+	for _, h := range holds {
+		hs.holds[h.ID] = h
+	}
+}
+
+// Grant stores the hold, replacing the hold of the same ID. The name of the
+// replaced hold is kept if h has none. Holds expired at the grant time of h
+// are removed.
+func (hs *HoldStore) Grant(h *pb.InternalCompactionHoldGrantRequest) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	if old, ok := hs.holds[h.ID]; ok && h.Name == "" {
+		h.Name = old.Name
+	}
+	tx := hs.be.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	now := time.Unix(0, h.GrantTime)
+	for id, old := range hs.holds {
+		if id != h.ID && holdExpired(old, now) {
+			schema.UnsafeDeleteCompactionHold(tx, id)
+			delete(hs.holds, id)
+		}
+	}
+	schema.MustUnsafePutCompactionHold(tx, h)
+	hs.holds[h.ID] = h
+}
+
+// Revoke removes the hold of the given ID.
+func (hs *HoldStore) Revoke(id int64) error {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	if _, ok := hs.holds[id]; !ok {
+		return ErrHoldNotFound
+	}
+	tx := hs.be.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	schema.UnsafeDeleteCompactionHold(tx, id)
+	delete(hs.holds, id)
+	return nil
+}
+
+// Holds returns the holds that have not expired at now, ordered by ID.
+func (hs *HoldStore) Holds(now time.Time) []*pb.CompactionHold {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	var ret []*pb.CompactionHold
+	for _, h := range hs.holds {
+		if holdExpired(h, now) {
+			continue
+		}
+		ret = append(ret, &pb.CompactionHold{
+			ID:       h.ID,
+			Name:     h.Name,
+			Revision: h.Revision,
+			TTL:      int64(holdExpiry(h).Sub(now).Round(time.Second) / time.Second),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID < ret[j].ID })
+	return ret
+}
+
+// HeldRevision returns the lowest revision held by a hold that has not
+// expired at now, or 0 if there is none.
+func (hs *HoldStore) HeldRevision(now time.Time) int64 {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	held := int64(0)
+	for _, h := range hs.holds {
+		if !holdExpired(h, now) && (held == 0 || h.Revision < held) {
+			held = h.Revision
+		}
+	}
+	return held
+}
+
+func holdExpiry(h *pb.InternalCompactionHoldGrantRequest) time.Time {
+	return time.Unix(0, h.GrantTime).Add(time.Duration(h.TTL) * time.Second)
+}
+
+func holdExpired(h *pb.InternalCompactionHoldGrantRequest, now time.Time) bool {
+	return !now.Before(holdExpiry(h))
+}
+
+// HeldRevisionGetter returns the lowest revision held back from compaction
+// at the given time, or 0 if there is none.
+type HeldRevisionGetter interface {
+	HeldRevision(now time.Time) int64
+}
+
+// NewHoldingCompactable returns a Compactable that lowers the revision of
+// compaction requests to the revision held by hg, so that auto-compaction
+// does not compact past the revisions clients still need.
+func NewHoldingCompactable(lg *zap.Logger, c Compactable, hg HeldRevisionGetter) Compactable {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	return &holdingCompactable{lg: lg, clock: clockwork.NewRealClock(), c: c, hg: hg}
+}
+
+type holdingCompactable struct {
+	lg    *zap.Logger
+	clock clockwork.Clock
+	c     Compactable
+	hg    HeldRevisionGetter
+}
+
+func (hc *holdingCompactable) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error) {
+	if held := hc.hg.HeldRevision(hc.clock.Now()); held > 0 && held < r.Revision {
+		hc.lg.Info(
+			"limiting auto compaction to held revision",
+			zap.Int64("revision", r.Revision),
+			zap.Int64("held-revision", held),
+		)
+		r = &pb.CompactionRequest{Revision: held, Physical: r.Physical}
+	}
+	return hc.c.Compact(ctx, r)
+}
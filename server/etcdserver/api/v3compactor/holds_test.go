@@ -0,0 +1,102 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3compactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/testutil"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func TestHoldStore(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	// the bucket is only created by the first hold
+	tx := be.ReadTx()
+	tx.RLock()
+	assert.Empty(t, schema.MustUnsafeGetAllCompactionHolds(tx))
+	tx.RUnlock()
+
+	now := time.Unix(1000, 0)
+	hs := NewHoldStore(be)
+	assert.Zero(t, hs.HeldRevision(now))
+
+	hs.Grant(&pb.InternalCompactionHoldGrantRequest{ID: 1, Name: "a", Revision: 10, TTL: 60, GrantTime: now.UnixNano()})
+	hs.Grant(&pb.InternalCompactionHoldGrantRequest{ID: 2, Name: "b", Revision: 5, TTL: 10, GrantTime: now.UnixNano()})
+	assert.Equal(t, int64(5), hs.HeldRevision(now))
+	assert.Equal(t, []*pb.CompactionHold{
+		{ID: 1, Name: "a", Revision: 10, TTL: 60},
+		{ID: 2, Name: "b", Revision: 5, TTL: 10},
+	}, hs.Holds(now))
+
+	// expired holds are ignored
+	later := now.Add(10 * time.Second)
+	assert.Equal(t, int64(10), hs.HeldRevision(later))
+	assert.Len(t, hs.Holds(later), 1)
+
+	// renewing keeps the name and removes the expired holds
+	hs.Grant(&pb.InternalCompactionHoldGrantRequest{ID: 1, Revision: 20, TTL: 60, GrantTime: later.UnixNano()})
+	assert.Equal(t, []*pb.CompactionHold{{ID: 1, Name: "a", Revision: 20, TTL: 60}}, hs.Holds(later))
+
+	// holds survive a reload from the backend
+	be.ForceCommit()
+	hs = NewHoldStore(be)
+	assert.Equal(t, int64(20), hs.HeldRevision(later))
+
+	require.NoError(t, hs.Revoke(1))
+	require.ErrorIs(t, hs.Revoke(1), ErrHoldNotFound)
+	assert.Zero(t, hs.HeldRevision(later))
+	hs.Recover(be)
+	assert.Empty(t, hs.Holds(later))
+}
+
+type fakeHeldRevisionGetter int64
+
+func (g fakeHeldRevisionGetter) HeldRevision(time.Time) int64 { return int64(g) }
+
+func TestHoldingCompactable(t *testing.T) {
+	tests := []struct {
+		name    string
+		held    int64
+		rev     int64
+		wantRev int64
+	}{
+		{name: "no hold", rev: 100, wantRev: 100},
+		{name: "hold below revision", held: 40, rev: 100, wantRev: 40},
+		{name: "hold above revision", held: 200, rev: 100, wantRev: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := &fakeCompactable{&testutil.RecorderBuffered{}}
+			hc := &holdingCompactable{lg: zaptest.NewLogger(t), clock: clockwork.NewFakeClock(), c: fc, hg: fakeHeldRevisionGetter(tt.held)}
+			_, err := hc.Compact(context.Background(), &pb.CompactionRequest{Revision: tt.rev})
+			require.NoError(t, err)
+			acts := fc.Action()
+			require.Len(t, acts, 1)
+			assert.Equal(t, tt.wantRev, acts[0].Params[0].(*pb.CompactionRequest).Revision)
+		})
+	}
+}
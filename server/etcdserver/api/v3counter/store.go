@@ -0,0 +1,117 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package v3counter implements the counters of the Counter service.
+package v3counter
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var ErrOverflow = errors.New("etcdserver: counter overflow")
+
+// Store keeps the counters in their own backend bucket rather than in the
+// MVCC key space. An increment overwrites the value of the counter in place,
+// so high rate counters do not create a revision per increment and leave no
+// history to compact. Counters reaching 0 are removed.
+type Store struct {
+	mu sync.RWMutex
+	be backend.Backend
+}
+
+func NewStore(be backend.Backend) *Store {
+	return &Store{be: be}
+}
+
+// Recover switches the store to the given backend, which replaces the
+// previous one.
+func (s *Store) Recover(be backend.Backend) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.be = be
+}
+
+// Add applies the deltas in order, all or none, and returns the value of
+// each counter after its delta.
+func (s *Store) Add(deltas []*pb.CounterDelta) ([]*pb.CounterValue, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tx := s.be.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	if len(deltas) == 0 {
+		return nil, nil
+	}
+	schema.UnsafeCreateCounterBucket(tx)
+
+	values := make(map[string]int64, len(deltas))
+	ret := make([]*pb.CounterValue, len(deltas))
+	for i, d := range deltas {
+		v, ok := values[string(d.Key)]
+		if !ok {
+			v = schema.UnsafeGetCounter(tx, d.Key)
+		}
+		if (d.Delta > 0 && v > math.MaxInt64-d.Delta) || (d.Delta < 0 && v < math.MinInt64-d.Delta) {
+			return nil, ErrOverflow
+		}
+		v += d.Delta
+		values[string(d.Key)] = v
+		ret[i] = &pb.CounterValue{Key: d.Key, Value: v}
+	}
+	for k, v := range values {
+		if v == 0 {
+			schema.UnsafeDeleteCounter(tx, []byte(k))
+			continue
+		}
+		schema.UnsafePutCounter(tx, []byte(k), v)
+	}
+	return ret, nil
+}
+
+// Get returns the non-zero counters of the range [key, end), ordered by key,
+// or the counter of key if end is empty. An end of "\x00" selects all the
+// counters from key.
+func (s *Store) Get(key, end []byte) []*pb.CounterValue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tx := s.be.ConcurrentReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	if len(end) == 0 {
+		if v := schema.UnsafeGetCounter(tx, key); v != 0 {
+			return []*pb.CounterValue{{Key: key, Value: v}}
+		}
+		return nil
+	}
+
+	var ret []*pb.CounterValue
+	fromKey := bytes.Equal(end, []byte{0})
+	schema.MustUnsafeForEachCounter(tx, func(k []byte, v int64) {
+		if bytes.Compare(k, key) >= 0 && (fromKey || bytes.Compare(k, end) < 0) {
+			ret = append(ret, &pb.CounterValue{Key: bytes.Clone(k), Value: v})
+		}
+	})
+	sort.Slice(ret, func(i, j int) bool { return bytes.Compare(ret[i].Key, ret[j].Key) < 0 })
+	return ret
+}
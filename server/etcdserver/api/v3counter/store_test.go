@@ -0,0 +1,109 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3counter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestStoreAdd(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	s := NewStore(be)
+	assert.Empty(t, s.Get([]byte("a"), nil))
+	assert.Empty(t, s.Get([]byte("a"), []byte{0}))
+
+	got, err := s.Add([]*pb.CounterDelta{
+		{Key: []byte("a"), Delta: 3},
+		{Key: []byte("b"), Delta: -2},
+		{Key: []byte("a"), Delta: 4},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.CounterValue{
+		{Key: []byte("a"), Value: 3},
+		{Key: []byte("b"), Value: -2},
+		{Key: []byte("a"), Value: 7},
+	}, got)
+	assert.Equal(t, []*pb.CounterValue{{Key: []byte("a"), Value: 7}}, s.Get([]byte("a"), nil))
+
+	// an overflowing delta leaves every counter of the request untouched
+	_, err = s.Add([]*pb.CounterDelta{
+		{Key: []byte("b"), Delta: 1},
+		{Key: []byte("a"), Delta: math.MaxInt64},
+	})
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = s.Add([]*pb.CounterDelta{{Key: []byte("b"), Delta: math.MinInt64}})
+	require.ErrorIs(t, err, ErrOverflow)
+	assert.Equal(t, []*pb.CounterValue{
+		{Key: []byte("a"), Value: 7},
+		{Key: []byte("b"), Value: -2},
+	}, s.Get([]byte("a"), []byte{0}))
+
+	// a counter reaching 0 is removed
+	_, err = s.Add([]*pb.CounterDelta{{Key: []byte("b"), Delta: 2}})
+	require.NoError(t, err)
+	be.ForceCommit()
+	assert.Empty(t, s.Get([]byte("b"), nil))
+
+	// the counters survive a reload from the backend
+	s = NewStore(be)
+	assert.Equal(t, []*pb.CounterValue{{Key: []byte("a"), Value: 7}}, s.Get([]byte("a"), []byte{0}))
+}
+
+func TestStoreGetRange(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	s := NewStore(be)
+	var deltas []*pb.CounterDelta
+	for i, k := range []string{"/b/2", "/a", "/b/1", "/c"} {
+		deltas = append(deltas, &pb.CounterDelta{Key: []byte(k), Delta: int64(i + 1)})
+	}
+	_, err := s.Add(deltas)
+	require.NoError(t, err)
+
+	tcs := []struct {
+		name     string
+		key, end string
+		want     []string
+	}{
+		{name: "single key", key: "/b/1", want: []string{"/b/1"}},
+		{name: "missing key", key: "/b", want: nil},
+		{name: "prefix", key: "/b/", end: "/b0", want: []string{"/b/1", "/b/2"}},
+		{name: "range", key: "/a", end: "/c", want: []string{"/a", "/b/1", "/b/2"}},
+		{name: "from key", key: "/b/2", end: "\x00", want: []string{"/b/2", "/c"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var end []byte
+			if tc.end != "" {
+				end = []byte(tc.end)
+			}
+			var keys []string
+			for _, c := range s.Get([]byte(tc.key), end) {
+				keys = append(keys, string(c.Key))
+			}
+			assert.Equal(t, tc.want, keys)
+		})
+	}
+}
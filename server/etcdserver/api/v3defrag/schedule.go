@@ -0,0 +1,151 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3defrag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const cronSchedulePrefix = "cron:"
+
+// Schedule returns the times at which defragmentation is attempted.
+type Schedule interface {
+	// Next returns the first activation time strictly after t.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses a schedule of the form "cron:<expression>", where the
+// expression uses the standard five cron fields: minute, hour, day of month,
+// month and day of week. Each field supports "*", single values, ranges
+// ("1-5"), lists ("1,3,5") and steps ("*/15", "0-30/10").
+func ParseSchedule(spec string) (Schedule, error) {
+	expr, ok := strings.CutPrefix(spec, cronSchedulePrefix)
+	if !ok {
+		return nil, fmt.Errorf("unsupported schedule %q, expected %q prefix", spec, cronSchedulePrefix)
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q, expected 5 fields", expr)
+	}
+	var s cronSchedule
+	var err error
+	bounds := []struct {
+		field    *uint64
+		min, max int
+	}{
+		{&s.minute, 0, 59},
+		{&s.hour, 0, 23},
+		{&s.dom, 1, 31},
+		{&s.month, 1, 12},
+		{&s.dow, 0, 7},
+	}
+	for i, b := range bounds {
+		if *b.field, err = parseCronField(fields[i], b.min, b.max); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+	}
+	// 7 is an alias of Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domRestricted = fields[2] != "*"
+	s.dowRestricted = fields[4] != "*"
+	return &s, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+		lo, hi := min, max
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loStr); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is out of range [%d, %d]", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	domRestricted, dowRestricted bool
+}
+
+// maxCronSearch bounds the search for the next activation, so that
+// expressions that never match (e.g. "0 0 31 2 *") do not loop forever.
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronSearch)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	// like cron, if both day fields are restricted, either may match
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3defrag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleNext(t *testing.T) {
+	// Wednesday
+	base := time.Date(2026, 1, 14, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"cron:0 3 * * *", time.Date(2026, 1, 15, 3, 0, 0, 0, time.UTC)},
+		{"cron:*/15 * * * *", time.Date(2026, 1, 14, 10, 45, 0, 0, time.UTC)},
+		{"cron:30 10 * * *", time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"cron:0 0 1 * *", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"cron:0 2 * * 0", time.Date(2026, 1, 18, 2, 0, 0, 0, time.UTC)},
+		{"cron:0 2 * * 7", time.Date(2026, 1, 18, 2, 0, 0, 0, time.UTC)},
+		{"cron:0 2 * * 1-5", time.Date(2026, 1, 15, 2, 0, 0, 0, time.UTC)},
+		{"cron:0 0,12 * * *", time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC)},
+		{"cron:0 0 1 3 *", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		// either day field may match when both are restricted
+		{"cron:0 0 20 * 5", time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"cron:0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"cron:0 0 31 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(base))
+		})
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"0 3 * * *",
+		"daily",
+		"cron:0 3 * *",
+		"cron:60 * * * *",
+		"cron:* 24 * * *",
+		"cron:* * 0 * *",
+		"cron:* * * 13 *",
+		"cron:* * * * 8",
+		"cron:*/0 * * * *",
+		"cron:5-1 * * * *",
+		"cron:a * * * *",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			_, err := ParseSchedule(spec)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseThreshold(t *testing.T) {
+	v, err := ParseThreshold("30%")
+	require.NoError(t, err)
+	assert.InDelta(t, 30.0, v, 0)
+
+	v, err = ParseThreshold("12.5")
+	require.NoError(t, err)
+	assert.InDelta(t, 12.5, v, 0)
+
+	for _, s := range []string{"", "abc", "-1%", "101%"} {
+		_, err = ParseThreshold(s)
+		require.Errorf(t, err, "threshold %q", s)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3defrag
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"go.uber.org/zap"
+)
+
+// Defragmentable is the backend being defragmented.
+type Defragmentable interface {
+	Size() int64
+	SizeInUse() int64
+	Defrag() error
+}
+
+// LockFunc acquires the cluster wide defragmentation lock. It blocks until
+// the lock is acquired or ctx is done, and returns a function releasing it.
+// ctx only bounds the wait: the lock must stay held until unlock is called.
+type LockFunc func(ctx context.Context) (unlock func(), err error)
+
+// Config configures a Scheduler.
+type Config struct {
+	Logger *zap.Logger
+	Clock  clockwork.Clock
+
+	Schedule Schedule
+	// Threshold is the minimum fragmentation, in percent of the backend
+	// size, for defragmentation to run.
+	Threshold float64
+	// LockTimeout bounds how long a scheduled run waits for other members
+	// to finish defragmenting.
+	LockTimeout time.Duration
+
+	// Backend returns the current backend of the member.
+	Backend func() Defragmentable
+	// IsLeader reports whether the member is the raft leader. The leader
+	// never defragments, since blocking it would stall the whole cluster.
+	IsLeader func() bool
+	// Lock serializes defragmentation across members.
+	Lock LockFunc
+	// OnDefrag is called after a successful defragmentation.
+	OnDefrag func()
+}
+
+// DefaultLockTimeout is the default for Config.LockTimeout.
+const DefaultLockTimeout = time.Hour
+
+// Scheduler defragments the backend at the times of its schedule when the
+// backend is fragmented beyond the threshold, at most one member at a time.
+type Scheduler struct {
+	cfg Config
+	lg  *zap.Logger
+}
+
+// NewScheduler creates a new Scheduler.
+func NewScheduler(cfg Config) *Scheduler {
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clockwork.NewRealClock()
+	}
+	if cfg.LockTimeout == 0 {
+		cfg.LockTimeout = DefaultLockTimeout
+	}
+	return &Scheduler{cfg: cfg, lg: cfg.Logger}
+}
+
+// Run runs the scheduler until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		now := s.cfg.Clock.Now()
+		next := s.cfg.Schedule.Next(now)
+		if next.IsZero() {
+			s.lg.Warn("defragmentation schedule never fires again, stopping scheduler")
+			return
+		}
+		s.lg.Info("scheduled next defragmentation", zap.Time("at", next))
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.cfg.Clock.After(next.Sub(now)):
+		}
+		if err := s.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			s.lg.Warn("scheduled defragmentation failed", zap.Error(err))
+		}
+	}
+}
+
+// RunOnce defragments the backend if the member is not the leader and the
+// backend is fragmented beyond the threshold, holding the lock meanwhile.
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	if !s.shouldDefrag() {
+		return nil
+	}
+
+	lctx, cancel := context.WithTimeout(ctx, s.cfg.LockTimeout)
+	unlock, err := s.cfg.Lock(lctx)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to acquire defragmentation lock: %w", err)
+	}
+	defer unlock()
+
+	// leadership or fragmentation may have changed while waiting for the lock,
+	// e.g. because another member was defragmenting.
+	if !s.shouldDefrag() {
+		return nil
+	}
+
+	be := s.cfg.Backend()
+	size, inUse := be.Size(), be.SizeInUse()
+	s.lg.Info("starting scheduled defragmentation",
+		zap.Int64("size", size),
+		zap.Int64("size-in-use", inUse),
+	)
+	start := s.cfg.Clock.Now()
+	if err = be.Defrag(); err != nil {
+		return err
+	}
+	s.lg.Info("finished scheduled defragmentation",
+		zap.Duration("took", s.cfg.Clock.Since(start)),
+		zap.Int64("size-before", size),
+		zap.Int64("size-after", be.Size()),
+	)
+	if s.cfg.OnDefrag != nil {
+		s.cfg.OnDefrag()
+	}
+	return nil
+}
+
+func (s *Scheduler) shouldDefrag() bool {
+	if s.cfg.IsLeader() {
+		s.lg.Info("skipping scheduled defragmentation on leader")
+		return false
+	}
+	be := s.cfg.Backend()
+	frag := Fragmentation(be.Size(), be.SizeInUse())
+	if frag < s.cfg.Threshold {
+		s.lg.Info("skipping scheduled defragmentation, fragmentation below threshold",
+			zap.Float64("fragmentation-percent", frag),
+			zap.Float64("threshold-percent", s.cfg.Threshold),
+		)
+		return false
+	}
+	return true
+}
+
+// Fragmentation returns the percentage of the backend size that is not in use.
+func Fragmentation(size, inUse int64) float64 {
+	if size <= 0 || inUse >= size {
+		return 0
+	}
+	return float64(size-inUse) / float64(size) * 100
+}
+
+// ParseThreshold parses a fragmentation threshold like "30%" or "30".
+func ParseThreshold(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil || v < 0 || v > 100 {
+		return 0, fmt.Errorf("invalid fragmentation threshold %q, expected a percentage between 0%% and 100%%", s)
+	}
+	return v, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3defrag
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fakeBackend struct {
+	size, inUse int64
+	defrags     int
+}
+
+func (b *fakeBackend) Size() int64      { return b.size }
+func (b *fakeBackend) SizeInUse() int64 { return b.inUse }
+func (b *fakeBackend) Defrag() error {
+	b.defrags++
+	b.size = b.inUse
+	return nil
+}
+
+func TestSchedulerRunOnce(t *testing.T) {
+	tests := []struct {
+		name        string
+		size, inUse int64
+		leader      bool
+		lockErr     error
+
+		wantDefrag bool
+		wantLock   bool
+		wantErr    bool
+	}{
+		{name: "fragmented", size: 100, inUse: 50, wantDefrag: true, wantLock: true},
+		{name: "below threshold", size: 100, inUse: 80},
+		{name: "leader", size: 100, inUse: 10, leader: true},
+		{name: "lock failure", size: 100, inUse: 10, lockErr: errors.New("lock"), wantLock: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			be := &fakeBackend{size: tt.size, inUse: tt.inUse}
+			locked, unlocked := 0, 0
+			s := NewScheduler(Config{
+				Logger:    zaptest.NewLogger(t),
+				Threshold: 30,
+				Backend:   func() Defragmentable { return be },
+				IsLeader:  func() bool { return tt.leader },
+				Lock: func(ctx context.Context) (func(), error) {
+					locked++
+					if tt.lockErr != nil {
+						return nil, tt.lockErr
+					}
+					return func() { unlocked++ }, nil
+				},
+			})
+			err := s.RunOnce(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDefrag, be.defrags == 1)
+			assert.Equal(t, tt.wantLock, locked == 1)
+			assert.Equal(t, locked-boolToInt(tt.lockErr != nil), unlocked)
+		})
+	}
+}
+
+func TestSchedulerRechecksAfterLock(t *testing.T) {
+	be := &fakeBackend{size: 100, inUse: 50}
+	leader := false
+	s := NewScheduler(Config{
+		Logger:    zaptest.NewLogger(t),
+		Threshold: 30,
+		Backend:   func() Defragmentable { return be },
+		IsLeader:  func() bool { return leader },
+		Lock: func(ctx context.Context) (func(), error) {
+			// the member became leader while waiting for the lock
+			leader = true
+			return func() {}, nil
+		},
+	})
+	require.NoError(t, s.RunOnce(context.Background()))
+	assert.Equal(t, 0, be.defrags)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package v3fence implements the fences of prefixes set by FencePrefix.
+package v3fence
+
+import (
+	"crypto/subtle"
+	"errors"
+	"sync"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var ErrPrefixFenced = errors.New("etcdserver: writes to prefix are fenced")
+
+// Store keeps the fences of prefixes. A fence rejects the writes to the keys
+// under its prefix, except for the writes carrying its token. Fences are
+// replicated through raft and persisted in the backend. They do not expire
+// by themselves: the leader lifts the expired fences through raft, so that
+// every member rejects the same writes.
+type Store struct {
+	mu     sync.RWMutex
+	be     backend.Backend
+	fences map[string]*pb.InternalFencePrefixRequest
+}
+
+func NewStore(be backend.Backend) *Store {
+	s := &Store{}
+	s.Recover(be)
+	return s
+}
+
+// Recover reloads the fences from the given backend, which replaces the
+// previous one.
+func (s *Store) Recover(be backend.Backend) {
+	tx := be.ReadTx()
+	tx.RLock()
+	fences := schema.MustUnsafeGetAllFences(tx)
+	tx.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.be = be
+	s.fences = make(map[string]*pb.InternalFencePrefixRequest, len(fences))
+	for _, f := range fences {
+		s.fences[string(f.Prefix)] = f
+	}
+}
+
+// Fence sets the fence of the prefix of f, replacing the previous one, or
+// lifts it if f has no TTL.
+func (s *Store) Fence(f *pb.InternalFencePrefixRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.fences[string(f.Prefix)]
+	if f.TTL <= 0 && (!ok || f.GrantTime != 0 && f.GrantTime != old.GrantTime) {
+		return
+	}
+	tx := s.be.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	if f.TTL <= 0 {
+		schema.UnsafeDeleteFence(tx, f.Prefix)
+		delete(s.fences, string(f.Prefix))
+		return
+	}
+	schema.MustUnsafePutFence(tx, f)
+	s.fences[string(f.Prefix)] = f
+}
+
+// Check returns ErrPrefixFenced if the range [key, end) has a key under a
+// fenced prefix whose token is not the given one.
+func (s *Store) Check(key, end []byte, token string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, f := range s.fences {
+		if !clusterconfig.OverlapsPrefix(key, end, f.Prefix) {
+			continue
+		}
+		if token == "" || f.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(f.Token)) != 1 {
+			return ErrPrefixFenced
+		}
+	}
+	return nil
+}
+
+// Expired returns the fences expired at now.
+func (s *Store) Expired(now time.Time) []*pb.InternalFencePrefixRequest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ret []*pb.InternalFencePrefixRequest
+	for _, f := range s.fences {
+		expiry := time.Unix(0, f.GrantTime).Add(time.Duration(f.TTL) * time.Second)
+		if !now.Before(expiry) {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3fence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestStore(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	now := time.Now()
+	s := NewStore(be)
+	require.NoError(t, s.Check([]byte("/app/a"), nil, ""))
+
+	s.Fence(&pb.InternalFencePrefixRequest{Prefix: []byte("/app/"), TTL: 10, Token: "secret", GrantTime: now.UnixNano()})
+	s.Fence(&pb.InternalFencePrefixRequest{Prefix: []byte("/open/"), TTL: 20, GrantTime: now.UnixNano()})
+	be.ForceCommit()
+
+	// the fences survive a reload from the backend
+	s = NewStore(be)
+
+	tcs := []struct {
+		name     string
+		key, end string
+		token    string
+		fenced   bool
+	}{
+		{name: "key outside", key: "/apps"},
+		{name: "key inside", key: "/app/a", fenced: true},
+		{name: "key inside with token", key: "/app/a", token: "secret"},
+		{name: "key inside with other token", key: "/app/a", token: "other", fenced: true},
+		{name: "range over prefixes", key: "/", end: "/p", token: "secret", fenced: true},
+		{name: "range before prefix", key: "/a", end: "/app/"},
+		{name: "fence without token", key: "/open/a", fenced: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var end []byte
+			if tc.end != "" {
+				end = []byte(tc.end)
+			}
+			err := s.Check([]byte(tc.key), end, tc.token)
+			if tc.fenced {
+				require.ErrorIs(t, err, ErrPrefixFenced)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	assert.Empty(t, s.Expired(now))
+	expired := s.Expired(now.Add(15 * time.Second))
+	require.Len(t, expired, 1)
+	assert.Equal(t, []byte("/app/"), expired[0].Prefix)
+
+	// a lift of an expired fence does not lift the fence replacing it
+	s.Fence(&pb.InternalFencePrefixRequest{Prefix: []byte("/app/"), TTL: 10, Token: "secret", GrantTime: now.Add(time.Second).UnixNano()})
+	s.Fence(&pb.InternalFencePrefixRequest{Prefix: []byte("/app/"), GrantTime: now.UnixNano()})
+	require.ErrorIs(t, s.Check([]byte("/app/a"), nil, ""), ErrPrefixFenced)
+
+	// a lift without grant time lifts any fence
+	s.Fence(&pb.InternalFencePrefixRequest{Prefix: []byte("/app/")})
+	require.NoError(t, s.Check([]byte("/app/a"), nil, ""))
+	be.ForceCommit()
+	s = NewStore(be)
+	require.NoError(t, s.Check([]byte("/app/a"), nil, ""))
+}
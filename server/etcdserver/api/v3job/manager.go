@@ -0,0 +1,338 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3job
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	servererrors "go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var (
+	ErrJobNotFound   = errors.New("etcdserver: job not found")
+	ErrJobNotRunning = errors.New("etcdserver: job is not running")
+)
+
+// maxEndedJobs is the number of records of ended jobs kept. The records of
+// older jobs are deleted.
+const maxEndedJobs = 64
+
+// Func runs the work of a job. It reports its progress through j and should
+// return an error once ctx is done, unless its work cannot stop midway.
+type Func func(ctx context.Context, j *Job) error
+
+// Job is the handle of a running job given to its Func.
+type Job struct {
+	m  *Manager
+	id int64
+}
+
+// ID returns the ID of the job.
+func (j *Job) ID() int64 { return j.id }
+
+// Progress sets the work done by the job out of total, and its current step.
+// The progress is kept in memory; only the records of started and ended jobs
+// are persisted.
+func (j *Job) Progress(done, total int64, message string) {
+	j.m.mu.Lock()
+	defer j.m.mu.Unlock()
+	if r, ok := j.m.running[j.id]; ok {
+		r.rec.Done, r.rec.Total, r.rec.Message = done, total, message
+	}
+}
+
+type runningJob struct {
+	id     int64
+	rec    *pb.Job
+	cancel context.CancelFunc
+	// done is closed once the job ended and err is set.
+	done chan struct{}
+	err  error
+}
+
+// Manager runs the maintenance jobs of a member and keeps their records.
+// Records are persisted in the backend when a job starts and ends, so the
+// jobs of a member can be listed after it restarts. Jobs still running when
+// the member stopped are recorded as failed on restart.
+type Manager struct {
+	lg  *zap.Logger
+	now func() time.Time
+
+	// bemu serializes the writes of the records to the backend. It is not
+	// held with mu, so that jobs can be listed while the backend is busy,
+	// for instance by a defragmentation.
+	bemu sync.Mutex
+	be   backend.Backend
+
+	mu      sync.Mutex
+	nextID  int64
+	records map[int64]*pb.Job
+	running map[int64]*runningJob
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// NewManager returns a manager of the jobs recorded in the given backend.
+func NewManager(lg *zap.Logger, be backend.Backend) *Manager {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	m := &Manager{
+		lg:      lg,
+		now:     time.Now,
+		be:      be,
+		nextID:  1,
+		records: make(map[int64]*pb.Job),
+		running: make(map[int64]*runningJob),
+	}
+
+	tx := be.ReadTx()
+	tx.RLock()
+	recs := schema.MustUnsafeGetAllJobs(tx)
+	tx.RUnlock()
+
+	var interrupted []*pb.Job
+	for _, rec := range recs {
+		if rec.State == pb.Job_RUNNING {
+			rec.State = pb.Job_FAILED
+			rec.Message = "interrupted by the member stopping"
+			interrupted = append(interrupted, rec)
+		}
+		m.records[rec.ID] = rec
+		m.nextID = max(m.nextID, rec.ID+1)
+	}
+	if len(interrupted) > 0 {
+		lg.Warn("recorded jobs interrupted by the member stopping as failed", zap.Int("jobs", len(interrupted)))
+		btx := be.BatchTx()
+		btx.LockOutsideApply()
+		for _, rec := range interrupted {
+			schema.MustUnsafePutJob(btx, rec)
+		}
+		btx.Unlock()
+	}
+	return m
+}
+
+// Recover writes the records of the jobs to the given backend, which
+// replaces the previous one. The records of the given backend, which come
+// from another member, are dropped.
+func (m *Manager) Recover(be backend.Backend) {
+	m.bemu.Lock()
+	defer m.bemu.Unlock()
+	m.be = be
+
+	recs := m.Jobs()
+	tx := be.BatchTx()
+	// the records are local to the member, not applied through raft
+	tx.LockOutsideApply()
+	defer tx.Unlock()
+	tx.UnsafeDeleteBucket(schema.Job)
+	for _, rec := range recs {
+		schema.MustUnsafePutJob(tx, rec)
+	}
+}
+
+// Start starts a job of the given type running fn. The job is canceled once
+// ctx is done or it is canceled through Cancel.
+func (m *Manager) Start(ctx context.Context, typ string, fn Func) (*pb.Job, error) {
+	r, err := m.start(ctx, typ, fn)
+	if err != nil {
+		return nil, err
+	}
+	return m.Job(r.id)
+}
+
+// Run runs a job of the given type running fn, and waits for it to end. It
+// returns the error of fn.
+func (m *Manager) Run(ctx context.Context, typ string, fn Func) error {
+	r, err := m.start(ctx, typ, fn)
+	if err != nil {
+		return err
+	}
+	<-r.done
+	return r.err
+}
+
+func (m *Manager) start(ctx context.Context, typ string, fn Func) (*runningJob, error) {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		return nil, servererrors.ErrStopped
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	r := &runningJob{
+		id: m.nextID,
+		rec: &pb.Job{
+			ID:        m.nextID,
+			Type:      typ,
+			State:     pb.Job_RUNNING,
+			StartTime: m.now().UnixNano(),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	m.nextID++
+	m.records[r.rec.ID] = r.rec
+	m.running[r.rec.ID] = r
+	m.wg.Add(1)
+	started := clone(r.rec)
+	m.mu.Unlock()
+
+	// the record is persisted before the job runs, so that it is never
+	// overwritten by the one of the started job
+	m.persist(started, nil)
+	m.lg.Info("started job", zap.Int64("job-id", started.ID), zap.String("job-type", typ))
+
+	go func() {
+		defer m.wg.Done()
+		r.err = fn(ctx, &Job{m: m, id: r.id})
+		m.finish(r)
+	}()
+	return r, nil
+}
+
+func (m *Manager) finish(r *runningJob) {
+	err := r.err
+	m.mu.Lock()
+	rec := r.rec
+	rec.EndTime = m.now().UnixNano()
+	switch {
+	case err == nil:
+		rec.State = pb.Job_SUCCEEDED
+		rec.Message = ""
+	case rec.CancelRequested:
+		// the job failed once asked to stop, which it did
+		rec.State = pb.Job_CANCELED
+		rec.Message = ""
+	default:
+		rec.State = pb.Job_FAILED
+		rec.Message = err.Error()
+	}
+	delete(m.running, rec.ID)
+	r.cancel()
+	ended := clone(rec)
+	pruned := m.prune()
+	m.mu.Unlock()
+
+	m.persist(ended, pruned)
+	close(r.done)
+	m.lg.Info(
+		"ended job",
+		zap.Int64("job-id", ended.ID),
+		zap.String("job-type", ended.Type),
+		zap.Stringer("job-state", ended.State),
+		zap.Duration("took", time.Duration(ended.EndTime-ended.StartTime)),
+		zap.String("message", ended.Message),
+	)
+}
+
+// prune drops the records of the oldest ended jobs beyond maxEndedJobs, and
+// returns their IDs. It must be called with mu held.
+func (m *Manager) prune() []int64 {
+	var ended []int64
+	for id, rec := range m.records {
+		if rec.State != pb.Job_RUNNING {
+			ended = append(ended, id)
+		}
+	}
+	if len(ended) <= maxEndedJobs {
+		return nil
+	}
+	sort.Slice(ended, func(i, j int) bool { return ended[i] < ended[j] })
+	pruned := ended[:len(ended)-maxEndedJobs]
+	for _, id := range pruned {
+		delete(m.records, id)
+	}
+	return pruned
+}
+
+func (m *Manager) persist(rec *pb.Job, deleted []int64) {
+	m.bemu.Lock()
+	defer m.bemu.Unlock()
+	tx := m.be.BatchTx()
+	tx.LockOutsideApply()
+	defer tx.Unlock()
+	for _, id := range deleted {
+		schema.UnsafeDeleteJob(tx, id)
+	}
+	schema.MustUnsafePutJob(tx, rec)
+}
+
+// Jobs returns the records of the running jobs and of the latest ended jobs,
+// ordered by ID.
+func (m *Manager) Jobs() []*pb.Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobs := make([]*pb.Job, 0, len(m.records))
+	for _, rec := range m.records {
+		jobs = append(jobs, clone(rec))
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs
+}
+
+// Job returns the record of the job of the given ID.
+func (m *Manager) Job(id int64) (*pb.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec, ok := m.records[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return clone(rec), nil
+}
+
+// Cancel asks the running job of the given ID to stop. It does not wait for
+// the job to end.
+func (m *Manager) Cancel(id int64) (*pb.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.running[id]
+	if !ok {
+		if _, ok = m.records[id]; ok {
+			return nil, ErrJobNotRunning
+		}
+		return nil, ErrJobNotFound
+	}
+	r.rec.CancelRequested = true
+	r.cancel()
+	return clone(r.rec), nil
+}
+
+// Stop cancels the running jobs and waits for them to end. No job can be
+// started once the manager is stopped.
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	m.stopped = true
+	for _, r := range m.running {
+		r.cancel()
+	}
+	m.mu.Unlock()
+	m.wg.Wait()
+}
+
+func clone(rec *pb.Job) *pb.Job {
+	c := *rec
+	return &c
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3job
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	servererrors "go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func persistedJobs(be backend.Backend) []*pb.Job {
+	be.ForceCommit()
+	tx := be.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	return schema.MustUnsafeGetAllJobs(tx)
+}
+
+func TestManagerRun(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	m := NewManager(zaptest.NewLogger(t), be)
+	defer m.Stop()
+
+	err := m.Run(t.Context(), "ok", func(ctx context.Context, j *Job) error {
+		j.Progress(3, 3, "")
+		return nil
+	})
+	require.NoError(t, err)
+	errFailed := errors.New("failed")
+	err = m.Run(t.Context(), "fail", func(ctx context.Context, j *Job) error {
+		j.Progress(1, 3, "step 2")
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+
+	jobs := m.Jobs()
+	require.Len(t, jobs, 2)
+	assert.Equal(t, int64(1), jobs[0].ID)
+	assert.Equal(t, "ok", jobs[0].Type)
+	assert.Equal(t, pb.Job_SUCCEEDED, jobs[0].State)
+	assert.Equal(t, int64(3), jobs[0].Done)
+	assert.Equal(t, int64(3), jobs[0].Total)
+	assert.GreaterOrEqual(t, jobs[0].EndTime, jobs[0].StartTime)
+	assert.Equal(t, pb.Job_FAILED, jobs[1].State)
+	assert.Equal(t, "failed", jobs[1].Message)
+	assert.Equal(t, int64(1), jobs[1].Done)
+
+	job, err := m.Job(2)
+	require.NoError(t, err)
+	assert.Equal(t, jobs[1], job)
+	_, err = m.Job(3)
+	require.ErrorIs(t, err, ErrJobNotFound)
+
+	assert.Equal(t, jobs, persistedJobs(be))
+}
+
+func TestManagerCancel(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	m := NewManager(zaptest.NewLogger(t), be)
+	defer m.Stop()
+
+	started := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- m.Run(t.Context(), "wait", func(ctx context.Context, j *Job) error {
+			j.Progress(1, 2, "waiting")
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+	<-started
+
+	job, err := m.Job(1)
+	require.NoError(t, err)
+	assert.Equal(t, pb.Job_RUNNING, job.State)
+	assert.Equal(t, "waiting", job.Message)
+	assert.Zero(t, job.EndTime)
+	// the running job is persisted as it started
+	persisted := persistedJobs(be)
+	require.Len(t, persisted, 1)
+	assert.Equal(t, pb.Job_RUNNING, persisted[0].State)
+
+	job, err = m.Cancel(1)
+	require.NoError(t, err)
+	assert.True(t, job.CancelRequested)
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	job, err = m.Job(1)
+	require.NoError(t, err)
+	assert.Equal(t, pb.Job_CANCELED, job.State)
+	assert.Empty(t, job.Message)
+
+	_, err = m.Cancel(1)
+	require.ErrorIs(t, err, ErrJobNotRunning)
+	_, err = m.Cancel(2)
+	require.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestManagerRestart(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	m := NewManager(zaptest.NewLogger(t), be)
+	require.NoError(t, m.Run(t.Context(), "ok", func(ctx context.Context, j *Job) error { return nil }))
+	release := make(chan struct{})
+	_, err := m.Start(t.Context(), "wait", func(ctx context.Context, j *Job) error {
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+
+	// the member restarts while the job runs
+	restarted := NewManager(zaptest.NewLogger(t), be)
+	jobs := restarted.Jobs()
+	require.Len(t, jobs, 2)
+	assert.Equal(t, pb.Job_SUCCEEDED, jobs[0].State)
+	assert.Equal(t, pb.Job_FAILED, jobs[1].State)
+	assert.Equal(t, "interrupted by the member stopping", jobs[1].Message)
+
+	rec, err := restarted.Start(t.Context(), "next", func(ctx context.Context, j *Job) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), rec.ID)
+
+	close(release)
+	m.Stop()
+	restarted.Stop()
+	_, err = restarted.Start(t.Context(), "stopped", func(ctx context.Context, j *Job) error { return nil })
+	require.ErrorIs(t, err, servererrors.ErrStopped)
+}
+
+func TestManagerPrune(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	m := NewManager(zaptest.NewLogger(t), be)
+	defer m.Stop()
+	for range maxEndedJobs + 3 {
+		require.NoError(t, m.Run(t.Context(), "ok", func(ctx context.Context, j *Job) error { return nil }))
+	}
+
+	jobs := m.Jobs()
+	require.Len(t, jobs, maxEndedJobs)
+	assert.Equal(t, int64(4), jobs[0].ID)
+	assert.Equal(t, jobs, persistedJobs(be))
+}
@@ -18,8 +18,10 @@ import (
 	"context"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api"
 )
 
 type AuthServer struct {
@@ -102,6 +104,29 @@ func (as *AuthServer) RoleRevokePermission(ctx context.Context, r *pb.AuthRoleRe
 	return resp, nil
 }
 
+func (as *AuthServer) RoleGrantRPCPermission(ctx context.Context, r *pb.AuthRoleGrantRPCPermissionRequest) (*pb.AuthRoleGrantRPCPermissionResponse, error) {
+	// members older than 3.7 cannot apply RPC permission changes
+	if !api.IsCapabilityEnabled(api.RPCPermissionCapability) {
+		return nil, rpctypes.ErrGRPCNotCapable
+	}
+	resp, err := as.authenticator.RoleGrantRPCPermission(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	return resp, nil
+}
+
+func (as *AuthServer) RoleRevokeRPCPermission(ctx context.Context, r *pb.AuthRoleRevokeRPCPermissionRequest) (*pb.AuthRoleRevokeRPCPermissionResponse, error) {
+	if !api.IsCapabilityEnabled(api.RPCPermissionCapability) {
+		return nil, rpctypes.ErrGRPCNotCapable
+	}
+	resp, err := as.authenticator.RoleRevokeRPCPermission(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	return resp, nil
+}
+
 func (as *AuthServer) RoleGrantPermission(ctx context.Context, r *pb.AuthRoleGrantPermissionRequest) (*pb.AuthRoleGrantPermissionResponse, error) {
 	resp, err := as.authenticator.RoleGrantPermission(ctx, r)
 	if err != nil {
@@ -166,6 +191,14 @@ func (as *AuthServer) UserChangePassword(ctx context.Context, r *pb.AuthUserChan
 	return resp, nil
 }
 
+func (as *AuthServer) UserRotatePassword(ctx context.Context, r *pb.AuthUserRotatePasswordRequest) (*pb.AuthUserRotatePasswordResponse, error) {
+	resp, err := as.authenticator.UserRotatePassword(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	return resp, nil
+}
+
 type AuthGetter interface {
 	AuthInfoFromCtx(ctx context.Context) (*auth.AuthInfo, error)
 	AuthStore() auth.AuthStore
@@ -175,13 +208,26 @@ type AuthAdmin struct {
 	ag AuthGetter
 }
 
-// isPermitted verifies the user has admin privilege.
-// Only users with "root" role are permitted.
-func (aa *AuthAdmin) isPermitted(ctx context.Context) error {
+// isPermitted verifies the user may call the given administrative RPC
+// method. Users with "root" role and users whose roles were granted the
+// method are permitted.
+func (aa *AuthAdmin) isPermitted(ctx context.Context, method string) error {
 	authInfo, err := aa.ag.AuthInfoFromCtx(ctx)
 	if err != nil {
 		return err
 	}
 
-	return aa.ag.AuthStore().IsAdminPermitted(authInfo)
+	return aa.ag.AuthStore().IsRPCPermitted(authInfo, method)
+}
+
+// isAuthenticated verifies the request is made by a user if auth is enabled.
+func (aa *AuthAdmin) isAuthenticated(ctx context.Context) error {
+	authInfo, err := aa.ag.AuthInfoFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if aa.ag.AuthStore().IsAuthEnabled() && (authInfo == nil || authInfo.Username == "") {
+		return auth.ErrUserEmpty
+	}
+	return nil
 }
@@ -0,0 +1,34 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+func TestRoleRPCPermissionNotCapable(t *testing.T) {
+	withClusterVersion(t, "3.6.0")
+	as := &AuthServer{}
+
+	_, err := as.RoleGrantRPCPermission(t.Context(), &pb.AuthRoleGrantRPCPermissionRequest{Name: "root", Method: "Maintenance.Defragment"})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCNotCapable)
+	_, err = as.RoleRevokeRPCPermission(t.Context(), &pb.AuthRoleRevokeRPCPermissionRequest{Role: "root", Method: "Maintenance.Defragment"})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCNotCapable)
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"context"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/stats"
+)
+
+// ResponseCompressors are the compressors the server may compress unary
+// responses with, negotiated with the compressors advertised by the client.
+var ResponseCompressors = []string{gzip.Name, zstdName}
+
+const zstdName = "zstd"
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// newCompressionUnaryInterceptor compresses the responses of at least minBytes
+// bytes with the first of compressors the client advertised support for.
+// Smaller responses are sent uncompressed, even to clients compressing their
+// requests.
+func newCompressionUnaryInterceptor(compressors []string, minBytes int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		m, ok := resp.(interface{ Size() int })
+		if !ok {
+			return resp, nil
+		}
+		if m.Size() < minBytes {
+			grpc.SetSendCompressor(ctx, encoding.Identity)
+			return resp, nil
+		}
+		supported, serr := grpc.ClientSupportedCompressors(ctx)
+		if serr != nil {
+			return resp, nil
+		}
+		for _, name := range compressors {
+			if !slices.Contains(supported, name) {
+				continue
+			}
+			if grpc.SetSendCompressor(ctx, name) == nil {
+				compressedResponses.WithLabelValues(name).Inc()
+			}
+			break
+		}
+		return resp, nil
+	}
+}
+
+// compressionStatsHandler counts the bytes saved by compressing responses.
+type compressionStatsHandler struct{}
+
+func (compressionStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (compressionStatsHandler) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if out, ok := s.(*stats.OutPayload); ok && out.CompressedLength < out.Length {
+		compressionSavedBytes.Add(float64(out.Length - out.CompressedLength))
+	}
+}
+
+func (compressionStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (compressionStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// zstdCompressor is the zstd gRPC compressor. Its encoders and decoders are
+// pooled since they are expensive to create.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc, ok := c.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		if enc, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+	} else {
+		enc.Reset(w)
+	}
+	return &zstdWriter{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, ok := c.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		if dec, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+	} else if err := dec.Reset(r); err != nil {
+		c.decoders.Put(dec)
+		return nil, err
+	}
+	return &zstdReader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+func (c *zstdCompressor) Name() string { return zstdName }
+
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+	return err
+}
+
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		// the whole message is read; the decoder can serve another one
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
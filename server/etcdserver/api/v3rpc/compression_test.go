@@ -0,0 +1,45 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestZstdCompressorRoundTrip(t *testing.T) {
+	c := encoding.GetCompressor(zstdName)
+	require.NotNil(t, c)
+
+	// the second round reuses the pooled encoder and decoder
+	for _, msg := range [][]byte{bytes.Repeat([]byte("foo"), 4096), []byte("bar")} {
+		var buf bytes.Buffer
+		w, err := c.Compress(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(msg)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		r, err := c.Decompress(&buf)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, msg, got)
+	}
+}
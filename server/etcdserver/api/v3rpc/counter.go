@@ -0,0 +1,80 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"context"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api"
+)
+
+type counterServer struct {
+	hdr header
+	cs  etcdserver.Counter
+	// maxOps is the max number of deltas per CounterAdd.
+	maxOps uint
+}
+
+func NewCounterServer(s *etcdserver.EtcdServer) pb.CounterServer {
+	return &counterServer{hdr: newHeader(s), cs: s, maxOps: s.Cfg.MaxTxnOps}
+}
+
+func (s *counterServer) CounterAdd(ctx context.Context, r *pb.CounterAddRequest) (*pb.CounterAddResponse, error) {
+	if err := checkCounterAddRequest(r, int(s.maxOps)); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.cs.CounterAdd(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+
+	s.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (s *counterServer) CounterGet(ctx context.Context, r *pb.CounterGetRequest) (*pb.CounterGetResponse, error) {
+	if len(r.Key) == 0 {
+		return nil, rpctypes.ErrGRPCEmptyKey
+	}
+
+	resp, err := s.cs.CounterGet(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+
+	s.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func checkCounterAddRequest(r *pb.CounterAddRequest, maxOps int) error {
+	// members older than 3.7 cannot apply counter updates; wait for the
+	// cluster version to move past them before accepting any.
+	if !api.IsCapabilityEnabled(api.CounterCapability) {
+		return rpctypes.ErrGRPCNotCapable
+	}
+	if len(r.Deltas) > maxOps {
+		return rpctypes.ErrGRPCTooManyOps
+	}
+	for _, d := range r.Deltas {
+		if len(d.Key) == 0 {
+			return rpctypes.ErrGRPCEmptyKey
+		}
+	}
+	return nil
+}
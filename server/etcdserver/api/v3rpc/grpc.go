@@ -57,6 +57,10 @@ func Server(s *etcdserver.EtcdServer, tls *tls.Config, interceptor grpc.UnarySer
 		newUnaryInterceptor(s),
 		serverMetrics.UnaryServerInterceptor(),
 	}
+	if len(s.Cfg.GRPCResponseCompressors) > 0 {
+		chainUnaryInterceptors = append(chainUnaryInterceptors, newCompressionUnaryInterceptor(s.Cfg.GRPCResponseCompressors, s.Cfg.GRPCResponseCompressionMinBytes))
+		opts = append(opts, grpc.StatsHandler(compressionStatsHandler{}))
+	}
 	if interceptor != nil {
 		chainUnaryInterceptors = append(chainUnaryInterceptors, interceptor)
 	}
@@ -82,6 +86,7 @@ func Server(s *etcdserver.EtcdServer, tls *tls.Config, interceptor grpc.UnarySer
 	pb.RegisterKVServer(grpcServer, NewQuotaKVServer(s))
 	pb.RegisterWatchServer(grpcServer, NewWatchServer(s))
 	pb.RegisterLeaseServer(grpcServer, NewQuotaLeaseServer(s))
+	pb.RegisterCounterServer(grpcServer, NewQuotaCounterServer(s))
 	pb.RegisterClusterServer(grpcServer, NewClusterServer(s))
 	pb.RegisterAuthServer(grpcServer, NewAuthServer(s))
 
@@ -25,6 +25,10 @@ type header struct {
 	memberID  int64
 	sg        apply.RaftStatusGetter
 	rev       func() int64
+	// leaderValidMs is the leadership validity hinted by the leader, the
+	// election timeout: followers do not campaign before it elapses without
+	// hearing from the leader.
+	leaderValidMs int64
 }
 
 func newHeader(s *etcdserver.EtcdServer) header {
@@ -33,6 +37,8 @@ func newHeader(s *etcdserver.EtcdServer) header {
 		memberID:  int64(s.MemberID()),
 		sg:        s,
 		rev:       func() int64 { return s.KV().Rev() },
+
+		leaderValidMs: int64(s.Cfg.ElectionTicks) * int64(s.Cfg.TickMs),
 	}
 }
 
@@ -47,4 +53,8 @@ func (h *header) fill(rh *pb.ResponseHeader) {
 	if rh.Revision == 0 {
 		rh.Revision = h.rev()
 	}
+	rh.Leader = uint64(h.sg.Leader())
+	if rh.Leader == rh.MemberId {
+		rh.LeaderValidMs = h.leaderValidMs
+	}
 }
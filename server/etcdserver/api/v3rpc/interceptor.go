@@ -16,6 +16,7 @@ package v3rpc
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"time"
 	"unicode/utf8"
@@ -49,10 +50,24 @@ func newUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerInterceptor {
 			return nil, rpctypes.ErrGRPCNotCapable
 		}
 
+		if s.IsMemberExist(s.MemberID()) && s.IsStandby() && !isRPCSupportedForStandby(req) {
+			return nil, rpctypes.ErrGRPCNotSupportedForStandby
+		}
+
 		if s.IsMemberExist(s.MemberID()) && s.IsLearner() && !isRPCSupportedForLearner(req) {
 			return nil, rpctypes.ErrGRPCNotSupportedForLearner
 		}
 
+		if err := s.CheckRequestFields(info.FullMethod, req); err != nil {
+			return nil, togRPCError(err)
+		}
+
+		if m, ok := req.(interface{ Size() int }); ok {
+			n := int64(m.Size())
+			s.AccountGRPCBuffer(n)
+			defer s.AccountGRPCBuffer(-n)
+		}
+
 		md, ok := metadata.FromIncomingContext(ctx)
 		if ok {
 			ver, vs := "unknown", md.Get(rpctypes.MetadataClientAPIVersionKey)
@@ -69,6 +84,31 @@ func newUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerInterceptor {
 					return nil, rpctypes.ErrGRPCNoLeader
 				}
 			}
+
+			if ks := md[rpctypes.MetadataPriorityKey]; len(ks) > 0 && ks[0] == rpctypes.MetadataPriorityLow {
+				ctx = etcdserver.WithLowPriority(ctx)
+			}
+
+			if ks := md[rpctypes.MetadataElectionLeaseKey]; len(ks) > 0 {
+				id, err := strconv.ParseInt(ks[0], 10, 64)
+				if err != nil {
+					return nil, rpctypes.ErrGRPCLeaseNotFound
+				}
+				ctx = etcdserver.WithElectionLease(ctx, id)
+			}
+
+			if ks := md[rpctypes.MetadataFenceTokenKey]; len(ks) > 0 {
+				ctx = etcdserver.WithFenceToken(ctx, ks[0])
+			}
+
+			if ks := md[rpctypes.MetadataRequestTimingsKey]; len(ks) > 0 && ks[0] == rpctypes.MetadataRequestTimings {
+				ctx = etcdserver.WithRequestTimings(ctx)
+				resp, err := handler(ctx, req)
+				if r, ok := resp.(interface{ GetHeader() *pb.ResponseHeader }); ok && r.GetHeader() != nil {
+					r.GetHeader().Timings = etcdserver.RequestTimings(ctx)
+				}
+				return resp, err
+			}
 		}
 
 		return handler(ctx, req)
@@ -79,6 +119,7 @@ func newLogUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerIntercepto
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		startTime := time.Now()
 		resp, err := handler(ctx, req)
+		s.LogRequest(ctx, info.FullMethod, req, err, startTime)
 		lg := s.Logger()
 		if lg != nil { // acquire stats if debug level is enabled or RequestInfo is expensive
 			defer logUnaryRequestStats(ctx, lg, s.Cfg.WarningUnaryRequestDuration, info, startTime, req, resp)
@@ -220,6 +261,10 @@ func newStreamInterceptor(s *etcdserver.EtcdServer) grpc.StreamServerInterceptor
 			return rpctypes.ErrGRPCNotCapable
 		}
 
+		if s.IsMemberExist(s.MemberID()) && s.IsStandby() { // standby does not support any stream RPC
+			return rpctypes.ErrGRPCNotSupportedForStandby
+		}
+
 		if s.IsMemberExist(s.MemberID()) && s.IsLearner() && info.FullMethod != snapshotMethod { // learner does not support stream RPC except Snapshot
 			return rpctypes.ErrGRPCNotSupportedForLearner
 		}
@@ -257,8 +302,26 @@ func newStreamInterceptor(s *etcdserver.EtcdServer) grpc.StreamServerInterceptor
 			}
 		}
 
-		return handler(srv, ss)
+		return handler(srv, checkedServerStream{ServerStream: ss, s: s, method: info.FullMethod})
+	}
+}
+
+// checkedServerStream checks the fields of each request received on a stream
+// against the cluster version.
+type checkedServerStream struct {
+	grpc.ServerStream
+	s      *etcdserver.EtcdServer
+	method string
+}
+
+func (ss checkedServerStream) RecvMsg(m any) error {
+	if err := ss.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := ss.s.CheckRequestFields(ss.method, m); err != nil {
+		return togRPCError(err)
 	}
+	return nil
 }
 
 // cancellableContext wraps a context with new cancellable context that allows a
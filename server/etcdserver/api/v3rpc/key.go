@@ -22,6 +22,8 @@ import (
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/pkg/v3/adt"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api"
+	"go.etcd.io/etcd/server/v3/etcdserver/txn"
 )
 
 type kvServer struct {
@@ -137,6 +139,16 @@ func checkPutRequest(r *pb.PutRequest) error {
 	if r.IgnoreLease && r.Lease != 0 {
 		return rpctypes.ErrGRPCLeaseProvided
 	}
+	if r.SequenceSuffix {
+		if r.IgnoreValue || r.IgnoreLease {
+			return rpctypes.ErrGRPCSequenceWithIgnore
+		}
+		// members older than 3.7 would put the bare prefix; wait for the
+		// cluster version to move past them before accepting any.
+		if !api.IsCapabilityEnabled(api.SequenceCapability) {
+			return rpctypes.ErrGRPCNotCapable
+		}
+	}
 	return nil
 }
 
@@ -144,6 +156,86 @@ func checkDeleteRequest(r *pb.DeleteRangeRequest) error {
 	if len(r.Key) == 0 {
 		return rpctypes.ErrGRPCEmptyKey
 	}
+	if r.MaxDeletions < 0 || r.IfCountLessThan < 0 {
+		return rpctypes.ErrGRPCInvalidDeleteLimit
+	}
+	// members older than 3.7 would ignore the limits and delete the whole
+	// range; wait for the cluster version to move past them before
+	// accepting any.
+	if (r.MaxDeletions != 0 || r.IfCountLessThan != 0) && !api.IsCapabilityEnabled(api.DeleteLimitCapability) {
+		return rpctypes.ErrGRPCNotCapable
+	}
+	return nil
+}
+
+func checkAppendRequest(r *pb.AppendRequest) error {
+	if len(r.Key) == 0 {
+		return rpctypes.ErrGRPCEmptyKey
+	}
+	// members older than 3.7 cannot apply appends; wait for the cluster
+	// version to move past them before accepting any.
+	if !api.IsCapabilityEnabled(api.AppendCapability) {
+		return rpctypes.ErrGRPCNotCapable
+	}
+	return nil
+}
+
+// checkForEachRequest checks the operations of a foreach request, which are
+// applied up to max_keys times, against the maximum number of operations.
+func checkForEachRequest(r *pb.ForEachRequest, maxTxnOps int) error {
+	if len(r.Key) == 0 {
+		return rpctypes.ErrGRPCEmptyKey
+	}
+	if r.MaxKeys <= 0 || len(r.Ops) == 0 {
+		return rpctypes.ErrGRPCInvalidForEach
+	}
+	if r.MaxKeys > int64(maxTxnOps/len(r.Ops)) {
+		return rpctypes.ErrGRPCTooManyOps
+	}
+	for _, c := range r.Compare {
+		if len(c.Key) != 0 || len(c.RangeEnd) != 0 || c.Target == pb.Compare_FENCING_TOKEN {
+			return rpctypes.ErrGRPCInvalidForEach
+		}
+	}
+	writes := 0
+	for _, u := range r.Ops {
+		switch uv := u.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			rr := uv.RequestRange
+			if rr == nil || len(rr.Key) != 0 || len(rr.RangeEnd) != 0 {
+				return rpctypes.ErrGRPCInvalidForEach
+			}
+		case *pb.RequestOp_RequestPut:
+			pr := uv.RequestPut
+			if pr == nil || len(pr.Key) != 0 || pr.SequenceSuffix {
+				return rpctypes.ErrGRPCInvalidForEach
+			}
+			if pr.IgnoreValue && len(pr.Value) != 0 {
+				return rpctypes.ErrGRPCValueProvided
+			}
+			if pr.IgnoreLease && pr.Lease != 0 {
+				return rpctypes.ErrGRPCLeaseProvided
+			}
+			writes++
+		case *pb.RequestOp_RequestDeleteRange:
+			dr := uv.RequestDeleteRange
+			if dr == nil || len(dr.Key) != 0 || len(dr.RangeEnd) != 0 || dr.MaxDeletions != 0 || dr.IfCountLessThan != 0 {
+				return rpctypes.ErrGRPCInvalidForEach
+			}
+			writes++
+		default:
+			return rpctypes.ErrGRPCInvalidForEach
+		}
+	}
+	// every key is written at most once per txn
+	if writes > 1 {
+		return rpctypes.ErrGRPCDuplicateKey
+	}
+	// members older than 3.7 cannot apply foreach; wait for the cluster
+	// version to move past them before accepting any.
+	if !api.IsCapabilityEnabled(api.ForEachCapability) {
+		return rpctypes.ErrGRPCNotCapable
+	}
 	return nil
 }
 
@@ -163,6 +255,21 @@ func checkTxnRequest(r *pb.TxnRequest, maxTxnOps int) error {
 		if len(c.Key) == 0 {
 			return rpctypes.ErrGRPCEmptyKey
 		}
+		if c.Target == pb.Compare_VALUE_INT || c.Target == pb.Compare_VALUE_SEMVER {
+			// members older than 3.7 take unknown targets as equal, so the
+			// txn would take different branches on them.
+			if !api.IsCapabilityEnabled(api.ValueCompareCapability) {
+				return rpctypes.ErrGRPCNotCapable
+			}
+		}
+		if c.Target == pb.Compare_FENCING_TOKEN && !api.IsCapabilityEnabled(api.FencingTokenCapability) {
+			return rpctypes.ErrGRPCNotCapable
+		}
+		if c.Target == pb.Compare_VALUE_SEMVER {
+			if _, err := txn.ParseSemver(c.GetValueSemver()); err != nil {
+				return rpctypes.ErrGRPCInvalidSemver
+			}
+		}
 	}
 	for _, u := range r.Success {
 		if err := checkRequestOp(u, maxTxnOps-opc); err != nil {
@@ -203,6 +310,22 @@ func checkIntervals(reqs []*pb.RequestOp) (map[string]struct{}, adt.IntervalTree
 		dels.Insert(iv, struct{}{})
 	}
 
+	// foreach ranges may write any of their keys, so they must not overlap
+	// the other writes
+	var forEachs []adt.Interval
+	for _, req := range reqs {
+		tv, ok := req.Request.(*pb.RequestOp_RequestForEach)
+		if !ok || tv.RequestForEach == nil {
+			continue
+		}
+		iv := forEachInterval(tv.RequestForEach)
+		if dels.Intersects(iv) {
+			return nil, dels, rpctypes.ErrGRPCDuplicateKey
+		}
+		dels.Insert(iv, struct{}{})
+		forEachs = append(forEachs, iv)
+	}
+
 	// collect children puts/deletes
 	puts := make(map[string]struct{})
 	for _, req := range reqs {
@@ -218,6 +341,11 @@ func checkIntervals(reqs []*pb.RequestOp) (map[string]struct{}, adt.IntervalTree
 		if err != nil {
 			return nil, dels, err
 		}
+		for _, iv := range forEachs {
+			if delsThen.Intersects(iv) || delsElse.Intersects(iv) {
+				return nil, dels, rpctypes.ErrGRPCDuplicateKey
+			}
+		}
 		for k := range putsThen {
 			if _, ok := puts[k]; ok {
 				return nil, dels, rpctypes.ErrGRPCDuplicateKey
@@ -244,13 +372,23 @@ func checkIntervals(reqs []*pb.RequestOp) (map[string]struct{}, adt.IntervalTree
 		dels.Union(delsElse, adt.NewStringAffineInterval("\x00", ""))
 	}
 
-	// collect and check this level's puts
+	// collect and check this level's puts and appends
 	for _, req := range reqs {
-		tv, ok := req.Request.(*pb.RequestOp_RequestPut)
-		if !ok || tv.RequestPut == nil {
+		var k string
+		switch tv := req.Request.(type) {
+		case *pb.RequestOp_RequestPut:
+			if tv.RequestPut == nil {
+				continue
+			}
+			k = string(tv.RequestPut.Key)
+		case *pb.RequestOp_RequestAppend:
+			if tv.RequestAppend == nil {
+				continue
+			}
+			k = string(tv.RequestAppend.Key)
+		default:
 			continue
 		}
-		k := string(tv.RequestPut.Key)
 		if _, ok := puts[k]; ok {
 			return nil, dels, rpctypes.ErrGRPCDuplicateKey
 		}
@@ -262,6 +400,16 @@ func checkIntervals(reqs []*pb.RequestOp) (map[string]struct{}, adt.IntervalTree
 	return puts, dels, nil
 }
 
+func forEachInterval(r *pb.ForEachRequest) adt.Interval {
+	switch {
+	case len(r.RangeEnd) == 0:
+		return adt.NewStringAffinePoint(string(r.Key))
+	case len(r.RangeEnd) == 1 && r.RangeEnd[0] == 0:
+		return adt.NewStringAffineInterval(string(r.Key), "")
+	}
+	return adt.NewStringAffineInterval(string(r.Key), string(r.RangeEnd))
+}
+
 func checkRequestOp(u *pb.RequestOp, maxTxnOps int) error {
 	// TODO: ensure only one of the field is set.
 	switch uv := u.Request.(type) {
@@ -273,6 +421,10 @@ func checkRequestOp(u *pb.RequestOp, maxTxnOps int) error {
 		return checkDeleteRequest(uv.RequestDeleteRange)
 	case *pb.RequestOp_RequestTxn:
 		return checkTxnRequest(uv.RequestTxn, maxTxnOps)
+	case *pb.RequestOp_RequestAppend:
+		return checkAppendRequest(uv.RequestAppend)
+	case *pb.RequestOp_RequestForEach:
+		return checkForEachRequest(uv.RequestForEach, maxTxnOps)
 	default:
 		// empty op / nil entry
 		return rpctypes.ErrGRPCKeyNotFound
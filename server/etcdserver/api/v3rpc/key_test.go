@@ -17,6 +17,8 @@ package v3rpc
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
@@ -66,3 +68,43 @@ func getError(err error) string {
 
 	return err.Error()
 }
+
+func TestCheckTxnRequestNotCapable(t *testing.T) {
+	cmps := []*pb.Compare{
+		{Key: []byte("foo"), Target: pb.Compare_VALUE_INT, TargetUnion: &pb.Compare_ValueInt{ValueInt: 1}},
+		{Key: []byte("foo"), Target: pb.Compare_VALUE_SEMVER, TargetUnion: &pb.Compare_ValueSemver{ValueSemver: "1.0.0"}},
+	}
+	for _, c := range cmps {
+		require.NoError(t, checkTxnRequest(&pb.TxnRequest{Compare: []*pb.Compare{c}}, 128))
+	}
+
+	withClusterVersion(t, "3.6.0")
+	for _, c := range cmps {
+		err := checkTxnRequest(&pb.TxnRequest{Compare: []*pb.Compare{c}}, 128)
+		require.ErrorIs(t, err, rpctypes.ErrGRPCNotCapable)
+	}
+	c := &pb.Compare{Key: []byte("foo"), Target: pb.Compare_FENCING_TOKEN, TargetUnion: &pb.Compare_FencingToken{FencingToken: 1}}
+	require.ErrorIs(t, checkTxnRequest(&pb.TxnRequest{Compare: []*pb.Compare{c}}, 128), rpctypes.ErrGRPCNotCapable)
+
+	// the targets of older members are still accepted
+	c = &pb.Compare{Key: []byte("foo"), Target: pb.Compare_VALUE, TargetUnion: &pb.Compare_Value{Value: []byte("bar")}}
+	require.NoError(t, checkTxnRequest(&pb.TxnRequest{Compare: []*pb.Compare{c}}, 128))
+}
+
+func TestCheckDeleteRequestNotCapable(t *testing.T) {
+	reqs := []*pb.DeleteRangeRequest{
+		{Key: []byte("foo"), RangeEnd: []byte("fop"), MaxDeletions: 1},
+		{Key: []byte("foo"), RangeEnd: []byte("fop"), IfCountLessThan: 1},
+	}
+	for _, r := range reqs {
+		require.NoError(t, checkDeleteRequest(r))
+	}
+
+	withClusterVersion(t, "3.6.0")
+	for _, r := range reqs {
+		require.ErrorIs(t, checkDeleteRequest(r), rpctypes.ErrGRPCNotCapable)
+		txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: r}}}}
+		require.ErrorIs(t, checkTxnRequest(txn, 128), rpctypes.ErrGRPCNotCapable)
+	}
+	require.NoError(t, checkDeleteRequest(&pb.DeleteRangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop")}))
+}
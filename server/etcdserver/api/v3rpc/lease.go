@@ -143,6 +143,9 @@ func (ls *LeaseServer) leaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) erro
 		}
 
 		resp.TTL = ttl
+		if ttl > 0 {
+			resp.FencingToken = ls.le.LeaseFencingToken(lease.LeaseID(req.ID))
+		}
 		err = stream.Send(resp)
 		if err != nil {
 			if isClientCtxErr(stream.Context().Err(), err) {
@@ -15,6 +15,7 @@
 package v3rpc
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	errorspkg "errors"
@@ -23,12 +24,16 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
+	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api"
 	"go.etcd.io/etcd/server/v3/etcdserver/apply"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	serverversion "go.etcd.io/etcd/server/v3/etcdserver/version"
@@ -53,6 +58,62 @@ type Alarmer interface {
 	Alarm(ctx context.Context, ar *pb.AlarmRequest) (*pb.AlarmResponse, error)
 }
 
+type CompactionHolder interface {
+	CompactionHoldGrant(ctx context.Context, r *pb.CompactionHoldGrantRequest) (*pb.CompactionHoldGrantResponse, error)
+	CompactionHoldRevoke(ctx context.Context, r *pb.CompactionHoldRevokeRequest) (*pb.CompactionHoldRevokeResponse, error)
+	CompactionHolds() []*pb.CompactionHold
+}
+
+type GarbageCollector interface {
+	GarbageCollect(ctx context.Context, r *pb.GarbageCollectRequest) (*pb.GarbageCollectResponse, error)
+}
+
+type MemoryAccountant interface {
+	MemoryStats(ctx context.Context, r *pb.MemoryStatsRequest) (*pb.MemoryStatsResponse, error)
+}
+
+type StorageStatsGetter interface {
+	StorageStats(ctx context.Context, r *pb.StorageStatsRequest) (*pb.StorageStatsResponse, error)
+}
+
+type HotKeysTracker interface {
+	HotKeys(ctx context.Context, r *pb.HotKeysRequest) (*pb.HotKeysResponse, error)
+}
+
+type NewerFieldsReporter interface {
+	NewerFields(ctx context.Context, r *pb.NewerFieldsRequest) (*pb.NewerFieldsResponse, error)
+}
+
+type Checkpointer interface {
+	Checkpoint(ctx context.Context, r *pb.CheckpointRequest) (*pb.CheckpointResponse, error)
+}
+
+type Drainer interface {
+	DrainMember(ctx context.Context, r *pb.DrainMemberRequest) (*pb.DrainMemberResponse, error)
+}
+
+type Reseeder interface {
+	Reseed(ctx context.Context, r *pb.ReseedRequest) (*pb.ReseedResponse, error)
+}
+
+type PrefixFencer interface {
+	FencePrefix(ctx context.Context, r *pb.FencePrefixRequest) (*pb.FencePrefixResponse, error)
+}
+
+type RequestLogger interface {
+	SetRequestLogging(ctx context.Context, r *pb.SetRequestLoggingRequest) (*pb.SetRequestLoggingResponse, error)
+}
+
+type JobManager interface {
+	Jobs() []*pb.Job
+	Job(id int64) (*pb.Job, error)
+	CancelJob(id int64) (*pb.Job, error)
+}
+
+type Defragmenter interface {
+	Defragment() error
+}
+
 type Downgrader interface {
 	Downgrade(ctx context.Context, dr *pb.DowngradeRequest) (*pb.DowngradeResponse, error)
 }
@@ -79,9 +140,24 @@ type maintenanceServer struct {
 	hdr    header
 	cs     ClusterStatusGetter
 	d      Downgrader
+	ch     CompactionHolder
+	gc     GarbageCollector
+	ma     MemoryAccountant
+	hk     HotKeysTracker
+	jm     JobManager
+	nf     NewerFieldsReporter
+	cp     Checkpointer
+	dr     Drainer
+	rs     Reseeder
+	pf     PrefixFencer
+	rl     RequestLogger
+	ss     StorageStatsGetter
+	kg     KVGetter
+	df     Defragmenter
 	vs     serverversion.Server
 	cg     ConfigGetter
 
+	snapshots      *resumableSnapshots
 	healthNotifier notifier
 }
 
@@ -96,6 +172,20 @@ func NewMaintenanceServer(s *etcdserver.EtcdServer, healthNotifier notifier) pb.
 		hdr:            newHeader(s),
 		cs:             s,
 		d:              s,
+		ch:             s,
+		gc:             s,
+		ma:             s,
+		hk:             s,
+		jm:             s,
+		nf:             s,
+		cp:             s,
+		dr:             s,
+		rs:             s,
+		pf:             s,
+		rl:             s,
+		ss:             s,
+		kg:             s,
+		df:             s,
 		vs:             etcdserver.NewServerVersionAdapter(s),
 		healthNotifier: healthNotifier,
 		cg:             s,
@@ -103,6 +193,11 @@ func NewMaintenanceServer(s *etcdserver.EtcdServer, healthNotifier notifier) pb.
 	if srv.lg == nil {
 		srv.lg = zap.NewNop()
 	}
+	srv.snapshots = newResumableSnapshots(srv.lg)
+	go func() {
+		<-s.StoppingNotify()
+		srv.snapshots.stop()
+	}()
 	return &authMaintenanceServer{srv, &AuthAdmin{s}}
 }
 
@@ -110,7 +205,9 @@ func (ms *maintenanceServer) Defragment(ctx context.Context, sr *pb.DefragmentRe
 	ms.lg.Info("starting defragment")
 	ms.healthNotifier.defragStarted()
 	defer ms.healthNotifier.defragFinished()
-	err := ms.bg.Backend().Defrag()
+	// kept snapshots would block defragmentation until they expire
+	ms.snapshots.releaseAll()
+	err := ms.df.Defragment()
 	if err != nil {
 		ms.lg.Warn("failed to defragment", zap.Error(err))
 		return nil, togRPCError(err)
@@ -123,21 +220,42 @@ func (ms *maintenanceServer) Defragment(ctx context.Context, sr *pb.DefragmentRe
 const snapshotSendBufferSize = 32 * 1024
 
 func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance_SnapshotServer) error {
-	ver := schema.ReadStorageVersion(ms.bg.Backend().ReadTx())
-	storageVersion := ""
-	if ver != nil {
-		storageVersion = ver.String()
+	if sr.Offset > 0 && (sr.ResumeId == 0 || len(sr.OffsetSha256) != sha256.Size) {
+		return rpctypes.ErrGRPCInvalidSnapshotOffset
+	}
+	var rs *resumableSnapshot
+	if sr.ResumeId != 0 {
+		if rs = ms.snapshots.take(sr.ResumeId); rs == nil {
+			return rpctypes.ErrGRPCSnapshotNotFound
+		}
+	} else {
+		ver := schema.ReadStorageVersion(ms.bg.Backend().ReadTx())
+		storageVersion := ""
+		if ver != nil {
+			storageVersion = ver.String()
+		}
+		rs = ms.snapshots.newSnapshot(ms.bg.Backend().Snapshot(), storageVersion)
 	}
-	snap := ms.bg.Backend().Snapshot()
+	storageVersion := rs.storageVersion
 	pr, pw := io.Pipe()
 
-	defer pr.Close()
+	// keep the snapshot if the transfer is interrupted after it was
+	// successfully resumed, release it otherwise
+	resumable, completed := false, false
+	donec := make(chan struct{})
+	defer func() {
+		pr.Close()
+		<-donec
+		if resumable && !completed {
+			ms.snapshots.keep(rs)
+		} else {
+			ms.snapshots.release(rs)
+		}
+	}()
 
 	go func() {
-		snap.WriteTo(pw)
-		if err := snap.Close(); err != nil {
-			ms.lg.Warn("failed to close snapshot", zap.Error(err))
-		}
+		defer close(donec)
+		rs.snap.WriteTo(pw)
 		pw.Close()
 	}()
 
@@ -146,14 +264,38 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 	h := sha256.New()
 
 	sent := int64(0)
-	total := snap.Size()
+	total := rs.snap.Size()
 	size := humanize.Bytes(uint64(total))
 
+	if sr.Offset > 0 {
+		if sr.Offset > uint64(total) {
+			return rpctypes.ErrGRPCInvalidSnapshotOffset
+		}
+		// the skipped bytes are still hashed, so that the digest sent at the
+		// end covers the whole snapshot
+		if _, err := io.CopyN(h, pr, int64(sr.Offset)); err != nil {
+			return togRPCError(err)
+		}
+		if !bytes.Equal(h.Sum(nil), sr.OffsetSha256) {
+			return rpctypes.ErrGRPCSnapshotMismatch
+		}
+		sent = int64(sr.Offset)
+	}
+	resumable = true
+
+	var limiter *rate.Limiter
+	if sr.RateLimit > 0 {
+		limiter = rate.NewLimiter(rate.Limit(sr.RateLimit), snapshotSendBufferSize)
+	}
+
 	start := time.Now()
 	ms.lg.Info("sending database snapshot to client",
 		zap.Int64("total-bytes", total),
 		zap.String("size", size),
 		zap.String("storage-version", storageVersion),
+		zap.Uint64("snapshot-id", rs.id),
+		zap.Uint64("offset", sr.Offset),
+		zap.Uint64("rate-limit", sr.RateLimit),
 	)
 	for total-sent > 0 {
 		// buffer just holds read bytes from stream
@@ -168,6 +310,11 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 			return togRPCError(err)
 		}
 		sent += int64(n)
+		if limiter != nil {
+			if err = limiter.WaitN(srv.Context(), n); err != nil {
+				return togRPCError(err)
+			}
+		}
 
 		// if total is x * snapshotSendBufferSize. it is possible that
 		// resp.RemainingBytes == 0
@@ -181,6 +328,8 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 			RemainingBytes: uint64(total - sent),
 			Blob:           buf[:n],
 			Version:        storageVersion,
+			Offset:         sr.Offset,
+			SnapshotId:     rs.id,
 		}
 		if err = srv.Send(resp); err != nil {
 			return togRPCError(err)
@@ -196,10 +345,11 @@ func (ms *maintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance
 		zap.Int64("total-bytes", total),
 		zap.Int("checksum-size", len(sha)),
 	)
-	hresp := &pb.SnapshotResponse{RemainingBytes: 0, Blob: sha, Version: storageVersion}
+	hresp := &pb.SnapshotResponse{RemainingBytes: 0, Blob: sha, Version: storageVersion, Offset: sr.Offset, SnapshotId: rs.id}
 	if err := srv.Send(hresp); err != nil {
 		return togRPCError(err)
 	}
+	completed = true
 
 	ms.lg.Info("successfully sent database snapshot to client",
 		zap.Int64("total-bytes", total),
@@ -236,6 +386,25 @@ func (ms *maintenanceServer) HashKV(ctx context.Context, r *pb.HashKVRequest) (*
 	return resp, nil
 }
 
+func (ms *maintenanceServer) VerifySnapshot(ctx context.Context, r *pb.VerifySnapshotRequest) (*pb.VerifySnapshotResponse, error) {
+	if r.Revision <= 0 {
+		return nil, rpctypes.ErrGRPCSnapshotNoRevision
+	}
+	h, rev, err := ms.hasher.HashByRev(r.Revision)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+
+	resp := &pb.VerifySnapshotResponse{
+		Header:          &pb.ResponseHeader{Revision: rev},
+		Match:           h.Hash == r.Hash && h.CompactRevision == r.CompactRevision,
+		Hash:            h.Hash,
+		CompactRevision: h.CompactRevision,
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
 func (ms *maintenanceServer) Alarm(ctx context.Context, ar *pb.AlarmRequest) (*pb.AlarmResponse, error) {
 	resp, err := ms.a.Alarm(ctx, ar)
 	if err != nil {
@@ -303,13 +472,236 @@ func (ms *maintenanceServer) Downgrade(ctx context.Context, r *pb.DowngradeReque
 	return resp, nil
 }
 
+func (ms *maintenanceServer) CompactionHoldGrant(ctx context.Context, r *pb.CompactionHoldGrantRequest) (*pb.CompactionHoldGrantResponse, error) {
+	// members older than 3.7 cannot apply compaction holds
+	if !api.IsCapabilityEnabled(api.CompactionHoldCapability) {
+		return nil, rpctypes.ErrGRPCNotCapable
+	}
+	resp, err := ms.ch.CompactionHoldGrant(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) CompactionHoldRevoke(ctx context.Context, r *pb.CompactionHoldRevokeRequest) (*pb.CompactionHoldRevokeResponse, error) {
+	if !api.IsCapabilityEnabled(api.CompactionHoldCapability) {
+		return nil, rpctypes.ErrGRPCNotCapable
+	}
+	resp, err := ms.ch.CompactionHoldRevoke(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) CompactionHoldList(ctx context.Context, r *pb.CompactionHoldListRequest) (*pb.CompactionHoldListResponse, error) {
+	resp := &pb.CompactionHoldListResponse{Header: &pb.ResponseHeader{}, Holds: ms.ch.CompactionHolds()}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) GarbageCollect(ctx context.Context, r *pb.GarbageCollectRequest) (*pb.GarbageCollectResponse, error) {
+	resp, err := ms.gc.GarbageCollect(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) MemoryStats(ctx context.Context, r *pb.MemoryStatsRequest) (*pb.MemoryStatsResponse, error) {
+	resp, err := ms.ma.MemoryStats(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) HotKeys(ctx context.Context, r *pb.HotKeysRequest) (*pb.HotKeysResponse, error) {
+	resp, err := ms.hk.HotKeys(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) JobList(ctx context.Context, r *pb.JobListRequest) (*pb.JobListResponse, error) {
+	resp := &pb.JobListResponse{Header: &pb.ResponseHeader{}, Jobs: ms.jm.Jobs()}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) JobStatus(ctx context.Context, r *pb.JobStatusRequest) (*pb.JobStatusResponse, error) {
+	job, err := ms.jm.Job(r.ID)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.JobStatusResponse{Header: &pb.ResponseHeader{}, Job: job}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) JobCancel(ctx context.Context, r *pb.JobCancelRequest) (*pb.JobCancelResponse, error) {
+	job, err := ms.jm.CancelJob(r.ID)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.JobCancelResponse{Header: &pb.ResponseHeader{}, Job: job}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) NewerFields(ctx context.Context, r *pb.NewerFieldsRequest) (*pb.NewerFieldsResponse, error) {
+	resp, err := ms.nf.NewerFields(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) Checkpoint(ctx context.Context, r *pb.CheckpointRequest) (*pb.CheckpointResponse, error) {
+	resp, err := ms.cp.Checkpoint(ctx, r)
+	if err != nil {
+		ms.lg.Warn("failed to write backend checkpoint", zap.Error(err))
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) DrainMember(ctx context.Context, r *pb.DrainMemberRequest) (*pb.DrainMemberResponse, error) {
+	resp, err := ms.dr.DrainMember(ctx, r)
+	if err != nil {
+		ms.lg.Warn("failed to update draining mark", zap.Bool("undrain", r.Undrain), zap.Error(err))
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) Reseed(ctx context.Context, r *pb.ReseedRequest) (*pb.ReseedResponse, error) {
+	resp, err := ms.rs.Reseed(ctx, r)
+	if err != nil {
+		ms.lg.Warn("failed to reseed backend", zap.Error(err))
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) FencePrefix(ctx context.Context, r *pb.FencePrefixRequest) (*pb.FencePrefixResponse, error) {
+	// members older than 3.7 cannot apply fences
+	if !api.IsCapabilityEnabled(api.FenceCapability) {
+		return nil, rpctypes.ErrGRPCNotCapable
+	}
+	if len(r.Prefix) == 0 {
+		return nil, rpctypes.ErrGRPCEmptyKey
+	}
+	resp, err := ms.pf.FencePrefix(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) SetRequestLogging(ctx context.Context, r *pb.SetRequestLoggingRequest) (*pb.SetRequestLoggingResponse, error) {
+	if r.Duration < 0 || r.Duration > int64(etcdserver.MaxRequestLoggingDuration/time.Second) || r.SampleRate < 0 || r.SampleRate > 1 {
+		return nil, rpctypes.ErrGRPCInvalidRequestLogging
+	}
+	resp, err := ms.rl.SetRequestLogging(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) StorageStats(ctx context.Context, r *pb.StorageStatsRequest) (*pb.StorageStatsResponse, error) {
+	resp, err := ms.ss.StorageStats(ctx, r)
+	if err != nil {
+		ms.lg.Warn("failed to get storage stats", zap.Error(err))
+		return nil, togRPCError(err)
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+// exportPageSize and exportPageBytes bound the key-value pairs sent in each
+// response of an export stream.
+const (
+	exportPageSize  = 1000
+	exportPageBytes = 1024 * 1024
+)
+
+func (ms *maintenanceServer) Export(r *pb.ExportRequest, srv pb.Maintenance_ExportServer) error {
+	if len(r.Key) == 0 {
+		return rpctypes.ErrGRPCEmptyKey
+	}
+	end := r.RangeEnd
+	if len(end) == 1 && end[0] == 0 {
+		// support >= key ranges
+		end = []byte{}
+	}
+	start := time.Now()
+	var (
+		resp  *pb.ExportResponse
+		size  int
+		total int
+	)
+	send := func() error {
+		if total == 0 {
+			resp.Header = &pb.ResponseHeader{}
+			ms.hdr.fill(resp.Header)
+		}
+		total += len(resp.Kvs)
+		err := srv.Send(resp)
+		resp, size = nil, 0
+		return err
+	}
+	rev, err := ms.kg.KV().Export(srv.Context(), r.Key, end, r.Revision, func(rev int64, kv *mvccpb.KeyValue) error {
+		if resp == nil {
+			resp = &pb.ExportResponse{Revision: rev}
+		}
+		resp.Kvs = append(resp.Kvs, kv)
+		size += kv.Size()
+		if len(resp.Kvs) < exportPageSize && size < exportPageBytes {
+			return nil
+		}
+		return send()
+	})
+	if err != nil {
+		return togRPCError(err)
+	}
+	if resp != nil || total == 0 {
+		if resp == nil {
+			resp = &pb.ExportResponse{Revision: rev}
+		}
+		if err = send(); err != nil {
+			return togRPCError(err)
+		}
+	}
+	ms.lg.Info("exported keys to client",
+		zap.Int64("revision", rev),
+		zap.Int("keys", total),
+		zap.Duration("took", time.Since(start)),
+	)
+	return nil
+}
+
 type authMaintenanceServer struct {
 	*maintenanceServer
 	*AuthAdmin
 }
 
 func (ams *authMaintenanceServer) Defragment(ctx context.Context, sr *pb.DefragmentRequest) (*pb.DefragmentResponse, error) {
-	if err := ams.isPermitted(ctx); err != nil {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceDefragment); err != nil {
 		return nil, togRPCError(err)
 	}
 
@@ -317,7 +709,7 @@ func (ams *authMaintenanceServer) Defragment(ctx context.Context, sr *pb.Defragm
 }
 
 func (ams *authMaintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Maintenance_SnapshotServer) error {
-	if err := ams.isPermitted(srv.Context()); err != nil {
+	if err := ams.isPermitted(srv.Context(), auth.RPCMaintenanceSnapshot); err != nil {
 		return togRPCError(err)
 	}
 
@@ -325,7 +717,7 @@ func (ams *authMaintenanceServer) Snapshot(sr *pb.SnapshotRequest, srv pb.Mainte
 }
 
 func (ams *authMaintenanceServer) Hash(ctx context.Context, r *pb.HashRequest) (*pb.HashResponse, error) {
-	if err := ams.isPermitted(ctx); err != nil {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceHash); err != nil {
 		return nil, togRPCError(err)
 	}
 
@@ -333,14 +725,32 @@ func (ams *authMaintenanceServer) Hash(ctx context.Context, r *pb.HashRequest) (
 }
 
 func (ams *authMaintenanceServer) HashKV(ctx context.Context, r *pb.HashKVRequest) (*pb.HashKVResponse, error) {
-	if err := ams.isPermitted(ctx); err != nil {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceHashKV); err != nil {
 		return nil, togRPCError(err)
 	}
 	return ams.maintenanceServer.HashKV(ctx, r)
 }
 
+func (ams *authMaintenanceServer) VerifySnapshot(ctx context.Context, r *pb.VerifySnapshotRequest) (*pb.VerifySnapshotResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceVerifySnapshot); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.VerifySnapshot(ctx, r)
+}
+
+// Alarm only requires a permission for disarming; listing alarms and
+// activating them stays open as before.
+func (ams *authMaintenanceServer) Alarm(ctx context.Context, ar *pb.AlarmRequest) (*pb.AlarmResponse, error) {
+	if ar.Action == pb.AlarmRequest_DEACTIVATE {
+		if err := ams.isPermitted(ctx, auth.RPCMaintenanceAlarmDisarm); err != nil {
+			return nil, togRPCError(err)
+		}
+	}
+	return ams.maintenanceServer.Alarm(ctx, ar)
+}
+
 func (ams *authMaintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (*pb.StatusResponse, error) {
-	if err := ams.isPermitted(ctx); err != nil {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceStatus); err != nil {
 		return nil, togRPCError(err)
 	}
 
@@ -348,7 +758,7 @@ func (ams *authMaintenanceServer) Status(ctx context.Context, ar *pb.StatusReque
 }
 
 func (ams *authMaintenanceServer) MoveLeader(ctx context.Context, tr *pb.MoveLeaderRequest) (*pb.MoveLeaderResponse, error) {
-	if err := ams.isPermitted(ctx); err != nil {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceMoveLeader); err != nil {
 		return nil, togRPCError(err)
 	}
 
@@ -356,9 +766,131 @@ func (ams *authMaintenanceServer) MoveLeader(ctx context.Context, tr *pb.MoveLea
 }
 
 func (ams *authMaintenanceServer) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
-	if err := ams.isPermitted(ctx); err != nil {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceDowngrade); err != nil {
 		return nil, togRPCError(err)
 	}
 
 	return ams.maintenanceServer.Downgrade(ctx, r)
 }
+
+// Compaction holds are meant for regular consumers of the keyspace, so they
+// only require an authenticated user rather than admin privilege.
+
+func (ams *authMaintenanceServer) CompactionHoldGrant(ctx context.Context, r *pb.CompactionHoldGrantRequest) (*pb.CompactionHoldGrantResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.CompactionHoldGrant(ctx, r)
+}
+
+func (ams *authMaintenanceServer) CompactionHoldRevoke(ctx context.Context, r *pb.CompactionHoldRevokeRequest) (*pb.CompactionHoldRevokeResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.CompactionHoldRevoke(ctx, r)
+}
+
+func (ams *authMaintenanceServer) CompactionHoldList(ctx context.Context, r *pb.CompactionHoldListRequest) (*pb.CompactionHoldListResponse, error) {
+	if err := ams.isAuthenticated(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.CompactionHoldList(ctx, r)
+}
+
+func (ams *authMaintenanceServer) GarbageCollect(ctx context.Context, r *pb.GarbageCollectRequest) (*pb.GarbageCollectResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceGarbageCollect); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.GarbageCollect(ctx, r)
+}
+
+func (ams *authMaintenanceServer) MemoryStats(ctx context.Context, r *pb.MemoryStatsRequest) (*pb.MemoryStatsResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceMemoryStats); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.MemoryStats(ctx, r)
+}
+
+func (ams *authMaintenanceServer) HotKeys(ctx context.Context, r *pb.HotKeysRequest) (*pb.HotKeysResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceHotKeys); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.HotKeys(ctx, r)
+}
+
+func (ams *authMaintenanceServer) JobList(ctx context.Context, r *pb.JobListRequest) (*pb.JobListResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceJobList); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.JobList(ctx, r)
+}
+
+func (ams *authMaintenanceServer) JobStatus(ctx context.Context, r *pb.JobStatusRequest) (*pb.JobStatusResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceJobStatus); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.JobStatus(ctx, r)
+}
+
+func (ams *authMaintenanceServer) JobCancel(ctx context.Context, r *pb.JobCancelRequest) (*pb.JobCancelResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceJobCancel); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.JobCancel(ctx, r)
+}
+
+func (ams *authMaintenanceServer) NewerFields(ctx context.Context, r *pb.NewerFieldsRequest) (*pb.NewerFieldsResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceNewerFields); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.NewerFields(ctx, r)
+}
+
+func (ams *authMaintenanceServer) Checkpoint(ctx context.Context, r *pb.CheckpointRequest) (*pb.CheckpointResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceCheckpoint); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.Checkpoint(ctx, r)
+}
+
+func (ams *authMaintenanceServer) DrainMember(ctx context.Context, r *pb.DrainMemberRequest) (*pb.DrainMemberResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceDrainMember); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.DrainMember(ctx, r)
+}
+
+func (ams *authMaintenanceServer) Export(r *pb.ExportRequest, srv pb.Maintenance_ExportServer) error {
+	if err := ams.isPermitted(srv.Context(), auth.RPCMaintenanceExport); err != nil {
+		return togRPCError(err)
+	}
+	return ams.maintenanceServer.Export(r, srv)
+}
+
+func (ams *authMaintenanceServer) Reseed(ctx context.Context, r *pb.ReseedRequest) (*pb.ReseedResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceReseed); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.Reseed(ctx, r)
+}
+
+func (ams *authMaintenanceServer) FencePrefix(ctx context.Context, r *pb.FencePrefixRequest) (*pb.FencePrefixResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceFencePrefix); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.FencePrefix(ctx, r)
+}
+
+func (ams *authMaintenanceServer) SetRequestLogging(ctx context.Context, r *pb.SetRequestLoggingRequest) (*pb.SetRequestLoggingResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceRequestLogging); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.SetRequestLogging(ctx, r)
+}
+
+func (ams *authMaintenanceServer) StorageStats(ctx context.Context, r *pb.StorageStatsRequest) (*pb.StorageStatsResponse, error) {
+	if err := ams.isPermitted(ctx, auth.RPCMaintenanceStorageStats); err != nil {
+		return nil, togRPCError(err)
+	}
+	return ams.maintenanceServer.StorageStats(ctx, r)
+}
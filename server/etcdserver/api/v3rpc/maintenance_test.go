@@ -0,0 +1,51 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/server/v3/etcdserver/api"
+)
+
+// withClusterVersion enables the capabilities of the cluster version v for
+// the duration of the test.
+func withClusterVersion(t *testing.T, v string) {
+	api.UpdateCapability(nil, semver.New(v))
+	t.Cleanup(func() { api.UpdateCapability(nil, semver.New("3.7.0")) })
+}
+
+func TestCompactionHoldNotCapable(t *testing.T) {
+	withClusterVersion(t, "3.6.0")
+	ms := &maintenanceServer{}
+
+	_, err := ms.CompactionHoldGrant(t.Context(), &pb.CompactionHoldGrantRequest{TTL: 10})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCNotCapable)
+	_, err = ms.CompactionHoldRevoke(t.Context(), &pb.CompactionHoldRevokeRequest{ID: 1})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCNotCapable)
+}
+
+func TestFencePrefixNotCapable(t *testing.T) {
+	withClusterVersion(t, "3.6.0")
+	ms := &maintenanceServer{}
+
+	_, err := ms.FencePrefix(t.Context(), &pb.FencePrefixRequest{Prefix: []byte("foo"), TTL: 10})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCNotCapable)
+}
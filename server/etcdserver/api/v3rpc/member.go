@@ -46,11 +46,31 @@ func (cs *ClusterServer) MemberAdd(ctx context.Context, r *pb.MemberAddRequest)
 
 	now := time.Now()
 	var m *membership.Member
-	if r.IsLearner {
+	switch {
+	case r.IsStandby:
+		m = membership.NewMemberAsStandby("", urls, "", &now)
+	case r.IsLearner:
 		m = membership.NewMemberAsLearner("", urls, "", &now)
-	} else {
+	default:
 		m = membership.NewMember("", urls, "", &now)
 	}
+	if r.DryRun {
+		membs, impact, err := cs.server.DryRunAddMember(ctx, *m)
+		if err != nil {
+			return nil, togRPCError(err)
+		}
+		return &pb.MemberAddResponse{
+			Header: cs.header(),
+			Member: &pb.Member{
+				ID:        uint64(m.ID),
+				PeerURLs:  m.PeerURLs,
+				IsLearner: m.IsLearner,
+				IsStandby: m.IsStandby,
+			},
+			Members:      membersToProtoMembers(membs),
+			QuorumImpact: impact,
+		}, nil
+	}
 	membs, merr := cs.server.AddMember(ctx, *m)
 	if merr != nil {
 		return nil, togRPCError(merr)
@@ -62,6 +82,7 @@ func (cs *ClusterServer) MemberAdd(ctx context.Context, r *pb.MemberAddRequest)
 			ID:        uint64(m.ID),
 			PeerURLs:  m.PeerURLs,
 			IsLearner: m.IsLearner,
+			IsStandby: m.IsStandby,
 		},
 		Members: membersToProtoMembers(membs),
 	}, nil
@@ -80,6 +101,24 @@ func (cs *ClusterServer) MemberUpdate(ctx context.Context, r *pb.MemberUpdateReq
 		ID:             types.ID(r.ID),
 		RaftAttributes: membership.RaftAttributes{PeerURLs: r.PeerURLs},
 	}
+	// the update replaces all raft attributes, so keep what is not updated
+	if cur := cs.cluster.Member(m.ID); cur != nil {
+		if len(m.PeerURLs) == 0 && (r.UpdateLabels || r.UpdateLeadership) {
+			m.PeerURLs = cur.PeerURLs
+		}
+		m.Labels = cur.Labels
+		m.LeadershipDisallowed = cur.LeadershipDisallowed
+		m.Draining = cur.Draining
+	}
+	if r.UpdateLabels {
+		if err := membership.ValidateLabels(r.Labels); err != nil {
+			return nil, togRPCError(err)
+		}
+		m.Labels = r.Labels
+	}
+	if r.UpdateLeadership {
+		m.LeadershipDisallowed = r.LeadershipDisallowed
+	}
 	membs, err := cs.server.UpdateMember(ctx, m)
 	if err != nil {
 		return nil, togRPCError(err)
@@ -98,13 +137,38 @@ func (cs *ClusterServer) MemberList(ctx context.Context, r *pb.MemberListRequest
 }
 
 func (cs *ClusterServer) MemberPromote(ctx context.Context, r *pb.MemberPromoteRequest) (*pb.MemberPromoteResponse, error) {
-	membs, err := cs.server.PromoteMember(ctx, r.ID)
+	var membs []*membership.Member
+	var err error
+	if r.ReplaceID != 0 {
+		// older members cannot apply the joint configuration change replacing a member
+		if !api.IsCapabilityEnabled(api.ReplaceMemberCapability) {
+			return nil, rpctypes.ErrGRPCNotCapable
+		}
+		membs, err = cs.server.PromoteMemberReplacing(ctx, r.ID, r.ReplaceID)
+	} else {
+		membs, err = cs.server.PromoteMember(ctx, r.ID)
+	}
 	if err != nil {
 		return nil, togRPCError(err)
 	}
 	return &pb.MemberPromoteResponse{Header: cs.header(), Members: membersToProtoMembers(membs)}, nil
 }
 
+func (cs *ClusterServer) ClusterConfigGet(ctx context.Context, r *pb.ClusterConfigGetRequest) (*pb.ClusterConfigGetResponse, error) {
+	return &pb.ClusterConfigGetResponse{Header: cs.header(), Config: cs.server.ClusterConfig()}, nil
+}
+
+func (cs *ClusterServer) ClusterConfigSet(ctx context.Context, r *pb.ClusterConfigSetRequest) (*pb.ClusterConfigSetResponse, error) {
+	if !api.IsCapabilityEnabled(api.ClusterConfigCapability) {
+		return nil, rpctypes.ErrGRPCNotCapable
+	}
+	resp, err := cs.server.ClusterConfigSet(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	return resp, nil
+}
+
 func (cs *ClusterServer) header() *pb.ResponseHeader {
 	return &pb.ResponseHeader{ClusterId: uint64(cs.cluster.ID()), MemberId: uint64(cs.server.MemberID()), RaftTerm: cs.server.Term()}
 }
@@ -118,6 +182,11 @@ func membersToProtoMembers(membs []*membership.Member) []*pb.Member {
 			PeerURLs:   membs[i].PeerURLs,
 			ClientURLs: membs[i].ClientURLs,
 			IsLearner:  membs[i].IsLearner,
+			IsStandby:  membs[i].IsStandby,
+			Labels:     membs[i].Labels,
+
+			LeadershipDisallowed: membs[i].LeadershipDisallowed,
+			IsDraining:           membs[i].Draining,
 		}
 	}
 	return protoMembs
@@ -15,9 +15,21 @@
 package v3rpc
 
 import (
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const (
+	// maxWatchIdentities bounds the cardinality of the identity label of
+	// watch metrics. Identities seen after the limit is reached are reported
+	// as watchIdentityOther.
+	maxWatchIdentities = 100
+
+	watchIdentityAnonymous = "anonymous"
+	watchIdentityOther     = "other"
+)
+
 var (
 	sentBytes = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd",
@@ -33,6 +45,23 @@ var (
 		Help:      "The total number of bytes received from grpc clients.",
 	})
 
+	compressedResponses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "network",
+			Name:      "client_grpc_compressed_responses_total",
+			Help:      "The total number of grpc responses compressed by the server, by compressor.",
+		},
+		[]string{"compressor"},
+	)
+
+	compressionSavedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "network",
+		Name:      "client_grpc_compression_saved_bytes_total",
+		Help:      "The total number of bytes saved by compressing the messages sent to grpc clients.",
+	})
+
 	streamFailures = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "etcd",
@@ -52,11 +81,67 @@ var (
 		},
 		[]string{"type", "client_api_version"},
 	)
+
+	watchersByIdentity = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "server",
+			Name:      "watchers_by_identity",
+			Help:      "The number of active watchers by auth user or client certificate common name.",
+		},
+		[]string{"identity"},
+	)
+
+	watchEventsSentByIdentity = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "server",
+			Name:      "watch_events_sent_by_identity_total",
+			Help:      "The total number of watch events sent by auth user or client certificate common name.",
+		},
+		[]string{"identity"},
+	)
+
+	watchEventsDroppedByIdentity = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "server",
+			Name:      "watch_events_dropped_by_identity_total",
+			Help:      "The total number of watch events not delivered because the watch stream was closed, by auth user or client certificate common name.",
+		},
+		[]string{"identity"},
+	)
+
+	watchIdentitiesMu sync.Mutex
+	watchIdentities   = make(map[string]struct{})
 )
 
+// watchIdentityLabel returns the metric label for the given watcher identity,
+// keeping the number of distinct labels bounded.
+func watchIdentityLabel(identity string) string {
+	if identity == "" {
+		return watchIdentityAnonymous
+	}
+	watchIdentitiesMu.Lock()
+	defer watchIdentitiesMu.Unlock()
+	if _, ok := watchIdentities[identity]; ok {
+		return identity
+	}
+	if len(watchIdentities) >= maxWatchIdentities {
+		return watchIdentityOther
+	}
+	watchIdentities[identity] = struct{}{}
+	return identity
+}
+
 func init() {
 	prometheus.MustRegister(sentBytes)
 	prometheus.MustRegister(receivedBytes)
+	prometheus.MustRegister(compressedResponses)
+	prometheus.MustRegister(compressionSavedBytes)
 	prometheus.MustRegister(streamFailures)
 	prometheus.MustRegister(clientRequests)
+	prometheus.MustRegister(watchersByIdentity)
+	prometheus.MustRegister(watchEventsSentByIdentity)
+	prometheus.MustRegister(watchEventsDroppedByIdentity)
 }
@@ -33,11 +33,18 @@ type quotaAlarmer struct {
 	q  storage.Quota
 	a  Alarmer
 	id types.ID
+	// walDiskLow reports whether the free disk space of the WAL is low.
+	walDiskLow func() bool
 }
 
 // check whether request satisfies the quota. If there is not enough space,
-// ignore request and raise the free space alarm.
+// ignore request and raise the free space alarm. Requests adding data are
+// rejected while the free disk space of the WAL is low, whose alarm is raised
+// by the server.
 func (qa *quotaAlarmer) check(ctx context.Context, r any) error {
+	if qa.walDiskLow() && new(storage.BackendQuota).Cost(r) > 0 {
+		return rpctypes.ErrGRPCWALNoSpace
+	}
 	if qa.q.Available(r) {
 		return nil
 	}
@@ -53,7 +60,7 @@ func (qa *quotaAlarmer) check(ctx context.Context, r any) error {
 func NewQuotaKVServer(s *etcdserver.EtcdServer) pb.KVServer {
 	return &quotaKVServer{
 		NewKVServer(s),
-		quotaAlarmer{newBackendQuota(s, "kv"), s, s.MemberID()},
+		quotaAlarmer{newBackendQuota(s, "kv"), s, s.MemberID(), s.WALDiskLow},
 	}
 }
 
@@ -86,7 +93,26 @@ func (s *quotaLeaseServer) LeaseGrant(ctx context.Context, cr *pb.LeaseGrantRequ
 func NewQuotaLeaseServer(s *etcdserver.EtcdServer) pb.LeaseServer {
 	return &quotaLeaseServer{
 		NewLeaseServer(s),
-		quotaAlarmer{newBackendQuota(s, "lease"), s, s.MemberID()},
+		quotaAlarmer{newBackendQuota(s, "lease"), s, s.MemberID(), s.WALDiskLow},
+	}
+}
+
+type quotaCounterServer struct {
+	pb.CounterServer
+	qa quotaAlarmer
+}
+
+func (s *quotaCounterServer) CounterAdd(ctx context.Context, r *pb.CounterAddRequest) (*pb.CounterAddResponse, error) {
+	if err := s.qa.check(ctx, r); err != nil {
+		return nil, err
+	}
+	return s.CounterServer.CounterAdd(ctx, r)
+}
+
+func NewQuotaCounterServer(s *etcdserver.EtcdServer) pb.CounterServer {
+	return &quotaCounterServer{
+		NewCounterServer(s),
+		quotaAlarmer{newBackendQuota(s, "counter"), s, s.MemberID(), s.WALDiskLow},
 	}
 }
 
@@ -0,0 +1,139 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+const (
+	// snapshotResumeTimeout is how long the snapshot of an interrupted
+	// transfer is kept to be resumed. A kept snapshot holds a backend read
+	// transaction open, like an ongoing transfer does.
+	snapshotResumeTimeout = time.Minute
+	// maxResumableSnapshots bounds the number of snapshots kept at a time.
+	maxResumableSnapshots = 4
+)
+
+type resumableSnapshot struct {
+	id             uint64
+	snap           backend.Snapshot
+	storageVersion string
+	timer          *time.Timer
+}
+
+// resumableSnapshots keeps the snapshots of interrupted transfers so that
+// they can be resumed.
+type resumableSnapshots struct {
+	lg *zap.Logger
+
+	mu        sync.Mutex
+	nextID    uint64
+	snapshots map[uint64]*resumableSnapshot
+	stopped   bool
+}
+
+func newResumableSnapshots(lg *zap.Logger) *resumableSnapshots {
+	return &resumableSnapshots{
+		lg: lg,
+		// avoid reusing the IDs handed out before a restart
+		nextID:    uint64(time.Now().UnixNano()),
+		snapshots: make(map[uint64]*resumableSnapshot),
+	}
+}
+
+func (s *resumableSnapshots) newSnapshot(snap backend.Snapshot, storageVersion string) *resumableSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	return &resumableSnapshot{id: s.nextID, snap: snap, storageVersion: storageVersion}
+}
+
+// take removes the kept snapshot with the given ID, returning nil if there
+// is none.
+func (s *resumableSnapshots) take(id uint64) *resumableSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rs, ok := s.snapshots[id]
+	if !ok {
+		return nil
+	}
+	delete(s.snapshots, id)
+	rs.timer.Stop()
+	return rs
+}
+
+// keep retains the snapshot for snapshotResumeTimeout, releasing the oldest
+// kept snapshot if there are too many.
+func (s *resumableSnapshots) keep(rs *resumableSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		s.release(rs)
+		return
+	}
+	if len(s.snapshots) >= maxResumableSnapshots {
+		var oldest *resumableSnapshot
+		for _, kept := range s.snapshots {
+			if oldest == nil || kept.id < oldest.id {
+				oldest = kept
+			}
+		}
+		delete(s.snapshots, oldest.id)
+		oldest.timer.Stop()
+		s.release(oldest)
+	}
+	s.lg.Info("keeping snapshot of interrupted transfer",
+		zap.Uint64("snapshot-id", rs.id),
+		zap.Duration("timeout", snapshotResumeTimeout),
+	)
+	rs.timer = time.AfterFunc(snapshotResumeTimeout, func() {
+		if expired := s.take(rs.id); expired != nil {
+			s.release(expired)
+		}
+	})
+	s.snapshots[rs.id] = rs
+}
+
+func (s *resumableSnapshots) release(rs *resumableSnapshot) {
+	if err := rs.snap.Close(); err != nil {
+		s.lg.Warn("failed to close snapshot", zap.Uint64("snapshot-id", rs.id), zap.Error(err))
+	}
+}
+
+// releaseAll releases the kept snapshots, as their open read transactions
+// block backend defragmentation and closing.
+func (s *resumableSnapshots) releaseAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, rs := range s.snapshots {
+		delete(s.snapshots, id)
+		rs.timer.Stop()
+		s.release(rs)
+	}
+}
+
+// stop releases the kept snapshots and stops keeping new ones.
+func (s *resumableSnapshots) stop() {
+	s.releaseAll()
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fakeSnapshot struct {
+	closed bool
+}
+
+func (s *fakeSnapshot) Size() int64                      { return 0 }
+func (s *fakeSnapshot) WriteTo(io.Writer) (int64, error) { return 0, nil }
+func (s *fakeSnapshot) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestResumableSnapshots(t *testing.T) {
+	s := newResumableSnapshots(zaptest.NewLogger(t))
+
+	var snaps []*fakeSnapshot
+	var kept []*resumableSnapshot
+	for i := 0; i < maxResumableSnapshots+1; i++ {
+		snap := &fakeSnapshot{}
+		rs := s.newSnapshot(snap, "3.7.0")
+		s.keep(rs)
+		snaps = append(snaps, snap)
+		kept = append(kept, rs)
+	}
+	// keeping one snapshot too many releases the oldest one
+	assert.True(t, snaps[0].closed)
+	assert.Nil(t, s.take(kept[0].id))
+
+	rs := s.take(kept[1].id)
+	require.NotNil(t, rs)
+	assert.Equal(t, "3.7.0", rs.storageVersion)
+	assert.False(t, snaps[1].closed)
+	assert.Nil(t, s.take(kept[1].id), "a snapshot can only be taken once")
+
+	s.releaseAll()
+	for _, snap := range snaps[2:] {
+		assert.True(t, snap.closed)
+	}
+
+	s.stop()
+	snap := &fakeSnapshot{}
+	rs = s.newSnapshot(snap, "")
+	s.keep(rs)
+	assert.True(t, snap.closed, "snapshots are released once stopped")
+	assert.Nil(t, s.take(rs.id))
+}
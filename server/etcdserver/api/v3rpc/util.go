@@ -25,7 +25,12 @@ import (
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/server/v3/auth"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3counter"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3fence"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3job"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/etcd/server/v3/etcdserver/version"
 	"go.etcd.io/etcd/server/v3/lease"
@@ -39,16 +44,24 @@ var toGRPCErrorMap = map[error]error{
 	membership.ErrPeerURLexists:       rpctypes.ErrGRPCPeerURLExist,
 	membership.ErrMemberNotLearner:    rpctypes.ErrGRPCMemberNotLearner,
 	membership.ErrTooManyLearners:     rpctypes.ErrGRPCTooManyLearners,
+	membership.ErrMemberNotVoter:      rpctypes.ErrGRPCMemberNotVoter,
+	membership.ErrBadLabels:           rpctypes.ErrGRPCMemberBadLabels,
 	errors.ErrNotEnoughStartedMembers: rpctypes.ErrMemberNotEnoughStarted,
 	errors.ErrLearnerNotReady:         rpctypes.ErrGRPCLearnerNotReady,
 
-	mvcc.ErrCompacted:         rpctypes.ErrGRPCCompacted,
-	mvcc.ErrFutureRev:         rpctypes.ErrGRPCFutureRev,
-	errors.ErrRequestTooLarge: rpctypes.ErrGRPCRequestTooLarge,
-	errors.ErrNoSpace:         rpctypes.ErrGRPCNoSpace,
-	errors.ErrTooManyRequests: rpctypes.ErrTooManyRequests,
+	mvcc.ErrCompacted:           rpctypes.ErrGRPCCompacted,
+	mvcc.ErrFutureRev:           rpctypes.ErrGRPCFutureRev,
+	errors.ErrRequestTooLarge:   rpctypes.ErrGRPCRequestTooLarge,
+	errors.ErrNoSpace:           rpctypes.ErrGRPCNoSpace,
+	errors.ErrWALNoSpace:        rpctypes.ErrGRPCWALNoSpace,
+	errors.ErrThresholdExceeded: rpctypes.ErrGRPCThresholdExceeded,
+	errors.ErrTooManyRequests:   rpctypes.ErrTooManyRequests,
+	errors.ErrTooBusy:           rpctypes.ErrGRPCTooBusy,
 
 	errors.ErrNoLeader:                   rpctypes.ErrGRPCNoLeader,
+	errors.ErrCatchingUp:                 rpctypes.ErrGRPCCatchingUp,
+	errors.ErrMemberDraining:             rpctypes.ErrGRPCMemberDraining,
+	errors.ErrAppliedIndexBehind:         rpctypes.ErrGRPCAppliedIndexBehind,
 	errors.ErrNotLeader:                  rpctypes.ErrGRPCNotLeader,
 	errors.ErrLeaderChanged:              rpctypes.ErrGRPCLeaderChanged,
 	errors.ErrStopped:                    rpctypes.ErrGRPCStopped,
@@ -58,8 +71,16 @@ var toGRPCErrorMap = map[error]error{
 	errors.ErrTimeoutWaitAppliedIndex:    rpctypes.ErrGRPCTimeoutWaitAppliedIndex,
 	errors.ErrUnhealthy:                  rpctypes.ErrGRPCUnhealthy,
 	errors.ErrKeyNotFound:                rpctypes.ErrGRPCKeyNotFound,
+	errors.ErrTooManyDeletions:           rpctypes.ErrGRPCTooManyDeletions,
+	errors.ErrValueTooLarge:              rpctypes.ErrGRPCValueTooLarge,
+	errors.ErrForEachTooManyKeys:         rpctypes.ErrGRPCForEachTooManyKeys,
 	errors.ErrCorrupt:                    rpctypes.ErrGRPCCorrupt,
 	errors.ErrBadLeaderTransferee:        rpctypes.ErrGRPCBadLeaderTransferee,
+	errors.ErrHotKeysDisabled:            rpctypes.ErrGRPCHotKeysDisabled,
+	errors.ErrNewerRequestFields:         rpctypes.ErrGRPCNewerRequestFields,
+	errors.ErrDowngradeBlocked:           rpctypes.ErrGRPCDowngradeBlocked,
+	errors.ErrNewerFieldsDisabled:        rpctypes.ErrGRPCNewerFieldsDisabled,
+	errors.ErrReseedLeader:               rpctypes.ErrGRPCReseedLeader,
 
 	errors.ErrClusterVersionUnavailable:      rpctypes.ErrGRPCClusterVersionUnavailable,
 	errors.ErrWrongDowngradeVersionFormat:    rpctypes.ErrGRPCWrongDowngradeVersionFormat,
@@ -71,6 +92,17 @@ var toGRPCErrorMap = map[error]error{
 	lease.ErrLeaseExists:      rpctypes.ErrGRPCLeaseExist,
 	lease.ErrLeaseTTLTooLarge: rpctypes.ErrGRPCLeaseTTLTooLarge,
 
+	v3compactor.ErrHoldNotFound:  rpctypes.ErrGRPCCompactionHoldNotFound,
+	v3compactor.ErrHoldsDisabled: rpctypes.ErrGRPCCompactionHoldsDisabled,
+	v3counter.ErrOverflow:        rpctypes.ErrGRPCCounterOverflow,
+
+	v3job.ErrJobNotFound:   rpctypes.ErrGRPCJobNotFound,
+	v3job.ErrJobNotRunning: rpctypes.ErrGRPCJobNotRunning,
+
+	clusterconfig.ErrInvalidConfig:   rpctypes.ErrGRPCInvalidClusterConfig,
+	clusterconfig.ErrProtectedPrefix: rpctypes.ErrGRPCProtectedPrefix,
+	v3fence.ErrPrefixFenced:          rpctypes.ErrGRPCPrefixFenced,
+
 	auth.ErrRootUserNotExist:     rpctypes.ErrGRPCRootUserNotExist,
 	auth.ErrRootRoleNotExist:     rpctypes.ErrGRPCRootRoleNotExist,
 	auth.ErrUserAlreadyExist:     rpctypes.ErrGRPCUserAlreadyExist,
@@ -88,6 +120,10 @@ var toGRPCErrorMap = map[error]error{
 	auth.ErrInvalidAuthToken:     rpctypes.ErrGRPCInvalidAuthToken,
 	auth.ErrInvalidAuthMgmt:      rpctypes.ErrGRPCInvalidAuthMgmt,
 	auth.ErrAuthOldRevision:      rpctypes.ErrGRPCAuthOldRevision,
+	auth.ErrPasswordTooWeak:      rpctypes.ErrGRPCPasswordTooWeak,
+	auth.ErrPasswordExpired:      rpctypes.ErrGRPCPasswordExpired,
+	auth.ErrInvalidPasswordHash:  rpctypes.ErrGRPCInvalidPasswordHash,
+	auth.ErrInvalidRPCMethod:     rpctypes.ErrGRPCInvalidRPCMethod,
 
 	// In sync with status.FromContextError
 	context.Canceled:         rpctypes.ErrGRPCCanceled,
@@ -99,6 +135,10 @@ func togRPCError(err error) error {
 	if errorspkg.Is(err, context.Canceled) || errorspkg.Is(err, context.DeadlineExceeded) {
 		return err
 	}
+	var tooBusy *errors.TooBusyError
+	if errorspkg.As(err, &tooBusy) {
+		return rpctypes.NewGRPCTooBusyError(tooBusy.Backoff)
+	}
 	grpcErr, ok := toGRPCErrorMap[err]
 	if !ok {
 		return status.Error(codes.Unknown, err.Error())
@@ -138,6 +178,12 @@ func isClientCtxErr(ctxErr error, err error) bool {
 	return false
 }
 
+// standby member is only allowed to serve endpoint status
+func isRPCSupportedForStandby(req any) bool {
+	_, ok := req.(*pb.StatusRequest)
+	return ok
+}
+
 // in v3.4, learner is allowed to serve serializable read and endpoint status
 func isRPCSupportedForLearner(req any) bool {
 	switch r := req.(type) {
@@ -145,6 +191,8 @@ func isRPCSupportedForLearner(req any) bool {
 		return true
 	case *pb.RangeRequest:
 		return r.Serializable
+	case *pb.CounterGetRequest:
+		return r.Serializable
 	default:
 		return false
 	}
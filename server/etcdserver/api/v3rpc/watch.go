@@ -15,16 +15,20 @@
 package v3rpc
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"math/rand"
+	"slices"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
@@ -47,10 +51,29 @@ type watchServer struct {
 	memberID  int64
 
 	maxRequestBytes uint
+	// sendBatchInterval is the maximum time event responses are batched on
+	// a stream before being sent. Zero disables batching.
+	sendBatchInterval time.Duration
 
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
 	ag        AuthGetter
+	dr        WatchDrainer
+	// thresholdBlocked reports whether an operation is blocked by the
+	// crossed alarm thresholds.
+	thresholdBlocked func(pb.AlarmThreshold_Operation) bool
+}
+
+// WatchDrainer moves the watch streams away from a draining member.
+type WatchDrainer interface {
+	// Draining returns true if the member is draining.
+	Draining() bool
+	// DrainNotify returns a channel that is closed while the member is
+	// draining.
+	DrainNotify() <-chan struct{}
+	// TrackWatchStream counts an open watch stream until the returned
+	// function is called.
+	TrackWatchStream() (untrack func())
 }
 
 // NewWatchServer returns a new watch server.
@@ -61,11 +84,15 @@ func NewWatchServer(s *etcdserver.EtcdServer) pb.WatchServer {
 		clusterID: int64(s.Cluster().ID()),
 		memberID:  int64(s.MemberID()),
 
-		maxRequestBytes: s.Cfg.MaxRequestBytesWithOverhead(),
+		maxRequestBytes:   s.Cfg.MaxRequestBytesWithOverhead(),
+		sendBatchInterval: s.Cfg.WatchSendBatchInterval,
 
 		sg:        s,
 		watchable: s.Watchable(),
 		ag:        s,
+		dr:        s,
+
+		thresholdBlocked: s.ThresholdBlocked,
 	}
 	if srv.lg == nil {
 		srv.lg = zap.NewNop()
@@ -83,6 +110,10 @@ func NewWatchServer(s *etcdserver.EtcdServer) pb.WatchServer {
 	return srv
 }
 
+// monotonicStart is the reference of the monotonic time reported by progress
+// notifications.
+var monotonicStart = time.Now()
+
 var (
 	// External test can read this with GetProgressReportInterval()
 	// and change this to a small value to finish fast with
@@ -119,6 +150,10 @@ func SetProgressReportInterval(newTimeout time.Duration) {
 // ctrl requests are infrequent.
 const ctrlStreamBufLen = 16
 
+// initialStatePageSize is the maximum number of key-value pairs in a response
+// of the initial state of a watcher.
+const initialStatePageSize = 1000
+
 // serverWatchStream is an etcd server side stream. It receives requests
 // from client side gRPC stream. It receives watch events from mvcc.WatchStream,
 // and creates responses that forwarded to gRPC stream.
@@ -129,25 +164,47 @@ type serverWatchStream struct {
 	clusterID int64
 	memberID  int64
 
-	maxRequestBytes uint
+	maxRequestBytes   uint
+	sendBatchInterval time.Duration
 
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
 	ag        AuthGetter
 
+	thresholdBlocked func(pb.AlarmThreshold_Operation) bool
+
 	gRPCStream  pb.Watch_WatchServer
 	watchStream mvcc.WatchStream
 	ctrlStream  chan *pb.WatchResponse
 
-	// mu protects progress, prevKV, fragment
+	// mu protects progress, prevKV, fragment, active, closed
 	mu sync.RWMutex
 	// tracks the watchID that stream might need to send progress to
 	// TODO: combine progress and prevKV into a single struct?
 	progress map[mvcc.WatchID]bool
 	// record watch IDs that need return previous key-value pair
 	prevKV map[mvcc.WatchID]bool
-	// records fragmented watch IDs
-	fragment map[mvcc.WatchID]bool
+	// records the size the responses of fragmented watch IDs are split at
+	fragment map[mvcc.WatchID]uint
+	// sequences holds the sequence token of the last event sent to each
+	// watcher. It is only accessed by sendLoop.
+	sequences map[mvcc.WatchID]int64
+	// records watch IDs counted in the watchers by identity metric
+	active map[mvcc.WatchID]struct{}
+	// closed is set once the stream no longer counts its watchers
+	closed bool
+	// ranges records the ranges of the watchers of a stream with a user,
+	// whose permissions are revalidated when they change
+	ranges map[mvcc.WatchID]auth.KeyRange
+	// permitted records the parts of their range narrowed watchers are
+	// still permitted to read
+	permitted map[mvcc.WatchID][]auth.KeyRange
+
+	// user is the auth user or client certificate common name of the
+	// stream, or an empty string if it is unknown
+	user string
+	// identity is the metric label of the stream owner
+	identity string
 
 	// closec indicates the stream is closed.
 	closec chan struct{}
@@ -157,26 +214,43 @@ type serverWatchStream struct {
 }
 
 func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
+	if ws.dr.Draining() {
+		return rpctypes.ErrGRPCMemberDraining
+	}
+	defer ws.dr.TrackWatchStream()()
+
+	user := streamIdentity(ws.ag, stream.Context())
 	sws := serverWatchStream{
 		lg: ws.lg,
 
 		clusterID: ws.clusterID,
 		memberID:  ws.memberID,
 
-		maxRequestBytes: ws.maxRequestBytes,
+		maxRequestBytes:   ws.maxRequestBytes,
+		sendBatchInterval: ws.sendBatchInterval,
 
 		sg:        ws.sg,
 		watchable: ws.watchable,
 		ag:        ws.ag,
 
+		thresholdBlocked: ws.thresholdBlocked,
+
 		gRPCStream:  stream,
 		watchStream: ws.watchable.NewWatchStream(),
 		// chan for sending control response like watcher created and canceled.
 		ctrlStream: make(chan *pb.WatchResponse, ctrlStreamBufLen),
 
-		progress: make(map[mvcc.WatchID]bool),
-		prevKV:   make(map[mvcc.WatchID]bool),
-		fragment: make(map[mvcc.WatchID]bool),
+		progress:  make(map[mvcc.WatchID]bool),
+		prevKV:    make(map[mvcc.WatchID]bool),
+		fragment:  make(map[mvcc.WatchID]uint),
+		active:    make(map[mvcc.WatchID]struct{}),
+		ranges:    make(map[mvcc.WatchID]auth.KeyRange),
+		permitted: make(map[mvcc.WatchID][]auth.KeyRange),
+
+		sequences: make(map[mvcc.WatchID]int64),
+
+		user:     user,
+		identity: watchIdentityLabel(user),
 
 		closec: make(chan struct{}),
 	}
@@ -224,22 +298,51 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 		if errors.Is(err, context.Canceled) {
 			err = rpctypes.ErrGRPCWatchCanceled
 		}
+	case <-ws.dr.DrainNotify():
+		// the client reconnects the watches to another member
+		err = rpctypes.ErrGRPCMemberDraining
 	}
 
 	sws.close()
 	return err
 }
 
-func (sws *serverWatchStream) isWatchPermitted(wcr *pb.WatchCreateRequest) error {
+// streamIdentity returns the auth user or client certificate common name
+// of the watch stream, or an empty string if it is unknown. The common name
+// identifies the stream even when auth is disabled.
+func streamIdentity(ag AuthGetter, ctx context.Context) string {
+	authInfo, err := ag.AuthInfoFromCtx(ctx)
+	if err == nil && authInfo != nil && authInfo.Username != "" {
+		return authInfo.Username
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p == nil {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ""
+	}
+	for _, chain := range tlsInfo.State.VerifiedChains {
+		if len(chain) > 0 && chain[0].Subject.CommonName != "" {
+			return chain[0].Subject.CommonName
+		}
+	}
+	return ""
+}
+
+// isWatchPermitted checks the permission of the stream user, whose name it
+// returns, to watch the range of the request.
+func (sws *serverWatchStream) isWatchPermitted(wcr *pb.WatchCreateRequest) (string, error) {
 	authInfo, err := sws.ag.AuthInfoFromCtx(sws.gRPCStream.Context())
 	if err != nil {
-		return err
+		return "", err
 	}
 	if authInfo == nil {
 		// if auth is enabled, IsRangePermitted() can cause an error
 		authInfo = &auth.AuthInfo{}
 	}
-	return sws.ag.AuthStore().IsRangePermitted(authInfo, wcr.Key, wcr.RangeEnd)
+	return authInfo.Username, sws.ag.AuthStore().IsRangePermitted(authInfo, wcr.Key, wcr.RangeEnd)
 }
 
 func (sws *serverWatchStream) recvLoop() error {
@@ -254,121 +357,25 @@ func (sws *serverWatchStream) recvLoop() error {
 
 		switch uv := req.RequestUnion.(type) {
 		case *pb.WatchRequest_CreateRequest:
-			if uv.CreateRequest == nil {
-				break
-			}
-
-			creq := uv.CreateRequest
-			if len(creq.Key) == 0 {
-				// \x00 is the smallest key
-				creq.Key = []byte{0}
+			if uv.CreateRequest != nil && !sws.create(uv.CreateRequest) {
+				return nil
 			}
-			if len(creq.RangeEnd) == 0 {
-				// force nil since watchstream.Watch distinguishes
-				// between nil and []byte{} for single key / >=
-				creq.RangeEnd = nil
+		case *pb.WatchRequest_CancelRequest:
+			if uv.CancelRequest != nil && !sws.cancel(uv.CancelRequest) {
+				return nil
 			}
-			if len(creq.RangeEnd) == 1 && creq.RangeEnd[0] == 0 {
-				// support  >= key queries
-				creq.RangeEnd = []byte{}
+		case *pb.WatchRequest_BulkRequest:
+			if uv.BulkRequest == nil {
+				break
 			}
-
-			err := sws.isWatchPermitted(creq)
-			if err != nil {
-				var cancelReason string
-				switch {
-				case errors.Is(err, auth.ErrInvalidAuthToken):
-					cancelReason = rpctypes.ErrGRPCInvalidAuthToken.Error()
-				case errors.Is(err, auth.ErrAuthOldRevision):
-					cancelReason = rpctypes.ErrGRPCAuthOldRevision.Error()
-				case errors.Is(err, auth.ErrUserEmpty):
-					cancelReason = rpctypes.ErrGRPCUserEmpty.Error()
-				default:
-					if !errors.Is(err, auth.ErrPermissionDenied) {
-						sws.lg.Error("unexpected error code", zap.Error(err))
-					}
-					cancelReason = rpctypes.ErrGRPCPermissionDenied.Error()
-				}
-
-				wr := &pb.WatchResponse{
-					Header:       sws.newResponseHeader(sws.watchStream.Rev()),
-					WatchId:      clientv3.InvalidWatchID,
-					Canceled:     true,
-					Created:      true,
-					CancelReason: cancelReason,
-				}
-
-				select {
-				case sws.ctrlStream <- wr:
-					continue
-				case <-sws.closec:
+			for _, creq := range uv.BulkRequest.CreateRequests {
+				if !sws.create(creq) {
 					return nil
 				}
 			}
-
-			filters := FiltersFromRequest(creq)
-			ctx, _ := traceutil.Tracer.Start(sws.gRPCStream.Context(), "watch", trace.WithAttributes(
-				attribute.String("key", string(creq.Key)),
-				attribute.String("range_end", string(creq.RangeEnd)),
-				attribute.Int64("start_rev", creq.StartRevision),
-				attribute.Bool("progress_notify", creq.ProgressNotify),
-				attribute.Bool("prev_kv", creq.PrevKv),
-				attribute.Bool("fragment", creq.Fragment),
-			))
-
-			id, err := sws.watchStream.Watch(ctx, mvcc.WatchID(creq.WatchId), creq.Key, creq.RangeEnd, creq.StartRevision, filters...)
-			if err == nil {
-				sws.mu.Lock()
-				if creq.ProgressNotify {
-					sws.progress[id] = true
-				}
-				if creq.PrevKv {
-					sws.prevKV[id] = true
-				}
-				if creq.Fragment {
-					sws.fragment[id] = true
-				}
-				sws.mu.Unlock()
-			} else {
-				id = clientv3.InvalidWatchID
-			}
-
-			wr := &pb.WatchResponse{
-				Header:   sws.newResponseHeader(sws.watchStream.Rev()),
-				WatchId:  int64(id),
-				Created:  true,
-				Canceled: err != nil,
-			}
-			if err != nil {
-				wr.CancelReason = err.Error()
-			}
-			select {
-			case sws.ctrlStream <- wr:
-			case <-sws.closec:
-				return nil
-			}
-
-		case *pb.WatchRequest_CancelRequest:
-			if uv.CancelRequest != nil {
-				id := uv.CancelRequest.WatchId
-				err := sws.watchStream.Cancel(mvcc.WatchID(id))
-				if err == nil {
-					wr := &pb.WatchResponse{
-						Header:   sws.newResponseHeader(sws.watchStream.Rev()),
-						WatchId:  id,
-						Canceled: true,
-					}
-					select {
-					case sws.ctrlStream <- wr:
-					case <-sws.closec:
-						return nil
-					}
-
-					sws.mu.Lock()
-					delete(sws.progress, mvcc.WatchID(id))
-					delete(sws.prevKV, mvcc.WatchID(id))
-					delete(sws.fragment, mvcc.WatchID(id))
-					sws.mu.Unlock()
+			for _, cr := range uv.BulkRequest.CancelRequests {
+				if !sws.cancel(cr) {
+					return nil
 				}
 			}
 		case *pb.WatchRequest_ProgressRequest:
@@ -387,26 +394,325 @@ func (sws *serverWatchStream) recvLoop() error {
 	}
 }
 
+// create creates the watcher of a create request and sends its created
+// response. It returns false if the stream is closed.
+func (sws *serverWatchStream) create(creq *pb.WatchCreateRequest) bool {
+	if len(creq.Key) == 0 {
+		// \x00 is the smallest key
+		creq.Key = []byte{0}
+	}
+	if len(creq.RangeEnd) == 0 {
+		// force nil since watchstream.Watch distinguishes
+		// between nil and []byte{} for single key / >=
+		creq.RangeEnd = nil
+	}
+	if len(creq.RangeEnd) == 1 && creq.RangeEnd[0] == 0 {
+		// support  >= key queries
+		creq.RangeEnd = []byte{}
+	}
+
+	user, err := sws.isWatchPermitted(creq)
+	if err != nil {
+		var cancelReason string
+		switch {
+		case errors.Is(err, auth.ErrInvalidAuthToken):
+			cancelReason = rpctypes.ErrGRPCInvalidAuthToken.Error()
+		case errors.Is(err, auth.ErrAuthOldRevision):
+			cancelReason = rpctypes.ErrGRPCAuthOldRevision.Error()
+		case errors.Is(err, auth.ErrUserEmpty):
+			cancelReason = rpctypes.ErrGRPCUserEmpty.Error()
+		default:
+			if !errors.Is(err, auth.ErrPermissionDenied) {
+				sws.lg.Error("unexpected error code", zap.Error(err))
+			}
+			cancelReason = rpctypes.ErrGRPCPermissionDenied.Error()
+		}
+
+		wr := &pb.WatchResponse{
+			Header:        sws.newResponseHeader(sws.watchStream.Rev()),
+			WatchId:       clientv3.InvalidWatchID,
+			Canceled:      true,
+			Created:       true,
+			CancelReason:  cancelReason,
+			CancelCode:    pb.WatchResponse_AUTH_REVOKED,
+			CancelDetails: &pb.WatchCancelDetails{User: user, Key: creq.Key, RangeEnd: creq.RangeEnd},
+			BulkSupported: true,
+		}
+
+		select {
+		case sws.ctrlStream <- wr:
+			return true
+		case <-sws.closec:
+			return false
+		}
+	}
+
+	if sws.thresholdBlocked(pb.AlarmThreshold_WATCH) {
+		wr := &pb.WatchResponse{
+			Header:        sws.newResponseHeader(sws.watchStream.Rev()),
+			WatchId:       clientv3.InvalidWatchID,
+			Canceled:      true,
+			Created:       true,
+			CancelReason:  rpctypes.ErrGRPCThresholdExceeded.Error(),
+			CancelCode:    pb.WatchResponse_THRESHOLD_EXCEEDED,
+			BulkSupported: true,
+		}
+
+		select {
+		case sws.ctrlStream <- wr:
+			return true
+		case <-sws.closec:
+			return false
+		}
+	}
+
+	filters := FiltersFromRequest(creq)
+	ctx, _ := traceutil.Tracer.Start(sws.gRPCStream.Context(), "watch", trace.WithAttributes(
+		attribute.String("key", string(creq.Key)),
+		attribute.String("range_end", string(creq.RangeEnd)),
+		attribute.Int64("start_rev", creq.StartRevision),
+		attribute.Bool("progress_notify", creq.ProgressNotify),
+		attribute.Bool("prev_kv", creq.PrevKv),
+		attribute.Bool("fragment", creq.Fragment),
+	))
+
+	ctx = mvcc.WithWatcherIdentity(ctx, sws.identity)
+	startRev := creq.StartRevision
+	var stateRev int64
+	if creq.SendInitialState {
+		// pin the revision the initial state is read at, the watcher
+		// continues right after it so that no change is missed
+		if startRev == 0 {
+			startRev = sws.watchStream.Rev() + 1
+		}
+		stateRev = startRev - 1
+	}
+	id, err := sws.watchStream.Watch(ctx, mvcc.WatchID(creq.WatchId), creq.Key, creq.RangeEnd, startRev, filters...)
+	if err == nil {
+		sws.mu.Lock()
+		sws.trackWatcherLocked(id)
+		if sws.user != "" {
+			sws.ranges[id] = watchKeyRange(creq)
+		}
+		if creq.ProgressNotify {
+			sws.progress[id] = true
+		}
+		if creq.PrevKv {
+			sws.prevKV[id] = true
+		}
+		if limit := fragmentLimit(creq, sws.maxRequestBytes); limit > 0 {
+			sws.fragment[id] = limit
+		}
+		sws.mu.Unlock()
+	} else {
+		id = clientv3.InvalidWatchID
+	}
+
+	wr := &pb.WatchResponse{
+		Header:        sws.newResponseHeader(sws.watchStream.Rev()),
+		WatchId:       int64(id),
+		Created:       true,
+		Canceled:      err != nil,
+		BulkSupported: true,
+	}
+	if err != nil {
+		wr.CancelReason = err.Error()
+		wr.CancelCode = pb.WatchResponse_INVALID_REQUEST
+	} else {
+		wr.MaxResponseBytes = int64(fragmentLimit(creq, sws.maxRequestBytes))
+	}
+	if err == nil && creq.SendInitialState {
+		wr.Header.Revision = stateRev
+		wr.InitialState = true
+		wr.InitialStateMore = true
+	}
+	select {
+	case sws.ctrlStream <- wr:
+	case <-sws.closec:
+		return false
+	}
+	if err == nil && creq.SendInitialState {
+		return sws.sendInitialState(ctx, id, creq, stateRev)
+	}
+	return true
+}
+
+// cancel cancels the watcher of a cancel request and sends its canceled
+// response. It returns false if the stream is closed.
+func (sws *serverWatchStream) cancel(creq *pb.WatchCancelRequest) bool {
+	id := creq.WatchId
+	if err := sws.watchStream.Cancel(mvcc.WatchID(id)); err != nil {
+		return true
+	}
+	wr := &pb.WatchResponse{
+		Header:   sws.newResponseHeader(sws.watchStream.Rev()),
+		WatchId:  id,
+		Canceled: true,
+	}
+	select {
+	case sws.ctrlStream <- wr:
+	case <-sws.closec:
+		return false
+	}
+	sws.forgetWatcher(mvcc.WatchID(id))
+	return true
+}
+
+// sendInitialState streams the key-value pairs of the range of a watcher
+// created with send_initial_state, as read at rev, in pages of PUT events.
+// It returns false if the stream is closed.
+func (sws *serverWatchStream) sendInitialState(ctx context.Context, id mvcc.WatchID, creq *pb.WatchCreateRequest, rev int64) bool {
+	noPut := slices.Contains(creq.Filters, pb.WatchCreateRequest_NOPUT)
+	key := creq.Key
+	for {
+		var kvs []mvccpb.KeyValue
+		more := false
+		// there are no keys before the first revision
+		if rev > 0 {
+			r, err := sws.watchable.Range(ctx, key, creq.RangeEnd, mvcc.RangeOptions{Rev: rev, Limit: initialStatePageSize})
+			if err != nil {
+				return sws.cancelInitialState(id, rev, err)
+			}
+			kvs = r.KVs
+			more = len(kvs) < r.Count
+		}
+
+		wr := &pb.WatchResponse{
+			Header:           sws.newResponseHeader(rev),
+			WatchId:          int64(id),
+			InitialState:     true,
+			InitialStateMore: more,
+		}
+		if !noPut {
+			wr.Events = make([]*mvccpb.Event, len(kvs))
+			for i := range kvs {
+				wr.Events[i] = &mvccpb.Event{Type: mvccpb.PUT, Kv: &kvs[i]}
+			}
+		}
+		select {
+		case sws.ctrlStream <- wr:
+		case <-sws.closec:
+			return false
+		}
+		if !more {
+			return true
+		}
+		key = append(bytes.Clone(kvs[len(kvs)-1].Key), 0)
+	}
+}
+
+// cancelInitialState cancels a watcher whose initial state cannot be read.
+func (sws *serverWatchStream) cancelInitialState(id mvcc.WatchID, rev int64, err error) bool {
+	if cerr := sws.watchStream.Cancel(id); cerr != nil {
+		// the watcher was already canceled
+		return true
+	}
+	wr := &pb.WatchResponse{
+		Header:       sws.newResponseHeader(rev),
+		WatchId:      int64(id),
+		Canceled:     true,
+		CancelReason: err.Error(),
+		CancelCode:   pb.WatchResponse_INVALID_REQUEST,
+		InitialState: true,
+	}
+	if errors.Is(err, mvcc.ErrCompacted) {
+		wr.CancelCode = pb.WatchResponse_COMPACTED
+	}
+	select {
+	case sws.ctrlStream <- wr:
+	case <-sws.closec:
+		return false
+	}
+	sws.forgetWatcher(id)
+	return true
+}
+
+// forgetWatcher drops the options of a canceled watcher.
+func (sws *serverWatchStream) forgetWatcher(id mvcc.WatchID) {
+	sws.mu.Lock()
+	delete(sws.progress, id)
+	delete(sws.prevKV, id)
+	delete(sws.fragment, id)
+	delete(sws.ranges, id)
+	delete(sws.permitted, id)
+	sws.untrackWatcherLocked(id)
+	sws.mu.Unlock()
+}
+
+// watchKeyRange returns the key range of the watcher of a create request
+// whose range end is normalized.
+func watchKeyRange(creq *pb.WatchCreateRequest) auth.KeyRange {
+	r := auth.KeyRange{Key: creq.Key, RangeEnd: creq.RangeEnd}
+	if creq.RangeEnd != nil && len(creq.RangeEnd) == 0 {
+		r.RangeEnd = []byte{0}
+	}
+	return r
+}
+
 func (sws *serverWatchStream) sendLoop() {
 	// watch ids that are currently active
 	ids := make(map[mvcc.WatchID]struct{})
 	// watch responses pending on a watch id creation message
 	pending := make(map[mvcc.WatchID][]*pb.WatchResponse)
+	// watch ids whose permissions are revalidated once their creation
+	// message is sent
+	unvalidated := make(map[mvcc.WatchID]struct{})
+
+	// the permissions of the watchers of a stream with a user are
+	// revalidated when they change
+	var permc <-chan struct{}
+	if sws.user != "" {
+		permc = sws.ag.AuthStore().PermissionsNotify()
+	}
 
 	interval := GetProgressReportInterval()
 	progressTicker := time.NewTicker(interval)
 
+	// event responses held back to be sent together, flushed at the latest
+	// after sendBatchInterval
+	batch := newWatchResponseBatch(sws.maxRequestBytes)
+	var batchTimer *time.Timer
+	var batchc <-chan time.Time
+
+	flush := func() bool {
+		if batchTimer != nil {
+			batchTimer.Stop()
+			batchc = nil
+		}
+		if batch.empty() {
+			return true
+		}
+		for _, wr := range batch.take() {
+			sws.sequenceEvents(wr)
+			if err := sws.send(wr); err != nil {
+				sws.logSendError("failed to send watch response to gRPC stream", err)
+				return false
+			}
+			watchEventsSentByIdentity.WithLabelValues(sws.identity).Add(float64(len(wr.Events)))
+		}
+		return true
+	}
+
 	defer func() {
 		progressTicker.Stop()
+		if batchTimer != nil {
+			batchTimer.Stop()
+		}
 		// drain the chan to clean up pending events
+		dropped := batch.events
 		for ws := range sws.watchStream.Chan() {
 			mvcc.ReportEventReceived(len(ws.Events))
+			dropped += len(ws.Events)
 		}
 		for _, wrs := range pending {
 			for _, ws := range wrs {
 				mvcc.ReportEventReceived(len(ws.Events))
+				dropped += len(ws.Events)
 			}
 		}
+		if dropped > 0 {
+			watchEventsDroppedByIdentity.WithLabelValues(sws.identity).Add(float64(dropped))
+		}
 	}()
 
 	for {
@@ -420,10 +726,19 @@ func (sws *serverWatchStream) sendLoop() {
 			// either return []*mvccpb.Event from the mvcc package
 			// or define protocol buffer with []mvccpb.Event.
 			evs := wresp.Events
-			events := make([]*mvccpb.Event, len(evs))
 			sws.mu.RLock()
 			needPrevKV := sws.prevKV[wresp.WatchID]
+			permitted := sws.permitted[wresp.WatchID]
 			sws.mu.RUnlock()
+			if permitted != nil {
+				// the user may no longer read the keys of some events
+				evs = permittedEvents(evs, permitted)
+				mvcc.ReportEventReceived(len(wresp.Events) - len(evs))
+				if len(evs) == 0 && len(wresp.Events) > 0 && wresp.CompactRevision == 0 && !wresp.Dropped {
+					continue
+				}
+			}
+			events := make([]*mvccpb.Event, len(evs))
 			for i := range evs {
 				events[i] = &evs[i]
 				if needPrevKV && !IsCreateEvent(evs[i]) {
@@ -435,7 +750,7 @@ func (sws *serverWatchStream) sendLoop() {
 				}
 			}
 
-			canceled := wresp.CompactRevision != 0
+			canceled := wresp.CompactRevision != 0 || wresp.Dropped
 			wr := &pb.WatchResponse{
 				Header:          sws.newResponseHeader(wresp.Revision),
 				WatchId:         int64(wresp.WatchID),
@@ -443,6 +758,20 @@ func (sws *serverWatchStream) sendLoop() {
 				CompactRevision: wresp.CompactRevision,
 				Canceled:        canceled,
 			}
+			switch {
+			case wresp.CompactRevision != 0:
+				wr.CancelCode = pb.WatchResponse_COMPACTED
+				wr.CancelDetails = &pb.WatchCancelDetails{Revision: wresp.CompactRevision}
+			case wresp.Dropped:
+				wr.CancelReason = rpctypes.ErrorDesc(rpctypes.ErrGRPCWatcherDropped)
+				wr.CancelCode = pb.WatchResponse_WATCHER_OVERLOADED
+			case len(wresp.Events) == 0:
+				// progress notifications tell the time of the member, so
+				// that clients can estimate how stale their watches are
+				now := time.Now()
+				wr.ServerTime = now.UnixNano()
+				wr.ServerMonotonicTime = int64(now.Sub(monotonicStart))
+			}
 
 			// Progress notifications can have WatchID -1
 			// if they announce on behalf of multiple watchers
@@ -457,33 +786,55 @@ func (sws *serverWatchStream) sendLoop() {
 
 			mvcc.ReportEventReceived(len(evs))
 
-			sws.mu.RLock()
-			fragmented, ok := sws.fragment[wresp.WatchID]
-			sws.mu.RUnlock()
+			if sws.sendBatchInterval > 0 && len(evs) > 0 && !canceled {
+				sws.mu.Lock()
+				if sws.progress[wresp.WatchID] {
+					// elide next progress update, the batch is sent before it
+					sws.progress[wresp.WatchID] = false
+				}
+				sws.mu.Unlock()
 
-			var serr error
-			// gofail: var beforeSendWatchResponse struct{}
-			if !fragmented && !ok {
-				serr = sws.gRPCStream.Send(wr)
-			} else {
-				serr = sendFragments(wr, sws.maxRequestBytes, sws.gRPCStream.Send)
+				if batch.add(wr) {
+					if !flush() {
+						return
+					}
+				} else if batchc == nil {
+					if batchTimer == nil {
+						batchTimer = time.NewTimer(sws.sendBatchInterval)
+					} else {
+						batchTimer.Reset(sws.sendBatchInterval)
+					}
+					batchc = batchTimer.C
+				}
+				continue
 			}
 
-			if serr != nil {
-				if isClientCtxErr(sws.gRPCStream.Context().Err(), serr) {
-					sws.lg.Debug("failed to send watch response to gRPC stream", zap.Error(serr))
-				} else {
-					sws.lg.Warn("failed to send watch response to gRPC stream", zap.Error(serr))
-					streamFailures.WithLabelValues("send", "watch").Inc()
-				}
+			// responses other than events must not overtake batched events
+			if !flush() {
 				return
 			}
 
+			// gofail: var beforeSendWatchResponse struct{}
+			sws.sequenceEvents(wr)
+			if serr := sws.send(wr); serr != nil {
+				sws.logSendError("failed to send watch response to gRPC stream", serr)
+				return
+			}
+
+			watchEventsSentByIdentity.WithLabelValues(sws.identity).Add(float64(len(evs)))
+
 			sws.mu.Lock()
 			if len(evs) > 0 && sws.progress[wresp.WatchID] {
 				// elide next progress update if sent a key update
 				sws.progress[wresp.WatchID] = false
 			}
+			if canceled {
+				// the watcher was canceled by compaction or to reclaim memory
+				sws.untrackWatcherLocked(wresp.WatchID)
+				delete(sws.ranges, wresp.WatchID)
+				delete(sws.permitted, wresp.WatchID)
+				delete(sws.sequences, wresp.WatchID)
+			}
 			sws.mu.Unlock()
 
 		case c, ok := <-sws.ctrlStream:
@@ -491,7 +842,17 @@ func (sws *serverWatchStream) sendLoop() {
 				return
 			}
 
-			if err := sws.gRPCStream.Send(c); err != nil {
+			// a cancel response must not overtake batched events
+			if !flush() {
+				return
+			}
+			send := sws.gRPCStream.Send
+			if c.InitialState && len(c.Events) > 0 {
+				// initial state pages may be large
+				send = sws.send
+				sws.sequenceEvents(c)
+			}
+			if err := send(c); err != nil {
 				if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
 					sws.lg.Debug("failed to send watch control response to gRPC stream", zap.Error(err))
 				} else {
@@ -508,13 +869,23 @@ func (sws *serverWatchStream) sendLoop() {
 
 			if c.Canceled && wid != clientv3.InvalidWatchID {
 				delete(ids, wid)
+				delete(unvalidated, wid)
+				delete(sws.sequences, wid)
+				// events held back for the initial state are not sent
+				for _, v := range pending[wid] {
+					mvcc.ReportEventReceived(len(v.Events))
+					watchEventsDroppedByIdentity.WithLabelValues(sws.identity).Add(float64(len(v.Events)))
+				}
+				delete(pending, wid)
 				continue
 			}
-			if c.Created {
+			// live events are held back until the initial state is complete
+			if (c.Created && !c.InitialState) || (c.InitialState && !c.InitialStateMore) {
 				// flush buffered events
 				ids[wid] = struct{}{}
 				for _, v := range pending[wid] {
 					mvcc.ReportEventReceived(len(v.Events))
+					sws.sequenceEvents(v)
 					if err := sws.gRPCStream.Send(v); err != nil {
 						if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
 							sws.lg.Debug("failed to send pending watch response to gRPC stream", zap.Error(err))
@@ -524,8 +895,46 @@ func (sws *serverWatchStream) sendLoop() {
 						}
 						return
 					}
+					watchEventsSentByIdentity.WithLabelValues(sws.identity).Add(float64(len(v.Events)))
 				}
 				delete(pending, wid)
+
+				if _, ok := unvalidated[wid]; ok {
+					delete(unvalidated, wid)
+					if !sws.revalidateWatcher(wid, ids) {
+						return
+					}
+				}
+			}
+
+		case <-batchc:
+			batchc = nil
+			if !flush() {
+				return
+			}
+
+		case <-permc:
+			permc = sws.ag.AuthStore().PermissionsNotify()
+			// responses to revalidated watchers must not overtake batched
+			// events
+			if !flush() {
+				return
+			}
+			sws.mu.RLock()
+			wids := make([]mvcc.WatchID, 0, len(sws.ranges))
+			for id := range sws.ranges {
+				wids = append(wids, id)
+			}
+			sws.mu.RUnlock()
+			for _, id := range wids {
+				if _, ok := ids[id]; !ok {
+					// the watcher is revalidated once it is announced
+					unvalidated[id] = struct{}{}
+					continue
+				}
+				if !sws.revalidateWatcher(id, ids) {
+					return
+				}
 			}
 
 		case <-progressTicker.C:
@@ -544,6 +953,133 @@ func (sws *serverWatchStream) sendLoop() {
 	}
 }
 
+// revalidateWatcher checks the permission of the stream user to read the
+// range of an announced watcher. The watcher is canceled if the user may read
+// none of its range, and notified of the change otherwise if the user may
+// read only part of it or all of it again. A canceled watcher is removed from
+// the announced ids. It returns false if the response cannot be sent.
+func (sws *serverWatchStream) revalidateWatcher(id mvcc.WatchID, ids map[mvcc.WatchID]struct{}) bool {
+	sws.mu.RLock()
+	r, ok := sws.ranges[id]
+	prev := sws.permitted[id]
+	sws.mu.RUnlock()
+	if !ok {
+		return true
+	}
+
+	permitted := sws.ag.AuthStore().ReadableRanges(sws.user, r)
+	full := len(permitted) == 1 && keyRangesEqual(permitted, []auth.KeyRange{r})
+	var wr *pb.WatchResponse
+	switch {
+	case len(permitted) == 0:
+		if err := sws.watchStream.Cancel(id); err != nil {
+			// the watcher was already canceled
+			return true
+		}
+		wr = &pb.WatchResponse{
+			Header:        sws.newResponseHeader(sws.watchStream.Rev()),
+			WatchId:       int64(id),
+			Canceled:      true,
+			CancelReason:  rpctypes.ErrGRPCPermissionDenied.Error(),
+			CancelCode:    pb.WatchResponse_AUTH_REVOKED,
+			CancelDetails: &pb.WatchCancelDetails{User: sws.user, Key: r.Key, RangeEnd: r.RangeEnd},
+		}
+		sws.forgetWatcher(id)
+		delete(ids, id)
+		delete(sws.sequences, id)
+	case full && prev == nil, !full && keyRangesEqual(permitted, prev):
+		return true
+	default:
+		sws.mu.Lock()
+		if full {
+			delete(sws.permitted, id)
+		} else {
+			sws.permitted[id] = permitted
+		}
+		sws.mu.Unlock()
+		change := &pb.WatchPermissionChange{User: sws.user}
+		for _, p := range permitted {
+			change.PermittedRanges = append(change.PermittedRanges, &pb.WatchKeyRange{Key: p.Key, RangeEnd: p.RangeEnd})
+		}
+		wr = &pb.WatchResponse{
+			Header:           sws.newResponseHeader(sws.watchStream.Rev()),
+			WatchId:          int64(id),
+			PermissionChange: change,
+		}
+	}
+
+	if err := sws.gRPCStream.Send(wr); err != nil {
+		sws.logSendError("failed to send watch permission change to gRPC stream", err)
+		return false
+	}
+	return true
+}
+
+// permittedEvents returns the events of the keys in the permitted ranges.
+func permittedEvents(evs []mvccpb.Event, permitted []auth.KeyRange) []mvccpb.Event {
+	var kept []mvccpb.Event
+	for _, ev := range evs {
+		for _, p := range permitted {
+			if p.Contains(ev.Kv.Key) {
+				kept = append(kept, ev)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+func keyRangesEqual(a, b []auth.KeyRange) bool {
+	return slices.EqualFunc(a, b, func(x, y auth.KeyRange) bool {
+		return bytes.Equal(x.Key, y.Key) && bytes.Equal(x.RangeEnd, y.RangeEnd)
+	})
+}
+
+// sequenceEvents sets the sequence token of the first event of wr. The
+// events sent to each watcher of the stream are numbered consecutively from 1.
+func (sws *serverWatchStream) sequenceEvents(wr *pb.WatchResponse) {
+	if len(wr.Events) == 0 || wr.WatchId == clientv3.InvalidWatchID {
+		return
+	}
+	id := mvcc.WatchID(wr.WatchId)
+	wr.FirstSequence = sws.sequences[id] + 1
+	sws.sequences[id] += int64(len(wr.Events))
+}
+
+// send sends a watch response, splitting it into fragments if the watcher
+// asked for it.
+func (sws *serverWatchStream) send(wr *pb.WatchResponse) error {
+	sws.mu.RLock()
+	limit, ok := sws.fragment[mvcc.WatchID(wr.WatchId)]
+	sws.mu.RUnlock()
+
+	if !ok {
+		return sws.gRPCStream.Send(wr)
+	}
+	return sendFragments(wr, limit, sws.gRPCStream.Send)
+}
+
+// fragmentLimit returns the size the responses of the watcher created by
+// creq are split at, or 0 if they are not fragmented.
+func fragmentLimit(creq *pb.WatchCreateRequest, maxRequestBytes uint) uint {
+	if creq.MaxResponseBytes > 0 && uint(creq.MaxResponseBytes) < maxRequestBytes {
+		return uint(creq.MaxResponseBytes)
+	}
+	if creq.Fragment || creq.MaxResponseBytes > 0 {
+		return maxRequestBytes
+	}
+	return 0
+}
+
+func (sws *serverWatchStream) logSendError(msg string, err error) {
+	if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
+		sws.lg.Debug(msg, zap.Error(err))
+	} else {
+		sws.lg.Warn(msg, zap.Error(err))
+		streamFailures.WithLabelValues("send", "watch").Inc()
+	}
+}
+
 func IsCreateEvent(e mvccpb.Event) bool {
 	return e.Type == mvccpb.PUT && e.Kv.CreateRevision == e.Kv.ModRevision
 }
@@ -566,6 +1102,9 @@ func sendFragments(
 	var idx int
 	for {
 		cur := ow
+		if wr.FirstSequence != 0 {
+			cur.FirstSequence = wr.FirstSequence + int64(idx)
+		}
 		for _, ev := range wr.Events[idx:] {
 			cur.Events = append(cur.Events, ev)
 			if len(cur.Events) > 1 && uint(cur.Size()) >= maxRequestBytes {
@@ -590,10 +1129,38 @@ func sendFragments(
 
 func (sws *serverWatchStream) close() {
 	sws.watchStream.Close()
+	sws.mu.Lock()
+	sws.closed = true
+	watchersByIdentity.WithLabelValues(sws.identity).Sub(float64(len(sws.active)))
+	sws.active = nil
+	sws.mu.Unlock()
 	close(sws.closec)
 	sws.wg.Wait()
 }
 
+// trackWatcherLocked counts the created watcher in the watchers by identity
+// metric. sws.mu must be held.
+func (sws *serverWatchStream) trackWatcherLocked(id mvcc.WatchID) {
+	if sws.closed {
+		return
+	}
+	if _, ok := sws.active[id]; ok {
+		return
+	}
+	sws.active[id] = struct{}{}
+	watchersByIdentity.WithLabelValues(sws.identity).Inc()
+}
+
+// untrackWatcherLocked stops counting the canceled watcher in the watchers by
+// identity metric. sws.mu must be held.
+func (sws *serverWatchStream) untrackWatcherLocked(id mvcc.WatchID) {
+	if _, ok := sws.active[id]; !ok {
+		return
+	}
+	delete(sws.active, id)
+	watchersByIdentity.WithLabelValues(sws.identity).Dec()
+}
+
 func (sws *serverWatchStream) newResponseHeader(rev int64) *pb.ResponseHeader {
 	return &pb.ResponseHeader{
 		ClusterId: uint64(sws.clusterID),
@@ -0,0 +1,81 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// watchResponseBatch aggregates event responses of a watch stream so that
+// they are sent with fewer gRPC writes. Consecutive responses of the same
+// watcher are merged into a single response as long as the merged response
+// stays below maxBytes. Responses of different watchers are independent, so
+// their relative order does not matter.
+type watchResponseBatch struct {
+	maxBytes int
+
+	resps []*pb.WatchResponse
+	// last maps a watch ID to the index of its last response in resps.
+	last map[int64]int
+	// sizes holds an upper bound of the encoded size of each response in resps.
+	sizes []int
+
+	size   int
+	events int
+}
+
+func newWatchResponseBatch(maxBytes uint) *watchResponseBatch {
+	return &watchResponseBatch{
+		maxBytes: int(maxBytes),
+		last:     make(map[int64]int),
+	}
+}
+
+// add adds an event response to the batch and returns true if the batch
+// should be flushed because it reached its size limit.
+func (b *watchResponseBatch) add(wr *pb.WatchResponse) bool {
+	wrSize := wr.Size()
+	if i, ok := b.last[wr.WatchId]; ok && b.sizes[i]+wrSize <= b.maxBytes {
+		merged := b.resps[i]
+		merged.Header = wr.Header
+		merged.Events = append(merged.Events, wr.Events...)
+		// the header and watch ID are counted again, which overestimates
+		// the merged size but avoids computing it for every event
+		b.sizes[i] += wrSize
+		b.size += wrSize
+	} else {
+		b.last[wr.WatchId] = len(b.resps)
+		b.resps = append(b.resps, wr)
+		b.sizes = append(b.sizes, wrSize)
+		b.size += wrSize
+	}
+	b.events += len(wr.Events)
+	return b.size >= b.maxBytes
+}
+
+func (b *watchResponseBatch) empty() bool {
+	return len(b.resps) == 0
+}
+
+// take returns the batched responses and resets the batch.
+func (b *watchResponseBatch) take() []*pb.WatchResponse {
+	resps := b.resps
+	b.resps = nil
+	b.sizes = b.sizes[:0]
+	clear(b.last)
+	b.size = 0
+	b.events = 0
+	return resps
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func newBatchTestResponse(watchID, rev int64) *pb.WatchResponse {
+	wr := createResponse(10, 1)
+	wr.WatchId = watchID
+	wr.Header = &pb.ResponseHeader{Revision: rev}
+	wr.Events[0].Kv.ModRevision = rev
+	return wr
+}
+
+func TestWatchResponseBatchMerge(t *testing.T) {
+	b := newWatchResponseBatch(1024)
+	require.True(t, b.empty())
+
+	require.False(t, b.add(newBatchTestResponse(1, 2)))
+	require.False(t, b.add(newBatchTestResponse(2, 3)))
+	require.False(t, b.add(newBatchTestResponse(1, 4)))
+	assert.Equal(t, 3, b.events)
+
+	resps := b.take()
+	require.Len(t, resps, 2)
+	assert.Equal(t, int64(1), resps[0].WatchId)
+	assert.Equal(t, int64(4), resps[0].Header.Revision)
+	require.Len(t, resps[0].Events, 2)
+	assert.Equal(t, int64(2), resps[0].Events[0].Kv.ModRevision)
+	assert.Equal(t, int64(4), resps[0].Events[1].Kv.ModRevision)
+	assert.Equal(t, int64(2), resps[1].WatchId)
+
+	assert.True(t, b.empty())
+	assert.Equal(t, 0, b.events)
+	assert.Equal(t, 0, b.size)
+}
+
+func TestWatchResponseBatchSizeLimit(t *testing.T) {
+	wrSize := newBatchTestResponse(1, 2).Size()
+	b := newWatchResponseBatch(uint(2*wrSize + wrSize/2))
+
+	require.False(t, b.add(newBatchTestResponse(1, 2)))
+	require.False(t, b.add(newBatchTestResponse(1, 3)))
+	// merging would exceed the limit, so a new response is started
+	require.True(t, b.add(newBatchTestResponse(1, 4)))
+
+	resps := b.take()
+	require.Len(t, resps, 2)
+	assert.Len(t, resps[0].Events, 2)
+	assert.Len(t, resps[1].Events, 1)
+	for _, wr := range resps {
+		assert.LessOrEqual(t, wr.Size(), 2*wrSize+wrSize/2)
+	}
+}
+
+// BenchmarkWatchSend compares sending the events of 10k watchers one
+// response at a time with sending them through a watchResponseBatch.
+func BenchmarkWatchSend(b *testing.B) {
+	const (
+		watchers = 10000
+		events   = 5 * watchers
+	)
+	resps := make([]*pb.WatchResponse, events)
+	for i := range resps {
+		resps[i] = newBatchTestResponse(int64(i%watchers), int64(i))
+	}
+	var sends int
+	send := func(wr *pb.WatchResponse) {
+		sends++
+		if _, err := wr.Marshal(); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("unbatched", func(b *testing.B) {
+		sends = 0
+		for i := 0; i < b.N; i++ {
+			for _, wr := range resps {
+				send(wr)
+			}
+		}
+		b.ReportMetric(float64(sends)/float64(b.N), "sends/op")
+	})
+	b.Run("batched", func(b *testing.B) {
+		sends = 0
+		batch := newWatchResponseBatch(uint(1.5 * 1024 * 1024))
+		for i := 0; i < b.N; i++ {
+			for _, wr := range resps {
+				// copy, the batch merges events into the responses it holds
+				cp := *wr
+				if batch.add(&cp) {
+					for _, bwr := range batch.take() {
+						send(bwr)
+					}
+				}
+			}
+			for _, bwr := range batch.take() {
+				send(bwr)
+			}
+		}
+		b.ReportMetric(float64(sends)/float64(b.N), "sends/op")
+	})
+}
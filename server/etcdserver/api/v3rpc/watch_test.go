@@ -16,12 +16,21 @@ package v3rpc
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"fmt"
 	"math"
 	"testing"
 
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/auth"
 )
 
 func TestSendFragment(t *testing.T) {
@@ -83,6 +92,45 @@ func TestSendFragment(t *testing.T) {
 	}
 }
 
+func TestSendFragmentSequence(t *testing.T) {
+	wr := createResponse(15, 5)
+	wr.FirstSequence = 7
+	var fragments []*pb.WatchResponse
+	err := sendFragments(wr, 10, func(wr *pb.WatchResponse) error {
+		fragments = append(fragments, wr)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fragments) != 5 {
+		t.Fatalf("expected 5 fragments, got %d", len(fragments))
+	}
+	for i, f := range fragments {
+		if f.FirstSequence != int64(7+i) {
+			t.Errorf("#%d: expected first sequence %d, got %d", i, 7+i, f.FirstSequence)
+		}
+	}
+}
+
+func TestFragmentLimit(t *testing.T) {
+	tt := []struct {
+		creq  *pb.WatchCreateRequest
+		limit uint
+	}{
+		{creq: &pb.WatchCreateRequest{}, limit: 0},
+		{creq: &pb.WatchCreateRequest{Fragment: true}, limit: 100},
+		{creq: &pb.WatchCreateRequest{MaxResponseBytes: 10}, limit: 10},
+		{creq: &pb.WatchCreateRequest{MaxResponseBytes: 10, Fragment: true}, limit: 10},
+		{creq: &pb.WatchCreateRequest{MaxResponseBytes: 1000}, limit: 100},
+	}
+	for i, tc := range tt {
+		if got := fragmentLimit(tc.creq, 100); got != tc.limit {
+			t.Errorf("#%d: expected limit %d, got %d", i, tc.limit, got)
+		}
+	}
+}
+
 func createResponse(dataSize, events int) (resp *pb.WatchResponse) {
 	resp = &pb.WatchResponse{Events: make([]*mvccpb.Event, events)}
 	for i := range resp.Events {
@@ -94,3 +142,60 @@ func createResponse(dataSize, events int) (resp *pb.WatchResponse) {
 	}
 	return resp
 }
+
+func TestWatchIdentityLabel(t *testing.T) {
+	watchIdentitiesMu.Lock()
+	watchIdentities = make(map[string]struct{})
+	watchIdentitiesMu.Unlock()
+
+	if l := watchIdentityLabel(""); l != watchIdentityAnonymous {
+		t.Errorf("label of empty identity = %q, want %q", l, watchIdentityAnonymous)
+	}
+	for i := 0; i < maxWatchIdentities; i++ {
+		id := fmt.Sprintf("user-%d", i)
+		if l := watchIdentityLabel(id); l != id {
+			t.Errorf("label of %q = %q, want %q", id, l, id)
+		}
+	}
+	if l := watchIdentityLabel("user-0"); l != "user-0" {
+		t.Errorf("label of known identity = %q, want %q", l, "user-0")
+	}
+	if l := watchIdentityLabel("one-too-many"); l != watchIdentityOther {
+		t.Errorf("label over the limit = %q, want %q", l, watchIdentityOther)
+	}
+}
+
+type fakeAuthGetter struct {
+	AuthGetter
+	authInfo *auth.AuthInfo
+}
+
+func (ag fakeAuthGetter) AuthInfoFromCtx(context.Context) (*auth.AuthInfo, error) {
+	return ag.authInfo, nil
+}
+
+func TestStreamIdentity(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "client-cn"}}
+	tlsCtx := peer.NewContext(t.Context(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+
+	tests := []struct {
+		name     string
+		authInfo *auth.AuthInfo
+		ctx      context.Context
+		want     string
+	}{
+		{name: "no identity", ctx: t.Context(), want: ""},
+		{name: "auth user", authInfo: &auth.AuthInfo{Username: "alice"}, ctx: tlsCtx, want: "alice"},
+		{name: "common name with auth disabled", ctx: tlsCtx, want: "client-cn"},
+		{name: "peer without TLS", ctx: peer.NewContext(t.Context(), &peer.Peer{}), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := streamIdentity(fakeAuthGetter{authInfo: tt.authInfo}, tt.ctx); got != tt.want {
+				t.Errorf("streamIdentity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
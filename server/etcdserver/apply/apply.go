@@ -28,8 +28,12 @@ import (
 	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver/api"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3counter"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3fence"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	mvcctxn "go.etcd.io/etcd/server/v3/etcdserver/txn"
@@ -61,6 +65,8 @@ type Result struct {
 	// Compaction requests.
 	Physc <-chan struct{}
 	Trace *traceutil.Trace
+	// Took is the time spent applying the request.
+	Took time.Duration
 }
 
 type applyFunc func(*pb.InternalRaftRequest, membership.ShouldApplyV3) *Result
@@ -79,11 +85,21 @@ type applierV3 interface {
 
 	LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error)
 	LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error)
+	LeaseExpire(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error)
 
 	LeaseCheckpoint(lc *pb.LeaseCheckpointRequest) (*pb.LeaseCheckpointResponse, error)
 
 	Alarm(*pb.AlarmRequest) (*pb.AlarmResponse, error)
 
+	CompactionHoldGrant(h *pb.InternalCompactionHoldGrantRequest) (*pb.CompactionHoldGrantResponse, error)
+	CompactionHoldRevoke(r *pb.CompactionHoldRevokeRequest) (*pb.CompactionHoldRevokeResponse, error)
+
+	ClusterConfigSet(r *pb.ClusterConfigSetRequest) (*pb.ClusterConfigSetResponse, error)
+
+	CounterAdd(r *pb.CounterAddRequest) (*pb.CounterAddResponse, error)
+
+	FencePrefix(r *pb.InternalFencePrefixRequest) (*pb.FencePrefixResponse, error)
+
 	Authenticate(r *pb.InternalAuthenticateRequest) (*pb.AuthenticateResponse, error)
 
 	AuthEnable() (*pb.AuthEnableResponse, error)
@@ -100,6 +116,8 @@ type applierV3 interface {
 	RoleGrantPermission(ua *pb.AuthRoleGrantPermissionRequest) (*pb.AuthRoleGrantPermissionResponse, error)
 	RoleGet(ua *pb.AuthRoleGetRequest) (*pb.AuthRoleGetResponse, error)
 	RoleRevokePermission(ua *pb.AuthRoleRevokePermissionRequest) (*pb.AuthRoleRevokePermissionResponse, error)
+	RoleGrantRPCPermission(ua *pb.AuthRoleGrantRPCPermissionRequest) (*pb.AuthRoleGrantRPCPermissionResponse, error)
+	RoleRevokeRPCPermission(ua *pb.AuthRoleRevokeRPCPermissionRequest) (*pb.AuthRoleRevokeRPCPermissionResponse, error)
 	RoleDelete(ua *pb.AuthRoleDeleteRequest) (*pb.AuthRoleDeleteResponse, error)
 	UserList(ua *pb.AuthUserListRequest) (*pb.AuthUserListResponse, error)
 	RoleList(ua *pb.AuthRoleListRequest) (*pb.AuthRoleListResponse, error)
@@ -112,6 +130,10 @@ type ApplierOptions struct {
 	Logger                       *zap.Logger
 	KV                           mvcc.KV
 	AlarmStore                   *v3alarm.AlarmStore
+	CompactionHolds              *v3compactor.HoldStore
+	Counters                     *v3counter.Store
+	Fences                       *v3fence.Store
+	ClusterConfig                *clusterconfig.Store
 	AuthStore                    auth.AuthStore
 	Lessor                       lease.Lessor
 	Cluster                      *membership.RaftCluster
@@ -181,6 +203,7 @@ func (a *applierV3backend) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantR
 	if err == nil {
 		resp.ID = int64(l.ID)
 		resp.TTL = l.TTL()
+		resp.FencingToken = l.FencingToken()
 		resp.Header = a.newHeader()
 	}
 	return resp, err
@@ -191,6 +214,15 @@ func (a *applierV3backend) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevo
 	return &pb.LeaseRevokeResponse{Header: a.newHeader()}, err
 }
 
+func (a *applierV3backend) LeaseExpire(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	var prefix []byte
+	if a.options.ClusterConfig != nil {
+		prefix = a.options.ClusterConfig.Config().LeaseExpiryEventPrefix
+	}
+	err := a.options.Lessor.Expire(lease.LeaseID(lc.ID), prefix)
+	return &pb.LeaseRevokeResponse{Header: a.newHeader()}, err
+}
+
 func (a *applierV3backend) LeaseCheckpoint(lc *pb.LeaseCheckpointRequest) (*pb.LeaseCheckpointResponse, error) {
 	for _, c := range lc.Checkpoints {
 		err := a.options.Lessor.Checkpoint(lease.LeaseID(c.ID), c.Remaining_TTL)
@@ -201,6 +233,39 @@ func (a *applierV3backend) LeaseCheckpoint(lc *pb.LeaseCheckpointRequest) (*pb.L
 	return &pb.LeaseCheckpointResponse{Header: a.newHeader()}, nil
 }
 
+func (a *applierV3backend) CompactionHoldGrant(h *pb.InternalCompactionHoldGrantRequest) (*pb.CompactionHoldGrantResponse, error) {
+	// the revision may have been compacted since it was checked by the
+	// proposing member
+	if h.Revision < a.options.KV.FirstRev() {
+		return &pb.CompactionHoldGrantResponse{Header: a.newHeader()}, mvcc.ErrCompacted
+	}
+	a.options.CompactionHolds.Grant(h)
+	return &pb.CompactionHoldGrantResponse{Header: a.newHeader(), ID: h.ID, TTL: h.TTL, Revision: h.Revision}, nil
+}
+
+func (a *applierV3backend) CompactionHoldRevoke(r *pb.CompactionHoldRevokeRequest) (*pb.CompactionHoldRevokeResponse, error) {
+	err := a.options.CompactionHolds.Revoke(r.ID)
+	return &pb.CompactionHoldRevokeResponse{Header: a.newHeader()}, err
+}
+
+func (a *applierV3backend) FencePrefix(r *pb.InternalFencePrefixRequest) (*pb.FencePrefixResponse, error) {
+	a.options.Fences.Fence(r)
+	return &pb.FencePrefixResponse{Header: a.newHeader()}, nil
+}
+
+func (a *applierV3backend) ClusterConfigSet(r *pb.ClusterConfigSetRequest) (*pb.ClusterConfigSetResponse, error) {
+	a.options.ClusterConfig.Set(r.Config)
+	return &pb.ClusterConfigSetResponse{Header: a.newHeader()}, nil
+}
+
+func (a *applierV3backend) CounterAdd(r *pb.CounterAddRequest) (*pb.CounterAddResponse, error) {
+	counters, err := a.options.Counters.Add(r.Deltas)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CounterAddResponse{Header: a.newHeader(), Counters: counters}, nil
+}
+
 func (a *applierV3backend) Alarm(ar *pb.AlarmRequest) (*pb.AlarmResponse, error) {
 	resp := &pb.AlarmResponse{}
 
@@ -211,7 +276,12 @@ func (a *applierV3backend) Alarm(ar *pb.AlarmRequest) (*pb.AlarmResponse, error)
 		if ar.Alarm == pb.AlarmType_NONE {
 			break
 		}
-		m := a.options.AlarmStore.Activate(types.ID(ar.MemberID), ar.Alarm)
+		var m *pb.AlarmMember
+		if ar.Alarm == pb.AlarmType_THRESHOLD {
+			m = a.options.AlarmStore.ActivateThreshold(types.ID(ar.MemberID), ar.Metrics)
+		} else {
+			m = a.options.AlarmStore.Activate(types.ID(ar.MemberID), ar.Alarm)
+		}
 		if m == nil {
 			break
 		}
@@ -233,25 +303,33 @@ func (a *applierV3backend) Alarm(ar *pb.AlarmRequest) (*pb.AlarmResponse, error)
 type applierV3Capped struct {
 	applierV3
 	q serverstorage.BackendQuota
+	// err is the error of the rejected requests.
+	err error
 }
 
 // newApplierV3Capped creates an applyV3 that will reject Puts and transactions
-// with Puts so that the number of keys in the store is capped.
-func newApplierV3Capped(base applierV3) applierV3 { return &applierV3Capped{applierV3: base} }
+// with Puts with err so that the number of keys in the store is capped.
+func newApplierV3Capped(base applierV3, err error) applierV3 {
+	return &applierV3Capped{applierV3: base, err: err}
+}
 
 func (a *applierV3Capped) Put(_ *pb.PutRequest) (*pb.PutResponse, *traceutil.Trace, error) {
-	return nil, nil, errors.ErrNoSpace
+	return nil, nil, a.err
 }
 
 func (a *applierV3Capped) Txn(r *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Trace, error) {
 	if a.q.Cost(r) > 0 {
-		return nil, nil, errors.ErrNoSpace
+		return nil, nil, a.err
 	}
 	return a.applierV3.Txn(r)
 }
 
 func (a *applierV3Capped) LeaseGrant(_ *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
-	return nil, errors.ErrNoSpace
+	return nil, a.err
+}
+
+func (a *applierV3Capped) CounterAdd(_ *pb.CounterAddRequest) (*pb.CounterAddResponse, error) {
+	return nil, a.err
 }
 
 func (a *applierV3backend) AuthEnable() (*pb.AuthEnableResponse, error) {
@@ -362,6 +440,22 @@ func (a *applierV3backend) RoleRevokePermission(r *pb.AuthRoleRevokePermissionRe
 	return resp, err
 }
 
+func (a *applierV3backend) RoleGrantRPCPermission(r *pb.AuthRoleGrantRPCPermissionRequest) (*pb.AuthRoleGrantRPCPermissionResponse, error) {
+	resp, err := a.options.AuthStore.RoleGrantRPCPermission(r)
+	if resp != nil {
+		resp.Header = a.newHeader()
+	}
+	return resp, err
+}
+
+func (a *applierV3backend) RoleRevokeRPCPermission(r *pb.AuthRoleRevokeRPCPermissionRequest) (*pb.AuthRoleRevokeRPCPermissionResponse, error) {
+	resp, err := a.options.AuthStore.RoleRevokeRPCPermission(r)
+	if resp != nil {
+		resp.Header = a.newHeader()
+	}
+	return resp, err
+}
+
 func (a *applierV3backend) RoleDelete(r *pb.AuthRoleDeleteRequest) (*pb.AuthRoleDeleteResponse, error) {
 	resp, err := a.options.AuthStore.RoleDelete(r)
 	if resp != nil {
@@ -458,6 +552,15 @@ func (a *quotaApplierV3) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantRes
 	return resp, err
 }
 
+func (a *quotaApplierV3) CounterAdd(r *pb.CounterAddRequest) (*pb.CounterAddResponse, error) {
+	ok := a.q.Available(r)
+	resp, err := a.applierV3.CounterAdd(r)
+	if err == nil && !ok {
+		err = errors.ErrNoSpace
+	}
+	return resp, err
+}
+
 func (a *applierV3backend) newHeader() *pb.ResponseHeader {
 	return &pb.ResponseHeader{
 		ClusterId: uint64(a.options.Cluster.ID()),
@@ -64,7 +64,7 @@ func (aa *authApplierV3) Apply(r *pb.InternalRaftRequest, shouldApplyV3 membersh
 }
 
 func (aa *authApplierV3) Put(r *pb.PutRequest) (*pb.PutResponse, *traceutil.Trace, error) {
-	if err := aa.as.IsPutPermitted(&aa.authInfo, r.Key); err != nil {
+	if err := txn.IsPutPermitted(aa.as, &aa.authInfo, r); err != nil {
 		return nil, nil, err
 	}
 
@@ -113,6 +113,15 @@ func (aa *authApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Tra
 	return aa.applierV3.Txn(rt)
 }
 
+func (aa *authApplierV3) CounterAdd(r *pb.CounterAddRequest) (*pb.CounterAddResponse, error) {
+	for _, d := range r.Deltas {
+		if err := aa.as.IsPutPermitted(&aa.authInfo, d.Key); err != nil {
+			return nil, err
+		}
+	}
+	return aa.applierV3.CounterAdd(r)
+}
+
 func (aa *authApplierV3) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
 	if err := aa.checkLeasePuts(lease.LeaseID(lc.ID)); err != nil {
 		return nil, err
@@ -146,7 +155,9 @@ func (aa *authApplierV3) checkLeasePutsKeys(l *lease.Lease) error {
 
 func (aa *authApplierV3) UserGet(r *pb.AuthUserGetRequest) (*pb.AuthUserGetResponse, error) {
 	err := aa.as.IsAdminPermitted(&aa.authInfo)
-	if err != nil && r.Name != aa.authInfo.Username {
+	// password hashes are only handed out to the root role, not even to the
+	// user itself.
+	if err != nil && (r.Name != aa.authInfo.Username || r.WithPasswordHash) {
 		aa.authInfo.Username = ""
 		aa.authInfo.Revision = 0
 		return &pb.AuthUserGetResponse{}, err
@@ -190,12 +201,18 @@ func needAdminPermission(r *pb.InternalRaftRequest) bool {
 		return true
 	case r.AuthRoleRevokePermission != nil:
 		return true
+	case r.AuthRoleGrantRpcPermission != nil:
+		return true
+	case r.AuthRoleRevokeRpcPermission != nil:
+		return true
 	case r.AuthRoleDelete != nil:
 		return true
 	case r.AuthUserList != nil:
 		return true
 	case r.AuthRoleList != nil:
 		return true
+	case r.ClusterConfigSet != nil:
+		return true
 	default:
 		return false
 	}
@@ -731,6 +731,18 @@ func TestAuthApplierV3_UserGet(t *testing.T) {
 			request:     &pb.AuthUserGetRequest{Name: userWriteOnly},
 			expectError: nil,
 		},
+		{
+			name:        "UserGet with password hash permission denied with non-root role and requests itself",
+			userName:    userWriteOnly,
+			request:     &pb.AuthUserGetRequest{Name: userWriteOnly, WithPasswordHash: true},
+			expectError: auth.ErrPermissionDenied,
+		},
+		{
+			name:        "UserGet with password hash success with root role",
+			userName:    userRoot,
+			request:     &pb.AuthUserGetRequest{Name: userWriteOnly, WithPasswordHash: true},
+			expectError: nil,
+		},
 	}
 
 	authApplier := defaultAuthApplierV3(t)
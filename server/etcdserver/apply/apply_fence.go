@@ -0,0 +1,81 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"sync"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3fence"
+)
+
+// fenceApplierV3 rejects writes to the fenced prefixes unless the request
+// carries the token of the fence.
+type fenceApplierV3 struct {
+	applierV3
+	fences *v3fence.Store
+
+	// mu serializes Apply so that token isn't corrupted.
+	mu sync.Mutex
+
+	token string
+}
+
+func newFenceApplierV3(fences *v3fence.Store, base applierV3) applierV3 {
+	if fences == nil {
+		return base
+	}
+	return &fenceApplierV3{applierV3: base, fences: fences}
+}
+
+func (fa *fenceApplierV3) Apply(r *pb.InternalRaftRequest, shouldApplyV3 membership.ShouldApplyV3, applyFunc applyFunc) *Result {
+	fa.mu.Lock()
+	defer fa.mu.Unlock()
+	if r.Header != nil {
+		fa.token = r.Header.FenceToken
+	}
+	ret := fa.applierV3.Apply(r, shouldApplyV3, applyFunc)
+	fa.token = ""
+	return ret
+}
+
+func (fa *fenceApplierV3) Put(r *pb.PutRequest) (*pb.PutResponse, *traceutil.Trace, error) {
+	if err := fa.checkWrite(r.Key, nil); err != nil {
+		return nil, nil, err
+	}
+	return fa.applierV3.Put(r)
+}
+
+func (fa *fenceApplierV3) DeleteRange(r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, *traceutil.Trace, error) {
+	if err := fa.checkWrite(r.Key, r.RangeEnd); err != nil {
+		return nil, nil, err
+	}
+	return fa.applierV3.DeleteRange(r)
+}
+
+func (fa *fenceApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Trace, error) {
+	// like auth, both branches are checked so that the outcome does not
+	// depend on the comparisons.
+	if err := checkTxnWrites(rt, fa.checkWrite); err != nil {
+		return nil, nil, err
+	}
+	return fa.applierV3.Txn(rt)
+}
+
+func (fa *fenceApplierV3) checkWrite(key, end []byte) error {
+	return fa.fences.Check(key, end, fa.token)
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"context"
+	"sync"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/txn"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// protectApplierV3 rejects writes to the protected prefixes of the cluster
+// configuration unless the request carries the lease of a candidate of the
+// guarding election that is allowed to write.
+type protectApplierV3 struct {
+	applierV3
+	cs *clusterconfig.Store
+	kv mvcc.KV
+
+	// mu serializes Apply so that electionLease isn't corrupted.
+	mu sync.Mutex
+
+	electionLease int64
+}
+
+func newProtectApplierV3(cs *clusterconfig.Store, kv mvcc.KV, base applierV3) applierV3 {
+	if cs == nil {
+		return base
+	}
+	return &protectApplierV3{applierV3: base, cs: cs, kv: kv}
+}
+
+func (pa *protectApplierV3) Apply(r *pb.InternalRaftRequest, shouldApplyV3 membership.ShouldApplyV3, applyFunc applyFunc) *Result {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+	if r.Header != nil {
+		pa.electionLease = r.Header.ElectionLease
+	}
+	ret := pa.applierV3.Apply(r, shouldApplyV3, applyFunc)
+	pa.electionLease = 0
+	return ret
+}
+
+func (pa *protectApplierV3) Put(r *pb.PutRequest) (*pb.PutResponse, *traceutil.Trace, error) {
+	if err := pa.checkWrite(r.Key, nil); err != nil {
+		return nil, nil, err
+	}
+	return pa.applierV3.Put(r)
+}
+
+func (pa *protectApplierV3) DeleteRange(r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, *traceutil.Trace, error) {
+	if err := pa.checkWrite(r.Key, r.RangeEnd); err != nil {
+		return nil, nil, err
+	}
+	return pa.applierV3.DeleteRange(r)
+}
+
+func (pa *protectApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Trace, error) {
+	// like auth, both branches are checked so that the outcome does not
+	// depend on the comparisons.
+	if err := checkTxnWrites(rt, pa.checkWrite); err != nil {
+		return nil, nil, err
+	}
+	return pa.applierV3.Txn(rt)
+}
+
+// checkTxnWrites calls check with the range written by each write of the
+// transaction, and of its nested transactions and foreach requests, returning
+// the first error.
+func checkTxnWrites(rt *pb.TxnRequest, check func(key, end []byte) error) error {
+	for _, reqs := range [][]*pb.RequestOp{rt.Success, rt.Failure} {
+		for _, requ := range reqs {
+			var err error
+			switch tv := requ.Request.(type) {
+			case *pb.RequestOp_RequestPut:
+				if tv.RequestPut != nil {
+					err = check(tv.RequestPut.Key, nil)
+				}
+			case *pb.RequestOp_RequestDeleteRange:
+				if tv.RequestDeleteRange != nil {
+					err = check(tv.RequestDeleteRange.Key, tv.RequestDeleteRange.RangeEnd)
+				}
+			case *pb.RequestOp_RequestAppend:
+				if tv.RequestAppend != nil {
+					err = check(tv.RequestAppend.Key, nil)
+				}
+			case *pb.RequestOp_RequestTxn:
+				if tv.RequestTxn != nil {
+					err = checkTxnWrites(tv.RequestTxn, check)
+				}
+			case *pb.RequestOp_RequestForEach:
+				if tv.RequestForEach != nil && txn.IsForEachWrite(tv.RequestForEach) {
+					err = check(tv.RequestForEach.Key, tv.RequestForEach.RangeEnd)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (pa *protectApplierV3) checkWrite(key, end []byte) error {
+	ps := pa.cs.Protections(key, end)
+	if len(ps) == 0 {
+		return nil
+	}
+	if pa.electionLease == 0 {
+		return clusterconfig.ErrProtectedPrefix
+	}
+	txnRead := pa.kv.Read(mvcc.SharedBufReadTxMode, traceutil.TODO())
+	defer txnRead.End()
+	for _, p := range ps {
+		if !pa.isAllowedWriter(txnRead, p) {
+			return clusterconfig.ErrProtectedPrefix
+		}
+	}
+	return nil
+}
+
+// isAllowedWriter reports whether the election lease of the request belongs to
+// a candidate of the election of p that is allowed to write. The leader is the
+// candidate with the oldest key, as elected by the concurrency package.
+func (pa *protectApplierV3) isAllowedWriter(txnRead mvcc.TxnRead, p *pb.ProtectedPrefix) bool {
+	key, end := clusterconfig.CandidateRange(p.Election)
+	rr, err := txnRead.Range(context.TODO(), key, end, mvcc.RangeOptions{})
+	if err != nil || len(rr.KVs) == 0 {
+		return false
+	}
+	leader := rr.KVs[0]
+	candidate := false
+	for _, kv := range rr.KVs {
+		if kv.CreateRevision < leader.CreateRevision {
+			leader = kv
+		}
+		if kv.Lease == pa.electionLease {
+			candidate = true
+		}
+	}
+	if p.Writer == pb.ProtectedPrefix_FOLLOWER {
+		return candidate && leader.Lease != pa.electionLease
+	}
+	return leader.Lease == pa.electionLease
+}
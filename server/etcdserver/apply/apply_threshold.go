@@ -0,0 +1,99 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+// applierV3Threshold rejects the operations blocked by the alarm thresholds
+// crossed by the members raising the THRESHOLD alarm.
+type applierV3Threshold struct {
+	applierV3
+	blocked map[pb.AlarmThreshold_Operation]bool
+}
+
+func newApplierV3Threshold(base applierV3, blocked map[pb.AlarmThreshold_Operation]bool) applierV3 {
+	return &applierV3Threshold{applierV3: base, blocked: blocked}
+}
+
+func (a *applierV3Threshold) Put(r *pb.PutRequest) (*pb.PutResponse, *traceutil.Trace, error) {
+	if a.blocked[pb.AlarmThreshold_PUT] {
+		return nil, nil, errors.ErrThresholdExceeded
+	}
+	return a.applierV3.Put(r)
+}
+
+func (a *applierV3Threshold) DeleteRange(r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, *traceutil.Trace, error) {
+	if a.blocked[pb.AlarmThreshold_DELETE] {
+		return nil, nil, errors.ErrThresholdExceeded
+	}
+	return a.applierV3.DeleteRange(r)
+}
+
+func (a *applierV3Threshold) Txn(r *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Trace, error) {
+	// like auth, both branches are checked so that the outcome does not
+	// depend on the comparisons.
+	if a.isBlocked(r.Success) || a.isBlocked(r.Failure) {
+		return nil, nil, errors.ErrThresholdExceeded
+	}
+	return a.applierV3.Txn(r)
+}
+
+func (a *applierV3Threshold) LeaseGrant(r *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	if a.blocked[pb.AlarmThreshold_LEASE_GRANT] {
+		return nil, errors.ErrThresholdExceeded
+	}
+	return a.applierV3.LeaseGrant(r)
+}
+
+func (a *applierV3Threshold) CounterAdd(r *pb.CounterAddRequest) (*pb.CounterAddResponse, error) {
+	if a.blocked[pb.AlarmThreshold_PUT] {
+		return nil, errors.ErrThresholdExceeded
+	}
+	return a.applierV3.CounterAdd(r)
+}
+
+// isBlocked reports whether one of the operations of a transaction, or of its
+// nested transactions and foreach requests, is blocked.
+func (a *applierV3Threshold) isBlocked(reqs []*pb.RequestOp) bool {
+	for _, requ := range reqs {
+		switch tv := requ.Request.(type) {
+		case *pb.RequestOp_RequestPut:
+			if a.blocked[pb.AlarmThreshold_PUT] {
+				return true
+			}
+		case *pb.RequestOp_RequestAppend:
+			if a.blocked[pb.AlarmThreshold_PUT] {
+				return true
+			}
+		case *pb.RequestOp_RequestDeleteRange:
+			if a.blocked[pb.AlarmThreshold_DELETE] {
+				return true
+			}
+		case *pb.RequestOp_RequestTxn:
+			if tv.RequestTxn != nil && (a.isBlocked(tv.RequestTxn.Success) || a.isBlocked(tv.RequestTxn.Failure)) {
+				return true
+			}
+		case *pb.RequestOp_RequestForEach:
+			if tv.RequestForEach != nil && a.isBlocked(tv.RequestForEach.Ops) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -15,14 +15,16 @@
 package apply
 
 import (
-	"errors"
+	errorspkg "errors"
 	"time"
 
 	"go.uber.org/zap"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/etcd/server/v3/etcdserver/txn"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
@@ -35,6 +37,7 @@ type uberApplier struct {
 	lg *zap.Logger
 
 	alarmStore           *v3alarm.AlarmStore
+	clusterConfig        *clusterconfig.Store
 	warningApplyDuration time.Duration
 
 	// This is the applier that is taking in consideration current alarms
@@ -50,6 +53,7 @@ func NewUberApplier(opts ApplierOptions) UberApplier {
 	ua := &uberApplier{
 		lg:                   opts.Logger,
 		alarmStore:           opts.AlarmStore,
+		clusterConfig:        opts.ClusterConfig,
 		warningApplyDuration: opts.WarningApplyDuration,
 		applyV3:              applyV3base,
 		applyV3base:          applyV3base,
@@ -62,17 +66,33 @@ func newApplierV3(opts ApplierOptions) applierV3 {
 	applierBackend := newApplierV3Backend(opts)
 	return newAuthApplierV3(
 		opts.AuthStore,
-		newQuotaApplierV3(opts.Logger, opts.QuotaBackendBytesCfg, opts.Backend, applierBackend),
+		newFenceApplierV3(
+			opts.Fences,
+			newProtectApplierV3(
+				opts.ClusterConfig,
+				opts.KV,
+				newQuotaApplierV3(opts.Logger, opts.QuotaBackendBytesCfg, opts.Backend, applierBackend),
+			),
+		),
 		opts.Lessor,
 	)
 }
 
 func (a *uberApplier) restoreAlarms() {
 	noSpaceAlarms := len(a.alarmStore.Get(pb.AlarmType_NOSPACE)) > 0
+	walNoSpaceAlarms := len(a.alarmStore.Get(pb.AlarmType_WALNOSPACE)) > 0
 	corruptAlarms := len(a.alarmStore.Get(pb.AlarmType_CORRUPT)) > 0
 	a.applyV3 = a.applyV3base
 	if noSpaceAlarms {
-		a.applyV3 = newApplierV3Capped(a.applyV3)
+		a.applyV3 = newApplierV3Capped(a.applyV3, errors.ErrNoSpace)
+	}
+	if walNoSpaceAlarms {
+		a.applyV3 = newApplierV3Capped(a.applyV3, errors.ErrWALNoSpace)
+	}
+	if a.clusterConfig != nil {
+		if blocked := a.clusterConfig.Blocked(a.alarmStore.Get(pb.AlarmType_THRESHOLD)); len(blocked) > 0 {
+			a.applyV3 = newApplierV3Threshold(a.applyV3, blocked)
+		}
 	}
 	if corruptAlarms {
 		a.applyV3 = newApplierV3Corrupt(a.applyV3)
@@ -81,7 +101,7 @@ func (a *uberApplier) restoreAlarms() {
 
 func (a *uberApplier) Apply(r *pb.InternalRaftRequest, shouldApplyV3 membership.ShouldApplyV3) *Result {
 	// We first execute chain of Apply() calls down the hierarchy:
-	// (i.e. CorruptApplier -> CappedApplier -> Auth -> Quota -> Backend),
+	// (i.e. CorruptApplier -> CappedApplier -> Auth -> Fence -> Protect -> Quota -> Backend),
 	// then dispatch() unpacks the request to a specific method (like Put),
 	// that gets executed down the hierarchy again:
 	// i.e. CorruptApplier.Put(CappedApplier.Put(...(BackendApplier.Put(...)))).
@@ -94,7 +114,7 @@ func (a *uberApplier) dispatch(r *pb.InternalRaftRequest, shouldApplyV3 membersh
 	op := "unknown"
 	ar := &Result{}
 	defer func(start time.Time) {
-		success := ar.Err == nil || errors.Is(ar.Err, mvcc.ErrCompacted)
+		success := ar.Err == nil || errorspkg.Is(ar.Err, mvcc.ErrCompacted)
 		txn.ApplySecObserve(v3Version, op, success, time.Since(start))
 		txn.WarnOfExpensiveRequest(a.lg, a.warningApplyDuration, start, &pb.InternalRaftStringer{Request: r}, ar.Resp, ar.Err)
 		if !success {
@@ -149,12 +169,32 @@ func (a *uberApplier) dispatch(r *pb.InternalRaftRequest, shouldApplyV3 membersh
 	case r.LeaseRevoke != nil:
 		op = "LeaseRevoke"
 		ar.Resp, ar.Err = a.applyV3.LeaseRevoke(r.LeaseRevoke)
+	case r.LeaseExpire != nil:
+		op = "LeaseExpire"
+		ar.Resp, ar.Err = a.applyV3.LeaseExpire(r.LeaseExpire)
 	case r.LeaseCheckpoint != nil:
 		op = "LeaseCheckpoint"
 		ar.Resp, ar.Err = a.applyV3.LeaseCheckpoint(r.LeaseCheckpoint)
 	case r.Alarm != nil:
 		op = "Alarm"
 		ar.Resp, ar.Err = a.Alarm(r.Alarm)
+	case r.CompactionHoldGrant != nil:
+		op = "CompactionHoldGrant"
+		ar.Resp, ar.Err = a.applyV3.CompactionHoldGrant(r.CompactionHoldGrant)
+	case r.CompactionHoldRevoke != nil:
+		op = "CompactionHoldRevoke"
+		ar.Resp, ar.Err = a.applyV3.CompactionHoldRevoke(r.CompactionHoldRevoke)
+	case r.ClusterConfigSet != nil:
+		op = "ClusterConfigSet"
+		ar.Resp, ar.Err = a.applyV3.ClusterConfigSet(r.ClusterConfigSet)
+		// the thresholds may block other operations
+		a.restoreAlarms()
+	case r.CounterAdd != nil:
+		op = "CounterAdd"
+		ar.Resp, ar.Err = a.applyV3.CounterAdd(r.CounterAdd)
+	case r.FencePrefix != nil:
+		op = "FencePrefix"
+		ar.Resp, ar.Err = a.applyV3.FencePrefix(r.FencePrefix)
 	case r.Authenticate != nil:
 		op = "Authenticate"
 		ar.Resp, ar.Err = a.applyV3.Authenticate(r.Authenticate)
@@ -196,6 +236,12 @@ func (a *uberApplier) dispatch(r *pb.InternalRaftRequest, shouldApplyV3 membersh
 	case r.AuthRoleRevokePermission != nil:
 		op = "AuthRoleRevokePermission"
 		ar.Resp, ar.Err = a.applyV3.RoleRevokePermission(r.AuthRoleRevokePermission)
+	case r.AuthRoleGrantRpcPermission != nil:
+		op = "AuthRoleGrantRPCPermission"
+		ar.Resp, ar.Err = a.applyV3.RoleGrantRPCPermission(r.AuthRoleGrantRpcPermission)
+	case r.AuthRoleRevokeRpcPermission != nil:
+		op = "AuthRoleRevokeRPCPermission"
+		ar.Resp, ar.Err = a.applyV3.RoleRevokeRPCPermission(r.AuthRoleRevokeRpcPermission)
 	case r.AuthRoleDelete != nil:
 		op = "AuthRoleDelete"
 		ar.Resp, ar.Err = a.applyV3.RoleDelete(r.AuthRoleDelete)
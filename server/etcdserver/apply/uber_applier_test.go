@@ -25,6 +25,7 @@ import (
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/server/v3/auth"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
@@ -65,6 +66,7 @@ func defaultUberApplier(t *testing.T) UberApplier {
 		Logger:                       lg,
 		KV:                           kv,
 		AlarmStore:                   alarmStore,
+		ClusterConfig:                clusterconfig.NewStore(be),
 		AuthStore:                    authStore,
 		Lessor:                       lessor,
 		Cluster:                      cluster,
@@ -246,6 +248,93 @@ func TestUberApplier_Alarm_Quota(t *testing.T) {
 	}
 }
 
+// TestUberApplier_Alarm_WALNoSpace tests the applier returns ErrWALNoSpace after alarm WALNOSPACE is activated
+func TestUberApplier_Alarm_WALNoSpace(t *testing.T) {
+	ua := defaultUberApplier(t)
+	result := ua.Apply(&pb.InternalRaftRequest{
+		Header: &pb.RequestHeader{},
+		Alarm: &pb.AlarmRequest{
+			Action:   pb.AlarmRequest_ACTIVATE,
+			MemberID: memberID,
+			Alarm:    pb.AlarmType_WALNOSPACE,
+		},
+	}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.NoError(t, result.Err)
+
+	result = ua.Apply(&pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte(key)}}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.Equalf(t, errors.ErrWALNoSpace, result.Err, "Apply: got %v, expect: %v", result.Err, errors.ErrWALNoSpace)
+
+	result = ua.Apply(&pb.InternalRaftRequest{LeaseGrant: &pb.LeaseGrantRequest{ID: leaseID, TTL: 10}}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.Equalf(t, errors.ErrWALNoSpace, result.Err, "Apply: got %v, expect: %v", result.Err, errors.ErrWALNoSpace)
+
+	result = ua.Apply(&pb.InternalRaftRequest{Range: &pb.RangeRequest{Key: []byte(key)}}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.NoError(t, result.Err)
+}
+
+// TestUberApplier_Alarm_Threshold tests the applier returns ErrThresholdExceeded for
+// the operations blocked by the crossed thresholds of alarm THRESHOLD
+func TestUberApplier_Alarm_Threshold(t *testing.T) {
+	ua := defaultUberApplier(t)
+	result := ua.Apply(&pb.InternalRaftRequest{
+		Header: &pb.RequestHeader{},
+		ClusterConfigSet: &pb.ClusterConfigSetRequest{Config: &pb.ClusterConfig{AlarmThresholds: []*pb.AlarmThreshold{
+			{Metric: pb.AlarmThreshold_DB_SIZE_PERCENT, Value: 80, Block: []pb.AlarmThreshold_Operation{pb.AlarmThreshold_PUT, pb.AlarmThreshold_LEASE_GRANT}},
+			{Metric: pb.AlarmThreshold_WATCHERS, Value: 1000},
+		}}},
+	}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.NoError(t, result.Err)
+
+	activate := func(metrics ...pb.AlarmThreshold_Metric) {
+		result := ua.Apply(&pb.InternalRaftRequest{
+			Header: &pb.RequestHeader{},
+			Alarm: &pb.AlarmRequest{
+				Action:   pb.AlarmRequest_ACTIVATE,
+				MemberID: memberID,
+				Alarm:    pb.AlarmType_THRESHOLD,
+				Metrics:  metrics,
+			},
+		}, membership.ApplyBoth)
+		require.NotNil(t, result)
+		require.NoError(t, result.Err)
+	}
+
+	// the crossed threshold blocks nothing
+	activate(pb.AlarmThreshold_WATCHERS)
+	result = ua.Apply(&pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte(key)}}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.NoError(t, result.Err)
+
+	activate(pb.AlarmThreshold_WATCHERS, pb.AlarmThreshold_DB_SIZE_PERCENT)
+	result = ua.Apply(&pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte(key)}}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.Equalf(t, errors.ErrThresholdExceeded, result.Err, "Apply: got %v, expect: %v", result.Err, errors.ErrThresholdExceeded)
+
+	result = ua.Apply(&pb.InternalRaftRequest{Txn: &pb.TxnRequest{Failure: []*pb.RequestOp{
+		{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte(key)}}},
+	}}}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.Equalf(t, errors.ErrThresholdExceeded, result.Err, "Apply: got %v, expect: %v", result.Err, errors.ErrThresholdExceeded)
+
+	result = ua.Apply(&pb.InternalRaftRequest{LeaseGrant: &pb.LeaseGrantRequest{ID: leaseID, TTL: 10}}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.Equalf(t, errors.ErrThresholdExceeded, result.Err, "Apply: got %v, expect: %v", result.Err, errors.ErrThresholdExceeded)
+
+	result = ua.Apply(&pb.InternalRaftRequest{DeleteRange: &pb.DeleteRangeRequest{Key: []byte(key)}}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	require.NoError(t, result.Err)
+
+	// the metric is back under its threshold
+	activate(pb.AlarmThreshold_WATCHERS)
+	result = ua.Apply(&pb.InternalRaftRequest{Put: &pb.PutRequest{Key: []byte(key)}}, membership.ApplyBoth)
+	require.NotNil(t, result)
+	assert.NoError(t, result.Err)
+}
+
 // TestUberApplier_Alarm_Deactivate tests the applier should be able to apply after alarm is deactivated
 func TestUberApplier_Alarm_Deactivate(t *testing.T) {
 	ua := defaultUberApplier(t)
@@ -0,0 +1,95 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+// saveBootHash stores the keyspace hash in the backend, so that the initial
+// corruption check on the next boot can skip hashing the keyspace. It must
+// only be called on clean shutdown, once applies have stopped and before the
+// backend is closed.
+func (s *EtcdServer) saveBootHash() {
+	if !s.Cfg.CorruptCheckHashCache || s.kv == nil || s.be == nil {
+		return
+	}
+	lg := s.Logger()
+	h, _, err := s.kv.HashStorage().HashByRev(0)
+	if err != nil {
+		lg.Warn("failed to compute keyspace hash on shutdown", zap.Error(err))
+		return
+	}
+
+	tx := s.be.BatchTx()
+	tx.LockOutsideApply()
+	defer tx.Unlock()
+	ci, _ := schema.UnsafeReadConsistentIndex(tx)
+	schema.MustUnsafeSaveBootHash(lg, tx, schema.BootHash{
+		ConsistentIndex: ci,
+		Revision:        h.Revision,
+		CompactRevision: h.CompactRevision,
+		Hash:            h.Hash,
+	})
+	lg.Info(
+		"stored keyspace hash for next boot",
+		zap.Uint64("consistent-index", ci),
+		zap.Int64("revision", h.Revision),
+		zap.Uint32("hash", h.Hash),
+	)
+}
+
+// takeBootHash reads and removes the boot hash stored by the last clean
+// shutdown. Removing it makes sure that a hash is never reused after an
+// unclean shutdown, which might have left the backend in a different state.
+func (s *EtcdServer) takeBootHash() {
+	tx := s.be.BatchTx()
+	tx.LockOutsideApply()
+	h := schema.UnsafeReadBootHash(s.Logger(), tx)
+	if h != nil {
+		schema.UnsafeDeleteBootHash(tx)
+	}
+	tx.Unlock()
+	if h != nil {
+		s.be.ForceCommit()
+	}
+	s.bootHash = h
+}
+
+// cachedHash returns the keyspace hash stored by the last clean shutdown if
+// the backend is still at the position the hash was computed at. The hash is
+// handed out at most once.
+func (s *EtcdServer) cachedHash() (mvcc.KeyValueHash, bool) {
+	h := s.bootHash
+	s.bootHash = nil
+	if h == nil {
+		return mvcc.KeyValueHash{}, false
+	}
+	ci := s.consistIndex.ConsistentIndex()
+	if h.ConsistentIndex != ci || h.Revision != s.kv.Rev() {
+		s.Logger().Info(
+			"ignoring keyspace hash stored on shutdown",
+			zap.Uint64("hash-consistent-index", h.ConsistentIndex),
+			zap.Uint64("consistent-index", ci),
+			zap.Int64("hash-revision", h.Revision),
+			zap.Int64("revision", s.kv.Rev()),
+		)
+		return mvcc.KeyValueHash{}, false
+	}
+	return mvcc.KeyValueHash{Hash: h.Hash, Revision: h.Revision, CompactRevision: h.CompactRevision}, true
+}
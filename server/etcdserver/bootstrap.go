@@ -198,8 +198,28 @@ func bootstrapSnapshot(cfg config.ServerConfig) *snap.Snapshotter {
 	return snap.New(cfg.Logger, cfg.SnapDir())
 }
 
+// checkBackendEngine fails if the existing backend uses another engine than the configured one.
+func checkBackendEngine(cfg config.ServerConfig) error {
+	if cfg.BackendEngine == "" {
+		return nil
+	}
+	engine, err := backend.DetectEngine(cfg.BackendPath())
+	if err != nil {
+		return err
+	}
+	if engine != cfg.BackendEngine {
+		return fmt.Errorf("backend %q uses the %s engine but %s is configured, convert it with 'etcdutl convert-backend'", cfg.BackendPath(), engine, cfg.BackendEngine)
+	}
+	return nil
+}
+
 func bootstrapBackend(cfg config.ServerConfig, haveWAL bool, st v2store.Store, ss *snap.Snapshotter) (backend *bootstrappedBackend, err error) {
 	beExist := fileutil.Exist(cfg.BackendPath())
+	if beExist {
+		if err = checkBackendEngine(cfg); err != nil {
+			return nil, err
+		}
+	}
 	ci := cindex.NewConsistentIndex(nil)
 	beHooks := serverstorage.NewBackendHooks(cfg.Logger, ci)
 	be := serverstorage.OpenBackend(cfg, beHooks)
@@ -517,7 +537,7 @@ func raftConfig(cfg config.ServerConfig, id uint64, s *raft.MemoryStorage) *raft
 		Storage:         s,
 		MaxSizePerMsg:   maxSizePerMsg,
 		MaxInflightMsgs: maxInflightMsgs,
-		CheckQuorum:     true,
+		CheckQuorum:     cfg.CheckQuorum,
 		PreVote:         cfg.PreVote,
 		Logger:          NewRaftLoggerZap(cfg.Logger.Named("raft")),
 	}
@@ -0,0 +1,134 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+)
+
+const (
+	// checkpointExt is the extension of the backend checkpoints, which are
+	// named after the UTC time they are taken at so that they sort in order.
+	checkpointExt        = ".db"
+	checkpointTimeFormat = "20060102T150405.000000000Z"
+	// checkpointTmpExt is the extension of the checkpoints being written.
+	checkpointTmpExt = ".tmp"
+)
+
+// Checkpoint writes a checkpoint of the backend to the checkpoint directory
+// and removes the oldest checkpoints beyond the retention.
+func (s *EtcdServer) Checkpoint(ctx context.Context, r *pb.CheckpointRequest) (*pb.CheckpointResponse, error) {
+	return s.checkpoint()
+}
+
+func (s *EtcdServer) checkpoint() (*pb.CheckpointResponse, error) {
+	s.checkpointMu.Lock()
+	defer s.checkpointMu.Unlock()
+
+	lg := s.Logger()
+	dir := s.Cfg.CheckpointDir()
+	if err := fileutil.TouchDirAll(lg, dir); err != nil {
+		return nil, err
+	}
+	// checkpoints interrupted by a crash are never completed
+	if err := fileutil.RemoveMatchFile(lg, dir, func(name string) bool {
+		return filepath.Ext(name) == checkpointTmpExt
+	}); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	path := filepath.Join(dir, start.UTC().Format(checkpointTimeFormat)+checkpointExt)
+	tmp := path + checkpointTmpExt
+	cloned, err := s.Backend().Checkpoint(tmp)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return nil, err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	lg.Info(
+		"wrote backend checkpoint",
+		zap.String("path", path),
+		zap.Int64("size", fi.Size()),
+		zap.Bool("cloned", cloned),
+		zap.Duration("took", time.Since(start)),
+	)
+	s.purgeCheckpoints(dir)
+	return &pb.CheckpointResponse{
+		Header: &pb.ResponseHeader{},
+		Path:   path,
+		DbSize: fi.Size(),
+		Cloned: cloned,
+	}, nil
+}
+
+// purgeCheckpoints removes the oldest checkpoints beyond the retention.
+func (s *EtcdServer) purgeCheckpoints(dir string) {
+	lg := s.Logger()
+	retention := s.Cfg.BackendCheckpointRetention
+	if retention <= 0 {
+		return
+	}
+	names, err := fileutil.ReadDir(dir, fileutil.WithExt(checkpointExt))
+	if err != nil {
+		lg.Warn("failed to list backend checkpoints", zap.String("dir", dir), zap.Error(err))
+		return
+	}
+	for len(names) > retention {
+		path := filepath.Join(dir, names[0])
+		if err = os.Remove(path); err != nil {
+			lg.Warn("failed to remove backend checkpoint", zap.String("path", path), zap.Error(err))
+			return
+		}
+		lg.Info("removed backend checkpoint", zap.String("path", path))
+		names = names[1:]
+	}
+}
+
+// monitorCheckpoints writes a checkpoint of the backend at every checkpoint
+// interval.
+func (s *EtcdServer) monitorCheckpoints() {
+	interval := s.Cfg.BackendCheckpointInterval
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lg := s.Logger()
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+		}
+		if _, err := s.checkpoint(); err != nil {
+			lg.Warn("failed to write backend checkpoint", zap.Error(err))
+		}
+	}
+}
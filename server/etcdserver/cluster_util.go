@@ -291,7 +291,7 @@ func getVersion(lg *zap.Logger, m *membership.Member, rt http.RoundTripper, time
 	return nil, err
 }
 
-func promoteMemberHTTP(ctx context.Context, url string, id uint64, peerRt http.RoundTripper) ([]*membership.Member, error) {
+func promoteMemberHTTP(ctx context.Context, url string, id, replaceID uint64, peerRt http.RoundTripper) ([]*membership.Member, error) {
 	cc := &http.Client{
 		Transport: peerRt,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
@@ -301,6 +301,9 @@ func promoteMemberHTTP(ctx context.Context, url string, id uint64, peerRt http.R
 	// TODO: refactor member http handler code
 	// cannot import etcdhttp, so manually construct url
 	requestURL := url + "/members/promote/" + fmt.Sprintf("%d", id)
+	if replaceID != 0 {
+		requestURL += fmt.Sprintf("?replace=%d", replaceID)
+	}
 	req, err := http.NewRequest(http.MethodPost, requestURL, nil)
 	if err != nil {
 		return nil, err
@@ -320,13 +323,16 @@ func promoteMemberHTTP(ctx context.Context, url string, id uint64, peerRt http.R
 		return nil, errors.ErrTimeout
 	}
 	if resp.StatusCode == http.StatusPreconditionFailed {
-		// both ErrMemberNotLearner and ErrLearnerNotReady have same http status code
+		// ErrMemberNotLearner, ErrMemberNotVoter and ErrLearnerNotReady have same http status code
 		if strings.Contains(string(b), errors.ErrLearnerNotReady.Error()) {
 			return nil, errors.ErrLearnerNotReady
 		}
 		if strings.Contains(string(b), membership.ErrMemberNotLearner.Error()) {
 			return nil, membership.ErrMemberNotLearner
 		}
+		if strings.Contains(string(b), membership.ErrMemberNotVoter.Error()) {
+			return nil, membership.ErrMemberNotVoter
+		}
 		return nil, fmt.Errorf("member promote: unknown error(%s)", b)
 	}
 	if resp.StatusCode == http.StatusNotFound {
@@ -58,6 +58,9 @@ type Hasher interface {
 	PeerHashByRev(int64) []*peerHashKVResp
 	LinearizableReadNotify(context.Context) error
 	TriggerCorruptAlarm(types.ID)
+	// CachedHash returns the keyspace hash stored on the last clean shutdown
+	// if it is still valid for the local keyspace.
+	CachedHash() (mvcc.KeyValueHash, bool)
 }
 
 func newCorruptionChecker(lg *zap.Logger, s *EtcdServer, storage mvcc.HashStorage) *corruptionChecker {
@@ -84,6 +87,10 @@ func (h hasherAdapter) TriggerCorruptAlarm(memberID types.ID) {
 	h.EtcdServer.triggerCorruptAlarm(memberID)
 }
 
+func (h hasherAdapter) CachedHash() (mvcc.KeyValueHash, bool) {
+	return h.EtcdServer.cachedHash()
+}
+
 // InitialCheck compares initial hash values with its peers
 // before serving any peer/client traffic. Only mismatch when hashes
 // are different at requested revision, with same compact revision.
@@ -94,9 +101,19 @@ func (cm *corruptionChecker) InitialCheck() error {
 		zap.Duration("timeout", cm.hasher.ReqTimeout()),
 	)
 
-	h, _, err := cm.hasher.HashByRev(0)
-	if err != nil {
-		return fmt.Errorf("%s failed to fetch hash (%w)", cm.hasher.MemberID(), err)
+	var err error
+	h, ok := cm.hasher.CachedHash()
+	if ok {
+		cm.lg.Info(
+			"using keyspace hash stored on clean shutdown",
+			zap.Int64("revision", h.Revision),
+			zap.Uint32("hash", h.Hash),
+		)
+	} else {
+		h, _, err = cm.hasher.HashByRev(0)
+		if err != nil {
+			return fmt.Errorf("%s failed to fetch hash (%w)", cm.hasher.MemberID(), err)
+		}
 	}
 	peers := cm.hasher.PeerHashByRev(h.Revision)
 	mismatch := 0
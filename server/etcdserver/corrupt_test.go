@@ -53,56 +53,73 @@ func TestInitialCheck(t *testing.T) {
 			hasher: fakeHasher{
 				hashByRevResponses: []hashByRev{{hash: mvcc.KeyValueHash{Revision: 10}}},
 			},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(10)", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "PeerHashByRev(10)", "MemberID()"},
 		},
 		{
 			name:          "Error getting hash",
 			hasher:        fakeHasher{hashByRevResponses: []hashByRev{{err: fmt.Errorf("error getting hash")}}},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "MemberID()"},
 			expectError:   true,
 		},
 		{
 			name:          "Peer with empty response",
 			hasher:        fakeHasher{peerHashes: []*peerHashKVResp{{}}},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()"},
 		},
 		{
 			name:          "Peer returned ErrFutureRev",
 			hasher:        fakeHasher{peerHashes: []*peerHashKVResp{{err: rpctypes.ErrFutureRev}}},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
 		},
 		{
 			name:          "Peer returned ErrCompacted",
 			hasher:        fakeHasher{peerHashes: []*peerHashKVResp{{err: rpctypes.ErrCompacted}}},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
 		},
 		{
 			name:          "Peer returned other error",
 			hasher:        fakeHasher{peerHashes: []*peerHashKVResp{{err: rpctypes.ErrCorrupt}}},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()"},
 		},
 		{
 			name:          "Peer returned same hash",
 			hasher:        fakeHasher{hashByRevResponses: []hashByRev{{hash: mvcc.KeyValueHash{Hash: 1}}}, peerHashes: []*peerHashKVResp{{resp: &pb.HashKVResponse{Header: &pb.ResponseHeader{}, Hash: 1}}}},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
 		},
 		{
 			name:          "Peer returned different hash with same compaction rev",
 			hasher:        fakeHasher{hashByRevResponses: []hashByRev{{hash: mvcc.KeyValueHash{Hash: 1, CompactRevision: 1}}}, peerHashes: []*peerHashKVResp{{resp: &pb.HashKVResponse{Header: &pb.ResponseHeader{}, Hash: 2, CompactRevision: 1}}}},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
 			expectError:   true,
 		},
 		{
 			name:          "Peer returned different hash and compaction rev",
 			hasher:        fakeHasher{hashByRevResponses: []hashByRev{{hash: mvcc.KeyValueHash{Hash: 1, CompactRevision: 1}}}, peerHashes: []*peerHashKVResp{{resp: &pb.HashKVResponse{Header: &pb.ResponseHeader{}, Hash: 2, CompactRevision: 2}}}},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
 		},
 		{
 			name: "Cluster ID Mismatch does not fail CorruptionChecker.InitialCheck()",
 			hasher: fakeHasher{
 				peerHashes: []*peerHashKVResp{{err: rpctypes.ErrClusterIDMismatch}},
 			},
-			expectActions: []string{"MemberID()", "ReqTimeout()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "HashByRev(0)", "PeerHashByRev(0)", "MemberID()", "MemberID()"},
+		},
+		{
+			name: "Cached hash skips hashing the keyspace",
+			hasher: fakeHasher{
+				cachedHash: &mvcc.KeyValueHash{Hash: 1, Revision: 10},
+				peerHashes: []*peerHashKVResp{{resp: &pb.HashKVResponse{Header: &pb.ResponseHeader{}, Hash: 1}}},
+			},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "PeerHashByRev(10)", "MemberID()", "MemberID()"},
+		},
+		{
+			name: "Cached hash different from peer with same compaction rev",
+			hasher: fakeHasher{
+				cachedHash: &mvcc.KeyValueHash{Hash: 1, Revision: 10, CompactRevision: 1},
+				peerHashes: []*peerHashKVResp{{resp: &pb.HashKVResponse{Header: &pb.ResponseHeader{}, Hash: 2, CompactRevision: 1}}},
+			},
+			expectActions: []string{"MemberID()", "ReqTimeout()", "CachedHash()", "PeerHashByRev(10)", "MemberID()", "MemberID()"},
+			expectError:   true,
 		},
 	}
 	for _, tc := range tcs {
@@ -447,6 +464,7 @@ type fakeHasher struct {
 	hashByRevResponses     []hashByRev
 	linearizableReadNotify error
 	hashes                 []mvcc.KeyValueHash
+	cachedHash             *mvcc.KeyValueHash
 
 	alarmTriggered bool
 	actions        []string
@@ -472,6 +490,14 @@ func (f *fakeHasher) HashByRev(rev int64) (hash mvcc.KeyValueHash, revision int6
 	return hashByRev.hash, hashByRev.revision, hashByRev.err
 }
 
+func (f *fakeHasher) CachedHash() (mvcc.KeyValueHash, bool) {
+	f.actions = append(f.actions, "CachedHash()")
+	if f.cachedHash == nil {
+		return mvcc.KeyValueHash{}, false
+	}
+	return *f.cachedHash, true
+}
+
 func (f *fakeHasher) Store(hash mvcc.KeyValueHash) {
 	f.actions = append(f.actions, fmt.Sprintf("Store(%v)", hash))
 	f.hashes = append(f.hashes, hash)
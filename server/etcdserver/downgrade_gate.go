@@ -0,0 +1,86 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/version"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/wal"
+)
+
+// checkDowngradeGate rejects r with ErrDowngradeBlocked if a downgrade is in
+// progress and r uses fields, messages or enum values newer than the
+// downgrade target version. Once in the WAL log, such an entry could not be
+// interpreted by members of the target version. Rejected requests are
+// recorded, and each newer field is logged when first observed.
+func (s *EtcdServer) checkDowngradeGate(r *pb.InternalRaftRequest) error {
+	d := s.DowngradeInfo()
+	if d == nil || !d.Enabled {
+		return nil
+	}
+	target := d.GetTargetVersion()
+	newer := newerVisited(target, func(visitor wal.Visitor) error { return wal.VisitRaftRequest(r, visitor) })
+	if len(newer) == 0 {
+		return nil
+	}
+	name := raftRequestName(r)
+	if observed := s.downgradeBlocked.record(name, newer, time.Now()); len(observed) > 0 {
+		s.Logger().Warn(
+			"rejected request using features newer than the downgrade target version",
+			zap.String("request", name),
+			zap.Strings("fields", observed),
+			zap.String("target-version", target.String()),
+		)
+	}
+	return errors.ErrDowngradeBlocked
+}
+
+// raftRequestName returns the name of the request r carries, such as
+// "PutRequest".
+func raftRequestName(r *pb.InternalRaftRequest) string {
+	var name string
+	proto.MessageReflect(r).Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind || fd.Name() == "header" {
+			return true
+		}
+		name = string(fd.Message().Name())
+		return false
+	})
+	return name
+}
+
+func (s *EtcdServer) downgradeStatus() (*pb.DowngradeResponse, error) {
+	cv := s.ClusterVersion()
+	if cv == nil {
+		return nil, errors.ErrClusterVersionUnavailable
+	}
+	resp := &pb.DowngradeResponse{
+		Version:         version.Cluster(cv.String()),
+		DowngradeInfo:   &pb.DowngradeInfo{},
+		BlockedFeatures: s.downgradeBlocked.report(false),
+	}
+	if d := s.DowngradeInfo(); d != nil {
+		resp.DowngradeInfo.Enabled = d.Enabled
+		resp.DowngradeInfo.TargetVersion = d.TargetVersion
+	}
+	return resp, nil
+}
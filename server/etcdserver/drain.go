@@ -0,0 +1,179 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// Draining returns true if the local member is marked as draining.
+func (s *EtcdServer) Draining() bool {
+	m := s.cluster.Member(s.MemberID())
+	return m != nil && m.Draining
+}
+
+// DrainNotify returns a channel that is closed while the local member is
+// draining.
+func (s *EtcdServer) DrainNotify() <-chan struct{} {
+	s.drainMu.Lock()
+	defer s.drainMu.Unlock()
+	return s.drainNotifyLocked()
+}
+
+func (s *EtcdServer) drainNotifyLocked() chan struct{} {
+	if s.drainc == nil {
+		s.drainc = make(chan struct{})
+		if s.Draining() {
+			close(s.drainc)
+		}
+	}
+	return s.drainc
+}
+
+// TrackWatchStream counts an open watch stream of the member until the
+// returned function is called.
+func (s *EtcdServer) TrackWatchStream() (untrack func()) {
+	s.watchStreams.Add(1)
+	return func() { s.watchStreams.Add(-1) }
+}
+
+// DrainMember marks the local member as draining, or clears the mark if
+// r.Undrain is set. A draining member transfers its leadership away, rejects
+// new lease grants and watch streams, closes its open watch streams and is
+// skipped by the endpoint sync of the clients. The response reports the
+// member as ready once it is neither leader nor serving watch streams.
+func (s *EtcdServer) DrainMember(ctx context.Context, r *pb.DrainMemberRequest) (*pb.DrainMemberResponse, error) {
+	if err := s.setDraining(ctx, !r.Undrain); err != nil {
+		return nil, err
+	}
+	if !r.Undrain && s.isLeader() {
+		if err := s.transferDisallowedLeadership(); err != nil {
+			// monitorLeadership keeps retrying the transfer
+			s.Logger().Warn(
+				"failed to transfer leadership of draining member",
+				zap.String("local-member-id", s.MemberID().String()),
+				zap.Error(err),
+			)
+		}
+	}
+	resp := &pb.DrainMemberResponse{
+		Header:       &pb.ResponseHeader{},
+		Draining:     s.Draining(),
+		Leader:       s.isLeader(),
+		WatchStreams: s.watchStreams.Load(),
+	}
+	resp.Ready = resp.Draining && !resp.Leader && resp.WatchStreams == 0
+	return resp, nil
+}
+
+// setDraining updates the draining mark of the local member in the cluster
+// membership, then notifies the watch streams.
+func (s *EtcdServer) setDraining(ctx context.Context, draining bool) error {
+	s.drainMu.Lock()
+	defer s.drainMu.Unlock()
+	s.drainSet = true
+	return s.setDrainingLocked(ctx, draining)
+}
+
+func (s *EtcdServer) setDrainingLocked(ctx context.Context, draining bool) error {
+	m := s.cluster.Member(s.MemberID())
+	if m == nil {
+		return errors.ErrStopped
+	}
+	if m.Draining != draining {
+		m.Draining = draining
+		b, err := json.Marshal(m)
+		if err != nil {
+			s.Logger().Panic("failed to marshal member", zap.Error(err))
+		}
+		_, err = s.configure(ctx, raftpb.ConfChange{
+			Type:    raftpb.ConfChangeUpdateNode,
+			NodeID:  uint64(m.ID),
+			Context: b,
+		})
+		if err != nil {
+			return err
+		}
+		s.Logger().Info(
+			"updated draining mark of local member",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.Bool("draining", draining),
+		)
+	}
+
+	c := s.drainNotifyLocked()
+	select {
+	case <-c:
+		if !draining {
+			s.drainc = make(chan struct{})
+		}
+	default:
+		if draining {
+			close(c)
+		}
+	}
+	return nil
+}
+
+// clearStaleDrain clears the draining mark the local member kept from before
+// a restart, so that a restarted member serves again once it is ready. It
+// retries until the mark is cleared, updated by a request or the server
+// stops.
+func (s *EtcdServer) clearStaleDrain() {
+	select {
+	case <-s.ReadyNotify():
+	case <-s.stopping:
+		return
+	}
+	lg := s.Logger()
+	for {
+		done, err := s.clearStaleDrainOnce()
+		if done {
+			return
+		}
+		lg.Warn(
+			"failed to clear draining mark of restarted member",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.Error(err),
+		)
+		select {
+		case <-time.After(leadershipCheckInterval):
+		case <-s.stopping:
+			return
+		}
+	}
+}
+
+// clearStaleDrainOnce clears the draining mark if it was not updated by this
+// process. It returns true if nothing is left to clear.
+func (s *EtcdServer) clearStaleDrainOnce() (bool, error) {
+	s.drainMu.Lock()
+	defer s.drainMu.Unlock()
+	if s.drainSet || !s.Draining() {
+		return true, nil
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	err := s.setDrainingLocked(ctx, false)
+	return err == nil, err
+}
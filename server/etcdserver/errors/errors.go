@@ -17,6 +17,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 var (
@@ -32,18 +33,43 @@ var (
 	ErrNotEnoughStartedMembers     = errors.New("etcdserver: re-configuration failed due to not enough started members")
 	ErrLearnerNotReady             = errors.New("etcdserver: can only promote a learner member which is in sync with leader")
 	ErrNoLeader                    = errors.New("etcdserver: no leader")
+	ErrCatchingUp                  = errors.New("etcdserver: catching up with the leader")
+	ErrMemberDraining              = errors.New("etcdserver: member is draining")
+	ErrAppliedIndexBehind          = errors.New("etcdserver: applied index is behind the requested minimum")
 	ErrNotLeader                   = errors.New("etcdserver: not leader")
 	ErrRequestTooLarge             = errors.New("etcdserver: request is too large")
 	ErrNoSpace                     = errors.New("etcdserver: no space")
+	ErrWALNoSpace                  = errors.New("etcdserver: wal: not enough free disk space")
+	ErrThresholdExceeded           = errors.New("etcdserver: operation blocked by an alarm threshold")
 	ErrTooManyRequests             = errors.New("etcdserver: too many requests")
+	ErrTooBusy                     = errors.New("etcdserver: too busy")
 	ErrUnhealthy                   = errors.New("etcdserver: unhealthy cluster")
 	ErrCorrupt                     = errors.New("etcdserver: corrupt cluster")
 	ErrBadLeaderTransferee         = errors.New("etcdserver: bad leader transferee")
 	ErrClusterVersionUnavailable   = errors.New("etcdserver: cluster version not found during downgrade")
 	ErrWrongDowngradeVersionFormat = errors.New("etcdserver: wrong downgrade target version format")
 	ErrKeyNotFound                 = errors.New("etcdserver: key not found")
+	ErrTooManyDeletions            = errors.New("etcdserver: too many deletions")
+	ErrValueTooLarge               = errors.New("etcdserver: appended value exceeds max size")
+	ErrForEachTooManyKeys          = errors.New("etcdserver: foreach range holds more keys than max_keys")
+	ErrHotKeysDisabled             = errors.New("etcdserver: hot keys tracking is disabled")
+	ErrNewerRequestFields          = errors.New("etcdserver: request uses fields newer than the cluster version")
+	ErrDowngradeBlocked            = errors.New("etcdserver: request uses features newer than the downgrade target version")
+	ErrNewerFieldsDisabled         = errors.New("etcdserver: newer request fields detection is disabled")
+	ErrReseedLeader                = errors.New("etcdserver: the leader cannot be reseeded")
 )
 
+// TooBusyError is returned for low priority requests while the apply backlog
+// is over its threshold. It matches ErrTooBusy.
+type TooBusyError struct {
+	// Backoff is the time the client should wait before retrying.
+	Backoff time.Duration
+}
+
+func (e *TooBusyError) Error() string { return ErrTooBusy.Error() }
+
+func (e *TooBusyError) Is(target error) bool { return target == ErrTooBusy }
+
 type DiscoveryError struct {
 	Op  string
 	Err error
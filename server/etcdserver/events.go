@@ -0,0 +1,280 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3job"
+	"go.etcd.io/etcd/server/v3/etcdserver/apply"
+)
+
+// EventType is the type of a lifecycle event of the server.
+type EventType int
+
+const (
+	// EventBecameLeader is published when the local member becomes the leader.
+	EventBecameLeader EventType = iota + 1
+	// EventLostLeadership is published when the local member stops being the
+	// leader.
+	EventLostLeadership
+	// EventMemberAdded is published when a member is added to the cluster.
+	EventMemberAdded
+	// EventMemberRemoved is published when a member is removed from the
+	// cluster.
+	EventMemberRemoved
+	// EventCompactionFinished is published when the compaction of the local
+	// member finishes.
+	EventCompactionFinished
+	// EventDefragStarted is published when the defragmentation of the local
+	// member starts.
+	EventDefragStarted
+	// EventDefragFinished is published when the defragmentation of the local
+	// member finishes.
+	EventDefragFinished
+	// EventAlarmRaised is published each time an alarm is activated.
+	EventAlarmRaised
+)
+
+var eventTypeNames = map[EventType]string{
+	EventBecameLeader:       "became-leader",
+	EventLostLeadership:     "lost-leadership",
+	EventMemberAdded:        "member-added",
+	EventMemberRemoved:      "member-removed",
+	EventCompactionFinished: "compaction-finished",
+	EventDefragStarted:      "defrag-started",
+	EventDefragFinished:     "defrag-finished",
+	EventAlarmRaised:        "alarm-raised",
+}
+
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Event is a lifecycle event of the server.
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Term is the raft term of leadership events.
+	Term uint64
+	// MemberID is the member added or removed, or the member an alarm is
+	// raised for.
+	MemberID types.ID
+	// Revision is the compacted revision of EventCompactionFinished.
+	Revision int64
+	// Alarm is the alarm of EventAlarmRaised.
+	Alarm pb.AlarmType
+	// Err is the error of a failed defragmentation.
+	Err error
+}
+
+// EventHandler handles the events of an EventBus.
+type EventHandler func(Event)
+
+// EventBus delivers the lifecycle events of the server to handlers. Each
+// handler receives its events in order on its own goroutine, so a slow
+// handler delays neither the server nor other handlers.
+type EventBus struct {
+	mu     sync.Mutex
+	subs   map[*eventSubscriber]struct{}
+	closed bool
+}
+
+// NewEventBus creates a new EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[*eventSubscriber]struct{})}
+}
+
+// Subscribe calls h with the events of the given types, or with all events
+// if no type is given, until the returned function is called or the bus is
+// closed.
+func (b *EventBus) Subscribe(h EventHandler, types ...EventType) (unsubscribe func()) {
+	sub := &eventSubscriber{
+		h:      h,
+		notify: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+	if len(types) > 0 {
+		sub.types = make(map[EventType]struct{}, len(types))
+		for _, t := range types {
+			sub.types[t] = struct{}{}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return func() {}
+	}
+	b.subs[sub] = struct{}{}
+	go sub.run()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subs[sub]; ok {
+				delete(b.subs, sub)
+				sub.discard()
+				close(sub.stop)
+			}
+		})
+	}
+}
+
+// Publish delivers ev to the handlers subscribed to its type. Publishing to a
+// nil EventBus is a no-op.
+func (b *EventBus) Publish(ev Event) {
+	if b == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		sub.push(ev)
+	}
+}
+
+// Close stops the delivery of events once the events already published are
+// delivered.
+func (b *EventBus) Close() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for sub := range b.subs {
+		close(sub.stop)
+	}
+	b.subs = nil
+}
+
+type eventSubscriber struct {
+	h     EventHandler
+	types map[EventType]struct{}
+
+	mu    sync.Mutex
+	queue []Event
+	// notify is signaled when queue becomes non-empty.
+	notify chan struct{}
+	stop   chan struct{}
+}
+
+func (sub *eventSubscriber) push(ev Event) {
+	if sub.types != nil {
+		if _, ok := sub.types[ev.Type]; !ok {
+			return
+		}
+	}
+	sub.mu.Lock()
+	sub.queue = append(sub.queue, ev)
+	sub.mu.Unlock()
+	select {
+	case sub.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (sub *eventSubscriber) discard() {
+	sub.mu.Lock()
+	sub.queue = nil
+	sub.mu.Unlock()
+}
+
+func (sub *eventSubscriber) run() {
+	for {
+		select {
+		case <-sub.notify:
+			sub.deliver()
+		case <-sub.stop:
+			sub.deliver()
+			return
+		}
+	}
+}
+
+func (sub *eventSubscriber) deliver() {
+	for {
+		sub.mu.Lock()
+		queue := sub.queue
+		sub.queue = nil
+		sub.mu.Unlock()
+		if len(queue) == 0 {
+			return
+		}
+		for _, ev := range queue {
+			sub.h(ev)
+		}
+	}
+}
+
+// publishApplyEvents publishes the events caused by applying r.
+func (s *EtcdServer) publishApplyEvents(r *pb.InternalRaftRequest, ar *apply.Result) {
+	if ar.Err != nil {
+		return
+	}
+	switch {
+	case r.Compaction != nil && ar.Physc != nil:
+		rev := r.Compaction.Revision
+		s.GoAttach(func() {
+			select {
+			case <-ar.Physc:
+				s.events.Publish(Event{Type: EventCompactionFinished, Revision: rev})
+			case <-s.stopping:
+			}
+		})
+	case r.Alarm != nil && r.Alarm.Action == pb.AlarmRequest_ACTIVATE:
+		resp, ok := ar.Resp.(*pb.AlarmResponse)
+		if !ok {
+			return
+		}
+		for _, m := range resp.Alarms {
+			s.events.Publish(Event{Type: EventAlarmRaised, MemberID: types.ID(m.MemberID), Alarm: m.Alarm})
+		}
+	}
+}
+
+// Defragment defragments the backend of the local member in a job. A
+// defragmentation cannot be canceled once it started.
+func (s *EtcdServer) Defragment() error {
+	return s.jobs.Run(context.Background(), JobTypeDefrag, func(ctx context.Context, j *v3job.Job) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		j.Progress(0, 1, "copying the backend")
+		s.events.Publish(Event{Type: EventDefragStarted})
+		err := s.Backend().Defrag()
+		s.events.Publish(Event{Type: EventDefragFinished, Err: err})
+		if err == nil {
+			j.Progress(1, 1, "")
+		}
+		return err
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBus(t *testing.T) {
+	b := NewEventBus()
+
+	all := make(chan Event, 10)
+	b.Subscribe(func(ev Event) { all <- ev })
+	leader := make(chan Event, 10)
+	b.Subscribe(func(ev Event) { leader <- ev }, EventBecameLeader, EventLostLeadership)
+	unsubscribed := make(chan Event, 10)
+	unsubscribe := b.Subscribe(func(ev Event) { unsubscribed <- ev })
+	unsubscribe()
+
+	b.Publish(Event{Type: EventBecameLeader, Term: 2})
+	b.Publish(Event{Type: EventCompactionFinished, Revision: 10})
+	b.Publish(Event{Type: EventLostLeadership, Term: 3})
+	b.Close()
+	// publishing after Close is a no-op
+	b.Publish(Event{Type: EventDefragStarted})
+	b.Subscribe(func(ev Event) { t.Errorf("unexpected event %v after Close", ev.Type) })
+
+	recv := func(ch chan Event) Event {
+		select {
+		case ev := <-ch:
+			return ev
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for an event")
+			return Event{}
+		}
+	}
+	for _, want := range []EventType{EventBecameLeader, EventCompactionFinished, EventLostLeadership} {
+		ev := recv(all)
+		assert.Equal(t, want, ev.Type)
+		assert.False(t, ev.Time.IsZero())
+	}
+	assert.Equal(t, Event{Type: EventBecameLeader, Term: 2}, withoutTime(recv(leader)))
+	assert.Equal(t, Event{Type: EventLostLeadership, Term: 3}, withoutTime(recv(leader)))
+
+	time.Sleep(10 * time.Millisecond)
+	require.Empty(t, all)
+	require.Empty(t, leader)
+	require.Empty(t, unsubscribed)
+}
+
+func TestEventBusSlowHandler(t *testing.T) {
+	b := NewEventBus()
+	defer b.Close()
+
+	block := make(chan struct{})
+	b.Subscribe(func(Event) { <-block })
+	fast := make(chan Event, 100)
+	b.Subscribe(func(ev Event) { fast <- ev })
+
+	// a blocked handler neither blocks Publish nor other handlers
+	for i := 0; i < 100; i++ {
+		b.Publish(Event{Type: EventCompactionFinished, Revision: int64(i)})
+	}
+	for i := 0; i < 100; i++ {
+		select {
+		case ev := <-fast:
+			assert.Equal(t, int64(i), ev.Revision)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for an event")
+		}
+	}
+	close(block)
+}
+
+func TestEventTypeString(t *testing.T) {
+	assert.Equal(t, "became-leader", EventBecameLeader.String())
+	assert.Equal(t, "alarm-raised", EventAlarmRaised.String())
+	assert.Equal(t, "unknown", EventType(0).String())
+}
+
+func withoutTime(ev Event) Event {
+	ev.Time = time.Time{}
+	return ev
+}
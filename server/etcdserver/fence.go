@@ -0,0 +1,92 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// fenceExpiryCheckInterval is the interval at which the leader lifts the
+// expired fences.
+const fenceExpiryCheckInterval = 500 * time.Millisecond
+
+// FencePrefix sets, or lifts if r has no TTL, the fence of a prefix.
+func (s *EtcdServer) FencePrefix(ctx context.Context, r *pb.FencePrefixRequest) (*pb.FencePrefixResponse, error) {
+	f := &pb.InternalFencePrefixRequest{Prefix: r.Prefix}
+	if r.TTL > 0 {
+		f.TTL = r.TTL
+		f.Token = r.Token
+		f.GrantTime = time.Now().UnixNano()
+	}
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{FencePrefix: f})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.FencePrefixResponse), nil
+}
+
+// monitorFences lifts the expired fences while the member is the leader.
+// The fences are only lifted through raft, so that all members stop
+// rejecting writes at the same index.
+func (s *EtcdServer) monitorFences() {
+	ticker := time.NewTicker(fenceExpiryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+		}
+
+		if !s.isLeader() {
+			continue
+		}
+		for _, f := range s.fences.Expired(time.Now()) {
+			s.liftFence(f)
+		}
+	}
+}
+
+// liftFence lifts the expired fence, unless it was granted again since.
+// Failures are retried at the next check.
+func (s *EtcdServer) liftFence(f *pb.InternalFencePrefixRequest) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	lift := &pb.InternalFencePrefixRequest{Prefix: f.Prefix, GrantTime: f.GrantTime}
+	if _, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{FencePrefix: lift}); err != nil {
+		s.Logger().Warn("failed to lift expired fence", zap.ByteString("prefix", f.Prefix), zap.Error(err))
+		return
+	}
+	s.Logger().Info("lifted expired fence", zap.ByteString("prefix", f.Prefix))
+}
+
+type fenceTokenKey struct{}
+
+// WithFenceToken attaches to the requests proposed with ctx the token that
+// lets their writes through the fences of prefixes.
+func WithFenceToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, fenceTokenKey{}, token)
+}
+
+func fenceToken(ctx context.Context) string {
+	token, _ := ctx.Value(fenceTokenKey{}).(string)
+	return token
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	errorspkg "errors"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3job"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// gcRangeLimit is the number of keys read at a time when looking for keys
+// attached to leases that do not exist.
+const gcRangeLimit = 1000
+
+// GarbageCollect looks for keys attached to leases that do not exist, leases
+// without keys older than the requested age and auth tokens of deleted
+// users, and repairs them if requested. Orphaned keys are only deleted if
+// they have not been modified since they were found.
+// The garbage collection runs in a job, which stops the repair once it is
+// canceled.
+func (s *EtcdServer) GarbageCollect(ctx context.Context, r *pb.GarbageCollectRequest) (*pb.GarbageCollectResponse, error) {
+	var resp *pb.GarbageCollectResponse
+	err := s.jobs.Run(ctx, JobTypeGC, func(ctx context.Context, j *v3job.Job) error {
+		var err error
+		resp, err = s.garbageCollect(ctx, j, r)
+		return err
+	})
+	if resp == nil {
+		return nil, err
+	}
+	// a failed repair is reported by resp.Repaired
+	return resp, nil
+}
+
+func (s *EtcdServer) garbageCollect(ctx context.Context, j *v3job.Job, r *pb.GarbageCollectRequest) (*pb.GarbageCollectResponse, error) {
+	if err := s.linearizableReadNotify(ctx); err != nil {
+		return nil, err
+	}
+	j.Progress(0, 0, "finding garbage")
+	orphans, err := s.orphanedKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.GarbageCollectResponse{
+		Header:       &pb.ResponseHeader{},
+		OrphanedKeys: orphans,
+		EmptyLeases:  s.emptyLeases(time.Duration(r.EmptyLeaseMinAge) * time.Second),
+		StaleTokens:  int64(s.authStore.StaleTokens(false)),
+	}
+	if !r.Repair {
+		return resp, nil
+	}
+
+	lg := s.Logger()
+	total := int64(len(resp.OrphanedKeys) + len(resp.EmptyLeases))
+	j.Progress(0, total, "deleting orphaned keys")
+	if err = s.deleteOrphanedKeys(ctx, resp.OrphanedKeys); err != nil {
+		lg.Warn("failed to delete orphaned keys", zap.Error(err))
+		return resp, err
+	}
+	done := int64(len(resp.OrphanedKeys))
+	j.Progress(done, total, "revoking empty leases")
+	for _, id := range resp.EmptyLeases {
+		done++
+		// keys may have been attached since the lease was found empty
+		if l := s.lessor.Lookup(lease.LeaseID(id)); l == nil || len(l.Keys()) > 0 {
+			continue
+		}
+		_, err = s.LeaseRevoke(ctx, &pb.LeaseRevokeRequest{ID: id})
+		if err != nil && !errorspkg.Is(err, lease.ErrLeaseNotFound) {
+			lg.Warn("failed to revoke empty lease", zap.Int64("lease-id", id), zap.Error(err))
+			return resp, err
+		}
+		j.Progress(done, total, "revoking empty leases")
+	}
+	j.Progress(total, total, "invalidating stale auth tokens")
+	s.authStore.StaleTokens(true)
+	resp.Repaired = true
+	lg.Info(
+		"repaired garbage",
+		zap.Int("orphaned-keys", len(resp.OrphanedKeys)),
+		zap.Int("empty-leases", len(resp.EmptyLeases)),
+		zap.Int64("stale-tokens", resp.StaleTokens),
+	)
+	return resp, nil
+}
+
+// orphanedKeys returns the keys attached to leases that do not exist.
+func (s *EtcdServer) orphanedKeys(ctx context.Context) ([]*pb.OrphanedKey, error) {
+	var (
+		orphans []*pb.OrphanedKey
+		key     = []byte{0}
+		rev     int64
+	)
+	for {
+		// an empty end reads to the end of the keyspace
+		rr, err := s.KV().Range(ctx, key, []byte{}, mvcc.RangeOptions{Limit: gcRangeLimit, Rev: rev})
+		if err != nil {
+			return nil, err
+		}
+		rev = rr.Rev
+		for _, kv := range rr.KVs {
+			if kv.Lease != 0 && s.lessor.Lookup(lease.LeaseID(kv.Lease)) == nil {
+				orphans = append(orphans, &pb.OrphanedKey{Key: kv.Key, Lease: kv.Lease, ModRevision: kv.ModRevision})
+			}
+		}
+		if len(rr.KVs) < gcRangeLimit {
+			return orphans, nil
+		}
+		key = append(append([]byte{}, rr.KVs[len(rr.KVs)-1].Key...), 0)
+	}
+}
+
+// emptyLeases returns the IDs of the leases without keys that are at least
+// minAge old. No lease is returned if minAge is not positive.
+func (s *EtcdServer) emptyLeases(minAge time.Duration) []int64 {
+	if minAge <= 0 {
+		return nil
+	}
+	var ids []int64
+	now := time.Now()
+	for _, l := range s.lessor.Leases() {
+		if len(l.Keys()) == 0 && now.Sub(l.CreateTime()) >= minAge {
+			ids = append(ids, int64(l.ID))
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// deleteOrphanedKeys deletes the given keys in transactions of at most
+// MaxTxnOps keys, leaving the keys modified since they were found.
+func (s *EtcdServer) deleteOrphanedKeys(ctx context.Context, orphans []*pb.OrphanedKey) error {
+	batch := max(int(s.Cfg.MaxTxnOps), 1)
+	for len(orphans) > 0 {
+		n := min(batch, len(orphans))
+		resp, err := s.Txn(ctx, deleteUnmodifiedTxn(orphans[:n]))
+		if err != nil {
+			return err
+		}
+		if !resp.Succeeded {
+			// some keys were modified, retry the others one by one
+			for _, o := range orphans[:n] {
+				if _, err = s.Txn(ctx, deleteUnmodifiedTxn([]*pb.OrphanedKey{o})); err != nil {
+					return err
+				}
+			}
+		}
+		orphans = orphans[n:]
+	}
+	return nil
+}
+
+func deleteUnmodifiedTxn(orphans []*pb.OrphanedKey) *pb.TxnRequest {
+	txn := &pb.TxnRequest{}
+	for _, o := range orphans {
+		txn.Compare = append(txn.Compare, &pb.Compare{
+			Key:         o.Key,
+			Target:      pb.Compare_MOD,
+			Result:      pb.Compare_EQUAL,
+			TargetUnion: &pb.Compare_ModRevision{ModRevision: o.ModRevision},
+		})
+		txn.Success = append(txn.Success, &pb.RequestOp{
+			Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: o.Key}},
+		})
+	}
+	return txn
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"container/heap"
+	"context"
+	"hash/maphash"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"sync"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/etcdserver/txn"
+)
+
+const (
+	// hotKeySketchDepth and hotKeySketchWidth size the count-min sketches
+	// estimating the usage of each key. An estimate is over by at most
+	// e/width of the total usage with probability 1-exp(-depth).
+	hotKeySketchDepth = 4
+	hotKeySketchWidth = 2048
+)
+
+// hotKeyMetric is the usage tracked for each key. Its values match the ones
+// of pb.HotKeysRequest_SortBy.
+type hotKeyMetric int
+
+const (
+	hotKeyReads hotKeyMetric = iota
+	hotKeyWrites
+	hotKeyReadBytes
+	hotKeyWriteBytes
+	hotKeyMetrics
+)
+
+// hotKeyTracker estimates the keys with the most reads, writes, bytes read
+// and bytes written from a sample of the requests served by the member.
+// The usage of every sampled key is counted in a count-min sketch per
+// metric, and a heap per metric keeps the top-K keys by their estimates,
+// so the memory held is bounded regardless of the number of keys.
+type hotKeyTracker struct {
+	topK       int
+	sampleRate float64
+
+	mu       sync.Mutex
+	seeds    [hotKeySketchDepth]maphash.Seed
+	sketches [hotKeyMetrics][hotKeySketchDepth][hotKeySketchWidth]uint64
+	tops     [hotKeyMetrics]hotKeyHeap
+}
+
+func newHotKeyTracker(topK int, sampleRate float64) *hotKeyTracker {
+	t := &hotKeyTracker{topK: topK, sampleRate: sampleRate}
+	for i := range t.seeds {
+		t.seeds[i] = maphash.MakeSeed()
+	}
+	for m := range t.tops {
+		t.tops[m].index = make(map[string]int)
+	}
+	return t
+}
+
+// recordRead counts a read of size bytes of key, if the read is sampled.
+func (t *hotKeyTracker) recordRead(key []byte, size int) {
+	t.record(key, hotKeyReads, hotKeyReadBytes, size)
+}
+
+// recordWrite counts a write of size bytes of key, if the write is sampled.
+func (t *hotKeyTracker) recordWrite(key []byte, size int) {
+	t.record(key, hotKeyWrites, hotKeyWriteBytes, size)
+}
+
+// recordRange counts the reads of the keys returned for r, or of the key
+// of r if none is returned.
+func (t *hotKeyTracker) recordRange(r *pb.RangeRequest, resp *pb.RangeResponse) {
+	if t == nil {
+		return
+	}
+	if len(resp.GetKvs()) == 0 {
+		t.recordRead(r.Key, 0)
+		return
+	}
+	for _, kv := range resp.Kvs {
+		t.recordRead(kv.Key, kv.Size())
+	}
+}
+
+// recordPut counts the write of the key put by r, which is the created key
+// for puts with a sequence suffix.
+func (t *hotKeyTracker) recordPut(r *pb.PutRequest, resp *pb.PutResponse) {
+	key := r.Key
+	if k := resp.GetKey(); len(k) > 0 {
+		key = k
+	}
+	t.recordWrite(key, len(key)+len(r.Value))
+}
+
+// recordTxn counts the reads and writes of the operations of the branch of
+// r executed for resp.
+func (t *hotKeyTracker) recordTxn(r *pb.TxnRequest, resp *pb.TxnResponse) {
+	if t == nil || resp == nil {
+		return
+	}
+	ops := r.Failure
+	if resp.Succeeded {
+		ops = r.Success
+	}
+	for i, op := range ops {
+		if i >= len(resp.Responses) {
+			return
+		}
+		switch tv := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			t.recordRange(tv.RequestRange, resp.Responses[i].GetResponseRange())
+		case *pb.RequestOp_RequestPut:
+			t.recordPut(tv.RequestPut, resp.Responses[i].GetResponsePut())
+		case *pb.RequestOp_RequestDeleteRange:
+			t.recordWrite(tv.RequestDeleteRange.Key, len(tv.RequestDeleteRange.Key))
+		case *pb.RequestOp_RequestAppend:
+			t.recordWrite(tv.RequestAppend.Key, len(tv.RequestAppend.Key)+len(tv.RequestAppend.Value))
+		case *pb.RequestOp_RequestTxn:
+			t.recordTxn(tv.RequestTxn, resp.Responses[i].GetResponseTxn())
+		case *pb.RequestOp_RequestForEach:
+			if fr := resp.Responses[i].GetResponseForEach(); fr != nil && txn.IsForEachWrite(tv.RequestForEach) {
+				for _, k := range fr.Keys {
+					t.recordWrite(k, len(k))
+				}
+			}
+		}
+	}
+}
+
+func (t *hotKeyTracker) record(key []byte, ops, bytes hotKeyMetric, size int) {
+	if t == nil || (t.sampleRate < 1 && rand.Float64() >= t.sampleRate) {
+		return
+	}
+	var hashes [hotKeySketchDepth]uint64
+	for i, seed := range t.seeds {
+		hashes[i] = maphash.Bytes(seed, key)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.add(key, ops, &hashes, 1)
+	if size > 0 {
+		t.add(key, bytes, &hashes, uint64(size))
+	}
+}
+
+// add counts n for key in the sketch of m and updates the top keys of m
+// with the new estimate of key.
+func (t *hotKeyTracker) add(key []byte, m hotKeyMetric, hashes *[hotKeySketchDepth]uint64, n uint64) {
+	est := uint64(math.MaxUint64)
+	for i, h := range hashes {
+		c := &t.sketches[m][i][h%hotKeySketchWidth]
+		*c += n
+		est = min(est, *c)
+	}
+	t.tops[m].update(string(key), est, t.topK)
+}
+
+// estimate returns the estimated usage m of key. It must be called with mu
+// held.
+func (t *hotKeyTracker) estimate(key string, m hotKeyMetric) uint64 {
+	est := uint64(math.MaxUint64)
+	for i, seed := range t.seeds {
+		est = min(est, t.sketches[m][i][maphash.String(seed, key)%hotKeySketchWidth])
+	}
+	return est
+}
+
+// top returns up to limit of the hottest keys by sortBy, with their usage
+// scaled up from the sampled requests. If limit is 0, it returns all the
+// tracked keys.
+func (t *hotKeyTracker) top(sortBy pb.HotKeysRequest_SortBy, limit int) []*pb.HotKey {
+	m := hotKeyMetric(sortBy)
+	scale := func(n uint64) int64 { return int64(math.Round(float64(n) / t.sampleRate)) }
+
+	t.mu.Lock()
+	keys := make([]*pb.HotKey, 0, len(t.tops[m].entries))
+	for _, e := range t.tops[m].entries {
+		keys = append(keys, &pb.HotKey{
+			Key:        []byte(e.key),
+			Reads:      scale(t.estimate(e.key, hotKeyReads)),
+			Writes:     scale(t.estimate(e.key, hotKeyWrites)),
+			ReadBytes:  scale(t.estimate(e.key, hotKeyReadBytes)),
+			WriteBytes: scale(t.estimate(e.key, hotKeyWriteBytes)),
+		})
+	}
+	t.mu.Unlock()
+
+	usage := func(k *pb.HotKey) int64 {
+		switch m {
+		case hotKeyWrites:
+			return k.Writes
+		case hotKeyReadBytes:
+			return k.ReadBytes
+		case hotKeyWriteBytes:
+			return k.WriteBytes
+		default:
+			return k.Reads
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if ui, uj := usage(keys[i]), usage(keys[j]); ui != uj {
+			return ui > uj
+		}
+		return string(keys[i].Key) < string(keys[j].Key)
+	})
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
+
+// reset clears the tracked usage.
+func (t *hotKeyTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sketches = [hotKeyMetrics][hotKeySketchDepth][hotKeySketchWidth]uint64{}
+	for m := range t.tops {
+		t.tops[m].entries = t.tops[m].entries[:0]
+		clear(t.tops[m].index)
+	}
+}
+
+// HotKeys reports the hottest keys of the requests served by the member.
+func (s *EtcdServer) HotKeys(ctx context.Context, r *pb.HotKeysRequest) (*pb.HotKeysResponse, error) {
+	if s.hotKeys == nil {
+		return nil, errors.ErrHotKeysDisabled
+	}
+	resp := &pb.HotKeysResponse{
+		Header:     &pb.ResponseHeader{},
+		Keys:       s.hotKeys.top(r.SortBy, int(r.Limit)),
+		SampleRate: s.hotKeys.sampleRate,
+	}
+	if r.Reset_ {
+		s.hotKeys.reset()
+	}
+	return resp, nil
+}
+
+type hotKeyEntry struct {
+	key   string
+	count uint64
+}
+
+// hotKeyHeap is a min-heap of the top keys of a metric, indexed by key so
+// that the estimate of a tracked key is updated in place.
+type hotKeyHeap struct {
+	entries []hotKeyEntry
+	index   map[string]int
+}
+
+// update sets the estimate of key to count, replacing the coldest key if
+// key is not tracked, the heap holds k keys and count is above the count
+// of the coldest key.
+func (h *hotKeyHeap) update(key string, count uint64, k int) {
+	if i, ok := h.index[key]; ok {
+		h.entries[i].count = count
+		heap.Fix(h, i)
+		return
+	}
+	if len(h.entries) < k {
+		heap.Push(h, hotKeyEntry{key: key, count: count})
+		return
+	}
+	if count <= h.entries[0].count {
+		return
+	}
+	delete(h.index, h.entries[0].key)
+	h.entries[0] = hotKeyEntry{key: key, count: count}
+	h.index[key] = 0
+	heap.Fix(h, 0)
+}
+
+func (h *hotKeyHeap) Len() int { return len(h.entries) }
+
+func (h *hotKeyHeap) Less(i, j int) bool { return h.entries[i].count < h.entries[j].count }
+
+func (h *hotKeyHeap) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.index[h.entries[i].key] = i
+	h.index[h.entries[j].key] = j
+}
+
+func (h *hotKeyHeap) Push(x any) {
+	e := x.(hotKeyEntry)
+	h.index[e.key] = len(h.entries)
+	h.entries = append(h.entries, e)
+}
+
+func (h *hotKeyHeap) Pop() any {
+	e := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	delete(h.index, e.key)
+	return e
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func hotKeyNames(keys []*pb.HotKey) []string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = string(k.Key)
+	}
+	return names
+}
+
+func TestHotKeyTrackerTop(t *testing.T) {
+	tr := newHotKeyTracker(3, 1)
+	// key i is read i times and written 10-i times, with values of 100 bytes
+	for i := 1; i <= 9; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+		for j := 0; j < i; j++ {
+			tr.recordRead(key, 10)
+		}
+		for j := 0; j < 10-i; j++ {
+			tr.recordWrite(key, 100)
+		}
+	}
+
+	reads := tr.top(pb.HotKeysRequest_READS, 0)
+	require.Equal(t, []string{"key9", "key8", "key7"}, hotKeyNames(reads))
+	assert.Equal(t, &pb.HotKey{Key: []byte("key9"), Reads: 9, Writes: 1, ReadBytes: 90, WriteBytes: 100}, reads[0])
+
+	writes := tr.top(pb.HotKeysRequest_WRITES, 2)
+	assert.Equal(t, []string{"key1", "key2"}, hotKeyNames(writes))
+	assert.Equal(t, []string{"key1", "key2", "key3"}, hotKeyNames(tr.top(pb.HotKeysRequest_WRITE_BYTES, 0)))
+	assert.Equal(t, []string{"key9", "key8", "key7"}, hotKeyNames(tr.top(pb.HotKeysRequest_READ_BYTES, 0)))
+
+	tr.reset()
+	assert.Empty(t, tr.top(pb.HotKeysRequest_READS, 0))
+	tr.recordRead([]byte("key1"), 10)
+	assert.Equal(t, []*pb.HotKey{{Key: []byte("key1"), Reads: 1, ReadBytes: 10}}, tr.top(pb.HotKeysRequest_READS, 0))
+}
+
+func TestHotKeyTrackerHeavyHitters(t *testing.T) {
+	tr := newHotKeyTracker(5, 1)
+	// many cold keys collide in the sketches, but the hot keys stand out
+	for i := 0; i < 20000; i++ {
+		tr.recordRead([]byte(fmt.Sprintf("cold%d", i)), 0)
+		if i%100 == 0 {
+			for h := 0; h < 5; h++ {
+				tr.recordRead([]byte(fmt.Sprintf("hot%d", h)), 0)
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"hot0", "hot1", "hot2", "hot3", "hot4"}, hotKeyNames(tr.top(pb.HotKeysRequest_READS, 0)))
+}
+
+func TestHotKeyTrackerSampling(t *testing.T) {
+	tr := newHotKeyTracker(1, 0.5)
+	for i := 0; i < 10000; i++ {
+		tr.recordWrite([]byte("a"), 0)
+	}
+	keys := tr.top(pb.HotKeysRequest_WRITES, 0)
+	require.Len(t, keys, 1)
+	// the usage is scaled up from the sampled writes
+	assert.InDelta(t, 10000, keys[0].Writes, 1000)
+}
+
+func TestHotKeyTrackerTxn(t *testing.T) {
+	tr := newHotKeyTracker(10, 1)
+	r := &pb.TxnRequest{
+		Success: []*pb.RequestOp{
+			{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte("a")}}},
+			{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("b"), Value: []byte("xyz")}}},
+			{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{
+				Failure: []*pb.RequestOp{
+					{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: []byte("c")}}},
+				},
+			}}},
+		},
+		Failure: []*pb.RequestOp{
+			{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("d")}}},
+		},
+	}
+	resp := &pb.TxnResponse{
+		Succeeded: true,
+		Responses: []*pb.ResponseOp{
+			{Response: &pb.ResponseOp_ResponseRange{ResponseRange: &pb.RangeResponse{
+				Kvs: []*mvccpb.KeyValue{{Key: []byte("a"), Value: []byte("1")}},
+			}}},
+			{Response: &pb.ResponseOp_ResponsePut{ResponsePut: &pb.PutResponse{}}},
+			{Response: &pb.ResponseOp_ResponseTxn{ResponseTxn: &pb.TxnResponse{
+				Responses: []*pb.ResponseOp{
+					{Response: &pb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: &pb.DeleteRangeResponse{}}},
+				},
+			}}},
+		},
+	}
+	tr.recordTxn(r, resp)
+
+	assert.Equal(t, []string{"a"}, hotKeyNames(tr.top(pb.HotKeysRequest_READS, 0)))
+	assert.Equal(t, []string{"b", "c"}, hotKeyNames(tr.top(pb.HotKeysRequest_WRITES, 0)))
+	assert.Equal(t, []string{"b", "c"}, hotKeyNames(tr.top(pb.HotKeysRequest_WRITE_BYTES, 0)))
+
+	var disabled *hotKeyTracker
+	disabled.recordTxn(r, resp)
+	disabled.recordRead([]byte("a"), 1)
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// The types of the jobs run by the member.
+const (
+	JobTypeDefrag = "defrag"
+	JobTypeGC     = "gc"
+)
+
+// Jobs returns the running and the latest ended jobs of the member.
+func (s *EtcdServer) Jobs() []*pb.Job {
+	return s.jobs.Jobs()
+}
+
+// Job returns the job of the given ID.
+func (s *EtcdServer) Job(id int64) (*pb.Job, error) {
+	return s.jobs.Job(id)
+}
+
+// CancelJob asks the running job of the given ID to stop.
+func (s *EtcdServer) CancelJob(id int64) (*pb.Job, error) {
+	return s.jobs.Cancel(id)
+}
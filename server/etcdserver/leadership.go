@@ -0,0 +1,154 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// leadershipCheckInterval is the interval at which a leader whose leadership
+// is disallowed retries transferring it.
+const leadershipCheckInterval = time.Second
+
+var errNoLeadershipTransferee = errors.New("etcdserver: no active member is allowed to be leader")
+
+// leadershipTransferees returns the IDs of the voting members, other than the
+// local member, whose leadership is allowed.
+func (s *EtcdServer) leadershipTransferees() []types.ID {
+	var ids []types.ID
+	for _, m := range leadershipAllowedMembers(s.cluster.VotingMembers()) {
+		if m.ID != s.MemberID() {
+			ids = append(ids, m.ID)
+		}
+	}
+	return ids
+}
+
+// leadershipDisallowed returns true if the local member must not be leader,
+// including while it is draining.
+func (s *EtcdServer) leadershipDisallowed() bool {
+	m := s.cluster.Member(s.MemberID())
+	return m != nil && (m.LeadershipDisallowed || m.Draining)
+}
+
+// monitorLeadership records the configured leadership eligibility of the
+// local member in the cluster membership, then transfers the leadership away
+// whenever the local member is leader although its leadership is disallowed.
+func (s *EtcdServer) monitorLeadership() {
+	select {
+	case <-s.ReadyNotify():
+	case <-s.stopping:
+		return
+	}
+	if s.Cfg.SetLeadership {
+		s.applyLeadershipConfig()
+	}
+
+	lg := s.Logger()
+	ticker := time.NewTicker(leadershipCheckInterval)
+	defer ticker.Stop()
+	var failed bool
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-s.LeaderChangedNotify():
+		case <-ticker.C:
+		}
+
+		if !s.isLeader() || !s.leadershipDisallowed() {
+			failed = false
+			continue
+		}
+		err := s.transferDisallowedLeadership()
+		if err != nil && !failed {
+			// the transfer is retried on every tick; only warn once
+			lg.Warn(
+				"failed to transfer leadership of member whose leadership is disallowed",
+				zap.String("local-member-id", s.MemberID().String()),
+				zap.Error(err),
+			)
+		}
+		failed = err != nil
+	}
+}
+
+// transferDisallowedLeadership transfers the leadership of the local member to
+// the longest connected member whose leadership is allowed.
+func (s *EtcdServer) transferDisallowedLeadership() error {
+	transferee, ok := longestConnected(s.r.transport, s.leadershipTransferees())
+	if !ok {
+		return errNoLeadershipTransferee
+	}
+	s.Logger().Info(
+		"transferring leadership; leadership of local member is disallowed",
+		zap.String("local-member-id", s.MemberID().String()),
+		zap.String("transferee-member-id", transferee.String()),
+	)
+	ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	return s.MoveLeader(ctx, s.Lead(), uint64(transferee))
+}
+
+// applyLeadershipConfig updates the leadership eligibility of the local member
+// in the cluster membership to the configured one. It retries until the update
+// is applied or the server stops.
+func (s *EtcdServer) applyLeadershipConfig() {
+	lg := s.Logger()
+	for {
+		m := s.cluster.Member(s.MemberID())
+		if m == nil || m.LeadershipDisallowed == s.Cfg.LeadershipDisallowed {
+			return
+		}
+		m.LeadershipDisallowed = s.Cfg.LeadershipDisallowed
+		b, err := json.Marshal(m)
+		if err != nil {
+			lg.Panic("failed to marshal member", zap.Error(err))
+		}
+		ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+		_, err = s.configure(ctx, raftpb.ConfChange{
+			Type:    raftpb.ConfChangeUpdateNode,
+			NodeID:  uint64(m.ID),
+			Context: b,
+		})
+		cancel()
+		if err == nil {
+			lg.Info(
+				"updated leadership eligibility of local member",
+				zap.String("local-member-id", s.MemberID().String()),
+				zap.Bool("leadership-disallowed", s.Cfg.LeadershipDisallowed),
+			)
+			return
+		}
+		lg.Warn(
+			"failed to update leadership eligibility of local member",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.Error(err),
+		)
+		select {
+		case <-time.After(leadershipCheckInterval):
+		case <-s.stopping:
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/auth"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// DryRunAddMember validates adding memb to the cluster like AddMember does,
+// without adding it. It returns the members the cluster would have and the
+// impact of the addition on the quorum.
+func (s *EtcdServer) DryRunAddMember(ctx context.Context, memb membership.Member) ([]*membership.Member, *pb.QuorumImpact, error) {
+	if err := s.checkMembershipOperationPermission(ctx, auth.RPCClusterMemberAdd); err != nil {
+		return nil, nil, err
+	}
+	if err := s.mayAddMember(memb); err != nil {
+		return nil, nil, err
+	}
+	b, err := json.Marshal(memb)
+	if err != nil {
+		return nil, nil, err
+	}
+	cc := raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: uint64(memb.ID), Context: b}
+	if memb.IsLearner {
+		cc.Type = raftpb.ConfChangeAddLearnerNode
+	}
+	if err := s.cluster.ValidateConfigurationChange(cc, membership.ApplyBoth); err != nil {
+		return nil, nil, err
+	}
+
+	voters, started := 0, 0
+	for _, m := range s.cluster.VotingMembers() {
+		voters++
+		if m.IsStarted() {
+			started++
+		}
+	}
+	after := voters
+	if !memb.IsLearner {
+		after++
+	}
+	impact := &pb.QuorumImpact{
+		VotingMembersBefore:    uint32(voters),
+		VotingMembersAfter:     uint32(after),
+		QuorumBefore:           uint32(quorum(voters)),
+		QuorumAfter:            uint32(quorum(after)),
+		FailureToleranceBefore: uint32(voters - quorum(voters)),
+		FailureToleranceAfter:  uint32(after - quorum(after)),
+	}
+	if !memb.IsLearner {
+		if after%2 == 0 {
+			impact.Warnings = append(impact.Warnings, fmt.Sprintf("the cluster will have an even number of voting members (%d), tolerating no more failures than a cluster of %d", after, after-1))
+		}
+		// the added member counts towards the quorum as soon as it is added,
+		// but it only votes once started and caught up.
+		if tolerance := started - quorum(after); tolerance < 0 {
+			impact.Warnings = append(impact.Warnings, fmt.Sprintf("the cluster will lose its quorum until the new member is started: %d of %d voting members are started, a quorum of %d is needed", started, after, quorum(after)))
+		} else {
+			impact.Warnings = append(impact.Warnings, fmt.Sprintf("until the new member is started, the cluster tolerates %d failures; add it as a learner and promote it once started to avoid this", tolerance))
+		}
+	}
+	impact.Warnings = append(impact.Warnings, s.memberVersionWarnings()...)
+
+	membs := append(s.cluster.Members(), &memb)
+	return membs, impact, nil
+}
+
+// memberVersionWarnings warns about members running different server versions
+// or whose version could not be fetched.
+func (s *EtcdServer) memberVersionWarnings() []string {
+	var warnings, versions []string
+	for id, v := range getMembersVersions(s.Logger(), s.cluster, s.MemberID(), s.peerRt, s.Cfg.ReqTimeout()) {
+		if v == nil {
+			warnings = append(warnings, fmt.Sprintf("could not fetch the version of member %s", id))
+			continue
+		}
+		if !slices.Contains(versions, v.Server) {
+			versions = append(versions, v.Server)
+		}
+	}
+	slices.Sort(warnings)
+	if len(versions) > 1 {
+		slices.Sort(versions)
+		warnings = append(warnings, fmt.Sprintf("members run mixed server versions: %s", strings.Join(versions, ", ")))
+	}
+	return warnings
+}
+
+// quorum returns the number of voting members forming a quorum among n.
+func quorum(n int) int {
+	return n/2 + 1
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// memoryCheckInterval is the interval at which the accounted memory is
+// exported and checked against the soft limit.
+const memoryCheckInterval = time.Second
+
+// memoryUsage estimates the memory held by the components of the member.
+// The backend is memory-mapped and is not accounted.
+func (s *EtcdServer) memoryUsage() []*pb.MemoryUsage {
+	stats := s.KV().MemoryStats()
+	usage := []*pb.MemoryUsage{
+		{Component: "index", Bytes: stats.IndexBytes},
+		{Component: "watchers", Bytes: stats.WatcherBytes},
+	}
+	if s.lessor != nil {
+		usage = append(usage, &pb.MemoryUsage{Component: "leases", Bytes: s.lessor.Bytes()})
+	}
+	usage = append(usage, &pb.MemoryUsage{Component: "grpc", Bytes: s.grpcBufferBytes.Load()})
+	if s.readCache != nil {
+		usage = append(usage, &pb.MemoryUsage{Component: "read_cache", Bytes: s.readCache.bytes()})
+	}
+	return usage
+}
+
+// MemoryStats reports the memory accounted to the components of the member.
+func (s *EtcdServer) MemoryStats(ctx context.Context, r *pb.MemoryStatsRequest) (*pb.MemoryStatsResponse, error) {
+	resp := &pb.MemoryStatsResponse{
+		Header:    &pb.ResponseHeader{},
+		Usage:     s.memoryUsage(),
+		SoftLimit: s.Cfg.MemorySoftLimit,
+	}
+	for _, u := range resp.Usage {
+		resp.Total += u.Bytes
+	}
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	resp.HeapAlloc = ms.HeapAlloc
+	return resp, nil
+}
+
+// AccountGRPCBuffer adds delta to the bytes held by the buffers of the gRPC
+// requests being served.
+func (s *EtcdServer) AccountGRPCBuffer(delta int64) {
+	s.grpcBufferBytes.Add(delta)
+}
+
+// monitorMemory exports the accounted memory and, if the accounted memory
+// is over the soft limit, drops idle watchers to reclaim the excess.
+func (s *EtcdServer) monitorMemory() {
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+
+	lg := s.Logger()
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+		}
+
+		total := int64(0)
+		for _, u := range s.memoryUsage() {
+			memoryBytes.WithLabelValues(u.Component).Set(float64(u.Bytes))
+			total += u.Bytes
+		}
+		limit := s.Cfg.MemorySoftLimit
+		if limit == 0 || total <= limit {
+			continue
+		}
+		if dropped := s.KV().DropIdleWatchers(total - limit); dropped > 0 {
+			lg.Warn(
+				"accounted memory is over the soft limit; dropped idle watchers",
+				zap.Int64("accounted-bytes", total),
+				zap.Int64("soft-limit-bytes", limit),
+				zap.Int("dropped-watchers", dropped),
+			)
+		}
+	}
+}
@@ -59,6 +59,24 @@ var (
 		Name:      "learner_promote_successes",
 		Help:      "The total number of successful learner promotions while this member is leader.",
 	})
+	readCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "read_cache_hits_total",
+		Help:      "The total number of serializable range requests served from the read cache.",
+	})
+	readCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "read_cache_misses_total",
+		Help:      "The total number of cacheable serializable range requests not found in the read cache.",
+	})
+	readCacheBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "read_cache_bytes",
+		Help:      "The size in bytes of the responses held by the read cache.",
+	})
 	heartbeatSendFailures = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd",
 		Subsystem: "server",
@@ -95,6 +113,27 @@ var (
 		Name:      "proposals_failed_total",
 		Help:      "The total number of failed proposals seen.",
 	})
+	proposalsRejectedTooBusy = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "proposals_rejected_too_busy_total",
+		Help:      "The total number of low priority proposals rejected because the apply backlog was over its threshold.",
+	})
+	memoryBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "etcd",
+			Subsystem: "server",
+			Name:      "memory_bytes",
+			Help:      "The estimated memory held by a component of the server.",
+		},
+		[]string{"component"},
+	)
+	walDiskFreeBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "wal_disk_free_bytes",
+		Help:      "The free disk space of the WAL directory.",
+	})
 	slowReadIndex = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd",
 		Subsystem: "server",
@@ -107,6 +146,14 @@ var (
 		Name:      "read_indexes_failed_total",
 		Help:      "The total number of failed read indexes seen.",
 	})
+	leaseReads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "lease_reads_total",
+		Help:      "The total number of lease reads, by whether they were served from the leader lease or fell back to ReadIndex.",
+	},
+		[]string{"served_by"},
+	)
 	leaseExpired = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd_debugging",
 		Subsystem: "server",
@@ -147,6 +194,12 @@ var (
 		},
 		[]string{"name", "stage"},
 	)
+	staleReadGap = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "stale_read_gate_apply_gap",
+		Help:      "The number of entries the member has to apply to catch up with the leader before it serves serializable reads.",
+	})
 	fdUsed = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: "os",
 		Subsystem: "fd",
@@ -164,15 +217,23 @@ var (
 func init() {
 	prometheus.MustRegister(hasLeader)
 	prometheus.MustRegister(isLeader)
+	prometheus.MustRegister(staleReadGap)
 	prometheus.MustRegister(leaderChanges)
 	prometheus.MustRegister(heartbeatSendFailures)
+	prometheus.MustRegister(readCacheHits)
+	prometheus.MustRegister(readCacheMisses)
+	prometheus.MustRegister(readCacheBytes)
 	prometheus.MustRegister(applySnapshotInProgress)
 	prometheus.MustRegister(proposalsCommitted)
 	prometheus.MustRegister(proposalsApplied)
 	prometheus.MustRegister(proposalsPending)
 	prometheus.MustRegister(proposalsFailed)
+	prometheus.MustRegister(proposalsRejectedTooBusy)
+	prometheus.MustRegister(memoryBytes)
+	prometheus.MustRegister(walDiskFreeBytes)
 	prometheus.MustRegister(slowReadIndex)
 	prometheus.MustRegister(readIndexFailed)
+	prometheus.MustRegister(leaseReads)
 	prometheus.MustRegister(leaseExpired)
 	prometheus.MustRegister(currentVersion)
 	prometheus.MustRegister(currentGoVersion)
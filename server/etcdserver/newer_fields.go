@@ -0,0 +1,160 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/wal"
+)
+
+// newerFieldTracker records the fields, messages and enum values of the
+// served requests that are annotated with an etcd version newer than the
+// cluster version. Clients using them against a mixed-version cluster rely
+// on features that members of the older version do not implement.
+type newerFieldTracker struct {
+	reject bool
+
+	mu     sync.Mutex
+	fields map[protoreflect.FullName]*pb.NewerField
+}
+
+func newNewerFieldTracker(reject bool) *newerFieldTracker {
+	return &newerFieldTracker{reject: reject, fields: make(map[protoreflect.FullName]*pb.NewerField)}
+}
+
+// newerThan returns the names and versions of the parts of req newer than
+// clusterVersion.
+func newerThan(req proto.Message, clusterVersion *semver.Version) map[protoreflect.FullName]*semver.Version {
+	return newerVisited(clusterVersion, func(visitor wal.Visitor) error { return wal.VisitMessage(req, visitor) })
+}
+
+// newerVisited returns the names and versions newer than v passed by visit
+// to its visitor.
+func newerVisited(v *semver.Version, visit func(wal.Visitor) error) map[protoreflect.FullName]*semver.Version {
+	var newer map[protoreflect.FullName]*semver.Version
+	// The visit only fails on enum values unknown to this member, which it
+	// cannot attribute to a version.
+	_ = visit(func(path protoreflect.FullName, ver *semver.Version) error {
+		if ver != nil && v.LessThan(*ver) {
+			if newer == nil {
+				newer = make(map[protoreflect.FullName]*semver.Version)
+			}
+			newer[path] = ver
+		}
+		return nil
+	})
+	return newer
+}
+
+// record counts a request of method using the newer fields, and returns the
+// ones observed for the first time.
+func (t *newerFieldTracker) record(method string, newer map[protoreflect.FullName]*semver.Version, now time.Time) (observed []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for name, ver := range newer {
+		f, ok := t.fields[name]
+		if !ok {
+			f = &pb.NewerField{Name: string(name), Version: ver.String()}
+			t.fields[name] = f
+			observed = append(observed, f.Name)
+		}
+		f.Count++
+		f.LastMethod = method
+		f.LastSeenTime = now.UnixNano()
+	}
+	sort.Strings(observed)
+	return observed
+}
+
+// report returns copies of the observed fields sorted by name, clearing them
+// if reset is set.
+func (t *newerFieldTracker) report(reset bool) []*pb.NewerField {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fields := make([]*pb.NewerField, 0, len(t.fields))
+	for _, f := range t.fields {
+		c := *f
+		fields = append(fields, &c)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	if reset {
+		clear(t.fields)
+	}
+	return fields
+}
+
+// CheckRequestFields checks the fields, messages and enum values of a
+// request of method against the cluster version. Requests using newer ones
+// are recorded and, if the member rejects them, fail with
+// ErrNewerRequestFields. Each newer field is logged when first observed.
+func (s *EtcdServer) CheckRequestFields(method string, req any) error {
+	if s.newerFields == nil {
+		return nil
+	}
+	m, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	// Reporting the newer fields is local to the member.
+	if _, ok = req.(*pb.NewerFieldsRequest); ok {
+		return nil
+	}
+	cv := s.ClusterVersion()
+	if cv == nil {
+		return nil
+	}
+	newer := newerThan(m, cv)
+	if len(newer) == 0 {
+		return nil
+	}
+	if observed := s.newerFields.record(method, newer, time.Now()); len(observed) > 0 {
+		s.Logger().Warn(
+			"request uses fields newer than the cluster version",
+			zap.String("method", method),
+			zap.Strings("fields", observed),
+			zap.String("cluster-version", cv.String()),
+			zap.Bool("rejected", s.newerFields.reject),
+		)
+	}
+	if s.newerFields.reject {
+		return errors.ErrNewerRequestFields
+	}
+	return nil
+}
+
+func (s *EtcdServer) NewerFields(ctx context.Context, r *pb.NewerFieldsRequest) (*pb.NewerFieldsResponse, error) {
+	if s.newerFields == nil {
+		return nil, errors.ErrNewerFieldsDisabled
+	}
+	resp := &pb.NewerFieldsResponse{
+		Header: &pb.ResponseHeader{},
+		Fields: s.newerFields.report(r.Reset_),
+	}
+	if cv := s.ClusterVersion(); cv != nil {
+		resp.ClusterVersion = cv.String()
+	}
+	return resp, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/api"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func newNewerFieldsTestServer(t *testing.T, clusterVersion string, reject bool) *EtcdServer {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	t.Cleanup(func() { betesting.Close(t, be) })
+	cl := newTestClusterWithBackend(t, []*membership.Member{}, be)
+	cl.SetVersion(semver.New(clusterVersion), api.UpdateCapability, membership.ApplyBoth)
+	return &EtcdServer{
+		lgMu:        new(sync.RWMutex),
+		lg:          zaptest.NewLogger(t),
+		cluster:     cl,
+		newerFields: newNewerFieldTracker(reject),
+	}
+}
+
+func TestCheckRequestFields(t *testing.T) {
+	srv := newNewerFieldsTestServer(t, "3.6.0", false)
+
+	require.NoError(t, srv.CheckRequestFields("/etcdserverpb.KV/Put", &pb.PutRequest{Key: []byte("foo")}))
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{
+		{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{Key: []byte("foo"), MaxDeletions: 1}}},
+	}}
+	require.NoError(t, srv.CheckRequestFields("/etcdserverpb.KV/Txn", txn))
+	require.NoError(t, srv.CheckRequestFields("/etcdserverpb.KV/Txn", txn))
+	// reporting is not a use of the newer fields
+	require.NoError(t, srv.CheckRequestFields("/etcdserverpb.Maintenance/NewerFields", &pb.NewerFieldsRequest{Reset_: true}))
+
+	resp, err := srv.NewerFields(t.Context(), &pb.NewerFieldsRequest{Reset_: true})
+	require.NoError(t, err)
+	assert.Equal(t, "3.6.0", resp.ClusterVersion)
+	require.Len(t, resp.Fields, 1)
+	assert.Equal(t, "etcdserverpb.DeleteRangeRequest.max_deletions", resp.Fields[0].Name)
+	assert.Equal(t, "3.7.0", resp.Fields[0].Version)
+	assert.Equal(t, int64(2), resp.Fields[0].Count)
+	assert.Equal(t, "/etcdserverpb.KV/Txn", resp.Fields[0].LastMethod)
+	assert.NotZero(t, resp.Fields[0].LastSeenTime)
+
+	resp, err = srv.NewerFields(t.Context(), &pb.NewerFieldsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Fields)
+}
+
+func TestCheckRequestFieldsReject(t *testing.T) {
+	srv := newNewerFieldsTestServer(t, "3.6.0", true)
+
+	require.NoError(t, srv.CheckRequestFields("/etcdserverpb.KV/Range", &pb.RangeRequest{Key: []byte("foo")}))
+	err := srv.CheckRequestFields("/etcdserverpb.Maintenance/HotKeys", &pb.HotKeysRequest{Limit: 1})
+	require.ErrorIs(t, err, errors.ErrNewerRequestFields)
+
+	resp, err := srv.NewerFields(t.Context(), &pb.NewerFieldsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Fields, 1)
+	assert.Equal(t, "etcdserverpb.HotKeysRequest", resp.Fields[0].Name)
+}
+
+func TestCheckRequestFieldsCurrentVersion(t *testing.T) {
+	srv := newNewerFieldsTestServer(t, "3.7.0", true)
+
+	require.NoError(t, srv.CheckRequestFields("/etcdserverpb.Maintenance/HotKeys", &pb.HotKeysRequest{Limit: 1}))
+	resp, err := srv.NewerFields(t.Context(), &pb.NewerFieldsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Fields)
+}
+
+func TestNewerFieldsDisabled(t *testing.T) {
+	srv := &EtcdServer{}
+	require.NoError(t, srv.CheckRequestFields("/etcdserverpb.Maintenance/HotKeys", &pb.HotKeysRequest{}))
+	_, err := srv.NewerFields(t.Context(), &pb.NewerFieldsRequest{})
+	require.ErrorIs(t, err, errors.ErrNewerFieldsDisabled)
+}
@@ -74,6 +74,7 @@ type toApply struct {
 	// raftAdvancedC notifies EtcdServer.apply that
 	// 'raftLog.applied' has advanced by r.Advance
 	// it should be used only when entries contain raftpb.EntryConfChange
+	// or raftpb.EntryConfChangeV2
 	raftAdvancedC <-chan struct{}
 }
 
@@ -284,11 +285,13 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 
 				r.raftStorage.Append(rd.Entries)
 
-				confChanged := false
+				confChanged, confChangedV2 := false, false
 				for _, ent := range rd.CommittedEntries {
-					if ent.Type == raftpb.EntryConfChange {
+					switch ent.Type {
+					case raftpb.EntryConfChange:
 						confChanged = true
-						break
+					case raftpb.EntryConfChangeV2:
+						confChanged, confChangedV2 = true, true
 					}
 				}
 
@@ -323,6 +326,18 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 				} else {
 					// leader already processed 'MsgSnap' and signaled
 					notifyc <- struct{}{}
+
+					// Raft decides on Advance whether to leave a joint configuration, so
+					// the leader has to apply a ConfChangeV2 first. Otherwise, once the
+					// entry leaving it is committed, raft would still see the joint
+					// configuration and propose leaving it again.
+					if confChangedV2 {
+						select {
+						case notifyc <- struct{}{}:
+						case <-r.stopped:
+							return
+						}
+					}
 				}
 
 				// gofail: var raftBeforeAdvance struct{}
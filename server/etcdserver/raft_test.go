@@ -42,6 +42,12 @@ func TestGetIDs(t *testing.T) {
 	normalEntry := raftpb.Entry{Type: raftpb.EntryNormal}
 	updatecc := &raftpb.ConfChange{Type: raftpb.ConfChangeUpdateNode, NodeID: 2}
 	updateEntry := raftpb.Entry{Type: raftpb.EntryConfChange, Data: pbutil.MustMarshal(updatecc)}
+	replacecc := &raftpb.ConfChangeV2{Changes: []raftpb.ConfChangeSingle{
+		{Type: raftpb.ConfChangeAddNode, NodeID: 3},
+		{Type: raftpb.ConfChangeRemoveNode, NodeID: 2},
+	}}
+	replaceEntry := raftpb.Entry{Type: raftpb.EntryConfChangeV2, Data: pbutil.MustMarshal(replacecc)}
+	leaveJointEntry := raftpb.Entry{Type: raftpb.EntryConfChangeV2, Data: pbutil.MustMarshal(&raftpb.ConfChangeV2{})}
 
 	tests := []struct {
 		confState *raftpb.ConfState
@@ -80,6 +86,11 @@ func TestGetIDs(t *testing.T) {
 			[]raftpb.Entry{addEntry, removeEntry, normalEntry},
 			[]uint64{1},
 		},
+		{
+			&raftpb.ConfState{Voters: []uint64{1}},
+			[]raftpb.Entry{addEntry, replaceEntry, leaveJointEntry},
+			[]uint64{1, 3},
+		},
 	}
 
 	for i, tt := range tests {
@@ -0,0 +1,193 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// readCache caches the responses of serializable range requests of single
+// keys, so that frequently read keys are served without a backend read.
+// It observes the store to drop the entries of changed keys once the change
+// is visible to new reads, so a cached response is never older than what a
+// backend read would return.
+type readCache struct {
+	maxBytes int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	size  int
+	lru   *list.List
+	items map[string]*list.Element
+	// fills tracks the misses being read from the backend per key. A fill
+	// is only cached if its key did not change since the miss.
+	fills map[string]*readCacheFill
+	// rev is the latest revision whose changes have been observed. A cached
+	// response is up to date at least until that revision.
+	rev int64
+}
+
+type readCacheEntry struct {
+	key     string
+	resp    *pb.RangeResponse
+	size    int
+	expires time.Time
+}
+
+type readCacheFill struct {
+	refs  int
+	stale bool
+}
+
+func newReadCache(maxBytes int, ttl time.Duration) *readCache {
+	return &readCache{
+		maxBytes: maxBytes,
+		ttl:      ttl,
+		now:      time.Now,
+		lru:      list.New(),
+		items:    make(map[string]*list.Element),
+		fills:    make(map[string]*readCacheFill),
+	}
+}
+
+// cacheable returns true if the response of r may be served from the cache.
+func (c *readCache) cacheable(r *pb.RangeRequest) bool {
+	return c != nil && r.Serializable && r.MinAppliedIndex == 0 && len(r.RangeEnd) == 0 && r.Revision == 0 &&
+		!r.KeysOnly && !r.CountOnly && r.CountModificationsSinceRev == 0 &&
+		r.MinModRevision == 0 && r.MaxModRevision == 0 &&
+		r.MinCreateRevision == 0 && r.MaxCreateRevision == 0
+}
+
+// get returns the cached response of key. On a miss, it returns a fill that
+// must be passed to put once the response is read from the backend.
+func (c *readCache) get(key []byte) (*pb.RangeResponse, *readCacheFill) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[string(key)]; ok {
+		ent := e.Value.(*readCacheEntry)
+		if c.now().Before(ent.expires) {
+			c.lru.MoveToFront(e)
+			readCacheHits.Inc()
+			return c.copyResponse(ent.resp), nil
+		}
+		c.removeElement(e)
+	}
+	readCacheMisses.Inc()
+	f, ok := c.fills[string(key)]
+	if !ok {
+		f = &readCacheFill{}
+		c.fills[string(key)] = f
+	}
+	f.refs++
+	return nil, f
+}
+
+// put caches the response of key read after the miss that returned f.
+func (c *readCache) put(key []byte, f *readCacheFill, resp *pb.RangeResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if f.refs--; f.refs == 0 {
+		delete(c.fills, string(key))
+	}
+	if f.stale || resp == nil {
+		return
+	}
+	size := len(key) + resp.Size()
+	if size > c.maxBytes {
+		return
+	}
+	if e, ok := c.items[string(key)]; ok {
+		c.removeElement(e)
+	}
+	ent := &readCacheEntry{
+		key:     string(key),
+		resp:    c.copyResponse(resp),
+		size:    size,
+		expires: c.now().Add(c.ttl),
+	}
+	c.items[ent.key] = c.lru.PushFront(ent)
+	c.size += size
+	for c.size > c.maxBytes {
+		c.removeElement(c.lru.Back())
+	}
+	readCacheBytes.Set(float64(c.size))
+}
+
+// copyResponse returns a copy of resp that can be handed out, as the
+// response header is filled in by the caller.
+func (c *readCache) copyResponse(resp *pb.RangeResponse) *pb.RangeResponse {
+	cp := *resp
+	if resp.Header != nil {
+		h := *resp.Header
+		if h.Revision < c.rev {
+			h.Revision = c.rev
+		}
+		cp.Header = &h
+	}
+	cp.Kvs = append([]*mvccpb.KeyValue(nil), resp.Kvs...)
+	return &cp
+}
+
+// bytes returns the size of the cached responses.
+func (c *readCache) bytes() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return int64(c.size)
+}
+
+func (c *readCache) removeElement(e *list.Element) {
+	ent := c.lru.Remove(e).(*readCacheEntry)
+	delete(c.items, ent.key)
+	c.size -= ent.size
+	readCacheBytes.Set(float64(c.size))
+}
+
+// Changed implements mvcc.ChangeObserver.
+func (c *readCache) Changed(rev int64, evs []mvccpb.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ev := range evs {
+		key := string(ev.Kv.Key)
+		if e, ok := c.items[key]; ok {
+			c.removeElement(e)
+		}
+		if f, ok := c.fills[key]; ok {
+			f.stale = true
+		}
+	}
+	if rev > c.rev {
+		c.rev = rev
+	}
+}
+
+// Restored implements mvcc.ChangeObserver.
+func (c *readCache) Restored(rev int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	clear(c.items)
+	for _, f := range c.fills {
+		f.stale = true
+	}
+	c.size = 0
+	c.rev = rev
+	readCacheBytes.Set(0)
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func newReadCacheTestResponse(key, value string, rev int64) *pb.RangeResponse {
+	return &pb.RangeResponse{
+		Header: &pb.ResponseHeader{Revision: rev},
+		Kvs:    []*mvccpb.KeyValue{{Key: []byte(key), Value: []byte(value), ModRevision: rev}},
+		Count:  1,
+	}
+}
+
+func changeEvents(keys ...string) []mvccpb.Event {
+	evs := make([]mvccpb.Event, len(keys))
+	for i, k := range keys {
+		evs[i] = mvccpb.Event{Kv: &mvccpb.KeyValue{Key: []byte(k)}}
+	}
+	return evs
+}
+
+func TestReadCacheCacheable(t *testing.T) {
+	c := newReadCache(1024, time.Minute)
+	assert.True(t, c.cacheable(&pb.RangeRequest{Key: []byte("a"), Serializable: true}))
+	assert.False(t, c.cacheable(&pb.RangeRequest{Key: []byte("a")}))
+	assert.False(t, c.cacheable(&pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte("b"), Serializable: true}))
+	assert.False(t, c.cacheable(&pb.RangeRequest{Key: []byte("a"), Revision: 3, Serializable: true}))
+	assert.False(t, c.cacheable(&pb.RangeRequest{Key: []byte("a"), CountOnly: true, Serializable: true}))
+	assert.False(t, c.cacheable(&pb.RangeRequest{Key: []byte("a"), MinModRevision: 2, Serializable: true}))
+
+	var disabled *readCache
+	assert.False(t, disabled.cacheable(&pb.RangeRequest{Key: []byte("a"), Serializable: true}))
+}
+
+func TestReadCacheInvalidation(t *testing.T) {
+	c := newReadCache(1024, time.Minute)
+	key := []byte("a")
+
+	resp, fill := c.get(key)
+	require.Nil(t, resp)
+	c.put(key, fill, newReadCacheTestResponse("a", "1", 2))
+
+	resp, fill = c.get(key)
+	require.Nil(t, fill)
+	assert.Equal(t, []byte("1"), resp.Kvs[0].Value)
+	// the header of a hit is a copy, as it is filled in by the caller
+	resp.Header.MemberId = 1
+	resp, _ = c.get(key)
+	assert.Zero(t, resp.Header.MemberId)
+
+	// a change of another key only advances the revision of the hits
+	c.Changed(3, changeEvents("b"))
+	resp, _ = c.get(key)
+	assert.Equal(t, int64(3), resp.Header.Revision)
+
+	c.Changed(4, changeEvents("a"))
+	resp, fill = c.get(key)
+	require.Nil(t, resp)
+	c.put(key, fill, newReadCacheTestResponse("a", "2", 4))
+	resp, _ = c.get(key)
+	assert.Equal(t, []byte("2"), resp.Kvs[0].Value)
+
+	c.Restored(10)
+	resp, _ = c.get(key)
+	assert.Nil(t, resp)
+	assert.Zero(t, c.size)
+}
+
+func TestReadCacheStaleFill(t *testing.T) {
+	c := newReadCache(1024, time.Minute)
+	key := []byte("a")
+
+	_, fill := c.get(key)
+	// the key changes while the miss is read from the backend
+	c.Changed(3, changeEvents("a"))
+	c.put(key, fill, newReadCacheTestResponse("a", "1", 2))
+	resp, fill := c.get(key)
+	require.Nil(t, resp)
+	require.NotNil(t, fill)
+
+	c.put(key, fill, newReadCacheTestResponse("a", "2", 3))
+	resp, _ = c.get(key)
+	require.NotNil(t, resp)
+	assert.Equal(t, []byte("2"), resp.Kvs[0].Value)
+	assert.Empty(t, c.fills)
+}
+
+func TestReadCacheBounds(t *testing.T) {
+	entrySize := len("a") + newReadCacheTestResponse("a", "1", 2).Size()
+	c := newReadCache(2*entrySize, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	for _, k := range []string{"a", "b", "c"} {
+		_, fill := c.get([]byte(k))
+		c.put([]byte(k), fill, newReadCacheTestResponse(k, "1", 2))
+	}
+	// the least recently used key is evicted
+	assert.Len(t, c.items, 2)
+	assert.Equal(t, 2*entrySize, c.size)
+	resp, fill := c.get([]byte("a"))
+	assert.Nil(t, resp)
+	c.put([]byte("a"), fill, nil)
+
+	resp, _ = c.get([]byte("c"))
+	require.NotNil(t, resp)
+	now = now.Add(time.Minute)
+	resp, _ = c.get([]byte("c"))
+	assert.Nil(t, resp)
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
+)
+
+// MaxRequestLoggingDuration is the longest time request logging is enabled
+// for by a single SetRequestLogging request.
+const MaxRequestLoggingDuration = 24 * time.Hour
+
+// requestLogging is a setting of the request logging, which is immutable
+// once enabled.
+type requestLogging struct {
+	sampleRate float64
+	// methods are the full or short names of the logged methods, all of them
+	// if empty.
+	methods map[string]struct{}
+	// prefixes are the key prefixes of the logged requests, all of them if
+	// empty.
+	prefixes  [][]byte
+	logValues bool
+	expiry    time.Time
+}
+
+// requestLogger logs a sample of the unary requests served by the member,
+// while enabled by SetRequestLogging. The values of the requests are
+// redacted unless asked otherwise, and the content of the requests outside
+// of the KV service is never logged, as it may hold passwords or tokens.
+type requestLogger struct {
+	lg      *zap.Logger
+	setting atomic.Pointer[requestLogging]
+
+	// mu serializes the changes of the setting.
+	mu sync.Mutex
+	// timer disables the current setting once expired.
+	timer *time.Timer
+}
+
+func newRequestLogger(lg *zap.Logger) *requestLogger {
+	return &requestLogger{lg: lg}
+}
+
+// set replaces the setting of the request logging with r, or disables the
+// request logging if r has no duration.
+func (l *requestLogger) set(r *pb.SetRequestLoggingRequest) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+	if r.Duration <= 0 {
+		if l.setting.Swap(nil) != nil {
+			l.lg.Info("stopped request logging")
+		}
+		return
+	}
+
+	d := time.Duration(r.Duration) * time.Second
+	rl := &requestLogging{
+		sampleRate: r.SampleRate,
+		prefixes:   r.Prefixes,
+		logValues:  r.LogValues,
+		expiry:     time.Now().Add(d),
+	}
+	if len(r.Methods) > 0 {
+		rl.methods = make(map[string]struct{}, len(r.Methods))
+		for _, m := range r.Methods {
+			rl.methods[m] = struct{}{}
+		}
+	}
+	l.setting.Store(rl)
+	l.timer = time.AfterFunc(d, func() {
+		if l.setting.CompareAndSwap(rl, nil) {
+			l.lg.Info("request logging expired")
+		}
+	})
+	l.lg.Info(
+		"started request logging",
+		zap.Duration("duration", d),
+		zap.Float64("sample-rate", r.SampleRate),
+		zap.Strings("methods", r.Methods),
+		zap.Int("prefixes", len(r.Prefixes)),
+		zap.Bool("log-values", r.LogValues),
+	)
+}
+
+// match returns the setting the request of the given method is logged
+// with, or nil if it is not logged.
+func (l *requestLogger) match(method string, req any) *requestLogging {
+	if l == nil {
+		return nil
+	}
+	rl := l.setting.Load()
+	if rl == nil || !time.Now().Before(rl.expiry) {
+		return nil
+	}
+	if rl.methods != nil {
+		_, full := rl.methods[method]
+		_, short := rl.methods[method[strings.LastIndexByte(method, '/')+1:]]
+		if !full && !short {
+			return nil
+		}
+	}
+	if len(rl.prefixes) > 0 && !requestHasPrefix(req, rl.prefixes) {
+		return nil
+	}
+	if rl.sampleRate > 0 && rand.Float64() >= rl.sampleRate {
+		return nil
+	}
+	return rl
+}
+
+// requestHasPrefix reports whether a key accessed by req is under one of
+// the prefixes. Only the requests of the KV service access keys.
+func requestHasPrefix(req any, prefixes [][]byte) bool {
+	has := func(key, end []byte) bool {
+		for _, p := range prefixes {
+			if clusterconfig.OverlapsPrefix(key, end, p) {
+				return true
+			}
+		}
+		return false
+	}
+	switch r := req.(type) {
+	case *pb.RangeRequest:
+		return has(r.Key, r.RangeEnd)
+	case *pb.PutRequest:
+		return has(r.Key, nil)
+	case *pb.DeleteRangeRequest:
+		return has(r.Key, r.RangeEnd)
+	case *pb.TxnRequest:
+		for _, c := range r.Compare {
+			if has(c.Key, c.RangeEnd) {
+				return true
+			}
+		}
+		return opsHavePrefix(r.Success, prefixes) || opsHavePrefix(r.Failure, prefixes)
+	case *pb.ForEachRequest:
+		return has(r.Key, r.RangeEnd)
+	case *pb.AppendRequest:
+		return has(r.Key, nil)
+	}
+	return false
+}
+
+func opsHavePrefix(ops []*pb.RequestOp, prefixes [][]byte) bool {
+	for _, op := range ops {
+		var req any
+		switch o := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			req = o.RequestRange
+		case *pb.RequestOp_RequestPut:
+			req = o.RequestPut
+		case *pb.RequestOp_RequestDeleteRange:
+			req = o.RequestDeleteRange
+		case *pb.RequestOp_RequestTxn:
+			req = o.RequestTxn
+		case *pb.RequestOp_RequestAppend:
+			req = o.RequestAppend
+		case *pb.RequestOp_RequestForEach:
+			req = o.RequestForEach
+		}
+		if requestHasPrefix(req, prefixes) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestContent returns the loggable content of req, which is empty
+// outside of the KV service.
+func requestContent(req any, logValues bool) string {
+	switch r := req.(type) {
+	case *pb.RangeRequest:
+		return r.String()
+	case *pb.DeleteRangeRequest:
+		return r.String()
+	case *pb.PutRequest:
+		if logValues {
+			return r.String()
+		}
+		return pb.NewLoggablePutRequest(r).String()
+	case *pb.TxnRequest:
+		if logValues {
+			return r.String()
+		}
+		return pb.NewLoggableTxnRequest(r).String()
+	}
+	return ""
+}
+
+// SetRequestLogging enables, replaces or disables the request logging of
+// the member. The duration and sample rate of r are validated by the
+// caller.
+func (s *EtcdServer) SetRequestLogging(ctx context.Context, r *pb.SetRequestLoggingRequest) (*pb.SetRequestLoggingResponse, error) {
+	s.requestLogger.set(r)
+	return &pb.SetRequestLoggingResponse{Header: &pb.ResponseHeader{}}, nil
+}
+
+// LogRequest logs the unary request req of the given method, served since
+// start, if it is matched by the request logging of the member.
+func (s *EtcdServer) LogRequest(ctx context.Context, method string, req any, err error, start time.Time) {
+	rl := s.requestLogger.match(method, req)
+	if rl == nil {
+		return
+	}
+	remote := "No remote client info."
+	if p, ok := peer.FromContext(ctx); ok {
+		remote = p.Addr.String()
+	}
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("remote", remote),
+		zap.Time("start time", start),
+		zap.Duration("time spent", time.Since(start)),
+		zap.String("request content", requestContent(req, rl.logValues)),
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	s.Logger().Info("request", fields...)
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func TestRequestLoggerMatch(t *testing.T) {
+	put := &pb.PutRequest{Key: []byte("/a/1"), Value: []byte("v")}
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{
+		{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{Key: []byte("/b/1")}}},
+		{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{Failure: []*pb.RequestOp{
+			{Request: &pb.RequestOp_RequestPut{RequestPut: put}},
+		}}}},
+	}}
+	tests := []struct {
+		name    string
+		r       *pb.SetRequestLoggingRequest
+		method  string
+		req     any
+		matched bool
+	}{
+		{name: "disabled", r: &pb.SetRequestLoggingRequest{}, method: "/etcdserverpb.KV/Put", req: put},
+		{name: "all", r: &pb.SetRequestLoggingRequest{Duration: 60}, method: "/etcdserverpb.Lease/LeaseGrant", req: &pb.LeaseGrantRequest{}, matched: true},
+		{name: "short method", r: &pb.SetRequestLoggingRequest{Duration: 60, Methods: []string{"Put"}}, method: "/etcdserverpb.KV/Put", req: put, matched: true},
+		{name: "full method", r: &pb.SetRequestLoggingRequest{Duration: 60, Methods: []string{"/etcdserverpb.KV/Put"}}, method: "/etcdserverpb.KV/Put", req: put, matched: true},
+		{name: "other method", r: &pb.SetRequestLoggingRequest{Duration: 60, Methods: []string{"Range"}}, method: "/etcdserverpb.KV/Put", req: put},
+		{name: "prefix", r: &pb.SetRequestLoggingRequest{Duration: 60, Prefixes: [][]byte{[]byte("/a/")}}, method: "/etcdserverpb.KV/Put", req: put, matched: true},
+		{name: "other prefix", r: &pb.SetRequestLoggingRequest{Duration: 60, Prefixes: [][]byte{[]byte("/b/")}}, method: "/etcdserverpb.KV/Put", req: put},
+		{name: "range over prefix", r: &pb.SetRequestLoggingRequest{Duration: 60, Prefixes: [][]byte{[]byte("/b/")}}, method: "/etcdserverpb.KV/Range", req: &pb.RangeRequest{Key: []byte("/a"), RangeEnd: []byte("/c")}, matched: true},
+		{name: "nested txn prefix", r: &pb.SetRequestLoggingRequest{Duration: 60, Prefixes: [][]byte{[]byte("/a/")}}, method: "/etcdserverpb.KV/Txn", req: txn, matched: true},
+		{name: "prefix without keys", r: &pb.SetRequestLoggingRequest{Duration: 60, Prefixes: [][]byte{[]byte("/a/")}}, method: "/etcdserverpb.Lease/LeaseGrant", req: &pb.LeaseGrantRequest{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRequestLogger(zaptest.NewLogger(t))
+			l.set(tt.r)
+			defer l.set(&pb.SetRequestLoggingRequest{})
+			assert.Equal(t, tt.matched, l.match(tt.method, tt.req) != nil)
+		})
+	}
+}
+
+func TestRequestLoggerExpiry(t *testing.T) {
+	l := newRequestLogger(zaptest.NewLogger(t))
+	l.set(&pb.SetRequestLoggingRequest{Duration: 1})
+	assert.NotNil(t, l.match("/etcdserverpb.KV/Range", &pb.RangeRequest{}))
+	assert.Eventually(t, func() bool { return l.setting.Load() == nil }, 5*time.Second, 10*time.Millisecond)
+	assert.Nil(t, l.match("/etcdserverpb.KV/Range", &pb.RangeRequest{}))
+
+	// replacing a setting stops the expiry of the previous one
+	l.set(&pb.SetRequestLoggingRequest{Duration: 1})
+	l.set(&pb.SetRequestLoggingRequest{Duration: 60})
+	time.Sleep(1500 * time.Millisecond)
+	assert.NotNil(t, l.match("/etcdserverpb.KV/Range", &pb.RangeRequest{}))
+
+	l.set(&pb.SetRequestLoggingRequest{})
+	assert.Nil(t, l.match("/etcdserverpb.KV/Range", &pb.RangeRequest{}))
+}
+
+func TestRequestLoggerSampleRate(t *testing.T) {
+	l := newRequestLogger(zaptest.NewLogger(t))
+	l.set(&pb.SetRequestLoggingRequest{Duration: 60, SampleRate: 0.1})
+	defer l.set(&pb.SetRequestLoggingRequest{})
+	matched := 0
+	for i := 0; i < 10000; i++ {
+		if l.match("/etcdserverpb.KV/Range", &pb.RangeRequest{}) != nil {
+			matched++
+		}
+	}
+	assert.InDelta(t, 1000, matched, 300)
+}
+
+func TestRequestContent(t *testing.T) {
+	put := &pb.PutRequest{Key: []byte("k"), Value: []byte("secret")}
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestPut{RequestPut: put}}}}
+
+	assert.NotContains(t, requestContent(put, false), "secret")
+	assert.Contains(t, requestContent(put, true), "secret")
+	assert.NotContains(t, requestContent(txn, false), "secret")
+	assert.Contains(t, requestContent(txn, true), "secret")
+	assert.Empty(t, requestContent(&pb.AuthenticateRequest{Name: "root", Password: "secret"}, true))
+}
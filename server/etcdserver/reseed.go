@@ -0,0 +1,246 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	serverstorage "go.etcd.io/etcd/server/v3/storage"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+// PeerBackendPath serves a copy of the backend of the member to the members
+// being reseeded.
+const PeerBackendPath = "/members/backend"
+
+// reseedRequest hands a reseed over to the apply loop, which replaces the
+// backend between two applies.
+type reseedRequest struct {
+	ctx  context.Context
+	resp *pb.ReseedResponse
+	done chan error
+}
+
+// Reseed replaces the backend of the member with a copy of the backend of
+// the leader, then clears the CORRUPT alarm of the member.
+func (s *EtcdServer) Reseed(ctx context.Context, r *pb.ReseedRequest) (*pb.ReseedResponse, error) {
+	if s.isLeader() {
+		return nil, errors.ErrReseedLeader
+	}
+	req := &reseedRequest{ctx: ctx, resp: &pb.ReseedResponse{Header: &pb.ResponseHeader{}}, done: make(chan error, 1)}
+	select {
+	case s.reseedc <- req:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.stopping:
+		return nil, errors.ErrStopped
+	}
+	// the backend is being replaced, so wait for it even if the request is
+	// canceled; the fetch stops with the request context.
+	select {
+	case err := <-req.done:
+		if err != nil {
+			return nil, err
+		}
+	case <-s.stopping:
+		return nil, errors.ErrStopped
+	}
+
+	for _, m := range s.alarmStore.Get(pb.AlarmType_CORRUPT) {
+		if m.MemberID != uint64(s.MemberID()) {
+			continue
+		}
+		a := &pb.AlarmRequest{
+			MemberID: m.MemberID,
+			Action:   pb.AlarmRequest_DEACTIVATE,
+			Alarm:    pb.AlarmType_CORRUPT,
+		}
+		if _, err := s.raftRequest(ctx, pb.InternalRaftRequest{Alarm: a}); err != nil {
+			return nil, err
+		}
+		s.Logger().Info("cleared CORRUPT alarm after reseed")
+	}
+	return req.resp, nil
+}
+
+// applyReseed fetches the backend of the leader and replaces the backend of
+// the member with it. It runs on the apply loop, so the fetched backend is
+// at least as recent as the applied index; the entries applied to it already
+// are skipped by their consistent index when the member catches up.
+func (s *EtcdServer) applyReseed(ep *etcdProgress, r *reseedRequest) {
+	lg := s.Logger()
+	leader := s.cluster.Member(s.Leader())
+	if leader == nil {
+		r.done <- errors.ErrNoLeader
+		return
+	}
+	if leader.ID == s.MemberID() {
+		r.done <- errors.ErrReseedLeader
+		return
+	}
+
+	lg.Info(
+		"reseeding backend",
+		zap.String("leader-member-id", leader.ID.String()),
+		zap.Uint64("current-applied-index", ep.appliedi),
+	)
+	start := time.Now()
+	path, err := s.fetchBackend(r.ctx, leader.PeerURLs, ep.appliedi)
+	if err != nil {
+		lg.Warn("failed to fetch backend from leader", zap.String("leader-member-id", leader.ID.String()), zap.Error(err))
+		r.done <- err
+		return
+	}
+	newbe, err := serverstorage.OpenDBFileBackend(s.Cfg, path, s.beHooks)
+	if err != nil {
+		lg.Panic("failed to open reseeded backend", zap.Error(err))
+	}
+
+	// see applySnapshot for why the consistent index is set first.
+	s.consistIndex.SetBackend(newbe)
+	if ci := s.consistIndex.ConsistentIndex(); ci < ep.appliedi {
+		lg.Panic(
+			"reseeded backend is older than the applied index",
+			zap.Uint64("consistent-index", ci),
+			zap.Uint64("current-applied-index", ep.appliedi),
+		)
+	}
+	s.recoverBackend(newbe)
+	s.cluster.SetBackend(schema.NewMembershipBackend(lg, newbe))
+	s.uberApply = s.NewUberApplier()
+
+	r.resp.Leader = uint64(leader.ID)
+	r.resp.ConsistentIndex = s.consistIndex.ConsistentIndex()
+	r.resp.DbSize = newbe.Size()
+	lg.Info(
+		"reseeded backend",
+		zap.String("leader-member-id", leader.ID.String()),
+		zap.Uint64("consistent-index", r.resp.ConsistentIndex),
+		zap.Int64("size", r.resp.DbSize),
+		zap.Duration("took", time.Since(start)),
+	)
+	r.done <- nil
+}
+
+// fetchBackend saves a copy of the backend of the leader, applied up to at
+// least the given index, to the snapshot directory and returns its path.
+func (s *EtcdServer) fetchBackend(ctx context.Context, urls []string, minIndex uint64) (string, error) {
+	cc := &http.Client{Transport: s.peerRt}
+	var lastErr error
+	for _, u := range urls {
+		path, err := s.fetchBackendFrom(ctx, cc, u+PeerBackendPath+"?min-index="+strconv.FormatUint(minIndex, 10))
+		if err == nil {
+			return path, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return "", lastErr
+}
+
+func (s *EtcdServer) fetchBackendFrom(ctx context.Context, cc *http.Client, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Etcd-Cluster-ID", s.cluster.ID().String())
+	resp, err := cc.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to fetch backend from %s: %s", url, b)
+	}
+
+	// orphaned by a crash, the file is removed by the snapshotter on restart.
+	f, err := os.CreateTemp(s.Cfg.SnapDir(), "db.tmp.reseed")
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(f, resp.Body)
+	if err == nil {
+		err = fileutil.Fsync(f)
+	}
+	f.Close()
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+type backendHandler struct {
+	lg     *zap.Logger
+	server *EtcdServer
+}
+
+func (s *EtcdServer) BackendHandler() http.Handler {
+	return &backendHandler{lg: s.Logger(), server: s}
+}
+
+func (h *backendHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.URL.Path != PeerBackendPath {
+		http.Error(w, "bad path", http.StatusBadRequest)
+		return
+	}
+	if gcid := r.Header.Get("X-Etcd-Cluster-ID"); gcid != "" && gcid != h.server.cluster.ID().String() {
+		http.Error(w, rafthttp.ErrClusterIDMismatch.Error(), http.StatusPreconditionFailed)
+		return
+	}
+	minIndex, err := strconv.ParseUint(r.URL.Query().Get("min-index"), 10, 64)
+	if err != nil {
+		http.Error(w, "bad min-index", http.StatusBadRequest)
+		return
+	}
+
+	select {
+	case <-h.server.applyWait.Wait(minIndex):
+	case <-r.Context().Done():
+		return
+	case <-h.server.stopping:
+		http.Error(w, errors.ErrStopped.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	snapshot := h.server.Backend().Snapshot()
+	defer snapshot.Close()
+	w.Header().Set("X-Etcd-Cluster-ID", h.server.cluster.ID().String())
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.FormatInt(snapshot.Size(), 10))
+	if _, err = snapshot.WriteTo(w); err != nil {
+		h.lg.Warn("failed to send backend", zap.String("remote-addr", r.RemoteAddr), zap.Error(err))
+	}
+}
@@ -51,6 +51,7 @@ import (
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/etcdserver/api"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
 	httptypes "go.etcd.io/etcd/server/v3/etcdserver/api/etcdhttp/types"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
@@ -59,6 +60,9 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v2store"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3alarm"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3counter"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3fence"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3job"
 	"go.etcd.io/etcd/server/v3/etcdserver/apply"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
@@ -173,6 +177,11 @@ type Server interface {
 	// return ErrLearnerNotReady if the member are not ready.
 	// return ErrMemberNotLearner if the member is not a learner.
 	PromoteMember(ctx context.Context, id uint64) ([]*membership.Member, error)
+	// PromoteMemberReplacing attempts to promote a non-voting node to a voting node
+	// in place of the voting member replaceID, in a single configuration change.
+	// It returns the errors of PromoteMember, and ErrMemberNotVoter if the
+	// replaced member is not a voting member.
+	PromoteMemberReplacing(ctx context.Context, id, replaceID uint64) ([]*membership.Member, error)
 
 	// ClusterVersion is the cluster-wide minimum major.minor version.
 	// Cluster version is set to the min version that an etcd member is
@@ -233,6 +242,10 @@ type EtcdServer struct {
 	// when there is no error
 	readNotifier *notifier
 
+	// staleReads gates serializable reads until the member caught up with
+	// the leader, if Cfg.GateStaleReads is set.
+	staleReads staleReadGate
+
 	// stop signals the run goroutine should shutdown.
 	stop chan struct{}
 	// stopping is closed by run goroutine on shutdown.
@@ -263,11 +276,72 @@ type EtcdServer struct {
 	authStore  auth.AuthStore
 	alarmStore *v3alarm.AlarmStore
 
+	// readCache caches serializable reads of single keys, nil if disabled.
+	readCache *readCache
+
+	// hotKeys tracks the hottest keys of the served requests, nil if
+	// disabled.
+	hotKeys *hotKeyTracker
+
+	// requestLogger logs a sample of the served requests while enabled by
+	// SetRequestLogging.
+	requestLogger *requestLogger
+
+	// newerFields records the request fields newer than the cluster version,
+	// nil if disabled.
+	newerFields *newerFieldTracker
+	// downgradeBlocked records the fields newer than the downgrade target
+	// version of the rejected requests.
+	downgradeBlocked *newerFieldTracker
+
+	// bootHash is the keyspace hash stored by the last clean shutdown, nil
+	// once consumed by the initial corruption check.
+	bootHash *schema.BootHash
+
+	// grpcBufferBytes is the size of the gRPC requests being served.
+	grpcBufferBytes atomic.Int64
+
+	// walDiskLow is set while the free disk space of the WAL is under
+	// Cfg.WALDiskFreeThreshold.
+	walDiskLow atomic.Bool
+
+	// checkpointMu serializes the backend checkpoints.
+	checkpointMu sync.Mutex
+	// bucketStats holds the last tracked stats of the backend buckets.
+	bucketStats atomic.Pointer[trackedBucketStats]
+
+	// drainMu protects drainc and drainSet.
+	drainMu sync.Mutex
+	// drainc is closed while the local member is draining.
+	drainc chan struct{}
+	// drainSet is true once the draining mark was updated by this process.
+	drainSet bool
+	// watchStreams is the number of open watch streams of the member.
+	watchStreams atomic.Int64
+
+	// reseedc hands the reseed requests over to the apply loop.
+	reseedc chan *reseedRequest
+
+	// events publishes the lifecycle events of the server.
+	events *EventBus
+
 	stats  *stats.ServerStats
 	lstats *stats.LeaderStats
 
 	// compactor is used to auto-compact the KV.
 	compactor v3compactor.Compactor
+	// compactionHolds are the holds registered by clients to delay
+	// auto-compaction.
+	compactionHolds *v3compactor.HoldStore
+	// counters are the counters of the Counter service.
+	counters *v3counter.Store
+	// fences are the fences of prefixes set by FencePrefix.
+	fences *v3fence.Store
+	// clusterConfig is the cluster-wide configuration set through the
+	// Cluster API.
+	clusterConfig *clusterconfig.Store
+	// jobs runs the long-running maintenance tasks of the member.
+	jobs *v3job.Manager
 
 	// peerRt used to send requests (version, lease) to peers.
 	peerRt   http.RoundTripper
@@ -338,6 +412,7 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		consistIndex:          b.storage.backend.ci,
 		firstCommitInTerm:     notify.NewNotifier(),
 		clusterVersionChanged: notify.NewNotifier(),
+		events:                NewEventBus(),
 	}
 
 	addFeatureGateMetrics(cfg.ServerFeatureGate, serverFeatureEnabled)
@@ -355,6 +430,7 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		CheckpointInterval:         cfg.LeaseCheckpointInterval,
 		CheckpointPersist:          cfg.ServerFeatureGate.Enabled(features.LeaseCheckpointPersist),
 		ExpiredLeasesRetryInterval: srv.Cfg.ReqTimeout(),
+		RevokeRate:                 cfg.LeaseRevokeRate,
 	})
 
 	tp, err := auth.NewTokenProvider(cfg.Logger, cfg.AuthToken,
@@ -371,11 +447,33 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 	mvccStoreConfig := mvcc.StoreConfig{
 		CompactionBatchLimit:    cfg.CompactionBatchLimit,
 		CompactionSleepInterval: cfg.CompactionSleepInterval,
+		IndexCheckpointInterval: cfg.IndexCheckpointInterval,
+	}
+	if cfg.ServerFeatureGate.Enabled(features.ValueChunking) {
+		mvccStoreConfig.ValueChunkSize = cfg.ValueChunkSize
 	}
+	if cfg.SerializableReadCacheBytes > 0 {
+		srv.readCache = newReadCache(cfg.SerializableReadCacheBytes, cfg.SerializableReadCacheTTL)
+		mvccStoreConfig.ChangeObserver = srv.readCache
+	}
+	if cfg.HotKeysTopK > 0 {
+		srv.hotKeys = newHotKeyTracker(cfg.HotKeysTopK, cfg.HotKeysSampleRate)
+	}
+	if cfg.NewerRequestFields == config.NewerRequestFieldsLog || cfg.NewerRequestFields == config.NewerRequestFieldsReject {
+		srv.newerFields = newNewerFieldTracker(cfg.NewerRequestFields == config.NewerRequestFieldsReject)
+	}
+	srv.downgradeBlocked = newNewerFieldTracker(true)
+	srv.requestLogger = newRequestLogger(cfg.Logger)
 	srv.kv = mvcc.New(srv.Logger(), srv.be, srv.lessor, mvccStoreConfig)
+	srv.takeBootHash()
 	srv.corruptionChecker = newCorruptionChecker(cfg.Logger, srv, srv.kv.HashStorage())
 
 	srv.authStore = auth.NewAuthStore(srv.Logger(), schema.NewAuthBackend(srv.Logger(), srv.be), tp, int(cfg.BcryptCost))
+	srv.authStore.SetPasswordPolicy(auth.PasswordPolicy{
+		MinLength:      cfg.PasswordMinLength,
+		MinCharClasses: cfg.PasswordMinCharClasses,
+		MaxAge:         cfg.PasswordMaxAge,
+	})
 
 	newSrv := srv // since srv == nil in defer if srv is returned as nil
 	defer func() {
@@ -385,8 +483,17 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 			newSrv.kv.Close()
 		}
 	}()
+	srv.compactionHolds = v3compactor.NewHoldStore(srv.be)
+	srv.counters = v3counter.NewStore(srv.be)
+	srv.fences = v3fence.NewStore(srv.be)
+	srv.clusterConfig = clusterconfig.NewStore(srv.be)
+	srv.jobs = v3job.NewManager(cfg.Logger, srv.be)
 	if num := cfg.AutoCompactionRetention; num != 0 {
-		srv.compactor, err = v3compactor.New(cfg.Logger, cfg.AutoCompactionMode, num, srv.kv, srv)
+		compactable := v3compactor.NewHoldingCompactable(cfg.Logger, srv, srv.compactionHolds)
+		if cfg.AutoCompactionCoordinated {
+			compactable = v3compactor.NewCoordinatedCompactable(cfg.Logger, compactable, srv, cfg.AutoCompactionCoordinationTimeout)
+		}
+		srv.compactor, err = v3compactor.New(cfg.Logger, cfg.AutoCompactionMode, num, srv.kv, compactable)
 		if err != nil {
 			return nil, err
 		}
@@ -547,6 +654,15 @@ func (s *EtcdServer) Start() {
 	s.GoAttach(s.monitorKVHash)
 	s.GoAttach(s.monitorCompactHash)
 	s.GoAttach(s.monitorDowngrade)
+	s.GoAttach(s.monitorMemory)
+	s.GoAttach(s.monitorWALDisk)
+	s.GoAttach(s.monitorAlarmThresholds)
+	s.GoAttach(s.monitorFences)
+	s.GoAttach(s.monitorCheckpoints)
+	s.GoAttach(s.monitorBucketStats)
+	s.GoAttach(s.monitorLeadership)
+	s.GoAttach(s.clearStaleDrain)
+	s.GoAttach(s.monitorStaleReads)
 }
 
 // start prepares and starts server in a new goroutine. It is no longer safe to
@@ -579,6 +695,7 @@ func (s *EtcdServer) start() {
 	s.stopping = make(chan struct{}, 1)
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 	s.readwaitc = make(chan struct{}, 1)
+	s.reseedc = make(chan *reseedRequest)
 	s.readNotifier = newNotifier()
 	s.leaderChanged = notify.NewNotifier()
 	if s.ClusterVersion() != nil {
@@ -660,6 +777,7 @@ type ServerPeerV2 interface {
 	ServerPeer
 	HashKVHandler() http.Handler
 	DowngradeEnabledHandler() http.Handler
+	BackendHandler() http.Handler
 }
 
 func (s *EtcdServer) DowngradeInfo() *serverversion.DowngradeInfo { return s.cluster.DowngradeInfo() }
@@ -771,10 +889,20 @@ func (s *EtcdServer) run() {
 	// asynchronously accept toApply packets, dispatch progress in-order
 	sched := schedule.NewFIFOScheduler(lg)
 
+	// wasLeader is only accessed by the raft goroutine calling updateLeadership.
+	wasLeader := false
 	rh := &raftReadyHandler{
 		getLead:    func() (lead uint64) { return s.getLead() },
 		updateLead: func(lead uint64) { s.setLead(lead) },
 		updateLeadership: func(newLeader bool) {
+			if isLeader := s.isLeader(); isLeader != wasLeader {
+				wasLeader = isLeader
+				typ := EventLostLeadership
+				if isLeader {
+					typ = EventBecameLeader
+				}
+				s.events.Publish(Event{Type: typ, Term: s.Term()})
+			}
 			if !s.isLeader() {
 				if s.lessor != nil {
 					s.lessor.Demote()
@@ -819,6 +947,7 @@ func (s *EtcdServer) run() {
 		appliedi:            sn.Metadata.Index,
 	}
 
+	clean := false
 	defer func() {
 		s.wgMu.Lock() // block concurrent waitgroup adds in GoAttach while stopping
 		close(s.stopping)
@@ -833,7 +962,11 @@ func (s *EtcdServer) run() {
 		// by adding a peer after raft stops the transport
 		s.r.stop()
 
+		if clean {
+			s.saveBootHash()
+		}
 		s.Cleanup()
+		s.events.Close()
 
 		close(s.done)
 	}()
@@ -848,6 +981,9 @@ func (s *EtcdServer) run() {
 		case ap := <-s.r.apply():
 			f := schedule.NewJob("server_applyAll", func(context.Context) { s.applyAll(&ep, &ap) })
 			sched.Schedule(f)
+		case r := <-s.reseedc:
+			f := schedule.NewJob("server_reseed", func(context.Context) { s.applyReseed(&ep, r) })
+			sched.Schedule(f)
 		case leases := <-expiredLeaseC:
 			s.revokeExpiredLeases(leases)
 		case err := <-s.errorc:
@@ -855,6 +991,7 @@ func (s *EtcdServer) run() {
 			lg.Warn("data-dir used by this member must be removed")
 			return
 		case <-s.stop:
+			clean = true
 			return
 		}
 	}
@@ -887,7 +1024,7 @@ func (s *EtcdServer) revokeExpiredLeases(leases []*lease.Lease) {
 			f := func(lid int64) {
 				s.GoAttach(func() {
 					ctx := s.authStore.WithRoot(s.ctx)
-					_, lerr := s.LeaseRevoke(ctx, &pb.LeaseRevokeRequest{ID: lid})
+					lerr := s.leaseExpire(ctx, lid)
 					if lerr == nil {
 						leaseExpired.Inc()
 					} else {
@@ -960,6 +1097,10 @@ func (s *EtcdServer) Cleanup() {
 	if s.authStore != nil {
 		s.authStore.Close()
 	}
+	// the jobs record their end in the backend
+	if s.jobs != nil {
+		s.jobs.Stop()
+	}
 	if s.be != nil {
 		s.be.Close()
 	}
@@ -1043,6 +1184,59 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 	s.consistIndex.SetBackend(newbe)
 	verifySnapshotIndex(toApply.snapshot, s.consistIndex.ConsistentIndex())
 
+	s.recoverBackend(newbe)
+
+	lg.Info("restoring v2 store")
+	if err := s.v2store.Recovery(toApply.snapshot.Data); err != nil {
+		lg.Panic("failed to restore v2 store", zap.Error(err))
+	}
+
+	if err := serverstorage.AssertNoV2StoreContent(lg, s.v2store, s.Cfg.V2Deprecation); err != nil {
+		lg.Panic("illegal v2store content", zap.Error(err))
+	}
+
+	lg.Info("restored v2 store")
+
+	s.cluster.SetBackend(schema.NewMembershipBackend(lg, newbe))
+
+	lg.Info("restoring cluster configuration")
+
+	s.cluster.Recover(api.UpdateCapability)
+
+	lg.Info("restored cluster configuration")
+	lg.Info("removing old peers from network")
+
+	// recover raft transport
+	s.r.transport.RemoveAllPeers()
+
+	lg.Info("removed old peers from network")
+	lg.Info("adding peers from new cluster configuration")
+
+	for _, m := range s.cluster.Members() {
+		if m.ID == s.MemberID() {
+			continue
+		}
+		s.r.transport.AddPeer(m.ID, m.PeerURLs)
+	}
+
+	lg.Info("added peers from new cluster configuration")
+
+	ep.appliedt = toApply.snapshot.Metadata.Term
+	ep.appliedi = toApply.snapshot.Metadata.Index
+	ep.diskSnapshotIndex = ep.appliedi
+	ep.memorySnapshotIndex = ep.appliedi
+	ep.confState = toApply.snapshot.Metadata.ConfState
+
+	// As backends and implementations like alarmsStore changed, we need
+	// to re-bootstrap Appliers.
+	s.uberApply = s.NewUberApplier()
+}
+
+// recoverBackend replaces the backend of the server and recovers the stores
+// kept in it. The consistent index must already be set to the new backend.
+func (s *EtcdServer) recoverBackend(newbe backend.Backend) {
+	lg := s.Logger()
+
 	// always recover lessor before kv. When we recover the mvcc.KV it will reattach keys to its leases.
 	// If we recover mvcc.KV first, it will attach the keys to the wrong lessor before it recovers.
 	if s.lessor != nil {
@@ -1089,58 +1283,33 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 
 	lg.Info("restored alarm store")
 
-	if s.authStore != nil {
-		lg.Info("restoring auth store")
-
-		s.authStore.Recover(schema.NewAuthBackend(lg, newbe))
-
-		lg.Info("restored auth store")
+	if s.compactionHolds != nil {
+		s.compactionHolds.Recover(newbe)
 	}
 
-	lg.Info("restoring v2 store")
-	if err := s.v2store.Recovery(toApply.snapshot.Data); err != nil {
-		lg.Panic("failed to restore v2 store", zap.Error(err))
+	if s.counters != nil {
+		s.counters.Recover(newbe)
 	}
 
-	if err := serverstorage.AssertNoV2StoreContent(lg, s.v2store, s.Cfg.V2Deprecation); err != nil {
-		lg.Panic("illegal v2store content", zap.Error(err))
+	if s.fences != nil {
+		s.fences.Recover(newbe)
 	}
 
-	lg.Info("restored v2 store")
-
-	s.cluster.SetBackend(schema.NewMembershipBackend(lg, newbe))
-
-	lg.Info("restoring cluster configuration")
-
-	s.cluster.Recover(api.UpdateCapability)
-
-	lg.Info("restored cluster configuration")
-	lg.Info("removing old peers from network")
-
-	// recover raft transport
-	s.r.transport.RemoveAllPeers()
-
-	lg.Info("removed old peers from network")
-	lg.Info("adding peers from new cluster configuration")
+	if s.clusterConfig != nil {
+		s.clusterConfig.Recover(newbe)
+	}
 
-	for _, m := range s.cluster.Members() {
-		if m.ID == s.MemberID() {
-			continue
-		}
-		s.r.transport.AddPeer(m.ID, m.PeerURLs)
+	if s.jobs != nil {
+		s.jobs.Recover(newbe)
 	}
 
-	lg.Info("added peers from new cluster configuration")
+	if s.authStore != nil {
+		lg.Info("restoring auth store")
 
-	ep.appliedt = toApply.snapshot.Metadata.Term
-	ep.appliedi = toApply.snapshot.Metadata.Index
-	ep.diskSnapshotIndex = ep.appliedi
-	ep.memorySnapshotIndex = ep.appliedi
-	ep.confState = toApply.snapshot.Metadata.ConfState
+		s.authStore.Recover(schema.NewAuthBackend(lg, newbe))
 
-	// As backends and implementations like alarmsStore changed, we need
-	// to re-bootstrap Appliers.
-	s.uberApply = s.NewUberApplier()
+		lg.Info("restored auth store")
+	}
 }
 
 func (s *EtcdServer) NewUberApplier() apply.UberApplier {
@@ -1148,6 +1317,10 @@ func (s *EtcdServer) NewUberApplier() apply.UberApplier {
 		Logger:                       s.lg,
 		KV:                           s.KV(),
 		AlarmStore:                   s.alarmStore,
+		CompactionHolds:              s.compactionHolds,
+		Counters:                     s.counters,
+		Fences:                       s.fences,
+		ClusterConfig:                s.clusterConfig,
 		AuthStore:                    s.authStore,
 		Lessor:                       s.lessor,
 		Cluster:                      s.cluster,
@@ -1235,14 +1408,42 @@ func (s *EtcdServer) hasMultipleVotingMembers() bool {
 	return s.cluster != nil && len(s.cluster.VotingMemberIDs()) > 1
 }
 
+// preferredTransferees returns the voting members that share the labels
+// configured in LeaderTransferPreferLabels with the local member.
+func (s *EtcdServer) preferredTransferees() []types.ID {
+	if len(s.Cfg.LeaderTransferPreferLabels) == 0 {
+		return nil
+	}
+	return sameLabelMembers(s.cluster.Member(s.MemberID()), leadershipAllowedMembers(s.cluster.VotingMembers()), s.Cfg.LeaderTransferPreferLabels)
+}
+
 func (s *EtcdServer) isLeader() bool {
 	return uint64(s.MemberID()) == s.Lead()
 }
 
+// MaxReplicationLag returns the largest number of raft entries an active
+// voting member is behind the local member, or 0 if the local member is not
+// the leader. Members the leader has not heard from recently are ignored.
+func (s *EtcdServer) MaxReplicationLag() uint64 {
+	st := s.r.Status()
+	if st.RaftState != raft.StateLeader {
+		return 0
+	}
+	last := st.Progress[st.ID].Match
+	var lag uint64
+	for id, pr := range st.Progress {
+		if id == st.ID || pr.IsLearner || !pr.RecentActive || pr.Match >= last {
+			continue
+		}
+		lag = max(lag, last-pr.Match)
+	}
+	return lag
+}
+
 // MoveLeader transfers the leader to the given transferee.
 func (s *EtcdServer) MoveLeader(ctx context.Context, lead, transferee uint64) error {
 	member := s.cluster.Member(types.ID(transferee))
-	if member == nil || member.IsLearner {
+	if member == nil || member.IsLearner || member.LeadershipDisallowed || member.Draining {
 		return errors.ErrBadLeaderTransferee
 	}
 
@@ -1298,7 +1499,13 @@ func (s *EtcdServer) TryTransferLeadershipOnShutdown() error {
 		return nil
 	}
 
-	transferee, ok := longestConnected(s.r.transport, s.cluster.VotingMemberIDs())
+	transferee, ok := longestConnected(s.r.transport, s.preferredTransferees())
+	if !ok {
+		transferee, ok = longestConnected(s.r.transport, s.leadershipTransferees())
+	}
+	if !ok {
+		transferee, ok = longestConnected(s.r.transport, s.cluster.VotingMemberIDs())
+	}
 	if !ok {
 		return errors.ErrUnhealthy
 	}
@@ -1357,7 +1564,7 @@ func (s *EtcdServer) StopNotify() <-chan struct{} { return s.done }
 // when the server is being stopped.
 func (s *EtcdServer) StoppingNotify() <-chan struct{} { return s.stopping }
 
-func (s *EtcdServer) checkMembershipOperationPermission(ctx context.Context) error {
+func (s *EtcdServer) checkMembershipOperationPermission(ctx context.Context, method string) error {
 	if s.authStore == nil {
 		// In the context of ordinary etcd process, s.authStore will never be nil.
 		// This branch is for handling cases in server_test.go
@@ -1368,18 +1575,19 @@ func (s *EtcdServer) checkMembershipOperationPermission(ctx context.Context) err
 	// so TOCTOU problem can be caused potentially in a schedule like this:
 	// update membership with user A -> revoke root role of A -> toApply membership change
 	// in the state machine layer
-	// However, both of membership change and role management requires the root privilege.
+	// However, membership change requires the root privilege or a granted RPC permission,
+	// and role management requires the root privilege.
 	// So careful operation by admins can prevent the problem.
 	authInfo, err := s.AuthInfoFromCtx(ctx)
 	if err != nil {
 		return err
 	}
 
-	return s.AuthStore().IsAdminPermitted(authInfo)
+	return s.AuthStore().IsRPCPermitted(authInfo, method)
 }
 
 func (s *EtcdServer) AddMember(ctx context.Context, memb membership.Member) ([]*membership.Member, error) {
-	if err := s.checkMembershipOperationPermission(ctx); err != nil {
+	if err := s.checkMembershipOperationPermission(ctx, auth.RPCClusterMemberAdd); err != nil {
 		return nil, err
 	}
 
@@ -1438,7 +1646,7 @@ func (s *EtcdServer) mayAddMember(memb membership.Member) error {
 }
 
 func (s *EtcdServer) RemoveMember(ctx context.Context, id uint64) ([]*membership.Member, error) {
-	if err := s.checkMembershipOperationPermission(ctx); err != nil {
+	if err := s.checkMembershipOperationPermission(ctx, auth.RPCClusterMemberRemove); err != nil {
 		return nil, err
 	}
 
@@ -1456,10 +1664,27 @@ func (s *EtcdServer) RemoveMember(ctx context.Context, id uint64) ([]*membership
 
 // PromoteMember promotes a learner node to a voting node.
 func (s *EtcdServer) PromoteMember(ctx context.Context, id uint64) ([]*membership.Member, error) {
+	return s.promoteMemberOnLeader(ctx, id, 0)
+}
+
+// PromoteMemberReplacing promotes a learner (usually a warm standby) node to a voting node in
+// place of the voting member replaceID. The promotion and the removal of replaceID are a single
+// configuration change, applied through joint consensus, so that the number of voting members
+// does not change and the cluster never holds both members or neither of them.
+func (s *EtcdServer) PromoteMemberReplacing(ctx context.Context, id, replaceID uint64) ([]*membership.Member, error) {
+	if id == replaceID {
+		return nil, membership.ErrMemberNotVoter
+	}
+	return s.promoteMemberOnLeader(ctx, id, replaceID)
+}
+
+// promoteMemberOnLeader promotes the learner id, in place of the voting member replaceID if
+// not zero, forwarding the request to the leader if the local node is not the leader.
+func (s *EtcdServer) promoteMemberOnLeader(ctx context.Context, id, replaceID uint64) ([]*membership.Member, error) {
 	// only raft leader has information on whether the to-be-promoted learner node is ready. If promoteMember call
 	// fails with ErrNotLeader, forward the request to leader node via HTTP. If promoteMember call fails with error
 	// other than ErrNotLeader, return the error.
-	resp, err := s.promoteMember(ctx, id)
+	resp, err := s.promoteMember(ctx, id, replaceID)
 	if err == nil {
 		learnerPromoteSucceed.Inc()
 		return resp, nil
@@ -1478,12 +1703,12 @@ func (s *EtcdServer) PromoteMember(ctx context.Context, id uint64) ([]*membershi
 			return nil, err
 		}
 		for _, url := range leader.PeerURLs {
-			resp, err := promoteMemberHTTP(cctx, url, id, s.peerRt)
+			resp, err := promoteMemberHTTP(cctx, url, id, replaceID, s.peerRt)
 			if err == nil {
 				return resp, nil
 			}
 			// If member promotion failed, return early. Otherwise keep retry.
-			if errorspkg.Is(err, errors.ErrLearnerNotReady) || errorspkg.Is(err, membership.ErrIDNotFound) || errorspkg.Is(err, membership.ErrMemberNotLearner) {
+			if errorspkg.Is(err, errors.ErrLearnerNotReady) || errorspkg.Is(err, membership.ErrIDNotFound) || errorspkg.Is(err, membership.ErrMemberNotLearner) || errorspkg.Is(err, membership.ErrMemberNotVoter) {
 				return nil, err
 			}
 		}
@@ -1496,15 +1721,27 @@ func (s *EtcdServer) PromoteMember(ctx context.Context, id uint64) ([]*membershi
 }
 
 // promoteMember checks whether the to-be-promoted learner node is ready before sending the promote
-// request to raft.
+// request to raft, along with the removal of the voting member replaceID if not zero.
 // The function returns ErrNotLeader if the local node is not raft leader (therefore does not have
 // enough information to determine if the learner node is ready), returns ErrLearnerNotReady if the
 // local node is leader (therefore has enough information) but decided the learner node is not ready
 // to be promoted.
-func (s *EtcdServer) promoteMember(ctx context.Context, id uint64) ([]*membership.Member, error) {
-	if err := s.checkMembershipOperationPermission(ctx); err != nil {
+func (s *EtcdServer) promoteMember(ctx context.Context, id, replaceID uint64) ([]*membership.Member, error) {
+	if err := s.checkMembershipOperationPermission(ctx, auth.RPCClusterMemberPromote); err != nil {
 		return nil, err
 	}
+	if replaceID != 0 {
+		if err := s.checkMembershipOperationPermission(ctx, auth.RPCClusterMemberRemove); err != nil {
+			return nil, err
+		}
+		replaced := s.cluster.Member(types.ID(replaceID))
+		if replaced == nil {
+			return nil, membership.ErrIDNotFound
+		}
+		if replaced.IsLearner {
+			return nil, membership.ErrMemberNotVoter
+		}
+	}
 
 	// check if we can promote this learner.
 	if err := s.mayPromoteMember(types.ID(id)); err != nil {
@@ -1519,6 +1756,14 @@ func (s *EtcdServer) promoteMember(ctx context.Context, id uint64) ([]*membershi
 		IsPromote: true,
 	}
 
+	if replaceID != 0 {
+		// by default StrictReconfigCheck is enabled; reject removal if leads to quorum loss
+		if err := s.mayRemoveMember(types.ID(replaceID)); err != nil {
+			return nil, err
+		}
+		return s.configureReplace(ctx, promoteChangeContext, replaceID)
+	}
+
 	b, err := json.Marshal(promoteChangeContext)
 	if err != nil {
 		return nil, err
@@ -1655,7 +1900,7 @@ func (s *EtcdServer) UpdateMember(ctx context.Context, memb membership.Member) (
 		return nil, merr
 	}
 
-	if err := s.checkMembershipOperationPermission(ctx); err != nil {
+	if err := s.checkMembershipOperationPermission(ctx, auth.RPCClusterMemberUpdate); err != nil {
 		return nil, err
 	}
 	cc := raftpb.ConfChange{
@@ -1702,6 +1947,9 @@ func (s *EtcdServer) LeaderChangedNotify() <-chan struct{} {
 	return s.leaderChanged.Receive()
 }
 
+// Events returns the bus publishing the lifecycle events of the server.
+func (s *EtcdServer) Events() *EventBus { return s.events }
+
 // FirstCommitInTermNotify returns channel that will be unlocked on first
 // entry committed in new term, which is necessary for new leader to answer
 // read-only requests (leader is not able to respond any read-only requests
@@ -1740,13 +1988,53 @@ type confChangeResponse struct {
 // then waits for it to be applied to the server. It
 // will block until the change is performed or there is an error.
 func (s *EtcdServer) configure(ctx context.Context, cc raftpb.ConfChange) ([]*membership.Member, error) {
-	lg := s.Logger()
 	cc.ID = s.reqIDGen.Next()
-	ch := s.w.Register(cc.ID)
+	return s.proposeConfChange(ctx, cc.ID, cc,
+		zap.String("raft-conf-change", cc.Type.String()),
+		zap.String("raft-conf-change-node-id", types.ID(cc.NodeID).String()),
+	)
+}
+
+// replaceChangeContext is the context of the ConfChangeV2 promoting a learner
+// in place of a voting member.
+type replaceChangeContext struct {
+	// ID identifies the request waiting for the change to be applied.
+	ID uint64 `json:"id"`
+	// Promote is the context of the promotion of the learner.
+	Promote membership.ConfigChangeContext `json:"promote"`
+}
+
+// configureReplace promotes a learner in place of the voting member replaceID
+// with a single ConfChangeV2, which raft applies through joint consensus and
+// then leaves automatically.
+func (s *EtcdServer) configureReplace(ctx context.Context, promote membership.ConfigChangeContext, replaceID uint64) ([]*membership.Member, error) {
+	rc := replaceChangeContext{ID: s.reqIDGen.Next(), Promote: promote}
+	b, err := json.Marshal(rc)
+	if err != nil {
+		return nil, err
+	}
+	cc := raftpb.ConfChangeV2{
+		Changes: []raftpb.ConfChangeSingle{
+			{Type: raftpb.ConfChangeAddNode, NodeID: uint64(promote.Member.ID)},
+			{Type: raftpb.ConfChangeRemoveNode, NodeID: replaceID},
+		},
+		Context: b,
+	}
+	return s.proposeConfChange(ctx, rc.ID, cc,
+		zap.String("promoted-member-id", promote.Member.ID.String()),
+		zap.String("replaced-member-id", types.ID(replaceID).String()),
+	)
+}
+
+// proposeConfChange proposes cc and waits for it to be applied under the
+// request ID id.
+func (s *EtcdServer) proposeConfChange(ctx context.Context, id uint64, cc raftpb.ConfChangeI, fields ...zap.Field) ([]*membership.Member, error) {
+	lg := s.Logger()
+	ch := s.w.Register(id)
 
 	start := time.Now()
 	if err := s.r.ProposeConfChange(ctx, cc); err != nil {
-		s.w.Trigger(cc.ID, nil)
+		s.w.Trigger(id, nil)
 		return nil, err
 	}
 
@@ -1763,14 +2051,12 @@ func (s *EtcdServer) configure(ctx context.Context, cc raftpb.ConfChange) ([]*me
 		<-resp.raftAdvanceC
 		lg.Info(
 			"applied a configuration change through raft",
-			zap.String("local-member-id", s.MemberID().String()),
-			zap.String("raft-conf-change", cc.Type.String()),
-			zap.String("raft-conf-change-node-id", types.ID(cc.NodeID).String()),
+			append([]zap.Field{zap.String("local-member-id", s.MemberID().String())}, fields...)...,
 		)
 		return resp.membs, resp.err
 
 	case <-ctx.Done():
-		s.w.Trigger(cc.ID, nil) // GC wait
+		s.w.Trigger(id, nil) // GC wait
 		return nil, s.parseProposeCtxErr(ctx.Err(), start)
 
 	case <-s.stopping:
@@ -1917,10 +2203,21 @@ func (s *EtcdServer) apply(
 			shouldStop = shouldStop || removedSelf
 			s.w.Trigger(cc.ID, &confChangeResponse{s.cluster.Members(), raftAdvancedC, err})
 
+		case raftpb.EntryConfChangeV2:
+			var cc raftpb.ConfChangeV2
+			pbutil.MustUnmarshal(&cc, e.Data)
+			id, removedSelf, err := s.applyConfChangeV2(cc, confState, shouldApplyV3)
+			s.setAppliedIndex(e.Index)
+			s.setTerm(e.Term)
+			shouldStop = shouldStop || removedSelf
+			if id != 0 {
+				s.w.Trigger(id, &confChangeResponse{s.cluster.Members(), raftAdvancedC, err})
+			}
+
 		default:
 			lg := s.Logger()
 			lg.Panic(
-				"unknown entry type; must be either EntryNormal or EntryConfChange",
+				"unknown entry type; must be either EntryNormal, EntryConfChange or EntryConfChangeV2",
 				zap.String("type", e.Type.String()),
 			)
 		}
@@ -1984,7 +2281,11 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry, shouldApplyV3 membership.
 		if !needResult && raftReq.Txn != nil {
 			removeNeedlessRangeReqs(raftReq.Txn)
 		}
+		start := time.Now()
 		ar = s.uberApply.Apply(&raftReq, shouldApplyV3)
+		if ar != nil {
+			ar.Took = time.Since(start)
+		}
 	}
 
 	// do not re-toApply applied entries.
@@ -1995,6 +2296,7 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry, shouldApplyV3 membership.
 	if ar == nil {
 		return
 	}
+	s.publishApplyEvents(&raftReq, ar)
 
 	if !errorspkg.Is(ar.Err, errors.ErrNoSpace) || len(s.alarmStore.Get(pb.AlarmType_NOSPACE)) > 0 {
 		s.w.Trigger(id, ar)
@@ -2050,18 +2352,74 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 		lg.Error("Validation on configuration change failed", zap.Bool("shouldApplyV3", bool(shouldApplyV3)), zap.Error(err))
 		cc.NodeID = raft.None
 		s.r.ApplyConfChange(cc)
+		s.setConsistentIndexOutsideApply(shouldApplyV3)
+		return false, err
+	}
+
+	*confState = *s.r.ApplyConfChange(cc)
+	s.beHooks.SetConfState(confState)
+	return s.applyMembershipChange(cc, shouldApplyV3), nil
+}
+
+// applyConfChangeV2 applies a ConfChangeV2 promoting a learner in place of a
+// voting member, or leaving the joint configuration it entered. It returns the
+// ID of the request waiting for the change, zero if none, and whether the
+// local member was removed.
+func (s *EtcdServer) applyConfChangeV2(cc raftpb.ConfChangeV2, confState *raftpb.ConfState, shouldApplyV3 membership.ShouldApplyV3) (uint64, bool, error) {
+	lg := s.Logger()
+	if cc.LeaveJoint() {
+		*confState = *s.r.ApplyConfChange(cc)
+		s.beHooks.SetConfState(confState)
+		s.setConsistentIndexOutsideApply(shouldApplyV3)
+		return 0, false, nil
+	}
 
-		// The txPostLock callback will not get called in this case,
-		// so we should set the consistent index directly.
-		if s.consistIndex != nil && membership.ApplyBoth == shouldApplyV3 {
-			applyingIndex, applyingTerm := s.consistIndex.ConsistentApplyingIndex()
-			s.consistIndex.SetConsistentIndex(applyingIndex, applyingTerm)
+	rc := new(replaceChangeContext)
+	if err := json.Unmarshal(cc.Context, rc); err != nil {
+		lg.Panic("failed to unmarshal replace change context", zap.Error(err))
+	}
+	promote, err := json.Marshal(rc.Promote)
+	if err != nil {
+		lg.Panic("failed to marshal promote change context", zap.Error(err))
+	}
+	changes := make([]raftpb.ConfChange, len(cc.Changes))
+	for i, c := range cc.Changes {
+		changes[i] = raftpb.ConfChange{Type: c.Type, NodeID: c.NodeID}
+		if c.Type == raftpb.ConfChangeAddNode {
+			changes[i].Context = promote
+		}
+		if err = s.cluster.ValidateConfigurationChange(changes[i], shouldApplyV3); err != nil {
+			lg.Error("Validation on configuration change failed", zap.Bool("shouldApplyV3", bool(shouldApplyV3)), zap.Error(err))
+			// apply none of the changes
+			s.r.ApplyConfChange(raftpb.ConfChange{NodeID: raft.None})
+			s.setConsistentIndexOutsideApply(shouldApplyV3)
+			return rc.ID, false, err
 		}
-		return false, err
 	}
 
 	*confState = *s.r.ApplyConfChange(cc)
 	s.beHooks.SetConfState(confState)
+	removedSelf := false
+	for _, c := range changes {
+		removedSelf = s.applyMembershipChange(c, shouldApplyV3) || removedSelf
+	}
+	return rc.ID, removedSelf, nil
+}
+
+// setConsistentIndexOutsideApply sets the consistent index directly for
+// configuration changes that do not write to the backend, as the txPostLock
+// callback will not get called for them.
+func (s *EtcdServer) setConsistentIndexOutsideApply(shouldApplyV3 membership.ShouldApplyV3) {
+	if s.consistIndex != nil && membership.ApplyBoth == shouldApplyV3 {
+		applyingIndex, applyingTerm := s.consistIndex.ConsistentApplyingIndex()
+		s.consistIndex.SetConsistentIndex(applyingIndex, applyingTerm)
+	}
+}
+
+// applyMembershipChange applies a validated ConfChange to the membership of
+// the cluster, and returns true if it removed the local member.
+func (s *EtcdServer) applyMembershipChange(cc raftpb.ConfChange, shouldApplyV3 membership.ShouldApplyV3) bool {
+	lg := s.Logger()
 	switch cc.Type {
 	case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode:
 		confChangeContext := new(membership.ConfigChangeContext)
@@ -2079,6 +2437,7 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 			s.cluster.PromoteMember(confChangeContext.Member.ID, shouldApplyV3)
 		} else {
 			s.cluster.AddMember(&confChangeContext.Member, shouldApplyV3)
+			s.events.Publish(Event{Type: EventMemberAdded, MemberID: confChangeContext.Member.ID})
 
 			if confChangeContext.Member.ID != s.MemberID() {
 				s.r.transport.AddPeer(confChangeContext.Member.ID, confChangeContext.PeerURLs)
@@ -2088,8 +2447,9 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 	case raftpb.ConfChangeRemoveNode:
 		id := types.ID(cc.NodeID)
 		s.cluster.RemoveMember(id, shouldApplyV3)
+		s.events.Publish(Event{Type: EventMemberRemoved, MemberID: id})
 		if id == s.MemberID() {
-			return true, nil
+			return true
 		}
 		s.r.transport.RemovePeer(id)
 
@@ -2110,7 +2470,7 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 			s.r.transport.UpdatePeer(m.ID, m.PeerURLs)
 		}
 	}
-	return false, nil
+	return false
 }
 
 // TODO: non-blocking snapshot
@@ -2472,6 +2832,11 @@ func (s *EtcdServer) IsLearner() bool {
 	return s.cluster.IsLocalMemberLearner()
 }
 
+// IsStandby returns if the local member is a warm standby
+func (s *EtcdServer) IsStandby() bool {
+	return s.cluster.IsLocalMemberStandby()
+}
+
 // IsMemberExist returns if the member with the given id exists in cluster.
 func (s *EtcdServer) IsMemberExist(id types.ID) bool {
 	return s.cluster.IsMemberExist(id)
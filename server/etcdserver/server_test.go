@@ -1635,6 +1635,28 @@ func TestWaitAppliedIndex(t *testing.T) {
 	}
 }
 
+func TestProcessInternalRaftRequestTooBusy(t *testing.T) {
+	s := &EtcdServer{
+		Cfg: config.ServerConfig{
+			TooBusyApplyBacklog: 100,
+			TooBusyBackoff:      10 * time.Millisecond,
+		},
+	}
+	s.appliedIndex.Store(1000)
+	s.committedIndex.Store(1350)
+
+	_, err := s.processInternalRaftRequestOnce(WithLowPriority(context.Background()), pb.InternalRaftRequest{})
+	var tooBusy *errors.TooBusyError
+	require.ErrorAs(t, err, &tooBusy)
+	require.ErrorIs(t, err, errors.ErrTooBusy)
+	assert.Equal(t, 30*time.Millisecond, tooBusy.Backoff)
+
+	s.committedIndex.Store(1000 + 100*(maxTooBusyBackoffFactor+5))
+	_, err = s.processInternalRaftRequestOnce(WithLowPriority(context.Background()), pb.InternalRaftRequest{})
+	require.ErrorAs(t, err, &tooBusy)
+	assert.Equal(t, maxTooBusyBackoffFactor*10*time.Millisecond, tooBusy.Backoff)
+}
+
 func TestIsActive(t *testing.T) {
 	cases := []struct {
 		name                  string
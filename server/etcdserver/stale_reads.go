@@ -0,0 +1,103 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+// staleReadCheckInterval is the interval at which a member gating stale
+// reads compares its applied index with the commit index of the leader.
+const staleReadCheckInterval = 100 * time.Millisecond
+
+// staleReadGate tracks whether a member that gates stale reads has caught up
+// with the leader after it started.
+type staleReadGate struct {
+	// open is set once the applied index came within the threshold of the
+	// leader's commit index. It is never reset.
+	open atomic.Bool
+	// leaderCommit is the highest commit index of the leader confirmed by
+	// a ReadIndex round trip.
+	leaderCommit atomic.Uint64
+}
+
+func (g *staleReadGate) observe(index uint64) {
+	for {
+		cur := g.leaderCommit.Load()
+		if index <= cur || g.leaderCommit.CompareAndSwap(cur, index) {
+			return
+		}
+	}
+}
+
+// checkStaleRead rejects serializable reads with errors.ErrCatchingUp until
+// the member has caught up with the leader, if Cfg.GateStaleReads is set.
+func (s *EtcdServer) checkStaleRead() error {
+	if !s.Cfg.GateStaleReads || s.staleReads.open.Load() {
+		return nil
+	}
+	return errors.ErrCatchingUp
+}
+
+// monitorStaleReads requests the commit index of the leader until the applied
+// index is within Cfg.StaleReadsCatchUpThreshold of it, then opens the gate.
+func (s *EtcdServer) monitorStaleReads() {
+	if !s.Cfg.GateStaleReads {
+		return
+	}
+	lg := s.Logger()
+	ticker := time.NewTicker(staleReadCheckInterval)
+	defer ticker.Stop()
+	for {
+		// the read loop records the index confirmed by the leader, without
+		// waiting for it to be applied
+		select {
+		case s.readwaitc <- struct{}{}:
+		default:
+		}
+		select {
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+		}
+
+		leaderCommit := s.staleReads.leaderCommit.Load()
+		if leaderCommit == 0 {
+			continue
+		}
+		applied := s.getAppliedIndex()
+		var gap uint64
+		if leaderCommit > applied {
+			gap = leaderCommit - applied
+		}
+		staleReadGap.Set(float64(gap))
+		if gap > s.Cfg.StaleReadsCatchUpThreshold {
+			continue
+		}
+		s.staleReads.open.Store(true)
+		lg.Info(
+			"caught up with the leader; serving serializable reads",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.Uint64("applied-index", applied),
+			zap.Uint64("leader-commit-index", leaderCommit),
+		)
+		return
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/server/v3/config"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+)
+
+func TestMonitorStaleReads(t *testing.T) {
+	s := &EtcdServer{
+		lgMu: new(sync.RWMutex),
+		lg:   zaptest.NewLogger(t),
+		Cfg: config.ServerConfig{
+			GateStaleReads:             true,
+			StaleReadsCatchUpThreshold: 10,
+		},
+		readwaitc: make(chan struct{}, 1),
+		stopping:  make(chan struct{}),
+	}
+	s.appliedIndex.Store(50)
+	require.ErrorIs(t, s.checkStaleRead(), errors.ErrCatchingUp)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.monitorStaleReads()
+	}()
+	defer func() {
+		close(s.stopping)
+		<-done
+	}()
+
+	// the monitor requests the commit index of the leader
+	select {
+	case <-s.readwaitc:
+	case <-time.After(time.Second):
+		t.Fatal("expected a read index request")
+	}
+	s.staleReads.observe(100)
+	time.Sleep(3 * staleReadCheckInterval)
+	require.ErrorIs(t, s.checkStaleRead(), errors.ErrCatchingUp)
+
+	s.appliedIndex.Store(90)
+	require.Eventually(t, func() bool { return s.checkStaleRead() == nil }, time.Second, 10*time.Millisecond)
+	// the gate stays open once the member caught up
+	s.staleReads.observe(1000)
+	require.NoError(t, s.checkStaleRead())
+}
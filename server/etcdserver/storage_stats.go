@@ -0,0 +1,89 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+// trackedBucketStats are the stats of the backend buckets at a point in time.
+type trackedBucketStats struct {
+	buckets []backend.BucketStats
+	time    time.Time
+}
+
+// StorageStats returns the last tracked stats of the backend buckets. They
+// are tracked on demand if they never were or if periodic tracking is
+// disabled.
+func (s *EtcdServer) StorageStats(ctx context.Context, r *pb.StorageStatsRequest) (*pb.StorageStatsResponse, error) {
+	st := s.bucketStats.Load()
+	if st == nil || s.Cfg.BackendBucketStatsInterval <= 0 {
+		var err error
+		if st, err = s.trackBucketStats(); err != nil {
+			return nil, err
+		}
+	}
+	be := s.Backend()
+	resp := &pb.StorageStatsResponse{
+		Header:      &pb.ResponseHeader{},
+		DbSize:      be.Size(),
+		DbSizeInUse: be.SizeInUse(),
+		Time:        st.time.UnixNano(),
+	}
+	for _, b := range st.buckets {
+		resp.Buckets = append(resp.Buckets, &pb.BucketStats{Name: b.Name, Keys: b.Keys, SizeBytes: b.Size})
+	}
+	return resp, nil
+}
+
+func (s *EtcdServer) trackBucketStats() (*trackedBucketStats, error) {
+	now := time.Now()
+	buckets, err := s.Backend().BucketStats()
+	if err != nil {
+		return nil, err
+	}
+	st := &trackedBucketStats{buckets: buckets, time: now}
+	s.bucketStats.Store(st)
+	return st, nil
+}
+
+// monitorBucketStats tracks the stats of the backend buckets at every bucket
+// stats interval.
+func (s *EtcdServer) monitorBucketStats() {
+	interval := s.Cfg.BackendBucketStatsInterval
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lg := s.Logger()
+	for {
+		select {
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+		}
+		if _, err := s.trackBucketStats(); err != nil {
+			lg.Warn("failed to track backend bucket stats", zap.Error(err))
+		}
+	}
+}
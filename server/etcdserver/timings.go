@@ -0,0 +1,77 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+type requestTimingsKey struct{}
+
+// requestTimings records the breakdown of the time spent serving a request.
+// It is only used by the goroutine serving the request.
+type requestTimings struct {
+	start   time.Time
+	timings pb.RequestTimings
+}
+
+// WithRequestTimings makes the server record the breakdown of the time spent
+// serving the request handled with ctx, which is returned by RequestTimings.
+func WithRequestTimings(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestTimingsKey{}, &requestTimings{start: time.Now()})
+}
+
+// RequestTimings returns the breakdown recorded for the request handled with
+// ctx, or nil if it was not requested with WithRequestTimings.
+func RequestTimings(ctx context.Context) *pb.RequestTimings {
+	if t := requestTimingsFromContext(ctx); t != nil {
+		ret := t.timings
+		return &ret
+	}
+	return nil
+}
+
+func requestTimingsFromContext(ctx context.Context) *requestTimings {
+	t, _ := ctx.Value(requestTimingsKey{}).(*requestTimings)
+	return t
+}
+
+// queued records the time since the request was received as queue wait.
+func (t *requestTimings) queued() {
+	if t != nil {
+		t.timings.QueueWaitNs = int64(time.Since(t.start))
+	}
+}
+
+func (t *requestTimings) addRaftCommit(d time.Duration) {
+	if t != nil {
+		t.timings.RaftCommitNs += int64(d)
+	}
+}
+
+func (t *requestTimings) addApply(d time.Duration) {
+	if t != nil {
+		t.timings.ApplyNs += int64(d)
+	}
+}
+
+func (t *requestTimings) addBackendRead(d time.Duration) {
+	if t != nil {
+		t.timings.BackendReadNs += int64(d)
+	}
+}
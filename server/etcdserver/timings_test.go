@@ -0,0 +1,50 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimings(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, RequestTimings(ctx))
+	// recording without a request for timings is a no-op
+	requestTimingsFromContext(ctx).addApply(time.Second)
+
+	ctx = WithRequestTimings(ctx)
+	rt := requestTimingsFromContext(ctx)
+	rt.queued()
+	rt.addRaftCommit(2 * time.Millisecond)
+	rt.addRaftCommit(3 * time.Millisecond)
+	rt.addApply(time.Millisecond)
+	rt.addBackendRead(time.Microsecond)
+
+	timings := RequestTimings(ctx)
+	require.NotNil(t, timings)
+	assert.Positive(t, timings.QueueWaitNs)
+	assert.Equal(t, int64(5*time.Millisecond), timings.RaftCommitNs)
+	assert.Equal(t, int64(time.Millisecond), timings.ApplyNs)
+	assert.Equal(t, int64(time.Microsecond), timings.BackendReadNs)
+
+	// the returned timings are a copy
+	timings.ApplyNs = 0
+	assert.Equal(t, int64(time.Millisecond), RequestTimings(ctx).ApplyNs)
+}
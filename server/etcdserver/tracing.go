@@ -38,6 +38,10 @@ func firstOpKey(ops []*pb.RequestOp) string {
 			key = string(op.RequestRange.GetKey())
 		case *pb.RequestOp_RequestDeleteRange:
 			key = string(op.RequestDeleteRange.GetKey())
+		case *pb.RequestOp_RequestAppend:
+			key = string(op.RequestAppend.GetKey())
+		case *pb.RequestOp_RequestForEach:
+			key = string(op.RequestForEach.GetKey())
 		}
 		if key != "" {
 			return key
@@ -58,6 +62,10 @@ func firstOpType(ops []*pb.RequestOp) string {
 			return "delete_range"
 		case *pb.RequestOp_RequestTxn:
 			return "txn"
+		case *pb.RequestOp_RequestAppend:
+			return "append"
+		case *pb.RequestOp_RequestForEach:
+			return "foreach"
 		}
 	}
 	return ""
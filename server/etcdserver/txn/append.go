@@ -0,0 +1,79 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"context"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// checkAppend fails if appending to the key would grow its value past
+// max_size.
+func checkAppend(ctx context.Context, rv mvcc.ReadView, ar *pb.AppendRequest) error {
+	if ar.MaxSize <= 0 {
+		return nil
+	}
+	rr, err := rv.Range(ctx, ar.Key, nil, mvcc.RangeOptions{})
+	if err != nil {
+		return err
+	}
+	size := int64(len(ar.Value))
+	if len(rr.KVs) != 0 {
+		size += int64(len(rr.KVs[0].Value))
+	}
+	if size > ar.MaxSize {
+		return errors.ErrValueTooLarge
+	}
+	return nil
+}
+
+// appendValue writes the concatenation of the current value of the key and
+// the requested value as a new revision of the key. The key keeps its lease;
+// a missing key is created without one.
+func appendValue(ctx context.Context, txnWrite mvcc.TxnWrite, ar *pb.AppendRequest) (*pb.AppendResponse, error) {
+	trace := traceutil.Get(ctx)
+	resp := &pb.AppendResponse{}
+	resp.Header = &pb.ResponseHeader{}
+
+	var rr *mvcc.RangeResult
+	var err error
+	trace.StepWithFunction(func() {
+		rr, err = txnWrite.Range(ctx, ar.Key, nil, mvcc.RangeOptions{})
+	}, "get previous kv pair")
+	if err != nil {
+		return nil, err
+	}
+
+	val, leaseID := ar.Value, lease.NoLease
+	if len(rr.KVs) != 0 {
+		prev := &rr.KVs[0]
+		val = make([]byte, 0, len(prev.Value)+len(ar.Value))
+		val = append(append(val, prev.Value...), ar.Value...)
+		leaseID = lease.LeaseID(prev.Lease)
+		if ar.PrevKv {
+			resp.PrevKv = prev
+		}
+	}
+
+	resp.ValueSize = int64(len(val))
+	resp.Header.Revision = txnWrite.Put(ar.Key, val, leaseID)
+	trace.AddField(traceutil.Field{Key: "response_revision", Value: resp.Header.Revision})
+	return resp, nil
+}
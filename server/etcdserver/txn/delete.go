@@ -22,6 +22,7 @@ import (
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
@@ -32,15 +33,67 @@ func DeleteRange(ctx context.Context, lg *zap.Logger, kv mvcc.KV, dr *pb.DeleteR
 	)
 	txnWrite := kv.Write(trace)
 	defer txnWrite.End()
-	resp, err = deleteRange(ctx, txnWrite, dr)
+	if err = checkDeleteRange(ctx, txnWrite, dr); err != nil {
+		return nil, trace, err
+	}
+	unmet, err := deleteGuardUnmet(ctx, txnWrite, dr)
+	if err != nil {
+		return nil, trace, err
+	}
+	resp, err = deleteRange(ctx, txnWrite, dr, unmet)
 	return resp, trace, err
 }
 
-func deleteRange(ctx context.Context, txnWrite mvcc.TxnWrite, dr *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
+// checkDeleteRange fails if the delete range request would delete more keys
+// than its max_deletions.
+func checkDeleteRange(ctx context.Context, rv mvcc.ReadView, dr *pb.DeleteRangeRequest) error {
+	if dr.MaxDeletions <= 0 {
+		return nil
+	}
+	count, err := countRange(ctx, rv, dr)
+	if err != nil {
+		return err
+	}
+	if count > dr.MaxDeletions {
+		return errors.ErrTooManyDeletions
+	}
+	return nil
+}
+
+// deleteGuardUnmet returns true if the range of the delete range request
+// holds its if_count_less_than keys or more. Like max_deletions, the guard is
+// evaluated against rv before any operation of a transaction is applied.
+func deleteGuardUnmet(ctx context.Context, rv mvcc.ReadView, dr *pb.DeleteRangeRequest) (bool, error) {
+	if dr.IfCountLessThan <= 0 {
+		return false, nil
+	}
+	count, err := countRange(ctx, rv, dr)
+	if err != nil {
+		return false, err
+	}
+	return count >= dr.IfCountLessThan, nil
+}
+
+func countRange(ctx context.Context, rv mvcc.ReadView, dr *pb.DeleteRangeRequest) (int64, error) {
+	rr, err := rv.Range(ctx, dr.Key, mkGteRange(dr.RangeEnd), mvcc.RangeOptions{Count: true})
+	if err != nil {
+		return 0, err
+	}
+	return int64(rr.Count), nil
+}
+
+// deleteRange deletes the range of the delete range request, unless its
+// if_count_less_than guard is unmet.
+func deleteRange(ctx context.Context, txnWrite mvcc.TxnWrite, dr *pb.DeleteRangeRequest, guardUnmet bool) (*pb.DeleteRangeResponse, error) {
 	resp := &pb.DeleteRangeResponse{}
 	resp.Header = &pb.ResponseHeader{}
 	end := mkGteRange(dr.RangeEnd)
 
+	if guardUnmet {
+		resp.Header.Revision = txnWrite.Rev()
+		return resp, nil
+	}
+
 	if dr.PrevKv {
 		rr, err := txnWrite.Range(ctx, dr.Key, end, mvcc.RangeOptions{})
 		if err != nil {
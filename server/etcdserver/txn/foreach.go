@@ -0,0 +1,140 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// checkForEach fails if the range of the foreach request holds more keys
+// than its max_keys, or if one of its puts attaches a missing lease.
+func checkForEach(ctx context.Context, rv mvcc.ReadView, lessor lease.Lessor, fe *pb.ForEachRequest) error {
+	rr, err := rv.Range(ctx, fe.Key, mkGteRange(fe.RangeEnd), mvcc.RangeOptions{Count: true})
+	if err != nil {
+		return err
+	}
+	if int64(rr.Count) > fe.MaxKeys {
+		return errors.ErrForEachTooManyKeys
+	}
+	for _, u := range fe.Ops {
+		if p := u.GetRequestPut(); p != nil {
+			if err = checkLease(lessor, p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// forEach applies the operations of the foreach request to every key of its
+// range satisfying its predicates, as read when the operation starts.
+func forEach(ctx context.Context, lg *zap.Logger, txnWrite mvcc.TxnWrite, fe *pb.ForEachRequest) (*pb.ForEachResponse, error) {
+	trace := traceutil.Get(ctx)
+	resp := &pb.ForEachResponse{}
+	resp.Header = &pb.ResponseHeader{}
+
+	var rr *mvcc.RangeResult
+	var err error
+	trace.StepWithFunction(func() {
+		rr, err = txnWrite.Range(ctx, fe.Key, mkGteRange(fe.RangeEnd), mvcc.RangeOptions{})
+	}, "get the key-value pairs to iterate over")
+	if err != nil {
+		return nil, fmt.Errorf("applyTxn: failed foreach iteration: %w", err)
+	}
+
+	resp.Count = int64(len(rr.KVs))
+	for _, kv := range rr.KVs {
+		if !compareEach(fe.Compare, kv) {
+			continue
+		}
+		resp.Keys = append(resp.Keys, kv.Key)
+		for _, u := range fe.Ops {
+			op, err := forEachOp(ctx, lg, txnWrite, u, kv.Key)
+			if err != nil {
+				return nil, err
+			}
+			resp.Responses = append(resp.Responses, op)
+		}
+	}
+	resp.Header.Revision = txnWrite.Rev()
+	if len(txnWrite.Changes()) != 0 {
+		resp.Header.Revision++
+	}
+	return resp, nil
+}
+
+// IsForEachWrite reports whether an operation of the foreach request writes.
+func IsForEachWrite(fe *pb.ForEachRequest) bool {
+	for _, u := range fe.Ops {
+		if u.GetRequestRange() == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// compareEach reports whether the key-value pair satisfies all predicates.
+func compareEach(cmps []*pb.Compare, kv mvccpb.KeyValue) bool {
+	for _, c := range cmps {
+		if !compareKV(c, kv, nil) {
+			return false
+		}
+	}
+	return true
+}
+
+// forEachOp applies an operation of a foreach request to key, which stands
+// for the empty key of the operation.
+func forEachOp(ctx context.Context, lg *zap.Logger, txnWrite mvcc.TxnWrite, u *pb.RequestOp, key []byte) (*pb.ResponseOp, error) {
+	trace := traceutil.Get(ctx)
+	switch tv := u.Request.(type) {
+	case *pb.RequestOp_RequestRange:
+		r := *tv.RequestRange
+		r.Key = key
+		resp, err := executeRange(ctx, lg, txnWrite, &r)
+		if err != nil {
+			return nil, fmt.Errorf("applyTxn: failed foreach Range: %w", err)
+		}
+		return &pb.ResponseOp{Response: &pb.ResponseOp_ResponseRange{ResponseRange: resp}}, nil
+	case *pb.RequestOp_RequestPut:
+		p := *tv.RequestPut
+		p.Key = key
+		prevKV, err := checkAndGetPrevKV(trace, txnWrite, &p)
+		if err != nil {
+			return nil, fmt.Errorf("applyTxn: failed to get prevKV on foreach put: %w", err)
+		}
+		resp := put(ctx, txnWrite, &p, prevKV)
+		return &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{ResponsePut: resp}}, nil
+	case *pb.RequestOp_RequestDeleteRange:
+		dr := *tv.RequestDeleteRange
+		dr.Key = key
+		resp, err := deleteRange(ctx, txnWrite, &dr, false)
+		if err != nil {
+			return nil, fmt.Errorf("applyTxn: failed foreach DeleteRange: %w", err)
+		}
+		return &pb.ResponseOp{Response: &pb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: resp}}, nil
+	}
+	return nil, fmt.Errorf("applyTxn: unexpected foreach operation %T", u.Request)
+}
@@ -16,11 +16,14 @@ package txn
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/zap"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/etcd/server/v3/lease"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
@@ -62,11 +65,63 @@ func put(ctx context.Context, txnWrite mvcc.TxnWrite, p *pb.PutRequest, prevKV *
 		}
 	}
 
-	resp.Header.Revision = txnWrite.Put(p.Key, val, leaseID)
+	key := p.Key
+	if p.SequenceSuffix {
+		key = sequenceKey(p.Key, txnWrite.Rev()+1)
+		resp.Key = key
+	}
+	resp.Header.Revision = txnWrite.Put(key, val, leaseID)
+	if p.ReturnKv {
+		resp.Kv = storedKV(key, val, leaseID, resp.Header.Revision, prevKV)
+	}
 	trace.AddField(traceutil.Field{Key: "response_revision", Value: resp.Header.Revision})
 	return resp
 }
 
+// storedKV returns the key-value pair stored by a put at revision rev, given
+// the previous key-value pair of the key, if any.
+func storedKV(key, val []byte, leaseID lease.LeaseID, rev int64, prevKV *mvcc.RangeResult) *mvccpb.KeyValue {
+	kv := &mvccpb.KeyValue{
+		Key:            key,
+		Value:          val,
+		Lease:          int64(leaseID),
+		CreateRevision: rev,
+		ModRevision:    rev,
+		Version:        1,
+	}
+	if prevKV != nil && len(prevKV.KVs) != 0 {
+		kv.CreateRevision = prevKV.KVs[0].CreateRevision
+		kv.Version = prevKV.KVs[0].Version + 1
+	}
+	return kv
+}
+
+// sequenceKey returns the key created by a sequence_suffix put under prefix
+// at revision rev.
+func sequenceKey(prefix []byte, rev int64) []byte {
+	return fmt.Appendf(append([]byte{}, prefix...), "%020d", rev)
+}
+
+// sequenceKeyRange returns a range holding every key a sequence_suffix put
+// can create under prefix.
+func sequenceKeyRange(prefix []byte) (key, end []byte) {
+	key = append(append([]byte{}, prefix...), '0')
+	end = append(append([]byte{}, prefix...), '9'+1)
+	return key, end
+}
+
+// IsPutPermitted checks the write permission for a put, which for a
+// sequence_suffix put covers every key the suffix can produce.
+func IsPutPermitted(as auth.AuthStore, ai *auth.AuthInfo, p *pb.PutRequest) error {
+	if p.SequenceSuffix {
+		// writing anywhere in a range takes the same permission as
+		// deleting it
+		key, end := sequenceKeyRange(p.Key)
+		return as.IsDeleteRangePermitted(ai, key, end)
+	}
+	return as.IsPutPermitted(ai, p.Key)
+}
+
 func checkPut(trace *traceutil.Trace, txnWrite mvcc.ReadView, lessor lease.Lessor, p *pb.PutRequest) error {
 	err := checkLease(lessor, p)
 	if err != nil {
@@ -101,7 +156,8 @@ func checkAndGetPrevKV(trace *traceutil.Trace, txnWrite mvcc.ReadView, p *pb.Put
 }
 
 func getPrevKV(trace *traceutil.Trace, txnWrite mvcc.ReadView, p *pb.PutRequest) (prevKV *mvcc.RangeResult, err error) {
-	if p.IgnoreValue || p.IgnoreLease || p.PrevKv {
+	// a sequence_suffix put always creates a new key
+	if (p.IgnoreValue || p.IgnoreLease || p.PrevKv || p.ReturnKv) && !p.SequenceSuffix {
 		trace.StepWithFunction(func() {
 			prevKV, err = txnWrite.Range(context.TODO(), p.Key, nil, mvcc.RangeOptions{})
 		}, "get previous kv pair")
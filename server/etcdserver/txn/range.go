@@ -48,6 +48,8 @@ func executeRange(ctx context.Context, lg *zap.Logger, txnRead mvcc.TxnRead, r *
 		Limit: limit,
 		Rev:   r.Revision,
 		Count: r.CountOnly,
+
+		ModificationsSince: r.CountModificationsSinceRev,
 	}
 
 	rr, err := txnRead.Range(ctx, r.Key, mkGteRange(r.RangeEnd), ro)
@@ -18,7 +18,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/coreos/go-semver/semver"
 	"go.uber.org/zap"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
@@ -44,7 +47,7 @@ func Txn(ctx context.Context, lg *zap.Logger, rt *pb.TxnRequest, txnModeWriteWit
 	var txnPath []bool
 	trace.StepWithFunction(
 		func() {
-			txnPath = compareToPath(txnRead, rt)
+			txnPath = compareToPath(txnRead, rt, lessor)
 		},
 		"compare",
 	)
@@ -80,7 +83,11 @@ func Txn(ctx context.Context, lg *zap.Logger, rt *pb.TxnRequest, txnModeWriteWit
 
 func txn(ctx context.Context, lg *zap.Logger, txnWrite mvcc.TxnWrite, rt *pb.TxnRequest, isWrite bool, txnPath []bool) (*pb.TxnResponse, error) {
 	txnResp, _ := newTxnResp(rt, txnPath)
-	_, err := executeTxn(ctx, lg, txnWrite, rt, txnPath, txnResp)
+	unmetGuards := make(map[*pb.DeleteRangeRequest]struct{})
+	_, err := unmetDeleteGuards(ctx, txnWrite, rt, txnPath, unmetGuards)
+	if err == nil {
+		_, err = executeTxn(ctx, lg, txnWrite, rt, txnPath, txnResp, unmetGuards)
+	}
 	if err != nil {
 		if isWrite {
 			// CAUTION: When a txn performing write operations starts, we always expect it to be successful.
@@ -121,6 +128,10 @@ func newTxnResp(rt *pb.TxnRequest, txnPath []bool) (txnResp *pb.TxnResponse, txn
 			resps[i] = &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{}}
 		case *pb.RequestOp_RequestDeleteRange:
 			resps[i] = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseDeleteRange{}}
+		case *pb.RequestOp_RequestAppend:
+			resps[i] = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseAppend{}}
+		case *pb.RequestOp_RequestForEach:
+			resps[i] = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseForEach{}}
 		case *pb.RequestOp_RequestTxn:
 			resp, txns := newTxnResp(tv.RequestTxn, txnPath[1:])
 			resps[i] = &pb.ResponseOp{Response: &pb.ResponseOp_ResponseTxn{ResponseTxn: resp}}
@@ -132,7 +143,7 @@ func newTxnResp(rt *pb.TxnRequest, txnPath []bool) (txnResp *pb.TxnResponse, txn
 	return txnResp, txnCount
 }
 
-func executeTxn(ctx context.Context, lg *zap.Logger, txnWrite mvcc.TxnWrite, rt *pb.TxnRequest, txnPath []bool, tresp *pb.TxnResponse) (txns int, err error) {
+func executeTxn(ctx context.Context, lg *zap.Logger, txnWrite mvcc.TxnWrite, rt *pb.TxnRequest, txnPath []bool, tresp *pb.TxnResponse, unmetGuards map[*pb.DeleteRangeRequest]struct{}) (txns int, err error) {
 	trace := traceutil.Get(ctx)
 	reqs := rt.Success
 	if !txnPath[0] {
@@ -166,14 +177,37 @@ func executeTxn(ctx context.Context, lg *zap.Logger, txnWrite mvcc.TxnWrite, rt
 			respi.(*pb.ResponseOp_ResponsePut).ResponsePut = resp
 			trace.StopSubTrace()
 		case *pb.RequestOp_RequestDeleteRange:
-			resp, err := deleteRange(ctx, txnWrite, tv.RequestDeleteRange)
+			_, unmet := unmetGuards[tv.RequestDeleteRange]
+			resp, err := deleteRange(ctx, txnWrite, tv.RequestDeleteRange, unmet)
 			if err != nil {
 				return 0, fmt.Errorf("applyTxn: failed DeleteRange: %w", err)
 			}
 			respi.(*pb.ResponseOp_ResponseDeleteRange).ResponseDeleteRange = resp
+		case *pb.RequestOp_RequestAppend:
+			trace.StartSubTrace(
+				traceutil.Field{Key: "req_type", Value: "append"},
+				traceutil.Field{Key: "key", Value: string(tv.RequestAppend.Key)},
+				traceutil.Field{Key: "req_size", Value: tv.RequestAppend.Size()})
+			resp, err := appendValue(ctx, txnWrite, tv.RequestAppend)
+			if err != nil {
+				return 0, fmt.Errorf("applyTxn: failed Append: %w", err)
+			}
+			respi.(*pb.ResponseOp_ResponseAppend).ResponseAppend = resp
+			trace.StopSubTrace()
+		case *pb.RequestOp_RequestForEach:
+			trace.StartSubTrace(
+				traceutil.Field{Key: "req_type", Value: "foreach"},
+				traceutil.Field{Key: "range_begin", Value: string(tv.RequestForEach.Key)},
+				traceutil.Field{Key: "range_end", Value: string(tv.RequestForEach.RangeEnd)})
+			resp, err := forEach(ctx, lg, txnWrite, tv.RequestForEach)
+			if err != nil {
+				return 0, err
+			}
+			respi.(*pb.ResponseOp_ResponseForEach).ResponseForEach = resp
+			trace.StopSubTrace()
 		case *pb.RequestOp_RequestTxn:
 			resp := respi.(*pb.ResponseOp_ResponseTxn).ResponseTxn
-			applyTxns, err := executeTxn(ctx, lg, txnWrite, tv.RequestTxn, txnPath[1:], resp)
+			applyTxns, err := executeTxn(ctx, lg, txnWrite, tv.RequestTxn, txnPath[1:], resp, unmetGuards)
 			if err != nil {
 				// don't wrap the error. It's a recursive call and err should be already wrapped
 				return 0, err
@@ -187,6 +221,37 @@ func executeTxn(ctx context.Context, lg *zap.Logger, txnWrite mvcc.TxnWrite, rt
 	return txns, nil
 }
 
+// unmetDeleteGuards adds to unmet the delete range requests of the txn whose
+// if_count_less_than guard is unmet before the txn applies any operation, the
+// state checkTxn checks max_deletions against.
+func unmetDeleteGuards(ctx context.Context, rv mvcc.ReadView, rt *pb.TxnRequest, txnPath []bool, unmet map[*pb.DeleteRangeRequest]struct{}) (int, error) {
+	txnCount := 0
+	reqs := rt.Success
+	if !txnPath[0] {
+		reqs = rt.Failure
+	}
+	for _, req := range reqs {
+		switch tv := req.Request.(type) {
+		case *pb.RequestOp_RequestDeleteRange:
+			guardUnmet, err := deleteGuardUnmet(ctx, rv, tv.RequestDeleteRange)
+			if err != nil {
+				return 0, err
+			}
+			if guardUnmet {
+				unmet[tv.RequestDeleteRange] = struct{}{}
+			}
+		case *pb.RequestOp_RequestTxn:
+			txns, err := unmetDeleteGuards(ctx, rv, tv.RequestTxn, txnPath[1:], unmet)
+			if err != nil {
+				return 0, err
+			}
+			txnCount += txns + 1
+			txnPath = txnPath[txns+1:]
+		}
+	}
+	return txnCount, nil
+}
+
 func checkTxn(trace *traceutil.Trace, rv mvcc.ReadView, rt *pb.TxnRequest, lessor lease.Lessor, txnPath []bool) (int, error) {
 	txnCount := 0
 	reqs := rt.Success
@@ -202,6 +267,11 @@ func checkTxn(trace *traceutil.Trace, rv mvcc.ReadView, rt *pb.TxnRequest, lesso
 		case *pb.RequestOp_RequestPut:
 			err = checkPut(trace, rv, lessor, tv.RequestPut)
 		case *pb.RequestOp_RequestDeleteRange:
+			err = checkDeleteRange(context.TODO(), rv, tv.RequestDeleteRange)
+		case *pb.RequestOp_RequestAppend:
+			err = checkAppend(context.TODO(), rv, tv.RequestAppend)
+		case *pb.RequestOp_RequestForEach:
+			err = checkForEach(context.TODO(), rv, lessor, tv.RequestForEach)
 		case *pb.RequestOp_RequestTxn:
 			txns, err = checkTxn(trace, rv, tv.RequestTxn, lessor, txnPath[1:])
 			txnCount += txns + 1
@@ -227,10 +297,10 @@ func compareInt64(a, b int64) int {
 	}
 }
 
-func compareToPath(rv mvcc.ReadView, rt *pb.TxnRequest) []bool {
+func compareToPath(rv mvcc.ReadView, rt *pb.TxnRequest, lessor lease.Lessor) []bool {
 	txnPath := make([]bool, 1)
 	ops := rt.Success
-	if txnPath[0] = applyCompares(rv, rt.Compare); !txnPath[0] {
+	if txnPath[0] = applyCompares(rv, rt.Compare, lessor); !txnPath[0] {
 		ops = rt.Failure
 	}
 	for _, op := range ops {
@@ -238,14 +308,14 @@ func compareToPath(rv mvcc.ReadView, rt *pb.TxnRequest) []bool {
 		if !ok || tv.RequestTxn == nil {
 			continue
 		}
-		txnPath = append(txnPath, compareToPath(rv, tv.RequestTxn)...)
+		txnPath = append(txnPath, compareToPath(rv, tv.RequestTxn, lessor)...)
 	}
 	return txnPath
 }
 
-func applyCompares(rv mvcc.ReadView, cmps []*pb.Compare) bool {
+func applyCompares(rv mvcc.ReadView, cmps []*pb.Compare, lessor lease.Lessor) bool {
 	for _, c := range cmps {
-		if !applyCompare(rv, c) {
+		if !applyCompare(rv, c, lessor) {
 			return false
 		}
 	}
@@ -254,7 +324,7 @@ func applyCompares(rv mvcc.ReadView, cmps []*pb.Compare) bool {
 
 // applyCompare applies the compare request.
 // If the comparison succeeds, it returns true. Otherwise, returns false.
-func applyCompare(rv mvcc.ReadView, c *pb.Compare) bool {
+func applyCompare(rv mvcc.ReadView, c *pb.Compare, lessor lease.Lessor) bool {
 	// TODO: possible optimizations
 	// * chunk reads for large ranges to conserve memory
 	// * rewrite rules for common patterns:
@@ -265,22 +335,26 @@ func applyCompare(rv mvcc.ReadView, c *pb.Compare) bool {
 		return false
 	}
 	if len(rr.KVs) == 0 {
-		if c.Target == pb.Compare_VALUE {
+		switch c.Target {
+		case pb.Compare_VALUE, pb.Compare_VALUE_INT, pb.Compare_VALUE_SEMVER:
 			// Always fail if comparing a value on a key/keys that doesn't exist;
 			// nil == empty string in grpc; no way to represent missing value
 			return false
 		}
-		return compareKV(c, mvccpb.KeyValue{})
+		return compareKV(c, mvccpb.KeyValue{}, lessor)
 	}
 	for _, kv := range rr.KVs {
-		if !compareKV(c, kv) {
+		if !compareKV(c, kv, lessor) {
 			return false
 		}
 	}
 	return true
 }
 
-func compareKV(c *pb.Compare, ckv mvccpb.KeyValue) bool {
+// compareKV compares the key-value pair against c. The lessor looks up the
+// fencing tokens of the FENCING_TOKEN comparisons, which are compared with 0
+// if it is nil.
+func compareKV(c *pb.Compare, ckv mvccpb.KeyValue, lessor lease.Lessor) bool {
 	var result int
 	rev := int64(0)
 	switch c.Target {
@@ -310,6 +384,30 @@ func compareKV(c *pb.Compare, ckv mvccpb.KeyValue) bool {
 			rev = tv.Lease
 		}
 		result = compareInt64(ckv.Lease, rev)
+	case pb.Compare_VALUE_INT:
+		v, err := strconv.ParseInt(string(ckv.Value), 10, 64)
+		if err != nil {
+			return false
+		}
+		result = compareInt64(v, c.GetValueInt())
+	case pb.Compare_VALUE_SEMVER:
+		v, err := ParseSemver(string(ckv.Value))
+		if err != nil {
+			return false
+		}
+		cv, err := ParseSemver(c.GetValueSemver())
+		if err != nil {
+			return false
+		}
+		result = v.Compare(*cv)
+	case pb.Compare_FENCING_TOKEN:
+		var token int64
+		if ckv.Lease != 0 && lessor != nil {
+			if l := lessor.Lookup(lease.LeaseID(ckv.Lease)); l != nil {
+				token = l.FencingToken()
+			}
+		}
+		result = compareInt64(token, c.GetFencingToken())
 	}
 	switch c.Result {
 	case pb.Compare_EQUAL:
@@ -324,6 +422,12 @@ func compareKV(c *pb.Compare, ckv mvccpb.KeyValue) bool {
 	return true
 }
 
+// ParseSemver parses the semantic version of a VALUE_SEMVER comparison, which
+// may have a "v" prefix.
+func ParseSemver(v string) (*semver.Version, error) {
+	return semver.NewVersion(strings.TrimPrefix(v, "v"))
+}
+
 func IsTxnSerializable(r *pb.TxnRequest) bool {
 	for _, u := range r.Success {
 		if r := u.GetRequestRange(); r == nil || !r.Serializable {
@@ -381,7 +485,7 @@ func checkTxnReqsPermission(as auth.AuthStore, ai *auth.AuthInfo, reqs []*pb.Req
 				continue
 			}
 
-			if err := as.IsPutPermitted(ai, tv.RequestPut.Key); err != nil {
+			if err := IsPutPermitted(as, ai, tv.RequestPut); err != nil {
 				return err
 			}
 
@@ -401,6 +505,38 @@ func checkTxnReqsPermission(as auth.AuthStore, ai *auth.AuthInfo, reqs []*pb.Req
 			if err != nil {
 				return err
 			}
+
+		case *pb.RequestOp_RequestAppend:
+			if tv.RequestAppend == nil {
+				continue
+			}
+
+			// append reads the current value, so it is only allowed for
+			// users who could have read and rewritten the key themselves.
+			if err := as.IsRangePermitted(ai, tv.RequestAppend.Key, nil); err != nil {
+				return err
+			}
+
+			if err := as.IsPutPermitted(ai, tv.RequestAppend.Key); err != nil {
+				return err
+			}
+
+		case *pb.RequestOp_RequestForEach:
+			if tv.RequestForEach == nil {
+				continue
+			}
+
+			// the predicates read every key of the range, and the writes
+			// may touch any of them.
+			if err := as.IsRangePermitted(ai, tv.RequestForEach.Key, tv.RequestForEach.RangeEnd); err != nil {
+				return err
+			}
+
+			if IsForEachWrite(tv.RequestForEach) {
+				if err := as.IsDeleteRangePermitted(ai, tv.RequestForEach.Key, tv.RequestForEach.RangeEnd); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -17,6 +17,7 @@ package txn
 import (
 	"context"
 	"crypto/sha256"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -29,8 +30,10 @@ import (
 
 	"go.etcd.io/etcd/api/v3/authpb"
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/auth"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/etcd/server/v3/lease"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
@@ -339,6 +342,352 @@ func TestReadonlyTxnError(t *testing.T) {
 	}
 }
 
+func TestDeleteRangeLimits(t *testing.T) {
+	tcs := []struct {
+		name            string
+		maxDeletions    int64
+		ifCountLessThan int64
+
+		expectError   error
+		expectDeleted int64
+	}{
+		{name: "no limits", expectDeleted: 3},
+		{name: "max deletions above count", maxDeletions: 3, expectDeleted: 3},
+		{name: "max deletions below count", maxDeletions: 2, expectError: errors.ErrTooManyDeletions},
+		{name: "count less than guard passes", ifCountLessThan: 4, expectDeleted: 3},
+		{name: "count less than guard fails", ifCountLessThan: 3, expectDeleted: 0},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s, lessor := setup(t, testSetup{})
+			for _, k := range []string{"a/1", "a/2", "a/3", "b"} {
+				s.Put([]byte(k), []byte("v"), lease.NoLease)
+			}
+			dr := &pb.DeleteRangeRequest{
+				Key:             []byte("a/"),
+				RangeEnd:        []byte("a0"),
+				MaxDeletions:    tc.maxDeletions,
+				IfCountLessThan: tc.ifCountLessThan,
+			}
+
+			resp, _, err := DeleteRange(t.Context(), zaptest.NewLogger(t), s, dr)
+			require.ErrorIs(t, err, tc.expectError)
+			if err == nil {
+				assert.Equal(t, tc.expectDeleted, resp.Deleted)
+			}
+
+			// the same limits apply to deletes within a transaction
+			s.Put([]byte("a/1"), []byte("v"), lease.NoLease)
+			s.Put([]byte("a/2"), []byte("v"), lease.NoLease)
+			s.Put([]byte("a/3"), []byte("v"), lease.NoLease)
+			txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: dr}}}}
+			txnResp, _, err := Txn(t.Context(), zaptest.NewLogger(t), txn, false, s, lessor)
+			require.ErrorIs(t, err, tc.expectError)
+			if err == nil {
+				assert.Equal(t, tc.expectDeleted, txnResp.Responses[0].GetResponseDeleteRange().Deleted)
+			}
+
+			rr, err := s.Range(t.Context(), []byte("a/"), []byte("a0"), mvcc.RangeOptions{Count: true})
+			require.NoError(t, err)
+			assert.Equal(t, 3-int(tc.expectDeleted), rr.Count)
+		})
+	}
+}
+
+func TestDeleteRangeLimitsBeforeTxn(t *testing.T) {
+	tcs := []struct {
+		name            string
+		maxDeletions    int64
+		ifCountLessThan int64
+
+		expectError   error
+		expectDeleted int64
+	}{
+		{name: "max deletions ignores keys put by the txn", maxDeletions: 2, expectDeleted: 3},
+		{name: "max deletions counts keys present before the txn", maxDeletions: 1, expectError: errors.ErrTooManyDeletions},
+		{name: "count less than guard ignores keys put by the txn", ifCountLessThan: 3, expectDeleted: 3},
+		{name: "count less than guard counts keys present before the txn", ifCountLessThan: 2, expectDeleted: 0},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s, lessor := setup(t, testSetup{})
+			s.Put([]byte("a/1"), []byte("v"), lease.NoLease)
+			s.Put([]byte("a/2"), []byte("v"), lease.NoLease)
+			dr := &pb.DeleteRangeRequest{
+				Key:             []byte("a/"),
+				RangeEnd:        []byte("a0"),
+				MaxDeletions:    tc.maxDeletions,
+				IfCountLessThan: tc.ifCountLessThan,
+			}
+			txn := &pb.TxnRequest{Success: []*pb.RequestOp{
+				{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("a/3"), Value: []byte("v")}}},
+				{Request: &pb.RequestOp_RequestTxn{RequestTxn: &pb.TxnRequest{Success: []*pb.RequestOp{
+					{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: dr}},
+				}}}},
+			}}
+
+			txnResp, _, err := Txn(t.Context(), zaptest.NewLogger(t), txn, false, s, lessor)
+			require.ErrorIs(t, err, tc.expectError)
+			if err == nil {
+				nested := txnResp.Responses[1].GetResponseTxn()
+				assert.Equal(t, tc.expectDeleted, nested.Responses[0].GetResponseDeleteRange().Deleted)
+			}
+		})
+	}
+}
+
+func TestTxnAppend(t *testing.T) {
+	tcs := []struct {
+		name    string
+		prev    string
+		lease   int64
+		value   string
+		maxSize int64
+
+		expectError error
+		expectValue string
+	}{
+		{name: "missing key is created", value: "a", expectValue: "a"},
+		{name: "value is appended", prev: "ab", value: "c", expectValue: "abc"},
+		{name: "lease is kept", prev: "ab", lease: 1, value: "c", expectValue: "abc"},
+		{name: "max size reached", prev: "ab", value: "c", maxSize: 3, expectValue: "abc"},
+		{name: "max size exceeded", prev: "ab", value: "cd", maxSize: 3, expectError: errors.ErrValueTooLarge, expectValue: "ab"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s, lessor := setup(t, testSetup{lease: tc.lease})
+			if tc.prev != "" {
+				s.Put([]byte("foo"), []byte(tc.prev), lease.LeaseID(tc.lease))
+			}
+			rev := s.Rev()
+
+			txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestAppend{RequestAppend: &pb.AppendRequest{
+				Key:     []byte("foo"),
+				Value:   []byte(tc.value),
+				MaxSize: tc.maxSize,
+				PrevKv:  true,
+			}}}}}
+			resp, _, err := Txn(t.Context(), zaptest.NewLogger(t), txn, false, s, lessor)
+			require.ErrorIs(t, err, tc.expectError)
+			if err == nil {
+				ar := resp.Responses[0].GetResponseAppend()
+				require.NotNil(t, ar)
+				assert.Equal(t, rev+1, resp.Header.Revision)
+				assert.Equal(t, int64(len(tc.expectValue)), ar.ValueSize)
+				if tc.prev != "" {
+					require.NotNil(t, ar.PrevKv)
+					assert.Equal(t, tc.prev, string(ar.PrevKv.Value))
+				} else {
+					assert.Nil(t, ar.PrevKv)
+				}
+			}
+
+			rr, err := s.Range(t.Context(), []byte("foo"), nil, mvcc.RangeOptions{})
+			require.NoError(t, err)
+			if tc.expectValue == "" {
+				assert.Empty(t, rr.KVs)
+				return
+			}
+			require.Len(t, rr.KVs, 1)
+			assert.Equal(t, tc.expectValue, string(rr.KVs[0].Value))
+			assert.Equal(t, tc.lease, rr.KVs[0].Lease)
+		})
+	}
+}
+
+func TestCompareTypedValue(t *testing.T) {
+	tcs := []struct {
+		name   string
+		value  string
+		cmp    *pb.Compare
+		expect bool
+	}{
+		{name: "int greater", value: "10", cmp: &pb.Compare{Target: pb.Compare_VALUE_INT, Result: pb.Compare_GREATER, TargetUnion: &pb.Compare_ValueInt{ValueInt: 9}}, expect: true},
+		{name: "int not bytewise", value: "10", cmp: &pb.Compare{Target: pb.Compare_VALUE_INT, Result: pb.Compare_LESS, TargetUnion: &pb.Compare_ValueInt{ValueInt: 9}}, expect: false},
+		{name: "int negative", value: "-3", cmp: &pb.Compare{Target: pb.Compare_VALUE_INT, Result: pb.Compare_LESS, TargetUnion: &pb.Compare_ValueInt{ValueInt: 0}}, expect: true},
+		{name: "int equal", value: "42", cmp: &pb.Compare{Target: pb.Compare_VALUE_INT, Result: pb.Compare_EQUAL, TargetUnion: &pb.Compare_ValueInt{ValueInt: 42}}, expect: true},
+		{name: "not an int", value: "abc", cmp: &pb.Compare{Target: pb.Compare_VALUE_INT, Result: pb.Compare_NOT_EQUAL, TargetUnion: &pb.Compare_ValueInt{ValueInt: 0}}, expect: false},
+		{name: "semver greater", value: "3.10.0", cmp: &pb.Compare{Target: pb.Compare_VALUE_SEMVER, Result: pb.Compare_GREATER, TargetUnion: &pb.Compare_ValueSemver{ValueSemver: "3.9.1"}}, expect: true},
+		{name: "semver prefix", value: "v3.6.0", cmp: &pb.Compare{Target: pb.Compare_VALUE_SEMVER, Result: pb.Compare_EQUAL, TargetUnion: &pb.Compare_ValueSemver{ValueSemver: "3.6.0"}}, expect: true},
+		{name: "semver prerelease", value: "3.7.0-alpha.0", cmp: &pb.Compare{Target: pb.Compare_VALUE_SEMVER, Result: pb.Compare_LESS, TargetUnion: &pb.Compare_ValueSemver{ValueSemver: "v3.7.0"}}, expect: true},
+		{name: "not a semver", value: "latest", cmp: &pb.Compare{Target: pb.Compare_VALUE_SEMVER, Result: pb.Compare_NOT_EQUAL, TargetUnion: &pb.Compare_ValueSemver{ValueSemver: "3.7.0"}}, expect: false},
+		{name: "missing key", cmp: &pb.Compare{Target: pb.Compare_VALUE_INT, Result: pb.Compare_LESS, TargetUnion: &pb.Compare_ValueInt{ValueInt: 1}}, expect: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s, lessor := setup(t, testSetup{})
+			if tc.value != "" {
+				s.Put([]byte("foo"), []byte(tc.value), lease.NoLease)
+			}
+			tc.cmp.Key = []byte("foo")
+
+			txn := &pb.TxnRequest{Compare: []*pb.Compare{tc.cmp}}
+			resp, _, err := Txn(t.Context(), zaptest.NewLogger(t), txn, false, s, lessor)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, resp.Succeeded)
+		})
+	}
+}
+
+func TestPutSequenceSuffix(t *testing.T) {
+	s, lessor := setup(t, testSetup{})
+	s.Put([]byte("q/"), []byte("v"), lease.NoLease)
+	rev := s.Rev()
+
+	p := &pb.PutRequest{Key: []byte("q/"), Value: []byte("a"), SequenceSuffix: true, PrevKv: true}
+	resp, _, err := Put(t.Context(), zaptest.NewLogger(t), lessor, s, p)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("q/%020d", rev+1), string(resp.Key))
+	assert.Equal(t, rev+1, resp.Header.Revision)
+	assert.Nil(t, resp.PrevKv)
+
+	// puts in one transaction share its revision
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{
+		{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("q/"), Value: []byte("b"), SequenceSuffix: true}}},
+		{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("r/"), Value: []byte("c"), SequenceSuffix: true}}},
+	}}
+	txnResp, _, err := Txn(t.Context(), zaptest.NewLogger(t), txn, false, s, lessor)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("q/%020d", rev+2), string(txnResp.Responses[0].GetResponsePut().Key))
+	assert.Equal(t, fmt.Sprintf("r/%020d", rev+2), string(txnResp.Responses[1].GetResponsePut().Key))
+
+	rr, err := s.Range(t.Context(), []byte("q/"), []byte("q0"), mvcc.RangeOptions{})
+	require.NoError(t, err)
+	require.Len(t, rr.KVs, 3)
+	assert.Equal(t, "q/", string(rr.KVs[0].Key))
+	assert.Equal(t, "a", string(rr.KVs[1].Value))
+	assert.Equal(t, "b", string(rr.KVs[2].Value))
+}
+
+func TestPutReturnKV(t *testing.T) {
+	s, lessor := setup(t, testSetup{})
+
+	stored := func(key string) *mvccpb.KeyValue {
+		rr, err := s.Range(t.Context(), []byte(key), nil, mvcc.RangeOptions{})
+		require.NoError(t, err)
+		require.Len(t, rr.KVs, 1)
+		return &rr.KVs[0]
+	}
+
+	p := &pb.PutRequest{Key: []byte("foo"), Value: []byte("a"), ReturnKv: true}
+	resp, _, err := Put(t.Context(), zaptest.NewLogger(t), lessor, s, p)
+	require.NoError(t, err)
+	assert.Equal(t, stored("foo"), resp.Kv)
+	assert.Equal(t, int64(1), resp.Kv.Version)
+	assert.Nil(t, resp.PrevKv)
+
+	p = &pb.PutRequest{Key: []byte("foo"), Value: []byte("b"), ReturnKv: true}
+	resp, _, err = Put(t.Context(), zaptest.NewLogger(t), lessor, s, p)
+	require.NoError(t, err)
+	assert.Equal(t, stored("foo"), resp.Kv)
+	assert.Equal(t, int64(2), resp.Kv.Version)
+	assert.Equal(t, resp.Header.Revision, resp.Kv.ModRevision)
+
+	// puts of the same key in one transaction return the versions they store
+	txn := &pb.TxnRequest{Success: []*pb.RequestOp{
+		{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("foo"), Value: []byte("c"), ReturnKv: true}}},
+		{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("foo"), IgnoreValue: true, ReturnKv: true}}},
+		{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Key: []byte("q/"), Value: []byte("d"), SequenceSuffix: true, ReturnKv: true}}},
+	}}
+	txnResp, _, err := Txn(t.Context(), zaptest.NewLogger(t), txn, false, s, lessor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), txnResp.Responses[0].GetResponsePut().Kv.Version)
+	assert.Equal(t, stored("foo"), txnResp.Responses[1].GetResponsePut().Kv)
+	seq := txnResp.Responses[2].GetResponsePut()
+	assert.Equal(t, stored(string(seq.Key)), seq.Kv)
+}
+
+func TestTxnForEach(t *testing.T) {
+	versionIs := func(v int64) *pb.Compare {
+		return &pb.Compare{Target: pb.Compare_VERSION, Result: pb.Compare_EQUAL, TargetUnion: &pb.Compare_Version{Version: v}}
+	}
+	putOp := &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{Value: []byte("new")}}}
+	delOp := &pb.RequestOp{Request: &pb.RequestOp_RequestDeleteRange{RequestDeleteRange: &pb.DeleteRangeRequest{}}}
+	getOp := &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: &pb.RangeRequest{}}}
+
+	tcs := []struct {
+		name    string
+		compare []*pb.Compare
+		ops     []*pb.RequestOp
+		maxKeys int64
+
+		expectError error
+		expectKeys  []string
+		expectKVs   map[string]string
+	}{
+		{
+			name:       "put on every key",
+			ops:        []*pb.RequestOp{putOp},
+			maxKeys:    3,
+			expectKeys: []string{"foo/a", "foo/b", "foo/c"},
+			expectKVs:  map[string]string{"foo/a": "new", "foo/b": "new", "foo/c": "new", "zoo": "old"},
+		},
+		{
+			name:       "put on keys with matching version",
+			compare:    []*pb.Compare{versionIs(1)},
+			ops:        []*pb.RequestOp{putOp},
+			maxKeys:    3,
+			expectKeys: []string{"foo/a", "foo/c"},
+			expectKVs:  map[string]string{"foo/a": "new", "foo/b": "old", "foo/c": "new", "zoo": "old"},
+		},
+		{
+			name:       "delete on keys with matching version",
+			compare:    []*pb.Compare{versionIs(2)},
+			ops:        []*pb.RequestOp{getOp, delOp},
+			maxKeys:    3,
+			expectKeys: []string{"foo/b"},
+			expectKVs:  map[string]string{"foo/a": "old", "foo/c": "old", "zoo": "old"},
+		},
+		{
+			name:        "too many keys",
+			ops:         []*pb.RequestOp{putOp},
+			maxKeys:     2,
+			expectError: errors.ErrForEachTooManyKeys,
+			expectKVs:   map[string]string{"foo/a": "old", "foo/b": "old", "foo/c": "old", "zoo": "old"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s, lessor := setup(t, testSetup{})
+			for _, k := range []string{"foo/a", "foo/b", "foo/c", "zoo"} {
+				s.Put([]byte(k), []byte("old"), lease.NoLease)
+			}
+			s.Put([]byte("foo/b"), []byte("old"), lease.NoLease)
+			rev := s.Rev()
+
+			txn := &pb.TxnRequest{Success: []*pb.RequestOp{{Request: &pb.RequestOp_RequestForEach{RequestForEach: &pb.ForEachRequest{
+				Key:      []byte("foo/"),
+				RangeEnd: []byte("foo0"),
+				MaxKeys:  tc.maxKeys,
+				Compare:  tc.compare,
+				Ops:      tc.ops,
+			}}}}}
+			resp, _, err := Txn(t.Context(), zaptest.NewLogger(t), txn, false, s, lessor)
+			require.ErrorIs(t, err, tc.expectError)
+			if err == nil {
+				fr := resp.Responses[0].GetResponseForEach()
+				require.NotNil(t, fr)
+				assert.Equal(t, int64(3), fr.Count)
+				var keys []string
+				for _, k := range fr.Keys {
+					keys = append(keys, string(k))
+				}
+				assert.Equal(t, tc.expectKeys, keys)
+				assert.Len(t, fr.Responses, len(tc.expectKeys)*len(tc.ops))
+				assert.Equal(t, rev+1, resp.Header.Revision)
+			}
+
+			rr, err := s.Range(t.Context(), []byte("a"), []byte("zzz"), mvcc.RangeOptions{})
+			require.NoError(t, err)
+			kvs := make(map[string]string)
+			for _, kv := range rr.KVs {
+				kvs[string(kv.Key)] = string(kv.Value)
+			}
+			assert.Equal(t, tc.expectKVs, kvs)
+		})
+	}
+}
+
 func TestWriteTxnPanicWithoutApply(t *testing.T) {
 	b, bePath := betesting.NewDefaultTmpBackend(t)
 	s := mvcc.NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, mvcc.StoreConfig{})
@@ -468,6 +817,20 @@ func TestCheckTxnAuth(t *testing.T) {
 			},
 			err: auth.ErrPermissionDenied,
 		},
+		{
+			name: "Sequence put request in range is authorized",
+			txnRequest: &pb.TxnRequest{
+				Success: []*pb.RequestOp{inRangeRequestSequencePut},
+			},
+			err: nil,
+		},
+		{
+			name: "Sequence put request out of range is unauthorized",
+			txnRequest: &pb.TxnRequest{
+				Success: []*pb.RequestOp{outOfRangeRequestSequencePut},
+			},
+			err: auth.ErrPermissionDenied,
+		},
 		{
 			name: "Nil delete request is authorized",
 			txnRequest: &pb.TxnRequest{
@@ -621,6 +984,22 @@ var (
 			},
 		},
 	}
+	inRangeRequestSequencePut = &pb.RequestOp{
+		Request: &pb.RequestOp_RequestPut{
+			RequestPut: &pb.PutRequest{
+				Key:            []byte("foo/"),
+				SequenceSuffix: true,
+			},
+		},
+	}
+	outOfRangeRequestSequencePut = &pb.RequestOp{
+		Request: &pb.RequestOp_RequestPut{
+			RequestPut: &pb.PutRequest{
+				Key:            []byte("zoo"),
+				SequenceSuffix: true,
+			},
+		},
+	}
 	nilRequestRange = &pb.RequestOp{
 		Request: &pb.RequestOp_RequestRange{
 			RequestRange: nil,
@@ -81,6 +81,44 @@ func longestConnected(tp rafthttp.Transporter, membs []types.ID) (types.ID, bool
 	return longest, true
 }
 
+// leadershipAllowedMembers returns the members whose leadership is not
+// disallowed and that are not draining.
+func leadershipAllowedMembers(membs []*membership.Member) []*membership.Member {
+	var allowed []*membership.Member
+	for _, m := range membs {
+		if !m.LeadershipDisallowed && !m.Draining {
+			allowed = append(allowed, m)
+		}
+	}
+	return allowed
+}
+
+// sameLabelMembers returns the IDs of the given members, other than local,
+// that have the same values as local for all the given label keys.
+func sameLabelMembers(local *membership.Member, membs []*membership.Member, keys []string) []types.ID {
+	if local == nil || len(keys) == 0 {
+		return nil
+	}
+	var ids []types.ID
+	for _, m := range membs {
+		if m.ID == local.ID {
+			continue
+		}
+		same := true
+		for _, k := range keys {
+			lv, ok := local.Labels[k]
+			if mv, mok := m.Labels[k]; !ok || !mok || lv != mv {
+				same = false
+				break
+			}
+		}
+		if same {
+			ids = append(ids, m.ID)
+		}
+	}
+	return ids
+}
+
 type notifier struct {
 	c   chan struct{}
 	err error
@@ -16,6 +16,7 @@ package etcdserver
 
 import (
 	"net/http"
+	"reflect"
 	"testing"
 	"time"
 
@@ -61,6 +62,48 @@ func TestLongestConnected(t *testing.T) {
 	}
 }
 
+func TestSameLabelMembers(t *testing.T) {
+	local := &membership.Member{ID: 1, RaftAttributes: membership.RaftAttributes{Labels: map[string]string{"zone": "a", "rack": "r1"}}}
+	membs := []*membership.Member{
+		local,
+		{ID: 2, RaftAttributes: membership.RaftAttributes{Labels: map[string]string{"zone": "a", "rack": "r2"}}},
+		{ID: 3, RaftAttributes: membership.RaftAttributes{Labels: map[string]string{"zone": "b", "rack": "r1"}}},
+		{ID: 4},
+	}
+
+	tests := []struct {
+		keys []string
+		want []types.ID
+	}{
+		{[]string{"zone"}, []types.ID{2}},
+		{[]string{"rack"}, []types.ID{3}},
+		{[]string{"zone", "rack"}, nil},
+		{[]string{"host"}, nil},
+		{nil, nil},
+	}
+	for i, tt := range tests {
+		if got := sameLabelMembers(local, membs, tt.keys); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("#%d: sameLabelMembers(%v) = %v, want %v", i, tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestLeadershipAllowedMembers(t *testing.T) {
+	membs := []*membership.Member{
+		{ID: 1},
+		{ID: 2, RaftAttributes: membership.RaftAttributes{LeadershipDisallowed: true}},
+		{ID: 3},
+		{ID: 4, RaftAttributes: membership.RaftAttributes{Draining: true}},
+	}
+	got := leadershipAllowedMembers(membs)
+	if want := []*membership.Member{membs[0], membs[2]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("leadershipAllowedMembers = %v, want %v", got, want)
+	}
+	if got = leadershipAllowedMembers(membs[1:2]); got != nil {
+		t.Errorf("leadershipAllowedMembers = %v, want nil", got)
+	}
+}
+
 type nopTransporterWithActiveTime struct {
 	activeMap map[types.ID]time.Time
 }
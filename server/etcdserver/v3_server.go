@@ -33,7 +33,9 @@ import (
 	"go.etcd.io/etcd/api/v3/version"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/auth"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/clusterconfig"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
 	apply2 "go.etcd.io/etcd/server/v3/etcdserver/apply"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/etcd/server/v3/etcdserver/txn"
@@ -53,6 +55,10 @@ const (
 	traceThreshold                   = 100 * time.Millisecond
 	readIndexRetryTime               = 500 * time.Millisecond
 
+	// maxTooBusyBackoffFactor bounds the scaling of the backoff suggested
+	// with ErrTooBusy.
+	maxTooBusyBackoffFactor = 10
+
 	// The timeout for the node to catch up its applied index, and is used in
 	// lease related operations, such as LeaseRenew and LeaseTimeToLive.
 	applyTimeout = time.Second
@@ -76,6 +82,10 @@ type Lessor interface {
 	// is returned.
 	LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, error)
 
+	// LeaseFencingToken returns the fencing token of the lease with given ID,
+	// or 0 if the lease is not found.
+	LeaseFencingToken(id lease.LeaseID) int64
+
 	// LeaseTimeToLive retrieves lease information.
 	LeaseTimeToLive(ctx context.Context, r *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error)
 
@@ -83,6 +93,13 @@ type Lessor interface {
 	LeaseLeases(ctx context.Context, r *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error)
 }
 
+type Counter interface {
+	// CounterAdd sends CounterAdd request to raft and toApply it after committed.
+	CounterAdd(ctx context.Context, r *pb.CounterAddRequest) (*pb.CounterAddResponse, error)
+	// CounterGet retrieves the values of counters.
+	CounterGet(ctx context.Context, r *pb.CounterGetRequest) (*pb.CounterGetResponse, error)
+}
+
 type Authenticator interface {
 	AuthEnable(ctx context.Context, r *pb.AuthEnableRequest) (*pb.AuthEnableResponse, error)
 	AuthDisable(ctx context.Context, r *pb.AuthDisableRequest) (*pb.AuthDisableResponse, error)
@@ -91,6 +108,7 @@ type Authenticator interface {
 	UserAdd(ctx context.Context, r *pb.AuthUserAddRequest) (*pb.AuthUserAddResponse, error)
 	UserDelete(ctx context.Context, r *pb.AuthUserDeleteRequest) (*pb.AuthUserDeleteResponse, error)
 	UserChangePassword(ctx context.Context, r *pb.AuthUserChangePasswordRequest) (*pb.AuthUserChangePasswordResponse, error)
+	UserRotatePassword(ctx context.Context, r *pb.AuthUserRotatePasswordRequest) (*pb.AuthUserRotatePasswordResponse, error)
 	UserGrantRole(ctx context.Context, r *pb.AuthUserGrantRoleRequest) (*pb.AuthUserGrantRoleResponse, error)
 	UserGet(ctx context.Context, r *pb.AuthUserGetRequest) (*pb.AuthUserGetResponse, error)
 	UserRevokeRole(ctx context.Context, r *pb.AuthUserRevokeRoleRequest) (*pb.AuthUserRevokeRoleResponse, error)
@@ -98,6 +116,8 @@ type Authenticator interface {
 	RoleGrantPermission(ctx context.Context, r *pb.AuthRoleGrantPermissionRequest) (*pb.AuthRoleGrantPermissionResponse, error)
 	RoleGet(ctx context.Context, r *pb.AuthRoleGetRequest) (*pb.AuthRoleGetResponse, error)
 	RoleRevokePermission(ctx context.Context, r *pb.AuthRoleRevokePermissionRequest) (*pb.AuthRoleRevokePermissionResponse, error)
+	RoleGrantRPCPermission(ctx context.Context, r *pb.AuthRoleGrantRPCPermissionRequest) (*pb.AuthRoleGrantRPCPermissionResponse, error)
+	RoleRevokeRPCPermission(ctx context.Context, r *pb.AuthRoleRevokeRPCPermissionRequest) (*pb.AuthRoleRevokeRPCPermissionResponse, error)
 	RoleDelete(ctx context.Context, r *pb.AuthRoleDeleteRequest) (*pb.AuthRoleDeleteResponse, error)
 	UserList(ctx context.Context, r *pb.AuthUserListRequest) (*pb.AuthUserListResponse, error)
 	RoleList(ctx context.Context, r *pb.AuthRoleListRequest) (*pb.AuthRoleListResponse, error)
@@ -133,8 +153,20 @@ func (s *EtcdServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeRe
 		trace.LogIfLong(traceThreshold)
 	}(time.Now())
 
-	if !r.Serializable {
-		err = s.linearizableReadNotify(ctx)
+	timings := requestTimingsFromContext(ctx)
+	timings.queued()
+	if r.Serializable {
+		if err = s.checkStaleRead(); err != nil {
+			return nil, err
+		}
+		if r.MinAppliedIndex > s.getAppliedIndex() {
+			err = errors.ErrAppliedIndexBehind
+			return nil, err
+		}
+	} else {
+		start := time.Now()
+		err = s.rangeReadNotify(ctx, r.ReadConsistency)
+		timings.addRaftCommit(time.Since(start))
 		trace.Step("agreement among raft nodes before linearized reading")
 		if err != nil {
 			return nil, err
@@ -145,10 +177,30 @@ func (s *EtcdServer) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeRe
 	}
 
 	get := func() { resp, _, err = txn.Range(ctx, s.Logger(), s.KV(), r) }
+	if s.readCache.cacheable(r) {
+		get = func() {
+			var fill *readCacheFill
+			if resp, fill = s.readCache.get(r.Key); resp != nil {
+				return
+			}
+			var cached *pb.RangeResponse
+			if resp, _, err = txn.Range(ctx, s.Logger(), s.KV(), r); err == nil {
+				cached = resp
+			}
+			s.readCache.put(r.Key, fill, cached)
+		}
+	}
+	// entries are applied to the backend before the applied index is
+	// updated, so the response reflects at least the index loaded here
+	appliedIndex := s.getAppliedIndex()
 	if serr := s.doSerialize(ctx, chk, get); serr != nil {
 		err = serr
 		return nil, err
 	}
+	if err == nil {
+		resp.AppliedIndex = appliedIndex
+		s.hotKeys.recordRange(r, resp)
+	}
 	return resp, err
 }
 
@@ -164,7 +216,9 @@ func (s *EtcdServer) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse
 	if err != nil {
 		return nil, err
 	}
-	return resp.(*pb.PutResponse), nil
+	putResp := resp.(*pb.PutResponse)
+	s.hotKeys.recordPut(r, putResp)
+	return putResp, nil
 }
 
 func (s *EtcdServer) DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error) {
@@ -179,6 +233,7 @@ func (s *EtcdServer) DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest)
 	if err != nil {
 		return nil, err
 	}
+	s.hotKeys.recordWrite(r.Key, len(r.Key))
 	return resp.(*pb.DeleteRangeResponse), nil
 }
 
@@ -202,8 +257,16 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 		traceutil.Field{Key: "read_only", Value: readOnly},
 	)
 	if readOnly {
-		if !txn.IsTxnSerializable(r) {
+		timings := requestTimingsFromContext(ctx)
+		timings.queued()
+		if txn.IsTxnSerializable(r) {
+			if err := s.checkStaleRead(); err != nil {
+				return nil, err
+			}
+		} else {
+			start := time.Now()
 			err := s.linearizableReadNotify(ctx)
+			timings.addRaftCommit(time.Since(start))
 			trace.Step("agreement among raft nodes before linearized reading")
 			if err != nil {
 				return nil, err
@@ -226,6 +289,9 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 		if serr := s.doSerialize(ctx, chk, get); serr != nil {
 			return nil, serr
 		}
+		if err == nil {
+			s.hotKeys.recordTxn(r, resp)
+		}
 		return resp, err
 	}
 
@@ -234,7 +300,9 @@ func (s *EtcdServer) Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse
 	if err != nil {
 		return nil, err
 	}
-	return resp.(*pb.TxnResponse), nil
+	txnResp := resp.(*pb.TxnResponse)
+	s.hotKeys.recordTxn(r, txnResp)
+	return txnResp, nil
 }
 
 func (s *EtcdServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error) {
@@ -290,6 +358,9 @@ func (s *EtcdServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.
 }
 
 func (s *EtcdServer) LeaseGrant(ctx context.Context, r *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	if s.Draining() {
+		return nil, errors.ErrMemberDraining
+	}
 	// no id given? choose one
 	for r.ID == int64(lease.NoLease) {
 		// only use positive int64 id's
@@ -321,6 +392,21 @@ func (s *EtcdServer) waitAppliedIndex() error {
 	return nil
 }
 
+func (s *EtcdServer) LeaseFencingToken(id lease.LeaseID) int64 {
+	l := s.lessor.Lookup(id)
+	if l == nil {
+		// the lease may have been renewed by the leader before this member
+		// applied its grant.
+		if err := s.waitAppliedIndex(); err != nil {
+			return 0
+		}
+		if l = s.lessor.Lookup(id); l == nil {
+			return 0
+		}
+	}
+	return l.FencingToken()
+}
+
 func (s *EtcdServer) LeaseRevoke(ctx context.Context, r *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
 	var span trace.Span
 	ctx, span = traceutil.Tracer.Start(ctx, "lease_revoke", trace.WithAttributes(
@@ -335,6 +421,20 @@ func (s *EtcdServer) LeaseRevoke(ctx context.Context, r *pb.LeaseRevokeRequest)
 	return resp.(*pb.LeaseRevokeResponse), nil
 }
 
+// leaseExpire revokes the expired lease of the given ID. The expiry is
+// recorded under the lease expiry event prefix of the cluster configuration
+// if it is set; otherwise the lease is revoked as by LeaseRevoke, which
+// members older than 3.7 understand.
+func (s *EtcdServer) leaseExpire(ctx context.Context, id int64) error {
+	r := &pb.LeaseRevokeRequest{ID: id}
+	req := pb.InternalRaftRequest{LeaseRevoke: r}
+	if s.clusterConfig != nil && len(s.clusterConfig.Config().LeaseExpiryEventPrefix) > 0 {
+		req = pb.InternalRaftRequest{LeaseExpire: r}
+	}
+	_, err := s.raftRequestOnce(ctx, req)
+	return err
+}
+
 func (s *EtcdServer) LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, error) {
 	var span trace.Span
 	ctx, span = traceutil.Tracer.Start(ctx, "lease_renew", trace.WithAttributes(
@@ -550,6 +650,108 @@ func (s *EtcdServer) Alarm(ctx context.Context, r *pb.AlarmRequest) (*pb.AlarmRe
 	return resp.(*pb.AlarmResponse), nil
 }
 
+func (s *EtcdServer) CompactionHoldGrant(ctx context.Context, r *pb.CompactionHoldGrantRequest) (*pb.CompactionHoldGrantResponse, error) {
+	maxHold := s.Cfg.CompactionMaxHold
+	if maxHold <= 0 {
+		return nil, v3compactor.ErrHoldsDisabled
+	}
+	rev := r.Revision
+	if rev == 0 {
+		// hold the latest revision of the cluster rather than the one of the
+		// member, which may lag behind
+		if err := s.linearizableReadNotify(ctx); err != nil {
+			return nil, err
+		}
+		rev = s.KV().Rev()
+	}
+	if rev > s.KV().Rev() {
+		return nil, mvcc.ErrFutureRev
+	}
+	if rev < s.KV().FirstRev() {
+		return nil, mvcc.ErrCompacted
+	}
+	// the TTL is bounded here rather than when applied, so that members with
+	// different max hold durations agree on it.
+	ttl := max(int64(maxHold/time.Second), 1)
+	if r.TTL > 0 && r.TTL < ttl {
+		ttl = r.TTL
+	}
+	h := &pb.InternalCompactionHoldGrantRequest{
+		ID:        r.ID,
+		Name:      r.Name,
+		Revision:  rev,
+		TTL:       ttl,
+		GrantTime: time.Now().UnixNano(),
+	}
+	for h.ID == 0 {
+		// only use positive int64 id's
+		h.ID = int64(s.reqIDGen.Next() & ((1 << 63) - 1))
+	}
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{CompactionHoldGrant: h})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.CompactionHoldGrantResponse), nil
+}
+
+func (s *EtcdServer) CompactionHoldRevoke(ctx context.Context, r *pb.CompactionHoldRevokeRequest) (*pb.CompactionHoldRevokeResponse, error) {
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{CompactionHoldRevoke: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.CompactionHoldRevokeResponse), nil
+}
+
+func (s *EtcdServer) ClusterConfigSet(ctx context.Context, r *pb.ClusterConfigSetRequest) (*pb.ClusterConfigSetResponse, error) {
+	if err := clusterconfig.Validate(r.Config); err != nil {
+		return nil, err
+	}
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{ClusterConfigSet: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ClusterConfigSetResponse), nil
+}
+
+func (s *EtcdServer) CounterAdd(ctx context.Context, r *pb.CounterAddRequest) (*pb.CounterAddResponse, error) {
+	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{CounterAdd: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.CounterAddResponse), nil
+}
+
+func (s *EtcdServer) CounterGet(ctx context.Context, r *pb.CounterGetRequest) (*pb.CounterGetResponse, error) {
+	if r.Serializable {
+		if err := s.checkStaleRead(); err != nil {
+			return nil, err
+		}
+	} else if err := s.linearizableReadNotify(ctx); err != nil {
+		return nil, err
+	}
+	var resp *pb.CounterGetResponse
+	chk := func(ai *auth.AuthInfo) error {
+		return s.authStore.IsRangePermitted(ai, r.Key, r.RangeEnd)
+	}
+	get := func() {
+		resp = &pb.CounterGetResponse{Header: &pb.ResponseHeader{}, Counters: s.counters.Get(r.Key, r.RangeEnd)}
+	}
+	if err := s.doSerialize(ctx, chk, get); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ClusterConfig returns the cluster-wide configuration.
+func (s *EtcdServer) ClusterConfig() *pb.ClusterConfig {
+	return s.clusterConfig.Config()
+}
+
+// CompactionHolds returns the compaction holds that have not expired.
+func (s *EtcdServer) CompactionHolds() []*pb.CompactionHold {
+	return s.compactionHolds.Holds(time.Now())
+}
+
 func (s *EtcdServer) AuthEnable(ctx context.Context, r *pb.AuthEnableRequest) (*pb.AuthEnableResponse, error) {
 	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{AuthEnable: r})
 	if err != nil {
@@ -630,12 +832,24 @@ func (s *EtcdServer) Authenticate(ctx context.Context, r *pb.AuthenticateRequest
 
 func (s *EtcdServer) UserAdd(ctx context.Context, r *pb.AuthUserAddRequest) (*pb.AuthUserAddResponse, error) {
 	if r.Options == nil || !r.Options.NoPassword {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(r.Password), s.authStore.BcryptCost())
-		if err != nil {
-			return nil, err
+		if r.Password == "" && r.HashedPassword != "" {
+			// the password hash was exported from another user, e.g. of
+			// another cluster, so the password policy cannot be checked.
+			if err := auth.CheckPasswordHash(r.HashedPassword); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := s.authStore.PasswordPolicy().Check(r.Password); err != nil {
+				return nil, err
+			}
+			hashedPassword, err := bcrypt.GenerateFromPassword([]byte(r.Password), s.authStore.BcryptCost())
+			if err != nil {
+				return nil, err
+			}
+			r.HashedPassword = base64.StdEncoding.EncodeToString(hashedPassword)
+			r.Password = ""
 		}
-		r.HashedPassword = base64.StdEncoding.EncodeToString(hashedPassword)
-		r.Password = ""
+		r.PasswordChangedTime = time.Now().Unix()
 	}
 
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{AuthUserAdd: r})
@@ -655,12 +869,21 @@ func (s *EtcdServer) UserDelete(ctx context.Context, r *pb.AuthUserDeleteRequest
 
 func (s *EtcdServer) UserChangePassword(ctx context.Context, r *pb.AuthUserChangePasswordRequest) (*pb.AuthUserChangePasswordResponse, error) {
 	if r.Password != "" {
+		if err := s.authStore.PasswordPolicy().Check(r.Password); err != nil {
+			return nil, err
+		}
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(r.Password), s.authStore.BcryptCost())
 		if err != nil {
 			return nil, err
 		}
 		r.HashedPassword = base64.StdEncoding.EncodeToString(hashedPassword)
 		r.Password = ""
+		r.PasswordChangedTime = time.Now().Unix()
+	} else if r.HashedPassword != "" {
+		if err := auth.CheckPasswordHash(r.HashedPassword); err != nil {
+			return nil, err
+		}
+		r.PasswordChangedTime = time.Now().Unix()
 	}
 
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{AuthUserChangePassword: r})
@@ -670,6 +893,37 @@ func (s *EtcdServer) UserChangePassword(ctx context.Context, r *pb.AuthUserChang
 	return resp.(*pb.AuthUserChangePasswordResponse), nil
 }
 
+// UserRotatePassword replaces the password of a user with a random one
+// satisfying the password policy. The new password is only returned by the
+// response, as it is only stored hashed.
+func (s *EtcdServer) UserRotatePassword(ctx context.Context, r *pb.AuthUserRotatePasswordRequest) (*pb.AuthUserRotatePasswordResponse, error) {
+	password, err := s.authStore.PasswordPolicy().GeneratePassword()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), s.authStore.BcryptCost())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{AuthUserChangePassword: &pb.AuthUserChangePasswordRequest{
+		Name:                r.Name,
+		HashedPassword:      base64.StdEncoding.EncodeToString(hashedPassword),
+		PasswordChangedTime: time.Now().Unix(),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	// the password of a user without password is left unset, checked once
+	// applied so that the user is not revealed to unauthorized requests
+	if !s.authStore.HasPassword(r.Name) {
+		return nil, auth.ErrNoPasswordUser
+	}
+	return &pb.AuthUserRotatePasswordResponse{
+		Header:   resp.(*pb.AuthUserChangePasswordResponse).Header,
+		Password: password,
+	}, nil
+}
+
 func (s *EtcdServer) UserGrantRole(ctx context.Context, r *pb.AuthUserGrantRoleRequest) (*pb.AuthUserGrantRoleResponse, error) {
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{AuthUserGrantRole: r})
 	if err != nil {
@@ -742,6 +996,22 @@ func (s *EtcdServer) RoleRevokePermission(ctx context.Context, r *pb.AuthRoleRev
 	return resp.(*pb.AuthRoleRevokePermissionResponse), nil
 }
 
+func (s *EtcdServer) RoleGrantRPCPermission(ctx context.Context, r *pb.AuthRoleGrantRPCPermissionRequest) (*pb.AuthRoleGrantRPCPermissionResponse, error) {
+	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{AuthRoleGrantRpcPermission: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.AuthRoleGrantRPCPermissionResponse), nil
+}
+
+func (s *EtcdServer) RoleRevokeRPCPermission(ctx context.Context, r *pb.AuthRoleRevokeRPCPermissionRequest) (*pb.AuthRoleRevokeRPCPermissionResponse, error) {
+	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{AuthRoleRevokeRpcPermission: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.AuthRoleRevokeRPCPermissionResponse), nil
+}
+
 func (s *EtcdServer) RoleDelete(ctx context.Context, r *pb.AuthRoleDeleteRequest) (*pb.AuthRoleDeleteResponse, error) {
 	resp, err := s.raftRequest(ctx, pb.InternalRaftRequest{AuthRoleDelete: r})
 	if err != nil {
@@ -791,7 +1061,9 @@ func (s *EtcdServer) doSerialize(ctx context.Context, chk func(*auth.AuthInfo) e
 	}
 	trace.Step("get authentication metadata")
 	// fetch response for serialized request
+	start := time.Now()
 	get()
+	requestTimingsFromContext(ctx).addBackendRead(time.Since(start))
 	// check for stale token revision in case the auth store was updated while
 	// the request has been handled.
 	if ai.Revision != 0 && ai.Revision != s.authStore.Revision() {
@@ -806,9 +1078,15 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 	if ci > ai+maxGapBetweenApplyAndCommitIndex {
 		return nil, errors.ErrTooManyRequests
 	}
+	if threshold := s.Cfg.TooBusyApplyBacklog; threshold > 0 && ci > ai+threshold && isLowPriority(ctx) {
+		proposalsRejectedTooBusy.Inc()
+		return nil, &errors.TooBusyError{Backoff: s.tooBusyBackoff(ci - ai)}
+	}
 
 	r.Header = &pb.RequestHeader{
-		ID: s.reqIDGen.Next(),
+		ID:            s.reqIDGen.Next(),
+		ElectionLease: electionLease(ctx),
+		FenceToken:    fenceToken(ctx),
 	}
 
 	// check authinfo if it is not InternalAuthenticateRequest
@@ -823,6 +1101,10 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 		}
 	}
 
+	if err := s.checkDowngradeGate(&r); err != nil {
+		return nil, err
+	}
+
 	data, err := r.Marshal()
 	if err != nil {
 		return nil, err
@@ -850,13 +1132,19 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 		s.w.Trigger(id, nil) // GC wait
 		return nil, err
 	}
+	timings := requestTimingsFromContext(ctx)
+	timings.queued()
+	proposed := time.Now()
 	proposalsPending.Inc()
 	defer proposalsPending.Dec()
 
 	select {
 	case x := <-ch:
 		span.AddEvent("Receive raft result")
-		return x.(*apply2.Result), nil
+		result := x.(*apply2.Result)
+		timings.addApply(result.Took)
+		timings.addRaftCommit(time.Since(proposed) - result.Took)
+		return result, nil
 	case <-cctx.Done():
 		proposalsFailed.Inc()
 		s.w.Trigger(id, nil) // GC wait
@@ -866,6 +1154,42 @@ func (s *EtcdServer) processInternalRaftRequestOnce(ctx context.Context, r pb.In
 	}
 }
 
+type lowPriorityKey struct{}
+
+// WithLowPriority marks the requests proposed with ctx as low priority. They
+// are rejected with errors.ErrTooBusy instead of being queued while the apply
+// backlog is over Cfg.TooBusyApplyBacklog.
+func WithLowPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lowPriorityKey{}, true)
+}
+
+func isLowPriority(ctx context.Context) bool {
+	low, _ := ctx.Value(lowPriorityKey{}).(bool)
+	return low
+}
+
+type electionLeaseKey struct{}
+
+// WithElectionLease attaches to the requests proposed with ctx the lease
+// proving they come from a candidate of the election guarding a protected
+// prefix.
+func WithElectionLease(ctx context.Context, id int64) context.Context {
+	return context.WithValue(ctx, electionLeaseKey{}, id)
+}
+
+func electionLease(ctx context.Context) int64 {
+	id, _ := ctx.Value(electionLeaseKey{}).(int64)
+	return id
+}
+
+// tooBusyBackoff returns the backoff suggested to rejected low priority
+// requests, scaled by how far the backlog is over the threshold so that
+// clients back off harder the further the server falls behind.
+func (s *EtcdServer) tooBusyBackoff(backlog uint64) time.Duration {
+	factor := min(backlog/s.Cfg.TooBusyApplyBacklog, maxTooBusyBackoffFactor)
+	return s.Cfg.TooBusyBackoff * time.Duration(factor)
+}
+
 // Watchable returns a watchable interface attached to the etcdserver.
 func (s *EtcdServer) Watchable() mvcc.WatchableKV { return s.KV() }
 
@@ -901,6 +1225,7 @@ func (s *EtcdServer) linearizableReadLoop() {
 		}
 
 		trace.Step("read index received")
+		s.staleReads.observe(confirmedIndex)
 
 		trace.AddField(traceutil.Field{Key: "readStateIndex", Value: confirmedIndex})
 
@@ -1027,7 +1352,77 @@ func (s *EtcdServer) LinearizableReadNotify(ctx context.Context) error {
 	return s.linearizableReadNotify(ctx)
 }
 
+// linearizableReadNotify waits until the member can serve linearizable reads,
+// using the read consistency configured on the server.
 func (s *EtcdServer) linearizableReadNotify(ctx context.Context) error {
+	if s.Cfg.LeaseReads {
+		return s.leaseReadNotify(ctx)
+	}
+	return s.readIndexNotify(ctx)
+}
+
+func (s *EtcdServer) rangeReadNotify(ctx context.Context, rc pb.RangeRequest_ReadConsistency) error {
+	switch rc {
+	case pb.RangeRequest_READ_INDEX:
+		return s.readIndexNotify(ctx)
+	case pb.RangeRequest_LEASE:
+		return s.leaseReadNotify(ctx)
+	default:
+		return s.linearizableReadNotify(ctx)
+	}
+}
+
+// leaseReadNotify waits until the member has applied the commit index of the
+// leader, without confirming the leadership with a quorum. Members that cannot
+// serve reads from the leader lease fall back to ReadIndex.
+func (s *EtcdServer) leaseReadNotify(ctx context.Context) error {
+	index, ok := s.leaseReadIndex()
+	if !ok {
+		leaseReads.WithLabelValues("read_index").Inc()
+		return s.readIndexNotify(ctx)
+	}
+	leaseReads.WithLabelValues("lease").Inc()
+	if s.getAppliedIndex() >= index {
+		return nil
+	}
+	select {
+	case <-s.applyWait.Wait(index):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.done:
+		return errors.ErrStopped
+	}
+}
+
+// leaseReadIndex returns the commit index if the member is the leader and
+// holds the leader lease. With check quorum, the followers reject votes for
+// an election timeout after hearing from the leader, and the leader steps
+// down if it does not hear from a quorum within an election timeout, so no
+// other leader commits entries while it leads, given bounded clock drift.
+//
+// Like raft's ReadOnlyLeaseBased, the lease is not used until an entry of
+// the current term is committed, as the commit index of a new leader may be
+// behind the one of the previous leader, nor during a leadership transfer,
+// as the transferee campaigns without waiting for the lease to expire.
+func (s *EtcdServer) leaseReadIndex() (uint64, bool) {
+	if !s.Cfg.CheckQuorum {
+		return 0, false
+	}
+	st := s.r.Status()
+	if st.RaftState != raft.StateLeader || st.LeadTransferee != raft.None {
+		return 0, false
+	}
+	term, err := s.r.raftStorage.Term(st.Commit)
+	if err != nil || term != st.Term {
+		return 0, false
+	}
+	return st.Commit, true
+}
+
+// readIndexNotify waits until the member has applied the index confirmed by
+// a ReadIndex round trip to a quorum of members.
+func (s *EtcdServer) readIndexNotify(ctx context.Context) error {
 	s.readMu.RLock()
 	nc := s.readNotifier
 	s.readMu.RUnlock()
@@ -1069,6 +1464,8 @@ func (s *EtcdServer) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb
 		return s.downgradeEnable(ctx, r)
 	case pb.DowngradeRequest_CANCEL:
 		return s.downgradeCancel(ctx)
+	case pb.DowngradeRequest_STATUS:
+		return s.downgradeStatus()
 	default:
 		return nil, errors.ErrUnknownMethod
 	}
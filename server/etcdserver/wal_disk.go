@@ -0,0 +1,114 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+)
+
+// walDiskCheckInterval is the interval at which the free disk space of the
+// WAL is checked against the threshold.
+const walDiskCheckInterval = 5 * time.Second
+
+// WALDiskLow reports whether the free disk space of the WAL is under the
+// threshold, in which case writes are rejected before being proposed.
+func (s *EtcdServer) WALDiskLow() bool {
+	return s.walDiskLow.Load()
+}
+
+// monitorWALDisk checks the free disk space of the WAL. The backend quota
+// does not account the WAL, which can fill its disk and crash the member on
+// a failed fsync. Once the free space is under the threshold, the member
+// raises the WALNOSPACE alarm so that the cluster rejects writes while reads
+// keep being served; it clears the alarm once the space is reclaimed.
+func (s *EtcdServer) monitorWALDisk() {
+	threshold := s.Cfg.WALDiskFreeThreshold
+	if threshold == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(walDiskCheckInterval)
+	defer ticker.Stop()
+
+	lg := s.Logger()
+	dir := s.Cfg.WALDir()
+	for {
+		free, err := fileutil.DiskFree(dir)
+		if err != nil {
+			lg.Warn("failed to get free disk space of the WAL; stopped monitoring it", zap.String("wal-dir", dir), zap.Error(err))
+			return
+		}
+		walDiskFreeBytes.Set(float64(free))
+
+		low := free < threshold
+		if low != s.walDiskLow.Swap(low) {
+			fields := []zap.Field{
+				zap.String("wal-dir", dir),
+				zap.String("free", humanize.Bytes(free)),
+				zap.String("threshold", humanize.Bytes(threshold)),
+			}
+			if low {
+				lg.Warn("free disk space of the WAL is under the threshold; rejecting writes", fields...)
+			} else {
+				lg.Info("free disk space of the WAL is over the threshold; accepting writes", fields...)
+			}
+		}
+		if low != s.walNoSpaceAlarmed() {
+			s.alarmWALNoSpace(low)
+		}
+
+		select {
+		case <-s.stopping:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// walNoSpaceAlarmed reports whether the WALNOSPACE alarm of the member is
+// active.
+func (s *EtcdServer) walNoSpaceAlarmed() bool {
+	for _, m := range s.alarmStore.Get(pb.AlarmType_WALNOSPACE) {
+		if m.MemberID == uint64(s.MemberID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// alarmWALNoSpace activates or deactivates the WALNOSPACE alarm of the member.
+// Failures are retried at the next check.
+func (s *EtcdServer) alarmWALNoSpace(activate bool) {
+	a := &pb.AlarmRequest{
+		MemberID: uint64(s.MemberID()),
+		Action:   pb.AlarmRequest_DEACTIVATE,
+		Alarm:    pb.AlarmType_WALNOSPACE,
+	}
+	if activate {
+		a.Action = pb.AlarmRequest_ACTIVATE
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	if _, err := s.raftRequest(ctx, pb.InternalRaftRequest{Alarm: a}); err != nil {
+		s.Logger().Warn("failed to update the WALNOSPACE alarm", zap.Stringer("action", a.Action), zap.Error(err))
+	}
+}
@@ -32,6 +32,12 @@ type Lease struct {
 	expiryMu sync.RWMutex
 	// expiry is time when lease should expire. no expiration when expiry.IsZero() is true
 	expiry time.Time
+	// createTime is the time the lease was granted, or loaded from the
+	// backend by this member
+	createTime time.Time
+	// fencingToken is given to the lease when granted, greater than the
+	// tokens of the leases granted before it.
+	fencingToken int64
 
 	// mu protects concurrent accesses to itemSet
 	mu      sync.RWMutex
@@ -41,10 +47,11 @@ type Lease struct {
 
 func NewLease(id LeaseID, ttl int64) *Lease {
 	return &Lease{
-		ID:      id,
-		ttl:     ttl,
-		itemSet: make(map[LeaseItem]struct{}),
-		revokec: make(chan struct{}),
+		ID:         id,
+		ttl:        ttl,
+		createTime: time.Now(),
+		itemSet:    make(map[LeaseItem]struct{}),
+		revokec:    make(chan struct{}),
 	}
 }
 
@@ -53,7 +60,7 @@ func (l *Lease) expired() bool {
 }
 
 func (l *Lease) persistTo(b backend.Backend) {
-	lpb := leasepb.Lease{ID: int64(l.ID), TTL: l.ttl, RemainingTTL: l.remainingTTL}
+	lpb := leasepb.Lease{ID: int64(l.ID), TTL: l.ttl, RemainingTTL: l.remainingTTL, FencingToken: l.fencingToken}
 	tx := b.BatchTx()
 	tx.LockInsideApply()
 	defer tx.Unlock()
@@ -65,6 +72,18 @@ func (l *Lease) TTL() int64 {
 	return l.ttl
 }
 
+// FencingToken returns the fencing token of the Lease. Leases granted before
+// the cluster was on v3.7 have none, which is 0.
+func (l *Lease) FencingToken() int64 {
+	return l.fencingToken
+}
+
+// CreateTime returns the time the lease was granted. Leases loaded from the
+// backend report the time they were loaded by this member.
+func (l *Lease) CreateTime() time.Time {
+	return l.createTime
+}
+
 // SetLeaseItem sets the given lease item, this func is thread-safe
 func (l *Lease) SetLeaseItem(item LeaseItem) {
 	l.mu.Lock()
@@ -113,6 +132,13 @@ func (l *Lease) Keys() []string {
 	return keys
 }
 
+// itemCount returns the number of items attached to the lease.
+func (l *Lease) itemCount() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.itemSet)
+}
+
 // Remaining returns the remaining time of the lease.
 func (l *Lease) Remaining() time.Duration {
 	l.expiryMu.RLock()
@@ -133,3 +159,40 @@ type leasesByExpiry []*Lease
 func (le leasesByExpiry) Len() int           { return len(le) }
 func (le leasesByExpiry) Less(i, j int) bool { return le[i].Remaining() < le[j].Remaining() }
 func (le leasesByExpiry) Swap(i, j int)      { le[i], le[j] = le[j], le[i] }
+
+// leasesBySize sorts leases by the second in which they expire, and the
+// leases expiring in the same second by their number of attached items.
+type leasesBySize struct {
+	leases []*Lease
+	// windows and items are the expiry seconds and item counts of the leases.
+	windows []time.Duration
+	items   []int
+}
+
+func newLeasesBySize(leases []*Lease) *leasesBySize {
+	ls := &leasesBySize{
+		leases:  leases,
+		windows: make([]time.Duration, len(leases)),
+		items:   make([]int, len(leases)),
+	}
+	for i, l := range leases {
+		ls.windows[i] = l.Remaining().Truncate(time.Second)
+		ls.items[i] = l.itemCount()
+	}
+	return ls
+}
+
+func (ls *leasesBySize) Len() int { return len(ls.leases) }
+
+func (ls *leasesBySize) Less(i, j int) bool {
+	if ls.windows[i] != ls.windows[j] {
+		return ls.windows[i] < ls.windows[j]
+	}
+	return ls.items[i] < ls.items[j]
+}
+
+func (ls *leasesBySize) Swap(i, j int) {
+	ls.leases[i], ls.leases[j] = ls.leases[j], ls.leases[i]
+	ls.windows[i], ls.windows[j] = ls.windows[j], ls.windows[i]
+	ls.items[i], ls.items[j] = ls.items[j], ls.items[i]
+}
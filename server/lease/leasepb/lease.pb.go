@@ -26,9 +26,11 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Lease struct {
-	ID                   int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	TTL                  int64    `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
-	RemainingTTL         int64    `protobuf:"varint,3,opt,name=RemainingTTL,proto3" json:"RemainingTTL,omitempty"`
+	ID           int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	TTL          int64 `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	RemainingTTL int64 `protobuf:"varint,3,opt,name=RemainingTTL,proto3" json:"RemainingTTL,omitempty"`
+	// FencingToken is the fencing token given to the lease when granted.
+	FencingToken         int64    `protobuf:"varint,4,opt,name=FencingToken,proto3" json:"FencingToken,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -156,25 +158,26 @@ func init() {
 func init() { proto.RegisterFile("lease.proto", fileDescriptor_3dd57e402472b33a) }
 
 var fileDescriptor_3dd57e402472b33a = []byte{
-	// 283 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xce, 0x49, 0x4d, 0x2c,
-	0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x07, 0x73, 0x0a, 0x92, 0xa4, 0x44, 0xd2,
-	0xf3, 0xd3, 0xf3, 0xc1, 0x62, 0xfa, 0x20, 0x16, 0x44, 0x5a, 0x4a, 0x3e, 0xb5, 0x24, 0x39, 0x45,
-	0x3f, 0xb1, 0x20, 0x53, 0x1f, 0xc4, 0x28, 0x4e, 0x2d, 0x2a, 0x4b, 0x2d, 0x2a, 0x48, 0xd2, 0x2f,
-	0x2a, 0x48, 0x86, 0x28, 0x50, 0xf2, 0xe5, 0x62, 0xf5, 0x01, 0x99, 0x20, 0xc4, 0xc7, 0xc5, 0xe4,
-	0xe9, 0x22, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x1c, 0xc4, 0xe4, 0xe9, 0x22, 0x24, 0xc0, 0xc5, 0x1c,
-	0x12, 0xe2, 0x23, 0xc1, 0x04, 0x16, 0x00, 0x31, 0x85, 0x94, 0xb8, 0x78, 0x82, 0x52, 0x73, 0x13,
-	0x33, 0xf3, 0x32, 0xf3, 0xd2, 0x41, 0x52, 0xcc, 0x60, 0x29, 0x14, 0x31, 0xa5, 0x12, 0x2e, 0x11,
-	0xb0, 0x71, 0x9e, 0x79, 0x25, 0xa9, 0x45, 0x79, 0x89, 0x39, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5,
-	0x25, 0x42, 0x31, 0x5c, 0x62, 0x60, 0xf1, 0x90, 0xcc, 0xdc, 0xd4, 0x90, 0x7c, 0x9f, 0xcc, 0xb2,
-	0x54, 0xa8, 0x0c, 0xd8, 0x46, 0x6e, 0x23, 0x15, 0x3d, 0x64, 0xf7, 0xe9, 0x61, 0x57, 0x1b, 0x84,
-	0xc3, 0x0c, 0xa5, 0x0a, 0x2e, 0x51, 0x34, 0x5b, 0x8b, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xe2,
-	0xb9, 0xc4, 0x31, 0xb4, 0x40, 0xa4, 0xa0, 0xf6, 0xaa, 0x12, 0xb0, 0x17, 0xa2, 0x38, 0x08, 0x97,
-	0x29, 0x4e, 0x9e, 0x27, 0x1e, 0xca, 0x31, 0x5c, 0x78, 0x28, 0xc7, 0x70, 0xe2, 0x91, 0x1c, 0xe3,
-	0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xce, 0x78, 0x2c, 0xc7, 0x10, 0xa5, 0x9f, 0x9e,
-	0x0f, 0x36, 0x5b, 0x2f, 0x33, 0x1f, 0x1c, 0xf6, 0xfa, 0x10, 0x4b, 0xf4, 0xcb, 0x8c, 0xf5, 0xc1,
-	0x51, 0xa6, 0x0f, 0x8d, 0x38, 0x6b, 0x28, 0x9d, 0xc4, 0x06, 0x8e, 0x10, 0x63, 0x40, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x0e, 0x16, 0x3b, 0xc4, 0xdf, 0x01, 0x00, 0x00,
+	// 298 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x51, 0xcd, 0x4a, 0xf4, 0x30,
+	0x14, 0xed, 0xcf, 0xf7, 0x29, 0x64, 0x44, 0x24, 0x8c, 0x5a, 0x66, 0x11, 0xa5, 0x28, 0xb8, 0x6a,
+	0xc0, 0x59, 0xba, 0x93, 0x41, 0x28, 0x74, 0x55, 0xba, 0x12, 0x41, 0xda, 0x7a, 0x29, 0xc1, 0x99,
+	0x24, 0xd3, 0xd6, 0xe2, 0xa3, 0xf8, 0x48, 0xb3, 0x9c, 0x47, 0x70, 0xea, 0x8b, 0x48, 0x6e, 0xba,
+	0x18, 0x7f, 0x06, 0x57, 0xb9, 0xf7, 0x9c, 0x93, 0x73, 0x12, 0x0e, 0x19, 0xcd, 0x21, 0x6f, 0x20,
+	0xd2, 0xb5, 0x6a, 0x15, 0xdd, 0xc7, 0x45, 0x17, 0x93, 0x71, 0xa5, 0x2a, 0x85, 0x18, 0x37, 0x93,
+	0xa5, 0x27, 0x67, 0xd0, 0x96, 0x4f, 0x3c, 0xd7, 0x82, 0x9b, 0xa1, 0x81, 0xba, 0x83, 0x5a, 0x17,
+	0xbc, 0xd6, 0xa5, 0x15, 0x84, 0x4b, 0xf2, 0x3f, 0x31, 0x0e, 0xf4, 0x90, 0x78, 0xf1, 0x2c, 0x70,
+	0xcf, 0xdd, 0x2b, 0x3f, 0xf5, 0xe2, 0x19, 0x3d, 0x22, 0x7e, 0x96, 0x25, 0x81, 0x87, 0x80, 0x19,
+	0x69, 0x48, 0x0e, 0x52, 0x58, 0xe4, 0x42, 0x0a, 0x59, 0x19, 0xca, 0x47, 0xea, 0x0b, 0x66, 0x34,
+	0x77, 0x20, 0x4b, 0xb3, 0xa9, 0x67, 0x90, 0xc1, 0x3f, 0xab, 0xd9, 0xc6, 0xc2, 0x96, 0x8c, 0x31,
+	0x32, 0x96, 0x2d, 0xd4, 0x32, 0x9f, 0xa7, 0xb0, 0x7c, 0x81, 0xa6, 0xa5, 0x0f, 0xe4, 0x04, 0xf1,
+	0x4c, 0x2c, 0x20, 0x53, 0x89, 0xe8, 0x60, 0x60, 0xf0, 0x55, 0xa3, 0xeb, 0x8b, 0x68, 0xfb, 0x0f,
+	0xd1, 0xef, 0xda, 0x74, 0x87, 0x47, 0xf8, 0x4a, 0x8e, 0xbf, 0xa5, 0x36, 0x5a, 0xc9, 0x06, 0xe8,
+	0x23, 0x39, 0xfd, 0x71, 0xc5, 0x52, 0x43, 0xee, 0xe5, 0x1f, 0xb9, 0x56, 0x9c, 0xee, 0x72, 0xb9,
+	0x8d, 0x57, 0x1b, 0xe6, 0xac, 0x37, 0xcc, 0x59, 0xf5, 0xcc, 0x5d, 0xf7, 0xcc, 0x7d, 0xef, 0x99,
+	0xfb, 0xf6, 0xc1, 0x9c, 0x7b, 0x5e, 0x29, 0xf4, 0x8e, 0x84, 0xc2, 0x7e, 0xb8, 0x0d, 0xe1, 0xdd,
+	0x94, 0x63, 0xad, 0x7c, 0x28, 0xf7, 0x66, 0x38, 0x8b, 0x3d, 0x2c, 0x6d, 0xfa, 0x19, 0x00, 0x00,
+	0xff, 0xff, 0x5f, 0x45, 0x7e, 0x6d, 0x03, 0x02, 0x00, 0x00,
 }
 
 func (m *Lease) Marshal() (dAtA []byte, err error) {
@@ -201,6 +204,11 @@ func (m *Lease) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.FencingToken != 0 {
+		i = encodeVarintLease(dAtA, i, uint64(m.FencingToken))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.RemainingTTL != 0 {
 		i = encodeVarintLease(dAtA, i, uint64(m.RemainingTTL))
 		i--
@@ -323,6 +331,9 @@ func (m *Lease) Size() (n int) {
 	if m.RemainingTTL != 0 {
 		n += 1 + sovLease(uint64(m.RemainingTTL))
 	}
+	if m.FencingToken != 0 {
+		n += 1 + sovLease(uint64(m.FencingToken))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -453,6 +464,25 @@ func (m *Lease) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FencingToken", wireType)
+			}
+			m.FencingToken = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FencingToken |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLease(dAtA[iNdEx:])
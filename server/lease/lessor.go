@@ -15,13 +15,16 @@
 package lease
 
 import (
+	"bytes"
 	"container/heap"
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"sort"
 	"sync"
 	"time"
+	"unsafe"
 
 	"github.com/coreos/go-semver/semver"
 	"go.uber.org/zap"
@@ -39,10 +42,18 @@ const NoLease = LeaseID(0)
 // MaxLeaseTTL is the maximum lease TTL value
 const MaxLeaseTTL = 9000000000
 
+const (
+	// leaseSize accounts for a Lease, its entry in the lease map and its items
+	// in the expiry and checkpoint queues.
+	leaseSize = int64(unsafe.Sizeof(Lease{})+unsafe.Sizeof(LeaseID(0))+unsafe.Sizeof(&Lease{})) + 2*int64(unsafe.Sizeof(LeaseWithTime{}))
+	// leaseItemEntrySize accounts for the map entry of an attached item.
+	leaseItemEntrySize = int64(unsafe.Sizeof(LeaseItem{}) + unsafe.Sizeof(LeaseID(0)))
+)
+
 var (
 	forever = time.Time{}
 
-	// default number of leases to revoke per second; configurable for tests
+	// default number of leases to revoke per second
 	defaultLeaseRevokeRate = 1000
 
 	// maximum number of lease checkpoints recorded to the consensus log per second; configurable for tests
@@ -63,10 +74,12 @@ var (
 	ErrLeaseTTLTooLarge = errors.New("too large lease TTL")
 )
 
-// TxnDelete is a TxnWrite that only permits deletes. Defined here
-// to avoid circular dependency with mvcc.
+// TxnDelete is a TxnWrite that only permits deletes, and the puts
+// recording the expiry of leases. Defined here to avoid circular
+// dependency with mvcc.
 type TxnDelete interface {
 	DeleteRange(key, end []byte) (n, rev int64)
+	Put(key, value []byte, lease LeaseID) (rev int64)
 	End()
 }
 
@@ -95,6 +108,12 @@ type Lessor interface {
 	// will be returned.
 	Revoke(id LeaseID) error
 
+	// Expire revokes the expired lease with given ID like Revoke. If
+	// eventPrefix is not empty, a LeaseExpiryEvent listing the deleted
+	// keys is put under eventPrefix followed by the ID in hexadecimal,
+	// in the transaction deleting them.
+	Expire(id LeaseID, eventPrefix []byte) error
+
 	// Checkpoint applies the remainingTTL of a lease. The remainingTTL is used in Promote to set
 	// the expiry of leases to less than the full TTL when possible.
 	Checkpoint(id LeaseID, remainingTTL int64) error
@@ -135,6 +154,9 @@ type Lessor interface {
 	// Recover recovers the lessor state from the given backend and RangeDeleter.
 	Recover(b backend.Backend, rd RangeDeleter)
 
+	// Bytes estimates the memory held by the leases and their attached items.
+	Bytes() int64
+
 	// Stop stops the lessor for managing leases. The behavior of calling Stop multiple
 	// times is undefined.
 	Stop()
@@ -153,6 +175,8 @@ type lessor struct {
 	leaseExpiredNotifier *LeaseExpiredNotifier
 	leaseCheckpointHeap  LeaseQueue
 	itemMap              map[LeaseItem]LeaseID
+	// itemKeyBytes is the total length of the keys of itemMap.
+	itemKeyBytes int64
 
 	// When a lease expires, the lessor will delete the
 	// leased range (or key) by the RangeDeleter.
@@ -170,6 +194,11 @@ type lessor struct {
 	// requests for shorter TTLs are extended to the minimum TTL.
 	minLeaseTTL int64
 
+	// fencingToken is the last fencing token given to a lease. It is
+	// persisted along with the granted lease, so that the tokens keep
+	// increasing across restarts and leader changes.
+	fencingToken int64
+
 	// maximum number of leases to revoke per second
 	leaseRevokeRate int
 
@@ -201,8 +230,10 @@ type LessorConfig struct {
 	CheckpointInterval         time.Duration
 	ExpiredLeasesRetryInterval time.Duration
 	CheckpointPersist          bool
-
-	leaseRevokeRate int
+	// RevokeRate is the maximum number of expired leases revoked per second.
+	// The expiries of leases are spread on promotion so that they do not
+	// exceed it. 0 uses 1000.
+	RevokeRate int
 }
 
 func NewLessor(lg *zap.Logger, b backend.Backend, cluster cluster, cfg LessorConfig) Lessor {
@@ -212,7 +243,7 @@ func NewLessor(lg *zap.Logger, b backend.Backend, cluster cluster, cfg LessorCon
 func newLessor(lg *zap.Logger, b backend.Backend, cluster cluster, cfg LessorConfig) *lessor {
 	checkpointInterval := cfg.CheckpointInterval
 	expiredLeaseRetryInterval := cfg.ExpiredLeasesRetryInterval
-	leaseRevokeRate := cfg.leaseRevokeRate
+	leaseRevokeRate := cfg.RevokeRate
 	if checkpointInterval == 0 {
 		checkpointInterval = defaultLeaseCheckpointInterval
 	}
@@ -308,8 +339,19 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 		l.forever()
 	}
 
+	giveToken := le.shouldGiveFencingTokens()
+	if giveToken {
+		le.fencingToken++
+		l.fencingToken = le.fencingToken
+	}
 	le.leaseMap[id] = l
 	l.persistTo(le.b)
+	if giveToken {
+		tx := le.b.BatchTx()
+		tx.LockInsideApply()
+		schema.UnsafeSetLeaseFencingToken(tx, le.fencingToken)
+		tx.Unlock()
+	}
 
 	leaseTotalTTLs.Observe(float64(l.ttl))
 	leaseGranted.Inc()
@@ -324,6 +366,14 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 }
 
 func (le *lessor) Revoke(id LeaseID) error {
+	return le.revoke(id, nil)
+}
+
+func (le *lessor) Expire(id LeaseID, eventPrefix []byte) error {
+	return le.revoke(id, eventPrefix)
+}
+
+func (le *lessor) revoke(id LeaseID, eventPrefix []byte) error {
 	le.mu.Lock()
 
 	l := le.leaseMap[id]
@@ -349,6 +399,9 @@ func (le *lessor) Revoke(id LeaseID) error {
 	for _, key := range keys {
 		txn.DeleteRange([]byte(key), nil)
 	}
+	if len(eventPrefix) > 0 {
+		txn.Put(ExpiryEventKey(eventPrefix, l.ID), mustMarshalExpiryEvent(l, keys), NoLease)
+	}
 
 	le.mu.Lock()
 	defer le.mu.Unlock()
@@ -364,6 +417,24 @@ func (le *lessor) Revoke(id LeaseID) error {
 	return nil
 }
 
+// ExpiryEventKey returns the key recording the expiry of the lease of the
+// given ID under eventPrefix.
+func ExpiryEventKey(eventPrefix []byte, id LeaseID) []byte {
+	return fmt.Appendf(bytes.Clone(eventPrefix), "%016x", int64(id))
+}
+
+func mustMarshalExpiryEvent(l *Lease, keys []string) []byte {
+	ev := &pb.LeaseExpiryEvent{ID: int64(l.ID), TTL: l.TTL()}
+	for _, key := range keys {
+		ev.Keys = append(ev.Keys, []byte(key))
+	}
+	v, err := ev.Marshal()
+	if err != nil {
+		panic("failed to marshal lease expiry event")
+	}
+	return v
+}
+
 func (le *lessor) Checkpoint(id LeaseID, remainingTTL int64) error {
 	le.mu.Lock()
 	defer le.mu.Unlock()
@@ -387,6 +458,16 @@ func (le *lessor) shouldPersistCheckpoints() bool {
 	return le.checkpointPersist || (cv != nil && greaterOrEqual(*cv, version.V3_6))
 }
 
+// shouldGiveFencingTokens returns true once all members can persist fencing
+// tokens, so that members older than v3.7 never see the meta key.
+func (le *lessor) shouldGiveFencingTokens() bool {
+	if le.cluster == nil {
+		return false
+	}
+	cv := le.cluster.Version()
+	return cv != nil && greaterOrEqual(*cv, version.V3_7)
+}
+
 func greaterOrEqual(first, second semver.Version) bool {
 	return !version.LessThan(first, second)
 }
@@ -488,13 +569,16 @@ func (le *lessor) Promote(extend time.Duration) {
 		return
 	}
 
-	// adjust expiries in case of overlap
+	// adjust expiries in case of overlap, delaying the leases with the most
+	// attached items, which take the longest to revoke, so that the revoke
+	// storm after a leader change deletes the fewest keys first.
 	leases := le.unsafeLeases()
-	sort.Sort(leasesByExpiry(leases))
+	sort.Sort(newLeasesBySize(leases))
 
 	baseWindow := leases[0].Remaining()
 	nextWindow := baseWindow + time.Second
 	expires := 0
+	delayed := 0
 	// have fewer expires than the total revoke rate so piled up leases
 	// don't consume the entire revoke limit
 	targetExpiresPerSecond := (3 * le.leaseRevokeRate) / 4
@@ -520,6 +604,14 @@ func (le *lessor) Promote(extend time.Duration) {
 		item := &LeaseWithTime{id: l.ID, time: l.expiry}
 		le.leaseExpiredNotifier.RegisterOrUpdate(item)
 		le.scheduleCheckpointIfNeeded(l)
+		delayed++
+	}
+	if delayed > 0 && le.lg != nil {
+		le.lg.Info(
+			"delayed lease expiries to pace revocations",
+			zap.Int("delayed-leases", delayed),
+			zap.Int("revoke-rate", le.leaseRevokeRate),
+		)
 	}
 }
 
@@ -534,6 +626,7 @@ func (le *lessor) Demote() {
 
 	le.clearScheduledLeasesCheckpoints()
 	le.clearLeaseExpiredNotifier()
+	leaseRevokeQueueAge.Set(0)
 
 	if le.demotec != nil {
 		close(le.demotec)
@@ -556,6 +649,9 @@ func (le *lessor) Attach(id LeaseID, items []LeaseItem) error {
 	l.mu.Lock()
 	for _, it := range items {
 		l.itemSet[it] = struct{}{}
+		if _, ok := le.itemMap[it]; !ok {
+			le.itemKeyBytes += int64(len(it.Key))
+		}
 		le.itemMap[it] = id
 	}
 	l.mu.Unlock()
@@ -583,12 +679,22 @@ func (le *lessor) Detach(id LeaseID, items []LeaseItem) error {
 	l.mu.Lock()
 	for _, it := range items {
 		delete(l.itemSet, it)
+		if _, ok := le.itemMap[it]; ok {
+			le.itemKeyBytes -= int64(len(it.Key))
+		}
 		delete(le.itemMap, it)
 	}
 	l.mu.Unlock()
 	return nil
 }
 
+func (le *lessor) Bytes() int64 {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+	// every item is referenced from both itemMap and the itemSet of its lease.
+	return int64(len(le.leaseMap))*leaseSize + int64(len(le.itemMap))*2*leaseItemEntrySize + 2*le.itemKeyBytes
+}
+
 func (le *lessor) Recover(b backend.Backend, rd RangeDeleter) {
 	le.mu.Lock()
 	defer le.mu.Unlock()
@@ -597,6 +703,7 @@ func (le *lessor) Recover(b backend.Backend, rd RangeDeleter) {
 	le.rd = rd
 	le.leaseMap = make(map[LeaseID]*Lease)
 	le.itemMap = make(map[LeaseItem]LeaseID)
+	le.itemKeyBytes = 0
 	le.initAndRecover()
 }
 
@@ -638,6 +745,7 @@ func (le *lessor) revokeExpiredLeases() {
 	le.mu.RLock()
 	if le.isPrimary() {
 		ls = le.findExpiredLeases(revokeLimit)
+		leaseRevokeQueueAge.Set(le.revokeQueueAge().Seconds())
 	}
 	le.mu.RUnlock()
 
@@ -731,6 +839,7 @@ func (le *lessor) findExpiredLeases(limit int) []*Lease {
 
 		if l.expired() {
 			leases = append(leases, l)
+			leaseRevokeDelay.Observe(-l.Remaining().Seconds())
 
 			// reach expired limit
 			if len(leases) == limit {
@@ -742,6 +851,19 @@ func (le *lessor) findExpiredLeases(limit int) []*Lease {
 	return leases
 }
 
+// revokeQueueAge returns how long the oldest expired lease which was not
+// handed over for revocation, because of the revoke rate, has been expired.
+func (le *lessor) revokeQueueAge() time.Duration {
+	if le.leaseExpiredNotifier.Len() == 0 {
+		return 0
+	}
+	age := time.Since(le.leaseExpiredNotifier.Peek().time)
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
 func (le *lessor) scheduleCheckpointIfNeeded(lease *Lease) {
 	if le.cp == nil {
 		return
@@ -803,7 +925,9 @@ func (le *lessor) initAndRecover() {
 	tx.LockOutsideApply()
 	schema.UnsafeCreateLeaseBucket(tx)
 	lpbs := schema.MustUnsafeGetAllLeases(tx)
+	le.fencingToken = schema.UnsafeReadLeaseFencingToken(tx)
 	tx.Unlock()
+	now := time.Now()
 	for _, lpb := range lpbs {
 		ID := LeaseID(lpb.ID)
 		if lpb.TTL < le.minLeaseTTL {
@@ -816,9 +940,12 @@ func (le *lessor) initAndRecover() {
 			// set expiry to forever, refresh when promoted
 			itemSet:      make(map[LeaseItem]struct{}),
 			expiry:       forever,
+			createTime:   now,
 			revokec:      make(chan struct{}),
 			remainingTTL: lpb.RemainingTTL,
+			fencingToken: lpb.FencingToken,
 		}
+		le.fencingToken = max(le.fencingToken, lpb.FencingToken)
 	}
 	le.leaseExpiredNotifier.Init()
 	heap.Init(&le.leaseCheckpointHeap)
@@ -843,6 +970,8 @@ func (fl *FakeLessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 
 func (fl *FakeLessor) Revoke(id LeaseID) error { return nil }
 
+func (fl *FakeLessor) Expire(id LeaseID, eventPrefix []byte) error { return nil }
+
 func (fl *FakeLessor) Checkpoint(id LeaseID, remainingTTL int64) error { return nil }
 
 func (fl *FakeLessor) Attach(id LeaseID, items []LeaseItem) error { return nil }
@@ -869,11 +998,14 @@ func (fl *FakeLessor) ExpiredLeasesC() <-chan []*Lease { return nil }
 
 func (fl *FakeLessor) Recover(b backend.Backend, rd RangeDeleter) {}
 
+func (fl *FakeLessor) Bytes() int64 { return 0 }
+
 func (fl *FakeLessor) Stop() {}
 
 type FakeTxnDelete struct {
 	backend.BatchTx
 }
 
-func (ftd *FakeTxnDelete) DeleteRange(key, end []byte) (n, rev int64) { return 0, 0 }
-func (ftd *FakeTxnDelete) End()                                       { ftd.Unlock() }
+func (ftd *FakeTxnDelete) DeleteRange(key, end []byte) (n, rev int64)       { return 0, 0 }
+func (ftd *FakeTxnDelete) Put(key, value []byte, lease LeaseID) (rev int64) { return 0 }
+func (ftd *FakeTxnDelete) End()                                             { ftd.Unlock() }
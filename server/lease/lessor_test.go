@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -27,6 +28,7 @@ import (
 	"time"
 
 	"github.com/coreos/go-semver/semver"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 
@@ -209,6 +211,44 @@ func TestLessorRevoke(t *testing.T) {
 	}
 }
 
+// TestLessorExpireEvent ensures Expire deletes the items of the lease like Revoke,
+// recording the expiry under the event prefix in the same transaction.
+func TestLessorExpireEvent(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	var fd *fakeDeleter
+	le.SetRangeDeleter(func() TxnDelete {
+		fd = newFakeDeleter(be)
+		return fd
+	})
+
+	for _, id := range []LeaseID{1, 2} {
+		l, err := le.Grant(id, 100)
+		require.NoError(t, err)
+		require.NoError(t, le.Attach(l.ID, []LeaseItem{{"foo"}, {"bar"}}))
+	}
+
+	require.NoError(t, le.Expire(1, []byte("/events/")))
+	require.Nil(t, le.Lookup(1))
+	require.Equal(t, []string{"bar_", "foo_"}, fd.deleted)
+	require.Equal(t, []string{"/events/0000000000000001"}, fd.put)
+	var ev pb.LeaseExpiryEvent
+	require.NoError(t, ev.Unmarshal(fd.values[0]))
+	require.Equal(t, pb.LeaseExpiryEvent{ID: 1, TTL: 100, Keys: [][]byte{[]byte("bar"), []byte("foo")}}, ev)
+
+	// without a prefix the expiry is not recorded
+	require.NoError(t, le.Expire(2, nil))
+	require.Nil(t, le.Lookup(2))
+	require.Empty(t, fd.put)
+
+	require.ErrorIs(t, le.Expire(3, []byte("/events/")), ErrLeaseNotFound)
+}
+
 func renew(t *testing.T, le *lessor, id LeaseID) int64 {
 	ch := make(chan int64, 1)
 	errch := make(chan error, 1)
@@ -314,7 +354,7 @@ func TestLessorRenewExtendPileup(t *testing.T) {
 	dir, be := NewTestBackend(t)
 	defer os.RemoveAll(dir)
 
-	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, leaseRevokeRate: leaseRevokeRate})
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, RevokeRate: leaseRevokeRate})
 	ttl := int64(10)
 	for i := 1; i <= le.leaseRevokeRate*10; i++ {
 		if _, err := le.Grant(LeaseID(2*i), ttl); err != nil {
@@ -333,7 +373,7 @@ func TestLessorRenewExtendPileup(t *testing.T) {
 	bcfg.Path = filepath.Join(dir, "be")
 	be = backend.New(bcfg)
 	defer be.Close()
-	le = newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, leaseRevokeRate: leaseRevokeRate})
+	le = newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, RevokeRate: leaseRevokeRate})
 	defer le.Stop()
 
 	// extend after recovery should extend expiration on lease pile-up
@@ -357,6 +397,42 @@ func TestLessorRenewExtendPileup(t *testing.T) {
 	}
 }
 
+// TestLessorPromotePileupDelaysLargestLeases ensures the leases with the most
+// attached items are the ones delayed on promotion.
+func TestLessorPromotePileupDelaysLargestLeases(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, RevokeRate: 10})
+	defer le.Stop()
+	for i := 1; i <= 40; i++ {
+		l, err := le.Grant(LeaseID(i), 10)
+		require.NoError(t, err)
+		if i%2 == 0 {
+			items := []LeaseItem{{Key: fmt.Sprintf("a%d", i)}, {Key: fmt.Sprintf("b%d", i)}}
+			require.NoError(t, le.Attach(l.ID, items))
+		}
+	}
+
+	le.Promote(0)
+
+	var lastSmall, firstLarge time.Duration
+	firstLarge = time.Duration(math.MaxInt64)
+	for _, l := range le.leaseMap {
+		if l.itemCount() == 0 {
+			lastSmall = max(lastSmall, l.Remaining())
+		} else {
+			firstLarge = min(firstLarge, l.Remaining())
+		}
+	}
+	require.LessOrEqual(t, lastSmall, firstLarge+100*time.Millisecond)
+	// more leases than the revoke rate expire in the same second, so some
+	// large leases are delayed past the next second
+	require.Greater(t, firstLarge, 11*time.Second)
+}
+
 func TestLessorDetach(t *testing.T) {
 	lg := zap.NewNop()
 	dir, be := NewTestBackend(t)
@@ -398,6 +474,33 @@ func TestLessorDetach(t *testing.T) {
 
 // TestLessorRecover ensures Lessor recovers leases from
 // persist backend.
+func TestLessorBytes(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	require.Equal(t, int64(0), le.Bytes())
+
+	l, err := le.Grant(1, 100)
+	require.NoError(t, err)
+	require.Equal(t, leaseSize, le.Bytes())
+
+	items := []LeaseItem{{"foo"}, {"bar"}}
+	require.NoError(t, le.Attach(l.ID, items))
+	withItems := le.Bytes()
+	require.Equal(t, leaseSize+2*2*leaseItemEntrySize+2*int64(len("foobar")), withItems)
+
+	// attaching an item again does not count it twice
+	require.NoError(t, le.Attach(l.ID, items[:1]))
+	require.Equal(t, withItems, le.Bytes())
+
+	require.NoError(t, le.Detach(l.ID, items))
+	require.Equal(t, leaseSize, le.Bytes())
+}
+
 func TestLessorRecover(t *testing.T) {
 	lg := zap.NewNop()
 	dir, be := NewTestBackend(t)
@@ -424,6 +527,56 @@ func TestLessorRecover(t *testing.T) {
 	if nl2 == nil || nl2.ttl != l2.ttl {
 		t.Errorf("nl2 = %v, want nl2.ttl= %d", nl2.ttl, l2.ttl)
 	}
+	// recovered leases are as old as the lessor that loaded them
+	if nl1.CreateTime().Before(l2.CreateTime()) {
+		t.Errorf("create time = %v, want after %v", nl1.CreateTime(), l2.CreateTime())
+	}
+}
+
+func TestLessorFencingToken(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	le.SetRangeDeleter(func() TxnDelete { return newFakeDeleter(be) })
+	l1, err := le.Grant(1, 10)
+	require.NoError(t, err)
+	l2, err := le.Grant(2, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), l1.FencingToken())
+	require.Equal(t, int64(2), l2.FencingToken())
+
+	// revoking the latest lease does not let its token be given out again
+	require.NoError(t, le.Revoke(l2.ID))
+
+	nle := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer nle.Stop()
+	require.Equal(t, int64(1), nle.Lookup(l1.ID).FencingToken())
+	l3, err := nle.Grant(2, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), l3.FencingToken())
+}
+
+func TestLessorFencingTokenBeforeV3_7(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterV3_6(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	l, err := le.Grant(1, 10)
+	require.NoError(t, err)
+	require.Zero(t, l.FencingToken())
+
+	tx := be.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	_, vs := tx.UnsafeRange(schema.Meta, schema.MetaFencingTokenName, nil, 0)
+	require.Empty(t, vs)
 }
 
 func TestLessorExpire(t *testing.T) {
@@ -672,11 +825,13 @@ func TestLessorCheckpointPersistenceAfterRestart(t *testing.T) {
 
 type fakeDeleter struct {
 	deleted []string
+	put     []string
+	values  [][]byte
 	tx      backend.BatchTx
 }
 
 func newFakeDeleter(be backend.Backend) *fakeDeleter {
-	fd := &fakeDeleter{nil, be.BatchTx()}
+	fd := &fakeDeleter{tx: be.BatchTx()}
 	fd.tx.Lock()
 	return fd
 }
@@ -688,6 +843,12 @@ func (fd *fakeDeleter) DeleteRange(key, end []byte) (int64, int64) {
 	return 0, 0
 }
 
+func (fd *fakeDeleter) Put(key, value []byte, lease LeaseID) int64 {
+	fd.put = append(fd.put, string(key))
+	fd.values = append(fd.values, value)
+	return 0
+}
+
 func NewTestBackend(t *testing.T) (string, backend.Backend) {
 	lg := zaptest.NewLogger(t)
 	tmpPath := t.TempDir()
@@ -704,6 +865,10 @@ func clusterV3_5() cluster {
 	return fakeCluster{semver.New("3.5.0")}
 }
 
+func clusterV3_6() cluster {
+	return fakeCluster{semver.New("3.6.0")}
+}
+
 func clusterNil() cluster {
 	return fakeCluster{}
 }
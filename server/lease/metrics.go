@@ -40,6 +40,22 @@ var (
 		Help:      "The number of renewed leases seen by the leader.",
 	})
 
+	leaseRevokeDelay = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "lease",
+		Name:      "revoke_delay_seconds",
+		Help:      "Bucketed histogram of the time from the expiry of leases until they are handed over for revocation.",
+		// 1ms -> ~9 minutes
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 20),
+	})
+
+	leaseRevokeQueueAge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "lease",
+		Name:      "revoke_queue_age_seconds",
+		Help:      "The time the oldest expired lease waiting for revocation, because of the revoke rate, has been expired.",
+	})
+
 	leaseTotalTTLs = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "etcd_debugging",
@@ -56,5 +72,7 @@ func init() {
 	prometheus.MustRegister(leaseGranted)
 	prometheus.MustRegister(leaseRevoked)
 	prometheus.MustRegister(leaseRenewed)
+	prometheus.MustRegister(leaseRevokeDelay)
+	prometheus.MustRegister(leaseRevokeQueueAge)
 	prometheus.MustRegister(leaseTotalTTLs)
 }
@@ -64,6 +64,14 @@ func (s *as2ac) RoleRevokePermission(ctx context.Context, in *pb.AuthRoleRevokeP
 	return s.as.RoleRevokePermission(ctx, in)
 }
 
+func (s *as2ac) RoleGrantRPCPermission(ctx context.Context, in *pb.AuthRoleGrantRPCPermissionRequest, opts ...grpc.CallOption) (*pb.AuthRoleGrantRPCPermissionResponse, error) {
+	return s.as.RoleGrantRPCPermission(ctx, in)
+}
+
+func (s *as2ac) RoleRevokeRPCPermission(ctx context.Context, in *pb.AuthRoleRevokeRPCPermissionRequest, opts ...grpc.CallOption) (*pb.AuthRoleRevokeRPCPermissionResponse, error) {
+	return s.as.RoleRevokeRPCPermission(ctx, in)
+}
+
 func (s *as2ac) RoleGrantPermission(ctx context.Context, in *pb.AuthRoleGrantPermissionRequest, opts ...grpc.CallOption) (*pb.AuthRoleGrantPermissionResponse, error) {
 	return s.as.RoleGrantPermission(ctx, in)
 }
@@ -95,3 +103,7 @@ func (s *as2ac) UserRevokeRole(ctx context.Context, in *pb.AuthUserRevokeRoleReq
 func (s *as2ac) UserChangePassword(ctx context.Context, in *pb.AuthUserChangePasswordRequest, opts ...grpc.CallOption) (*pb.AuthUserChangePasswordResponse, error) {
 	return s.as.UserChangePassword(ctx, in)
 }
+
+func (s *as2ac) UserRotatePassword(ctx context.Context, in *pb.AuthUserRotatePasswordRequest, opts ...grpc.CallOption) (*pb.AuthUserRotatePasswordResponse, error) {
+	return s.as.UserRotatePassword(ctx, in)
+}
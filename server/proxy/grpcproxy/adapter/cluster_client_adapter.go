@@ -47,3 +47,11 @@ func (s *cls2clc) MemberRemove(ctx context.Context, r *pb.MemberRemoveRequest, o
 func (s *cls2clc) MemberPromote(ctx context.Context, r *pb.MemberPromoteRequest, opts ...grpc.CallOption) (*pb.MemberPromoteResponse, error) {
 	return s.cls.MemberPromote(ctx, r)
 }
+
+func (s *cls2clc) ClusterConfigGet(ctx context.Context, r *pb.ClusterConfigGetRequest, opts ...grpc.CallOption) (*pb.ClusterConfigGetResponse, error) {
+	return s.cls.ClusterConfigGet(ctx, r)
+}
+
+func (s *cls2clc) ClusterConfigSet(ctx context.Context, r *pb.ClusterConfigSetRequest, opts ...grpc.CallOption) (*pb.ClusterConfigSetResponse, error) {
+	return s.cls.ClusterConfigSet(ctx, r)
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+type cts2ctc struct{ cts pb.CounterServer }
+
+func CounterServerToCounterClient(cts pb.CounterServer) pb.CounterClient {
+	return &cts2ctc{cts}
+}
+
+func (s *cts2ctc) CounterAdd(ctx context.Context, r *pb.CounterAddRequest, opts ...grpc.CallOption) (*pb.CounterAddResponse, error) {
+	return s.cts.CounterAdd(ctx, r)
+}
+
+func (s *cts2ctc) CounterGet(ctx context.Context, r *pb.CounterGetRequest, opts ...grpc.CallOption) (*pb.CounterGetResponse, error) {
+	return s.cts.CounterGet(ctx, r)
+}
@@ -56,6 +56,74 @@ func (s *mts2mtc) Downgrade(ctx context.Context, r *pb.DowngradeRequest, opts ..
 	return s.mts.Downgrade(ctx, r)
 }
 
+func (s *mts2mtc) CompactionHoldGrant(ctx context.Context, r *pb.CompactionHoldGrantRequest, opts ...grpc.CallOption) (*pb.CompactionHoldGrantResponse, error) {
+	return s.mts.CompactionHoldGrant(ctx, r)
+}
+
+func (s *mts2mtc) CompactionHoldRevoke(ctx context.Context, r *pb.CompactionHoldRevokeRequest, opts ...grpc.CallOption) (*pb.CompactionHoldRevokeResponse, error) {
+	return s.mts.CompactionHoldRevoke(ctx, r)
+}
+
+func (s *mts2mtc) CompactionHoldList(ctx context.Context, r *pb.CompactionHoldListRequest, opts ...grpc.CallOption) (*pb.CompactionHoldListResponse, error) {
+	return s.mts.CompactionHoldList(ctx, r)
+}
+
+func (s *mts2mtc) GarbageCollect(ctx context.Context, r *pb.GarbageCollectRequest, opts ...grpc.CallOption) (*pb.GarbageCollectResponse, error) {
+	return s.mts.GarbageCollect(ctx, r)
+}
+
+func (s *mts2mtc) MemoryStats(ctx context.Context, r *pb.MemoryStatsRequest, opts ...grpc.CallOption) (*pb.MemoryStatsResponse, error) {
+	return s.mts.MemoryStats(ctx, r)
+}
+
+func (s *mts2mtc) VerifySnapshot(ctx context.Context, r *pb.VerifySnapshotRequest, opts ...grpc.CallOption) (*pb.VerifySnapshotResponse, error) {
+	return s.mts.VerifySnapshot(ctx, r)
+}
+
+func (s *mts2mtc) HotKeys(ctx context.Context, r *pb.HotKeysRequest, opts ...grpc.CallOption) (*pb.HotKeysResponse, error) {
+	return s.mts.HotKeys(ctx, r)
+}
+
+func (s *mts2mtc) JobList(ctx context.Context, r *pb.JobListRequest, opts ...grpc.CallOption) (*pb.JobListResponse, error) {
+	return s.mts.JobList(ctx, r)
+}
+
+func (s *mts2mtc) JobStatus(ctx context.Context, r *pb.JobStatusRequest, opts ...grpc.CallOption) (*pb.JobStatusResponse, error) {
+	return s.mts.JobStatus(ctx, r)
+}
+
+func (s *mts2mtc) JobCancel(ctx context.Context, r *pb.JobCancelRequest, opts ...grpc.CallOption) (*pb.JobCancelResponse, error) {
+	return s.mts.JobCancel(ctx, r)
+}
+
+func (s *mts2mtc) NewerFields(ctx context.Context, r *pb.NewerFieldsRequest, opts ...grpc.CallOption) (*pb.NewerFieldsResponse, error) {
+	return s.mts.NewerFields(ctx, r)
+}
+
+func (s *mts2mtc) Checkpoint(ctx context.Context, r *pb.CheckpointRequest, opts ...grpc.CallOption) (*pb.CheckpointResponse, error) {
+	return s.mts.Checkpoint(ctx, r)
+}
+
+func (s *mts2mtc) Reseed(ctx context.Context, r *pb.ReseedRequest, opts ...grpc.CallOption) (*pb.ReseedResponse, error) {
+	return s.mts.Reseed(ctx, r)
+}
+
+func (s *mts2mtc) FencePrefix(ctx context.Context, r *pb.FencePrefixRequest, opts ...grpc.CallOption) (*pb.FencePrefixResponse, error) {
+	return s.mts.FencePrefix(ctx, r)
+}
+
+func (s *mts2mtc) SetRequestLogging(ctx context.Context, r *pb.SetRequestLoggingRequest, opts ...grpc.CallOption) (*pb.SetRequestLoggingResponse, error) {
+	return s.mts.SetRequestLogging(ctx, r)
+}
+
+func (s *mts2mtc) DrainMember(ctx context.Context, r *pb.DrainMemberRequest, opts ...grpc.CallOption) (*pb.DrainMemberResponse, error) {
+	return s.mts.DrainMember(ctx, r)
+}
+
+func (s *mts2mtc) StorageStats(ctx context.Context, r *pb.StorageStatsRequest, opts ...grpc.CallOption) (*pb.StorageStatsResponse, error) {
+	return s.mts.StorageStats(ctx, r)
+}
+
 func (s *mts2mtc) Snapshot(ctx context.Context, in *pb.SnapshotRequest, opts ...grpc.CallOption) (pb.Maintenance_SnapshotClient, error) {
 	cs := newPipeStream(ctx, func(ss chanServerStream) error {
 		return s.mts.Snapshot(in, &ss2scServerStream{ss})
@@ -92,3 +160,28 @@ func (s *ss2scServerStream) Recv() (*pb.SnapshotRequest, error) {
 	}
 	return v.(*pb.SnapshotRequest), nil
 }
+
+func (s *mts2mtc) Export(ctx context.Context, in *pb.ExportRequest, opts ...grpc.CallOption) (pb.Maintenance_ExportClient, error) {
+	cs := newPipeStream(ctx, func(ss chanServerStream) error {
+		return s.mts.Export(in, &xs2xcServerStream{ss})
+	})
+	return &xs2xcClientStream{cs}, nil
+}
+
+// xs2xcClientStream implements Maintenance_ExportClient
+type xs2xcClientStream struct{ chanClientStream }
+
+// xs2xcServerStream implements Maintenance_ExportServer
+type xs2xcServerStream struct{ chanServerStream }
+
+func (s *xs2xcClientStream) Recv() (*pb.ExportResponse, error) {
+	var v any
+	if err := s.RecvMsg(&v); err != nil {
+		return nil, err
+	}
+	return v.(*pb.ExportResponse), nil
+}
+
+func (s *xs2xcServerStream) Send(rr *pb.ExportResponse) error {
+	return s.SendMsg(rr)
+}
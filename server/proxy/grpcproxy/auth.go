@@ -65,6 +65,14 @@ func (ap *AuthProxy) RoleRevokePermission(ctx context.Context, r *pb.AuthRoleRev
 	return ap.authClient.RoleRevokePermission(ctx, r)
 }
 
+func (ap *AuthProxy) RoleGrantRPCPermission(ctx context.Context, r *pb.AuthRoleGrantRPCPermissionRequest) (*pb.AuthRoleGrantRPCPermissionResponse, error) {
+	return ap.authClient.RoleGrantRPCPermission(ctx, r)
+}
+
+func (ap *AuthProxy) RoleRevokeRPCPermission(ctx context.Context, r *pb.AuthRoleRevokeRPCPermissionRequest) (*pb.AuthRoleRevokeRPCPermissionResponse, error) {
+	return ap.authClient.RoleRevokeRPCPermission(ctx, r)
+}
+
 func (ap *AuthProxy) RoleGrantPermission(ctx context.Context, r *pb.AuthRoleGrantPermissionRequest) (*pb.AuthRoleGrantPermissionResponse, error) {
 	return ap.authClient.RoleGrantPermission(ctx, r)
 }
@@ -96,3 +104,7 @@ func (ap *AuthProxy) UserRevokeRole(ctx context.Context, r *pb.AuthUserRevokeRol
 func (ap *AuthProxy) UserChangePassword(ctx context.Context, r *pb.AuthUserChangePasswordRequest) (*pb.AuthUserChangePasswordResponse, error) {
 	return ap.authClient.UserChangePassword(ctx, r)
 }
+
+func (ap *AuthProxy) UserRotatePassword(ctx context.Context, r *pb.AuthUserRotatePasswordRequest) (*pb.AuthUserRotatePasswordResponse, error) {
+	return ap.authClient.UserRotatePassword(ctx, r)
+}
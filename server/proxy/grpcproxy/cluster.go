@@ -138,6 +138,14 @@ func (cp *clusterProxy) MemberUpdate(ctx context.Context, r *pb.MemberUpdateRequ
 	return cp.clus.MemberUpdate(ctx, r)
 }
 
+func (cp *clusterProxy) ClusterConfigGet(ctx context.Context, r *pb.ClusterConfigGetRequest) (*pb.ClusterConfigGetResponse, error) {
+	return cp.clus.ClusterConfigGet(ctx, r)
+}
+
+func (cp *clusterProxy) ClusterConfigSet(ctx context.Context, r *pb.ClusterConfigSetRequest) (*pb.ClusterConfigSetResponse, error) {
+	return cp.clus.ClusterConfigSet(ctx, r)
+}
+
 func (cp *clusterProxy) membersFromUpdates() ([]*pb.Member, error) {
 	cp.umu.RLock()
 	defer cp.umu.RUnlock()
@@ -0,0 +1,51 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type CounterProxy struct {
+	counter clientv3.Counter
+}
+
+func NewCounterProxy(c *clientv3.Client) pb.CounterServer {
+	return &CounterProxy{counter: c.Counter}
+}
+
+func (cp *CounterProxy) CounterAdd(ctx context.Context, r *pb.CounterAddRequest) (*pb.CounterAddResponse, error) {
+	deltas := make([]clientv3.CounterDelta, len(r.Deltas))
+	for i, d := range r.Deltas {
+		deltas[i] = clientv3.CounterDelta{Key: string(d.Key), Delta: d.Delta}
+	}
+	resp, err := cp.counter.CounterAdd(ctx, deltas...)
+	return (*pb.CounterAddResponse)(resp), err
+}
+
+func (cp *CounterProxy) CounterGet(ctx context.Context, r *pb.CounterGetRequest) (*pb.CounterGetResponse, error) {
+	var opts []clientv3.OpOption
+	if len(r.RangeEnd) != 0 {
+		opts = append(opts, clientv3.WithRange(string(r.RangeEnd)))
+	}
+	if r.Serializable {
+		opts = append(opts, clientv3.WithSerializable())
+	}
+	resp, err := cp.counter.CounterGet(ctx, string(r.Key), opts...)
+	return (*pb.CounterGetResponse)(resp), err
+}
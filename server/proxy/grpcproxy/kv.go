@@ -39,7 +39,8 @@ func NewKvProxy(c *clientv3.Client) (pb.KVServer, <-chan struct{}) {
 }
 
 func (p *kvProxy) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResponse, error) {
-	if r.Serializable {
+	// responses from the cache may be older than the requested applied index
+	if r.Serializable && r.MinAppliedIndex == 0 {
 		resp, err := p.cache.Get(r)
 		switch {
 		case err == nil:
@@ -61,6 +62,7 @@ func (p *kvProxy) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeRespo
 	// cache linearizable as serializable
 	req := *r
 	req.Serializable = true
+	req.MinAppliedIndex = 0
 	gresp := (*pb.RangeResponse)(resp.Get())
 	p.cache.Add(&req, gresp)
 	cacheKeys.Set(float64(p.cache.Size()))
@@ -73,6 +75,10 @@ func (p *kvProxy) Put(ctx context.Context, r *pb.PutRequest) (*pb.PutResponse, e
 	cacheKeys.Set(float64(p.cache.Size()))
 
 	resp, err := p.kv.Do(ctx, PutRequestToOp(r))
+	if err == nil && r.SequenceSuffix {
+		// the created key is only known once the put is done
+		p.cache.Invalidate(resp.Put().Key, nil)
+	}
 	return (*pb.PutResponse)(resp.Put()), err
 }
 
@@ -89,9 +95,17 @@ func (p *kvProxy) txnToCache(reqs []*pb.RequestOp, resps []*pb.ResponseOp) {
 		switch tv := resps[i].Response.(type) {
 		case *pb.ResponseOp_ResponsePut:
 			p.cache.Invalidate(reqs[i].GetRequestPut().Key, nil)
+			if len(tv.ResponsePut.Key) != 0 {
+				p.cache.Invalidate(tv.ResponsePut.Key, nil)
+			}
 		case *pb.ResponseOp_ResponseDeleteRange:
 			rdr := reqs[i].GetRequestDeleteRange()
 			p.cache.Invalidate(rdr.Key, rdr.RangeEnd)
+		case *pb.ResponseOp_ResponseAppend:
+			p.cache.Invalidate(reqs[i].GetRequestAppend().Key, nil)
+		case *pb.ResponseOp_ResponseForEach:
+			rfe := reqs[i].GetRequestForEach()
+			p.cache.Invalidate(rfe.Key, rfe.RangeEnd)
 		case *pb.ResponseOp_ResponseRange:
 			req := *(reqs[i].GetRequestRange())
 			req.Serializable = true
@@ -158,6 +172,14 @@ func requestOpToOp(union *pb.RequestOp) clientv3.Op {
 		if tv.RequestTxn != nil {
 			return TxnRequestToOp(tv.RequestTxn)
 		}
+	case *pb.RequestOp_RequestAppend:
+		if tv.RequestAppend != nil {
+			return AppendRequestToOp(tv.RequestAppend)
+		}
+	case *pb.RequestOp_RequestForEach:
+		if tv.RequestForEach != nil {
+			return ForEachRequestToOp(tv.RequestForEach)
+		}
 	}
 	panic("unknown request")
 }
@@ -180,12 +202,17 @@ func RangeRequestToOp(r *pb.RangeRequest) clientv3.Op {
 	if r.CountOnly {
 		opts = append(opts, clientv3.WithCountOnly())
 	}
+	if r.CountModificationsSinceRev != 0 {
+		opts = append(opts, clientv3.WithCountModificationsSince(r.CountModificationsSinceRev))
+	}
 	if r.KeysOnly {
 		opts = append(opts, clientv3.WithKeysOnly())
 	}
 	if r.Serializable {
 		opts = append(opts, clientv3.WithSerializable())
+		opts = append(opts, clientv3.WithMinAppliedIndex(r.MinAppliedIndex))
 	}
+	opts = append(opts, clientv3.WithReadConsistency(clientv3.ReadConsistency(r.ReadConsistency)))
 
 	return clientv3.OpGet(string(r.Key), opts...)
 }
@@ -202,6 +229,12 @@ func PutRequestToOp(r *pb.PutRequest) clientv3.Op {
 	if r.PrevKv {
 		opts = append(opts, clientv3.WithPrevKV())
 	}
+	if r.SequenceSuffix {
+		opts = append(opts, clientv3.WithSequenceSuffix())
+	}
+	if r.ReturnKv {
+		opts = append(opts, clientv3.WithReturnKV())
+	}
 	return clientv3.OpPut(string(r.Key), string(r.Value), opts...)
 }
 
@@ -216,6 +249,33 @@ func DelRequestToOp(r *pb.DeleteRangeRequest) clientv3.Op {
 	return clientv3.OpDelete(string(r.Key), opts...)
 }
 
+func AppendRequestToOp(r *pb.AppendRequest) clientv3.Op {
+	var opts []clientv3.OpOption
+	if r.MaxSize != 0 {
+		opts = append(opts, clientv3.WithMaxSize(r.MaxSize))
+	}
+	if r.PrevKv {
+		opts = append(opts, clientv3.WithPrevKV())
+	}
+	return clientv3.OpAppend(string(r.Key), string(r.Value), opts...)
+}
+
+func ForEachRequestToOp(r *pb.ForEachRequest) clientv3.Op {
+	cmps := make([]clientv3.Cmp, len(r.Compare))
+	ops := make([]clientv3.Op, len(r.Ops))
+	for i := range r.Compare {
+		cmps[i] = (clientv3.Cmp)(*r.Compare[i])
+	}
+	for i := range r.Ops {
+		ops[i] = requestOpToOp(r.Ops[i])
+	}
+	opts := []clientv3.OpOption{clientv3.WithMaxKeys(r.MaxKeys)}
+	if len(r.RangeEnd) != 0 {
+		opts = append(opts, clientv3.WithRange(string(r.RangeEnd)))
+	}
+	return clientv3.OpForEach(string(r.Key), cmps, ops, opts...)
+}
+
 func TxnRequestToOp(r *pb.TxnRequest) clientv3.Op {
 	cmps := make([]clientv3.Cmp, len(r.Compare))
 	thenops := make([]clientv3.Op, len(r.Success))
@@ -86,3 +86,97 @@ func (mp *maintenanceProxy) MoveLeader(ctx context.Context, r *pb.MoveLeaderRequ
 func (mp *maintenanceProxy) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
 	return mp.maintenanceClient.Downgrade(ctx, r)
 }
+
+func (mp *maintenanceProxy) CompactionHoldGrant(ctx context.Context, r *pb.CompactionHoldGrantRequest) (*pb.CompactionHoldGrantResponse, error) {
+	return mp.maintenanceClient.CompactionHoldGrant(ctx, r)
+}
+
+func (mp *maintenanceProxy) CompactionHoldRevoke(ctx context.Context, r *pb.CompactionHoldRevokeRequest) (*pb.CompactionHoldRevokeResponse, error) {
+	return mp.maintenanceClient.CompactionHoldRevoke(ctx, r)
+}
+
+func (mp *maintenanceProxy) CompactionHoldList(ctx context.Context, r *pb.CompactionHoldListRequest) (*pb.CompactionHoldListResponse, error) {
+	return mp.maintenanceClient.CompactionHoldList(ctx, r)
+}
+
+func (mp *maintenanceProxy) GarbageCollect(ctx context.Context, r *pb.GarbageCollectRequest) (*pb.GarbageCollectResponse, error) {
+	return mp.maintenanceClient.GarbageCollect(ctx, r)
+}
+
+func (mp *maintenanceProxy) MemoryStats(ctx context.Context, r *pb.MemoryStatsRequest) (*pb.MemoryStatsResponse, error) {
+	return mp.maintenanceClient.MemoryStats(ctx, r)
+}
+
+func (mp *maintenanceProxy) VerifySnapshot(ctx context.Context, r *pb.VerifySnapshotRequest) (*pb.VerifySnapshotResponse, error) {
+	return mp.maintenanceClient.VerifySnapshot(ctx, r)
+}
+
+func (mp *maintenanceProxy) HotKeys(ctx context.Context, r *pb.HotKeysRequest) (*pb.HotKeysResponse, error) {
+	return mp.maintenanceClient.HotKeys(ctx, r)
+}
+
+func (mp *maintenanceProxy) JobList(ctx context.Context, r *pb.JobListRequest) (*pb.JobListResponse, error) {
+	return mp.maintenanceClient.JobList(ctx, r)
+}
+
+func (mp *maintenanceProxy) JobStatus(ctx context.Context, r *pb.JobStatusRequest) (*pb.JobStatusResponse, error) {
+	return mp.maintenanceClient.JobStatus(ctx, r)
+}
+
+func (mp *maintenanceProxy) JobCancel(ctx context.Context, r *pb.JobCancelRequest) (*pb.JobCancelResponse, error) {
+	return mp.maintenanceClient.JobCancel(ctx, r)
+}
+
+func (mp *maintenanceProxy) NewerFields(ctx context.Context, r *pb.NewerFieldsRequest) (*pb.NewerFieldsResponse, error) {
+	return mp.maintenanceClient.NewerFields(ctx, r)
+}
+
+func (mp *maintenanceProxy) Checkpoint(ctx context.Context, r *pb.CheckpointRequest) (*pb.CheckpointResponse, error) {
+	return mp.maintenanceClient.Checkpoint(ctx, r)
+}
+
+func (mp *maintenanceProxy) Reseed(ctx context.Context, r *pb.ReseedRequest) (*pb.ReseedResponse, error) {
+	return mp.maintenanceClient.Reseed(ctx, r)
+}
+
+func (mp *maintenanceProxy) FencePrefix(ctx context.Context, r *pb.FencePrefixRequest) (*pb.FencePrefixResponse, error) {
+	return mp.maintenanceClient.FencePrefix(ctx, r)
+}
+
+func (mp *maintenanceProxy) SetRequestLogging(ctx context.Context, r *pb.SetRequestLoggingRequest) (*pb.SetRequestLoggingResponse, error) {
+	return mp.maintenanceClient.SetRequestLogging(ctx, r)
+}
+
+func (mp *maintenanceProxy) DrainMember(ctx context.Context, r *pb.DrainMemberRequest) (*pb.DrainMemberResponse, error) {
+	return mp.maintenanceClient.DrainMember(ctx, r)
+}
+
+func (mp *maintenanceProxy) StorageStats(ctx context.Context, r *pb.StorageStatsRequest) (*pb.StorageStatsResponse, error) {
+	return mp.maintenanceClient.StorageStats(ctx, r)
+}
+
+func (mp *maintenanceProxy) Export(r *pb.ExportRequest, stream pb.Maintenance_ExportServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	ctx = withClientAuthToken(ctx, stream.Context())
+
+	ec, err := mp.maintenanceClient.Export(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	for {
+		rr, err := ec.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		err = stream.Send(rr)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -41,6 +41,24 @@ var (
 		Name:      "events_coalescing_total",
 		Help:      "Total number of events coalescing",
 	})
+	upstreamWatchers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "upstream_watchers",
+		Help:      "Number of watchers opened on etcd",
+	})
+	downstreamWatchers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "downstream_watchers",
+		Help:      "Number of client watchers",
+	})
+	watchRangesRegrouped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "watch_ranges_regrouped_total",
+		Help:      "Total number of watch ranges regrouped by merging or splitting overlapping watch ranges",
+	})
 	cacheKeys = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: "etcd",
 		Subsystem: "grpc_proxy",
@@ -64,6 +82,9 @@ var (
 func init() {
 	prometheus.MustRegister(watchersCoalescing)
 	prometheus.MustRegister(eventsCoalescing)
+	prometheus.MustRegister(upstreamWatchers)
+	prometheus.MustRegister(downstreamWatchers)
+	prometheus.MustRegister(watchRangesRegrouped)
 	prometheus.MustRegister(cacheKeys)
 	prometheus.MustRegister(cacheHits)
 	prometheus.MustRegister(cachedMisses)
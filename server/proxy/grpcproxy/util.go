@@ -62,6 +62,14 @@ func AuthUnaryClientInterceptor(ctx context.Context, method string, req, reply a
 		tokenCred := &proxyTokenCredential{token}
 		opts = append(opts, grpc.PerRPCCredentials(tokenCred))
 	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ls := md[rpctypes.MetadataElectionLeaseKey]; len(ls) > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, rpctypes.MetadataElectionLeaseKey, ls[0])
+		}
+		if ts := md[rpctypes.MetadataFenceTokenKey]; len(ts) > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, rpctypes.MetadataFenceTokenKey, ts[0])
+		}
+	}
 	return invoker(ctx, method, req, reply, cc, opts...)
 }
 
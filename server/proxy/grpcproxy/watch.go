@@ -242,6 +242,23 @@ func (wps *watchProxyStream) recvLoop() error {
 					Created:      true,
 					Canceled:     true,
 					CancelReason: err.Error(),
+					CancelCode:   pb.WatchResponse_AUTH_REVOKED,
+					CancelDetails: &pb.WatchCancelDetails{
+						Key:      cr.Key,
+						RangeEnd: cr.RangeEnd,
+					},
+				}
+				continue
+			}
+			if cr.SendInitialState {
+				// the initial state is not shared by coalesced watchers
+				wps.watchCh <- &pb.WatchResponse{
+					Header:       &pb.ResponseHeader{},
+					WatchId:      clientv3.InvalidWatchID,
+					Created:      true,
+					Canceled:     true,
+					CancelReason: "grpcproxy: send_initial_state is not supported",
+					CancelCode:   pb.WatchResponse_INVALID_REQUEST,
 				}
 				continue
 			}
@@ -258,7 +275,7 @@ func (wps *watchProxyStream) recvLoop() error {
 				filters:  v3rpc.FiltersFromRequest(cr),
 			}
 			if !w.wr.valid() {
-				w.post(&pb.WatchResponse{WatchId: clientv3.InvalidWatchID, Created: true, Canceled: true})
+				w.post(&pb.WatchResponse{WatchId: clientv3.InvalidWatchID, Created: true, Canceled: true, CancelCode: pb.WatchResponse_INVALID_REQUEST})
 				wps.mu.Unlock()
 				continue
 			}
@@ -30,6 +30,9 @@ type watchBroadcast struct {
 	// cancel stops the underlying etcd server watcher and closes ch.
 	cancel context.CancelFunc
 	donec  chan struct{}
+	// ctx is the context of the etcd server watcher; progress requests
+	// on it are answered on the watcher's stream.
+	ctx context.Context
 
 	// mu protects rev and receivers.
 	mu sync.RWMutex
@@ -39,41 +42,68 @@ type watchBroadcast struct {
 	receivers map[*watcher]struct{}
 	// responses counts the number of responses
 	responses int
-	lg        *zap.Logger
+	// lastResponse is the time of the last response.
+	lastResponse time.Time
+	// resumed is set if the receivers were taken over from other
+	// broadcasts, so they already received their create event.
+	resumed bool
+	lg      *zap.Logger
 }
 
-func newWatchBroadcast(lg *zap.Logger, wp *watchProxy, w *watcher, update func(*watchBroadcast)) *watchBroadcast {
-	cctx, cancel := context.WithCancel(wp.ctx)
+func newWatchBroadcast(lg *zap.Logger, wp *watchProxy, wr watchRange, w *watcher, update func(*watchBroadcast)) *watchBroadcast {
 	wb := &watchBroadcast{
-		cancel:    cancel,
 		nextrev:   w.nextrev,
 		receivers: make(map[*watcher]struct{}),
 		donec:     make(chan struct{}),
 		lg:        lg,
 	}
 	wb.add(w)
+	wb.start(wp, wr, w.wps.stream.Context(), update)
+	return wb
+}
+
+// newResumedWatchBroadcast serves receivers taken over from other broadcasts
+// on the range wr, starting at the revision rev.
+func newResumedWatchBroadcast(lg *zap.Logger, wp *watchProxy, wr watchRange, rev int64, receivers map[*watcher]struct{}, update func(*watchBroadcast)) *watchBroadcast {
+	wb := &watchBroadcast{
+		nextrev:   rev,
+		receivers: receivers,
+		donec:     make(chan struct{}),
+		resumed:   true,
+		lg:        lg,
+	}
+	if len(receivers) > 0 {
+		watchersCoalescing.Add(float64(len(receivers) - 1))
+	}
+	// resumed receivers never carry a client auth token
+	wb.start(wp, wr, wp.ctx, update)
+	return wb
+}
+
+func (wb *watchBroadcast) start(wp *watchProxy, wr watchRange, ctxWithToken context.Context, update func(*watchBroadcast)) {
+	cctx, cancel := context.WithCancel(wp.ctx)
+	wb.cancel = cancel
+	wb.ctx = withClientAuthToken(cctx, ctxWithToken)
+	upstreamWatchers.Inc()
 	go func() {
 		defer close(wb.donec)
 
 		opts := []clientv3.OpOption{
-			clientv3.WithRange(w.wr.end),
+			clientv3.WithRange(wr.end),
 			clientv3.WithProgressNotify(),
 			clientv3.WithRev(wb.nextrev),
 			clientv3.WithPrevKV(),
 			clientv3.WithCreatedNotify(),
 		}
 
-		cctx = withClientAuthToken(cctx, w.wps.stream.Context())
+		wch := wp.cw.Watch(wb.ctx, wr.key, opts...)
+		wp.lg.Debug("watch", zap.String("key", wr.key), zap.String("range-end", wr.end))
 
-		wch := wp.cw.Watch(cctx, w.wr.key, opts...)
-		wp.lg.Debug("watch", zap.String("key", w.wr.key))
-
-		for wr := range wch {
-			wb.bcast(wr)
+		for resp := range wch {
+			wb.bcast(resp)
 			update(wb)
 		}
 	}()
-	return wb
 }
 
 func (wb *watchBroadcast) bcast(wr clientv3.WatchResponse) {
@@ -84,6 +114,10 @@ func (wb *watchBroadcast) bcast(wr clientv3.WatchResponse) {
 		wb.nextrev = wr.Header.Revision + 1
 	}
 	wb.responses++
+	wb.lastResponse = time.Now()
+	if wr.Created && wb.resumed {
+		return
+	}
 	for r := range wb.receivers {
 		r.send(wr)
 	}
@@ -102,7 +136,7 @@ func (wb *watchBroadcast) add(w *watcher) bool {
 		// or wb is being established with a current watcher
 		return false
 	}
-	if wb.responses == 0 {
+	if wb.responses == 0 && !wb.resumed {
 		// Newly created; create event will be sent by etcd.
 		wb.receivers[w] = struct{}{}
 		return true
@@ -140,6 +174,27 @@ func (wb *watchBroadcast) delete(w *watcher) {
 	}
 }
 
+// takeReceivers removes and returns the receivers along with the next
+// revision they expect.
+func (wb *watchBroadcast) takeReceivers() (map[*watcher]struct{}, int64) {
+	wb.mu.Lock()
+	defer wb.mu.Unlock()
+	receivers := wb.receivers
+	if len(receivers) > 0 {
+		watchersCoalescing.Sub(float64(len(receivers) - 1))
+	}
+	wb.receivers = make(map[*watcher]struct{})
+	return receivers, wb.nextrev
+}
+
+// ready returns true if the broadcast has started and has received a
+// response within the given duration.
+func (wb *watchBroadcast) ready(fresh time.Duration) bool {
+	wb.mu.RLock()
+	defer wb.mu.RUnlock()
+	return wb.responses > 0 && time.Since(wb.lastResponse) < fresh
+}
+
 func (wb *watchBroadcast) size() int {
 	wb.mu.RLock()
 	defer wb.mu.RUnlock()
@@ -155,6 +210,7 @@ func (wb *watchBroadcast) stop() {
 	}
 
 	wb.cancel()
+	upstreamWatchers.Dec()
 
 	select {
 	case <-wb.donec:
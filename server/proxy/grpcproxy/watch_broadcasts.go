@@ -15,11 +15,19 @@
 package grpcproxy
 
 import (
+	"context"
 	"sync"
+	"time"
+
+	"go.uber.org/zap"
 )
 
 type watchBroadcasts struct {
 	wp *watchProxy
+	// wr is the range watched on etcd, which covers the ranges of all watchers.
+	wr watchRange
+	// notify is called on every broadcast update, if set.
+	notify func()
 
 	// mu protects bcasts and watchers from the coalesce loop.
 	mu       sync.Mutex
@@ -33,9 +41,11 @@ type watchBroadcasts struct {
 // maxCoalesceRecievers prevents a popular watchBroadcast from being coalseced.
 const maxCoalesceReceivers = 5
 
-func newWatchBroadcasts(wp *watchProxy) *watchBroadcasts {
+func newWatchBroadcasts(wp *watchProxy, wr watchRange, notify func()) *watchBroadcasts {
 	wbs := &watchBroadcasts{
 		wp:       wp,
+		wr:       wr,
+		notify:   notify,
 		bcasts:   make(map[*watchBroadcast]struct{}),
 		watchers: make(map[*watcher]*watchBroadcast),
 		updatec:  make(chan *watchBroadcast, 1),
@@ -93,7 +103,7 @@ func (wbs *watchBroadcasts) add(w *watcher) {
 		}
 	}
 	// no fit; create a bcast
-	wb := newWatchBroadcast(wbs.wp.lg, wbs.wp, w, wbs.update)
+	wb := newWatchBroadcast(wbs.wp.lg, wbs.wp, wbs.wr, w, wbs.update)
 	wbs.watchers[w] = wb
 	wbs.bcasts[wb] = struct{}{}
 }
@@ -116,6 +126,57 @@ func (wbs *watchBroadcasts) delete(w *watcher) int {
 	return len(wbs.bcasts)
 }
 
+// resume serves the given watchers on a new broadcast starting at rev.
+func (wbs *watchBroadcasts) resume(rev int64, ws []*watcher) {
+	wbs.mu.Lock()
+	defer wbs.mu.Unlock()
+	receivers := make(map[*watcher]struct{}, len(ws))
+	for _, w := range ws {
+		receivers[w] = struct{}{}
+	}
+	wb := newResumedWatchBroadcast(wbs.wp.lg, wbs.wp, wbs.wr, rev, receivers, wbs.update)
+	for _, w := range ws {
+		wbs.watchers[w] = wb
+	}
+	wbs.bcasts[wb] = struct{}{}
+}
+
+// ready returns true if all broadcasts have started and are fresh enough for
+// their watchers to be taken over. Progress is requested for stale ones.
+func (wbs *watchBroadcasts) ready(fresh time.Duration) bool {
+	wbs.mu.Lock()
+	defer wbs.mu.Unlock()
+	ok := true
+	for wb := range wbs.bcasts {
+		if wb.ready(fresh) {
+			continue
+		}
+		ok = false
+		go func(ctx context.Context) {
+			if err := wbs.wp.cw.RequestProgress(ctx); err != nil {
+				wbs.wp.lg.Debug("failed to request watch progress", zap.Error(err))
+			}
+		}(wb.ctx)
+	}
+	return ok
+}
+
+// takeWatchers removes all watchers, returning the next revision each of
+// them expects.
+func (wbs *watchBroadcasts) takeWatchers() map[*watcher]int64 {
+	wbs.mu.Lock()
+	defer wbs.mu.Unlock()
+	revs := make(map[*watcher]int64, len(wbs.watchers))
+	for wb := range wbs.bcasts {
+		receivers, rev := wb.takeReceivers()
+		for w := range receivers {
+			revs[w] = rev
+		}
+	}
+	wbs.watchers = make(map[*watcher]*watchBroadcast)
+	return revs
+}
+
 func (wbs *watchBroadcasts) stop() {
 	wbs.mu.Lock()
 	for wb := range wbs.bcasts {
@@ -132,4 +193,7 @@ func (wbs *watchBroadcasts) update(wb *watchBroadcast) {
 	case wbs.updatec <- wb:
 	default:
 	}
+	if wbs.notify != nil {
+		wbs.notify()
+	}
 }
@@ -15,55 +15,353 @@
 package grpcproxy
 
 import (
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.etcd.io/etcd/pkg/v3/adt"
+)
+
+const (
+	// regroupFreshness is how recent the last response of a broadcast must
+	// be for its watchers to be moved to another broadcast, so the revision
+	// they resume from is unlikely to be compacted.
+	regroupFreshness = time.Second
+	// regroupRetryInterval is the interval between attempts to regroup
+	// broadcasts that were not ready.
+	regroupRetryInterval = 100 * time.Millisecond
 )
 
-// watchRanges tracks all open watches for the proxy.
+// watchRanges tracks a set of watchers and the etcd watches serving them.
+//
+// Watchers without a client auth token are served under the proxy's own
+// credentials, so their ranges can be merged: overlapping ranges are served
+// by a single group of broadcasts watching the union of the ranges. Groups
+// are kept in an interval tree and merged or split in the background as
+// watchers come and go. Watchers with a client auth token may only watch the
+// ranges they are permitted to, so they are grouped by their exact range.
 type watchRanges struct {
 	wp *watchProxy
 
-	mu     sync.Mutex
-	bcasts map[watchRange]*watchBroadcasts
+	mu sync.Mutex
+	// exact maps the ranges of watchers with a client auth token to their
+	// broadcasts.
+	exact map[watchRange]*watchBroadcasts
+	// tree holds the broadcasts of watchers without a client auth token
+	// by the range they watch.
+	tree adt.IntervalTree
+	// groups maps every watcher to its broadcasts.
+	groups map[*watcher]*watchBroadcasts
+
+	// dirty is set when the groups in tree may need to be merged or split.
+	dirty   atomic.Bool
+	updatec chan struct{}
+	stopc   chan struct{}
+	donec   chan struct{}
 }
 
 func newWatchRanges(wp *watchProxy) *watchRanges {
-	return &watchRanges{
-		wp:     wp,
-		bcasts: make(map[watchRange]*watchBroadcasts),
+	wrs := &watchRanges{
+		wp:      wp,
+		exact:   make(map[watchRange]*watchBroadcasts),
+		tree:    adt.NewIntervalTree(),
+		groups:  make(map[*watcher]*watchBroadcasts),
+		updatec: make(chan struct{}, 1),
+		stopc:   make(chan struct{}),
+		donec:   make(chan struct{}),
 	}
+	go wrs.run()
+	return wrs
 }
 
 func (wrs *watchRanges) add(w *watcher) {
 	wrs.mu.Lock()
 	defer wrs.mu.Unlock()
+	downstreamWatchers.Inc()
 
-	if wbs := wrs.bcasts[w.wr]; wbs != nil {
+	if getAuthTokenFromClient(w.wps.stream.Context()) != "" {
+		wbs := wrs.exact[w.wr]
+		if wbs == nil {
+			wbs = newWatchBroadcasts(wrs.wp, w.wr, nil)
+			wrs.exact[w.wr] = wbs
+		}
 		wbs.add(w)
+		wrs.groups[w] = wbs
 		return
 	}
-	wbs := newWatchBroadcasts(wrs.wp)
-	wrs.bcasts[w.wr] = wbs
+
+	// join a group already watching the range
+	ivl := w.wr.interval()
+	var wbs *watchBroadcasts
+	wrs.tree.Visit(ivl, func(iv *adt.IntervalValue) bool {
+		if iv.Ivl.Begin.Compare(ivl.Begin) <= 0 && iv.Ivl.End.Compare(ivl.End) >= 0 {
+			wbs = iv.Val.(*watchBroadcasts)
+			return false
+		}
+		return true
+	})
+	if wbs == nil {
+		wbs = newWatchBroadcasts(wrs.wp, rangeFromInterval(ivl.Begin, ivl.End), wrs.update)
+		wrs.tree.Insert(ivl, wbs)
+		wrs.markDirty()
+	}
 	wbs.add(w)
+	wrs.groups[w] = wbs
 }
 
 func (wrs *watchRanges) delete(w *watcher) {
 	wrs.mu.Lock()
 	defer wrs.mu.Unlock()
-	wbs, ok := wrs.bcasts[w.wr]
+	wbs, ok := wrs.groups[w]
 	if !ok {
 		panic("deleting missing range")
 	}
-	if wbs.delete(w) == 0 {
-		wbs.stop()
-		delete(wrs.bcasts, w.wr)
+	delete(wrs.groups, w)
+	downstreamWatchers.Dec()
+	if wbs.delete(w) != 0 {
+		if wbs.notify != nil {
+			// the remaining watchers may need a narrower range
+			wrs.markDirty()
+		}
+		return
+	}
+	wbs.stop()
+	if wbs.notify != nil {
+		wrs.tree.Delete(wbs.wr.interval())
+	} else {
+		delete(wrs.exact, wbs.wr)
 	}
 }
 
 func (wrs *watchRanges) stop() {
+	close(wrs.stopc)
+	<-wrs.donec
+
+	wrs.mu.Lock()
+	defer wrs.mu.Unlock()
+	for _, wbs := range wrs.exact {
+		wbs.stop()
+	}
+	wrs.tree.Visit(adt.NewStringAffineInterval("\x00", ""), func(iv *adt.IntervalValue) bool {
+		iv.Val.(*watchBroadcasts).stop()
+		return true
+	})
+	wrs.exact = nil
+	wrs.tree = nil
+	wrs.groups = nil
+}
+
+func (wrs *watchRanges) markDirty() {
+	wrs.dirty.Store(true)
+	wrs.update()
+}
+
+// update wakes up the regrouping loop if there is work pending.
+func (wrs *watchRanges) update() {
+	if !wrs.dirty.Load() {
+		return
+	}
+	select {
+	case wrs.updatec <- struct{}{}:
+	default:
+	}
+}
+
+func (wrs *watchRanges) run() {
+	defer close(wrs.donec)
+	var retryc <-chan time.Time
+	for {
+		select {
+		case <-wrs.stopc:
+			return
+		case <-wrs.updatec:
+		case <-retryc:
+		}
+		retryc = nil
+		if wrs.dirty.Swap(false) && !wrs.regroup() {
+			wrs.dirty.Store(true)
+			retryc = time.After(regroupRetryInterval)
+		}
+	}
+}
+
+// watchGroup is a set of watchers with overlapping ranges.
+type watchGroup struct {
+	wr       watchRange
+	watchers []*watcher
+	// bcasts are the broadcasts currently serving the watchers.
+	bcasts map[*watchBroadcasts]struct{}
+}
+
+// regroup merges groups with overlapping ranges and splits or narrows groups
+// whose watchers no longer overlap. It returns false if some groups could not
+// be regrouped yet.
+func (wrs *watchRanges) regroup() bool {
 	wrs.mu.Lock()
 	defer wrs.mu.Unlock()
-	for _, wb := range wrs.bcasts {
-		wb.stop()
+
+	var ws []*watcher
+	counts := make(map[*watchBroadcasts]int)
+	for w, wbs := range wrs.groups {
+		if wbs.notify != nil {
+			ws = append(ws, w)
+			counts[wbs]++
+		}
+	}
+	ranges := make([]watchRange, len(ws))
+	for i, w := range ws {
+		ranges[i] = w.wr
+	}
+	merged, idx := mergeWatchRanges(ranges)
+	groups := make([]*watchGroup, len(merged))
+	for i := range merged {
+		groups[i] = &watchGroup{wr: merged[i], bcasts: make(map[*watchBroadcasts]struct{})}
+	}
+	for i, w := range ws {
+		g := groups[idx[i]]
+		g.watchers = append(g.watchers, w)
+		g.bcasts[wrs.groups[w]] = struct{}{}
+	}
+
+	// Groups that are served by broadcasts of their exact range and watchers
+	// are settled. The others are rebuilt along with all groups sharing their
+	// broadcasts.
+	owner := make(map[*watchBroadcasts]int)
+	parent := make([]int, len(groups))
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	settled := func(g *watchGroup) bool {
+		if len(g.bcasts) != 1 {
+			return false
+		}
+		for wbs := range g.bcasts {
+			if wbs.wr != g.wr || counts[wbs] != len(g.watchers) {
+				return false
+			}
+		}
+		return true
+	}
+	var unsettled []int
+	for i, g := range groups {
+		parent[i] = i
+		if settled(g) {
+			continue
+		}
+		unsettled = append(unsettled, i)
+		for wbs := range g.bcasts {
+			if j, ok := owner[wbs]; ok {
+				parent[find(i)] = find(j)
+			} else {
+				owner[wbs] = i
+			}
+		}
+	}
+	units := make(map[int][]*watchGroup)
+	for _, i := range unsettled {
+		units[find(i)] = append(units[find(i)], groups[i])
+	}
+
+	ok := true
+	for _, unit := range units {
+		if !wrs.rebuild(unit) {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// rebuild replaces the broadcasts of the given groups with one new broadcast
+// per group, resuming from the earliest revision the old broadcasts of the
+// group's watchers expect.
+func (wrs *watchRanges) rebuild(unit []*watchGroup) bool {
+	old := make(map[*watchBroadcasts]struct{})
+	for _, g := range unit {
+		for wbs := range g.bcasts {
+			old[wbs] = struct{}{}
+		}
+	}
+	for wbs := range old {
+		if !wbs.ready(regroupFreshness) {
+			return false
+		}
+	}
+
+	revs := make(map[*watcher]int64)
+	for wbs := range old {
+		for w, rev := range wbs.takeWatchers() {
+			revs[w] = rev
+		}
+		wbs.stop()
+		wrs.tree.Delete(wbs.wr.interval())
+	}
+	for _, g := range unit {
+		rev := int64(0)
+		for _, w := range g.watchers {
+			if rev == 0 || revs[w] < rev {
+				rev = revs[w]
+			}
+		}
+		wbs := newWatchBroadcasts(wrs.wp, g.wr, wrs.update)
+		wbs.resume(rev, g.watchers)
+		for _, w := range g.watchers {
+			wrs.groups[w] = wbs
+		}
+		wrs.tree.Insert(g.wr.interval(), wbs)
+	}
+	watchRangesRegrouped.Add(float64(len(unit)))
+	return true
+}
+
+// mergeWatchRanges merges overlapping ranges. It returns the merged ranges
+// and, for each given range, the index of the merged range covering it.
+func mergeWatchRanges(ranges []watchRange) (merged []watchRange, idx []int) {
+	ivls := make([]adt.Interval, len(ranges))
+	order := make([]int, len(ranges))
+	for i := range ranges {
+		ivls[i] = ranges[i].interval()
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return ivls[order[a]].Begin.Compare(ivls[order[b]].Begin) < 0
+	})
+
+	idx = make([]int, len(ranges))
+	var begin, end adt.Comparable
+	for _, i := range order {
+		if begin != nil && ivls[i].Begin.Compare(end) < 0 {
+			if ivls[i].End.Compare(end) > 0 {
+				end = ivls[i].End
+			}
+			idx[i] = len(merged)
+			continue
+		}
+		if begin != nil {
+			merged = append(merged, rangeFromInterval(begin, end))
+		}
+		begin, end = ivls[i].Begin, ivls[i].End
+		idx[i] = len(merged)
+	}
+	if begin != nil {
+		merged = append(merged, rangeFromInterval(begin, end))
+	}
+	return merged, idx
+}
+
+// rangeFromInterval returns the watch range of a key interval, in the same
+// form for equal intervals.
+func rangeFromInterval(begin, end adt.Comparable) watchRange {
+	key, rend := string(begin.(adt.StringAffineComparable)), string(end.(adt.StringAffineComparable))
+	switch {
+	case rend == key+"\x00":
+		rend = ""
+	case rend == "":
+		rend = "\x00"
 	}
-	wrs.bcasts = nil
+	return watchRange{key: key, end: rend}
 }
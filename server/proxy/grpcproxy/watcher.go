@@ -20,6 +20,7 @@ import (
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/adt"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
@@ -31,6 +32,35 @@ func (wr *watchRange) valid() bool {
 	return len(wr.end) == 0 || wr.end > wr.key || (wr.end[0] == 0 && len(wr.end) == 1)
 }
 
+// interval returns the key interval of the range; an open end is +infinity.
+func (wr *watchRange) interval() adt.Interval {
+	key := wr.key
+	if len(key) == 0 {
+		// the empty key never exists; "" would be +infinity as a begin
+		key = "\x00"
+	}
+	switch {
+	case len(wr.end) == 0:
+		return adt.NewStringAffinePoint(key)
+	case wr.end == "\x00":
+		return adt.NewStringAffineInterval(key, "")
+	default:
+		return adt.NewStringAffineInterval(key, wr.end)
+	}
+}
+
+// contains returns true if the key is in the range.
+func (wr *watchRange) contains(key string) bool {
+	switch {
+	case len(wr.end) == 0:
+		return key == wr.key
+	case wr.end == "\x00":
+		return key >= wr.key
+	default:
+		return key >= wr.key && key < wr.end
+	}
+}
+
 type watcher struct {
 	// user configuration
 
@@ -45,6 +75,8 @@ type watcher struct {
 	nextrev int64
 	// lastHeader has the last header sent over the stream.
 	lastHeader pb.ResponseHeader
+	// sequence is the sequence token of the last event sent over the stream.
+	sequence int64
 
 	// wps is the parent.
 	wps *watchProxyStream
@@ -77,6 +109,11 @@ func (w *watcher) send(wr clientv3.WatchResponse) {
 		// If w.nextrev updates here, it would skip events in the same txn.
 		lastRev = ev.Kv.ModRevision
 
+		// the upstream watch may cover a wider range than the watcher
+		if !w.wr.contains(string(ev.Kv.Key)) {
+			continue
+		}
+
 		filtered := false
 		for _, filter := range w.filters {
 			if filter(*ev) {
@@ -106,14 +143,26 @@ func (w *watcher) send(wr clientv3.WatchResponse) {
 	}
 
 	w.lastHeader = wr.Header
-	w.post(&pb.WatchResponse{
+	resp := &pb.WatchResponse{
 		Header:          &wr.Header,
 		Created:         wr.Created,
 		CompactRevision: wr.CompactRevision,
 		Canceled:        wr.Canceled,
+		CancelReason:    wr.CancelReason,
+		CancelCode:      wr.CancelCode,
+		CancelDetails:   wr.CancelDetails,
 		WatchId:         w.id,
 		Events:          events,
-	})
+	}
+	if len(events) > 0 {
+		resp.FirstSequence = w.sequence + 1
+		w.sequence += int64(len(events))
+	}
+	if !wr.ServerTime.IsZero() {
+		resp.ServerTime = wr.ServerTime.UnixNano()
+		resp.ServerMonotonicTime = int64(wr.ServerMonotonicTime)
+	}
+	w.post(resp)
 }
 
 // post puts a watch response on the watcher's proxy stream channel
@@ -31,6 +31,7 @@ import (
 func newBackend(cfg config.ServerConfig, hooks backend.Hooks) backend.Backend {
 	bcfg := backend.DefaultBackendConfig(cfg.Logger)
 	bcfg.Path = cfg.BackendPath()
+	bcfg.Engine = cfg.BackendEngine
 	bcfg.UnsafeNoFsync = cfg.UnsafeNoFsync
 	if cfg.BackendBatchLimit != 0 {
 		bcfg.BatchLimit = cfg.BackendBatchLimit
@@ -56,12 +57,29 @@ func newBackend(cfg config.ServerConfig, hooks backend.Hooks) backend.Backend {
 }
 
 // OpenSnapshotBackend renames a snapshot db to the current etcd db and opens it.
+// Snapshot dbs are in the bbolt format, so they are imported instead when the
+// pebble engine is used.
 func OpenSnapshotBackend(cfg config.ServerConfig, ss *snap.Snapshotter, snapshot raftpb.Snapshot, hooks *BackendHooks) (backend.Backend, error) {
 	snapPath, err := ss.DBFilePath(snapshot.Metadata.Index)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find database snapshot file (%w)", err)
 	}
-	if err := os.Rename(snapPath, cfg.BackendPath()); err != nil {
+	return OpenDBFileBackend(cfg, snapPath, hooks)
+}
+
+// OpenDBFileBackend renames a bbolt db file to the current etcd db and opens
+// it, or imports it when the pebble engine is used.
+func OpenDBFileBackend(cfg config.ServerConfig, dbPath string, hooks *BackendHooks) (backend.Backend, error) {
+	if cfg.BackendEngine == backend.EnginePebble {
+		if err := backend.ImportPebbleSnapshot(cfg.Logger, dbPath, cfg.BackendPath()); err != nil {
+			return nil, fmt.Errorf("failed to import database snapshot file (%w)", err)
+		}
+		if err := os.Remove(dbPath); err != nil {
+			return nil, fmt.Errorf("failed to remove database snapshot file (%w)", err)
+		}
+		return OpenBackend(cfg, hooks), nil
+	}
+	if err := os.Rename(dbPath, cfg.BackendPath()); err != nil {
 		return nil, fmt.Errorf("failed to rename database snapshot file (%w)", err)
 	}
 	return OpenBackend(cfg, hooks), nil
@@ -18,8 +18,6 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
-	"os"
-	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -66,7 +64,15 @@ type Backend interface {
 	SizeInUse() int64
 	// OpenReadTxN returns the number of currently open read transactions in the backend.
 	OpenReadTxN() int64
+	// BucketStats returns the stats of the buckets in name order and
+	// records them in the bucket metrics. It walks all the buckets, so it
+	// is expensive on large backends.
+	BucketStats() ([]BucketStats, error)
 	Defrag() error
+	// Checkpoint writes a consistent copy of the database in the bbolt file
+	// format to dst, which must not exist. It reports whether the copy is a
+	// copy-on-write clone of the database file.
+	Checkpoint(dst string) (cloned bool, err error)
 	ForceCommit()
 	Close() error
 
@@ -74,6 +80,14 @@ type Backend interface {
 	SetTxPostLockInsideApplyHook(func())
 }
 
+// BucketStats is the number of keys and the size of a bucket.
+type BucketStats struct {
+	Name string
+	Keys int64
+	// Size is the number of bytes used by the bucket.
+	Size int64
+}
+
 type Snapshot interface {
 	// Size gets the size of the snapshot.
 	Size() int64
@@ -104,9 +118,8 @@ type backend struct {
 	// mlock prevents backend database file to be swapped
 	mlock bool
 
-	mu    sync.RWMutex
-	bopts *bolt.Options
-	db    *bolt.DB
+	mu sync.RWMutex
+	db engine
 
 	batchInterval time.Duration
 	batchLimit    int
@@ -133,6 +146,9 @@ type backend struct {
 type BackendConfig struct {
 	// Path is the file path to the backend file.
 	Path string
+	// Engine is the storage engine of the backend. If empty, the engine of
+	// the existing database is used, or EngineBolt for a new one.
+	Engine string
 	// BatchInterval is the maximum time before flushing the BatchTx.
 	BatchInterval time.Duration
 	// BatchLimit is the maximum puts before flushing the BatchTx.
@@ -184,32 +200,19 @@ func NewDefaultBackend(lg *zap.Logger, path string, opts ...BackendConfigOption)
 }
 
 func newBackend(bcfg BackendConfig) *backend {
-	bopts := &bolt.Options{}
-	if boltOpenOptions != nil {
-		*bopts = *boltOpenOptions
-	}
-
 	if bcfg.Logger == nil {
 		bcfg.Logger = zap.NewNop()
 	}
 
-	bopts.InitialMmapSize = bcfg.mmapSize()
-	bopts.FreelistType = bcfg.BackendFreelistType
-	bopts.NoSync = bcfg.UnsafeNoFsync
-	bopts.NoGrowSync = bcfg.UnsafeNoFsync
-	bopts.Mlock = bcfg.Mlock
-	bopts.Logger = newBoltLoggerZap(bcfg)
-
-	db, err := bolt.Open(bcfg.Path, 0o600, bopts)
+	db, err := openEngine(bcfg)
 	if err != nil {
-		bcfg.Logger.Panic("failed to open database", zap.String("path", bcfg.Path), zap.Error(err))
+		bcfg.Logger.Panic("failed to open database", zap.String("path", bcfg.Path), zap.String("engine", bcfg.Engine), zap.Error(err))
 	}
 
 	// In future, may want to make buffering optional for low-concurrency systems
 	// or dynamically swap between buffered/non-buffered depending on workload.
 	b := &backend{
-		bopts: bopts,
-		db:    db,
+		db: db,
 
 		batchInterval: bcfg.BatchInterval,
 		batchLimit:    bcfg.BatchLimit,
@@ -221,7 +224,7 @@ func newBackend(bcfg BackendConfig) *backend {
 					txBuffer:   txBuffer{make(map[BucketID]*bucketBuffer)},
 					bufVersion: 0,
 				},
-				buckets: make(map[BucketID]*bolt.Bucket),
+				buckets: make(map[BucketID]engineBucket),
 				txWg:    new(sync.WaitGroup),
 				txMu:    new(sync.RWMutex),
 			},
@@ -351,13 +354,13 @@ func (b *backend) Snapshot() Snapshot {
 
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	tx, err := b.db.Begin(false)
+	snap, err := b.db.Snapshot()
 	if err != nil {
 		b.lg.Fatal("failed to begin tx", zap.Error(err))
 	}
 
 	stopc, donec := make(chan struct{}), make(chan struct{})
-	dbBytes := tx.Size()
+	dbBytes := snap.Size()
 	go func() {
 		defer close(donec)
 		// sendRateBytes is based on transferring snapshot data over a 1 gigabit/s connection
@@ -387,7 +390,7 @@ func (b *backend) Snapshot() Snapshot {
 		}
 	}()
 
-	return &snapshot{tx, stopc, donec}
+	return &snapshot{snap, stopc, donec}
 }
 
 func (b *backend) Hash(ignores func(bucketName, keyName []byte) bool) (uint32, error) {
@@ -395,23 +398,28 @@ func (b *backend) Hash(ignores func(bucketName, keyName []byte) bool) (uint32, e
 
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	err := b.db.View(func(tx *bolt.Tx) error {
-		c := tx.Cursor()
-		for next, _ := c.First(); next != nil; next, _ = c.Next() {
-			b := tx.Bucket(next)
-			if b == nil {
-				return fmt.Errorf("cannot get hash of bucket %s", next)
-			}
-			h.Write(next)
-			b.ForEach(func(k, v []byte) error {
-				if ignores != nil && !ignores(next, k) {
-					h.Write(k)
-					h.Write(v)
-				}
-				return nil
-			})
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	err = tx.ForEachBucket(func(next []byte, b engineBucket) error {
+		if b == nil {
+			return fmt.Errorf("cannot get hash of bucket %s", next)
 		}
-		return nil
+		// skip buckets ignored as a whole, so that members with and
+		// without the bucket have the same hash
+		if ignores != nil && ignores(next, nil) {
+			return nil
+		}
+		h.Write(next)
+		return b.ForEach(func(k, v []byte) error {
+			if ignores != nil && !ignores(next, k) {
+				h.Write(k)
+				h.Write(v)
+			}
+			return nil
+		})
 	})
 	if err != nil {
 		return 0, err
@@ -420,6 +428,33 @@ func (b *backend) Hash(ignores func(bucketName, keyName []byte) bool) (uint32, e
 	return h.Sum32(), nil
 }
 
+func (b *backend) BucketStats() ([]BucketStats, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	var stats []BucketStats
+	err = tx.ForEachBucket(func(name []byte, bk engineBucket) error {
+		keys, size := bk.Stats()
+		stats = append(stats, BucketStats{Name: string(name), Keys: keys, Size: size})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bucketKeys.Reset()
+	bucketSize.Reset()
+	for _, s := range stats {
+		bucketKeys.WithLabelValues(s.Name).Set(float64(s.Keys))
+		bucketSize.WithLabelValues(s.Name).Set(float64(s.Size))
+	}
+	return stats, nil
+}
+
 func (b *backend) Size() int64 {
 	return atomic.LoadInt64(&b.size)
 }
@@ -483,40 +518,6 @@ func (b *backend) defrag() error {
 	b.readTx.Lock()
 	defer b.readTx.Unlock()
 
-	// Create a temporary file to ensure we start with a clean slate.
-	// Snapshotter.cleanupSnapdir cleans up any of these that are found during startup.
-	dir := filepath.Dir(b.db.Path())
-	temp, err := os.CreateTemp(dir, "db.tmp.*")
-	if err != nil {
-		return err
-	}
-
-	options := bolt.Options{}
-	if boltOpenOptions != nil {
-		options = *boltOpenOptions
-	}
-	options.OpenFile = func(_ string, _ int, _ os.FileMode) (file *os.File, err error) {
-		// gofail: var defragOpenFileError string
-		// return nil, fmt.Errorf(defragOpenFileError)
-		return temp, nil
-	}
-	// Don't load tmp db into memory regardless of opening options
-	options.Mlock = false
-	tdbp := temp.Name()
-	tmpdb, err := bolt.Open(tdbp, 0o600, &options)
-	if err != nil {
-		temp.Close()
-		if rmErr := os.Remove(temp.Name()); rmErr != nil {
-			b.lg.Error(
-				"failed to remove temporary file",
-				zap.String("path", temp.Name()),
-				zap.Error(rmErr),
-			)
-		}
-
-		return err
-	}
-
 	dbp := b.db.Path()
 	size1, sizeInUse1 := b.Size(), b.SizeInUse()
 	b.lg.Info(
@@ -543,47 +544,23 @@ func (b *backend) defrag() error {
 	b.batchTx.tx = nil
 
 	// gofail: var defragBeforeCopy struct{}
-	err = defragdb(b.db, tmpdb, defragLimit)
+	err := b.db.Defrag()
 	if err != nil {
-		tmpdb.Close()
-		if rmErr := os.RemoveAll(tmpdb.Path()); rmErr != nil {
-			b.lg.Error("failed to remove db.tmp after defragmentation completed", zap.Error(rmErr))
-		}
-
-		// restore the bbolt transactions if defragmentation fails
+		// restore the transactions if defragmentation fails
 		b.batchTx.tx = b.unsafeBegin(true)
 		b.readTx.tx = b.unsafeBegin(false)
 
 		return err
 	}
 
-	err = b.db.Close()
-	if err != nil {
-		b.lg.Fatal("failed to close database", zap.Error(err))
-	}
-	err = tmpdb.Close()
-	if err != nil {
-		b.lg.Fatal("failed to close tmp database", zap.Error(err))
-	}
-	// gofail: var defragBeforeRename struct{}
-	err = os.Rename(tdbp, dbp)
-	if err != nil {
-		b.lg.Fatal("failed to rename tmp database", zap.Error(err))
-	}
-
-	b.db, err = bolt.Open(dbp, 0o600, b.bopts)
-	if err != nil {
-		b.lg.Fatal("failed to open database", zap.String("path", dbp), zap.Error(err))
-	}
 	b.batchTx.tx = b.unsafeBegin(true)
 
 	b.readTx.reset()
 	b.readTx.tx = b.unsafeBegin(false)
 
-	size := b.readTx.tx.Size()
-	db := b.readTx.tx.DB()
-	atomic.StoreInt64(&b.size, size)
-	atomic.StoreInt64(&b.sizeInUse, size-(int64(db.Stats().FreePageN)*int64(db.Info().PageSize)))
+	stats := b.readTx.tx.Stats()
+	atomic.StoreInt64(&b.size, stats.size)
+	atomic.StoreInt64(&b.sizeInUse, stats.sizeInUse)
 
 	took := time.Since(now)
 	defragSec.Observe(took.Seconds())
@@ -603,84 +580,27 @@ func (b *backend) defrag() error {
 	return nil
 }
 
-func defragdb(odb, tmpdb *bolt.DB, limit int) error {
+func defragdb(odb, tmpdb engine, limit int) error {
 	// gofail: var defragdbFail string
 	// return fmt.Errorf(defragdbFail)
 
-	// open a tx on tmpdb for writes
-	tmptx, err := tmpdb.Begin(true)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if err != nil {
-			tmptx.Rollback()
-		}
-	}()
-
-	// open a tx on old db for read
-	tx, err := odb.Begin(false)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	c := tx.Cursor()
-
-	count := 0
-	for next, _ := c.First(); next != nil; next, _ = c.Next() {
-		b := tx.Bucket(next)
-		if b == nil {
-			return fmt.Errorf("backend: cannot defrag bucket %s", next)
-		}
-
-		tmpb, berr := tmptx.CreateBucketIfNotExists(next)
-		if berr != nil {
-			return berr
-		}
-		tmpb.FillPercent = 0.9 // for bucket2seq write in for each
-
-		if err = b.ForEach(func(k, v []byte) error {
-			count++
-			if count > limit {
-				err = tmptx.Commit()
-				if err != nil {
-					return err
-				}
-				tmptx, err = tmpdb.Begin(true)
-				if err != nil {
-					return err
-				}
-				tmpb = tmptx.Bucket(next)
-				tmpb.FillPercent = 0.9 // for bucket2seq write in for each
-
-				count = 0
-			}
-			return tmpb.Put(k, v)
-		}); err != nil {
-			return err
-		}
-	}
-
-	return tmptx.Commit()
+	return copyEngine(odb, tmpdb, limit)
 }
 
-func (b *backend) begin(write bool) *bolt.Tx {
+func (b *backend) begin(write bool) engineTx {
 	b.mu.RLock()
 	tx := b.unsafeBegin(write)
 	b.mu.RUnlock()
 
-	size := tx.Size()
-	db := tx.DB()
-	stats := db.Stats()
-	atomic.StoreInt64(&b.size, size)
-	atomic.StoreInt64(&b.sizeInUse, size-(int64(stats.FreePageN)*int64(db.Info().PageSize)))
-	atomic.StoreInt64(&b.openReadTxN, int64(stats.OpenTxN))
+	stats := tx.Stats()
+	atomic.StoreInt64(&b.size, stats.size)
+	atomic.StoreInt64(&b.sizeInUse, stats.sizeInUse)
+	atomic.StoreInt64(&b.openReadTxN, stats.openReadTxN)
 
 	return tx
 }
 
-func (b *backend) unsafeBegin(write bool) *bolt.Tx {
+func (b *backend) unsafeBegin(write bool) engineTx {
 	// gofail: var beforeStartDBTxn struct{}
 	tx, err := b.db.Begin(write)
 	// gofail: var afterStartDBTxn struct{}
@@ -695,7 +615,7 @@ func (b *backend) OpenReadTxN() int64 {
 }
 
 type snapshot struct {
-	*bolt.Tx
+	engineSnapshot
 	stopc chan struct{}
 	donec chan struct{}
 }
@@ -703,22 +623,5 @@ type snapshot struct {
 func (s *snapshot) Close() error {
 	close(s.stopc)
 	<-s.donec
-	return s.Tx.Rollback()
-}
-
-func newBoltLoggerZap(bcfg BackendConfig) bolt.Logger {
-	lg := bcfg.Logger.Named("bbolt")
-	return &zapBoltLogger{lg.WithOptions(zap.AddCallerSkip(1)).Sugar()}
-}
-
-type zapBoltLogger struct {
-	*zap.SugaredLogger
-}
-
-func (zl *zapBoltLogger) Warning(args ...any) {
-	zl.SugaredLogger.Warn(args...)
-}
-
-func (zl *zapBoltLogger) Warningf(format string, args ...any) {
-	zl.SugaredLogger.Warnf(format, args...)
+	return s.engineSnapshot.Close()
 }
@@ -17,6 +17,7 @@ package backend_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -88,6 +89,35 @@ func TestBackendSnapshot(t *testing.T) {
 	newTx.Unlock()
 }
 
+func TestBackendCheckpoint(t *testing.T) {
+	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
+	defer betesting.Close(t, b)
+
+	// the pending writes are committed before the checkpoint
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Test)
+	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+	tx.Unlock()
+
+	dst := filepath.Join(t.TempDir(), "checkpoint.db")
+	_, err := b.Checkpoint(dst)
+	require.NoError(t, err)
+	_, err = b.Checkpoint(dst)
+	require.Error(t, err, "a checkpoint must not overwrite a file")
+
+	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(t))
+	bcfg.Path, bcfg.BatchInterval, bcfg.BatchLimit = dst, time.Hour, 10000
+	nb := backend.New(bcfg)
+	defer betesting.Close(t, nb)
+
+	newTx := nb.BatchTx()
+	newTx.Lock()
+	_, vs := newTx.UnsafeRange(schema.Test, []byte("foo"), nil, 0)
+	newTx.Unlock()
+	require.Equal(t, [][]byte{[]byte("bar")}, vs)
+}
+
 func TestBackendBatchIntervalCommit(t *testing.T) {
 	// start backend with super short batch interval so
 	// we do not need to wait long before commit to happen.
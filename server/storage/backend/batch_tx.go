@@ -16,16 +16,12 @@ package backend
 
 import (
 	"bytes"
-	"errors"
 	"math"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
-
-	bolt "go.etcd.io/bbolt"
-	bolterrors "go.etcd.io/bbolt/errors"
 )
 
 type BucketID int
@@ -72,7 +68,7 @@ type UnsafeWriter interface {
 
 type batchTx struct {
 	sync.Mutex
-	tx      *bolt.Tx
+	tx      engineTx
 	backend *backend
 
 	pending int
@@ -126,7 +122,7 @@ func (t *batchTx) UnsafeCreateBucket(bucket Bucket) {
 
 func (t *batchTx) UnsafeDeleteBucket(bucket Bucket) {
 	err := t.tx.DeleteBucket(bucket.Name())
-	if err != nil && !errors.Is(err, bolterrors.ErrBucketNotFound) {
+	if err != nil {
 		t.backend.lg.Fatal(
 			"failed to delete a bucket",
 			zap.Stringer("bucket-name", bucket),
@@ -155,12 +151,11 @@ func (t *batchTx) unsafePut(bucketType Bucket, key []byte, value []byte, seq boo
 			zap.Stack("stack"),
 		)
 	}
+	put := bucket.Put
 	if seq {
-		// it is useful to increase fill percent when the workloads are mostly append-only.
-		// this can delay the page split and reduce space usage.
-		bucket.FillPercent = 0.9
+		put = bucket.SeqPut
 	}
-	if err := bucket.Put(key, value); err != nil {
+	if err := put(key, value); err != nil {
 		t.backend.lg.Fatal(
 			"failed to write to a bucket",
 			zap.Stringer("bucket-name", bucketType),
@@ -180,10 +175,12 @@ func (t *batchTx) UnsafeRange(bucketType Bucket, key, endKey []byte, limit int64
 			zap.Stack("stack"),
 		)
 	}
-	return unsafeRange(bucket.Cursor(), key, endKey, limit)
+	c := bucket.Cursor()
+	defer c.Close()
+	return unsafeRange(c, key, endKey, limit)
 }
 
-func unsafeRange(c *bolt.Cursor, key, endKey []byte, limit int64) (keys [][]byte, vs [][]byte) {
+func unsafeRange(c engineCursor, key, endKey []byte, limit int64) (keys [][]byte, vs [][]byte) {
 	if limit <= 0 {
 		limit = math.MaxInt64
 	}
@@ -231,7 +228,7 @@ func (t *batchTx) UnsafeForEach(bucket Bucket, visitor func(k, v []byte) error)
 	return unsafeForEach(t.tx, bucket, visitor)
 }
 
-func unsafeForEach(tx *bolt.Tx, bucket Bucket, visitor func(k, v []byte) error) error {
+func unsafeForEach(tx engineTx, bucket Bucket, visitor func(k, v []byte) error) error {
 	if b := tx.Bucket(bucket.Name()); b != nil {
 		return b.ForEach(visitor)
 	}
@@ -271,9 +268,6 @@ func (t *batchTx) commit(stop bool) {
 		err := t.tx.Commit()
 		// gofail: var afterCommit struct{}
 
-		rebalanceSec.Observe(t.tx.Stats().RebalanceTime.Seconds())
-		spillSec.Observe(t.tx.Stats().SpillTime.Seconds())
-		writeSec.Observe(t.tx.Stats().WriteTime.Seconds())
 		commitSec.Observe(time.Since(start).Seconds())
 		atomic.AddInt64(&t.backend.commits, 1)
 
@@ -368,7 +362,7 @@ func (t *batchTxBuffered) unsafeCommit(stop bool) {
 	if t.backend.readTx.tx != nil {
 		// wait all store read transactions using the current boltdb tx to finish,
 		// then close the boltdb tx
-		go func(tx *bolt.Tx, wg *sync.WaitGroup) {
+		go func(tx engineTx, wg *sync.WaitGroup) {
 			wg.Wait()
 			if err := tx.Rollback(); err != nil {
 				t.backend.lg.Fatal("failed to rollback tx", zap.Error(err))
@@ -0,0 +1,68 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"errors"
+	"os"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+)
+
+var errCloneUnsupported = errors.New("backend: copy-on-write clone not supported")
+
+// Checkpoint clones the database file while no transaction can commit, which
+// takes little time on filesystems supporting copy-on-write clones. Otherwise,
+// the database is copied from a read transaction, without blocking writes.
+func (b *backend) Checkpoint(dst string) (bool, error) {
+	b.batchTx.LockOutsideApply()
+	b.batchTx.commit(false)
+	b.mu.RLock()
+	err := b.db.Clone(dst)
+	b.mu.RUnlock()
+	b.batchTx.Unlock()
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, errCloneUnsupported) {
+		return false, err
+	}
+	b.lg.Debug("copying database for checkpoint", zap.String("path", dst), zap.Error(err))
+	return false, b.copyCheckpoint(dst)
+}
+
+func (b *backend) copyCheckpoint(dst string) error {
+	snap := b.Snapshot()
+	defer snap.Close()
+
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fileutil.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+	if _, err = snap.WriteTo(f); err == nil {
+		err = fileutil.Fsync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		if rmErr := os.Remove(dst); rmErr != nil {
+			b.lg.Error("failed to remove partial checkpoint", zap.String("path", dst), zap.Error(rmErr))
+		}
+	}
+	return err
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+)
+
+// cloneFile creates dst as a copy-on-write clone of src with the FICLONE
+// ioctl, which fails on filesystems without reflink support.
+func cloneFile(src, dst string) error {
+	sf, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sf.Close()
+	df, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fileutil.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+	err = unix.IoctlFileClone(int(df.Fd()), int(sf.Fd()))
+	if err != nil {
+		err = fmt.Errorf("%w: %w", errCloneUnsupported, err)
+	} else {
+		err = fileutil.Fsync(df)
+	}
+	if cerr := df.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(dst)
+	}
+	return err
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// EngineBolt stores the backend in a single bbolt file.
+	EngineBolt = "bolt"
+	// EnginePebble stores the backend in a pebble directory. It is experimental.
+	EnginePebble = "pebble"
+)
+
+// engine is the key-value store holding the buckets of the backend. The
+// batching, buffering and read transaction handling of the backend is built
+// on top of it, so that the storage implementation can be swapped.
+type engine interface {
+	// Begin starts a transaction. At most one writable transaction can be
+	// open at a time.
+	Begin(writable bool) (engineTx, error)
+	// Snapshot returns a point in time copy of the whole database encoded
+	// in the bbolt file format, which is what peers and clients expect.
+	Snapshot() (engineSnapshot, error)
+	// Defrag rewrites the database to give the space held by deleted
+	// data back to the filesystem. No transaction can be open during it.
+	Defrag() error
+	// Clone writes a copy-on-write clone of the database to the file dst,
+	// which is cheap on filesystems supporting it, like XFS and btrfs. It
+	// returns errCloneUnsupported if the engine or the filesystem cannot
+	// clone. No transaction can commit during it.
+	Clone(dst string) error
+	// Path returns the location of the database.
+	Path() string
+	Close() error
+}
+
+type engineTx interface {
+	// Bucket returns the bucket with the given name or nil if it does not exist.
+	Bucket(name []byte) engineBucket
+	CreateBucketIfNotExists(name []byte) (engineBucket, error)
+	// DeleteBucket removes the bucket and all its keys. Deleting a missing
+	// bucket is not an error.
+	DeleteBucket(name []byte) error
+	// ForEachBucket calls fn for every bucket in bucket name order.
+	ForEachBucket(fn func(name []byte, b engineBucket) error) error
+	// Stats returns the size of the database as seen by the transaction.
+	Stats() engineStats
+	Commit() error
+	Rollback() error
+}
+
+type engineBucket interface {
+	Put(key, value []byte) error
+	// SeqPut is like Put, but hints that the keys are written in ascending order.
+	SeqPut(key, value []byte) error
+	Delete(key []byte) error
+	// Cursor returns a cursor over the keys of the bucket. The cursor must be
+	// closed once done with.
+	Cursor() engineCursor
+	ForEach(fn func(k, v []byte) error) error
+	// Stats returns the number of keys of the bucket and the number of
+	// bytes they use. It walks the whole bucket.
+	Stats() (keys, size int64)
+}
+
+type engineCursor interface {
+	// Seek moves the cursor to the first key greater or equal to key and
+	// returns it. A nil key is returned if there is no such key.
+	Seek(key []byte) (k, v []byte)
+	Next() (k, v []byte)
+	Close()
+}
+
+type engineSnapshot interface {
+	Size() int64
+	WriteTo(w io.Writer) (n int64, err error)
+	Close() error
+}
+
+type engineStats struct {
+	// size is the number of bytes allocated by the database.
+	size int64
+	// sizeInUse is the number of bytes holding live data.
+	sizeInUse int64
+	// openReadTxN is the number of open read transactions.
+	openReadTxN int64
+}
+
+func openEngine(bcfg BackendConfig) (engine, error) {
+	if bcfg.Engine == "" {
+		engine, err := DetectEngine(bcfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		bcfg.Engine = engine
+	}
+	switch bcfg.Engine {
+	case "", EngineBolt:
+		return openBoltEngine(bcfg)
+	case EnginePebble:
+		return openPebbleEngine(bcfg)
+	default:
+		return nil, fmt.Errorf("unknown backend engine %q", bcfg.Engine)
+	}
+}
+
+// DetectEngine returns the engine of the database at the given path, or an
+// empty string if there is no database. A bbolt database is a single file,
+// whereas a pebble one is a directory.
+func DetectEngine(path string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if fi.IsDir() {
+		return EnginePebble, nil
+	}
+	return EngineBolt, nil
+}
+
+// Convert copies the database at src into a new database of the given engine
+// at dst. The database at src must not be in use.
+func Convert(lg *zap.Logger, src, dst, engine string) (err error) {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	srcEngine, err := DetectEngine(src)
+	if err != nil {
+		return err
+	}
+	if srcEngine == "" {
+		return fmt.Errorf("database %q does not exist", src)
+	}
+	if dstEngine, derr := DetectEngine(dst); derr != nil || dstEngine != "" {
+		return errors.Join(fmt.Errorf("database %q already exists", dst), derr)
+	}
+
+	bcfg := DefaultBackendConfig(lg)
+	bcfg.Path, bcfg.Engine = src, srcEngine
+	se, err := openEngine(bcfg)
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+
+	bcfg.Path, bcfg.Engine = dst, engine
+	de, err := openEngine(bcfg)
+	if err != nil {
+		return err
+	}
+	err = copyEngine(se, de, defragLimit)
+	if cerr := de.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		if rmErr := os.RemoveAll(dst); rmErr != nil {
+			lg.Error("failed to remove converted database", zap.String("path", dst), zap.Error(rmErr))
+		}
+	}
+	return err
+}
+
+// copyEngine copies all buckets of src into dst, committing dst every limit keys.
+func copyEngine(src, dst engine, limit int) (err error) {
+	tx, err := src.Begin(false)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	dtx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil && dtx != nil {
+			dtx.Rollback()
+		}
+	}()
+
+	count := 0
+	err = tx.ForEachBucket(func(name []byte, b engineBucket) error {
+		db, berr := dtx.CreateBucketIfNotExists(name)
+		if berr != nil {
+			return berr
+		}
+		return b.ForEach(func(k, v []byte) error {
+			count++
+			if count > limit {
+				if cerr := dtx.Commit(); cerr != nil {
+					return cerr
+				}
+				var berr error
+				if dtx, berr = dst.Begin(true); berr != nil {
+					return berr
+				}
+				db = dtx.Bucket(name)
+				count = 0
+			}
+			// for bucket2seq write in for each
+			return db.SeqPut(k, v)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return dtx.Commit()
+}
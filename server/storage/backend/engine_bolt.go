@@ -0,0 +1,268 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	bolt "go.etcd.io/bbolt"
+	bolterrors "go.etcd.io/bbolt/errors"
+)
+
+type boltEngine struct {
+	lg    *zap.Logger
+	bopts *bolt.Options
+	db    *bolt.DB
+}
+
+func openBoltEngine(bcfg BackendConfig) (engine, error) {
+	bopts := &bolt.Options{}
+	if boltOpenOptions != nil {
+		*bopts = *boltOpenOptions
+	}
+	bopts.InitialMmapSize = bcfg.mmapSize()
+	bopts.FreelistType = bcfg.BackendFreelistType
+	bopts.NoSync = bcfg.UnsafeNoFsync
+	bopts.NoGrowSync = bcfg.UnsafeNoFsync
+	bopts.Mlock = bcfg.Mlock
+	bopts.Logger = newBoltLoggerZap(bcfg)
+
+	db, err := bolt.Open(bcfg.Path, 0o600, bopts)
+	if err != nil {
+		return nil, err
+	}
+	return &boltEngine{lg: bcfg.Logger, bopts: bopts, db: db}, nil
+}
+
+func (e *boltEngine) Path() string { return e.db.Path() }
+
+func (e *boltEngine) Begin(writable bool) (engineTx, error) {
+	tx, err := e.db.Begin(writable)
+	if err != nil {
+		return nil, err
+	}
+	return (*boltTx)(tx), nil
+}
+
+func (e *boltEngine) Snapshot() (engineSnapshot, error) {
+	tx, err := e.db.Begin(false)
+	if err != nil {
+		return nil, err
+	}
+	return &boltSnapshot{tx}, nil
+}
+
+func (e *boltEngine) Close() error { return e.db.Close() }
+
+func (e *boltEngine) Clone(dst string) error { return cloneFile(e.db.Path(), dst) }
+
+// Defrag copies the database into a temporary file, which then replaces it.
+func (e *boltEngine) Defrag() error {
+	// Create a temporary file to ensure we start with a clean slate.
+	// Snapshotter.cleanupSnapdir cleans up any of these that are found during startup.
+	dir := filepath.Dir(e.db.Path())
+	temp, err := os.CreateTemp(dir, "db.tmp.*")
+	if err != nil {
+		return err
+	}
+
+	options := bolt.Options{}
+	if boltOpenOptions != nil {
+		options = *boltOpenOptions
+	}
+	options.OpenFile = func(_ string, _ int, _ os.FileMode) (file *os.File, err error) {
+		// gofail: var defragOpenFileError string
+		// return nil, fmt.Errorf(defragOpenFileError)
+		return temp, nil
+	}
+	// Don't load tmp db into memory regardless of opening options
+	options.Mlock = false
+	tdbp := temp.Name()
+	tmpdb, err := bolt.Open(tdbp, 0o600, &options)
+	if err != nil {
+		temp.Close()
+		if rmErr := os.Remove(temp.Name()); rmErr != nil {
+			e.lg.Error(
+				"failed to remove temporary file",
+				zap.String("path", temp.Name()),
+				zap.Error(rmErr),
+			)
+		}
+
+		return fmt.Errorf("failed to open temporary database: %w", err)
+	}
+
+	err = defragdb(e, &boltEngine{db: tmpdb}, defragLimit)
+	if err != nil {
+		tmpdb.Close()
+		if rmErr := os.RemoveAll(tmpdb.Path()); rmErr != nil {
+			e.lg.Error("failed to remove db.tmp after defragmentation completed", zap.Error(rmErr))
+		}
+		return err
+	}
+
+	dbp := e.db.Path()
+	err = e.db.Close()
+	if err != nil {
+		e.lg.Fatal("failed to close database", zap.Error(err))
+	}
+	err = tmpdb.Close()
+	if err != nil {
+		e.lg.Fatal("failed to close tmp database", zap.Error(err))
+	}
+	// gofail: var defragBeforeRename struct{}
+	err = os.Rename(tdbp, dbp)
+	if err != nil {
+		e.lg.Fatal("failed to rename tmp database", zap.Error(err))
+	}
+
+	e.db, err = bolt.Open(dbp, 0o600, e.bopts)
+	if err != nil {
+		e.lg.Fatal("failed to open database", zap.String("path", dbp), zap.Error(err))
+	}
+	return nil
+}
+
+// exportToBolt writes all buckets of e into a new bbolt database at path.
+func exportToBolt(e engine, path string) error {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{NoSync: true, NoGrowSync: true})
+	if err != nil {
+		return err
+	}
+	err = copyEngine(e, &boltEngine{db: db}, defragLimit)
+	if cerr := db.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+type boltSnapshot struct {
+	*bolt.Tx
+}
+
+func (s *boltSnapshot) Close() error { return s.Tx.Rollback() }
+
+type boltTx bolt.Tx
+
+func (tx *boltTx) Bucket(name []byte) engineBucket {
+	if b := (*bolt.Tx)(tx).Bucket(name); b != nil {
+		return (*boltBucket)(b)
+	}
+	return nil
+}
+
+func (tx *boltTx) CreateBucketIfNotExists(name []byte) (engineBucket, error) {
+	b, err := (*bolt.Tx)(tx).CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return (*boltBucket)(b), nil
+}
+
+func (tx *boltTx) DeleteBucket(name []byte) error {
+	err := (*bolt.Tx)(tx).DeleteBucket(name)
+	if errors.Is(err, bolterrors.ErrBucketNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (tx *boltTx) ForEachBucket(fn func(name []byte, b engineBucket) error) error {
+	return (*bolt.Tx)(tx).ForEach(func(name []byte, b *bolt.Bucket) error {
+		return fn(name, (*boltBucket)(b))
+	})
+}
+
+func (tx *boltTx) Stats() engineStats {
+	btx := (*bolt.Tx)(tx)
+	size := btx.Size()
+	db := btx.DB()
+	stats := db.Stats()
+	return engineStats{
+		size:        size,
+		sizeInUse:   size - (int64(stats.FreePageN) * int64(db.Info().PageSize)),
+		openReadTxN: int64(stats.OpenTxN),
+	}
+}
+
+func (tx *boltTx) Commit() error {
+	btx := (*bolt.Tx)(tx)
+	err := btx.Commit()
+	rebalanceSec.Observe(btx.Stats().RebalanceTime.Seconds())
+	spillSec.Observe(btx.Stats().SpillTime.Seconds())
+	writeSec.Observe(btx.Stats().WriteTime.Seconds())
+	return err
+}
+
+func (tx *boltTx) Rollback() error { return (*bolt.Tx)(tx).Rollback() }
+
+type boltBucket bolt.Bucket
+
+func (b *boltBucket) Put(key, value []byte) error {
+	return (*bolt.Bucket)(b).Put(key, value)
+}
+
+func (b *boltBucket) SeqPut(key, value []byte) error {
+	// it is useful to increase fill percent when the workloads are mostly append-only.
+	// this can delay the page split and reduce space usage.
+	b.FillPercent = 0.9
+	return (*bolt.Bucket)(b).Put(key, value)
+}
+
+func (b *boltBucket) Delete(key []byte) error {
+	return (*bolt.Bucket)(b).Delete(key)
+}
+
+func (b *boltBucket) Cursor() engineCursor {
+	return (*boltCursor)((*bolt.Bucket)(b).Cursor())
+}
+
+func (b *boltBucket) ForEach(fn func(k, v []byte) error) error {
+	return (*bolt.Bucket)(b).ForEach(fn)
+}
+
+// Stats returns the bytes used by the pages of the bucket as its size.
+func (b *boltBucket) Stats() (keys, size int64) {
+	s := (*bolt.Bucket)(b).Stats()
+	return int64(s.KeyN), int64(s.BranchInuse + s.LeafInuse + s.InlineBucketInuse)
+}
+
+type boltCursor bolt.Cursor
+
+func (c *boltCursor) Seek(key []byte) ([]byte, []byte) { return (*bolt.Cursor)(c).Seek(key) }
+func (c *boltCursor) Next() ([]byte, []byte)           { return (*bolt.Cursor)(c).Next() }
+func (c *boltCursor) Close()                           {}
+
+func newBoltLoggerZap(bcfg BackendConfig) bolt.Logger {
+	lg := bcfg.Logger.Named("bbolt")
+	return &zapBoltLogger{lg.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+type zapBoltLogger struct {
+	*zap.SugaredLogger
+}
+
+func (zl *zapBoltLogger) Warning(args ...any) {
+	zl.SugaredLogger.Warn(args...)
+}
+
+func (zl *zapBoltLogger) Warningf(format string, args ...any) {
+	zl.SugaredLogger.Warnf(format, args...)
+}
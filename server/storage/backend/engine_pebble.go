@@ -0,0 +1,576 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cockroachdb/pebble"
+	"go.uber.org/zap"
+)
+
+// The pebble database at the backend path is a directory holding numbered
+// generations of the pebble store. Importing a snapshot creates a new
+// generation, so that a backend still open on the previous one keeps working
+// until it is closed; the next open uses the newest generation.
+//
+// Within a generation, a bucket is recorded by a marker key made of a zero
+// byte followed by the bucket name, which keeps the buckets listed in name
+// order like bbolt does. The keys of a bucket are prefixed with the length of
+// the bucket name followed by the name.
+
+const (
+	pebbleTmpSuffix = ".tmp"
+	// maxPebbleBucketNameLen keeps the key prefix of every bucket incrementable
+	// into an exclusive upper bound.
+	maxPebbleBucketNameLen = 0xfe
+)
+
+var (
+	pebbleBucketMarkerStart = []byte{0}
+	pebbleBucketMarkerEnd   = []byte{1}
+
+	errPebbleTxNotWritable = errors.New("backend: tx not writable")
+	errPebbleTxClosed      = errors.New("backend: tx closed")
+)
+
+// openPebbleDirs tracks the generations opened by this process, which must
+// not be removed when opening or importing a newer one.
+var openPebbleDirs = struct {
+	sync.Mutex
+	dirs map[string]struct{}
+}{dirs: make(map[string]struct{})}
+
+type pebbleEngine struct {
+	lg    *zap.Logger
+	path  string
+	dir   string
+	gen   uint64
+	wopts *pebble.WriteOptions
+	db    *pebble.DB
+
+	openReadTxN atomic.Int64
+	// readTxWg lets Close wait for the read transactions, which the backend
+	// rolls back asynchronously, like bbolt does.
+	readTxWg sync.WaitGroup
+}
+
+func openPebbleEngine(bcfg BackendConfig) (engine, error) {
+	openPebbleDirs.Lock()
+	defer openPebbleDirs.Unlock()
+
+	if err := os.MkdirAll(bcfg.Path, 0o700); err != nil {
+		return nil, err
+	}
+	gens, err := pebbleGenerations(bcfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	gen := uint64(1)
+	if len(gens) > 0 {
+		gen = gens[len(gens)-1]
+	}
+	for _, g := range gens {
+		dir := pebbleGenerationDir(bcfg.Path, g)
+		if _, ok := openPebbleDirs.dirs[dir]; g < gen && !ok {
+			bcfg.Logger.Info("removing stale pebble generation", zap.String("path", dir))
+			if err = os.RemoveAll(dir); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	e, err := openPebbleDir(bcfg, pebbleGenerationDir(bcfg.Path, gen))
+	if err != nil {
+		return nil, err
+	}
+	e.gen = gen
+	openPebbleDirs.dirs[e.dir] = struct{}{}
+	return e, nil
+}
+
+func openPebbleDir(bcfg BackendConfig, dir string) (*pebbleEngine, error) {
+	e := &pebbleEngine{
+		lg:    bcfg.Logger,
+		path:  bcfg.Path,
+		dir:   dir,
+		wopts: pebble.Sync,
+	}
+	if bcfg.UnsafeNoFsync {
+		e.wopts = pebble.NoSync
+	}
+	db, err := pebble.Open(dir, &pebble.Options{
+		Logger: bcfg.Logger.Named("pebble").WithOptions(zap.AddCallerSkip(1)).Sugar(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.db = db
+	return e, nil
+}
+
+// pebbleGenerations returns the generations found at path in ascending order.
+// Leftovers of interrupted imports are removed.
+func pebbleGenerations(path string) ([]uint64, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var gens []uint64
+	for _, ent := range entries {
+		if !ent.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(ent.Name(), pebbleTmpSuffix) {
+			if err = os.RemoveAll(filepath.Join(path, ent.Name())); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		gen, perr := strconv.ParseUint(ent.Name(), 16, 64)
+		if perr != nil {
+			continue
+		}
+		gens = append(gens, gen)
+	}
+	sort.Slice(gens, func(i, j int) bool { return gens[i] < gens[j] })
+	return gens, nil
+}
+
+func pebbleGenerationDir(path string, gen uint64) string {
+	return filepath.Join(path, fmt.Sprintf("%016x", gen))
+}
+
+func (e *pebbleEngine) Path() string { return e.path }
+
+func (e *pebbleEngine) Begin(writable bool) (engineTx, error) {
+	tx := &pebbleTx{e: e, buckets: make(map[string]*pebbleBucket)}
+	if writable {
+		tx.batch = e.db.NewIndexedBatch()
+		tx.r = tx.batch
+	} else {
+		tx.snap = e.db.NewSnapshot()
+		tx.r = tx.snap
+		e.openReadTxN.Add(1)
+		e.readTxWg.Add(1)
+	}
+	return tx, nil
+}
+
+// Snapshot exports the database into a temporary bbolt file next to it, which
+// is removed once the snapshot is closed.
+func (e *pebbleEngine) Snapshot() (engineSnapshot, error) {
+	f, err := os.CreateTemp(filepath.Dir(e.path), "db.tmp.*")
+	if err != nil {
+		return nil, err
+	}
+	name := f.Name()
+	if err = f.Close(); err == nil {
+		err = exportToBolt(e, name)
+	}
+	if err == nil {
+		f, err = os.Open(name)
+	}
+	var fi os.FileInfo
+	if err == nil {
+		if fi, err = f.Stat(); err != nil {
+			f.Close()
+		}
+	}
+	if err != nil {
+		if rmErr := os.Remove(name); rmErr != nil {
+			e.lg.Error("failed to remove temporary file", zap.String("path", name), zap.Error(rmErr))
+		}
+		return nil, err
+	}
+	return &pebbleSnapshot{File: f, size: fi.Size()}, nil
+}
+
+// Clone is not supported, since checkpoints are written in the bbolt format.
+func (e *pebbleEngine) Clone(string) error { return errCloneUnsupported }
+
+// Defrag compacts the whole key space, which drops deleted and overwritten
+// data and gives the space of the obsolete files back.
+func (e *pebbleEngine) Defrag() error {
+	it, err := e.db.NewIter(nil)
+	if err != nil {
+		return err
+	}
+	var start, end []byte
+	if it.First() {
+		start = append([]byte(nil), it.Key()...)
+		if it.Last() {
+			end = append(append([]byte(nil), it.Key()...), 0)
+		}
+	}
+	if err = it.Close(); err != nil || start == nil {
+		return err
+	}
+	return e.db.Compact(start, end, true)
+}
+
+// Close closes the database and removes its generation if a newer one has
+// been imported in the meantime.
+func (e *pebbleEngine) Close() error {
+	e.readTxWg.Wait()
+	err := e.db.Close()
+
+	openPebbleDirs.Lock()
+	defer openPebbleDirs.Unlock()
+	delete(openPebbleDirs.dirs, e.dir)
+	gens, gerr := pebbleGenerations(e.path)
+	if gerr != nil {
+		return errors.Join(err, gerr)
+	}
+	if len(gens) > 0 && gens[len(gens)-1] > e.gen {
+		e.lg.Info("removing replaced pebble generation", zap.String("path", e.dir))
+		err = errors.Join(err, os.RemoveAll(e.dir))
+	}
+	return err
+}
+
+// ImportPebbleSnapshot loads the bbolt database at snapPath into a new
+// generation of the pebble database at path. Backends already open on the
+// database are not affected; the next one opened uses the imported data.
+func ImportPebbleSnapshot(lg *zap.Logger, snapPath, path string) error {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	openPebbleDirs.Lock()
+	defer openPebbleDirs.Unlock()
+
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return err
+	}
+	gens, err := pebbleGenerations(path)
+	if err != nil {
+		return err
+	}
+	gen := uint64(1)
+	if len(gens) > 0 {
+		gen = gens[len(gens)-1] + 1
+	}
+
+	bcfg := DefaultBackendConfig(lg)
+	bcfg.Path = snapPath
+	src, err := openBoltEngine(bcfg)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Build the generation under a temporary name, so that an interrupted
+	// import is never picked up by open.
+	dir := pebbleGenerationDir(path, gen)
+	tmp := dir + pebbleTmpSuffix
+	dst, err := openPebbleDir(bcfg, tmp)
+	if err != nil {
+		return err
+	}
+	err = copyEngine(src, dst, defragLimit)
+	if cerr := dst.db.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, dir)
+	}
+	if err != nil {
+		if rmErr := os.RemoveAll(tmp); rmErr != nil {
+			lg.Error("failed to remove temporary directory", zap.String("path", tmp), zap.Error(rmErr))
+		}
+	}
+	return err
+}
+
+type pebbleSnapshot struct {
+	*os.File
+	size int64
+}
+
+func (s *pebbleSnapshot) Size() int64 { return s.size }
+
+func (s *pebbleSnapshot) WriteTo(w io.Writer) (int64, error) {
+	return io.Copy(w, s.File)
+}
+
+func (s *pebbleSnapshot) Close() error {
+	err := s.File.Close()
+	return errors.Join(err, os.Remove(s.File.Name()))
+}
+
+// pebbleReader is implemented by both the indexed batch of a writable
+// transaction and the snapshot of a read-only one.
+type pebbleReader interface {
+	Get(key []byte) ([]byte, io.Closer, error)
+	NewIter(o *pebble.IterOptions) (*pebble.Iterator, error)
+}
+
+type pebbleTx struct {
+	e     *pebbleEngine
+	r     pebbleReader
+	batch *pebble.Batch
+	snap  *pebble.Snapshot
+
+	// mu protects buckets, which caches the result of bucket lookups.
+	mu      sync.Mutex
+	buckets map[string]*pebbleBucket
+	closed  bool
+}
+
+func (tx *pebbleTx) Bucket(name []byte) engineBucket {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	b, ok := tx.buckets[string(name)]
+	if !ok {
+		if len(name) > 0 && len(name) <= maxPebbleBucketNameLen {
+			_, closer, err := tx.r.Get(pebbleBucketMarker(name))
+			switch {
+			case err == nil:
+				closer.Close()
+				b = newPebbleBucket(tx, name)
+			case !errors.Is(err, pebble.ErrNotFound):
+				tx.e.lg.Fatal("failed to look up a bucket", zap.ByteString("bucket-name", name), zap.Error(err))
+			}
+		}
+		tx.buckets[string(name)] = b
+	}
+	if b == nil {
+		return nil
+	}
+	return b
+}
+
+func (tx *pebbleTx) CreateBucketIfNotExists(name []byte) (engineBucket, error) {
+	if tx.batch == nil {
+		return nil, errPebbleTxNotWritable
+	}
+	if len(name) == 0 || len(name) > maxPebbleBucketNameLen {
+		return nil, fmt.Errorf("backend: bucket name must be 1 to %d bytes long", maxPebbleBucketNameLen)
+	}
+	if b := tx.Bucket(name); b != nil {
+		return b, nil
+	}
+	if err := tx.batch.Set(pebbleBucketMarker(name), nil, nil); err != nil {
+		return nil, err
+	}
+	b := newPebbleBucket(tx, name)
+	tx.mu.Lock()
+	tx.buckets[string(name)] = b
+	tx.mu.Unlock()
+	return b, nil
+}
+
+func (tx *pebbleTx) DeleteBucket(name []byte) error {
+	if tx.batch == nil {
+		return errPebbleTxNotWritable
+	}
+	if tx.Bucket(name) == nil {
+		return nil
+	}
+	b := newPebbleBucket(tx, name)
+	if err := tx.batch.Delete(pebbleBucketMarker(name), nil); err != nil {
+		return err
+	}
+	if err := tx.batch.DeleteRange(b.prefix, b.upper, nil); err != nil {
+		return err
+	}
+	tx.mu.Lock()
+	tx.buckets[string(name)] = nil
+	tx.mu.Unlock()
+	return nil
+}
+
+func (tx *pebbleTx) ForEachBucket(fn func(name []byte, b engineBucket) error) error {
+	it, err := tx.r.NewIter(&pebble.IterOptions{
+		LowerBound: pebbleBucketMarkerStart,
+		UpperBound: pebbleBucketMarkerEnd,
+	})
+	if err != nil {
+		return err
+	}
+	for it.First(); it.Valid(); it.Next() {
+		name := append([]byte(nil), it.Key()[len(pebbleBucketMarkerStart):]...)
+		if err = fn(name, newPebbleBucket(tx, name)); err != nil {
+			it.Close()
+			return err
+		}
+	}
+	return it.Close()
+}
+
+func (tx *pebbleTx) Stats() engineStats {
+	m := tx.e.db.Metrics()
+	return engineStats{
+		size:        int64(m.DiskSpaceUsage()),
+		sizeInUse:   m.Total().Size + int64(m.WAL.PhysicalSize),
+		openReadTxN: tx.e.openReadTxN.Load(),
+	}
+}
+
+func (tx *pebbleTx) Commit() error {
+	if tx.batch == nil {
+		return errPebbleTxNotWritable
+	}
+	if tx.closed {
+		return errPebbleTxClosed
+	}
+	err := tx.batch.Commit(tx.e.wopts)
+	tx.close()
+	return err
+}
+
+func (tx *pebbleTx) Rollback() error {
+	if tx.closed {
+		return errPebbleTxClosed
+	}
+	return tx.close()
+}
+
+func (tx *pebbleTx) close() error {
+	tx.closed = true
+	if tx.batch != nil {
+		return tx.batch.Close()
+	}
+	err := tx.snap.Close()
+	tx.e.openReadTxN.Add(-1)
+	tx.e.readTxWg.Done()
+	return err
+}
+
+type pebbleBucket struct {
+	tx *pebbleTx
+	// prefix and upper bound the keys of the bucket.
+	prefix []byte
+	upper  []byte
+}
+
+func newPebbleBucket(tx *pebbleTx, name []byte) *pebbleBucket {
+	prefix := make([]byte, 0, len(name)+1)
+	prefix = append(append(prefix, byte(len(name))), name...)
+	upper := append([]byte(nil), prefix...)
+	for i := len(upper) - 1; i >= 0; i-- {
+		if upper[i] < 0xff {
+			upper[i]++
+			upper = upper[:i+1]
+			break
+		}
+	}
+	return &pebbleBucket{tx: tx, prefix: prefix, upper: upper}
+}
+
+func (b *pebbleBucket) key(key []byte) []byte {
+	k := make([]byte, 0, len(b.prefix)+len(key))
+	return append(append(k, b.prefix...), key...)
+}
+
+func (b *pebbleBucket) Put(key, value []byte) error {
+	if b.tx.batch == nil {
+		return errPebbleTxNotWritable
+	}
+	return b.tx.batch.Set(b.key(key), value, nil)
+}
+
+// SeqPut is the same as Put, as pebble does not split pages.
+func (b *pebbleBucket) SeqPut(key, value []byte) error {
+	return b.Put(key, value)
+}
+
+func (b *pebbleBucket) Delete(key []byte) error {
+	if b.tx.batch == nil {
+		return errPebbleTxNotWritable
+	}
+	return b.tx.batch.Delete(b.key(key), nil)
+}
+
+func (b *pebbleBucket) Cursor() engineCursor {
+	it, err := b.tx.r.NewIter(&pebble.IterOptions{LowerBound: b.prefix, UpperBound: b.upper})
+	if err != nil {
+		b.tx.e.lg.Fatal("failed to create an iterator", zap.Error(err))
+	}
+	return &pebbleCursor{b: b, it: it}
+}
+
+func (b *pebbleBucket) ForEach(fn func(k, v []byte) error) error {
+	c := b.Cursor()
+	defer c.Close()
+	for k, v := c.Seek(nil); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats returns the total length of the keys and values of the bucket as its
+// size, as pebble compresses and compacts them in the background.
+func (b *pebbleBucket) Stats() (keys, size int64) {
+	b.ForEach(func(k, v []byte) error {
+		keys++
+		size += int64(len(k) + len(v))
+		return nil
+	})
+	return keys, size
+}
+
+type pebbleCursor struct {
+	b  *pebbleBucket
+	it *pebble.Iterator
+}
+
+func (c *pebbleCursor) Seek(key []byte) ([]byte, []byte) {
+	c.it.SeekGE(c.b.key(key))
+	return c.current()
+}
+
+func (c *pebbleCursor) Next() ([]byte, []byte) {
+	c.it.Next()
+	return c.current()
+}
+
+// current copies the key and value under the cursor, since pebble only keeps
+// them valid until the iterator moves, while bbolt keeps them valid for the
+// whole transaction.
+func (c *pebbleCursor) current() ([]byte, []byte) {
+	if !c.it.Valid() {
+		return nil, nil
+	}
+	key := c.it.Key()[len(c.b.prefix):]
+	k := make([]byte, len(key))
+	copy(k, key)
+	value := c.it.Value()
+	v := make([]byte, len(value))
+	copy(v, value)
+	return k, v
+}
+
+func (c *pebbleCursor) Close() {
+	if err := c.it.Close(); err != nil {
+		c.b.tx.e.lg.Error("failed to close an iterator", zap.Error(err))
+	}
+}
+
+func pebbleBucketMarker(name []byte) []byte {
+	k := make([]byte, 0, len(pebbleBucketMarkerStart)+len(name))
+	return append(append(k, pebbleBucketMarkerStart...), name...)
+}
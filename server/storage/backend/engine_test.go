@@ -0,0 +1,239 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	bolt "go.etcd.io/bbolt"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func newTmpEngineBackend(t *testing.T, engine string) (backend.Backend, string) {
+	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(t))
+	bcfg.Engine, bcfg.BatchInterval = engine, time.Hour
+	return betesting.NewTmpBackendFromCfg(t, bcfg)
+}
+
+func openEngineBackend(t *testing.T, path, engine string) backend.Backend {
+	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(t))
+	bcfg.Path, bcfg.Engine, bcfg.BatchInterval = path, engine, time.Hour
+	return backend.New(bcfg)
+}
+
+// writeEngineTestData writes the same keys to the given backend, deleting
+// some of them and a whole bucket on the way.
+func writeEngineTestData(b backend.Backend) {
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Key)
+	tx.UnsafeCreateBucket(schema.Meta)
+	tx.UnsafeCreateBucket(schema.Test)
+	for i := 0; i < 100; i++ {
+		tx.UnsafeSeqPut(schema.Key, []byte(fmt.Sprintf("key_%03d", i)), []byte(fmt.Sprintf("val_%d", i)))
+		tx.UnsafePut(schema.Test, []byte(fmt.Sprintf("test_%03d", i)), []byte("bar"))
+	}
+	tx.UnsafePut(schema.Meta, []byte("meta"), []byte{})
+	tx.Unlock()
+	b.ForceCommit()
+
+	tx.Lock()
+	for i := 0; i < 10; i++ {
+		tx.UnsafeDelete(schema.Key, []byte(fmt.Sprintf("key_%03d", i)))
+	}
+	tx.UnsafeDeleteBucket(schema.Test)
+	tx.UnsafeDeleteBucket(schema.Lease)
+	tx.Unlock()
+	b.ForceCommit()
+}
+
+func TestPebbleBackend(t *testing.T) {
+	b, path := newTmpEngineBackend(t, backend.EnginePebble)
+	defer betesting.Close(t, b)
+	writeEngineTestData(b)
+
+	engine, err := backend.DetectEngine(path)
+	require.NoError(t, err)
+	assert.Equal(t, backend.EnginePebble, engine)
+
+	rtx := b.ConcurrentReadTx()
+	rtx.RLock()
+	ks, vs := rtx.UnsafeRange(schema.Key, []byte("key_"), []byte("key_999"), 0)
+	rtx.RUnlock()
+	require.Len(t, ks, 90)
+	assert.Equal(t, []byte("key_010"), ks[0])
+	assert.Equal(t, []byte("val_10"), vs[0])
+
+	tx := b.BatchTx()
+	tx.Lock()
+	ks, _ = tx.UnsafeRange(schema.Key, []byte("key_005"), []byte("key_015"), 2)
+	assert.Equal(t, [][]byte{[]byte("key_010"), []byte("key_011")}, ks)
+	ks, vs = tx.UnsafeRange(schema.Meta, []byte("meta"), nil, 0)
+	assert.Equal(t, [][]byte{[]byte("meta")}, ks)
+	assert.Equal(t, [][]byte{{}}, vs)
+	var n int
+	require.NoError(t, tx.UnsafeForEach(schema.Key, func(k, v []byte) error {
+		n++
+		return nil
+	}))
+	assert.Equal(t, 90, n)
+	tx.Unlock()
+
+	require.NoError(t, b.Defrag())
+	rtx = b.ReadTx()
+	rtx.RLock()
+	ks, _ = rtx.UnsafeRange(schema.Key, []byte("key_"), []byte("key_999"), 0)
+	rtx.RUnlock()
+	assert.Len(t, ks, 90)
+}
+
+func TestBackendHashAcrossEngines(t *testing.T) {
+	bb, _ := newTmpEngineBackend(t, backend.EngineBolt)
+	defer betesting.Close(t, bb)
+	pb, _ := newTmpEngineBackend(t, backend.EnginePebble)
+	defer betesting.Close(t, pb)
+	writeEngineTestData(bb)
+	writeEngineTestData(pb)
+
+	bh, err := bb.Hash(nil)
+	require.NoError(t, err)
+	ph, err := pb.Hash(nil)
+	require.NoError(t, err)
+	assert.Equal(t, bh, ph)
+}
+
+func TestBackendBucketStats(t *testing.T) {
+	for _, engine := range []string{backend.EngineBolt, backend.EnginePebble} {
+		t.Run(engine, func(t *testing.T) {
+			b, _ := newTmpEngineBackend(t, engine)
+			defer betesting.Close(t, b)
+			writeEngineTestData(b)
+
+			stats, err := b.BucketStats()
+			require.NoError(t, err)
+			require.Len(t, stats, 2)
+			assert.Equal(t, "key", stats[0].Name)
+			assert.Equal(t, int64(90), stats[0].Keys)
+			// the keys and values of the key bucket take 12 to 13 bytes
+			assert.GreaterOrEqual(t, stats[0].Size, int64(90*12))
+			assert.Equal(t, "meta", stats[1].Name)
+			assert.Equal(t, int64(1), stats[1].Keys)
+		})
+	}
+}
+
+func TestPebbleBackendSnapshot(t *testing.T) {
+	b, _ := newTmpEngineBackend(t, backend.EnginePebble)
+	defer betesting.Close(t, b)
+	writeEngineTestData(b)
+	h, err := b.Hash(nil)
+	require.NoError(t, err)
+
+	f, err := os.CreateTemp(t.TempDir(), "etcd_backend_test")
+	require.NoError(t, err)
+	snap := b.Snapshot()
+	n, err := snap.WriteTo(f)
+	require.NoError(t, err)
+	assert.Equal(t, snap.Size(), n)
+	require.NoError(t, snap.Close())
+	require.NoError(t, f.Close())
+
+	// the snapshot is a bbolt database
+	db, err := bolt.Open(f.Name(), 0o600, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	sb := openEngineBackend(t, f.Name(), backend.EngineBolt)
+	defer betesting.Close(t, sb)
+	sh, err := sb.Hash(nil)
+	require.NoError(t, err)
+	assert.Equal(t, h, sh)
+}
+
+func TestConvert(t *testing.T) {
+	b, path := newTmpEngineBackend(t, backend.EngineBolt)
+	writeEngineTestData(b)
+	h, err := b.Hash(nil)
+	require.NoError(t, err)
+	betesting.Close(t, b)
+
+	dir := t.TempDir()
+	ppath := filepath.Join(dir, "pebble")
+	require.NoError(t, backend.Convert(zaptest.NewLogger(t), path, ppath, backend.EnginePebble))
+	require.Error(t, backend.Convert(zaptest.NewLogger(t), path, ppath, backend.EnginePebble))
+	bpath := filepath.Join(dir, "bolt")
+	require.NoError(t, backend.Convert(zaptest.NewLogger(t), ppath, bpath, backend.EngineBolt))
+
+	for path, engine := range map[string]string{ppath: backend.EnginePebble, bpath: backend.EngineBolt} {
+		detected, err := backend.DetectEngine(path)
+		require.NoError(t, err)
+		assert.Equal(t, engine, detected)
+
+		cb := openEngineBackend(t, path, engine)
+		ch, err := cb.Hash(nil)
+		require.NoError(t, err)
+		assert.Equal(t, h, ch, "hash of converted %s database", engine)
+		betesting.Close(t, cb)
+	}
+}
+
+func TestImportPebbleSnapshot(t *testing.T) {
+	b, path := newTmpEngineBackend(t, backend.EnginePebble)
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Key)
+	tx.UnsafePut(schema.Key, []byte("foo"), []byte("old"))
+	tx.Unlock()
+	b.ForceCommit()
+
+	sb, snapPath := newTmpEngineBackend(t, backend.EngineBolt)
+	tx = sb.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Key)
+	tx.UnsafePut(schema.Key, []byte("foo"), []byte("new"))
+	tx.Unlock()
+	betesting.Close(t, sb)
+
+	require.NoError(t, backend.ImportPebbleSnapshot(zaptest.NewLogger(t), snapPath, path))
+	nb := openEngineBackend(t, path, backend.EnginePebble)
+	defer betesting.Close(t, nb)
+
+	// the backend open before the import keeps its data
+	for be, want := range map[backend.Backend]string{b: "old", nb: "new"} {
+		rtx := be.ReadTx()
+		rtx.RLock()
+		_, vs := rtx.UnsafeRange(schema.Key, []byte("foo"), nil, 0)
+		rtx.RUnlock()
+		assert.Equal(t, [][]byte{[]byte(want)}, vs)
+	}
+
+	entries, err := os.ReadDir(path)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	betesting.Close(t, b)
+	entries, err = os.ReadDir(path)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
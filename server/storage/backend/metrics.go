@@ -90,6 +90,20 @@ var (
 		Name:      "defrag_inflight",
 		Help:      "Whether or not defrag is active on the member. 1 means active, 0 means not.",
 	})
+
+	bucketKeys = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "disk",
+		Name:      "backend_bucket_keys",
+		Help:      "Number of keys in each bucket of the backend, as last tracked.",
+	}, []string{"bucket"})
+
+	bucketSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "disk",
+		Name:      "backend_bucket_size_bytes",
+		Help:      "Number of bytes used by each bucket of the backend, as last tracked.",
+	}, []string{"bucket"})
 )
 
 func init() {
@@ -100,4 +114,6 @@ func init() {
 	prometheus.MustRegister(defragSec)
 	prometheus.MustRegister(snapshotTransferSec)
 	prometheus.MustRegister(isDefragActive)
+	prometheus.MustRegister(bucketKeys)
+	prometheus.MustRegister(bucketSize)
 }
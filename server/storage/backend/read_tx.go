@@ -17,8 +17,6 @@ package backend
 import (
 	"math"
 	"sync"
-
-	bolt "go.etcd.io/bbolt"
 )
 
 // IsSafeRangeBucket is a hack to avoid inadvertently reading duplicate keys;
@@ -45,8 +43,8 @@ type baseReadTx struct {
 	// TODO: group and encapsulate {txMu, tx, buckets, txWg}, as they share the same lifecycle.
 	// txMu protects accesses to buckets and tx on Range requests.
 	txMu    *sync.RWMutex
-	tx      *bolt.Tx
-	buckets map[BucketID]*bolt.Bucket
+	tx      engineTx
+	buckets map[BucketID]engineBucket
 	// txWg protects tx from being rolled back at the end of a batch interval until all reads using this tx are done.
 	txWg *sync.WaitGroup
 }
@@ -118,6 +116,7 @@ func (baseReadTx *baseReadTx) UnsafeRange(bucketType Bucket, key, endKey []byte,
 	baseReadTx.txMu.Unlock()
 
 	k2, v2 := unsafeRange(c, key, endKey, limit-int64(len(keys)))
+	c.Close()
 	return append(k2, keys...), append(v2, vals...)
 }
 
@@ -132,7 +131,7 @@ func (rt *readTx) RUnlock() { rt.mu.RUnlock() }
 
 func (rt *readTx) reset() {
 	rt.buf.reset()
-	rt.buckets = make(map[BucketID]*bolt.Bucket)
+	rt.buckets = make(map[BucketID]engineBucket)
 	rt.tx = nil
 	rt.txWg = new(sync.WaitGroup)
 }
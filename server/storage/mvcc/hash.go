@@ -15,6 +15,7 @@
 package mvcc
 
 import (
+	"encoding/binary"
 	"hash"
 	"hash/crc32"
 	"sort"
@@ -30,13 +31,41 @@ const (
 	hashStorageMaxSize = 10
 )
 
-func unsafeHashByRev(tx backend.UnsafeReader, compactRevision, revision int64, keep map[Revision]struct{}) (KeyValueHash, error) {
+// hashRevisionWindow is the number of main revisions whose keys are read at
+// once to hash a store holding chunked values.
+var hashRevisionWindow int64 = 10000
+
+func unsafeHashByRev(tx backend.UnsafeReader, compactRevision, revision int64, keep map[Revision]struct{}, valueChunks bool) (KeyValueHash, error) {
 	h := newKVHasher(compactRevision, revision, keep)
-	err := tx.UnsafeForEach(schema.Key, func(k, v []byte) error {
-		h.WriteKeyValue(k, v)
-		return nil
-	})
-	return h.Hash(), err
+	if !valueChunks {
+		err := tx.UnsafeForEach(schema.Key, func(k, v []byte) error {
+			h.WriteKeyValue(k, v)
+			return nil
+		})
+		return h.Hash(), err
+	}
+
+	// UnsafeForEach does not allow nested reads, so the keys are ranged over
+	// in windows of revisions instead, reading every chunked value along with
+	// its key so that only one of them is held in memory at a time.
+	lowest := int64(0)
+	if len(keep) > 0 {
+		lowest = compactRevision + 1
+		for rev := range keep {
+			lowest = min(lowest, rev.Main)
+		}
+	}
+	start, end := make([]byte, 8), make([]byte, 8)
+	for main := lowest; main <= revision; main += hashRevisionWindow {
+		binary.BigEndian.PutUint64(start, uint64(main))
+		binary.BigEndian.PutUint64(end, uint64(main+hashRevisionWindow))
+		keys, values := tx.UnsafeRange(schema.Key, start, end, 0)
+		for i := range keys {
+			v, _ := unsafeLogicalKeyValue(tx, keys[i], values[i])
+			h.WriteKeyValue(keys[i], v)
+		}
+	}
+	return h.Hash(), nil
 }
 
 type kvHasher struct {
@@ -59,22 +88,12 @@ func newKVHasher(compactRev, rev int64, keep map[Revision]struct{}) kvHasher {
 
 func (h *kvHasher) WriteKeyValue(k, v []byte) {
 	kr := BytesToRev(k)
-	upper := Revision{Main: h.revision + 1}
-	if !upper.GreaterThan(kr) {
+	if !h.includes(kr) {
 		return
 	}
 
 	isTombstone := BytesToBucketKey(k).tombstone
 
-	lower := Revision{Main: h.compactRevision + 1}
-	// skip revisions that are scheduled for deletion
-	// due to compacting; don't skip if there isn't one.
-	if lower.GreaterThan(kr) && len(h.keep) > 0 {
-		if _, ok := h.keep[kr]; !ok {
-			return
-		}
-	}
-
 	// When performing compaction, if the compacted revision is a
 	// tombstone, older versions (<= 3.5.15 or <= 3.4.33) will delete
 	// the tombstone. But newer versions (> 3.5.15 or > 3.4.33) won't
@@ -89,6 +108,24 @@ func (h *kvHasher) WriteKeyValue(k, v []byte) {
 	h.hash.Write(v)
 }
 
+// includes returns true if the given revision is within the hashed range.
+func (h *kvHasher) includes(kr Revision) bool {
+	upper := Revision{Main: h.revision + 1}
+	if !upper.GreaterThan(kr) {
+		return false
+	}
+
+	lower := Revision{Main: h.compactRevision + 1}
+	// skip revisions that are scheduled for deletion
+	// due to compacting; don't skip if there isn't one.
+	if lower.GreaterThan(kr) && len(h.keep) > 0 {
+		if _, ok := h.keep[kr]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (h *kvHasher) Hash() KeyValueHash {
 	return KeyValueHash{Hash: h.hash.Sum32(), CompactRevision: h.compactRevision, Revision: h.revision}
 }
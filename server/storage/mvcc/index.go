@@ -26,6 +26,7 @@ type index interface {
 	Range(key, end []byte, atRev int64) ([][]byte, []Revision)
 	Revisions(key, end []byte, atRev int64, limit int) ([]Revision, int)
 	CountRevisions(key, end []byte, atRev int64) int
+	CountModifications(key, end []byte, since, atRev int64) int
 	Put(key []byte, rev Revision)
 	Tombstone(key []byte, rev Revision) error
 	Compact(rev int64) map[Revision]struct{}
@@ -34,12 +35,21 @@ type index interface {
 
 	Insert(ki *keyIndex)
 	KeyIndex(ki *keyIndex) *keyIndex
+	// KeyIndexesAt visits up to limit key indexes from key on, in key order,
+	// and returns copies of them as they were at atRev, skipping keys created
+	// after atRev. It also returns the key to continue from, or nil once all
+	// keys were visited.
+	KeyIndexesAt(key []byte, atRev int64, limit int) ([]*keyIndex, []byte)
+	// Bytes estimates the memory held by the index.
+	Bytes() int64
 }
 
 type treeIndex struct {
 	sync.RWMutex
 	tree *btree.BTreeG[*keyIndex]
 	lg   *zap.Logger
+	// bytes is the sum of the estimated sizes of the key indexes.
+	bytes int64
 }
 
 func newTreeIndex(lg *zap.Logger) index {
@@ -60,9 +70,12 @@ func (ti *treeIndex) Put(key []byte, rev Revision) {
 	if !ok {
 		keyi.put(ti.lg, rev.Main, rev.Sub)
 		ti.tree.ReplaceOrInsert(keyi)
+		ti.bytes += keyi.bytes()
 		return
 	}
+	before := okeyi.bytes()
 	okeyi.put(ti.lg, rev.Main, rev.Sub)
+	ti.bytes += okeyi.bytes() - before
 }
 
 func (ti *treeIndex) Get(key []byte, atRev int64) (modified, created Revision, ver int64, err error) {
@@ -155,6 +168,27 @@ func (ti *treeIndex) CountRevisions(key, end []byte, atRev int64) int {
 	return total
 }
 
+// CountModifications returns the number of modifications from key(included)
+// to end(excluded) with main revisions in (since, atRev].
+func (ti *treeIndex) CountModifications(key, end []byte, since, atRev int64) int {
+	ti.RLock()
+	defer ti.RUnlock()
+
+	if end == nil {
+		ki := ti.keyIndex(&keyIndex{key: key})
+		if ki == nil {
+			return 0
+		}
+		return ki.countModifications(since, atRev)
+	}
+	total := 0
+	ti.unsafeVisit(key, end, func(ki *keyIndex) bool {
+		total += ki.countModifications(since, atRev)
+		return true
+	})
+	return total
+}
+
 func (ti *treeIndex) Range(key, end []byte, atRev int64) (keys [][]byte, revs []Revision) {
 	ti.RLock()
 	defer ti.RUnlock()
@@ -186,7 +220,10 @@ func (ti *treeIndex) Tombstone(key []byte, rev Revision) error {
 		return ErrRevisionNotFound
 	}
 
-	return ki.tombstone(ti.lg, rev.Main, rev.Sub)
+	before := ki.bytes()
+	err := ki.tombstone(ti.lg, rev.Main, rev.Sub)
+	ti.bytes += ki.bytes() - before
+	return err
 }
 
 func (ti *treeIndex) Compact(rev int64) map[Revision]struct{} {
@@ -200,12 +237,16 @@ func (ti *treeIndex) Compact(rev int64) map[Revision]struct{} {
 		// Lock is needed here to prevent modification to the keyIndex while
 		// compaction is going on or revision added to empty before deletion
 		ti.Lock()
+		before := keyi.bytes()
 		keyi.compact(ti.lg, rev, available)
 		if keyi.isEmpty() {
 			_, ok := ti.tree.Delete(keyi)
 			if !ok {
 				ti.lg.Panic("failed to delete during compaction")
 			}
+			ti.bytes -= before
+		} else {
+			ti.bytes += keyi.bytes() - before
 		}
 		ti.Unlock()
 		return true
@@ -225,6 +266,24 @@ func (ti *treeIndex) Keep(rev int64) map[Revision]struct{} {
 	return available
 }
 
+func (ti *treeIndex) KeyIndexesAt(key []byte, atRev int64, limit int) (kis []*keyIndex, next []byte) {
+	ti.RLock()
+	defer ti.RUnlock()
+	visited := 0
+	ti.unsafeVisit(key, nil, func(ki *keyIndex) bool {
+		if visited == limit {
+			next = ki.key
+			return false
+		}
+		visited++
+		if c := ki.at(atRev); c != nil {
+			kis = append(kis, c)
+		}
+		return true
+	})
+	return kis, next
+}
+
 func (ti *treeIndex) Equal(bi index) bool {
 	b := bi.(*treeIndex)
 
@@ -249,5 +308,14 @@ func (ti *treeIndex) Equal(bi index) bool {
 func (ti *treeIndex) Insert(ki *keyIndex) {
 	ti.Lock()
 	defer ti.Unlock()
-	ti.tree.ReplaceOrInsert(ki)
+	if old, ok := ti.tree.ReplaceOrInsert(ki); ok {
+		ti.bytes -= old.bytes()
+	}
+	ti.bytes += ki.bytes()
+}
+
+func (ti *treeIndex) Bytes() int64 {
+	ti.RLock()
+	defer ti.RUnlock()
+	return ti.bytes
 }
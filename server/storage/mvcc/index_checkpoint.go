@@ -0,0 +1,341 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/pkg/v3/schedule"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+// An index checkpoint persists the key index at a revision in the key index
+// checkpoint bucket, so that restoring the index only needs to replay the
+// revisions written after it. The bucket holds a meta entry and the keys of
+// the index in chunks, in key order:
+//
+//	meta:  version | revision | compact revision | number of chunks
+//	chunk: (length | key index)...
+//
+// A checkpoint is only used if no compaction happened since it was taken, as
+// the revisions removed by a compaction cannot be replayed.
+
+const (
+	indexCheckpointVersion   = 1
+	indexCheckpointChunkKeys = 10000
+)
+
+var (
+	indexCheckpointMetaKey = []byte("meta")
+
+	errInvalidIndexCheckpoint = errors.New("mvcc: invalid index checkpoint")
+)
+
+type indexCheckpointMeta struct {
+	rev        int64
+	compactRev int64
+	chunks     int
+}
+
+func (m indexCheckpointMeta) marshal() []byte {
+	b := []byte{indexCheckpointVersion}
+	b = binary.AppendVarint(b, m.rev)
+	b = binary.AppendVarint(b, m.compactRev)
+	return binary.AppendUvarint(b, uint64(m.chunks))
+}
+
+func (m *indexCheckpointMeta) unmarshal(b []byte) error {
+	if len(b) == 0 || b[0] != indexCheckpointVersion {
+		return errInvalidIndexCheckpoint
+	}
+	d := indexCheckpointDecoder{b: b[1:]}
+	m.rev, m.compactRev, m.chunks = d.varint(), d.varint(), int(d.uvarint())
+	return d.err
+}
+
+func indexCheckpointChunkKey(i int) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(i))
+}
+
+// unsafeReadIndexCheckpoint returns the meta and the chunks of the index
+// checkpoint, if there is one.
+func unsafeReadIndexCheckpoint(tx backend.UnsafeReader) (indexCheckpointMeta, [][]byte, error) {
+	var m indexCheckpointMeta
+	var meta []byte
+	var chunks [][]byte
+	err := tx.UnsafeForEach(schema.KeyIndexCheckpoint, func(k, v []byte) error {
+		if string(k) == string(indexCheckpointMetaKey) {
+			meta = v
+		} else {
+			chunks = append(chunks, v)
+		}
+		return nil
+	})
+	if err != nil || meta == nil {
+		return m, nil, err
+	}
+	if err = m.unmarshal(meta); err != nil {
+		return m, nil, err
+	}
+	if m.chunks != len(chunks) {
+		return m, nil, errInvalidIndexCheckpoint
+	}
+	return m, chunks, nil
+}
+
+func appendKeyIndex(b []byte, ki *keyIndex, lid lease.LeaseID) []byte {
+	var e []byte
+	e = binary.AppendUvarint(e, uint64(len(ki.key)))
+	e = append(e, ki.key...)
+	e = binary.AppendVarint(e, int64(lid))
+	e = appendRevision(e, ki.modified)
+	e = binary.AppendUvarint(e, uint64(len(ki.generations)))
+	for _, g := range ki.generations {
+		e = binary.AppendVarint(e, g.ver)
+		e = appendRevision(e, g.created)
+		e = binary.AppendUvarint(e, uint64(len(g.revs)))
+		for _, rev := range g.revs {
+			e = appendRevision(e, rev)
+		}
+	}
+	b = binary.AppendUvarint(b, uint64(len(e)))
+	return append(b, e...)
+}
+
+func appendRevision(b []byte, rev Revision) []byte {
+	b = binary.AppendVarint(b, rev.Main)
+	return binary.AppendVarint(b, rev.Sub)
+}
+
+// decodeIndexCheckpointChunk calls fn with the key indexes of the chunk whose
+// key is accepted by owns.
+func decodeIndexCheckpointChunk(chunk []byte, owns func(key []byte) bool, fn func(ki *keyIndex, lid lease.LeaseID)) error {
+	d := indexCheckpointDecoder{b: chunk}
+	for len(d.b) > 0 && d.err == nil {
+		e := indexCheckpointDecoder{b: d.bytes(int(d.uvarint()))}
+		key := e.bytes(int(e.uvarint()))
+		if e.err != nil || !owns(key) {
+			d.err = e.err
+			continue
+		}
+		ki := &keyIndex{key: append([]byte(nil), key...)}
+		lid := lease.LeaseID(e.varint())
+		ki.modified = e.revision()
+		ki.generations = make([]generation, e.length())
+		for i := range ki.generations {
+			g := &ki.generations[i]
+			g.ver = e.varint()
+			g.created = e.revision()
+			if n := e.length(); n > 0 {
+				g.revs = make([]Revision, n)
+				for j := range g.revs {
+					g.revs[j] = e.revision()
+				}
+			}
+		}
+		if e.err == nil && (len(e.b) != 0 || len(ki.generations) == 0) {
+			e.err = errInvalidIndexCheckpoint
+		}
+		if d.err = e.err; d.err == nil {
+			fn(ki, lid)
+		}
+	}
+	return d.err
+}
+
+type indexCheckpointDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *indexCheckpointDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.b)
+	if n <= 0 {
+		d.err = errInvalidIndexCheckpoint
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *indexCheckpointDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.err = errInvalidIndexCheckpoint
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+// length decodes a number of elements, which cannot exceed the remaining
+// bytes.
+func (d *indexCheckpointDecoder) length() int {
+	n := d.uvarint()
+	if n > uint64(len(d.b)) {
+		d.err = errInvalidIndexCheckpoint
+		return 0
+	}
+	return int(n)
+}
+
+func (d *indexCheckpointDecoder) bytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.b) {
+		d.err = errInvalidIndexCheckpoint
+		return nil
+	}
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b
+}
+
+func (d *indexCheckpointDecoder) revision() Revision {
+	return Revision{Main: d.varint(), Sub: d.varint()}
+}
+
+// startIndexCheckpoints periodically schedules index checkpoints until stopc
+// is closed.
+func (s *store) startIndexCheckpoints(stopc <-chan struct{}) {
+	if s.cfg.IndexCheckpointInterval <= 0 {
+		return
+	}
+	go func() {
+		t := time.NewTicker(s.cfg.IndexCheckpointInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-stopc:
+				return
+			case <-t.C:
+			}
+			s.mu.Lock()
+			select {
+			case <-stopc:
+				s.mu.Unlock()
+				return
+			default:
+			}
+			// checkpoints are serialized with compactions
+			s.fifoSched.Schedule(schedule.NewJob("kvstore_indexCheckpoint", func(ctx context.Context) {
+				if ctx.Err() == nil {
+					s.checkpointIndex(ctx)
+				}
+			}))
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// checkpointIndex persists the key index at the current revision. The index
+// is copied in batches under its read lock, and each chunk is written in its
+// own backend transaction, so neither writes nor applies wait for the whole
+// index. The meta is deleted first and written last, so an incomplete
+// checkpoint is never used.
+func (s *store) checkpointIndex(ctx context.Context) {
+	s.revMu.RLock()
+	m := indexCheckpointMeta{rev: s.currentRev, compactRev: s.compactMainRev}
+	s.revMu.RUnlock()
+
+	start := time.Now()
+	tx := s.b.BatchTx()
+	tx.LockOutsideApply()
+	tx.UnsafeCreateBucket(schema.KeyIndexCheckpoint)
+	_, vs := tx.UnsafeRange(schema.KeyIndexCheckpoint, indexCheckpointMetaKey, nil, 0)
+	var old indexCheckpointMeta
+	if len(vs) == 1 && old.unmarshal(vs[0]) == nil && old.rev == m.rev && old.compactRev == m.compactRev {
+		// nothing changed since the last checkpoint
+		tx.Unlock()
+		return
+	}
+	tx.UnsafeDelete(schema.KeyIndexCheckpoint, indexCheckpointMetaKey)
+	tx.Unlock()
+
+	keys := 0
+	var next []byte
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		var kis []*keyIndex
+		kis, next = s.kvindex.KeyIndexesAt(next, m.rev, indexCheckpointChunkKeys)
+		var chunk []byte
+		for _, ki := range kis {
+			// keys changed after m.rev get their lease back when their
+			// revisions are replayed
+			lid := lease.NoLease
+			if s.le != nil && !ki.generations[len(ki.generations)-1].isEmpty() {
+				lid = s.le.GetLease(lease.LeaseItem{Key: string(ki.key)})
+			}
+			chunk = appendKeyIndex(chunk, ki, lid)
+		}
+		if len(chunk) > 0 {
+			tx.LockOutsideApply()
+			tx.UnsafePut(schema.KeyIndexCheckpoint, indexCheckpointChunkKey(m.chunks), chunk)
+			tx.Unlock()
+			keys += len(kis)
+			m.chunks++
+		}
+		if next == nil {
+			break
+		}
+	}
+
+	tx.LockOutsideApply()
+	defer tx.Unlock()
+	var stale [][]byte
+	tx.UnsafeForEach(schema.KeyIndexCheckpoint, func(k, _ []byte) error {
+		if len(k) == 8 && binary.BigEndian.Uint64(k) >= uint64(m.chunks) {
+			stale = append(stale, k)
+		}
+		return nil
+	})
+	for _, k := range stale {
+		tx.UnsafeDelete(schema.KeyIndexCheckpoint, k)
+	}
+	s.revMu.RLock()
+	compacted := s.compactMainRev != m.compactRev
+	s.revMu.RUnlock()
+	if compacted {
+		// the index copy may miss revisions of the compaction
+		s.lg.Info("discarded key index checkpoint taken during compaction", zap.Int64("revision", m.rev))
+		return
+	}
+	tx.UnsafePut(schema.KeyIndexCheckpoint, indexCheckpointMetaKey, m.marshal())
+
+	indexCheckpointSec.Observe(time.Since(start).Seconds())
+	s.lg.Info(
+		"persisted key index checkpoint",
+		zap.Int64("revision", m.rev),
+		zap.Int("keys", keys),
+		zap.Int("chunks", m.chunks),
+		zap.Duration("took", time.Since(start)),
+	)
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+// itemLessor keeps the lease of the attached items.
+type itemLessor struct {
+	lease.FakeLessor
+	items map[lease.LeaseItem]lease.LeaseID
+}
+
+func newItemLessor() *itemLessor {
+	return &itemLessor{items: make(map[lease.LeaseItem]lease.LeaseID)}
+}
+
+func (le *itemLessor) Attach(id lease.LeaseID, items []lease.LeaseItem) error {
+	for _, it := range items {
+		le.items[it] = id
+	}
+	return nil
+}
+
+func (le *itemLessor) Detach(id lease.LeaseID, items []lease.LeaseItem) error {
+	for _, it := range items {
+		delete(le.items, it)
+	}
+	return nil
+}
+
+func (le *itemLessor) GetLease(item lease.LeaseItem) lease.LeaseID { return le.items[item] }
+
+func TestIndexCheckpointRestore(t *testing.T) {
+	oldChunk := restoreChunkKeys
+	restoreChunkKeys = 7
+	defer func() { restoreChunkKeys = oldChunk }()
+
+	tests := []struct {
+		name string
+		// compact compacts the store after the checkpoint, which makes it unusable
+		compact bool
+		// corrupt corrupts the checkpoint
+		corrupt bool
+	}{
+		{name: "checkpoint"},
+		{name: "compacted after checkpoint", compact: true},
+		{name: "corrupted checkpoint", corrupt: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := betesting.NewDefaultTmpBackend(t)
+			defer betesting.Close(t, b)
+			cfg := StoreConfig{IndexCheckpointInterval: time.Hour}
+			le := newItemLessor()
+			s := NewStore(zaptest.NewLogger(t), b, le, cfg)
+
+			r := rand.New(rand.NewSource(1))
+			write := func(n int) {
+				for i := 0; i < n; i++ {
+					k := []byte(fmt.Sprintf("foo-%02d", r.Intn(40)))
+					switch r.Intn(4) {
+					case 0:
+						s.DeleteRange(k, nil)
+					case 1:
+						s.Put(k, []byte("bar"), lease.LeaseID(r.Intn(3)))
+					default:
+						s.Put(k, []byte("bar"), lease.NoLease)
+					}
+				}
+			}
+			write(200)
+			s.checkpointIndex(t.Context())
+			if tt.compact {
+				donec, err := s.Compact(traceutil.TODO(), 150)
+				require.NoError(t, err)
+				<-donec
+			}
+			if tt.corrupt {
+				tx := b.BatchTx()
+				tx.Lock()
+				tx.UnsafePut(schema.KeyIndexCheckpoint, indexCheckpointChunkKey(0), []byte{0xff})
+				tx.Unlock()
+			}
+			write(100)
+			s.Close()
+
+			wantRev := s.currentRev
+			keys := make(map[string]lease.LeaseID)
+			for it, lid := range le.items {
+				keys[it.Key] = lid
+			}
+			le = newItemLessor()
+			restored := NewStore(zaptest.NewLogger(t), b, le, cfg)
+			defer restored.Close()
+			assert.Equal(t, wantRev, restored.currentRev)
+			assert.True(t, restored.kvindex.Equal(s.kvindex))
+			for k, lid := range keys {
+				assert.Equal(t, lid, le.items[lease.LeaseItem{Key: k}], k)
+			}
+		})
+	}
+}
+
+func TestIndexCheckpointDisabled(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, b)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{IndexCheckpointInterval: time.Hour})
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.checkpointIndex(t.Context())
+	s.Close()
+
+	// disabling checkpoints removes the stale checkpoint
+	s = NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer s.Close()
+	tx := b.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	_, chunks, err := unsafeReadIndexCheckpoint(tx)
+	require.NoError(t, err)
+	assert.Nil(t, chunks)
+}
+
+func TestDecodeRestoreKeyValue(t *testing.T) {
+	kvs := []mvccpb.KeyValue{
+		{Key: []byte("foo"), CreateRevision: 2, ModRevision: 5, Version: 3, Value: []byte("bar"), Lease: 7},
+		{Key: []byte("foo")},
+	}
+	for _, kv := range kvs {
+		b, err := kv.Marshal()
+		require.NoError(t, err)
+		rkv, err := decodeRestoreKeyValue(b)
+		require.NoError(t, err)
+		assert.Equal(t, restoreKeyValue{key: kv.Key, createRevision: kv.CreateRevision, version: kv.Version, lease: kv.Lease}, rkv)
+	}
+	_, err := decodeRestoreKeyValue([]byte{0x0a, 0x05})
+	require.Error(t, err)
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"cmp"
+	"errors"
+	"hash/maphash"
+	"runtime"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"go.etcd.io/etcd/client/pkg/v3/verify"
+	"go.etcd.io/etcd/server/v3/lease"
+)
+
+// indexRestorer rebuilds the key index in parallel. Every chunk read from the
+// backend is handed to all workers and each worker indexes the keys it owns,
+// so that it sees all revisions of its keys in order. The keys are assigned
+// to workers by hash.
+type indexRestorer struct {
+	lg      *zap.Logger
+	seed    maphash.Seed
+	workers []*indexRestoreWorker
+	wg      sync.WaitGroup
+}
+
+// restoreBatch is a chunk of the key bucket or of an index checkpoint.
+type restoreBatch struct {
+	keys, vals [][]byte
+	checkpoint []byte
+}
+
+type indexRestoreWorker struct {
+	r  *indexRestorer
+	id int
+
+	batchc chan restoreBatch
+	kis    map[string]*keyIndex
+	leases map[string]lease.LeaseID
+	// currentRev is the last revision restored by the worker.
+	currentRev int64
+	err        error
+}
+
+func newIndexRestorer(lg *zap.Logger) *indexRestorer {
+	r := &indexRestorer{lg: lg, seed: maphash.MakeSeed()}
+	for i := 0; i < runtime.GOMAXPROCS(0); i++ {
+		w := &indexRestoreWorker{
+			r:      r,
+			id:     i,
+			batchc: make(chan restoreBatch, 1),
+			kis:    make(map[string]*keyIndex),
+			leases: make(map[string]lease.LeaseID),
+		}
+		r.workers = append(r.workers, w)
+		r.wg.Add(1)
+		go w.run()
+	}
+	return r
+}
+
+// restore hands a batch to the workers. The keys and values must stay valid
+// until finish returns.
+func (r *indexRestorer) restore(b restoreBatch) {
+	for _, w := range r.workers {
+		w.batchc <- b
+	}
+}
+
+// finish waits for the workers and inserts the restored keys into idx in
+// key order. It returns the lease of every key attached to one.
+func (r *indexRestorer) finish(idx index) (map[string]lease.LeaseID, error) {
+	for _, w := range r.workers {
+		close(w.batchc)
+	}
+	r.wg.Wait()
+
+	var kis []*keyIndex
+	keyToLease := make(map[string]lease.LeaseID)
+	for _, w := range r.workers {
+		if w.err != nil {
+			return nil, w.err
+		}
+		for _, ki := range w.kis {
+			kis = append(kis, ki)
+		}
+		for k, lid := range w.leases {
+			keyToLease[k] = lid
+		}
+	}
+	slices.SortFunc(kis, func(a, b *keyIndex) int { return cmp.Compare(string(a.key), string(b.key)) })
+	for _, ki := range kis {
+		idx.Insert(ki)
+	}
+	return keyToLease, nil
+}
+
+func (r *indexRestorer) owner(key []byte) *indexRestoreWorker {
+	return r.workers[maphash.Bytes(r.seed, key)%uint64(len(r.workers))]
+}
+
+func (w *indexRestoreWorker) run() {
+	defer w.r.wg.Done()
+	for b := range w.batchc {
+		if w.err != nil {
+			continue
+		}
+		if b.checkpoint != nil {
+			w.err = w.restoreCheckpoint(b.checkpoint)
+			continue
+		}
+		for i, key := range b.keys {
+			rkv, err := decodeRestoreKeyValue(b.vals[i])
+			if err != nil {
+				w.r.lg.Fatal("failed to unmarshal mvccpb.KeyValue", zap.Error(err))
+			}
+			if w.r.owner(rkv.key) == w {
+				w.restoreRevision(key, rkv)
+			}
+		}
+	}
+}
+
+func (w *indexRestoreWorker) restoreCheckpoint(chunk []byte) error {
+	return decodeIndexCheckpointChunk(chunk, func(key []byte) bool {
+		return w.r.owner(key) == w
+	}, func(ki *keyIndex, lid lease.LeaseID) {
+		if !ki.generations[len(ki.generations)-1].isEmpty() {
+			keysGauge.Inc()
+			if lid != lease.NoLease {
+				w.leases[string(ki.key)] = lid
+			}
+		}
+		w.kis[string(ki.key)] = ki
+	})
+}
+
+func (w *indexRestoreWorker) restoreRevision(key []byte, rkv restoreKeyValue) {
+	lg := w.r.lg
+	rev := BytesToRev(key)
+	verify.Verify("revision shouldn't be less than the previous revision", func() (bool, map[string]any) {
+		return rev.Main >= w.currentRev, map[string]any{
+			"revision":          rev.Main,
+			"previous revision": w.currentRev,
+		}
+	})
+	w.currentRev = rev.Main
+
+	tombstone := isTombstone(key)
+	if ki, ok := w.kis[string(rkv.key)]; ok {
+		if tombstone {
+			if err := ki.tombstone(lg, rev.Main, rev.Sub); err != nil {
+				lg.Warn("tombstone encountered error", zap.Error(err))
+			}
+		} else {
+			ki.put(lg, rev.Main, rev.Sub)
+		}
+	} else {
+		ki = &keyIndex{key: slices.Clone(rkv.key)}
+		if tombstone {
+			ki.restoreTombstone(lg, rev.Main, rev.Sub)
+		} else {
+			ki.restore(lg, Revision{Main: rkv.createRevision}, rev, rkv.version)
+		}
+		w.kis[string(ki.key)] = ki
+	}
+
+	if lid := lease.LeaseID(rkv.lease); !tombstone && lid != lease.NoLease {
+		w.leases[string(rkv.key)] = lid
+	} else {
+		delete(w.leases, string(rkv.key))
+	}
+}
+
+// restoreKeyValue has the fields of a mvccpb.KeyValue needed to restore the
+// index. The key refers to the encoded key value.
+type restoreKeyValue struct {
+	key            []byte
+	createRevision int64
+	version        int64
+	lease          int64
+}
+
+var errInvalidKeyValue = errors.New("mvcc: invalid key value")
+
+// decodeRestoreKeyValue decodes the fields of an encoded mvccpb.KeyValue
+// needed to restore the index, without copying the value.
+func decodeRestoreKeyValue(b []byte) (rkv restoreKeyValue, err error) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return rkv, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			rkv.key, n = protowire.ConsumeBytes(b)
+		case num == 2 && typ == protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			rkv.createRevision = int64(v)
+		case num == 4 && typ == protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			rkv.version = int64(v)
+		case num == 6 && typ == protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			rkv.lease = int64(v)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return rkv, protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	if rkv.key == nil {
+		return rkv, errInvalidKeyValue
+	}
+	return rkv, nil
+}
@@ -234,6 +234,71 @@ func TestIndexRevision(t *testing.T) {
 	}
 }
 
+func TestIndexCountModifications(t *testing.T) {
+	ti := newTreeIndex(zaptest.NewLogger(t))
+	ti.Put([]byte("foo"), Revision{Main: 1})
+	ti.Put([]byte("foo1"), Revision{Main: 2})
+	ti.Put([]byte("foo2"), Revision{Main: 3})
+	if err := ti.Tombstone([]byte("foo"), Revision{Main: 4}); err != nil {
+		t.Fatal(err)
+	}
+	ti.Put([]byte("foo"), Revision{Main: 5})
+	ti.Put([]byte("foo1"), Revision{Main: 6})
+
+	tests := []struct {
+		key, end     []byte
+		since, atRev int64
+		wcount       int
+	}{
+		// single key that not found
+		{[]byte("bar"), nil, 0, 6, 0},
+		// single key, including the tombstone
+		{[]byte("foo"), nil, 0, 6, 3},
+		{[]byte("foo"), nil, 1, 6, 2},
+		{[]byte("foo"), nil, 4, 6, 1},
+		{[]byte("foo"), nil, 5, 6, 0},
+		{[]byte("foo"), nil, 1, 4, 1},
+		// range keys
+		{[]byte("foo"), []byte("fop"), 0, 6, 6},
+		{[]byte("foo"), []byte("fop"), 2, 6, 4},
+		{[]byte("foo"), []byte("fop"), 2, 3, 1},
+		{[]byte("foo1"), []byte("fop"), 0, 6, 3},
+		{[]byte("foo3"), []byte("fop"), 0, 6, 0},
+		{[]byte("foo"), []byte("fop"), 6, 6, 0},
+	}
+	for i, tt := range tests {
+		count := ti.CountModifications(tt.key, tt.end, tt.since, tt.atRev)
+		if count != tt.wcount {
+			t.Errorf("#%d: count = %d, want %d", i, count, tt.wcount)
+		}
+	}
+}
+
+func TestIndexKeyIndexesAt(t *testing.T) {
+	ti := newTreeIndex(zaptest.NewLogger(t))
+	ti.Put([]byte("a"), Revision{Main: 2})
+	ti.Put([]byte("b"), Revision{Main: 3})
+	ti.Put([]byte("a"), Revision{Main: 4})
+	ti.Put([]byte("c"), Revision{Main: 5})
+	ti.Put([]byte("d"), Revision{Main: 6})
+
+	var keys []string
+	var next []byte
+	for {
+		var kis []*keyIndex
+		kis, next = ti.KeyIndexesAt(next, 4, 2)
+		for _, ki := range kis {
+			keys = append(keys, string(ki.key))
+			require.LessOrEqual(t, ki.modified.Main, int64(4))
+		}
+		if next == nil {
+			break
+		}
+	}
+	// keys created after revision 4 are skipped
+	require.Equal(t, []string{"a", "b"}, keys)
+}
+
 func TestIndexCompactAndKeep(t *testing.T) {
 	maxRev := int64(20)
 
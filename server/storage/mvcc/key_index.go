@@ -18,12 +18,23 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"unsafe"
 
 	"go.uber.org/zap"
 )
 
 var ErrRevisionNotFound = errors.New("mvcc: revision not found")
 
+const (
+	// keyIndexSize accounts for a keyIndex and the pointer to it in the
+	// index tree.
+	keyIndexSize   = int64(unsafe.Sizeof(keyIndex{})) + int64(unsafe.Sizeof(&keyIndex{}))
+	generationSize = int64(unsafe.Sizeof(generation{}))
+	revisionSize   = int64(unsafe.Sizeof(Revision{}))
+)
+
 // keyIndex stores the revisions of a key in the backend.
 // Each keyIndex has at least one key generation.
 // Each generation might have several key versions.
@@ -166,6 +177,24 @@ func (ki *keyIndex) get(lg *zap.Logger, atRev int64) (modified, created Revision
 	return Revision{}, Revision{}, 0, ErrRevisionNotFound
 }
 
+// countModifications returns the number of modifications, including
+// tombstones, with main revisions in (since, atRev]. Revisions sharing the
+// same main revision are counted once.
+func (ki *keyIndex) countModifications(since, atRev int64) int {
+	n := 0
+	var last int64
+	for _, g := range ki.generations {
+		for _, r := range g.revs {
+			if r.Main <= since || r.Main > atRev || r.Main == last {
+				continue
+			}
+			last = r.Main
+			n++
+		}
+	}
+	return n
+}
+
 // since returns revisions since the given rev. Only the revision with the
 // largest sub revision will be returned if multiple revisions have the same
 // main revision.
@@ -281,6 +310,45 @@ func (ki *keyIndex) doCompact(atRev int64, available map[Revision]struct{}) (gen
 	return genIdx, revIndex
 }
 
+// bytes estimates the memory held by the keyIndex, including its node in the
+// index tree.
+func (ki *keyIndex) bytes() int64 {
+	n := keyIndexSize + int64(cap(ki.key)) + int64(cap(ki.generations))*generationSize
+	for _, g := range ki.generations {
+		n += int64(cap(g.revs)) * revisionSize
+	}
+	return n
+}
+
+// at returns a copy of the key index as it was at the given main revision, or
+// nil if the key was not created yet.
+func (ki *keyIndex) at(atRev int64) *keyIndex {
+	c := &keyIndex{key: ki.key}
+	for _, g := range ki.generations {
+		n := sort.Search(len(g.revs), func(i int) bool { return g.revs[i].Main > atRev })
+		if n == 0 {
+			if len(c.generations) > 0 {
+				// the key was deleted at or before atRev
+				c.generations = append(c.generations, generation{})
+			}
+			break
+		}
+		c.modified = g.revs[n-1]
+		c.generations = append(c.generations, generation{
+			ver:     g.ver - int64(len(g.revs)-n),
+			created: g.created,
+			revs:    slices.Clone(g.revs[:n]),
+		})
+		if n < len(g.revs) {
+			break
+		}
+	}
+	if len(c.generations) == 0 {
+		return nil
+	}
+	return c
+}
+
 func (ki *keyIndex) isEmpty() bool {
 	return len(ki.generations) == 1 && ki.generations[0].isEmpty()
 }
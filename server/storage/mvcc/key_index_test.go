@@ -652,6 +652,47 @@ func TestKeyIndexCompactOnFurtherRev(t *testing.T) {
 	}
 }
 
+func TestKeyIndexAt(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	ops := []struct {
+		rev       Revision
+		tombstone bool
+	}{
+		{Revision{Main: 2}, false},
+		{Revision{Main: 4}, false},
+		{Revision{Main: 6}, true},
+		{Revision{Main: 8}, false},
+		{Revision{Main: 10}, false},
+		{Revision{Main: 12}, true},
+		{Revision{Main: 14}, false},
+		{Revision{Main: 15, Sub: 1}, false},
+		{Revision{Main: 16}, true},
+	}
+	ki := newTestKeyIndex(lg)
+	for rev := int64(0); rev <= 17; rev++ {
+		var want *keyIndex
+		for _, op := range ops {
+			if op.rev.Main > rev {
+				break
+			}
+			if want == nil {
+				want = &keyIndex{key: []byte("foo")}
+			}
+			if op.tombstone {
+				require.NoError(t, want.tombstone(lg, op.rev.Main, op.rev.Sub))
+			} else {
+				want.put(lg, op.rev.Main, op.rev.Sub)
+			}
+		}
+		got := ki.at(rev)
+		if want == nil {
+			assert.Nilf(t, got, "rev %d", rev)
+			continue
+		}
+		assert.Truef(t, want.equal(got), "rev %d: got %+v, want %+v", rev, got, want)
+	}
+}
+
 func TestKeyIndexIsEmpty(t *testing.T) {
 	tests := []struct {
 		ki *keyIndex
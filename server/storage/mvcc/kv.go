@@ -27,6 +27,9 @@ type RangeOptions struct {
 	Limit int64
 	Rev   int64
 	Count bool
+	// ModificationsSince, when positive, makes the range return only the
+	// number of modifications in the range after the given revision.
+	ModificationsSince int64
 }
 
 type RangeResult struct {
@@ -125,6 +128,12 @@ type KV interface {
 	// Compact frees all superseded keys with revisions less than rev.
 	Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, error)
 
+	// Export calls fn with the exported revision and each key-value pair in
+	// the range [key, end) at that revision, in key order, and returns the
+	// exported revision. If rev <= 0, the pairs are exported at the current
+	// revision. Compactions do not affect the pairs once Export has started.
+	Export(ctx context.Context, key, end []byte, rev int64, fn func(rev int64, kv *mvccpb.KeyValue) error) (int64, error)
+
 	// Commit commits outstanding txns into the underlying backend.
 	Commit()
 
@@ -137,6 +146,13 @@ type KV interface {
 type WatchableKV interface {
 	KV
 	Watchable
+
+	// MemoryStats estimates the memory held by the key index and watchers.
+	MemoryStats() MemoryStats
+
+	// DropIdleWatchers cancels idle watchers until about the given number
+	// of bytes is reclaimed, and returns the number of canceled watchers.
+	DropIdleWatchers(bytes int64) int
 }
 
 // Watchable is the interface that wraps the NewWatchStream function.
@@ -257,6 +257,47 @@ func testKVRangeLimit(t *testing.T, f rangeFunc) {
 	}
 }
 
+func TestKVRangeModificationsSince(t *testing.T)    { testKVRangeModificationsSince(t, normalRangeFunc) }
+func TestKVTxnRangeModificationsSince(t *testing.T) { testKVRangeModificationsSince(t, txnRangeFunc) }
+
+func testKVRangeModificationsSince(t *testing.T, f rangeFunc) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	put3TestKVs(s)
+	s.Put([]byte("foo"), []byte("bar1"), lease.NoLease)
+	s.DeleteRange([]byte("foo1"), nil)
+	if _, err := s.Compact(traceutil.TODO(), 3); err != nil {
+		t.Fatalf("compact error (%v)", err)
+	}
+
+	tests := []struct {
+		since, rev int64
+		wcount     int
+		werr       error
+	}{
+		{2, 0, -1, ErrCompacted},
+		{3, 0, 3, nil},
+		{4, 0, 2, nil},
+		{5, 0, 1, nil},
+		{6, 0, 0, nil},
+		{3, 5, 2, nil},
+	}
+	for i, tt := range tests {
+		r, err := f(s, []byte("foo"), []byte("foo3"), RangeOptions{Rev: tt.rev, ModificationsSince: tt.since})
+		if !errors.Is(err, tt.werr) {
+			t.Fatalf("#%d: error = %v, want %v", i, err, tt.werr)
+		}
+		if r.Count != tt.wcount {
+			t.Errorf("#%d: count = %d, want %d", i, r.Count, tt.wcount)
+		}
+		if len(r.KVs) != 0 {
+			t.Errorf("#%d: len(kvs) = %d, want 0", i, len(r.KVs))
+		}
+	}
+}
+
 func TestKVPutMultipleTimes(t *testing.T)    { testKVPutMultipleTimes(t, normalPutFunc) }
 func TestKVTxnPutMultipleTimes(t *testing.T) { testKVPutMultipleTimes(t, txnPutFunc) }
 
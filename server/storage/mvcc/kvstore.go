@@ -20,12 +20,12 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
-	"go.etcd.io/etcd/client/pkg/v3/verify"
 	"go.etcd.io/etcd/pkg/v3/schedule"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/lease"
@@ -47,6 +47,25 @@ var (
 type StoreConfig struct {
 	CompactionBatchLimit    int
 	CompactionSleepInterval time.Duration
+	// ValueChunkSize is the size above which values are split into chunks
+	// stored outside the key bucket. Zero disables chunking.
+	ValueChunkSize int
+	// IndexCheckpointInterval is the interval between checkpoints of the key
+	// index persisted in the backend, which are used to skip most of the
+	// index rebuild on restore. Zero disables index checkpoints.
+	IndexCheckpointInterval time.Duration
+	// ChangeObserver, if set, is notified of the changes of the watchable
+	// store once they are visible to new reads.
+	ChangeObserver ChangeObserver
+}
+
+// ChangeObserver observes the changes applied to a watchable store.
+type ChangeObserver interface {
+	// Changed is called with the events of each write txn and the revision
+	// they were written at.
+	Changed(rev int64, evs []mvccpb.Event)
+	// Restored is called after the store is restored from a backend.
+	Restored(rev int64)
 }
 
 type store struct {
@@ -78,6 +97,11 @@ type store struct {
 
 	lg     *zap.Logger
 	hashes HashStorage
+
+	// valueChunks is true once the key chunk bucket holds chunks. The bucket
+	// is only created when the first value is chunked, so that it does not
+	// change the backend of stores not using chunks.
+	valueChunks atomic.Bool
 }
 
 // NewStore returns a new store. It is useful to create a store inside
@@ -120,6 +144,10 @@ func NewStore(lg *zap.Logger, b backend.Backend, le lease.Lessor, cfg StoreConfi
 	tx.LockOutsideApply()
 	tx.UnsafeCreateBucket(schema.Key)
 	schema.UnsafeCreateMetaBucket(tx)
+	if cfg.IndexCheckpointInterval <= 0 {
+		// a stale checkpoint would only waste space
+		tx.UnsafeDeleteBucket(schema.KeyIndexCheckpoint)
+	}
 	tx.Unlock()
 	s.b.ForceCommit()
 
@@ -129,6 +157,7 @@ func NewStore(lg *zap.Logger, b backend.Backend, le lease.Lessor, cfg StoreConfi
 		// TODO: return the error instead of panic here?
 		panic("failed to recover store from backend")
 	}
+	s.startIndexCheckpoints(s.stopc)
 
 	return s
 }
@@ -188,7 +217,7 @@ func (s *store) hashByRev(rev int64) (hash KeyValueHash, currentRev int64, err e
 	tx.RLock()
 	defer tx.RUnlock()
 	s.mu.RUnlock()
-	hash, err = unsafeHashByRev(tx, compactRev, rev, keep)
+	hash, err = unsafeHashByRev(tx, compactRev, rev, keep, s.valueChunks.Load())
 	hashRevSec.Observe(time.Since(start).Seconds())
 	return hash, currentRev, err
 }
@@ -309,23 +338,22 @@ func (s *store) Restore(b backend.Backend) error {
 	s.fifoSched = schedule.NewFIFOScheduler(s.lg)
 	s.stopc = make(chan struct{})
 
-	return s.restore()
+	if err := s.restore(); err != nil {
+		return err
+	}
+	s.startIndexCheckpoints(s.stopc)
+	return nil
 }
 
-//nolint:unparam
 func (s *store) restore() error {
 	s.setupMetricsReporter()
 
-	min, max := NewRevBytes(), NewRevBytes()
-	min = RevToBytes(Revision{Main: 1}, min)
-	max = RevToBytes(Revision{Main: math.MaxInt64, Sub: math.MaxInt64}, max)
-
-	keyToLease := make(map[string]lease.LeaseID)
-
 	// restore index
 	tx := s.b.ReadTx()
 	tx.RLock()
 
+	s.valueChunks.Store(unsafeHasValueChunks(tx))
+
 	finishedCompact, found := UnsafeReadFinishedCompact(tx)
 	if found {
 		s.revMu.Lock()
@@ -338,32 +366,24 @@ func (s *store) restore() error {
 		)
 		s.revMu.Unlock()
 	}
-	scheduledCompact, _ := UnsafeReadScheduledCompact(tx)
-	// index keys concurrently as they're loaded in from tx
+	scheduledCompact, scheduledFound := UnsafeReadScheduledCompact(tx)
 	keysGauge.Set(0)
-	rkvc, revc := restoreIntoIndex(s.lg, s.kvindex)
-	for {
-		keys, vals := tx.UnsafeRange(schema.Key, min, max, int64(restoreChunkKeys))
-		if len(keys) == 0 {
-			break
-		}
-		// rkvc blocks if the total pending keys exceeds the restore
-		// chunk size to keep keys from consuming too much memory.
-		restoreChunk(s.lg, rkvc, keys, vals, keyToLease)
-		if len(keys) < restoreChunkKeys {
-			// partial set implies final set
-			break
+	start := time.Now()
+	cp, chunks := s.unsafeReadIndexCheckpoint(tx, scheduledFound && scheduledCompact > s.compactMainRev)
+	keyToLease, restoredRev, err := s.restoreIndex(tx, cp, chunks)
+	if err != nil {
+		s.lg.Warn("failed to restore key index from checkpoint; rebuilding it", zap.Error(err))
+		keysGauge.Set(0)
+		if keyToLease, restoredRev, err = s.restoreIndex(tx, nil, nil); err != nil {
+			tx.RUnlock()
+			return err
 		}
-		// next set begins after where this one ended
-		newMin := BytesToRev(keys[len(keys)-1][:revBytesLen])
-		newMin.Sub++
-		min = RevToBytes(newMin, min)
 	}
-	close(rkvc)
+	indexRestoreSec.Observe(time.Since(start).Seconds())
 
 	{
 		s.revMu.Lock()
-		s.currentRev = <-revc
+		s.currentRev = restoredRev
 
 		// keys in the range [compacted revision -N, compaction] might all be deleted due to compaction.
 		// the correct revision should be set to compaction revision in the case, not the largest revision
@@ -424,87 +444,69 @@ func (s *store) restore() error {
 	return nil
 }
 
-type revKeyValue struct {
-	key  []byte
-	kv   mvccpb.KeyValue
-	kstr string
+// unsafeReadIndexCheckpoint returns the index checkpoint if it can be used to
+// restore the index, or nil.
+func (s *store) unsafeReadIndexCheckpoint(tx backend.UnsafeReader, compactionPending bool) (*indexCheckpointMeta, [][]byte) {
+	if s.cfg.IndexCheckpointInterval <= 0 {
+		return nil, nil
+	}
+	cp, chunks, err := unsafeReadIndexCheckpoint(tx)
+	switch {
+	case err != nil:
+		s.lg.Warn("ignoring invalid key index checkpoint", zap.Error(err))
+		return nil, nil
+	case chunks == nil:
+		return nil, nil
+	case compactionPending || cp.compactRev != s.compactMainRev:
+		s.lg.Info(
+			"ignoring key index checkpoint taken before the last compaction",
+			zap.Int64("checkpoint-revision", cp.rev),
+			zap.Int64("checkpoint-compact-revision", cp.compactRev),
+		)
+		return nil, nil
+	}
+	s.lg.Info("restoring key index from checkpoint", zap.Int64("checkpoint-revision", cp.rev))
+	return &cp, chunks
 }
 
-func restoreIntoIndex(lg *zap.Logger, idx index) (chan<- revKeyValue, <-chan int64) {
-	rkvc, revc := make(chan revKeyValue, restoreChunkKeys), make(chan int64, 1)
-	go func() {
-		currentRev := int64(1)
-		defer func() { revc <- currentRev }()
-		// restore the tree index from streaming the unordered index.
-		kiCache := make(map[string]*keyIndex, restoreChunkKeys)
-		for rkv := range rkvc {
-			ki, ok := kiCache[rkv.kstr]
-			// purge kiCache if many keys but still missing in the cache
-			if !ok && len(kiCache) >= restoreChunkKeys {
-				i := 10
-				for k := range kiCache {
-					delete(kiCache, k)
-					if i--; i == 0 {
-						break
-					}
-				}
-			}
-			// cache miss, fetch from tree index if there
-			if !ok {
-				ki = &keyIndex{key: rkv.kv.Key}
-				if idxKey := idx.KeyIndex(ki); idxKey != nil {
-					kiCache[rkv.kstr], ki = idxKey, idxKey
-					ok = true
-				}
-			}
-
-			rev := BytesToRev(rkv.key)
-			verify.Verify("revision shouldn't be less than the previous revision", func() (bool, map[string]any) {
-				return rev.Main >= currentRev, map[string]any{
-					"revision":          rev.Main,
-					"previous revision": currentRev,
-				}
-			})
-			currentRev = rev.Main
-
-			if ok {
-				if isTombstone(rkv.key) {
-					if err := ki.tombstone(lg, rev.Main, rev.Sub); err != nil {
-						lg.Warn("tombstone encountered error", zap.Error(err))
-					}
-					continue
-				}
-				ki.put(lg, rev.Main, rev.Sub)
-			} else {
-				if isTombstone(rkv.key) {
-					ki.restoreTombstone(lg, rev.Main, rev.Sub)
-				} else {
-					ki.restore(lg, Revision{Main: rkv.kv.CreateRevision}, rev, rkv.kv.Version)
-				}
-				idx.Insert(ki)
-				kiCache[rkv.kstr] = ki
-			}
-		}
-	}()
-	return rkvc, revc
-}
+// restoreIndex restores the key index from the key bucket, starting from the
+// given checkpoint if any. It returns the leases attached to keys and the
+// last restored revision.
+func (s *store) restoreIndex(tx backend.UnsafeReader, cp *indexCheckpointMeta, chunks [][]byte) (map[string]lease.LeaseID, int64, error) {
+	min, max := NewRevBytes(), NewRevBytes()
+	min = RevToBytes(Revision{Main: 1}, min)
+	max = RevToBytes(Revision{Main: math.MaxInt64, Sub: math.MaxInt64}, max)
 
-func restoreChunk(lg *zap.Logger, kvc chan<- revKeyValue, keys, vals [][]byte, keyToLease map[string]lease.LeaseID) {
-	for i, key := range keys {
-		rkv := revKeyValue{key: key}
-		if err := rkv.kv.Unmarshal(vals[i]); err != nil {
-			lg.Fatal("failed to unmarshal mvccpb.KeyValue", zap.Error(err))
+	currentRev := int64(1)
+	// index keys concurrently as they're loaded in from tx
+	r := newIndexRestorer(s.lg)
+	if cp != nil {
+		for _, chunk := range chunks {
+			r.restore(restoreBatch{checkpoint: chunk})
 		}
-		rkv.kstr = string(rkv.kv.Key)
-		if isTombstone(key) {
-			delete(keyToLease, rkv.kstr)
-		} else if lid := lease.LeaseID(rkv.kv.Lease); lid != lease.NoLease {
-			keyToLease[rkv.kstr] = lid
-		} else {
-			delete(keyToLease, rkv.kstr)
+		min = RevToBytes(Revision{Main: cp.rev + 1}, min)
+		currentRev = cp.rev
+	}
+	for {
+		keys, vals := tx.UnsafeRange(schema.Key, min, max, int64(restoreChunkKeys))
+		if len(keys) == 0 {
+			break
 		}
-		kvc <- rkv
+		// the workers block once they have a chunk pending to keep keys
+		// from consuming too much memory.
+		r.restore(restoreBatch{keys: keys, vals: vals})
+		currentRev = BytesToRev(keys[len(keys)-1][:revBytesLen]).Main
+		if len(keys) < restoreChunkKeys {
+			// partial set implies final set
+			break
+		}
+		// next set begins after where this one ended
+		newMin := BytesToRev(keys[len(keys)-1][:revBytesLen])
+		newMin.Sub++
+		min = RevToBytes(newMin, min)
 	}
+	keyToLease, err := r.finish(s.kvindex)
+	return keyToLease, currentRev, err
 }
 
 func (s *store) Close() error {
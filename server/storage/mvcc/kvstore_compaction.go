@@ -51,13 +51,21 @@ func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyVal
 		tx.LockOutsideApply()
 		// gofail: var compactAfterAcquiredBatchTxLock struct{}
 		keys, values := tx.UnsafeRange(schema.Key, last, end, int64(batchNum))
+		valueChunks := s.valueChunks.Load()
 		for i := range keys {
 			rev = BytesToRev(keys[i])
+			v, chunked := values[i], false
+			if valueChunks {
+				v, chunked = unsafeLogicalKeyValue(tx, keys[i], values[i])
+			}
+			h.WriteKeyValue(keys[i], v)
 			if _, ok := keep[rev]; !ok {
 				tx.UnsafeDelete(schema.Key, keys[i])
+				if chunked {
+					unsafeDeleteValueChunks(tx, keys[i])
+				}
 				keyCompactions++
 			}
-			h.WriteKeyValue(keys[i], values[i])
 		}
 
 		if len(keys) < batchNum {
@@ -0,0 +1,84 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func (s *store) Export(ctx context.Context, key, end []byte, rev int64, fn func(rev int64, kv *mvccpb.KeyValue) error) (int64, error) {
+	s.mu.RLock()
+	s.revMu.RLock()
+	compactRev, currentRev := s.compactMainRev, s.currentRev
+	s.revMu.RUnlock()
+	if rev > currentRev {
+		s.mu.RUnlock()
+		return 0, ErrFutureRev
+	}
+	if rev <= 0 {
+		rev = currentRev
+	}
+	if rev < compactRev {
+		s.mu.RUnlock()
+		return 0, ErrCompacted
+	}
+	// holding s.mu keeps compactions from starting until both the revisions
+	// and the read transaction are taken. A compaction starting later only
+	// removes revisions superseded by the time it is applied, while the
+	// revisions at rev stay visible to the read transaction anyway.
+	revs, _ := s.kvindex.Revisions(key, end, rev, 0)
+	tx := s.b.ConcurrentReadTx()
+	tx.RLock()
+	s.mu.RUnlock()
+	defer tx.RUnlock()
+
+	valueChunks := s.valueChunks.Load()
+	revBytes := NewRevBytes()
+	for _, r := range revs {
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("export: context cancelled: %w", ctx.Err())
+		default:
+		}
+		revBytes = RevToBytes(r, revBytes)
+		_, vs := tx.UnsafeRange(schema.Key, revBytes, nil, 0)
+		if len(vs) != 1 {
+			s.lg.Fatal(
+				"export failed to find revision pair",
+				zap.Int64("revision-main", r.Main),
+				zap.Int64("revision-sub", r.Sub),
+				zap.Int64("export-rev", rev),
+				zap.Int("len-values", len(vs)),
+			)
+		}
+		var kv mvccpb.KeyValue
+		if err := kv.Unmarshal(vs[0]); err != nil {
+			s.lg.Fatal("failed to unmarshal mvccpb.KeyValue", zap.Error(err))
+		}
+		if valueChunks {
+			unsafeResolveChunkedValue(tx, revBytes, &kv)
+		}
+		if err := fn(rev, &kv); err != nil {
+			return 0, err
+		}
+	}
+	return rev, nil
+}
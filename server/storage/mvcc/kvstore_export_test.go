@@ -0,0 +1,78 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestStoreExport(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	s.Put([]byte("a"), []byte("1"), lease.NoLease)  // rev 2
+	s.Put([]byte("b"), []byte("1"), lease.NoLease)  // rev 3
+	s.Put([]byte("a"), []byte("2"), lease.NoLease)  // rev 4
+	s.DeleteRange([]byte("b"), nil)                 // rev 5
+	s.Put([]byte("c"), []byte("1"), lease.NoLease)  // rev 6
+	s.Put([]byte("zz"), []byte("1"), lease.NoLease) // rev 7
+
+	export := func(rev int64, fn func()) (int64, []string) {
+		var got []string
+		var fnRev int64
+		exported, err := s.Export(context.Background(), []byte("a"), []byte("d"), rev, func(r int64, kv *mvccpb.KeyValue) error {
+			fnRev = r
+			got = append(got, string(kv.Key)+"="+string(kv.Value))
+			if fn != nil {
+				fn()
+				fn = nil
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, exported, fnRev)
+		return exported, got
+	}
+
+	rev, got := export(0, nil)
+	assert.Equal(t, int64(7), rev)
+	assert.Equal(t, []string{"a=2", "c=1"}, got)
+
+	// compacting past the exported revision while exporting does not
+	// affect the keys still to be exported
+	rev, got = export(4, func() {
+		done, err := s.Compact(traceutil.TODO(), 6)
+		require.NoError(t, err)
+		<-done
+	})
+	assert.Equal(t, int64(4), rev)
+	assert.Equal(t, []string{"a=2", "b=1"}, got)
+
+	_, err := s.Export(context.Background(), []byte("a"), []byte("d"), 4, func(int64, *mvccpb.KeyValue) error { return nil })
+	require.ErrorIs(t, err, ErrCompacted)
+	_, err = s.Export(context.Background(), []byte("a"), []byte("d"), 8, func(int64, *mvccpb.KeyValue) error { return nil })
+	require.ErrorIs(t, err, ErrFutureRev)
+}
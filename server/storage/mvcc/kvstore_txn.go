@@ -80,6 +80,14 @@ func (tr *storeTxnCommon) rangeKeys(ctx context.Context, key, end []byte, curRev
 	if rev < tr.s.compactMainRev {
 		return &RangeResult{KVs: nil, Count: -1, Rev: 0}, ErrCompacted
 	}
+	if ro.ModificationsSince > 0 {
+		if ro.ModificationsSince < tr.s.compactMainRev {
+			return &RangeResult{KVs: nil, Count: -1, Rev: 0}, ErrCompacted
+		}
+		total := tr.s.kvindex.CountModifications(key, end, ro.ModificationsSince, rev)
+		tr.trace.Step("count modifications from in-memory index tree")
+		return &RangeResult{KVs: nil, Count: total, Rev: curRev}, nil
+	}
 	if ro.Count {
 		total := tr.s.kvindex.CountRevisions(key, end, rev)
 		tr.trace.Step("count revisions from in-memory index tree")
@@ -126,6 +134,9 @@ func (tr *storeTxnCommon) rangeKeys(ctx context.Context, key, end []byte, curRev
 				zap.Error(err),
 			)
 		}
+		if tr.s.valueChunks.Load() {
+			unsafeResolveChunkedValue(tr.tx, revBytes, &kvs[i])
+		}
 	}
 	tr.trace.Step("range keys from bolt db")
 	return &RangeResult{KVs: kvs, Count: total, Rev: curRev}, nil
@@ -220,7 +231,13 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 		Lease:          int64(leaseID),
 	}
 
-	d, err := kv.Marshal()
+	stored := kv
+	chunkSize := tw.s.cfg.ValueChunkSize
+	chunked := chunkSize > 0 && len(value) > chunkSize
+	if chunked {
+		stored.Value = nil
+	}
+	d, err := stored.Marshal()
 	if err != nil {
 		tw.storeTxnCommon.s.lg.Fatal(
 			"failed to marshal mvccpb.KeyValue",
@@ -230,6 +247,14 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 
 	tw.trace.Step("marshal mvccpb.KeyValue")
 	tw.tx.UnsafeSeqPut(schema.Key, ibytes, d)
+	if chunked {
+		if !tw.s.valueChunks.Load() {
+			tw.tx.UnsafeCreateBucket(schema.KeyChunk)
+			tw.s.valueChunks.Store(true)
+		}
+		unsafePutValueChunks(tw.tx, ibytes, value, chunkSize)
+		tw.trace.Step("store value chunks into bolt db")
+	}
 	tw.s.kvindex.Put(key, idxRev)
 	tw.changes = append(tw.changes, kv)
 	tw.trace.Step("store kv pair into bolt db")
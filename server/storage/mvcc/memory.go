@@ -0,0 +1,119 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"cmp"
+	"slices"
+	"unsafe"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+const (
+	// watcherSize accounts for a watcher and its entries in the watcher
+	// group and the watch stream.
+	watcherSize = int64(unsafe.Sizeof(watcher{})) + 4*int64(unsafe.Sizeof(&watcher{}))
+	eventSize   = int64(unsafe.Sizeof(mvccpb.Event{}) + unsafe.Sizeof(mvccpb.KeyValue{}))
+	// watchResponseSize is the size of a slot in the buffered channel of a
+	// watch stream.
+	watchResponseSize = int64(unsafe.Sizeof(WatchResponse{}))
+)
+
+// MemoryStats estimates the memory held by the store outside of the backend.
+type MemoryStats struct {
+	// IndexBytes is held by the in-memory key index.
+	IndexBytes int64
+	// WatcherBytes is held by the watchers, their pending events and the
+	// buffers of the watch streams.
+	WatcherBytes int64
+	// Watchers is the number of watchers.
+	Watchers int
+}
+
+func (s *watchableStore) MemoryStats() MemoryStats {
+	s.mu.RLock()
+	n := int64(0)
+	watchers := len(s.synced.watchers) + len(s.unsynced.watchers)
+	for w := range s.synced.watchers {
+		n += w.bytes()
+	}
+	for w := range s.unsynced.watchers {
+		n += w.bytes()
+	}
+	for _, wb := range s.victims {
+		watchers += len(wb)
+		for w, eb := range wb {
+			n += w.bytes()
+			for _, ev := range eb.evs {
+				n += eventSize + int64(len(ev.Kv.Key)+len(ev.Kv.Value))
+			}
+		}
+	}
+	s.mu.RUnlock()
+	n += s.streams.Load() * int64(chanBufLen) * watchResponseSize
+	return MemoryStats{IndexBytes: s.kvindex.Bytes(), WatcherBytes: n, Watchers: watchers}
+}
+
+// DropIdleWatchers cancels watchers until the given number of bytes is
+// reclaimed, and returns the number of canceled watchers. Synced watchers
+// that have not been notified for the longest time are dropped first, then
+// unsynced watchers. A watcher is only dropped if the response telling its
+// client about it can be sent without blocking; victims are never dropped.
+func (s *watchableStore) DropIdleWatchers(bytes int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byMinRev := func(a, b *watcher) int { return cmp.Compare(a.minRev, b.minRev) }
+	synced := make([]*watcher, 0, s.synced.size())
+	for w := range s.synced.watchers {
+		synced = append(synced, w)
+	}
+	slices.SortFunc(synced, byMinRev)
+	unsynced := make([]*watcher, 0, s.unsynced.size())
+	for w := range s.unsynced.watchers {
+		unsynced = append(unsynced, w)
+	}
+	slices.SortFunc(unsynced, byMinRev)
+
+	dropped, freed := 0, int64(0)
+	drop := func(wg *watcherGroup, ws []*watcher) {
+		for _, w := range ws {
+			if freed >= bytes {
+				return
+			}
+			select {
+			case w.ch <- WatchResponse{WatchID: w.id, Dropped: true}:
+			default:
+				continue
+			}
+			w.dropped = true
+			wg.delete(w)
+			freed += w.bytes()
+			dropped++
+		}
+	}
+	drop(&s.synced, synced)
+	unsyncedBefore := s.unsynced.size()
+	drop(&s.unsynced, unsynced)
+	slowWatcherGauge.Sub(float64(unsyncedBefore - s.unsynced.size()))
+	droppedWatcherCounter.Add(float64(dropped))
+	return dropped
+}
+
+// bytes estimates the memory held by the watcher.
+func (w *watcher) bytes() int64 {
+	return watcherSize + int64(len(w.key)+len(w.end))
+}
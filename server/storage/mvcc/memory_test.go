@@ -0,0 +1,98 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestMemoryStats(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	empty := s.MemoryStats()
+	assert.Equal(t, int64(0), empty.IndexBytes)
+	assert.Equal(t, int64(0), empty.WatcherBytes)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	one := s.MemoryStats().IndexBytes
+	require.Positive(t, one)
+	s.Put([]byte("foo"), []byte("baz"), lease.NoLease)
+	s.Put([]byte("bar"), []byte("baz"), lease.NoLease)
+	two := s.MemoryStats().IndexBytes
+	require.Greater(t, two, one)
+
+	s.DeleteRange([]byte("foo"), nil)
+	s.Put([]byte("bar"), []byte("qux"), lease.NoLease)
+	done, err := s.Compact(traceutil.TODO(), s.Rev())
+	require.NoError(t, err)
+	<-done
+	assert.Less(t, s.MemoryStats().IndexBytes, two)
+
+	w := s.NewWatchStream()
+	streamOnly := s.MemoryStats().WatcherBytes
+	assert.Equal(t, int64(chanBufLen)*watchResponseSize, streamOnly)
+	id, err := w.Watch(t.Context(), 0, []byte("foo"), []byte("fop"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, streamOnly+watcherSize+6, s.MemoryStats().WatcherBytes)
+	assert.Equal(t, 1, s.MemoryStats().Watchers)
+
+	require.NoError(t, w.Cancel(id))
+	assert.Equal(t, streamOnly, s.MemoryStats().WatcherBytes)
+	assert.Equal(t, 0, s.MemoryStats().Watchers)
+	w.Close()
+	assert.Equal(t, int64(0), s.MemoryStats().WatcherBytes)
+}
+
+func TestDropIdleWatchers(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	idle, err := w.Watch(t.Context(), 0, []byte("idle"), nil, 0)
+	require.NoError(t, err)
+	busy, err := w.Watch(t.Context(), 0, []byte("busy"), nil, 0)
+	require.NoError(t, err)
+
+	s.Put([]byte("busy"), []byte("v"), lease.NoLease)
+	resp := <-w.Chan()
+	require.Equal(t, busy, resp.WatchID)
+
+	// dropping the idle watcher reclaims a single byte
+	require.Equal(t, 1, s.DropIdleWatchers(1))
+	resp = <-w.Chan()
+	assert.Equal(t, idle, resp.WatchID)
+	assert.True(t, resp.Dropped)
+	assert.Equal(t, 1, s.synced.size())
+	require.NoError(t, w.Cancel(idle))
+
+	assert.Equal(t, 0, s.DropIdleWatchers(0))
+	require.Equal(t, 1, s.DropIdleWatchers(1<<20))
+	resp = <-w.Chan()
+	assert.Equal(t, busy, resp.WatchID)
+	assert.True(t, resp.Dropped)
+	assert.Equal(t, 0, s.synced.size())
+}
@@ -93,6 +93,25 @@ var (
 		},
 	)
 
+	slowWatcherByIdentityCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "slow_watcher_by_identity_total",
+			Help:      "Total number of times a watcher became slow because its channel was full, by watcher identity.",
+		},
+		[]string{"identity"},
+	)
+
+	droppedWatcherCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "dropped_watcher_total",
+			Help:      "Total number of watchers canceled to reclaim memory.",
+		},
+	)
+
 	totalEventsCounter = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "etcd_debugging",
@@ -220,6 +239,27 @@ var (
 	reportDbOpenReadTxNMu sync.RWMutex
 	reportDbOpenReadTxN   = func() float64 { return 0 }
 
+	indexCheckpointSec = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "mvcc",
+		Name:      "index_checkpoint_duration_seconds",
+		Help:      "The latency distribution of persisting key index checkpoints.",
+
+		// lowest bucket start of upper bound 0.01 sec (10 ms) with factor 2
+		// highest bucket start of 0.01 sec * 2^14 == 163.84 sec
+		Buckets: prometheus.ExponentialBuckets(.01, 2, 15),
+	})
+	indexRestoreSec = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "mvcc",
+		Name:      "index_restore_duration_seconds",
+		Help:      "The latency distribution of restoring the key index from the backend.",
+
+		// lowest bucket start of upper bound 0.01 sec (10 ms) with factor 2
+		// highest bucket start of 0.01 sec * 2^14 == 163.84 sec
+		Buckets: prometheus.ExponentialBuckets(.01, 2, 15),
+	})
+
 	hashSec = prometheus.NewHistogram(prometheus.HistogramOpts{
 		Namespace: "etcd",
 		Subsystem: "mvcc",
@@ -295,6 +335,8 @@ func init() {
 	prometheus.MustRegister(watchStreamGauge)
 	prometheus.MustRegister(watcherGauge)
 	prometheus.MustRegister(slowWatcherGauge)
+	prometheus.MustRegister(slowWatcherByIdentityCounter)
+	prometheus.MustRegister(droppedWatcherCounter)
 	prometheus.MustRegister(totalEventsCounter)
 	prometheus.MustRegister(pendingEventsGauge)
 	prometheus.MustRegister(indexCompactionPauseMs)
@@ -305,6 +347,8 @@ func init() {
 	prometheus.MustRegister(dbTotalSize)
 	prometheus.MustRegister(dbTotalSizeInUse)
 	prometheus.MustRegister(dbOpenReadTxN)
+	prometheus.MustRegister(indexCheckpointSec)
+	prometheus.MustRegister(indexRestoreSec)
 	prometheus.MustRegister(hashSec)
 	prometheus.MustRegister(hashRevSec)
 	prometheus.MustRegister(currentRev)
@@ -0,0 +1,156 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+// Values larger than StoreConfig.ValueChunkSize are not stored inline in the
+// key bucket. Instead the key bucket holds the mvccpb.KeyValue with an empty
+// value and the value is split into chunks stored in the key chunk bucket under
+//
+//	revision bytes (17 bytes) + chunk index (4 bytes, big endian)
+//
+// Chunks are written in the same backend transaction as the revision itself,
+// so a chunked revision is atomically visible. They are removed when the
+// revision is compacted.
+
+const chunkIndexLen = 4
+
+func chunkKey(revBytes []byte, idx uint32) []byte {
+	k := make([]byte, revBytesLen+chunkIndexLen)
+	copy(k, revBytes[:revBytesLen])
+	binary.BigEndian.PutUint32(k[revBytesLen:], idx)
+	return k
+}
+
+// chunkRange returns the range of chunk keys belonging to the given revision.
+func chunkRange(revBytes []byte) (start, end []byte) {
+	start = chunkKey(revBytes, 0)
+	end = make([]byte, revBytesLen+chunkIndexLen+1)
+	copy(end, revBytes[:revBytesLen])
+	for i := revBytesLen; i < len(end); i++ {
+		end[i] = 0xff
+	}
+	return start, end
+}
+
+// unsafePutValueChunks stores value in chunks of chunkSize bytes.
+func unsafePutValueChunks(tx backend.UnsafeWriter, revBytes, value []byte, chunkSize int) {
+	for idx := uint32(0); len(value) > 0; idx++ {
+		n := min(chunkSize, len(value))
+		tx.UnsafeSeqPut(schema.KeyChunk, chunkKey(revBytes, idx), value[:n])
+		value = value[n:]
+	}
+}
+
+var errValueChunkFound = errors.New("mvcc: value chunk found")
+
+// unsafeHasValueChunks returns true if the key chunk bucket exists and holds
+// at least one chunk.
+func unsafeHasValueChunks(tx backend.UnsafeReader) bool {
+	err := tx.UnsafeForEach(schema.KeyChunk, func(_, _ []byte) error {
+		return errValueChunkFound
+	})
+	return errors.Is(err, errValueChunkFound)
+}
+
+// unsafeReadValueChunks reassembles the chunked value of the given revision.
+// It returns nil if the revision has no chunks.
+func unsafeReadValueChunks(tx backend.UnsafeReader, revBytes []byte) []byte {
+	start, end := chunkRange(revBytes)
+	_, vs := tx.UnsafeRange(schema.KeyChunk, start, end, 0)
+	if len(vs) == 0 {
+		return nil
+	}
+	size := 0
+	for _, v := range vs {
+		size += len(v)
+	}
+	value := make([]byte, 0, size)
+	for _, v := range vs {
+		value = append(value, v...)
+	}
+	return value
+}
+
+// unsafeDeleteValueChunks removes all chunks of the given revision and returns
+// the number of removed chunks.
+func unsafeDeleteValueChunks(tx backend.UnsafeReadWriter, revBytes []byte) int {
+	start, end := chunkRange(revBytes)
+	keys, _ := tx.UnsafeRange(schema.KeyChunk, start, end, 0)
+	for _, k := range keys {
+		tx.UnsafeDelete(schema.KeyChunk, k)
+	}
+	return len(keys)
+}
+
+// mayBeChunked returns true if the stored key bucket entry may have its value
+// stored in the key chunk bucket.
+func mayBeChunked(revBytes []byte, kv *mvccpb.KeyValue) bool {
+	return len(kv.Value) == 0 && !isTombstone(revBytes)
+}
+
+// unsafeResolveChunkedValue fills in the value of kv from the key chunk bucket
+// if the revision was stored in chunks.
+func unsafeResolveChunkedValue(tx backend.UnsafeReader, revBytes []byte, kv *mvccpb.KeyValue) {
+	if !mayBeChunked(revBytes, kv) {
+		return
+	}
+	if v := unsafeReadValueChunks(tx, revBytes); v != nil {
+		kv.Value = v
+	}
+}
+
+// unsafeLogicalKeyValue returns the key bucket entry v with the chunked value
+// inlined, so that hashes do not depend on how the value is stored. It also
+// reports whether the revision was stored in chunks.
+func unsafeLogicalKeyValue(tx backend.UnsafeReader, revBytes, v []byte) ([]byte, bool) {
+	if isTombstone(revBytes) {
+		return v, false
+	}
+	var kv mvccpb.KeyValue
+	if err := kv.Unmarshal(v); err != nil || len(kv.Value) != 0 {
+		return v, false
+	}
+	value := unsafeReadValueChunks(tx, revBytes)
+	if value == nil {
+		return v, false
+	}
+	return inlineChunkedValue(v, value), true
+}
+
+// inlineChunkedValue returns the key bucket entry v with its value set to value.
+func inlineChunkedValue(v, value []byte) []byte {
+	if value == nil {
+		return v
+	}
+	var kv mvccpb.KeyValue
+	if err := kv.Unmarshal(v); err != nil || len(kv.Value) != 0 {
+		return v
+	}
+	kv.Value = value
+	d, err := kv.Marshal()
+	if err != nil {
+		return v
+	}
+	return d
+}
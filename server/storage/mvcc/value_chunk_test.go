@@ -0,0 +1,106 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func TestValueChunking(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{ValueChunkSize: 4})
+	defer cleanup(s, b)
+
+	large := []byte("a value that spans several chunks")
+	s.Put([]byte("foo"), large, lease.NoLease)
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s.Put([]byte("empty"), nil, lease.NoLease)
+
+	r, err := s.Range(context.TODO(), []byte("foo"), nil, RangeOptions{Rev: 2})
+	require.NoError(t, err)
+	require.Len(t, r.KVs, 1)
+	assert.Equal(t, large, r.KVs[0].Value)
+
+	r, err = s.Range(context.TODO(), []byte("empty"), nil, RangeOptions{})
+	require.NoError(t, err)
+	require.Len(t, r.KVs, 1)
+	assert.Empty(t, r.KVs[0].Value)
+
+	evs := rangeEvents(s.store.lg, b, 2, 3)
+	require.Len(t, evs, 1)
+	assert.Equal(t, mvccpb.PUT, evs[0].Type)
+	assert.Equal(t, large, evs[0].Kv.Value)
+
+	// the hash must not depend on whether values are chunked
+	b2, _ := betesting.NewDefaultTmpBackend(t)
+	s2 := NewStore(zaptest.NewLogger(t), b2, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s2, b2)
+	s2.Put([]byte("foo"), large, lease.NoLease)
+	s2.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	s2.Put([]byte("empty"), nil, lease.NoLease)
+	h, _, err := s.HashStorage().HashByRev(0)
+	require.NoError(t, err)
+	h2, _, err := s2.HashStorage().HashByRev(0)
+	require.NoError(t, err)
+	assert.Equal(t, h2.Hash, h.Hash)
+	// nor on the windows of revisions the keys are read in
+	defer func(window int64) { hashRevisionWindow = window }(hashRevisionWindow)
+	hashRevisionWindow = 1
+	h, _, err = s.HashStorage().HashByRev(0)
+	require.NoError(t, err)
+	assert.Equal(t, h2.Hash, h.Hash)
+
+	require.Positive(t, chunkCount(s.store))
+	done, err := s.Compact(traceutil.TODO(), 3)
+	require.NoError(t, err)
+	<-done
+	assert.Zero(t, chunkCount(s.store))
+}
+
+func chunkCount(s *store) int {
+	tx := s.b.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	n := 0
+	tx.UnsafeForEach(schema.KeyChunk, func(k, v []byte) error {
+		n++
+		return nil
+	})
+	return n
+}
+
+func TestChunkRange(t *testing.T) {
+	revBytes := RevToBytes(Revision{Main: 2, Sub: 1}, NewRevBytes())
+	start, end := chunkRange(revBytes)
+	for _, idx := range []uint32{0, 1, 1 << 31} {
+		k := chunkKey(revBytes, idx)
+		assert.GreaterOrEqual(t, bytes.Compare(k, start), 0)
+		assert.Negative(t, bytes.Compare(k, end))
+	}
+	next := RevToBytes(Revision{Main: 2, Sub: 2}, NewRevBytes())
+	assert.Positive(t, bytes.Compare(chunkKey(next, 0), end))
+}
@@ -16,6 +16,7 @@ package mvcc
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -46,10 +47,11 @@ var (
 func ChanBufLen() int { return chanBufLen }
 
 type watchable interface {
-	watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc)
+	watch(key, end []byte, startRev int64, id WatchID, identity string, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc)
 	progress(w *watcher)
 	progressAll(watchers map[WatchID]*watcher) bool
 	rev() int64
+	closeStream()
 }
 
 type watchableStore struct {
@@ -70,6 +72,9 @@ type watchableStore struct {
 	// The key of the map is the key that the watcher watches on.
 	synced watcherGroup
 
+	// streams is the number of open watch streams.
+	streams atomic.Int64
+
 	stopc chan struct{}
 	wg    sync.WaitGroup
 }
@@ -116,6 +121,7 @@ func (s *watchableStore) Close() error {
 
 func (s *watchableStore) NewWatchStream() WatchStream {
 	watchStreamGauge.Inc()
+	s.streams.Add(1)
 	return &watchStream{
 		watchable: s,
 		ch:        make(chan WatchResponse, chanBufLen),
@@ -124,13 +130,14 @@ func (s *watchableStore) NewWatchStream() WatchStream {
 	}
 }
 
-func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc) {
+func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, identity string, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc) {
 	wa := &watcher{
 		key:      key,
 		end:      end,
 		startRev: startRev,
 		minRev:   startRev,
 		id:       id,
+		identity: identity,
 		ch:       ch,
 		fcs:      fcs,
 	}
@@ -170,7 +177,7 @@ func (s *watchableStore) cancelWatcher(wa *watcher) {
 		} else if wa.ch == nil {
 			// already canceled (e.g., cancel/close race)
 			break
-		} else if wa.compacted {
+		} else if wa.compacted || wa.dropped {
 			watcherGauge.Dec()
 			break
 		}
@@ -216,6 +223,10 @@ func (s *watchableStore) Restore(b backend.Backend) error {
 		s.unsynced.add(wa)
 	}
 	s.synced = newWatcherGroup()
+
+	if obs := s.store.cfg.ChangeObserver; obs != nil {
+		obs.Restored(s.store.Rev())
+	}
 	return nil
 }
 
@@ -389,6 +400,7 @@ func (s *watchableStore) syncWatchers(evs []mvccpb.Event) (int, []mvccpb.Event)
 			pendingEventsGauge.Add(float64(len(eb.evs)))
 		} else {
 			w.victim = true
+			w.reportSlow()
 		}
 
 		if w.victim {
@@ -456,7 +468,7 @@ func rangeEvents(lg *zap.Logger, b backend.Backend, minRev, maxRev int64) []mvcc
 	tx := b.ReadTx()
 	tx.RLock()
 	revs, vs := tx.UnsafeRange(schema.Key, minBytes, maxBytes, 0)
-	evs := kvsToEvents(lg, revs, vs)
+	evs := kvsToEvents(lg, tx, revs, vs)
 	// Must unlock after kvsToEvents, because vs (come from boltdb memory) is not deep copy.
 	// We can only unlock after Unmarshal, which will do deep copy.
 	// Otherwise we will trigger SIGSEGV during boltdb re-mmap.
@@ -465,12 +477,13 @@ func rangeEvents(lg *zap.Logger, b backend.Backend, minRev, maxRev int64) []mvcc
 }
 
 // kvsToEvents gets all events for the watchers from all key-value pairs
-func kvsToEvents(lg *zap.Logger, revs, vals [][]byte) (evs []mvccpb.Event) {
+func kvsToEvents(lg *zap.Logger, tx backend.UnsafeReader, revs, vals [][]byte) (evs []mvccpb.Event) {
 	for i, v := range vals {
 		var kv mvccpb.KeyValue
 		if err := kv.Unmarshal(v); err != nil {
 			lg.Panic("failed to unmarshal mvccpb.KeyValue", zap.Error(err))
 		}
+		unsafeResolveChunkedValue(tx, revs[i], &kv)
 
 		ty := mvccpb.PUT
 		if isTombstone(revs[i]) {
@@ -499,6 +512,7 @@ func (s *watchableStore) notify(rev int64, evs []mvccpb.Event) {
 		} else {
 			// move slow watcher to victims
 			w.victim = true
+			w.reportSlow()
 			victim[w] = eb
 			s.synced.delete(w)
 			slowWatcherGauge.Inc()
@@ -524,6 +538,11 @@ func (s *watchableStore) addVictim(victim watcherBatch) {
 
 func (s *watchableStore) rev() int64 { return s.store.Rev() }
 
+func (s *watchableStore) closeStream() {
+	watchStreamGauge.Dec()
+	s.streams.Add(-1)
+}
+
 func (s *watchableStore) progress(w *watcher) {
 	s.progressIfSync(map[WatchID]*watcher{w.id: w}, w.id)
 }
@@ -572,6 +591,9 @@ type watcher struct {
 	// compacted is set when the watcher is removed because of compaction
 	compacted bool
 
+	// dropped is set when the watcher is removed to reclaim memory
+	dropped bool
+
 	// restore is true when the watcher is being restored from leader snapshot
 	// which means that this watcher has just been moved from "synced" to "unsynced"
 	// watcher group, possibly with a future revision when it was first added
@@ -584,6 +606,8 @@ type watcher struct {
 	// minRev is the minimum revision update the watcher will accept
 	minRev int64
 	id     WatchID
+	// identity is the owner of the watcher reported in metrics, if known.
+	identity string
 
 	fcs []FilterFunc
 	// a chan to send out the watch response.
@@ -591,6 +615,12 @@ type watcher struct {
 	ch chan<- WatchResponse
 }
 
+func (w *watcher) reportSlow() {
+	if w.identity != "" {
+		slowWatcherByIdentityCounter.WithLabelValues(w.identity).Inc()
+	}
+}
+
 func (w *watcher) send(wr WatchResponse) bool {
 	progressEvent := len(wr.Events) == 0
 
@@ -44,6 +44,10 @@ func (tw *watchableStoreTxnWrite) End() {
 	tw.s.notify(rev, evs)
 	tw.TxnWrite.End()
 	tw.s.mu.Unlock()
+
+	if obs := tw.s.store.cfg.ChangeObserver; obs != nil {
+		obs.Changed(rev, evs)
+	}
 }
 
 type watchableStoreTxnWrite struct {
@@ -34,6 +34,20 @@ var (
 
 type WatchID int64
 
+type watcherIdentityKey struct{}
+
+// WithWatcherIdentity returns a context that attributes watchers created with
+// it to the given identity in metrics. The identity should come from a bounded
+// set of values, since it is used as a metric label.
+func WithWatcherIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, watcherIdentityKey{}, identity)
+}
+
+func watcherIdentityFromContext(ctx context.Context) string {
+	identity, _ := ctx.Value(watcherIdentityKey{}).(string)
+	return identity
+}
+
 // FilterFunc returns true if the given event should be filtered out.
 type FilterFunc func(e mvccpb.Event) bool
 
@@ -95,6 +109,9 @@ type WatchResponse struct {
 
 	// CompactRevision is set when the watcher is cancelled due to compaction.
 	CompactRevision int64
+
+	// Dropped is set when the watcher is cancelled to reclaim memory.
+	Dropped bool
 }
 
 // watchStream contains a collection of watchers that share
@@ -135,7 +152,7 @@ func (ws *watchStream) Watch(ctx context.Context, id WatchID, key, end []byte, s
 		return -1, ErrWatcherDuplicateID
 	}
 
-	w, c := ws.watchable.watch(key, end, startRev, id, ws.ch, fcs...)
+	w, c := ws.watchable.watch(key, end, startRev, id, watcherIdentityFromContext(ctx), ws.ch, fcs...)
 
 	span := trace.SpanFromContext(ctx)
 	ws.cancels[id] = func() {
@@ -184,7 +201,7 @@ func (ws *watchStream) Close() {
 	}
 	ws.closed = true
 	close(ws.ch)
-	watchStreamGauge.Dec()
+	ws.watchable.closeStream()
 }
 
 func (ws *watchStream) Rev() int64 {
@@ -61,6 +61,8 @@ const (
 	leaseOverhead = 64
 	// kvOverhead is an estimate for the cost of storing a key's Metadata
 	kvOverhead = 256
+	// counterOverhead is an estimate for the cost of storing a counter value
+	counterOverhead = 16
 )
 
 var (
@@ -141,6 +143,8 @@ func (b *BackendQuota) Cost(v any) int {
 		return costTxn(r)
 	case *pb.LeaseGrantRequest:
 		return leaseOverhead
+	case *pb.CounterAddRequest:
+		return costCounterAdd(r)
 	default:
 		panic("unexpected cost")
 	}
@@ -148,7 +152,25 @@ func (b *BackendQuota) Cost(v any) int {
 
 func costPut(r *pb.PutRequest) int { return kvOverhead + len(r.Key) + len(r.Value) }
 
+func costCounterAdd(r *pb.CounterAddRequest) int {
+	size := 0
+	for _, d := range r.Deltas {
+		size += counterOverhead + len(d.Key)
+	}
+	return size
+}
+
 func costTxnReq(u *pb.RequestOp) int {
+	if fe := u.GetRequestForEach(); fe != nil {
+		// every put of a foreach rewrites one of at most max_keys keys
+		size := 0
+		for _, op := range fe.Ops {
+			if r := op.GetRequestPut(); r != nil {
+				size += int(fe.MaxKeys) * (kvOverhead + len(fe.Key) + len(r.Value))
+			}
+		}
+		return size
+	}
 	r := u.GetRequestPut()
 	if r == nil {
 		return 0
@@ -15,6 +15,10 @@
 package schema
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
 	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/server/v3/storage/backend"
@@ -49,6 +53,59 @@ func (a deleteKeyAction) unsafeDo(tx backend.UnsafeReadWriter) (action, error) {
 	return revert, nil
 }
 
+// deleteOptionalKeyAction deletes the field if it is set. Unlike
+// deleteKeyAction, it does not require the bucket to exist.
+type deleteOptionalKeyAction struct {
+	Bucket    backend.Bucket
+	FieldName []byte
+}
+
+var errKeyFound = errors.New("key found")
+
+func (a deleteOptionalKeyAction) unsafeDo(tx backend.UnsafeReadWriter) (action, error) {
+	var value []byte
+	err := tx.UnsafeForEach(a.Bucket, func(k, v []byte) error {
+		if bytes.Equal(k, a.FieldName) {
+			value = bytes.Clone(v)
+			return errKeyFound
+		}
+		return nil
+	})
+	if !errors.Is(err, errKeyFound) {
+		return noopAction{}, err
+	}
+	tx.UnsafeDelete(a.Bucket, a.FieldName)
+	return &setKeyAction{
+		Bucket:     a.Bucket,
+		FieldName:  a.FieldName,
+		FieldValue: value,
+	}, nil
+}
+
+type noopAction struct{}
+
+func (a noopAction) unsafeDo(tx backend.UnsafeReadWriter) (action, error) {
+	return noopAction{}, nil
+}
+
+// emptyBucketAction fails if the bucket holds any keys. It protects data that
+// older versions cannot read from being silently ignored on downgrade.
+type emptyBucketAction struct {
+	Bucket backend.Bucket
+}
+
+var errBucketNotEmpty = errors.New("bucket not empty")
+
+func (a emptyBucketAction) unsafeDo(tx backend.UnsafeReadWriter) (action, error) {
+	err := tx.UnsafeForEach(a.Bucket, func(_, _ []byte) error {
+		return errBucketNotEmpty
+	})
+	if errors.Is(err, errBucketNotEmpty) {
+		return nil, fmt.Errorf("bucket %q is not empty", a.Bucket.String())
+	}
+	return noopAction{}, err
+}
+
 func restoreFieldValueAction(tx backend.UnsafeReader, bucket backend.Bucket, fieldName []byte) action {
 	_, vs := tx.UnsafeRange(bucket, fieldName, nil, 1)
 	if len(vs) == 1 {
@@ -66,6 +66,21 @@ func TestActionIsReversible(t *testing.T) {
 			},
 			state: map[string]string{"/test": "2"},
 		},
+		{
+			name: "deleteOptionalKeyAction empty state",
+			action: deleteOptionalKeyAction{
+				Bucket:    Meta,
+				FieldName: []byte("/test"),
+			},
+		},
+		{
+			name: "deleteOptionalKeyAction with key",
+			action: deleteOptionalKeyAction{
+				Bucket:    Meta,
+				FieldName: []byte("/test"),
+			},
+			state: map[string]string{"/test": "2", "/other": "3"},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,62 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+// BootHash is the keyspace hash computed on clean shutdown together with the
+// position of the backend it was computed at.
+type BootHash struct {
+	ConsistentIndex uint64 `json:"consistentIndex"`
+	Revision        int64  `json:"revision"`
+	CompactRevision int64  `json:"compactRevision"`
+	Hash            uint32 `json:"hash"`
+}
+
+// MustUnsafeSaveBootHash persists the boot hash using given transaction (tx).
+// The boot hash is persisted since etcd v3.7.
+func MustUnsafeSaveBootHash(lg *zap.Logger, tx backend.UnsafeWriter, h BootHash) {
+	val, err := json.Marshal(h)
+	if err != nil {
+		lg.Panic("Cannot marshal boot hash", zap.Error(err))
+	}
+	tx.UnsafePut(Meta, MetaBootHashName, val)
+}
+
+// UnsafeReadBootHash retrieves the boot hash from the backend.
+// Returns nil if no boot hash is persisted or it cannot be decoded.
+func UnsafeReadBootHash(lg *zap.Logger, tx backend.UnsafeReader) *BootHash {
+	_, vs := tx.UnsafeRange(Meta, MetaBootHashName, nil, 0)
+	if len(vs) == 0 {
+		return nil
+	}
+	var h BootHash
+	if err := json.Unmarshal(vs[0], &h); err != nil {
+		lg.Warn("Ignoring boot hash that cannot be unmarshalled", zap.ByteString("boot-hash-json", vs[0]), zap.Error(err))
+		return nil
+	}
+	return &h
+}
+
+// UnsafeDeleteBootHash removes the boot hash from the backend.
+func UnsafeDeleteBootHash(tx backend.UnsafeWriter) {
+	tx.UnsafeDelete(Meta, MetaBootHashName)
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestBootHash(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	tx := be.BatchTx()
+	CreateMetaBucket(tx)
+	tx.Lock()
+	defer tx.Unlock()
+	assert.Nil(t, UnsafeReadBootHash(lg, tx))
+
+	h := BootHash{ConsistentIndex: 5, Revision: 10, CompactRevision: 3, Hash: 42}
+	MustUnsafeSaveBootHash(lg, tx, h)
+	assert.Equal(t, h, *UnsafeReadBootHash(lg, tx))
+
+	UnsafeDeleteBootHash(tx)
+	assert.Nil(t, UnsafeReadBootHash(lg, tx))
+
+	tx.UnsafePut(Meta, MetaBootHashName, []byte("garbage"))
+	assert.Nil(t, UnsafeReadBootHash(lg, tx))
+}
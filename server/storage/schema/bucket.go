@@ -22,10 +22,21 @@ import (
 )
 
 var (
-	keyBucketName   = []byte("key")
-	metaBucketName  = []byte("meta")
-	leaseBucketName = []byte("lease")
-	alarmBucketName = []byte("alarm")
+	keyBucketName      = []byte("key")
+	keyChunkBucketName = []byte("key_chunk")
+	metaBucketName     = []byte("meta")
+	leaseBucketName    = []byte("lease")
+	alarmBucketName    = []byte("alarm")
+
+	compactionHoldBucketName = []byte("compaction_hold")
+
+	keyIndexCheckpointBucketName = []byte("key_index_checkpoint")
+
+	jobBucketName = []byte("job")
+
+	counterBucketName = []byte("counter")
+
+	fenceBucketName = []byte("fence")
 
 	clusterBucketName = []byte("cluster")
 
@@ -45,6 +56,19 @@ var (
 	Lease   = backend.Bucket(bucket{id: 3, name: leaseBucketName, safeRangeBucket: false})
 	Alarm   = backend.Bucket(bucket{id: 4, name: alarmBucketName, safeRangeBucket: false})
 	Cluster = backend.Bucket(bucket{id: 5, name: clusterBucketName, safeRangeBucket: false})
+	// KeyChunk stores the values of keys that exceed the configured value chunk size.
+	KeyChunk = backend.Bucket(bucket{id: 6, name: keyChunkBucketName, safeRangeBucket: true})
+	// CompactionHold stores the compaction holds registered by clients.
+	CompactionHold = backend.Bucket(bucket{id: 7, name: compactionHoldBucketName, safeRangeBucket: false})
+	// KeyIndexCheckpoint stores a checkpoint of the key index used to speed up
+	// restoring the index.
+	KeyIndexCheckpoint = backend.Bucket(bucket{id: 8, name: keyIndexCheckpointBucketName, safeRangeBucket: false})
+	// Job stores the records of the maintenance jobs run by the member.
+	Job = backend.Bucket(bucket{id: 9, name: jobBucketName, safeRangeBucket: false})
+	// Counter stores the values of the counters, outside of the key space.
+	Counter = backend.Bucket(bucket{id: 12, name: counterBucketName, safeRangeBucket: false})
+	// Fence stores the fences of prefixes, keyed by prefix.
+	Fence = backend.Bucket(bucket{id: 13, name: fenceBucketName, safeRangeBucket: false})
 
 	Members        = backend.Bucket(bucket{id: 10, name: membersBucketName, safeRangeBucket: false})
 	MembersRemoved = backend.Bucket(bucket{id: 11, name: membersRemovedBucketName, safeRangeBucket: false})
@@ -55,7 +79,7 @@ var (
 
 	Test = backend.Bucket(bucket{id: 100, name: testBucketName, safeRangeBucket: false})
 
-	AllBuckets = []backend.Bucket{Key, Meta, Lease, Alarm, Cluster, Members, MembersRemoved, Auth, AuthUsers, AuthRoles}
+	AllBuckets = []backend.Bucket{Key, KeyChunk, Meta, Lease, Alarm, CompactionHold, KeyIndexCheckpoint, Job, Counter, Fence, Cluster, Members, MembersRemoved, Auth, AuthUsers, AuthRoles}
 )
 
 type bucket struct {
@@ -83,16 +107,26 @@ var (
 	ClusterDowngradeKeyName      = []byte("downgrade")
 	// Since v3.6
 	MetaStorageVersionName = []byte("storageVersion")
+	// Since v3.7
+	ClusterConfigKeyName = []byte("clusterConfig")
+	MetaBootHashName     = []byte("bootHash")
+	MetaFencingTokenName = []byte("leaseFencingToken")
 	// Before adding new meta key please update server/etcdserver/version
 )
 
 // DefaultIgnores defines buckets & keys to ignore in hash checking.
 func DefaultIgnores(bucket, key []byte) bool {
+	// index checkpoints are taken and jobs are run independently by each
+	// member.
+	if bytes.Equal(bucket, KeyIndexCheckpoint.Name()) || bytes.Equal(bucket, Job.Name()) {
+		return true
+	}
 	// consistent index & term might be changed due to v2 internal sync, which
 	// is not controllable by the user.
 	// storage version might change after wal snapshot and is not controller by user.
+	// boot hash is only written by members configured to cache it on shutdown.
 	return bytes.Equal(bucket, Meta.Name()) &&
-		(bytes.Equal(key, MetaTermKeyName) || bytes.Equal(key, MetaConsistentIndexKeyName) || bytes.Equal(key, MetaStorageVersionName))
+		(bytes.Equal(key, MetaTermKeyName) || bytes.Equal(key, MetaConsistentIndexKeyName) || bytes.Equal(key, MetaStorageVersionName) || bytes.Equal(key, MetaBootHashName))
 }
 
 func BackendMemberKey(id types.ID) []byte {
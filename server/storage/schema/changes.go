@@ -36,6 +36,28 @@ func addNewField(bucket backend.Bucket, fieldName []byte, fieldValue []byte) sch
 	}
 }
 
+// addOptionalField represents adding a field that is only set once in use, so
+// upgrade leaves it unset. Downgrade will remove the field, if the bucket
+// exists.
+func addOptionalField(bucket backend.Bucket, fieldName []byte) schemaChange {
+	return simpleSchemaChange{
+		upgrade: noopAction{},
+		downgrade: deleteOptionalKeyAction{
+			Bucket:    bucket,
+			FieldName: fieldName,
+		},
+	}
+}
+
+// addBucket represents adding a bucket that older versions do not read.
+// Downgrade fails while the bucket holds any keys.
+func addBucket(bucket backend.Bucket) schemaChange {
+	return simpleSchemaChange{
+		upgrade:   noopAction{},
+		downgrade: emptyBucketAction{Bucket: bucket},
+	}
+}
+
 type simpleSchemaChange struct {
 	upgrade   action
 	downgrade action
@@ -27,14 +27,40 @@ func TestUpgradeDowngrade(t *testing.T) {
 	tcs := []struct {
 		name                      string
 		change                    schemaChange
+		initialState              map[string]string
 		expectStateAfterUpgrade   map[string]string
 		expectStateAfterDowngrade map[string]string
+		expectDowngradeErr        bool
 	}{
 		{
 			name:                    "addNewField empty",
 			change:                  addNewField(Meta, []byte("/test"), []byte("1")),
 			expectStateAfterUpgrade: map[string]string{"/test": "1"},
 		},
+		{
+			name:                    "addOptionalField unset",
+			change:                  addOptionalField(Meta, []byte("/test")),
+			expectStateAfterUpgrade: map[string]string{},
+		},
+		{
+			name:                    "addOptionalField set",
+			change:                  addOptionalField(Meta, []byte("/test")),
+			initialState:            map[string]string{"/test": "1"},
+			expectStateAfterUpgrade: map[string]string{"/test": "1"},
+		},
+		{
+			name:                    "addBucket empty",
+			change:                  addBucket(Meta),
+			expectStateAfterUpgrade: map[string]string{},
+		},
+		{
+			name:                      "addBucket not empty",
+			change:                    addBucket(Meta),
+			initialState:              map[string]string{"/test": "1"},
+			expectStateAfterUpgrade:   map[string]string{"/test": "1"},
+			expectStateAfterDowngrade: map[string]string{"/test": "1"},
+			expectDowngradeErr:        true,
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -45,6 +71,9 @@ func TestUpgradeDowngrade(t *testing.T) {
 			tx.Lock()
 			defer tx.Unlock()
 			UnsafeCreateMetaBucket(tx)
+			for k, v := range tc.initialState {
+				tx.UnsafePut(Meta, []byte(k), []byte(v))
+			}
 
 			_, err := tc.change.upgradeAction().unsafeDo(tx)
 			if err != nil {
@@ -52,8 +81,8 @@ func TestUpgradeDowngrade(t *testing.T) {
 			}
 			assertBucketState(t, tx, Meta, tc.expectStateAfterUpgrade)
 			_, err = tc.change.downgradeAction().unsafeDo(tx)
-			if err != nil {
-				t.Errorf("Failed to downgrade, err: %v", err)
+			if (err != nil) != tc.expectDowngradeErr {
+				t.Errorf("Unexpected downgrade error, expected error: %v, got: %v", tc.expectDowngradeErr, err)
 			}
 			assertBucketState(t, tx, Meta, tc.expectStateAfterDowngrade)
 		})
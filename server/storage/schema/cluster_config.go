@@ -0,0 +1,47 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+// MustUnsafePutClusterConfig stores the cluster-wide configuration.
+// The field is populated since etcd v3.7.
+func MustUnsafePutClusterConfig(tx backend.UnsafeWriter, cfg *etcdserverpb.ClusterConfig) {
+	val, err := cfg.Marshal()
+	if err != nil {
+		panic("failed to marshal cluster config")
+	}
+	tx.UnsafeCreateBucket(Cluster)
+	tx.UnsafePut(Cluster, ClusterConfigKeyName, val)
+}
+
+// MustUnsafeGetClusterConfig reads the cluster-wide configuration, or returns
+// nil if it was never set.
+func MustUnsafeGetClusterConfig(tx backend.UnsafeReader) *etcdserverpb.ClusterConfig {
+	_, vs := tx.UnsafeRange(Cluster, ClusterConfigKeyName, nil, 0)
+	if len(vs) == 0 {
+		return nil
+	}
+	var cfg etcdserverpb.ClusterConfig
+	if err := cfg.Unmarshal(vs[0]); err != nil {
+		panic(fmt.Errorf("failed to unmarshal cluster config: %w", err))
+	}
+	return &cfg
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+// MustUnsafePutCompactionHold stores the hold. The bucket is only created for
+// the first hold, so that backends of clusters not using holds are unchanged.
+func MustUnsafePutCompactionHold(tx backend.UnsafeWriter, h *etcdserverpb.InternalCompactionHoldGrantRequest) {
+	val, err := h.Marshal()
+	if err != nil {
+		panic("failed to marshal compaction hold")
+	}
+	tx.UnsafeCreateBucket(CompactionHold)
+	tx.UnsafePut(CompactionHold, leaseIDToBytes(h.ID), val)
+}
+
+func UnsafeDeleteCompactionHold(tx backend.UnsafeWriter, id int64) {
+	tx.UnsafeDelete(CompactionHold, leaseIDToBytes(id))
+}
+
+func MustUnsafeGetAllCompactionHolds(tx backend.UnsafeReader) []*etcdserverpb.InternalCompactionHoldGrantRequest {
+	var hs []*etcdserverpb.InternalCompactionHoldGrantRequest
+	err := tx.UnsafeForEach(CompactionHold, func(k, v []byte) error {
+		var h etcdserverpb.InternalCompactionHoldGrantRequest
+		if err := h.Unmarshal(v); err != nil {
+			return fmt.Errorf("failed to unmarshal compaction hold %016x: %w", bytesToLeaseID(k), err)
+		}
+		hs = append(hs, &h)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return hs
+}
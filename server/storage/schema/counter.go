@@ -0,0 +1,66 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/binary"
+
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+// UnsafeCreateCounterBucket creates the counter bucket. It is only created
+// for the first counter, so that backends of clusters not using counters
+// are unchanged.
+func UnsafeCreateCounterBucket(tx backend.UnsafeWriter) {
+	tx.UnsafeCreateBucket(Counter)
+}
+
+func UnsafePutCounter(tx backend.UnsafeWriter, key []byte, value int64) {
+	tx.UnsafePut(Counter, key, counterToBytes(value))
+}
+
+func UnsafeDeleteCounter(tx backend.UnsafeWriter, key []byte) {
+	tx.UnsafeDelete(Counter, key)
+}
+
+// UnsafeGetCounter returns the value of the counter, or 0 if there is none.
+func UnsafeGetCounter(tx backend.UnsafeReader, key []byte) int64 {
+	_, vs := tx.UnsafeRange(Counter, key, nil, 0)
+	if len(vs) == 0 {
+		return 0
+	}
+	return bytesToCounter(vs[0])
+}
+
+// MustUnsafeForEachCounter visits the counters in no particular order.
+func MustUnsafeForEachCounter(tx backend.UnsafeReader, visitor func(key []byte, value int64)) {
+	err := tx.UnsafeForEach(Counter, func(k, v []byte) error {
+		visitor(k, bytesToCounter(v))
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func counterToBytes(n int64) []byte {
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, uint64(n))
+	return bytes
+}
+
+func bytesToCounter(bytes []byte) int64 {
+	return int64(binary.BigEndian.Uint64(bytes))
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+// MustUnsafePutFence stores the fence. The bucket is only created for the
+// first fence, so that backends of clusters not using fences are unchanged.
+func MustUnsafePutFence(tx backend.UnsafeWriter, f *etcdserverpb.InternalFencePrefixRequest) {
+	val, err := f.Marshal()
+	if err != nil {
+		panic("failed to marshal fence")
+	}
+	tx.UnsafeCreateBucket(Fence)
+	tx.UnsafePut(Fence, f.Prefix, val)
+}
+
+func UnsafeDeleteFence(tx backend.UnsafeWriter, prefix []byte) {
+	tx.UnsafeDelete(Fence, prefix)
+}
+
+func MustUnsafeGetAllFences(tx backend.UnsafeReader) []*etcdserverpb.InternalFencePrefixRequest {
+	var fs []*etcdserverpb.InternalFencePrefixRequest
+	err := tx.UnsafeForEach(Fence, func(k, v []byte) error {
+		var f etcdserverpb.InternalFencePrefixRequest
+		if err := f.Unmarshal(v); err != nil {
+			return fmt.Errorf("failed to unmarshal fence of %q: %w", k, err)
+		}
+		fs = append(fs, &f)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return fs
+}
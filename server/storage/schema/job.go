@@ -0,0 +1,54 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+// MustUnsafePutJob stores the record of the job. The bucket is only created
+// for the first job, so that backends of members not running jobs are
+// unchanged.
+func MustUnsafePutJob(tx backend.UnsafeWriter, j *etcdserverpb.Job) {
+	val, err := j.Marshal()
+	if err != nil {
+		panic("failed to marshal job")
+	}
+	tx.UnsafeCreateBucket(Job)
+	tx.UnsafePut(Job, leaseIDToBytes(j.ID), val)
+}
+
+func UnsafeDeleteJob(tx backend.UnsafeWriter, id int64) {
+	tx.UnsafeDelete(Job, leaseIDToBytes(id))
+}
+
+func MustUnsafeGetAllJobs(tx backend.UnsafeReader) []*etcdserverpb.Job {
+	var js []*etcdserverpb.Job
+	err := tx.UnsafeForEach(Job, func(k, v []byte) error {
+		var j etcdserverpb.Job
+		if err := j.Unmarshal(v); err != nil {
+			return fmt.Errorf("failed to unmarshal job %d: %w", bytesToLeaseID(k), err)
+		}
+		js = append(js, &j)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return js
+}
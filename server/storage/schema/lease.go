@@ -22,8 +22,11 @@ import (
 	"go.etcd.io/etcd/server/v3/storage/backend"
 )
 
+// UnsafeCreateLeaseBucket creates the buckets holding the leases and their
+// last fencing token.
 func UnsafeCreateLeaseBucket(tx backend.UnsafeWriter) {
 	tx.UnsafeCreateBucket(Lease)
+	tx.UnsafeCreateBucket(Meta)
 }
 
 func MustUnsafeGetAllLeases(tx backend.UnsafeReader) []*leasepb.Lease {
@@ -70,6 +73,22 @@ func MustUnsafeGetLease(tx backend.UnsafeReader, leaseID int64) *leasepb.Lease {
 	return &lpb
 }
 
+// UnsafeSetLeaseFencingToken persists the last fencing token given to a
+// lease.
+func UnsafeSetLeaseFencingToken(tx backend.UnsafeWriter, token int64) {
+	tx.UnsafePut(Meta, MetaFencingTokenName, leaseIDToBytes(token))
+}
+
+// UnsafeReadLeaseFencingToken returns the last fencing token given to a
+// lease, or 0 if none was given.
+func UnsafeReadLeaseFencingToken(tx backend.UnsafeReader) int64 {
+	_, vs := tx.UnsafeRange(Meta, MetaFencingTokenName, nil, 0)
+	if len(vs) != 1 {
+		return 0
+	}
+	return bytesToLeaseID(vs[0])
+}
+
 func leaseIDToBytes(n int64) []byte {
 	bytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(bytes, uint64(n))
@@ -235,6 +235,83 @@ func TestMigrate(t *testing.T) {
 	}
 }
 
+func TestMigrateDowngradeRemovesV3_7Fields(t *testing.T) {
+	tcs := []struct {
+		name   string
+		bucket backend.Bucket
+		key    []byte
+	}{
+		{
+			name:   "lease fencing token",
+			bucket: Meta,
+			key:    MetaFencingTokenName,
+		},
+		{
+			name:   "boot hash",
+			bucket: Meta,
+			key:    MetaBootHashName,
+		},
+		{
+			name:   "cluster config",
+			bucket: Cluster,
+			key:    ClusterConfigKeyName,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			lg := zap.NewNop()
+			dataPath := setupBackendData(t, version.V3_7, nil)
+			w, _ := waltesting.NewTmpWAL(t, nil)
+			defer w.Close()
+			walVersion, err := wal.ReadWALVersion(w)
+			require.NoError(t, err)
+
+			b := backend.NewDefaultBackend(lg, dataPath)
+			defer b.Close()
+			tx := b.BatchTx()
+			tx.Lock()
+			tx.UnsafeCreateBucket(tc.bucket)
+			tx.UnsafePut(tc.bucket, tc.key, []byte("1"))
+			tx.Unlock()
+
+			require.NoError(t, Migrate(lg, tx, walVersion, version.V3_6))
+			tx.Lock()
+			defer tx.Unlock()
+			_, vs := tx.UnsafeRange(tc.bucket, tc.key, nil, 0)
+			assert.Empty(t, vs)
+			assert.Equal(t, &version.V3_6, UnsafeReadStorageVersion(tx))
+		})
+	}
+}
+
+func TestMigrateDowngradeFailsWithValueChunks(t *testing.T) {
+	lg := zap.NewNop()
+	dataPath := setupBackendData(t, version.V3_7, nil)
+	w, _ := waltesting.NewTmpWAL(t, nil)
+	defer w.Close()
+	walVersion, err := wal.ReadWALVersion(w)
+	require.NoError(t, err)
+
+	b := backend.NewDefaultBackend(lg, dataPath)
+	defer b.Close()
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(KeyChunk)
+	tx.UnsafePut(KeyChunk, []byte("chunk"), []byte("1"))
+	tx.Unlock()
+
+	require.ErrorContains(t, Migrate(lg, tx, walVersion, version.V3_6), "is not empty")
+	tx.Lock()
+	assert.Equal(t, &version.V3_7, UnsafeReadStorageVersion(tx))
+	tx.UnsafeDelete(KeyChunk, []byte("chunk"))
+	tx.Unlock()
+
+	require.NoError(t, Migrate(lg, tx, walVersion, version.V3_6))
+	tx.Lock()
+	defer tx.Unlock()
+	assert.Equal(t, &version.V3_6, UnsafeReadStorageVersion(tx))
+}
+
 func TestMigrateIsReversible(t *testing.T) {
 	tcs := []struct {
 		initialVersion semver.Version
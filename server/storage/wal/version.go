@@ -94,6 +94,13 @@ func VisitFileDescriptor(file protoreflect.FileDescriptor, visitor Visitor) erro
 	return nil
 }
 
+// VisitMessage calls visitor on the message and on each of its set fields,
+// nested messages and enum values with etcd version read from proto definition.
+// If a descriptor is not annotated, visitor will be called with nil.
+func VisitMessage(m proto.Message, visitor Visitor) error {
+	return visitMessage(proto.MessageReflect(m), visitor)
+}
+
 func visitEntry(ent raftpb.Entry, visitor Visitor) error {
 	err := visitMessage(proto.MessageReflect(&ent), visitor)
 	if err != nil {
@@ -117,17 +124,7 @@ func visitEntryData(entryType raftpb.EntryType, data []byte, visitor Visitor) er
 			msg = proto.MessageReflect(&r)
 			break
 		}
-		msg = proto.MessageReflect(&raftReq)
-		if raftReq.DowngradeVersionTest != nil {
-			ver, err := semver.NewVersion(raftReq.DowngradeVersionTest.Ver)
-			if err != nil {
-				return err
-			}
-			err = visitor(msg.Descriptor().FullName(), ver)
-			if err != nil {
-				return err
-			}
-		}
+		return VisitRaftRequest(&raftReq, visitor)
 	case raftpb.EntryConfChange:
 		var confChange raftpb.ConfChange
 		err := pbutil.Unmarshaler(&confChange).Unmarshal(data)
@@ -150,6 +147,24 @@ func visitEntryData(entryType raftpb.EntryType, data []byte, visitor Visitor) er
 	return visitMessage(msg, visitor)
 }
 
+// VisitRaftRequest calls visitor on the request and on each of its set fields,
+// nested messages and enum values with the etcd version read from proto
+// definition, as they are when the request is an entry of the WAL log.
+func VisitRaftRequest(r *etcdserverpb.InternalRaftRequest, visitor Visitor) error {
+	msg := proto.MessageReflect(r)
+	if r.DowngradeVersionTest != nil {
+		ver, err := semver.NewVersion(r.DowngradeVersionTest.Ver)
+		if err != nil {
+			return err
+		}
+		err = visitor(msg.Descriptor().FullName(), ver)
+		if err != nil {
+			return err
+		}
+	}
+	return visitMessage(msg, visitor)
+}
+
 func visitMessageDescriptor(md protoreflect.MessageDescriptor, visitor Visitor) error {
 	err := visitDescriptor(md, visitor)
 	if err != nil {
@@ -192,6 +207,16 @@ func visitMessage(m protoreflect.Message, visitor Visitor) error {
 			err = visitMessage(m, visitor)
 		case protoreflect.EnumNumber:
 			err = visitEnumNumber(fd.Enum(), m, visitor)
+		case protoreflect.List:
+			// e.g. txn operations, which may use fields newer than the txn itself
+			for i := 0; i < m.Len() && err == nil; i++ {
+				switch v := m.Get(i).Interface().(type) {
+				case protoreflect.Message:
+					err = visitMessage(v, visitor)
+				case protoreflect.EnumNumber:
+					err = visitEnumNumber(fd.Enum(), v, visitor)
+				}
+			}
 		}
 		return err == nil
 	})
@@ -172,6 +172,15 @@ func TestEtcdVersionFromMessage(t *testing.T) {
 			input:  &etcdserverpb.Compare{TargetUnion: &etcdserverpb.Compare_Lease{}},
 			expect: &version.V3_3,
 		},
+		{
+			name: "Append operation inside a txn implies v3.7",
+			input: &etcdserverpb.InternalRaftRequest{Txn: &etcdserverpb.TxnRequest{
+				Success: []*etcdserverpb.RequestOp{
+					{Request: &etcdserverpb.RequestOp_RequestAppend{RequestAppend: &etcdserverpb.AppendRequest{Key: []byte("foo")}}},
+				},
+			}},
+			expect: &version.V3_7,
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
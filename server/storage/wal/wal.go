@@ -654,6 +654,21 @@ func ValidSnapshotEntries(lg *zap.Logger, walDir string) ([]walpb.Snapshot, erro
 	return snaps, nil
 }
 
+// FilesBefore returns the names of the WAL files in walDir that are not
+// needed to replay the WAL from the given snapshot, because all their
+// entries precede the file holding the snapshot record. The names are
+// sorted from the oldest file.
+func FilesBefore(lg *zap.Logger, walDir string, snap walpb.Snapshot) ([]string, error) {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	names, nameIndex, err := selectWALFiles(lg, walDir, snap)
+	if err != nil {
+		return nil, err
+	}
+	return names[:nameIndex], nil
+}
+
 // Verify reads through the given WAL and verifies that it is not corrupted.
 // It creates a new decoder to read through the records of the given WAL.
 // It does not conflict with any open WAL, but it is recommended not to
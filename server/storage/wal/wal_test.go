@@ -722,6 +722,28 @@ func TestSaveEmpty(t *testing.T) {
 	}
 }
 
+func TestFilesBefore(t *testing.T) {
+	p := t.TempDir()
+	w, err := Create(zaptest.NewLogger(t), p, nil)
+	require.NoError(t, err)
+
+	// make 5 separate files, the ones after the first starting at index 2, 3, 4 and 5
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, w.Save(raftpb.HardState{}, []raftpb.Entry{{Index: uint64(i)}}))
+		require.NoError(t, w.cut())
+	}
+	require.NoError(t, w.Close())
+
+	names, err := FilesBefore(zaptest.NewLogger(t), p, walpb.Snapshot{Index: 3})
+	require.NoError(t, err)
+	// the file starting at index 3 may hold the snapshot record
+	require.Equal(t, []string{walName(0, 0), walName(1, 2)}, names)
+
+	names, err = FilesBefore(zaptest.NewLogger(t), p, walpb.Snapshot{Index: 0})
+	require.NoError(t, err)
+	require.Empty(t, names)
+}
+
 func TestReleaseLockTo(t *testing.T) {
 	p := t.TempDir()
 	// create WAL
@@ -96,6 +96,44 @@ func corruptTest(cx ctlCtx) {
 	e2e.WaitReadyExpectProc(context.TODO(), proc, []string{fmt.Sprintf("etcdmain: %016x found data inconsistency with peers", id0)})
 }
 
+func TestInitialCorruptCheckHashCache(t *testing.T) {
+	e2e.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	epc, err := e2e.NewEtcdProcessCluster(ctx, t,
+		e2e.WithClusterSize(3),
+		e2e.WithInitialCorruptCheck(true),
+		e2e.WithCorruptCheckHashCache(true),
+	)
+	require.NoError(t, err)
+	defer epc.Close()
+
+	cc := epc.Etcdctl()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, cc.Put(ctx, fmt.Sprintf("foo%05d", i), fmt.Sprintf("v%05d", i), config.PutOptions{}))
+	}
+
+	const cachedHashLog = "using keyspace hash stored on clean shutdown"
+	member := epc.Procs[0]
+
+	t.Log("restarting member after clean shutdown, the stored hash should be used")
+	require.NoError(t, member.Restart(ctx))
+	_, err = member.Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: cachedHashLog})
+	require.NoError(t, err)
+	require.NoError(t, cc.Put(ctx, "foo", "bar", config.PutOptions{}))
+
+	t.Log("restarting member after unclean shutdown, the keyspace should be hashed")
+	require.NoError(t, member.Kill())
+	require.NoError(t, member.Wait(ctx))
+	require.NoError(t, member.Start(ctx))
+	_, err = member.Logs().ExpectWithContext(ctx, expect.ExpectedResponse{Value: "initial corruption checking passed"})
+	require.NoError(t, err)
+	for _, line := range member.Logs().Lines() {
+		assert.NotContains(t, line, cachedHashLog)
+	}
+}
+
 func TestInPlaceRecovery(t *testing.T) {
 	basePort := 20000
 	e2e.BeforeTest(t)
@@ -0,0 +1,75 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestCtlV3AuthExportApply(t *testing.T) { testCtl(t, authExportApplyTest) }
+
+func authExportApplyTest(cx ctlCtx) {
+	require.NoError(cx.t, authEnable(cx))
+	cx.user, cx.pass = "root", "root"
+	authSetupTestUser(cx)
+
+	// password hashes are only exported on request
+	lines, err := ctlV3AuthExport(cx)
+	require.NoError(cx.t, err)
+	assert.NotContains(cx.t, strings.Join(lines, "\n"), "passwordHash")
+
+	lines, err = ctlV3AuthExport(cx, "--with-password-hashes")
+	require.NoError(cx.t, err)
+	file := filepath.Join(cx.t.TempDir(), "auth.yaml")
+	require.NoError(cx.t, os.WriteFile(file, []byte(strings.Join(lines, "\n")), 0o600))
+
+	// diverge from the exported file
+	require.NoError(cx.t, ctlV3User(cx, []string{"delete", "test-user"}, "User test-user deleted", nil))
+	require.NoError(cx.t, ctlV3Role(cx, []string{"add", "extra-role"}, "Role extra-role created"))
+	require.NoError(cx.t, ctlV3Role(cx, []string{"grant-permission", "test-role", "read", "bar"}, "Role test-role updated"))
+
+	require.NoError(cx.t, ctlV3AuthApply(cx, file, "4 change(s) planned (dry run)", "--dry-run", "--prune"))
+	require.NoError(cx.t, ctlV3Role(cx, []string{"get", "extra-role"}, "Role extra-role"))
+
+	require.NoError(cx.t, ctlV3AuthApply(cx, file, "4 change(s) applied", "--prune"))
+	require.NoError(cx.t, ctlV3AuthApply(cx, file, "No changes", "--prune"))
+
+	// the user is restored with its password and role
+	cx.user, cx.pass = "test-user", "pass"
+	require.NoError(cx.t, ctlV3Put(cx, "foo", "bar", ""))
+}
+
+func ctlV3AuthExport(cx ctlCtx, flags ...string) ([]string, error) {
+	cmdArgs := append(cx.PrefixArgs(), "auth", "export")
+	lines, err := e2e.RunUtilCompletion(append(cmdArgs, flags...), cx.envMap)
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], "\r")
+	}
+	return lines, err
+}
+
+func ctlV3AuthApply(cx ctlCtx, file, expected string, flags ...string) error {
+	cmdArgs := append(cx.PrefixArgs(), "auth", "apply", "-f", file)
+	return e2e.SpawnWithExpects(append(cmdArgs, flags...), cx.envMap, expect.ExpectedResponse{Value: expected})
+}
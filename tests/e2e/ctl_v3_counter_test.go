@@ -0,0 +1,55 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestCtlV3Counter(t *testing.T) { testCtl(t, counterTest) }
+
+func counterTest(cx ctlCtx) {
+	require.NoError(cx.t, ctlV3CounterAdd(cx, []string{"/hits", "1", "/bytes", "512"}, "/hits", "1", "/bytes", "512"))
+	require.NoError(cx.t, ctlV3CounterAdd(cx, []string{"/hits", "1"}, "/hits", "2"))
+
+	proc, err := e2e.SpawnCmd(append(cx.PrefixArgs(), "counter", "add", "/hits", "9223372036854775807"), cx.envMap)
+	require.NoError(cx.t, err)
+	defer proc.Close()
+	_, err = proc.Expect("Error: etcdserver: counter overflow")
+	require.NoError(cx.t, err)
+	proc.Wait()
+
+	cmdArgs := append(cx.PrefixArgs(), "counter", "get", "/", "--prefix")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap,
+		expect.ExpectedResponse{Value: "/bytes"}, expect.ExpectedResponse{Value: "512"},
+		expect.ExpectedResponse{Value: "/hits"}, expect.ExpectedResponse{Value: "2"}))
+
+	// the counters are not keys
+	require.NoError(cx.t, ctlV3Count(cx, "/", 0))
+}
+
+func ctlV3CounterAdd(cx ctlCtx, args []string, expected ...string) error {
+	cmdArgs := append(append(cx.PrefixArgs(), "counter", "add"), args...)
+	var lines []expect.ExpectedResponse
+	for _, e := range expected {
+		lines = append(lines, expect.ExpectedResponse{Value: e})
+	}
+	return e2e.SpawnWithExpects(cmdArgs, cx.envMap, lines...)
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestCtlV3DelUndo(t *testing.T)         { testCtl(t, delUndoTest) }
+func TestCtlV3DelInteractive(t *testing.T)  { testCtl(t, delInteractiveTest) }
+func TestCtlV3LeaseRevokeUndo(t *testing.T) { testCtl(t, leaseRevokeUndoTest) }
+
+func delUndoTest(cx ctlCtx) {
+	for _, kv := range []kv{{"foo1", "bar1"}, {"foo2", "bar2"}, {"foo3", "bar3"}} {
+		require.NoError(cx.t, ctlV3Put(cx, kv.key, kv.val, ""))
+	}
+	bundle := filepath.Join(cx.t.TempDir(), "undo.json")
+	require.NoError(cx.t, ctlV3Del(cx, []string{"foo", "--prefix", "--undo-file", bundle}, 3))
+
+	// keys created again are not overwritten
+	require.NoError(cx.t, ctlV3Put(cx, "foo2", "new", ""))
+	require.NoError(cx.t, ctlV3Undo(cx, bundle, "restored 0 leases and 2 keys, skipped 1 existing keys"))
+	require.NoError(cx.t, ctlV3Get(cx, []string{"foo", "--prefix"}, kv{"foo1", "bar1"}, kv{"foo2", "new"}, kv{"foo3", "bar3"}))
+
+	// an existing undo file is not overwritten
+	proc, err := e2e.SpawnCmd(append(cx.PrefixArgs(), "del", "foo1", "--undo-file", bundle), cx.envMap)
+	require.NoError(cx.t, err)
+	defer proc.Close()
+	_, err = proc.Expect("failed to create undo file")
+	require.NoError(cx.t, err)
+	proc.Wait()
+	require.NoError(cx.t, ctlV3Get(cx, []string{"foo1"}, kv{"foo1", "bar1"}))
+}
+
+func delInteractiveTest(cx ctlCtx) {
+	for _, kv := range []kv{{"foo1", "bar1"}, {"foo2", "bar2"}} {
+		require.NoError(cx.t, ctlV3Put(cx, kv.key, kv.val, ""))
+	}
+	cmdArgs := append(cx.PrefixArgs(), "del", "foo", "--prefix", "--interactive")
+	require.NoError(cx.t, spawnWithInput(cx, cmdArgs, "n", "canceled"))
+	require.NoError(cx.t, ctlV3Get(cx, []string{"foo", "--prefix"}, kv{"foo1", "bar1"}, kv{"foo2", "bar2"}))
+
+	require.NoError(cx.t, spawnWithInput(cx, cmdArgs, "y", `Delete 2 key(s) with prefix "foo"?`))
+	require.NoError(cx.t, ctlV3Count(cx, "foo", 0))
+}
+
+func leaseRevokeUndoTest(cx ctlCtx) {
+	leaseID, err := ctlV3LeaseGrant(cx, 100)
+	require.NoError(cx.t, err)
+	require.NoError(cx.t, ctlV3Put(cx, "key", "val", leaseID))
+
+	bundle := filepath.Join(cx.t.TempDir(), "undo.json")
+	cmdArgs := append(cx.PrefixArgs(), "lease", "revoke", leaseID, "--undo-file", bundle)
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: "revoked"}))
+	require.NoError(cx.t, ctlV3Count(cx, "key", 0))
+
+	require.NoError(cx.t, ctlV3Undo(cx, bundle, "restored 1 leases and 1 keys, skipped 0 existing keys"))
+	require.NoError(cx.t, ctlV3Get(cx, []string{"key"}, kv{"key", "val"}))
+	cmdArgs = append(cx.PrefixArgs(), "lease", "timetolive", leaseID, "--keys")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: "attached keys([key])"}))
+}
+
+func ctlV3Count(cx ctlCtx, prefix string, count int) error {
+	cmdArgs := append(cx.PrefixArgs(), "get", prefix, "--prefix", "--count-only", "--write-out=fields")
+	return e2e.SpawnWithExpects(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: fmt.Sprintf("\"Count\" : %d", count)})
+}
+
+func ctlV3Undo(cx ctlCtx, bundle, expected string) error {
+	cmdArgs := append(cx.PrefixArgs(), "undo", bundle)
+	return e2e.SpawnWithExpects(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: expected})
+}
+
+// spawnWithInput runs the command, sending the input line to it.
+func spawnWithInput(cx ctlCtx, cmdArgs []string, input, expected string) error {
+	proc, err := e2e.SpawnCmd(cmdArgs, cx.envMap)
+	if err != nil {
+		return err
+	}
+	defer proc.Close()
+	if err = proc.Send(input + "\r"); err != nil {
+		return err
+	}
+	_, err = proc.Expect(expected)
+	if err != nil {
+		return err
+	}
+	proc.Wait()
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/client/rest/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestRESTClient(t *testing.T) {
+	e2e.BeforeTest(t)
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	epc, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithClusterSize(1))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, epc.Close())
+	}()
+	createUsers(ctx, t, epc.Etcdctl())
+	require.NoError(t, epc.Etcdctl().AuthEnable(ctx))
+
+	cli, err := rest.New(rest.Config{Endpoints: epc.EndpointsHTTP(), Username: "test", Password: "testPassword"})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	pr, err := cli.Put(ctx, &rest.PutRequest{Key: []byte("/test/a"), Value: []byte("1")})
+	require.NoError(t, err)
+	rev := pr.Header.Revision
+
+	wctx, wcancel := context.WithCancel(ctx)
+	defer wcancel()
+	wch := cli.Watch(wctx, &rest.WatchRequest{Key: []byte("/test/"), RangeEnd: rest.PrefixRangeEnd([]byte("/test/")), StartRevision: rev, PrevKv: true})
+
+	tr, err := cli.Txn(ctx, &rest.TxnRequest{
+		Compare: []rest.Compare{{Key: []byte("/test/b"), Target: rest.CompareVersion, Result: rest.CompareEqual}},
+		Success: []rest.RequestOp{{RequestPut: &rest.PutRequest{Key: []byte("/test/b"), Value: []byte("2")}}},
+		Failure: []rest.RequestOp{{RequestRange: &rest.RangeRequest{Key: []byte("/test/b")}}},
+	})
+	require.NoError(t, err)
+	require.True(t, tr.Succeeded)
+	require.NotNil(t, tr.Responses[0].ResponsePut)
+
+	rr, err := cli.Range(ctx, &rest.RangeRequest{Key: []byte("/test/"), RangeEnd: rest.PrefixRangeEnd([]byte("/test/")), SortOrder: rest.SortDescend, SortTarget: rest.SortByKey})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), rr.Count)
+	require.Equal(t, "/test/b", string(rr.Kvs[0].Key))
+	require.Equal(t, "2", string(rr.Kvs[0].Value))
+	require.Equal(t, rev+1, rr.Kvs[0].CreateRevision)
+
+	dr, err := cli.DeleteRange(ctx, &rest.DeleteRangeRequest{Key: []byte("/test/a"), PrevKv: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), dr.Deleted)
+	require.Equal(t, "1", string(dr.PrevKvs[0].Value))
+
+	var events []*rest.Event
+	for len(events) < 3 {
+		wr := <-wch
+		require.NoError(t, wr.Err())
+		events = append(events, wr.Events...)
+	}
+	require.Equal(t, rest.EventTypePut, events[0].Type)
+	require.Equal(t, "/test/a", string(events[0].Kv.Key))
+	require.Equal(t, rest.EventTypePut, events[1].Type)
+	require.Equal(t, "/test/b", string(events[1].Kv.Key))
+	require.Equal(t, rest.EventTypeDelete, events[2].Type)
+	require.Equal(t, "1", string(events[2].PrevKv.Value))
+
+	_, err = cli.Put(ctx, &rest.PutRequest{Key: []byte("/other"), Value: []byte("1")})
+	var rerr *rest.Error
+	require.ErrorAs(t, err, &rerr)
+	require.Equal(t, "etcdserver: permission denied", rerr.Message)
+}
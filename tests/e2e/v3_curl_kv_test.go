@@ -16,6 +16,7 @@ package e2e
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	protov1 "github.com/golang/protobuf/proto"
@@ -36,6 +37,10 @@ func TestCurlV3KVTxn(t *testing.T) {
 	testCurlV3KV(t, testCurlV3KVTxn)
 }
 
+func TestCurlV3KVBatch(t *testing.T) {
+	testCurlV3KV(t, testCurlV3KVBatch)
+}
+
 func TestCurlV3KVCompact(t *testing.T) {
 	testCurlV3KV(t, testCurlV3KVCompact)
 }
@@ -197,6 +202,69 @@ func mustExecuteTxn(cx ctlCtx, reqData string) (bool, []any) {
 	return succeeded.(bool), responses.([]any)
 }
 
+func testCurlV3KVBatch(cx ctlCtx) {
+	m := gw.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: false,
+		},
+	}
+	var ops []json.RawMessage
+	for i := 0; i < 5; i++ {
+		op := &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{
+			Key:   []byte(fmt.Sprintf("batch%d", i)),
+			Value: []byte("v"),
+		}}}
+		dat, err := m.Marshal(protov1.MessageV2(op))
+		require.NoError(cx.t, err)
+		ops = append(ops, dat)
+	}
+	resp := mustExecuteBatch(cx, map[string]any{"ops": ops, "chunk_size": 2})
+	require.InDelta(cx.t, 5, resp["succeeded"], 0)
+	require.InDelta(cx.t, 0, resp["failed"], 0)
+	require.Len(cx.t, resp["chunks"], 3)
+
+	// a put attached to a missing lease fails its chunk and the rest is skipped
+	badOp, err := m.Marshal(protov1.MessageV2(&pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: &pb.PutRequest{
+		Key:   []byte("batch-bad"),
+		Value: []byte("v"),
+		Lease: 12345,
+	}}}))
+	require.NoError(cx.t, err)
+	resp = mustExecuteBatch(cx, map[string]any{"ops": []json.RawMessage{ops[0], badOp, ops[1], ops[2]}, "chunk_size": 2})
+	require.InDelta(cx.t, 0, resp["succeeded"], 0)
+	require.InDelta(cx.t, 2, resp["failed"], 0)
+	require.InDelta(cx.t, 2, resp["skipped"], 0)
+	chunks := resp["chunks"].([]any)
+	require.Len(cx.t, chunks, 1)
+	require.Contains(cx.t, chunks[0].(map[string]any)["error"].(map[string]any)["message"], "requested lease not found")
+
+	resp = mustExecuteBatch(cx, map[string]any{"ops": []json.RawMessage{badOp, ops[0]}, "chunk_size": 1, "continue_on_error": true})
+	require.InDelta(cx.t, 1, resp["succeeded"], 0)
+	require.InDelta(cx.t, 1, resp["failed"], 0)
+	require.Len(cx.t, resp["chunks"], 2)
+
+	err = e2e.CURLPost(cx.epc, e2e.CURLReq{
+		Endpoint: "/v3/kv/batch",
+		Value:    `{"ops":[{"request_range":{"key":"Zm9v"}}]}`,
+		Expected: expect.ExpectedResponse{Value: "only request_put and request_delete_range are allowed"},
+	})
+	require.NoErrorf(cx.t, err, "testCurlV3KVBatch with range op failed")
+}
+
+func mustExecuteBatch(cx ctlCtx, req map[string]any) map[string]any {
+	reqData, err := json.Marshal(req)
+	require.NoError(cx.t, err)
+	clus := cx.epc
+	args := e2e.CURLPrefixArgsCluster(clus.Cfg, clus.Procs[0], "POST", e2e.CURLReq{
+		Endpoint: "/v3/kv/batch",
+		Value:    string(reqData),
+	})
+	resp, err := runCommandAndReadJSONOutput(args)
+	require.NoError(cx.t, err)
+	return resp
+}
+
 func testCurlV3KVCompact(cx ctlCtx) {
 	compactRequest, err := json.Marshal(&pb.CompactionRequest{
 		Revision: 10000,
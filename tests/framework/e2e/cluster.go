@@ -122,6 +122,26 @@ func NewConfigJWT() *EtcdProcessClusterConfig {
 	)
 }
 
+// NamedConfig is a cluster configuration of a test matrix.
+type NamedConfig struct {
+	Name   string
+	Config *EtcdProcessClusterConfig
+}
+
+// TLSMatrix returns the cluster configurations of the combinations of client
+// and peer TLS supported by etcd, to run a test against each of them.
+func TLSMatrix() []NamedConfig {
+	return []NamedConfig{
+		{Name: "NoTLS", Config: NewConfigNoTLS()},
+		{Name: "PeerTLS", Config: NewConfigPeerTLS()},
+		{Name: "PeerAutoTLS", Config: NewConfigAutoTLS()},
+		{Name: "ClientTLS", Config: NewConfigClientTLS()},
+		{Name: "ClientAutoTLS", Config: NewConfigClientAutoTLS()},
+		{Name: "ClientTLSCertAuth", Config: NewConfigClientTLSCertAuth()},
+		{Name: "TLS", Config: NewConfigTLS()},
+	}
+}
+
 func ConfigStandalone(cfg EtcdProcessClusterConfig) *EtcdProcessClusterConfig {
 	ret := cfg
 	ret.ClusterSize = 1
@@ -306,6 +326,10 @@ func WithCorruptCheckTime(time time.Duration) EPClusterOption {
 	return func(c *EtcdProcessClusterConfig) { c.ServerConfig.CorruptCheckTime = time }
 }
 
+func WithCorruptCheckHashCache(enabled bool) EPClusterOption {
+	return func(c *EtcdProcessClusterConfig) { c.ServerConfig.CorruptCheckHashCache = enabled }
+}
+
 func WithInitialClusterToken(token string) EPClusterOption {
 	return func(c *EtcdProcessClusterConfig) { c.ServerConfig.InitialClusterToken = token }
 }
@@ -0,0 +1,63 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package e2e runs clusters of etcd processes for end-to-end tests.
+
+The clusters run the etcd binaries as child processes, so they behave like
+production members: they can be killed, restarted, upgraded from the last
+release, served over TLS, and have failpoints injected when the binaries are
+built with gofail. The package is used by the etcd tests and can be imported
+by other projects, such as operators and backup tools, to test against real
+clusters:
+
+	import "go.etcd.io/etcd/tests/v3/framework/e2e"
+
+	func TestMain(m *testing.M) {
+		e2e.Init(e2e.Paths{BinDir: "/usr/local/bin", CertDir: "testdata/certs"})
+		os.Exit(m.Run())
+	}
+
+	func TestBackup(t *testing.T) {
+		e2e.BeforeTest(t)
+		for _, tc := range e2e.TLSMatrix() {
+			t.Run(tc.Name, func(t *testing.T) {
+				clus, err := e2e.NewEtcdProcessCluster(t.Context(), t,
+					e2e.WithConfig(tc.Config),
+					e2e.WithClusterSize(3),
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer clus.Close()
+
+				if err = clus.Etcdctl().Put(t.Context(), "foo", "bar", config.PutOptions{}); err != nil {
+					t.Fatal(err)
+				}
+				// test the project against clus.EndpointsGRPC()...
+			})
+		}
+	}
+
+Init locates the binaries and the certificates: the etcd, etcdctl and etcdutl
+binaries of the release under test, the etcd binary of the last release for
+the mixed version clusters, and the certificates for the TLS clusters, named
+like the ones of the tests/fixtures directory of the etcd repository.
+Failpoints are enabled with WithGoFailEnabled and set through the Failpoints
+of the processes, which requires binaries built with "make gofail-enable".
+
+BeforeTest skips the tests in short mode, and checks that they do not leak
+goroutines.
+*/
+package e2e
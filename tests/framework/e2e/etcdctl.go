@@ -289,6 +289,11 @@ func addTxnResponse(resp *clientv3.TxnResponse, jsonData string) {
 				Response: &etcdserverpb.ResponseOp_ResponseTxn{},
 			})
 		}
+		if t == "response_append" {
+			resp.Responses = append(resp.Responses, &etcdserverpb.ResponseOp{
+				Response: &etcdserverpb.ResponseOp_ResponseAppend{},
+			})
+		}
 	}
 }
 
@@ -17,12 +17,15 @@ package e2e
 import (
 	"flag"
 	"os"
+	"path/filepath"
 	"runtime"
 
 	"go.etcd.io/etcd/tests/v3/framework/testutils"
 )
 
 var (
+	// CertDir is the directory of the certificates used by the TLS
+	// clusters. The certificate paths below are derived from it by Init.
 	CertDir string
 
 	CertPath       string
@@ -39,11 +42,16 @@ var (
 	RevokedCertPath       string
 	RevokedPrivateKeyPath string
 
-	BinPath     binPath
+	// BinPath holds the paths of the binaries run by the clusters.
+	BinPath BinPaths
+	// FixturesDir is the directory of the certificates and keys used by
+	// the JWT tokens and the proxies. It defaults to the fixtures directory
+	// of the etcd tests.
 	FixturesDir = testutils.MustAbsPath("../fixtures")
 )
 
-type binPath struct {
+// BinPaths holds the paths of the binaries run by the clusters.
+type BinPaths struct {
 	Etcd            string
 	EtcdLastRelease string
 	Etcdctl         string
@@ -51,7 +59,7 @@ type binPath struct {
 	LazyFS          string
 }
 
-func (bp *binPath) LazyFSAvailable() bool {
+func (bp *BinPaths) LazyFSAvailable() bool {
 	_, err := os.Stat(bp.LazyFS)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -62,27 +70,42 @@ func (bp *binPath) LazyFSAvailable() bool {
 	return true
 }
 
-func InitFlags() {
-	os.Setenv("ETCD_UNSUPPORTED_ARCH", runtime.GOARCH)
-
-	binDirDef := testutils.MustAbsPath("../../bin")
-	certDirDef := FixturesDir
-
-	binDir := flag.String("bin-dir", binDirDef, "The directory for store etcd and etcdctl binaries.")
-	binLastRelease := flag.String("bin-last-release", "", "The path for the last release etcd binary.")
+// Paths locates the binaries and certificates used by the clusters.
+type Paths struct {
+	// BinDir is the directory of the etcd, etcd-last-release, etcdctl,
+	// etcdutl and lazyfs binaries. Only the binaries used by a test need
+	// to exist.
+	BinDir string
+	// BinLastRelease is the path of the etcd binary of the last release, run
+	// by the mixed version clusters. It defaults to BinDir/etcd-last-release.
+	BinLastRelease string
+	// CertDir is the directory of the certificates used by the TLS clusters,
+	// named like the ones of the fixtures directory of the etcd tests. It
+	// defaults to FixturesDir.
+	CertDir string
+}
 
-	flag.StringVar(&CertDir, "cert-dir", certDirDef, "The directory for store certificate files.")
-	flag.Parse()
+// Init sets the paths of the binaries and certificates used by the
+// clusters. It must be called before creating a cluster, typically from
+// TestMain. Projects importing the package use it instead of InitFlags,
+// which reads the paths from the flags of the etcd tests.
+func Init(p Paths) {
+	os.Setenv("ETCD_UNSUPPORTED_ARCH", runtime.GOARCH)
 
-	BinPath = binPath{
-		Etcd:            *binDir + "/etcd",
-		EtcdLastRelease: *binDir + "/etcd-last-release",
-		Etcdctl:         *binDir + "/etcdctl",
-		Etcdutl:         *binDir + "/etcdutl",
-		LazyFS:          *binDir + "/lazyfs",
+	BinPath = BinPaths{
+		Etcd:            filepath.Join(p.BinDir, "etcd"),
+		EtcdLastRelease: filepath.Join(p.BinDir, "etcd-last-release"),
+		Etcdctl:         filepath.Join(p.BinDir, "etcdctl"),
+		Etcdutl:         filepath.Join(p.BinDir, "etcdutl"),
+		LazyFS:          filepath.Join(p.BinDir, "lazyfs"),
 	}
-	if *binLastRelease != "" {
-		BinPath.EtcdLastRelease = *binLastRelease
+	if p.BinLastRelease != "" {
+		BinPath.EtcdLastRelease = p.BinLastRelease
+	}
+
+	CertDir = p.CertDir
+	if CertDir == "" {
+		CertDir = FixturesDir
 	}
 	CertPath = CertDir + "/server.crt"
 	PrivateKeyPath = CertDir + "/server.key.insecure"
@@ -97,3 +120,18 @@ func InitFlags() {
 	CertPath3 = CertDir + "/server3.crt"
 	PrivateKeyPath3 = CertDir + "/server3.key.insecure"
 }
+
+// InitFlags parses the -bin-dir, -bin-last-release and -cert-dir flags and
+// sets the paths of the binaries and certificates from them.
+func InitFlags() {
+	binDir := flag.String("bin-dir", testutils.MustAbsPath("../../bin"), "The directory for store etcd and etcdctl binaries.")
+	binLastRelease := flag.String("bin-last-release", "", "The path for the last release etcd binary.")
+	certDir := flag.String("cert-dir", FixturesDir, "The directory for store certificate files.")
+	flag.Parse()
+
+	Init(Paths{
+		BinDir:         *binDir,
+		BinLastRelease: *binLastRelease,
+		CertDir:        *certDir,
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInit(t *testing.T) {
+	t.Setenv("ETCD_UNSUPPORTED_ARCH", "")
+	certs := []*string{&CertDir, &CertPath, &PrivateKeyPath, &CaPath, &CertPath2, &PrivateKeyPath2, &CertPath3, &PrivateKeyPath3, &CrlPath, &RevokedCertPath, &RevokedPrivateKeyPath}
+	saved := make([]string, len(certs))
+	for i, c := range certs {
+		saved[i] = *c
+	}
+	defer func(bp BinPaths) {
+		BinPath = bp
+		for i, c := range certs {
+			*c = saved[i]
+		}
+	}(BinPath)
+
+	Init(Paths{BinDir: "/opt/etcd/bin", CertDir: "/opt/etcd/certs"})
+	assert.Equal(t, BinPaths{
+		Etcd:            "/opt/etcd/bin/etcd",
+		EtcdLastRelease: "/opt/etcd/bin/etcd-last-release",
+		Etcdctl:         "/opt/etcd/bin/etcdctl",
+		Etcdutl:         "/opt/etcd/bin/etcdutl",
+		LazyFS:          "/opt/etcd/bin/lazyfs",
+	}, BinPath)
+	assert.Equal(t, "/opt/etcd/certs/server.crt", CertPath)
+	assert.Equal(t, "/opt/etcd/certs/ca.crt", CaPath)
+
+	Init(Paths{BinDir: "/opt/etcd/bin", BinLastRelease: "/opt/etcd-3.6/bin/etcd"})
+	assert.Equal(t, "/opt/etcd-3.6/bin/etcd", BinPath.EtcdLastRelease)
+	assert.Equal(t, FixturesDir, CertDir)
+	assert.Equal(t, FixturesDir+"/server.crt", CertPath)
+}
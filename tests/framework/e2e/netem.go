@@ -0,0 +1,137 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// netemDevice is the interface carrying traffic between processes of an e2e cluster.
+const netemDevice = "lo"
+
+var (
+	netemAvailableOnce sync.Once
+	netemAvailable     bool
+)
+
+// NetemConfig describes the impairments applied by tc netem.
+type NetemConfig struct {
+	// Latency is added to every packet.
+	Latency time.Duration
+	// Jitter randomizes Latency by up to the given value.
+	Jitter time.Duration
+	// LossPercent is the probability of a packet being dropped, from 0 to 100.
+	LossPercent float64
+}
+
+func (c NetemConfig) args() []string {
+	var args []string
+	if c.Latency > 0 {
+		args = append(args, "delay", netemDuration(c.Latency))
+		if c.Jitter > 0 {
+			args = append(args, netemDuration(c.Jitter))
+		}
+	}
+	if c.LossPercent > 0 {
+		args = append(args, "loss", strconv.FormatFloat(c.LossPercent, 'f', -1, 64)+"%")
+	}
+	return args
+}
+
+func netemDuration(d time.Duration) string {
+	return fmt.Sprintf("%dus", d.Microseconds())
+}
+
+// NetemAvailable returns whether traffic on the loopback interface can be
+// impaired with tc netem. It requires the tc binary, root privileges and
+// kernel support for the prio and netem queueing disciplines.
+func NetemAvailable() bool {
+	netemAvailableOnce.Do(func() {
+		if os.Geteuid() != 0 {
+			return
+		}
+		if _, err := exec.LookPath("tc"); err != nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := addNetemQdisc(ctx, NetemConfig{}); err != nil {
+			return
+		}
+		netemAvailable = deleteNetemQdisc(ctx) == nil
+	})
+	return netemAvailable
+}
+
+// ApplyNetem impairs loopback traffic sent to or from the given TCP port
+// until the returned function is called. Traffic on other ports is not
+// affected. Only one impairment can be applied at a time.
+func ApplyNetem(ctx context.Context, port int, cfg NetemConfig) (remove func(context.Context) error, err error) {
+	if err = addNetemQdisc(ctx, cfg); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			deleteNetemQdisc(ctx)
+		}
+	}()
+	// Members are reached through localhost, which can resolve to either IPv4 or IPv6.
+	for _, family := range []struct{ protocol, selector string }{{"ip", "ip"}, {"ipv6", "ip6"}} {
+		for _, direction := range []string{"sport", "dport"} {
+			err = runTC(ctx, "filter", "add", "dev", netemDevice, "parent", "1:0", "protocol", family.protocol, "prio", "1",
+				"u32", "match", family.selector, direction, strconv.Itoa(port), "0xffff", "flowid", "1:4")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return deleteNetemQdisc, nil
+}
+
+// addNetemQdisc replaces the root queueing discipline with a prio one that
+// sends all traffic to its first band, unless a filter redirects it to the
+// last band impaired by netem.
+func addNetemQdisc(ctx context.Context, cfg NetemConfig) error {
+	err := runTC(ctx, "qdisc", "add", "dev", netemDevice, "root", "handle", "1:", "prio", "bands", "4",
+		"priomap", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0", "0")
+	if err != nil {
+		return err
+	}
+	err = runTC(ctx, append([]string{"qdisc", "add", "dev", netemDevice, "parent", "1:4", "handle", "40:", "netem"}, cfg.args()...)...)
+	if err != nil {
+		deleteNetemQdisc(ctx)
+		return err
+	}
+	return nil
+}
+
+func deleteNetemQdisc(ctx context.Context) error {
+	return runTC(ctx, "qdisc", "del", "dev", netemDevice, "root")
+}
+
+func runTC(ctx context.Context, args ...string) error {
+	out, err := exec.CommandContext(ctx, "tc", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("tc %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
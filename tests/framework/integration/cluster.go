@@ -171,10 +171,25 @@ type ClusterConfig struct {
 	LeaseCheckpointPersist  bool
 
 	WatchProgressNotifyInterval time.Duration
+	WatchSendBatchInterval      time.Duration
+	SerializableReadCacheBytes  int
+	HotKeysTopK                 int
+	NewerRequestFields          string
+	PasswordMinLength           int
+	PasswordMaxAge              time.Duration
+	CompactionMaxHold           time.Duration
+	MemorySoftLimit             int64
+	WALDiskFreeThreshold        uint64
+	BackendCheckpointRetention  int
+	LeaseReads                  bool
+	GateStaleReads              bool
 	MaxLearners                 int
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
 	Metrics                     string
+
+	GRPCResponseCompressors         []string
+	GRPCResponseCompressionMinBytes int
 }
 
 type Cluster struct {
@@ -284,10 +299,25 @@ func (c *Cluster) MustNewMember(t testutil.TB) *Member {
 			LeaseCheckpointInterval:     c.Cfg.LeaseCheckpointInterval,
 			LeaseCheckpointPersist:      c.Cfg.LeaseCheckpointPersist,
 			WatchProgressNotifyInterval: c.Cfg.WatchProgressNotifyInterval,
+			WatchSendBatchInterval:      c.Cfg.WatchSendBatchInterval,
+			SerializableReadCacheBytes:  c.Cfg.SerializableReadCacheBytes,
+			HotKeysTopK:                 c.Cfg.HotKeysTopK,
+			NewerRequestFields:          c.Cfg.NewerRequestFields,
+			PasswordMinLength:           c.Cfg.PasswordMinLength,
+			PasswordMaxAge:              c.Cfg.PasswordMaxAge,
+			CompactionMaxHold:           c.Cfg.CompactionMaxHold,
+			MemorySoftLimit:             c.Cfg.MemorySoftLimit,
+			WALDiskFreeThreshold:        c.Cfg.WALDiskFreeThreshold,
+			BackendCheckpointRetention:  c.Cfg.BackendCheckpointRetention,
+			LeaseReads:                  c.Cfg.LeaseReads,
+			GateStaleReads:              c.Cfg.GateStaleReads,
 			MaxLearners:                 c.Cfg.MaxLearners,
 			DisableStrictReconfigCheck:  c.Cfg.DisableStrictReconfigCheck,
 			CorruptCheckTime:            c.Cfg.CorruptCheckTime,
 			Metrics:                     c.Cfg.Metrics,
+
+			GRPCResponseCompressors:         c.Cfg.GRPCResponseCompressors,
+			GRPCResponseCompressionMinBytes: c.Cfg.GRPCResponseCompressionMinBytes,
 		})
 	return m
 }
@@ -609,10 +639,25 @@ type MemberConfig struct {
 	LeaseCheckpointInterval     time.Duration
 	LeaseCheckpointPersist      bool
 	WatchProgressNotifyInterval time.Duration
+	WatchSendBatchInterval      time.Duration
+	SerializableReadCacheBytes  int
+	HotKeysTopK                 int
+	NewerRequestFields          string
+	PasswordMinLength           int
+	PasswordMaxAge              time.Duration
+	CompactionMaxHold           time.Duration
+	MemorySoftLimit             int64
+	WALDiskFreeThreshold        uint64
+	BackendCheckpointRetention  int
+	LeaseReads                  bool
+	GateStaleReads              bool
 	MaxLearners                 int
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
 	Metrics                     string
+
+	GRPCResponseCompressors         []string
+	GRPCResponseCompressionMinBytes int
 }
 
 // MustNewMember return an inited member with the given name. If peerTLS is
@@ -664,6 +709,7 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 	m.InitialElectionTickAdvance = true
 	m.TickMs = uint(framecfg.TickDuration / time.Millisecond)
 	m.PreVote = true
+	m.CheckQuorum = true
 	m.QuotaBackendBytes = mcfg.QuotaBackendBytes
 	m.BackendBatchInterval = mcfg.BackendBatchInterval
 	m.MaxTxnOps = mcfg.MaxTxnOps
@@ -714,6 +760,23 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 	m.LeaseCheckpointInterval = mcfg.LeaseCheckpointInterval
 
 	m.WatchProgressNotifyInterval = mcfg.WatchProgressNotifyInterval
+	m.WatchSendBatchInterval = mcfg.WatchSendBatchInterval
+	m.SerializableReadCacheBytes = mcfg.SerializableReadCacheBytes
+	m.SerializableReadCacheTTL = embed.DefaultSerializableReadCacheTTL
+	m.HotKeysTopK = mcfg.HotKeysTopK
+	// sample every request so that the tracked usage is exact
+	m.HotKeysSampleRate = 1
+	m.NewerRequestFields = mcfg.NewerRequestFields
+	m.PasswordMinLength = mcfg.PasswordMinLength
+	m.PasswordMaxAge = mcfg.PasswordMaxAge
+	m.CompactionMaxHold = mcfg.CompactionMaxHold
+	m.MemorySoftLimit = mcfg.MemorySoftLimit
+	m.WALDiskFreeThreshold = mcfg.WALDiskFreeThreshold
+	m.BackendCheckpointRetention = mcfg.BackendCheckpointRetention
+	m.LeaseReads = mcfg.LeaseReads
+	m.GateStaleReads = mcfg.GateStaleReads
+	m.GRPCResponseCompressors = mcfg.GRPCResponseCompressors
+	m.GRPCResponseCompressionMinBytes = mcfg.GRPCResponseCompressionMinBytes
 
 	m.InitialCorruptCheck = true
 	if mcfg.CorruptCheckTime > time.Duration(0) {
@@ -1564,6 +1627,8 @@ type GRPCAPI struct {
 	Lock lockpb.LockClient
 	// Election is the election API for the client'Server connection.
 	Election epb.ElectionClient
+	// Counter is the counter API for the client'Server connection.
+	Counter pb.CounterClient
 }
 
 // GetLearnerMembers returns the list of learner members in Cluster using MemberList API.
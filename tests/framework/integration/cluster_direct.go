@@ -35,6 +35,7 @@ func ToGRPC(c *clientv3.Client) GRPCAPI {
 		pb.NewAuthClient(c.ActiveConnection()),
 		v3lockpb.NewLockClient(c.ActiveConnection()),
 		v3electionpb.NewElectionClient(c.ActiveConnection()),
+		pb.NewCounterClient(c.ActiveConnection()),
 	}
 }
 
@@ -63,6 +63,7 @@ func ToGRPC(c *clientv3.Client) GRPCAPI {
 	c.KV = namespace.NewKV(c.KV, proxyNamespace)
 	c.Watcher = namespace.NewWatcher(c.Watcher, proxyNamespace)
 	c.Lease = namespace.NewLease(c.Lease, proxyNamespace)
+	c.Counter = namespace.NewCounter(c.Counter, proxyNamespace)
 	// test coalescing/caching proxy
 	kvp, kvpch := grpcproxy.NewKvProxy(c)
 	wp, wpch := grpcproxy.NewWatchProxy(ctx, lg, c)
@@ -72,6 +73,7 @@ func ToGRPC(c *clientv3.Client) GRPCAPI {
 	authp := grpcproxy.NewAuthProxy(c)
 	lockp := grpcproxy.NewLockProxy(c)
 	electp := grpcproxy.NewElectionProxy(c)
+	counterp := grpcproxy.NewCounterProxy(c)
 
 	grpc := GRPCAPI{
 		adapter.ClusterServerToClusterClient(clp),
@@ -82,6 +84,7 @@ func ToGRPC(c *clientv3.Client) GRPCAPI {
 		adapter.AuthServerToAuthClient(authp),
 		adapter.LockServerToLockClient(lockp),
 		adapter.ElectionServerToElectionClient(electp),
+		adapter.CounterServerToCounterClient(counterp),
 	}
 	proxies[c] = grpcClientProxy{ctx: ctx, ctxCancel: ctxCancel, grpc: grpc, wdonec: wpch, kvdonec: kvpch, lpdonec: lpch}
 	return grpc
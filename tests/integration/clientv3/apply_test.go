@@ -0,0 +1,124 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/apply"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestApply(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	for key, value := range map[string]string{"cfg/a": "1", "cfg/b": "2", "cfg/c": "3", "other": "x"} {
+		_, err := cli.Put(t.Context(), key, value)
+		require.NoError(t, err)
+	}
+	desired := map[string][]byte{
+		"cfg/a": []byte("1"),
+		"cfg/b": []byte("20"),
+		"cfg/d": []byte("4"),
+	}
+
+	a := apply.New(cli, "cfg/", apply.WithMaxTxnOps(2))
+	report, err := a.Apply(t.Context(), desired, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cfg/d"}, report.Created)
+	assert.Equal(t, []string{"cfg/b"}, report.Updated)
+	assert.Empty(t, report.Deleted)
+
+	report, err = a.Apply(t.Context(), desired, true)
+	require.NoError(t, err)
+	assert.Empty(t, report.Created)
+	assert.Empty(t, report.Updated)
+	assert.Equal(t, []string{"cfg/c"}, report.Deleted)
+
+	resp, err := cli.Get(t.Context(), "", clientv3.WithPrefix())
+	require.NoError(t, err)
+	got := map[string]string{}
+	for _, kv := range resp.Kvs {
+		got[string(kv.Key)] = string(kv.Value)
+	}
+	assert.Equal(t, map[string]string{"cfg/a": "1", "cfg/b": "20", "cfg/d": "4", "other": "x"}, got)
+
+	// applying the same state again changes nothing
+	report, err = a.Apply(t.Context(), desired, true)
+	require.NoError(t, err)
+	assert.False(t, report.Changed())
+	assert.Equal(t, resp.Header.Revision, report.Revision)
+
+	_, err = a.Apply(t.Context(), map[string][]byte{"other": []byte("y")}, false)
+	require.ErrorIs(t, err, apply.ErrKeyOutsidePrefix)
+}
+
+// racingKV calls race before each transaction, to change keys concurrently.
+type racingKV struct {
+	clientv3.KV
+	race func()
+}
+
+func (kv *racingKV) Txn(ctx context.Context) clientv3.Txn {
+	kv.race()
+	return kv.KV.Txn(ctx)
+}
+
+func TestApplyConflict(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	_, err := cli.Put(t.Context(), "cfg/a", "1")
+	require.NoError(t, err)
+
+	var once sync.Once
+	kv := &racingKV{KV: cli, race: func() {
+		once.Do(func() {
+			_, err := cli.Put(t.Context(), "cfg/a", "concurrent")
+			require.NoError(t, err)
+		})
+	}}
+	report, err := apply.New(kv, "cfg/").Apply(t.Context(), map[string][]byte{"cfg/a": []byte("2")}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Conflicts)
+	assert.Equal(t, []string{"cfg/a"}, report.Updated)
+
+	resp, err := cli.Get(t.Context(), "cfg/a")
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(resp.Kvs[0].Value))
+	assert.Equal(t, resp.Header.Revision, report.Revision)
+
+	kv = &racingKV{KV: cli, race: func() {
+		_, err := cli.Put(t.Context(), "cfg/a", "concurrent")
+		require.NoError(t, err)
+	}}
+	report, err = apply.New(kv, "cfg/", apply.WithMaxRetries(2)).Apply(t.Context(), map[string][]byte{"cfg/a": []byte("3")}, false)
+	require.ErrorIs(t, err, apply.ErrTooManyConflicts)
+	assert.Equal(t, 3, report.Conflicts)
+	assert.False(t, report.Changed())
+}
@@ -16,6 +16,7 @@ package clientv3test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -25,7 +26,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/client/pkg/v3/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
 )
 
@@ -90,6 +95,43 @@ func TestMemberAddWithExistingURLs(t *testing.T) {
 	}
 }
 
+func TestMemberAddDryRun(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, DisableStrictReconfigCheck: true})
+	defer clus.Terminate(t)
+
+	capi := clus.RandClient()
+
+	urls := []string{"http://127.0.0.1:1234"}
+	resp, err := capi.MemberAddDryRun(t.Context(), urls, false)
+	require.NoError(t, err)
+	require.Equal(t, urls, resp.Member.PeerURLs)
+	require.Len(t, resp.Members, 4)
+	qi := resp.QuorumImpact
+	require.NotNil(t, qi)
+	require.Equal(t, [6]uint32{3, 4, 2, 3, 1, 1}, [6]uint32{qi.VotingMembersBefore, qi.VotingMembersAfter, qi.QuorumBefore, qi.QuorumAfter, qi.FailureToleranceBefore, qi.FailureToleranceAfter})
+	require.Len(t, qi.Warnings, 2)
+	require.Contains(t, qi.Warnings[0], "even number of voting members")
+	require.Contains(t, qi.Warnings[1], "tolerates 0 failures")
+
+	resp, err = capi.MemberAddDryRun(t.Context(), urls, true)
+	require.NoError(t, err)
+	require.True(t, resp.Member.IsLearner)
+	qi = resp.QuorumImpact
+	require.Equal(t, [6]uint32{3, 3, 2, 2, 1, 1}, [6]uint32{qi.VotingMembersBefore, qi.VotingMembersAfter, qi.QuorumBefore, qi.QuorumAfter, qi.FailureToleranceBefore, qi.FailureToleranceAfter})
+	require.Empty(t, qi.Warnings)
+
+	// the dry run is validated like an actual member add
+	_, err = capi.MemberAddDryRun(t.Context(), clus.Members[0].PeerURLs.StringSlice(), false)
+	require.ErrorContains(t, err, "Peer URLs already exists")
+
+	// nothing was added
+	lresp, err := capi.MemberList(t.Context())
+	require.NoError(t, err)
+	require.Len(t, lresp.Members, 3)
+}
+
 func TestMemberRemove(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -156,6 +198,92 @@ func TestMemberUpdate(t *testing.T) {
 	}
 }
 
+func TestMemberUpdateLabels(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	capi := clus.RandClient()
+	resp, err := capi.MemberList(t.Context())
+	if err != nil {
+		t.Fatalf("failed to list member %v", err)
+	}
+	m := resp.Members[0]
+
+	labels := map[string]string{"zone": "us-east-1a", "rack": "r1"}
+	if _, err = capi.MemberUpdateLabels(t.Context(), m.ID, labels); err != nil {
+		t.Fatalf("failed to update member labels %v", err)
+	}
+	// updating the peer URLs keeps the labels
+	if _, err = capi.MemberUpdate(t.Context(), m.ID, m.PeerURLs); err != nil {
+		t.Fatalf("failed to update member %v", err)
+	}
+
+	resp, err = capi.MemberList(t.Context())
+	if err != nil {
+		t.Fatalf("failed to list member %v", err)
+	}
+	if !reflect.DeepEqual(resp.Members[0].Labels, labels) {
+		t.Errorf("labels = %v, want %v", resp.Members[0].Labels, labels)
+	}
+	if !reflect.DeepEqual(resp.Members[0].PeerURLs, m.PeerURLs) {
+		t.Errorf("urls = %v, want %v", resp.Members[0].PeerURLs, m.PeerURLs)
+	}
+
+	_, err = capi.MemberUpdateLabels(t.Context(), m.ID, map[string]string{"bad key": "v"})
+	if !errors.Is(err, rpctypes.ErrMemberBadLabels) {
+		t.Errorf("err = %v, want %v", err, rpctypes.ErrMemberBadLabels)
+	}
+}
+
+func TestMemberUpdateLeadership(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	oldLead := clus.WaitLeader(t)
+	leadID := uint64(clus.Members[oldLead].Server.MemberID())
+
+	capi := clus.RandClient()
+	if _, err := capi.MemberUpdateLeadership(t.Context(), leadID, true); err != nil {
+		t.Fatalf("failed to update member leadership %v", err)
+	}
+
+	// the disallowed leader transfers its leadership
+	newLead := oldLead
+	for i := 0; i < 50 && newLead == oldLead; i++ {
+		time.Sleep(100 * time.Millisecond)
+		newLead = clus.WaitLeader(t)
+	}
+	if newLead == oldLead {
+		t.Fatalf("leader %x kept its leadership although it is disallowed", leadID)
+	}
+
+	resp, err := capi.MemberList(t.Context())
+	if err != nil {
+		t.Fatalf("failed to list member %v", err)
+	}
+	for _, m := range resp.Members {
+		if m.LeadershipDisallowed != (m.ID == leadID) {
+			t.Errorf("member %x: leadership disallowed = %v", m.ID, m.LeadershipDisallowed)
+		}
+	}
+
+	_, err = clus.Client(newLead).MoveLeader(t.Context(), leadID)
+	if !errors.Is(err, rpctypes.ErrBadLeaderTransferee) {
+		t.Errorf("err = %v, want %v", err, rpctypes.ErrBadLeaderTransferee)
+	}
+
+	if _, err = capi.MemberUpdateLeadership(t.Context(), leadID, false); err != nil {
+		t.Fatalf("failed to update member leadership %v", err)
+	}
+	if _, err = clus.Client(newLead).MoveLeader(t.Context(), leadID); err != nil {
+		t.Errorf("failed to move leader to allowed member %v", err)
+	}
+}
+
 func TestMemberAddUpdateWrongURLs(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -293,6 +421,71 @@ func TestMemberPromote(t *testing.T) {
 	}
 }
 
+// TestMemberPromoteReplacing ensures that a standby member is promoted in place of a
+// voting member in a single configuration change.
+func TestMemberPromoteReplacing(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, DisableStrictReconfigCheck: true})
+	defer clus.Terminate(t)
+
+	// This test explicitly includes the server-side forwarding by
+	// sending the request to follower, and replaces the other follower.
+	leaderIdx := clus.WaitLeader(t)
+	followerIdx := (leaderIdx + 1) % 3
+	capi := clus.Client(followerIdx)
+	replacedID := uint64(clus.Members[(leaderIdx+2)%3].ID())
+
+	standby := clus.MustNewMember(t)
+	memberAddResp, err := capi.MemberAddAsStandby(t.Context(), standby.PeerURLs.StringSlice())
+	if err != nil {
+		t.Fatalf("failed to add member %v", err)
+	}
+	standbyID := memberAddResp.Member.ID
+
+	// a standby cannot replace itself, and only a voting member can be replaced
+	for _, id := range []uint64{standbyID, replacedID + standbyID} {
+		if _, err = capi.MemberPromoteReplacing(t.Context(), standbyID, id); err == nil {
+			t.Fatalf("expect replacing member %x to fail, got no error", id)
+		}
+	}
+
+	clus.InitializeMemberWithResponse(t, standby, memberAddResp)
+	require.NoError(t, standby.Launch())
+
+	// retry until promote succeed or timeout
+	expectedErrKeywords := "can only promote a learner member which is in sync with leader"
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case <-time.After(500 * time.Millisecond):
+		case <-timeout:
+			t.Fatalf("failed all attempts to promote standby member, last error: %v", err)
+		}
+
+		_, err = capi.MemberPromoteReplacing(t.Context(), standbyID, replacedID)
+		if err == nil {
+			break
+		}
+		if !strings.Contains(err.Error(), expectedErrKeywords) {
+			t.Fatalf("unexpected error when promoting standby member: %v", err)
+		}
+	}
+
+	resp, err := capi.MemberList(t.Context())
+	require.NoError(t, err)
+	require.Len(t, resp.Members, 3)
+	for _, m := range resp.Members {
+		require.NotEqual(t, replacedID, m.ID)
+		require.False(t, m.IsLearner)
+		require.False(t, m.IsStandby)
+	}
+
+	// raft rejects configuration changes until the joint configuration is left
+	_, err = capi.MemberAddAsLearner(t.Context(), []string{"http://127.0.0.1:1234"})
+	require.NoError(t, err)
+}
+
 // TestMemberPromoteMemberNotLearner ensures that promoting a voting member fails.
 func TestMemberPromoteMemberNotLearner(t *testing.T) {
 	integration2.BeforeTest(t, integration2.WithFailpoint("raftBeforeAdvance", `sleep(100)`))
@@ -429,3 +622,72 @@ func TestMaxLearnerInCluster(t *testing.T) {
 		t.Errorf("failed to add member %v", err)
 	}
 }
+
+func TestClusterConfigProtectedPrefix(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	_, err := cli.ClusterConfigSet(ctx, &clientv3.ClusterConfig{ProtectedPrefixes: []*pb.ProtectedPrefix{
+		{Prefix: []byte("/elect"), Election: []byte("/elect")},
+	}})
+	require.ErrorIs(t, err, rpctypes.ErrInvalidClusterConfig)
+
+	_, err = cli.ClusterConfigSet(ctx, &clientv3.ClusterConfig{ProtectedPrefixes: []*pb.ProtectedPrefix{
+		{Prefix: []byte("/state/"), Election: []byte("/elect")},
+		{Prefix: []byte("/observed/"), Election: []byte("/elect"), Writer: pb.ProtectedPrefix_FOLLOWER},
+	}})
+	require.NoError(t, err)
+	resp, err := cli.ClusterConfigGet(ctx)
+	require.NoError(t, err)
+	require.Len(t, resp.Config.ProtectedPrefixes, 2)
+
+	s1, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	defer s1.Close()
+	s2, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	defer s2.Close()
+	e1 := concurrency.NewElection(s1, "/elect")
+	require.NoError(t, e1.Campaign(ctx, "s1"))
+	// s2 joins as a follower without blocking on the campaign.
+	_, err = cli.Put(ctx, "/elect/s2", "s2", clientv3.WithLease(s2.Lease()))
+	require.NoError(t, err)
+
+	leaderCtx := clientv3.WithElectionLease(ctx, s1.Lease())
+	followerCtx := clientv3.WithElectionLease(ctx, s2.Lease())
+
+	_, err = cli.Put(ctx, "/state/a", "v")
+	require.ErrorIs(t, err, rpctypes.ErrProtectedPrefix)
+	_, err = cli.Put(followerCtx, "/state/a", "v")
+	require.ErrorIs(t, err, rpctypes.ErrProtectedPrefix)
+	_, err = cli.Put(leaderCtx, "/state/a", "v")
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "/other", "v")
+	require.NoError(t, err)
+
+	_, err = cli.Txn(followerCtx).Then(clientv3.OpPut("/other", "v"), clientv3.OpDelete("/state/a")).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrProtectedPrefix)
+	_, err = cli.Delete(leaderCtx, "/", clientv3.WithPrefix())
+	require.ErrorIs(t, err, rpctypes.ErrProtectedPrefix, "deleting /observed/ requires a follower")
+
+	_, err = cli.Put(leaderCtx, "/observed/a", "v")
+	require.ErrorIs(t, err, rpctypes.ErrProtectedPrefix)
+	_, err = cli.Put(followerCtx, "/observed/a", "v")
+	require.NoError(t, err)
+
+	require.NoError(t, e1.Resign(ctx))
+	_, err = cli.Put(leaderCtx, "/state/a", "v2")
+	require.ErrorIs(t, err, rpctypes.ErrProtectedPrefix)
+	_, err = cli.Put(followerCtx, "/state/a", "v2")
+	require.NoError(t, err)
+
+	_, err = cli.ClusterConfigSet(ctx, &clientv3.ClusterConfig{})
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "/state/a", "v3")
+	require.NoError(t, err)
+}
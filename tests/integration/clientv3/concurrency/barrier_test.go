@@ -0,0 +1,160 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestBarrierReleasedOnSessionExpiry(t *testing.T) {
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	s1, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	s2, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	defer s2.Close()
+
+	b1 := concurrency.NewBarrier(s1, "/barrier-expiry")
+	b2 := concurrency.NewBarrier(s2, "/barrier-expiry")
+	require.NoError(t, b1.Hold(t.Context()))
+	require.NoError(t, b1.Hold(t.Context()))
+	require.ErrorIs(t, b2.Hold(t.Context()), concurrency.ErrBarrierHeld)
+	require.ErrorIs(t, b2.Release(t.Context()), concurrency.ErrBarrierNotHeld)
+
+	waitc := make(chan error, 1)
+	go func() { waitc <- b2.Wait(t.Context()) }()
+	select {
+	case err = <-waitc:
+		t.Fatalf("wait returned before the barrier was released: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the holder disappears
+	require.NoError(t, s1.Close())
+	select {
+	case err = <-waitc:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("wait did not return after the holder's session expired")
+	}
+}
+
+func TestDoubleBarrierRecoversFromExpiredSession(t *testing.T) {
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	const key, count = "/double-barrier-expiry", 3
+	newBarrier := func() (*concurrency.Session, *concurrency.DoubleBarrier) {
+		s, serr := concurrency.NewSession(cli)
+		require.NoError(t, serr)
+		return s, concurrency.NewDoubleBarrier(s, key, count)
+	}
+
+	enter := func(b *concurrency.DoubleBarrier) chan error {
+		errc := make(chan error, 1)
+		go func() { errc <- b.Enter(t.Context()) }()
+		return errc
+	}
+
+	s1, b1 := newBarrier()
+	defer s1.Close()
+	s2, b2 := newBarrier()
+	e1, e2 := enter(b1), enter(b2)
+
+	time.Sleep(100 * time.Millisecond)
+	// the second process crashes before the barrier is ready
+	require.NoError(t, s2.Close())
+	require.ErrorIs(t, <-e2, concurrency.ErrSessionExpired)
+
+	s3, b3 := newBarrier()
+	defer s3.Close()
+	e3 := enter(b3)
+	select {
+	case err = <-e1:
+		t.Fatalf("entered before %d processes entered: %v", count, err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	s4, b4 := newBarrier()
+	defer s4.Close()
+	e4 := enter(b4)
+	for _, errc := range []chan error{e1, e3, e4} {
+		select {
+		case err = <-errc:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out entering the barrier")
+		}
+	}
+
+	leave := func(b *concurrency.DoubleBarrier) chan error {
+		errc := make(chan error, 1)
+		go func() { errc <- b.Leave(t.Context()) }()
+		return errc
+	}
+	l1, l3 := leave(b1), leave(b3)
+	select {
+	case err = <-l1:
+		t.Fatalf("left before all processes left: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	// the last process crashes instead of leaving
+	require.NoError(t, s4.Close())
+	for _, errc := range []chan error{l1, l3} {
+		select {
+		case err = <-errc:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out leaving the barrier")
+		}
+	}
+
+	resp, err := cli.Get(t.Context(), key, clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Empty(t, resp.Kvs)
+}
+
+func TestDoubleBarrierEnterCanceled(t *testing.T) {
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	s, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	defer s.Close()
+
+	b := concurrency.NewDoubleBarrier(s, "/double-barrier-cancel", 2)
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, b.Enter(ctx), context.DeadlineExceeded)
+
+	// the slot is released
+	resp, err := cli.Get(t.Context(), "/double-barrier-cancel", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Empty(t, resp.Kvs)
+	require.ErrorIs(t, b.Leave(t.Context()), concurrency.ErrBarrierNotEntered)
+}
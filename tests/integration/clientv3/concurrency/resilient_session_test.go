@@ -0,0 +1,138 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestResilientSessionRebuild(t *testing.T) {
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	rs, err := concurrency.NewResilientSession(cli, concurrency.WithTTL(5))
+	require.NoError(t, err)
+	defer rs.Close()
+
+	m := rs.NewMutex("/resilient-lock")
+	require.NoError(t, m.Lock(t.Context()))
+	e := rs.NewElection("/resilient-election")
+	require.NoError(t, e.Campaign(t.Context(), "leader-1"))
+	idle := rs.NewMutex("/resilient-idle")
+
+	first := rs.Session()
+	_, err = cli.Revoke(t.Context(), first.Lease())
+	require.NoError(t, err)
+
+	ep := waitEpoch(t, rs)
+	assert.Equal(t, 2, ep.Number)
+	assert.NotEqual(t, first.Lease(), ep.Session.Lease())
+	assert.Equal(t, rs.Session(), ep.Session)
+	assert.ElementsMatch(t, []string{"/resilient-lock", "/resilient-election"}, ep.Reacquired)
+	assert.Empty(t, ep.Lost)
+	assert.Nil(t, idle.Mutex())
+
+	// the lock and the leadership are held under the new lease
+	resp, err := cli.Get(t.Context(), m.Mutex().Key())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, int64(ep.Session.Lease()), resp.Kvs[0].Lease)
+
+	leader, err := concurrency.NewElection(ep.Session, "/resilient-election").Leader(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "leader-1", string(leader.Kvs[0].Value))
+	require.NoError(t, e.Proclaim(t.Context(), "leader-2"))
+
+	require.NoError(t, m.Unlock(t.Context()))
+	require.NoError(t, e.Resign(t.Context()))
+}
+
+func TestResilientSessionLostLock(t *testing.T) {
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	rs, err := concurrency.NewResilientSession(cli, concurrency.WithTTL(5))
+	require.NoError(t, err)
+	defer rs.Close()
+	m := rs.NewMutex("/resilient-lost-lock")
+	require.NoError(t, m.Lock(t.Context()))
+
+	// another session queues up for the lock and takes it as soon as the
+	// lease of the resilient session is gone
+	s, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	defer s.Close()
+	other := concurrency.NewMutex(s, "/resilient-lost-lock")
+	lockedc := make(chan error, 1)
+	go func() { lockedc <- other.Lock(t.Context()) }()
+	require.Eventually(t, func() bool {
+		resp, gerr := cli.Get(t.Context(), "/resilient-lost-lock/", clientv3.WithPrefix(), clientv3.WithCountOnly())
+		return gerr == nil && resp.Count == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = cli.Revoke(t.Context(), rs.Session().Lease())
+	require.NoError(t, err)
+
+	ep := waitEpoch(t, rs)
+	assert.Equal(t, 2, ep.Number)
+	assert.Empty(t, ep.Reacquired)
+	assert.Equal(t, []string{"/resilient-lost-lock"}, ep.Lost)
+	assert.Nil(t, m.Mutex())
+	require.NoError(t, <-lockedc)
+	require.NoError(t, other.Unlock(t.Context()))
+}
+
+func TestResilientSessionClose(t *testing.T) {
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	rs, err := concurrency.NewResilientSession(cli)
+	require.NoError(t, err)
+	id := rs.Session().Lease()
+	require.NoError(t, rs.Close())
+
+	select {
+	case <-rs.Done():
+	case <-time.After(time.Second):
+		t.Fatal("resilient session did not stop after close")
+	}
+	_, ok := <-rs.Epochs()
+	assert.False(t, ok)
+	resp, err := cli.TimeToLive(t.Context(), id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), resp.TTL)
+}
+
+func waitEpoch(t *testing.T, rs *concurrency.ResilientSession) concurrency.Epoch {
+	t.Helper()
+	select {
+	case ep := <-rs.Epochs():
+		return ep
+	case <-time.After(10 * time.Second):
+		t.Fatal("resilient session was not rebuilt")
+		return concurrency.Epoch{}
+	}
+}
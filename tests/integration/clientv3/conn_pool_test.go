@@ -0,0 +1,93 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestConnectionsPerEndpoint(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	tests := []struct {
+		conns              int
+		wantWatchConns     int
+		wantDedicatedLease bool
+	}{
+		{conns: 0, wantWatchConns: 1},
+		{conns: 2, wantWatchConns: 1},
+		{conns: 4, wantWatchConns: 2, wantDedicatedLease: true},
+	}
+	for _, tt := range tests {
+		cli, err := integration2.NewClient(t, clientv3.Config{
+			Endpoints:              []string{clus.Members[0].GRPCURL},
+			ConnectionsPerEndpoint: tt.conns,
+		})
+		require.NoError(t, err)
+
+		unary := cli.Connections(clientv3.StreamTypeUnary)
+		require.Equal(t, []*grpc.ClientConn{cli.ActiveConnection()}, unary)
+		watch := cli.Connections(clientv3.StreamTypeWatch)
+		require.Len(t, watch, tt.wantWatchConns)
+		assert.Equal(t, tt.conns > 1, watch[0] != unary[0], "dedicated watch connection with %d connections", tt.conns)
+		lease := cli.Connections(clientv3.StreamTypeLease)
+		require.Len(t, lease, 1)
+		assert.Equal(t, tt.wantDedicatedLease, lease[0] != unary[0], "dedicated lease connection with %d connections", tt.conns)
+
+		// the watch streams are spread over the watch connections
+		ctx := t.Context()
+		wchs := []clientv3.WatchChan{
+			cli.Watch(ctx, "foo"),
+			cli.Watch(clientv3.WithRequireLeader(ctx), "foo"),
+		}
+		_, err = cli.Put(ctx, "foo", "bar")
+		require.NoError(t, err)
+		for _, wch := range wchs {
+			select {
+			case resp := <-wch:
+				require.NoError(t, resp.Err())
+				require.Len(t, resp.Events, 1)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the watch event")
+			}
+		}
+		for _, conn := range watch {
+			assert.Equal(t, connectivity.Ready, conn.GetState())
+		}
+
+		lresp, err := cli.Grant(ctx, 10)
+		require.NoError(t, err)
+		_, err = cli.KeepAliveOnce(ctx, lresp.ID)
+		require.NoError(t, err)
+		assert.Equal(t, connectivity.Ready, lease[0].GetState())
+
+		require.NoError(t, cli.Close())
+		for _, conn := range append(watch, lease...) {
+			assert.Equal(t, connectivity.Shutdown, conn.GetState())
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"log"
 	"time"
 
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
@@ -73,6 +74,44 @@ func ExampleWatcher_watchWithPrefix() {
 	// PUT "foo1" : "bar"
 }
 
+func mockClientWatchWithRecovery() {
+	fmt.Println(`PUT "foo1" : "bar"`)
+}
+
+func ExampleClient_watchWithRecovery() {
+	forUnitTestsRunInMockedContext(mockClientWatchWithRecovery, func() {
+		cli, err := clientv3.New(clientv3.Config{
+			Endpoints:   exampleEndpoints(),
+			DialTimeout: dialTimeout,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer cli.Close()
+
+		// cache mirrors the keys under "foo", starting from a full list.
+		cache := make(map[string]string)
+		relist := func(kvs []*mvccpb.KeyValue) {
+			clear(cache)
+			for _, kv := range kvs {
+				cache[string(kv.Key)] = string(kv.Value)
+			}
+		}
+		rch := cli.WatchWithRecovery(context.Background(), "foo", relist, clientv3.WithPrefix(), clientv3.WithRev(1))
+		for wresp := range rch {
+			for _, ev := range wresp.Events {
+				if ev.Type == clientv3.EventTypeDelete {
+					delete(cache, string(ev.Kv.Key))
+				} else {
+					cache[string(ev.Kv.Key)] = string(ev.Kv.Value)
+				}
+				fmt.Printf("%s %q : %q\n", ev.Type, ev.Kv.Key, ev.Kv.Value)
+			}
+		}
+	})
+	// PUT "foo1" : "bar"
+}
+
 func mockWatcherWatchWithRange() {
 	fmt.Println(`PUT "foo1" : "bar1"`)
 	fmt.Println(`PUT "foo2" : "bar2"`)
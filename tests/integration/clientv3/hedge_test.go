@@ -0,0 +1,62 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestKVHedgedGet checks that hedged serializable reads are answered while a
+// member does not respond.
+func TestKVHedgedGet(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, UseBridge: true})
+	defer clus.Terminate(t)
+
+	_, err := clus.RandClient().Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	// linearizable reads wait for the put to be applied on every member
+	for _, m := range clus.Members {
+		_, err = m.Client.Get(t.Context(), "foo")
+		require.NoError(t, err)
+	}
+
+	cli, err := integration2.NewClient(t, clientv3.Config{
+		Endpoints: []string{clus.Members[0].GRPCURL, clus.Members[1].GRPCURL, clus.Members[2].GRPCURL},
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	clus.Members[0].Bridge().Blackhole()
+	defer clus.Members[0].Bridge().Unblackhole()
+
+	// every endpoint is sent the first request of some reads
+	for i := 0; i < 6; i++ {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		resp, err := cli.Get(ctx, "foo", clientv3.WithSerializable(), clientv3.WithHedging(50*time.Millisecond))
+		cancel()
+		require.NoError(t, err)
+		require.Len(t, resp.Kvs, 1)
+		require.Equal(t, "bar", string(resp.Kvs[0].Value))
+	}
+}
@@ -173,6 +173,108 @@ func TestKVPutWithIgnoreLease(t *testing.T) {
 	}
 }
 
+// TestKVPutWithSequenceSuffix ensures that Put with WithSequenceSuffix creates
+// a new key under the prefix for every put, ordered by revision.
+func TestKVPutWithSequenceSuffix(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+
+	var keys []string
+	for _, v := range []string{"a", "b", "c"} {
+		resp, err := kv.Put(t.Context(), "queue/", v, clientv3.WithSequenceSuffix())
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("queue/%020d", resp.Header.Revision), string(resp.Key))
+		keys = append(keys, string(resp.Key))
+	}
+
+	gresp, err := kv.Get(t.Context(), "queue/", clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	require.NoError(t, err)
+	require.Len(t, gresp.Kvs, len(keys))
+	for i, v := range []string{"a", "b", "c"} {
+		require.Equal(t, keys[i], string(gresp.Kvs[i].Key))
+		require.Equal(t, v, string(gresp.Kvs[i].Value))
+	}
+
+	tresp, err := kv.Txn(t.Context()).Then(clientv3.OpPut("queue/", "d", clientv3.WithSequenceSuffix())).Commit()
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("queue/%020d", tresp.Header.Revision), string(tresp.Responses[0].GetResponsePut().Key))
+
+	_, err = kv.Put(t.Context(), "queue/", "", clientv3.WithSequenceSuffix(), clientv3.WithIgnoreValue())
+	require.ErrorIs(t, err, rpctypes.ErrSequenceWithIgnore)
+}
+
+func TestKVPutWithReturnKV(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+
+	resp, err := kv.Put(t.Context(), "foo", "bar", clientv3.WithReturnKV())
+	require.NoError(t, err)
+	require.NotNil(t, resp.Kv)
+	gresp, err := kv.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.Equal(t, gresp.Kvs[0], resp.Kv)
+
+	// the returned mod revision guards a compare-and-swap without a Get
+	resp, err = kv.Put(t.Context(), "foo", "baz", clientv3.WithReturnKV(), clientv3.WithPrevKV())
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(resp.PrevKv.Value))
+	require.Equal(t, int64(2), resp.Kv.Version)
+	tresp, err := kv.Txn(t.Context()).
+		If(clientv3.Compare(clientv3.ModRevision("foo"), "=", resp.Kv.ModRevision)).
+		Then(clientv3.OpPut("foo", "qux", clientv3.WithReturnKV())).
+		Commit()
+	require.NoError(t, err)
+	require.True(t, tresp.Succeeded)
+	kvResp := tresp.Responses[0].GetResponsePut().Kv
+	require.Equal(t, "qux", string(kvResp.Value))
+	require.Equal(t, tresp.Header.Revision, kvResp.ModRevision)
+}
+
+func TestKVRequestTimings(t *testing.T) {
+	if integration2.ThroughProxy {
+		t.Skip("grpc-proxy does not forward the request timings metadata")
+	}
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	ctx := clientv3.WithRequestTimings(t.Context())
+
+	put, err := kv.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	require.NotNil(t, put.Header.Timings)
+	require.Positive(t, put.Header.Timings.RaftCommitNs)
+	require.Positive(t, put.Header.Timings.ApplyNs)
+	require.Zero(t, put.Header.Timings.BackendReadNs)
+
+	get, err := kv.Get(ctx, "foo")
+	require.NoError(t, err)
+	require.NotNil(t, get.Header.Timings)
+	require.Positive(t, get.Header.Timings.RaftCommitNs)
+	require.Positive(t, get.Header.Timings.BackendReadNs)
+	require.Zero(t, get.Header.Timings.ApplyNs)
+
+	get, err = kv.Get(ctx, "foo", clientv3.WithSerializable())
+	require.NoError(t, err)
+	require.Zero(t, get.Header.Timings.RaftCommitNs)
+	require.Positive(t, get.Header.Timings.BackendReadNs)
+
+	// timings are only reported when requested
+	get, err = kv.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.Nil(t, get.Header.Timings)
+}
+
 func TestKVPutWithRequireLeader(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -276,6 +378,57 @@ func TestKVRange(t *testing.T) {
 	}
 }
 
+func TestKVGetCountModificationsSince(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := t.Context()
+
+	resp, err := kv.Put(ctx, "a", "1")
+	require.NoError(t, err)
+	since := resp.Header.Revision
+	for _, k := range []string{"a", "b", "c", "a"} {
+		_, err = kv.Put(ctx, k, "2")
+		require.NoError(t, err)
+	}
+	_, err = kv.Delete(ctx, "b")
+	require.NoError(t, err)
+	_, err = kv.Put(ctx, "z", "3")
+	require.NoError(t, err)
+
+	tests := []struct {
+		key   string
+		opts  []clientv3.OpOption
+		count int64
+	}{
+		{"a", nil, 2},
+		{"b", nil, 2},
+		{"a", []clientv3.OpOption{clientv3.WithRange("c")}, 4},
+		{"", []clientv3.OpOption{clientv3.WithFromKey()}, 6},
+		{"a", []clientv3.OpOption{clientv3.WithRange("z"), clientv3.WithRev(since + 2)}, 2},
+		{"a", []clientv3.OpOption{clientv3.WithSerializable(), clientv3.WithRange("z")}, 5},
+		{"y", nil, 0},
+	}
+	for i, tt := range tests {
+		opts := append(tt.opts, clientv3.WithCountModificationsSince(since))
+		gresp, gerr := kv.Get(ctx, tt.key, opts...)
+		require.NoErrorf(t, gerr, "#%d", i)
+		require.Equalf(t, tt.count, gresp.Count, "#%d", i)
+		require.Emptyf(t, gresp.Kvs, "#%d", i)
+	}
+
+	_, err = kv.Compact(ctx, since+1)
+	require.NoError(t, err)
+	_, err = kv.Get(ctx, "a", clientv3.WithCountModificationsSince(since))
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+	gresp, err := kv.Get(ctx, "a", clientv3.WithCountModificationsSince(since+1))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), gresp.Count)
+}
+
 func TestKVGetErrConnClosed(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -377,6 +530,47 @@ func TestKVDeleteRange(t *testing.T) {
 	}
 }
 
+func TestKVDeleteLimits(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := t.Context()
+
+	for _, key := range []string{"a/1", "a/2", "a/3"} {
+		if _, err := kv.Put(ctx, key, ""); err != nil {
+			t.Fatalf("couldn't put %q (%v)", key, err)
+		}
+	}
+
+	_, err := kv.Delete(ctx, "a/", clientv3.WithPrefix(), clientv3.WithMaxDeletions(2))
+	if !errors.Is(err, rpctypes.ErrTooManyDeletions) {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrTooManyDeletions, err)
+	}
+	_, err = kv.Delete(ctx, "a/", clientv3.WithPrefix(), clientv3.WithMaxDeletions(-1))
+	if !errors.Is(err, rpctypes.ErrInvalidDeleteLimit) {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrInvalidDeleteLimit, err)
+	}
+
+	resp, err := kv.Delete(ctx, "a/", clientv3.WithPrefix(), clientv3.WithIfCountLessThan(3))
+	if err != nil {
+		t.Fatalf("couldn't delete range (%v)", err)
+	}
+	if resp.Deleted != 0 {
+		t.Fatalf("expected no deletion, got %d", resp.Deleted)
+	}
+
+	resp, err = kv.Delete(ctx, "a/", clientv3.WithPrefix(), clientv3.WithMaxDeletions(3))
+	if err != nil {
+		t.Fatalf("couldn't delete range (%v)", err)
+	}
+	if resp.Deleted != 3 {
+		t.Fatalf("expected 3 deletions, got %d", resp.Deleted)
+	}
+}
+
 func TestKVCompactError(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -829,6 +1023,70 @@ func TestKVForLearner(t *testing.T) {
 	}
 }
 
+// TestKVForStandby ensures a standby member only serves the Status RPC, and rejects
+// every other unary RPC and every stream.
+func TestKVForStandby(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, DisableStrictReconfigCheck: true})
+	defer clus.Terminate(t)
+
+	standby := clus.MustNewMember(t)
+	memberAddResp, err := clus.Client(0).MemberAddAsStandby(t.Context(), standby.PeerURLs.StringSlice())
+	if err != nil {
+		t.Fatalf("failed to add standby member %v", err)
+	}
+	if !memberAddResp.Member.IsStandby {
+		t.Fatalf("Added a member as standby, got resp.Member.IsStandby = %v", memberAddResp.Member.IsStandby)
+	}
+	clus.InitializeMemberWithResponse(t, standby, memberAddResp)
+	require.NoError(t, standby.Launch())
+
+	cfg := clientv3.Config{
+		Endpoints:   []string{standby.GRPCURL},
+		DialTimeout: 5 * time.Second,
+		DialOptions: []grpc.DialOption{grpc.WithBlock()},
+	}
+	// this client only has endpoint of the standby member
+	cli, err := integration2.NewClient(t, cfg)
+	if err != nil {
+		t.Fatalf("failed to create clientv3: %v", err)
+	}
+	defer cli.Close()
+
+	// wait until standby member is ready
+	<-standby.ReadyNotify()
+
+	if _, err = cli.Status(t.Context(), standby.GRPCURL); err != nil {
+		t.Errorf("expect no error on Status, got %v", err)
+	}
+
+	expectedErrKeywords := rpctypes.ErrorDesc(rpctypes.ErrGRPCNotSupportedForStandby)
+	// unlike a learner, a standby rejects serializable reads too
+	for idx, op := range []clientv3.Op{
+		clientv3.OpGet("foo", clientv3.WithSerializable()),
+		clientv3.OpGet("foo"),
+		clientv3.OpPut("foo", "bar"),
+	} {
+		if _, err = cli.Do(t.Context(), op); err == nil || !strings.Contains(err.Error(), expectedErrKeywords) {
+			t.Errorf("%d: expect error to contain %s, got %v", idx, expectedErrKeywords, err)
+		}
+	}
+
+	wch := cli.Watch(t.Context(), "foo")
+	select {
+	case wresp, ok := <-wch:
+		if !ok {
+			t.Fatal("watch channel closed without a response")
+		}
+		if err = wresp.Err(); err == nil || !strings.Contains(err.Error(), expectedErrKeywords) {
+			t.Errorf("expect watch error to contain %s, got %v", expectedErrKeywords, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the watch on the standby to be rejected")
+	}
+}
+
 // TestBalancerSupportLearner verifies that balancer's retry and failover mechanism supports cluster with learner member
 func TestBalancerSupportLearner(t *testing.T) {
 	integration2.BeforeTest(t)
@@ -0,0 +1,102 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestResponseHeaderLeader checks that every member reports the leader in
+// the response headers, and that only the leader reports its validity.
+func TestResponseHeaderLeader(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	for i, m := range clus.Members {
+		resp, err := m.Client.Get(t.Context(), "foo")
+		require.NoError(t, err)
+		require.Equal(t, uint64(clus.Members[lead].ID()), resp.Header.Leader)
+		if i == lead {
+			require.Positive(t, resp.Header.LeaderValidMs)
+		} else {
+			require.Zero(t, resp.Header.LeaderValidMs)
+		}
+	}
+}
+
+// TestKVLeaderRouting checks that linearizable requests are sent to the
+// leader with Config.LeaderRouting, and follow the leader when it changes.
+func TestKVLeaderRouting(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, UseBridge: true})
+	defer clus.Terminate(t)
+
+	cli, err := integration2.NewClient(t, clientv3.Config{
+		Endpoints:     []string{clus.Members[0].GRPCURL, clus.Members[1].GRPCURL, clus.Members[2].GRPCURL},
+		LeaderRouting: true,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	// waitRouted waits for the puts to be answered by the leader only.
+	waitRouted := func(lead int) {
+		id := uint64(clus.Members[lead].ID())
+		require.Eventually(t, func() bool {
+			for i := 0; i < 5; i++ {
+				resp, err := cli.Put(t.Context(), "foo", "bar")
+				if err != nil || resp.Header.MemberId != id {
+					return false
+				}
+			}
+			return true
+		}, 10*time.Second, 100*time.Millisecond)
+	}
+
+	lead := clus.WaitLeader(t)
+	waitRouted(lead)
+
+	// the followers no longer answer the client, but the leader still does
+	for i, m := range clus.Members {
+		if i != lead {
+			m.Bridge().Blackhole()
+		}
+	}
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		_, err = cli.Get(ctx, "foo")
+		cancel()
+		require.NoError(t, err)
+	}
+	for i, m := range clus.Members {
+		if i != lead {
+			m.Bridge().Unblackhole()
+		}
+	}
+
+	target := (lead + 1) % len(clus.Members)
+	require.NoError(t, clus.Members[lead].Server.MoveLeader(t.Context(), uint64(clus.Members[lead].ID()), uint64(clus.Members[target].ID())))
+	waitRouted(target)
+}
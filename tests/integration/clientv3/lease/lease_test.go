@@ -114,6 +114,57 @@ func TestLeaseKeepAliveOnce(t *testing.T) {
 	}
 }
 
+func TestLeaseFencingToken(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+
+	l1, err := cli.Grant(t.Context(), 10)
+	require.NoError(t, err)
+	l2, err := cli.Grant(t.Context(), 10)
+	require.NoError(t, err)
+	require.Positive(t, l1.FencingToken)
+	require.Greater(t, l2.FencingToken, l1.FencingToken)
+
+	// followers know the token of the lease they keep alive
+	ka, err := clus.Client(1).KeepAliveOnce(t.Context(), l1.ID)
+	require.NoError(t, err)
+	require.Equal(t, l1.FencingToken, ka.FencingToken)
+
+	_, err = cli.Put(t.Context(), "lock", "holder", clientv3.WithLease(l1.ID))
+	require.NoError(t, err)
+	write := func(token int64) bool {
+		resp, terr := cli.Txn(t.Context()).
+			If(clientv3.Compare(clientv3.FencingToken("lock"), "=", token)).
+			Then(clientv3.OpPut("data", "v")).
+			Commit()
+		require.NoError(t, terr)
+		return resp.Succeeded
+	}
+	require.True(t, write(l1.FencingToken))
+
+	// the lock is taken over by a newer lease
+	_, err = cli.Revoke(t.Context(), l1.ID)
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "lock", "holder", clientv3.WithLease(l2.ID))
+	require.NoError(t, err)
+	require.False(t, write(l1.FencingToken))
+	require.True(t, write(l2.FencingToken))
+
+	// a key without a lease has no fencing token
+	_, err = cli.Put(t.Context(), "lock", "holder")
+	require.NoError(t, err)
+	require.True(t, write(0))
+
+	s, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	defer s.Close()
+	require.Greater(t, s.FencingToken(), l2.FencingToken)
+}
+
 func TestLeaseKeepAlive(t *testing.T) {
 	integration2.BeforeTest(t)
 
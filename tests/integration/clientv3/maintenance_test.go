@@ -34,14 +34,20 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.etcd.io/etcd/client/v3/export"
+	"go.etcd.io/etcd/client/v3/snapshot"
 	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/lease/leasepb"
 	"go.etcd.io/etcd/server/v3/storage"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/server/v3/storage/mvcc/testutil"
+	"go.etcd.io/etcd/server/v3/storage/schema"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
 )
 
@@ -147,6 +153,558 @@ func TestMaintenanceMoveLeader(t *testing.T) {
 	}
 }
 
+// TestMaintenanceCompactionHolds ensures that compaction holds are granted,
+// renewed, listed on all members and revoked.
+func TestMaintenanceCompactionHolds(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, CompactionMaxHold: time.Hour})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	for i := 0; i < 3; i++ {
+		_, err := cli.Put(t.Context(), "foo", "bar")
+		require.NoError(t, err)
+	}
+
+	grant, err := cli.CompactionHoldGrant(t.Context(), "indexer", 2, 0)
+	require.NoError(t, err)
+	assert.NotZero(t, grant.ID)
+	assert.Equal(t, int64(time.Hour/time.Second), grant.TTL)
+
+	_, err = cli.CompactionHoldGrant(t.Context(), "future", 100, 0)
+	require.ErrorIs(t, err, rpctypes.ErrFutureRev)
+
+	renew, err := cli.CompactionHoldRenew(t.Context(), grant.ID, 3, 60)
+	require.NoError(t, err)
+	assert.Equal(t, grant.ID, renew.ID)
+	assert.Equal(t, int64(60), renew.TTL)
+
+	// holds are replicated, so every member lists them
+	for i := range clus.Members {
+		resp, lerr := clus.Client(i).CompactionHoldList(t.Context())
+		require.NoError(t, lerr)
+		require.Len(t, resp.Holds, 1)
+		assert.Equal(t, grant.ID, resp.Holds[0].ID)
+		assert.Equal(t, "indexer", resp.Holds[0].Name)
+		assert.Equal(t, int64(3), resp.Holds[0].Revision)
+	}
+
+	_, err = cli.CompactionHoldRevoke(t.Context(), grant.ID)
+	require.NoError(t, err)
+	_, err = cli.CompactionHoldRevoke(t.Context(), grant.ID)
+	require.ErrorIs(t, err, rpctypes.ErrCompactionHoldNotFound)
+	resp, err := cli.CompactionHoldList(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, resp.Holds)
+}
+
+func TestMaintenanceCompactionHoldsDisabled(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	_, err := clus.Client(0).CompactionHoldGrant(t.Context(), "", 0, 0)
+	require.ErrorIs(t, err, rpctypes.ErrCompactionHoldsDisabled)
+}
+
+func TestMaintenanceReadSnapshot(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, CompactionMaxHold: time.Hour})
+	defer clus.Terminate(t)
+
+	put, err := clus.Client(0).Put(t.Context(), "foo", "v1")
+	require.NoError(t, err)
+
+	// the snapshot pins the latest revision even if taken on another member
+	cli := clus.Client(1)
+	s, err := concurrency.NewReadSnapshot(t.Context(), cli, 2)
+	require.NoError(t, err)
+	assert.Equal(t, put.Header.Revision, s.Rev())
+
+	_, err = cli.Put(t.Context(), "foo", "v2")
+	require.NoError(t, err)
+	resp, err := s.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(resp.Kvs[0].Value))
+
+	// the hold outlives its TTL while the snapshot is renewed
+	time.Sleep(3 * time.Second)
+	holds, err := cli.CompactionHoldList(t.Context())
+	require.NoError(t, err)
+	require.Len(t, holds.Holds, 1)
+	assert.Equal(t, s.HoldID(), holds.Holds[0].ID)
+	assert.Equal(t, s.Rev(), holds.Holds[0].Revision)
+
+	require.NoError(t, s.Release(t.Context()))
+	select {
+	case <-s.Done():
+	default:
+		t.Fatal("released snapshot is not done")
+	}
+	holds, err = cli.CompactionHoldList(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, holds.Holds)
+}
+
+func TestMaintenanceGarbageCollect(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	orphanLease, err := cli.Grant(t.Context(), 3600)
+	require.NoError(t, err)
+	put, err := cli.Put(t.Context(), "orphan", "v", clientv3.WithLease(orphanLease.ID))
+	require.NoError(t, err)
+	emptyLease, err := cli.Grant(t.Context(), 3600)
+	require.NoError(t, err)
+
+	// drop the lease of the key from the backend, as left behind by a
+	// partially restored member
+	clus.Members[0].Stop(t)
+	be := backend.NewDefaultBackend(zaptest.NewLogger(t), clus.Members[0].BackendPath())
+	tx := be.BatchTx()
+	tx.LockOutsideApply()
+	schema.UnsafeDeleteLease(tx, &leasepb.Lease{ID: int64(orphanLease.ID)})
+	tx.Unlock()
+	require.NoError(t, be.Close())
+	require.NoError(t, clus.Members[0].Restart(t))
+	clus.WaitLeader(t)
+
+	resp, err := cli.GarbageCollect(t.Context(), 0, false)
+	require.NoError(t, err)
+	require.Len(t, resp.OrphanedKeys, 1)
+	assert.True(t, bytes.HasSuffix(resp.OrphanedKeys[0].Key, []byte("orphan")))
+	assert.Equal(t, int64(orphanLease.ID), resp.OrphanedKeys[0].Lease)
+	assert.Equal(t, put.Header.Revision, resp.OrphanedKeys[0].ModRevision)
+	assert.Empty(t, resp.EmptyLeases)
+	assert.Zero(t, resp.StaleTokens)
+	assert.False(t, resp.Repaired)
+
+	// the age of leases loaded from the backend counts from the restart
+	time.Sleep(time.Second)
+	resp, err = cli.GarbageCollect(t.Context(), time.Second, true)
+	require.NoError(t, err)
+	assert.Len(t, resp.OrphanedKeys, 1)
+	assert.Equal(t, []int64{int64(emptyLease.ID)}, resp.EmptyLeases)
+	assert.True(t, resp.Repaired)
+
+	get, err := cli.Get(t.Context(), "orphan")
+	require.NoError(t, err)
+	assert.Zero(t, get.Count)
+	ttl, err := cli.TimeToLive(t.Context(), emptyLease.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), ttl.TTL)
+
+	resp, err = cli.GarbageCollect(t.Context(), time.Second, false)
+	require.NoError(t, err)
+	assert.Empty(t, resp.OrphanedKeys)
+	assert.Empty(t, resp.EmptyLeases)
+}
+
+func TestMaintenanceHotKeys(t *testing.T) {
+	if integration2.ThroughProxy {
+		t.Skip("the member tracks the keys prefixed with the namespace of the grpc-proxy")
+	}
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, HotKeysTopK: 2})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	ep := clus.Members[0].GRPCURL
+	for i, key := range []string{"a", "b", "c"} {
+		for j := 0; j <= i; j++ {
+			_, err := cli.Put(t.Context(), key, "value")
+			require.NoError(t, err)
+		}
+	}
+	for range 5 {
+		_, err := cli.Get(t.Context(), "a")
+		require.NoError(t, err)
+	}
+
+	resp, err := cli.HotKeys(t.Context(), ep, clientv3.HotKeysByWrites, 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, resp.SampleRate)
+	require.Len(t, resp.Keys, 2)
+	assert.Equal(t, "c", string(resp.Keys[0].Key))
+	assert.Equal(t, int64(3), resp.Keys[0].Writes)
+	assert.Equal(t, int64(3*len("cvalue")), resp.Keys[0].WriteBytes)
+	assert.Equal(t, "b", string(resp.Keys[1].Key))
+
+	resp, err = cli.HotKeys(t.Context(), ep, clientv3.HotKeysByReads, 1, true)
+	require.NoError(t, err)
+	require.Len(t, resp.Keys, 1)
+	assert.Equal(t, "a", string(resp.Keys[0].Key))
+	assert.Equal(t, int64(5), resp.Keys[0].Reads)
+	assert.Equal(t, int64(1), resp.Keys[0].Writes)
+
+	resp, err = cli.HotKeys(t.Context(), ep, clientv3.HotKeysByReads, 0, false)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Keys)
+}
+
+func TestMaintenanceHotKeysDisabled(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	_, err := clus.Client(0).HotKeys(t.Context(), clus.Members[0].GRPCURL, clientv3.HotKeysByReads, 0, false)
+	require.ErrorIs(t, err, rpctypes.ErrHotKeysDisabled)
+}
+
+func TestMaintenanceNewerFields(t *testing.T) {
+	if integration2.ThroughProxy {
+		t.Skip("the grpc-proxy forwards deletes without max deletions")
+	}
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, NewerRequestFields: "reject"})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	ep := clus.Members[0].GRPCURL
+	// enabling the downgrade lowers the cluster version
+	_, err := cli.Downgrade(t.Context(), clientv3.DowngradeEnable, "3.6")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		resp, err := cli.NewerFields(t.Context(), ep, false)
+		return err == nil && resp.ClusterVersion == "3.6.0"
+	}, 10*time.Second, 100*time.Millisecond)
+
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	_, err = cli.Delete(t.Context(), "foo", clientv3.WithMaxDeletions(1))
+	require.ErrorIs(t, err, rpctypes.ErrNewerRequestFields)
+
+	resp, err := cli.NewerFields(t.Context(), ep, true)
+	require.NoError(t, err)
+	assert.Equal(t, "3.6.0", resp.ClusterVersion)
+	require.Len(t, resp.Fields, 1)
+	assert.Equal(t, "etcdserverpb.DeleteRangeRequest.max_deletions", resp.Fields[0].Name)
+	assert.Equal(t, "3.7.0", resp.Fields[0].Version)
+	assert.Equal(t, int64(1), resp.Fields[0].Count)
+	assert.Equal(t, "/etcdserverpb.KV/DeleteRange", resp.Fields[0].LastMethod)
+
+	resp, err = cli.NewerFields(t.Context(), ep, false)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Fields)
+}
+
+func TestMaintenanceNewerFieldsDisabled(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	_, err := clus.Client(0).NewerFields(t.Context(), clus.Members[0].GRPCURL, false)
+	require.ErrorIs(t, err, rpctypes.ErrNewerFieldsDisabled)
+}
+
+func TestMaintenanceDowngradeStatus(t *testing.T) {
+	if integration2.ThroughProxy {
+		t.Skip("the grpc-proxy forwards deletes without max deletions")
+	}
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	resp, err := cli.Downgrade(t.Context(), clientv3.DowngradeStatus, "")
+	require.NoError(t, err)
+	assert.False(t, resp.DowngradeInfo.Enabled)
+	assert.Empty(t, resp.BlockedFeatures)
+	_, err = cli.Delete(t.Context(), "foo", clientv3.WithMaxDeletions(1))
+	require.NoError(t, err)
+
+	_, err = cli.Downgrade(t.Context(), clientv3.DowngradeEnable, "3.6")
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	_, err = cli.Delete(t.Context(), "foo", clientv3.WithMaxDeletions(1))
+	require.ErrorIs(t, err, rpctypes.ErrDowngradeBlocked)
+
+	resp, err = cli.Downgrade(t.Context(), clientv3.DowngradeStatus, "")
+	require.NoError(t, err)
+	assert.True(t, resp.DowngradeInfo.Enabled)
+	assert.Equal(t, "3.6.0", resp.DowngradeInfo.TargetVersion)
+	require.Len(t, resp.BlockedFeatures, 1)
+	assert.Equal(t, "etcdserverpb.DeleteRangeRequest.max_deletions", resp.BlockedFeatures[0].Name)
+	assert.Equal(t, "3.7.0", resp.BlockedFeatures[0].Version)
+	assert.Equal(t, int64(1), resp.BlockedFeatures[0].Count)
+	assert.Equal(t, "DeleteRangeRequest", resp.BlockedFeatures[0].LastMethod)
+
+	_, err = cli.Downgrade(t.Context(), clientv3.DowngradeCancel, "")
+	require.NoError(t, err)
+	_, err = cli.Delete(t.Context(), "foo", clientv3.WithMaxDeletions(1))
+	require.NoError(t, err)
+}
+
+func TestMaintenanceJobs(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	ep := clus.Members[0].GRPCURL
+	_, err := cli.Defragment(t.Context(), ep)
+	require.NoError(t, err)
+	_, err = cli.GarbageCollect(t.Context(), 0, false)
+	require.NoError(t, err)
+
+	resp, err := cli.JobList(t.Context(), ep)
+	require.NoError(t, err)
+	require.Len(t, resp.Jobs, 2)
+	assert.Equal(t, "defrag", resp.Jobs[0].Type)
+	assert.Equal(t, "gc", resp.Jobs[1].Type)
+	for _, job := range resp.Jobs {
+		assert.Equal(t, pb.Job_SUCCEEDED, job.State)
+		assert.GreaterOrEqual(t, job.EndTime, job.StartTime)
+	}
+
+	sresp, err := cli.JobStatus(t.Context(), ep, resp.Jobs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, resp.Jobs[0], sresp.Job)
+
+	_, err = cli.JobCancel(t.Context(), ep, resp.Jobs[0].ID)
+	require.ErrorIs(t, err, rpctypes.ErrJobNotRunning)
+	_, err = cli.JobStatus(t.Context(), ep, 42)
+	require.ErrorIs(t, err, rpctypes.ErrJobNotFound)
+	_, err = cli.JobCancel(t.Context(), ep, 42)
+	require.ErrorIs(t, err, rpctypes.ErrJobNotFound)
+}
+
+func TestMaintenanceCheckpoint(t *testing.T) {
+	if integration2.ThroughProxy {
+		t.Skip("the member stores the keys prefixed with the namespace of the grpc-proxy")
+	}
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, BackendCheckpointRetention: 2})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	_, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	var paths []string
+	for i := 0; i < 3; i++ {
+		resp, cerr := cli.Checkpoint(t.Context(), clus.Members[0].GRPCURL)
+		require.NoError(t, cerr)
+		require.Positive(t, resp.DbSize)
+		require.Equal(t, filepath.Join(clus.Members[0].DataDir, "checkpoints"), filepath.Dir(resp.Path))
+		paths = append(paths, resp.Path)
+	}
+	// the oldest checkpoint is beyond the retention
+	require.NoFileExists(t, paths[0])
+	require.FileExists(t, paths[1])
+
+	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(t))
+	bcfg.Path = paths[2]
+	be := backend.New(bcfg)
+	defer be.Close()
+	st := mvcc.NewStore(zaptest.NewLogger(t), be, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	defer st.Close()
+	r, err := st.Range(t.Context(), []byte("foo"), nil, mvcc.RangeOptions{})
+	require.NoError(t, err)
+	require.Len(t, r.KVs, 1)
+	require.Equal(t, "bar", string(r.KVs[0].Value))
+}
+
+func TestMaintenanceExport(t *testing.T) {
+	if integration2.ThroughProxy {
+		t.Skip("the member stores the keys prefixed with the namespace of the grpc-proxy")
+	}
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	// more keys than sent in a single response of the export stream
+	for i := 0; i < 1500; i += 100 {
+		var ops []clientv3.Op
+		for j := i; j < i+100; j++ {
+			ops = append(ops, clientv3.OpPut(fmt.Sprintf("foo%04d", j), fmt.Sprintf("bar%d", j)))
+		}
+		_, err := cli.Txn(t.Context()).Then(ops...).Commit()
+		require.NoError(t, err)
+	}
+	presp, err := cli.Put(t.Context(), "zoo", "bar")
+	require.NoError(t, err)
+	rev := presp.Header.Revision
+	_, err = cli.Delete(t.Context(), "foo", clientv3.WithPrefix())
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "zoo", "baz")
+	require.NoError(t, err)
+
+	resp, err := cli.Export(t.Context(), "foo", clientv3.WithPrefix(), clientv3.WithRev(rev))
+	require.NoError(t, err)
+	require.Equal(t, rev, resp.Revision)
+	kvs, err := resp.Next()
+	require.NoError(t, err)
+	// compacting the exported revision away does not affect the export
+	_, err = cli.Compact(t.Context(), rev+2, clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+	for {
+		page, nerr := resp.Next()
+		if errors.Is(nerr, io.EOF) {
+			break
+		}
+		require.NoError(t, nerr)
+		kvs = append(kvs, page...)
+	}
+	require.Len(t, kvs, 1500)
+	for i, kv := range kvs {
+		require.Equal(t, fmt.Sprintf("foo%04d", i), string(kv.Key))
+		require.Equal(t, fmt.Sprintf("bar%d", i), string(kv.Value))
+	}
+
+	_, err = cli.Export(t.Context(), "foo", clientv3.WithPrefix(), clientv3.WithRev(rev))
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+
+	var buf bytes.Buffer
+	erev, count, err := export.Fetch(t.Context(), cli, &buf, "", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Equal(t, rev+2, erev)
+	require.Equal(t, int64(1), count)
+	r, err := export.NewReader(&buf)
+	require.NoError(t, err)
+	require.Equal(t, erev, r.Revision())
+	kv, err := r.Next()
+	require.NoError(t, err)
+	require.Equal(t, "zoo", string(kv.Key))
+	require.Equal(t, "baz", string(kv.Value))
+	_, err = r.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestMaintenanceStorageStats(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	for i := 0; i < 3; i++ {
+		_, err := cli.Put(t.Context(), fmt.Sprintf("foo%d", i), "bar")
+		require.NoError(t, err)
+	}
+	clus.Members[0].Server.Backend().ForceCommit()
+
+	resp, err := cli.StorageStats(t.Context(), clus.Members[0].GRPCURL)
+	require.NoError(t, err)
+	var key *pb.BucketStats
+	for _, b := range resp.Buckets {
+		if b.Name == "key" {
+			key = b
+		}
+	}
+	require.NotNil(t, key)
+	assert.Equal(t, int64(3), key.Keys)
+	assert.Positive(t, key.SizeBytes)
+	assert.Positive(t, resp.DbSize)
+	assert.Positive(t, resp.DbSizeInUse)
+	assert.Positive(t, resp.Time)
+}
+
+func TestMaintenanceMemoryStats(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	// the soft limit is always exceeded, so watchers are dropped as soon as
+	// the member checks its memory
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, MemorySoftLimit: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	_, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	resp, err := cli.MemoryStats(t.Context(), clus.Members[0].GRPCURL)
+	require.NoError(t, err)
+	usage := make(map[string]int64)
+	total := int64(0)
+	for _, u := range resp.Usage {
+		usage[u.Component] = u.Bytes
+		total += u.Bytes
+	}
+	assert.Positive(t, usage["index"])
+	assert.Contains(t, usage, "watchers")
+	assert.Contains(t, usage, "leases")
+	assert.Contains(t, usage, "grpc")
+	assert.Equal(t, total, resp.Total)
+	assert.Equal(t, int64(1), resp.SoftLimit)
+	assert.Positive(t, resp.HeapAlloc)
+
+	wch := cli.Watch(t.Context(), "foo")
+	select {
+	case wresp, ok := <-wch:
+		require.True(t, ok)
+		require.True(t, wresp.Canceled)
+		require.ErrorIs(t, wresp.Err(), rpctypes.ErrWatcherDropped)
+	case <-time.After(10 * time.Second):
+		t.Fatal("watcher was not dropped")
+	}
+}
+
+func TestMaintenanceVerifySnapshot(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	for i := 0; i < 10; i++ {
+		_, err := cli.Put(t.Context(), fmt.Sprintf("foo%d", i), "bar")
+		require.NoError(t, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.db")
+	_, err := snapshot.SaveWithVersion(t.Context(), zaptest.NewLogger(t), clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}}, path)
+	require.NoError(t, err)
+	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(t))
+	bcfg.Path = path
+	be := backend.New(bcfg)
+	st := mvcc.NewStore(zaptest.NewLogger(t), be, nil, mvcc.StoreConfig{})
+	h, _, err := mvcc.NewHashStorage(zaptest.NewLogger(t), st).HashByRev(0)
+	require.NoError(t, err)
+	st.Close()
+	be.Close()
+
+	// later writes do not change the hash at the revision of the snapshot
+	_, err = cli.Put(t.Context(), "foo0", "baz")
+	require.NoError(t, err)
+	for _, m := range clus.Members {
+		resp, err := cli.VerifySnapshot(t.Context(), m.GRPCURL, h.Revision, h.Hash, h.CompactRevision)
+		require.NoError(t, err)
+		assert.True(t, resp.Match, "snapshot does not match member %s", m.Name)
+		assert.Equal(t, h.Hash, resp.Hash)
+	}
+
+	resp, err := cli.VerifySnapshot(t.Context(), clus.Members[0].GRPCURL, h.Revision, h.Hash+1, h.CompactRevision)
+	require.NoError(t, err)
+	assert.False(t, resp.Match)
+	assert.Equal(t, h.Hash, resp.Hash)
+
+	_, err = cli.VerifySnapshot(t.Context(), clus.Members[0].GRPCURL, 0, h.Hash, h.CompactRevision)
+	require.ErrorIs(t, err, rpctypes.ErrSnapshotNoRevision)
+
+	_, err = cli.Compact(t.Context(), h.Revision-1)
+	require.NoError(t, err)
+	resp, err = cli.VerifySnapshot(t.Context(), clus.Members[0].GRPCURL, h.Revision, h.Hash, h.CompactRevision)
+	require.NoError(t, err)
+	assert.False(t, resp.Match)
+	assert.Equal(t, h.Revision-1, resp.CompactRevision)
+}
+
 // TestMaintenanceSnapshotCancel ensures that context cancel
 // before snapshot reading returns corresponding context errors.
 func TestMaintenanceSnapshotCancel(t *testing.T) {
@@ -393,6 +951,106 @@ func TestMaintenanceSnapshotContentDigest(t *testing.T) {
 	require.Equal(t, checksumInBytes, actualChecksum)
 }
 
+func TestMaintenanceSnapshotResume(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	populateDataIntoCluster(t, clus, 3, 1024*1024)
+	cli := clus.RandClient()
+
+	// interrupt the transfer half way
+	ctx, cancel := context.WithCancel(t.Context())
+	resp, err := cli.SnapshotWithVersion(ctx)
+	require.NoError(t, err)
+	require.NotZero(t, resp.ID)
+	head := make([]byte, 1024*1024)
+	_, err = io.ReadFull(resp.Snapshot, head)
+	require.NoError(t, err)
+	cancel()
+	resp.Snapshot.Close()
+	digest := sha256.Sum256(head)
+
+	_, err = cli.SnapshotWithOptions(t.Context(), clientv3.SnapshotOptions{ResumeID: resp.ID, Offset: int64(len(head)), OffsetSHA256: make([]byte, sha256.Size)})
+	require.ErrorContains(t, err, rpctypes.ErrSnapshotMismatch.Error())
+	// the snapshot is only kept if the transfer is interrupted after resuming
+	_, err = cli.SnapshotWithOptions(t.Context(), clientv3.SnapshotOptions{ResumeID: resp.ID, Offset: int64(len(head)), OffsetSHA256: digest[:]})
+	require.ErrorContains(t, err, rpctypes.ErrSnapshotNotFound.Error())
+
+	ctx, cancel = context.WithCancel(t.Context())
+	resp, err = cli.SnapshotWithVersion(ctx)
+	require.NoError(t, err)
+	_, err = io.ReadFull(resp.Snapshot, head)
+	require.NoError(t, err)
+	cancel()
+	resp.Snapshot.Close()
+	digest = sha256.Sum256(head)
+
+	resumed, err := cli.SnapshotWithOptions(t.Context(), clientv3.SnapshotOptions{ResumeID: resp.ID, Offset: int64(len(head)), OffsetSHA256: digest[:]})
+	require.NoError(t, err)
+	require.Equal(t, resp.ID, resumed.ID)
+	require.Equal(t, int64(len(head)), resumed.Offset)
+	rest, err := io.ReadAll(resumed.Snapshot)
+	require.NoError(t, err)
+	require.NoError(t, resumed.Snapshot.Close())
+
+	// the digest of the resumed transfer covers the whole snapshot
+	full := append(head, rest...)
+	digest = sha256.Sum256(full[:len(full)-sha256.Size])
+	require.Equal(t, digest[:], full[len(full)-sha256.Size:])
+
+	_, err = cli.SnapshotWithOptions(t.Context(), clientv3.SnapshotOptions{Offset: int64(len(head)), OffsetSHA256: digest[:]})
+	require.ErrorContains(t, err, rpctypes.ErrInvalidSnapshotOffset.Error())
+}
+
+func TestMaintenanceSnapshotSaveResume(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, UseBridge: true})
+	defer clus.Terminate(t)
+
+	populateDataIntoCluster(t, clus, 3, 1024*1024)
+
+	go func() {
+		time.Sleep(time.Second)
+		clus.Members[0].Bridge().DropConnections()
+	}()
+	dbPath := filepath.Join(t.TempDir(), "snapshot.db")
+	cfg := clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}}
+	// the rate limit keeps the transfer going when the connection is dropped
+	_, err := snapshot.SaveWithOptions(t.Context(), zaptest.NewLogger(t), cfg, dbPath, snapshot.SaveOptions{RateLimit: 2 * 1024 * 1024, MaxResumes: 3})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = snapshot.SaveToWriter(t.Context(), zaptest.NewLogger(t), cfg, &buf, snapshot.SaveOptions{})
+	require.NoError(t, err)
+	saved, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+	require.Len(t, saved, buf.Len())
+}
+
+func TestMaintenanceSnapshotRateLimit(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	populateDataIntoCluster(t, clus, 1, 1024*1024)
+
+	const rateLimit = 2 * 1024 * 1024
+	start := time.Now()
+	resp, err := clus.RandClient().SnapshotWithOptions(t.Context(), clientv3.SnapshotOptions{RateLimit: rateLimit})
+	require.NoError(t, err)
+	n, err := io.Copy(io.Discard, resp.Snapshot)
+	require.NoError(t, err)
+	require.NoError(t, resp.Snapshot.Close())
+
+	// the first buffer is sent without waiting
+	minDuration := time.Duration(float64(n-32*1024) / rateLimit * float64(time.Second))
+	require.GreaterOrEqual(t, time.Since(start), minDuration)
+}
+
 func TestMaintenanceStatus(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -440,3 +1098,164 @@ func TestMaintenanceStatus(t *testing.T) {
 		t.Fatal("no leader found")
 	}
 }
+
+func TestMaintenanceDrainMember(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	leadID := uint64(clus.Members[lead].Server.MemberID())
+	ep := clus.Members[lead].GRPCURL
+	cli := clus.Client((lead + 1) % 3)
+
+	// the watch of a client connected only to the draining member is closed
+	wcli, err := integration2.NewClient(t, clientv3.Config{Endpoints: []string{ep}})
+	require.NoError(t, err)
+	defer wcli.Close()
+	wch := wcli.Watch(t.Context(), "foo", clientv3.WithCreatedNotify())
+	<-wch
+
+	var resp *clientv3.DrainMemberResponse
+	for i := 0; i < 50; i++ {
+		resp, err = cli.DrainMember(t.Context(), ep, false)
+		require.NoError(t, err)
+		require.True(t, resp.Draining)
+		if resp.Ready {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.Truef(t, resp.Ready, "member never got ready to be stopped: %+v", resp)
+	require.False(t, resp.Leader)
+	require.Zero(t, resp.WatchStreams)
+	require.NotEqual(t, lead, clus.WaitLeader(t))
+
+	mresp, err := cli.MemberList(t.Context())
+	require.NoError(t, err)
+	if !integration2.ThroughProxy {
+		for _, m := range mresp.Members {
+			require.Equalf(t, m.ID == leadID, m.IsDraining, "member %x", m.ID)
+		}
+
+		_, err = pb.NewLeaseClient(wcli.ActiveConnection()).LeaseGrant(t.Context(), &pb.LeaseGrantRequest{TTL: 10})
+		require.ErrorIs(t, rpctypes.Error(err), rpctypes.ErrMemberDraining)
+
+		// the endpoint sync skips the draining member
+		scli, serr := integration2.NewClient(t, clientv3.Config{Endpoints: cli.Endpoints()})
+		require.NoError(t, serr)
+		defer scli.Close()
+		require.NoError(t, scli.Sync(t.Context()))
+		require.NotContains(t, scli.Endpoints(), clus.Members[lead].ClientURLs[0].String())
+		require.Len(t, scli.Endpoints(), 2)
+	}
+
+	resp, err = cli.DrainMember(t.Context(), ep, true)
+	require.NoError(t, err)
+	require.False(t, resp.Draining)
+	require.False(t, resp.Ready)
+
+	_, err = wcli.Grant(t.Context(), 10)
+	require.NoError(t, err)
+	_, err = clus.Client(clus.WaitLeader(t)).MoveLeader(t.Context(), leadID)
+	require.NoError(t, err)
+}
+
+func TestMaintenanceFencePrefix(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	_, err := cli.FencePrefix(t.Context(), "", 10, "secret")
+	require.ErrorIs(t, err, rpctypes.ErrEmptyKey)
+
+	_, err = cli.FencePrefix(t.Context(), "/app/", 10, "secret")
+	require.NoError(t, err)
+
+	// every member rejects the writes under the prefix, but not the reads
+	for i := range clus.Members {
+		c := clus.Client(i)
+		_, err = c.Put(t.Context(), "/app/foo", "bar")
+		require.ErrorIs(t, err, rpctypes.ErrPrefixFenced)
+		_, err = c.Delete(t.Context(), "/", clientv3.WithPrefix())
+		require.ErrorIs(t, err, rpctypes.ErrPrefixFenced)
+		_, err = c.Txn(t.Context()).Then(clientv3.OpPut("/app/foo", "bar")).Commit()
+		require.ErrorIs(t, err, rpctypes.ErrPrefixFenced)
+		_, err = c.Put(clientv3.WithFenceToken(t.Context(), "other"), "/app/foo", "bar")
+		require.ErrorIs(t, err, rpctypes.ErrPrefixFenced)
+		_, err = c.Get(t.Context(), "/app/foo")
+		require.NoError(t, err)
+		_, err = c.Put(t.Context(), "/apps", "bar")
+		require.NoError(t, err)
+	}
+
+	// the writes carrying the token go through the fence
+	_, err = cli.Put(clientv3.WithFenceToken(t.Context(), "secret"), "/app/foo", "bar")
+	require.NoError(t, err)
+
+	_, err = cli.FencePrefix(t.Context(), "/app/", 0, "")
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "/app/foo", "baz")
+	require.NoError(t, err)
+
+	// the fence is lifted once expired
+	_, err = cli.FencePrefix(t.Context(), "/app/", 1, "")
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "/app/foo", "bar")
+	require.ErrorIs(t, err, rpctypes.ErrPrefixFenced)
+	require.Eventually(t, func() bool {
+		_, err = cli.Put(t.Context(), "/app/foo", "bar")
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestMaintenanceSetRequestLogging(t *testing.T) {
+	if integration2.ThroughProxy {
+		t.Skip("the member logs the keys prefixed with the namespace of the grpc-proxy")
+	}
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	m := clus.Members[0]
+	_, err := cli.SetRequestLogging(t.Context(), m.GRPCURL, clientv3.RequestLogging{Duration: time.Minute, SampleRate: 2})
+	require.ErrorIs(t, err, rpctypes.ErrInvalidRequestLogging)
+	_, err = cli.SetRequestLogging(t.Context(), m.GRPCURL, clientv3.RequestLogging{Duration: 48 * time.Hour})
+	require.ErrorIs(t, err, rpctypes.ErrInvalidRequestLogging)
+
+	_, err = cli.SetRequestLogging(t.Context(), m.GRPCURL, clientv3.RequestLogging{
+		Duration: time.Minute,
+		Methods:  []string{"Put"},
+		Prefixes: []string{"/app/"},
+	})
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "/other/foo", "secret-value")
+	require.NoError(t, err)
+	_, err = cli.Get(t.Context(), "/app/foo")
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "/app/foo", "secret-value")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	lines, err := m.LogObserver.Expect(ctx, `"method": "/etcdserverpb.KV/`, 1)
+	require.NoError(t, err)
+	assert.Contains(t, lines[0], "/etcdserverpb.KV/Put")
+	assert.Contains(t, lines[0], "/app/foo")
+	assert.Contains(t, lines[0], "value_size:12")
+	assert.NotContains(t, lines[0], "secret-value")
+
+	_, err = cli.SetRequestLogging(t.Context(), m.GRPCURL, clientv3.RequestLogging{})
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "/app/bar", "value")
+	require.NoError(t, err)
+	ctx, cancel = context.WithTimeout(t.Context(), 500*time.Millisecond)
+	defer cancel()
+	_, err = m.LogObserver.Expect(ctx, "/app/bar", 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -30,10 +30,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"google.golang.org/grpc"
 
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/metrics"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
 )
 
@@ -118,6 +122,88 @@ func TestV3ClientMetrics(t *testing.T) {
 	}
 }
 
+func TestV3ClientMetricsPrometheus(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	reg := prometheus.NewRegistry()
+	m, err := metrics.NewPrometheus(reg)
+	require.NoError(t, err)
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}, Metrics: m})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	_, err = cli.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	cli.Watch(t.Context(), "foo")
+
+	// the watch stream is opened asynchronously
+	require.Eventually(t, func() bool {
+		return gatherCounter(t, reg, "etcd_client_streams_started_total", map[string]string{"method": "/etcdserverpb.Watch/Watch", "code": "OK"}) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, gatherCounter(t, reg, "etcd_client_requests_total", map[string]string{"method": "/etcdserverpb.KV/Put", "code": "OK"}))
+	require.Equal(t, 1, gatherCounter(t, reg, "etcd_client_requests_total", map[string]string{"method": "/etcdserverpb.KV/Range", "code": "OK"}))
+}
+
+func TestV3ClientMetricsMeterProvider(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer mp.Shutdown(t.Context())
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}, MeterProvider: mp})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	var puts int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, metric := range sm.Metrics {
+			if metric.Name != "etcd.client.requests" {
+				continue
+			}
+			for _, dp := range metric.Data.(metricdata.Sum[int64]).DataPoints {
+				if v, _ := dp.Attributes.Value("rpc.method"); v == attribute.StringValue("/etcdserverpb.KV/Put") {
+					puts += dp.Value
+				}
+			}
+		}
+	}
+	require.Equal(t, int64(1), puts)
+}
+
+// gatherCounter returns the value of the counter name of reg with labels.
+func gatherCounter(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) int {
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			match := len(m.GetLabel()) == len(labels)
+			for _, lp := range m.GetLabel() {
+				match = match && labels[lp.GetName()] == lp.GetValue()
+			}
+			if match {
+				return int(m.GetCounter().GetValue())
+			}
+		}
+	}
+	return 0
+}
+
 func sumCountersForMetricAndLabels(t *testing.T, url string, metricName string, matchingLabelValues ...string) int {
 	count := 0
 	for _, line := range getHTTPBodyAsLines(t, url) {
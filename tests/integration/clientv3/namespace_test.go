@@ -15,6 +15,7 @@
 package clientv3test
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -50,6 +51,44 @@ func TestNamespacePutGet(t *testing.T) {
 	}
 }
 
+func TestNamespacePutSequenceSuffix(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	nsKV := namespace.NewKV(c.KV, "foo/")
+
+	presp, err := nsKV.Put(t.Context(), "q/", "bar", clientv3.WithSequenceSuffix())
+	require.NoError(t, err)
+	key := fmt.Sprintf("q/%020d", presp.Header.Revision)
+	if string(presp.Key) != key {
+		t.Errorf("expected key=%q, got key=%q", key, presp.Key)
+	}
+
+	resp, err := c.Get(t.Context(), "foo/"+key)
+	require.NoError(t, err)
+	if len(resp.Kvs) != 1 || string(resp.Kvs[0].Value) != "bar" {
+		t.Errorf("expected value=%q, got %+v", "bar", resp.Kvs)
+	}
+}
+
+func TestNamespacePutReturnKV(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	nsKV := namespace.NewKV(clus.Client(0).KV, "foo/")
+
+	presp, err := nsKV.Put(t.Context(), "abc", "bar", clientv3.WithReturnKV())
+	require.NoError(t, err)
+	require.NotNil(t, presp.Kv)
+	require.Equal(t, "abc", string(presp.Kv.Key))
+	require.Equal(t, presp.Header.Revision, presp.Kv.ModRevision)
+}
+
 func TestNamespaceWatch(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -78,3 +117,80 @@ func TestNamespaceWatch(t *testing.T) {
 	// let client close teardown namespace watch
 	c.Watcher = nsWatcher
 }
+
+func TestNamespaceCounter(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	nsCounter := namespace.NewCounter(c.Counter, "foo/")
+
+	aresp, err := nsCounter.CounterAdd(t.Context(), clientv3.CounterDelta{Key: "a", Delta: 2}, clientv3.CounterDelta{Key: "b", Delta: 3})
+	require.NoError(t, err)
+	require.Len(t, aresp.Counters, 2)
+	require.Equal(t, "a", string(aresp.Counters[0].Key))
+	require.Equal(t, "b", string(aresp.Counters[1].Key))
+
+	gresp, err := nsCounter.CounterGet(t.Context(), "a", clientv3.WithFromKey())
+	require.NoError(t, err)
+	require.Len(t, gresp.Counters, 2)
+	require.Equal(t, "a", string(gresp.Counters[0].Key))
+	require.Equal(t, int64(2), gresp.Counters[0].Value)
+
+	gresp, err = c.CounterGet(t.Context(), "foo/b")
+	require.NoError(t, err)
+	require.Len(t, gresp.Counters, 1)
+	require.Equal(t, int64(3), gresp.Counters[0].Value)
+}
+
+func TestNamespaceStacked(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	nsKV := namespace.NewKV(namespace.NewKV(c.KV, "foo/"), "bar/")
+	nsLease := namespace.NewLease(namespace.NewLease(c.Lease, "foo/"), "bar/")
+	nsWatcher := namespace.NewWatcher(namespace.NewWatcher(c.Watcher, "foo/"), "bar/")
+	defer nsWatcher.Close()
+
+	// a key outside the stacked namespace must not be visible through it
+	_, err := c.Put(t.Context(), "foo/baz", "v")
+	require.NoError(t, err)
+
+	lresp, err := nsLease.Grant(t.Context(), 60)
+	require.NoError(t, err)
+	_, err = nsKV.Put(t.Context(), "abc", "v", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+
+	resp, err := c.Get(t.Context(), "foo/bar/abc")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+
+	tresp, err := nsKV.Txn(t.Context()).
+		If(clientv3.Compare(clientv3.Version("").WithPrefix(), "=", 1)).
+		Then(clientv3.OpGet("", clientv3.WithFromKey())).
+		Commit()
+	require.NoError(t, err)
+	require.True(t, tresp.Succeeded)
+	kvs := tresp.Responses[0].GetResponseRange().Kvs
+	require.Len(t, kvs, 1)
+	require.Equal(t, "abc", string(kvs[0].Key))
+
+	ttl, err := nsLease.TimeToLive(t.Context(), lresp.ID, clientv3.WithAttachedKeys())
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("abc")}, ttl.Keys)
+
+	_, err = nsKV.Put(t.Context(), "abc", "w")
+	require.NoError(t, err)
+	wch := nsWatcher.Watch(t.Context(), "", clientv3.WithPrefix(), clientv3.WithRev(1), clientv3.WithPrevKV())
+	wr := <-wch
+	require.NoError(t, wr.Err())
+	require.Len(t, wr.Events, 2)
+	require.Equal(t, "abc", string(wr.Events[0].Kv.Key))
+	require.Equal(t, "abc", string(wr.Events[1].Kv.Key))
+	require.Equal(t, "abc", string(wr.Events[1].PrevKv.Key))
+}
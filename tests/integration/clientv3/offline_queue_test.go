@@ -0,0 +1,108 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestOfflineQueue(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	dir := t.TempDir()
+	var mu sync.Mutex
+	var dropped []string
+	newClient := func() *clientv3.Client {
+		cli, err := clientv3.New(
+			clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}, DialTimeout: 5 * time.Second},
+			clientv3.WithOfflineQueue(dir),
+			clientv3.WithOfflineQueueErrorHandler(func(op clientv3.Op, err error) {
+				assert.ErrorIs(t, err, clientv3.ErrOfflineWriteConflict)
+				mu.Lock()
+				defer mu.Unlock()
+				dropped = append(dropped, string(op.KeyBytes()))
+			}),
+		)
+		require.NoError(t, err)
+		return cli
+	}
+	cli := newClient()
+	kv := clus.Client(1)
+
+	for _, k := range []string{"conflict", "unchanged", "deleted"} {
+		_, err := cli.Put(t.Context(), k, "v0")
+		require.NoError(t, err)
+	}
+
+	clus.Members[0].Stop(t)
+	clus.WaitMembersForLeader(t, clus.Members[1:])
+	_, err := kv.Put(t.Context(), "conflict", "other")
+	require.NoError(t, err)
+
+	ops := []clientv3.Op{
+		clientv3.OpPut("conflict", "v1"),
+		clientv3.OpPut("unchanged", "v1"),
+		clientv3.OpPut("unchanged", "v2"),
+		clientv3.OpDelete("deleted"),
+		clientv3.OpPut("new", "v1"),
+	}
+	for _, op := range ops {
+		ctx, cancel := context.WithTimeout(t.Context(), 500*time.Millisecond)
+		_, err = cli.Do(ctx, op)
+		cancel()
+		require.ErrorIs(t, err, clientv3.ErrWriteQueued)
+	}
+	assert.Equal(t, len(ops), cli.OfflineQueue().Len())
+	// queued writes survive the restart of the client
+	require.NoError(t, cli.Close())
+
+	clus.Members[0].Restart(t)
+	cli = newClient()
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, cli.OfflineQueue().Flush(ctx))
+	assert.Equal(t, 0, cli.OfflineQueue().Len())
+
+	for k, want := range map[string]string{"conflict": "other", "unchanged": "v2", "deleted": "", "new": "v1"} {
+		resp, err := kv.Get(t.Context(), k)
+		require.NoError(t, err)
+		if want == "" {
+			assert.Empty(t, resp.Kvs, k)
+			continue
+		}
+		require.Len(t, resp.Kvs, 1, k)
+		assert.Equal(t, want, string(resp.Kvs[0].Value), k)
+	}
+	mu.Lock()
+	assert.Equal(t, []string{"conflict"}, dropped)
+	mu.Unlock()
+
+	// writes are not queued once the queue is empty
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestPersistentCache(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	dir := t.TempDir()
+	newClient := func() *clientv3.Client {
+		cli, err := clientv3.New(
+			clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}, DialTimeout: 5 * time.Second},
+			clientv3.WithPersistentCache(dir),
+			clientv3.WithPersistentCachePrefixes("config/"),
+		)
+		require.NoError(t, err)
+		return cli
+	}
+	get := func(cli *clientv3.Client, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+		return cli.Get(ctx, key, opts...)
+	}
+
+	cli := newClient()
+	for _, k := range []string{"config/a", "config/b", "other"} {
+		_, err := cli.Put(t.Context(), k, "v1")
+		require.NoError(t, err)
+	}
+	<-cli.PersistentCache().Revalidated()
+	resp, err := get(cli, "config/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 2)
+	rev := resp.Header.Revision
+	// the cache is saved when the client is closed
+	require.NoError(t, cli.Close())
+
+	// the cached prefix is served while the cluster is unreachable
+	clus.Members[0].Stop(t)
+	cli = newClient()
+	resp, err = get(cli, "config/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	assert.Equal(t, rev, resp.Header.Revision)
+	assert.Zero(t, resp.Header.MemberId)
+	require.Len(t, resp.Kvs, 2)
+	assert.Equal(t, "config/a", string(resp.Kvs[0].Key))
+	resp, err = get(cli, "config/b")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, "v1", string(resp.Kvs[0].Value))
+	_, err = get(cli, "other")
+	require.Error(t, err)
+
+	// the prefix is revalidated once the cluster is reachable
+	clus.Members[0].Restart(t)
+	kv, err := integration2.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	defer kv.Close()
+	_, err = kv.Put(t.Context(), "config/a", "v2")
+	require.NoError(t, err)
+	_, err = kv.Delete(t.Context(), "config/b")
+	require.NoError(t, err)
+	select {
+	case <-cli.PersistentCache().Revalidated():
+	case <-time.After(10 * time.Second):
+		t.Fatal("persistent cache not revalidated")
+	}
+	resp, err = get(cli, "config/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, "v2", string(resp.Kvs[0].Value))
+	assert.NotZero(t, resp.Header.MemberId)
+
+	// gets fall back to the cache once the cluster is unreachable again
+	clus.Members[0].Stop(t)
+	resp, err = get(cli, "config/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	assert.Equal(t, "v2", string(resp.Kvs[0].Value))
+	assert.Zero(t, resp.Header.MemberId)
+	require.NoError(t, cli.Close())
+}
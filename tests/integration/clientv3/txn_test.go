@@ -214,3 +214,130 @@ func TestTxnNested(t *testing.T) {
 		t.Errorf("unexpected Get response %+v", resp)
 	}
 }
+
+func TestTxnAppend(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	ctx := t.Context()
+
+	for _, entry := range []string{"a;", "b;", "c;"} {
+		tresp, err := kv.Txn(ctx).Then(clientv3.OpAppend("log", entry, clientv3.WithMaxSize(6))).Commit()
+		require.NoError(t, err)
+		require.Len(t, tresp.Responses, 1)
+		require.NotNil(t, tresp.Responses[0].GetResponseAppend())
+	}
+	resp, err := kv.Get(ctx, "log")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	require.Equal(t, "a;b;c;", string(resp.Kvs[0].Value))
+	require.Equal(t, int64(3), resp.Kvs[0].Version)
+
+	// the whole transaction fails once the value would outgrow max size
+	_, err = kv.Txn(ctx).Then(clientv3.OpPut("other", "x"), clientv3.OpAppend("log", "d;", clientv3.WithMaxSize(6))).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrValueTooLarge)
+	resp, err = kv.Get(ctx, "other")
+	require.NoError(t, err)
+	require.Empty(t, resp.Kvs)
+
+	// appends conflict with other writes to the same key
+	_, err = kv.Txn(ctx).Then(clientv3.OpPut("log", "x"), clientv3.OpAppend("log", "d;")).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrDuplicateKey)
+
+	oresp, err := kv.Do(ctx, clientv3.OpAppend("log", "d;", clientv3.WithPrevKV()))
+	require.NoError(t, err)
+	aresp := oresp.Append()
+	require.NotNil(t, aresp)
+	require.Equal(t, int64(8), aresp.ValueSize)
+	require.Equal(t, "a;b;c;", string(aresp.PrevKv.Value))
+	require.Equal(t, resp.Header.Revision+1, aresp.Header.Revision)
+}
+
+func TestTxnCompareTypedValue(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kv := clus.RandClient()
+	ctx := t.Context()
+
+	_, err := kv.Put(ctx, "quota", "9")
+	require.NoError(t, err)
+	_, err = kv.Put(ctx, "version", "v3.10.1")
+	require.NoError(t, err)
+
+	// "9" < "10" bytewise, but not as integers
+	tresp, err := kv.Txn(ctx).If(clientv3.Compare(clientv3.ValueInt("quota"), "<", 10)).Then(clientv3.OpPut("quota", "10")).Commit()
+	require.NoError(t, err)
+	require.True(t, tresp.Succeeded)
+	tresp, err = kv.Txn(ctx).If(clientv3.Compare(clientv3.ValueInt("quota"), "<", 10)).Then(clientv3.OpPut("quota", "11")).Commit()
+	require.NoError(t, err)
+	require.False(t, tresp.Succeeded)
+
+	tresp, err = kv.Txn(ctx).If(clientv3.Compare(clientv3.ValueSemver("version"), ">", "3.9.0")).Commit()
+	require.NoError(t, err)
+	require.True(t, tresp.Succeeded)
+
+	_, err = kv.Txn(ctx).If(clientv3.Compare(clientv3.ValueSemver("version"), ">", "3.9")).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrInvalidSemver)
+}
+
+func TestTxnForEach(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kv := clus.Client(0)
+	ctx := t.Context()
+
+	for _, k := range []string{"cfg/a", "cfg/b", "cfg/c"} {
+		_, err := kv.Put(ctx, k, "v1")
+		require.NoError(t, err)
+	}
+	_, err := kv.Put(ctx, "cfg/b", "v1")
+	require.NoError(t, err)
+
+	// update every key of the prefix that was written only once
+	cmps := []clientv3.Cmp{clientv3.Compare(clientv3.Version(""), "=", 1)}
+	op := clientv3.OpForEach("cfg/", cmps, []clientv3.Op{clientv3.OpPut("", "v2")}, clientv3.WithPrefix(), clientv3.WithMaxKeys(3))
+	tresp, err := kv.Txn(ctx).Then(op).Commit()
+	require.NoError(t, err)
+	fr := tresp.Responses[0].GetResponseForEach()
+	require.NotNil(t, fr)
+	require.Equal(t, int64(3), fr.Count)
+	require.Equal(t, [][]byte{[]byte("cfg/a"), []byte("cfg/c")}, fr.Keys)
+
+	resp, err := kv.Get(ctx, "cfg/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 3)
+	for i, v := range []string{"v2", "v1", "v2"} {
+		require.Equal(t, v, string(resp.Kvs[i].Value))
+	}
+	// all updates share the revision of the transaction
+	require.Equal(t, tresp.Header.Revision, resp.Kvs[0].ModRevision)
+	require.Equal(t, tresp.Header.Revision, resp.Kvs[2].ModRevision)
+
+	// the range may not grow past max keys
+	op = clientv3.OpForEach("cfg/", nil, []clientv3.Op{clientv3.OpDelete("")}, clientv3.WithPrefix(), clientv3.WithMaxKeys(2))
+	_, err = kv.Txn(ctx).Then(op).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrForEachTooManyKeys)
+
+	// foreach conflicts with other writes in its range
+	op = clientv3.OpForEach("cfg/", nil, []clientv3.Op{clientv3.OpDelete("")}, clientv3.WithPrefix(), clientv3.WithMaxKeys(3))
+	_, err = kv.Txn(ctx).Then(clientv3.OpPut("cfg/d", "x"), op).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrDuplicateKey)
+
+	oresp, err := kv.Do(ctx, op)
+	require.NoError(t, err)
+	require.NotNil(t, oresp.ForEach())
+	require.Len(t, oresp.ForEach().Keys, 3)
+	require.Equal(t, resp.Header.Revision+1, oresp.ForEach().Header.Revision)
+	resp, err = kv.Get(ctx, "cfg/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Empty(t, resp.Kvs)
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/client/pkg/v3/testutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
@@ -39,9 +40,9 @@ func TestWatchFragmentDisable(t *testing.T) {
 // TestWatchFragmentDisableWithGRPCLimit verifies
 // large watch response exceeding server-side request
 // limit and client-side gRPC response receive limit
-// cannot arrive without watch events fragmentation,
-// because multiple events exceed client-side gRPC
-// response receive limit.
+// arrive without explicit watch events fragmentation,
+// because the client negotiates its receive limit as
+// the maximum watch response size.
 func TestWatchFragmentDisableWithGRPCLimit(t *testing.T) {
 	testWatchFragment(t, false, true)
 }
@@ -101,14 +102,6 @@ func testWatchFragment(t *testing.T, fragment, exceedRecvLimit bool) {
 	// expect 10 MiB watch response
 	select {
 	case ws := <-wch:
-		// without fragment, should exceed gRPC client receive limit
-		if !fragment && exceedRecvLimit {
-			require.Emptyf(t, ws.Events, "expected 0 events with watch fragmentation")
-			exp := "code = ResourceExhausted desc = grpc: received message larger than max ("
-			require.Containsf(t, ws.Err().Error(), exp, "expected 'ResourceExhausted' error")
-			return
-		}
-
 		// still expect merged watch events
 		require.Lenf(t, ws.Events, 10, "expected 10 events with watch fragmentation")
 		require.NoErrorf(t, ws.Err(), "unexpected error")
@@ -117,3 +110,107 @@ func testWatchFragment(t *testing.T, fragment, exceedRecvLimit bool) {
 		t.Fatalf("took too long to receive events")
 	}
 }
+
+// TestWatchMaxResponseBytes ensures the server fragments watch responses
+// at the size negotiated by the watcher and reports it on creation.
+func TestWatchMaxResponseBytes(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	for i := 0; i < 10; i++ {
+		_, err := cli.Put(t.Context(), fmt.Sprint("foo", i), strings.Repeat("a", 10*1024))
+		require.NoError(t, err)
+	}
+
+	// the watcher delivers the reassembled response
+	wch := cli.Watch(t.Context(), "foo", clientv3.WithPrefix(), clientv3.WithRev(1), clientv3.WithMaxResponseBytes(32*1024))
+	select {
+	case ws := <-wch:
+		require.NoError(t, ws.Err())
+		require.Len(t, ws.Events, 10)
+	case <-time.After(testutil.RequestTimeout):
+		t.Fatalf("took too long to receive events")
+	}
+
+	// the raw stream receives the fragments
+	wc, err := pb.NewWatchClient(cli.ActiveConnection()).Watch(t.Context())
+	require.NoError(t, err)
+	err = wc.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{CreateRequest: &pb.WatchCreateRequest{
+		Key:              []byte("foo"),
+		RangeEnd:         []byte("fop"),
+		StartRevision:    1,
+		MaxResponseBytes: 32 * 1024,
+	}}})
+	require.NoError(t, err)
+	resp, err := wc.Recv()
+	require.NoError(t, err)
+	require.True(t, resp.Created)
+	require.Equal(t, int64(32*1024), resp.MaxResponseBytes)
+
+	a := clientv3.NewWatchResponseAssembler(0)
+	fragments := 0
+	for {
+		resp, err = wc.Recv()
+		require.NoError(t, err)
+		require.LessOrEqual(t, resp.Size(), 32*1024)
+		fragments++
+		full, err := a.Add(resp)
+		require.NoError(t, err)
+		if full != nil {
+			require.Len(t, full.Events, 10)
+			break
+		}
+	}
+	require.Greater(t, fragments, 1)
+}
+
+// TestWatchMaxReassemblyBytes ensures a watcher is canceled when a fragmented
+// response exceeds the client reassembly limit.
+func TestWatchMaxReassemblyBytes(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	for i := 0; i < 10; i++ {
+		_, err := clus.Client(0).Put(t.Context(), fmt.Sprint("foo", i), strings.Repeat("a", 10*1024))
+		require.NoError(t, err)
+	}
+
+	cli, err := integration2.NewClient(t, clientv3.Config{
+		Endpoints:               []string{clus.Members[0].GRPCURL},
+		WatchMaxResponseBytes:   32 * 1024,
+		WatchMaxReassemblyBytes: 64 * 1024,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	wch := cli.Watch(t.Context(), "foo", clientv3.WithPrefix(), clientv3.WithRev(1))
+	select {
+	case ws := <-wch:
+		require.ErrorIs(t, ws.Err(), clientv3.ErrWatchResponseTooLarge)
+		require.True(t, ws.Canceled)
+		require.Empty(t, ws.Events)
+	case <-time.After(testutil.RequestTimeout):
+		t.Fatalf("took too long to cancel the watcher")
+	}
+	select {
+	case _, ok := <-wch:
+		require.False(t, ok)
+	case <-time.After(testutil.RequestTimeout):
+		t.Fatalf("took too long to close the watch channel")
+	}
+
+	// a small enough response is delivered
+	wch = cli.Watch(t.Context(), "foo1", clientv3.WithRev(1))
+	select {
+	case ws := <-wch:
+		require.NoError(t, ws.Err())
+		require.Len(t, ws.Events, 1)
+	case <-time.After(testutil.RequestTimeout):
+		t.Fatalf("took too long to receive events")
+	}
+}
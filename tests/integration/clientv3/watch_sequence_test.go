@@ -0,0 +1,110 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/watchutil"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchSequenceTokens ensures the events of a watch channel are numbered
+// consecutively, also after the watch resumes on a new stream.
+func TestWatchSequenceTokens(t *testing.T) {
+	integration2.BeforeTest(t)
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	wch := cli.Watch(t.Context(), "k", clientv3.WithPrefix())
+	_, err := cli.Txn(t.Context()).Then(clientv3.OpPut("k1", "v"), clientv3.OpPut("k2", "v"), clientv3.OpPut("k3", "v")).Commit()
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "k4", "v")
+	require.NoError(t, err)
+
+	next := int64(1)
+	receive := func(n int) {
+		for n > 0 {
+			select {
+			case resp := <-wch:
+				require.NoError(t, resp.Err())
+				require.NotEmpty(t, resp.Events)
+				assert.Equal(t, next, resp.FirstSequence)
+				next += int64(len(resp.Events))
+				n -= len(resp.Events)
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for events")
+			}
+		}
+	}
+	receive(4)
+
+	clus.Members[0].Stop(t)
+	clus.Members[0].Restart(t)
+	clus.WaitLeader(t)
+	_, err = cli.Put(t.Context(), "k5", "v")
+	require.NoError(t, err)
+	receive(1)
+}
+
+// TestWatchDedupRestart ensures a consumer restarting its watch from a
+// checkpoint in the middle of a transaction receives every event once.
+func TestWatchDedupRestart(t *testing.T) {
+	integration2.BeforeTest(t)
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	d := watchutil.NewDedup(watchutil.Checkpoint{})
+	ctx, cancel := context.WithCancel(t.Context())
+	wch := d.Watch(ctx, cli, "k", clientv3.WithPrefix())
+	_, err := cli.Txn(t.Context()).Then(clientv3.OpPut("k1", "v"), clientv3.OpPut("k2", "v"), clientv3.OpPut("k3", "v")).Commit()
+	require.NoError(t, err)
+
+	var keys []string
+	resp := <-wch
+	require.NoError(t, resp.Err())
+	require.Len(t, resp.Events, 3)
+	// the consumer stops after processing the first event
+	keys = append(keys, string(resp.Events[0].Kv.Key))
+	cp := watchutil.Checkpoint{Revision: resp.Events[0].Kv.ModRevision, Events: 1}
+	cancel()
+
+	_, err = cli.Put(t.Context(), "k4", "v")
+	require.NoError(t, err)
+
+	d = watchutil.NewDedup(cp)
+	wch = d.Watch(t.Context(), cli, "k", clientv3.WithPrefix())
+	for len(keys) < 4 {
+		select {
+		case resp = <-wch:
+			require.NoError(t, resp.Err())
+			for _, ev := range resp.Events {
+				keys = append(keys, string(ev.Kv.Key))
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+	assert.Equal(t, []string{"k1", "k2", "k3", "k4"}, keys)
+	assert.Equal(t, cp.Revision+1, d.Checkpoint().Revision)
+}
@@ -22,16 +22,20 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	mvccpb "go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/watchutil"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3rpc"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
 )
@@ -251,6 +255,92 @@ func testWatchReconnRunning(t *testing.T, wctx *watchctx) {
 	putAndWatch(t, wctx, "a", "b")
 }
 
+// TestWatchReconnBulkResume ensures watchers are recreated with bulk requests
+// after the watch stream reconnects.
+func TestWatchReconnBulkResume(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, UseBridge: true})
+	defer clus.Terminate(t)
+
+	var mu sync.Mutex
+	var bulkCreates []int
+	countBulk := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &watchRequestStream{ClientStream: cs, onSend: func(req *pb.WatchRequest) {
+			if br := req.GetBulkRequest(); br != nil {
+				mu.Lock()
+				bulkCreates = append(bulkCreates, len(br.CreateRequests))
+				mu.Unlock()
+			}
+		}}, nil
+	}
+	cli, err := integration2.NewClient(t, clientv3.Config{
+		Endpoints:   []string{clus.Members[0].GRPCURL},
+		DialOptions: []grpc.DialOption{grpc.WithChainStreamInterceptor(countBulk)},
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	const watchers = 100
+	chs := make([]clientv3.WatchChan, watchers)
+	for i := range chs {
+		chs[i] = cli.Watch(t.Context(), fmt.Sprintf("key-%d", i), clientv3.WithCreatedNotify())
+		wresp := <-chs[i]
+		require.Truef(t, wresp.Created, "expected created response, got %+v", wresp)
+	}
+
+	clus.Members[0].Bridge().DropConnections()
+
+	for i := range chs {
+		key := fmt.Sprintf("key-%d", i)
+		// the connection or the watcher may not have been recreated yet, so
+		// keep writing until the event is observed.
+		for {
+			if _, err = cli.Put(t.Context(), key, "v"); err != nil {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			select {
+			case wresp, ok := <-chs[i]:
+				require.Truef(t, ok, "watcher %d closed unexpectedly", i)
+				require.NoError(t, wresp.Err())
+				if len(wresp.Events) == 0 {
+					continue
+				}
+				require.Equal(t, key, string(wresp.Events[0].Kv.Key))
+			case <-time.After(time.Second):
+				continue
+			}
+			break
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	total := 0
+	for _, n := range bulkCreates {
+		total += n
+	}
+	require.NotEmptyf(t, bulkCreates, "expected watchers to be resumed with bulk requests")
+	require.Greaterf(t, total, 1, "expected bulk requests to carry several creates, got %v", bulkCreates)
+}
+
+type watchRequestStream struct {
+	grpc.ClientStream
+	onSend func(*pb.WatchRequest)
+}
+
+func (s *watchRequestStream) SendMsg(m any) error {
+	if req, ok := m.(*pb.WatchRequest); ok {
+		s.onSend(req)
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
 // TestWatchCancelImmediate ensures a closed channel is returned
 // if the context is cancelled.
 func TestWatchCancelImmediate(t *testing.T) {
@@ -498,6 +588,53 @@ func TestWatchCompactRevision(t *testing.T) {
 	}
 }
 
+// TestWatchWithRecovery ensures that WatchWithRecovery relists a compacted
+// range and resumes the watch after the listed revision.
+func TestWatchWithRecovery(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	for _, k := range []string{"foo/a", "foo/b", "foo/a", "zoo"} {
+		_, err := cli.Put(t.Context(), k, "v")
+		require.NoError(t, err)
+	}
+	resp, err := cli.Delete(t.Context(), "foo/b")
+	require.NoError(t, err)
+	_, err = cli.Compact(t.Context(), resp.Header.Revision)
+	require.NoError(t, err)
+
+	relistc := make(chan []*mvccpb.KeyValue, 1)
+	wch := cli.WatchWithRecovery(t.Context(), "foo/", func(kvs []*mvccpb.KeyValue) {
+		relistc <- kvs
+	}, clientv3.WithPrefix(), clientv3.WithRev(2))
+
+	select {
+	case kvs := <-relistc:
+		require.Len(t, kvs, 1)
+		require.Equal(t, "foo/a", string(kvs[0].Key))
+		require.Equal(t, int64(4), kvs[0].ModRevision)
+	case wresp := <-wch:
+		t.Fatalf("expected relist, got %+v", wresp)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for relist")
+	}
+
+	presp, err := cli.Put(t.Context(), "foo/c", "v")
+	require.NoError(t, err)
+	select {
+	case wresp := <-wch:
+		require.NoError(t, wresp.Err())
+		require.Len(t, wresp.Events, 1)
+		require.Equal(t, "foo/c", string(wresp.Events[0].Kv.Key))
+		require.Equal(t, presp.Header.Revision, wresp.Events[0].Kv.ModRevision)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
 func TestWatchWithProgressNotify(t *testing.T)        { testWatchWithProgressNotify(t, true) }
 func TestWatchWithProgressNotifyNoEvent(t *testing.T) { testWatchWithProgressNotify(t, false) }
 
@@ -582,6 +719,37 @@ func TestConfigurableWatchProgressNotifyInterval(t *testing.T) {
 	}
 }
 
+func TestWatchProgressNotifyServerTime(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, WatchProgressNotifyInterval: 200 * time.Millisecond})
+	defer clus.Terminate(t)
+
+	rch := clus.RandClient().Watch(t.Context(), "foo", clientv3.WithProgressNotify())
+	staleness := watchutil.NewStaleness()
+	var last clientv3.WatchResponse
+	for i := 0; i < 2; i++ {
+		select {
+		case resp := <-rch:
+			require.True(t, resp.IsProgressNotify())
+			// the member and the client share the same clock
+			require.WithinDuration(t, time.Now(), resp.ServerTime, 5*time.Second)
+			require.False(t, resp.ReceivedTime.Before(resp.ServerTime))
+			require.Greater(t, resp.ServerMonotonicTime, last.ServerMonotonicTime)
+			staleness.Observe(resp)
+			last = resp
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watch progress notify response")
+		}
+	}
+	offset, ok := staleness.ClockOffset()
+	require.True(t, ok)
+	require.GreaterOrEqual(t, offset, time.Duration(0))
+	d, ok := staleness.Estimate()
+	require.True(t, ok)
+	require.Less(t, d, 5*time.Second)
+}
+
 func TestWatchRequestProgress(t *testing.T) {
 	if integration2.ThroughProxy {
 		t.Skipf("grpc-proxy does not support WatchProgress yet")
@@ -1133,3 +1301,48 @@ func testWatchClose(t *testing.T, wctx *watchctx) {
 	wresp, ok := <-wch
 	require.Falsef(t, ok, "read wch got %v; expected closed channel", wresp)
 }
+
+// TestWatchInitialState ensures WithInitialState first delivers the current
+// key-value pairs, and that a watcher resumed after the initial state is
+// complete only receives the following events.
+func TestWatchInitialState(t *testing.T) {
+	integration2.BeforeTest(t)
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.Client(0)
+
+	for _, k := range []string{"a/1", "a/2", "b"} {
+		_, err := cli.Put(t.Context(), k, "v")
+		require.NoError(t, err)
+	}
+
+	wch := cli.Watch(t.Context(), "a/", clientv3.WithPrefix(), clientv3.WithInitialState())
+	var keys []string
+	for more := true; more; {
+		resp := <-wch
+		require.NoError(t, resp.Err())
+		require.True(t, resp.InitialState)
+		require.False(t, resp.IsProgressNotify())
+		for _, ev := range resp.Events {
+			keys = append(keys, string(ev.Kv.Key))
+		}
+		more = resp.InitialStateMore
+	}
+	require.Equal(t, []string{"a/1", "a/2"}, keys)
+
+	clus.Members[0].Stop(t)
+	require.NoError(t, clus.Members[0].Restart(t))
+	clus.WaitLeader(t)
+
+	_, err := cli.Put(t.Context(), "a/3", "v")
+	require.NoError(t, err)
+	select {
+	case resp := <-wch:
+		require.NoError(t, resp.Err())
+		require.False(t, resp.InitialState)
+		require.Len(t, resp.Events, 1)
+		require.Equal(t, "a/3", string(resp.Events[0].Kv.Key))
+	case <-time.After(10 * time.Second):
+		t.Fatal("took too long to receive the event after the initial state")
+	}
+}
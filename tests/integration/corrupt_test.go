@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/storage/mvcc/testutil"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
@@ -231,3 +232,73 @@ func TestCompactHashCheckDetectMultipleCorruption(t *testing.T) {
 
 	require.Equal(t, expectedAlarmMap, actualAlarmMap)
 }
+
+func TestReseedRepairsCorruption(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cc, err := clus.ClusterClient(t)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	for i := 0; i < 10; i++ {
+		_, err = cc.Put(ctx, testutil.PickKey(int64(i)), fmt.Sprint(i))
+		require.NoErrorf(t, err, "error on put")
+	}
+
+	clus.Members[0].Stop(t)
+	clus.WaitLeader(t)
+
+	err = testutil.CorruptBBolt(clus.Members[0].BackendPath())
+	require.NoError(t, err)
+
+	err = clus.Members[0].Restart(t)
+	require.NoError(t, err)
+	leader := clus.WaitLeader(t)
+	if leader == 0 {
+		target := clus.Members[1].ID()
+		err = clus.Members[0].Server.MoveLeader(ctx, uint64(clus.Members[0].ID()), uint64(target))
+		require.NoError(t, err)
+		leader = clus.WaitMembersForLeader(t, clus.Members)
+		require.NotEqual(t, 0, leader)
+	}
+
+	err = clus.Members[leader].Server.CorruptionChecker().PeriodicCheck()
+	require.NoErrorf(t, err, "error on periodic check")
+	time.Sleep(50 * time.Millisecond)
+	alarmResponse, err := cc.AlarmList(ctx)
+	require.NoErrorf(t, err, "error on alarm list")
+	require.Equal(t, []*etcdserverpb.AlarmMember{{Alarm: etcdserverpb.AlarmType_CORRUPT, MemberID: uint64(clus.Members[0].ID())}}, alarmResponse.Alarms)
+
+	_, err = cc.Reseed(ctx, clus.Members[leader].GRPCURL)
+	require.ErrorIs(t, err, rpctypes.ErrReseedLeader)
+
+	resp, err := cc.Reseed(ctx, clus.Members[0].GRPCURL)
+	require.NoError(t, err)
+	require.Equal(t, uint64(clus.Members[leader].ID()), resp.Leader)
+	require.Positive(t, resp.DbSize)
+
+	alarmResponse, err = cc.AlarmList(ctx)
+	require.NoErrorf(t, err, "error on alarm list")
+	assert.Empty(t, alarmResponse.Alarms)
+
+	_, err = cc.Put(ctx, testutil.PickKey(10), "10")
+	require.NoError(t, err)
+	err = clus.Members[leader].Server.CorruptionChecker().PeriodicCheck()
+	require.NoErrorf(t, err, "error on periodic check")
+	time.Sleep(50 * time.Millisecond)
+	alarmResponse, err = cc.AlarmList(ctx)
+	require.NoErrorf(t, err, "error on alarm list")
+	assert.Empty(t, alarmResponse.Alarms)
+
+	for i := 0; i <= 10; i++ {
+		want, gerr := clus.Client(leader).Get(ctx, testutil.PickKey(int64(i)), clientv3.WithSerializable())
+		require.NoError(t, gerr)
+		got, gerr := clus.Client(0).Get(ctx, testutil.PickKey(int64(i)), clientv3.WithSerializable())
+		require.NoError(t, gerr)
+		assert.Equal(t, want.Kvs, got.Kvs)
+	}
+}
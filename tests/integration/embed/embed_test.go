@@ -31,10 +31,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/client/pkg/v3/testutil"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/embed"
+	"go.etcd.io/etcd/server/v3/etcdserver"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
 	"go.etcd.io/etcd/tests/v3/framework/testutils"
 )
@@ -175,6 +177,42 @@ func testEmbedEtcdGracefulStop(t *testing.T, secure bool) {
 	require.NoError(t, err)
 }
 
+// TestEmbedEtcdListenerConfigs ensures a client listener can serve TLS with
+// its own config while the other listeners do not.
+func TestEmbedEtcdListenerConfigs(t *testing.T) {
+	testutil.SkipTestIfShortMode(t, "Cannot start embedded cluster in --short tests")
+
+	cfg := embed.NewConfig()
+	urls := newEmbedURLs(false, 2)
+	secureURL := newEmbedURLs(true, 3)[2]
+	setupEmbedCfg(cfg, []url.URL{urls[0], secureURL}, []url.URL{urls[1]})
+	cfg.ListenerConfigs = []embed.ListenerConfig{{
+		URL:            secureURL.String(),
+		Name:           "secure",
+		MaxConnections: 8,
+		TLSInfo:        testTLSInfo,
+	}}
+	cfg.Dir = filepath.Join(t.TempDir(), "embed-etcd")
+
+	e, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	defer e.Close()
+	<-e.Server.ReadyNotify()
+
+	tls, err := testTLSInfo.ClientConfig()
+	require.NoError(t, err)
+	for _, clientCfg := range []clientv3.Config{
+		{Endpoints: []string{urls[0].String()}},
+		{Endpoints: []string{secureURL.String()}, TLS: tls},
+	} {
+		cli, err := integration2.NewClient(t, clientCfg)
+		require.NoError(t, err)
+		_, err = cli.Put(t.Context(), "foo", "bar")
+		require.NoError(t, err)
+		cli.Close()
+	}
+}
+
 func newEmbedURLs(secure bool, n int) (urls []url.URL) {
 	scheme := "unix"
 	if secure {
@@ -258,3 +296,76 @@ func TestEmbedEtcdStopDuringBootstrapping(t *testing.T) {
 		t.Error("timeout in bootstrapping etcd")
 	}
 }
+
+func TestEmbedEtcdEventHandlers(t *testing.T) {
+	cfg := embed.NewConfig()
+	urls := newEmbedURLs(false, 2)
+	setupEmbedCfg(cfg, []url.URL{urls[0]}, []url.URL{urls[1]})
+	cfg.Dir = filepath.Join(t.TempDir(), "embed-etcd")
+
+	events := make(chan etcdserver.Event, 16)
+	cfg.EventHandlers = []etcdserver.EventHandler{func(ev etcdserver.Event) { events <- ev }}
+	e, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	defer e.Close()
+	<-e.Server.ReadyNotify()
+
+	// waitEvent skips the events of other types, such as the addition of
+	// the member while the cluster bootstraps
+	waitEvent := func(typ etcdserver.EventType) etcdserver.Event {
+		for {
+			select {
+			case ev := <-events:
+				if ev.Type == typ {
+					return ev
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatalf("timed out waiting for %v", typ)
+			}
+		}
+	}
+	ev := waitEvent(etcdserver.EventBecameLeader)
+	assert.NotZero(t, ev.Term)
+
+	defrags := make(chan etcdserver.Event, 2)
+	cancel := e.OnEvent(func(ev etcdserver.Event) { defrags <- ev }, etcdserver.EventDefragStarted, etcdserver.EventDefragFinished)
+	defer cancel()
+
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: []string{urls[0].String()}})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	resp, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	_, err = cli.Compact(t.Context(), resp.Header.Revision, clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+	ev = waitEvent(etcdserver.EventCompactionFinished)
+	assert.Equal(t, resp.Header.Revision, ev.Revision)
+
+	_, err = cli.Defragment(t.Context(), urls[0].String())
+	require.NoError(t, err)
+	for _, typ := range []etcdserver.EventType{etcdserver.EventDefragStarted, etcdserver.EventDefragFinished} {
+		ev = waitEvent(typ)
+		require.NoError(t, ev.Err)
+		assert.Equal(t, typ, (<-defrags).Type)
+	}
+
+	member, err := cli.MemberAddAsLearner(t.Context(), []string{"http://127.0.0.1:1"})
+	require.NoError(t, err)
+	ev = waitEvent(etcdserver.EventMemberAdded)
+	assert.Equal(t, member.Member.ID, uint64(ev.MemberID))
+	_, err = cli.MemberRemove(t.Context(), member.Member.ID)
+	require.NoError(t, err)
+	ev = waitEvent(etcdserver.EventMemberRemoved)
+	assert.Equal(t, member.Member.ID, uint64(ev.MemberID))
+
+	_, err = e.Server.Alarm(t.Context(), &pb.AlarmRequest{
+		Action:   pb.AlarmRequest_ACTIVATE,
+		MemberID: uint64(e.Server.MemberID()),
+		Alarm:    pb.AlarmType_NOSPACE,
+	})
+	require.NoError(t, err)
+	ev = waitEvent(etcdserver.EventAlarmRaised)
+	assert.Equal(t, pb.AlarmType_NOSPACE, ev.Alarm)
+	assert.Equal(t, e.Server.MemberID(), ev.MemberID)
+}
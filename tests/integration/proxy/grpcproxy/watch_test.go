@@ -0,0 +1,200 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchProxyOverlappingRanges ensures watchers with overlapping ranges
+// share upstream watches and still receive exactly the events of their own
+// range while the proxy merges and splits the upstream watches.
+func TestWatchProxyOverlappingRanges(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	wts := newWatchProxyServer([]string{clus.Members[0].GRPCURL}, t)
+	defer wts.close()
+
+	client, err := integration2.NewClient(t, clientv3.Config{
+		Endpoints:   []string{wts.l.Addr().String()},
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+	kv := clus.Client(0)
+
+	watchers := map[string]*rangeWatcher{
+		"prefix a": newRangeWatcher(t, client, kv, "a", clientv3.WithPrefix()),
+		"a5 to c":  newRangeWatcher(t, client, kv, "a5", clientv3.WithRange("c")),
+		"c1":       newRangeWatcher(t, client, kv, "c1"),
+		"z":        newRangeWatcher(t, client, kv, "z"),
+	}
+	keys := []string{"a1", "a6", "b1", "c1", "c2", "q", "z"}
+	// put while the upstream watches are being merged
+	putKeys(t, kv, keys, 20)
+	checkWatchers(t, watchers, keys, 20)
+	// [a, c), c1 and z
+	waitWatcherCounts(t, 3, 4)
+
+	// removing the watcher spanning the prefix narrows the merged range
+	// and adding a watcher of all keys merges all ranges into one
+	watchers["a5 to c"].cancel()
+	delete(watchers, "a5 to c")
+	watchers["all"] = newRangeWatcher(t, client, kv, "\x00", clientv3.WithFromKey())
+	putKeys(t, kv, keys, 20)
+	checkWatchers(t, watchers, keys, 20)
+	waitWatcherCounts(t, 1, 4)
+}
+
+type rangeWatcher struct {
+	op     clientv3.Op
+	wch    clientv3.WatchChan
+	cancel context.CancelFunc
+}
+
+// newRangeWatcher watches through c the events following the current
+// revision of kv.
+func newRangeWatcher(t *testing.T, c, kv *clientv3.Client, key string, opts ...clientv3.OpOption) *rangeWatcher {
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+	resp, err := kv.Get(ctx, "foo")
+	require.NoError(t, err)
+	opts = append(opts, clientv3.WithRev(resp.Header.Revision+1))
+	return &rangeWatcher{
+		op:     clientv3.OpGet(key, opts...),
+		wch:    c.Watch(ctx, key, opts...),
+		cancel: cancel,
+	}
+}
+
+func (rw *rangeWatcher) contains(key string) bool {
+	begin, end := string(rw.op.KeyBytes()), string(rw.op.RangeBytes())
+	switch {
+	case len(end) == 0:
+		return key == begin
+	case end == "\x00":
+		return key >= begin
+	default:
+		return key >= begin && key < end
+	}
+}
+
+func putKeys(t *testing.T, kv *clientv3.Client, keys []string, rounds int) {
+	for i := 0; i < rounds; i++ {
+		for _, k := range keys {
+			_, err := kv.Put(t.Context(), k, "v")
+			require.NoError(t, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func checkWatchers(t *testing.T, watchers map[string]*rangeWatcher, keys []string, rounds int) {
+	for name, rw := range watchers {
+		var want []string
+		for i := 0; i < rounds; i++ {
+			for _, k := range keys {
+				if rw.contains(k) {
+					want = append(want, k)
+				}
+			}
+		}
+		var got []string
+		var lastRev int64
+		timeout := time.After(10 * time.Second)
+		for len(got) < len(want) {
+			select {
+			case resp := <-rw.wch:
+				require.NoError(t, resp.Err(), name)
+				for _, ev := range resp.Events {
+					require.Greater(t, ev.Kv.ModRevision, lastRev, name)
+					lastRev = ev.Kv.ModRevision
+					got = append(got, string(ev.Kv.Key))
+				}
+			case <-timeout:
+				t.Fatalf("%s: timed out, got %d of %d events", name, len(got), len(want))
+			}
+		}
+		assert.Equal(t, want, got, name)
+	}
+}
+
+func waitWatcherCounts(t *testing.T, upstream, downstream float64) {
+	assert.Eventually(t, func() bool {
+		u, d := proxyMetric(t, "etcd_grpc_proxy_upstream_watchers"), proxyMetric(t, "etcd_grpc_proxy_downstream_watchers")
+		return u == upstream && d == downstream
+	}, 10*time.Second, 10*time.Millisecond, "upstream %v, downstream %v", upstream, downstream)
+}
+
+func proxyMetric(t *testing.T, name string) float64 {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, mf := range mfs {
+		if mf.GetName() == name {
+			return mf.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	return 0
+}
+
+type watchProxyTestServer struct {
+	c      *clientv3.Client
+	server *grpc.Server
+	l      net.Listener
+}
+
+func (wts *watchProxyTestServer) close() {
+	wts.server.Stop()
+	wts.l.Close()
+	wts.c.Close()
+}
+
+func newWatchProxyServer(endpoints []string, t *testing.T) *watchProxyTestServer {
+	client, err := integration2.NewClient(t, clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	wp, _ := grpcproxy.NewWatchProxy(client.Ctx(), zaptest.NewLogger(t), client)
+
+	wts := &watchProxyTestServer{c: client}
+	wts.server = grpc.NewServer()
+	pb.RegisterWatchServer(wts.server, wp)
+
+	wts.l, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go wts.server.Serve(wts.l)
+
+	return wts
+}
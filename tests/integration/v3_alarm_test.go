@@ -16,6 +16,7 @@ package integration
 
 import (
 	"context"
+	"math"
 	"os"
 	"path/filepath"
 	"sync"
@@ -27,6 +28,7 @@ import (
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/lease/leasepb"
 	"go.etcd.io/etcd/server/v3/storage/backend"
@@ -158,6 +160,64 @@ func TestV3AlarmDeactivate(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestV3WALNoSpaceAlarm ensures a member whose WAL is low on disk space
+// raises the WALNOSPACE alarm, which makes the cluster reject writes while
+// reads are served, and clears it once the space is available again.
+func TestV3WALNoSpaceAlarm(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 2})
+	defer clus.Terminate(t)
+
+	// no disk has that much free space
+	clus.Members[0].WALDiskFreeThreshold = math.MaxUint64
+	clus.Members[0].Stop(t)
+	clus.Members[0].Restart(t)
+	clus.WaitMembersForLeader(t, clus.Members)
+	kvc0 := integration.ToGRPC(clus.Client(0)).KV
+	kvc1 := integration.ToGRPC(clus.Client(1)).KV
+	waitForRestart(t, kvc0)
+	waitWALNoSpaceAlarm(t, clus.Members[1], true)
+
+	key := []byte("foo")
+	// rejected early by the member low on disk space, and on apply by others
+	_, err := kvc0.Put(t.Context(), &pb.PutRequest{Key: key, Value: []byte("bar")})
+	require.ErrorContains(t, err, rpctypes.ErrorDesc(rpctypes.ErrGRPCWALNoSpace))
+	_, err = kvc1.Put(t.Context(), &pb.PutRequest{Key: key, Value: []byte("bar")})
+	require.ErrorContains(t, err, rpctypes.ErrorDesc(rpctypes.ErrGRPCWALNoSpace))
+	_, err = integration.ToGRPC(clus.Client(1)).Lease.LeaseGrant(t.Context(), &pb.LeaseGrantRequest{TTL: 10})
+	require.ErrorContains(t, err, rpctypes.ErrorDesc(rpctypes.ErrGRPCWALNoSpace))
+
+	_, err = kvc0.Range(t.Context(), &pb.RangeRequest{Key: key})
+	require.NoError(t, err)
+	_, err = kvc1.Range(t.Context(), &pb.RangeRequest{Key: key})
+	require.NoError(t, err)
+
+	clus.Members[0].WALDiskFreeThreshold = 1
+	clus.Members[0].Stop(t)
+	clus.Members[0].Restart(t)
+	clus.WaitMembersForLeader(t, clus.Members)
+	waitWALNoSpaceAlarm(t, clus.Members[1], false)
+
+	_, err = kvc1.Put(t.Context(), &pb.PutRequest{Key: key, Value: []byte("bar")})
+	require.NoError(t, err)
+}
+
+func waitWALNoSpaceAlarm(t *testing.T, m *integration.Member, active bool) {
+	stopc := time.After(10 * time.Second)
+	for {
+		resp, err := m.Server.Alarm(t.Context(), &pb.AlarmRequest{Action: pb.AlarmRequest_GET, Alarm: pb.AlarmType_WALNOSPACE})
+		require.NoError(t, err)
+		if (len(resp.Alarms) != 0) == active {
+			return
+		}
+		select {
+		case <-stopc:
+			t.Fatalf("timed out waiting for WALNOSPACE alarm to be active=%v", active)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
 func TestV3CorruptAlarm(t *testing.T) {
 	integration.BeforeTest(t)
 	lg := zaptest.NewLogger(t)
@@ -314,3 +374,56 @@ func TestV3CorruptAlarmWithLeaseCorrupted(t *testing.T) {
 		t.Fatalf("expected %v, got %+v (%v)", rpctypes.ErrCorrupt, presp, perr)
 	}
 }
+
+func TestV3ThresholdAlarm(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	_, err := cli.ClusterConfigSet(t.Context(), &clientv3.ClusterConfig{AlarmThresholds: []*pb.AlarmThreshold{
+		{Metric: pb.AlarmThreshold_LEASES, Value: 1, Block: []pb.AlarmThreshold_Operation{pb.AlarmThreshold_PUT, pb.AlarmThreshold_WATCH}},
+	}})
+	require.NoError(t, err)
+
+	lresp, err := cli.Grant(t.Context(), 60)
+	require.NoError(t, err)
+	waitThresholdAlarm(t, clus.Members[0], true)
+
+	resp, err := cli.AlarmList(t.Context())
+	require.NoError(t, err)
+	require.Len(t, resp.Alarms, 1)
+	require.Equal(t, []pb.AlarmThreshold_Metric{pb.AlarmThreshold_LEASES}, resp.Alarms[0].Metrics)
+
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.ErrorIs(t, err, rpctypes.ErrThresholdExceeded)
+	_, err = cli.Delete(t.Context(), "foo")
+	require.NoError(t, err)
+
+	wch := cli.Watch(t.Context(), "foo")
+	wresp := <-wch
+	require.ErrorIs(t, wresp.Err(), rpctypes.ErrThresholdExceeded)
+
+	_, err = cli.Revoke(t.Context(), lresp.ID)
+	require.NoError(t, err)
+	waitThresholdAlarm(t, clus.Members[0], false)
+
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+}
+
+func waitThresholdAlarm(t *testing.T, m *integration.Member, active bool) {
+	stopc := time.After(10 * time.Second)
+	for {
+		resp, err := m.Server.Alarm(t.Context(), &pb.AlarmRequest{Action: pb.AlarmRequest_GET, Alarm: pb.AlarmType_THRESHOLD})
+		require.NoError(t, err)
+		if (len(resp.Alarms) != 0) == active {
+			return
+		}
+		select {
+		case <-stopc:
+			t.Fatalf("timed out waiting for THRESHOLD alarm to be active=%v", active)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
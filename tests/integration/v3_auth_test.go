@@ -415,10 +415,183 @@ func TestV3AuthWatchErrorAndWatchId0(t *testing.T) {
 
 	wChan := c.Watch(ctx, "non-allowed-key", clientv3.WithRev(1))
 	watchResponse := <-wChan
-	require.Error(t, watchResponse.Err()) // permission denied
+	require.ErrorIs(t, watchResponse.Err(), rpctypes.ErrPermissionDenied)
 
 	_, err := c.Put(ctx, "k1", "val")
 	require.NoErrorf(t, err, "Unexpected error from Put: %v", err)
 
 	<-watchEndCh
 }
+
+// TestV3AuthWatchPermissionChange ensures that the watchers of a user whose
+// permissions change are narrowed to the ranges the user may still read, or
+// canceled if it may read none of them, without closing the stream.
+func TestV3AuthWatchPermissionChange(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skipf("grpc-proxy does not revalidate the permissions of its watchers")
+	}
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	authSetupUsers(t, integration.ToGRPC(clus.Client(0)).Auth, []user{{name: "user1", password: "user1-123", role: "role1", key: "a", end: "c"}})
+	authSetupRoot(t, integration.ToGRPC(clus.Client(0)).Auth)
+
+	rootc, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "root", Password: "123"})
+	require.NoError(t, err)
+	defer rootc.Close()
+	c, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "user1", Password: "user1-123"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	wchAll := c.Watch(ctx, "a", clientv3.WithRange("c"), clientv3.WithCreatedNotify())
+	wr := <-wchAll
+	require.True(t, wr.Created)
+	wchB := c.Watch(ctx, "b", clientv3.WithPrefix(), clientv3.WithCreatedNotify())
+	wr = <-wchB
+	require.True(t, wr.Created)
+
+	// narrow the permission of the user to [a, b)
+	_, err = rootc.RoleGrantPermission(ctx, "role1", "a", "b", clientv3.PermissionType(clientv3.PermReadWrite))
+	require.NoError(t, err)
+	_, err = rootc.RoleRevokePermission(ctx, "role1", "a", "c")
+	require.NoError(t, err)
+
+	wr = <-wchB
+	require.True(t, wr.Canceled)
+	require.ErrorIs(t, wr.Err(), rpctypes.ErrPermissionDenied)
+	assert.Equal(t, pb.WatchResponse_AUTH_REVOKED, wr.CancelCode)
+
+	wr = <-wchAll
+	require.NoError(t, wr.Err())
+	require.NotNil(t, wr.PermissionChange)
+	assert.False(t, wr.IsProgressNotify())
+	assert.Equal(t, "user1", wr.PermissionChange.User)
+	require.Len(t, wr.PermissionChange.PermittedRanges, 1)
+	assert.Equal(t, []byte("a"), wr.PermissionChange.PermittedRanges[0].Key)
+	assert.Equal(t, []byte("b"), wr.PermissionChange.PermittedRanges[0].RangeEnd)
+
+	// the events of the keys the user may no longer read are not sent
+	_, err = rootc.Put(ctx, "b1", "v")
+	require.NoError(t, err)
+	_, err = rootc.Put(ctx, "a1", "v")
+	require.NoError(t, err)
+	wr = <-wchAll
+	require.Len(t, wr.Events, 1)
+	assert.Equal(t, []byte("a1"), wr.Events[0].Kv.Key)
+
+	// the stream is kept, so a watcher can be created on it
+	wchA := c.Watch(ctx, "a2")
+	_, err = rootc.Put(ctx, "a2", "v")
+	require.NoError(t, err)
+	wr = <-wchA
+	require.Len(t, wr.Events, 1)
+	wr = <-wchAll
+	require.Len(t, wr.Events, 1)
+	assert.Equal(t, []byte("a2"), wr.Events[0].Kv.Key)
+
+	// the watcher receives all of its events again once the user may read
+	// all of its range
+	_, err = rootc.RoleGrantPermission(ctx, "role1", "a", "c", clientv3.PermissionType(clientv3.PermRead))
+	require.NoError(t, err)
+	wr = <-wchAll
+	require.NotNil(t, wr.PermissionChange)
+	require.Len(t, wr.PermissionChange.PermittedRanges, 1)
+	assert.Equal(t, []byte("c"), wr.PermissionChange.PermittedRanges[0].RangeEnd)
+	_, err = rootc.Put(ctx, "b2", "v")
+	require.NoError(t, err)
+	wr = <-wchAll
+	require.Len(t, wr.Events, 1)
+	assert.Equal(t, []byte("b2"), wr.Events[0].Kv.Key)
+}
+
+// TestV3AuthPasswordPolicy ensures that passwords are checked against the
+// password policy and that rotated passwords are usable right away.
+func TestV3AuthPasswordPolicy(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, PasswordMinLength: 8})
+	defer clus.Terminate(t)
+
+	api := integration.ToGRPC(clus.Client(0))
+	_, err := api.Auth.UserAdd(t.Context(), &pb.AuthUserAddRequest{Name: "root", Password: "123", Options: &authpb.UserAddOptions{}})
+	require.Truef(t, eqErrGRPC(err, rpctypes.ErrPasswordTooWeak), "got %v, expected %v", err, rpctypes.ErrPasswordTooWeak)
+	authSetupUsers(t, api.Auth, []user{{name: "root", password: "root-password", role: "root"}})
+	_, err = api.Auth.AuthEnable(t.Context(), &pb.AuthEnableRequest{})
+	require.NoError(t, err)
+
+	rootc, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "root", Password: "root-password"})
+	require.NoError(t, err)
+	defer rootc.Close()
+
+	_, err = rootc.UserAdd(t.Context(), "app", "app-password")
+	require.NoError(t, err)
+	_, err = rootc.UserChangePassword(t.Context(), "app", "short")
+	require.ErrorIs(t, err, rpctypes.ErrPasswordTooWeak)
+
+	resp, err := rootc.UserRotatePassword(t.Context(), "app")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(resp.Password), 8)
+
+	_, err = rootc.Authenticate(t.Context(), "app", "app-password")
+	require.ErrorIs(t, err, rpctypes.ErrAuthFailed)
+	_, err = rootc.Authenticate(t.Context(), "app", resp.Password)
+	require.NoError(t, err)
+
+	_, err = rootc.UserAddWithOptions(t.Context(), "nopass", "", &clientv3.UserAddOptions{NoPassword: true})
+	require.NoError(t, err)
+	_, err = rootc.UserRotatePassword(t.Context(), "nopass")
+	require.Error(t, err)
+	_, err = rootc.UserRotatePassword(t.Context(), "no-such-user")
+	require.ErrorIs(t, err, rpctypes.ErrUserNotFound)
+}
+
+// TestV3AuthRPCPermission ensures that administrative RPCs can be delegated
+// to a role without granting the root role.
+func TestV3AuthRPCPermission(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	authSetupUsers(t, integration.ToGRPC(clus.Client(0)).Auth, []user{{name: "ops", password: "ops-123", role: "operator"}})
+	authSetupRoot(t, integration.ToGRPC(clus.Client(0)).Auth)
+
+	rootc, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "root", Password: "123"})
+	require.NoError(t, err)
+	defer rootc.Close()
+	opsc, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "ops", Password: "ops-123"})
+	require.NoError(t, err)
+	defer opsc.Close()
+
+	ep := clus.Client(0).Endpoints()[0]
+	_, err = opsc.Defragment(t.Context(), ep)
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+	_, err = opsc.MemberAdd(t.Context(), []string{"http://127.0.0.1:1"})
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+
+	_, err = rootc.RoleGrantRPCPermission(t.Context(), "operator", "Maintenance.Frobnicate")
+	require.ErrorIs(t, err, rpctypes.ErrInvalidRPCMethod)
+	_, err = rootc.RoleGrantRPCPermission(t.Context(), "operator", "Maintenance.Defragment")
+	require.NoError(t, err)
+
+	resp, err := rootc.RoleGet(t.Context(), "operator")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Maintenance.Defragment"}, resp.RpcPermissions)
+
+	_, err = opsc.Defragment(t.Context(), ep)
+	require.NoError(t, err)
+	_, err = opsc.Status(t.Context(), ep)
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+	_, err = opsc.MemberAdd(t.Context(), []string{"http://127.0.0.1:1"})
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+	// the granted method does not open up the auth management
+	_, err = opsc.RoleGrantRPCPermission(t.Context(), "operator", "Cluster.MemberAdd")
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+
+	_, err = rootc.RoleRevokeRPCPermission(t.Context(), "operator", "Maintenance.Defragment")
+	require.NoError(t, err)
+	_, err = opsc.Defragment(t.Context(), ep)
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestV3CounterAdd ensures concurrent increments through different members
+// are all applied, without creating revisions in the key-value store.
+func TestV3CounterAdd(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	rev := mustPutRevision(t, clus.RandClient())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(cc pb.CounterClient) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, err := cc.CounterAdd(t.Context(), &pb.CounterAddRequest{Deltas: []*pb.CounterDelta{
+					{Key: []byte("hits"), Delta: 1},
+					{Key: []byte("bytes"), Delta: 100},
+				}})
+				assert.NoError(t, err)
+			}
+		}(integration.ToGRPC(clus.Client(i)).Counter)
+	}
+	wg.Wait()
+
+	for i := 0; i < 3; i++ {
+		resp, err := integration.ToGRPC(clus.Client(i)).Counter.CounterGet(t.Context(), &pb.CounterGetRequest{Key: []byte("a"), RangeEnd: []byte("z")})
+		require.NoError(t, err)
+		require.Equal(t, []*pb.CounterValue{
+			{Key: []byte("bytes"), Value: 3000},
+			{Key: []byte("hits"), Value: 30},
+		}, resp.Counters)
+	}
+	require.Equal(t, rev+1, mustPutRevision(t, clus.RandClient()))
+}
+
+func mustPutRevision(t *testing.T, cli *clientv3.Client) int64 {
+	resp, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	return resp.Header.Revision
+}
+
+func TestV3CounterAddErrors(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxTxnOps: 2})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	_, err := cli.CounterAdd(t.Context(), clientv3.CounterDelta{Key: "a", Delta: 5})
+	require.NoError(t, err)
+
+	tcs := []struct {
+		name   string
+		deltas []clientv3.CounterDelta
+		werr   error
+	}{
+		{
+			name:   "overflow",
+			deltas: []clientv3.CounterDelta{{Key: "b", Delta: 1}, {Key: "a", Delta: math.MaxInt64}},
+			werr:   rpctypes.ErrCounterOverflow,
+		},
+		{
+			name:   "empty key",
+			deltas: []clientv3.CounterDelta{{Key: "", Delta: 1}},
+			werr:   rpctypes.ErrEmptyKey,
+		},
+		{
+			name:   "too many deltas",
+			deltas: []clientv3.CounterDelta{{Key: "a", Delta: 1}, {Key: "b", Delta: 1}, {Key: "c", Delta: 1}},
+			werr:   rpctypes.ErrTooManyOps,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := cli.CounterAdd(t.Context(), tc.deltas...)
+			require.ErrorIs(t, err, tc.werr)
+		})
+	}
+
+	// no delta of a failed request was applied
+	resp, err := cli.CounterGet(t.Context(), "", clientv3.WithFromKey())
+	require.NoError(t, err)
+	require.Equal(t, []*pb.CounterValue{{Key: []byte("a"), Value: 5}}, resp.Counters)
+
+	// a counter reaching 0 is removed
+	_, err = cli.CounterAdd(t.Context(), clientv3.CounterDelta{Key: "a", Delta: -5})
+	require.NoError(t, err)
+	resp, err = cli.CounterGet(t.Context(), "a", clientv3.WithSerializable())
+	require.NoError(t, err)
+	require.Empty(t, resp.Counters)
+}
+
+func TestV3CounterAuth(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	users := []user{{name: "user1", password: "user1-123", role: "role1", key: "/user1/", end: "/user10"}}
+	authSetupUsers(t, integration.ToGRPC(clus.Client(0)).Auth, users)
+	authSetupRoot(t, integration.ToGRPC(clus.Client(0)).Auth)
+
+	c, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "user1", Password: "user1-123"})
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.CounterAdd(t.Context(), clientv3.CounterDelta{Key: "/user1/a", Delta: 1})
+	require.NoError(t, err)
+	_, err = c.CounterAdd(t.Context(), clientv3.CounterDelta{Key: "/user1/a", Delta: 1}, clientv3.CounterDelta{Key: "/user2/a", Delta: 1})
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+	resp, err := c.CounterGet(t.Context(), "/user1/", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Equal(t, []*pb.CounterValue{{Key: []byte("/user1/a"), Value: 1}}, resp.Counters)
+	_, err = c.CounterGet(t.Context(), "/", clientv3.WithPrefix())
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+}
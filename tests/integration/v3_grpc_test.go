@@ -22,6 +22,7 @@ import (
 	"math/rand"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -1536,6 +1537,210 @@ func TestV3RangeRequest(t *testing.T) {
 	}
 }
 
+// TestV3SerializableReadCache ensures serializable reads served from the read
+// cache of a follower reflect the writes applied by the follower.
+func TestV3SerializableReadCache(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, SerializableReadCacheBytes: 1024 * 1024})
+	defer clus.Terminate(t)
+
+	leader := clus.WaitLeader(t)
+	follower := (leader + 1) % 3
+	kvc := integration.ToGRPC(clus.Client(leader)).KV
+	fkvc := integration.ToGRPC(clus.Client(follower)).KV
+
+	waitValue := func(value string) *pb.RangeResponse {
+		var resp *pb.RangeResponse
+		var err error
+		for i := 0; i < 100; i++ {
+			resp, err = fkvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo"), Serializable: true})
+			require.NoError(t, err)
+			if len(resp.Kvs) == 1 && string(resp.Kvs[0].Value) == value {
+				return resp
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("expected value %q, got %v", value, resp.Kvs)
+		return nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value := fmt.Sprintf("bar%d", i)
+		presp, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), Value: []byte(value)})
+		require.NoError(t, err)
+		waitValue(value)
+		// served from the cache until the key changes again
+		for j := 0; j < 5; j++ {
+			resp := waitValue(value)
+			require.Equal(t, presp.Header.Revision, resp.Kvs[0].ModRevision)
+			require.GreaterOrEqual(t, resp.Header.Revision, presp.Header.Revision)
+			require.Equal(t, uint64(clus.Members[follower].Server.MemberID()), resp.Header.MemberId)
+		}
+	}
+
+	_, err := kvc.DeleteRange(t.Context(), &pb.DeleteRangeRequest{Key: []byte("foo")})
+	require.NoError(t, err)
+	for i := 0; ; i++ {
+		resp, rerr := fkvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo"), Serializable: true})
+		require.NoError(t, rerr)
+		if len(resp.Kvs) == 0 {
+			break
+		}
+		require.Less(t, i, 100, "deleted key still cached")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	hits, err := clus.Members[follower].Metric("etcd_server_read_cache_hits_total")
+	require.NoError(t, err)
+	require.NotEqual(t, "0", hits)
+}
+
+// TestV3GateStaleReads ensures a restarted member gating stale reads does not
+// serve serializable reads before it applied the entries it missed.
+func TestV3GateStaleReads(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, GateStaleReads: true})
+	defer clus.Terminate(t)
+
+	leader := clus.WaitLeader(t)
+	follower := (leader + 1) % 3
+	kvc := integration.ToGRPC(clus.Client(leader)).KV
+
+	clus.Client(follower).Close()
+	clus.Members[follower].Stop(t)
+	var rev int64
+	for i := 0; i < 200; i++ {
+		resp, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte(fmt.Sprintf("foo%d", i)), Value: []byte("bar")})
+		require.NoError(t, err)
+		rev = resp.Header.Revision
+	}
+	require.NoError(t, clus.Members[follower].Restart(t))
+	c, cerr := integration.NewClientV3(clus.Members[follower])
+	require.NoError(t, cerr)
+	clus.Members[follower].ServerClient = c
+	fkvc := integration.ToGRPC(c).KV
+
+	for i := 0; ; i++ {
+		resp, err := fkvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo"), Serializable: true})
+		if err == nil {
+			require.GreaterOrEqual(t, resp.Header.Revision, rev)
+			break
+		}
+		require.Truef(t, eqErrGRPC(err, rpctypes.ErrGRPCCatchingUp), "unexpected error %v", err)
+		require.Less(t, i, 100, "serializable reads still gated")
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// TestV3RangeMinAppliedIndex ensures a serializable read carrying the applied
+// index of a previous read is not served by a member that is behind it.
+func TestV3RangeMinAppliedIndex(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	leader := clus.WaitLeader(t)
+	follower := (leader + 1) % 3
+	kvc := integration.ToGRPC(clus.Client(leader)).KV
+
+	clus.Client(follower).Close()
+	clus.Members[follower].Stop(t)
+	for i := 0; i < 200; i++ {
+		_, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte(fmt.Sprintf("foo%d", i)), Value: []byte("bar")})
+		require.NoError(t, err)
+	}
+	resp, err := kvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo199")})
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	token := resp.AppliedIndex
+	require.NotZero(t, token)
+
+	require.NoError(t, clus.Members[follower].Restart(t))
+	c, cerr := integration.NewClientV3(clus.Members[follower])
+	require.NoError(t, cerr)
+	clus.Members[follower].ServerClient = c
+	fkvc := integration.ToGRPC(c).KV
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	_, err = fkvc.Range(ctx, &pb.RangeRequest{Key: []byte("foo199"), Serializable: true, MinAppliedIndex: token + 1000})
+	cancel()
+	require.Error(t, err)
+	if !integration.ThroughProxy {
+		// the proxy retries until the deadline
+		require.Truef(t, eqErrGRPC(err, rpctypes.ErrGRPCAppliedIndexBehind), "unexpected error %v", err)
+	}
+
+	for i := 0; ; i++ {
+		fresp, err := fkvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo199"), Serializable: true, MinAppliedIndex: token})
+		if err == nil {
+			require.Len(t, fresp.Kvs, 1, "read went back in time")
+			require.GreaterOrEqual(t, fresp.AppliedIndex, token)
+			break
+		}
+		require.Truef(t, eqErrGRPC(err, rpctypes.ErrGRPCAppliedIndexBehind), "unexpected error %v", err)
+		require.Less(t, i, 100, "follower never caught up")
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// TestV3RangeLeaseRead ensures that lease reads are served from the leader
+// lease on the leader and fall back to ReadIndex on followers.
+func TestV3RangeLeaseRead(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, LeaseReads: true})
+	defer clus.Terminate(t)
+
+	leader := clus.WaitLeader(t)
+	follower := (leader + 1) % 3
+	kvc := integration.ToGRPC(clus.Client(leader)).KV
+	fkvc := integration.ToGRPC(clus.Client(follower)).KV
+
+	leaseReads := func(servedBy string) int {
+		v, err := clus.Members[leader].Metric("etcd_server_lease_reads_total", `served_by="`+servedBy+`"`)
+		require.NoError(t, err)
+		if v == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(v)
+		require.NoError(t, err)
+		return n
+	}
+
+	presp, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		kvc          pb.KVClient
+		rc           pb.RangeRequest_ReadConsistency
+		wantServedBy string
+	}{
+		{name: "server default on leader", kvc: kvc, wantServedBy: "lease"},
+		{name: "lease on leader", kvc: kvc, rc: pb.RangeRequest_LEASE, wantServedBy: "lease"},
+		{name: "lease on follower", kvc: fkvc, rc: pb.RangeRequest_LEASE, wantServedBy: "read_index"},
+		{name: "read index on leader", kvc: kvc, rc: pb.RangeRequest_READ_INDEX},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaseBefore, readIndexBefore := leaseReads("lease"), leaseReads("read_index")
+			resp, err := tt.kvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo"), ReadConsistency: tt.rc})
+			require.NoError(t, err)
+			require.Len(t, resp.Kvs, 1)
+			require.Equal(t, presp.Header.Revision, resp.Kvs[0].ModRevision)
+
+			wantLease, wantReadIndex := leaseBefore, readIndexBefore
+			switch tt.wantServedBy {
+			case "lease":
+				wantLease++
+			case "read_index":
+				wantReadIndex++
+			}
+			require.Equal(t, wantLease, leaseReads("lease"))
+			require.Equal(t, wantReadIndex, leaseReads("read_index"))
+		})
+	}
+}
+
 // TestTLSGRPCRejectInsecureClient checks that connection is rejected if server is TLS but not client.
 func TestTLSGRPCRejectInsecureClient(t *testing.T) {
 	integration.BeforeTest(t)
@@ -1986,3 +2191,47 @@ func waitForRestart(t *testing.T, kvc pb.KVClient) {
 		t.Fatalf("timed out waiting for restart: %v", err)
 	}
 }
+
+// TestV3ResponseCompression ensures only the responses above the compression
+// threshold are compressed, with the preferred compressor of the server.
+func TestV3ResponseCompression(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:                            1,
+		GRPCResponseCompressors:         []string{"zstd", "gzip"},
+		GRPCResponseCompressionMinBytes: 1024,
+	})
+	defer clus.Terminate(t)
+
+	compressed := func() int {
+		v, err := clus.Members[0].Metric("etcd_network_client_grpc_compressed_responses_total", `compressor="zstd"`)
+		require.NoError(t, err)
+		if v == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(v)
+		require.NoError(t, err)
+		return n
+	}
+
+	cli := clus.Client(0)
+	_, err := cli.Put(t.Context(), "small", "v")
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "large", strings.Repeat("a", 64*1024))
+	require.NoError(t, err)
+
+	before := compressed()
+	resp, err := cli.Get(t.Context(), "small")
+	require.NoError(t, err)
+	require.Equal(t, "v", string(resp.Kvs[0].Value))
+	require.Equal(t, before, compressed())
+
+	resp, err = cli.Get(t.Context(), "large")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs[0].Value, 64*1024)
+	require.Equal(t, before+1, compressed())
+
+	saved, err := clus.Members[0].Metric("etcd_network_client_grpc_compression_saved_bytes_total")
+	require.NoError(t, err)
+	require.NotEqual(t, "0", saved)
+}
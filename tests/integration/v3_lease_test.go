@@ -281,6 +281,66 @@ func TestV3LeaseExpire(t *testing.T) {
 	})
 }
 
+// TestV3LeaseExpiryEvent ensures the expiry of a lease is recorded under the
+// lease expiry event prefix, in the revision deleting its keys, while leases
+// revoked by clients are not.
+func TestV3LeaseExpiryEvent(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("the expiry is recorded outside the namespace of the grpc-proxy")
+	}
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	ctx := t.Context()
+	_, err := cli.ClusterConfigSet(ctx, &clientv3.ClusterConfig{LeaseExpiryEventPrefix: []byte("/events/")})
+	require.NoError(t, err)
+
+	revoked, err := cli.Grant(ctx, 60)
+	require.NoError(t, err)
+	expired, err := cli.Grant(ctx, 1)
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "revoked", "v", clientv3.WithLease(revoked.ID))
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "foo", "v", clientv3.WithLease(expired.ID))
+	require.NoError(t, err)
+	presp, err := cli.Put(ctx, "bar", "v", clientv3.WithLease(expired.ID))
+	require.NoError(t, err)
+	_, err = cli.Revoke(ctx, revoked.ID)
+	require.NoError(t, err)
+
+	wctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	wch := cli.Watch(wctx, "", clientv3.WithPrefix(), clientv3.WithRev(presp.Header.Revision+1))
+	var evs []*clientv3.Event
+	for wresp := range wch {
+		require.NoError(t, wresp.Err())
+		evs = append(evs, wresp.Events...)
+		if len(evs) >= 4 {
+			break
+		}
+	}
+	require.Len(t, evs, 4)
+	// the revoked lease deletes its key without recording an event
+	assert.Equal(t, "revoked", string(evs[0].Kv.Key))
+	assert.Equal(t, mvccpb.DELETE, evs[0].Type)
+	assert.Equal(t, "bar", string(evs[1].Kv.Key))
+	assert.Equal(t, mvccpb.DELETE, evs[1].Type)
+	assert.Equal(t, "foo", string(evs[2].Kv.Key))
+	assert.Equal(t, mvccpb.DELETE, evs[2].Type)
+	assert.Equal(t, fmt.Sprintf("/events/%016x", expired.ID), string(evs[3].Kv.Key))
+	assert.Equal(t, mvccpb.PUT, evs[3].Type)
+	assert.Equal(t, evs[1].Kv.ModRevision, evs[3].Kv.ModRevision)
+	assert.Equal(t, evs[2].Kv.ModRevision, evs[3].Kv.ModRevision)
+
+	var ev pb.LeaseExpiryEvent
+	require.NoError(t, ev.Unmarshal(evs[3].Kv.Value))
+	assert.Equal(t, int64(expired.ID), ev.ID)
+	assert.Equal(t, expired.TTL, ev.TTL)
+	assert.Equal(t, [][]byte{[]byte("bar"), []byte("foo")}, ev.Keys)
+}
+
 // TestV3LeaseKeepAlive ensures keepalive keeps the lease alive.
 func TestV3LeaseKeepAlive(t *testing.T) {
 	integration.BeforeTest(t)
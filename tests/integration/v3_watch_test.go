@@ -417,6 +417,9 @@ func TestV3WatchWrongRange(t *testing.T) {
 		if tt.canceled && cresp.WatchId != clientv3.InvalidWatchID {
 			t.Fatalf("#%d: canceled watch ID %d, want %d", i, cresp.WatchId, clientv3.InvalidWatchID)
 		}
+		if tt.canceled && cresp.CancelCode != pb.WatchResponse_INVALID_REQUEST {
+			t.Fatalf("#%d: cancel code %v, want %v", i, cresp.CancelCode, pb.WatchResponse_INVALID_REQUEST)
+		}
 	}
 }
 
@@ -1212,6 +1215,59 @@ func TestV3WatchClose(t *testing.T) {
 	wg.Wait()
 }
 
+// TestV3WatchSendBatching ensures batched watch responses deliver every event
+// in order, and that control responses do not overtake batched events.
+func TestV3WatchSendBatching(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, WatchSendBatchInterval: 50 * time.Millisecond})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	wStream, err := integration.ToGRPC(clus.RandClient()).Watch.Watch(ctx)
+	require.NoError(t, err)
+
+	const watchers, puts = 3, 30
+	for i := 0; i < watchers; i++ {
+		err = wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+			CreateRequest: &pb.WatchCreateRequest{Key: []byte(fmt.Sprintf("foo%d", i)), WatchId: int64(i)},
+		}})
+		require.NoError(t, err)
+		resp, rerr := wStream.Recv()
+		require.NoError(t, rerr)
+		require.True(t, resp.Created)
+	}
+
+	kvc := integration.ToGRPC(clus.RandClient()).KV
+	for i := 0; i < puts; i++ {
+		_, err = kvc.Put(t.Context(), &pb.PutRequest{Key: []byte(fmt.Sprintf("foo%d", i%watchers)), Value: []byte("bar")})
+		require.NoError(t, err)
+	}
+	err = wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CancelRequest{
+		CancelRequest: &pb.WatchCancelRequest{WatchId: 0},
+	}})
+	require.NoError(t, err)
+
+	lastRev := make(map[int64]int64)
+	received := make(map[int64]int)
+	events := 0
+	for events < puts {
+		resp, rerr := wStream.Recv()
+		require.NoError(t, rerr)
+		if resp.Canceled {
+			require.Equal(t, puts/watchers, received[resp.WatchId], "watcher canceled before all its events were sent")
+			continue
+		}
+		for _, ev := range resp.Events {
+			require.Equal(t, fmt.Sprintf("foo%d", resp.WatchId), string(ev.Kv.Key))
+			require.Greater(t, ev.Kv.ModRevision, lastRev[resp.WatchId])
+			lastRev[resp.WatchId] = ev.Kv.ModRevision
+			received[resp.WatchId]++
+			events++
+		}
+	}
+}
+
 // TestV3WatchWithFilter ensures watcher filters out the events correctly.
 func TestV3WatchWithFilter(t *testing.T) {
 	integration.BeforeTest(t)
@@ -1585,3 +1641,232 @@ func TestV3NoEventsLostOnCompact(t *testing.T) {
 	}
 	assert.Truef(t, compacted, "Expected stream to get compacted, instead we got %d events out of %d events", eventCount, writeCount)
 }
+
+// TestV3WatchCancelCodeCompacted ensures a watcher starting from a compacted
+// revision is canceled with the COMPACTED code and the compact revision.
+func TestV3WatchCancelCodeCompacted(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvc := integration.ToGRPC(clus.RandClient()).KV
+	for i := 0; i < 3; i++ {
+		_, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+		require.NoError(t, err)
+	}
+	_, err := kvc.Compact(t.Context(), &pb.CompactionRequest{Revision: 3, Physical: true})
+	require.NoError(t, err)
+
+	wStream, err := integration.ToGRPC(clus.RandClient()).Watch.Watch(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo"), StartRevision: 1},
+	}}))
+
+	resp, err := wStream.Recv()
+	require.NoError(t, err)
+	require.True(t, resp.Created)
+	for !resp.Canceled {
+		resp, err = wStream.Recv()
+		require.NoError(t, err)
+	}
+	require.Equal(t, int64(3), resp.CompactRevision)
+	require.Equal(t, pb.WatchResponse_COMPACTED, resp.CancelCode)
+	require.NotNil(t, resp.CancelDetails)
+	require.Equal(t, int64(3), resp.CancelDetails.Revision)
+}
+
+// TestV3WatchInitialState ensures a watcher created with send_initial_state
+// receives the key-value pairs of its range in pages, and then every event
+// after the revision they were read at, while writes keep going.
+func TestV3WatchInitialState(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvc := integration.ToGRPC(clus.RandClient()).KV
+	// more keys than an initial state page
+	const keys = 1500
+	for i := 0; i < keys; i += 100 {
+		txn := &pb.TxnRequest{}
+		for j := i; j < i+100; j++ {
+			txn.Success = append(txn.Success, &pb.RequestOp{Request: &pb.RequestOp_RequestPut{
+				RequestPut: &pb.PutRequest{Key: []byte(fmt.Sprintf("foo/%04d", j)), Value: []byte("v")},
+			}})
+		}
+		_, err := kvc.Txn(t.Context(), txn)
+		require.NoError(t, err)
+	}
+	_, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("zoo"), Value: []byte("v")})
+	require.NoError(t, err)
+
+	wStream, err := integration.ToGRPC(clus.RandClient()).Watch.Watch(t.Context())
+	require.NoError(t, err)
+	err = wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo/"), RangeEnd: []byte("foo0"), SendInitialState: true},
+	}})
+	require.NoError(t, err)
+
+	// keep writing while the initial state is sent
+	const puts = 50
+	var putRevs []int64
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		for i := 0; i < puts; i++ {
+			presp, perr := kvc.Put(context.TODO(), &pb.PutRequest{Key: []byte(fmt.Sprintf("foo/%04d", i)), Value: []byte("w")})
+			if perr != nil {
+				t.Errorf("put failed: %v", perr)
+				return
+			}
+			putRevs = append(putRevs, presp.Header.Revision)
+		}
+	}()
+
+	resp, err := wStream.Recv()
+	require.NoError(t, err)
+	require.True(t, resp.Created)
+	require.True(t, resp.InitialState)
+	require.True(t, resp.InitialStateMore)
+	stateRev := resp.Header.Revision
+
+	state := make(map[string]string)
+	pages := 0
+	for more := true; more; {
+		resp, err = wStream.Recv()
+		require.NoError(t, err)
+		require.True(t, resp.InitialState)
+		require.Equal(t, stateRev, resp.Header.Revision)
+		for _, ev := range resp.Events {
+			require.Equal(t, mvccpb.PUT, ev.Type)
+			require.LessOrEqual(t, ev.Kv.ModRevision, stateRev)
+			state[string(ev.Kv.Key)] = string(ev.Kv.Value)
+		}
+		more = resp.InitialStateMore
+		pages++
+	}
+	require.Len(t, state, keys)
+	require.Greater(t, pages, 1)
+
+	<-donec
+	require.Len(t, putRevs, puts)
+	// the writes after the state revision follow without gap
+	var wrevs, revs []int64
+	for _, rev := range putRevs {
+		if rev > stateRev {
+			wrevs = append(wrevs, rev)
+		}
+	}
+	for len(revs) < len(wrevs) {
+		resp, err = wStream.Recv()
+		require.NoError(t, err)
+		require.False(t, resp.InitialState)
+		for _, ev := range resp.Events {
+			revs = append(revs, ev.Kv.ModRevision)
+		}
+	}
+	require.Equal(t, wrevs, revs)
+	firstRev := putRevs[0]
+
+	// a state at a past revision and an empty range
+	for _, tc := range []struct {
+		key, end string
+		startRev int64
+		wkeys    int
+	}{
+		{"foo/", "foo0", firstRev, keys},
+		{"bar", "", 0, 0},
+	} {
+		err = wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+			CreateRequest: &pb.WatchCreateRequest{Key: []byte(tc.key), RangeEnd: []byte(tc.end), StartRevision: tc.startRev, SendInitialState: true},
+		}})
+		require.NoError(t, err)
+		n := 0
+		for more := true; more; {
+			resp, err = wStream.Recv()
+			require.NoError(t, err)
+			require.True(t, resp.InitialState)
+			if tc.startRev > 0 {
+				require.Equal(t, tc.startRev-1, resp.Header.Revision)
+			}
+			for _, ev := range resp.Events {
+				if ev.Kv.ModRevision >= firstRev {
+					t.Fatalf("unexpected key-value %s at revision %d", ev.Kv.Key, ev.Kv.ModRevision)
+				}
+			}
+			n += len(resp.Events)
+			more = resp.InitialStateMore
+		}
+		require.Equal(t, tc.wkeys, n)
+	}
+
+	// the watcher of the past revision replays the writes that followed
+	for n := 0; n < puts; {
+		resp, err = wStream.Recv()
+		require.NoError(t, err)
+		n += len(resp.Events)
+	}
+}
+
+// TestV3WatchBulk tests creating and canceling watchers with a bulk request.
+func TestV3WatchBulk(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	wStream, err := integration.ToGRPC(clus.RandClient()).Watch.Watch(t.Context())
+	require.NoError(t, err)
+
+	keys := []string{"foo", "bar", "baz"}
+	bulk := &pb.WatchBulkRequest{}
+	for _, key := range keys {
+		bulk.CreateRequests = append(bulk.CreateRequests, &pb.WatchCreateRequest{Key: []byte(key)})
+	}
+	require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_BulkRequest{BulkRequest: bulk}}))
+
+	// every create is answered, in order
+	ids := make(map[int64]string)
+	var cancels []*pb.WatchCancelRequest
+	for _, key := range keys {
+		resp, rerr := wStream.Recv()
+		require.NoError(t, rerr)
+		require.True(t, resp.Created)
+		require.False(t, resp.Canceled)
+		require.True(t, resp.BulkSupported)
+		require.NotContains(t, ids, resp.WatchId)
+		ids[resp.WatchId] = key
+		if key != "baz" {
+			cancels = append(cancels, &pb.WatchCancelRequest{WatchId: resp.WatchId})
+		}
+	}
+
+	kvc := integration.ToGRPC(clus.RandClient()).KV
+	for _, key := range keys {
+		_, err = kvc.Put(t.Context(), &pb.PutRequest{Key: []byte(key), Value: []byte("v")})
+		require.NoError(t, err)
+		resp, rerr := wStream.Recv()
+		require.NoError(t, rerr)
+		require.Len(t, resp.Events, 1)
+		require.Equal(t, key, ids[resp.WatchId])
+		require.Equal(t, key, string(resp.Events[0].Kv.Key))
+	}
+
+	bulk = &pb.WatchBulkRequest{CancelRequests: cancels}
+	require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_BulkRequest{BulkRequest: bulk}}))
+	for _, cr := range cancels {
+		resp, rerr := wStream.Recv()
+		require.NoError(t, rerr)
+		require.True(t, resp.Canceled)
+		require.Equal(t, cr.WatchId, resp.WatchId)
+	}
+
+	// only the watcher which is not canceled gets events
+	for _, key := range keys {
+		_, err = kvc.Put(t.Context(), &pb.PutRequest{Key: []byte(key), Value: []byte("w")})
+		require.NoError(t, err)
+	}
+	resp, err := wStream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 1)
+	require.Equal(t, "baz", string(resp.Events[0].Kv.Key))
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+// MirrorUntilRevision replicates the whole keyspace from the source to the
+// destination endpoints the same way `etcdctl make-mirror` does, until it has
+// replicated the revision provided via maxRevisionChan. Every source revision
+// is applied as a single destination transaction fenced on the mod revision of
+// report.MirrorRevisionKey, so replication can resume after failures without
+// applying a revision twice. It returns the source to destination revision
+// mapping of every applied transaction.
+func MirrorUntilRevision(ctx context.Context, lg *zap.Logger, sourceEndpoints, destinationEndpoints []string, maxRevisionChan <-chan int64) ([]report.MirrorCheckpoint, error) {
+	source, err := newMirrorClient(sourceEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	defer source.Close()
+	destination, err := newMirrorClient(destinationEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	defer destination.Close()
+	m := &mirror{
+		lg:          lg,
+		source:      source,
+		destination: destination,
+	}
+	return m.run(ctx, maxRevisionChan)
+}
+
+func newMirrorClient(endpoints []string) (*clientv3.Client, error) {
+	return clientv3.New(clientv3.Config{
+		Endpoints:            endpoints,
+		Logger:               zap.NewNop(),
+		DialKeepAliveTime:    10 * time.Second,
+		DialKeepAliveTimeout: 100 * time.Millisecond,
+	})
+}
+
+type mirror struct {
+	lg          *zap.Logger
+	source      *clientv3.Client
+	destination *clientv3.Client
+
+	// sourceRevision is the source revision the destination keyspace reflects.
+	sourceRevision int64
+	// markerRevision is the mod revision of report.MirrorRevisionKey in the destination.
+	markerRevision int64
+	checkpoints    []report.MirrorCheckpoint
+}
+
+func (m *mirror) run(ctx context.Context, maxRevisionChan <-chan int64) ([]report.MirrorCheckpoint, error) {
+	var maxRevision int64
+	var closing bool
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for {
+		if closing {
+			if maxRevision == 0 {
+				return m.checkpoints, errors.New("mirror didn't replicate all revisions, max revision not set")
+			}
+			if m.sourceRevision < maxRevision {
+				return m.checkpoints, fmt.Errorf("mirror didn't replicate all revisions, got: %d, expected: %d", m.sourceRevision, maxRevision)
+			}
+			return m.checkpoints, nil
+		}
+		if err := m.resume(ctx); err != nil {
+			if ctx.Err() != nil {
+				closing = true
+				continue
+			}
+			m.lg.Info("Failed to resume mirror", zap.Error(err))
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		watch := m.source.Watch(watchCtx, "", clientv3.WithPrefix(), clientv3.WithRev(m.sourceRevision+1))
+	watchLoop:
+		for {
+			select {
+			case revision, ok := <-maxRevisionChan:
+				if ok {
+					maxRevision = revision
+				} else if maxRevision == 0 {
+					// Only stop if maxRevision was never set.
+					closing = true
+					cancel()
+				}
+				maxRevisionChan = nil
+			case resp, ok := <-watch:
+				if !ok {
+					m.lg.Info("Mirror watch channel closed")
+					break watchLoop
+				}
+				if err := resp.Err(); err != nil {
+					if errors.Is(err, rpctypes.ErrCompacted) {
+						m.lg.Info("Mirror watch compacted, resynchronizing", zap.Int64("compact-revision", resp.CompactRevision))
+						if err := m.sync(ctx); err != nil {
+							m.lg.Info("Failed to resynchronize mirror", zap.Error(err))
+						}
+					}
+					break watchLoop
+				}
+				if err := m.apply(ctx, resp.Events); err != nil {
+					m.lg.Info("Failed to apply mirrored events", zap.Error(err))
+					break watchLoop
+				}
+			}
+			if maxRevision != 0 && m.sourceRevision >= maxRevision {
+				closing = true
+				cancel()
+				break
+			}
+		}
+		watchCancel()
+	}
+}
+
+// resume reloads the replicated source revision from the destination,
+// synchronizing the whole keyspace if nothing was replicated yet.
+func (m *mirror) resume(ctx context.Context) error {
+	resp, err := m.destination.Get(ctx, report.MirrorRevisionKey)
+	if err != nil {
+		return err
+	}
+	if len(resp.Kvs) == 0 {
+		m.markerRevision = 0
+		return m.sync(ctx)
+	}
+	kv := resp.Kvs[0]
+	sourceRevision, err := strconv.ParseInt(string(kv.Value), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid mirror revision %q: %w", kv.Value, err)
+	}
+	// The last transaction might have succeeded even though its response was lost.
+	m.checkpoint(sourceRevision, kv.ModRevision)
+	return nil
+}
+
+// sync replaces the destination keyspace with the current source keyspace in
+// a single transaction.
+func (m *mirror) sync(ctx context.Context) error {
+	sourceResp, err := m.source.Get(ctx, "", clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+	destinationResp, err := m.destination.Get(ctx, "", clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return err
+	}
+	sourceKeys := make(map[string]struct{}, len(sourceResp.Kvs))
+	ops := make([]clientv3.Op, 0, len(sourceResp.Kvs)+len(destinationResp.Kvs)+1)
+	for _, kv := range sourceResp.Kvs {
+		sourceKeys[string(kv.Key)] = struct{}{}
+		ops = append(ops, clientv3.OpPut(string(kv.Key), string(kv.Value)))
+	}
+	for _, kv := range destinationResp.Kvs {
+		key := string(kv.Key)
+		if _, ok := sourceKeys[key]; ok || key == report.MirrorRevisionKey {
+			continue
+		}
+		ops = append(ops, clientv3.OpDelete(key))
+	}
+	return m.commit(ctx, sourceResp.Header.Revision, ops)
+}
+
+// apply replicates watch events, committing one destination transaction per
+// source revision.
+func (m *mirror) apply(ctx context.Context, events []*clientv3.Event) error {
+	var ops []clientv3.Op
+	var revision int64
+	for _, event := range events {
+		if event.Kv.ModRevision != revision && len(ops) != 0 {
+			if err := m.commit(ctx, revision, ops); err != nil {
+				return err
+			}
+			ops = nil
+		}
+		revision = event.Kv.ModRevision
+		switch event.Type {
+		case mvccpb.PUT:
+			ops = append(ops, clientv3.OpPut(string(event.Kv.Key), string(event.Kv.Value)))
+		case mvccpb.DELETE:
+			ops = append(ops, clientv3.OpDelete(string(event.Kv.Key)))
+		}
+	}
+	if len(ops) != 0 {
+		return m.commit(ctx, revision, ops)
+	}
+	return nil
+}
+
+func (m *mirror) commit(ctx context.Context, sourceRevision int64, ops []clientv3.Op) error {
+	ops = append(ops, clientv3.OpPut(report.MirrorRevisionKey, strconv.FormatInt(sourceRevision, 10)))
+	resp, err := m.destination.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(report.MirrorRevisionKey), "=", m.markerRevision)).
+		Then(ops...).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return fmt.Errorf("mirror revision changed, expected mod revision %d", m.markerRevision)
+	}
+	m.checkpoint(sourceRevision, resp.Header.Revision)
+	return nil
+}
+
+func (m *mirror) checkpoint(sourceRevision, destinationRevision int64) {
+	m.sourceRevision = sourceRevision
+	m.markerRevision = destinationRevision
+	if len(m.checkpoints) != 0 && m.checkpoints[len(m.checkpoints)-1].DestinationRevision >= destinationRevision {
+		return
+	}
+	m.checkpoints = append(m.checkpoints, report.MirrorCheckpoint{
+		SourceRevision:      sourceRevision,
+		DestinationRevision: destinationRevision,
+	})
+}
@@ -53,6 +53,9 @@ var allFailpoints = []Failpoint{
 	RaftAfterSaveSleep,
 	ApplyBeforeOpenSnapshot,
 	SleepBeforeSendWatchResponse,
+	BlackholePeerNetworkOutbound,
+	FlappingPeerNetwork,
+	NetemPeerNetwork,
 }
 
 func PickRandom(clus *e2e.EtcdProcessCluster, profile traffic.Profile) (Failpoint, error) {
@@ -17,6 +17,7 @@ package failpoint
 import (
 	"context"
 	"math/rand"
+	"strconv"
 	"testing"
 	"time"
 
@@ -34,6 +35,10 @@ var (
 	BlackholeUntilSnapshot Failpoint = blackholePeerNetworkFailpoint{triggerBlackhole{waitTillSnapshot: true}}
 	DelayPeerNetwork       Failpoint = delayPeerNetworkFailpoint{duration: time.Second, baseLatency: 75 * time.Millisecond, randomizedLatency: 50 * time.Millisecond}
 	DropPeerNetwork        Failpoint = dropPeerNetworkFailpoint{duration: time.Second, dropProbabilityPercent: 50}
+	// BlackholePeerNetworkOutbound creates an asymmetric partition, where the member keeps receiving messages from peers, but none of its messages reach them.
+	BlackholePeerNetworkOutbound Failpoint = blackholeOutboundPeerNetworkFailpoint{duration: 3 * time.Second}
+	FlappingPeerNetwork          Failpoint = flappingPeerNetworkFailpoint{duration: 3 * time.Second}
+	NetemPeerNetwork             Failpoint = netemPeerNetworkFailpoint{duration: time.Second, config: e2e.NetemConfig{Latency: 50 * time.Millisecond, Jitter: 25 * time.Millisecond, LossPercent: 10}}
 )
 
 type blackholePeerNetworkFailpoint struct {
@@ -218,3 +223,123 @@ func (f dropPeerNetworkFailpoint) Name() string {
 func (f dropPeerNetworkFailpoint) Available(config e2e.EtcdProcessClusterConfig, clus e2e.EtcdProcess, profile traffic.Profile) bool {
 	return config.ClusterSize > 1 && clus.PeerProxy() != nil
 }
+
+type blackholeOutboundPeerNetworkFailpoint struct {
+	duration time.Duration
+}
+
+func (f blackholeOutboundPeerNetworkFailpoint) Inject(ctx context.Context, t *testing.T, lg *zap.Logger, clus *e2e.EtcdProcessCluster, baseTime time.Time, ids identity.Provider) ([]report.ClientReport, error) {
+	member := clus.Procs[rand.Int()%len(clus.Procs)]
+	proxy := member.PeerProxy()
+
+	// Peers dial the member to receive its messages over streams, so the proxy
+	// transmits only what the member sends. Messages from peers still arrive
+	// through streams dialed by the member and pipelines, which pass through
+	// the proxies of the peers.
+	proxy.BlackholeTx()
+	lg.Info("Blackholing traffic from member", zap.String("member", member.Config().Name))
+	time.Sleep(f.duration)
+	lg.Info("Traffic restored from member", zap.String("member", member.Config().Name))
+	proxy.UnblackholeTx()
+	return nil, nil
+}
+
+func (f blackholeOutboundPeerNetworkFailpoint) Name() string {
+	return "blackholePeerNetworkOutbound"
+}
+
+func (f blackholeOutboundPeerNetworkFailpoint) Available(config e2e.EtcdProcessClusterConfig, clus e2e.EtcdProcess, profile traffic.Profile) bool {
+	return config.ClusterSize > 1 && clus.PeerProxy() != nil
+}
+
+type flappingPeerNetworkFailpoint struct {
+	duration time.Duration
+}
+
+func (f flappingPeerNetworkFailpoint) Inject(ctx context.Context, t *testing.T, lg *zap.Logger, clus *e2e.EtcdProcessCluster, baseTime time.Time, ids identity.Provider) ([]report.ClientReport, error) {
+	member := clus.Procs[rand.Int()%len(clus.Procs)]
+	proxy := member.PeerProxy()
+	// Randomize how long the member stays disconnected, so some outages are
+	// short enough to go unnoticed and others cause an election.
+	maxOutage := 2 * time.Duration(clus.Cfg.ServerConfig.ElectionMs) * time.Millisecond
+
+	lg.Info("Flapping traffic from and to member", zap.String("member", member.Config().Name), zap.Duration("maxOutage", maxOutage))
+	deadline := time.Now().Add(f.duration)
+	for flaps := 0; time.Now().Before(deadline); flaps++ {
+		proxy.BlackholeTx()
+		proxy.BlackholeRx()
+		err := sleepWithContext(ctx, randomDuration(maxOutage))
+		proxy.UnblackholeTx()
+		proxy.UnblackholeRx()
+		if err != nil {
+			return nil, err
+		}
+		if err = sleepWithContext(ctx, randomDuration(maxOutage)); err != nil {
+			return nil, err
+		}
+		lg.Debug("Traffic flapped", zap.String("member", member.Config().Name), zap.Int("flaps", flaps+1))
+	}
+	lg.Info("Traffic flapping stopped", zap.String("member", member.Config().Name))
+	return nil, nil
+}
+
+func (f flappingPeerNetworkFailpoint) Name() string {
+	return "flappingPeerNetwork"
+}
+
+func (f flappingPeerNetworkFailpoint) Available(config e2e.EtcdProcessClusterConfig, clus e2e.EtcdProcess, profile traffic.Profile) bool {
+	return config.ClusterSize > 1 && clus.PeerProxy() != nil
+}
+
+func randomDuration(maxDuration time.Duration) time.Duration {
+	if maxDuration <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(maxDuration)))
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type netemPeerNetworkFailpoint struct {
+	duration time.Duration
+	config   e2e.NetemConfig
+}
+
+func (f netemPeerNetworkFailpoint) Inject(ctx context.Context, t *testing.T, lg *zap.Logger, clus *e2e.EtcdProcessCluster, baseTime time.Time, ids identity.Provider) ([]report.ClientReport, error) {
+	member := clus.Procs[rand.Int()%len(clus.Procs)]
+	port, err := strconv.Atoi(member.Config().PeerURL.Port())
+	if err != nil {
+		return nil, err
+	}
+
+	// Unlike the peer proxy, netem impairs individual packets, so TCP
+	// retransmissions and congestion control take part in the failure.
+	remove, err := e2e.ApplyNetem(ctx, port, f.config)
+	if err != nil {
+		return nil, err
+	}
+	lg.Info("Impairing traffic from and to member", zap.String("member", member.Config().Name), zap.Duration("latency", f.config.Latency), zap.Duration("jitter", f.config.Jitter), zap.Float64("lossPercent", f.config.LossPercent))
+	time.Sleep(f.duration)
+	lg.Info("Traffic impairment removed", zap.String("member", member.Config().Name))
+	// Use a fresh context so the impairment does not outlive a canceled injection.
+	removeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return nil, remove(removeCtx)
+}
+
+func (f netemPeerNetworkFailpoint) Name() string {
+	return "netemPeerNetwork"
+}
+
+func (f netemPeerNetworkFailpoint) Available(config e2e.EtcdProcessClusterConfig, clus e2e.EtcdProcess, profile traffic.Profile) bool {
+	return config.ClusterSize > 1 && e2e.NetemAvailable()
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package robustness
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+	"go.etcd.io/etcd/tests/v3/robustness/client"
+	"go.etcd.io/etcd/tests/v3/robustness/failpoint"
+	"go.etcd.io/etcd/tests/v3/robustness/identity"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+	"go.etcd.io/etcd/tests/v3/robustness/scenarios"
+	"go.etcd.io/etcd/tests/v3/robustness/traffic"
+	"go.etcd.io/etcd/tests/v3/robustness/validate"
+)
+
+func TestRobustnessMirror(t *testing.T) {
+	testRunner.BeforeTest(t)
+	for _, s := range scenarios.Mirror(t) {
+		t.Run(s.Name, func(t *testing.T) {
+			lg := zaptest.NewLogger(t)
+			s.Cluster.Logger = lg
+			s.Destination.Logger = lg
+			ctx := t.Context()
+			source, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithConfig(&s.Cluster))
+			require.NoError(t, err)
+			defer forcestopCluster(source)
+			destination, err := e2e.NewEtcdProcessCluster(ctx, t, e2e.WithConfig(&s.Destination))
+			require.NoError(t, err)
+			defer forcestopCluster(destination)
+			faulted := source
+			if s.FaultDestination {
+				faulted = destination
+			}
+			s.Failpoint, err = failpoint.PickRandom(faulted, s.Profile)
+			require.NoError(t, err)
+			t.Run(s.Failpoint.Name(), func(t *testing.T) {
+				testMirror(ctx, t, lg, s, source, destination)
+			})
+		})
+	}
+}
+
+func testMirror(ctx context.Context, t *testing.T, lg *zap.Logger, s scenarios.MirrorScenario, source, destination *e2e.EtcdProcessCluster) {
+	serverDataPaths := report.ServerDataPaths(source)
+	for name, dataPath := range report.ServerDataPaths(destination) {
+		serverDataPaths["destination-"+name] = dataPath
+	}
+	r := report.TestReport{
+		Logger:          lg,
+		ServersDataPath: serverDataPaths,
+		Traffic:         &report.TrafficDetail{ExpectUniqueRevision: s.Traffic.ExpectUniqueRevision()},
+	}
+	// t.Failed() returns false during panicking. We need to forcibly
+	// save data on panicking.
+	// Refer to: https://github.com/golang/go/issues/49929
+	panicked := true
+	defer func() {
+		_, persistResults := os.LookupEnv("PERSIST_RESULTS")
+		shouldReport := t.Failed() || panicked || persistResults
+		path := testResultsDirectory(t)
+		if shouldReport {
+			if err := r.Report(path); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	r.Client, r.Mirror = runMirrorScenario(ctx, t, s, lg, source, destination)
+	sourceRequests, err := report.PersistedRequestsCluster(lg, source)
+	if err != nil {
+		t.Error(err)
+	}
+	destinationRequests, err := report.PersistedRequestsCluster(lg, destination)
+	if err != nil {
+		t.Error(err)
+	}
+
+	validateConfig := validate.Config{ExpectRevisionUnique: s.Traffic.ExpectUniqueRevision()}
+	result := validate.ValidateAndReturnVisualize(lg, validateConfig, r.Client, sourceRequests, 5*time.Minute)
+	r.Visualize = result.Linearization.Visualize
+	if err = result.Error(); err != nil {
+		t.Error(err)
+	}
+	if err = validate.ValidateMirror(lg, r.Mirror, sourceRequests, destinationRequests).Error(); err != nil {
+		t.Errorf("mirror: %v", err)
+	}
+	panicked = false
+}
+
+func runMirrorScenario(ctx context.Context, t *testing.T, s scenarios.MirrorScenario, lg *zap.Logger, source, destination *e2e.EtcdProcessCluster) (reports []report.ClientReport, checkpoints []report.MirrorCheckpoint) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	g := errgroup.Group{}
+	var failpointClientReport []report.ClientReport
+	failpointInjected := make(chan report.FailpointInjection, 1)
+	faulted := source
+	if s.FaultDestination {
+		faulted = destination
+	}
+
+	baseTime := time.Now()
+	ids := identity.NewIDProvider()
+	g.Go(func() error {
+		defer close(failpointInjected)
+		time.Sleep(randomizeTime(WaitBeforeFailpoint, WaitJitter))
+		fr, err := failpoint.Inject(ctx, t, lg, faulted, s.Failpoint, baseTime, ids)
+		if err != nil {
+			t.Error(err)
+			cancel()
+		}
+		time.Sleep(randomizeTime(WaitAfterFailpoint, WaitJitter))
+		if fr != nil {
+			failpointInjected <- fr.FailpointInjection
+			failpointClientReport = fr.Client
+		}
+		return nil
+	})
+	trafficSet := client.NewSet(ids, baseTime)
+	defer trafficSet.Close()
+	watchMaxRevisionChan := make(chan int64, 1)
+	mirrorMaxRevisionChan := make(chan int64, 1)
+	g.Go(func() error {
+		defer close(watchMaxRevisionChan)
+		defer close(mirrorMaxRevisionChan)
+		operationReport := traffic.SimulateTraffic(ctx, t, lg, source, s.Profile, s.Traffic, failpointInjected, trafficSet)
+		maxRevision := report.OperationsMaxRevision(operationReport)
+		watchMaxRevisionChan <- maxRevision
+		mirrorMaxRevisionChan <- maxRevision
+		lg.Info("Finished simulating Traffic", zap.Int64("max-revision", maxRevision))
+		return nil
+	})
+	watchSet := client.NewSet(ids, baseTime)
+	defer watchSet.Close()
+	g.Go(func() error {
+		return client.CollectClusterWatchEvents(ctx, lg, processEndpoints(source), watchMaxRevisionChan, s.Watch, watchSet)
+	})
+	g.Go(func() error {
+		var err error
+		checkpoints, err = client.MirrorUntilRevision(ctx, lg, source.EndpointsGRPC(), destination.EndpointsGRPC(), mirrorMaxRevisionChan)
+		lg.Info("Finished mirroring", zap.Int("checkpoints", len(checkpoints)))
+		return err
+	})
+	err := g.Wait()
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, clus := range []*e2e.EtcdProcessCluster{source, destination} {
+		if err = client.CheckEndOfTestHashKV(ctx, clus); err != nil {
+			t.Error(err)
+		}
+	}
+	return slices.Concat(trafficSet.Reports(), watchSet.Reports(), failpointClientReport), checkpoints
+}
@@ -51,6 +51,11 @@ func (r *EtcdReplay) StateForRevision(revision int64) (EtcdState, error) {
 	return r.revisionToEtcdState[revision], nil
 }
 
+// LastRevision returns the latest revision observed in replay.
+func (r *EtcdReplay) LastRevision() int64 {
+	return int64(len(r.revisionToEtcdState) - 1)
+}
+
 func (r *EtcdReplay) EventsForWatch(watch WatchRequest) (events []PersistedEvent) {
 	for _, e := range r.Events {
 		if e.Revision < watch.Revision || !e.Match(watch) {
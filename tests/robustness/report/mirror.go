@@ -0,0 +1,57 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+// MirrorRevisionKey is the key under which the mirror stores, in the
+// destination cluster, the source revision it has replicated up to. It is
+// excluded when comparing source and destination keyspaces.
+const MirrorRevisionKey = "__mirror_revision"
+
+// MirrorCheckpoint maps a source revision to the destination revision at
+// which the mirror finished replicating it.
+type MirrorCheckpoint struct {
+	SourceRevision      int64 `json:"sourceRevision"`
+	DestinationRevision int64 `json:"destinationRevision"`
+}
+
+const mirrorCheckpointsFileName = "mirror.json"
+
+func persistMirrorCheckpoints(lg *zap.Logger, p string, checkpoints []MirrorCheckpoint) error {
+	lg.Info("Saving mirror checkpoints", zap.String("path", path.Join(p, mirrorCheckpointsFileName)))
+	b, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(p, mirrorCheckpointsFileName), b, 0o644)
+}
+
+func LoadMirrorCheckpoints(p string) ([]MirrorCheckpoint, error) {
+	var checkpoints []MirrorCheckpoint
+	b, err := os.ReadFile(filepath.Join(p, mirrorCheckpointsFileName))
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &checkpoints)
+	return checkpoints, err
+}
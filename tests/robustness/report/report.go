@@ -33,6 +33,7 @@ type TestReport struct {
 	Client          []ClientReport
 	Visualize       func(lg *zap.Logger, path string) error
 	Traffic         *TrafficDetail
+	Mirror          []MirrorCheckpoint
 }
 
 func (r *TestReport) Report(path string) error {
@@ -58,6 +59,11 @@ func (r *TestReport) Report(path string) error {
 			return err
 		}
 	}
+	if r.Mirror != nil {
+		if err := persistMirrorCheckpoints(r.Logger, path, r.Mirror); err != nil {
+			return err
+		}
+	}
 	if r.Visualize != nil {
 		if err := r.Visualize(r.Logger, filepath.Join(path, "history.html")); err != nil {
 			return err
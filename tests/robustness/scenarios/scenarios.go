@@ -283,5 +283,63 @@ func Regression(t *testing.T) []TestScenario {
 			Cluster:   *e2e.NewConfig(opts...),
 		})
 	}
+	// Linearizable reads served by a member that cannot reach its peers would be stale.
+	scenarios = append(scenarios, TestScenario{
+		Name:      "AsymmetricPartition",
+		Failpoint: failpoint.BlackholePeerNetworkOutbound,
+		Profile:   traffic.LowTraffic,
+		Traffic:   traffic.EtcdPut,
+		Cluster: *e2e.NewConfig(
+			e2e.WithPeerProxy(true),
+			e2e.WithIsPeerTLS(true),
+		),
+	})
+	return scenarios
+}
+
+// MirrorScenario replicates the keyspace of the Cluster to the Destination
+// cluster while injecting a failpoint into one of them.
+type MirrorScenario struct {
+	TestScenario
+	Destination e2e.EtcdProcessClusterConfig
+	// FaultDestination injects the failpoint into the destination cluster instead of the source one.
+	FaultDestination bool
+}
+
+// mirrorDestinationBasePort keeps destination cluster ports away from the source cluster.
+const mirrorDestinationBasePort = e2e.EtcdProcessBasePort + 1000
+
+func Mirror(_ *testing.T) []MirrorScenario {
+	baseOptions := []e2e.EPClusterOption{
+		e2e.WithGoFailEnabled(true),
+		e2e.WithWatchProcessNotifyInterval(100 * time.Millisecond),
+	}
+	scenarios := []MirrorScenario{}
+	for _, tp := range trafficProfiles {
+		// Mirror applies a single transaction per source revision, so it would lag behind high traffic.
+		if tp.Profile.MaximalQPS > traffic.LowTraffic.MaximalQPS {
+			continue
+		}
+		for _, faultDestination := range []bool{false, true} {
+			name := filepath.Join(tp.Name, "FaultSource")
+			if faultDestination {
+				name = filepath.Join(tp.Name, "FaultDestination")
+			}
+			scenarios = append(scenarios, MirrorScenario{
+				TestScenario: TestScenario{
+					Name:    name,
+					Traffic: tp.Traffic,
+					// Frequent compaction forces the mirror to resynchronize.
+					Profile: tp.Profile.WithCompactionPeriod(time.Second),
+					Cluster: *e2e.NewConfig(append(baseOptions, options.WithSnapshotCount(50, 100, 1000))...),
+				},
+				Destination: *e2e.NewConfig(append(baseOptions,
+					options.WithSnapshotCount(50, 100, 1000),
+					e2e.WithBasePort(mirrorDestinationBasePort),
+				)...),
+				FaultDestination: faultDestination,
+			})
+		}
+	}
 	return scenarios
 }
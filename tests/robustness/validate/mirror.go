@@ -0,0 +1,110 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+var (
+	errMirrorNoCheckpoints = errors.New("mirror didn't replicate any revision")
+	errMirrorNotOrdered    = errors.New("mirror checkpoints are not ordered by revision")
+	errMirrorDiverged      = errors.New("destination keyspace doesn't match source keyspace")
+	errMirrorTrailing      = errors.New("destination changed after last mirror checkpoint")
+)
+
+// ValidateMirror checks that for every checkpoint the destination keyspace at
+// the destination revision matches the source keyspace at the source revision,
+// and that the destination was not modified after the last checkpoint.
+// Key mod revisions and versions are not compared, as the mirror doesn't
+// preserve them. report.MirrorRevisionKey is ignored.
+func ValidateMirror(lg *zap.Logger, checkpoints []report.MirrorCheckpoint, sourceRequests, destinationRequests []model.EtcdRequest) Result {
+	lg.Info("Validating mirror")
+	start := time.Now()
+	err := validateMirrorError(lg, checkpoints, model.NewReplay(sourceRequests), model.NewReplay(destinationRequests))
+	if err != nil {
+		lg.Error("Mirror validation failed", zap.Duration("duration", time.Since(start)), zap.Error(err))
+	} else {
+		lg.Info("Mirror validation success", zap.Duration("duration", time.Since(start)))
+	}
+	return ResultFromError(err)
+}
+
+func validateMirrorError(lg *zap.Logger, checkpoints []report.MirrorCheckpoint, source, destination *model.EtcdReplay) error {
+	if len(checkpoints) == 0 {
+		return errMirrorNoCheckpoints
+	}
+	for i, checkpoint := range checkpoints {
+		if i > 0 {
+			previous := checkpoints[i-1]
+			if checkpoint.SourceRevision <= previous.SourceRevision || checkpoint.DestinationRevision <= previous.DestinationRevision {
+				lg.Error("Mirror checkpoints out of order", zap.Any("previous", previous), zap.Any("checkpoint", checkpoint))
+				return errMirrorNotOrdered
+			}
+		}
+		sourceState, err := source.StateForRevision(checkpoint.SourceRevision)
+		if err != nil {
+			return fmt.Errorf("source: %w", err)
+		}
+		destinationState, err := destination.StateForRevision(checkpoint.DestinationRevision)
+		if err != nil {
+			return fmt.Errorf("destination: %w", err)
+		}
+		if key, ok := firstDivergingKey(sourceState, destinationState); ok {
+			lg.Error("Mirror diverged",
+				zap.Int64("source-revision", checkpoint.SourceRevision),
+				zap.Int64("destination-revision", checkpoint.DestinationRevision),
+				zap.String("key", key),
+				zap.Any("source", sourceState.KeyValues[key].Value),
+				zap.Any("destination", destinationState.KeyValues[key].Value),
+			)
+			return errMirrorDiverged
+		}
+	}
+	last := checkpoints[len(checkpoints)-1]
+	if destination.LastRevision() != last.DestinationRevision {
+		lg.Error("Destination modified after last mirror checkpoint", zap.Int64("checkpoint-revision", last.DestinationRevision), zap.Int64("last-revision", destination.LastRevision()))
+		return errMirrorTrailing
+	}
+	return nil
+}
+
+func firstDivergingKey(source, destination model.EtcdState) (string, bool) {
+	var keys []string
+	for key, sourceValue := range source.KeyValues {
+		destinationValue, ok := destination.KeyValues[key]
+		if !ok || destinationValue.Value != sourceValue.Value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range destination.KeyValues {
+		if _, ok := source.KeyValues[key]; !ok && key != report.MirrorRevisionKey {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "", false
+	}
+	sort.Strings(keys)
+	return keys[0], true
+}
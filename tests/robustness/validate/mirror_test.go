@@ -0,0 +1,147 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/tests/v3/robustness/model"
+	"go.etcd.io/etcd/tests/v3/robustness/report"
+)
+
+func TestValidateMirror(t *testing.T) {
+	source := []model.EtcdRequest{
+		putRequest("a", "1"),
+		putRequest("b", "2"),
+		deleteRequest("a"),
+	}
+	tcs := []struct {
+		name        string
+		checkpoints []report.MirrorCheckpoint
+		destination []model.EtcdRequest
+		expectError string
+	}{
+		{
+			name:        "Success, revision by revision",
+			checkpoints: []report.MirrorCheckpoint{checkpoint(2, 2), checkpoint(3, 3), checkpoint(4, 4)},
+			destination: []model.EtcdRequest{
+				mirrorRequest(2, putOperation("a", "1")),
+				mirrorRequest(3, putOperation("b", "2")),
+				mirrorRequest(4, deleteOperation("a")),
+			},
+		},
+		{
+			name:        "Success, resynchronized after compaction",
+			checkpoints: []report.MirrorCheckpoint{checkpoint(2, 2), checkpoint(4, 3)},
+			destination: []model.EtcdRequest{
+				mirrorRequest(2, putOperation("a", "1")),
+				mirrorRequest(4, putOperation("b", "2"), deleteOperation("a")),
+			},
+		},
+		{
+			name:        "Failure, no checkpoints",
+			destination: []model.EtcdRequest{},
+			expectError: errMirrorNoCheckpoints.Error(),
+		},
+		{
+			name:        "Failure, value diverged",
+			checkpoints: []report.MirrorCheckpoint{checkpoint(2, 2), checkpoint(3, 3)},
+			destination: []model.EtcdRequest{
+				mirrorRequest(2, putOperation("a", "1")),
+				mirrorRequest(3, putOperation("b", "3")),
+			},
+			expectError: errMirrorDiverged.Error(),
+		},
+		{
+			name:        "Failure, missing delete",
+			checkpoints: []report.MirrorCheckpoint{checkpoint(2, 2), checkpoint(4, 3)},
+			destination: []model.EtcdRequest{
+				mirrorRequest(2, putOperation("a", "1")),
+				mirrorRequest(4, putOperation("b", "2")),
+			},
+			expectError: errMirrorDiverged.Error(),
+		},
+		{
+			name:        "Failure, checkpoints out of order",
+			checkpoints: []report.MirrorCheckpoint{checkpoint(3, 2), checkpoint(2, 3)},
+			destination: []model.EtcdRequest{
+				mirrorRequest(3, putOperation("a", "1"), putOperation("b", "2")),
+				mirrorRequest(2, deleteOperation("b")),
+			},
+			expectError: errMirrorNotOrdered.Error(),
+		},
+		{
+			name:        "Failure, destination modified after last checkpoint",
+			checkpoints: []report.MirrorCheckpoint{checkpoint(2, 2)},
+			destination: []model.EtcdRequest{
+				mirrorRequest(2, putOperation("a", "1")),
+				putRequest("c", "3"),
+			},
+			expectError: errMirrorTrailing.Error(),
+		},
+		{
+			name:        "Failure, checkpoint beyond source history",
+			checkpoints: []report.MirrorCheckpoint{checkpoint(5, 2)},
+			destination: []model.EtcdRequest{
+				mirrorRequest(5, putOperation("b", "2")),
+			},
+			expectError: "source: requested revision 5, higher than observed in replay 4",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ValidateMirror(zaptest.NewLogger(t), tc.checkpoints, source, tc.destination)
+			if tc.expectError != "" {
+				assert.EqualError(t, result.Error(), tc.expectError)
+			} else {
+				assert.NoError(t, result.Error())
+			}
+		})
+	}
+}
+
+func mirrorRequest(sourceRevision int64, ops ...model.EtcdOperation) model.EtcdRequest {
+	return model.EtcdRequest{
+		Type: model.Txn,
+		Txn: &model.TxnRequest{
+			OperationsOnSuccess: append(ops, putOperation(report.MirrorRevisionKey, strconv.FormatInt(sourceRevision, 10))),
+		},
+	}
+}
+
+func putOperation(key, value string) model.EtcdOperation {
+	return model.EtcdOperation{
+		Type: model.PutOperation,
+		Put: model.PutOptions{
+			Key:   key,
+			Value: model.ToValueOrHash(value),
+		},
+	}
+}
+
+func deleteOperation(key string) model.EtcdOperation {
+	return model.EtcdOperation{
+		Type:   model.DeleteOperation,
+		Delete: model.DeleteOptions{Key: key},
+	}
+}
+
+func checkpoint(sourceRevision, destinationRevision int64) report.MirrorCheckpoint {
+	return report.MirrorCheckpoint{SourceRevision: sourceRevision, DestinationRevision: destinationRevision}
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+
+	v3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/report"
+)
+
+// compareCmd represents the compare command
+var compareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Benchmark the same workload against two clusters and compare the results",
+	Long: `Runs an identical, seeded workload against two sets of endpoints (A and B)
+and prints their latency and throughput side by side.
+
+The global --endpoints and --target-leader flags are ignored; the other
+global flags (--conns, --clients, TLS and authentication) apply to both sides.`,
+
+	Run: compareFunc,
+}
+
+var (
+	compareEndpointsA []string
+	compareEndpointsB []string
+	compareWorkload   string
+	compareMode       string
+	compareSeed       int64
+	compareTotal      int
+	compareRate       int
+	compareRWRatio    float64
+)
+
+func init() {
+	RootCmd.AddCommand(compareCmd)
+	compareCmd.Flags().StringSliceVar(&compareEndpointsA, "a-endpoints", nil, "gRPC endpoints of cluster A")
+	compareCmd.Flags().StringSliceVar(&compareEndpointsB, "b-endpoints", nil, "gRPC endpoints of cluster B")
+	compareCmd.Flags().StringVar(&compareWorkload, "workload", "put", "Workload to run: 'put', 'range' or 'txn-mixed'")
+	compareCmd.Flags().StringVar(&compareMode, "mode", "sequential", "Run A then B ('sequential') or both at once ('concurrent')")
+	compareCmd.Flags().Int64Var(&compareSeed, "seed", 1, "Seed of the workload generator; both sides receive the same requests")
+	compareCmd.Flags().IntVar(&compareTotal, "total", 10000, "Total number of requests sent to each side")
+	compareCmd.Flags().IntVar(&compareRate, "rate", 0, "Maximum requests per second on each side (0 is no limit)")
+	compareCmd.Flags().IntVar(&keySize, "key-size", 8, "Key size of the workload")
+	compareCmd.Flags().IntVar(&valSize, "val-size", 8, "Value size of put requests")
+	compareCmd.Flags().IntVar(&keySpaceSize, "key-space-size", 1, "Maximum possible keys")
+	compareCmd.Flags().StringVar(&rangeConsistency, "consistency", "l", "Linearizable(l) or Serializable(s) range requests")
+	compareCmd.Flags().Float64Var(&compareRWRatio, "rw-ratio", 1, "Read/write ops ratio of the txn-mixed workload")
+}
+
+func compareFunc(cmd *cobra.Command, _ []string) {
+	if len(compareEndpointsA) == 0 || len(compareEndpointsB) == 0 {
+		fmt.Fprintln(os.Stderr, "both --a-endpoints and --b-endpoints are required")
+		os.Exit(1)
+	}
+	if compareMode != "sequential" && compareMode != "concurrent" {
+		fmt.Fprintf(os.Stderr, "unknown --mode %q\n", compareMode)
+		os.Exit(1)
+	}
+	if keySpaceSize <= 0 {
+		fmt.Fprintf(os.Stderr, "expected positive --key-space-size, got (%v)\n", keySpaceSize)
+		os.Exit(1)
+	}
+	if rangeConsistency != "l" && rangeConsistency != "s" {
+		fmt.Fprintln(os.Stderr, cmd.Usage())
+		os.Exit(1)
+	}
+
+	ops, err := compareOps(compareWorkload, compareSeed, compareTotal)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	clientsA := mustCreateClientsTo(compareEndpointsA, totalClients, totalConns)
+	clientsB := mustCreateClientsTo(compareEndpointsB, totalClients, totalConns)
+
+	// both sides advance a single bar, since a pool of bars requires a terminal
+	bar = pb.New(2 * compareTotal)
+	bar.Start()
+
+	var statsA, statsB report.Stats
+	if compareMode == "concurrent" {
+		var cwg sync.WaitGroup
+		cwg.Add(2)
+		go func() {
+			defer cwg.Done()
+			statsA = runCompareSide(cmd.Name()+"-a", clientsA, ops)
+		}()
+		go func() {
+			defer cwg.Done()
+			statsB = runCompareSide(cmd.Name()+"-b", clientsB, ops)
+		}()
+		cwg.Wait()
+	} else {
+		statsA = runCompareSide(cmd.Name()+"-a", clientsA, ops)
+		statsB = runCompareSide(cmd.Name()+"-b", clientsB, ops)
+	}
+	bar.Finish()
+
+	fmt.Printf("\nComparison of %d %s requests (seed %d, %s):\n", compareTotal, compareWorkload, compareSeed, compareMode)
+	printComparison(os.Stdout, statsA, statsB)
+}
+
+// compareOps deterministically generates the requests sent to both sides,
+// so that A and B observe exactly the same keys, values and op mix.
+func compareOps(workload string, seed int64, total int) ([]v3.Op, error) {
+	rnd := rand.New(rand.NewSource(seed))
+	key := func() string {
+		k := make([]byte, keySize)
+		binary.PutVarint(k, int64(rnd.Intn(keySpaceSize)))
+		return string(k)
+	}
+	val := func() string {
+		v := make([]byte, valSize)
+		rnd.Read(v)
+		return string(v)
+	}
+	get := func() v3.Op {
+		if rangeConsistency == "s" {
+			return v3.OpGet(key(), v3.WithSerializable())
+		}
+		return v3.OpGet(key())
+	}
+
+	ops := make([]v3.Op, total)
+	for i := range ops {
+		switch workload {
+		case "put":
+			ops[i] = v3.OpPut(key(), val())
+		case "range":
+			ops[i] = get()
+		case "txn-mixed":
+			var op v3.Op
+			if rnd.Float64() < compareRWRatio/(1+compareRWRatio) {
+				op = get()
+			} else {
+				op = v3.OpPut(key(), val())
+			}
+			ops[i] = v3.OpTxn(nil, []v3.Op{op}, nil)
+		default:
+			return nil, fmt.Errorf("unknown --workload %q", workload)
+		}
+	}
+	return ops, nil
+}
+
+func runCompareSide(name string, clients []*v3.Client, ops []v3.Op) report.Stats {
+	r := rate.Limit(compareRate)
+	if compareRate == 0 {
+		r = math.MaxInt32
+	}
+	limit := rate.NewLimiter(r, 1)
+
+	requests := make(chan v3.Op, len(clients))
+	rep := newReport(name)
+	var swg sync.WaitGroup
+	for _, c := range clients {
+		swg.Add(1)
+		go func(c *v3.Client) {
+			defer swg.Done()
+			for op := range requests {
+				limit.Wait(context.Background())
+				st := time.Now()
+				_, err := c.Do(context.Background(), op)
+				rep.Results() <- report.Result{Err: err, Start: st, End: time.Now()}
+				bar.Increment()
+			}
+		}(c)
+	}
+
+	go func() {
+		for _, op := range ops {
+			requests <- op
+		}
+		close(requests)
+	}()
+
+	sc := rep.Stats()
+	swg.Wait()
+	close(rep.Results())
+	return <-sc
+}
+
+func printComparison(out io.Writer, a, b report.Stats) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "\tA\tB\tB vs A\t")
+
+	secs := func(label string, x, y float64) {
+		fmt.Fprintf(w, "%s\t%.4f secs\t%.4f secs\t%s\t\n", label, x, y, compareDelta(x, y))
+	}
+	secs("Total", a.Total.Seconds(), b.Total.Seconds())
+	fmt.Fprintf(w, "Requests/sec\t%.4f\t%.4f\t%s\t\n", a.RPS, b.RPS, compareDelta(a.RPS, b.RPS))
+	secs("Average", a.Average, b.Average)
+	secs("Stddev", a.Stddev, b.Stddev)
+	secs("Fastest", a.Fastest, b.Fastest)
+	secs("Slowest", a.Slowest, b.Slowest)
+
+	pcs, pa := report.Percentiles(a.Lats)
+	_, pbs := report.Percentiles(b.Lats)
+	for i, pc := range pcs {
+		if pc < 50 {
+			continue
+		}
+		secs(fmt.Sprintf("p%g", pc), pa[i], pbs[i])
+	}
+
+	errsA, errsB := 0, 0
+	for _, n := range a.ErrorDist {
+		errsA += n
+	}
+	for _, n := range b.ErrorDist {
+		errsB += n
+	}
+	fmt.Fprintf(w, "Errors\t%d\t%d\t\t\n", errsA, errsB)
+	w.Flush()
+
+	printCompareErrors(out, "A", a.ErrorDist)
+	printCompareErrors(out, "B", b.ErrorDist)
+}
+
+func printCompareErrors(out io.Writer, side string, dist map[string]int) {
+	if len(dist) == 0 {
+		return
+	}
+	errs := make([]string, 0, len(dist))
+	for e := range dist {
+		errs = append(errs, e)
+	}
+	sort.Strings(errs)
+	fmt.Fprintf(out, "\nError distribution of %s:\n", side)
+	for _, e := range errs {
+		fmt.Fprintf(out, "  [%d]\t%s\n", dist[e], e)
+	}
+}
+
+// compareDelta returns the relative change from a to b.
+func compareDelta(a, b float64) string {
+	if a == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", (b-a)/a*100)
+}
@@ -93,6 +93,18 @@ func mustCreateConn() *clientv3.Client {
 		connEndpoints = []string{endpoints[dialTotal%len(endpoints)]}
 		dialTotal++
 	}
+	client := mustDial(connEndpoints)
+	if targetLeader && len(leaderEps) == 0 {
+		mustFindLeaderEndpoints(client)
+		client.Close()
+		return mustCreateConn()
+	}
+	return client
+}
+
+// mustDial creates a client connected to the given endpoints using the
+// global TLS, authentication and timeout flags.
+func mustDial(connEndpoints []string) *clientv3.Client {
 	cfg := clientv3.Config{
 		AutoSyncInterval: autoSyncInterval,
 		Endpoints:        connEndpoints,
@@ -118,11 +130,6 @@ func mustCreateConn() *clientv3.Client {
 	}
 
 	client, err := clientv3.New(cfg)
-	if targetLeader && len(leaderEps) == 0 {
-		mustFindLeaderEndpoints(client)
-		client.Close()
-		return mustCreateConn()
-	}
 
 	grpclog.SetLoggerV2(grpclog.NewLoggerV2(os.Stderr, os.Stderr, os.Stderr))
 
@@ -147,6 +154,22 @@ func mustCreateClients(totalClients, totalConns uint) []*clientv3.Client {
 	return clients
 }
 
+// mustCreateClientsTo is like mustCreateClients but hands out connections
+// to the given endpoints in round-robin order, ignoring --endpoints and
+// --target-leader.
+func mustCreateClientsTo(eps []string, totalClients, totalConns uint) []*clientv3.Client {
+	conns := make([]*clientv3.Client, totalConns)
+	for i := range conns {
+		conns[i] = mustDial([]string{eps[i%len(eps)]})
+	}
+
+	clients := make([]*clientv3.Client, totalClients)
+	for i := range clients {
+		clients[i] = conns[i%int(totalConns)]
+	}
+	return clients
+}
+
 func mustRandBytes(n int) []byte {
 	rb := make([]byte, n)
 	_, err := rand.Read(rb)
@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/time/rate"
 
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/report"
 )
@@ -34,7 +35,12 @@ var watchLatencyCmd = &cobra.Command{
 	Short: "Benchmark watch latency",
 	Long: `Benchmarks the latency for watches by measuring
 	the latency between writing to a key and receiving the
-	associated watch response.`,
+	associated watch response.
+
+	The watchers are spread over --ranges keys, which are written
+	round-robin. --slow-watchers adds watchers on the same keys that
+	read their responses slowly, so that the server has to handle
+	watchers falling behind while the latency is measured.`,
 	Run: watchLatencyFunc,
 }
 
@@ -46,6 +52,9 @@ var (
 	watchLStreams           int
 	watchLWatchersPerStream int
 	watchLPrevKV            bool
+	watchLRanges            int
+	watchLSlowWatchers      int
+	watchLSlowDelay         time.Duration
 )
 
 func init() {
@@ -53,6 +62,9 @@ func init() {
 	watchLatencyCmd.Flags().IntVar(&watchLStreams, "streams", 10, "Total watch streams")
 	watchLatencyCmd.Flags().IntVar(&watchLWatchersPerStream, "watchers-per-stream", 10, "Total watchers per stream")
 	watchLatencyCmd.Flags().BoolVar(&watchLPrevKV, "prevkv", false, "PrevKV enabled on watch requests")
+	watchLatencyCmd.Flags().IntVar(&watchLRanges, "ranges", 1, "Number of keys the watchers are spread over")
+	watchLatencyCmd.Flags().IntVar(&watchLSlowWatchers, "slow-watchers", 0, "Number of additional watchers that read their responses slowly")
+	watchLatencyCmd.Flags().DurationVar(&watchLSlowDelay, "slow-watcher-delay", time.Second, "Delay between two responses read by a slow watcher")
 
 	watchLatencyCmd.Flags().IntVar(&watchLPutTotal, "put-total", 1000, "Total number of put requests")
 	watchLatencyCmd.Flags().IntVar(&watchLPutRate, "put-rate", 100, "Number of keys to put per second")
@@ -60,33 +72,53 @@ func init() {
 	watchLatencyCmd.Flags().IntVar(&watchLValueSize, "val-size", 32, "Value size of watch response")
 }
 
+// watchEvent is the revision of an event and the time it was received.
+type watchEvent struct {
+	rev int64
+	at  time.Time
+}
+
 func watchLatencyFunc(cmd *cobra.Command, _ []string) {
-	key := string(mustRandBytes(watchLKeySize))
+	if watchLRanges < 1 {
+		fmt.Fprintf(os.Stderr, "expected positive --ranges, got (%v)\n", watchLRanges)
+		os.Exit(1)
+	}
+	keys := make([]string, watchLRanges)
+	for i := range keys {
+		keys[i] = string(mustRandBytes(watchLKeySize))
+	}
 	value := string(mustRandBytes(watchLValueSize))
-	wchs := setupWatchChannels(key)
+	wchs := setupWatchChannels(keys)
 	putClient := mustCreateConn()
 
-	bar = pb.New(watchLPutTotal * len(wchs))
+	slowCtx, stopSlow := context.WithCancel(context.Background())
+	defer stopSlow()
+	setupSlowWatchers(slowCtx, keys)
+
+	total := 0
+	eventTimes := make([][]watchEvent, len(wchs))
+	for i := range wchs {
+		eventTimes[i] = make([]watchEvent, 0, putsPerRange(i%len(keys)))
+		total += cap(eventTimes[i])
+	}
+	bar = pb.New(total)
 	bar.Start()
 
 	limiter := rate.NewLimiter(rate.Limit(watchLPutRate), watchLPutRate)
 
-	putTimes := make([]time.Time, watchLPutTotal)
-	eventTimes := make([][]time.Time, len(wchs))
+	putTimes := make(map[int64]time.Time, watchLPutTotal)
 
 	for i, wch := range wchs {
 		wch := wch
 		i := i
-		eventTimes[i] = make([]time.Time, watchLPutTotal)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			eventCount := 0
-			for eventCount < watchLPutTotal {
+			for len(eventTimes[i]) < cap(eventTimes[i]) {
 				resp := <-wch
-				for range resp.Events {
-					eventTimes[i][eventCount] = time.Now()
-					eventCount++
+				now := time.Now()
+				for _, ev := range resp.Events {
+					eventTimes[i] = append(eventTimes[i], watchEvent{rev: ev.Kv.ModRevision, at: now})
 					bar.Increment()
 				}
 			}
@@ -103,27 +135,29 @@ func watchLatencyFunc(cmd *cobra.Command, _ []string) {
 			break
 		}
 		start := time.Now()
-		if _, err := putClient.Put(context.TODO(), key, value); err != nil {
+		resp, err := putClient.Put(context.TODO(), keys[i%len(keys)], value)
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to Put for watch latency benchmark: %v\n", err)
 			os.Exit(1)
 		}
 		end := time.Now()
 		putReport.Results() <- report.Result{Start: start, End: end}
-		putTimes[i] = end
+		putTimes[resp.Header.Revision] = end
 	}
 	wg.Wait()
 	close(putReport.Results())
 	bar.Finish()
 	fmt.Printf("\nPut summary:\n%s", <-putReportResults)
 
-	for i := 0; i < len(wchs); i++ {
-		for j := 0; j < watchLPutTotal; j++ {
-			start := putTimes[j]
-			end := eventTimes[i][j]
-			if end.Before(start) {
-				start = end
+	for i := range eventTimes {
+		for _, ev := range eventTimes[i] {
+			// the put is committed before its response is received, so the
+			// event may be received first
+			start := putTimes[ev.rev]
+			if ev.at.Before(start) {
+				start = ev.at
 			}
-			watchReport.Results() <- report.Result{Start: start, End: end}
+			watchReport.Results() <- report.Result{Start: start, End: ev.at}
 		}
 	}
 
@@ -131,7 +165,16 @@ func watchLatencyFunc(cmd *cobra.Command, _ []string) {
 	fmt.Printf("\nWatch events summary:\n%s", <-watchReportResults)
 }
 
-func setupWatchChannels(key string) []clientv3.WatchChan {
+// putsPerRange returns the number of puts written to the key of the given range.
+func putsPerRange(r int) int {
+	n := watchLPutTotal / watchLRanges
+	if r < watchLPutTotal%watchLRanges {
+		n++
+	}
+	return n
+}
+
+func setupWatchChannels(keys []string) []clientv3.WatchChan {
 	clients := mustCreateClients(totalClients, totalConns)
 
 	streams := make([]clientv3.Watcher, watchLStreams)
@@ -145,8 +188,46 @@ func setupWatchChannels(key string) []clientv3.WatchChan {
 	wchs := make([]clientv3.WatchChan, len(streams)*watchLWatchersPerStream)
 	for i := 0; i < len(streams); i++ {
 		for j := 0; j < watchLWatchersPerStream; j++ {
-			wchs[i*watchLWatchersPerStream+j] = streams[i].Watch(context.TODO(), key, opts...)
+			w := i*watchLWatchersPerStream + j
+			wchs[w] = streams[i].Watch(context.TODO(), keys[w%len(keys)], opts...)
 		}
 	}
 	return wchs
 }
+
+// setupSlowWatchers starts watchers that read one response per
+// watchLSlowDelay until ctx is done. They use raw watch streams on their own
+// connection, so that the server, not the client library, buffers the events
+// they have not read yet.
+func setupSlowWatchers(ctx context.Context, keys []string) {
+	if watchLSlowWatchers <= 0 {
+		return
+	}
+	wc := etcdserverpb.NewWatchClient(mustCreateConn().ActiveConnection())
+	for i := 0; i < watchLSlowWatchers; i++ {
+		ws, err := wc.Watch(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create slow watch stream: %v\n", err)
+			os.Exit(1)
+		}
+		req := &etcdserverpb.WatchRequest{RequestUnion: &etcdserverpb.WatchRequest_CreateRequest{
+			CreateRequest: &etcdserverpb.WatchCreateRequest{Key: []byte(keys[i%len(keys)]), PrevKv: watchLPrevKV},
+		}}
+		if err = ws.Send(req); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create slow watcher: %v\n", err)
+			os.Exit(1)
+		}
+		go func() {
+			for {
+				if _, err := ws.Recv(); err != nil {
+					return
+				}
+				select {
+				case <-time.After(watchLSlowDelay):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
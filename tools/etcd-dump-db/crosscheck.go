@@ -0,0 +1,208 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+	"go.etcd.io/etcd/server/v3/storage/wal"
+	"go.etcd.io/etcd/server/v3/storage/wal/walpb"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// walState is what the snapshot and the WAL of a member imply about its
+// backend.
+type walState struct {
+	snapIndex, snapTerm uint64
+	commit              uint64
+	firstIndex          uint64
+	lastIndex           uint64
+	// terms are the terms of the entries in the WAL, by index.
+	terms map[uint64]uint64
+	// confChanges are the configuration changes in the WAL, ordered by index.
+	confChanges []raftpb.Entry
+	// learners are the members of the snapshot configuration, true for learners.
+	learners map[types.ID]bool
+}
+
+// backendState is what the backend of a member records.
+type backendState struct {
+	index, term uint64
+	// learners are the members of the cluster, true for learners.
+	learners map[types.ID]bool
+}
+
+func readWALState(lg *zap.Logger, dataDir, walDir string) (*walState, error) {
+	walSnaps, err := wal.ValidSnapshotEntries(lg, walDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WAL snapshot entries: %w", err)
+	}
+	ws := &walState{terms: make(map[uint64]uint64), learners: make(map[types.ID]bool)}
+	var walsnap walpb.Snapshot
+	snapshot, err := snap.New(lg, snapDir(dataDir)).LoadNewestAvailable(walSnaps)
+	switch {
+	case err == nil:
+		ws.snapIndex, ws.snapTerm = snapshot.Metadata.Index, snapshot.Metadata.Term
+		walsnap.Index, walsnap.Term = ws.snapIndex, ws.snapTerm
+		cs := snapshot.Metadata.ConfState
+		for _, id := range append(cs.Voters, cs.VotersOutgoing...) {
+			ws.learners[types.ID(id)] = false
+		}
+		for _, id := range append(cs.Learners, cs.LearnersNext...) {
+			ws.learners[types.ID(id)] = true
+		}
+	case !errors.Is(err, snap.ErrNoSnapshot):
+		return nil, fmt.Errorf("failed to load snapshot: %w", err)
+	}
+
+	w, err := wal.OpenForRead(lg, walDir, walsnap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open WAL: %w", err)
+	}
+	defer w.Close()
+	_, hs, ents, err := w.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WAL: %w", err)
+	}
+	ws.commit = hs.Commit
+	ws.lastIndex = ws.snapIndex
+	if len(ents) > 0 {
+		ws.firstIndex, ws.lastIndex = ents[0].Index, ents[len(ents)-1].Index
+	}
+	for _, e := range ents {
+		ws.terms[e.Index] = e.Term
+		if e.Type == raftpb.EntryConfChange {
+			ws.confChanges = append(ws.confChanges, e)
+		}
+	}
+	return ws, nil
+}
+
+func readBackendState(lg *zap.Logger, dbPath string) *backendState {
+	be := backend.NewDefaultBackend(lg, dbPath)
+	defer be.Close()
+	bs := &backendState{learners: make(map[types.ID]bool)}
+	bs.index, bs.term = schema.ReadConsistentIndex(be.ReadTx())
+	members, _ := schema.NewMembershipBackend(lg, be).MustReadMembersFromBackend()
+	for id, m := range members {
+		bs.learners[id] = m.IsLearner
+	}
+	return bs
+}
+
+// crossCheck returns the divergences between the backend and what the
+// snapshot and the WAL imply.
+func crossCheck(ws *walState, bs *backendState) (divergences []string) {
+	switch {
+	case bs.index < ws.snapIndex:
+		divergences = append(divergences, fmt.Sprintf("consistent index %d is behind the snapshot index %d; the backend is older than the snapshot", bs.index, ws.snapIndex))
+	case bs.index > ws.lastIndex:
+		divergences = append(divergences, fmt.Sprintf("consistent index %d is beyond the last WAL entry %d; applied entries are missing from the WAL", bs.index, ws.lastIndex))
+	case bs.index > ws.commit:
+		divergences = append(divergences, fmt.Sprintf("consistent index %d is beyond the WAL commit index %d; uncommitted entries were applied", bs.index, ws.commit))
+	}
+
+	// backends written before the term was recorded have a term of 0
+	if bs.term != 0 {
+		wantTerm, ok := ws.terms[bs.index]
+		if bs.index == ws.snapIndex {
+			wantTerm, ok = ws.snapTerm, true
+		}
+		if ok && wantTerm != bs.term {
+			divergences = append(divergences, fmt.Sprintf("term %d of the consistent index %d differs from the term %d of the WAL entry", bs.term, bs.index, wantTerm))
+		}
+	}
+
+	// replay the configuration changes applied to the backend onto the
+	// configuration of the snapshot
+	learners := make(map[types.ID]bool, len(ws.learners))
+	for id, isLearner := range ws.learners {
+		learners[id] = isLearner
+	}
+	changedAt := make(map[types.ID]uint64)
+	for _, e := range ws.confChanges {
+		if e.Index > bs.index {
+			break
+		}
+		var cc raftpb.ConfChange
+		if err := cc.Unmarshal(e.Data); err != nil {
+			divergences = append(divergences, fmt.Sprintf("failed to decode the configuration change of entry %d: %v", e.Index, err))
+			continue
+		}
+		id := types.ID(cc.NodeID)
+		switch cc.Type {
+		case raftpb.ConfChangeAddNode:
+			learners[id] = false
+		case raftpb.ConfChangeAddLearnerNode:
+			learners[id] = true
+		case raftpb.ConfChangeRemoveNode:
+			delete(learners, id)
+		default:
+			continue
+		}
+		changedAt[id] = e.Index
+	}
+	for _, id := range sortedIDs(learners, bs.learners) {
+		want, inWAL := learners[id]
+		got, inBackend := bs.learners[id]
+		at := "the snapshot"
+		if idx, ok := changedAt[id]; ok {
+			at = fmt.Sprintf("entry %d", idx)
+		}
+		switch {
+		case inWAL && !inBackend:
+			divergences = append(divergences, fmt.Sprintf("member %s of the configuration as of %s is missing from the backend", id, at))
+		case !inWAL && inBackend:
+			if _, removed := changedAt[id]; removed {
+				divergences = append(divergences, fmt.Sprintf("member %s removed by %s is still in the backend", id, at))
+			} else {
+				divergences = append(divergences, fmt.Sprintf("member %s of the backend is not in the configuration", id))
+			}
+		case want != got:
+			divergences = append(divergences, fmt.Sprintf("member %s is a %s in the backend but a %s as of %s", id, memberRole(got), memberRole(want), at))
+		}
+	}
+	return divergences
+}
+
+func memberRole(isLearner bool) string {
+	if isLearner {
+		return "learner"
+	}
+	return "voter"
+}
+
+func sortedIDs(ms ...map[types.ID]bool) []types.ID {
+	seen := make(map[types.ID]struct{})
+	var ids []types.ID
+	for _, m := range ms {
+		for id := range m {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
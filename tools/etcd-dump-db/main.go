@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/client/pkg/v3/fileutil"
 )
@@ -53,12 +54,19 @@ var (
 		Short: "hash computes the hash of db file.",
 		Run:   getHashCommandFunc,
 	}
+	crossCheckCommand = &cobra.Command{
+		Use:   "cross-check --data-dir [data dir]",
+		Short: "cross-check compares the consistent index, term and membership of the db file against the WAL.",
+		Run:   crossCheckCommandFunc,
+	}
 )
 
 var (
 	flockTimeout        time.Duration
 	iterateBucketLimit  uint64
 	iterateBucketDecode bool
+	crossCheckDataDir   string
+	crossCheckWALDir    string
 )
 
 func init() {
@@ -66,10 +74,15 @@ func init() {
 	iterateBucketCommand.PersistentFlags().Uint64Var(&iterateBucketLimit, "limit", 0, "max number of key-value pairs to iterate (0< to iterate all)")
 	iterateBucketCommand.PersistentFlags().BoolVar(&iterateBucketDecode, "decode", false, "true to decode Protocol Buffer encoded data")
 
+	crossCheckCommand.Flags().StringVar(&crossCheckDataDir, "data-dir", "", "path to the data directory of the member")
+	crossCheckCommand.Flags().StringVar(&crossCheckWALDir, "wal-dir", "", "path to the WAL directory, if not in the data directory")
+	crossCheckCommand.MarkFlagRequired("data-dir")
+
 	rootCommand.AddCommand(listBucketCommand)
 	rootCommand.AddCommand(iterateBucketCommand)
 	rootCommand.AddCommand(scanKeySpaceCommand)
 	rootCommand.AddCommand(getHashCommand)
+	rootCommand.AddCommand(crossCheckCommand)
 }
 
 func main() {
@@ -157,3 +170,41 @@ func getHashCommandFunc(_ *cobra.Command, args []string) {
 	}
 	fmt.Printf("db path: %s\nHash: %d\n", dp, hash)
 }
+
+func crossCheckCommandFunc(_ *cobra.Command, args []string) {
+	if len(args) != 0 {
+		log.Fatalf("Must provide no argument (got %v)", args)
+	}
+	dp := filepath.Join(snapDir(crossCheckDataDir), "db")
+	if !fileutil.Exist(dp) {
+		log.Fatalf("%q does not exist", dp)
+	}
+	wd := crossCheckWALDir
+	if wd == "" {
+		wd = filepath.Join(crossCheckDataDir, "member", "wal")
+	}
+
+	lg := zap.NewNop()
+	ws, err := readWALState(lg, crossCheckDataDir, wd)
+	if err != nil {
+		log.Fatal(err)
+	}
+	bs := readBackendState(lg, dp)
+
+	fmt.Printf("snapshot: index=%d term=%d\n", ws.snapIndex, ws.snapTerm)
+	fmt.Printf("WAL: entries=[%d, %d] commit=%d\n", ws.firstIndex, ws.lastIndex, ws.commit)
+	fmt.Printf("backend: consistent index=%d term=%d members=%d\n", bs.index, bs.term, len(bs.learners))
+	if bs.index < ws.commit {
+		fmt.Printf("%d committed entries are not applied yet; they are applied when the member starts\n", ws.commit-bs.index)
+	}
+
+	divergences := crossCheck(ws, bs)
+	if len(divergences) == 0 {
+		fmt.Println("no divergence found")
+		return
+	}
+	for _, d := range divergences {
+		fmt.Println("divergence:", d)
+	}
+	os.Exit(1)
+}
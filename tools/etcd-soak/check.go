@@ -0,0 +1,318 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// revTracker matches the revisions written by the workload against the
+// events delivered by the watch. A written revision that is covered by the
+// watch progress but was never delivered is a gap.
+//
+// Writes and events race with each other, so a write is only checked one
+// round after it was recorded, and events are kept for one extra round.
+type revTracker struct {
+	mu    sync.Mutex
+	round int
+	// all events up to watermark have been delivered
+	watermark int64
+	// events below compacted were compacted before they could be delivered
+	compacted int64
+	writes    map[int64]int
+	events    map[int64]int
+}
+
+func newRevTracker() *revTracker {
+	return &revTracker{writes: make(map[int64]int), events: make(map[int64]int)}
+}
+
+func (t *revTracker) written(rev int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.writes[rev] = t.round
+}
+
+func (t *revTracker) observed(rev int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events[rev] = t.round
+	t.watermark = max(t.watermark, rev)
+}
+
+func (t *revTracker) progress(rev int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.watermark = max(t.watermark, rev)
+}
+
+func (t *revTracker) skip(compactRev int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.compacted = max(t.compacted, compactRev)
+	t.watermark = max(t.watermark, compactRev-1)
+}
+
+// check counts the gaps among the writes recorded before the current round
+// and starts a new round.
+func (t *revTracker) check(s *stats) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for rev, round := range t.writes {
+		if round >= t.round || rev > t.watermark {
+			continue
+		}
+		delete(t.writes, rev)
+		if _, ok := t.events[rev]; ok {
+			delete(t.events, rev)
+		} else if rev >= t.compacted {
+			s.watchGaps.Add(1)
+		}
+	}
+	for rev, round := range t.events {
+		if round < t.round {
+			delete(t.events, rev)
+		}
+	}
+	t.round++
+}
+
+// watchChecker watches the keys of the workload and reports the delivered
+// events to the revTracker. Events delivered out of order count as gaps.
+type watchChecker struct {
+	lg     *zap.Logger
+	cli    *clientv3.Client
+	prefix string
+	s      *stats
+	tr     *revTracker
+}
+
+func (wc *watchChecker) run(ctx context.Context, rev int64) {
+	for {
+		last := rev - 1
+		wctx, cancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+		wch := wc.cli.Watch(wctx, wc.prefix, clientv3.WithPrefix(), clientv3.WithRev(rev), clientv3.WithProgressNotify())
+		for wr := range wch {
+			if wr.CompactRevision != 0 {
+				wc.lg.Warn("watch revision compacted, skipping events", zap.Int64("from", rev), zap.Int64("compact-revision", wr.CompactRevision))
+				wc.tr.skip(wr.CompactRevision)
+				rev = wr.CompactRevision
+				break
+			}
+			if err := wr.Err(); err != nil {
+				wc.lg.Warn("watch failed", zap.Error(err))
+				break
+			}
+			if wr.IsProgressNotify() {
+				wc.tr.progress(wr.Header.Revision)
+				continue
+			}
+			for _, ev := range wr.Events {
+				r := ev.Kv.ModRevision
+				if r < last {
+					wc.lg.Error("watch event out of order", zap.String("key", string(ev.Kv.Key)), zap.Int64("revision", r), zap.Int64("previous-revision", last))
+					wc.s.watchGaps.Add(1)
+				}
+				last = max(last, r)
+				rev = last + 1
+				wc.tr.observed(r)
+				wc.s.watchEvents.Add(1)
+			}
+		}
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+		wc.s.watchRestarts.Add(1)
+	}
+}
+
+// requestProgress asks for a progress notification on the watch, so that
+// the watermark of the revTracker catches up with the cluster.
+func (wc *watchChecker) requestProgress(ctx context.Context) {
+	if err := wc.cli.RequestProgress(clientv3.WithRequireLeader(ctx)); err != nil {
+		wc.lg.Warn("failed to request watch progress", zap.Error(err))
+	}
+}
+
+// hashChecker compares the HashKV of all endpoints at the same revision.
+type hashChecker struct {
+	lg        *zap.Logger
+	cli       *clientv3.Client
+	endpoints []string
+	s         *stats
+}
+
+type endpointHash struct {
+	endpoint string
+	hash     uint32
+}
+
+func (hc *hashChecker) check(ctx context.Context) {
+	if len(hc.endpoints) < 2 {
+		return
+	}
+	resp, err := hc.cli.Get(ctx, "health")
+	if err != nil {
+		hc.lg.Warn("failed to get the revision to hash", zap.Error(err))
+		hc.s.hashCheckErrors.Add(1)
+		return
+	}
+	rev := resp.Header.Revision
+
+	// members compacted at different revisions have different hashes, so
+	// only members with the same compact revision are compared
+	byCompactRev := make(map[int64][]endpointHash)
+	for _, ep := range hc.endpoints {
+		hresp, err := hc.hashKV(ctx, ep, rev)
+		if err != nil {
+			hc.lg.Warn("failed to hash endpoint", zap.String("endpoint", ep), zap.Int64("revision", rev), zap.Error(err))
+			hc.s.hashCheckErrors.Add(1)
+			continue
+		}
+		byCompactRev[hresp.CompactRevision] = append(byCompactRev[hresp.CompactRevision], endpointHash{ep, hresp.Hash})
+	}
+	for compactRev, hashes := range byCompactRev {
+		if len(hashes) < 2 {
+			continue
+		}
+		hc.s.hashChecks.Add(1)
+		for _, h := range hashes[1:] {
+			if h.hash != hashes[0].hash {
+				hc.lg.Error("hash mismatch",
+					zap.Int64("revision", rev),
+					zap.Int64("compact-revision", compactRev),
+					zap.String("endpoint", hashes[0].endpoint),
+					zap.Uint32("hash", hashes[0].hash),
+					zap.String("mismatched-endpoint", h.endpoint),
+					zap.Uint32("mismatched-hash", h.hash),
+				)
+				hc.s.hashMismatches.Add(1)
+				break
+			}
+		}
+	}
+}
+
+// hashKV retries while the endpoint has not applied rev yet.
+func (hc *hashChecker) hashKV(ctx context.Context, ep string, rev int64) (*clientv3.HashKVResponse, error) {
+	for {
+		resp, err := hc.cli.HashKV(ctx, ep, rev)
+		if !errors.Is(err, rpctypes.ErrFutureRev) {
+			return resp, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// leaseChecker grants a lease with an attached key every round without
+// keeping it alive, and checks in later rounds that the lease expired and
+// the key was deleted.
+type leaseChecker struct {
+	lg      *zap.Logger
+	cli     *clientv3.Client
+	cfg     *config
+	s       *stats
+	pending []pendingLease
+}
+
+type pendingLease struct {
+	id       clientv3.LeaseID
+	key      string
+	deadline time.Time
+}
+
+// check verifies the leases whose deadline has passed.
+func (lc *leaseChecker) check(ctx context.Context) {
+	var pending []pendingLease
+	for _, pl := range lc.pending {
+		if time.Now().Before(pl.deadline) {
+			pending = append(pending, pl)
+			continue
+		}
+		expired, err := lc.expired(ctx, pl)
+		if err != nil {
+			lc.lg.Warn("failed to check lease", zap.String("lease", fmt.Sprintf("%016x", pl.id)), zap.Error(err))
+			pending = append(pending, pl)
+			continue
+		}
+		lc.s.leaseChecks.Add(1)
+		if !expired {
+			lc.lg.Error("lease did not expire", zap.String("lease", fmt.Sprintf("%016x", pl.id)), zap.Time("deadline", pl.deadline))
+			lc.s.leaseViolations.Add(1)
+			lc.cli.Revoke(ctx, pl.id)
+		}
+	}
+	lc.pending = pending
+}
+
+// grant grants a lease to be verified after its deadline.
+func (lc *leaseChecker) grant(ctx context.Context) {
+	resp, err := lc.cli.Grant(ctx, lc.cfg.leaseTTL)
+	if err != nil {
+		lc.lg.Warn("failed to grant lease", zap.Error(err))
+		return
+	}
+	key := fmt.Sprintf("%s/lease/%016x", lc.cfg.prefix, resp.ID)
+	if _, err = lc.cli.Put(ctx, key, "", clientv3.WithLease(resp.ID)); err != nil {
+		lc.lg.Warn("failed to attach key to lease", zap.Error(err))
+	}
+	lc.pending = append(lc.pending, pendingLease{
+		id:       resp.ID,
+		key:      key,
+		deadline: time.Now().Add(time.Duration(lc.cfg.leaseTTL)*time.Second + lc.cfg.leaseGrace),
+	})
+}
+
+func (lc *leaseChecker) expired(ctx context.Context, pl pendingLease) (bool, error) {
+	ttl, err := lc.cli.TimeToLive(ctx, pl.id)
+	if err != nil {
+		return false, err
+	}
+	if ttl.TTL != -1 {
+		return false, nil
+	}
+	resp, err := lc.cli.Get(ctx, pl.key, clientv3.WithCountOnly())
+	if err != nil {
+		return false, err
+	}
+	return resp.Count == 0, nil
+}
+
+// drain revokes the leases that are not due for checking yet.
+func (lc *leaseChecker) drain(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, lc.cfg.reqTimeout)
+	defer cancel()
+	for _, pl := range lc.pending {
+		lc.cli.Revoke(ctx, pl.id)
+	}
+	lc.pending = nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// builtinFaults are the faults that can be injected through the client API
+// of the cluster under test.
+var builtinFaults = []string{"move-leader", "defrag"}
+
+type faultFunc func(ctx context.Context, fi *faultInjector) error
+
+var faultFuncs = map[string]faultFunc{
+	"move-leader": moveLeader,
+	"defrag":      defrag,
+}
+
+// faultInjector injects a random fault every fault interval. Faults that
+// require access to the hosts, such as killing members or partitioning the
+// network, are left to the --fault-exec command.
+type faultInjector struct {
+	lg  *zap.Logger
+	cli *clientv3.Client
+	cfg *config
+	s   *stats
+}
+
+func (fi *faultInjector) run(ctx context.Context) {
+	names := append([]string{}, fi.cfg.faults...)
+	if fi.cfg.faultExec != "" {
+		names = append(names, "exec")
+	}
+	if len(names) == 0 {
+		return
+	}
+	ticker := time.NewTicker(fi.cfg.faultInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		name := names[rand.Intn(len(names))]
+		f := faultFuncs[name]
+		if name == "exec" {
+			f = execFault
+		}
+		fi.lg.Info("injecting fault", zap.String("fault", name))
+		err := f(ctx, fi)
+		if ctx.Err() != nil {
+			return
+		}
+		fi.s.faults.Add(1)
+		if err != nil {
+			fi.lg.Warn("failed to inject fault", zap.String("fault", name), zap.Error(err))
+			fi.s.faultErrors.Add(1)
+		}
+	}
+}
+
+// moveLeader transfers the leadership to a random follower.
+func moveLeader(ctx context.Context, fi *faultInjector) error {
+	ctx, cancel := context.WithTimeout(ctx, fi.cfg.faultInterval)
+	defer cancel()
+
+	var leaderEp string
+	var leaderID uint64
+	for _, ep := range fi.cfg.endpoints {
+		resp, err := fi.cli.Status(ctx, ep)
+		if err != nil {
+			continue
+		}
+		if resp.Header.MemberId == resp.Leader {
+			leaderEp, leaderID = ep, resp.Leader
+		}
+	}
+	if leaderEp == "" {
+		return errors.New("no leader found among the endpoints")
+	}
+	mresp, err := fi.cli.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+	var followers []uint64
+	for _, m := range mresp.Members {
+		if m.ID != leaderID && !m.IsLearner {
+			followers = append(followers, m.ID)
+		}
+	}
+	if len(followers) == 0 {
+		return errors.New("no follower to move the leadership to")
+	}
+
+	// only the leader accepts leadership transfers
+	lcli, err := newClient(fi.cfg, []string{leaderEp})
+	if err != nil {
+		return err
+	}
+	defer lcli.Close()
+	target := followers[rand.Intn(len(followers))]
+	_, err = lcli.MoveLeader(ctx, target)
+	if err != nil {
+		return fmt.Errorf("failed to move leader to %016x: %w", target, err)
+	}
+	return nil
+}
+
+// defrag defragments a random endpoint, which blocks its reads and writes
+// while it runs.
+func defrag(ctx context.Context, fi *faultInjector) error {
+	ep := fi.cfg.endpoints[rand.Intn(len(fi.cfg.endpoints))]
+	_, err := fi.cli.Defragment(ctx, ep)
+	return err
+}
+
+// execFault runs the --fault-exec shell command.
+func execFault(ctx context.Context, fi *faultInjector) error {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", fi.cfg.faultExec)
+	cmd.Env = append(os.Environ(), "ETCD_SOAK_ENDPOINTS="+strings.Join(fi.cfg.endpoints, ","))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/logutil"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+)
+
+type config struct {
+	endpoints   []string
+	dialTimeout time.Duration
+	tls         transport.TLSInfo
+	user        string
+
+	duration   time.Duration
+	clients    int
+	prefix     string
+	keySpace   int
+	valSize    int
+	reqTimeout time.Duration
+
+	verifyInterval time.Duration
+	leaseTTL       int64
+	leaseGrace     time.Duration
+
+	faultInterval time.Duration
+	faults        []string
+	faultExec     string
+
+	output   string
+	minScore float64
+}
+
+func parseFlags(args []string) (*config, error) {
+	cfg := &config{}
+	fs := flag.NewFlagSet("etcd-soak", flag.ContinueOnError)
+
+	endpoints := fs.String("endpoints", "127.0.0.1:2379", "comma separated gRPC endpoints of the cluster")
+	fs.DurationVar(&cfg.dialTimeout, "dial-timeout", 5*time.Second, "dial timeout for client connections")
+	fs.StringVar(&cfg.tls.CertFile, "cert", "", "identify HTTPS client using this SSL certificate file")
+	fs.StringVar(&cfg.tls.KeyFile, "key", "", "identify HTTPS client using this SSL key file")
+	fs.StringVar(&cfg.tls.TrustedCAFile, "cacert", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
+	fs.BoolVar(&cfg.tls.InsecureSkipVerify, "insecure-skip-tls-verify", false, "skip server certificate verification")
+	fs.StringVar(&cfg.user, "user", "", "username[:password] for authentication")
+
+	fs.DurationVar(&cfg.duration, "duration", time.Hour, "how long to run the soak")
+	fs.IntVar(&cfg.clients, "clients", 8, "number of concurrent workload clients")
+	fs.StringVar(&cfg.prefix, "prefix", "/etcd-soak", "key prefix used by the soak; keys under it are overwritten and deleted")
+	fs.IntVar(&cfg.keySpace, "key-space", 10000, "number of distinct keys written by the workload")
+	fs.IntVar(&cfg.valSize, "val-size", 256, "size of the written values in bytes")
+	fs.DurationVar(&cfg.reqTimeout, "request-timeout", 5*time.Second, "timeout of each workload request")
+
+	fs.DurationVar(&cfg.verifyInterval, "verify-interval", 30*time.Second, "interval of the hash, watch and lease verification")
+	fs.Int64Var(&cfg.leaseTTL, "lease-ttl", 10, "TTL in seconds of the leases granted by the lease expiry check")
+	fs.DurationVar(&cfg.leaseGrace, "lease-grace", 30*time.Second, "time allowed after the lease TTL before an unexpired lease is reported")
+
+	fs.DurationVar(&cfg.faultInterval, "fault-interval", 0, "interval between injected faults (0 disables fault injection)")
+	faults := fs.String("faults", strings.Join(builtinFaults, ","), "comma separated built-in faults to inject: "+strings.Join(builtinFaults, ", "))
+	fs.StringVar(&cfg.faultExec, "fault-exec", "", "shell command run as an additional fault; the endpoints are passed in ETCD_SOAK_ENDPOINTS")
+
+	fs.StringVar(&cfg.output, "output", "text", "report format: text or json")
+	fs.Float64Var(&cfg.minScore, "min-score", 99, "minimum score out of 100 for the soak to pass")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	cfg.endpoints = splitList(*endpoints)
+	cfg.faults = splitList(*faults)
+	return cfg, cfg.validate()
+}
+
+func (cfg *config) validate() error {
+	if len(cfg.endpoints) == 0 {
+		return errors.New("--endpoints must not be empty")
+	}
+	if cfg.clients < 1 {
+		return fmt.Errorf("--clients must be >0 (set to %d)", cfg.clients)
+	}
+	if cfg.keySpace < 1 {
+		return fmt.Errorf("--key-space must be >0 (set to %d)", cfg.keySpace)
+	}
+	if cfg.verifyInterval <= 0 {
+		return fmt.Errorf("--verify-interval must be >0 (set to %v)", cfg.verifyInterval)
+	}
+	if cfg.leaseTTL < 1 {
+		return fmt.Errorf("--lease-ttl must be >0 (set to %d)", cfg.leaseTTL)
+	}
+	for _, f := range cfg.faults {
+		if _, ok := faultFuncs[f]; !ok {
+			return fmt.Errorf("unknown fault %q (expected one of %s)", f, strings.Join(builtinFaults, ", "))
+		}
+	}
+	if cfg.output != "text" && cfg.output != "json" {
+		return fmt.Errorf("unknown --output %q (expected text or json)", cfg.output)
+	}
+	return nil
+}
+
+func splitList(s string) []string {
+	var ret []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func main() {
+	cfg, err := parseFlags(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	lg, err := logutil.CreateDefaultZapLogger(zap.InfoLevel)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	r, err := run(ctx, lg, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err = r.write(os.Stdout, cfg.output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if !r.Passed {
+		os.Exit(3)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// report summarizes a soak. The score is the percentage of successful
+// requests; any correctness violation fails the soak with a zero score, as
+// no amount of availability makes up for it.
+type report struct {
+	Duration  string  `json:"duration"`
+	Ops       int64   `json:"ops"`
+	OpErrors  int64   `json:"op_errors"`
+	OpsPerSec float64 `json:"ops_per_sec"`
+
+	HashChecks      int64 `json:"hash_checks"`
+	HashCheckErrors int64 `json:"hash_check_errors"`
+	HashMismatches  int64 `json:"hash_mismatches"`
+
+	WatchEvents   int64 `json:"watch_events"`
+	WatchRestarts int64 `json:"watch_restarts"`
+	WatchGaps     int64 `json:"watch_gaps"`
+
+	LeaseChecks     int64 `json:"lease_checks"`
+	LeaseViolations int64 `json:"lease_violations"`
+
+	Faults      int64 `json:"faults"`
+	FaultErrors int64 `json:"fault_errors"`
+
+	Availability float64 `json:"availability"`
+	Score        float64 `json:"score"`
+	Passed       bool    `json:"passed"`
+}
+
+func newReport(s *stats, took time.Duration, minScore float64) *report {
+	r := &report{
+		Duration:        took.Round(time.Second).String(),
+		Ops:             s.ops.Load(),
+		OpErrors:        s.opErrors.Load(),
+		HashChecks:      s.hashChecks.Load(),
+		HashCheckErrors: s.hashCheckErrors.Load(),
+		HashMismatches:  s.hashMismatches.Load(),
+		WatchEvents:     s.watchEvents.Load(),
+		WatchRestarts:   s.watchRestarts.Load(),
+		WatchGaps:       s.watchGaps.Load(),
+		LeaseChecks:     s.leaseChecks.Load(),
+		LeaseViolations: s.leaseViolations.Load(),
+		Faults:          s.faults.Load(),
+		FaultErrors:     s.faultErrors.Load(),
+	}
+	if took > 0 {
+		r.OpsPerSec = float64(r.Ops) / took.Seconds()
+	}
+	if r.Ops > 0 {
+		r.Availability = float64(r.Ops-r.OpErrors) / float64(r.Ops)
+	}
+	if r.violations() == 0 {
+		r.Score = 100 * r.Availability
+	}
+	r.Passed = r.violations() == 0 && r.Ops > 0 && r.Score >= minScore
+	return r
+}
+
+func (r *report) violations() int64 {
+	return r.HashMismatches + r.WatchGaps + r.LeaseViolations
+}
+
+func (r *report) write(w io.Writer, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	result := "PASS"
+	if !r.Passed {
+		result = "FAIL"
+	}
+	_, err := fmt.Fprintf(w, `Soak report:
+  Duration:	%s
+  Requests:	%d (%d failed, %.2f/s)
+  Hash checks:	%d (%d mismatched, %d failed)
+  Watch events:	%d (%d gaps, %d restarts)
+  Lease checks:	%d (%d not expired)
+  Faults:	%d (%d failed)
+  Availability:	%.4f%%
+  Score:	%.2f
+  Result:	%s
+`,
+		r.Duration,
+		r.Ops, r.OpErrors, r.OpsPerSec,
+		r.HashChecks, r.HashMismatches, r.HashCheckErrors,
+		r.WatchEvents, r.WatchGaps, r.WatchRestarts,
+		r.LeaseChecks, r.LeaseViolations,
+		r.Faults, r.FaultErrors,
+		100*r.Availability,
+		r.Score,
+		result,
+	)
+	return err
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// stats counts the outcome of the soak. All fields are updated atomically.
+type stats struct {
+	ops      atomic.Int64
+	opErrors atomic.Int64
+
+	hashChecks      atomic.Int64
+	hashCheckErrors atomic.Int64
+	hashMismatches  atomic.Int64
+
+	watchEvents   atomic.Int64
+	watchRestarts atomic.Int64
+	watchGaps     atomic.Int64
+
+	leaseChecks     atomic.Int64
+	leaseViolations atomic.Int64
+
+	faults      atomic.Int64
+	faultErrors atomic.Int64
+}
+
+func newClient(cfg *config, endpoints []string) (*clientv3.Client, error) {
+	ccfg := clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: cfg.dialTimeout,
+		Logger:      zap.NewNop(),
+	}
+	if !cfg.tls.Empty() || cfg.tls.InsecureSkipVerify {
+		tlsConfig, err := cfg.tls.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		ccfg.TLS = tlsConfig
+	}
+	if cfg.user != "" {
+		user, password, _ := strings.Cut(cfg.user, ":")
+		ccfg.Username, ccfg.Password = user, password
+	}
+	return clientv3.New(ccfg)
+}
+
+// run drives the workload, the verification and the fault injection until
+// the configured duration elapses or ctx is canceled.
+func run(ctx context.Context, lg *zap.Logger, cfg *config) (*report, error) {
+	cli, err := newClient(cfg, cfg.endpoints)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+
+	// the revision the watch checker starts from
+	resp, err := cli.Get(ctx, cfg.prefix, clientv3.WithCountOnly())
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the cluster: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.duration)
+	defer cancel()
+
+	s := &stats{}
+	tr := newRevTracker()
+	wc := &watchChecker{lg: lg, cli: cli, prefix: workloadPrefix(cfg), s: s, tr: tr}
+	hc := &hashChecker{lg: lg, cli: cli, endpoints: cfg.endpoints, s: s}
+	lc := &leaseChecker{lg: lg, cli: cli, cfg: cfg, s: s}
+
+	lg.Info("starting soak", zap.Strings("endpoints", cfg.endpoints), zap.Duration("duration", cfg.duration), zap.Int("clients", cfg.clients))
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wc.run(ctx, resp.Header.Revision+1)
+	}()
+	for i := 0; i < cfg.clients; i++ {
+		w := &worker{cli: cli, cfg: cfg, s: s, tr: tr, rand: rand.New(rand.NewSource(time.Now().UnixNano() + int64(i)))}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.run(ctx)
+		}()
+	}
+	if cfg.faultInterval > 0 {
+		fi := &faultInjector{lg: lg, cli: cli, cfg: cfg, s: s}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fi.run(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(cfg.verifyInterval)
+	defer ticker.Stop()
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			done = true
+		case <-ticker.C:
+		}
+		// the final verification runs without the expired soak context
+		vctx, vcancel := context.WithTimeout(context.Background(), cfg.verifyInterval)
+		if !done {
+			wc.requestProgress(vctx)
+		}
+		hc.check(vctx)
+		lc.check(vctx)
+		if !done {
+			lc.grant(vctx)
+		}
+		vcancel()
+		tr.check(s)
+		r := newReport(s, time.Since(start), cfg.minScore)
+		lg.Info("soak progress", zap.Int64("ops", r.Ops), zap.Int64("op-errors", r.OpErrors), zap.Int64("violations", r.violations()))
+	}
+	wg.Wait()
+	// account for the events of the last writes
+	tr.check(s)
+	tr.check(s)
+	lc.drain(context.Background())
+	return newReport(s, time.Since(start), cfg.minScore), nil
+}
+
+func workloadPrefix(cfg *config) string { return cfg.prefix + "/kv/" }
+
+// worker issues a mix of puts, ranges, deletes and transactions on the keys
+// of the workload, and records the revisions of its writes for the watch
+// checker.
+type worker struct {
+	cli  *clientv3.Client
+	cfg  *config
+	s    *stats
+	tr   *revTracker
+	rand *rand.Rand
+}
+
+func (w *worker) run(ctx context.Context) {
+	val := make([]byte, w.cfg.valSize)
+	for ctx.Err() == nil {
+		w.rand.Read(val)
+		rev, err := w.do(ctx, string(val))
+		if ctx.Err() != nil {
+			return
+		}
+		w.s.ops.Add(1)
+		if err != nil {
+			w.s.opErrors.Add(1)
+			continue
+		}
+		if rev > 0 {
+			w.tr.written(rev)
+		}
+	}
+}
+
+// do issues one random request and returns the revision it created, or 0 if
+// it did not write.
+func (w *worker) do(ctx context.Context, val string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.cfg.reqTimeout)
+	defer cancel()
+
+	key := fmt.Sprintf("%s%d", workloadPrefix(w.cfg), w.rand.Intn(w.cfg.keySpace))
+	switch n := w.rand.Intn(10); {
+	case n < 4:
+		resp, err := w.cli.Put(ctx, key, val)
+		if err != nil {
+			return 0, err
+		}
+		return resp.Header.Revision, nil
+	case n < 7:
+		_, err := w.cli.Get(ctx, key)
+		return 0, err
+	case n < 8:
+		resp, err := w.cli.Delete(ctx, key)
+		if err != nil || resp.Deleted == 0 {
+			return 0, err
+		}
+		return resp.Header.Revision, nil
+	default:
+		// either branch writes the key exactly once
+		resp, err := w.cli.Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+			Then(clientv3.OpPut(key, val)).
+			Else(clientv3.OpDelete(key)).
+			Commit()
+		if err != nil {
+			return 0, err
+		}
+		return resp.Header.Revision, nil
+	}
+}
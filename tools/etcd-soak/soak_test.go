@@ -0,0 +1,94 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevTracker(t *testing.T) {
+	s := &stats{}
+	tr := newRevTracker()
+
+	// an event may be delivered before its write is recorded
+	tr.observed(2)
+	tr.written(2)
+	tr.written(3)
+	tr.written(5)
+	tr.observed(5)
+	// writes of the current round are not checked yet
+	tr.check(s)
+	assert.Zero(t, s.watchGaps.Load())
+
+	// 3 is covered by the watermark but was never delivered
+	tr.check(s)
+	assert.Equal(t, int64(1), s.watchGaps.Load())
+	assert.Empty(t, tr.writes)
+
+	// writes beyond the watermark wait for the watch progress
+	tr.written(7)
+	tr.check(s)
+	tr.check(s)
+	assert.Len(t, tr.writes, 1)
+	tr.progress(7)
+	tr.check(s)
+	assert.Equal(t, int64(2), s.watchGaps.Load())
+
+	// compacted writes are not gaps
+	tr.written(8)
+	tr.written(10)
+	tr.skip(10)
+	tr.observed(10)
+	tr.check(s)
+	tr.check(s)
+	assert.Equal(t, int64(2), s.watchGaps.Load())
+	assert.Empty(t, tr.writes)
+	assert.Empty(t, tr.events)
+}
+
+func TestNewReport(t *testing.T) {
+	s := &stats{}
+	s.ops.Store(1000)
+	s.opErrors.Store(5)
+	r := newReport(s, 10*time.Second, 99)
+	assert.InDelta(t, 100.0, r.OpsPerSec, 0.001)
+	assert.InDelta(t, 99.5, r.Score, 0.001)
+	assert.True(t, r.Passed)
+
+	r = newReport(s, 10*time.Second, 99.9)
+	assert.False(t, r.Passed)
+
+	s.watchGaps.Store(1)
+	r = newReport(s, 10*time.Second, 0)
+	assert.Zero(t, r.Score)
+	assert.False(t, r.Passed)
+
+	assert.False(t, newReport(&stats{}, time.Second, 0).Passed)
+}
+
+func TestParseFlags(t *testing.T) {
+	cfg, err := parseFlags([]string{"--endpoints", "a:2379, b:2379", "--faults", "defrag"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a:2379", "b:2379"}, cfg.endpoints)
+	assert.Equal(t, []string{"defrag"}, cfg.faults)
+
+	_, err = parseFlags([]string{"--faults", "kill"})
+	assert.ErrorContains(t, err, `unknown fault "kill"`)
+	_, err = parseFlags([]string{"--clients", "0"})
+	assert.ErrorContains(t, err, "--clients must be >0")
+}